@@ -33,6 +33,10 @@ func NewCassandraAccessor(sourceProfile profiles.SourceProfile) (*CassandraAcces
 	cfg := sourceProfile.Conn.Cassandra
 
 	port, _ := strconv.Atoi(cfg.Port)
+	tlsConfig, err := cfg.TLS.Config()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	client, err := GetOrCreateClient(
 		strings.Split(cfg.Host, ","),
@@ -41,6 +45,7 @@ func NewCassandraAccessor(sourceProfile profiles.SourceProfile) (*CassandraAcces
 		cfg.DataCenter,
 		cfg.User,
 		cfg.Pwd,
+		tlsConfig,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create Cassandra client: %w", err)
@@ -60,6 +65,11 @@ func NewCassandraAccessor(sourceProfile profiles.SourceProfile) (*CassandraAcces
 	return accessor, keyspaceMD, nil
 }
 
+// Client returns the client of the cluster, to read the rows of tables.
+func (acc *CassandraAccessor) Client() cc.CassandraClusterInterface {
+	return acc.client
+}
+
 func (acc *CassandraAccessor) Close() {
 	if acc.client != nil {
 		acc.client.Close()
@@ -14,6 +14,7 @@
 package cassandraaccessor
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"testing"
@@ -46,13 +47,14 @@ func TestNewCassandraAccessor(t *testing.T) {
 
 		mockClient.On("KeyspaceMetadata", "test_keyspace").Return(mockMetadata, nil).Once()
 
-		GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			assert.Equal(t, []string{"127.0.0.1"}, contactPoints)
 			assert.Equal(t, 9042, port)
 			assert.Equal(t, "test_keyspace", keyspace)
 			assert.Equal(t, "dc1", datacenter)
 			assert.Equal(t, "user", user)
 			assert.Equal(t, "pass", password)
+			assert.Nil(t, tlsConfig)
 			return mockClient, nil
 		}
 
@@ -68,7 +70,7 @@ func TestNewCassandraAccessor(t *testing.T) {
 
 	t.Run("Failure to create client", func(t *testing.T) {
 		expectedErr := errors.New("client creation failed")
-		GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			return nil, expectedErr
 		}
 
@@ -88,7 +90,7 @@ func TestNewCassandraAccessor(t *testing.T) {
 		mockClient.On("KeyspaceMetadata", "test_keyspace").Return(nil, expectedErr).Once()
 		mockClient.On("Close").Return().Once()
 
-		GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			return mockClient, nil
 		}
 
@@ -15,6 +15,7 @@
 package cassandraclient
 
 import (
+	"crypto/tls"
 	"fmt"
 	"sync"
 	"time"
@@ -36,7 +37,7 @@ var createSessionFromCluster = func(c *gocql.ClusterConfig) (GocqlSessionInterfa
 	return NewGocqlSessionImpl(session), nil
 }
 
-func GetOrCreateCassandraClusterClient(contactPoints []string, port int, keyspace, datacenter, user, password string) (CassandraClusterInterface, error) {
+func GetOrCreateCassandraClusterClient(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (CassandraClusterInterface, error) {
 	once.Do(func() {
 		clusterCfg := newCluster(contactPoints...)
 		clusterCfg.Keyspace = keyspace
@@ -52,6 +53,10 @@ func GetOrCreateCassandraClusterClient(contactPoints []string, port int, keyspac
 		if datacenter != "" {
 			clusterCfg.PoolConfig.HostSelectionPolicy = gocql.DCAwareRoundRobinPolicy(datacenter)
 		}
+		if tlsConfig != nil {
+			// Hosts are verified as configured in tlsConfig.
+			clusterCfg.SslOpts = &gocql.SslOptions{Config: tlsConfig}
+		}
 		globalClusterConfig = clusterCfg
 	})
 
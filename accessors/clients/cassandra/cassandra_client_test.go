@@ -14,6 +14,7 @@
 package cassandraclient
 
 import (
+	"crypto/tls"
 	"errors"
 	"sync"
 	"testing"
@@ -52,7 +53,7 @@ func TestGetOrCreateCassandraClusterClient(t *testing.T) {
 			return mockSession, nil
 		}
 
-		client, err := GetOrCreateCassandraClusterClient([]string{"127.0.0.1"}, 0, "test_keyspace", "", "", "")
+		client, err := GetOrCreateCassandraClusterClient([]string{"127.0.0.1"}, 0, "test_keyspace", "", "", "", nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, client)
@@ -63,6 +64,7 @@ func TestGetOrCreateCassandraClusterClient(t *testing.T) {
 		assert.IsType(t, &gocql.SimpleRetryPolicy{}, capturedClusterConfig.RetryPolicy)
 		assert.Equal(t, 3, capturedClusterConfig.RetryPolicy.(*gocql.SimpleRetryPolicy).NumRetries)
 		assert.Nil(t, capturedClusterConfig.Authenticator)
+		assert.Nil(t, capturedClusterConfig.SslOpts)
 	})
 
 	t.Run("Success on first call with full config", func(t *testing.T) {
@@ -79,7 +81,8 @@ func TestGetOrCreateCassandraClusterClient(t *testing.T) {
 			return mockSession, nil
 		}
 
-		client, err := GetOrCreateCassandraClusterClient([]string{"127.0.0.1"}, 9042, "ks", "dc1", "user", "pass")
+		tlsConfig := &tls.Config{ServerName: "cassandra.example.com"}
+		client, err := GetOrCreateCassandraClusterClient([]string{"127.0.0.1"}, 9042, "ks", "dc1", "user", "pass", tlsConfig)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, client)
@@ -88,6 +91,7 @@ func TestGetOrCreateCassandraClusterClient(t *testing.T) {
 		assert.IsType(t, gocql.DCAwareRoundRobinPolicy(""), capturedClusterConfig.PoolConfig.HostSelectionPolicy)
 		expectedAuth := gocql.PasswordAuthenticator{Username: "user", Password: "pass"}
 		assert.Equal(t, expectedAuth, capturedClusterConfig.Authenticator)
+		assert.Equal(t, &gocql.SslOptions{Config: tlsConfig}, capturedClusterConfig.SslOpts)
 	})
 
 	t.Run("Failure on session creation", func(t *testing.T) {
@@ -97,7 +101,7 @@ func TestGetOrCreateCassandraClusterClient(t *testing.T) {
 			return nil, expectedErr
 		}
 
-		client, err := GetOrCreateCassandraClusterClient([]string{"127.0.0.1"}, 9042, "ks", "", "", "")
+		client, err := GetOrCreateCassandraClusterClient([]string{"127.0.0.1"}, 9042, "ks", "", "", "", nil)
 
 		assert.Nil(t, client)
 		assert.Error(t, err)
@@ -118,9 +122,9 @@ func TestGetOrCreateCassandraClusterClientSingleton(t *testing.T) {
 	}
 	t.Cleanup(func() { newCluster = originalNewCluster })
 
-	_, _ = GetOrCreateCassandraClusterClient([]string{"host1"}, 9042, "keyspace1", "dc1", "user1", "pass1")
-	_, _ = GetOrCreateCassandraClusterClient([]string{"host2"}, 9043, "keyspace2", "dc2", "user2", "pass2")
-	_, _ = GetOrCreateCassandraClusterClient([]string{"host3"}, 9044, "keyspace3", "dc3", "user3", "pass3")
+	_, _ = GetOrCreateCassandraClusterClient([]string{"host1"}, 9042, "keyspace1", "dc1", "user1", "pass1", nil)
+	_, _ = GetOrCreateCassandraClusterClient([]string{"host2"}, 9043, "keyspace2", "dc2", "user2", "pass2", nil)
+	_, _ = GetOrCreateCassandraClusterClient([]string{"host3"}, 9044, "keyspace3", "dc3", "user3", "pass3", nil)
 
 
 	assert.Equal(t, 1, newClusterCallCount, "The cluster config should only be initialized once.")
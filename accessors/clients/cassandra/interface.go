@@ -15,23 +15,45 @@ package cassandraclient
 
 import (
 	"fmt"
+	"reflect"
+
 	"github.com/gocql/gocql"
 )
 
 type GocqlSessionInterface interface {
 	KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMetadata, error)
+	Query(stmt string, values ...interface{}) RowIterator
 	Close()
 }
 
 type KeyspaceMetadataInterface interface {
 	Tables() map[string]*gocql.TableMetadata
+	UserTypes() map[string]UserType
+}
+
+// UserType is a user-defined type of a keyspace. FieldTypes are the CQL
+// types of its fields e.g. text or frozen<address>.
+type UserType struct {
+	Name       string
+	FieldNames []string
+	FieldTypes []string
 }
 
 type CassandraClusterInterface interface {
 	KeyspaceMetadata(keyspace string) (KeyspaceMetadataInterface, error)
+	Query(stmt string, values ...interface{}) RowIterator
 	Close() 
 }
 
+// RowIterator iterates over the rows returned by a query.
+type RowIterator interface {
+	// Next returns the next row, keyed by column name, or false when there
+	// are no more rows. Values of NULL columns are nil.
+	Next() (map[string]interface{}, bool)
+	// Close closes the iterator and returns the error of the query, if any.
+	Close() error
+}
+
 type GocqlSessionImpl struct {
 	session *gocql.Session
 }
@@ -51,6 +73,10 @@ func (gs *GocqlSessionImpl) KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMe
 	return ks, nil
 }
 
+func (gs *GocqlSessionImpl) Query(stmt string, values ...interface{}) RowIterator {
+	return &gocqlRowIterator{iter: gs.session.Query(stmt, values...).Iter()}
+}
+
 func (gs *GocqlSessionImpl) Close() {
 	if gs.session != nil {
 		gs.session.Close()
@@ -59,12 +85,17 @@ func (gs *GocqlSessionImpl) Close() {
 
 type CassandraKeyspaceMetadataImpl struct {
 	keyspaceMetadata *gocql.KeyspaceMetadata
+	userTypes        map[string]UserType
 }
 
 func (c *CassandraKeyspaceMetadataImpl) Tables() map[string]*gocql.TableMetadata {
 	return c.keyspaceMetadata.Tables
 }
 
+func (c *CassandraKeyspaceMetadataImpl) UserTypes() map[string]UserType {
+	return c.userTypes
+}
+
 type CassandraClusterImpl struct {
 	session GocqlSessionInterface
 }
@@ -77,11 +108,92 @@ func (c *CassandraClusterImpl) KeyspaceMetadata(keyspace string) (KeyspaceMetada
 	if ks == nil {
 		return nil, fmt.Errorf("keyspace %s not found in cluster metadata", keyspace)
 	}
-	return &CassandraKeyspaceMetadataImpl{keyspaceMetadata: ks}, nil
+	userTypes, err := c.userTypes(keyspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user types for %s: %w", keyspace, err)
+	}
+	return &CassandraKeyspaceMetadataImpl{keyspaceMetadata: ks, userTypes: userTypes}, nil
+}
+
+// userTypes reads the user-defined types of a keyspace from the schema
+// tables. gocql parses the types of their fields, losing the names of the
+// user-defined types nested in them.
+func (c *CassandraClusterImpl) userTypes(keyspace string) (map[string]UserType, error) {
+	iter := c.session.Query("SELECT type_name, field_names, field_types FROM system_schema.types WHERE keyspace_name = ?", keyspace)
+	userTypes := make(map[string]UserType)
+	for {
+		row, ok := iter.Next()
+		if !ok {
+			break
+		}
+		name, _ := row["type_name"].(string)
+		fieldNames, _ := row["field_names"].([]string)
+		fieldTypes, _ := row["field_types"].([]string)
+		userTypes[name] = UserType{Name: name, FieldNames: fieldNames, FieldTypes: fieldTypes}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return userTypes, nil
+}
+
+func (c *CassandraClusterImpl) Query(stmt string, values ...interface{}) RowIterator {
+	return c.session.Query(stmt, values...)
 }
 
 func (c *CassandraClusterImpl) Close() {
 	c.session.Close()
 }
 
+// gocqlRowIterator implements RowIterator for a gocql.Iter. Values are
+// scanned into the Go types gocql uses for the column types, e.g. int32
+// for int, []T for list<T> and set<T> and map[K]V for map<K,V>.
+type gocqlRowIterator struct {
+	iter    *gocql.Iter
+	columns []string
+	types   []reflect.Type
+	err     error
+}
 
+func (it *gocqlRowIterator) Next() (map[string]interface{}, bool) {
+	if it.err != nil {
+		return nil, false
+	}
+	if it.types == nil {
+		rd, err := it.iter.RowData()
+		if err != nil {
+			it.err = err
+			return nil, false
+		}
+		it.columns = rd.Columns
+		for _, v := range rd.Values {
+			it.types = append(it.types, reflect.TypeOf(v))
+		}
+	}
+	// Scanning into pointers to pointers leaves the pointers of NULL values
+	// nil, where scanning into pointers would give zero values.
+	dest := make([]interface{}, len(it.types))
+	for i, t := range it.types {
+		dest[i] = reflect.New(t).Interface()
+	}
+	if !it.iter.Scan(dest...) {
+		return nil, false
+	}
+	row := make(map[string]interface{}, len(dest))
+	for i, col := range it.columns {
+		v := reflect.ValueOf(dest[i]).Elem()
+		if v.IsNil() {
+			row[col] = nil
+			continue
+		}
+		row[col] = v.Elem().Interface()
+	}
+	return row, true
+}
+
+func (it *gocqlRowIterator) Close() error {
+	if err := it.iter.Close(); err != nil {
+		return err
+	}
+	return it.err
+}
@@ -22,11 +22,16 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+const userTypesQuery = "SELECT type_name, field_names, field_types FROM system_schema.types WHERE keyspace_name = ?"
+
 func TestCassandraClusterImpl(t *testing.T) {
 	t.Run("KeyspaceMetadata Success", func(t *testing.T) {
 		mockSession := new(MockGocqlSession)
 		expectedMetadata := &gocql.KeyspaceMetadata{Name: "testks"}
 		mockSession.On("KeyspaceMetadata", "testks").Return(expectedMetadata, nil).Once()
+		mockSession.On("Query", userTypesQuery).Return(&MockRowIterator{Rows: []map[string]interface{}{
+			{"type_name": "address", "field_names": []string{"city", "geo"}, "field_types": []string{"text", "frozen<point>"}},
+		}}).Once()
 
 		clusterImpl := &CassandraClusterImpl{session: mockSession}
 		keyspaceMeta, err := clusterImpl.KeyspaceMetadata("testks")
@@ -36,6 +41,22 @@ func TestCassandraClusterImpl(t *testing.T) {
 		impl, ok := keyspaceMeta.(*CassandraKeyspaceMetadataImpl)
 		assert.True(t, ok)
 		assert.Equal(t, expectedMetadata, impl.keyspaceMetadata)
+		assert.Equal(t, map[string]UserType{
+			"address": {Name: "address", FieldNames: []string{"city", "geo"}, FieldTypes: []string{"text", "frozen<point>"}},
+		}, keyspaceMeta.UserTypes())
+		mockSession.AssertExpectations(t)
+	})
+
+	t.Run("KeyspaceMetadata Error User Types", func(t *testing.T) {
+		mockSession := new(MockGocqlSession)
+		mockSession.On("KeyspaceMetadata", "testks").Return(&gocql.KeyspaceMetadata{Name: "testks"}, nil).Once()
+		mockSession.On("Query", userTypesQuery).Return(&MockRowIterator{Err: errors.New("unauthorized")}).Once()
+
+		clusterImpl := &CassandraClusterImpl{session: mockSession}
+		keyspaceMeta, err := clusterImpl.KeyspaceMetadata("testks")
+
+		assert.Nil(t, keyspaceMeta)
+		assert.EqualError(t, err, "failed to get user types for testks: unauthorized")
 		mockSession.AssertExpectations(t)
 	})
 
@@ -68,6 +89,23 @@ func TestCassandraClusterImpl(t *testing.T) {
 		mockSession.AssertExpectations(t)
 	})
 
+	t.Run("Query", func(t *testing.T) {
+		mockSession := new(MockGocqlSession)
+		rows := &MockRowIterator{Rows: []map[string]interface{}{{"id": 1}}}
+		mockSession.On("Query", "SELECT id FROM t").Return(rows).Once()
+
+		clusterImpl := &CassandraClusterImpl{session: mockSession}
+		iter := clusterImpl.Query("SELECT id FROM t")
+
+		row, ok := iter.Next()
+		assert.True(t, ok)
+		assert.Equal(t, map[string]interface{}{"id": 1}, row)
+		_, ok = iter.Next()
+		assert.False(t, ok)
+		assert.NoError(t, iter.Close())
+		mockSession.AssertExpectations(t)
+	})
+
 	t.Run("Close", func(t *testing.T) {
 		mockSession := new(MockGocqlSession)
 		mockSession.On("Close").Return().Once()
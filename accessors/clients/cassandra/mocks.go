@@ -30,13 +30,19 @@ func (m *MockGocqlSession) KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMet
 	return nil, args.Error(1)
 }
 
+func (m *MockGocqlSession) Query(stmt string, values ...interface{}) RowIterator {
+	args := m.Called(stmt)
+	return args.Get(0).(RowIterator)
+}
+
 func (m *MockGocqlSession) Close() {
 	m.Called()
 }
 
 type MockKeyspaceMetadata struct {
 	mock.Mock
-	MockTables map[string]*gocql.TableMetadata
+	MockTables    map[string]*gocql.TableMetadata
+	MockUserTypes map[string]UserType
 }
 
 func (m *MockKeyspaceMetadata) Tables() map[string]*gocql.TableMetadata {
@@ -47,6 +53,10 @@ func (m *MockKeyspaceMetadata) Tables() map[string]*gocql.TableMetadata {
 	return m.MockTables
 }
 
+func (m *MockKeyspaceMetadata) UserTypes() map[string]UserType {
+	return m.MockUserTypes
+}
+
 type MockCassandraCluster struct {
 	mock.Mock
 }
@@ -59,6 +69,30 @@ func (m *MockCassandraCluster) KeyspaceMetadata(keyspace string) (KeyspaceMetada
 	return nil, args.Error(1)
 }
 
+func (m *MockCassandraCluster) Query(stmt string, values ...interface{}) RowIterator {
+	args := m.Called(stmt)
+	return args.Get(0).(RowIterator)
+}
+
 func (m *MockCassandraCluster) Close() {
 	m.Called()
 }
+
+// MockRowIterator returns Rows, followed by Err on Close.
+type MockRowIterator struct {
+	Rows []map[string]interface{}
+	Err  error
+}
+
+func (m *MockRowIterator) Next() (map[string]interface{}, bool) {
+	if len(m.Rows) == 0 {
+		return nil, false
+	}
+	row := m.Rows[0]
+	m.Rows = m.Rows[1:]
+	return row, true
+}
+
+func (m *MockRowIterator) Close() error {
+	return m.Err
+}
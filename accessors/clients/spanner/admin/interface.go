@@ -38,6 +38,9 @@ type CreateDatabaseOperation interface {
 // Use this interface instead of database.UpdateDatabaseDdlOperation to support mocking.
 type UpdateDatabaseDdlOperation interface {
 	Wait(ctx context.Context, opts ...gax.CallOption) error
+	// Metadata returns the progress of the operation, e.g. the commit
+	// timestamps of the statements applied so far.
+	Metadata() (*databasepb.UpdateDatabaseDdlMetadata, error)
 }
 
 // This implements the AdminClient interface. This is the primary implementation that should be used in all places other than tests.
@@ -91,6 +94,10 @@ func (c *UpdateDatabaseDdlImpl) Wait(ctx context.Context, opts ...gax.CallOption
 	return c.dbo.Wait(ctx, opts...)
 }
 
+func (c *UpdateDatabaseDdlImpl) Metadata() (*databasepb.UpdateDatabaseDdlMetadata, error) {
+	return c.dbo.Metadata()
+}
+
 func (c *AdminClientImpl) GetDatabaseDdl(ctx context.Context, req *databasepb.GetDatabaseDdlRequest, opts ...gax.CallOption) (*databasepb.GetDatabaseDdlResponse, error) {
 	return c.adminClient.GetDatabaseDdl(ctx, req, opts...)
 }
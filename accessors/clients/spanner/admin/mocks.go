@@ -62,10 +62,19 @@ func (dbo *CreateDatabaseOperationMock) Wait(ctx context.Context, opts ...gax.Ca
 
 // Mock that implements the UpdateDatabaseDdlOperation interface.
 // Pass in unit tests where UpdateDatabaseDdlOperation is an input parameter.
+// MetadataMock is optional, no metadata is returned when it isn't set.
 type UpdateDatabaseDdlOperationMock struct {
-	WaitMock func(ctx context.Context, opts ...gax.CallOption) error
+	WaitMock     func(ctx context.Context, opts ...gax.CallOption) error
+	MetadataMock func() (*databasepb.UpdateDatabaseDdlMetadata, error)
 }
 
 func (dbo *UpdateDatabaseDdlOperationMock) Wait(ctx context.Context, opts ...gax.CallOption) error {
 	return dbo.WaitMock(ctx, opts...)
 }
+
+func (dbo *UpdateDatabaseDdlOperationMock) Metadata() (*databasepb.UpdateDatabaseDdlMetadata, error) {
+	if dbo.MetadataMock == nil {
+		return nil, nil
+	}
+	return dbo.MetadataMock()
+}
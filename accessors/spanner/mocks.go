@@ -29,6 +29,7 @@ type SpannerAccessorMock struct {
 	CheckExistingDbMock             func(ctx context.Context, dbURI string) (bool, error)
 	CreateEmptyDatabaseMock         func(ctx context.Context, dbURI, dialect string) error
 	GetSpannerLeaderLocationMock    func(ctx context.Context, instanceURI string) (string, error)
+	GetProcessingUnitsMock          func(ctx context.Context, instanceURI string) (int32, error)
 	CheckIfChangeStreamExistsMock   func(ctx context.Context, changeStreamName, dbURI string) (bool, error)
 	ValidateChangeStreamOptionsMock func(ctx context.Context, changeStreamName, dbURI string) error
 	CreateChangeStreamMock          func(ctx context.Context, changeStreamName, dbURI string) error
@@ -37,6 +38,8 @@ type SpannerAccessorMock struct {
 	CreateOrUpdateDatabaseMock      func(ctx context.Context, dbURI, driver string, conv *internal.Conv, migrationType string) error
 	VerifyDbMock                    func(ctx context.Context, dbURI string) (dbExists bool, err error)
 	ValidateDDLMock                 func(ctx context.Context, dbURI string) error
+	GetDatabaseDDLMock              func(ctx context.Context, dbURI string) ([]string, error)
+	UpdateDatabaseDDLMock           func(ctx context.Context, dbURI string, stmts []string, protoDescriptors []byte) error
 	UpdateDDLForeignKeysMock        func(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string)
 	DropDatabaseMock                func(ctx context.Context, dbURI string) error
 	ValidateDMLMock                 func(ctx context.Context, query string) (bool, error)
@@ -64,6 +67,10 @@ func (sam *SpannerAccessorMock) GetSpannerLeaderLocation(ctx context.Context, in
 	return sam.GetSpannerLeaderLocationMock(ctx, instanceURI)
 }
 
+func (sam *SpannerAccessorMock) GetProcessingUnits(ctx context.Context, instanceURI string) (int32, error) {
+	return sam.GetProcessingUnitsMock(ctx, instanceURI)
+}
+
 func (sam *SpannerAccessorMock) CheckIfChangeStreamExists(ctx context.Context, changeStreamName, dbURI string) (bool, error) {
 	return sam.CheckIfChangeStreamExistsMock(ctx, changeStreamName, dbURI)
 }
@@ -91,6 +98,13 @@ func (sam *SpannerAccessorMock) VerifyDb(ctx context.Context, dbURI string) (dbE
 func (sam *SpannerAccessorMock) ValidateDDL(ctx context.Context, dbURI string) error {
 	return sam.ValidateDDLMock(ctx, dbURI)
 }
+
+func (sam *SpannerAccessorMock) GetDatabaseDDL(ctx context.Context, dbURI string) ([]string, error) {
+	return sam.GetDatabaseDDLMock(ctx, dbURI)
+}
+func (sam *SpannerAccessorMock) UpdateDatabaseDDL(ctx context.Context, dbURI string, stmts []string, protoDescriptors []byte) error {
+	return sam.UpdateDatabaseDDLMock(ctx, dbURI, stmts, protoDescriptors)
+}
 func (sam *SpannerAccessorMock) UpdateDDLForeignKeys(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string) {
 }
 
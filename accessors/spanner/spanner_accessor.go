@@ -34,6 +34,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/api/iterator"
 	adminpb "google.golang.org/genproto/googleapis/spanner/admin/database/v1"
+	"google.golang.org/grpc/codes"
 )
 
 var (
@@ -56,6 +57,8 @@ type SpannerAccessor interface {
 	CreateEmptyDatabase(ctx context.Context, dbURI, dialect string) error
 	// Fetch the leader of the Spanner instance.
 	GetSpannerLeaderLocation(ctx context.Context, instanceURI string) (string, error)
+	// Fetch the compute capacity of the Spanner instance, in processing units.
+	GetProcessingUnits(ctx context.Context, instanceURI string) (int32, error)
 	// Check if a change stream already exists.
 	CheckIfChangeStreamExists(ctx context.Context, changeStreamName, dbURI string) (bool, error)
 	// Validate that change stream option 'VALUE_CAPTURE_TYPE' is 'NEW_ROW'.
@@ -72,6 +75,10 @@ type SpannerAccessor interface {
 	VerifyDb(ctx context.Context, dbURI string) (dbExists bool, err error)
 	// Verify if an existing DB's ddl follows what is supported by Spanner migration tool. Currently, we only support empty schema when db already exists.
 	ValidateDDL(ctx context.Context, dbURI string) error
+	// Fetch the DDL statements defining the schema of an existing database.
+	GetDatabaseDDL(ctx context.Context, dbURI string) ([]string, error)
+	// Apply DDL statements to an existing database, in batches.
+	UpdateDatabaseDDL(ctx context.Context, dbURI string, stmts []string, protoDescriptors []byte) error
 	// UpdateDDLForeignKeys updates the Spanner database with foreign key constraints using ALTER TABLE statements.
 	UpdateDDLForeignKeys(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string)
 	// Deletes a database.
@@ -200,6 +207,18 @@ func (sp *SpannerAccessorImpl) GetSpannerLeaderLocation(ctx context.Context, ins
 	return "", fmt.Errorf("no leader found for spanner instance %s while trying fetch location", instanceURI)
 }
 
+func (sp *SpannerAccessorImpl) GetProcessingUnits(ctx context.Context, instanceURI string) (int32, error) {
+	instanceInfo, err := sp.InstanceClient.GetInstance(ctx, &instancepb.GetInstanceRequest{Name: instanceURI})
+	if err != nil {
+		return 0, err
+	}
+	if instanceInfo.ProcessingUnits > 0 {
+		return instanceInfo.ProcessingUnits, nil
+	}
+	// A node is 1000 processing units.
+	return instanceInfo.NodeCount * 1000, nil
+}
+
 // Consider using a CreateChangestream operation and check for alreadyExists error. That uses adminClient which can be unit tested.
 func (sp *SpannerAccessorImpl) CheckIfChangeStreamExists(ctx context.Context, changeStreamName, dbURI string) (bool, error) {
 	spClient, err := spannerclient.GetOrCreateClient(ctx, dbURI)
@@ -296,19 +315,27 @@ func (sp *SpannerAccessorImpl) CreateDatabase(ctx context.Context, dbURI string,
 	// using backticks (to avoid any issues with Spanner reserved words).
 	// Foreign Keys are set to false since we create them post data migration.
 	req := &adminpb.CreateDatabaseRequest{
-		Parent: fmt.Sprintf("projects/%s/instances/%s", project, instance),
+		Parent:           fmt.Sprintf("projects/%s/instances/%s", project, instance),
+		ProtoDescriptors: conv.ProtoDescriptors,
 	}
 
 	req.CreateStatement = fetchCreateDatabaseStatement(conv.SpDialect, dbName)
+	// Statements that don't fit in the create request are applied in
+	// batches once the database is created.
+	var batches [][]string
 	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
 		req.DatabaseDialect = adminpb.DatabaseDialect_POSTGRESQL
 	} else {
-		if migrationType == constants.DATAFLOW_MIGRATION {
-			req.ExtraStatements = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences)
+		var stmts []string
+		if foreignKeysCreatedWithTables(conv, migrationType) {
+			stmts = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects())
 		} else {
-			req.ExtraStatements = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences)
+			stmts = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects())
+		}
+		batches = ddl.SplitDDLBatches(stmts, ddl.MaxDDLBatchStatements, ddl.MaxDDLBatchBytes)
+		if len(batches) > 0 {
+			req.ExtraStatements, batches = batches[0], batches[1:]
 		}
-
 	}
 
 	op, err := sp.AdminClient.CreateDatabase(ctx, req)
@@ -323,7 +350,7 @@ func (sp *SpannerAccessorImpl) CreateDatabase(ctx context.Context, dbURI string,
 		// Update schema separately for PG databases.
 		return sp.UpdateDatabase(ctx, dbURI, conv, driver)
 	}
-	return nil
+	return sp.updateDatabaseDdlInBatches(ctx, dbURI, batches, nil)
 }
 
 func (sp *SpannerAccessorImpl) TableExists(ctx context.Context, tableName string) (bool, error) {
@@ -348,23 +375,84 @@ func (sp *SpannerAccessorImpl) UpdateDatabase(ctx context.Context, dbURI string,
 	// Spanner DDL doesn't accept them), and protects table and col names
 	// using backticks (to avoid any issues with Spanner reserved words).
 	// Foreign Keys are set to false since we create them post data migration.
-	schema := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences)
-	req := &adminpb.UpdateDatabaseDdlRequest{
-		Database:   dbURI,
-		Statements: schema,
-	}
-	// Update queries for postgres as target db return response after more
-	// than 1 min for large schemas, therefore, timeout is specified as 5 minutes
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	// Table and column comments can be kept in PostgreSQL databases as
+	// COMMENT ON statements.
+	schema := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, CommentStatements: conv.CommentStatements, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects())
+	return sp.updateDatabaseDdlInBatches(ctx, dbURI, ddl.SplitDDLBatches(schema, ddl.MaxDDLBatchStatements, ddl.MaxDDLBatchBytes), conv.ProtoDescriptors)
+}
+
+// maxDDLBatchRetries is the number of times the remaining statements of a
+// DDL batch are retried after a transient failure.
+const maxDDLBatchRetries = 3
+
+// ddlBatchTimeout is the deadline of each UpdateDatabaseDdl request, including
+// waiting for its statements to be applied. Update queries for postgres as
+// target db return response after more than 1 min for large batches.
+const ddlBatchTimeout = 5 * time.Minute
+
+// updateDatabaseDdlInBatches applies the batches of DDL statements in order,
+// each with one UpdateDatabaseDdl request. Spanner applies the statements of
+// a request one at a time, so when a request fails with a transient error,
+// the statements it didn't apply are retried. Other errors are returned with
+// the statement that failed. The proto descriptors are only sent with the
+// batch creating the proto bundle.
+func (sp *SpannerAccessorImpl) updateDatabaseDdlInBatches(ctx context.Context, dbURI string, batches [][]string, protoDescriptors []byte) error {
+	for _, batch := range batches {
+		for retries := 0; ; retries++ {
+			req := &adminpb.UpdateDatabaseDdlRequest{Database: dbURI, Statements: batch}
+			for _, stmt := range batch {
+				if strings.HasPrefix(stmt, "CREATE PROTO BUNDLE") {
+					req.ProtoDescriptors = protoDescriptors
+				}
+			}
+			applied, err := sp.updateDatabaseDdl(ctx, req)
+			if err == nil {
+				break
+			}
+			if applied < 0 {
+				return fmt.Errorf("can't build UpdateDatabaseDdlRequest: %w", parse.AnalyzeError(err, dbURI))
+			}
+			if applied >= len(batch) {
+				return fmt.Errorf("UpdateDatabaseDdl call failed: %w", parse.AnalyzeError(err, dbURI))
+			}
+			if !isRetryableDDLError(err) || ctx.Err() != nil || retries >= maxDDLBatchRetries {
+				return fmt.Errorf("UpdateDatabaseDdl call failed for statement %q: %w", batch[applied], parse.AnalyzeError(err, dbURI))
+			}
+			logger.Log.Debug(fmt.Sprintf("UpdateDatabaseDdl call failed after applying %d of %d statements, retrying the remaining statements: %v", applied, len(batch), err))
+			batch = batch[applied:]
+		}
+	}
+	return nil
+}
+
+// updateDatabaseDdl sends an UpdateDatabaseDdl request and waits for it to
+// complete, within ddlBatchTimeout. When the request fails, it returns the
+// number of statements that were applied, or -1 if the request wasn't sent.
+func (sp *SpannerAccessorImpl) updateDatabaseDdl(ctx context.Context, req *adminpb.UpdateDatabaseDdlRequest) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, ddlBatchTimeout)
 	defer cancel()
 	op, err := sp.AdminClient.UpdateDatabaseDdl(ctx, req)
 	if err != nil {
-		return fmt.Errorf("can't build UpdateDatabaseDdlRequest: %w", parse.AnalyzeError(err, dbURI))
+		return -1, err
 	}
-	if err := op.Wait(ctx); err != nil {
-		return fmt.Errorf("UpdateDatabaseDdl call failed: %w", parse.AnalyzeError(err, dbURI))
+	if err = op.Wait(ctx); err == nil {
+		return len(req.Statements), nil
 	}
-	return nil
+	applied := 0
+	if metadata, metadataErr := op.Metadata(); metadataErr == nil && metadata != nil {
+		applied = len(metadata.CommitTimestamps)
+	}
+	return applied, err
+}
+
+// isRetryableDDLError reports whether a failed UpdateDatabaseDdl request
+// can succeed when sent again.
+func isRetryableDDLError(err error) bool {
+	switch spanner.ErrCode(err) {
+	case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
 }
 
 // CreatesOrUpdatesDatabase updates an existing Spanner database or creates a new one if one does not exist.
@@ -415,11 +503,40 @@ func (sp *SpannerAccessorImpl) ValidateDDL(ctx context.Context, dbURI string) er
 	return nil
 }
 
+// GetDatabaseDDL returns the DDL statements defining the schema of an
+// existing database, e.g. to parse them with ddl.ParseDDL.
+func (sp *SpannerAccessorImpl) GetDatabaseDDL(ctx context.Context, dbURI string) ([]string, error) {
+	dbDdl, err := sp.AdminClient.GetDatabaseDdl(ctx, &adminpb.GetDatabaseDdlRequest{Database: dbURI})
+	if err != nil {
+		return nil, fmt.Errorf("can't fetch database ddl: %v", err)
+	}
+	return dbDdl.Statements, nil
+}
+
+// UpdateDatabaseDDL applies the DDL statements to an existing database, e.g.
+// those updating its schema, in batches of ddl.MaxDDLBatchStatements.
+func (sp *SpannerAccessorImpl) UpdateDatabaseDDL(ctx context.Context, dbURI string, stmts []string, protoDescriptors []byte) error {
+	return sp.updateDatabaseDdlInBatches(ctx, dbURI, ddl.SplitDDLBatches(stmts, ddl.MaxDDLBatchStatements, ddl.MaxDDLBatchBytes), protoDescriptors)
+}
+
+// foreignKeysCreatedWithTables reports whether CreateDatabase creates the
+// foreign keys along with the tables, before data is loaded. This is only
+// done for minimal downtime migrations to GoogleSQL databases, and not when
+// the foreign keys form a cycle as data can't then be loaded in an order
+// satisfying them.
+func foreignKeysCreatedWithTables(conv *internal.Conv, migrationType string) bool {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL || migrationType != constants.DATAFLOW_MIGRATION {
+		return false
+	}
+	_, ok := ddl.GetSortedTableIdsForDataLoad(conv.SpSchema)
+	return ok
+}
+
 // UpdateDDLForeignKeys updates the Spanner database with foreign key
 // constraints using ALTER TABLE statements.
 func (sp *SpannerAccessorImpl) UpdateDDLForeignKeys(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string) {
 
-	if conv.SpDialect != constants.DIALECT_POSTGRESQL && migrationType == constants.DATAFLOW_MIGRATION {
+	if foreignKeysCreatedWithTables(conv, migrationType) {
 		//foreign keys were applied as part of CreateDatabase
 		return
 	}
@@ -428,7 +545,7 @@ func (sp *SpannerAccessorImpl) UpdateDDLForeignKeys(ctx context.Context, dbURI s
 	// Spanner DDL doesn't accept them), and protects table and col names
 	// using backticks (to avoid any issues with Spanner reserved words).
 	// Sequences will not be passed as they have already been created.
-	fkStmts := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: false, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, make(map[string]ddl.Sequence))
+	fkStmts := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: false, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, make(map[string]ddl.Sequence), ddl.SchemaObjects{})
 	if len(fkStmts) == 0 {
 		return
 	}
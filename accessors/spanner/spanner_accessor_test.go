@@ -35,6 +35,9 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/exp/rand"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const TablePerDbError = "can't create/update database: can't create database: can't build CreateDatabaseRequest: rpc error: code = FailedPrecondition desc = Cannot add table table_999: too many tables (limit 5000)."
@@ -229,6 +232,43 @@ func TestSpannerAccessorImpl_GetDatabaseDialect(t *testing.T) {
 	}
 }
 
+func TestSpannerAccessorImpl_GetDatabaseDDL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		acm         spanneradmin.AdminClientMock
+		expectError bool
+		want        []string
+	}{
+		{
+			name: "Basic",
+			acm: spanneradmin.AdminClientMock{
+				GetDatabaseDdlMock: func(ctx context.Context, req *databasepb.GetDatabaseDdlRequest, opts ...gax.CallOption) (*databasepb.GetDatabaseDdlResponse, error) {
+					return &databasepb.GetDatabaseDdlResponse{Statements: []string{"CREATE TABLE t (a INT64) PRIMARY KEY (a)"}}, nil
+				},
+			},
+			expectError: false,
+			want:        []string{"CREATE TABLE t (a INT64) PRIMARY KEY (a)"},
+		},
+		{
+			name: "Error case",
+			acm: spanneradmin.AdminClientMock{
+				GetDatabaseDdlMock: func(ctx context.Context, req *databasepb.GetDatabaseDdlRequest, opts ...gax.CallOption) (*databasepb.GetDatabaseDdlResponse, error) {
+					return nil, fmt.Errorf("test-error")
+				},
+			},
+			expectError: true,
+			want:        nil,
+		},
+	}
+	ctx := context.Background()
+	for _, tc := range testCases {
+		spA := SpannerAccessorImpl{AdminClient: &tc.acm}
+		got, err := spA.GetDatabaseDDL(ctx, "testUri")
+		assert.Equal(t, tc.expectError, err != nil, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+}
+
 func TestSpannerAccessorImpl_CheckExistingDb(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -469,6 +509,32 @@ func TestSpannerAccessorImpl_GetSpannerLeaderLocation(t *testing.T) {
 	}
 }
 
+func TestSpannerAccessorImpl_GetProcessingUnits(t *testing.T) {
+	testCases := []struct {
+		name        string
+		instance    *instancepb.Instance
+		err         error
+		expectError bool
+		want        int32
+	}{
+		{name: "Processing units", instance: &instancepb.Instance{ProcessingUnits: 500}, want: 500},
+		{name: "Nodes", instance: &instancepb.Instance{NodeCount: 3}, want: 3000},
+		{name: "Error", err: fmt.Errorf("error"), expectError: true},
+	}
+	ctx := context.Background()
+	for _, tc := range testCases {
+		iac := spinstanceadmin.InstanceAdminClientMock{
+			GetInstanceMock: func(ctx context.Context, req *instancepb.GetInstanceRequest, opts ...gax.CallOption) (*instancepb.Instance, error) {
+				return tc.instance, tc.err
+			},
+		}
+		spA := SpannerAccessorImpl{InstanceClient: &iac}
+		got, err := spA.GetProcessingUnits(ctx, "projects/test-project/instances/test-instance")
+		assert.Equal(t, tc.expectError, err != nil, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+}
+
 func TestSpannerAccessorImpl_CreateDatabase(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -587,6 +653,7 @@ func TestSpannerAccessorImpl_CreateDatabase(t *testing.T) {
 		dbURI := "projects/project-id/instances/instance-id/databases/database-id"
 		conv := internal.MakeConv()
 		conv.SpDialect = tc.dialect
+		conv.SpSchema = ddl.Schema{"t1": {Name: "table1", Id: "t1"}}
 		spA := SpannerAccessorImpl{AdminClient: &tc.acm}
 		err := spA.CreateDatabase(ctx, dbURI, conv, "", tc.migrationType)
 		assert.Equal(t, tc.expectError, err != nil, tc.name)
@@ -624,6 +691,12 @@ func TestSpannerAccessorImpl_CreateDatabase_exceeds_and_hit_limits(t *testing.T)
 						WaitMock: func(ctx context.Context, opts ...gax.CallOption) (*databasepb.Database, error) { return nil, nil },
 					}, nil
 				},
+				// The statements not fitting in the create request are applied in batches.
+				UpdateDatabaseDdlMock: func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+					return &spanneradmin.UpdateDatabaseDdlOperationMock{
+						WaitMock: func(ctx context.Context, opts ...gax.CallOption) error { return nil },
+					}, nil
+				},
 			},
 			expectedErrorMsg: "",
 			expectError:      false,
@@ -1027,6 +1100,7 @@ func TestSpannerAccessorImpl_CreateOrUpdateDatabase(t *testing.T) {
 		dbURI := "projects/project-id/instances/instance-id/databases/database-id"
 		conv := internal.MakeConv()
 		conv.SpDialect = tc.dialect
+		conv.SpSchema = ddl.Schema{"t1": {Name: "table1", Id: "t1"}}
 		spA := SpannerAccessorImpl{AdminClient: &tc.acm}
 		err := spA.CreateOrUpdateDatabase(ctx, dbURI, "", conv, tc.migrationType)
 		assert.Equal(t, tc.expectError, err != nil, tc.name)
@@ -1075,12 +1149,89 @@ func TestSpannerAccessorImpl_UpdateDatabase(t *testing.T) {
 	for _, tc := range testCases {
 		dbURI := "projects/project-id/instances/instance-id/databases/database-id"
 		conv := internal.MakeConv()
+		conv.SpSchema = ddl.Schema{"t1": {Name: "table1", Id: "t1"}}
 		spA := SpannerAccessorImpl{AdminClient: &tc.acm}
 		err := spA.UpdateDatabase(ctx, dbURI, conv, "")
 		assert.Equal(t, tc.expectError, err != nil, tc.name)
 	}
 }
 
+func TestSpannerAccessorImpl_UpdateDatabaseDdlInBatches(t *testing.T) {
+	var requests [][]string
+	failures := 1
+	acm := spanneradmin.AdminClientMock{
+		UpdateDatabaseDdlMock: func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+			requests = append(requests, req.Statements)
+			// The first request is aborted after applying its first statement.
+			if failures > 0 {
+				failures--
+				return &spanneradmin.UpdateDatabaseDdlOperationMock{
+					WaitMock: func(ctx context.Context, opts ...gax.CallOption) error { return status.Error(codes.Aborted, "aborted") },
+					MetadataMock: func() (*databasepb.UpdateDatabaseDdlMetadata, error) {
+						return &databasepb.UpdateDatabaseDdlMetadata{CommitTimestamps: make([]*timestamppb.Timestamp, 1)}, nil
+					},
+				}, nil
+			}
+			return &spanneradmin.UpdateDatabaseDdlOperationMock{
+				WaitMock: func(ctx context.Context, opts ...gax.CallOption) error { return nil },
+			}, nil
+		},
+	}
+	spA := SpannerAccessorImpl{AdminClient: &acm}
+	batches := [][]string{{"CREATE TABLE a", "CREATE INDEX a_idx"}, {"CREATE TABLE b"}}
+	assert.Nil(t, spA.updateDatabaseDdlInBatches(context.Background(), "db", batches, nil))
+	assert.Equal(t, [][]string{{"CREATE TABLE a", "CREATE INDEX a_idx"}, {"CREATE INDEX a_idx"}, {"CREATE TABLE b"}}, requests)
+
+	// Transient failures are retried even when no statement was applied.
+	requests, failures = nil, 0
+	acm.UpdateDatabaseDdlMock = func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+		requests = append(requests, req.Statements)
+		return &spanneradmin.UpdateDatabaseDdlOperationMock{
+			WaitMock: func(ctx context.Context, opts ...gax.CallOption) error {
+				return status.Error(codes.Unavailable, "unavailable")
+			},
+		}, nil
+	}
+	assert.NotNil(t, spA.updateDatabaseDdlInBatches(context.Background(), "db", batches, nil))
+	assert.Equal(t, maxDDLBatchRetries+1, len(requests))
+
+	// Other failures aren't retried, and the error names the failing statement.
+	requests = nil
+	acm.UpdateDatabaseDdlMock = func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+		requests = append(requests, req.Statements)
+		return &spanneradmin.UpdateDatabaseDdlOperationMock{
+			WaitMock: func(ctx context.Context, opts ...gax.CallOption) error {
+				return status.Error(codes.InvalidArgument, "invalid statement")
+			},
+			MetadataMock: func() (*databasepb.UpdateDatabaseDdlMetadata, error) {
+				return &databasepb.UpdateDatabaseDdlMetadata{CommitTimestamps: make([]*timestamppb.Timestamp, 1)}, nil
+			},
+		}, nil
+	}
+	err := spA.updateDatabaseDdlInBatches(context.Background(), "db", batches, nil)
+	assert.ErrorContains(t, err, `"CREATE INDEX a_idx"`)
+	assert.Equal(t, [][]string{{"CREATE TABLE a", "CREATE INDEX a_idx"}}, requests)
+
+	// Each request has its own deadline, so that large schemas aren't cut off
+	// partway through.
+	var deadlines []time.Time
+	acm.UpdateDatabaseDdlMock = func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		deadlines = append(deadlines, deadline)
+		return &spanneradmin.UpdateDatabaseDdlOperationMock{
+			WaitMock: func(ctx context.Context, opts ...gax.CallOption) error {
+				time.Sleep(time.Millisecond)
+				return nil
+			},
+		}, nil
+	}
+	assert.Nil(t, spA.updateDatabaseDdlInBatches(context.Background(), "db", batches, nil))
+	assert.Equal(t, 2, len(deadlines))
+	assert.True(t, deadlines[1].After(deadlines[0]))
+	assert.True(t, time.Until(deadlines[1]) <= ddlBatchTimeout)
+}
+
 func TestSpannerAccessorImpl_UpdateDDLForeignKey(t *testing.T) {
 	schemaWithStatements := map[string]ddl.CreateTable{
 		"table_id": {
@@ -1288,3 +1439,33 @@ func TestFetchCreateDatabaseStatement(t *testing.T) {
 		})
 	}
 }
+
+func TestForeignKeysCreatedWithTables(t *testing.T) {
+	logger.Log = zap.NewNop()
+	acyclic := ddl.Schema{
+		"t1": {Name: "A", Id: "t1", ForeignKeys: []ddl.Foreignkey{{Name: "fk_ab", ReferTableId: "t2"}}},
+		"t2": {Name: "B", Id: "t2"},
+	}
+	cyclic := ddl.Schema{
+		"t1": {Name: "A", Id: "t1", ForeignKeys: []ddl.Foreignkey{{Name: "fk_ab", ReferTableId: "t2"}}},
+		"t2": {Name: "B", Id: "t2", ForeignKeys: []ddl.Foreignkey{{Name: "fk_ba", ReferTableId: "t1"}}},
+	}
+	testCases := []struct {
+		name          string
+		spSchema      ddl.Schema
+		dialect       string
+		migrationType string
+		expected      bool
+	}{
+		{name: "GoogleSql Dataflow", spSchema: acyclic, dialect: constants.DIALECT_GOOGLESQL, migrationType: constants.DATAFLOW_MIGRATION, expected: true},
+		{name: "GoogleSql Dataflow cyclic foreign keys", spSchema: cyclic, dialect: constants.DIALECT_GOOGLESQL, migrationType: constants.DATAFLOW_MIGRATION, expected: false},
+		{name: "GoogleSql bulk", spSchema: acyclic, dialect: constants.DIALECT_GOOGLESQL, migrationType: constants.BULK_MIGRATION, expected: false},
+		{name: "Pg Dataflow", spSchema: acyclic, dialect: constants.DIALECT_POSTGRESQL, migrationType: constants.DATAFLOW_MIGRATION, expected: false},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		conv.SpDialect = tc.dialect
+		conv.SpSchema = tc.spSchema
+		assert.Equal(t, tc.expected, foreignKeysCreatedWithTables(conv, tc.migrationType), tc.name)
+	}
+}
@@ -134,7 +134,8 @@ func initializeCollectors(conv *internal.Conv, sourceProfile profiles.SourceProf
 			ddl.GetDDL(
 				ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: "mysql"},
 				conv.SpSchema,
-				conv.SpSequences),
+				conv.SpSequences,
+				conv.SpSchemaObjects()),
 			"\n")
 
 		logger.Log.Debug("mysqlSchema", zap.String("schema", mysqlSchema))
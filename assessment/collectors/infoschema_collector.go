@@ -255,7 +255,7 @@ func (c InfoSchemaCollector) ListIndexes() (map[string]utils.SrcIndexDetails, ma
 				TableId:   c.indexes[i].TableId,
 				IsUnique:  c.indexes[i].IndexDef.Unique,
 				TableName: c.conv.SpSchema[c.indexes[i].TableId].Name,
-				Ddl:       getSpannerIndex(c.indexes[i].IndexDef.Id, c.conv.SpSchema[c.indexes[i].TableId]).PrintCreateIndex(c.conv.SpSchema[c.indexes[i].TableId], ddl.Config{}),
+				Ddl:       getSpannerIndex(c.indexes[i].IndexDef.Id, c.conv.SpSchema[c.indexes[i].TableId]).PrintCreateIndex(c.conv.SpSchema, c.conv.SpSchema[c.indexes[i].TableId], ddl.Config{}),
 			}
 		}
 	}
@@ -34,6 +34,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/proto/migration"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/google/subcommands"
 	"go.uber.org/zap"
@@ -49,11 +50,14 @@ type DataCmd struct {
 	filePrefix       string // TODO: move filePrefix to global flags
 	project          string
 	WriteLimit       int64
+	ConcurrentTables int
 	dryRun           bool
 	logLevel         string
 	SkipForeignKeys  bool
 	validate         bool
 	dataflowTemplate string
+	includeTables    string
+	excludeTables    string
 }
 
 // Name returns the name of operation.
@@ -73,7 +77,9 @@ func (cmd *DataCmd) Usage() string {
 Migrate data from source db to target db. Source db dump file can be specified
 by either file param in source-profile or piped to stdin. Connection profile
 for source databases in direct connect mode can be specified by setting
-appropriate params in source-profile. The data flags are:
+appropriate params in source-profile. The data of Spanner databases
+(-source=spanner) is copied by Dataflow jobs, through CSV files in the gcsPath
+of the source-profile. The data flags are:
 `, path.Base(os.Args[0]))
 }
 
@@ -86,12 +92,15 @@ func (cmd *DataCmd) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&cmd.targetProfile, "target-profile", "", "Flag for specifying connection profile for target database e.g., \"dialect=postgresql\"")
 	f.StringVar(&cmd.filePrefix, "prefix", "", "File prefix for generated files")
 	f.StringVar(&cmd.project, "project", "", "Flag spcifying default project id for all the generated resources for the migration")
-	f.Int64Var(&cmd.WriteLimit, "write-limit", DefaultWritersLimit, "Write limit for writes to spanner")
+	f.Int64Var(&cmd.WriteLimit, "write-limit", 0, "Limit on in-progress writes to spanner, shared by the tables written concurrently, derived from the node count of the instance when 0")
 	f.BoolVar(&cmd.dryRun, "dry-run", false, "Flag for generating DDL and schema conversion report without creating a spanner database")
 	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
 	f.BoolVar(&cmd.SkipForeignKeys, "skip-foreign-keys", false, "Skip creating foreign keys after data migration is complete (ddl statements for foreign keys can still be found in the downloaded schema.ddl.txt file and the same can be applied separately)")
 	f.BoolVar(&cmd.validate, "validate", false, "Flag for validating if all the required input parameters are present")
 	f.StringVar(&cmd.dataflowTemplate, "dataflow-template", constants.DEFAULT_TEMPLATE_PATH, "GCS path of the Dataflow template")
+	f.StringVar(&cmd.includeTables, "include-tables", "", "Optional. Regular expression matching the whole name of the source tables to migrate, e.g. \"orders|customers\"")
+	f.StringVar(&cmd.excludeTables, "exclude-tables", "", "Optional. Regular expression matching the whole name of the source tables not to migrate, e.g. \"tmp_.*\"")
+	f.IntVar(&cmd.ConcurrentTables, "concurrent-tables", 0, "Number of tables written to spanner concurrently, derived from the node count of the instance when 0")
 }
 
 func (cmd *DataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
@@ -118,6 +127,13 @@ func (cmd *DataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface
 		err = fmt.Errorf("error while preparing prerequisites for migration: %v", err)
 		return subcommands.ExitUsageError
 	}
+	if err = setTableFilters(&sourceProfile, cmd.includeTables, cmd.excludeTables); err != nil {
+		return subcommands.ExitUsageError
+	}
+	if cmd.ConcurrentTables < 0 || cmd.WriteLimit < 0 {
+		err = fmt.Errorf("please specify a positive concurrent-tables and write-limit, or 0 to derive them from the node count of the instance")
+		return subcommands.ExitUsageError
+	}
 	if cmd.project == "" {
 		getInfo := &utils.GetUtilInfoImpl{}
 		cmd.project, err = getInfo.GetProject()
@@ -155,6 +171,11 @@ func (cmd *DataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface
 			return subcommands.ExitUsageError
 		}
 	}
+	// Without the flags, the data of the tables selected when converting the
+	// schema is migrated.
+	if sourceProfile.TableFilters != (schema.TableFilters{}) {
+		conv.TableFilters = sourceProfile.TableFilters
+	}
 
 	var (
 		dbURI string
@@ -177,8 +198,10 @@ func (cmd *DataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface
 			}
 		}
 
+		// Nothing is written in a dry run, so the instance isn't looked up.
+		_, writeLimit := conversion.DataConcurrency(0, cmd.ConcurrentTables, cmd.WriteLimit)
 		convImpl := &conversion.ConvImpl{}
-		bw, err = convImpl.DataConv(ctx, cmd.project, sourceProfile, targetProfile, &ioHelper, nil, conv, true, cmd.WriteLimit, &conversion.DataFromSourceImpl{})
+		bw, err = convImpl.DataConv(ctx, cmd.project, sourceProfile, targetProfile, &ioHelper, nil, conv, true, writeLimit, &conversion.DataFromSourceImpl{})
 
 		if err != nil {
 			err = fmt.Errorf("can't finish data conversion for db %s: %v", dbName, err)
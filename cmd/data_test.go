@@ -37,7 +37,7 @@ func TestDataSetFlags(t *testing.T) {
                                 target:           "Spanner",
                                 targetProfile:    "",
                                 filePrefix:       "",
-                                WriteLimit:       DefaultWritersLimit,
+                                WriteLimit:       0,
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -54,7 +54,7 @@ func TestDataSetFlags(t *testing.T) {
                                 target:           "Spanner",
                                 targetProfile:    "",
                                 filePrefix:       "",
-                                WriteLimit:       DefaultWritersLimit,
+                                WriteLimit:       0,
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -71,7 +71,7 @@ func TestDataSetFlags(t *testing.T) {
                                 target:           "Spanner",
                                 targetProfile:    "target.json",
                                 filePrefix:       "",
-                                WriteLimit:       DefaultWritersLimit,
+                                WriteLimit:       0,
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -80,8 +80,8 @@ func TestDataSetFlags(t *testing.T) {
                         },
                 },
                 {
-                        testName: "File Prefix and Write Limit",
-                        flagArgs: []string{"--prefix=test", "--write-limit=100"},
+                        testName: "File Prefix, Write Limit and Concurrent Tables",
+                        flagArgs: []string{"--prefix=test", "--write-limit=100", "--concurrent-tables=4"},
                         expectedValues: DataCmd{
                                 source:           "",
                                 sourceProfile:    "",
@@ -89,6 +89,7 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "",
                                 filePrefix:       "test",
                                 WriteLimit:       100,
+                                ConcurrentTables: 4,
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -105,7 +106,7 @@ func TestDataSetFlags(t *testing.T) {
                                 target:           "Spanner",
                                 targetProfile:    "",
                                 filePrefix:       "",
-                                WriteLimit:       DefaultWritersLimit,
+                                WriteLimit:       0,
                                 dryRun:           true,
                                 logLevel:         "INFO",
                                 SkipForeignKeys:  false,
@@ -122,7 +123,7 @@ func TestDataSetFlags(t *testing.T) {
                                 target:           "Spanner",
                                 targetProfile:    "",
                                 filePrefix:       "",
-                                WriteLimit:       DefaultWritersLimit,
+                                WriteLimit:       0,
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  true,
@@ -139,7 +140,7 @@ func TestDataSetFlags(t *testing.T) {
                                 target:           "Spanner",
                                 targetProfile:    "",
                                 filePrefix:       "",
-                                WriteLimit:       DefaultWritersLimit,
+                                WriteLimit:       0,
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
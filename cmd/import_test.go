@@ -38,7 +38,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-const expectedDDL = "CREATE TABLE cart ( \tuser_id STRING(20) NOT NULL , \tproduct_id STRING(20) NOT NULL , \tquantity INT64, \tlast_modified TIMESTAMP NOT NULL , ) PRIMARY KEY (user_id, product_id);CREATE INDEX idx ON cart (quantity)"
+const expectedDDL = "CREATE TABLE cart ( \tuser_id STRING(20) NOT NULL , \tproduct_id STRING(20) NOT NULL , \tquantity INT64, \tlast_modified TIMESTAMP NOT NULL  OPTIONS (allow_commit_timestamp = true), ) PRIMARY KEY (user_id, product_id);CREATE INDEX idx ON cart (quantity)"
 
 func TestBasicCsvImport(t *testing.T) {
 	importDataCmd := ImportDataCmd{}
@@ -772,7 +772,8 @@ func fetchDDLString(conv *internal.Conv) string {
 		ddl.GetDDL(
 			ddl.Config{Comments: false, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: "mysql"},
 			conv.SpSchema,
-			conv.SpSequences), ";"), "\n", " ", -1)
+			conv.SpSequences,
+			conv.SpSchemaObjects()), ";"), "\n", " ", -1)
 }
 
 func TestGetDialectWithDefaults(t *testing.T) {
@@ -48,6 +48,8 @@ type SchemaCmd struct {
 	dryRun        bool
 	validate      bool
 	sessionJSON   string
+	includeTables string
+	excludeTables string
 }
 
 // Name returns the name of operation.
@@ -83,6 +85,8 @@ func (cmd *SchemaCmd) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&cmd.dryRun, "dry-run", false, "Flag for generating DDL and schema conversion report without creating a spanner database")
 	f.BoolVar(&cmd.validate, "validate", false, "Flag for validating if all the required input parameters are present")
 	f.StringVar(&cmd.sessionJSON, "session", "", "Optional. Specifies the file we restore session state from.")
+	f.StringVar(&cmd.includeTables, "include-tables", "", "Optional. Regular expression matching the whole name of the source tables to migrate, e.g. \"orders|customers\"")
+	f.StringVar(&cmd.excludeTables, "exclude-tables", "", "Optional. Regular expression matching the whole name of the source tables not to migrate, e.g. \"tmp_.*\"")
 }
 
 func (cmd *SchemaCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
@@ -106,6 +110,9 @@ func (cmd *SchemaCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interfa
 		err = fmt.Errorf("error while preparing prerequisites for migration: %v", err)
 		return subcommands.ExitUsageError
 	}
+	if err = setTableFilters(&sourceProfile, cmd.includeTables, cmd.excludeTables); err != nil {
+		return subcommands.ExitUsageError
+	}
 	if cmd.project == "" {
 		getInfo := &utils.GetUtilInfoImpl{}
 		cmd.project, err = getInfo.GetProject()
@@ -164,7 +171,14 @@ func (cmd *SchemaCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interfa
 		logger.Log.Error("Could not initialize conversion context from")
 		return subcommands.ExitFailure
 	}
+	if err = applyTargetSchemaOptions(conv, targetProfile, dbName, cmd.filePrefix, ioHelper.Out); err != nil {
+		logger.Log.Error(err.Error())
+		return subcommands.ExitFailure
+	}
+	reportInvalidIdentifiers(conv)
 	conversion.WriteSchemaFile(conv, schemaConversionStartTime, cmd.filePrefix+schemaFile, ioHelper.Out, sourceProfile.Driver)
+	conversion.WriteLimitUsageFile(conv, cmd.filePrefix+limitUsageFile, ioHelper.Out)
+	conversion.WriteSourceSchemaFile(conv, cmd.filePrefix+sourceSchemaFile, ioHelper.Out, sourceProfile.Driver)
 	// We always write the session file to accommodate for a re-run that might change anything.
 	conversion.WriteSessionFile(conv, cmd.filePrefix+sessionFile, ioHelper.Out)
 
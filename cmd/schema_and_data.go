@@ -47,10 +47,13 @@ type SchemaAndDataCmd struct {
 	filePrefix       string // TODO: move filePrefix to global flags
 	project          string
 	WriteLimit       int64
+	ConcurrentTables int
 	dryRun           bool
 	logLevel         string
 	validate         bool
 	dataflowTemplate string
+	includeTables    string
+	excludeTables    string
 }
 
 // Name returns the name of operation.
@@ -70,7 +73,9 @@ func (cmd *SchemaAndDataCmd) Usage() string {
 Migrate schema and data from source db to target db in schema-and-data. Source db dump
 file can be specified by either file param in source-profile or piped to stdin.
 Connection profile for source databases in direct connect mode can be specified
-by setting appropriate params in source-profile. The schema-and-data flags are:
+by setting appropriate params in source-profile. The data of Spanner databases
+(-source=spanner) is copied by Dataflow jobs, through CSV files in the gcsPath
+of the source-profile. The schema-and-data flags are:
 `, path.Base(os.Args[0]))
 }
 
@@ -83,11 +88,14 @@ func (cmd *SchemaAndDataCmd) SetFlags(f *flag.FlagSet) {
 	f.BoolVar(&cmd.SkipForeignKeys, "skip-foreign-keys", false, "Skip creating foreign keys after data migration is complete (ddl statements for foreign keys can still be found in the downloaded schema.ddl.txt file and the same can be applied separately)")
 	f.StringVar(&cmd.filePrefix, "prefix", "", "File prefix for generated files")
 	f.StringVar(&cmd.project, "project", "", "Flag spcifying default project id for all the generated resources for the migration")
-	f.Int64Var(&cmd.WriteLimit, "write-limit", DefaultWritersLimit, "Write limit for writes to spanner")
+	f.Int64Var(&cmd.WriteLimit, "write-limit", 0, "Limit on in-progress writes to spanner, shared by the tables written concurrently, derived from the node count of the instance when 0")
 	f.BoolVar(&cmd.dryRun, "dry-run", false, "Flag for generating DDL and schema conversion report without creating a spanner database")
 	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
 	f.BoolVar(&cmd.validate, "validate", false, "Flag for validating if all the required input parameters are present")
 	f.StringVar(&cmd.dataflowTemplate, "dataflow-template", constants.DEFAULT_TEMPLATE_PATH, "GCS path of the Dataflow template")
+	f.StringVar(&cmd.includeTables, "include-tables", "", "Optional. Regular expression matching the whole name of the source tables to migrate, e.g. \"orders|customers\"")
+	f.StringVar(&cmd.excludeTables, "exclude-tables", "", "Optional. Regular expression matching the whole name of the source tables not to migrate, e.g. \"tmp_.*\"")
+	f.IntVar(&cmd.ConcurrentTables, "concurrent-tables", 0, "Number of tables written to spanner concurrently, derived from the node count of the instance when 0")
 }
 
 func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
@@ -112,6 +120,13 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 		err = fmt.Errorf("error while preparing prerequisites for migration: %v", err)
 		return subcommands.ExitUsageError
 	}
+	if err = setTableFilters(&sourceProfile, cmd.includeTables, cmd.excludeTables); err != nil {
+		return subcommands.ExitUsageError
+	}
+	if cmd.ConcurrentTables < 0 || cmd.WriteLimit < 0 {
+		err = fmt.Errorf("please specify a positive concurrent-tables and write-limit, or 0 to derive them from the node count of the instance")
+		return subcommands.ExitUsageError
+	}
 	if cmd.project == "" {
 		getInfo := &utils.GetUtilInfoImpl{}
 		cmd.project, err = getInfo.GetProject()
@@ -149,6 +164,11 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 	if err != nil {
 		panic(err)
 	}
+	if err = applyTargetSchemaOptions(conv, targetProfile, dbName, cmd.filePrefix, ioHelper.Out); err != nil {
+		logger.Log.Error(err.Error())
+		return subcommands.ExitFailure
+	}
+	reportInvalidIdentifiers(conv)
 	schemaCoversionEndTime := time.Now()
 	conv.Audit.SchemaConversionDuration = schemaCoversionEndTime.Sub(schemaConversionStartTime)
 
@@ -158,6 +178,8 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 	conv.Audit.MigrationType = migration.MigrationData_SCHEMA_AND_DATA.Enum()
 
 	conversion.WriteSchemaFile(conv, schemaConversionStartTime, cmd.filePrefix+schemaFile, ioHelper.Out, sourceProfile.Driver)
+	conversion.WriteLimitUsageFile(conv, cmd.filePrefix+limitUsageFile, ioHelper.Out)
+	conversion.WriteSourceSchemaFile(conv, cmd.filePrefix+sourceSchemaFile, ioHelper.Out, sourceProfile.Driver)
 	conversion.WriteSessionFile(conv, cmd.filePrefix+sessionFile, ioHelper.Out)
 	conv.Audit.SkipMetricsPopulation = os.Getenv("SKIP_METRICS_POPULATION") == "true"
 	reportImpl := conversion.ReportImpl{}
@@ -185,7 +207,9 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 			}
 		}
 
-		bw, err = convImpl.DataConv(ctx, cmd.project, sourceProfile, targetProfile, &ioHelper, nil, conv, true, cmd.WriteLimit, &conversion.DataFromSourceImpl{})
+		// Nothing is written in a dry run, so the instance isn't looked up.
+		_, writeLimit := conversion.DataConcurrency(0, cmd.ConcurrentTables, cmd.WriteLimit)
+		bw, err = convImpl.DataConv(ctx, cmd.project, sourceProfile, targetProfile, &ioHelper, nil, conv, true, writeLimit, &conversion.DataFromSourceImpl{})
 		if err != nil {
 			err = fmt.Errorf("can't finish data conversion for db %s: %v", dbName, err)
 			return subcommands.ExitFailure
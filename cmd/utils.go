@@ -18,6 +18,8 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	sp "cloud.google.com/go/spanner"
@@ -33,7 +35,10 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/conversion"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/helpers"
 	"google.golang.org/grpc/metadata"
@@ -41,9 +46,12 @@ import (
 )
 
 var (
-	badDataFile = ".dropped.txt"
-	schemaFile  = ".schema.txt"
-	sessionFile = ".session.json"
+	badDataFile      = ".dropped.txt"
+	schemaFile       = ".schema.txt"
+	limitUsageFile   = ".limits.txt"
+	sourceSchemaFile = ".source.schema.sql"
+	sessionFile      = ".session.json"
+	protoEnumsFile   = ".enums.proto"
 )
 
 const (
@@ -61,6 +69,134 @@ func metricsPopulation(ctx context.Context, driver string, conv *internal.Conv)
 	}
 }
 
+// applyTargetSchemaOptions applies the schema options of the target profile,
+// such as column policies, constraint naming, change streams, trigger change
+// streams, locality groups, placements, models, property graphs, proto enums,
+// schema mapping, unenforced foreign keys, UUID fallback, source table name
+// synonyms and database options, to the converted schema of database dbName.
+func applyTargetSchemaOptions(conv *internal.Conv, targetProfile profiles.TargetProfile, dbName, filePrefix string, out io.Writer) error {
+	if targetProfile.Conn.Sp.ColumnPoliciesFile != "" {
+		if err := conversion.ReadColumnPoliciesFile(conv, targetProfile.Conn.Sp.ColumnPoliciesFile); err != nil {
+			return fmt.Errorf("can't apply column policies: %v", err)
+		}
+	}
+	if sp := targetProfile.Conn.Sp; sp.FkNameTemplate != "" || sp.IndexNameTemplate != "" || sp.CheckNameTemplate != "" {
+		naming := internal.ConstraintNaming{ForeignKey: sp.FkNameTemplate, Index: sp.IndexNameTemplate, CheckConstraint: sp.CheckNameTemplate, MaxLength: sp.ConstraintNameMaxLength}
+		if err := internal.ApplyConstraintNaming(conv, naming); err != nil {
+			return fmt.Errorf("can't name constraints: %v", err)
+		}
+	}
+	if targetProfile.Conn.Sp.ChangeStreamsFile != "" {
+		if err := conversion.ReadChangeStreamsFile(conv, targetProfile.Conn.Sp.ChangeStreamsFile); err != nil {
+			return fmt.Errorf("can't add change streams: %v", err)
+		}
+	}
+	if targetProfile.Conn.Sp.TriggerChangeStreams {
+		if err := internal.AddTriggerChangeStreams(conv); err != nil {
+			return fmt.Errorf("can't add trigger change streams: %v", err)
+		}
+	}
+	if targetProfile.Conn.Sp.LocalityGroupsFile != "" {
+		if err := conversion.ReadLocalityGroupsFile(conv, targetProfile.Conn.Sp.LocalityGroupsFile); err != nil {
+			return fmt.Errorf("can't add locality groups: %v", err)
+		}
+	}
+	if targetProfile.Conn.Sp.PlacementsFile != "" {
+		if err := conversion.ReadPlacementsFile(conv, targetProfile.Conn.Sp.PlacementsFile); err != nil {
+			return fmt.Errorf("can't add placements: %v", err)
+		}
+	}
+	if targetProfile.Conn.Sp.ModelsFile != "" {
+		if err := conversion.ReadModelsFile(conv, targetProfile.Conn.Sp.ModelsFile); err != nil {
+			return fmt.Errorf("can't add models: %v", err)
+		}
+	}
+	if name := targetProfile.Conn.Sp.PropertyGraph; name != "" {
+		if err := conversion.AddProposedPropertyGraph(conv, name); err != nil {
+			return fmt.Errorf("can't add property graph: %v", err)
+		}
+	}
+	if pkg := targetProfile.Conn.Sp.ProtoEnumPackage; pkg != "" {
+		if err := conversion.MapEnumsToProto(conv, pkg); err != nil {
+			return fmt.Errorf("can't map enums to proto enums: %v", err)
+		}
+		if err := conversion.WriteProtoEnumsFile(conv, pkg, filePrefix+protoEnumsFile); err != nil {
+			return fmt.Errorf("can't write proto enums file: %v", err)
+		}
+		fmt.Fprintf(out, "Wrote proto enum definitions to file '%s'.\n", filePrefix+protoEnumsFile)
+	}
+	if strategy := targetProfile.Conn.Sp.EnumStrategy; strategy != "" {
+		if err := internal.ApplyEnumStrategy(conv, strategy); err != nil {
+			return fmt.Errorf("can't apply enum strategy: %v", err)
+		}
+	}
+	if policy := targetProfile.Conn.Sp.AutoIncrementPolicy; policy != "" {
+		if err := internal.ApplyAutoIncrementPolicy(conv, policy); err != nil {
+			return fmt.Errorf("can't apply auto-increment policy: %v", err)
+		}
+	}
+	if mapping := targetProfile.Conn.Sp.SchemaMapping; mapping != "" {
+		if err := internal.ApplySchemaMapping(conv, mapping); err != nil {
+			return fmt.Errorf("can't apply schema mapping: %v", err)
+		}
+	}
+	conv.CommentStatements = targetProfile.Conn.Sp.CommentStatements
+	if targetProfile.Conn.Sp.FkNotEnforced {
+		for tableId, ct := range conv.SpSchema {
+			for i := range ct.ForeignKeys {
+				ct.ForeignKeys[i].NotEnforced = true
+			}
+			conv.SpSchema[tableId] = ct
+		}
+	}
+	if targetProfile.Conn.Sp.UUIDAsString {
+		conversion.MapUUIDsToString(conv)
+	}
+	if targetProfile.Conn.Sp.KeepSourceNameAsSynonym {
+		for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+			if err := internal.SetSourceTableSynonym(conv, tableId, true); err != nil {
+				fmt.Fprintf(out, "Not adding a synonym for table %s: %v\n", conv.SpSchema[tableId].Name, err)
+			}
+		}
+	}
+	conv.SpDatabaseOptions = ddl.DatabaseOptions{
+		DatabaseName:           dbName,
+		VersionRetentionPeriod: targetProfile.Conn.Sp.VersionRetentionPeriod,
+		DefaultLeader:          targetProfile.Conn.Sp.DefaultLeader,
+		DefaultSequenceKind:    targetProfile.Conn.Sp.DefaultSequenceKind,
+	}
+	if targetProfile.Conn.Sp.ProtoDescriptors != "" {
+		if err := conversion.ReadProtoDescriptorsFile(conv, targetProfile.Conn.Sp.ProtoDescriptors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportInvalidIdentifiers records the names of the converted schema that don't
+// follow the Spanner naming rules as unexpected conditions, so that they are
+// listed in the report.
+func reportInvalidIdentifiers(conv *internal.Conv) {
+	for _, violation := range ddl.ValidateIdentifiers(conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects()) {
+		conv.Unexpected(violation)
+	}
+}
+
+// validateSchemaLimits checks the converted schema against the Spanner
+// limits, so that migrations fail before any DDL Spanner would reject is
+// applied.
+func validateSchemaLimits(conv *internal.Conv) error {
+	violations := ddl.Validate(conv.SpSchema)
+	if len(violations) == 0 {
+		return nil
+	}
+	var msgs []string
+	for _, v := range violations {
+		msgs = append(msgs, v.String())
+	}
+	return fmt.Errorf("schema exceeds Spanner limits:\n\t%s", strings.Join(msgs, "\n\t"))
+}
+
 // CreateDatabaseClient creates new database client and admin client.
 func CreateDatabaseClient(ctx context.Context, targetProfile profiles.TargetProfile, driver, dbName string, ioHelper utils.IOStreams) (*database.DatabaseAdminClient, *sp.Client, string, error) {
 	if targetProfile.Conn.Sp.Dbname == "" {
@@ -125,6 +261,17 @@ func PrepareMigrationPrerequisites(sourceProfileString, targetProfileString, sou
 	return sourceProfile, targetProfile, ioHelper, dbName, nil
 }
 
+// setTableFilters sets the table filters of sourceProfile from the
+// include-tables and exclude-tables flags.
+func setTableFilters(sourceProfile *profiles.SourceProfile, includeTables, excludeTables string) error {
+	filters := schema.TableFilters{Include: includeTables, Exclude: excludeTables}
+	if err := filters.Validate(); err != nil {
+		return err
+	}
+	sourceProfile.TableFilters = filters
+	return nil
+}
+
 // MigrateData creates database and populates data in it.
 func MigrateDatabase(ctx context.Context, migrationProjectId string, targetProfile profiles.TargetProfile, sourceProfile profiles.SourceProfile, dbName string, ioHelper *utils.IOStreams, cmd interface{}, conv *internal.Conv, migrationError *error) (*writer.BatchWriter, error) {
 	var (
@@ -164,6 +311,20 @@ func migrateSchema(ctx context.Context, targetProfile profiles.TargetProfile, so
 	if err != nil {
 		return err
 	}
+	if err = validateSchemaLimits(conv); err != nil {
+		return err
+	}
+	if targetProfile.Conn.Sp.UpdateExistingSchema {
+		updated, err := updateExistingSchema(ctx, spA, conv, dbURI, sourceProfile.Driver, targetProfile.Conn.Sp.AllowSchemaDrops, ioHelper.Out)
+		if err != nil {
+			return fmt.Errorf("can't update schema of database %s: %v", dbURI, err)
+		}
+		if updated {
+			metricsPopulation(ctx, sourceProfile.Driver, conv)
+			conv.Audit.Progress.UpdateProgress("Schema migration complete.", completionPercentage, internal.SchemaMigrationComplete)
+			return nil
+		}
+	}
 	err = spA.CreateOrUpdateDatabase(ctx, dbURI, sourceProfile.Driver, conv, sourceProfile.Config.ConfigType)
 	if err != nil {
 		err = fmt.Errorf("can't create/update database: %v", err)
@@ -174,6 +335,35 @@ func migrateSchema(ctx context.Context, targetProfile profiles.TargetProfile, so
 	return nil
 }
 
+// updateExistingSchema updates the schema of database dbURI to the converted
+// schema when the database exists and its schema isn't empty, see
+// conversion.GetSchemaUpdateDDL. The statements are printed to out before
+// they are applied. It returns false when the database has yet to be created
+// or has an empty schema, which CreateOrUpdateDatabase handles.
+func updateExistingSchema(ctx context.Context, spA spanneraccessor.SpannerAccessor, conv *internal.Conv, dbURI, driver string, allowDrops bool, out io.Writer) (bool, error) {
+	dbExists, err := spA.CheckExistingDb(ctx, dbURI)
+	if err != nil || !dbExists {
+		return false, err
+	}
+	existingDDL, err := spA.GetDatabaseDDL(ctx, dbURI)
+	if err != nil || len(existingDDL) == 0 {
+		return false, err
+	}
+	stmts, err := conversion.GetSchemaUpdateDDL(ctx, spA, conv, dbURI, driver, allowDrops)
+	if err != nil {
+		return false, err
+	}
+	if len(stmts) == 0 {
+		fmt.Fprintf(out, "Schema of database %s is already up to date.\n", dbURI)
+		return true, nil
+	}
+	fmt.Fprintf(out, "Updating schema of database %s with:\n", dbURI)
+	for _, stmt := range stmts {
+		fmt.Fprintf(out, "%s;\n", stmt)
+	}
+	return true, spA.UpdateDatabaseDDL(ctx, dbURI, stmts, conv.ProtoDescriptors)
+}
+
 func migrateData(ctx context.Context, migrationProjectId string, targetProfile profiles.TargetProfile, sourceProfile profiles.SourceProfile,
 	ioHelper *utils.IOStreams, conv *internal.Conv, dbURI string, adminClient *database.DatabaseAdminClient, client *sp.Client, cmd *DataCmd) (*writer.BatchWriter, error) {
 	var (
@@ -197,8 +387,10 @@ func migrateData(ctx context.Context, migrationProjectId string, targetProfile p
 		}
 	}
 
+	var writeLimit int64
+	conv.ConcurrentTables, writeLimit = dataConcurrency(ctx, targetProfile, cmd.ConcurrentTables, cmd.WriteLimit)
 	c := &conversion.ConvImpl{}
-	bw, err = c.DataConv(ctx, migrationProjectId, sourceProfile, targetProfile, ioHelper, client, conv, true, cmd.WriteLimit, &conversion.DataFromSourceImpl{})
+	bw, err = c.DataConv(ctx, migrationProjectId, sourceProfile, targetProfile, ioHelper, client, conv, true, writeLimit, &conversion.DataFromSourceImpl{})
 
 	if err != nil {
 		err = fmt.Errorf("can't finish data conversion for db %s: %v", dbURI, err)
@@ -217,10 +409,16 @@ func migrateData(ctx context.Context, migrationProjectId string, targetProfile p
 
 func migrateSchemaAndData(ctx context.Context, migrationProjectId string, targetProfile profiles.TargetProfile, sourceProfile profiles.SourceProfile,
 	ioHelper *utils.IOStreams, conv *internal.Conv, dbURI string, adminClient *database.DatabaseAdminClient, client *sp.Client, cmd *SchemaAndDataCmd) (*writer.BatchWriter, error) {
+	if targetProfile.Conn.Sp.UpdateExistingSchema {
+		return nil, fmt.Errorf("the updateExistingSchema param of the target profile is only supported by the schema command")
+	}
 	spA, err := spanneraccessor.NewSpannerAccessorClientImpl(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if err = validateSchemaLimits(conv); err != nil {
+		return nil, err
+	}
 	err = spA.CreateOrUpdateDatabase(ctx, dbURI, sourceProfile.Driver, conv, sourceProfile.Config.ConfigType)
 	if err != nil {
 		err = fmt.Errorf("can't create/update database: %v", err)
@@ -237,8 +435,10 @@ func migrateSchemaAndData(ctx context.Context, migrationProjectId string, target
 		}
 	}
 
+	var writeLimit int64
+	conv.ConcurrentTables, writeLimit = dataConcurrency(ctx, targetProfile, cmd.ConcurrentTables, cmd.WriteLimit)
 	convImpl := &conversion.ConvImpl{}
-	bw, err := convImpl.DataConv(ctx, migrationProjectId, sourceProfile, targetProfile, ioHelper, client, conv, true, cmd.WriteLimit, &conversion.DataFromSourceImpl{})
+	bw, err := convImpl.DataConv(ctx, migrationProjectId, sourceProfile, targetProfile, ioHelper, client, conv, true, writeLimit, &conversion.DataFromSourceImpl{})
 
 	if err != nil {
 		err = fmt.Errorf("can't finish data conversion for db %s: %v", dbURI, err)
@@ -252,6 +452,27 @@ func migrateSchemaAndData(ctx context.Context, migrationProjectId string, target
 	return bw, nil
 }
 
+// dataConcurrency returns the number of tables written concurrently and the
+// limit on the in-progress writes they share, set by the concurrent-tables
+// and write-limit flags, or derived from the processing units of the Spanner
+// instance, see conversion.DataConcurrency.
+func dataConcurrency(ctx context.Context, targetProfile profiles.TargetProfile, concurrentTables int, writeLimit int64) (int, int64) {
+	if concurrentTables > 0 && writeLimit > 0 {
+		return concurrentTables, writeLimit
+	}
+	var processingUnits int32
+	spA, err := spanneraccessor.NewSpannerAccessorClientImpl(ctx)
+	if err == nil {
+		processingUnits, err = spA.GetProcessingUnits(ctx, fmt.Sprintf("projects/%s/instances/%s", targetProfile.Conn.Sp.Project, targetProfile.Conn.Sp.Instance))
+	}
+	if err != nil {
+		logger.Log.Warn(fmt.Sprintf("couldn't get the node count of the Spanner instance, writing data as for a single node: %v", err))
+	}
+	concurrentTables, writeLimit = conversion.DataConcurrency(processingUnits, concurrentTables, writeLimit)
+	logger.Log.Info(fmt.Sprintf("Writing %d table(s) at a time to Spanner, with up to %d writes in progress", concurrentTables, writeLimit))
+	return concurrentTables, writeLimit
+}
+
 func ValidateResourceGenerationHelper(ctx context.Context, migrationProjectId string, instanceId string, sourceProfile profiles.SourceProfile, conv *internal.Conv) error {
 	spanneraccessor, err := spanneraccessor.NewSpannerAccessorClientImpl(ctx)
 	if err != nil {
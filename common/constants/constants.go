@@ -44,9 +44,58 @@ const (
 	// This is an experimental driver; implementation in progress.
 	ORACLE string = "oracle"
 
+	// ORACLEDUMP is the driver name for the SQL files that impdp writes
+	// for Oracle Data Pump exports.
+	ORACLEDUMP string = "oracledump"
+
 	// CASSANDRA is the driver name for Cassandra.
 	CASSANDRA string = "cassandra"
 
+	// SQLITE is the driver name for SQLite.
+	SQLITE string = "sqlite"
+
+	// MONGODB is the driver name for MongoDB.
+	MONGODB string = "mongodb"
+
+	// MARIADB is the driver name for MariaDB.
+	MARIADB string = "mariadb"
+
+	// SNOWFLAKE is the driver name for Snowflake.
+	SNOWFLAKE string = "snowflake"
+
+	// REDSHIFT is the driver name for Amazon Redshift.
+	REDSHIFT string = "redshift"
+
+	// BIGQUERY is the driver name for BigQuery.
+	BIGQUERY string = "bigquery"
+
+	// SYBASE is the driver name for SAP ASE (Sybase Adaptive Server Enterprise).
+	SYBASE string = "sybase"
+
+	// PARQUET is the driver name for Parquet files.
+	PARQUET string = "parquet"
+
+	// AVRO is the driver name for Avro files.
+	AVRO string = "avro"
+
+	// ORC is the driver name for ORC files.
+	ORC string = "orc"
+
+	// EXCEL is the driver name for Excel workbooks (.xlsx files).
+	EXCEL string = "excel"
+
+	// BACPAC is the driver name for SQL Server packages, i.e. .bacpac and
+	// .dacpac files.
+	BACPAC string = "bacpac"
+
+	// SPANNER is the driver name for Spanner databases, e.g. to convert a
+	// GoogleSQL database to a PostgreSQL database.
+	SPANNER string = "spanner"
+
+	// FIXED_WIDTH is the csv source format for fixed-width flat files,
+	// such as mainframe extracts.
+	FIXED_WIDTH string = "fixed-width"
+
 	// Target db for which schema is being generated.
 	// This can be removed once the support for global flags is removed.
 	TargetSpanner              string = "spanner"
@@ -69,6 +118,9 @@ const (
 	// Scheme used for GCS paths
 	GCS_SCHEME      string = "gs"
 	GCS_FILE_PREFIX string = "gs://"
+	// Scheme used for S3 paths
+	S3_SCHEME      string = "s3"
+	S3_FILE_PREFIX string = "s3://"
 
 	// File upload prefix for dump and session load.
 	UPLOAD_FILE_DIR string = "upload-file"
@@ -111,6 +163,8 @@ const (
 	UUID           string = "UUID"
 	SEQUENCE       string = "Sequence"
 	AUTO_INCREMENT string = "Auto Increment"
+	AUTO_RANDOM    string = "Auto Random"
+	IDENTITY       string = "Identity"
 	// Default gcs path of the Dataflow template.
 	DEFAULT_TEMPLATE_PATH string = "gs://dataflow-templates/latest/flex/Cloud_Datastream_to_Spanner"
 
@@ -105,6 +105,10 @@ func getMigrationDataSourceDetails(driver string, migrationData *migration.Migra
 		return migration.MigrationData_DB_DUMP.Enum(), migration.MigrationData_POSTGRESQL.Enum()
 	case constants.MYSQLDUMP:
 		return migration.MigrationData_DB_DUMP.Enum(), migration.MigrationData_MYSQL.Enum()
+	case constants.ORACLEDUMP:
+		return migration.MigrationData_DB_DUMP.Enum(), migration.MigrationData_ORACLE.Enum()
+	case constants.BACPAC:
+		return migration.MigrationData_DB_DUMP.Enum(), migration.MigrationData_SQL_SERVER.Enum()
 	case constants.POSTGRES:
 		return migration.MigrationData_DIRECT_CONNECTION.Enum(), migration.MigrationData_POSTGRESQL.Enum()
 	case constants.MYSQL:
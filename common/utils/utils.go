@@ -29,6 +29,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"runtime/debug"
 	"sort"
 	"strings"
 	"syscall"
@@ -78,7 +79,8 @@ type GetUtilInfoInterface interface {
 type GetUtilInfoImpl struct{}
 
 // NewIOStreams returns a new IOStreams struct such that input stream is set
-// to open file descriptor for dumpFile if driver is PGDUMP or MYSQLDUMP.
+// to open file descriptor for dumpFile if driver is PGDUMP, MYSQLDUMP or
+// ORACLEDUMP.
 // Input stream defaults to stdin. Output stream is always set to stdout.
 func NewIOStreams(driver string, dumpFile string) IOStreams {
 	io := IOStreams{In: os.Stdin, Out: os.Stdout}
@@ -87,7 +89,7 @@ func NewIOStreams(driver string, dumpFile string) IOStreams {
 		fmt.Printf("parseFilePath: unable parse file path for dumpfile %s", dumpFile)
 		log.Fatal(err)
 	}
-	if (driver == constants.PGDUMP || driver == constants.MYSQLDUMP) && dumpFile != "" {
+	if (driver == constants.PGDUMP || driver == constants.MYSQLDUMP || driver == constants.ORACLEDUMP) && dumpFile != "" {
 		fmt.Printf("\nLoading dump file from path: %s\n", dumpFile)
 		var f *os.File
 		var err error
@@ -292,6 +294,16 @@ func GenerateHashStr() string {
 	return fmt.Sprintf("%x-%x", b[0:2], b[2:4])
 }
 
+// ToolVersion returns the version of the tool from its build information,
+// or "unknown" if the build information is unavailable.
+func ToolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" {
+		return "unknown"
+	}
+	return info.Main.Version
+}
+
 // PrintPermissionsWarning prints permission warning.
 func PrintPermissionsWarning(driver string, out *os.File) {
 	fmt.Fprintf(out,
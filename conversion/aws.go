@@ -0,0 +1,174 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds/rdsutils"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// awsOptions returns the AWS options of the MySQL or PostgreSQL database of
+// sourceProfile.
+func awsOptions(sourceProfile profiles.SourceProfile) profiles.AwsOptions {
+	if sourceProfile.Driver == constants.POSTGRES {
+		return sourceProfile.Conn.Pg.Aws
+	}
+	return sourceProfile.Conn.Mysql.Aws
+}
+
+// openSQLDb opens the MySQL or PostgreSQL database of sourceProfile at
+// connectionStr, or with IAM database authentication if it's enabled.
+func openSQLDb(sourceProfile profiles.SourceProfile, connectionStr string) (*sql.DB, error) {
+	if !awsOptions(sourceProfile).IAMAuth {
+		return sql.Open(sourceProfile.Driver, connectionStr)
+	}
+	if sourceProfile.Driver == constants.POSTGRES {
+		return openIAMAuthPGDb(sourceProfile.Conn.Pg)
+	}
+	return openIAMAuthMySQLDb(sourceProfile.Conn.Mysql)
+}
+
+// openReaderDb opens the reader endpoint of the Aurora cluster of
+// sourceProfile, or returns nil if it isn't set.
+func openReaderDb(sourceProfile profiles.SourceProfile) (*sql.DB, error) {
+	readerHost := awsOptions(sourceProfile).ReaderHost
+	if readerHost == "" {
+		return nil, nil
+	}
+	reader := sourceProfile
+	reader.Conn.Mysql.Host = readerHost
+	reader.Conn.Pg.Host = readerHost
+	reader, err := tunnelSourceProfile(reader)
+	if err != nil {
+		return nil, err
+	}
+	return openSQLDb(reader, profiles.GetSQLConnectionStr(reader))
+}
+
+// auroraVersion returns the Aurora version of db, read with getVersion, or
+// "" if it isn't an Aurora cluster. It is an error for the source not to be
+// an Aurora cluster if the source profile requires one.
+func auroraVersion(db *sql.DB, opts profiles.AwsOptions, getVersion func(*sql.DB) (string, error)) (string, error) {
+	version, err := getVersion(db)
+	if err != nil {
+		if opts.Aurora {
+			return "", err
+		}
+		logger.Log.Debug(fmt.Sprintf("couldn't check whether the source is Aurora: %v", err))
+		return "", nil
+	}
+	if version == "" && opts.Aurora {
+		return "", fmt.Errorf("the source isn't an Aurora cluster, but aurora is set in the source-profile")
+	}
+	if version != "" {
+		logger.Log.Info(fmt.Sprintf("Source is an Aurora cluster, version %s", version))
+	}
+	return version, nil
+}
+
+// newIAMAuthToken returns a function generating IAM database authentication
+// tokens for user at endpoint, with the AWS credentials of the environment.
+// Tokens are only valid for 15 minutes, so one is generated per connection.
+func newIAMAuthToken(endpoint, region, user string) (func() (string, error), error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("can't create AWS session for IAM database authentication: %w", err)
+	}
+	return func() (string, error) {
+		return rdsutils.BuildAuthToken(endpoint, region, user, sess.Config.Credentials)
+	}, nil
+}
+
+func openIAMAuthPGDb(conn profiles.SourceProfileConnectionPostgreSQL) (*sql.DB, error) {
+	token, err := newIAMAuthToken(net.JoinHostPort(conn.Host, conn.Port), conn.Aws.Region, conn.User)
+	if err != nil {
+		return nil, err
+	}
+	// IAM database authentication requires TLS.
+	config, err := pgx.ParseConfig(fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=require", conn.Host, conn.Port, conn.User, conn.Db))
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := conn.TLS.Config()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = conn.Host
+		}
+		config.TLSConfig = tlsConfig
+	}
+	return stdlib.OpenDB(*config, stdlib.OptionBeforeConnect(func(ctx context.Context, config *pgx.ConnConfig) error {
+		password, err := token()
+		config.Password = password
+		return err
+	})), nil
+}
+
+func openIAMAuthMySQLDb(conn profiles.SourceProfileConnectionMySQL) (*sql.DB, error) {
+	cfg := mysqldriver.NewConfig()
+	cfg.User, cfg.DBName = conn.User, conn.Db
+	cfg.Net, cfg.Addr = "tcp", net.JoinHostPort(conn.Host, conn.Port)
+	// IAM database authentication tokens are sent in clear text, so they
+	// require TLS.
+	cfg.TLSConfig = "true"
+	cfg.AllowCleartextPasswords = true
+	var err error
+	if cfg.TLS, err = conn.TLS.Config(); err != nil {
+		return nil, err
+	}
+	token, err := newIAMAuthToken(cfg.Addr, conn.Aws.Region, conn.User)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(iamAuthMySQLConnector{cfg: cfg, token: token}), nil
+}
+
+// iamAuthMySQLConnector connects to MySQL databases with a new IAM database
+// authentication token per connection.
+type iamAuthMySQLConnector struct {
+	cfg   *mysqldriver.Config
+	token func() (string, error)
+}
+
+func (c iamAuthMySQLConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	cfg := c.cfg.Clone()
+	var err error
+	if cfg.Passwd, err = c.token(); err != nil {
+		return nil, err
+	}
+	connector, err := mysqldriver.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+func (c iamAuthMySQLConnector) Driver() driver.Driver {
+	return mysqldriver.MySQLDriver{}
+}
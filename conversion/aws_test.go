@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+)
+
+func TestAuroraVersion(t *testing.T) {
+	logger.Log = zap.NewNop()
+	version := func(v string, err error) func(*sql.DB) (string, error) {
+		return func(*sql.DB) (string, error) { return v, err }
+	}
+	testCases := []struct {
+		name          string
+		opts          profiles.AwsOptions
+		getVersion    func(*sql.DB) (string, error)
+		want          string
+		errorExpected bool
+	}{
+		{"detected", profiles.AwsOptions{}, version("3.05.2", nil), "3.05.2", false},
+		{"not aurora", profiles.AwsOptions{}, version("", nil), "", false},
+		{"detection fails", profiles.AwsOptions{}, version("", fmt.Errorf("error")), "", false},
+		{"required", profiles.AwsOptions{Aurora: true}, version("3.05.2", nil), "3.05.2", false},
+		{"required but not aurora", profiles.AwsOptions{Aurora: true}, version("", nil), "", true},
+		{"required but detection fails", profiles.AwsOptions{Aurora: true}, version("", fmt.Errorf("error")), "", true},
+	}
+	for _, tc := range testCases {
+		got, err := auroraVersion(nil, tc.opts, tc.getVersion)
+		assert.Equal(t, tc.errorExpected, err != nil, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+}
+
+func TestOpenReaderDb(t *testing.T) {
+	db, err := openReaderDb(profiles.SourceProfile{Driver: constants.MYSQL})
+	assert.Nil(t, err)
+	assert.Nil(t, db)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ChangeStreamSpec declares a change stream to be created as part of the
+// target schema. Tables and columns are referenced by their Spanner names.
+type ChangeStreamSpec struct {
+	Name             string
+	WatchAll         bool
+	Tables           []ChangeStreamTableSpec
+	ValueCaptureType string
+	RetentionPeriod  string
+}
+
+// ChangeStreamTableSpec references a table watched by a change stream. When
+// Columns is empty, all columns of the table are watched.
+type ChangeStreamTableSpec struct {
+	Table   string
+	Columns []string
+}
+
+// ReadChangeStreamsFile reads a JSON list of change stream specs and adds the
+// corresponding change streams to conv.
+func ReadChangeStreamsFile(conv *internal.Conv, changeStreamsJSON string) error {
+	s, err := os.ReadFile(changeStreamsJSON)
+	if err != nil {
+		return err
+	}
+	var specs []ChangeStreamSpec
+	if err = json.Unmarshal(s, &specs); err != nil {
+		return fmt.Errorf("can't parse change streams file %s: %v", changeStreamsJSON, err)
+	}
+	return AddChangeStreams(conv, specs)
+}
+
+// AddChangeStreams resolves the table and column names of the change stream
+// specs against the Spanner schema and adds them to conv.
+func AddChangeStreams(conv *internal.Conv, specs []ChangeStreamSpec) error {
+	if conv.SpChangeStreams == nil {
+		conv.SpChangeStreams = make(map[string]ddl.ChangeStream)
+	}
+	for _, spec := range specs {
+		if _, found := conv.UsedNames[strings.ToLower(spec.Name)]; found {
+			return fmt.Errorf("change stream name %s is used by another entity", spec.Name)
+		}
+		cs := ddl.ChangeStream{
+			Id:               internal.GenerateChangeStreamId(),
+			Name:             spec.Name,
+			WatchAll:         spec.WatchAll,
+			ValueCaptureType: strings.ToUpper(spec.ValueCaptureType),
+			RetentionPeriod:  spec.RetentionPeriod,
+		}
+		for _, t := range spec.Tables {
+			tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, t.Table)
+			if err != nil {
+				return fmt.Errorf("can't add change stream %s: %v", spec.Name, err)
+			}
+			wt := ddl.ChangeStreamTable{TableId: tableId}
+			for _, col := range t.Columns {
+				colId, err := internal.GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, col)
+				if err != nil {
+					return fmt.Errorf("can't add change stream %s: %v", spec.Name, err)
+				}
+				wt.ColIds = append(wt.ColIds, colId)
+			}
+			cs.WatchedTables = append(cs.WatchedTables, wt)
+		}
+		if err := internal.ValidateChangeStream(conv.SpSchema, cs); err != nil {
+			return err
+		}
+		conv.SpChangeStreams[cs.Id] = cs
+		conv.UsedNames[strings.ToLower(cs.Name)] = true
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func changeStreamTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "status", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+		},
+	}
+	conv.UsedNames["orders"] = true
+	return conv
+}
+
+func TestAddChangeStreams(t *testing.T) {
+	tests := []struct {
+		name          string
+		specs         []ChangeStreamSpec
+		expectError   bool
+		expectedCount int
+	}{
+		{
+			name: "watched table and columns",
+			specs: []ChangeStreamSpec{{
+				Name:             "orders_stream",
+				Tables:           []ChangeStreamTableSpec{{Table: "orders", Columns: []string{"status"}}},
+				ValueCaptureType: "new_row",
+				RetentionPeriod:  "7d",
+			}},
+			expectedCount: 1,
+		},
+		{
+			name:          "watch all",
+			specs:         []ChangeStreamSpec{{Name: "all_stream", WatchAll: true}},
+			expectedCount: 1,
+		},
+		{
+			name:        "unknown table",
+			specs:       []ChangeStreamSpec{{Name: "cs", Tables: []ChangeStreamTableSpec{{Table: "customers"}}}},
+			expectError: true,
+		},
+		{
+			name:        "unknown column",
+			specs:       []ChangeStreamSpec{{Name: "cs", Tables: []ChangeStreamTableSpec{{Table: "orders", Columns: []string{"total"}}}}},
+			expectError: true,
+		},
+		{
+			name:        "unsupported value capture type",
+			specs:       []ChangeStreamSpec{{Name: "cs", WatchAll: true, ValueCaptureType: "ALL_VALUES"}},
+			expectError: true,
+		},
+		{
+			name:        "name already used",
+			specs:       []ChangeStreamSpec{{Name: "Orders", WatchAll: true}},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conv := changeStreamTestConv()
+			err := AddChangeStreams(conv, tc.specs)
+			assert.Equal(t, tc.expectError, err != nil)
+			assert.Equal(t, tc.expectedCount, len(conv.SpChangeStreams))
+		})
+	}
+}
+
+func TestReadChangeStreamsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "change_streams.json")
+	content := `[{"Name": "orders_stream", "Tables": [{"Table": "orders", "Columns": ["status"]}], "ValueCaptureType": "NEW_VALUES"}]`
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	conv := changeStreamTestConv()
+	assert.Nil(t, ReadChangeStreamsFile(conv, path))
+	assert.Equal(t, 1, len(conv.SpChangeStreams))
+	for _, cs := range conv.SpChangeStreams {
+		assert.Equal(t, "orders_stream", cs.Name)
+		assert.Equal(t, []ddl.ChangeStreamTable{{TableId: "t1", ColIds: []string{"c2"}}}, cs.WatchedTables)
+		assert.Equal(t, "NEW_VALUES", cs.ValueCaptureType)
+	}
+	assert.True(t, conv.UsedNames["orders_stream"])
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Policies of source columns whose data must not be migrated, e.g. columns
+// holding PII.
+const (
+	// ColumnPolicyExclude drops the column from the Spanner schema.
+	ColumnPolicyExclude = "exclude"
+	// ColumnPolicyRedact keeps the column in the Spanner schema, and writes
+	// its mask, or NULL, in place of its values.
+	ColumnPolicyRedact = "redact"
+)
+
+// ColumnPoliciesSpec declares the policies of source columns. Tables and
+// columns are referenced by their source names.
+type ColumnPoliciesSpec struct {
+	Columns []ColumnPolicySpec
+}
+
+// ColumnPolicySpec declares the policy of a source column. Redacted columns
+// are written Mask, which only STRING columns can be given, or NULL when Mask
+// is empty.
+type ColumnPolicySpec struct {
+	Table  string
+	Column string
+	Policy string // ColumnPolicyExclude or ColumnPolicyRedact.
+	Mask   string
+}
+
+// ReadColumnPoliciesFile reads a JSON column policies spec and applies it to
+// conv.
+func ReadColumnPoliciesFile(conv *internal.Conv, columnPoliciesJSON string) error {
+	s, err := os.ReadFile(columnPoliciesJSON)
+	if err != nil {
+		return err
+	}
+	var spec ColumnPoliciesSpec
+	if err = json.Unmarshal(s, &spec); err != nil {
+		return fmt.Errorf("can't parse column policies file %s: %v", columnPoliciesJSON, err)
+	}
+	return ApplyColumnPolicies(conv, spec)
+}
+
+// ApplyColumnPolicies drops the excluded columns of the spec from the Spanner
+// schema, and records the redacted ones in conv.RedactedColumns so that their
+// values are replaced when writing rows.
+func ApplyColumnPolicies(conv *internal.Conv, spec ColumnPoliciesSpec) error {
+	for _, c := range spec.Columns {
+		tableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, c.Table)
+		if err != nil {
+			return fmt.Errorf("can't apply column policy: %v", err)
+		}
+		colId, err := internal.GetColIdFromSrcName(conv.SrcSchema[tableId].ColDefs, c.Column)
+		if err != nil {
+			return fmt.Errorf("can't apply column policy: %v", err)
+		}
+		ct, ok := conv.SpSchema[tableId]
+		if !ok {
+			return fmt.Errorf("can't apply column policy: table %s is not migrated", c.Table)
+		}
+		if _, ok := ct.ColDefs[colId]; !ok {
+			if c.Policy == ColumnPolicyExclude {
+				// The column was excluded already, e.g. in the session file.
+				continue
+			}
+			return fmt.Errorf("can't apply column policy: column %s of table %s is not migrated", c.Column, c.Table)
+		}
+		for _, pk := range ct.PrimaryKeys {
+			if pk.ColId == colId {
+				return fmt.Errorf("can't apply column policy: column %s of table %s is part of the primary key", c.Column, c.Table)
+			}
+		}
+		switch c.Policy {
+		case ColumnPolicyExclude:
+			excludeColumn(conv, tableId, colId)
+		case ColumnPolicyRedact:
+			if err := redactColumn(conv, tableId, colId, c.Mask); err != nil {
+				return fmt.Errorf("can't redact column %s of table %s: %v", c.Column, c.Table, err)
+			}
+		default:
+			return fmt.Errorf("invalid policy %q of column %s of table %s, expected %q or %q", c.Policy, c.Column, c.Table, ColumnPolicyExclude, ColumnPolicyRedact)
+		}
+	}
+	return nil
+}
+
+// excludeColumn drops column colId from Spanner table tableId, along with
+// the indexes, foreign keys and check constraints using it.
+func excludeColumn(conv *internal.Conv, tableId, colId string) {
+	ct := conv.SpSchema[tableId]
+	name := ct.ColDefs[colId].Name
+	ct.ColIds = slices.DeleteFunc(ct.ColIds, func(id string) bool { return id == colId })
+	delete(ct.ColDefs, colId)
+
+	var indexes []ddl.CreateIndex
+	for _, index := range ct.Indexes {
+		if indexUsesColumn(index, colId) {
+			delete(conv.UsedNames, strings.ToLower(index.Name))
+			continue
+		}
+		index.StoredColumnIds = slices.DeleteFunc(index.StoredColumnIds, func(id string) bool { return id == colId })
+		indexes = append(indexes, index)
+	}
+	ct.Indexes = indexes
+
+	usesColumn := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+	var checks []ddl.CheckConstraint
+	for _, check := range ct.CheckConstraints {
+		if usesColumn.MatchString(check.Expr) {
+			delete(conv.UsedNames, strings.ToLower(check.Name))
+			continue
+		}
+		checks = append(checks, check)
+	}
+	ct.CheckConstraints = checks
+	conv.SpSchema[tableId] = ct
+
+	for id, t := range conv.SpSchema {
+		var fks []ddl.Foreignkey
+		for _, fk := range t.ForeignKeys {
+			if (id == tableId && slices.Contains(fk.ColIds, colId)) || (fk.ReferTableId == tableId && slices.Contains(fk.ReferColumnIds, colId)) {
+				delete(conv.UsedNames, strings.ToLower(fk.Name))
+				continue
+			}
+			fks = append(fks, fk)
+		}
+		t.ForeignKeys = fks
+		conv.SpSchema[id] = t
+	}
+	if issues, ok := conv.SchemaIssues[tableId]; ok {
+		delete(issues.ColumnLevelIssues, colId)
+	}
+}
+
+// redactColumn records that the values of column colId of Spanner table
+// tableId are replaced by mask, or by NULL when mask is empty, in which case
+// the column is made nullable.
+func redactColumn(conv *internal.Conv, tableId, colId, mask string) error {
+	ct := conv.SpSchema[tableId]
+	col := ct.ColDefs[colId]
+	if mask == "" {
+		col.NotNull = false
+		ct.ColDefs[colId] = col
+	} else {
+		if col.T.Name != ddl.String || col.T.IsArray {
+			return fmt.Errorf("only STRING columns can be masked")
+		}
+		if col.T.Len != ddl.MaxLength && int64(len(mask)) > col.T.Len {
+			return fmt.Errorf("mask %q is longer than the column", mask)
+		}
+	}
+	if conv.RedactedColumns == nil {
+		conv.RedactedColumns = make(map[string]map[string]string)
+	}
+	if conv.RedactedColumns[tableId] == nil {
+		conv.RedactedColumns[tableId] = make(map[string]string)
+	}
+	conv.RedactedColumns[tableId][colId] = mask
+	return nil
+}
+
+// indexUsesColumn reports whether column colId is a key of index.
+func indexUsesColumn(index ddl.CreateIndex, colId string) bool {
+	for _, key := range index.Keys {
+		if key.ColId == colId {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func columnPolicyTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name:   "customers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1"},
+				"c2": {Name: "ssn", Id: "c2"},
+				"c3": {Name: "email", Id: "c3"},
+				"c4": {Name: "birth_year", Id: "c4"},
+			},
+		},
+		"t2": {
+			Name:    "orders",
+			Id:      "t2",
+			ColIds:  []string{"c5", "c6"},
+			ColDefs: map[string]schema.Column{"c5": {Name: "id", Id: "c5"}, "c6": {Name: "customer_ssn", Id: "c6"}},
+		},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "customers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Name: "ssn", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 11}, NotNull: true},
+				"c3": {Name: "email", Id: "c3", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+				"c4": {Name: "birth_year", Id: "c4", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			Indexes: []ddl.CreateIndex{
+				{Name: "customers_ssn", Id: "i1", TableId: "t1", Unique: true, Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}}},
+				{Name: "customers_email", Id: "i2", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c3", Order: 1}}, StoredColumnIds: []string{"c2"}},
+			},
+			CheckConstraints: []ddl.CheckConstraint{
+				{Id: "ck1", Name: "ssn_format", Expr: "(REGEXP_CONTAINS(ssn, '^[0-9-]+$'))"},
+				{Id: "ck2", Name: "birth_year_range", Expr: "(birth_year > 1900)"},
+			},
+		},
+		"t2": {
+			Name:        "orders",
+			Id:          "t2",
+			ColIds:      []string{"c5", "c6"},
+			ColDefs:     map[string]ddl.ColumnDef{"c5": {Name: "id", Id: "c5", T: ddl.Type{Name: ddl.Int64}, NotNull: true}, "c6": {Name: "customer_ssn", Id: "c6", T: ddl.Type{Name: ddl.String, Len: 11}}},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c5", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "orders_customer", Id: "f1", ColIds: []string{"c6"}, ReferTableId: "t1", ReferColumnIds: []string{"c2"}}},
+		},
+	}
+	return conv
+}
+
+func TestApplyColumnPoliciesExclude(t *testing.T) {
+	conv := columnPolicyTestConv()
+	err := ApplyColumnPolicies(conv, ColumnPoliciesSpec{Columns: []ColumnPolicySpec{{Table: "customers", Column: "ssn", Policy: ColumnPolicyExclude}}})
+	assert.Nil(t, err)
+	ct := conv.SpSchema["t1"]
+	assert.Equal(t, []string{"c1", "c3", "c4"}, ct.ColIds)
+	assert.NotContains(t, ct.ColDefs, "c2")
+	assert.Equal(t, 1, len(ct.Indexes))
+	assert.Equal(t, "customers_email", ct.Indexes[0].Name)
+	assert.Empty(t, ct.Indexes[0].StoredColumnIds)
+	assert.Equal(t, []ddl.CheckConstraint{{Id: "ck2", Name: "birth_year_range", Expr: "(birth_year > 1900)"}}, ct.CheckConstraints)
+	assert.Empty(t, conv.SpSchema["t2"].ForeignKeys)
+	assert.Empty(t, conv.RedactedColumns)
+}
+
+func TestApplyColumnPoliciesRedact(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           ColumnPolicySpec
+		expectError      bool
+		expectedNotNull  bool
+		expectedRedacted map[string]map[string]string
+	}{
+		{
+			name:             "null",
+			policy:           ColumnPolicySpec{Table: "customers", Column: "birth_year", Policy: ColumnPolicyRedact},
+			expectedRedacted: map[string]map[string]string{"t1": {"c4": ""}},
+		},
+		{
+			name:             "mask",
+			policy:           ColumnPolicySpec{Table: "customers", Column: "email", Policy: ColumnPolicyRedact, Mask: "redacted@example.com"},
+			expectedNotNull:  true,
+			expectedRedacted: map[string]map[string]string{"t1": {"c3": "redacted@example.com"}},
+		},
+		{
+			name:            "mask of a non-string column",
+			policy:          ColumnPolicySpec{Table: "customers", Column: "birth_year", Policy: ColumnPolicyRedact, Mask: "0"},
+			expectError:     true,
+			expectedNotNull: true,
+		},
+		{
+			name:            "mask longer than the column",
+			policy:          ColumnPolicySpec{Table: "customers", Column: "ssn", Policy: ColumnPolicyRedact, Mask: "XXX-XX-XXXX-X"},
+			expectError:     true,
+			expectedNotNull: true,
+		},
+		{
+			name:            "primary key",
+			policy:          ColumnPolicySpec{Table: "customers", Column: "id", Policy: ColumnPolicyRedact},
+			expectError:     true,
+			expectedNotNull: true,
+		},
+		{
+			name:            "unknown column",
+			policy:          ColumnPolicySpec{Table: "customers", Column: "phone", Policy: ColumnPolicyRedact},
+			expectError:     true,
+			expectedNotNull: true,
+		},
+		{
+			name:            "invalid policy",
+			policy:          ColumnPolicySpec{Table: "customers", Column: "email", Policy: "hash"},
+			expectError:     true,
+			expectedNotNull: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conv := columnPolicyTestConv()
+			err := ApplyColumnPolicies(conv, ColumnPoliciesSpec{Columns: []ColumnPolicySpec{tc.policy}})
+			assert.Equal(t, tc.expectError, err != nil)
+			assert.Equal(t, tc.expectedRedacted, conv.RedactedColumns)
+			assert.Equal(t, 4, len(conv.SpSchema["t1"].ColIds))
+			colId, _ := internal.GetColIdFromSpName(conv.SpSchema["t1"].ColDefs, tc.policy.Column)
+			if colId != "" {
+				assert.Equal(t, tc.expectedNotNull, conv.SpSchema["t1"].ColDefs[colId].NotNull)
+			}
+		})
+	}
+}
+
+func TestReadColumnPoliciesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "column_policies.json")
+	content := `{"Columns": [{"Table": "orders", "Column": "customer_ssn", "Policy": "exclude"}, {"Table": "customers", "Column": "ssn", "Policy": "redact", "Mask": "XXX-XX-XXXX"}]}`
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	conv := columnPolicyTestConv()
+	assert.Nil(t, ReadColumnPoliciesFile(conv, path))
+	assert.Equal(t, []string{"c5"}, conv.SpSchema["t2"].ColIds)
+	assert.Empty(t, conv.SpSchema["t2"].ForeignKeys)
+	assert.Equal(t, map[string]map[string]string{"t1": {"c2": "XXX-XX-XXXX"}}, conv.RedactedColumns)
+
+	// The policies can be applied again, e.g. to a session restored from file.
+	assert.Nil(t, ReadColumnPoliciesFile(conv, path))
+}
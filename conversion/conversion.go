@@ -77,9 +77,9 @@ type ConvImpl struct{}
 // The SourceProfile param provides the connection details to use the go SQL library.
 func (ci *ConvImpl) SchemaConv(migrationProjectId string, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile, ioHelper *utils.IOStreams, schemaFromSource SchemaFromSourceInterface) (*internal.Conv, error) {
 	switch sourceProfile.Driver {
-	case constants.POSTGRES, constants.MYSQL, constants.DYNAMODB, constants.SQLSERVER, constants.ORACLE, constants.CASSANDRA:
+	case constants.POSTGRES, constants.MYSQL, constants.DYNAMODB, constants.SQLSERVER, constants.ORACLE, constants.CASSANDRA, constants.SQLITE, constants.MONGODB, constants.MARIADB, constants.SNOWFLAKE, constants.REDSHIFT, constants.BIGQUERY, constants.SYBASE, constants.PARQUET, constants.AVRO, constants.ORC, constants.EXCEL, constants.BACPAC, constants.SPANNER:
 		return schemaFromSource.schemaFromDatabase(migrationProjectId, sourceProfile, targetProfile, &GetInfoImpl{}, &common.ProcessSchemaImpl{})
-	case constants.PGDUMP, constants.MYSQLDUMP:
+	case constants.PGDUMP, constants.MYSQLDUMP, constants.ORACLEDUMP:
 		expressionVerificationAccessor, _ := expressions_api.NewExpressionVerificationAccessorImpl(context.Background(), targetProfile.Conn.Sp.Project, targetProfile.Conn.Sp.Instance)
 		return schemaFromSource.SchemaFromDump(targetProfile.Conn.Sp.Project, targetProfile.Conn.Sp.Instance, sourceProfile.Driver, targetProfile.Conn.Sp.Dialect, ioHelper, &ProcessDumpByDialectImpl{ExpressionVerificationAccessor: expressionVerificationAccessor})
 	default:
@@ -97,13 +97,17 @@ func (ci *ConvImpl) DataConv(ctx context.Context, migrationProjectId string, sou
 		Verbose:    internal.Verbose(),
 	}
 	switch sourceProfile.Driver {
-	case constants.POSTGRES, constants.MYSQL, constants.DYNAMODB, constants.SQLSERVER, constants.ORACLE:
+	case constants.POSTGRES, constants.MYSQL, constants.DYNAMODB, constants.SQLSERVER, constants.ORACLE, constants.CASSANDRA, constants.SQLITE, constants.MONGODB, constants.MARIADB, constants.SNOWFLAKE, constants.REDSHIFT, constants.BIGQUERY, constants.SYBASE, constants.PARQUET, constants.AVRO, constants.ORC, constants.EXCEL, constants.BACPAC:
 		return dataFromSource.dataFromDatabase(ctx, migrationProjectId, sourceProfile, targetProfile, config, conv, client, &GetInfoImpl{}, &DataFromDatabaseImpl{}, &SnapshotMigrationImpl{})
 	case constants.PGDUMP, constants.MYSQLDUMP:
 		if conv.SpSchema.CheckInterleaved() {
 			return nil, fmt.Errorf("spanner migration tool does not currently support data conversion from dump files\nif the schema contains interleaved tables. Suggest using direct access to source database\ni.e. using drivers postgres and mysql")
 		}
 		return dataFromSource.dataFromDump(sourceProfile.Driver, config, ioHelper, client, conv, dataOnly, &ProcessDumpByDialectImpl{}, &PopulateDataConvImpl{})
+	case constants.ORACLEDUMP:
+		return nil, fmt.Errorf("the SQL files of Oracle Data Pump exports have no data: unload the tables to CSV files, e.g. with SQL*Plus or SQL Developer, and migrate them with -source=csv")
+	case constants.SPANNER:
+		return dataFromSource.dataFromSpanner(ctx, migrationProjectId, sourceProfile, targetProfile, conv)
 	case constants.CSV:
 		return dataFromSource.dataFromCSV(ctx, sourceProfile, targetProfile, config, conv, client, &PopulateDataConvImpl{}, &csv.CsvImpl{})
 	default:
@@ -15,11 +15,12 @@
 package conversion
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	dataflow "cloud.google.com/go/dataflow/apiv1beta3"
 	sp "cloud.google.com/go/spanner"
 	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
 	storageaccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/storage"
@@ -32,6 +33,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/csv"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/streaming"
 	"go.uber.org/zap"
@@ -50,6 +52,7 @@ type DataFromSourceInterface interface {
 	dataFromDatabase(ctx context.Context, migrationProjectId string, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile, config writer.BatchWriterConfig, conv *internal.Conv, client *sp.Client, getInfo GetInfoInterface, dataFromDb DataFromDatabaseInterface, snapshotMigration SnapshotMigrationInterface) (*writer.BatchWriter, error)
 	dataFromDump(driver string, config writer.BatchWriterConfig, ioHelper *utils.IOStreams, client *sp.Client, conv *internal.Conv, dataOnly bool, processDump ProcessDumpByDialectInterface, populateDataConv PopulateDataConvInterface) (*writer.BatchWriter, error)
 	dataFromCSV(ctx context.Context, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile, config writer.BatchWriterConfig, conv *internal.Conv, client *sp.Client, populateDataConv PopulateDataConvInterface, csv csv.CsvInterface) (*writer.BatchWriter, error)
+	dataFromSpanner(ctx context.Context, migrationProjectId string, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile, conv *internal.Conv) (*writer.BatchWriter, error)
 }
 
 type DataFromSourceImpl struct{}
@@ -60,6 +63,10 @@ func (sads *SchemaFromSourceImpl) schemaFromDatabase(migrationProjectId string,
 	conv.SpProjectId = targetProfile.Conn.Sp.Project
 	conv.SpInstanceId = targetProfile.Conn.Sp.Instance
 	conv.Source = sourceProfile.Driver
+	conv.TableFilters = sourceProfile.TableFilters
+	// The schema of minimal downtime migrations is limited to the types
+	// Datastream and Dataflow support.
+	conv.Audit.StreamingStats.Streaming = sourceProfile.Conn.Streaming || sourceProfile.Config.ConfigType == constants.DATAFLOW_MIGRATION
 	//handle fetching schema differently for sharded migrations, we only connect to the primary shard to
 	//fetch the schema. We reuse the SourceProfileConnection object for this purpose.
 	var infoSchema common.InfoSchema
@@ -109,7 +116,23 @@ func (sads *SchemaFromSourceImpl) schemaFromDatabase(migrationProjectId string,
 		DdlV:                           sads.DdlVerifier,
 		ExpressionVerificationAccessor: expressionVerificationAccessor,
 	}
-	return conv, processSchema.ProcessSchema(conv, infoSchema, common.DefaultWorkers, additionalSchemaAttributes, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	err = processSchema.ProcessSchema(conv, infoSchema, sourceProfile.SchemaWorkers(), additionalSchemaAttributes, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	if err != nil {
+		return conv, err
+	}
+	if source, ok := infoSchema.(common.ChildTableSource); ok {
+		common.InterleaveChildTables(conv, source)
+	}
+	if sampleSize := targetProfile.Conn.Sp.StringLengthSampleSize; sampleSize > 0 {
+		sampler, ok := infoSchema.(common.ColumnLengthSampler)
+		if !ok {
+			return conv, fmt.Errorf("inferring string lengths from the data is not supported for %s", sourceProfile.Driver)
+		}
+		if err := common.InferStringLengths(conv, sampler, sampleSize, targetProfile.Conn.Sp.StringLengthMargin); err != nil {
+			return conv, err
+		}
+	}
+	return conv, nil
 }
 
 func (sads *SchemaFromSourceImpl) SchemaFromDump(SpProjectId string, SpInstanceId string, driver string, spDialect string, ioHelper *utils.IOStreams, processDump ProcessDumpByDialectInterface) (*internal.Conv, error) {
@@ -126,7 +149,11 @@ func (sads *SchemaFromSourceImpl) SchemaFromDump(SpProjectId string, SpInstanceI
 	conv.SpProjectId = SpProjectId
 	conv.SpInstanceId = SpInstanceId
 	p := internal.NewProgress(n, "Generating schema", internal.Verbose(), false, int(internal.SchemaCreationInProgress))
-	r := internal.NewReader(bufio.NewReader(f), p)
+	r, err := newDumpReader(driver, f, p)
+	if err != nil {
+		fmt.Fprintf(ioHelper.Out, "Failed to read the data file: %v", err)
+		return nil, fmt.Errorf("failed to read the data file")
+	}
 	conv.SetSchemaMode() // Build schema and ignore data in dump.
 	conv.SetDataSink(nil)
 	err = processDump.ProcessDump(driver, conv, r)
@@ -161,7 +188,10 @@ func (sads *DataFromSourceImpl) dataFromDump(driver string, config writer.BatchW
 	totalRows := conv.Rows()
 
 	conv.Audit.Progress = *internal.NewProgress(totalRows, "Writing data to Spanner", internal.Verbose(), false, int(internal.DataWriteInProgress))
-	r := internal.NewReader(bufio.NewReader(ioHelper.SeekableIn), nil)
+	r, err := newDumpReader(driver, ioHelper.SeekableIn, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the data file: %v", err)
+	}
 	batchWriter := populateDataConv.populateDataConv(conv, config, client)
 	processDump.ProcessDump(driver, conv, r)
 	batchWriter.Flush()
@@ -190,6 +220,18 @@ func (sads *DataFromSourceImpl) dataFromCSV(ctx context.Context, sourceProfile p
 		return nil, fmt.Errorf("dialect specified in target profile does not match spanner dialect")
 	}
 
+	switch sourceProfile.Csv.Format {
+	case "", constants.CSV:
+	case constants.FIXED_WIDTH:
+		err = utils.ReadSpannerSchema(ctx, conv, client)
+		if err != nil {
+			return nil, fmt.Errorf("error trying to read and convert spanner schema: %v", err)
+		}
+		return dataFromFixedWidth(sourceProfile, config, conv, client, populateDataConv)
+	default:
+		return nil, fmt.Errorf("format should be '%s' or '%s', found '%s'", constants.CSV, constants.FIXED_WIDTH, sourceProfile.Csv.Format)
+	}
+
 	delimiterStr := sourceProfile.Csv.Delimiter
 	if len(delimiterStr) != 1 {
 		return nil, fmt.Errorf("delimiter should only be a single character long, found '%s'", delimiterStr)
@@ -225,6 +267,33 @@ func (sads *DataFromSourceImpl) dataFromCSV(ctx context.Context, sourceProfile p
 	return batchWriter, nil
 }
 
+// dataFromFixedWidth writes the records of the fixed-width files described
+// by the csv manifest to Spanner.
+func dataFromFixedWidth(sourceProfile profiles.SourceProfile, config writer.BatchWriterConfig, conv *internal.Conv, client *sp.Client, populateDataConv PopulateDataConvInterface) (*writer.BatchWriter, error) {
+	fixedWidth := csv.FixedWidthImpl{}
+	tables, err := fixedWidth.GetFixedWidthFiles(conv, sourceProfile)
+	if err != nil {
+		return nil, fmt.Errorf("error finding fixed-width files: %v", err)
+	}
+
+	// Find the number of records in each file for generating stats.
+	err = fixedWidth.SetFixedWidthRowStats(conv, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	totalRows := conv.Rows()
+	conv.Audit.Progress = *internal.NewProgress(totalRows, "Writing data to Spanner", internal.Verbose(), false, int(internal.DataWriteInProgress))
+	batchWriter := populateDataConv.populateDataConv(conv, config, client)
+	err = fixedWidth.ProcessFixedWidth(conv, tables, sourceProfile.Csv.NullStr)
+	if err != nil {
+		return nil, fmt.Errorf("can't process fixed-width files: %v", err)
+	}
+	batchWriter.Flush()
+	conv.Audit.Progress.Done()
+	return batchWriter, nil
+}
+
 func (sads *DataFromSourceImpl) dataFromDatabase(ctx context.Context, migrationProjectId string, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile, config writer.BatchWriterConfig, conv *internal.Conv, client *sp.Client, getInfo GetInfoInterface, dataFromDb DataFromDatabaseInterface, snapshotMigration SnapshotMigrationInterface) (*writer.BatchWriter, error) {
 	//handle migrating data for sharded migrations differently
 	//sharded migrations are identified via the config= flag, if that flag is not present
@@ -346,3 +415,49 @@ func (sads *DataFromSourceImpl) dataFromDatabase(ctx context.Context, migrationP
 		return snapshotMigration.performSnapshotMigration(config, conv, client, infoSchema, internal.AdditionalDataAttributes{ShardId: ""}, &common.InfoSchemaImpl{}, &PopulateDataConvImpl{}), nil
 	}
 }
+
+// dataFromSpanner copies the data of a Spanner database to the database of
+// the other dialect it was converted to, with the Dataflow jobs of
+// spanner.DataflowCopy. The jobs run in the migration project.
+func (sads *DataFromSourceImpl) dataFromSpanner(ctx context.Context, migrationProjectId string, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile, conv *internal.Conv) (*writer.BatchWriter, error) {
+	src := sourceProfile.Conn.Spanner
+	if src.GcsPath == "" || src.DataflowRegion == "" {
+		return nil, fmt.Errorf("please specify gcsPath and dataflowRegion in the source-profile to copy the data of Spanner databases with Dataflow")
+	}
+	templatesClient, err := dataflow.NewTemplatesClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dataflow templates client: %v", err)
+	}
+	defer templatesClient.Close()
+	jobsClient, err := dataflow.NewJobsV1Beta3Client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dataflow jobs client: %v", err)
+	}
+	defer jobsClient.Close()
+	sc, err := storageclient.NewStorageClientImpl(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sa := storageaccessor.StorageAccessorImpl{}
+	if migrationProjectId == "" {
+		migrationProjectId = targetProfile.Conn.Sp.Project
+	}
+	dc := spanner.DataflowCopy{
+		Templates: templatesClient,
+		Jobs:      jobsClient,
+		WriteGCSFile: func(ctx context.Context, filePath, fileName, data string) error {
+			return sa.WriteDataToGCS(ctx, sc, filePath, fileName, data)
+		},
+		Project:      migrationProjectId,
+		Region:       src.DataflowRegion,
+		GcsPath:      src.GcsPath,
+		PollInterval: 30 * time.Second,
+	}
+	err = dc.CopyData(ctx, conv,
+		spanner.Database{Project: src.Project, Instance: src.Instance, Db: src.Db},
+		spanner.Database{Project: targetProfile.Conn.Sp.Project, Instance: targetProfile.Conn.Sp.Instance, Db: targetProfile.Conn.Sp.Dbname})
+	if err != nil {
+		return nil, err
+	}
+	return &writer.BatchWriter{}, nil
+}
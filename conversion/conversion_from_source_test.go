@@ -15,15 +15,21 @@
 package conversion
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 )
 
 func TestSchemaFromDatabase(t *testing.T) {
@@ -166,3 +172,23 @@ func TestSchemaFromDatabase(t *testing.T) {
 		assert.Equal(t, tc.errorExpected, err != nil, tc.name)
 	}
 }
+
+func TestNewDumpReader(t *testing.T) {
+	logger.Log = zap.NewNop()
+	dump := "CREATE TABLE t (a INT);\nINSERT INTO t VALUES (1);\n"
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, err := w.Write([]byte(dump))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	for name, content := range map[string][]byte{"plain": []byte(dump), "gzip": compressed.Bytes()} {
+		p := internal.NewProgress(int64(len(content)), "Generating schema", false, false, int(internal.SchemaCreationInProgress))
+		r, err := newDumpReader(constants.MYSQLDUMP, bytes.NewReader(content), p)
+		assert.Nil(t, err, name)
+		assert.Equal(t, "CREATE TABLE t (a INT);\n", string(r.ReadLine()), name)
+		assert.Equal(t, "INSERT INTO t VALUES (1);\n", string(r.ReadLine()), name)
+		r.ReadLine()
+		assert.True(t, r.EOF, name)
+	}
+}
@@ -15,6 +15,7 @@
 package conversion
 
 import (
+	"bufio"
 	"context"
 	"encoding/base64"
 	"fmt"
@@ -30,11 +31,13 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/metrics"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/oracle"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/aws/aws-sdk-go/aws"
@@ -89,6 +92,50 @@ func getSeekable(f *os.File) (*os.File, int64, error) {
 	return fcopy, n, nil
 }
 
+// newDumpReader returns a reader of the dump file f, which may be gzip or
+// zstd compressed. Since line offsets in a compressed dump don't measure
+// progress, p, if not nil, is reported against the bytes read from f.
+// pg_dump custom format archives, and directory format archives when f is
+// their directory, are translated to plain-text dumps.
+func newDumpReader(driver string, f io.Reader, p *internal.Progress) (*internal.Reader, error) {
+	if dir, ok := f.(*os.File); ok && driver == constants.PGDUMP {
+		if info, err := dir.Stat(); err == nil && info.IsDir() {
+			r, err := postgres.NewDirectoryArchiveReader(dir.Name())
+			if err != nil {
+				return nil, err
+			}
+			return internal.NewReader(bufio.NewReader(r), nil), nil
+		}
+	}
+	r, err := file_reader.Decompress(&progressReader{r: f, p: p})
+	if err != nil {
+		return nil, err
+	}
+	if driver == constants.PGDUMP {
+		r, err = postgres.NewDumpReader(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return internal.NewReader(bufio.NewReader(r), nil), nil
+}
+
+// progressReader reports the number of bytes read from r to p.
+type progressReader struct {
+	r io.Reader
+	p *internal.Progress
+	n int64
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	pr.n += int64(n)
+	if pr.p != nil {
+		pr.p.MaybeReport(pr.n)
+	}
+	return n, err
+}
+
 // ProcessDump invokes process dump function from a sql package based on driver selected.
 func (pdd *ProcessDumpByDialectImpl) ProcessDump(driver string, conv *internal.Conv, r *internal.Reader) error {
 	switch driver {
@@ -96,6 +143,8 @@ func (pdd *ProcessDumpByDialectImpl) ProcessDump(driver string, conv *internal.C
 		return common.ProcessDbDump(conv, r, mysql.DbDumpImpl{}, pdd.DdlVerifier, pdd.ExpressionVerificationAccessor)
 	case constants.PGDUMP:
 		return common.ProcessDbDump(conv, r, postgres.DbDumpImpl{}, pdd.DdlVerifier, pdd.ExpressionVerificationAccessor)
+	case constants.ORACLEDUMP:
+		return common.ProcessDbDump(conv, r, oracle.DbDumpImpl{}, pdd.DdlVerifier, pdd.ExpressionVerificationAccessor)
 	default:
 		return fmt.Errorf("process dump for driver %s not supported", driver)
 	}
@@ -129,12 +178,19 @@ func (pdc *PopulateDataConvImpl) populateDataConv(conv *internal.Conv, config wr
 		conv.DataFlush = func() {
 			batchWriter.Flush()
 		}
+		conv.DataFlushTable = func(table string) {
+			batchWriter.FlushTable(table)
+		}
 	}
 
 	return batchWriter
 }
 
 func ConnectionConfig(sourceProfile profiles.SourceProfile) (interface{}, error) {
+	sourceProfile, err := tunnelSourceProfile(sourceProfile)
+	if err != nil {
+		return nil, err
+	}
 	switch sourceProfile.Driver {
 	// For PG and MYSQL, When called as part of the subcommand flow, host/user/db etc will
 	// never be empty as we error out right during source profile creation. If any of them
@@ -163,9 +219,42 @@ func ConnectionConfig(sourceProfile profiles.SourceProfile) (interface{}, error)
 		return profiles.GetSQLConnectionStr(sourceProfile), nil
 	case constants.ORACLE:
 		return profiles.GetSQLConnectionStr(sourceProfile), nil
+	case constants.SQLITE:
+		return profiles.GetSQLConnectionStr(sourceProfile), nil
+	case constants.MONGODB:
+		return sourceProfile.Conn.MongoDB.URI, nil
+	case constants.MARIADB:
+		return profiles.GetSQLConnectionStr(sourceProfile), nil
+	case constants.SNOWFLAKE:
+		return profiles.GetSQLConnectionStr(sourceProfile), nil
+	case constants.REDSHIFT:
+		return profiles.GetSQLConnectionStr(sourceProfile), nil
+	case constants.SYBASE:
+		return profiles.GetSQLConnectionStr(sourceProfile), nil
+	// Returns an empty string as BigQuery datasets are accessed with the BigQuery API.
+	case constants.BIGQUERY:
+		return "", nil
+	// Returns an empty string as Parquet files are read from their paths.
+	case constants.PARQUET:
+		return "", nil
+	// Returns an empty string as Avro files are read from their paths.
+	case constants.AVRO:
+		return "", nil
+	// Returns an empty string as ORC files are read from their paths.
+	case constants.ORC:
+		return "", nil
+	// Returns an empty string as Excel workbooks are read from their paths.
+	case constants.EXCEL:
+		return "", nil
+	// Returns an empty string as SQL Server packages are read from their paths.
+	case constants.BACPAC:
+		return "", nil
 	// Returns an empty string as Cassandra connections are managed directly by the gocql session.	
 	case constants.CASSANDRA:
 		return "", nil
+	// Returns an empty string as Spanner databases are accessed with the Spanner client.
+	case constants.SPANNER:
+		return "", nil
 	default:
 		return "", fmt.Errorf("driver %s not supported", sourceProfile.Driver)
 	}
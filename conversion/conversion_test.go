@@ -171,6 +171,13 @@ func TestDataConv(t *testing.T) {
 			function: 				"dataFromCSV",
 			errorExpected: 			false,
 		},
+		{
+			name: 					"spanner driver",
+			sourceProfileDriver: 	"spanner",
+			output: 				&writer.BatchWriter{},
+			function: 				"dataFromSpanner",
+			errorExpected: 			false,
+		},
 		{
 			name: 					"invalid driver",
 			sourceProfileDriver: 	"invalid",
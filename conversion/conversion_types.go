@@ -14,7 +14,11 @@
 
 package conversion
 
-import "context"
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+)
 
 type ConnectionProfile struct {
 	// Project Id of the resource
@@ -37,6 +41,8 @@ type ConnectionProfile struct {
 	User string
 	// Region of connection profile to be created
 	Region string
+	// For source connection profile SSH tunnel the MySql instance is reached through, if any
+	SSHTunnel *profiles.SSHOptions
 	// For target connection profile name of gcs bucket to be created
 	BucketName string
 }
@@ -0,0 +1,46 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+const (
+	// WritersPerNode is the number of in-progress writes to Spanner per node
+	// of the instance when migrating data, unless set otherwise.
+	WritersPerNode = 40
+	// MaxConcurrentTables is the maximum number of tables whose rows are
+	// written concurrently when migrating data, unless set otherwise.
+	MaxConcurrentTables = 16
+)
+
+// DataConcurrency returns the number of tables whose rows are written to
+// Spanner concurrently, and the limit on the in-progress writes shared by
+// these tables, for an instance of processingUnits. concurrentTables and
+// writeLimit are returned as they are when above 0. Otherwise a table is
+// written per node, up to MaxConcurrentTables, and the tables share
+// WritersPerNode writes per node. Instances of less than a node, or whose
+// processing units aren't known, are written one table at a time.
+func DataConcurrency(processingUnits int32, concurrentTables int, writeLimit int64) (int, int64) {
+	// A node is 1000 processing units.
+	nodes := int(processingUnits / 1000)
+	if nodes < 1 {
+		nodes = 1
+	}
+	if concurrentTables <= 0 {
+		concurrentTables = min(nodes, MaxConcurrentTables)
+	}
+	if writeLimit <= 0 {
+		writeLimit = int64(WritersPerNode * nodes)
+	}
+	return concurrentTables, writeLimit
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataConcurrency(t *testing.T) {
+	tests := []struct {
+		name               string
+		processingUnits    int32
+		concurrentTables   int
+		writeLimit         int64
+		expectedTables     int
+		expectedWriteLimit int64
+	}{
+		{name: "unknown instance", expectedTables: 1, expectedWriteLimit: 40},
+		{name: "less than a node", processingUnits: 300, expectedTables: 1, expectedWriteLimit: 40},
+		{name: "one node", processingUnits: 1000, expectedTables: 1, expectedWriteLimit: 40},
+		{name: "nodes", processingUnits: 5000, expectedTables: 5, expectedWriteLimit: 200},
+		{name: "more nodes than tables", processingUnits: 32000, expectedTables: 16, expectedWriteLimit: 1280},
+		{name: "concurrent tables set", processingUnits: 8000, concurrentTables: 2, expectedTables: 2, expectedWriteLimit: 320},
+		{name: "write limit set", processingUnits: 8000, writeLimit: 10, expectedTables: 8, expectedWriteLimit: 10},
+		{name: "both set", processingUnits: 8000, concurrentTables: 3, writeLimit: 10, expectedTables: 3, expectedWriteLimit: 10},
+	}
+	for _, tc := range tests {
+		tables, writeLimit := DataConcurrency(tc.processingUnits, tc.concurrentTables, tc.writeLimit)
+		assert.Equal(t, tc.expectedTables, tables, tc.name)
+		assert.Equal(t, tc.expectedWriteLimit, writeLimit, tc.name)
+	}
+}
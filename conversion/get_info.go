@@ -22,21 +22,37 @@ import (
 	"strings"
 
 	"cloud.google.com/go/cloudsqlconn"
+	sp "cloud.google.com/go/spanner"
 	ca "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/cassandra" 
+	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/avro"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/bigquery"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/cassandra"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/dynamodb"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/excel"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mariadb"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mongodb"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mysql"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/oracle"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/orc"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/parquet"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/redshift"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/snowflake"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/sqlite"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/sqlserver"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/sybase"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	dydb "github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodbstreams"
+	"github.com/aws/aws-sdk-go/service/s3"
 	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
@@ -56,6 +72,11 @@ func (gi *GetInfoImpl) getInfoSchemaForShard(migrationProjectId string, shardCon
 	params["dbName"] = shardConnInfo.DbName
 	params["port"] = shardConnInfo.Port
 	params["password"] = shardConnInfo.Password
+	if ssh := shardConnInfo.SSHTunnel; ssh != nil {
+		params["sshHost"], params["sshPort"], params["sshUser"] = ssh.Host, ssh.Port, ssh.User
+		params["sshKey"], params["sshKnownHosts"] = ssh.KeyFile, ssh.KnownHostsFile
+	}
+	params["proxy"] = shardConnInfo.Proxy
 	//while adding other sources, a switch-case will be added here on the basis of the driver input param passed.
 	//pased on the driver name, profiles.NewSourceProfileConnection<DBName> will need to be called to create
 	//the source profile information.
@@ -80,44 +101,50 @@ func (gi *GetInfoImpl) GetInfoSchemaFromCloudSQL(migrationProjectId string, sour
 	driver := sourceProfile.Driver
 	switch driver {
 	case constants.MYSQL:
-		d, err := cloudsqlconn.NewDialer(context.Background(), cloudsqlconn.WithIAMAuthN())
+		conn := sourceProfile.ConnCloudSQL.Mysql
+		d, opts, err := cloudSQLDialer(conn.Opts)
 		if err != nil {
-			return nil, fmt.Errorf("cloudsqlconn.NewDialer: %w", err)
+			return nil, err
 		}
-		var opts []cloudsqlconn.DialOption
-		instanceName := fmt.Sprintf("%s:%s:%s", sourceProfile.ConnCloudSQL.Mysql.Project, sourceProfile.ConnCloudSQL.Mysql.Region, sourceProfile.ConnCloudSQL.Mysql.InstanceName)
+		instanceName := fmt.Sprintf("%s:%s:%s", conn.Project, conn.Region, conn.InstanceName)
 		mysqldriver.RegisterDialContext("cloudsqlconn",
 			func(ctx context.Context, addr string) (net.Conn, error) {
 				return d.Dial(ctx, instanceName, opts...)
 			})
 
-		dbURI := fmt.Sprintf("%s:empty@cloudsqlconn(localhost:3306)/%s?parseTime=true",
-			sourceProfile.ConnCloudSQL.Mysql.User, sourceProfile.ConnCloudSQL.Mysql.Db)
+		pwd := "empty"
+		if !conn.Opts.IAMAuth {
+			pwd = conn.Pwd
+		}
+		dbURI := fmt.Sprintf("%s:%s@cloudsqlconn(localhost:3306)/%s?parseTime=true", conn.User, pwd, conn.Db)
 
 		db, err := sql.Open("mysql", dbURI)
 		if err != nil {
 			return nil, fmt.Errorf("sql.Open: %w", err)
 		}
 		return mysql.InfoSchemaImpl{
-			DbName:             sourceProfile.ConnCloudSQL.Mysql.Db,
+			DbName:             conn.Db,
 			Db:                 db,
 			MigrationProjectId: migrationProjectId,
 			SourceProfile:      sourceProfile,
 			TargetProfile:      targetProfile,
 		}, nil
 	case constants.POSTGRES:
-		d, err := cloudsqlconn.NewDialer(context.Background(), cloudsqlconn.WithIAMAuthN())
+		conn := sourceProfile.ConnCloudSQL.Pg
+		d, opts, err := cloudSQLDialer(conn.Opts)
 		if err != nil {
-			return nil, fmt.Errorf("cloudsqlconn.NewDialer: %w", err)
+			return nil, err
 		}
-		var opts []cloudsqlconn.DialOption
 
-		dsn := fmt.Sprintf("user=%s database=%s", sourceProfile.ConnCloudSQL.Pg.User, sourceProfile.ConnCloudSQL.Pg.Db)
+		dsn := fmt.Sprintf("user=%s database=%s", conn.User, conn.Db)
 		config, err := pgx.ParseConfig(dsn)
 		if err != nil {
 			return nil, err
 		}
-		instanceName := fmt.Sprintf("%s:%s:%s", sourceProfile.ConnCloudSQL.Pg.Project, sourceProfile.ConnCloudSQL.Pg.Region, sourceProfile.ConnCloudSQL.Pg.InstanceName)
+		if !conn.Opts.IAMAuth {
+			config.Password = conn.Pwd
+		}
+		instanceName := fmt.Sprintf("%s:%s:%s", conn.Project, conn.Region, conn.InstanceName)
 		config.DialFunc = func(ctx context.Context, network, instance string) (net.Conn, error) {
 			return d.Dial(ctx, instanceName, opts...)
 		}
@@ -139,6 +166,24 @@ func (gi *GetInfoImpl) GetInfoSchemaFromCloudSQL(migrationProjectId string, sour
 	}
 }
 
+// cloudSQLDialer returns a Cloud SQL connector dialer, and the options to
+// dial instances with, for the Cloud SQL options opts.
+func cloudSQLDialer(opts profiles.CloudSQLOptions) (*cloudsqlconn.Dialer, []cloudsqlconn.DialOption, error) {
+	var dialerOpts []cloudsqlconn.Option
+	if opts.IAMAuth {
+		dialerOpts = append(dialerOpts, cloudsqlconn.WithIAMAuthN())
+	}
+	d, err := cloudsqlconn.NewDialer(context.Background(), dialerOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cloudsqlconn.NewDialer: %w", err)
+	}
+	var dialOpts []cloudsqlconn.DialOption
+	if opts.PrivateIP {
+		dialOpts = append(dialOpts, cloudsqlconn.WithPrivateIP())
+	}
+	return d, dialOpts, nil
+}
+
 func (gi *GetInfoImpl) GetInfoSchema(migrationProjectId string, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile) (common.InfoSchema, error) {
 	connectionConfig, err := ConnectionConfig(sourceProfile)
 	if err != nil {
@@ -147,20 +192,45 @@ func (gi *GetInfoImpl) GetInfoSchema(migrationProjectId string, sourceProfile pr
 	driver := sourceProfile.Driver
 	switch driver {
 	case constants.MYSQL:
-		db, err := sql.Open(driver, connectionConfig.(string))
+		db, err := openSQLDb(sourceProfile, connectionConfig.(string))
 		dbName := getDbNameFromSQLConnectionStr(driver, connectionConfig.(string))
 		if err != nil {
 			return nil, err
 		}
+		// TiDB is migrated through the MySQL path. If the version can't be
+		// read, the source is treated as MySQL.
+		tidb, err := mysql.IsTiDB(db)
+		if err != nil {
+			logger.Log.Debug(fmt.Sprintf("couldn't check whether the source is TiDB: %v", err))
+		}
+		aurora, err := auroraVersion(db, sourceProfile.Conn.Mysql.Aws, mysql.GetAuroraVersion)
+		if err != nil {
+			return nil, err
+		}
+		readerDb, err := openReaderDb(sourceProfile)
+		if err != nil {
+			return nil, err
+		}
 		return mysql.InfoSchemaImpl{
 			DbName:             dbName,
 			Db:                 db,
 			MigrationProjectId: migrationProjectId,
 			SourceProfile:      sourceProfile,
 			TargetProfile:      targetProfile,
+			TiDB:               tidb,
+			AuroraVersion:      aurora,
+			ReaderDb:           readerDb,
 		}, nil
 	case constants.POSTGRES:
-		db, err := sql.Open(driver, connectionConfig.(string))
+		db, err := openSQLDb(sourceProfile, connectionConfig.(string))
+		if err != nil {
+			return nil, err
+		}
+		aurora, err := auroraVersion(db, sourceProfile.Conn.Pg.Aws, postgres.GetAuroraVersion)
+		if err != nil {
+			return nil, err
+		}
+		readerDb, err := openReaderDb(sourceProfile)
 		if err != nil {
 			return nil, err
 		}
@@ -171,6 +241,8 @@ func (gi *GetInfoImpl) GetInfoSchema(migrationProjectId string, sourceProfile pr
 			SourceProfile:      sourceProfile,
 			TargetProfile:      targetProfile,
 			IsSchemaUnique:     &temp, //this is a workaround to set a bool pointer
+			AuroraVersion:      aurora,
+			ReaderDb:           readerDb,
 		}, nil
 	case constants.DYNAMODB:
 		mySession := session.Must(session.NewSession())
@@ -191,7 +263,7 @@ func (gi *GetInfoImpl) GetInfoSchema(migrationProjectId string, sourceProfile pr
 		if err != nil {
 			return nil, err
 		}
-		return sqlserver.InfoSchemaImpl{DbName: dbName, Db: db}, nil
+		return sqlserver.InfoSchemaImpl{DbName: dbName, Db: db, SourceProfile: sourceProfile}, nil
 	case constants.ORACLE:
 		db, err := sql.Open(driver, connectionConfig.(string))
 		dbName := getDbNameFromSQLConnectionStr(driver, connectionConfig.(string))
@@ -199,16 +271,185 @@ func (gi *GetInfoImpl) GetInfoSchema(migrationProjectId string, sourceProfile pr
 			return nil, err
 		}
 		return oracle.InfoSchemaImpl{DbName: strings.ToUpper(dbName), Db: db, MigrationProjectId: migrationProjectId, SourceProfile: sourceProfile, TargetProfile: targetProfile}, nil
+	case constants.SQLITE:
+		if err := sqlite.ValidateTypeAffinities(sourceProfile.Conn.SQLite.TypeAffinities); err != nil {
+			return nil, err
+		}
+		db, err := sql.Open(sqlite.DriverName, connectionConfig.(string))
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.InfoSchemaImpl{Db: db, TypeAffinities: sourceProfile.Conn.SQLite.TypeAffinities}, nil
+	case constants.MONGODB:
+		mongoConn := sourceProfile.Conn.MongoDB
+		if err := mongodb.ValidateStrategies(mongoConn.Strategy, mongoConn.CollectionStrategies); err != nil {
+			return nil, err
+		}
+		tlsConfig, err := mongoConn.TLS.Config()
+		if err != nil {
+			return nil, err
+		}
+		database, err := mongodb.NewDatabase(connectionConfig.(string), mongoConn.Db, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		return mongodb.InfoSchemaImpl{
+			Database:             database,
+			DbName:               mongoConn.Db,
+			SampleSize:           profiles.GetSchemaSampleSize(sourceProfile),
+			Strategy:             mongoConn.Strategy,
+			CollectionStrategies: mongoConn.CollectionStrategies,
+		}, nil
+	case constants.MARIADB:
+		// MariaDB is accessed with the MySQL driver.
+		db, err := sql.Open(constants.MYSQL, connectionConfig.(string))
+		if err != nil {
+			return nil, err
+		}
+		version, err := mariadb.GetVersion(db)
+		if err != nil {
+			return nil, err
+		}
+		return mariadb.InfoSchemaImpl{
+			InfoSchemaImpl: mysql.InfoSchemaImpl{
+				DbName:             sourceProfile.Conn.MariaDB.Db,
+				Db:                 db,
+				MigrationProjectId: migrationProjectId,
+				SourceProfile:      sourceProfile,
+				TargetProfile:      targetProfile,
+			},
+			Version: version,
+		}, nil
+	case constants.SNOWFLAKE:
+		db, err := sql.Open(snowflake.DriverName, connectionConfig.(string))
+		if err != nil {
+			return nil, err
+		}
+		sfConn := sourceProfile.Conn.Snowflake
+		isi := snowflake.InfoSchemaImpl{Db: db, Schemas: sfConn.Schemas}
+		if sfConn.UnloadUri != "" {
+			isi.Unload = &snowflake.UnloadConfig{
+				Uri:                sfConn.UnloadUri,
+				StorageIntegration: sfConn.StorageIntegration,
+				Format:             sfConn.UnloadFormat,
+			}
+		}
+		return isi, nil
+	case constants.REDSHIFT:
+		// Redshift is accessed with the PostgreSQL driver.
+		db, err := sql.Open(constants.POSTGRES, connectionConfig.(string))
+		if err != nil {
+			return nil, err
+		}
+		rsConn := sourceProfile.Conn.Redshift
+		temp := false
+		isi := redshift.InfoSchemaImpl{
+			InfoSchemaImpl: postgres.InfoSchemaImpl{
+				Db:                 db,
+				MigrationProjectId: migrationProjectId,
+				SourceProfile:      sourceProfile,
+				TargetProfile:      targetProfile,
+				IsSchemaUnique:     &temp, //this is a workaround to set a bool pointer
+			},
+		}
+		if rsConn.UnloadUri != "" {
+			isi.Unload = &redshift.UnloadConfig{Uri: rsConn.UnloadUri, IamRole: rsConn.IamRole}
+			isi.S3 = s3.New(session.Must(session.NewSession()))
+		}
+		return isi, nil
+	case constants.BIGQUERY:
+		bqConn := sourceProfile.Conn.BigQuery
+		dataset, err := bigquery.NewDataset(context.Background(), bqConn.Project, bqConn.Dataset, bqConn.Location)
+		if err != nil {
+			return nil, err
+		}
+		isi := bigquery.InfoSchemaImpl{
+			Dataset:   dataset,
+			Project:   bqConn.Project,
+			DatasetId: bqConn.Dataset,
+			Nested:    bqConn.Nested,
+		}
+		if bqConn.ExportUri != "" {
+			isi.Export = bigquery.NewExportConfig(bqConn.ExportUri)
+		}
+		return isi, nil
+	case constants.SYBASE:
+		db, err := sql.Open(sybase.DriverName, connectionConfig.(string))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't open Sybase ASE database, a FreeTDS compatible driver must be registered as %q: %w", sybase.DriverName, err)
+		}
+		return sybase.InfoSchemaImpl{DbName: sourceProfile.Conn.Sybase.Db, Db: db}, nil
+	case constants.PARQUET:
+		pqConn := sourceProfile.Conn.Parquet
+		fileSets, err := common.GetFileSets(context.Background(), pqConn.Dir, pqConn.Manifest, parquet.Extension)
+		if err != nil {
+			return nil, err
+		}
+		return parquet.InfoSchemaImpl{FileSets: fileSets}, nil
+	case constants.AVRO:
+		avroConn := sourceProfile.Conn.Avro
+		fileSets, err := common.GetFileSets(context.Background(), avroConn.Dir, avroConn.Manifest, avro.Extension)
+		if err != nil {
+			return nil, err
+		}
+		return avro.InfoSchemaImpl{FileSets: fileSets}, nil
+	case constants.ORC:
+		orcConn := sourceProfile.Conn.Orc
+		fileSets, err := common.GetFileSets(context.Background(), orcConn.Dir, orcConn.Manifest, orc.Extension)
+		if err != nil {
+			return nil, err
+		}
+		return orc.InfoSchemaImpl{FileSets: fileSets}, nil
+	case constants.EXCEL:
+		excelConn := sourceProfile.Conn.Excel
+		var columnTypes map[string]map[string]string
+		if excelConn.Manifest != "" {
+			columnTypes, err = excel.LoadManifest(context.Background(), excelConn.Manifest)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return excel.InfoSchemaImpl{Path: excelConn.Path, ColumnTypes: columnTypes}, nil
+	case constants.BACPAC:
+		isi, err := sqlserver.NewPackageInfoSchema(context.Background(), sourceProfile.Conn.Bacpac.Path)
+		if err != nil {
+			return nil, err
+		}
+		return isi, nil
 	case constants.CASSANDRA:
-		_, ksMetadata, err := ca.NewCassandraAccessor(sourceProfile)
+		cassandraConn := sourceProfile.Conn.Cassandra
+		if err := cassandra.ValidateUserTypeStrategies(cassandraConn.UserTypeStrategy, cassandraConn.UserTypeStrategies); err != nil {
+			return nil, err
+		}
+		accessor, ksMetadata, err := ca.NewCassandraAccessor(sourceProfile)
 		if err != nil {
 			return nil, err
 		}
 		return cassandra.InfoSchemaImpl{
-			KeyspaceMetadata: ksMetadata, 
+			KeyspaceMetadata: ksMetadata,
+			Client:           accessor.Client(),
 			SourceProfile:    sourceProfile,
 			TargetProfile:    targetProfile,
 		}, nil
+	case constants.SPANNER:
+		spConn := sourceProfile.Conn.Spanner
+		ctx := context.Background()
+		dbURI := fmt.Sprintf(constants.DB_URI, spConn.Project, spConn.Instance, spConn.Db)
+		spannerAccessor, err := spanneraccessor.NewSpannerAccessorClientImpl(ctx)
+		if err != nil {
+			return nil, err
+		}
+		dialect, err := spannerAccessor.GetDatabaseDialect(ctx, dbURI)
+		if err != nil {
+			return nil, fmt.Errorf("can't get the dialect of Spanner database %s: %w", dbURI, err)
+		}
+		// The client isn't shared, as the clients of the Spanner database
+		// migrated to are.
+		client, err := sp.NewClient(ctx, dbURI)
+		if err != nil {
+			return nil, fmt.Errorf("can't create client for Spanner database %s: %w", dbURI, err)
+		}
+		return spanner.InfoSchemaImpl{Client: client, Ctx: ctx, SpDialect: dialect}, nil
 	default:
 		return nil, fmt.Errorf("driver %s not supported", driver)
 	}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// LocalityGroupSpec declares a locality group to be created as part of the
+// target schema, along with the tables and columns stored in it, e.g. to keep
+// large archival tables on HDD storage. Tables and columns are referenced by
+// their Spanner names.
+type LocalityGroupSpec struct {
+	Name                  string
+	Storage               string
+	SsdToHddSpillTimespan string
+	Tables                []LocalityGroupTableSpec
+}
+
+// LocalityGroupTableSpec references a table placed in a locality group. When
+// Columns is empty, the whole table is placed in the locality group,
+// otherwise only the listed columns are.
+type LocalityGroupTableSpec struct {
+	Table   string
+	Columns []string
+}
+
+// ReadLocalityGroupsFile reads a JSON list of locality group specs and adds
+// the corresponding locality groups to conv.
+func ReadLocalityGroupsFile(conv *internal.Conv, localityGroupsJSON string) error {
+	s, err := os.ReadFile(localityGroupsJSON)
+	if err != nil {
+		return err
+	}
+	var specs []LocalityGroupSpec
+	if err = json.Unmarshal(s, &specs); err != nil {
+		return fmt.Errorf("can't parse locality groups file %s: %v", localityGroupsJSON, err)
+	}
+	return AddLocalityGroups(conv, specs)
+}
+
+// AddLocalityGroups resolves the table and column names of the locality group
+// specs against the Spanner schema, adds the locality groups to conv and
+// places the tables and columns in them.
+func AddLocalityGroups(conv *internal.Conv, specs []LocalityGroupSpec) error {
+	if conv.SpLocalityGroups == nil {
+		conv.SpLocalityGroups = make(map[string]ddl.LocalityGroup)
+	}
+	for _, spec := range specs {
+		lg := ddl.LocalityGroup{
+			Id:                    internal.GenerateLocalityGroupId(),
+			Name:                  spec.Name,
+			Storage:               strings.ToLower(spec.Storage),
+			SsdToHddSpillTimespan: spec.SsdToHddSpillTimespan,
+		}
+		if err := internal.ValidateLocalityGroup(conv.SpLocalityGroups, lg); err != nil {
+			return err
+		}
+		for _, t := range spec.Tables {
+			tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, t.Table)
+			if err != nil {
+				return fmt.Errorf("can't add locality group %s: %v", spec.Name, err)
+			}
+			ct := conv.SpSchema[tableId]
+			if len(t.Columns) == 0 {
+				ct.LocalityGroup = lg.Name
+			}
+			for _, col := range t.Columns {
+				colId, err := internal.GetColIdFromSpName(ct.ColDefs, col)
+				if err != nil {
+					return fmt.Errorf("can't add locality group %s: %v", spec.Name, err)
+				}
+				cd := ct.ColDefs[colId]
+				if cd.Opts == nil {
+					cd.Opts = map[string]string{}
+				}
+				cd.Opts[ddl.LocalityGroupOpt] = lg.Name
+				ct.ColDefs[colId] = cd
+			}
+			conv.SpSchema[tableId] = ct
+		}
+		conv.SpLocalityGroups[lg.Id] = lg
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func localityGroupTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "orders_archive",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "payload", Id: "c2", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			},
+		},
+		"t2": {
+			Name:   "orders",
+			Id:     "t2",
+			ColIds: []string{"c3", "c4"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c3": {Name: "id", Id: "c3", T: ddl.Type{Name: ddl.Int64}},
+				"c4": {Name: "receipt", Id: "c4", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			},
+		},
+	}
+	return conv
+}
+
+func TestAddLocalityGroups(t *testing.T) {
+	tests := []struct {
+		name          string
+		specs         []LocalityGroupSpec
+		expectError   bool
+		expectedCount int
+	}{
+		{
+			name: "tables and columns",
+			specs: []LocalityGroupSpec{{
+				Name:    "archive",
+				Storage: "HDD",
+				Tables:  []LocalityGroupTableSpec{{Table: "orders_archive"}, {Table: "orders", Columns: []string{"receipt"}}},
+			}},
+			expectedCount: 1,
+		},
+		{
+			name:          "tiered storage",
+			specs:         []LocalityGroupSpec{{Name: "recent", Storage: "ssd", SsdToHddSpillTimespan: "30d"}},
+			expectedCount: 1,
+		},
+		{
+			name:        "unknown table",
+			specs:       []LocalityGroupSpec{{Name: "archive", Storage: "hdd", Tables: []LocalityGroupTableSpec{{Table: "customers"}}}},
+			expectError: true,
+		},
+		{
+			name:        "unknown column",
+			specs:       []LocalityGroupSpec{{Name: "archive", Storage: "hdd", Tables: []LocalityGroupTableSpec{{Table: "orders", Columns: []string{"total"}}}}},
+			expectError: true,
+		},
+		{
+			name:        "unsupported storage",
+			specs:       []LocalityGroupSpec{{Name: "archive", Storage: "tape"}},
+			expectError: true,
+		},
+		{
+			name:          "duplicate name",
+			specs:         []LocalityGroupSpec{{Name: "archive", Storage: "hdd"}, {Name: "Archive", Storage: "ssd"}},
+			expectError:   true,
+			expectedCount: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conv := localityGroupTestConv()
+			err := AddLocalityGroups(conv, tc.specs)
+			assert.Equal(t, tc.expectError, err != nil)
+			assert.Equal(t, tc.expectedCount, len(conv.SpLocalityGroups))
+		})
+	}
+}
+
+func TestReadLocalityGroupsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locality_groups.json")
+	content := `[{"Name": "archive", "Storage": "hdd", "Tables": [{"Table": "orders_archive"}, {"Table": "orders", "Columns": ["receipt"]}]}]`
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	conv := localityGroupTestConv()
+	assert.Nil(t, ReadLocalityGroupsFile(conv, path))
+	assert.Equal(t, 1, len(conv.SpLocalityGroups))
+	for _, lg := range conv.SpLocalityGroups {
+		assert.Equal(t, "archive", lg.Name)
+		assert.Equal(t, "hdd", lg.Storage)
+	}
+	assert.Equal(t, "archive", conv.SpSchema["t1"].LocalityGroup)
+	assert.Equal(t, "", conv.SpSchema["t2"].LocalityGroup)
+	assert.Equal(t, "archive", conv.SpSchema["t2"].ColDefs["c4"].Opts[ddl.LocalityGroupOpt])
+}
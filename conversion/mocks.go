@@ -86,6 +86,10 @@ func (msads *MockDataFromSource) dataFromCSV(ctx context.Context, sourceProfile
 	args := msads.Called(ctx, sourceProfile, targetProfile, config, conv, client, pdc, csv)
 	return args.Get(0).(*writer.BatchWriter), args.Error(1)
 }
+func (msads *MockDataFromSource) dataFromSpanner(ctx context.Context, migrationProjectId string, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile, conv *internal.Conv) (*writer.BatchWriter, error) {
+	args := msads.Called(ctx, migrationProjectId, sourceProfile, targetProfile, conv)
+	return args.Get(0).(*writer.BatchWriter), args.Error(1)
+}
 
 type MockValidateOrCreateResources struct {
 	mock.Mock
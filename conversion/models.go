@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ModelSpec declares a remote Vertex AI model to be created as part of the
+// target schema, e.g. for queries using ML.PREDICT. When Inputs and Outputs
+// are empty, Spanner reads the model columns from the endpoint.
+type ModelSpec struct {
+	Name             string
+	Inputs           []ModelColumnSpec
+	Outputs          []ModelColumnSpec
+	Endpoint         string
+	Endpoints        []string
+	DefaultBatchSize int64
+}
+
+// ModelColumnSpec declares an input or output column of a model. Type is a
+// GoogleSQL type, e.g. STRING(MAX) or ARRAY<FLOAT32>.
+type ModelColumnSpec struct {
+	Name     string
+	Type     string
+	Optional bool
+}
+
+// ReadModelsFile reads a JSON list of model specs and adds the corresponding
+// models to conv.
+func ReadModelsFile(conv *internal.Conv, modelsJSON string) error {
+	s, err := os.ReadFile(modelsJSON)
+	if err != nil {
+		return err
+	}
+	var specs []ModelSpec
+	if err = json.Unmarshal(s, &specs); err != nil {
+		return fmt.Errorf("can't parse models file %s: %v", modelsJSON, err)
+	}
+	return AddModels(conv, specs)
+}
+
+// AddModels adds the models of the specs to conv. Models are only supported
+// by GoogleSQL databases.
+func AddModels(conv *internal.Conv, specs []ModelSpec) error {
+	if len(specs) > 0 && conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Errorf("models are not supported by PostgreSQL dialect databases")
+	}
+	if conv.SpModels == nil {
+		conv.SpModels = make(map[string]ddl.Model)
+	}
+	for _, spec := range specs {
+		if _, found := conv.UsedNames[strings.ToLower(spec.Name)]; found {
+			return fmt.Errorf("model name %s is used by another entity", spec.Name)
+		}
+		m := ddl.Model{
+			Id:               internal.GenerateModelId(),
+			Name:             spec.Name,
+			Endpoint:         spec.Endpoint,
+			Endpoints:        spec.Endpoints,
+			DefaultBatchSize: spec.DefaultBatchSize,
+		}
+		var err error
+		if m.Inputs, err = toModelColumns(spec.Inputs); err != nil {
+			return fmt.Errorf("can't add model %s: %v", spec.Name, err)
+		}
+		if m.Outputs, err = toModelColumns(spec.Outputs); err != nil {
+			return fmt.Errorf("can't add model %s: %v", spec.Name, err)
+		}
+		if err := internal.ValidateModel(conv.SpModels, m); err != nil {
+			return err
+		}
+		conv.SpModels[m.Id] = m
+		conv.UsedNames[strings.ToLower(m.Name)] = true
+	}
+	return nil
+}
+
+func toModelColumns(specs []ModelColumnSpec) ([]ddl.ModelColumn, error) {
+	var cols []ddl.ModelColumn
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("model column name is empty")
+		}
+		ty, err := ddl.ParseGoogleSQLType(spec.Type)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, ddl.ModelColumn{Name: spec.Name, T: ty, Optional: spec.Optional})
+	}
+	return cols, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddModels(t *testing.T) {
+	endpoint := "//aiplatform.googleapis.com/projects/p/locations/us-central1/endpoints/e"
+	tests := []struct {
+		name          string
+		dialect       string
+		specs         []ModelSpec
+		expectError   bool
+		expectedCount int
+	}{
+		{
+			name: "input and output columns",
+			specs: []ModelSpec{{
+				Name:     "sentiment",
+				Inputs:   []ModelColumnSpec{{Name: "text", Type: "STRING(MAX)"}},
+				Outputs:  []ModelColumnSpec{{Name: "score", Type: "FLOAT64"}},
+				Endpoint: endpoint,
+			}},
+			expectedCount: 1,
+		},
+		{
+			name:          "endpoints only",
+			specs:         []ModelSpec{{Name: "embeddings", Endpoints: []string{endpoint, endpoint}}},
+			expectedCount: 1,
+		},
+		{
+			name:        "missing endpoint",
+			specs:       []ModelSpec{{Name: "m"}},
+			expectError: true,
+		},
+		{
+			name:        "endpoint and endpoints",
+			specs:       []ModelSpec{{Name: "m", Endpoint: endpoint, Endpoints: []string{endpoint}}},
+			expectError: true,
+		},
+		{
+			name:        "inputs without outputs",
+			specs:       []ModelSpec{{Name: "m", Inputs: []ModelColumnSpec{{Name: "text", Type: "STRING(MAX)"}}, Endpoint: endpoint}},
+			expectError: true,
+		},
+		{
+			name:        "invalid column type",
+			specs:       []ModelSpec{{Name: "m", Inputs: []ModelColumnSpec{{Name: "text", Type: "VARCHAR"}}, Outputs: []ModelColumnSpec{{Name: "score", Type: "FLOAT64("}}, Endpoint: endpoint}},
+			expectError: true,
+		},
+		{
+			name:        "name already used",
+			specs:       []ModelSpec{{Name: "Orders", Endpoint: endpoint}},
+			expectError: true,
+		},
+		{
+			name:        "postgresql dialect",
+			dialect:     constants.DIALECT_POSTGRESQL,
+			specs:       []ModelSpec{{Name: "m", Endpoint: endpoint}},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conv := changeStreamTestConv()
+			conv.SpDialect = tc.dialect
+			err := AddModels(conv, tc.specs)
+			assert.Equal(t, tc.expectError, err != nil)
+			assert.Equal(t, tc.expectedCount, len(conv.SpModels))
+		})
+	}
+}
+
+func TestReadModelsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "models.json")
+	content := `[{"Name": "sentiment", "Inputs": [{"Name": "text", "Type": "STRING(MAX)"}], "Outputs": [{"Name": "label", "Type": "STRING(MAX)", "Optional": true}], "Endpoint": "//aiplatform.googleapis.com/e", "DefaultBatchSize": 4}]`
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	conv := changeStreamTestConv()
+	assert.Nil(t, ReadModelsFile(conv, path))
+	assert.Equal(t, 1, len(conv.SpModels))
+	for _, m := range conv.SpModels {
+		assert.Equal(t, "sentiment", m.Name)
+		assert.Equal(t, []ddl.ModelColumn{{Name: "text", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}}, m.Inputs)
+		assert.Equal(t, []ddl.ModelColumn{{Name: "label", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, Optional: true}}, m.Outputs)
+		assert.Equal(t, "//aiplatform.googleapis.com/e", m.Endpoint)
+		assert.Equal(t, int64(4), m.DefaultBatchSize)
+	}
+	assert.True(t, conv.UsedNames["sentiment"])
+}
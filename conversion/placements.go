@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// PlacementsSpec declares the placements of a geo-partitioned target schema,
+// e.g. one per regional source shard, and the placement key columns of the
+// tables whose rows are distributed across them. Tables and columns are
+// referenced by their Spanner names.
+type PlacementsSpec struct {
+	Placements    []PlacementSpec
+	PlacementKeys []PlacementKeySpec
+}
+
+// PlacementSpec declares a placement to be created as part of the target schema.
+type PlacementSpec struct {
+	Name              string
+	InstancePartition string
+	DefaultLeader     string
+}
+
+// PlacementKeySpec nominates the placement key column of a table.
+type PlacementKeySpec struct {
+	Table  string
+	Column string
+}
+
+// ReadPlacementsFile reads a JSON placements spec and adds the corresponding
+// placements and placement keys to conv.
+func ReadPlacementsFile(conv *internal.Conv, placementsJSON string) error {
+	s, err := os.ReadFile(placementsJSON)
+	if err != nil {
+		return err
+	}
+	var spec PlacementsSpec
+	if err = json.Unmarshal(s, &spec); err != nil {
+		return fmt.Errorf("can't parse placements file %s: %v", placementsJSON, err)
+	}
+	return AddPlacements(conv, spec)
+}
+
+// AddPlacements adds the placements of the spec to conv and sets the
+// placement keys of the tables it references.
+func AddPlacements(conv *internal.Conv, spec PlacementsSpec) error {
+	if conv.SpPlacements == nil {
+		conv.SpPlacements = make(map[string]ddl.Placement)
+	}
+	for _, ps := range spec.Placements {
+		if ps.Name == "" {
+			return fmt.Errorf("placement name is empty")
+		}
+		for _, p := range conv.SpPlacements {
+			if strings.EqualFold(p.Name, ps.Name) {
+				return fmt.Errorf("placement name %s is already used", ps.Name)
+			}
+		}
+		p := ddl.Placement{
+			Id:                internal.GeneratePlacementId(),
+			Name:              ps.Name,
+			InstancePartition: ps.InstancePartition,
+			DefaultLeader:     ps.DefaultLeader,
+		}
+		conv.SpPlacements[p.Id] = p
+	}
+	for _, pk := range spec.PlacementKeys {
+		tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, pk.Table)
+		if err != nil {
+			return fmt.Errorf("can't set placement key: %v", err)
+		}
+		colId, err := internal.GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, pk.Column)
+		if err != nil {
+			return fmt.Errorf("can't set placement key: %v", err)
+		}
+		if err := internal.SetPlacementKey(conv, tableId, colId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func placementTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "customers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Name: "region", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+			},
+		},
+		"t2": {
+			Name:   "orders",
+			Id:     "t2",
+			ColIds: []string{"c3", "c4"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c3": {Name: "id", Id: "c3", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c4": {Name: "region", Id: "c4", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+			},
+			ParentTable: ddl.InterleavedParent{Id: "t1"},
+		},
+	}
+	return conv
+}
+
+func TestAddPlacements(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          PlacementsSpec
+		expectError   bool
+		expectedCount int
+	}{
+		{
+			name: "placements and placement key",
+			spec: PlacementsSpec{
+				Placements:    []PlacementSpec{{Name: "europe", InstancePartition: "europe-partition", DefaultLeader: "europe-west1"}, {Name: "asia", InstancePartition: "asia-partition"}},
+				PlacementKeys: []PlacementKeySpec{{Table: "customers", Column: "region"}},
+			},
+			expectedCount: 2,
+		},
+		{
+			name:          "duplicate name",
+			spec:          PlacementsSpec{Placements: []PlacementSpec{{Name: "europe"}, {Name: "Europe"}}},
+			expectError:   true,
+			expectedCount: 1,
+		},
+		{
+			name:        "unknown column",
+			spec:        PlacementsSpec{PlacementKeys: []PlacementKeySpec{{Table: "customers", Column: "country"}}},
+			expectError: true,
+		},
+		{
+			name:        "not a string column",
+			spec:        PlacementsSpec{PlacementKeys: []PlacementKeySpec{{Table: "customers", Column: "id"}}},
+			expectError: true,
+		},
+		{
+			name:        "interleaved table",
+			spec:        PlacementsSpec{PlacementKeys: []PlacementKeySpec{{Table: "orders", Column: "region"}}},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conv := placementTestConv()
+			err := AddPlacements(conv, tc.spec)
+			assert.Equal(t, tc.expectError, err != nil)
+			assert.Equal(t, tc.expectedCount, len(conv.SpPlacements))
+		})
+	}
+}
+
+func TestReadPlacementsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "placements.json")
+	content := `{"Placements": [{"Name": "europe", "InstancePartition": "europe-partition"}], "PlacementKeys": [{"Table": "customers", "Column": "region"}]}`
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	conv := placementTestConv()
+	assert.Nil(t, ReadPlacementsFile(conv, path))
+	assert.Equal(t, 1, len(conv.SpPlacements))
+	for _, p := range conv.SpPlacements {
+		assert.Equal(t, "europe", p.Name)
+		assert.Equal(t, "europe-partition", p.InstancePartition)
+	}
+	assert.Equal(t, "c2", conv.SpSchema["t1"].PlacementKey)
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ProposePropertyGraph proposes a property graph named name over the
+// Spanner schema of conv, derived from its foreign keys:
+//   - a table whose primary key is made of the columns of exactly two
+//     foreign keys, e.g. a many-to-many junction table, becomes an edge
+//     between the two referenced tables;
+//   - any other foreign key becomes an edge, named after the foreign key,
+//     from the referencing table to the referenced table;
+//   - the tables at either end of an edge become nodes.
+//
+// Only foreign keys referencing the primary key of a table are used, as
+// edges reference nodes by their key. Nodes and edges expose all columns
+// under their default label.
+func ProposePropertyGraph(conv *internal.Conv, name string) ddl.PropertyGraph {
+	pg := ddl.PropertyGraph{Id: internal.GeneratePropertyGraphId(), Name: name}
+	nodes := make(map[string]bool)
+	edgeNames := make(map[string]bool)
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		ct := conv.SpSchema[tableId]
+		var fks []ddl.Foreignkey
+		for _, fk := range ct.ForeignKeys {
+			if referencesPrimaryKey(conv.SpSchema, fk) {
+				fks = append(fks, fk)
+			}
+		}
+		if len(fks) == 2 && len(ct.ForeignKeys) == 2 && sameColumns(primaryKeyColIds(ct), append(append([]string{}, fks[0].ColIds...), fks[1].ColIds...)) {
+			pg.EdgeTables = append(pg.EdgeTables, ddl.GraphElementTable{
+				TableId:     tableId,
+				Source:      ddl.GraphNodeReference{ColIds: fks[0].ColIds, NodeTableId: fks[0].ReferTableId, NodeColIds: fks[0].ReferColumnIds},
+				Destination: ddl.GraphNodeReference{ColIds: fks[1].ColIds, NodeTableId: fks[1].ReferTableId, NodeColIds: fks[1].ReferColumnIds},
+			})
+			edgeNames[strings.ToLower(ct.Name)] = true
+			nodes[fks[0].ReferTableId], nodes[fks[1].ReferTableId] = true, true
+			continue
+		}
+		for _, fk := range fks {
+			pg.EdgeTables = append(pg.EdgeTables, ddl.GraphElementTable{
+				TableId:     tableId,
+				Alias:       fkEdgeName(conv.SpSchema, ct, fk, edgeNames),
+				Source:      ddl.GraphNodeReference{ColIds: primaryKeyColIds(ct), NodeTableId: tableId, NodeColIds: primaryKeyColIds(ct)},
+				Destination: ddl.GraphNodeReference{ColIds: fk.ColIds, NodeTableId: fk.ReferTableId, NodeColIds: fk.ReferColumnIds},
+			})
+			nodes[tableId], nodes[fk.ReferTableId] = true, true
+		}
+	}
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		if nodes[tableId] {
+			pg.NodeTables = append(pg.NodeTables, ddl.GraphElementTable{TableId: tableId})
+		}
+	}
+	// Junction tables that are also nodes need another name as edges.
+	for i, e := range pg.EdgeTables {
+		if e.Alias == "" && nodes[e.TableId] {
+			pg.EdgeTables[i].Alias = uniqueElementName(conv.SpSchema, conv.SpSchema[e.TableId].Name+"_edge", edgeNames)
+		}
+	}
+	return pg
+}
+
+// AddProposedPropertyGraph adds the property graph proposed by
+// ProposePropertyGraph to conv. Property graphs are only supported by
+// GoogleSQL databases.
+func AddProposedPropertyGraph(conv *internal.Conv, name string) error {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Errorf("property graphs are not supported by PostgreSQL dialect databases")
+	}
+	if err := ddl.ValidateIdentifier(name); err != nil {
+		return fmt.Errorf("invalid property graph name: %v", err)
+	}
+	if _, found := conv.UsedNames[strings.ToLower(name)]; found {
+		return fmt.Errorf("property graph name %s is used by another entity", name)
+	}
+	pg := ProposePropertyGraph(conv, name)
+	if len(pg.EdgeTables) == 0 {
+		return fmt.Errorf("no foreign keys referencing primary keys found to derive property graph %s from", name)
+	}
+	if conv.SpPropertyGraphs == nil {
+		conv.SpPropertyGraphs = make(map[string]ddl.PropertyGraph)
+	}
+	conv.SpPropertyGraphs[pg.Id] = pg
+	conv.UsedNames[strings.ToLower(name)] = true
+	return nil
+}
+
+// fkEdgeName returns the name of the edge of foreign key fk of table ct: the
+// foreign key name or, for unnamed foreign keys, the names of the tables it
+// relates.
+func fkEdgeName(spSchema ddl.Schema, ct ddl.CreateTable, fk ddl.Foreignkey, used map[string]bool) string {
+	name := fk.Name
+	if name == "" {
+		name = ct.Name + "_" + spSchema[fk.ReferTableId].Name
+	}
+	return uniqueElementName(spSchema, name, used)
+}
+
+// uniqueElementName returns name, with a numeric suffix if needed to make it
+// unique among the element names of the graph, i.e. the table names and the
+// edge names already used.
+func uniqueElementName(spSchema ddl.Schema, name string, used map[string]bool) string {
+	taken := func(n string) bool {
+		_, err := internal.GetTableIdFromSpName(spSchema, n)
+		return used[strings.ToLower(n)] || err == nil
+	}
+	for i, base := 1, name; taken(name); i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+func referencesPrimaryKey(spSchema ddl.Schema, fk ddl.Foreignkey) bool {
+	refTable, ok := spSchema[fk.ReferTableId]
+	return ok && len(fk.ColIds) == len(fk.ReferColumnIds) && sameColumns(primaryKeyColIds(refTable), fk.ReferColumnIds)
+}
+
+func primaryKeyColIds(ct ddl.CreateTable) []string {
+	pks := append([]ddl.IndexKey{}, ct.PrimaryKeys...)
+	sort.SliceStable(pks, func(i, j int) bool { return pks[i].Order < pks[j].Order })
+	var colIds []string
+	for _, pk := range pks {
+		colIds = append(colIds, pk.ColId)
+	}
+	return colIds
+}
+
+// sameColumns reports whether a and b hold the same column ids, in any order.
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) || len(a) == 0 {
+		return false
+	}
+	seen := make(map[string]int)
+	for _, id := range a {
+		seen[id]++
+	}
+	for _, id := range b {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func propertyGraphTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	intCol := func(id, name string) ddl.ColumnDef {
+		return ddl.ColumnDef{Name: name, Id: id, T: ddl.Type{Name: ddl.Int64}}
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:        "students",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			ColDefs:     map[string]ddl.ColumnDef{"c1": intCol("c1", "id"), "c2": intCol("c2", "advisor_id")},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "fk_advisor", ColIds: []string{"c2"}, ReferTableId: "t3", ReferColumnIds: []string{"c5"}}},
+		},
+		"t2": {
+			Name:        "enrollments",
+			Id:          "t2",
+			ColIds:      []string{"c3", "c4"},
+			ColDefs:     map[string]ddl.ColumnDef{"c3": intCol("c3", "student_id"), "c4": intCol("c4", "course_id")},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c3", Order: 1}, {ColId: "c4", Order: 2}},
+			ForeignKeys: []ddl.Foreignkey{
+				{Name: "fk_student", ColIds: []string{"c3"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}},
+				{Name: "fk_course", ColIds: []string{"c4"}, ReferTableId: "t4", ReferColumnIds: []string{"c7"}},
+			},
+		},
+		"t3": {
+			Name:        "professors",
+			Id:          "t3",
+			ColIds:      []string{"c5", "c6"},
+			ColDefs:     map[string]ddl.ColumnDef{"c5": intCol("c5", "id"), "c6": intCol("c6", "badge")},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c5", Order: 1}},
+		},
+		"t4": {
+			Name:        "courses",
+			Id:          "t4",
+			ColIds:      []string{"c7", "c8"},
+			ColDefs:     map[string]ddl.ColumnDef{"c7": intCol("c7", "id"), "c8": intCol("c8", "badge")},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c7", Order: 1}},
+			// Doesn't reference a primary key, so it isn't an edge.
+			ForeignKeys: []ddl.Foreignkey{{Name: "fk_badge", ColIds: []string{"c8"}, ReferTableId: "t3", ReferColumnIds: []string{"c6"}}},
+		},
+		"t5": {
+			Name:        "rooms",
+			Id:          "t5",
+			ColIds:      []string{"c9"},
+			ColDefs:     map[string]ddl.ColumnDef{"c9": intCol("c9", "id")},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c9", Order: 1}},
+		},
+	}
+	conv.UsedNames = internal.ComputeUsedNames(conv)
+	return conv
+}
+
+func TestProposePropertyGraph(t *testing.T) {
+	logger.Log = zap.NewNop()
+	conv := propertyGraphTestConv()
+	pg := ProposePropertyGraph(conv, "school")
+	assert.Equal(t, "school", pg.Name)
+	assert.Equal(t, []ddl.GraphElementTable{{TableId: "t4"}, {TableId: "t3"}, {TableId: "t1"}}, pg.NodeTables)
+	assert.Equal(t, []ddl.GraphElementTable{
+		{
+			TableId:     "t2",
+			Source:      ddl.GraphNodeReference{ColIds: []string{"c3"}, NodeTableId: "t1", NodeColIds: []string{"c1"}},
+			Destination: ddl.GraphNodeReference{ColIds: []string{"c4"}, NodeTableId: "t4", NodeColIds: []string{"c7"}},
+		},
+		{
+			TableId:     "t1",
+			Alias:       "fk_advisor",
+			Source:      ddl.GraphNodeReference{ColIds: []string{"c1"}, NodeTableId: "t1", NodeColIds: []string{"c1"}},
+			Destination: ddl.GraphNodeReference{ColIds: []string{"c2"}, NodeTableId: "t3", NodeColIds: []string{"c5"}},
+		},
+	}, pg.EdgeTables)
+}
+
+func TestAddProposedPropertyGraph(t *testing.T) {
+	logger.Log = zap.NewNop()
+	conv := propertyGraphTestConv()
+	assert.Nil(t, AddProposedPropertyGraph(conv, "school"))
+	assert.Equal(t, 1, len(conv.SpPropertyGraphs))
+	assert.True(t, conv.UsedNames["school"])
+	assert.NotNil(t, AddProposedPropertyGraph(conv, "School"))
+	assert.NotNil(t, AddProposedPropertyGraph(conv, "students"))
+
+	conv = propertyGraphTestConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	assert.NotNil(t, AddProposedPropertyGraph(conv, "school"))
+
+	conv = propertyGraphTestConv()
+	for id, ct := range conv.SpSchema {
+		ct.ForeignKeys = nil
+		conv.SpSchema[id] = ct
+	}
+	assert.NotNil(t, AddProposedPropertyGraph(conv, "school"))
+	assert.Empty(t, conv.SpPropertyGraphs)
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+var protoPackageRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+var nonProtoIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// MapEnumsToProto maps the Spanner columns converted from source ENUM columns
+// onto proto enums in protoPackage, instead of STRING. The enum for a column
+// is named <table>_<column>. Proto types are only supported by the GoogleSQL
+// dialect.
+func MapEnumsToProto(conv *internal.Conv, protoPackage string) error {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Errorf("proto enums are not supported for the PostgreSQL dialect")
+	}
+	if !protoPackageRegex.MatchString(protoPackage) {
+		return fmt.Errorf("invalid proto package name %q", protoPackage)
+	}
+	for tableId, ct := range conv.SpSchema {
+		srcTable, ok := conv.SrcSchema[tableId]
+		if !ok {
+			continue
+		}
+		for _, colId := range ct.ColIds {
+			srcCol, ok := srcTable.ColDefs[colId]
+			spCol := ct.ColDefs[colId]
+			if !ok || srcCol.Type.Name != "enum" || len(srcCol.EnumValues) == 0 || spCol.T.Name != ddl.String || spCol.T.IsArray {
+				continue
+			}
+			spCol.T = ddl.Type{Name: ddl.Enum, ProtoName: protoPackage + "." + protoEnumName(ct.Name, spCol.Name)}
+			ct.ColDefs[colId] = spCol
+		}
+	}
+	return nil
+}
+
+// GetProtoEnumDefinitions returns a .proto file declaring the proto enums that
+// source ENUM columns were mapped to by MapEnumsToProto. The enum values are
+// numbered from 1 in the order of the source ENUM values, which is how data
+// is converted; 0 is reserved for the unspecified value.
+func GetProtoEnumDefinitions(conv *internal.Conv, protoPackage string) string {
+	var sb strings.Builder
+	sb.WriteString("syntax = \"proto3\";\n\n")
+	sb.WriteString(fmt.Sprintf("package %s;\n", protoPackage))
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		ct := conv.SpSchema[tableId]
+		for _, colId := range ct.ColIds {
+			spCol := ct.ColDefs[colId]
+			if spCol.T.Name != ddl.Enum || !strings.HasPrefix(spCol.T.ProtoName, protoPackage+".") {
+				continue
+			}
+			srcCol := conv.SrcSchema[tableId].ColDefs[colId]
+			enumName := strings.TrimPrefix(spCol.T.ProtoName, protoPackage+".")
+			prefix := strings.ToUpper(enumName)
+			sb.WriteString(fmt.Sprintf("\nenum %s {\n", enumName))
+			sb.WriteString(fmt.Sprintf("  %s_UNSPECIFIED = 0;\n", prefix))
+			for i, v := range srcCol.EnumValues {
+				sb.WriteString(fmt.Sprintf("  %s_%s = %d;\n", prefix, strings.ToUpper(nonProtoIdentifierChars.ReplaceAllString(v, "_")), i+1))
+			}
+			sb.WriteString("}\n")
+		}
+	}
+	return sb.String()
+}
+
+// WriteProtoEnumsFile writes the .proto file returned by
+// GetProtoEnumDefinitions to name.
+func WriteProtoEnumsFile(conv *internal.Conv, protoPackage, name string) error {
+	return os.WriteFile(name, []byte(GetProtoEnumDefinitions(conv, protoPackage)), 0644)
+}
+
+// ReadProtoDescriptorsFile reads the serialized FileDescriptorSet for the
+// proto types used by PROTO and ENUM columns. The descriptors are sent to
+// Spanner along with the CREATE PROTO BUNDLE statement.
+func ReadProtoDescriptorsFile(conv *internal.Conv, name string) error {
+	descriptors, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("can't read proto descriptors file %s: %v", name, err)
+	}
+	conv.ProtoDescriptors = descriptors
+	return nil
+}
+
+func protoEnumName(tableName, colName string) string {
+	return nonProtoIdentifierChars.ReplaceAllString(tableName+"_"+colName, "_")
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func protoEnumTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "status", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c3": {Name: "note", Id: "c3", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+		},
+	}
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "int"}},
+				"c2": {Name: "status", Id: "c2", Type: schema.Type{Name: "enum"}, EnumValues: []string{"new", "in-transit"}},
+				"c3": {Name: "note", Id: "c3", Type: schema.Type{Name: "varchar"}},
+			},
+		},
+	}
+	return conv
+}
+
+func TestMapEnumsToProto(t *testing.T) {
+	logger.Log = zap.NewNop()
+	conv := protoEnumTestConv()
+	assert.Nil(t, MapEnumsToProto(conv, "shop.v1"))
+	assert.Equal(t, ddl.Type{Name: ddl.Enum, ProtoName: "shop.v1.orders_status"}, conv.SpSchema["t1"].ColDefs["c2"].T)
+	assert.Equal(t, ddl.String, conv.SpSchema["t1"].ColDefs["c3"].T.Name)
+
+	expected := "syntax = \"proto3\";\n\n" +
+		"package shop.v1;\n\n" +
+		"enum orders_status {\n" +
+		"  ORDERS_STATUS_UNSPECIFIED = 0;\n" +
+		"  ORDERS_STATUS_NEW = 1;\n" +
+		"  ORDERS_STATUS_IN_TRANSIT = 2;\n" +
+		"}\n"
+	assert.Equal(t, expected, GetProtoEnumDefinitions(conv, "shop.v1"))
+}
+
+func TestMapEnumsToProtoErrors(t *testing.T) {
+	conv := protoEnumTestConv()
+	assert.NotNil(t, MapEnumsToProto(conv, "shop..v1"))
+
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	assert.NotNil(t, MapEnumsToProto(conv, "shop.v1"))
+	assert.Equal(t, ddl.String, conv.SpSchema["t1"].ColDefs["c2"].T.Name)
+}
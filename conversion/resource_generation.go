@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -139,7 +140,10 @@ func (r ResourceGenerationImpl) PrepareMinimalDowntimeResources(createResourceDa
 	createResourceData.ConnectionProfile.BucketName = bucketName
 
 	// Set Profile for resource creation
-	setConnectionProfileFromRequest(createResourceData, req)
+	if err := setConnectionProfileFromRequest(createResourceData, req); err != nil {
+		createResourceData.Error = err
+		return task.TaskResult[*ConnectionProfileReq]{Result: createResourceData, Err: err}
+	}
 
 	// Create or Validate Resource
 	_, err := r.DsAcc.CreateConnectionProfile(createResourceData.Ctx, r.DsClient, req)
@@ -309,6 +313,7 @@ func getSourceConnectionProfileForCreation(ctx context.Context, projectId string
 				Password:     profile.SrcConnectionProfile.Password,
 				User:         profile.SrcConnectionProfile.User,
 				Region:       profile.SrcConnectionProfile.Location,
+				SSHTunnel:    profile.SrcConnectionProfile.SSHTunnel,
 				ValidateOnly: validateOnly},
 			Ctx: ctx,
 		}
@@ -369,6 +374,13 @@ func setConnectionProfileFromRequest(details *ConnectionProfileReq, req *datastr
 				Password: details.ConnectionProfile.Password,
 			},
 		}
+		if ssh := details.ConnectionProfile.SSHTunnel; ssh != nil && ssh.Host != "" {
+			connectivity, err := forwardSshConnectivity(*ssh)
+			if err != nil {
+				return err
+			}
+			req.ConnectionProfile.Connectivity = connectivity
+		}
 	} else {
 		req.ConnectionProfile.Profile = &datastreampb.ConnectionProfile_GcsProfile{
 			GcsProfile: &datastreampb.GcsProfile{
@@ -381,6 +393,32 @@ func setConnectionProfileFromRequest(details *ConnectionProfileReq, req *datastr
 	return nil
 }
 
+// Returns the connectivity of connection profiles reached through the SSH
+// tunnel ssh. Datastream authenticates with the private key of the tunnel.
+func forwardSshConnectivity(ssh profiles.SSHOptions) (*datastreampb.ConnectionProfile_ForwardSshConnectivity, error) {
+	if ssh.KeyFile == "" {
+		return nil, fmt.Errorf("please specify the keyFile of the SSH tunnel of %s for Datastream", ssh.Host)
+	}
+	key, err := os.ReadFile(ssh.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the private key of the SSH tunnel: %w", err)
+	}
+	port := int64(22)
+	if ssh.Port != "" {
+		if port, err = strconv.ParseInt(ssh.Port, 10, 32); err != nil {
+			return nil, err
+		}
+	}
+	return &datastreampb.ConnectionProfile_ForwardSshConnectivity{
+		ForwardSshConnectivity: &datastreampb.ForwardSshTunnelConnectivity{
+			Hostname:             ssh.Host,
+			Username:             ssh.User,
+			Port:                 int32(port),
+			AuthenticationMethod: &datastreampb.ForwardSshTunnelConnectivity_PrivateKey{PrivateKey: string(key)},
+		},
+	}, nil
+}
+
 // Clubs multiple errors into one error
 func mergeError(errorMessages []error) error {
 	var errorStrings []string
@@ -17,6 +17,8 @@ package conversion_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -231,6 +233,12 @@ func TestPrepareMinimalDowntimeResources(t *testing.T) {
 		},
 		Ctx: ctx,
 	}
+	keyFile := filepath.Join(t.TempDir(), "id_ed25519")
+	assert.Nil(t, os.WriteFile(keyFile, []byte("private-key"), 0600))
+	sshConnectionProfileReq := validConnectionProfileReq
+	sshConnectionProfileReq.ConnectionProfile.SSHTunnel = &profiles.SSHOptions{Host: "bastion.example.com", Port: "2222", User: "migrator", KeyFile: keyFile}
+	sshAgentConnectionProfileReq := validConnectionProfileReq
+	sshAgentConnectionProfileReq.ConnectionProfile.SSHTunnel = &profiles.SSHOptions{Host: "bastion.example.com", Port: "22", User: "migrator"}
 	testCases := []struct {
 		name                     string
 		sam                      storageaccessor.StorageAccessorMock
@@ -337,6 +345,29 @@ func TestPrepareMinimalDowntimeResources(t *testing.T) {
 			connectionProfileRequest: validConnectionProfileReq,
 			expectError:              true,
 		},
+		{
+			name: "source through ssh tunnel",
+			dsAcc: datastream_accessor.DatastreamAccessorMock{
+				CreateConnectionProfileMock: func(ctx context.Context, datastreamClient datastreamclient.DatastreamClient, req *datastreampb.CreateConnectionProfileRequest) (*datastreampb.ConnectionProfile, error) {
+					ssh := req.ConnectionProfile.GetForwardSshConnectivity()
+					if ssh == nil || ssh.Hostname != "bastion.example.com" || ssh.Port != 2222 || ssh.Username != "migrator" || ssh.GetPrivateKey() != "private-key" {
+						return nil, fmt.Errorf("unexpected ssh connectivity %v", ssh)
+					}
+					return &datastreampb.ConnectionProfile{}, nil
+				},
+			},
+			validateOnly:             false,
+			isSource:                 true,
+			connectionProfileRequest: sshConnectionProfileReq,
+			expectError:              false,
+		},
+		{
+			name:                     "source through ssh tunnel without key",
+			validateOnly:             false,
+			isSource:                 true,
+			connectionProfileRequest: sshAgentConnectionProfileReq,
+			expectError:              true,
+		},
 	}
 	for _, tc := range testCases {
 		tc.connectionProfileRequest.ConnectionProfile.ValidateOnly = tc.validateOnly
@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl/diff"
+)
+
+// ReadSpannerSchema loads the schema of an existing Spanner database into
+// conv, replacing its Spanner schema. The DDL statements, in the dialect of
+// conv, are read from the .sql file ddlFile if it is set, and otherwise
+// fetched from the database dbURI with GetDatabaseDdl. Statements without a
+// representation in the schema, such as CREATE ROLE, are logged and ignored.
+func ReadSpannerSchema(ctx context.Context, spA spanneraccessor.SpannerAccessor, conv *internal.Conv, ddlFile, dbURI string) error {
+	var stmts []string
+	if ddlFile != "" {
+		s, err := os.ReadFile(ddlFile)
+		if err != nil {
+			return err
+		}
+		stmts, err = ddl.SplitDDLStatements(string(s))
+		if err != nil {
+			return fmt.Errorf("can't split DDL file %s into statements: %v", ddlFile, err)
+		}
+	} else {
+		var err error
+		stmts, err = spA.GetDatabaseDDL(ctx, dbURI)
+		if err != nil {
+			return err
+		}
+	}
+	parsed, err := ddl.ParseDDL(stmts, conv.SpDialect, internal.GenerateId)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range parsed.Skipped {
+		logger.Log.Debug(fmt.Sprintf("Skipping DDL statement: %s", stmt))
+	}
+	conv.SpSchema = parsed.Tables
+	conv.SpSequences = parsed.Sequences
+	conv.SpViews = parsed.Objects.Views
+	conv.SpChangeStreams = parsed.Objects.ChangeStreams
+	conv.SpLocalityGroups = parsed.Objects.LocalityGroups
+	conv.SpPlacements = parsed.Objects.Placements
+	conv.SpModels = parsed.Objects.Models
+	conv.SpPropertyGraphs = parsed.Objects.PropertyGraphs
+	conv.SpDatabaseOptions = parsed.Objects.DatabaseOptions
+	conv.UsedNames = internal.ComputeUsedNames(conv)
+	return nil
+}
+
+// GetSchemaUpdateDDL returns the DDL statements that update the schema of the
+// existing database dbURI to the converted schema of conv. The existing
+// schema is loaded with ReadSpannerSchema and compared with the converted
+// one by diff.GetSchemaDiffDDL. Tables of the database that aren't part of
+// the conversion are left as they are, and sequences missing from the
+// database are created.
+//
+// Unless allowDrops is set, an error listing them is returned when the
+// update would drop tables, columns, indexes or constraints of the existing
+// schema, as dropping them can't be undone.
+func GetSchemaUpdateDDL(ctx context.Context, spA spanneraccessor.SpannerAccessor, conv *internal.Conv, dbURI, driver string, allowDrops bool) ([]string, error) {
+	dialect, err := spA.GetDatabaseDialect(ctx, dbURI)
+	if err != nil {
+		return nil, err
+	}
+	if dialect != conv.SpDialect {
+		return nil, fmt.Errorf("database %s has dialect %s, not %s", dbURI, dialect, conv.SpDialect)
+	}
+	existing := internal.MakeConv()
+	existing.SpDialect = conv.SpDialect
+	if err := ReadSpannerSchema(ctx, spA, existing, "", dbURI); err != nil {
+		return nil, err
+	}
+	converted := make(map[string]bool)
+	for _, ct := range conv.SpSchema {
+		converted[ct.Name] = true
+	}
+	oldSchema := ddl.NewSchema()
+	for id, ct := range existing.SpSchema {
+		if converted[ct.Name] {
+			oldSchema[id] = ct
+		}
+	}
+	c := ddl.Config{Comments: false, ProtectIds: true, SpDialect: conv.SpDialect, Source: driver}
+	stmts, err := diff.GetSchemaDiffDDL(c, oldSchema, conv.SpSchema)
+	if err != nil {
+		return nil, err
+	}
+	if !allowDrops {
+		var drops []string
+		for _, stmt := range stmts {
+			if isDropStatement(stmt) {
+				drops = append(drops, stmt)
+			}
+		}
+		if len(drops) > 0 {
+			return nil, fmt.Errorf("updating the schema of database %s drops schema objects, which is only done with the allowSchemaDrops param of the target profile: %s", dbURI, strings.Join(drops, "; "))
+		}
+	}
+
+	existingSeqs := make(map[string]bool)
+	for _, seq := range existing.SpSequences {
+		existingSeqs[seq.Name] = true
+	}
+	var seqStmts []string
+	for _, seqId := range ddl.GetSortedSequenceIds(conv.SpSequences) {
+		seq := conv.SpSequences[seqId]
+		if existingSeqs[seq.Name] {
+			continue
+		}
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			seqStmts = append(seqStmts, seq.PGPrintSequence(c))
+		} else {
+			seqStmts = append(seqStmts, seq.PrintSequence(c))
+		}
+	}
+	return append(seqStmts, stmts...), nil
+}
+
+// isDropStatement returns true if stmt, generated by diff.GetSchemaDiffDDL,
+// drops a table, column, index or constraint.
+func isDropStatement(stmt string) bool {
+	return strings.HasPrefix(stmt, "DROP ") || strings.Contains(stmt, " DROP COLUMN ") || strings.Contains(stmt, " DROP CONSTRAINT ")
+}
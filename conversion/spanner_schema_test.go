@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestReadSpannerSchema(t *testing.T) {
+	logger.Log = zap.NewNop()
+	ddlFile := filepath.Join(t.TempDir(), "schema.sql")
+	content := "CREATE TABLE customers (\n  id INT64 NOT NULL,\n  name STRING(MAX),\n) PRIMARY KEY (id);\n\n-- Index on the names of customers; looked up by the shop.\nCREATE INDEX customers_by_name ON customers (name);\n\nCREATE ROLE reader;\n"
+	assert.Nil(t, os.WriteFile(ddlFile, []byte(content), 0644))
+	expected := []string{
+		"CREATE TABLE customers (\n\tid INT64 NOT NULL ,\n\tname STRING(MAX),\n) PRIMARY KEY (id)",
+		"CREATE INDEX customers_by_name ON customers (name)",
+	}
+
+	tests := []struct {
+		name        string
+		ddlFile     string
+		statements  []string
+		ddlErr      error
+		expectError bool
+		expectDDL   []string
+	}{
+		{
+			name:      "ddl file",
+			ddlFile:   ddlFile,
+			expectDDL: expected,
+		},
+		{
+			name:       "existing database",
+			statements: []string{"CREATE TABLE customers (\n  id INT64 NOT NULL,\n  name STRING(MAX),\n) PRIMARY KEY (id)", "CREATE INDEX customers_by_name ON customers (name)", "CREATE ROLE reader"},
+			expectDDL:  expected,
+		},
+		{
+			name:        "missing ddl file",
+			ddlFile:     filepath.Join(t.TempDir(), "missing.sql"),
+			expectError: true,
+		},
+		{
+			name:        "invalid statement",
+			statements:  []string{"CREATE TABLE customers (id INT64 NOT NULL) PRIMARY KEY (customer_id)"},
+			expectError: true,
+		},
+		{
+			name:        "fetch error",
+			ddlErr:      fmt.Errorf("test-error"),
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spA := &spanneraccessor.SpannerAccessorMock{
+				GetDatabaseDDLMock: func(ctx context.Context, dbURI string) ([]string, error) {
+					assert.Equal(t, "db-uri", dbURI)
+					return tc.statements, tc.ddlErr
+				},
+			}
+			conv := internal.MakeConv()
+			conv.SpDialect = constants.DIALECT_GOOGLESQL
+			err := ReadSpannerSchema(context.Background(), spA, conv, tc.ddlFile, "db-uri")
+			assert.Equal(t, tc.expectError, err != nil)
+			if tc.expectError {
+				return
+			}
+			stmts := ddl.GetDDL(ddl.Config{Tables: true, SpDialect: conv.SpDialect}, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects())
+			assert.Equal(t, tc.expectDDL, stmts)
+			assert.True(t, conv.UsedNames["customers_by_name"])
+		})
+	}
+}
+
+func TestGetSchemaUpdateDDL(t *testing.T) {
+	logger.Log = zap.NewNop()
+	existingDDL := []string{
+		"CREATE TABLE customers (\n  id INT64 NOT NULL,\n  name STRING(MAX),\n  fax STRING(20),\n) PRIMARY KEY (id)",
+		"CREATE TABLE audit (\n  id INT64 NOT NULL,\n) PRIMARY KEY (id)",
+	}
+	tests := []struct {
+		name        string
+		dialect     string
+		converted   []string
+		allowDrops  bool
+		expectError bool
+		expectDDL   []string
+	}{
+		{
+			name: "additions",
+			converted: []string{
+				"CREATE SEQUENCE orders_seq OPTIONS (sequence_kind = 'bit_reversed_positive')",
+				"CREATE TABLE customers (\n  id INT64 NOT NULL,\n  name STRING(MAX),\n  fax STRING(20),\n  email STRING(100),\n) PRIMARY KEY (id)",
+				"CREATE INDEX customers_by_name ON customers (name)",
+			},
+			expectDDL: []string{
+				"CREATE SEQUENCE `orders_seq` OPTIONS (sequence_kind='bit_reversed_positive') ",
+				"ALTER TABLE `customers` ADD COLUMN `email` STRING(100)",
+				"CREATE INDEX `customers_by_name` ON `customers` (`name`)",
+			},
+		},
+		{
+			name:      "up to date",
+			converted: []string{"CREATE TABLE customers (\n  id INT64 NOT NULL,\n  name STRING(MAX),\n  fax STRING(20),\n) PRIMARY KEY (id)"},
+		},
+		{
+			name:        "drop refused",
+			converted:   []string{"CREATE TABLE customers (\n  id INT64 NOT NULL,\n  name STRING(MAX),\n) PRIMARY KEY (id)"},
+			expectError: true,
+		},
+		{
+			name:       "drop allowed",
+			converted:  []string{"CREATE TABLE customers (\n  id INT64 NOT NULL,\n  name STRING(MAX),\n) PRIMARY KEY (id)"},
+			allowDrops: true,
+			expectDDL:  []string{"ALTER TABLE `customers` DROP COLUMN `fax`"},
+		},
+		{
+			name:        "dialect mismatch",
+			dialect:     constants.DIALECT_POSTGRESQL,
+			converted:   []string{"CREATE TABLE customers (\n  id INT64 NOT NULL,\n) PRIMARY KEY (id)"},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spA := &spanneraccessor.SpannerAccessorMock{
+				GetDatabaseDialectMock: func(ctx context.Context, dbURI string) (string, error) {
+					if tc.dialect != "" {
+						return tc.dialect, nil
+					}
+					return constants.DIALECT_GOOGLESQL, nil
+				},
+				GetDatabaseDDLMock: func(ctx context.Context, dbURI string) ([]string, error) {
+					return existingDDL, nil
+				},
+			}
+			parsed, err := ddl.ParseDDL(tc.converted, constants.DIALECT_GOOGLESQL, internal.GenerateId)
+			assert.Nil(t, err)
+			conv := internal.MakeConv()
+			conv.SpDialect = constants.DIALECT_GOOGLESQL
+			conv.SpSchema = parsed.Tables
+			conv.SpSequences = parsed.Sequences
+			stmts, err := GetSchemaUpdateDDL(context.Background(), spA, conv, "db-uri", constants.MYSQL, tc.allowDrops)
+			assert.Equal(t, tc.expectError, err != nil)
+			assert.Equal(t, tc.expectDDL, stmts)
+		})
+	}
+}
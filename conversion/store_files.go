@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
@@ -39,10 +40,10 @@ func WriteSchemaFile(conv *internal.Conv, now time.Time, name string, out *os.Fi
 	}
 
 	// The schema file we write out below is optimized for reading. It includes comments, foreign keys
-	// and doesn't add backticks around table and column names. This file is
+	// and only adds backticks around GoogleSQL names that are reserved keywords. This file is
 	// intended for explanatory and documentation purposes, and is not strictly
 	// legal Cloud Spanner DDL (Cloud Spanner doesn't currently support comments).
-	spDDL := ddl.GetDDL(ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences)
+	spDDL := ddl.GetDDL(ddl.Config{Comments: true, ProtectIds: conv.SpDialect != constants.DIALECT_POSTGRESQL, QuoteReservedOnly: true, Tables: true, ForeignKeys: true, CommentStatements: conv.CommentStatements, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects())
 	if len(spDDL) == 0 {
 		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
 	}
@@ -66,24 +67,85 @@ func WriteSchemaFile(conv *internal.Conv, now time.Time, name string, out *os.Fi
 		fmt.Fprintf(out, "Can't create legal schema ddl file %s: %v\n", name, err)
 		return
 	}
+	defer f.Close()
 
-	// We change 'Comments' to false and 'ProtectIds' to true below to write out a
-	// schema file that is a legal Cloud Spanner DDL.
-	spDDL = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences)
-	if len(spDDL) == 0 {
-		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
-	}
-	l = []string{
-		strings.Join(spDDL, ";\n\n"),
-		"\n",
-	}
-	if _, err = f.WriteString(strings.Join(l, "")); err != nil {
+	// We write out a schema file that is a legal Cloud Spanner DDL, without
+	// comments on the statements and with all names quoted.
+	spDDL = ddl.GetDDL(ddl.SQLFileConfig(conv.SpDialect, driver), conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects())
+	if err = ddl.WriteSQLFile(f, spDDL, ddl.SQLFileOptions{Header: sqlFileHeader(conv, now, driver, conv.SpDialect)}); err != nil {
 		fmt.Fprintf(out, "Can't write out legal schema ddl file: %v\n", err)
 		return
 	}
 	fmt.Fprintf(out, "Wrote legal schema ddl to file '%s'.\n", name)
 }
 
+// sqlFileHeader returns the provenance header of the SQL files written for
+// conv, with statements in the given dialect.
+func sqlFileHeader(conv *internal.Conv, now time.Time, driver, dialect string) ddl.SQLFileHeader {
+	return ddl.SQLFileHeader{Generated: now, ToolVersion: utils.ToolVersion(), Source: driver, Dialect: dialect, SessionId: conv.Audit.MigrationRequestId}
+}
+
+// WriteLimitUsageFile writes a report of how close the Spanner schema is to
+// the Spanner limits, per table, with warnings for the limits whose usage is
+// above ddl.LimitWarningPercent.
+func WriteLimitUsageFile(conv *internal.Conv, name string, out *os.File) {
+	f, err := os.Create(name)
+	if err != nil {
+		fmt.Fprintf(out, "Can't create limit usage file %s: %v\n", name, err)
+		return
+	}
+	defer f.Close()
+	database, tables := ddl.GetLimitUsage(conv.SpSchema)
+	var l, warnings []string
+	l = append(l, "Database\n")
+	for _, u := range database {
+		l = append(l, fmt.Sprintf("  %s\n", u))
+		if u.Warn() {
+			warnings = append(warnings, fmt.Sprintf("  database, %s\n", u))
+		}
+	}
+	for _, t := range tables {
+		l = append(l, fmt.Sprintf("\nTable %s\n", t.Table))
+		for _, u := range t.Usages {
+			l = append(l, fmt.Sprintf("  %s\n", u))
+			if u.Warn() {
+				warnings = append(warnings, fmt.Sprintf("  table %s, %s\n", t.Table, u))
+			}
+		}
+	}
+	if len(warnings) > 0 {
+		l = append(l, fmt.Sprintf("\nWarnings: usage above %d%% of the limit\n", ddl.LimitWarningPercent))
+		l = append(l, warnings...)
+	}
+	if _, err := f.WriteString(strings.Join(l, "")); err != nil {
+		fmt.Fprintf(out, "Can't write out limit usage file: %v\n", err)
+		return
+	}
+	fmt.Fprintf(out, "Wrote limit usage to file '%s'.\n", name)
+}
+
+// WriteSourceSchemaFile writes the statements creating the Spanner schema in
+// a database of the source dialect, e.g. to provision the target of reverse
+// replication. Nothing is written for sources other than MySQL and
+// PostgreSQL.
+func WriteSourceSchemaFile(conv *internal.Conv, name string, out *os.File, driver string) {
+	srcDDL, err := ddl.GetSourceDDL(conv.SpSchema, driver)
+	if err != nil {
+		return
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		fmt.Fprintf(out, "Can't create source schema file %s: %v\n", name, err)
+		return
+	}
+	defer f.Close()
+	if err := ddl.WriteSQLFile(f, srcDDL, ddl.SQLFileOptions{Header: sqlFileHeader(conv, time.Now(), driver, driver), SectionMarkers: true}); err != nil {
+		fmt.Fprintf(out, "Can't write out source schema file: %v\n", err)
+		return
+	}
+	fmt.Fprintf(out, "Wrote source schema to file '%s'.\n", name)
+}
+
 // WriteSessionFile writes conv struct to a file in JSON format.
 func WriteSessionFile(conv *internal.Conv, name string, out *os.File) {
 	f, err := os.Create(name)
@@ -117,6 +179,7 @@ func WriteConvGeneratedFiles(conv *internal.Conv, dbName string, driver string,
 	}
 	schemaFileName := dirPath + dbName + "_schema.txt"
 	WriteSchemaFile(conv, now, schemaFileName, out, driver)
+	WriteLimitUsageFile(conv, dirPath+dbName+".limits.txt", out)
 	reportFileName := dirPath + dbName
 	reportImpl := ReportImpl{}
 	reportImpl.GenerateReport(driver, nil, BytesRead, "", conv, reportFileName, dbName, out)
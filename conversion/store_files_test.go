@@ -15,13 +15,18 @@
 package conversion
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestReadSessionFile(t *testing.T) {
@@ -133,3 +138,67 @@ func TestReadSessionFile(t *testing.T) {
 		assert.Equal(t, &tc.expectedConv, &conv, tc.name)
 	}
 }
+
+func TestWriteLimitUsageFile(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:        "Singers",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "SingerId", Id: "c1", T: ddl.Type{Name: ddl.Int64}}, "c2": {Name: "Name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 100}}},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+	}
+	name := filepath.Join(t.TempDir(), "test.limits.txt")
+	WriteLimitUsageFile(conv, name, os.Stdout)
+	b, err := os.ReadFile(name)
+	assert.Nil(t, err)
+	assert.Equal(t, `Database
+  tables per database: 1 of 5000 (0%)
+  indexes per database: 0 of 10000 (0%)
+
+Table Singers
+  columns per table: 2 of 1024 (0%)
+  indexes per table: 0 of 128 (0%)
+  key columns: 1 of 16 (6%)
+  non-key columns size: 100 of 1677721600 (0%)
+`, string(b))
+
+	// Limits used above the warning threshold are listed at the end.
+	ct := conv.SpSchema["t1"]
+	for i := 0; i < 14; i++ {
+		ct.PrimaryKeys = append(ct.PrimaryKeys, ddl.IndexKey{ColId: "c2"})
+	}
+	conv.SpSchema["t1"] = ct
+	WriteLimitUsageFile(conv, name, os.Stdout)
+	b, err = os.ReadFile(name)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "\nWarnings: usage above 80% of the limit\n  table Singers, key columns: 15 of 16 (94%)\n")
+}
+
+func TestWriteSourceSchemaFile(t *testing.T) {
+	logger.Log = zap.NewNop()
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:        "Singers",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "SingerId", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true}, "c2": {Name: "Name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 100}}},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+	}
+	name := filepath.Join(t.TempDir(), "test.source.schema.sql")
+	WriteSourceSchemaFile(conv, name, os.Stdout, constants.MYSQL)
+	b, err := os.ReadFile(name)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "-- Source: mysql\n")
+	assert.True(t, strings.HasSuffix(string(b), "\n-- Table Singers\nCREATE TABLE `Singers` (\n\t`SingerId` BIGINT NOT NULL,\n\t`Name` VARCHAR(100),\n\tPRIMARY KEY (`SingerId`)\n);\n"))
+
+	// No file is written for sources other than MySQL and PostgreSQL.
+	name = filepath.Join(t.TempDir(), "oracle.source.schema.sql")
+	WriteSourceSchemaFile(conv, name, os.Stdout, constants.ORACLE)
+	_, err = os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+}
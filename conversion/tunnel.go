@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/net/proxy"
+)
+
+type tunnelKey struct {
+	ssh   profiles.SSHOptions
+	proxy string
+	addr  string
+}
+
+var (
+	tunnelsMu sync.Mutex
+	// tunnels are the local addresses of the tunnels opened, which stay
+	// open for the rest of the migration.
+	tunnels = map[tunnelKey]string{}
+)
+
+// tunnelSourceProfile opens the tunnel of the database of sourceProfile,
+// through its SSH bastion host or proxy, if it has one, and returns
+// sourceProfile with the database at the local end of the tunnel. The name
+// of the server verified over TLS remains the host of the database.
+func tunnelSourceProfile(sourceProfile profiles.SourceProfile) (profiles.SourceProfile, error) {
+	if sourceProfile.Ty != profiles.SourceProfileTypeConnection {
+		return sourceProfile, nil
+	}
+	conn := &sourceProfile.Conn
+	var err error
+	switch conn.Ty {
+	case profiles.SourceProfileConnectionTypeMySQL:
+		err = tunnel(&conn.Mysql.Host, &conn.Mysql.Port, &conn.Mysql.TLS, conn.Mysql.SSH, conn.Mysql.Proxy)
+	case profiles.SourceProfileConnectionTypePostgreSQL:
+		err = tunnel(&conn.Pg.Host, &conn.Pg.Port, &conn.Pg.TLS, conn.Pg.SSH, conn.Pg.Proxy)
+	case profiles.SourceProfileConnectionTypeSqlServer:
+		err = tunnel(&conn.SqlServer.Host, &conn.SqlServer.Port, &conn.SqlServer.TLS, conn.SqlServer.SSH, conn.SqlServer.Proxy)
+	case profiles.SourceProfileConnectionTypeOracle:
+		err = tunnel(&conn.Oracle.Host, &conn.Oracle.Port, &conn.Oracle.TLS, conn.Oracle.SSH, conn.Oracle.Proxy)
+	case profiles.SourceProfileConnectionTypeMariaDB:
+		err = tunnel(&conn.MariaDB.Host, &conn.MariaDB.Port, &conn.MariaDB.TLS, conn.MariaDB.SSH, conn.MariaDB.Proxy)
+	case profiles.SourceProfileConnectionTypeRedshift:
+		err = tunnel(&conn.Redshift.Host, &conn.Redshift.Port, &conn.Redshift.TLS, conn.Redshift.SSH, conn.Redshift.Proxy)
+	case profiles.SourceProfileConnectionTypeSybase:
+		err = tunnel(&conn.Sybase.Host, &conn.Sybase.Port, nil, conn.Sybase.SSH, conn.Sybase.Proxy)
+	}
+	return sourceProfile, err
+}
+
+// tunnel replaces host and port with the local address of the tunnel to
+// them through the SSH bastion host of opts and the proxy proxyURL, if
+// either is set.
+func tunnel(host, port *string, tlsOpts *profiles.TLSOptions, opts profiles.SSHOptions, proxyURL string) error {
+	if opts.Host == "" && proxyURL == "" {
+		return nil
+	}
+	localAddr, err := openTunnel(opts, proxyURL, net.JoinHostPort(*host, *port))
+	if err != nil {
+		return err
+	}
+	if tlsOpts != nil && tlsOpts.ServerName == "" {
+		tlsOpts.ServerName = *host
+	}
+	*host, *port, err = net.SplitHostPort(localAddr)
+	return err
+}
+
+// openTunnel returns the local address of a tunnel to addr through the SSH
+// bastion host of opts and the proxy proxyURL, opening it if it isn't
+// already.
+func openTunnel(opts profiles.SSHOptions, proxyURL string, addr string) (string, error) {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	key := tunnelKey{ssh: opts, proxy: proxyURL, addr: addr}
+	if localAddr, ok := tunnels[key]; ok {
+		return localAddr, nil
+	}
+	dialer, err := tunnelDialer(opts, proxyURL)
+	if err != nil {
+		return "", err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("can't listen for the tunnel: %w", err)
+	}
+	go func() {
+		for {
+			local, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go forward(dialer, local, addr)
+		}
+	}()
+	if opts.Host != "" {
+		logger.Log.Info(fmt.Sprintf("Opened SSH tunnel to %s through %s", addr, opts.Host))
+	} else {
+		logger.Log.Info(fmt.Sprintf("Opened tunnel to %s through proxy %s", addr, proxyURL))
+	}
+	tunnels[key] = listener.Addr().String()
+	return tunnels[key], nil
+}
+
+// tunnelDialer returns the dialer of the connections of tunnels through the
+// SSH bastion host of opts and the proxy proxyURL, which the bastion host
+// is reached through if both are set.
+func tunnelDialer(opts profiles.SSHOptions, proxyURL string) (proxy.Dialer, error) {
+	var dialer proxy.Dialer = proxy.Direct
+	if proxyURL != "" {
+		u, err := utils.ParseProxyURL(proxyURL)
+		if err != nil {
+			return nil, err
+		}
+		if dialer, err = utils.NewProxyDialer(u); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Host == "" {
+		return dialer, nil
+	}
+	config, err := sshClientConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	bastion := net.JoinHostPort(opts.Host, opts.Port)
+	conn, err := dialer.Dial("tcp", bastion)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to SSH bastion host %s: %w", opts.Host, err)
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, bastion, config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("can't connect to SSH bastion host %s: %w", opts.Host, err)
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// forward copies the data of local to and from addr, through dialer.
+func forward(dialer proxy.Dialer, local net.Conn, addr string) {
+	defer local.Close()
+	remote, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("can't connect to %s through the tunnel: %v", addr, err))
+		return
+	}
+	defer remote.Close()
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// sshClientConfig returns the configuration of the SSH client of opts,
+// authenticated with its private key or the keys of the SSH agent.
+func sshClientConfig(opts profiles.SSHOptions) (*ssh.ClientConfig, error) {
+	knownHostsFile := opts.KnownHostsFile
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("can't find the known hosts of SSH tunnels: %w", err)
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the known hosts of SSH tunnels: %w", err)
+	}
+	var auth ssh.AuthMethod
+	if opts.KeyFile != "" {
+		pem, err := os.ReadFile(opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read the private key of the SSH tunnel: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(pem)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse the private key of the SSH tunnel, keys with passphrases must be added to the SSH agent: %w", err)
+		}
+		auth = ssh.PublicKeys(signer)
+	} else {
+		sock, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK"))
+		if err != nil {
+			return nil, fmt.Errorf("can't connect to the SSH agent: %w", err)
+		}
+		auth = ssh.PublicKeysCallback(agent.NewClient(sock).Signers)
+	}
+	return &ssh.ClientConfig{
+		User:            opts.User,
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
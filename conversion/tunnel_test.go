@@ -0,0 +1,305 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+)
+
+// startEchoServer starts a TCP server echoing the lines it receives, and
+// returns its address.
+func startEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// startSSHServer starts an SSH server forwarding connections for the user
+// of clientKey, and returns its address and a known_hosts file of it.
+func startSSHServer(t *testing.T, dir string, clientKey ssh.PublicKey) (string, string) {
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	assert.Nil(t, err)
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "migrator" && string(key.Marshal()) == string(clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, assert.AnError
+		},
+	}
+	config.AddHostKey(hostSigner)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, config)
+		}
+	}()
+	knownHosts := filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(listener.Addr().String())}, hostSigner.PublicKey())
+	assert.Nil(t, os.WriteFile(knownHosts, []byte(line+"\n"), 0600))
+	return listener.Addr().String(), knownHosts
+}
+
+// serveSSH serves the direct-tcpip channels of an SSH connection.
+func serveSSH(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		if newChan.ChannelType() != "direct-tcpip" {
+			newChan.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		ssh.Unmarshal(newChan.ExtraData(), &target)
+		remote, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			newChan.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		ch, chReqs, err := newChan.Accept()
+		if err != nil {
+			remote.Close()
+			continue
+		}
+		go ssh.DiscardRequests(chReqs)
+		go func() {
+			defer ch.Close()
+			defer remote.Close()
+			go io.Copy(remote, ch)
+			io.Copy(ch, remote)
+		}()
+	}
+}
+
+// writeSSHKey writes a private key for SSH clients to dir, and returns its
+// path and public key.
+func writeSSHKey(t *testing.T, dir string) (string, ssh.PublicKey) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	assert.Nil(t, err)
+	keyFile := filepath.Join(dir, "id_ed25519")
+	assert.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600))
+	signer, err := ssh.NewSignerFromKey(priv)
+	assert.Nil(t, err)
+	return keyFile, signer.PublicKey()
+}
+
+func TestTunnelSourceProfile(t *testing.T) {
+	logger.Log = zap.NewNop()
+	dir := t.TempDir()
+	keyFile, pubKey := writeSSHKey(t, dir)
+	bastionAddr, knownHosts := startSSHServer(t, dir, pubKey)
+	bastionHost, bastionPort, _ := net.SplitHostPort(bastionAddr)
+	dbHost, dbPort, _ := net.SplitHostPort(startEchoServer(t))
+	ssh := profiles.SSHOptions{Host: bastionHost, Port: bastionPort, User: "migrator", KeyFile: keyFile, KnownHostsFile: knownHosts}
+
+	sourceProfile := profiles.SourceProfile{
+		Ty: profiles.SourceProfileTypeConnection,
+		Conn: profiles.SourceProfileConnection{
+			Ty: profiles.SourceProfileConnectionTypeMySQL,
+			Mysql: profiles.SourceProfileConnectionMySQL{
+				Host: dbHost, Port: dbPort, TLS: profiles.TLSOptions{Mode: profiles.TLSModeVerifyFull}, SSH: ssh,
+			},
+		},
+	}
+	tunnelled, err := tunnelSourceProfile(sourceProfile)
+	assert.Nil(t, err)
+	mysql := tunnelled.Conn.Mysql
+	assert.Equal(t, "127.0.0.1", mysql.Host)
+	assert.NotEqual(t, dbPort, mysql.Port)
+	// The server is still verified against its own host.
+	assert.Equal(t, dbHost, mysql.TLS.ServerName)
+	// The source profile given is left as it is.
+	assert.Equal(t, dbPort, sourceProfile.Conn.Mysql.Port)
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(mysql.Host, mysql.Port))
+	assert.Nil(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("ping\n"))
+	assert.Nil(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	assert.Nil(t, err)
+	assert.Equal(t, "ping\n", line)
+
+	// Tunnels are reused for the same database.
+	again, err := tunnelSourceProfile(sourceProfile)
+	assert.Nil(t, err)
+	assert.Equal(t, mysql.Port, again.Conn.Mysql.Port)
+
+	// Profiles without tunnels are left as they are.
+	sourceProfile.Conn.Mysql.SSH = profiles.SSHOptions{}
+	direct, err := tunnelSourceProfile(sourceProfile)
+	assert.Nil(t, err)
+	assert.Equal(t, sourceProfile, direct)
+}
+
+func TestTunnelSourceProfileUnknownHost(t *testing.T) {
+	dir := t.TempDir()
+	keyFile, pubKey := writeSSHKey(t, dir)
+	bastionAddr, _ := startSSHServer(t, dir, pubKey)
+	bastionHost, bastionPort, _ := net.SplitHostPort(bastionAddr)
+	otherKnownHosts := filepath.Join(dir, "other_known_hosts")
+	assert.Nil(t, os.WriteFile(otherKnownHosts, nil, 0600))
+
+	sourceProfile := profiles.SourceProfile{
+		Ty: profiles.SourceProfileTypeConnection,
+		Conn: profiles.SourceProfileConnection{
+			Ty: profiles.SourceProfileConnectionTypePostgreSQL,
+			Pg: profiles.SourceProfileConnectionPostgreSQL{
+				Host: "10.0.0.12", Port: "5432",
+				SSH: profiles.SSHOptions{Host: bastionHost, Port: bastionPort, User: "migrator", KeyFile: keyFile, KnownHostsFile: otherKnownHosts},
+			},
+		},
+	}
+	_, err := tunnelSourceProfile(sourceProfile)
+	assert.NotNil(t, err)
+}
+
+// startConnectProxy starts an HTTP proxy tunnelling the connections of
+// CONNECT requests, and returns its address and the number of connections
+// it tunnelled.
+func startConnectProxy(t *testing.T) (string, *int32) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+	var tunnelled int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil || req.Method != http.MethodConnect {
+					return
+				}
+				remote, err := net.Dial("tcp", req.Host)
+				if err != nil {
+					io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+					return
+				}
+				defer remote.Close()
+				atomic.AddInt32(&tunnelled, 1)
+				io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+				go io.Copy(remote, conn)
+				io.Copy(conn, remote)
+			}()
+		}
+	}()
+	return listener.Addr().String(), &tunnelled
+}
+
+func TestTunnelSourceProfileProxy(t *testing.T) {
+	logger.Log = zap.NewNop()
+	dir := t.TempDir()
+	keyFile, pubKey := writeSSHKey(t, dir)
+	bastionAddr, knownHosts := startSSHServer(t, dir, pubKey)
+	bastionHost, bastionPort, _ := net.SplitHostPort(bastionAddr)
+	dbHost, dbPort, _ := net.SplitHostPort(startEchoServer(t))
+	proxyAddr, tunnelled := startConnectProxy(t)
+
+	testCases := []struct {
+		name              string
+		ssh               profiles.SSHOptions
+		expectedTunnelled int32
+	}{
+		{
+			name:              "database through proxy",
+			expectedTunnelled: 1,
+		},
+		{
+			// Only the connection to the bastion host goes through the
+			// proxy, that to the database going through the bastion host.
+			name:              "bastion host through proxy",
+			ssh:               profiles.SSHOptions{Host: bastionHost, Port: bastionPort, User: "migrator", KeyFile: keyFile, KnownHostsFile: knownHosts},
+			expectedTunnelled: 1,
+		},
+	}
+	for _, tc := range testCases {
+		atomic.StoreInt32(tunnelled, 0)
+		sourceProfile := profiles.SourceProfile{
+			Ty: profiles.SourceProfileTypeConnection,
+			Conn: profiles.SourceProfileConnection{
+				Ty:        profiles.SourceProfileConnectionTypeSqlServer,
+				SqlServer: profiles.SourceProfileConnectionSqlServer{Host: dbHost, Port: dbPort, SSH: tc.ssh, Proxy: "http://" + proxyAddr},
+			},
+		}
+		tunnelledProfile, err := tunnelSourceProfile(sourceProfile)
+		assert.Nil(t, err, tc.name)
+		ss := tunnelledProfile.Conn.SqlServer
+		assert.Equal(t, "127.0.0.1", ss.Host, tc.name)
+
+		conn, err := net.Dial("tcp", net.JoinHostPort(ss.Host, ss.Port))
+		assert.Nil(t, err, tc.name)
+		_, err = conn.Write([]byte("ping\n"))
+		assert.Nil(t, err, tc.name)
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, "ping\n", line, tc.name)
+		conn.Close()
+		assert.Equal(t, tc.expectedTunnelled, atomic.LoadInt32(tunnelled), tc.name)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// uuidStringLength is the length of the textual form of a UUID.
+const uuidStringLength = 36
+
+// MapUUIDsToString maps the UUID columns of the Spanner schema onto
+// STRING(36) columns holding the textual form of the UUIDs, for databases
+// that don't use the UUID type. The columns are marked with the UUIDAsString
+// issue, which tells data conversion to convert their values to UUIDs.
+func MapUUIDsToString(conv *internal.Conv) {
+	for tableId, ct := range conv.SpSchema {
+		for colId, col := range ct.ColDefs {
+			if col.T.Name != ddl.UUID {
+				continue
+			}
+			col.T = ddl.Type{Name: ddl.String, Len: uuidStringLength, IsArray: col.T.IsArray}
+			ct.ColDefs[colId] = col
+			tableIssues := conv.SchemaIssues[tableId]
+			if tableIssues.ColumnLevelIssues == nil {
+				tableIssues.ColumnLevelIssues = make(map[string][]internal.SchemaIssue)
+			}
+			tableIssues.ColumnLevelIssues[colId] = append(tableIssues.ColumnLevelIssues[colId], internal.UUIDAsString)
+			conv.SchemaIssues[tableId] = tableIssues
+		}
+		conv.SpSchema[tableId] = ct
+	}
+}
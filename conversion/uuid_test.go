@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapUUIDsToString(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Name:   "users",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.UUID}, NotNull: true},
+			"c2": {Name: "aliases", Id: "c2", T: ddl.Type{Name: ddl.UUID, IsArray: true}},
+			"c3": {Name: "name", Id: "c3", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		},
+	}
+	MapUUIDsToString(conv)
+	cols := conv.SpSchema["t1"].ColDefs
+	assert.Equal(t, ddl.ColumnDef{Name: "id", Id: "c1", T: ddl.Type{Name: ddl.String, Len: 36}, NotNull: true}, cols["c1"])
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 36, IsArray: true}, cols["c2"].T)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, cols["c3"].T)
+	assert.Equal(t, map[string][]internal.SchemaIssue{"c1": {internal.UUIDAsString}, "c2": {internal.UUIDAsString}}, conv.SchemaIssues["t1"].ColumnLevelIssues)
+}
@@ -202,7 +202,7 @@ func (ddlv *DDLVerifierImpl) GetSourceExpressionDetails(conv *internal.Conv, tab
 						Name: tyName,
 					},
 					ExpressionId: srcCol.DefaultValue.Value.ExpressionId,
-					Expression:   srcCol.DefaultValue.Value.Statement,
+					Expression:   ddl.TranslateDefaultExpression(srcCol.DefaultValue.Value.Statement, conv.SpSchema[tableId].ColDefs[srcColId].T, conv.SpDialect),
 					Type:         constants.DEFAULT_EXPRESSION,
 					Metadata:     map[string]string{"TableId": tableId, "ColId": srcColId},
 				}
@@ -14,6 +14,7 @@ import (
 	spanneradmin "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/admin"
 	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
 	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
@@ -426,6 +427,33 @@ func TestGetSourceExpressionDetails(t *testing.T) {
 	}
 }
 
+func TestGetSourceExpressionDetailsTranslatesDefaults(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	conv.SrcSchema = map[string]schema.Table{
+		"table1": {
+			ColIds: []string{"col1"},
+			ColDefs: map[string]schema.Column{
+				"col1": {DefaultValue: ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "expr1", Statement: "now()"}}},
+			},
+		},
+	}
+	conv.SpSchema = ddl.Schema{
+		"table1": {ColDefs: map[string]ddl.ColumnDef{"col1": {T: ddl.Type{Name: ddl.Timestamp}}}},
+	}
+	ddlv := &expressions_api.DDLVerifierImpl{}
+	expected := []internal.ExpressionDetail{
+		{
+			ReferenceElement: internal.ReferenceElement{Name: ddl.PGTimestamptz},
+			ExpressionId:     "expr1",
+			Expression:       "CURRENT_TIMESTAMP",
+			Type:             "DEFAULT",
+			Metadata:         map[string]string{"TableId": "table1", "ColId": "col1"},
+		},
+	}
+	assert.Equal(t, expected, ddlv.GetSourceExpressionDetails(conv, []string{"table1"}))
+}
+
 func TestGetSpannerExpressionDetails(t *testing.T) {
 	conv := internal.MakeConv()
 	conv.SpSchema = ddl.Schema{
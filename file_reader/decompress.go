@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_reader
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Decompress returns a reader of the content of r, which is decompressed
+// as it is read if r is gzip (.gz) or zstd (.zst) compressed. Compression
+// is detected from the first bytes of r rather than from a file name, so
+// that it also works for standard input and GCS objects.
+//
+// When r isn't compressed and can seek, r itself is returned, positioned
+// where it was.
+func Decompress(r io.Reader) (io.Reader, error) {
+	magic := make([]byte, len(zstdMagic))
+	var n int
+	if s, ok := r.(io.ReadSeeker); ok && isSeekable(s) {
+		var err error
+		n, err = io.ReadFull(s, magic)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, err
+		}
+		if _, err := s.Seek(int64(-n), io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	} else {
+		br := bufio.NewReader(r)
+		peeked, err := br.Peek(len(magic))
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		n = copy(magic, peeked)
+		r = br
+	}
+	magic = magic[:n]
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("can't read gzip header: %v", err)
+		}
+		return gr, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		// A single goroutine decodes synchronously, so the decoder doesn't
+		// need to be closed.
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("can't read zstd frame: %v", err)
+		}
+		return zr, nil
+	}
+	return r, nil
+}
+
+// isSeekable reports whether s can seek, which files such as pipes can't.
+func isSeekable(s io.Seeker) bool {
+	_, err := s.Seek(0, io.SeekCurrent)
+	return err == nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_reader
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+const dump = "CREATE TABLE t (a INT);\nINSERT INTO t VALUES (1);\n"
+
+func gzipped(t *testing.T, s string) []byte {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	_, err := w.Write([]byte(s))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	return b.Bytes()
+}
+
+func zstdCompressed(t *testing.T, s string) []byte {
+	var b bytes.Buffer
+	w, err := zstd.NewWriter(&b)
+	assert.Nil(t, err)
+	_, err = w.Write([]byte(s))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	return b.Bytes()
+}
+
+func TestDecompress(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{name: "plain", content: []byte(dump), want: dump},
+		{name: "empty", content: nil, want: ""},
+		{name: "short", content: []byte{0x1f}, want: "\x1f"},
+		{name: "gzip", content: gzipped(t, dump), want: dump},
+		{name: "concatenated gzip", content: append(gzipped(t, "a\n"), gzipped(t, "b\n")...), want: "a\nb\n"},
+		{name: "zstd", content: zstdCompressed(t, dump), want: dump},
+	}
+	for _, tc := range testCases {
+		// Readers that can't seek are buffered.
+		r, err := Decompress(io.NopCloser(bytes.NewReader(tc.content)))
+		assert.Nil(t, err, tc.name)
+		got, err := io.ReadAll(r)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, string(got), tc.name)
+
+		// Seekable readers are rewound.
+		r, err = Decompress(bytes.NewReader(tc.content))
+		assert.Nil(t, err, tc.name)
+		got, err = io.ReadAll(r)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, string(got), tc.name)
+	}
+
+	_, err := Decompress(bytes.NewReader([]byte{0x1f, 0x8b, 0x00}))
+	assert.NotNil(t, err)
+	r, err := Decompress(bytes.NewReader(gzipped(t, dump)[:20]))
+	assert.Nil(t, err)
+	_, err = io.ReadAll(r)
+	assert.NotNil(t, err)
+}
+
+func TestDecompressUncompressedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.sql")
+	assert.Nil(t, os.WriteFile(path, []byte(dump), 0644))
+	f, err := os.Open(path)
+	assert.Nil(t, err)
+	defer f.Close()
+	_, err = f.Seek(7, io.SeekStart)
+	assert.Nil(t, err)
+	r, err := Decompress(f)
+	assert.Nil(t, err)
+	assert.Equal(t, f, r)
+	got, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, dump[7:], string(got))
+}
+
+func TestLocalFileReaderCompressed(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string][]byte{"dump.sql.gz": gzipped(t, dump), "dump.sql.zst": zstdCompressed(t, dump)} {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.WriteFile(path, content, 0644))
+		reader, err := NewFileReader(context.Background(), path)
+		assert.Nil(t, err, name)
+
+		r, err := reader.CreateReader(context.Background())
+		assert.Nil(t, err, name)
+		got, err := io.ReadAll(r)
+		assert.Nil(t, err, name)
+		assert.Equal(t, dump, string(got), name)
+
+		r, err = reader.ResetReader(context.Background())
+		assert.Nil(t, err, name)
+		got, err = io.ReadAll(r)
+		assert.Nil(t, err, name)
+		assert.Equal(t, dump, string(got), name)
+		reader.Close()
+
+		reader, err = NewFileReader(context.Background(), path)
+		assert.Nil(t, err, name)
+		got, err = reader.ReadAll(context.Background())
+		assert.Nil(t, err, name)
+		assert.Equal(t, dump, string(got), name)
+		reader.Close()
+	}
+}
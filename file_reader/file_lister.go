@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_reader
+
+import (
+	"context"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+)
+
+// ListFiles returns the paths of the files under dir, a local directory or
+// a GCS path e.g. gs://bucket/exports, whose names end with suffix. Files
+// in subdirectories are listed too. Paths are sorted, and GCS paths are
+// returned as gs:// URIs.
+var ListFiles = listFiles
+
+func listFiles(ctx context.Context, dir, suffix string) ([]string, error) {
+	u, err := url.Parse(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	if u.Scheme == constants.GCS_SCHEME {
+		files, err = listGcsFiles(ctx, u.Host, strings.TrimPrefix(u.Path, "/"), suffix)
+	} else {
+		files, err = listLocalFiles(dir, suffix)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func listLocalFiles(dir, suffix string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), suffix) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// listGcsFiles lists the objects of bucket under the "directory" prefix.
+func listGcsFiles(ctx context.Context, bucket, prefix, suffix string) ([]string, error) {
+	client, err := GoogleStorageNewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	var files []string
+	it := client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(attrs.Name, suffix) {
+			files = append(files, constants.GCS_FILE_PREFIX+bucket+"/"+attrs.Name)
+		}
+	}
+	return files, nil
+}
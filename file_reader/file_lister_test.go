@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_reader
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListFilesLocal(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.parquet", "a.parquet", "notes.txt", "orders/part-0.parquet"} {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.Nil(t, os.WriteFile(path, []byte(name), 0644))
+	}
+	files, err := ListFiles(context.Background(), dir, ".parquet")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.parquet"),
+		filepath.Join(dir, "b.parquet"),
+		filepath.Join(dir, "orders", "part-0.parquet"),
+	}, files)
+
+	_, err = ListFiles(context.Background(), filepath.Join(dir, "missing"), ".parquet")
+	assert.NotNil(t, err)
+}
+
+func TestOpenReaderAtLocal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.bin")
+	assert.Nil(t, os.WriteFile(path, []byte("0123456789"), 0644))
+	r, err := OpenReaderAt(context.Background(), path)
+	assert.Nil(t, err)
+	defer r.Close()
+	p := make([]byte, 3)
+	_, err = r.ReadAt(p, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, "789", string(p))
+	_, err = r.ReadAt(p, 9)
+	assert.Equal(t, io.EOF, err)
+}
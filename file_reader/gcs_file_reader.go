@@ -56,7 +56,7 @@ func (reader *GcsFileReaderImpl) CreateReader(ctx context.Context) (io.Reader, e
 		return nil, err
 	}
 	reader.storageReader = rc
-	return rc, nil
+	return Decompress(rc)
 }
 
 func (reader *GcsFileReaderImpl) Close() {
@@ -70,13 +70,17 @@ func (reader *GcsFileReaderImpl) Close() {
 }
 
 func (reader *GcsFileReaderImpl) ReadAll(ctx context.Context) ([]byte, error) {
+	var r io.Reader
+	var err error
 	if reader.storageReader == nil {
-		_, err := reader.CreateReader(ctx)
-		if err != nil {
-			return nil, err
-		}
+		r, err = reader.CreateReader(ctx)
+	} else {
+		r, err = Decompress(reader.storageReader)
 	}
-	return io.ReadAll(reader.storageReader)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
 }
 
 func validateObjectExists(ctx context.Context, client *storage.Client, bucket, object string) error {
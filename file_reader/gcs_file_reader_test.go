@@ -1,6 +1,7 @@
 package file_reader
 
 import (
+	"bufio"
 	"cloud.google.com/go/httpreplay"
 	"cloud.google.com/go/storage"
 	"context"
@@ -193,7 +194,9 @@ func TestGCSFileReaderImpl_CreateReader(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, r)
-				assert.IsType(t, &storage.Reader{}, r)
+				// Uncompressed objects are read through the buffer that
+				// detected they aren't compressed.
+				assert.IsType(t, &bufio.Reader{}, r)
 			}
 		})
 	}
@@ -257,7 +260,9 @@ func TestGCSFileReaderImpl_ResetReader(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, r)
-				assert.IsType(t, &storage.Reader{}, r)
+				// Uncompressed objects are read through the buffer that
+				// detected they aren't compressed.
+				assert.IsType(t, &bufio.Reader{}, r)
 			}
 		})
 	}
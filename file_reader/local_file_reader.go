@@ -25,7 +25,7 @@ func (reader *LocalFileReaderImpl) ResetReader(ctx context.Context) (io.Reader,
 	if reader.fileHandle != nil {
 		_, err := reader.fileHandle.Seek(0, 0)
 		if err == nil {
-			return reader.fileHandle, nil
+			return Decompress(reader.fileHandle)
 		}
 		reader.fileHandle.Close()
 	}
@@ -40,7 +40,7 @@ func (reader *LocalFileReaderImpl) CreateReader(_ context.Context) (io.Reader, e
 		return nil, err
 	}
 	reader.fileHandle = f
-	return f, nil
+	return Decompress(f)
 }
 
 func (reader *LocalFileReaderImpl) Close() {
@@ -50,11 +50,15 @@ func (reader *LocalFileReaderImpl) Close() {
 }
 
 func (reader *LocalFileReaderImpl) ReadAll(_ context.Context) ([]byte, error) {
+	var r io.Reader
+	var err error
 	if reader.fileHandle == nil {
-		_, err := reader.CreateReader(context.Background())
-		if err != nil {
-			return nil, err
-		}
+		r, err = reader.CreateReader(context.Background())
+	} else {
+		r, err = Decompress(reader.fileHandle)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return io.ReadAll(reader.fileHandle)
+	return io.ReadAll(r)
 }
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+)
+
+// ReaderAtSeekCloser is a file that can be read at any offset, as needed to
+// read columnar files e.g. Parquet files, whose metadata is at their end.
+type ReaderAtSeekCloser interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+// OpenReaderAt opens the local or GCS file at uri for reading at any
+// offset. GCS objects aren't downloaded: each read is a range request, so
+// only the parts of the object that are read are transferred.
+var OpenReaderAt = openReaderAt
+
+func openReaderAt(ctx context.Context, uri string) (ReaderAtSeekCloser, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != constants.GCS_SCHEME {
+		return os.Open(uri)
+	}
+	client, err := GoogleStorageNewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	obj := client.Bucket(u.Host).Object(strings.TrimPrefix(u.Path, "/"))
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("couldn't open %s: %w", uri, err)
+	}
+	return &gcsReaderAt{ctx: ctx, client: client, obj: obj, size: attrs.Size}, nil
+}
+
+// gcsReaderAt reads a GCS object with range requests.
+type gcsReaderAt struct {
+	ctx    context.Context
+	client *storage.Client
+	obj    *storage.ObjectHandle
+	size   int64
+	offset int64
+}
+
+func (r *gcsReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	rr, err := r.obj.NewRangeReader(r.ctx, off, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	defer rr.Close()
+	n, err := io.ReadFull(rr, p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *gcsReaderAt) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.offset)
+	r.offset += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+func (r *gcsReaderAt) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d", offset)
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *gcsReaderAt) Close() error {
+	return r.client.Close()
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"go.uber.org/zap"
+	"io"
+	"os"
 )
 
 var NewSpannerAccessor = func(ctx context.Context, dbURI string) (spanneraccessor.SpannerAccessor, error) {
@@ -70,7 +72,7 @@ func NewImportFromDump(
 
 // CreateSchema Process database dump file. Convert schema to spanner DDL. Update the provided database with the schema.
 func (source *ImportFromDumpImpl) CreateSchema(ctx context.Context, dialect string) (*internal.Conv, error) {
-	reader, err := source.dumpReader.CreateReader(ctx)
+	reader, err := source.openDump(ctx, source.dumpReader.CreateReader)
 	if err != nil {
 		logger.Log.Error("Failed to create reader:", zap.Error(err))
 		return nil, fmt.Errorf("failed to create reader: %v", err)
@@ -105,7 +107,7 @@ func (source *ImportFromDumpImpl) CreateSchema(ctx context.Context, dialect stri
 
 // ImportData process database dump file. Convert insert statement to spanner mutation. Load data into spanner.
 func (source *ImportFromDumpImpl) ImportData(ctx context.Context, conv *internal.Conv) error {
-	dumpReader, err := source.dumpReader.ResetReader(ctx)
+	dumpReader, err := source.openDump(ctx, source.dumpReader.ResetReader)
 	if err != nil {
 		return fmt.Errorf("can't read dump file: %s due to: %v", source.DumpUri, err)
 	}
@@ -121,6 +123,23 @@ func (source *ImportFromDumpImpl) ImportData(ctx context.Context, conv *internal
 	return nil
 }
 
+// openDump returns a reader of the dump created by createReader. pg_dump
+// custom format archives, and local directory format archives, are
+// translated to plain-text dumps.
+func (source *ImportFromDumpImpl) openDump(ctx context.Context, createReader func(ctx context.Context) (io.Reader, error)) (io.Reader, error) {
+	if source.SourceFormat != constants.PGDUMP {
+		return createReader(ctx)
+	}
+	if info, err := os.Stat(source.DumpUri); err == nil && info.IsDir() {
+		return postgres.NewDirectoryArchiveReader(source.DumpUri)
+	}
+	reader, err := createReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return postgres.NewDumpReader(reader)
+}
+
 func getDbDump(sourceFormat string) (common.DbDump, error) {
 	switch sourceFormat {
 	case constants.MYSQLDUMP:
@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Policies of converting source auto-increment columns, e.g. MySQL
+// AUTO_INCREMENT or AUTO_RANDOM columns and PostgreSQL identity columns, to
+// Spanner.
+const (
+	// AutoIncrementPolicySequence converts auto-increment columns to INT64
+	// columns using a bit-reversed sequence. This is the default.
+	AutoIncrementPolicySequence = "sequence"
+	// AutoIncrementPolicyIdentity converts auto-increment columns to INT64
+	// identity columns, which generate bit-reversed values.
+	AutoIncrementPolicyIdentity = "identity"
+	// AutoIncrementPolicyUUID converts auto-increment columns to STRING(36)
+	// columns generating UUIDs.
+	AutoIncrementPolicyUUID = "uuid"
+	// AutoIncrementPolicyKeep converts auto-increment columns to INT64
+	// columns without auto-generation, so that the application keeps
+	// setting their values.
+	AutoIncrementPolicyKeep = "keep"
+)
+
+// autoIncrementIssues are the issues of auto-increment columns which depend
+// on the policy they are converted with.
+var autoIncrementIssues = []SchemaIssue{SequenceCreated, AutoRandom, SequenceOptionUnsupported, AutoIncrementIdentity, AutoIncrementUUID, AutoIncrementKept}
+
+// IsAutoIncrementColumn reports whether column colId of table tableId was
+// converted from a source auto-increment column, which auto-increment
+// policies apply to.
+func IsAutoIncrementColumn(conv *Conv, tableId, colId string) bool {
+	if _, ok := conv.SpSchema[tableId].ColDefs[colId]; !ok {
+		return false
+	}
+	_, ok := autoIncrementSequenceId(conv, tableId, colId)
+	return ok
+}
+
+// GetAutoIncrementPolicy returns the policy the auto-increment columns of
+// table tableId were converted with.
+func GetAutoIncrementPolicy(conv *Conv, tableId string) string {
+	if policy, ok := conv.AutoIncrementPolicies[tableId]; ok {
+		return policy
+	}
+	return AutoIncrementPolicySequence
+}
+
+// SetAutoIncrementPolicy converts the auto-increment columns of table
+// tableId with policy, and records it in conv.AutoIncrementPolicies. Spanner
+// sequences no longer used by any column are removed, and created again when
+// the table is set back to AutoIncrementPolicySequence.
+func SetAutoIncrementPolicy(conv *Conv, tableId, policy string) error {
+	switch policy {
+	case AutoIncrementPolicySequence, AutoIncrementPolicyIdentity, AutoIncrementPolicyUUID, AutoIncrementPolicyKeep:
+	default:
+		return fmt.Errorf("invalid auto-increment policy %q, expected %q, %q, %q or %q", policy, AutoIncrementPolicySequence, AutoIncrementPolicyIdentity, AutoIncrementPolicyUUID, AutoIncrementPolicyKeep)
+	}
+	ct, ok := conv.SpSchema[tableId]
+	if !ok {
+		return fmt.Errorf("table %s not found", tableId)
+	}
+	var colIds []string
+	for _, colId := range ct.ColIds {
+		if IsAutoIncrementColumn(conv, tableId, colId) {
+			colIds = append(colIds, colId)
+		}
+	}
+	if len(colIds) == 0 {
+		return fmt.Errorf("table %s has no auto-increment columns", ct.Name)
+	}
+	if policy == AutoIncrementPolicyUUID {
+		for _, colId := range colIds {
+			if err := checkColumnTypeChangeable(conv, tableId, colId); err != nil {
+				return err
+			}
+		}
+	}
+	for _, colId := range colIds {
+		setColumnAutoIncrementPolicy(conv, tableId, colId, policy)
+	}
+	if policy == AutoIncrementPolicySequence {
+		delete(conv.AutoIncrementPolicies, tableId)
+		return nil
+	}
+	if conv.AutoIncrementPolicies == nil {
+		conv.AutoIncrementPolicies = make(map[string]string)
+	}
+	conv.AutoIncrementPolicies[tableId] = policy
+	return nil
+}
+
+// ApplyAutoIncrementPolicy converts the auto-increment columns of all the
+// tables of conv with policy.
+func ApplyAutoIncrementPolicy(conv *Conv, policy string) error {
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		hasAutoIncrement := false
+		for _, colId := range conv.SpSchema[tableId].ColIds {
+			hasAutoIncrement = hasAutoIncrement || IsAutoIncrementColumn(conv, tableId, colId)
+		}
+		if !hasAutoIncrement {
+			continue
+		}
+		if err := SetAutoIncrementPolicy(conv, tableId, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// autoIncrementSequenceId returns the id of the source sequence generating
+// the values of column colId of table tableId.
+func autoIncrementSequenceId(conv *Conv, tableId, colId string) (string, bool) {
+	srcCol, ok := conv.SrcSchema[tableId].ColDefs[colId]
+	if !ok || srcCol.AutoGen.Name == "" || srcCol.Ignored.AutoIncrement {
+		return "", false
+	}
+	switch srcCol.AutoGen.GenerationType {
+	case constants.AUTO_INCREMENT, constants.AUTO_RANDOM, constants.SEQUENCE:
+	default:
+		return "", false
+	}
+	for seqId, seq := range conv.SrcSequences {
+		if seq.Name == srcCol.AutoGen.Name {
+			return seqId, true
+		}
+	}
+	return "", false
+}
+
+// checkColumnTypeChangeable returns an error if column colId of table
+// tableId is referenced by foreign keys or by the primary key of interleaved
+// tables, whose columns must have the same type.
+func checkColumnTypeChangeable(conv *Conv, tableId, colId string) error {
+	name := conv.SpSchema[tableId].ColDefs[colId].Name
+	for _, ct := range conv.SpSchema {
+		for _, fk := range ct.ForeignKeys {
+			if fk.ReferTableId != tableId {
+				continue
+			}
+			for _, referColId := range fk.ReferColumnIds {
+				if referColId == colId {
+					return fmt.Errorf("column %s is referenced by foreign key %s of table %s, and can't be converted to UUIDs", name, fk.Name, ct.Name)
+				}
+			}
+		}
+		if ct.ParentTable.Id != tableId {
+			continue
+		}
+		for _, pk := range conv.SpSchema[tableId].PrimaryKeys {
+			if pk.ColId == colId {
+				return fmt.Errorf("column %s is in the primary key of interleaved table %s, and can't be converted to UUIDs", name, ct.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func setColumnAutoIncrementPolicy(conv *Conv, tableId, colId, policy string) {
+	seqId, _ := autoIncrementSequenceId(conv, tableId, colId)
+	if policy != AutoIncrementPolicySequence {
+		releaseSequence(conv, seqId, tableId, colId)
+	}
+	ct := conv.SpSchema[tableId]
+	cd := ct.ColDefs[colId]
+	var issues []SchemaIssue
+	for _, issue := range conv.SchemaIssues[tableId].ColumnLevelIssues[colId] {
+		if !containsSchemaIssue(autoIncrementIssues, issue) {
+			issues = append(issues, issue)
+		}
+	}
+	cd.T = ddl.Type{Name: ddl.Int64}
+	switch policy {
+	case AutoIncrementPolicySequence:
+		srcSequence := conv.SrcSequences[seqId]
+		seq, ok := conv.SpSequences[seqId]
+		if !ok {
+			seq, _ = ToSpannerSequence(srcSequence)
+		}
+		if seq.ColumnsUsingSeq == nil {
+			seq.ColumnsUsingSeq = make(map[string][]string)
+		}
+		if !containsString(seq.ColumnsUsingSeq[tableId], colId) {
+			seq.ColumnsUsingSeq[tableId] = append(seq.ColumnsUsingSeq[tableId], colId)
+		}
+		conv.SpSequences[seqId] = seq
+		cd.AutoGen = ddl.AutoGenCol{Name: seq.Name, GenerationType: constants.SEQUENCE}
+		if conv.SrcSchema[tableId].ColDefs[colId].AutoGen.GenerationType == constants.AUTO_RANDOM {
+			issues = append(issues, AutoRandom)
+		} else {
+			issues = append(issues, SequenceCreated)
+		}
+		if _, unsupported := ToSpannerSequence(srcSequence); len(unsupported) > 0 {
+			issues = append(issues, SequenceOptionUnsupported)
+		}
+	case AutoIncrementPolicyIdentity:
+		cd.AutoGen = ddl.AutoGenCol{GenerationType: constants.IDENTITY}
+		issues = append(issues, AutoIncrementIdentity)
+	case AutoIncrementPolicyUUID:
+		cd.T = ddl.Type{Name: ddl.String, Len: 36}
+		cd.AutoGen = ddl.AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}
+		issues = append(issues, AutoIncrementUUID)
+	case AutoIncrementPolicyKeep:
+		cd.AutoGen = ddl.AutoGenCol{}
+		issues = append(issues, AutoIncrementKept)
+	}
+	ct.ColDefs[colId] = cd
+	conv.SpSchema[tableId] = ct
+	tableIssues := conv.SchemaIssues[tableId]
+	if tableIssues.ColumnLevelIssues == nil {
+		tableIssues.ColumnLevelIssues = make(map[string][]SchemaIssue)
+	}
+	tableIssues.ColumnLevelIssues[colId] = issues
+	conv.SchemaIssues[tableId] = tableIssues
+}
+
+// releaseSequence removes column colId of table tableId from the columns
+// using Spanner sequence seqId, and removes the sequence if no other column
+// uses it.
+func releaseSequence(conv *Conv, seqId, tableId, colId string) {
+	seq, ok := conv.SpSequences[seqId]
+	if !ok {
+		return
+	}
+	var colIds []string
+	for _, id := range seq.ColumnsUsingSeq[tableId] {
+		if id != colId {
+			colIds = append(colIds, id)
+		}
+	}
+	if len(colIds) > 0 {
+		seq.ColumnsUsingSeq[tableId] = colIds
+	} else {
+		delete(seq.ColumnsUsingSeq, tableId)
+	}
+	if len(seq.ColumnsUsingSeq) == 0 {
+		delete(conv.SpSequences, seqId)
+		return
+	}
+	conv.SpSequences[seqId] = seq
+}
+
+func containsSchemaIssue(issues []SchemaIssue, issue SchemaIssue) bool {
+	for _, i := range issues {
+		if i == issue {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
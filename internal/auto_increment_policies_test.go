@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func autoIncrementPoliciesTestConv() *Conv {
+	conv := MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "int"}, AutoGen: ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.AUTO_INCREMENT}},
+				"c2": {Name: "total", Id: "c2", Type: schema.Type{Name: "int"}},
+			},
+		},
+		"t2": {
+			Name:    "items",
+			Id:      "t2",
+			ColIds:  []string{"c3", "c4"},
+			ColDefs: map[string]schema.Column{"c3": {Name: "id", Id: "c3"}, "c4": {Name: "order_id", Id: "c4"}},
+		},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:        "orders",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, AutoGen: ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.SEQUENCE}},
+				"c2": {Name: "total", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+			},
+		},
+		"t2": {
+			Name:    "items",
+			Id:      "t2",
+			ColIds:  []string{"c3", "c4"},
+			ColDefs: map[string]ddl.ColumnDef{"c3": {Name: "id", Id: "c3", T: ddl.Type{Name: ddl.Int64}}, "c4": {Name: "order_id", Id: "c4", T: ddl.Type{Name: ddl.Int64}}},
+		},
+	}
+	conv.SrcSequences = map[string]ddl.Sequence{"s1": {Id: "s1", Name: "orders_id_seq"}}
+	conv.SpSequences = map[string]ddl.Sequence{"s1": {Id: "s1", Name: "orders_id_seq", SequenceKind: "BIT REVERSED POSITIVE", StartWithCounter: "1000", ColumnsUsingSeq: map[string][]string{"t1": {"c1"}}}}
+	conv.SchemaIssues = map[string]TableIssues{"t1": {ColumnLevelIssues: map[string][]SchemaIssue{"c1": {SequenceCreated}}}}
+	return conv
+}
+
+func TestSetAutoIncrementPolicy(t *testing.T) {
+	conv := autoIncrementPoliciesTestConv()
+	assert.True(t, IsAutoIncrementColumn(conv, "t1", "c1"))
+	assert.False(t, IsAutoIncrementColumn(conv, "t1", "c2"))
+	assert.Equal(t, AutoIncrementPolicySequence, GetAutoIncrementPolicy(conv, "t1"))
+
+	assert.Nil(t, SetAutoIncrementPolicy(conv, "t1", AutoIncrementPolicyIdentity))
+	assert.Equal(t, ddl.AutoGenCol{GenerationType: constants.IDENTITY}, conv.SpSchema["t1"].ColDefs["c1"].AutoGen)
+	assert.Empty(t, conv.SpSequences)
+	assert.Equal(t, []SchemaIssue{AutoIncrementIdentity}, conv.SchemaIssues["t1"].ColumnLevelIssues["c1"])
+	assert.Equal(t, map[string]string{"t1": AutoIncrementPolicyIdentity}, conv.AutoIncrementPolicies)
+	assert.Equal(t, AutoIncrementPolicyIdentity, GetAutoIncrementPolicy(conv, "t1"))
+
+	assert.Nil(t, SetAutoIncrementPolicy(conv, "t1", AutoIncrementPolicyUUID))
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 36}, conv.SpSchema["t1"].ColDefs["c1"].T)
+	assert.Equal(t, ddl.AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}, conv.SpSchema["t1"].ColDefs["c1"].AutoGen)
+	assert.Equal(t, []SchemaIssue{AutoIncrementUUID}, conv.SchemaIssues["t1"].ColumnLevelIssues["c1"])
+
+	assert.Nil(t, SetAutoIncrementPolicy(conv, "t1", AutoIncrementPolicyKeep))
+	assert.Equal(t, ddl.Type{Name: ddl.Int64}, conv.SpSchema["t1"].ColDefs["c1"].T)
+	assert.Equal(t, ddl.AutoGenCol{}, conv.SpSchema["t1"].ColDefs["c1"].AutoGen)
+	assert.Equal(t, []SchemaIssue{AutoIncrementKept}, conv.SchemaIssues["t1"].ColumnLevelIssues["c1"])
+
+	// The sequence is created again from the source sequence.
+	assert.Nil(t, SetAutoIncrementPolicy(conv, "t1", AutoIncrementPolicySequence))
+	assert.Equal(t, ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.SEQUENCE}, conv.SpSchema["t1"].ColDefs["c1"].AutoGen)
+	assert.Equal(t, map[string][]string{"t1": {"c1"}}, conv.SpSequences["s1"].ColumnsUsingSeq)
+	assert.Equal(t, []SchemaIssue{SequenceCreated}, conv.SchemaIssues["t1"].ColumnLevelIssues["c1"])
+	assert.Empty(t, conv.AutoIncrementPolicies)
+
+	assert.NotNil(t, SetAutoIncrementPolicy(conv, "t1", "serial"))
+	assert.NotNil(t, SetAutoIncrementPolicy(conv, "t2", AutoIncrementPolicyKeep))
+}
+
+func TestSetAutoIncrementPolicyKeepsSequence(t *testing.T) {
+	conv := autoIncrementPoliciesTestConv()
+	// Setting the default policy again keeps the changes made to the sequence.
+	assert.Nil(t, SetAutoIncrementPolicy(conv, "t1", AutoIncrementPolicySequence))
+	assert.Equal(t, "1000", conv.SpSequences["s1"].StartWithCounter)
+	assert.Equal(t, map[string][]string{"t1": {"c1"}}, conv.SpSequences["s1"].ColumnsUsingSeq)
+}
+
+func TestSetAutoIncrementPolicyUUIDReferenced(t *testing.T) {
+	conv := autoIncrementPoliciesTestConv()
+	ct := conv.SpSchema["t2"]
+	ct.ForeignKeys = []ddl.Foreignkey{{Name: "fk_items_orders", ColIds: []string{"c4"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}}}
+	conv.SpSchema["t2"] = ct
+	assert.NotNil(t, SetAutoIncrementPolicy(conv, "t1", AutoIncrementPolicyUUID))
+	assert.Equal(t, ddl.Type{Name: ddl.Int64}, conv.SpSchema["t1"].ColDefs["c1"].T)
+	assert.Nil(t, SetAutoIncrementPolicy(conv, "t1", AutoIncrementPolicyIdentity))
+}
+
+func TestApplyAutoIncrementPolicy(t *testing.T) {
+	conv := autoIncrementPoliciesTestConv()
+	assert.Nil(t, ApplyAutoIncrementPolicy(conv, AutoIncrementPolicyKeep))
+	assert.Equal(t, map[string]string{"t1": AutoIncrementPolicyKeep}, conv.AutoIncrementPolicies)
+	assert.NotNil(t, ApplyAutoIncrementPolicy(conv, "serial"))
+}
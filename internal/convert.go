@@ -39,25 +39,79 @@ type Conv struct {
 	ToSource           map[string]NameAndCols       `json:"-"` // Maps from Spanner table name to source-DB table name and column mapping.
 	UsedNames          map[string]bool              `json:"-"` // Map storing the names that are already assigned to tables, indices or foreign key contraints.
 	dataSink           func(table string, cols []string, values []interface{})
-	DataFlush          func()                  `json:"-"` // Data flush is used to flush out remaining writes and wait for them to complete.
-	Location           *time.Location          // Timezone (for timestamp conversion).
-	sampleBadRows      rowSamples              // Rows that generated errors during conversion.
-	Stats              stats                   `json:"-"`
-	TimezoneOffset     string                  // Timezone offset for timestamp conversion.
-	SpDialect          string                  // The dialect of the spanner database to which Spanner migration tool is writing.
-	UniquePKey         map[string][]string     // Maps Spanner table name to unique column name being used as primary key (if needed).
-	Audit              Audit                   `json:"-"` // Stores the audit information for the database conversion
-	Rules              []Rule                  // Stores applied rules during schema conversion
-	IsSharded          bool                    // Flag denoting if the migration is sharded or not
-	ConvLock           sync.RWMutex            `json:"-"` // ConvLock prevents concurrent map read/write operations. This lock will be used in all the APIs that either read or write elements to the conv object.
-	SpRegion           string                  // Leader Region for Spanner Instance
-	ResourceValidation bool                    // Flag denoting if validation for resources to generated is complete
-	UI                 bool                    // Flag if UI interface was used for migration. ToDo: Remove flag after resource generation is introduced to UI
-	SpSequences        map[string]ddl.Sequence // Maps Spanner Sequences to Sequence Schema
-	SrcSequences       map[string]ddl.Sequence // Maps source-DB Sequences to Sequence schema information
-	SpProjectId        string                  // Spanner Project Id
-	SpInstanceId       string                  // Spanner Instance Id
-	Source             string                  // Source Database type being migrated
+	DataFlush          func()                       `json:"-"` // Data flush is used to flush out remaining writes and wait for them to complete.
+	DataFlushTable     func(table string)           `json:"-"` // Flushes out the remaining writes of a Spanner table and waits for them to complete, while other tables are written.
+	Location           *time.Location               // Timezone (for timestamp conversion).
+	sampleBadRows      rowSamples                   // Rows that generated errors during conversion.
+	Stats              stats                        `json:"-"`
+	TimezoneOffset     string                       // Timezone offset for timestamp conversion.
+	SrcCharset         string                       // Character set of the values of dump files, e.g. set by SET NAMES.
+	SpDialect          string                       // The dialect of the spanner database to which Spanner migration tool is writing.
+	UniquePKey         map[string][]string          // Maps Spanner table name to unique column name being used as primary key (if needed).
+	Audit              Audit                        `json:"-"` // Stores the audit information for the database conversion
+	Rules              []Rule                       // Stores applied rules during schema conversion
+	IsSharded          bool                         // Flag denoting if the migration is sharded or not
+	ConvLock           sync.RWMutex                 `json:"-"` // ConvLock prevents concurrent map read/write operations. This lock will be used in all the APIs that either read or write elements to the conv object.
+	SpRegion           string                       // Leader Region for Spanner Instance
+	ResourceValidation bool                         // Flag denoting if validation for resources to generated is complete
+	UI                 bool                         // Flag if UI interface was used for migration. ToDo: Remove flag after resource generation is introduced to UI
+	SpSequences        map[string]ddl.Sequence      // Maps Spanner Sequences to Sequence Schema
+	SrcSequences       map[string]ddl.Sequence      // Maps source-DB Sequences to Sequence schema information
+	SpViews            map[string]ddl.CreateView    // Maps Spanner view id to view definition
+	SrcViews           map[string]schema.View       // Maps source-DB view id to view information
+	ViewIssues         map[string]string            // Maps source-DB view id to the reason it wasn't translated to a Spanner view
+	SrcRoutines        map[string]schema.Routine    // Maps source-DB routine id to stored procedure, function or trigger information
+	SpChangeStreams    map[string]ddl.ChangeStream  // Maps Spanner change stream id to change stream definition
+	SpLocalityGroups   map[string]ddl.LocalityGroup // Maps Spanner locality group id to locality group definition
+	SpPlacements       map[string]ddl.Placement     // Maps Spanner placement id to placement definition
+	SpModels           map[string]ddl.Model         // Maps Spanner model id to model definition
+	SpPropertyGraphs   map[string]ddl.PropertyGraph // Maps Spanner property graph id to property graph definition
+	SpDatabaseOptions  ddl.DatabaseOptions          // Options of the Spanner database, set along with the schema
+	ProtoDescriptors   []byte                       // Serialized FileDescriptorSet for the proto types used by PROTO and ENUM columns
+	SpProjectId        string                       // Spanner Project Id
+	SpInstanceId       string                       // Spanner Instance Id
+	Source             string                       // Source Database type being migrated
+
+	// Maps Spanner table id to column id to the length of the STRING or BYTES
+	// column inferred from the source data, see ApplyInferredLength.
+	InferredLengths map[string]map[string]InferredLength
+
+	// Maps source table id to the single-table design detected for the
+	// table, proposing to split it into a table per entity type.
+	SingleTableDesigns map[string]SingleTableDesign
+
+	// Maps the names of the user-defined types used by source columns to
+	// their definitions.
+	UserDefinedTypes map[string]UserDefinedType
+
+	// Maps Spanner table id to column id to the id of the CHECK constraint
+	// allowing only the values of the source ENUM column, see
+	// SetEnumStrategy.
+	EnumCheckConstraints map[string]map[string]string
+
+	// Maps Spanner table id to the policy its auto-increment columns were
+	// converted with, when not AutoIncrementPolicySequence, see
+	// SetAutoIncrementPolicy.
+	AutoIncrementPolicies map[string]string
+
+	// If true, the comments of tables and columns are kept in PostgreSQL
+	// dialect databases with COMMENT ON statements, see
+	// ddl.Config.CommentStatements.
+	CommentStatements bool
+
+	// TableFilters select the source tables whose schema and data are
+	// migrated, from the include-tables and exclude-tables flags.
+	TableFilters schema.TableFilters
+
+	// Maps Spanner table id to column id to the value written in place of
+	// the values of the redacted column, or "" for NULL, see
+	// conversion.ApplyColumnPolicies.
+	RedactedColumns map[string]map[string]string
+
+	// Number of tables whose rows are written to Spanner concurrently when
+	// migrating the data of a source database, see
+	// common.InfoSchemaImpl.ProcessData. At most one when not set.
+	ConcurrentTables int `json:"-"`
 }
 
 type InvalidCheckExp struct {
@@ -148,6 +202,34 @@ const (
 	CheckConstraintFunctionNotFoundError
 	GenericError
 	GenericWarning
+	SequenceOptionUnsupported
+	SchemaLimitExceeded
+	DistributionKey
+	SortKey
+	Money
+	LargeObject
+	AutoRandom
+	MultipleEntityTypes
+	SetToArray
+	CollectionToJSON
+	UserTypeToJSON
+	CounterSnapshot
+	Spatial
+	GeneratedColumnMaterialized
+	CaseInsensitiveText
+	CollationChanged
+	SetToString
+	TemporalTable
+	TemporalHistoryTable
+	TemporalPeriod
+	IndexExpressionColumn
+	IndexExpressionDropped
+	MaterializedViewTable
+	AutoIncrementIdentity
+	AutoIncrementUUID
+	AutoIncrementKept
+	UniqueConstraintDropped
+	UUIDAsString
 )
 
 const (
@@ -364,9 +446,32 @@ func MakeConv() *Conv {
 			StreamingStats: streamingStats{},
 			MigrationType:  migration.MigrationData_SCHEMA_ONLY.Enum(),
 		},
-		Rules:        []Rule{},
-		SpSequences:  make(map[string]ddl.Sequence),
-		SrcSequences: make(map[string]ddl.Sequence),
+		Rules:            []Rule{},
+		SpSequences:      make(map[string]ddl.Sequence),
+		SrcSequences:     make(map[string]ddl.Sequence),
+		SpViews:          make(map[string]ddl.CreateView),
+		SrcViews:         make(map[string]schema.View),
+		ViewIssues:       make(map[string]string),
+		SrcRoutines:      make(map[string]schema.Routine),
+		SpChangeStreams:  make(map[string]ddl.ChangeStream),
+		SpLocalityGroups: make(map[string]ddl.LocalityGroup),
+		SpPlacements:     make(map[string]ddl.Placement),
+		SpModels:         make(map[string]ddl.Model),
+		SpPropertyGraphs: make(map[string]ddl.PropertyGraph),
+	}
+}
+
+// SpSchemaObjects returns the Spanner schema objects, other than tables and
+// sequences, that are printed alongside the tables.
+func (conv *Conv) SpSchemaObjects() ddl.SchemaObjects {
+	return ddl.SchemaObjects{
+		Views:           conv.SpViews,
+		ChangeStreams:   conv.SpChangeStreams,
+		LocalityGroups:  conv.SpLocalityGroups,
+		Placements:      conv.SpPlacements,
+		Models:          conv.SpModels,
+		PropertyGraphs:  conv.SpPropertyGraphs,
+		DatabaseOptions: conv.SpDatabaseOptions,
 	}
 }
 
@@ -409,6 +514,7 @@ func (conv *Conv) SetDataMode() {
 
 // WriteRow calls dataSink and updates row stats.
 func (conv *Conv) WriteRow(srcTable, spTable string, spCols []string, spVals []interface{}) {
+	spVals = conv.redact(spTable, spCols, spVals)
 	if conv.Audit.DryRun {
 		conv.statsAddGoodRow(srcTable, conv.DataMode())
 	} else if conv.dataSink == nil {
@@ -424,6 +530,32 @@ func (conv *Conv) WriteRow(srcTable, spTable string, spCols []string, spVals []i
 	}
 }
 
+// redact returns spVals with the values of the redacted columns of Spanner
+// table spTable replaced by their mask, or NULL.
+func (conv *Conv) redact(spTable string, spCols []string, spVals []interface{}) []interface{} {
+	for tableId, masks := range conv.RedactedColumns {
+		ct, ok := conv.SpSchema[tableId]
+		if !ok || ct.Name != spTable {
+			continue
+		}
+		redacted := append([]interface{}{}, spVals...)
+		for colId, mask := range masks {
+			for i, col := range spCols {
+				if col != ct.ColDefs[colId].Name {
+					continue
+				}
+				if mask == "" {
+					redacted[i] = nil
+				} else {
+					redacted[i] = mask
+				}
+			}
+		}
+		return redacted
+	}
+	return spVals
+}
+
 // Rows returns the total count of data rows processed.
 func (conv *Conv) Rows() int64 {
 	n := int64(0)
@@ -498,7 +630,7 @@ func (conv *Conv) AddShardIdColumn() {
 			colName := conv.buildColumnNameWithBase(t, ShardIdColumn)
 			columnId := GenerateColumnId()
 			ct.ColIds = append(ct.ColIds, columnId)
-			ct.ColDefs[columnId] = ddl.ColumnDef{Name: colName, Id: columnId, T: ddl.Type{Name: ddl.String, Len: 50}, NotNull: false, AutoGen: ddl.AutoGenCol{Name: "", GenerationType: ""}}
+			ct.ColDefs[columnId] = ddl.ColumnDef{Name: colName, Id: columnId, T: ddl.Type{Name: ddl.String, Len: 50}, NotNull: false, AutoGen: ddl.AutoGenCol{Name: "", GenerationType: ""}, Hidden: true}
 			ct.ShardIdColumn = columnId
 			conv.SpSchema[t] = ct
 			var issues []SchemaIssue
@@ -44,6 +44,30 @@ func TestSetDataMode(t *testing.T) {
 	assert.True(t, conv.DataMode())
 }
 
+func TestWriteRowRedactedColumns(t *testing.T) {
+	conv := MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "customers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1"},
+				"c2": {Name: "ssn", Id: "c2"},
+				"c3": {Name: "birth_year", Id: "c3"},
+			},
+		},
+	}
+	conv.RedactedColumns = map[string]map[string]string{"t1": {"c2": "XXX-XX-XXXX", "c3": ""}}
+	var rows [][]interface{}
+	conv.SetDataSink(func(table string, cols []string, vals []interface{}) { rows = append(rows, vals) })
+	vals := []interface{}{int64(1), "123-45-6789", int64(1980)}
+	conv.WriteRow("customers", "customers", []string{"id", "ssn", "birth_year"}, vals)
+	conv.WriteRow("orders", "orders", []string{"id", "ssn"}, []interface{}{int64(2), "123-45-6789"})
+	assert.Equal(t, [][]interface{}{{int64(1), "XXX-XX-XXXX", nil}, {int64(2), "123-45-6789"}}, rows)
+	assert.Equal(t, "123-45-6789", vals[1])
+}
+
 func TestRows(t *testing.T) {
 	conv := MakeConv()
 	conv.Stats.Rows["table1"] = 42
@@ -0,0 +1,194 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Strategies of converting source ENUM columns, e.g. MySQL ENUM columns or
+// columns of PostgreSQL enum types, to Spanner. They also apply to MySQL SET
+// columns, converted to arrays or comma-separated strings of their members.
+const (
+	// EnumStrategyString converts ENUM columns to STRING columns. This is
+	// the default.
+	EnumStrategyString = "string"
+	// EnumStrategyCheckConstraint converts ENUM columns to STRING columns
+	// with a CHECK constraint allowing only the values of the ENUM, and SET
+	// columns to columns allowing only its members.
+	EnumStrategyCheckConstraint = "check-constraint"
+)
+
+// IsEnumColumn reports whether column colId of table tableId was converted
+// from a source ENUM column with known values to a STRING column, or from a
+// SET column to an ARRAY<STRING> or STRING column, which enum strategies
+// apply to.
+func IsEnumColumn(conv *Conv, tableId, colId string) bool {
+	srcCol, ok := conv.SrcSchema[tableId].ColDefs[colId]
+	if !ok || (srcCol.Type.Name != "enum" && srcCol.Type.Name != "set") || len(srcCol.EnumValues) == 0 {
+		return false
+	}
+	spCol, ok := conv.SpSchema[tableId].ColDefs[colId]
+	return ok && spCol.T.Name == ddl.String && (!spCol.T.IsArray || srcCol.Type.Name == "set")
+}
+
+// GetEnumStrategy returns the strategy column colId of table tableId was
+// converted with.
+func GetEnumStrategy(conv *Conv, tableId, colId string) string {
+	if _, ok := conv.EnumCheckConstraints[tableId][colId]; ok {
+		return EnumStrategyCheckConstraint
+	}
+	return EnumStrategyString
+}
+
+// SetEnumStrategy converts column colId of table tableId with strategy,
+// adding or removing the CHECK constraint of the column. The CHECK
+// constraints added are recorded in conv.EnumCheckConstraints. Columns can
+// always be set back to EnumStrategyString, e.g. after their type changed.
+func SetEnumStrategy(conv *Conv, tableId, colId, strategy string) error {
+	switch strategy {
+	case EnumStrategyString:
+		removeEnumCheckConstraint(conv, tableId, colId)
+		return nil
+	case EnumStrategyCheckConstraint:
+	default:
+		return fmt.Errorf("invalid enum strategy %q, expected %q or %q", strategy, EnumStrategyString, EnumStrategyCheckConstraint)
+	}
+	if !IsEnumColumn(conv, tableId, colId) {
+		return fmt.Errorf("column %s of table %s isn't an ENUM or SET column converted to STRING", colId, tableId)
+	}
+	ct := conv.SpSchema[tableId]
+	srcCol := conv.SrcSchema[tableId].ColDefs[colId]
+	var expr string
+	if srcCol.Type.Name == "set" {
+		expr = setCheckExpr(conv.SpDialect, ct.ColDefs[colId], srcCol.EnumValues)
+	} else {
+		expr = enumCheckExpr(conv.SpDialect, ct.ColDefs[colId].Name, srcCol.EnumValues)
+	}
+	if ccId, ok := conv.EnumCheckConstraints[tableId][colId]; ok {
+		for i := range ct.CheckConstraints {
+			if ct.CheckConstraints[i].Id == ccId {
+				ct.CheckConstraints[i].Expr = expr
+			}
+		}
+		conv.SpSchema[tableId] = ct
+		return nil
+	}
+	cc := ddl.CheckConstraint{
+		Id:     GenerateCheckConstrainstId(),
+		Name:   ToSpannerCheckConstraintName(conv, fmt.Sprintf("chk_%s_%s", ct.Name, ct.ColDefs[colId].Name)),
+		Expr:   expr,
+		ExprId: GenerateExpressionId(),
+	}
+	ct.CheckConstraints = append(ct.CheckConstraints, cc)
+	conv.SpSchema[tableId] = ct
+	if conv.EnumCheckConstraints == nil {
+		conv.EnumCheckConstraints = make(map[string]map[string]string)
+	}
+	if conv.EnumCheckConstraints[tableId] == nil {
+		conv.EnumCheckConstraints[tableId] = make(map[string]string)
+	}
+	conv.EnumCheckConstraints[tableId][colId] = cc.Id
+	return nil
+}
+
+// ApplyEnumStrategy converts all the ENUM and SET columns of conv with
+// strategy.
+func ApplyEnumStrategy(conv *Conv, strategy string) error {
+	for tableId, ct := range conv.SpSchema {
+		for _, colId := range ct.ColIds {
+			if !IsEnumColumn(conv, tableId, colId) {
+				continue
+			}
+			if err := SetEnumStrategy(conv, tableId, colId, strategy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func removeEnumCheckConstraint(conv *Conv, tableId, colId string) {
+	ccId, ok := conv.EnumCheckConstraints[tableId][colId]
+	if !ok {
+		return
+	}
+	ct := conv.SpSchema[tableId]
+	var ccs []ddl.CheckConstraint
+	for _, cc := range ct.CheckConstraints {
+		if cc.Id != ccId {
+			ccs = append(ccs, cc)
+		}
+	}
+	ct.CheckConstraints = ccs
+	conv.SpSchema[tableId] = ct
+	delete(conv.EnumCheckConstraints[tableId], colId)
+}
+
+// enumCheckExpr returns the expression of the CHECK constraint allowing
+// only values of column colName, in the dialect of the Spanner database.
+func enumCheckExpr(dialect, colName string, values []string) string {
+	return fmt.Sprintf("(%s IN (%s))", quoteColumn(dialect, colName), strings.Join(stringLiterals(dialect, values), ", "))
+}
+
+// setCheckExpr returns the expression of the CHECK constraint allowing only
+// the members of a SET column, whether its values are arrays of members or
+// strings of comma-separated members.
+func setCheckExpr(dialect string, cd ddl.ColumnDef, members []string) string {
+	col := quoteColumn(dialect, cd.Name)
+	if cd.T.IsArray {
+		literals := strings.Join(stringLiterals(dialect, members), ", ")
+		if dialect == constants.DIALECT_POSTGRESQL {
+			return fmt.Sprintf("(%s <@ ARRAY[%s])", col, literals)
+		}
+		return fmt.Sprintf("ARRAY_INCLUDES_ALL([%s], %s)", literals, col)
+	}
+	var alternatives []string
+	for _, m := range members {
+		alternatives = append(alternatives, regexp.QuoteMeta(m))
+	}
+	member := "(" + strings.Join(alternatives, "|") + ")"
+	pattern := stringLiterals(dialect, []string{"^(" + member + "(," + member + ")*)?$"})[0]
+	if dialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Sprintf("(%s ~ %s)", col, pattern)
+	}
+	return fmt.Sprintf("REGEXP_CONTAINS(%s, %s)", col, pattern)
+}
+
+// stringLiterals returns the string literals of values, in the dialect of
+// the Spanner database.
+func stringLiterals(dialect string, values []string) []string {
+	var literals []string
+	for _, v := range values {
+		if dialect == constants.DIALECT_POSTGRESQL {
+			literals = append(literals, "'"+strings.ReplaceAll(v, "'", "''")+"'")
+		} else {
+			literals = append(literals, "'"+strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v)+"'")
+		}
+	}
+	return literals
+}
+
+func quoteColumn(dialect, colName string) string {
+	if dialect == constants.DIALECT_POSTGRESQL {
+		return `"` + colName + `"`
+	}
+	return "`" + colName + "`"
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func enumStrategiesTestConv() *Conv {
+	conv := MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name:   "shirts",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "int"}},
+				"c2": {Name: "size", Id: "c2", Type: schema.Type{Name: "enum"}, EnumValues: []string{"small", "it's large"}},
+				"c3": {Name: "color", Id: "c3", Type: schema.Type{Name: "enum"}, EnumValues: []string{"red", "blue"}},
+			},
+		},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "shirts",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "size", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c3": {Name: "color", Id: "c3", T: ddl.Type{Name: ddl.Enum, ProtoName: "shop.shirts_color"}},
+			},
+		},
+	}
+	return conv
+}
+
+func TestSetEnumStrategy(t *testing.T) {
+	conv := enumStrategiesTestConv()
+	assert.True(t, IsEnumColumn(conv, "t1", "c2"))
+	// Columns mapped to proto enums aren't STRING columns.
+	assert.False(t, IsEnumColumn(conv, "t1", "c3"))
+	assert.Equal(t, EnumStrategyString, GetEnumStrategy(conv, "t1", "c2"))
+
+	assert.Nil(t, SetEnumStrategy(conv, "t1", "c2", EnumStrategyCheckConstraint))
+	ccs := conv.SpSchema["t1"].CheckConstraints
+	assert.Equal(t, 1, len(ccs))
+	assert.Equal(t, "chk_shirts_size", ccs[0].Name)
+	assert.Equal(t, "(`size` IN ('small', 'it\\'s large'))", ccs[0].Expr)
+	assert.Equal(t, map[string]map[string]string{"t1": {"c2": ccs[0].Id}}, conv.EnumCheckConstraints)
+	assert.Equal(t, EnumStrategyCheckConstraint, GetEnumStrategy(conv, "t1", "c2"))
+
+	// Setting the strategy again updates the constraint, e.g. after the
+	// column was renamed.
+	ct := conv.SpSchema["t1"]
+	cd := ct.ColDefs["c2"]
+	cd.Name = "shirt_size"
+	ct.ColDefs["c2"] = cd
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	assert.Nil(t, SetEnumStrategy(conv, "t1", "c2", EnumStrategyCheckConstraint))
+	assert.Equal(t, 1, len(conv.SpSchema["t1"].CheckConstraints))
+	assert.Equal(t, `("shirt_size" IN ('small', 'it''s large'))`, conv.SpSchema["t1"].CheckConstraints[0].Expr)
+
+	assert.Nil(t, SetEnumStrategy(conv, "t1", "c2", EnumStrategyString))
+	assert.Empty(t, conv.SpSchema["t1"].CheckConstraints)
+	assert.Equal(t, EnumStrategyString, GetEnumStrategy(conv, "t1", "c2"))
+
+	assert.NotNil(t, SetEnumStrategy(conv, "t1", "c2", "proto"))
+	assert.NotNil(t, SetEnumStrategy(conv, "t1", "c1", EnumStrategyCheckConstraint))
+	assert.NotNil(t, SetEnumStrategy(conv, "t1", "c3", EnumStrategyCheckConstraint))
+}
+
+func TestApplyEnumStrategy(t *testing.T) {
+	conv := enumStrategiesTestConv()
+	assert.Nil(t, ApplyEnumStrategy(conv, EnumStrategyCheckConstraint))
+	assert.Equal(t, 1, len(conv.SpSchema["t1"].CheckConstraints))
+	assert.Equal(t, EnumStrategyCheckConstraint, GetEnumStrategy(conv, "t1", "c2"))
+
+	assert.NotNil(t, ApplyEnumStrategy(conv, "proto"))
+}
+
+func TestSetEnumStrategySetColumns(t *testing.T) {
+	conv := MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name:   "shirts",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "tags", Id: "c1", Type: schema.Type{Name: "set", ArrayBounds: []int64{-1}}, EnumValues: []string{"a.b", "it's"}},
+				"c2": {Name: "sizes", Id: "c2", Type: schema.Type{Name: "set", ArrayBounds: []int64{-1}}, EnumValues: []string{"s", "m"}},
+			},
+		},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "shirts",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "tags", Id: "c1", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+				"c2": {Name: "sizes", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+		},
+	}
+	assert.True(t, IsEnumColumn(conv, "t1", "c1"))
+	assert.True(t, IsEnumColumn(conv, "t1", "c2"))
+
+	assert.Nil(t, ApplyEnumStrategy(conv, EnumStrategyCheckConstraint))
+	exprs := map[string]string{}
+	for _, cc := range conv.SpSchema["t1"].CheckConstraints {
+		exprs[cc.Name] = cc.Expr
+	}
+	assert.Equal(t, map[string]string{
+		"chk_shirts_tags":  "ARRAY_INCLUDES_ALL(['a.b', 'it\\'s'], `tags`)",
+		"chk_shirts_sizes": "REGEXP_CONTAINS(`sizes`, '^((s|m)(,(s|m))*)?$')",
+	}, exprs)
+
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	assert.Nil(t, ApplyEnumStrategy(conv, EnumStrategyCheckConstraint))
+	exprs = map[string]string{}
+	for _, cc := range conv.SpSchema["t1"].CheckConstraints {
+		exprs[cc.Name] = cc.Expr
+	}
+	assert.Equal(t, map[string]string{
+		"chk_shirts_tags":  `("tags" <@ ARRAY['a.b', 'it''s'])`,
+		"chk_shirts_sizes": `("sizes" ~ '^((s|m)(,(s|m))*)?$')`,
+	}, exprs)
+}
@@ -76,6 +76,21 @@ func GenerateRuleId() string {
 func GenerateSequenceId() string {
 	return GenerateId("s")
 }
+func GenerateChangeStreamId() string {
+	return GenerateId("cs")
+}
+func GenerateLocalityGroupId() string {
+	return GenerateId("lg")
+}
+func GeneratePlacementId() string {
+	return GenerateId("pl")
+}
+func GenerateModelId() string {
+	return GenerateId("ml")
+}
+func GeneratePropertyGraphId() string {
+	return GenerateId("gr")
+}
 func GenerateExpressionId() string {
 	return GenerateId("e")
 }
@@ -167,9 +182,132 @@ func ComputeUsedNames(conv *Conv) map[string]bool {
 			usedNames[strings.ToLower(fk.Name)] = true
 		}
 	}
+	for _, view := range conv.SpViews {
+		usedNames[strings.ToLower(view.Name)] = true
+	}
+	for _, cs := range conv.SpChangeStreams {
+		usedNames[strings.ToLower(cs.Name)] = true
+	}
+	for _, m := range conv.SpModels {
+		usedNames[strings.ToLower(m.Name)] = true
+	}
+	for _, pg := range conv.SpPropertyGraphs {
+		usedNames[strings.ToLower(pg.Name)] = true
+	}
 	return usedNames
 }
 
+// ValidateChangeStream checks that the tables and columns watched by a change
+// stream exist in the Spanner schema and that its value capture type is supported.
+func ValidateChangeStream(spSchema ddl.Schema, cs ddl.ChangeStream) error {
+	if cs.Name == "" {
+		return fmt.Errorf("change stream name is empty")
+	}
+	if cs.ValueCaptureType != "" {
+		supported := false
+		for _, vct := range ddl.ChangeStreamValueCaptureTypes {
+			if strings.EqualFold(vct, cs.ValueCaptureType) {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("value capture type %s is not supported for change stream %s", cs.ValueCaptureType, cs.Name)
+		}
+	}
+	if cs.WatchAll {
+		return nil
+	}
+	for _, wt := range cs.WatchedTables {
+		table, ok := spSchema[wt.TableId]
+		if !ok {
+			return fmt.Errorf("table id %s watched by change stream %s not found", wt.TableId, cs.Name)
+		}
+		for _, colId := range wt.ColIds {
+			if _, ok := table.ColDefs[colId]; !ok {
+				return fmt.Errorf("column id %s of table %s watched by change stream %s not found", colId, table.Name, cs.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateLocalityGroup checks that a locality group has a unique name and a
+// supported storage type.
+func ValidateLocalityGroup(localityGroups map[string]ddl.LocalityGroup, lg ddl.LocalityGroup) error {
+	if lg.Name == "" {
+		return fmt.Errorf("locality group name is empty")
+	}
+	for id, other := range localityGroups {
+		if id != lg.Id && strings.EqualFold(other.Name, lg.Name) {
+			return fmt.Errorf("locality group name %s is already used", lg.Name)
+		}
+	}
+	if lg.Storage != "" {
+		supported := false
+		for _, st := range ddl.LocalityGroupStorageTypes {
+			if strings.EqualFold(st, lg.Storage) {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("storage %s is not supported for locality group %s", lg.Storage, lg.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateModel checks that a model has a unique name, exactly one of an
+// endpoint or a list of endpoints, and either both input and output columns
+// or neither.
+func ValidateModel(models map[string]ddl.Model, m ddl.Model) error {
+	if m.Name == "" {
+		return fmt.Errorf("model name is empty")
+	}
+	for id, other := range models {
+		if id != m.Id && strings.EqualFold(other.Name, m.Name) {
+			return fmt.Errorf("model name %s is already used", m.Name)
+		}
+	}
+	if (m.Endpoint == "") == (len(m.Endpoints) == 0) {
+		return fmt.Errorf("model %s must have either an endpoint or a list of endpoints", m.Name)
+	}
+	if (len(m.Inputs) == 0) != (len(m.Outputs) == 0) {
+		return fmt.Errorf("model %s must have both input and output columns, or neither", m.Name)
+	}
+	if m.DefaultBatchSize < 0 {
+		return fmt.Errorf("default batch size of model %s is negative", m.Name)
+	}
+	return nil
+}
+
+// SetPlacementKey makes column colId the placement key of table tableId, or
+// removes the placement key of the table if colId is empty. The placement key
+// must be a STRING column of a table that isn't interleaved, as interleaved
+// tables are stored along with their parent rows.
+func SetPlacementKey(conv *Conv, tableId, colId string) error {
+	ct, found := conv.SpSchema[tableId]
+	if !found {
+		return fmt.Errorf("table id %s not found in the Spanner schema", tableId)
+	}
+	if colId != "" {
+		cd, found := ct.ColDefs[colId]
+		if !found {
+			return fmt.Errorf("column id %s not found in table %s", colId, ct.Name)
+		}
+		if cd.T.Name != ddl.String || cd.T.IsArray {
+			return fmt.Errorf("placement key column %s of table %s must be of type STRING", cd.Name, ct.Name)
+		}
+		if ct.ParentTable.Id != "" {
+			return fmt.Errorf("interleaved table %s can't have a placement key", ct.Name)
+		}
+	}
+	ct.PlacementKey = colId
+	conv.SpSchema[tableId] = ct
+	return nil
+}
+
 func GetSrcTableByName(srcSchema map[string]schema.Table, name string) (*schema.Table, bool) {
 	for _, v := range srcSchema {
 		if v.Name == name {
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"math"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// DefaultStringLengthMargin is the factor applied to the longest sampled
+// value of a column when inferring its length, if no margin is set.
+const DefaultStringLengthMargin = 1.5
+
+// InferredLength is the length of a STRING or BYTES column inferred from a
+// sample of the source data.
+type InferredLength struct {
+	MaxSampled int64 // Length of the longest sampled value.
+	Length     int64 // Length given to the Spanner column.
+}
+
+// HasInferableLength reports whether the length of column cd can be inferred
+// from the source data: STRING columns, and BYTES columns for GoogleSQL, of
+// unbounded length, e.g. mapped from source TEXT columns. Columns whose
+// length was already inferred qualify again, so that inference can be
+// re-run on a new sample.
+func HasInferableLength(conv *Conv, tableId string, cd ddl.ColumnDef) bool {
+	if _, ok := conv.InferredLengths[tableId][cd.Id]; ok {
+		return true
+	}
+	if cd.T.IsArray || (cd.T.Len != ddl.MaxLength && cd.T.Len < ddl.PGMaxLength) {
+		return false
+	}
+	// PostgreSQL bytea columns have no length.
+	return cd.T.Name == ddl.String || (cd.T.Name == ddl.Bytes && conv.SpDialect != constants.DIALECT_POSTGRESQL)
+}
+
+// ApplyInferredLength sets the length of column colId of table tableId to the
+// length of its longest sampled value, maxSampled, times margin, and records
+// it in conv.InferredLengths. Columns keep an unbounded length if no
+// non-empty value was sampled or if the inferred length exceeds the maximum
+// length of their type.
+func ApplyInferredLength(conv *Conv, tableId, colId string, maxSampled int64, margin float64) {
+	ct := conv.SpSchema[tableId]
+	cd := ct.ColDefs[colId]
+	maxLength := int64(ddl.StringMaxLength)
+	if cd.T.Name == ddl.Bytes {
+		maxLength = ddl.BytesMaxLength
+	}
+	length := int64(math.Ceil(float64(maxSampled) * margin))
+	if maxSampled <= 0 || length > maxLength {
+		if _, ok := conv.InferredLengths[tableId][colId]; ok {
+			delete(conv.InferredLengths[tableId], colId)
+			cd.T.Len = ddl.MaxLength
+			ct.ColDefs[colId] = cd
+		}
+		return
+	}
+	cd.T.Len = length
+	ct.ColDefs[colId] = cd
+	if conv.InferredLengths == nil {
+		conv.InferredLengths = make(map[string]map[string]InferredLength)
+	}
+	if conv.InferredLengths[tableId] == nil {
+		conv.InferredLengths[tableId] = make(map[string]InferredLength)
+	}
+	conv.InferredLengths[tableId][colId] = InferredLength{MaxSampled: maxSampled, Length: length}
+}
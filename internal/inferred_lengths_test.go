@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func inferredLengthsTestConv() *Conv {
+	conv := MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "notes",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4", "c5"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "body", Id: "c1", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c2": {Name: "title", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 100}},
+				"c3": {Name: "data", Id: "c3", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+				"c4": {Name: "tags", Id: "c4", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+				"c5": {Name: "id", Id: "c5", T: ddl.Type{Name: ddl.Int64}},
+			},
+		},
+	}
+	return conv
+}
+
+func TestHasInferableLength(t *testing.T) {
+	conv := inferredLengthsTestConv()
+	cols := conv.SpSchema["t1"].ColDefs
+	assert.True(t, HasInferableLength(conv, "t1", cols["c1"]))
+	assert.False(t, HasInferableLength(conv, "t1", cols["c2"]))
+	assert.True(t, HasInferableLength(conv, "t1", cols["c3"]))
+	assert.False(t, HasInferableLength(conv, "t1", cols["c4"]))
+	assert.False(t, HasInferableLength(conv, "t1", cols["c5"]))
+
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	assert.True(t, HasInferableLength(conv, "t1", cols["c1"]))
+	assert.False(t, HasInferableLength(conv, "t1", cols["c3"]))
+
+	// Columns whose length was inferred remain inferable.
+	ApplyInferredLength(conv, "t1", "c1", 40, 1.5)
+	assert.True(t, HasInferableLength(conv, "t1", conv.SpSchema["t1"].ColDefs["c1"]))
+}
+
+func TestApplyInferredLength(t *testing.T) {
+	conv := inferredLengthsTestConv()
+	ApplyInferredLength(conv, "t1", "c1", 41, 1.5)
+	ApplyInferredLength(conv, "t1", "c3", 1000, 2)
+	assert.Equal(t, int64(62), conv.SpSchema["t1"].ColDefs["c1"].T.Len)
+	assert.Equal(t, int64(2000), conv.SpSchema["t1"].ColDefs["c3"].T.Len)
+	assert.Equal(t, map[string]map[string]InferredLength{
+		"t1": {
+			"c1": {MaxSampled: 41, Length: 62},
+			"c3": {MaxSampled: 1000, Length: 2000},
+		},
+	}, conv.InferredLengths)
+
+	// Columns with no sampled value, or too long values, are unbounded.
+	ApplyInferredLength(conv, "t1", "c1", 0, 1.5)
+	ApplyInferredLength(conv, "t1", "c3", ddl.BytesMaxLength, 1.5)
+	assert.Equal(t, int64(ddl.MaxLength), conv.SpSchema["t1"].ColDefs["c1"].T.Len)
+	assert.Equal(t, int64(ddl.MaxLength), conv.SpSchema["t1"].ColDefs["c3"].T.Len)
+	assert.Empty(t, conv.InferredLengths["t1"])
+}
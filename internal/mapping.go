@@ -16,6 +16,7 @@ package internal
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -54,6 +55,39 @@ func GetSpannerTable(conv *Conv, tableId string) (string, error) {
 	return spTableName, nil
 }
 
+// SetSourceTableSynonym keeps the source DB name of a table that was renamed
+// when mapped to Spanner addressable as a synonym of the Spanner table, so that
+// applications can keep using the old name during cutover. If keep is false,
+// any synonym of the table is removed. The source name can't be used as a
+// synonym if it isn't a legal Spanner name or clashes with another name.
+func SetSourceTableSynonym(conv *Conv, tableId string, keep bool) error {
+	sp, found := conv.SpSchema[tableId]
+	if !found {
+		return fmt.Errorf("table id %s not found in the Spanner schema", tableId)
+	}
+	if sp.Synonym != "" {
+		delete(conv.UsedNames, strings.ToLower(sp.Synonym))
+		sp.Synonym = ""
+	}
+	if keep {
+		srcTableName := conv.SrcSchema[tableId].Name
+		if strings.EqualFold(srcTableName, sp.Name) {
+			conv.SpSchema[tableId] = sp
+			return nil
+		}
+		if _, changed := FixName(srcTableName); changed {
+			return fmt.Errorf("source table name %s is not a valid Spanner name and can't be used as a synonym", srcTableName)
+		}
+		if _, found := conv.UsedNames[strings.ToLower(srcTableName)]; found {
+			return fmt.Errorf("source table name %s is already used in the Spanner schema and can't be used as a synonym", srcTableName)
+		}
+		conv.UsedNames[strings.ToLower(srcTableName)] = true
+		sp.Synonym = srcTableName
+	}
+	conv.SpSchema[tableId] = sp
+	return nil
+}
+
 // GetSpannerCol maps a source DB table/column into a legal Spanner column
 // name. If mustExist is true, we return error if the column is new.
 // Note that source DB column names can be essentially any string, but
@@ -251,6 +285,62 @@ func ToSpannerCheckConstraintName(conv *Conv, srcCheckConstraintName string) str
 	return GetSpannerValidName(conv, srcCheckConstraintName)
 }
 
+// ToSpannerSequence maps a source sequence to a bit-reversed Spanner
+// sequence. The skipped range and start counter are carried over. As
+// Spanner sequences skip a single range of values, a source MINVALUE or
+// MAXVALUE is mapped to the skipped range below or above it when the range
+// is not already taken. Options that can't be represented are dropped, and
+// a description of each, along with the fallback used, is returned so that
+// it can be reported to the user.
+func ToSpannerSequence(srcSequence ddl.Sequence) (ddl.Sequence, []string) {
+	spSequence := ddl.Sequence{
+		Name:             srcSequence.Name,
+		Id:               srcSequence.Id,
+		SequenceKind:     "BIT REVERSED POSITIVE",
+		SkipRangeMin:     srcSequence.SkipRangeMin,
+		SkipRangeMax:     srcSequence.SkipRangeMax,
+		StartWithCounter: srcSequence.StartWithCounter,
+	}
+	var unsupported []string
+	skipRangeFree := spSequence.SkipRangeMin == "" && spSequence.SkipRangeMax == ""
+	if srcSequence.MinValue != "" {
+		min, err := strconv.ParseInt(srcSequence.MinValue, 10, 64)
+		switch {
+		case err != nil:
+			unsupported = append(unsupported, fmt.Sprintf("MINVALUE %s is not a valid INT64 and was dropped", srcSequence.MinValue))
+		case min <= 1:
+			// Spanner sequences only generate positive values.
+		case skipRangeFree:
+			spSequence.SkipRangeMin, spSequence.SkipRangeMax = "1", strconv.FormatInt(min-1, 10)
+			skipRangeFree = false
+		default:
+			unsupported = append(unsupported, fmt.Sprintf("MINVALUE %s was dropped as the skipped range is already set; values below it may be generated", srcSequence.MinValue))
+		}
+	}
+	if srcSequence.MaxValue != "" {
+		max, err := strconv.ParseInt(srcSequence.MaxValue, 10, 64)
+		switch {
+		case err != nil:
+			unsupported = append(unsupported, fmt.Sprintf("MAXVALUE %s is not a valid INT64 and was dropped", srcSequence.MaxValue))
+		case max == math.MaxInt64:
+		case skipRangeFree && max >= 1:
+			spSequence.SkipRangeMin, spSequence.SkipRangeMax = strconv.FormatInt(max+1, 10), strconv.FormatInt(math.MaxInt64, 10)
+		default:
+			unsupported = append(unsupported, fmt.Sprintf("MAXVALUE %s was dropped as Spanner sequences skip a single range; values above it may be generated", srcSequence.MaxValue))
+		}
+	}
+	if srcSequence.Increment != "" && srcSequence.Increment != "1" {
+		unsupported = append(unsupported, fmt.Sprintf("INCREMENT BY %s was dropped as bit-reversed sequences don't generate values in order", srcSequence.Increment))
+	}
+	if srcSequence.CacheSize != "" && srcSequence.CacheSize != "1" {
+		unsupported = append(unsupported, fmt.Sprintf("CACHE %s was dropped as Spanner manages sequence caching itself", srcSequence.CacheSize))
+	}
+	if srcSequence.Cycle {
+		unsupported = append(unsupported, "CYCLE was dropped as Spanner sequences don't wrap around")
+	}
+	return spSequence, unsupported
+}
+
 // conv.UsedNames tracks Spanner names that have been used for table names, foreign key constraints
 // and indexes. We use this to ensure we generate unique names when
 // we map from source dbs to Spanner since Spanner requires all these names to be
@@ -164,6 +164,36 @@ func TestGetSpannerTable(t *testing.T) {
 	}
 }
 
+func TestSetSourceTableSynonym(t *testing.T) {
+	conv := MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "Orders", Id: "t1"},
+		"t2": {Name: "order-items", Id: "t2"},
+		"t3": {Name: "customers", Id: "t3"},
+		"t4": {Name: "Users", Id: "t4"},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "orders_v2", Id: "t1"},
+		"t2": {Name: "order_items", Id: "t2"},
+		"t3": {Name: "customers", Id: "t3"},
+		"t4": {Name: "users_4", Id: "t4"},
+	}
+	conv.UsedNames = map[string]bool{"orders_v2": true, "order_items": true, "customers": true, "users": true, "users_4": true}
+
+	assert.Nil(t, SetSourceTableSynonym(conv, "t1", true))
+	assert.Equal(t, "Orders", conv.SpSchema["t1"].Synonym)
+	assert.True(t, conv.UsedNames["orders"])
+	assert.NotNil(t, SetSourceTableSynonym(conv, "t2", true))
+	assert.Nil(t, SetSourceTableSynonym(conv, "t3", true))
+	assert.Equal(t, "", conv.SpSchema["t3"].Synonym)
+	assert.NotNil(t, SetSourceTableSynonym(conv, "t4", true))
+	assert.NotNil(t, SetSourceTableSynonym(conv, "t5", true))
+
+	assert.Nil(t, SetSourceTableSynonym(conv, "t1", false))
+	assert.Equal(t, "", conv.SpSchema["t1"].Synonym)
+	assert.False(t, conv.UsedNames["orders"])
+}
+
 func TestGetSpannerCol(t *testing.T) {
 	conv := MakeConv()
 	conv.SrcSchema = map[string]schema.Table{
@@ -485,6 +515,61 @@ func TestToSpannerOnUpdate(t *testing.T) {
 	}
 }
 
+func TestToSpannerSequence(t *testing.T) {
+	tests := []struct {
+		name                string
+		srcSequence         ddl.Sequence
+		expectedSequence    ddl.Sequence
+		expectedUnsupported []string
+	}{
+		{
+			name:             "skip range and start counter",
+			srcSequence:      ddl.Sequence{Id: "s1", Name: "seq", SequenceKind: constants.AUTO_INCREMENT, SkipRangeMin: "1", SkipRangeMax: "10", StartWithCounter: "5", Increment: "1", CacheSize: "1"},
+			expectedSequence: ddl.Sequence{Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "10", StartWithCounter: "5"},
+		},
+		{
+			name:             "min value",
+			srcSequence:      ddl.Sequence{Id: "s1", Name: "seq", MinValue: "100"},
+			expectedSequence: ddl.Sequence{Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "99"},
+		},
+		{
+			name:             "max value",
+			srcSequence:      ddl.Sequence{Id: "s1", Name: "seq", MinValue: "1", MaxValue: "1000"},
+			expectedSequence: ddl.Sequence{Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1001", SkipRangeMax: "9223372036854775807"},
+		},
+		{
+			name:             "min and max value",
+			srcSequence:      ddl.Sequence{Id: "s1", Name: "seq", MinValue: "100", MaxValue: "1000"},
+			expectedSequence: ddl.Sequence{Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "99"},
+			expectedUnsupported: []string{
+				"MAXVALUE 1000 was dropped as Spanner sequences skip a single range; values above it may be generated",
+			},
+		},
+		{
+			name:             "unsupported options",
+			srcSequence:      ddl.Sequence{Id: "s1", Name: "seq", SkipRangeMin: "1", SkipRangeMax: "10", MinValue: "20", Increment: "2", CacheSize: "20", Cycle: true},
+			expectedSequence: ddl.Sequence{Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "10"},
+			expectedUnsupported: []string{
+				"MINVALUE 20 was dropped as the skipped range is already set; values below it may be generated",
+				"INCREMENT BY 2 was dropped as bit-reversed sequences don't generate values in order",
+				"CACHE 20 was dropped as Spanner manages sequence caching itself",
+				"CYCLE was dropped as Spanner sequences don't wrap around",
+			},
+		},
+		{
+			name:                "invalid values",
+			srcSequence:         ddl.Sequence{Id: "s1", Name: "seq", MinValue: "abc", MaxValue: "99999999999999999999"},
+			expectedSequence:    ddl.Sequence{Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE"},
+			expectedUnsupported: []string{"MINVALUE abc is not a valid INT64 and was dropped", "MAXVALUE 99999999999999999999 is not a valid INT64 and was dropped"},
+		},
+	}
+	for _, tc := range tests {
+		spSequence, unsupported := ToSpannerSequence(tc.srcSequence)
+		assert.Equal(t, tc.expectedSequence, spSequence, tc.name)
+		assert.Equal(t, tc.expectedUnsupported, unsupported, tc.name)
+	}
+}
+
 func TestGetSpannerID(t *testing.T) {
 	conv := MakeConv()
 	basicTests := []struct {
@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// maxConstraintNameLength is the maximum length of Spanner identifiers.
+const maxConstraintNameLength = 128
+
+// ConstraintNaming is a strategy to name the foreign keys, indexes and check
+// constraints that are unnamed in the source database. Each field is a
+// template for the names of one kind of constraint, where:
+//   - {table} is replaced by the table name;
+//   - {cols} is replaced by the names of the constrained columns, joined by
+//     "_", or of the key columns for indexes;
+//   - {ref_table} is replaced by the referenced table name, for foreign keys.
+//
+// e.g. fk_{table}_{cols}. Constraints whose template is empty keep the name
+// given during conversion. Names longer than MaxLength are truncated and
+// suffixed with a hash of the full name, so that they stay unique and stable
+// across runs.
+type ConstraintNaming struct {
+	ForeignKey      string
+	Index           string
+	CheckConstraint string
+	MaxLength       int // If 0, the maximum Spanner identifier length.
+}
+
+// ApplyConstraintNaming renames the foreign keys, indexes, search indexes,
+// vector indexes and check constraints of the Spanner schema whose source
+// counterpart is unnamed, using naming. Tables are processed in order of
+// table name, so that names colliding after expansion get the same numeric
+// suffixes across runs.
+func ApplyConstraintNaming(conv *Conv, naming ConstraintNaming) error {
+	maxLength := naming.MaxLength
+	if maxLength == 0 {
+		maxLength = maxConstraintNameLength
+	}
+	if maxLength < 16 || maxLength > maxConstraintNameLength {
+		return fmt.Errorf("constraint name max length must be between 16 and %d", maxConstraintNameLength)
+	}
+	for _, template := range []string{naming.ForeignKey, naming.Index, naming.CheckConstraint} {
+		if err := validateNamingTemplate(template); err != nil {
+			return err
+		}
+	}
+	rename := func(template, oldName, table string, cols []string, refTable string) string {
+		if template == "" {
+			return oldName
+		}
+		delete(conv.UsedNames, strings.ToLower(oldName))
+		r := strings.NewReplacer("{table}", table, "{cols}", strings.Join(cols, "_"), "{ref_table}", refTable)
+		return uniqueConstraintName(conv, r.Replace(template), maxLength)
+	}
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		ct := conv.SpSchema[tableId]
+		srcTable := conv.SrcSchema[tableId]
+		colNames := func(colIds []string) []string {
+			var names []string
+			for _, colId := range colIds {
+				names = append(names, ct.ColDefs[colId].Name)
+			}
+			return names
+		}
+		keyNames := func(keys []ddl.IndexKey) []string {
+			var colIds []string
+			for _, k := range keys {
+				colIds = append(colIds, k.ColId)
+			}
+			return colNames(colIds)
+		}
+		unnamed := make(map[string]bool)
+		for _, fk := range srcTable.ForeignKeys {
+			unnamed[fk.Id] = fk.Name == ""
+		}
+		for _, idx := range srcTable.Indexes {
+			unnamed[idx.Id] = idx.Name == ""
+		}
+		for _, cc := range srcTable.CheckConstraints {
+			unnamed[cc.Id] = cc.Name == ""
+		}
+		for i, fk := range ct.ForeignKeys {
+			if unnamed[fk.Id] {
+				ct.ForeignKeys[i].Name = rename(naming.ForeignKey, fk.Name, ct.Name, colNames(fk.ColIds), conv.SpSchema[fk.ReferTableId].Name)
+			}
+		}
+		for i, idx := range ct.Indexes {
+			if unnamed[idx.Id] {
+				ct.Indexes[i].Name = rename(naming.Index, idx.Name, ct.Name, keyNames(idx.Keys), "")
+			}
+		}
+		for i, idx := range ct.SearchIndexes {
+			if unnamed[idx.Id] {
+				ct.SearchIndexes[i].Name = rename(naming.Index, idx.Name, ct.Name, keyNames(idx.Keys), "")
+			}
+		}
+		for i, idx := range ct.VectorIndexes {
+			if unnamed[idx.Id] {
+				ct.VectorIndexes[i].Name = rename(naming.Index, idx.Name, ct.Name, colNames([]string{idx.ColId}), "")
+			}
+		}
+		for i, cc := range ct.CheckConstraints {
+			if unnamed[cc.Id] {
+				ct.CheckConstraints[i].Name = rename(naming.CheckConstraint, cc.Name, ct.Name, nil, "")
+			}
+		}
+		conv.SpSchema[tableId] = ct
+	}
+	return nil
+}
+
+func validateNamingTemplate(template string) error {
+	rest := strings.NewReplacer("{table}", "", "{cols}", "", "{ref_table}", "").Replace(template)
+	if strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("invalid constraint naming template %s: only {table}, {cols} and {ref_table} can be used", template)
+	}
+	return nil
+}
+
+// uniqueConstraintName returns a legal Spanner name for name, at most
+// maxLength long and not in conv.UsedNames, and marks it as used. A numeric
+// suffix is added to names already used.
+func uniqueConstraintName(conv *Conv, name string, maxLength int) string {
+	candidate := uniqueName(name, maxLength, func(n string) bool { return conv.UsedNames[strings.ToLower(n)] })
+	conv.UsedNames[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// uniqueName returns a legal Spanner name for name, at most maxLength long,
+// for which used returns false. A numeric suffix is added to names already
+// used.
+func uniqueName(name string, maxLength int, used func(string) bool) string {
+	name, _ = FixName(name)
+	if len(name) > maxLength {
+		sum := sha256.Sum256([]byte(name))
+		name = name[:maxLength-9] + "_" + hex.EncodeToString(sum[:])[:8]
+	}
+	candidate := name
+	for i := 1; used(candidate); i++ {
+		suffix := "_" + strconv.Itoa(i)
+		base := name
+		if len(base)+len(suffix) > maxLength {
+			base = base[:maxLength-len(suffix)]
+		}
+		candidate = base + suffix
+	}
+	return candidate
+}
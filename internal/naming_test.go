@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func namingTestConv() *Conv {
+	conv := MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "singers", Id: "t1"},
+		"t2": {
+			Name:             "albums",
+			Id:               "t2",
+			ForeignKeys:      []schema.ForeignKey{{Id: "f1"}, {Name: "fk_named", Id: "f2"}},
+			Indexes:          []schema.Index{{Id: "i1"}, {Id: "i2"}},
+			CheckConstraints: []schema.CheckConstraint{{Id: "ck1"}},
+		},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "Singers", Id: "t1", ColIds: []string{"c1"}, ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "SingerId", Id: "c1"}}},
+		"t2": {
+			Name:    "Albums",
+			Id:      "t2",
+			ColIds:  []string{"c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{"c2": {Name: "SingerId", Id: "c2"}, "c3": {Name: "Title", Id: "c3"}},
+			ForeignKeys: []ddl.Foreignkey{
+				{Id: "f1", ColIds: []string{"c2"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}},
+				{Name: "fk_named", Id: "f2", ColIds: []string{"c2"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}},
+			},
+			Indexes: []ddl.CreateIndex{
+				{Name: "Index_albums", Id: "i1", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c3"}}},
+				{Name: "Index_albums_5", Id: "i2", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c3"}}},
+			},
+			CheckConstraints: []ddl.CheckConstraint{{Name: "_7", Id: "ck1", Expr: "(Title != '')"}},
+		},
+	}
+	conv.UsedNames = map[string]bool{"singers": true, "albums": true, "fk_named": true, "index_albums": true, "index_albums_5": true, "_7": true}
+	return conv
+}
+
+func TestApplyConstraintNaming(t *testing.T) {
+	logger.Log = zap.NewNop()
+	conv := namingTestConv()
+	naming := ConstraintNaming{ForeignKey: "fk_{table}_{ref_table}", Index: "idx_{table}_{cols}", CheckConstraint: "chk_{table}"}
+	assert.Nil(t, ApplyConstraintNaming(conv, naming))
+	ct := conv.SpSchema["t2"]
+	assert.Equal(t, "fk_Albums_Singers", ct.ForeignKeys[0].Name)
+	assert.Equal(t, "fk_named", ct.ForeignKeys[1].Name)
+	assert.Equal(t, "idx_Albums_Title", ct.Indexes[0].Name)
+	assert.Equal(t, "idx_Albums_Title_1", ct.Indexes[1].Name)
+	assert.Equal(t, "chk_Albums", ct.CheckConstraints[0].Name)
+	assert.Equal(t, map[string]bool{"singers": true, "albums": true, "fk_named": true, "fk_albums_singers": true, "idx_albums_title": true, "idx_albums_title_1": true, "chk_albums": true}, conv.UsedNames)
+
+	// Applying the naming again gives the same names.
+	assert.Nil(t, ApplyConstraintNaming(conv, naming))
+	assert.Equal(t, ct, conv.SpSchema["t2"])
+
+	// Long names are truncated with a hash suffix.
+	conv = namingTestConv()
+	assert.Nil(t, ApplyConstraintNaming(conv, ConstraintNaming{Index: strings.Repeat("x", 30) + "_{cols}", MaxLength: 20}))
+	ct = conv.SpSchema["t2"]
+	assert.Len(t, ct.Indexes[0].Name, 20)
+	assert.True(t, strings.HasPrefix(ct.Indexes[0].Name, "xxxxxxxxxxx_"))
+	assert.Len(t, ct.Indexes[1].Name, 20)
+	assert.Equal(t, ct.Indexes[0].Name[:18]+"_1", ct.Indexes[1].Name)
+	assert.Equal(t, "", ct.ForeignKeys[0].Name)
+	assert.Equal(t, "_7", ct.CheckConstraints[0].Name)
+
+	assert.NotNil(t, ApplyConstraintNaming(namingTestConv(), ConstraintNaming{Index: "idx_{column}"}))
+	assert.NotNil(t, ApplyConstraintNaming(namingTestConv(), ConstraintNaming{Index: "idx", MaxLength: 200}))
+}
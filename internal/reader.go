@@ -58,3 +58,45 @@ func (r *Reader) ReadLine() []byte {
 	}
 	return b
 }
+
+// Peek returns the next n bytes of input without consuming them. Fewer
+// than n bytes are returned at eof.
+func (r *Reader) Peek(n int) []byte {
+	if r.EOF {
+		return []byte{}
+	}
+	b, _ := r.r.Peek(n)
+	return b
+}
+
+// ReadByte returns the next byte of input, so that very long lines can be
+// processed without reading them whole. Like ReadLine, it sets EOF once
+// the last byte has been read, and returns io.EOF after that.
+func (r *Reader) ReadByte() (byte, error) {
+	if r.EOF {
+		return 0, io.EOF
+	}
+	c, err := r.r.ReadByte()
+	if err == io.EOF {
+		r.EOF = true
+		return 0, err
+	} else if err != nil {
+		fmt.Printf("Error reading input data: %v\n", err)
+		r.EOF = true
+		return 0, io.EOF
+	}
+	r.Offset++
+	if c == '\n' {
+		r.LineNumber++
+	}
+	if r.r.Buffered() == 0 {
+		if _, err := r.r.Peek(1); err == io.EOF {
+			r.EOF = true
+		}
+	}
+	// Report progress at the end of each line, and every 64KB of long lines.
+	if r.progress != nil && (c == '\n' || r.Offset%(1<<16) == 0) {
+		r.progress.MaybeReport(int64(r.Offset - 1))
+	}
+	return c, nil
+}
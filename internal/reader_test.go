@@ -16,6 +16,7 @@ package internal
 
 import (
 	"bufio"
+	"io"
 	"strings"
 	"testing"
 
@@ -59,3 +60,30 @@ func TestReadLine(t *testing.T) {
 		}
 	}
 }
+
+func TestReadByte(t *testing.T) {
+	r := NewReader(bufio.NewReader(strings.NewReader("ab\nc")), nil)
+	assert.Equal(t, []byte("ab\n"), r.Peek(3))
+	assert.Equal(t, 1, r.Offset)
+	for _, want := range []struct {
+		c      byte
+		eof    bool
+		line   int
+		offset int
+	}{
+		{'a', false, 1, 2},
+		{'b', false, 1, 3},
+		{'\n', false, 2, 4},
+		{'c', true, 2, 5},
+	} {
+		c, err := r.ReadByte()
+		assert.Nil(t, err)
+		assert.Equal(t, want.c, c)
+		assert.Equal(t, want.eof, r.EOF)
+		assert.Equal(t, want.line, r.LineNumber)
+		assert.Equal(t, want.offset, r.Offset)
+	}
+	_, err := r.ReadByte()
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []byte{}, r.Peek(1))
+}
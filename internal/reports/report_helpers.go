@@ -35,19 +35,25 @@ func AnalyzeTables(conv *internal.Conv, badWrites map[string]int64) (r []tableRe
 		tableNames = append(tableNames, srcTable.Name)
 	}
 	sort.Strings(tableNames)
+	violations := make(map[string][]ddl.Violation)
+	for _, v := range ddl.Validate(conv.SpSchema) {
+		violations[v.TableId] = append(violations[v.TableId], v)
+	}
 	for _, tableName := range tableNames {
 		tableId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, tableName)
 		if err != nil {
 			continue
 		}
 		if _, isPresent := conv.SpSchema[tableId]; isPresent {
-			r = append(r, buildTableReport(conv, tableId, badWrites))
+			r = append(r, buildTableReport(conv, tableId, badWrites, violations[tableId]))
 		}
 	}
 	return r
 }
 
-func buildTableReport(conv *internal.Conv, tableId string, badWrites map[string]int64) tableReport {
+// buildTableReport builds the report of table tableId, where violations are
+// the Spanner limits exceeded by the table.
+func buildTableReport(conv *internal.Conv, tableId string, badWrites map[string]int64, violations []ddl.Violation) tableReport {
 	srcSchema, ok1 := conv.SrcSchema[tableId]
 	spSchema, ok2 := conv.SpSchema[tableId]
 	tr := tableReport{SrcTable: tableId, SpTable: tableId}
@@ -62,15 +68,15 @@ func buildTableReport(conv *internal.Conv, tableId string, badWrites map[string]
 		tr.Cols = cols
 		tr.Warnings = warnings
 		schemaIssues := conv.SchemaIssues[tableId].TableLevelIssues
-		tr.Errors = int64(len(schemaIssues))
+		tr.Errors = int64(len(schemaIssues) + len(violations))
 		if pk, ok := conv.SyntheticPKeys[tableId]; ok {
 			tr.SyntheticPKey = pk.ColId
 			synthColName := conv.SpSchema[tableId].ColDefs[pk.ColId].Name
-			tr.Body = buildTableReportBody(conv, tableId, issues, spSchema, srcSchema, &synthColName, nil, schemaIssues)
+			tr.Body = buildTableReportBody(conv, tableId, issues, spSchema, srcSchema, &synthColName, nil, schemaIssues, violations)
 		} else if pk, ok := conv.UniquePKey[tableId]; ok {
-			tr.Body = buildTableReportBody(conv, tableId, issues, spSchema, srcSchema, nil, pk, schemaIssues)
+			tr.Body = buildTableReportBody(conv, tableId, issues, spSchema, srcSchema, nil, pk, schemaIssues, violations)
 		} else {
-			tr.Body = buildTableReportBody(conv, tableId, issues, spSchema, srcSchema, nil, nil, schemaIssues)
+			tr.Body = buildTableReportBody(conv, tableId, issues, spSchema, srcSchema, nil, nil, schemaIssues, violations)
 		}
 
 	}
@@ -80,7 +86,7 @@ func buildTableReport(conv *internal.Conv, tableId string, badWrites map[string]
 	return tr
 }
 
-func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string][]internal.SchemaIssue, spSchema ddl.CreateTable, srcSchema schema.Table, syntheticPK *string, uniquePK []string, tableLevelIssues []internal.SchemaIssue) []tableReportBody {
+func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string][]internal.SchemaIssue, spSchema ddl.CreateTable, srcSchema schema.Table, syntheticPK *string, uniquePK []string, tableLevelIssues []internal.SchemaIssue, violations []ddl.Violation) []tableReportBody {
 	var body []tableReportBody
 	for _, p := range []struct {
 		heading  string
@@ -110,6 +116,94 @@ func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string
 			}
 
 		}
+		if p.severity == Errors {
+			for _, v := range violations {
+				l = append(l, Issue{
+					Category:    IssueDB[internal.SchemaLimitExceeded].Category,
+					Description: fmt.Sprintf("Table '%s': %s", conv.SpSchema[tableId].Name, v),
+				})
+			}
+		}
+
+		if design, ok := conv.SingleTableDesigns[tableId]; ok && p.severity == suggestion {
+			var entities []string
+			for _, e := range design.Entities {
+				entities = append(entities, e.Name)
+			}
+			l = append(l, Issue{
+				Category:    IssueDB[internal.MultipleEntityTypes].Category,
+				Description: fmt.Sprintf("Table '%s' holds items of entity types %s, told apart by attribute '%s'. %s", conv.SpSchema[tableId].Name, strings.Join(entities, ", "), design.Attribute, IssueDB[internal.MultipleEntityTypes].Brief),
+			})
+		}
+
+		if t := srcSchema.Temporal; t != nil {
+			if t.HistoryTable != "" && p.severity == suggestion {
+				l = append(l, Issue{
+					Category:    IssueDB[internal.TemporalTable].Category,
+					Description: fmt.Sprintf("Table '%s' is a system-versioned temporal table, with history table '%s'. %s", conv.SpSchema[tableId].Name, t.HistoryTable, IssueDB[internal.TemporalTable].Brief),
+				})
+			}
+			if t.CurrentTable != "" && p.severity == note {
+				l = append(l, Issue{
+					Category:    IssueDB[internal.TemporalHistoryTable].Category,
+					Description: fmt.Sprintf("Table '%s' is the history table of system-versioned table '%s'. %s", conv.SpSchema[tableId].Name, t.CurrentTable, IssueDB[internal.TemporalHistoryTable].Brief),
+				})
+			}
+		}
+
+		if srcSchema.MaterializedView != "" && p.severity == warning {
+			l = append(l, Issue{
+				Category:    IssueDB[internal.MaterializedViewTable].Category,
+				Description: fmt.Sprintf("Table '%s' is a materialized view in the source, migrated as a table. %s", conv.SpSchema[tableId].Name, IssueDB[internal.MaterializedViewTable].Brief),
+			})
+		}
+
+		for _, srcIndex := range srcSchema.Indexes {
+			var exprs []string
+			for _, k := range srcIndex.Keys {
+				if k.Expr != "" {
+					exprs = append(exprs, k.Expr)
+				}
+			}
+			if len(exprs) == 0 {
+				continue
+			}
+			spIndex, converted := findSpIndex(spSchema.Indexes, srcIndex.Id)
+			if converted && p.severity == note {
+				l = append(l, Issue{
+					Category:    IssueDB[internal.IndexExpressionColumn].Category,
+					Description: fmt.Sprintf("Table '%s': Index '%s' on expression %s is converted to index '%s' on generated columns. %s", conv.SpSchema[tableId].Name, srcIndex.Name, strings.Join(exprs, ", "), spIndex.Name, IssueDB[internal.IndexExpressionColumn].Brief),
+				})
+			}
+			if !converted && p.severity == warning {
+				l = append(l, Issue{
+					Category:    IssueDB[internal.IndexExpressionDropped].Category,
+					Description: fmt.Sprintf("Table '%s': Index '%s' on expression %s: %s", conv.SpSchema[tableId].Name, srcIndex.Name, strings.Join(exprs, ", "), IssueDB[internal.IndexExpressionDropped].Brief),
+				})
+			}
+		}
+
+		for _, srcIndex := range srcSchema.Indexes {
+			if !srcIndex.Constraint || p.severity != warning {
+				continue
+			}
+			// The constraint is honored by a unique index on all its columns.
+			if spIndex, ok := findSpIndex(spSchema.Indexes, srcIndex.Id); ok && spIndex.Unique && len(spIndex.Keys) == len(srcIndex.Keys) {
+				continue
+			}
+			constraint := "UNIQUE constraint"
+			if srcIndex.Name != "" {
+				constraint += fmt.Sprintf(" '%s'", srcIndex.Name)
+			}
+			var cols []string
+			for _, k := range srcIndex.Keys {
+				cols = append(cols, srcSchema.ColDefs[k.ColId].Name)
+			}
+			l = append(l, Issue{
+				Category:    IssueDB[internal.UniqueConstraintDropped].Category,
+				Description: fmt.Sprintf("Table '%s': %s on columns %s is not converted. %s", conv.SpSchema[tableId].Name, constraint, strings.Join(cols, ", "), IssueDB[internal.UniqueConstraintDropped].Brief),
+			})
+		}
 
 		// added if condition to add table level warnings
 		if p.severity == warning && len(conv.InvalidCheckExp[tableId]) != 0 {
@@ -330,6 +424,32 @@ func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string
 						Description: fmt.Sprintf("Auto-Increment has been converted to Sequence '%s' for column '%s' in table '%s'. Set Skipped Range or Start with Counter to avoid duplicate value errors.", conv.SpSchema[tableId].ColDefs[colId].AutoGen.Name, spColName, conv.SpSchema[tableId].Name),
 					}
 					l = append(l, toAppend)
+				case internal.AutoRandom:
+					toAppend := Issue{
+						Category:    IssueDB[i].Category,
+						Description: fmt.Sprintf("AUTO_RANDOM has been converted to bit-reversed Sequence '%s' for column '%s' in table '%s'. Set Skipped Range or Start with Counter to avoid duplicate value errors.", conv.SpSchema[tableId].ColDefs[colId].AutoGen.Name, spColName, conv.SpSchema[tableId].Name),
+					}
+					l = append(l, toAppend)
+				case internal.AutoIncrementIdentity, internal.AutoIncrementUUID, internal.AutoIncrementKept:
+					l = append(l, Issue{
+						Category:    IssueDB[i].Category,
+						Description: fmt.Sprintf("Table '%s': Auto-increment column '%s' is converted with policy '%s'. %s", conv.SpSchema[tableId].Name, spColName, internal.GetAutoIncrementPolicy(conv, tableId), IssueDB[i].Brief),
+					})
+				case internal.SequenceOptionUnsupported:
+					srcSeqName := conv.SrcSchema[tableId].ColDefs[colId].AutoGen.Name
+					for _, srcSequence := range conv.SrcSequences {
+						if srcSequence.Name != srcSeqName {
+							continue
+						}
+						_, unsupported := internal.ToSpannerSequence(srcSequence)
+						for _, u := range unsupported {
+							toAppend := Issue{
+								Category:    IssueDB[i].Category,
+								Description: fmt.Sprintf("Sequence '%s' for column '%s' in table '%s': %s", conv.SpSchema[tableId].ColDefs[colId].AutoGen.Name, spColName, conv.SpSchema[tableId].Name, u),
+							}
+							l = append(l, toAppend)
+						}
+					}
 				case internal.Timestamp:
 					// Avoid the confusing "timestamp is mapped to timestamp" message.
 					toAppend := Issue{
@@ -424,6 +544,38 @@ func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string
 						}
 						l = append(l, toAppend)
 					}
+				case internal.DistributionKey:
+					str := fmt.Sprintf("Table '%s' is distributed by column '%s' in the source database. %s", spSchema.Name, spColName, IssueDB[i].Brief)
+					if parent := getReferTableName(conv, tableId, colId); parent != "" {
+						str = fmt.Sprintf("Table '%s' is distributed by column '%s' in the source database, which references table '%s'. Consider interleaving it in table '%s'", spSchema.Name, spColName, parent, parent)
+					}
+					if !Contains(l, str) {
+						toAppend := Issue{
+							Category:    IssueDB[i].Category,
+							Description: str,
+						}
+						l = append(l, toAppend)
+					}
+				case internal.CounterSnapshot:
+					str := fmt.Sprintf("Table '%s': Column '%s', type %s is mapped to %s. %s", spSchema.Name, spColName, srcColType, spColType, IssueDB[i].Brief)
+					if tsColName := commitTimestampColName(srcSchema, spSchema); tsColName != "" {
+						str += fmt.Sprintf(". Column '%s' records when each row was migrated, to reconcile the counters incremented since", tsColName)
+					}
+					l = append(l, Issue{
+						Category:    IssueDB[i].Category,
+						Description: str,
+					})
+				case internal.GeneratedColumnMaterialized:
+					l = append(l, Issue{
+						Category:    IssueDB[i].Category,
+						Description: fmt.Sprintf("Table '%s': Column '%s' is generated from expression '%s' in the source database. %s", spSchema.Name, spColName, srcSchema.ColDefs[colId].GeneratedColumn.Value.Statement, IssueDB[i].Brief),
+					})
+				case internal.SortKey:
+					toAppend := Issue{
+						Category:    IssueDB[i].Category,
+						Description: fmt.Sprintf("Table '%s' is sorted by column '%s' in the source database. %s", spSchema.Name, spColName, IssueDB[i].Brief),
+					}
+					l = append(l, toAppend)
 				case internal.RedundantIndex:
 					str := fmt.Sprintf(" %s for Table '%s' and Column  '%s'", IssueDB[i].Brief, spSchema.Name, spColName)
 
@@ -525,7 +677,30 @@ func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string
 	return body
 }
 
+// commitTimestampColName returns the name of the Spanner column added to
+// hold the commit timestamp of the rows of a table, or an empty string if
+// there is none.
+func commitTimestampColName(srcSchema schema.Table, spSchema ddl.CreateTable) string {
+	for _, colId := range srcSchema.ColIds {
+		if spCol, ok := spSchema.ColDefs[colId]; ok && srcSchema.ColDefs[colId].CommitTimestamp {
+			return spCol.Name
+		}
+	}
+	return ""
+}
+
 // Contains check string present in list.
+// findSpIndex returns the Spanner index converted from the source index
+// with id indexId.
+func findSpIndex(indexes []ddl.CreateIndex, indexId string) (ddl.CreateIndex, bool) {
+	for _, index := range indexes {
+		if index.Id == indexId {
+			return index, true
+		}
+	}
+	return ddl.CreateIndex{}, false
+}
+
 func Contains(l []Issue, str string) bool {
 	for _, s := range l {
 		if s.Description == str {
@@ -573,6 +748,19 @@ func getInterleaveDetail(conv *internal.Conv, tableId string, colId string, issu
 	return "", "", ""
 }
 
+// getReferTableName returns the name of the table referenced by a foreign
+// key of the table with column colId, or an empty string if there is none.
+func getReferTableName(conv *internal.Conv, tableId string, colId string) string {
+	for _, fk := range conv.SpSchema[tableId].ForeignKeys {
+		for _, columnId := range fk.ColIds {
+			if columnId == colId {
+				return conv.SpSchema[fk.ReferTableId].Name
+			}
+		}
+	}
+	return ""
+}
+
 func getPkOrderForReport(pks []ddl.IndexKey, colId string) (int, error) {
 	for _, pk := range pks {
 		if pk.ColId == colId {
@@ -629,9 +817,9 @@ var IssueDB = map[internal.SchemaIssue]struct {
 	internal.MultiDimensionalArray:                {Brief: "Spanner doesn't support multi-dimensional arrays", Severity: warning, Category: "MULTI_DIMENSIONAL_ARRAY_USES"},
 	internal.NoGoodType: {Brief: "No appropriate Spanner type. The column will be made nullable in Spanner", Severity: warning, Category: "INAPPROPRIATE_TYPE",
 		CategoryDescription: "No appropriate Spanner type"},
-	internal.Numeric:              {Brief: "Spanner does not support numeric. This type mapping could lose precision and is not recommended for production use", Severity: warning, Category: "NUMERIC_USES"},
+	internal.Numeric:              {Brief: "The precision of this numeric exceeds the precision of Spanner's NUMERIC type. This type mapping could lose precision and is not recommended for production use", Severity: warning, Category: "NUMERIC_USES"},
 	internal.NumericThatFits:      {Brief: "Spanner does not support numeric, but this type mapping preserves the numeric's specified precision", Severity: suggestion, Category: "NUMERIC_THAT_FITS"},
-	internal.Decimal:              {Brief: "Spanner does not support decimal. This type mapping could lose precision and is not recommended for production use", Severity: warning, Category: "DECIMAL_USES"},
+	internal.Decimal:              {Brief: "The precision of this decimal exceeds the precision of Spanner's NUMERIC type. This type mapping could lose precision and is not recommended for production use", Severity: warning, Category: "DECIMAL_USES"},
 	internal.DecimalThatFits:      {Brief: "Spanner does not support decimal, but this type mapping preserves the decimal's specified precision", Severity: suggestion, Category: "DECIMAL_THAT_FITS"},
 	internal.Serial:               {Brief: "Spanner does not support autoincrementing types", Severity: warning, Category: "AUTOINCREMENTING_TYPE_USES"},
 	internal.AutoIncrement:        {Brief: "Spanner does not support auto_increment attribute", Severity: warning, Category: "AUTO_INCREMENT_ATTRIBUTE_USES"},
@@ -668,11 +856,42 @@ var IssueDB = map[internal.SchemaIssue]struct {
 		CategoryDescription: "Primary Key is missing, unique column(s) used as primary key"},
 	internal.ArrayTypeNotSupported:        {Brief: "Array datatype migration is not fully supported. Please validate data after data migration", Severity: warning, Category: "ARRAY_TYPE_NOT_SUPPORTED"},
 	internal.SequenceCreated:              {Brief: "Auto Increment has been converted to Sequence, set Skipped Range or Start with Counter to avoid duplicate value errors", Severity: warning, Category: "SEQUENCE_CREATED"},
+	internal.SequenceOptionUnsupported:    {Brief: "Some sequence options are not supported by Spanner and were dropped", Severity: warning, Category: "SEQUENCE_OPTION_UNSUPPORTED"},
+	internal.SchemaLimitExceeded:          {Brief: "Schema exceeds a Spanner limit", Severity: Errors, Category: "SCHEMA_LIMIT_EXCEEDED"},
 	internal.ForeignKeyOnDelete:           {Brief: "Spanner supports only ON DELETE CASCADE/NO ACTION", Severity: warning, Category: "FOREIGN_KEY_ACTIONS"},
 	internal.ForeignKeyOnUpdate:           {Brief: "Spanner supports only ON UPDATE NO ACTION", Severity: warning, Category: "FOREIGN_KEY_ACTIONS"},
 	internal.ForeignKeyActionNotSupported: {Brief: "Spanner supports foreign key action migration only for MySQL and PostgreSQL", Severity: warning, Category: "FOREIGN_KEY_ACTIONS"},
 	internal.NumericPKNotSupported:        {Brief: "Spanner PostgreSQL does not support numeric primary keys / unique indices", Severity: warning, Category: "NUMERIC_PK_NOT_SUPPORTED"},
 	internal.DefaultValueError:            {Brief: "Some columns have default value expressions not supported by Spanner. Please fix them to continue migration.", Severity: Errors, batch: true, Category: "INCOMPATIBLE_DEFAULT_VALUE_CONSTRAINTS"},
+	internal.DistributionKey: {Brief: "Consider interleaving the table in the table it is joined with on this column, so that their rows are stored together in Spanner too", Severity: suggestion, Category: "DISTRIBUTION_KEY_SUGGESTION",
+		CategoryDescription: "Some tables are distributed by a column in the source database and can be interleaved"},
+	internal.SortKey: {Brief: "Consider adding the column to the primary key, after a column with well distributed values, so that range scans over it stay efficient", Severity: suggestion, Category: "SORT_KEY_SUGGESTION",
+		CategoryDescription: "Some tables are sorted by columns in the source database which can be added to the primary key"},
+	internal.Money:       {Brief: "Spanner does not have a money type. Values are stored as NUMERIC, whose arithmetic is not rounded to 4 decimal places", Severity: warning, batch: true, Category: "MONEY_TYPE_USES"},
+	internal.LargeObject: {Brief: "Text and image types are deprecated large object types. Spanner stores them as STRING(MAX)/BYTES(MAX), whose values are limited to 10MiB", Severity: warning, batch: true, Category: "LARGE_OBJECT_TYPE_USES"},
+	internal.AutoRandom:  {Brief: "AUTO_RANDOM has been converted to a bit-reversed Sequence, set Skipped Range or Start with Counter to avoid duplicate value errors", Severity: warning, Category: "AUTO_RANDOM_SEQUENCE_CREATED"},
+	internal.MultipleEntityTypes: {Brief: "Consider splitting the table into a table per entity type, interleaved where items share partition keys, in the web UI before migrating data", Severity: suggestion, Category: "SINGLE_TABLE_DESIGN_SUGGESTION",
+		CategoryDescription: "Some tables hold items of several entity types, which can be split into a table per entity type"},
+	internal.SetToArray:                  {Brief: "Set elements are stored in an array, in the order of the source, but Spanner doesn't keep them unique on writes", Severity: note, Category: "SET_TO_ARRAY"},
+	internal.CollectionToJSON:            {Brief: "Values are stored as JSON: map keys become strings and Spanner doesn't enforce the key and element types", Severity: warning, Category: "COLLECTION_TO_JSON"},
+	internal.UserTypeToJSON:              {Brief: "Values of the user-defined type are stored as JSON objects and Spanner doesn't enforce the types of their fields. The type can be flattened into a column per field instead", Severity: warning, Category: "USER_TYPE_TO_JSON"},
+	internal.CounterSnapshot:             {Brief: "Spanner has no counter type, so the column holds a snapshot of the counter value. Increments must be rewritten as read-modify-write transactions, and counters incremented during the migration reconciled", Severity: warning, Category: "COUNTER_SNAPSHOT"},
+	internal.Spatial:                     {Brief: "Spanner has no spatial types, so values are stored as WKT in STRING, WKB in BYTES or GeoJSON in JSON columns. Spatial indexes aren't migrated and spatial functions aren't available, so spatial filtering must be done by the application, e.g. with a bounding box stored in FLOAT64 columns", Severity: warning, Category: "SPATIAL_TYPE_USES"},
+	internal.GeneratedColumnMaterialized: {Brief: "The generated column expression couldn't be translated to Spanner, so the column is a regular column whose values are copied from the source during data migration. The application must set its value on writes, or the expression must be rewritten as a Spanner generated column", Severity: warning, Category: "GENERATED_COLUMN_MATERIALIZED"},
+	internal.CaseInsensitiveText:         {Brief: "Spanner string comparisons are case-sensitive, unlike those of citext columns. Queries, unique indexes and primary keys relying on case-insensitive matches must use LOWER(column), e.g. in a stored generated column", Severity: warning, Category: "CASE_INSENSITIVE_TEXT"},
+	internal.CollationChanged:            {Brief: "Spanner compares strings by their UTF-8 bytes, unlike the collation of this column. The order of its values, and which of them are duplicates in keys and unique indexes, may change e.g. with case-insensitive collations", Severity: warning, Category: "COLLATION_CHANGED"},
+	internal.SetToString:                 {Brief: "Set elements are stored as a comma-separated string, and Spanner doesn't check they are allowed members unless the column has a CHECK constraint", Severity: note, Category: "SET_TO_STRING"},
+	internal.TemporalTable:               {Brief: "Spanner doesn't keep the previous versions of rows. Migrate the history table with temporalHistory=true in the source profile to keep the history up to the migration, and use change streams to capture changes from then on", Severity: suggestion, Category: "TEMPORAL_TABLE"},
+	internal.TemporalHistoryTable:        {Brief: "The history table is migrated as a regular table, and Spanner doesn't add the previous versions of rows to it on writes", Severity: note, Category: "TEMPORAL_HISTORY_TABLE"},
+	internal.TemporalPeriod:              {Brief: "Period column of a system-versioned table: values are copied from the source, but Spanner doesn't set them on writes, so the application must, e.g. with commit timestamps", Severity: warning, Category: "TEMPORAL_PERIOD_COLUMN"},
+	internal.IndexExpressionColumn:       {Brief: "Spanner indexes are built over columns, so each expression is a stored generated column of the table, and queries must filter on these columns to use the index", Severity: note, Category: "INDEX_EXPRESSION_COLUMN"},
+	internal.IndexExpressionDropped:      {Brief: "The index expression couldn't be translated to Spanner, so the index has been dropped", Severity: warning, Category: "INDEX_EXPRESSION_DROPPED"},
+	internal.MaterializedViewTable:       {Brief: "The table holds the rows of the view at the time of the migration, and Spanner doesn't refresh it: the application must recompute its rows when the source refreshed the view, or migrate it as a view with materializedViews=view in the source profile", Severity: warning, Category: "MATERIALIZED_VIEW_TABLE"},
+	internal.AutoIncrementIdentity:       {Brief: "Spanner identity columns generate bit-reversed values, which aren't in order. Set the start counter of the identity column above the largest migrated value to avoid duplicate value errors", Severity: note, Category: "AUTO_INCREMENT_IDENTITY"},
+	internal.AutoIncrementUUID:           {Brief: "Migrated rows keep their integer values as strings, and new rows get generated UUIDs, so applications reading the column as a number must be updated", Severity: warning, Category: "AUTO_INCREMENT_UUID"},
+	internal.AutoIncrementKept:           {Brief: "The column is migrated without auto-generation, so the application must set its values on writes, and monotonically increasing values cause hotspots when used as a key", Severity: warning, Category: "AUTO_INCREMENT_KEPT"},
+	internal.UniqueConstraintDropped:     {Brief: "Spanner enforces UNIQUE constraints with unique indexes on all the columns of the constraint, which can't be created: the application must enforce the constraint", Severity: warning, Category: "UNIQUE_CONSTRAINT_DROPPED"},
+	internal.UUIDAsString:                {Brief: "UUIDs are stored in their textual form, as the uuidAsString param of the target profile is set", Severity: note, Category: "UUID_AS_STRING"},
 }
 
 type Severity int
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 )
 
 //report_text.go contains the logic to convert a structured spanner migration tool 
@@ -43,6 +44,9 @@ func (r *ReportImpl) GenerateTextReport(structuredReport StructuredReport, w *bu
 	}
 	writeNameChanges(structuredReport, w)
 	writeTableReports(structuredReport, w)
+	writeViewReports(structuredReport, w)
+	writeRoutineReports(structuredReport, w)
+	writeSearchIndexReports(structuredReport, w)
 	writeUnexpectedConditionsv2(structuredReport, w)
 
 }
@@ -143,6 +147,104 @@ func writeTableReports(structuredReport StructuredReport, w *bufio.Writer) {
 	}
 }
 
+// Generates the report of source views, listing the views converted to
+// Spanner views and, for the others, why they weren't and their source
+// definition. Nothing is written when there are no source views.
+func writeViewReports(structuredReport StructuredReport, w *bufio.Writer) {
+	if len(structuredReport.ViewReports) == 0 {
+		return
+	}
+	writeHeading(w, "Views")
+	for _, viewReport := range structuredReport.ViewReports {
+		kind := "View"
+		if viewReport.Materialized {
+			kind = "Materialized view"
+		}
+		if viewReport.Issue == "" {
+			h := fmt.Sprintf("%s %s: converted", kind, viewReport.SrcViewName)
+			if viewReport.SrcViewName != viewReport.SpViewName {
+				h = h + fmt.Sprintf(" (mapped to Spanner view %s)", viewReport.SpViewName)
+			}
+			if viewReport.Materialized {
+				// Spanner views aren't materialized.
+				justifyLines(w, h+". Its rows are computed on each read, which is always up to date but can be slower than reading the stored rows of the source.", 80, 0)
+				w.WriteString("\n")
+				continue
+			}
+			w.WriteString(h + ".\n")
+			continue
+		}
+		justifyLines(w, fmt.Sprintf("%s %s: not converted, because it %s.", kind, viewReport.SrcViewName, viewReport.Issue), 80, 0)
+		w.WriteString("\n")
+		for _, l := range strings.Split(strings.TrimSpace(viewReport.Definition), "\n") {
+			fmt.Fprintf(w, "    %s\n", l)
+		}
+	}
+	w.WriteString("\n")
+}
+
+var routineKindNames = map[string]string{
+	schema.RoutineProcedure: "Procedure",
+	schema.RoutineFunction:  "Function",
+	schema.RoutineTrigger:   "Trigger",
+}
+
+// Generates the report of the stored procedures, functions and triggers of
+// the source, with their complexity, suggested replacement and body.
+// Nothing is written when the source has none.
+func writeRoutineReports(structuredReport StructuredReport, w *bufio.Writer) {
+	if len(structuredReport.RoutineReports) == 0 {
+		return
+	}
+	writeHeading(w, "Stored Procedures, Functions and Triggers")
+	justifyLines(w, "Spanner doesn't support stored procedures, functions or triggers: the "+
+		"following need to be reimplemented before cutover.", 80, 0)
+	w.WriteString("\n\n")
+	for _, r := range structuredReport.RoutineReports {
+		h := fmt.Sprintf("%s %s", routineKindNames[r.Kind], r.Name)
+		if r.Table != "" {
+			h = h + fmt.Sprintf(" on %s (%s %s)", r.Table, r.Timing, strings.Join(r.Events, ", "))
+		}
+		fmt.Fprintf(w, "%s: complexity %s (score %d).\n", h, r.Complexity, r.Score)
+		justifyLines(w, "Suggestion: "+r.Suggestion, 80, 0)
+		w.WriteString("\n")
+		if r.ChangeStream != "" {
+			fmt.Fprintf(w, "Change stream: %s;\n", r.ChangeStream)
+			justifyLines(w, "Consumer: "+r.ConsumerStub, 80, 0)
+			w.WriteString("\n")
+		}
+		for _, l := range strings.Split(strings.TrimSpace(r.Body), "\n") {
+			fmt.Fprintf(w, "    %s\n", l)
+		}
+		w.WriteString("\n")
+	}
+}
+
+// Generates the report of the search indexes converted from full-text
+// indexes of the source, with the rewrite of full-text queries. Nothing is
+// written when there are no search indexes.
+func writeSearchIndexReports(structuredReport StructuredReport, w *bufio.Writer) {
+	if len(structuredReport.SearchIndexReports) == 0 {
+		return
+	}
+	writeHeading(w, "Search Indexes")
+	justifyLines(w, "Full-text indexes are mapped to Spanner search indexes over generated "+
+		"TOKENLIST columns. Full-text queries have to be rewritten with the SEARCH function, "+
+		"and ranked with SCORE where needed.", 80, 0)
+	w.WriteString("\n\n")
+	for _, r := range structuredReport.SearchIndexReports {
+		h := fmt.Sprintf("Search index %s on %s", r.Name, r.Table)
+		if r.SourceIndex != "" {
+			h = h + fmt.Sprintf(" (from index %s)", r.SourceIndex)
+		}
+		fmt.Fprintf(w, "%s: token columns %s.\n", h, strings.Join(r.TokenColumns, ", "))
+		if r.SourceQuery != "" {
+			fmt.Fprintf(w, "    Source:  %s\n", r.SourceQuery)
+		}
+		fmt.Fprintf(w, "    Spanner: %s\n\n", r.SpannerQuery)
+	}
+}
+
 func writeNameChanges(structuredReport StructuredReport, w *bufio.Writer) {
 	if structuredReport.NameChanges != nil {
 		w.WriteString("-----------------------------------------------------------------------------------------------------\n")
@@ -16,6 +16,7 @@
 package reports
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -75,7 +76,16 @@ func (r *ReportImpl) GenerateStructuredReport(driverName string, dbName string,
 		smtReport.TableReports = fetchTableReports(tableReports, conv)
 	}
 
-	//9. Unexpected Conditions
+	//9. View Reports
+	smtReport.ViewReports = fetchViewReports(conv)
+
+	//10. Stored procedures, functions and triggers
+	smtReport.RoutineReports = fetchRoutineReports(conv)
+
+	//11. Search indexes
+	smtReport.SearchIndexReports = fetchSearchIndexReports(conv)
+
+	//12. Unexpected Conditions
 	if printUnexpecteds {
 		smtReport.UnexpectedConditions = fetchUnexceptedConditions(driverName, conv)
 	}
@@ -198,6 +208,23 @@ func fetchTableReports(inputTableReports []tableReport, conv *internal.Conv) (ta
 	return tableReports
 }
 
+// fetchViewReports returns the reports of the source views of conv, sorted
+// by name.
+func fetchViewReports(conv *internal.Conv) (viewReports []ViewReport) {
+	for viewId, srcView := range conv.SrcViews {
+		viewReport := ViewReport{SrcViewName: srcView.Name, Materialized: srcView.Materialized}
+		if spView, ok := conv.SpViews[viewId]; ok {
+			viewReport.SpViewName = spView.Name
+		} else {
+			viewReport.Issue = conv.ViewIssues[viewId]
+			viewReport.Definition = srcView.Query
+		}
+		viewReports = append(viewReports, viewReport)
+	}
+	sort.Slice(viewReports, func(i, j int) bool { return viewReports[i].SrcViewName < viewReports[j].SrcViewName })
+	return viewReports
+}
+
 func getSchemaReport(cols, issues, warnings, errors int64, missingPKey bool) (schemaReport SchemaReport) {
 	schemaReport.TotalColumns = cols
 	schemaReport.Issues = issues
@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reports
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Spanner has no stored procedures, functions or triggers: those of the
+// source are listed in the report with a complexity score, estimating the
+// effort to reimplement them, and a suggested replacement.
+
+// Complexities of routines.
+const (
+	ComplexityLow    = "LOW"
+	ComplexityMedium = "MEDIUM"
+	ComplexityHigh   = "HIGH"
+)
+
+var (
+	// Ends of control flow blocks e.g. END IF, which aren't counted.
+	blockEndRegex = regexp.MustCompile(`(?i)\bEND\s+(IF|CASE|WHILE|LOOP|REPEAT|FOR)\b`)
+	// Branches and loops.
+	controlFlowRegex = regexp.MustCompile(`(?i)\b(IF|ELSEIF|ELSIF|CASE|WHILE|LOOP|REPEAT|FOR\s+\w+\s+IN)\b`)
+	cursorRegex      = regexp.MustCompile(`(?i)\bCURSOR\b`)
+	// Statements built at run time e.g. MySQL PREPARE, PostgreSQL EXECUTE
+	// format(...) or SQL Server sp_executesql.
+	dynamicSQLRegex = regexp.MustCompile(`(?i)\b(PREPARE\s+\w+\s+FROM|EXECUTE\s+IMMEDIATE|EXECUTE\s+format|EXEC(UTE)?\s*\(|sp_executesql)`)
+	// Transaction control, which moves to the client along with the
+	// transactions.
+	transactionRegex = regexp.MustCompile(`(?i)\b(COMMIT|ROLLBACK|SAVEPOINT)\b`)
+)
+
+// routineComplexity scores the body of a routine by its statements, and
+// the branches, loops, cursors, dynamic SQL and transaction control which
+// make it harder to reimplement.
+func routineComplexity(body string) (string, int) {
+	score := strings.Count(body, ";")
+	if score == 0 && strings.TrimSpace(body) != "" {
+		score = 1
+	}
+	body = blockEndRegex.ReplaceAllString(body, "")
+	score += 2 * len(controlFlowRegex.FindAllString(body, -1))
+	score += 3 * len(cursorRegex.FindAllString(body, -1))
+	score += 5 * len(dynamicSQLRegex.FindAllString(body, -1))
+	score += 3 * len(transactionRegex.FindAllString(body, -1))
+	switch {
+	case score < 10:
+		return ComplexityLow, score
+	case score < 30:
+		return ComplexityMedium, score
+	}
+	return ComplexityHigh, score
+}
+
+// routineSuggestion returns the suggested Spanner-era replacement of a
+// routine.
+func routineSuggestion(r schema.Routine, complexity string) string {
+	switch r.Kind {
+	case schema.RoutineTrigger:
+		if r.Timing == "AFTER" {
+			return fmt.Sprintf("Capture the changes to %s with a change stream and reproduce the effects of the trigger "+
+				"in a Cloud Function processing them, or in the application in the transactions writing to %s.", r.Table, r.Table)
+		}
+		return fmt.Sprintf("Reimplement the trigger in the application, in the transactions writing to %s. "+
+			"Checks of the new row can be replaced by check constraints, and computed values by default values or generated columns.", r.Table)
+	case schema.RoutineFunction:
+		if complexity == ComplexityLow {
+			return "Inline the function as an expression of the queries, views or generated columns using it, " +
+				"or reimplement it in the application."
+		}
+		return "Reimplement the function in the application."
+	}
+	if complexity == ComplexityHigh {
+		return "Reimplement the procedure in the application, running its statements in a read-write transaction. " +
+			"Procedures shared by several applications can be moved to a Cloud Function or Cloud Run service."
+	}
+	return "Reimplement the procedure in the application, running its statements in a read-write transaction."
+}
+
+// triggerConsumerStub describes the consumer of change stream cs
+// reproducing the effects of trigger r on Spanner table table.
+func triggerConsumerStub(r schema.Routine, cs ddl.ChangeStream, table string) string {
+	events := internal.TriggerEvents(r)
+	stub := fmt.Sprintf("Read the change stream %s, e.g. with a Dataflow pipeline using SpannerIO.readChangeStream, "+
+		"and for each data change record of %s with mod type %s, reproduce the effects of the trigger. "+
+		"The NEW row of the trigger is in the new values of the record", cs.Name, table, strings.Join(events, " or "))
+	if slices.ContainsFunc(events, func(e string) bool { return e != "INSERT" }) {
+		stub += ", and the OLD row in its old values, which only hold the modified columns of updated rows"
+	}
+	stub += ". Records are read once their transaction has committed, so the effects are applied asynchronously, outside of it."
+	if r.Timing != "AFTER" {
+		stub += fmt.Sprintf(" As the trigger runs %s the change, only its side effects can move to the consumer: "+
+			"changes to the row itself, or checks rejecting it, have to be made in the transactions writing to %s.", r.Timing, table)
+	}
+	return stub
+}
+
+// fetchRoutineReports returns the reports of the stored procedures,
+// functions and triggers of conv: triggers first, as they're the easiest to
+// miss, then procedures and functions, by name.
+func fetchRoutineReports(conv *internal.Conv) (routineReports []RoutineReport) {
+	changeStreams := internal.TriggerChangeStreams(conv)
+	config := ddl.Config{ProtectIds: conv.SpDialect != constants.DIALECT_POSTGRESQL, QuoteReservedOnly: true, SpDialect: conv.SpDialect}
+	for _, r := range conv.SrcRoutines {
+		complexity, score := routineComplexity(r.Body)
+		report := RoutineReport{
+			Name:       r.Name,
+			Schema:     r.Schema,
+			Kind:       r.Kind,
+			Table:      r.Table,
+			Timing:     r.Timing,
+			Events:     r.Events,
+			Complexity: complexity,
+			Score:      score,
+			Suggestion: routineSuggestion(r, complexity),
+			Body:       r.Body,
+		}
+		if r.Kind == schema.RoutineTrigger {
+			tableId, _ := internal.GetTableIdFromSrcName(conv.SrcSchema, r.Table)
+			if cs, ok := changeStreams[tableId]; ok && len(internal.TriggerEvents(r)) > 0 {
+				report.ChangeStream = cs.PrintChangeStream(conv.SpSchema, config)
+				report.ConsumerStub = triggerConsumerStub(r, cs, conv.SpSchema[tableId].Name)
+			}
+		}
+		routineReports = append(routineReports, report)
+	}
+	sort.Slice(routineReports, func(i, j int) bool {
+		a, b := routineReports[i], routineReports[j]
+		if a.Kind != b.Kind {
+			return a.Kind > b.Kind
+		}
+		if a.Schema != b.Schema {
+			return a.Schema < b.Schema
+		}
+		return a.Name < b.Name
+	})
+	return routineReports
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+)
+
+// Full-text indexes of the source are converted to Spanner search indexes
+// over generated TOKENLIST columns. Queries using them have to be rewritten
+// with the SEARCH function: the report shows how, for each search index.
+
+// fetchSearchIndexReports returns the reports of the search indexes of
+// conv, sorted by table and index name.
+func fetchSearchIndexReports(conv *internal.Conv) (searchIndexReports []SearchIndexReport) {
+	for tableId, spTable := range conv.SpSchema {
+		srcTable := conv.SrcSchema[tableId]
+		for _, searchIndex := range spTable.SearchIndexes {
+			r := SearchIndexReport{Name: searchIndex.Name, Table: spTable.Name}
+			for _, srcIndex := range srcTable.Indexes {
+				if srcIndex.Id != searchIndex.Id {
+					continue
+				}
+				r.SourceIndex = srcIndex.Name
+				var srcCols []string
+				for _, k := range srcIndex.Keys {
+					if cd, ok := srcTable.ColDefs[k.ColId]; ok {
+						srcCols = append(srcCols, cd.Name)
+					}
+				}
+				r.SourceQuery = sourceFullTextQuery(conv.Source, srcTable.Name, srcCols)
+			}
+			for _, k := range searchIndex.Keys {
+				if cd, ok := spTable.ColDefs[k.ColId]; ok {
+					r.TokenColumns = append(r.TokenColumns, cd.Name)
+				}
+			}
+			r.SpannerQuery = spannerSearchQuery(conv.SpDialect, spTable.Name, r.TokenColumns)
+			searchIndexReports = append(searchIndexReports, r)
+		}
+	}
+	sort.Slice(searchIndexReports, func(i, j int) bool {
+		if searchIndexReports[i].Table != searchIndexReports[j].Table {
+			return searchIndexReports[i].Table < searchIndexReports[j].Table
+		}
+		return searchIndexReports[i].Name < searchIndexReports[j].Name
+	})
+	return searchIndexReports
+}
+
+// sourceFullTextQuery returns an example full-text query of the source
+// over cols, or an empty string for sources without full-text indexes.
+func sourceFullTextQuery(source, table string, cols []string) string {
+	if len(cols) == 0 {
+		return ""
+	}
+	switch source {
+	case constants.MYSQL, constants.MYSQLDUMP, constants.MARIADB:
+		return fmt.Sprintf("SELECT * FROM %s WHERE MATCH(%s) AGAINST ('term')", table, strings.Join(cols, ", "))
+	case constants.POSTGRES, constants.PGDUMP:
+		var docs []string
+		for _, col := range cols {
+			docs = append(docs, fmt.Sprintf("to_tsvector(%s)", col))
+		}
+		return fmt.Sprintf("SELECT * FROM %s WHERE (%s) @@ to_tsquery('term')", table, strings.Join(docs, " || "))
+	}
+	return ""
+}
+
+// spannerSearchQuery returns the Spanner rewrite of a full-text query,
+// searching each of the TOKENLIST columns of a search index.
+func spannerSearchQuery(dialect, table string, tokenCols []string) string {
+	if len(tokenCols) == 0 {
+		return ""
+	}
+	fn := "SEARCH"
+	if dialect == constants.DIALECT_POSTGRESQL {
+		fn = "spanner.search"
+	}
+	var searches []string
+	for _, col := range tokenCols {
+		searches = append(searches, fmt.Sprintf("%s(%s, 'term')", fn, col))
+	}
+	return fmt.Sprintf("SELECT * FROM %s WHERE %s", table, strings.Join(searches, " OR "))
+}
@@ -97,6 +97,48 @@ type TableReport struct {
 	Issues       []Issues     `json:"issues"`
 }
 
+// ViewReport describes the conversion of a source view. Views which
+// couldn't be translated have an issue, and their source definition.
+type ViewReport struct {
+	SrcViewName string `json:"srcViewName"`
+	SpViewName  string `json:"spViewName,omitempty"`
+	Issue       string `json:"issue,omitempty"`
+	Definition  string `json:"definition,omitempty"`
+	// Materialized is set for the materialized views of the source.
+	Materialized bool `json:"materialized,omitempty"`
+}
+
+// RoutineReport describes a stored procedure, function or trigger of the
+// source, which has to be reimplemented.
+type RoutineReport struct {
+	Name       string   `json:"name"`
+	Schema     string   `json:"schema"`
+	Kind       string   `json:"kind"`
+	Table      string   `json:"table,omitempty"`
+	Timing     string   `json:"timing,omitempty"`
+	Events     []string `json:"events,omitempty"`
+	Complexity string   `json:"complexity"`
+	Score      int      `json:"score"`
+	Suggestion string   `json:"suggestion"`
+	Body       string   `json:"body"`
+	// ChangeStream is the DDL of the change stream suggested to replace a
+	// trigger, and ConsumerStub describes the logic of its consumer.
+	ChangeStream string `json:"changeStream,omitempty"`
+	ConsumerStub string `json:"consumerStub,omitempty"`
+}
+
+// SearchIndexReport describes a Spanner search index converted from a
+// full-text index of the source, with an example of the rewrite of queries
+// using it.
+type SearchIndexReport struct {
+	Name         string   `json:"name"`
+	Table        string   `json:"table"`
+	SourceIndex  string   `json:"sourceIndex,omitempty"`
+	TokenColumns []string `json:"tokenColumns"`
+	SourceQuery  string   `json:"sourceQuery,omitempty"`
+	SpannerQuery string   `json:"spannerQuery"`
+}
+
 type UnexpectedCondition struct {
 	Count     int64  `json:"count"`
 	Condition string `json:"condition"`
@@ -116,6 +158,9 @@ type StructuredReport struct {
 	StatementStats       StatementStats       `json:"statementStats"`
 	NameChanges          []NameChange         `json:"nameChanges"`
 	TableReports         []TableReport        `json:"tableReports"`
+	ViewReports          []ViewReport         `json:"viewReports,omitempty"`
+	RoutineReports       []RoutineReport      `json:"routineReports,omitempty"`
+	SearchIndexReports   []SearchIndexReport  `json:"searchIndexReports,omitempty"`
 	UnexpectedConditions UnexpectedConditions `json:"unexpectedConditions"`
 	SchemaOnly           bool                 `json:"-"`
 }
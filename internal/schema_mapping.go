@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Mappings of the tables of source schemas other than the default one, e.g.
+// PostgreSQL schemas other than public or MySQL databases other than the one
+// connected to, to Spanner.
+const (
+	// SchemaMappingPrefix maps them to tables of the default Spanner schema
+	// whose names are prefixed with the source schema, e.g. sales_orders.
+	// This is the default.
+	SchemaMappingPrefix = "prefix"
+	// SchemaMappingNamedSchema maps them to tables of the Spanner named
+	// schema of the same name, e.g. sales.orders, along with their indexes.
+	SchemaMappingNamedSchema = "named-schema"
+)
+
+// ApplySchemaMapping maps the tables of conv converted from tables of source
+// schemas other than the default one with mapping.
+func ApplySchemaMapping(conv *Conv, mapping string) error {
+	switch mapping {
+	case SchemaMappingPrefix:
+		return nil
+	case SchemaMappingNamedSchema:
+	default:
+		return fmt.Errorf("invalid schema mapping %q, expected %q or %q", mapping, SchemaMappingPrefix, SchemaMappingNamedSchema)
+	}
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		ct := conv.SpSchema[tableId]
+		srcTable, ok := conv.SrcSchema[tableId]
+		// Source tables of the default schema aren't qualified by it.
+		if !ok || srcTable.Schema == "" || !strings.HasPrefix(srcTable.Name, srcTable.Schema+".") || ddl.NamedSchema(ct.Name) != "" {
+			continue
+		}
+		schema, _ := FixName(srcTable.Schema)
+		name := strings.TrimPrefix(ct.Name, schema+"_")
+		ct.Name = renameUsedName(conv, ct.Name, schema+"."+name)
+		for i, index := range ct.Indexes {
+			if ddl.NamedSchema(index.Name) == "" {
+				ct.Indexes[i].Name = renameUsedName(conv, index.Name, schema+"."+index.Name)
+			}
+		}
+		conv.SpSchema[tableId] = ct
+	}
+	return nil
+}
+
+// renameUsedName replaces the Spanner name oldName with newName in
+// conv.UsedNames, and returns newName.
+func renameUsedName(conv *Conv, oldName, newName string) string {
+	delete(conv.UsedNames, strings.ToLower(oldName))
+	conv.UsedNames[strings.ToLower(newName)] = true
+	return newName
+}
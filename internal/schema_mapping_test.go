@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func schemaMappingTestConv() *Conv {
+	conv := MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "orders", Schema: "public", Id: "t1"},
+		"t2": {Name: "sales.orders", Schema: "sales", Id: "t2"},
+		"t3": {Name: "hr-eu.employees", Schema: "hr-eu", Id: "t3"},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "orders", Id: "t1"},
+		"t2": {Name: "sales_orders", Id: "t2", Indexes: []ddl.CreateIndex{{Name: "orders_by_date", TableId: "t2"}}},
+		"t3": {Name: "hr_eu_employees", Id: "t3"},
+	}
+	for _, name := range []string{"orders", "sales_orders", "orders_by_date", "hr_eu_employees"} {
+		conv.UsedNames[name] = true
+	}
+	return conv
+}
+
+func TestApplySchemaMapping(t *testing.T) {
+	conv := schemaMappingTestConv()
+	assert.Nil(t, ApplySchemaMapping(conv, SchemaMappingPrefix))
+	assert.Equal(t, "sales_orders", conv.SpSchema["t2"].Name)
+
+	assert.Nil(t, ApplySchemaMapping(conv, SchemaMappingNamedSchema))
+	assert.Equal(t, "orders", conv.SpSchema["t1"].Name)
+	assert.Equal(t, "sales.orders", conv.SpSchema["t2"].Name)
+	assert.Equal(t, "sales.orders_by_date", conv.SpSchema["t2"].Indexes[0].Name)
+	assert.Equal(t, "hr_eu.employees", conv.SpSchema["t3"].Name)
+	assert.Equal(t, map[string]bool{"orders": true, "sales.orders": true, "sales.orders_by_date": true, "hr_eu.employees": true}, conv.UsedNames)
+
+	// Tables already in named schemas are left as they are.
+	assert.Nil(t, ApplySchemaMapping(conv, SchemaMappingNamedSchema))
+	assert.Equal(t, "sales.orders", conv.SpSchema["t2"].Name)
+
+	assert.NotNil(t, ApplySchemaMapping(conv, "schema"))
+}
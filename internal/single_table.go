@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+// SingleTableDesign is detected for source tables, such as DynamoDB tables,
+// which hold the items of several entity types: a single-table design.
+// It proposes to migrate the items of each entity type to its own table.
+type SingleTableDesign struct {
+	TableId string // Id of the source table.
+	// Attribute holds the entity type of the items. If KeyPrefix is set,
+	// Attribute is a key attribute whose values start with the entity type
+	// followed by '#', e.g. ORDER#1234.
+	Attribute string
+	KeyPrefix bool
+	Entities  []Entity
+}
+
+// Entity is an entity type of a single-table design.
+type Entity struct {
+	Name      string   // Entity type, as found in the items.
+	TableName string   // Name of the table of the entity type.
+	Items     int64    // Number of sampled items of the entity type.
+	ColIds    []string // Columns of the source table found in the items of the entity type.
+	// Parent is the entity type whose table the table of this entity type
+	// is interleaved in, or "" for none. The items of both entity types
+	// share partition keys, and the parent has one item per partition key.
+	Parent string
+}
+
+// SetSingleTableDesign records the single-table design detected for a
+// source table in conv.SingleTableDesigns.
+func SetSingleTableDesign(conv *Conv, design SingleTableDesign) {
+	if conv.SingleTableDesigns == nil {
+		conv.SingleTableDesigns = make(map[string]SingleTableDesign)
+	}
+	conv.SingleTableDesigns[design.TableId] = design
+}
@@ -32,6 +32,8 @@ func AssertSpSchema(conv *Conv, t *testing.T, expectedSchema, actualSchema map[s
 		assertSpPk(conv, t, tableId, expectedTable.PrimaryKeys, actualSchema[tableId].PrimaryKeys)
 		assertSpFk(conv, t, tableId, expectedTable.ForeignKeys, actualSchema[tableId].ForeignKeys)
 		assertSpIndexes(conv, t, tableId, expectedTable.Indexes, actualSchema[tableId].Indexes)
+		assertSpSearchIndexes(conv, t, tableId, expectedTable.SearchIndexes, actualSchema[tableId].SearchIndexes)
+		assertSpVectorIndexes(conv, t, tableId, expectedTable.VectorIndexes, actualSchema[tableId].VectorIndexes)
 		assertCheckConstraints(conv, t, tableId, expectedTable.CheckConstraints, actualSchema[tableId].CheckConstraints)
 	}
 }
@@ -104,6 +106,7 @@ func assertSpIndexes(conv *Conv, t *testing.T, tableId string, expectedIndexes,
 			assert.Equal(t, indexTableId, actualIndex.TableId)
 		}
 		assert.Equal(t, index.Unique, actualIndex.Unique)
+		assert.Equal(t, index.NullFiltered, actualIndex.NullFiltered)
 		assert.Equal(t, len(index.Keys), len(actualIndex.Keys))
 		for j, indexKey := range index.Keys {
 			colId, err := GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, indexKey.ColId)
@@ -120,6 +123,38 @@ func assertSpIndexes(conv *Conv, t *testing.T, tableId string, expectedIndexes,
 	}
 }
 
+func assertSpSearchIndexes(conv *Conv, t *testing.T, tableId string, expectedIndexes, actualIndexes []ddl.SearchIndex) {
+	assert.Equal(t, len(expectedIndexes), len(actualIndexes))
+	for i, index := range expectedIndexes {
+		if i >= len(actualIndexes) {
+			break
+		}
+		actualIndex := actualIndexes[i]
+		assert.Equal(t, index.Name, actualIndex.Name)
+		for j, indexKey := range index.Keys {
+			colId, err := GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, indexKey.ColId)
+			assert.Equal(t, nil, err)
+			index.Keys[j].ColId = colId
+		}
+		assert.Equal(t, index.Keys, actualIndex.Keys)
+	}
+}
+
+func assertSpVectorIndexes(conv *Conv, t *testing.T, tableId string, expectedIndexes, actualIndexes []ddl.VectorIndex) {
+	assert.Equal(t, len(expectedIndexes), len(actualIndexes))
+	for i, index := range expectedIndexes {
+		if i >= len(actualIndexes) {
+			break
+		}
+		actualIndex := actualIndexes[i]
+		assert.Equal(t, index.Name, actualIndex.Name)
+		colId, err := GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, index.ColId)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, colId, actualIndex.ColId)
+		assert.Equal(t, index.DistanceType, actualIndex.DistanceType)
+	}
+}
+
 func getFkIdFromSpName(fks []ddl.Foreignkey, fkName string) string {
 	for _, fk := range fks {
 		if fk.Name == fkName {
@@ -203,6 +238,7 @@ func assertSrcIndexes(t *testing.T, conv *Conv, tableId string, expectedIndexes,
 		actualIndex, err := getIndexFromSrcName(conv.SrcSchema[tableId].Indexes, index.Name)
 		assert.Equal(t, err, nil)
 		assert.Equal(t, index.Unique, actualIndex.Unique)
+		assert.Equal(t, index.NullFiltered, actualIndex.NullFiltered)
 		assert.Equal(t, len(index.Keys), len(actualIndex.Keys))
 		for j, indexKey := range index.Keys {
 			colId := conv.SrcSchema[tableId].ColNameIdMap[indexKey.ColId]
@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Spanner has no triggers. The effects of a source trigger can instead be
+// reproduced by a consumer of a change stream watching its table, which
+// reads the rows inserted, updated or deleted by each transaction once it
+// has committed.
+
+// TriggerEvents returns the events of trigger r captured by change streams,
+// i.e. INSERT, UPDATE and DELETE, which are also the mod types of the data
+// change records.
+func TriggerEvents(r schema.Routine) []string {
+	var events []string
+	for _, e := range r.Events {
+		switch e = strings.ToUpper(strings.TrimSpace(e)); e {
+		case "INSERT", "UPDATE", "DELETE":
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// TriggerChangeStreams returns the change streams suggested to replace the
+// triggers of the source, by Spanner table id: one per table with triggers
+// on inserts, updates or deletes, capturing the new rows along with the old
+// values of updated and deleted rows. Change streams already added for the
+// triggers of a table, see AddTriggerChangeStreams, are returned as they are.
+func TriggerChangeStreams(conv *Conv) map[string]ddl.ChangeStream {
+	oldValues := make(map[string]bool)
+	for _, r := range conv.SrcRoutines {
+		events := TriggerEvents(r)
+		if r.Kind != schema.RoutineTrigger || len(events) == 0 {
+			continue
+		}
+		tableId, err := GetTableIdFromSrcName(conv.SrcSchema, r.Table)
+		if err != nil {
+			continue
+		}
+		if _, ok := conv.SpSchema[tableId]; !ok {
+			continue
+		}
+		oldValues[tableId] = oldValues[tableId] || slices.ContainsFunc(events, func(e string) bool { return e != "INSERT" })
+	}
+	var tableIds []string
+	for tableId := range oldValues {
+		tableIds = append(tableIds, tableId)
+	}
+	sort.Slice(tableIds, func(i, j int) bool { return conv.SpSchema[tableIds[i]].Name < conv.SpSchema[tableIds[j]].Name })
+
+	suggested := make(map[string]ddl.ChangeStream)
+	names := make(map[string]bool)
+	for _, tableId := range tableIds {
+		name := strings.ReplaceAll(conv.SpSchema[tableId].Name, ".", "_") + "_changes"
+		if cs, ok := triggerChangeStream(conv, tableId, name); ok {
+			suggested[tableId] = cs
+			continue
+		}
+		name = uniqueName(name, maxConstraintNameLength, func(n string) bool {
+			return conv.UsedNames[strings.ToLower(n)] || names[strings.ToLower(n)]
+		})
+		names[strings.ToLower(name)] = true
+		cs := ddl.ChangeStream{
+			Name:             name,
+			WatchedTables:    []ddl.ChangeStreamTable{{TableId: tableId}},
+			ValueCaptureType: "NEW_ROW",
+		}
+		if oldValues[tableId] {
+			cs.ValueCaptureType = "NEW_ROW_AND_OLD_VALUES"
+		}
+		suggested[tableId] = cs
+	}
+	return suggested
+}
+
+// triggerChangeStream returns the change stream of conv named name, or name
+// with a numeric suffix, if it watches all the columns of table tableId only.
+func triggerChangeStream(conv *Conv, tableId, name string) (ddl.ChangeStream, bool) {
+	nameRegex := regexp.MustCompile(`(?i)^` + regexp.QuoteMeta(name) + `(_[0-9]+)?$`)
+	for _, cs := range conv.SpChangeStreams {
+		if !nameRegex.MatchString(cs.Name) {
+			continue
+		}
+		if !cs.WatchAll && len(cs.WatchedTables) == 1 && cs.WatchedTables[0].TableId == tableId && len(cs.WatchedTables[0].ColIds) == 0 {
+			return cs, true
+		}
+	}
+	return ddl.ChangeStream{}, false
+}
+
+// AddTriggerChangeStreams adds the change streams suggested to replace the
+// triggers of the source to conv, see TriggerChangeStreams.
+func AddTriggerChangeStreams(conv *Conv) error {
+	if conv.SpChangeStreams == nil {
+		conv.SpChangeStreams = make(map[string]ddl.ChangeStream)
+	}
+	for _, cs := range TriggerChangeStreams(conv) {
+		if cs.Id != "" {
+			continue
+		}
+		cs.Id = GenerateChangeStreamId()
+		if err := ValidateChangeStream(conv.SpSchema, cs); err != nil {
+			return fmt.Errorf("can't add change stream %s: %v", cs.Name, err)
+		}
+		conv.SpChangeStreams[cs.Id] = cs
+		conv.UsedNames[strings.ToLower(cs.Name)] = true
+	}
+	return nil
+}
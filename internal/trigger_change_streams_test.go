@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func triggerTestConv() *Conv {
+	conv := MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "orders", Id: "t1"},
+		"t2": {Name: "customers", Id: "t2"},
+		"t3": {Name: "audit", Id: "t3"},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "orders", Id: "t1"},
+		"t2": {Name: "customers", Id: "t2"},
+		"t3": {Name: "audit", Id: "t3"},
+	}
+	conv.UsedNames = map[string]bool{"orders": true, "customers": true, "audit": true, "customers_changes": true}
+	conv.SrcRoutines = map[string]schema.Routine{
+		"r1": {Name: "audit_orders", Kind: schema.RoutineTrigger, Table: "orders", Timing: "AFTER", Events: []string{"INSERT"}},
+		"r2": {Name: "total_orders", Kind: schema.RoutineTrigger, Table: "customers", Timing: "AFTER", Events: []string{"INSERT", "DELETE"}},
+		"r3": {Name: "truncate_audit", Kind: schema.RoutineTrigger, Table: "audit", Timing: "AFTER", Events: []string{"TRUNCATE"}},
+		"r4": {Name: "archive", Kind: schema.RoutineProcedure},
+		"r5": {Name: "dropped", Kind: schema.RoutineTrigger, Table: "carts", Timing: "AFTER", Events: []string{"INSERT"}},
+	}
+	return conv
+}
+
+func TestTriggerChangeStreams(t *testing.T) {
+	conv := triggerTestConv()
+	assert.Equal(t, map[string]ddl.ChangeStream{
+		"t1": {Name: "orders_changes", WatchedTables: []ddl.ChangeStreamTable{{TableId: "t1"}}, ValueCaptureType: "NEW_ROW"},
+		"t2": {Name: "customers_changes_1", WatchedTables: []ddl.ChangeStreamTable{{TableId: "t2"}}, ValueCaptureType: "NEW_ROW_AND_OLD_VALUES"},
+	}, TriggerChangeStreams(conv))
+	// Suggesting change streams doesn't change the schema.
+	assert.Empty(t, conv.SpChangeStreams)
+	assert.False(t, conv.UsedNames["orders_changes"])
+}
+
+func TestAddTriggerChangeStreams(t *testing.T) {
+	conv := triggerTestConv()
+	assert.Nil(t, AddTriggerChangeStreams(conv))
+	assert.Equal(t, 2, len(conv.SpChangeStreams))
+	assert.True(t, conv.UsedNames["orders_changes"])
+
+	// The change streams added are suggested again, and not added twice.
+	suggested := TriggerChangeStreams(conv)
+	assert.Equal(t, "orders_changes", suggested["t1"].Name)
+	assert.NotEmpty(t, suggested["t1"].Id)
+	assert.Nil(t, AddTriggerChangeStreams(conv))
+	assert.Equal(t, 2, len(conv.SpChangeStreams))
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+// UserDefinedType is a user-defined type of the source database, such as a
+// Cassandra UDT, used by the columns of source tables.
+type UserDefinedType struct {
+	Name       string
+	FieldNames []string
+	FieldTypes []string // Source types of the fields, e.g. text or frozen<address>.
+	// Flatten is set to map each column of the type to a column per field,
+	// rather than to a JSON column. Fields of nested user-defined types
+	// are flattened if their types are flattened too.
+	Flatten bool
+}
+
+// SetUserDefinedType records a user-defined type used by source columns in
+// conv.UserDefinedTypes.
+func SetUserDefinedType(conv *Conv, t UserDefinedType) {
+	if conv.UserDefinedTypes == nil {
+		conv.UserDefinedTypes = make(map[string]UserDefinedType)
+	}
+	conv.UserDefinedTypes[t.Name] = t
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	go_ora "github.com/sijms/go-ora/v2"
+	"github.com/snowflakedb/gosnowflake"
 )
 
 // Parses input string `s` as a map of key-value pairs. It's expected that the
@@ -84,10 +85,10 @@ func GetSQLConnectionStr(sourceProfile SourceProfile) string {
 		switch sourceProfile.Conn.Ty {
 		case SourceProfileConnectionTypeMySQL:
 			connParams := sourceProfile.Conn.Mysql
-			return getMYSQLConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db)
+			return getMYSQLConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db, connParams.TLS)
 		case SourceProfileConnectionTypePostgreSQL:
 			connParams := sourceProfile.Conn.Pg
-			return getPGSQLConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db)
+			return getPGSQLConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db, connParams.TLS)
 		case SourceProfileConnectionTypeDynamoDB:
 			// For DynamoDB, client provided by aws-sdk reads connection credentials from env variables only.
 			// Thus, there is no need to create sqlConnectionStr for the same. We instead set the env variables
@@ -95,10 +96,23 @@ func GetSQLConnectionStr(sourceProfile SourceProfile) string {
 			return ""
 		case SourceProfileConnectionTypeSqlServer:
 			connParams := sourceProfile.Conn.SqlServer
-			return getSQLSERVERConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db)
+			return getSQLSERVERConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db, connParams.TLS)
 		case SourceProfileConnectionTypeOracle:
 			connParams := sourceProfile.Conn.Oracle
-			return getORACLEConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db)
+			return getORACLEConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db, connParams.TLS)
+		case SourceProfileConnectionTypeSQLite:
+			return getSQLITEConnectionStr(sourceProfile.Conn.SQLite.Path)
+		case SourceProfileConnectionTypeMariaDB:
+			connParams := sourceProfile.Conn.MariaDB
+			return getMYSQLConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db, connParams.TLS)
+		case SourceProfileConnectionTypeSnowflake:
+			return getSNOWFLAKEConnectionStr(sourceProfile.Conn.Snowflake)
+		case SourceProfileConnectionTypeRedshift:
+			connParams := sourceProfile.Conn.Redshift
+			return getREDSHIFTConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db, connParams.TLS)
+		case SourceProfileConnectionTypeSybase:
+			connParams := sourceProfile.Conn.Sybase
+			return getSYBASEConnectionStr(connParams.Host, connParams.Port, connParams.User, connParams.Pwd, connParams.Db)
 		}
 	}
 	return sqlConnectionStr
@@ -118,11 +132,11 @@ func GeneratePGSQLConnectionStr() (string, error) {
 		getInfo := utils.GetUtilInfoImpl{}
 		password = getInfo.GetPassword()
 	}
-	return getPGSQLConnectionStr(server, port, user, password, dbName), nil
+	return getPGSQLConnectionStr(server, port, user, password, dbName, TLSOptions{}), nil
 }
 
-func getPGSQLConnectionStr(server, port, user, password, dbName string) string {
-	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", server, port, user, password, dbName)
+func getPGSQLConnectionStr(server, port, user, password, dbName string, tlsOpts TLSOptions) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s %s", server, port, user, password, dbName, pgTLSParams(tlsOpts, TLSModeDisable))
 }
 
 func GenerateMYSQLConnectionStr() (string, error) {
@@ -139,15 +153,58 @@ func GenerateMYSQLConnectionStr() (string, error) {
 		getInfo := utils.GetUtilInfoImpl{}
 		password = getInfo.GetPassword()
 	}
-	return getMYSQLConnectionStr(server, port, user, password, dbName), nil
+	return getMYSQLConnectionStr(server, port, user, password, dbName, TLSOptions{}), nil
 }
 
-func getMYSQLConnectionStr(server, port, user, password, dbName string) string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", user, password, server, port, dbName)
+func getMYSQLConnectionStr(server, port, user, password, dbName string, tlsOpts TLSOptions) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s%s", user, password, server, port, dbName, mysqlTLSParams(server, port, tlsOpts))
 }
 
-func getSQLSERVERConnectionStr(server, port, user, password, dbName string) string {
-	return fmt.Sprintf(`sqlserver://%s:%s@%s:%s?database=%s`, user, password, server, port, dbName)
+func getSQLSERVERConnectionStr(server, port, user, password, dbName string, tlsOpts TLSOptions) string {
+	connStr := fmt.Sprintf(`sqlserver://%s:%s@%s:%s?database=%s`, user, password, server, port, dbName)
+	if params := sqlServerTLSParams(tlsOpts); len(params) > 0 {
+		connStr += "&" + params.Encode()
+	}
+	return connStr
+}
+
+// getSQLITEConnectionStr opens the database file read-only, so that the
+// migration can't modify the source database. Characters with a meaning in
+// SQLite URI filenames are escaped.
+func getSQLITEConnectionStr(path string) string {
+	path = strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(path)
+	return fmt.Sprintf("file:%s?mode=ro", path)
+}
+
+// getSNOWFLAKEConnectionStr returns the DSN of the Snowflake database of
+// connParams. Warehouse and role default to those of the user if not set.
+func getSNOWFLAKEConnectionStr(connParams SourceProfileConnectionSnowflake) string {
+	dsn, err := gosnowflake.DSN(&gosnowflake.Config{
+		Account:   connParams.Account,
+		User:      connParams.User,
+		Password:  connParams.Pwd,
+		Database:  connParams.Db,
+		Warehouse: connParams.Warehouse,
+		Role:      connParams.Role,
+	})
+	if err != nil {
+		return ""
+	}
+	return dsn
+}
+
+// getREDSHIFTConnectionStr returns the connection string of a Redshift
+// database, which is accessed with the PostgreSQL driver. Redshift clusters
+// require SSL connections by default.
+func getREDSHIFTConnectionStr(server, port, user, password, dbName string, tlsOpts TLSOptions) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s %s", server, port, user, password, dbName, pgTLSParams(tlsOpts, TLSModeRequire))
+}
+
+// getSYBASEConnectionStr returns the connection string of an ASE database,
+// in the tds URL form of FreeTDS compatible drivers. Values are exchanged
+// in UTF-8, whatever the default character set of the server.
+func getSYBASEConnectionStr(server, port, user, password, dbName string) string {
+	return fmt.Sprintf("tds://%s:%s@%s:%s/%s?charset=utf8", user, password, server, port, dbName)
 }
 
 func GetSchemaSampleSize(sourceProfile SourceProfile) int64 {
@@ -158,11 +215,16 @@ func GetSchemaSampleSize(sourceProfile SourceProfile) int64 {
 				schemaSampleSize = sourceProfile.Conn.Dydb.SchemaSampleSize
 			}
 		}
+		if sourceProfile.Conn.Ty == SourceProfileConnectionTypeMongoDB {
+			if sourceProfile.Conn.MongoDB.SchemaSampleSize != 0 {
+				schemaSampleSize = sourceProfile.Conn.MongoDB.SchemaSampleSize
+			}
+		}
 	}
 	return schemaSampleSize
 }
 
-func getORACLEConnectionStr(server, port, user, password, dbName string) string {
+func getORACLEConnectionStr(server, port, user, password, dbName string, tlsOpts TLSOptions) string {
 	portNumber, _ := strconv.Atoi(port)
-	return go_ora.BuildUrl(server, portNumber, dbName, user, password, nil)
+	return go_ora.BuildUrl(server, portNumber, dbName, user, password, oracleTLSOptions(tlsOpts))
 }
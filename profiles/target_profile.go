@@ -17,6 +17,7 @@ package profiles
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,11 +42,54 @@ const (
 )
 
 type TargetProfileConnectionSpanner struct {
-	Endpoint string // Same as SPANNER_API_ENDPOINT environment variable
-	Project  string // Same as GCLOUD_PROJECT environment variable
-	Instance string
-	Dbname   string
-	Dialect  string
+	Endpoint          string // Same as SPANNER_API_ENDPOINT environment variable
+	Project           string // Same as GCLOUD_PROJECT environment variable
+	Instance          string
+	Dbname            string
+	Dialect           string
+	ChangeStreamsFile string // JSON file declaring change streams to create in the target database
+	ProtoEnumPackage  string // If set, source ENUM columns are mapped to proto enums in this package
+	ProtoDescriptors  string // File containing the serialized FileDescriptorSet for PROTO and ENUM columns
+	EnumStrategy      string // Strategy of converting source ENUM columns, see internal.EnumStrategyString
+	FkNotEnforced     bool   // If true, foreign keys are created as informational NOT ENFORCED foreign keys
+	UUIDAsString      bool   // If true, UUID columns are created as STRING(36) instead
+	// Policy of converting source auto-increment columns, see internal.AutoIncrementPolicySequence.
+	AutoIncrementPolicy string
+	// If true, table and column comments are created in PostgreSQL dialect databases with COMMENT ON statements.
+	CommentStatements bool
+	// Mapping of the tables of source schemas other than the default one, see internal.SchemaMappingPrefix.
+	SchemaMapping string
+	// If true, tables renamed during the conversion keep their source name as a synonym.
+	KeepSourceNameAsSynonym bool
+	// If true, the schema of an existing database that isn't empty is updated to the converted schema, see conversion.GetSchemaUpdateDDL.
+	UpdateExistingSchema bool
+	// If true, the schema update also drops the tables, columns, indexes and constraints of the existing schema missing from the converted one.
+	AllowSchemaDrops bool
+	// JSON file declaring locality groups to create in the target database and the tables stored in them.
+	LocalityGroupsFile string
+	// JSON file declaring placements to create in the target database and the placement keys of tables.
+	PlacementsFile string
+	// If true, the change streams suggested to replace the triggers of the source are created, see internal.TriggerChangeStreams.
+	TriggerChangeStreams bool
+	// JSON file declaring remote Vertex AI models to create in the target database.
+	ModelsFile string
+	// JSON file declaring source columns to exclude from the migration or whose data is redacted.
+	ColumnPoliciesFile string
+	// If set, a property graph with this name is proposed from the foreign keys of the schema and created along with it.
+	PropertyGraph string
+	// Templates naming the foreign keys, indexes and check constraints unnamed in the source, see internal.ConstraintNaming.
+	FkNameTemplate          string // e.g. fk_{table}_{cols}
+	IndexNameTemplate       string // e.g. idx_{table}_{cols}
+	CheckNameTemplate       string // e.g. chk_{table}
+	ConstraintNameMaxLength int
+	// If set, STRING and BYTES columns of unbounded length are given the length of their longest
+	// value in this many rows sampled per source table times StringLengthMargin, see common.InferStringLengths.
+	StringLengthSampleSize int64
+	StringLengthMargin     float64 // e.g. 1.5
+	// Database options set when the schema is created.
+	VersionRetentionPeriod string // e.g. 7d
+	DefaultLeader          string // e.g. us-central1
+	DefaultSequenceKind    string // e.g. bit_reversed_positive
 }
 
 type TargetProfileConnection struct {
@@ -124,6 +168,107 @@ func (targetProfile *TargetProfile) GetResourceIds(ctx context.Context, now time
 //
 // Example: -target-profile="instance=my-instance1,dbName=my-new-db1"
 // Example: -target-profile="instance=my-instance1,dbName=my-new-db1,dialect=PostgreSQL"
+//
+// Change streams to create along with the schema can be declared in a JSON
+// file passed with the changeStreams param.
+// Example: -target-profile="instance=my-instance1,changeStreams=change_streams.json"
+//
+// The conversion report suggests a change stream on the table of each source
+// trigger, whose consumer reproduces the effects of the trigger. The change
+// streams are created along with the schema with the triggerChangeStreams
+// param.
+// Example: -target-profile="instance=my-instance1,triggerChangeStreams=true"
+//
+// Locality groups, e.g. to keep large archival tables on HDD storage, can be
+// declared in a JSON file passed with the localityGroups param.
+// Example: -target-profile="instance=my-instance1,localityGroups=locality_groups.json"
+//
+// Placements of a geo-partitioned database, and the placement key columns of
+// its tables, can be declared in a JSON file passed with the placements param.
+// Example: -target-profile="instance=my-instance1,placements=placements.json"
+//
+// Remote Vertex AI models, e.g. for queries using ML.PREDICT, can be declared
+// in a JSON file passed with the models param.
+// Example: -target-profile="instance=my-instance1,models=models.json"
+//
+// Source columns whose data must not be migrated, e.g. columns holding PII,
+// can be declared in a JSON file passed with the columnPolicies param.
+// Excluded columns are dropped from the schema, redacted columns are kept but
+// written NULL or a fixed mask in place of their values.
+// Example: -target-profile="instance=my-instance1,columnPolicies=column_policies.json"
+//
+// A property graph over the schema, with tables related by foreign keys as
+// nodes and the foreign keys as edges, can be created with the propertyGraph
+// param naming the graph.
+// Example: -target-profile="instance=my-instance1,propertyGraph=MusicGraph"
+//
+// Source ENUM columns can be mapped to Spanner proto enums instead of STRING
+// with the protoEnumPackage param. The proto descriptors of the enums, built
+// from the generated .proto file, are passed with the protoDescriptors param.
+// Example: -target-profile="instance=my-instance1,protoEnumPackage=shop.enums,protoDescriptors=descriptors.pb"
+//
+// Source ENUM columns mapped to STRING, and MySQL SET columns, can also be
+// given a CHECK constraint allowing only the values of the ENUM or members of
+// the SET with the enumStrategy param.
+// Example: -target-profile="instance=my-instance1,enumStrategy=check-constraint"
+//
+// Source auto-increment columns, e.g. MySQL AUTO_INCREMENT columns or
+// PostgreSQL identity columns, are converted to columns using a bit-reversed
+// sequence by default. The autoIncrementPolicy param converts them to identity
+// columns (identity), STRING(36) columns generating UUIDs (uuid), or INT64
+// columns without auto-generation (keep) instead. The policy can then be
+// changed per table in the web UI.
+// Example: -target-profile="instance=my-instance1,autoIncrementPolicy=identity"
+//
+// Table and column comments are carried over from MySQL, PostgreSQL and
+// Oracle databases to the schema. They are created in PostgreSQL dialect
+// databases with COMMENT ON statements with the commentStatements param.
+// Example: -target-profile="instance=my-instance1,dialect=postgresql,commentStatements=true"
+//
+// Tables of source schemas other than the default one, e.g. those of the
+// schemas or databases selected with the schemas param of the source profile,
+// are created with their names prefixed by their schema by default. The
+// schemaMapping param creates them in Spanner named schemas of the same name
+// instead (named-schema).
+// Example: -target-profile="instance=my-instance1,schemaMapping=named-schema"
+//
+// Foreign keys can be created as informational foreign keys, which Spanner
+// does not enforce, with the fkNotEnforced param.
+// Example: -target-profile="instance=my-instance1,fkNotEnforced=true"
+//
+// Columns mapped to the UUID type can be created as STRING(36) columns
+// instead with the uuidAsString param.
+// Example: -target-profile="instance=my-instance1,uuidAsString=true"
+//
+// The schema command only writes to existing databases with an empty schema by
+// default. With the updateExistingSchema param, the schema of an existing
+// database is compared with the converted schema and updated with the
+// statements adding and altering its tables, columns, indexes and constraints
+// instead, so that the tool can be re-run against an evolving source. Tables,
+// columns, indexes and constraints missing from the converted schema are only
+// dropped with the allowSchemaDrops param.
+// Example: -target-profile="instance=my-instance1,dbName=my-db,updateExistingSchema=true"
+//
+// Tables whose name had to change when mapped to Spanner can keep their source
+// name addressable as a table synonym with the keepSourceNameAsSynonym param.
+// Example: -target-profile="instance=my-instance1,keepSourceNameAsSynonym=true"
+//
+// Foreign keys, indexes and check constraints unnamed in the source are named
+// from templates with the fkNameTemplate, indexNameTemplate and
+// checkNameTemplate params, and names are truncated to
+// constraintNameMaxLength characters.
+// Example: -target-profile="instance=my-instance1,fkNameTemplate=fk_{table}_{ref_table},indexNameTemplate=idx_{table}_{cols}"
+//
+// For MySQL and PostgreSQL databases, STRING and BYTES columns mapped from
+// source columns of unbounded length, e.g. TEXT, are given the length of their
+// longest value in stringLengthSampleSize rows sampled per table, times
+// stringLengthMargin (1.5 by default).
+// Example: -target-profile="instance=my-instance1,stringLengthSampleSize=10000,stringLengthMargin=2"
+//
+// The version retention period, default leader and default sequence kind of
+// the database are set along with the schema with the versionRetentionPeriod,
+// defaultLeader and defaultSequenceKind params.
+// Example: -target-profile="instance=my-instance1,versionRetentionPeriod=7d,defaultLeader=us-central1"
 func NewTargetProfile(s string) (TargetProfile, error) {
 	params, err := ParseMap(s)
 	if err != nil {
@@ -146,6 +291,117 @@ func NewTargetProfile(s string) (TargetProfile, error) {
 	if dialect, ok := params["dialect"]; ok {
 		sp.Dialect = strings.ToLower(dialect)
 	}
+	if changeStreamsFile, ok := params["changeStreams"]; ok {
+		sp.ChangeStreamsFile = changeStreamsFile
+	}
+	if triggerChangeStreams, ok := params["triggerChangeStreams"]; ok {
+		sp.TriggerChangeStreams, err = strconv.ParseBool(triggerChangeStreams)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse triggerChangeStreams param, error = %v", err)
+		}
+	}
+	if localityGroupsFile, ok := params["localityGroups"]; ok {
+		sp.LocalityGroupsFile = localityGroupsFile
+	}
+	if placementsFile, ok := params["placements"]; ok {
+		sp.PlacementsFile = placementsFile
+	}
+	if modelsFile, ok := params["models"]; ok {
+		sp.ModelsFile = modelsFile
+	}
+	if columnPoliciesFile, ok := params["columnPolicies"]; ok {
+		sp.ColumnPoliciesFile = columnPoliciesFile
+	}
+	if propertyGraph, ok := params["propertyGraph"]; ok {
+		sp.PropertyGraph = propertyGraph
+	}
+	if protoEnumPackage, ok := params["protoEnumPackage"]; ok {
+		sp.ProtoEnumPackage = protoEnumPackage
+	}
+	if protoDescriptors, ok := params["protoDescriptors"]; ok {
+		sp.ProtoDescriptors = protoDescriptors
+	}
+	if enumStrategy, ok := params["enumStrategy"]; ok {
+		sp.EnumStrategy = enumStrategy
+	}
+	if autoIncrementPolicy, ok := params["autoIncrementPolicy"]; ok {
+		sp.AutoIncrementPolicy = autoIncrementPolicy
+	}
+	if schemaMapping, ok := params["schemaMapping"]; ok {
+		sp.SchemaMapping = schemaMapping
+	}
+	if commentStatements, ok := params["commentStatements"]; ok {
+		sp.CommentStatements, err = strconv.ParseBool(commentStatements)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse commentStatements param, error = %v", err)
+		}
+	}
+	if fkNotEnforced, ok := params["fkNotEnforced"]; ok {
+		sp.FkNotEnforced, err = strconv.ParseBool(fkNotEnforced)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse fkNotEnforced param, error = %v", err)
+		}
+	}
+	if uuidAsString, ok := params["uuidAsString"]; ok {
+		sp.UUIDAsString, err = strconv.ParseBool(uuidAsString)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse uuidAsString param, error = %v", err)
+		}
+	}
+	if updateExistingSchema, ok := params["updateExistingSchema"]; ok {
+		sp.UpdateExistingSchema, err = strconv.ParseBool(updateExistingSchema)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse updateExistingSchema param, error = %v", err)
+		}
+	}
+	if allowSchemaDrops, ok := params["allowSchemaDrops"]; ok {
+		sp.AllowSchemaDrops, err = strconv.ParseBool(allowSchemaDrops)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse allowSchemaDrops param, error = %v", err)
+		}
+	}
+	if keepSourceNameAsSynonym, ok := params["keepSourceNameAsSynonym"]; ok {
+		sp.KeepSourceNameAsSynonym, err = strconv.ParseBool(keepSourceNameAsSynonym)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse keepSourceNameAsSynonym param, error = %v", err)
+		}
+	}
+	if fkNameTemplate, ok := params["fkNameTemplate"]; ok {
+		sp.FkNameTemplate = fkNameTemplate
+	}
+	if indexNameTemplate, ok := params["indexNameTemplate"]; ok {
+		sp.IndexNameTemplate = indexNameTemplate
+	}
+	if checkNameTemplate, ok := params["checkNameTemplate"]; ok {
+		sp.CheckNameTemplate = checkNameTemplate
+	}
+	if constraintNameMaxLength, ok := params["constraintNameMaxLength"]; ok {
+		sp.ConstraintNameMaxLength, err = strconv.Atoi(constraintNameMaxLength)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse constraintNameMaxLength param, error = %v", err)
+		}
+	}
+	if stringLengthSampleSize, ok := params["stringLengthSampleSize"]; ok {
+		sp.StringLengthSampleSize, err = strconv.ParseInt(stringLengthSampleSize, 10, 64)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse stringLengthSampleSize param, error = %v", err)
+		}
+	}
+	if stringLengthMargin, ok := params["stringLengthMargin"]; ok {
+		sp.StringLengthMargin, err = strconv.ParseFloat(stringLengthMargin, 64)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("could not parse stringLengthMargin param, error = %v", err)
+		}
+	}
+	if versionRetentionPeriod, ok := params["versionRetentionPeriod"]; ok {
+		sp.VersionRetentionPeriod = versionRetentionPeriod
+	}
+	if defaultLeader, ok := params["defaultLeader"]; ok {
+		sp.DefaultLeader = defaultLeader
+	}
+	if defaultSequenceKind, ok := params["defaultSequenceKind"]; ok {
+		sp.DefaultSequenceKind = defaultSequenceKind
+	}
 	if sp.Dialect == "" {
 		sp.Dialect = constants.DIALECT_GOOGLESQL
 	} else if sp.Dialect != constants.DIALECT_POSTGRESQL && sp.Dialect != constants.DIALECT_GOOGLESQL {
@@ -0,0 +1,251 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiles
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/go-sql-driver/mysql"
+)
+
+// TLS modes of connections to source databases, named after the SSL modes
+// of libpq.
+const (
+	TLSModeDisable    = "disable"     // Don't use TLS.
+	TLSModeRequire    = "require"     // Use TLS, without verifying the server.
+	TLSModeVerifyCA   = "verify-ca"   // Use TLS, verifying the certificate chain of the server.
+	TLSModeVerifyFull = "verify-full" // Use TLS, verifying the certificate chain and the name of the server.
+)
+
+// TLSOptions are the TLS options of connections to source databases. Files
+// are PEM encoded.
+type TLSOptions struct {
+	Mode       string // TLS mode, the default of the driver of the source if empty.
+	CACert     string // Path of the CA certificates verifying the server, instead of those of the system.
+	ClientCert string // Path of the certificate of the client, for client certificate authentication.
+	ClientKey  string // Path of the key of the client certificate.
+	ServerName string // Name of the server verified in its certificate, instead of its host.
+}
+
+// tlsSupport is the TLS options supported by the driver of a source, which
+// all support the disable and require modes.
+type tlsSupport struct {
+	verifyCA   bool
+	verifyFull bool
+	caCert     bool
+	clientCert bool
+	serverName bool
+}
+
+// tlsSupports are the TLS supports of the drivers of the sources accepting
+// TLS options.
+var tlsSupports = map[string]tlsSupport{
+	constants.MYSQL:     {verifyCA: true, verifyFull: true, caCert: true, clientCert: true, serverName: true},
+	constants.MARIADB:   {verifyCA: true, verifyFull: true, caCert: true, clientCert: true, serverName: true},
+	constants.POSTGRES:  {verifyCA: true, verifyFull: true, caCert: true, clientCert: true},
+	constants.REDSHIFT:  {verifyCA: true, verifyFull: true, caCert: true, clientCert: true},
+	constants.SQLSERVER: {verifyFull: true, caCert: true, serverName: true},
+	// Oracle servers are only verified against the certificates of wallets.
+	constants.ORACLE:    {},
+	constants.CASSANDRA: {verifyCA: true, verifyFull: true, caCert: true, clientCert: true, serverName: true},
+	constants.MONGODB:   {verifyCA: true, verifyFull: true, caCert: true, clientCert: true, serverName: true},
+}
+
+// newTLSOptions parses the TLS options of params, for a database of source.
+// The mode defaults to verify-full when a CA certificate is given, and to
+// require when other options are.
+func newTLSOptions(params map[string]string, source string) (TLSOptions, error) {
+	opts := TLSOptions{
+		Mode:       params["tlsMode"],
+		CACert:     params["tlsCaCert"],
+		ClientCert: params["tlsClientCert"],
+		ClientKey:  params["tlsClientKey"],
+		ServerName: params["tlsServerName"],
+	}
+	if opts.Mode == "" && opts.CACert != "" {
+		opts.Mode = TLSModeVerifyFull
+	}
+	switch opts.Mode {
+	case "", TLSModeRequire, TLSModeVerifyCA, TLSModeVerifyFull:
+	case TLSModeDisable:
+		if opts != (TLSOptions{Mode: TLSModeDisable}) {
+			return opts, fmt.Errorf("tlsMode is disable, but other TLS options are set in the source-profile")
+		}
+		return opts, nil
+	default:
+		return opts, fmt.Errorf("invalid tlsMode %q, must be one of %s, %s, %s or %s", opts.Mode, TLSModeDisable, TLSModeRequire, TLSModeVerifyCA, TLSModeVerifyFull)
+	}
+	if (opts.ClientCert == "") != (opts.ClientKey == "") {
+		return opts, fmt.Errorf("please specify both tlsClientCert and tlsClientKey in the source-profile")
+	}
+	if opts.Mode == "" && opts != (TLSOptions{}) {
+		opts.Mode = TLSModeRequire
+	}
+	support := tlsSupports[source]
+	switch {
+	case opts.Mode == TLSModeVerifyCA && !support.verifyCA, opts.Mode == TLSModeVerifyFull && !support.verifyFull:
+		return opts, fmt.Errorf("tlsMode %s isn't supported with %s sources", opts.Mode, source)
+	case opts.CACert != "" && !support.caCert:
+		return opts, fmt.Errorf("tlsCaCert isn't supported with %s sources", source)
+	case opts.ClientCert != "" && !support.clientCert:
+		return opts, fmt.Errorf("tlsClientCert and tlsClientKey aren't supported with %s sources", source)
+	case opts.ServerName != "" && !support.serverName:
+		return opts, fmt.Errorf("tlsServerName isn't supported with %s sources", source)
+	}
+	// Check the files upfront, rather than on connecting.
+	if _, err := opts.Config(); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// Config returns the TLS configuration of opts, nil if TLS isn't enabled.
+// The server name is only set if it's given in opts, drivers defaulting to
+// the host they connect to.
+func (opts TLSOptions) Config() (*tls.Config, error) {
+	if opts.Mode == "" || opts.Mode == TLSModeDisable {
+		return nil, nil
+	}
+	cfg := &tls.Config{ServerName: opts.ServerName}
+	if opts.CACert != "" {
+		pem, err := os.ReadFile(opts.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("can't read tlsCaCert: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in tlsCaCert %s", opts.CACert)
+		}
+	}
+	if opts.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("can't load tlsClientCert and tlsClientKey: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	switch opts.Mode {
+	case TLSModeRequire:
+		cfg.InsecureSkipVerify = true
+	case TLSModeVerifyCA:
+		// The default verification also checks the name of the server, so
+		// it's replaced by one of the certificate chain only.
+		cfg.InsecureSkipVerify = true
+		roots := cfg.RootCAs
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyCertChain(rawCerts, roots)
+		}
+	}
+	return cfg, nil
+}
+
+// verifyCertChain verifies the certificate chain rawCerts against roots, or
+// the roots of the system if nil.
+func verifyCertChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("the server didn't present a certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("can't parse the certificate of the server: %w", err)
+		}
+		certs[i] = cert
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	return err
+}
+
+// mysqlTLSParams returns the tls parameter of the DSNs of MySQL databases,
+// empty for the default of the driver. The TLS configuration of opts is
+// registered with the driver under the address of the server.
+func mysqlTLSParams(server, port string, opts TLSOptions) string {
+	switch opts.Mode {
+	case "":
+		return ""
+	case TLSModeDisable:
+		return "?tls=false"
+	}
+	name := net.JoinHostPort(server, port)
+	// If the configuration can't be registered, connections fail as the
+	// name is unknown, rather than falling back to plain connections.
+	if cfg, err := opts.Config(); err == nil {
+		mysql.RegisterTLSConfig(name, cfg)
+	}
+	return "?tls=" + url.QueryEscape(name)
+}
+
+// pgTLSParams returns the SSL parameters of the connection strings of
+// PostgreSQL databases, with mode defaultMode if opts has none.
+func pgTLSParams(opts TLSOptions, defaultMode string) string {
+	mode := opts.Mode
+	if mode == "" {
+		mode = defaultMode
+	}
+	params := "sslmode=" + mode
+	if opts.CACert != "" {
+		params += " sslrootcert=" + opts.CACert
+	}
+	if opts.ClientCert != "" {
+		params += fmt.Sprintf(" sslcert=%s sslkey=%s", opts.ClientCert, opts.ClientKey)
+	}
+	return params
+}
+
+// sqlServerTLSParams returns the encryption parameters of the connection
+// strings of SQL Server databases, empty for the defaults of the driver.
+func sqlServerTLSParams(opts TLSOptions) url.Values {
+	params := url.Values{}
+	switch opts.Mode {
+	case TLSModeDisable:
+		params.Set("encrypt", "disable")
+	case TLSModeRequire:
+		params.Set("encrypt", "true")
+		params.Set("TrustServerCertificate", "true")
+	case TLSModeVerifyFull:
+		params.Set("encrypt", "true")
+		params.Set("TrustServerCertificate", "false")
+		if opts.CACert != "" {
+			params.Set("certificate", opts.CACert)
+		}
+		if opts.ServerName != "" {
+			params.Set("hostNameInCertificate", opts.ServerName)
+		}
+	}
+	return params
+}
+
+// oracleTLSOptions returns the SSL options of the URLs of Oracle databases,
+// nil for the defaults of the driver.
+func oracleTLSOptions(opts TLSOptions) map[string]string {
+	switch opts.Mode {
+	case TLSModeRequire:
+		return map[string]string{"SSL": "true", "SSL VERIFY": "false"}
+	case TLSModeVerifyFull:
+		return map[string]string{"SSL": "true", "SSL VERIFY": "true"}
+	}
+	return nil
+}
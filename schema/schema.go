@@ -27,6 +27,7 @@ package schema
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -45,18 +46,157 @@ type Table struct {
 	CheckConstraints []CheckConstraint
 	Indexes          []Index
 	Id               string
+	// ItemFilter is set for the tables split out of a source table with a
+	// single-table design: they hold the items of one of its entity types.
+	ItemFilter *ItemFilter `json:",omitempty"`
+	// Partitioning is set for tables partitioned in the source.
+	Partitioning *Partitioning `json:",omitempty"`
+	// Temporal is set for system-versioned temporal tables and their
+	// history tables.
+	Temporal *Temporal `json:",omitempty"`
+	// MaterializedView is the query of materialized views migrated as
+	// tables, populated with the rows of the view during data migration.
+	MaterializedView string `json:",omitempty"`
+	// Comment is the comment of the table in the source, e.g. MySQL's
+	// TABLE_COMMENT, carried over to the Spanner table.
+	Comment string `json:",omitempty"`
+}
+
+// ItemFilter selects the items of one entity type from a source table
+// with a single-table design, see internal.SingleTableDesign.
+type ItemFilter struct {
+	SourceTable string // Name of the source table holding the items.
+	Attribute   string // Attribute holding the entity type of the items.
+	KeyPrefix   bool   // Attribute values start with the entity type followed by '#'.
+	Entity      string // Entity type of the selected items.
+}
+
+// TableFilters select the source tables migrated by their name, e.g.
+// sales.orders for the tables of PostgreSQL schemas other than public. They
+// are regular expressions matching the whole name.
+type TableFilters struct {
+	Include string `json:",omitempty"` // If set, only the tables it matches are migrated.
+	Exclude string `json:",omitempty"` // The tables it matches aren't migrated.
+}
+
+// Validate checks that the filters are valid regular expressions.
+func (f TableFilters) Validate() error {
+	if _, err := regexp.Compile(f.Include); err != nil {
+		return fmt.Errorf("invalid include-tables filter %q: %w", f.Include, err)
+	}
+	if _, err := regexp.Compile(f.Exclude); err != nil {
+		return fmt.Errorf("invalid exclude-tables filter %q: %w", f.Exclude, err)
+	}
+	return nil
+}
+
+// Matches reports whether the source table name is selected by the filters.
+// Invalid filters match no table.
+func (f TableFilters) Matches(name string) bool {
+	if f.Include != "" {
+		if matched, err := matchesWhole(f.Include, name); err != nil || !matched {
+			return false
+		}
+	}
+	if f.Exclude != "" {
+		if matched, err := matchesWhole(f.Exclude, name); err != nil || matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesWhole reports whether the regular expression expr matches the whole
+// of s.
+func matchesWhole(expr, s string) (bool, error) {
+	return regexp.MatchString("^(?:"+expr+")$", s)
+}
+
+// Mappings of the partitions of partitioned source tables to Spanner.
+const (
+	// PartitionMappingSingleTable maps the partitions to a single Spanner
+	// table, whose rows are read from the source table.
+	PartitionMappingSingleTable = "single-table"
+	// PartitionMappingParallelExport maps the partitions to a single Spanner
+	// table, whose rows are read from the partitions in parallel.
+	PartitionMappingParallelExport = "parallel-export"
+	// PartitionMappingPrimaryKey maps the partitions to a single Spanner
+	// table whose primary key is led by the columns of the partition key.
+	PartitionMappingPrimaryKey = "primary-key"
+)
+
+// Partitioning is the partitioning scheme of a partitioned source table.
+type Partitioning struct {
+	Method     string   // Partitioning method in the source, e.g. RANGE, LIST or HASH.
+	Expression string   // Partition key, as defined in the source.
+	ColIds     []string // Columns the partition key is made of.
+	Partitions []Partition
+	Mapping    string // Mapping of the partitions to Spanner, see PartitionMappingSingleTable.
+}
+
+// Partition is a partition of a partitioned source table.
+type Partition struct {
+	Name  string
+	Bound string // Values of the partition, as defined in the source, e.g. VALUES LESS THAN (2024).
+	// Schema and Table name the table holding the rows of the partition, in
+	// sources where partitions are tables.
+	Schema string `json:",omitempty"`
+	Table  string `json:",omitempty"`
+}
+
+// Temporal describes a system-versioned temporal table, whose source keeps
+// the previous versions of its rows in a history table, or such a history
+// table.
+type Temporal struct {
+	CurrentTable     string `json:",omitempty"` // Name of the system-versioned table, for history tables.
+	HistoryTable     string `json:",omitempty"` // Name of the history table, for system-versioned tables.
+	PeriodStartColId string // Column holding the start of the validity period of rows.
+	PeriodEndColId   string // Column holding the end of the validity period of rows.
 }
 
 // Column represents a database column.
 // TODO: add support for foreign keys.
 type Column struct {
-	Name         string
-	Type         Type
-	NotNull      bool
-	Ignored      Ignored
-	Id           string
-	AutoGen      ddl.AutoGenCol
-	DefaultValue ddl.DefaultValue
+	Name            string
+	Type            Type
+	NotNull         bool
+	Ignored         Ignored
+	Id              string
+	AutoGen         ddl.AutoGenCol
+	DefaultValue    ddl.DefaultValue
+	GeneratedColumn ddl.GeneratedColumn // Set when the column value is computed from an expression.
+	EnumValues      []string            // Allowed values of ENUM and SET columns, in declaration order.
+	// Charset and Collation are the character set and collation of string
+	// columns, e.g. latin1 and latin1_swedish_ci, when the source has them.
+	Charset   string `json:",omitempty"`
+	Collation string `json:",omitempty"`
+	// Comment is the comment of the column in the source.
+	Comment string `json:",omitempty"`
+	// OnUpdateCurrentTimestamp is set for columns that the source updates to
+	// the current time on every write, e.g. MySQL's ON UPDATE CURRENT_TIMESTAMP.
+	OnUpdateCurrentTimestamp bool
+	// CommitTimestamp is set for columns the migration adds to hold the
+	// commit timestamp of the rows written to Spanner, such as the column
+	// added to reconcile Cassandra counters. They aren't read from the source.
+	CommitTimestamp bool
+	// DistKey and SortKeyOrder are set for the columns a data warehouse, such
+	// as Redshift, distributes and sorts the rows of the table by.
+	// SortKeyOrder is the position of the column in the sort key, starting
+	// at 1, and 0 for columns which aren't part of it.
+	DistKey      bool
+	SortKeyOrder int
+	// UserTypeField is set for the columns holding a field of a column of a
+	// user-defined type, such as a Cassandra UDT, flattened into a column
+	// per field.
+	UserTypeField *UserTypeField `json:",omitempty"`
+}
+
+// UserTypeField is a field of a column of a user-defined type, see
+// internal.UserDefinedType.
+type UserTypeField struct {
+	Column     string   // Name of the column of the user-defined type.
+	ColumnType string   // Type of the column, e.g. frozen<address>.
+	Path       []string // Name of the field, preceded by the names of the fields of the nested types holding it.
 }
 
 // ForeignKey represents a foreign key.
@@ -91,6 +231,11 @@ type Key struct {
 	ColId string
 	Desc  bool // By default, order is ASC. Set to true to specifiy DESC.
 	Order int
+	// Expression of index keys on an expression rather than a column, as
+	// reported by the source e.g. UPPER("EMAIL") for Oracle function-based
+	// indexes, and the type of its values. ColId is empty for these keys.
+	Expr     string `json:",omitempty"`
+	ExprType *Type  `json:",omitempty"`
 }
 
 // Index represents a database index.
@@ -104,12 +249,56 @@ type Key struct {
 // We use this single representation of unique constraints to simplify their processing and avoid having
 // to handle lots of cases for the same concept. Our choice of an index representation for unique is largely
 // motivated by the fact that databases typically implement UNIQUE via an index.
+// Unique constraints are told apart from unique indexes created as such by
+// Constraint.
 type Index struct {
 	Name            string
 	Unique          bool
 	Keys            []Key
 	Id              string
 	StoredColumnIds []string
+	FullText        bool   // True for full-text indexes e.g. MySQL FULLTEXT or Postgres GIN indexes.
+	VectorDistance  string // Distance type of vector indexes e.g. pgvector indexes; empty for other indexes.
+	NullFiltered    bool   // True for partial indexes that only exclude rows with NULL key values e.g. Postgres WHERE col IS NOT NULL.
+	Spatial         bool   // True for spatial indexes e.g. MySQL SPATIAL or PostGIS GiST indexes.
+	FullTextConfig  string // Text search configuration of full-text indexes e.g. english for Postgres to_tsvector('english', body).
+	// Constraint is set for unique indexes implementing a UNIQUE constraint
+	// of the source, rather than created with e.g. CREATE UNIQUE INDEX. They
+	// are only converted when all their columns are, since dropping columns
+	// from them would change the constraint.
+	Constraint bool `json:",omitempty"`
+}
+
+// View represents a database view.
+type View struct {
+	Name   string
+	Schema string
+	Query  string // View definition as reported by the source database.
+	Id     string
+	// Materialized is set for materialized views, whose rows the source
+	// stores and refreshes on demand.
+	Materialized bool `json:",omitempty"`
+}
+
+// Kinds of routines.
+const (
+	RoutineProcedure = "PROCEDURE"
+	RoutineFunction  = "FUNCTION"
+	RoutineTrigger   = "TRIGGER"
+)
+
+// Routine represents a stored procedure, function or trigger. Spanner has
+// no equivalent: they're inventoried in the conversion report, to be
+// reimplemented.
+type Routine struct {
+	Name   string
+	Schema string
+	Kind   string   // See RoutineProcedure.
+	Table  string   // Table of triggers.
+	Timing string   // Timing of triggers e.g. BEFORE, AFTER or INSTEAD OF.
+	Events []string // Events of triggers e.g. INSERT, UPDATE or DELETE.
+	Body   string   // Definition as reported by the source database.
+	Id     string
 }
 
 // Type represents the type of a column.
@@ -0,0 +1,236 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// avroType is a parsed Avro schema. Unions of null and another type are
+// parsed as the other type, with nullable set, while other unions have
+// type union and their non-null types in branches.
+type avroType struct {
+	// The definition is shared by the uses of named types, so that the
+	// uses of recursive records in their own fields have all their fields.
+	*typeDef
+	nullable bool
+	// union is set for types of union values, which are decoded as maps from
+	// the name of the type to the value.
+	union bool
+}
+
+type typeDef struct {
+	typ         string // Primitive or complex type, e.g. long or record.
+	logicalType string // Logical type if supported, e.g. timestamp-micros.
+	mods        []int64
+	fields      []avroField // Fields of records.
+	items       *avroType   // Items of arrays.
+	values      *avroType   // Values of maps.
+	branches    []*avroType // Non-null types of unions.
+}
+
+type avroField struct {
+	name string
+	typ  *avroType
+}
+
+// logicalTypes are the Avro logical types values are converted from, and
+// their underlying types. Other logical types are ignored.
+var logicalTypes = map[string][]string{
+	typeDecimal:              {typeBytes, typeFixed},
+	typeUUID:                 {typeString},
+	typeDate:                 {typeInt},
+	typeTimeMillis:           {typeInt},
+	typeTimeMicros:           {typeLong},
+	typeTimestampMillis:      {typeLong},
+	typeTimestampMicros:      {typeLong},
+	typeLocalTimestampMillis: {typeLong},
+	typeLocalTimestampMicros: {typeLong},
+}
+
+// parseSchema parses the JSON Avro schema of a file.
+func parseSchema(s string) (*avroType, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("couldn't parse Avro schema: %w", err)
+	}
+	p := schemaParser{named: make(map[string]*avroType)}
+	return p.parse(v, "")
+}
+
+type schemaParser struct {
+	named map[string]*avroType // Named types, by full name.
+}
+
+func (p schemaParser) parse(v interface{}, namespace string) (*avroType, error) {
+	switch s := v.(type) {
+	case string:
+		switch s {
+		case typeNull, typeBoolean, typeInt, typeLong, typeFloat, typeDouble, typeBytes, typeString:
+			return &avroType{typeDef: &typeDef{typ: s}}, nil
+		}
+		if t, ok := p.named[fullName(s, namespace)]; ok {
+			return t, nil
+		}
+		if t, ok := p.named[s]; ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf("unknown Avro type %s", s)
+	case []interface{}:
+		return p.parseUnion(s, namespace)
+	case map[string]interface{}:
+		return p.parseObject(s, namespace)
+	}
+	return nil, fmt.Errorf("invalid Avro schema %v", v)
+}
+
+func (p schemaParser) parseUnion(branches []interface{}, namespace string) (*avroType, error) {
+	t := &avroType{typeDef: &typeDef{typ: typeUnion}, union: true}
+	for _, b := range branches {
+		bt, err := p.parse(b, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if bt.typ == typeNull {
+			t.nullable = true
+			continue
+		}
+		t.branches = append(t.branches, bt)
+	}
+	if len(t.branches) == 1 {
+		nt := *t.branches[0]
+		nt.nullable, nt.union = t.nullable, true
+		return &nt, nil
+	}
+	return t, nil
+}
+
+func (p schemaParser) parseObject(s map[string]interface{}, namespace string) (*avroType, error) {
+	typ, ok := s["type"].(string)
+	if !ok {
+		// e.g. {"type": {"type": "array", ...}}
+		return p.parse(s["type"], namespace)
+	}
+	t := &avroType{typeDef: &typeDef{typ: typ}}
+	switch typ {
+	case typeRecord, typeEnum, typeFixed:
+		name, _ := s["name"].(string)
+		if ns, ok := s["namespace"].(string); ok && !strings.Contains(name, ".") {
+			namespace = ns
+		}
+		name = fullName(name, namespace)
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			namespace = name[:i]
+		}
+		// Registered before its fields are parsed, as they may refer to it.
+		p.named[name] = t
+	}
+	switch typ {
+	case typeRecord:
+		fields, _ := s["fields"].([]interface{})
+		for _, f := range fields {
+			fm, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid field %v", f)
+			}
+			name, _ := fm["name"].(string)
+			ft, err := p.parse(fm["type"], namespace)
+			if err != nil {
+				return nil, fmt.Errorf("invalid type of field %s: %w", name, err)
+			}
+			t.fields = append(t.fields, avroField{name: name, typ: ft})
+		}
+	case typeArray:
+		items, err := p.parse(s["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		t.items = items
+	case typeMap:
+		values, err := p.parse(s["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		t.values = values
+	case typeFixed:
+		if size, ok := s["size"].(float64); ok {
+			t.mods = []int64{int64(size)}
+		}
+	case typeEnum, typeNull, typeBoolean, typeInt, typeLong, typeFloat, typeDouble, typeBytes, typeString:
+	default:
+		return p.parse(typ, namespace)
+	}
+	if lt, ok := s["logicalType"].(string); ok {
+		for _, base := range logicalTypes[lt] {
+			if base == typ {
+				t.logicalType = lt
+			}
+		}
+	}
+	if t.logicalType == typeDecimal {
+		precision, _ := s["precision"].(float64)
+		scale, _ := s["scale"].(float64)
+		t.mods = []int64{int64(precision), int64(scale)}
+	}
+	return t, nil
+}
+
+func fullName(name, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// toType maps an Avro type to a schema.Type, named after its logical type
+// if any. The mods of decimals are their precision and scale, and those of
+// fixed types their size. Arrays are arrays of their items, and arrays of
+// arrays multi-dimensional arrays.
+func toType(t *avroType) schema.Type {
+	if t.typ == typeArray {
+		items := toType(t.items)
+		items.ArrayBounds = append([]int64{-1}, items.ArrayBounds...)
+		return items
+	}
+	name := t.typ
+	if t.logicalType != "" {
+		name = t.logicalType
+	}
+	return schema.Type{Name: name, Mods: t.mods}
+}
+
+// datastreamPayload returns the type of the payload of the records of
+// files written by Datastream, whose records wrap the rows of the source
+// table in a payload field along with metadata about the change.
+func datastreamPayload(t *avroType) (*avroType, bool) {
+	var payload *avroType
+	var hasMetadata bool
+	for _, f := range t.fields {
+		switch f.name {
+		case "payload":
+			payload = f.typ
+		case "source_metadata":
+			hasMetadata = true
+		}
+	}
+	if payload == nil || !hasMetadata || payload.typ != typeRecord {
+		return nil, false
+	}
+	return payload, true
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestParseSchema(t *testing.T) {
+	s := `{"type": "record", "name": "node", "namespace": "ns", "fields": [
+		{"name": "id", "type": "int"},
+		{"name": "hash", "type": {"type": "fixed", "name": "md5", "size": 16}},
+		{"name": "other_hash", "type": ["null", "ns.md5"]},
+		{"name": "at", "type": {"type": "int", "logicalType": "time-millis"}},
+		{"name": "duration", "type": {"type": "fixed", "name": "duration", "size": 12, "logicalType": "duration"}},
+		{"name": "value", "type": ["null", "long", "string"]},
+		{"name": "attrs", "type": {"type": "map", "values": "string"}},
+		{"name": "matrix", "type": {"type": "array", "items": {"type": "array", "items": "double"}}},
+		{"name": "next", "type": ["null", "node"]}]}`
+	rt, err := parseSchema(s)
+	assert.Nil(t, err)
+	var types []schema.Type
+	var nullable []bool
+	for _, f := range rt.fields {
+		types = append(types, toType(f.typ))
+		nullable = append(nullable, f.typ.nullable)
+	}
+	assert.Equal(t, []schema.Type{
+		{Name: typeInt},
+		{Name: typeFixed, Mods: []int64{16}},
+		{Name: typeFixed, Mods: []int64{16}},
+		{Name: typeTimeMillis},
+		{Name: typeFixed, Mods: []int64{12}},
+		{Name: typeUnion},
+		{Name: typeMap},
+		{Name: typeDouble, ArrayBounds: []int64{-1, -1}},
+		{Name: typeRecord},
+	}, types)
+	assert.Equal(t, []bool{false, false, true, false, false, true, false, false, true}, nullable)
+	// The recursive reference is resolved.
+	assert.Equal(t, rt.fields, rt.fields[8].typ.fields)
+
+	_, err = parseSchema(`{"type": "record", "name": "r", "fields": [{"name": "a", "type": "unknown"}]}`)
+	assert.NotNil(t, err)
+}
+
+func TestValue(t *testing.T) {
+	rt, err := parseSchema(`{"type": "record", "name": "r", "fields": [
+		{"name": "n", "type": ["null", "int"]},
+		{"name": "at", "type": {"type": "long", "logicalType": "time-micros"}},
+		{"name": "local", "type": {"type": "long", "logicalType": "local-timestamp-millis"}},
+		{"name": "value", "type": ["null", "long", "string"]},
+		{"name": "counts", "type": {"type": "map", "values": "int"}}]}`)
+	assert.Nil(t, err)
+	got := value(rt, map[string]interface{}{
+		"n":      map[string]interface{}{"int": int32(7)},
+		"at":     3*time.Hour + 4*time.Minute,
+		"local":  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixMilli(),
+		"value":  map[string]interface{}{"long": int64(5)},
+		"counts": map[string]interface{}{"a": int32(1)},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"n":      int64(7),
+		"at":     civil.Time{Hour: 3, Minute: 4},
+		"local":  civil.DateTime{Date: civil.Date{Year: 2024, Month: 1, Day: 2}, Time: civil.Time{Hour: 3, Minute: 4, Second: 5}},
+		"value":  int64(5),
+		"counts": map[string]interface{}{"a": int64(1)},
+	}, got)
+}
+
+func TestConvScalar(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		in      interface{}
+		want    interface{}
+	}{
+		{name: "bool", spType: ddl.Bool, in: true, want: true},
+		{name: "float32", spType: ddl.Float32, in: float32(0.1), want: float32(0.1)},
+		{name: "float32 to float64", spType: ddl.Float64, in: float32(0.1), want: 0.1},
+		{name: "numeric pg", dialect: constants.DIALECT_POSTGRESQL, spType: ddl.Numeric, in: big.NewRat(25, 2), want: spanner.PGNumeric{Numeric: "12.5", Valid: true}},
+		{name: "numeric to string", spType: ddl.String, in: big.NewRat(1, 8), want: "0.125"},
+		{name: "integral numeric to string", spType: ddl.String, in: big.NewRat(100, 1), want: "100"},
+		{name: "local timestamp", spType: ddl.Timestamp, in: civil.DateTime{Date: civil.Date{Year: 2024, Month: 1, Day: 2}}, want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "time to string", spType: ddl.String, in: civil.Time{Hour: 3, Minute: 4, Second: 5}, want: "03:04:05"},
+		{name: "record", spType: ddl.JSON, in: map[string]interface{}{"n": big.NewRat(5, 4), "b": []byte{0xca, 0xfe}}, want: `{"b":"yv4=","n":1.25}`},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		got, err := convScalar(conv, tc.in, tc.spType)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+	_, err := convScalar(internal.MakeConv(), "abc", ddl.Int64)
+	assert.NotNil(t, err)
+}
+
+func TestConvArray(t *testing.T) {
+	conv := internal.MakeConv()
+	got, err := convArray(conv, []interface{}{int64(1), nil}, ddl.Int64)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullInt64{{Int64: 1, Valid: true}, {}}, got)
+	got, err = convArray(conv, []interface{}{map[string]interface{}{"a": int64(1)}}, ddl.JSON)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullJSON{{Value: json.RawMessage(`{"a":1}`), Valid: true}}, got)
+	_, err = convArray(conv, []interface{}{"abc"}, ddl.Int64)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package avro handles schema and data migrations from Avro object
+// container files. Each table is read from a set of files, found under a
+// local directory or GCS path or listed in a manifest (see
+// common.GetFileSets). The schema of a table is the schema of the records
+// of the first file of its set. Files written by Datastream are
+// recognized, and the rows of their payloads migrated.
+package avro
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/linkedin/goavro/v2"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Extension is the extension of Avro files.
+const Extension = ".avro"
+
+// Source types are named after the Avro types, or their logical types.
+const (
+	typeNull                 = "null"
+	typeBoolean              = "boolean"
+	typeInt                  = "int"
+	typeLong                 = "long"
+	typeFloat                = "float"
+	typeDouble               = "double"
+	typeBytes                = "bytes"
+	typeString               = "string"
+	typeRecord               = "record"
+	typeEnum                 = "enum"
+	typeArray                = "array"
+	typeMap                  = "map"
+	typeFixed                = "fixed"
+	typeUnion                = "union" // Unions of several non-null types.
+	typeDecimal              = "decimal"
+	typeUUID                 = "uuid"
+	typeDate                 = "date"
+	typeTimeMillis           = "time-millis"
+	typeTimeMicros           = "time-micros"
+	typeTimestampMillis      = "timestamp-millis"
+	typeTimestampMicros      = "timestamp-micros"
+	typeLocalTimestampMillis = "local-timestamp-millis"
+	typeLocalTimestampMicros = "local-timestamp-micros"
+)
+
+// InfoSchemaImpl is the Avro specific implementation of InfoSchema.
+type InfoSchemaImpl struct {
+	FileSets []common.FileSet
+}
+
+// GetToDdl implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: files can only be migrated with
+// bulk migrations.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for Avro files")
+}
+
+// StartStreamingMigration is not supported: files can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for Avro files")
+}
+
+// GetTableName returns table name. Tables have no schema.
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	return tableName
+}
+
+// GetTables returns a table for each file set.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	var tables []common.SchemaAndName
+	for _, fs := range isi.FileSets {
+		tables = append(tables, common.SchemaAndName{Name: fs.Table})
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names, read from the
+// schema of the first file of the table. Fields that aren't nullable are
+// NOT NULL.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	files, err := isi.getFiles(table.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rowType *avroType
+	err = readFile(context.Background(), files[0], func(r *goavro.OCFReader) error {
+		rowType, err = readRowType(r)
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't read schema of table %s from %s: %w", table.Name, files[0], err)
+	}
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	for _, f := range rowType.fields {
+		colId := internal.GenerateColumnId()
+		colDefs[colId] = schema.Column{
+			Id:      colId,
+			Name:    f.name,
+			Type:    toType(f.typ),
+			NotNull: !f.typ.nullable,
+		}
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetRowsFromTable is not used: data is read from files by ProcessData.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
+	return nil, fmt.Errorf("data of Avro files is read by ProcessData")
+}
+
+// GetRowCount returns the number of records of the files of a table. The
+// blocks of the files are read, but their records aren't decoded.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	files, err := isi.getFiles(table.Name)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, path := range files {
+		err := readFile(context.Background(), path, func(r *goavro.OCFReader) error {
+			for r.Scan() {
+				count += r.RemainingBlockItems()
+				r.SkipThisBlockAndReset()
+			}
+			return r.Err()
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// GetConstraints returns no constraints: Avro files have no primary keys,
+// so tables get a synthetic primary key.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	return nil, nil, make(map[string][]string), nil
+}
+
+// GetForeignKeys returns no foreign keys: Avro files have none.
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	return nil, nil
+}
+
+// GetIndexes returns no indexes: Avro files have none.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return nil, nil
+}
+
+// ProcessData reads the records of the files of a table, converts them to
+// Spanner data (based on the source and Spanner schemas) and writes them
+// to Spanner. Records are decoded with the schema of their file, so fields
+// missing from a file are NULL. Records of deletes in files written by
+// Datastream are skipped.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	files, err := isi.getFiles(srcSchema.Name)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	ctx := context.Background()
+	for _, path := range files {
+		err := readFile(ctx, path, func(r *goavro.OCFReader) error {
+			return readRows(r, func(row map[string]interface{}) {
+				ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, row)
+			})
+		})
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read file %s of table %s : err = %s", path, srcSchema.Name, err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (isi InfoSchemaImpl) getFiles(tableName string) ([]string, error) {
+	for _, fs := range isi.FileSets {
+		if fs.Table == tableName && len(fs.Files) > 0 {
+			return fs.Files, nil
+		}
+	}
+	return nil, fmt.Errorf("no files found for table %s", tableName)
+}
+
+// readFile calls read with a reader of the local or GCS Avro file at path,
+// which is streamed rather than read in memory.
+func readFile(ctx context.Context, path string, read func(r *goavro.OCFReader) error) error {
+	fr, err := file_reader.NewFileReader(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+	rd, err := fr.CreateReader(ctx)
+	if err != nil {
+		return err
+	}
+	r, err := goavro.NewOCFReader(bufio.NewReader(rd))
+	if err != nil {
+		return err
+	}
+	return read(r)
+}
+
+// readRowType returns the type of the rows of a file: the type of its
+// records, or of their payloads for files written by Datastream.
+func readRowType(r *goavro.OCFReader) (*avroType, error) {
+	t, err := parseSchema(r.Codec().Schema())
+	if err != nil {
+		return nil, err
+	}
+	if t.typ != typeRecord {
+		return nil, fmt.Errorf("records are of type %s, not records", t.typ)
+	}
+	if payload, ok := datastreamPayload(t); ok {
+		return payload, nil
+	}
+	return t, nil
+}
+
+// readRows calls processRow with each row of a file, keyed by column name.
+// See value for the types of values.
+func readRows(r *goavro.OCFReader, processRow func(row map[string]interface{})) error {
+	t, err := parseSchema(r.Codec().Schema())
+	if err != nil {
+		return err
+	}
+	_, datastream := datastreamPayload(t)
+	for r.Scan() {
+		datum, err := r.Read()
+		if err != nil {
+			return err
+		}
+		record, ok := value(t, datum).(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected Avro datum of type %T", datum)
+		}
+		if datastream {
+			if isDeleted(record) {
+				continue
+			}
+			if record, ok = record["payload"].(map[string]interface{}); !ok {
+				continue
+			}
+		}
+		processRow(record)
+	}
+	return r.Err()
+}
+
+// isDeleted returns whether a record of a file written by Datastream is
+// the record of a delete.
+func isDeleted(record map[string]interface{}) bool {
+	metadata, _ := record["source_metadata"].(map[string]interface{})
+	deleted, _ := metadata["is_deleted"].(bool)
+	return deleted
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+var created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+const ordersSchema = `{"type": "record", "name": "orders", "namespace": "shop", "fields": [
+	{"name": "id", "type": "long"},
+	{"name": "ref", "type": {"type": "string", "logicalType": "uuid"}},
+	{"name": "amount", "type": ["null", {"type": "bytes", "logicalType": "decimal", "precision": 12, "scale": 2}]},
+	{"name": "created", "type": ["null", {"type": "long", "logicalType": "timestamp-micros"}]},
+	{"name": "status", "type": {"type": "enum", "name": "status", "symbols": ["NEW", "SHIPPED"]}},
+	{"name": "tags", "type": {"type": "array", "items": "string"}},
+	{"name": "shipping", "type": ["null", {"type": "record", "name": "address", "fields": [
+		{"name": "city", "type": ["null", "string"]}]}]}]}`
+
+// writeFile writes an Avro file with schema avroSchema and records records.
+func writeFile(t *testing.T, path, avroSchema string, records ...map[string]interface{}) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+	f, err := os.Create(path)
+	assert.Nil(t, err)
+	defer f.Close()
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{W: f, Schema: avroSchema, CompressionName: goavro.CompressionDeflateLabel})
+	assert.Nil(t, err)
+	var data []interface{}
+	for _, r := range records {
+		data = append(data, r)
+	}
+	assert.Nil(t, w.Append(data))
+}
+
+func order(id int64) map[string]interface{} {
+	if id%2 == 0 {
+		return map[string]interface{}{
+			"id": id, "ref": "0b1c9b4e-5ff9-4a3e-9b53-2c5a1b3c6d7e", "amount": nil, "created": nil,
+			"status": "NEW", "tags": []interface{}{}, "shipping": nil,
+		}
+	}
+	return map[string]interface{}{
+		"id":       id,
+		"ref":      "0b1c9b4e-5ff9-4a3e-9b53-2c5a1b3c6d7e",
+		"amount":   goavro.Union("bytes.decimal", big.NewRat(25, 2)),
+		"created":  goavro.Union("long.timestamp-micros", created),
+		"status":   "SHIPPED",
+		"tags":     []interface{}{"a", "b"},
+		"shipping": goavro.Union("shop.address", map[string]interface{}{"city": goavro.Union("string", "Paris")}),
+	}
+}
+
+// mkInfoSchema writes the files of the orders table to a temporary
+// directory, and returns an InfoSchemaImpl reading them.
+func mkInfoSchema(t *testing.T) InfoSchemaImpl {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "orders", "part-00000.avro"), ordersSchema, order(1), order(2))
+	writeFile(t, filepath.Join(dir, "orders", "part-00001.avro"), ordersSchema, order(3))
+	fileSets, err := common.GetFileSets(context.Background(), dir, "", Extension)
+	assert.Nil(t, err)
+	return InfoSchemaImpl{FileSets: fileSets}
+}
+
+func processSchema(t *testing.T, isi InfoSchemaImpl) *internal.Conv {
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	return conv
+}
+
+func TestProcessSchema(t *testing.T) {
+	conv := processSchema(t, mkInfoSchema(t))
+	expectedSchema := map[string]ddl.CreateTable{
+		"orders": {
+			Name:   "orders",
+			ColIds: []string{"id", "ref", "amount", "created", "status", "tags", "shipping", "synth_id"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":       {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"ref":      {Name: "ref", T: ddl.Type{Name: ddl.String, Len: 36}, NotNull: true},
+				"amount":   {Name: "amount", T: ddl.Type{Name: ddl.Numeric}},
+				"created":  {Name: "created", T: ddl.Type{Name: ddl.Timestamp}},
+				"status":   {Name: "status", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+				"tags":     {Name: "tags", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+				"shipping": {Name: "shipping", T: ddl.Type{Name: ddl.JSON}},
+				"synth_id": {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
+
+func TestGetRowCount(t *testing.T) {
+	isi := mkInfoSchema(t)
+	count, err := isi.GetRowCount(common.SchemaAndName{Name: "orders"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+	_, err = isi.GetRowCount(common.SchemaAndName{Name: "missing"})
+	assert.NotNil(t, err)
+}
+
+// processTableData migrates the data of the Spanner table spTableName and
+// returns the rows written, without their synthetic primary keys.
+func processTableData(t *testing.T, conv *internal.Conv, isi InfoSchemaImpl, spTableName string) []spannerData {
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols[:len(cols)-1], vals: vals[:len(vals)-1]})
+		})
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, spTableName)
+	assert.Nil(t, err)
+	colIds := conv.SpSchema[tableId].ColIds
+	err = isi.ProcessData(conv, tableId, conv.SrcSchema[tableId], colIds[:len(colIds)-1], conv.SpSchema[tableId], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	return rows
+}
+
+func TestProcessData(t *testing.T) {
+	isi := mkInfoSchema(t)
+	conv := processSchema(t, isi)
+	rows := processTableData(t, conv, isi, "orders")
+	cols := []string{"id", "ref", "amount", "created", "status", "tags", "shipping"}
+	ref := "0b1c9b4e-5ff9-4a3e-9b53-2c5a1b3c6d7e"
+	full := func(id int64) spannerData {
+		return spannerData{
+			table: "orders",
+			cols:  cols,
+			vals: []interface{}{
+				id, ref, big.NewRat(25, 2), created, "SHIPPED",
+				[]spanner.NullString{{StringVal: "a", Valid: true}, {StringVal: "b", Valid: true}},
+				`{"city":"Paris"}`,
+			},
+		}
+	}
+	assert.Equal(t, []spannerData{
+		full(1),
+		{table: "orders", cols: cols, vals: []interface{}{int64(2), ref, nil, nil, "NEW", []spanner.NullString{}, nil}},
+		full(3),
+	}, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+}
+
+const datastreamSchema = `{"type": "record", "name": "envelope", "fields": [
+	{"name": "uuid", "type": "string"},
+	{"name": "read_timestamp", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+	{"name": "source_metadata", "type": {"type": "record", "name": "source_metadata", "fields": [
+		{"name": "table", "type": "string"},
+		{"name": "is_deleted", "type": "boolean"}]}},
+	{"name": "payload", "type": {"type": "record", "name": "payload", "fields": [
+		{"name": "id", "type": ["null", "int"]},
+		{"name": "birthday", "type": ["null", {"type": "int", "logicalType": "date"}]},
+		{"name": "updated", "type": ["null", {"type": "long", "logicalType": "local-timestamp-micros"}]}]}}]}`
+
+func TestDatastream(t *testing.T) {
+	dir := t.TempDir()
+	row := func(id int32, deleted bool) map[string]interface{} {
+		return map[string]interface{}{
+			"uuid":            "u",
+			"read_timestamp":  created,
+			"source_metadata": map[string]interface{}{"table": "customers", "is_deleted": deleted},
+			"payload": map[string]interface{}{
+				"id":       goavro.Union("int", id),
+				"birthday": goavro.Union("int.date", created),
+				"updated":  goavro.Union("long", created.UnixMicro()),
+			},
+		}
+	}
+	writeFile(t, filepath.Join(dir, "customers.avro"), datastreamSchema, row(1, false), row(2, true))
+	fileSets, err := common.GetFileSets(context.Background(), dir, "", Extension)
+	assert.Nil(t, err)
+	isi := InfoSchemaImpl{FileSets: fileSets}
+	conv := processSchema(t, isi)
+	expectedSchema := map[string]ddl.CreateTable{
+		"customers": {
+			Name:   "customers",
+			ColIds: []string{"id", "birthday", "updated", "synth_id"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":       {Name: "id", T: ddl.Type{Name: ddl.Int64}},
+				"birthday": {Name: "birthday", T: ddl.Type{Name: ddl.Date}},
+				"updated":  {Name: "updated", T: ddl.Type{Name: ddl.Timestamp}},
+				"synth_id": {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+
+	// The record of the delete is skipped.
+	rows := processTableData(t, conv, isi, "customers")
+	assert.Equal(t, []spannerData{
+		{table: "customers", cols: []string{"id", "birthday", "updated"}, vals: []interface{}{int64(1), civil.Date{Year: 2024, Month: 1, Day: 2}, created}},
+	}, rows)
+}
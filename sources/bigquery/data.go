@@ -0,0 +1,426 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	bq "google.golang.org/api/bigquery/v2"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ProcessDataRow converts a row, keyed by column name, and writes it out
+// to Spanner.
+func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, row map[string]interface{}) {
+	spVals, badCols, srcStrVals := cvtRow(conv, row, srcSchema, spSchema, colIds)
+	srcTableName := srcSchema.Name
+	spColNames := []string{}
+	srcColNames := []string{}
+	for _, colId := range colIds {
+		srcColNames = append(srcColNames, srcSchema.ColDefs[colId].Name)
+		spColNames = append(spColNames, spSchema.ColDefs[colId].Name)
+	}
+	if len(badCols) > 0 {
+		conv.Unexpected(fmt.Sprintf("Data conversion error for table %s in column(s) %s\n", srcTableName, badCols))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcColNames, srcStrVals)
+		return
+	}
+	if aux, ok := conv.SyntheticPKeys[tableId]; ok {
+		spColNames = append(spColNames, conv.SpSchema[tableId].ColDefs[aux.ColId].Name)
+		spVals = append(spVals, fmt.Sprintf("%d", int64(bits.Reverse64(uint64(aux.Sequence)))))
+		aux.Sequence++
+		conv.SyntheticPKeys[tableId] = aux
+	}
+	conv.WriteRow(srcTableName, spSchema.Name, spColNames, spVals)
+}
+
+// cvtRow converts the values of the columns colIds of row to the types of
+// their Spanner columns. It returns the converted values, the names of the
+// columns whose values couldn't be converted and the values as strings.
+func cvtRow(conv *internal.Conv, row map[string]interface{}, srcSchema schema.Table, spSchema ddl.CreateTable, colIds []string) ([]interface{}, []string, []string) {
+	var srcStrVals []string
+	var spVals []interface{}
+	var badCols []string
+	for _, colId := range colIds {
+		srcColDef := srcSchema.ColDefs[colId]
+		val := row[srcColDef.Name]
+		if val == nil {
+			srcStrVals = append(srcStrVals, "NULL")
+			spVals = append(spVals, nil)
+			continue
+		}
+		spType := spSchema.ColDefs[colId].T
+		var spVal interface{}
+		var err error
+		if arr, ok := val.([]interface{}); ok && spType.IsArray {
+			spVal, err = convArray(conv, arr, spType.Name)
+		} else {
+			spVal, err = convScalar(conv, val, spType.Name)
+		}
+		if err != nil {
+			badCols = append(badCols, srcColDef.Name)
+		}
+		srcStrVals = append(srcStrVals, toString(val))
+		spVals = append(spVals, spVal)
+	}
+	return spVals, badCols, srcStrVals
+}
+
+// recordValues returns the values of the fields of a record decoded from
+// an exported Avro file, keyed by field name. Values of nullable fields
+// are unwrapped from their Avro union, records are recursively converted,
+// and values are converted to the Go types below, by BigQuery type:
+//
+//	DATE: civil.Date
+//	TIME: civil.Time
+//	DATETIME: civil.DateTime
+//	TIMESTAMP: time.Time
+//	NUMERIC, BIGNUMERIC: *big.Rat
+//	JSON: json.RawMessage
+//	STRUCT: map[string]interface{}
+//
+// Values of repeated fields are []interface{}.
+func recordValues(fields []*bq.TableFieldSchema, record map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, f := range fields {
+		values[f.Name] = fieldValue(f, record[f.Name])
+	}
+	return values
+}
+
+func fieldValue(f *bq.TableFieldSchema, val interface{}) interface{} {
+	// Values of nullable fields are unions of null and the type of the
+	// field, which are decoded as maps from the type to the value.
+	if union, ok := val.(map[string]interface{}); ok && f.Mode != modeRequired && f.Mode != modeRepeated {
+		val = nil
+		for _, v := range union {
+			val = v
+		}
+	}
+	if val == nil {
+		return nil
+	}
+	if f.Mode == modeRepeated {
+		elems, ok := val.([]interface{})
+		if !ok {
+			return val
+		}
+		vals := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			vals[i] = elemValue(f, elem)
+		}
+		return vals
+	}
+	return elemValue(f, val)
+}
+
+func elemValue(f *bq.TableFieldSchema, val interface{}) interface{} {
+	switch toTypeName(f.Type) {
+	case typeDate:
+		if t, ok := val.(time.Time); ok {
+			return civil.DateOf(t.UTC())
+		}
+	case typeTime:
+		if d, ok := val.(time.Duration); ok {
+			return civil.TimeOf(time.Time{}.Add(d))
+		}
+	case typeDatetime:
+		if s, ok := val.(string); ok {
+			if dt, err := civil.ParseDateTime(strings.Replace(s, " ", "T", 1)); err == nil {
+				return dt
+			}
+		}
+	case typeJSON:
+		if s, ok := val.(string); ok {
+			return json.RawMessage(s)
+		}
+	case typeStruct:
+		if record, ok := val.(map[string]interface{}); ok {
+			return recordValues(f.Fields, record)
+		}
+	}
+	return val
+}
+
+// recordRows returns the rows of the records at path in a record with
+// values values, keyed by column name. Rows of child tables start with
+// keys, the primary key of the parent row, followed by the offset of their
+// record in the repeated field.
+func recordRows(fields []*bq.TableFieldSchema, values map[string]interface{}, path []string, keys map[string]interface{}) []map[string]interface{} {
+	if len(path) == 0 {
+		row := make(map[string]interface{})
+		for k, v := range values {
+			row[k] = v
+		}
+		for k, v := range keys {
+			row[k] = v
+		}
+		return []map[string]interface{}{row}
+	}
+	var field *bq.TableFieldSchema
+	for _, f := range fields {
+		if f.Name == path[0] {
+			field = f
+		}
+	}
+	elems, _ := values[path[0]].([]interface{})
+	if field == nil {
+		return nil
+	}
+	var rows []map[string]interface{}
+	for i, elem := range elems {
+		record, ok := elem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		childKeys := map[string]interface{}{path[0] + offsetSuffix: int64(i)}
+		for k, v := range keys {
+			childKeys[k] = v
+		}
+		rows = append(rows, recordRows(field.Fields, record, path[1:], childKeys)...)
+	}
+	return rows
+}
+
+// convScalar converts a value returned by recordValues to a value of
+// Spanner type spType.
+func convScalar(conv *internal.Conv, val interface{}, spType string) (interface{}, error) {
+	switch spType {
+	case ddl.Bool:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+	case ddl.Bytes:
+		switch v := val.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+	case ddl.Date:
+		if d, ok := val.(civil.Date); ok {
+			return d, nil
+		}
+	case ddl.Float64:
+		if f, ok := val.(float64); ok {
+			return f, nil
+		}
+	case ddl.Int64:
+		switch v := val.(type) {
+		case int64:
+			return v, nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+	case ddl.Numeric:
+		switch v := val.(type) {
+		case *big.Rat:
+			return convNumeric(conv, v), nil
+		case int64:
+			return convNumeric(conv, big.NewRat(v, 1)), nil
+		}
+	case ddl.Timestamp:
+		switch v := val.(type) {
+		case time.Time:
+			return v.UTC(), nil
+		case civil.DateTime:
+			return v.In(time.UTC), nil
+		}
+	case ddl.String:
+		return toString(val), nil
+	case ddl.JSON:
+		return toJSON(val)
+	}
+	return nil, fmt.Errorf("can't convert value %v of type %T to Spanner type %s", val, val, spType)
+}
+
+// convArray converts the elements of a repeated field to a slice of the
+// Spanner type spType. The Spanner client doesn't accept []interface{} for
+// arrays, only slices of specific types.
+func convArray(conv *internal.Conv, vals []interface{}, spType string) (interface{}, error) {
+	elems := make([]interface{}, len(vals))
+	for i, val := range vals {
+		elem, err := convScalar(conv, val, spType)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = elem
+	}
+	switch spType {
+	case ddl.Bool:
+		r := []spanner.NullBool{}
+		for _, e := range elems {
+			r = append(r, spanner.NullBool{Bool: e.(bool), Valid: true})
+		}
+		return r, nil
+	case ddl.Bytes:
+		r := [][]byte{}
+		for _, e := range elems {
+			r = append(r, e.([]byte))
+		}
+		return r, nil
+	case ddl.Date:
+		r := []spanner.NullDate{}
+		for _, e := range elems {
+			r = append(r, spanner.NullDate{Date: e.(civil.Date), Valid: true})
+		}
+		return r, nil
+	case ddl.Float64:
+		r := []spanner.NullFloat64{}
+		for _, e := range elems {
+			r = append(r, spanner.NullFloat64{Float64: e.(float64), Valid: true})
+		}
+		return r, nil
+	case ddl.Int64:
+		r := []spanner.NullInt64{}
+		for _, e := range elems {
+			r = append(r, spanner.NullInt64{Int64: e.(int64), Valid: true})
+		}
+		return r, nil
+	case ddl.Numeric:
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			r := []spanner.PGNumeric{}
+			for _, e := range elems {
+				r = append(r, e.(spanner.PGNumeric))
+			}
+			return r, nil
+		}
+		r := []spanner.NullNumeric{}
+		for _, e := range elems {
+			r = append(r, spanner.NullNumeric{Numeric: *e.(*big.Rat), Valid: true})
+		}
+		return r, nil
+	case ddl.String:
+		r := []spanner.NullString{}
+		for _, e := range elems {
+			r = append(r, spanner.NullString{StringVal: e.(string), Valid: true})
+		}
+		return r, nil
+	case ddl.Timestamp:
+		r := []spanner.NullTime{}
+		for _, e := range elems {
+			r = append(r, spanner.NullTime{Time: e.(time.Time), Valid: true})
+		}
+		return r, nil
+	case ddl.JSON:
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			r := []spanner.PGJsonB{}
+			for _, e := range elems {
+				r = append(r, spanner.PGJsonB{Value: json.RawMessage(e.(string)), Valid: true})
+			}
+			return r, nil
+		}
+		r := []spanner.NullJSON{}
+		for _, e := range elems {
+			r = append(r, spanner.NullJSON{Value: json.RawMessage(e.(string)), Valid: true})
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("array type conversion not implemented for type %v", spType)
+}
+
+// convNumeric maps a rational number into a valid Spanner numeric.
+func convNumeric(conv *internal.Conv, r *big.Rat) interface{} {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return spanner.PGNumeric{Numeric: decimalString(r), Valid: true}
+	}
+	return r
+}
+
+// decimalString formats a NUMERIC or BIGNUMERIC value, which has at most
+// 38 digits after the decimal point, without trailing zeros.
+func decimalString(r *big.Rat) string {
+	s := r.FloatString(38)
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// toString formats a value returned by recordValues as a string.
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case json.RawMessage:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case *big.Rat:
+		return decimalString(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case civil.Date, civil.Time, civil.DateTime:
+		return fmt.Sprint(v)
+	}
+	s, err := toJSON(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+	return s
+}
+
+// toJSON formats a value returned by recordValues as JSON. Bytes are
+// base64 encoded, and numerics are JSON numbers.
+func toJSON(val interface{}) (string, error) {
+	if v, ok := val.(json.RawMessage); ok {
+		return string(v), nil
+	}
+	b, err := json.Marshal(toJSONValue(val))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func toJSONValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for k, e := range v {
+			m[k] = toJSONValue(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = toJSONValue(e)
+		}
+		return a
+	case *big.Rat:
+		return json.Number(decimalString(v))
+	}
+	return val
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+	bq "google.golang.org/api/bigquery/v2"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestRecordValues(t *testing.T) {
+	fields := []*bq.TableFieldSchema{
+		{Name: "day", Type: "DATE"},
+		{Name: "at", Type: "TIME", Mode: "REQUIRED"},
+		{Name: "local", Type: "DATETIME"},
+		{Name: "doc", Type: "JSON"},
+		{Name: "point", Type: "STRUCT", Fields: []*bq.TableFieldSchema{
+			{Name: "x", Type: "FLOAT64"},
+		}},
+		{Name: "days", Type: "DATE", Mode: "REPEATED"},
+	}
+	record := map[string]interface{}{
+		"day":   map[string]interface{}{"int.date": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		"at":    3*time.Hour + 4*time.Minute + 5*time.Second + 600*time.Microsecond,
+		"local": map[string]interface{}{"string": "2024-01-02T03:04:05.5"},
+		"doc":   map[string]interface{}{"string": `{"a": [1, 2]}`},
+		"point": map[string]interface{}{"point": map[string]interface{}{"x": map[string]interface{}{"double": 1.5}}},
+		"days":  []interface{}{time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"day":   civil.Date{Year: 2024, Month: 1, Day: 2},
+		"at":    civil.Time{Hour: 3, Minute: 4, Second: 5, Nanosecond: 600000},
+		"local": civil.DateTime{Date: civil.Date{Year: 2024, Month: 1, Day: 2}, Time: civil.Time{Hour: 3, Minute: 4, Second: 5, Nanosecond: 500000000}},
+		"doc":   json.RawMessage(`{"a": [1, 2]}`),
+		"point": map[string]interface{}{"x": 1.5},
+		"days":  []interface{}{civil.Date{Year: 2024, Month: 1, Day: 3}},
+	}, recordValues(fields, record))
+}
+
+func TestConvScalar(t *testing.T) {
+	local := civil.DateTime{Date: civil.Date{Year: 2024, Month: 1, Day: 2}, Time: civil.Time{Hour: 3}}
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		in      interface{}
+		want    interface{}
+	}{
+		{name: "bool", spType: ddl.Bool, in: true, want: true},
+		{name: "bool to int64", spType: ddl.Int64, in: true, want: int64(1)},
+		{name: "bytes", spType: ddl.Bytes, in: []byte{0xca, 0xfe}, want: []byte{0xca, 0xfe}},
+		{name: "date", spType: ddl.Date, in: civil.Date{Year: 2024, Month: 1, Day: 2}, want: civil.Date{Year: 2024, Month: 1, Day: 2}},
+		{name: "numeric", spType: ddl.Numeric, in: big.NewRat(25, 2), want: big.NewRat(25, 2)},
+		{name: "numeric pg", dialect: constants.DIALECT_POSTGRESQL, spType: ddl.Numeric, in: big.NewRat(25, 2), want: spanner.PGNumeric{Numeric: "12.5", Valid: true}},
+		{name: "datetime", spType: ddl.Timestamp, in: local, want: time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{name: "time to string", spType: ddl.String, in: civil.Time{Hour: 3, Minute: 4, Second: 5}, want: "03:04:05"},
+		{name: "numeric to string", spType: ddl.String, in: big.NewRat(1, 3), want: "0.33333333333333333333333333333333333333"},
+		{name: "integral numeric to string", spType: ddl.String, in: big.NewRat(100, 1), want: "100"},
+		{name: "bytes to string", spType: ddl.String, in: []byte{0xca, 0xfe}, want: "yv4="},
+		{name: "json", spType: ddl.JSON, in: json.RawMessage(`{"a": [1, 2]}`), want: `{"a": [1, 2]}`},
+		{name: "struct", spType: ddl.JSON, in: map[string]interface{}{"n": big.NewRat(5, 4), "d": civil.Date{Year: 2024, Month: 1, Day: 2}, "b": []byte{0xca, 0xfe}}, want: `{"b":"yv4=","d":"2024-01-02","n":1.25}`},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		got, err := convScalar(conv, tc.in, tc.spType)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+	_, err := convScalar(internal.MakeConv(), "abc", ddl.Int64)
+	assert.NotNil(t, err)
+}
+
+func TestConvArray(t *testing.T) {
+	conv := internal.MakeConv()
+	got, err := convArray(conv, []interface{}{big.NewRat(1, 2)}, ddl.Numeric)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullNumeric{{Numeric: *big.NewRat(1, 2), Valid: true}}, got)
+	got, err = convArray(conv, []interface{}{civil.Date{Year: 2024, Month: 1, Day: 2}}, ddl.Date)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullDate{{Date: civil.Date{Year: 2024, Month: 1, Day: 2}, Valid: true}}, got)
+	got, err = convArray(conv, []interface{}{json.RawMessage(`{"a":1}`)}, ddl.JSON)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullJSON{{Value: json.RawMessage(`{"a":1}`), Valid: true}}, got)
+	_, err = convArray(conv, []interface{}{"abc"}, ddl.Int64)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bq "google.golang.org/api/bigquery/v2"
+)
+
+// jobPollInterval is the interval at which the status of query jobs is
+// polled while waiting for them to complete.
+var jobPollInterval = 2 * time.Second
+
+// DatasetAPI is the subset of the BigQuery API used for schema and data
+// migrations of a dataset.
+type DatasetAPI interface {
+	// ListTables returns the names of the tables of the dataset, excluding
+	// views, external tables and snapshots.
+	ListTables(ctx context.Context) ([]string, error)
+	// GetTable returns the metadata of a table of the dataset, including
+	// its schema and constraints.
+	GetTable(ctx context.Context, table string) (*bq.Table, error)
+	// Query runs a GoogleSQL query job and waits for it to complete.
+	Query(ctx context.Context, q string) (*bq.Job, error)
+}
+
+type datasetImpl struct {
+	svc      *bq.Service
+	project  string
+	dataset  string
+	location string
+}
+
+// NewDataset returns the API of dataset of project, with the application
+// default credentials. Query jobs are run in location, or in the location
+// of the dataset if empty.
+func NewDataset(ctx context.Context, project, dataset, location string) (DatasetAPI, error) {
+	svc, err := bq.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not create BigQuery client: %v", err)
+	}
+	return datasetImpl{svc: svc, project: project, dataset: dataset, location: location}, nil
+}
+
+func (d datasetImpl) ListTables(ctx context.Context) ([]string, error) {
+	var tables []string
+	err := d.svc.Tables.List(d.project, d.dataset).Pages(ctx, func(page *bq.TableList) error {
+		for _, t := range page.Tables {
+			if t.Type == "TABLE" {
+				tables = append(tables, t.TableReference.TableId)
+			}
+		}
+		return nil
+	})
+	return tables, err
+}
+
+func (d datasetImpl) GetTable(ctx context.Context, table string) (*bq.Table, error) {
+	return d.svc.Tables.Get(d.project, d.dataset, table).Context(ctx).Do()
+}
+
+func (d datasetImpl) Query(ctx context.Context, q string) (*bq.Job, error) {
+	useLegacySql := false
+	job := &bq.Job{
+		JobReference: &bq.JobReference{ProjectId: d.project, Location: d.location},
+		Configuration: &bq.JobConfiguration{
+			Query: &bq.JobConfigurationQuery{Query: q, UseLegacySql: &useLegacySql},
+		},
+	}
+	job, err := d.svc.Jobs.Insert(d.project, job).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	for job.Status == nil || job.Status.State != "DONE" {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(jobPollInterval):
+		}
+		job, err = d.svc.Jobs.Get(d.project, job.JobReference.JobId).Location(job.JobReference.Location).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if job.Status.ErrorResult != nil {
+		return nil, fmt.Errorf("query job %s failed: %s", job.JobReference.JobId, job.Status.ErrorResult.Message)
+	}
+	return job, nil
+}
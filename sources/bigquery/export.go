@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/linkedin/goavro/v2"
+
+	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
+	storageaccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ExportConfig configures the export of the data of tables to GCS. The
+// credentials running the migration must be allowed to write to Uri.
+type ExportConfig struct {
+	Uri string // GCS path the data is exported under, e.g. gs://bucket/path.
+
+	// files are the exported files of each BigQuery table, which are read
+	// again for each of its child tables.
+	files map[string][]string
+}
+
+// NewExportConfig returns the configuration of exports under uri.
+func NewExportConfig(uri string) *ExportConfig {
+	return &ExportConfig{Uri: uri, files: make(map[string][]string)}
+}
+
+// tableUri returns the GCS path the data of table tableName of dataset
+// dataset is exported under.
+func (ec *ExportConfig) tableUri(dataset, tableName string) string {
+	return fmt.Sprintf("%s/%s/%s/", strings.TrimSuffix(ec.Uri, "/"), url.PathEscape(dataset), url.PathEscape(tableName))
+}
+
+// ProcessData performs data conversion for a table. The data of the
+// BigQuery table is exported to GCS as Avro files, which are then read
+// back, converted to Spanner data (based on the source and Spanner schemas)
+// and written to Spanner. The rows of child tables are the repeated
+// records of the rows of their BigQuery table.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	if isi.Export == nil {
+		err := fmt.Errorf("please specify exportUri in the source-profile to migrate data")
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	ctx := context.Background()
+	tbl, path, err := isi.getTable(srcSchema.Name)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	files, err := isi.exportTable(ctx, strings.SplitN(srcSchema.Name, ".", 2)[0])
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't export data for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	sa, sc, err := isi.getStorage(ctx)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't read exported data for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	primaryKeys := primaryKey(tbl)
+	for _, file := range files {
+		content, err := sa.ReadGcsFile(ctx, sc, file)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read exported file %s of table %s : err = %s", file, srcSchema.Name, err))
+			return err
+		}
+		err = readAvro(content, func(record map[string]interface{}) {
+			values := recordValues(tbl.Schema.Fields, record)
+			keys := make(map[string]interface{})
+			for _, name := range primaryKeys {
+				keys[name] = values[name]
+			}
+			for _, row := range recordRows(tbl.Schema.Fields, values, path, keys) {
+				ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, row)
+			}
+		})
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read exported file %s of table %s : err = %s", file, srcSchema.Name, err))
+			return err
+		}
+	}
+	return nil
+}
+
+// exportTable exports the data of BigQuery table tableName with EXPORT
+// DATA, unless it was already exported, and returns the GCS paths of the
+// files written.
+func (isi InfoSchemaImpl) exportTable(ctx context.Context, tableName string) ([]string, error) {
+	if files, ok := isi.Export.files[tableName]; ok {
+		return files, nil
+	}
+	uri := isi.Export.tableUri(isi.DatasetId, tableName)
+	q := fmt.Sprintf("EXPORT DATA OPTIONS(uri='%s*.avro', format='AVRO', overwrite=true, use_avro_logical_types=true) AS SELECT * FROM `%s.%s.%s`",
+		strings.ReplaceAll(uri, "'", `\'`), isi.Project, isi.DatasetId, tableName)
+	job, err := isi.Dataset.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	var fileCount int64
+	if s := job.Statistics; s != nil && s.Query != nil && s.Query.ExportDataStatistics != nil {
+		fileCount = s.Query.ExportDataStatistics.FileCount
+	}
+	// The wildcard of the uri is replaced with the 12 digit sequence number
+	// of each file, starting at 0.
+	files := []string{}
+	for i := int64(0); i < fileCount; i++ {
+		files = append(files, fmt.Sprintf("%s%012d.avro", uri, i))
+	}
+	isi.Export.files[tableName] = files
+	return files, nil
+}
+
+func (isi InfoSchemaImpl) getStorage(ctx context.Context) (storageaccessor.StorageAccessor, storageclient.StorageClient, error) {
+	sa, sc := isi.StorageAccessor, isi.StorageClient
+	if sa == nil {
+		sa = &storageaccessor.StorageAccessorImpl{}
+	}
+	if sc == nil {
+		var err error
+		sc, err = storageclient.NewStorageClientImpl(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return sa, sc, nil
+}
+
+// readAvro calls processRecord with each record of an exported Avro file.
+func readAvro(content string, processRecord func(record map[string]interface{})) error {
+	r, err := goavro.NewOCFReader(strings.NewReader(content))
+	if err != nil {
+		return err
+	}
+	for r.Scan() {
+		datum, err := r.Read()
+		if err != nil {
+			return err
+		}
+		record, ok := datum.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected Avro datum of type %T", datum)
+		}
+		processRecord(record)
+	}
+	return r.Err()
+}
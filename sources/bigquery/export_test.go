@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+
+	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
+	storageaccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+)
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+// ordersAvroSchema is the schema of the Avro files the orders table of
+// mkDataset is exported to.
+const ordersAvroSchema = `{"type": "record", "name": "Root", "fields": [
+	{"name": "id", "type": "long"},
+	{"name": "customer_id", "type": ["null", "long"]},
+	{"name": "created", "type": ["null", {"type": "long", "logicalType": "timestamp-micros"}]},
+	{"name": "amount", "type": ["null", {"type": "bytes", "logicalType": "decimal", "precision": 38, "scale": 9}]},
+	{"name": "shipping", "type": ["null", {"type": "record", "name": "shipping", "fields": [
+		{"name": "city", "type": ["null", "string"]}]}]},
+	{"name": "tags", "type": {"type": "array", "items": "string"}},
+	{"name": "items", "type": {"type": "array", "items": {"type": "record", "name": "items", "fields": [
+		{"name": "sku", "type": "string"},
+		{"name": "id", "type": ["null", "string"]},
+		{"name": "parts", "type": {"type": "array", "items": {"type": "record", "name": "parts", "fields": [
+			{"name": "name", "type": ["null", "string"]}]}}}]}}}]}`
+
+var created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func mkOrdersAvroFile(t *testing.T) string {
+	var buf bytes.Buffer
+	w, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &buf, Schema: ordersAvroSchema})
+	assert.Nil(t, err)
+	err = w.Append([]interface{}{
+		map[string]interface{}{
+			"id":          int64(1),
+			"customer_id": goavro.Union("long", int64(7)),
+			"created":     goavro.Union("long.timestamp-micros", created),
+			"amount":      goavro.Union("bytes.decimal", big.NewRat(25, 2)),
+			"shipping":    goavro.Union("shipping", map[string]interface{}{"city": goavro.Union("string", "Paris")}),
+			"tags":        []interface{}{"a", "b"},
+			"items": []interface{}{
+				map[string]interface{}{"sku": "s1", "id": goavro.Union("string", "x"), "parts": []interface{}{
+					map[string]interface{}{"name": goavro.Union("string", "p1")},
+					map[string]interface{}{"name": nil},
+				}},
+				map[string]interface{}{"sku": "s2", "id": nil, "parts": []interface{}{}},
+			},
+		},
+		map[string]interface{}{
+			"id":          int64(2),
+			"customer_id": nil,
+			"created":     nil,
+			"amount":      nil,
+			"shipping":    nil,
+			"tags":        []interface{}{},
+			"items":       []interface{}{},
+		},
+	})
+	assert.Nil(t, err)
+	return buf.String()
+}
+
+func mkExportInfoSchema(t *testing.T, nested string) (InfoSchemaImpl, *fakeDataset) {
+	dataset := mkDataset()
+	dataset.fileCount = 1
+	files := map[string]string{"gs://bucket/export/sales/orders/000000000000.avro": mkOrdersAvroFile(t)}
+	isi := InfoSchemaImpl{
+		Dataset:   dataset,
+		Project:   "proj",
+		DatasetId: "sales",
+		Nested:    nested,
+		Export:    NewExportConfig("gs://bucket/export/"),
+		StorageAccessor: &storageaccessor.StorageAccessorMock{
+			ReadGcsFileMock: func(ctx context.Context, sc storageclient.StorageClient, filePath string) (string, error) {
+				content, ok := files[filePath]
+				if !ok {
+					return "", fmt.Errorf("file %s not found", filePath)
+				}
+				return content, nil
+			},
+		},
+		StorageClient: &storageclient.StorageClientMock{},
+	}
+	return isi, dataset
+}
+
+// processTableData migrates the data of the Spanner table spTableName and
+// returns the rows written.
+func processTableData(t *testing.T, conv *internal.Conv, isi InfoSchemaImpl, spTableName string) []spannerData {
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, spTableName)
+	assert.Nil(t, err)
+	err = isi.ProcessData(conv, tableId, conv.SrcSchema[tableId], conv.SpSchema[tableId].ColIds, conv.SpSchema[tableId], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	return rows
+}
+
+func TestProcessData(t *testing.T) {
+	isi, dataset := mkExportInfoSchema(t, NestedJSON)
+	conv := processSchema(t, isi)
+	rows := processTableData(t, conv, isi, "orders")
+	assert.Equal(t, []string{"EXPORT DATA OPTIONS(uri='gs://bucket/export/sales/orders/*.avro', format='AVRO', overwrite=true, use_avro_logical_types=true) " +
+		"AS SELECT * FROM `proj.sales.orders`"}, dataset.queries)
+	cols := []string{"id", "customer_id", "created", "amount", "shipping", "tags", "items"}
+	assert.Equal(t, []spannerData{
+		{
+			table: "orders",
+			cols:  cols,
+			vals: []interface{}{
+				int64(1), int64(7), created, big.NewRat(25, 2), `{"city":"Paris"}`,
+				[]spanner.NullString{{StringVal: "a", Valid: true}, {StringVal: "b", Valid: true}},
+				`[{"id":"x","parts":[{"name":"p1"},{"name":null}],"sku":"s1"},{"id":null,"parts":[],"sku":"s2"}]`,
+			},
+		},
+		{
+			table: "orders",
+			cols:  cols,
+			vals:  []interface{}{int64(2), nil, nil, nil, nil, []spanner.NullString{}, `[]`},
+		},
+	}, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+}
+
+func TestProcessData_NestedTables(t *testing.T) {
+	isi, dataset := mkExportInfoSchema(t, NestedTables)
+	conv := processSchema(t, isi)
+	rows := processTableData(t, conv, isi, "orders")
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, []string{"id", "customer_id", "created", "amount", "shipping", "tags"}, rows[0].cols)
+
+	rows = processTableData(t, conv, isi, "orders_items")
+	assert.Equal(t, []spannerData{
+		{table: "orders_items", cols: []string{"id", "items_offset", "sku"}, vals: []interface{}{int64(1), int64(0), "s1"}},
+		{table: "orders_items", cols: []string{"id", "items_offset", "sku"}, vals: []interface{}{int64(1), int64(1), "s2"}},
+	}, rows)
+
+	rows = processTableData(t, conv, isi, "orders_items_parts")
+	cols := []string{"id", "items_offset", "parts_offset", "name"}
+	assert.Equal(t, []spannerData{
+		{table: "orders_items_parts", cols: cols, vals: []interface{}{int64(1), int64(0), int64(0), "p1"}},
+		{table: "orders_items_parts", cols: cols, vals: []interface{}{int64(1), int64(0), int64(1), nil}},
+	}, rows)
+
+	// The table is exported once, for the table and its child tables.
+	assert.Equal(t, 1, len(dataset.queries))
+	assert.Equal(t, int64(0), conv.BadRows())
+}
+
+func TestProcessData_NoExport(t *testing.T) {
+	isi, _ := mkExportInfoSchema(t, NestedJSON)
+	conv := processSchema(t, isi)
+	isi.Export = nil
+	tableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "orders")
+	err := isi.ProcessData(conv, tableId, conv.SrcSchema[tableId], conv.SpSchema[tableId].ColIds, conv.SpSchema[tableId], internal.AdditionalDataAttributes{})
+	assert.NotNil(t, err)
+}
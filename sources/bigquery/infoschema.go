@@ -0,0 +1,425 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bigquery handles schema and data migrations from BigQuery
+// datasets. Schemas are read with the BigQuery API. Records are stored in
+// JSON columns, except for repeated records, which can be stored in child
+// tables interleaved in the table of the records instead. Data is exported
+// to GCS as Avro files with EXPORT DATA and read from there.
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sp "cloud.google.com/go/spanner"
+	bq "google.golang.org/api/bigquery/v2"
+
+	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
+	storageaccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// Source types are named after the GoogleSQL data types, in lower case.
+const (
+	typeString     = "string"
+	typeBytes      = "bytes"
+	typeInt64      = "int64"
+	typeFloat64    = "float64"
+	typeNumeric    = "numeric"
+	typeBigNumeric = "bignumeric"
+	typeBool       = "bool"
+	typeTimestamp  = "timestamp"
+	typeDate       = "date"
+	typeTime       = "time"
+	typeDatetime   = "datetime"
+	typeGeography  = "geography"
+	typeJSON       = "json"
+	typeStruct     = "struct"
+	typeInterval   = "interval"
+	typeRange      = "range"
+)
+
+// Field modes of BigQuery table schemas.
+const (
+	modeRequired = "REQUIRED"
+	modeRepeated = "REPEATED"
+)
+
+// Repeated records are mapped using one of the strategies below.
+const (
+	// NestedJSON stores repeated records in JSON columns, like other
+	// records.
+	NestedJSON = "json"
+	// NestedTables stores repeated records in child tables, interleaved in
+	// the table of the records. The primary key of child tables is the
+	// primary key of their parent table followed by the offset of the
+	// record in the repeated field, named after the field e.g.
+	// items_offset. Repeated records of tables without a primary key are
+	// stored in JSON columns.
+	NestedTables = "tables"
+)
+
+// offsetSuffix suffixes the name of the offset columns of child tables.
+const offsetSuffix = "_offset"
+
+// InfoSchemaImpl is BigQuery specific implementation for InfoSchema.
+type InfoSchemaImpl struct {
+	Dataset   DatasetAPI
+	Project   string
+	DatasetId string
+	Nested    string        // One of NestedJSON and NestedTables.
+	Export    *ExportConfig // Export configuration, required to migrate data.
+
+	// Storage accessor and client used to read exported data, created on
+	// first use if nil.
+	StorageAccessor storageaccessor.StorageAccessor
+	StorageClient   storageclient.StorageClient
+}
+
+// GetToDdl implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: BigQuery can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for BigQuery")
+}
+
+// StartStreamingMigration is not supported: BigQuery can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for BigQuery")
+}
+
+// GetTableName returns table name. Tables are all in the same dataset, so
+// they aren't prefixed with its name.
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	return tableName
+}
+
+// GetParentTableName implements the common.ChildTableSource interface.
+// Child tables are named after the path of their repeated field e.g.
+// orders.items, and their parent table after the path of the parent field.
+func (isi InfoSchemaImpl) GetParentTableName(tableName string) string {
+	if i := strings.LastIndex(tableName, "."); i >= 0 {
+		return tableName[:i]
+	}
+	return ""
+}
+
+// GetTables returns the tables of the dataset and, with the NestedTables
+// strategy, the child tables of their repeated records.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	ctx := context.Background()
+	names, err := isi.Dataset.ListTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list tables: %v", err)
+	}
+	var tables []common.SchemaAndName
+	for _, name := range names {
+		tables = append(tables, common.SchemaAndName{Schema: isi.DatasetId, Name: name})
+		if isi.Nested != NestedTables {
+			continue
+		}
+		tbl, _, err := isi.getTable(name)
+		if err != nil {
+			return nil, err
+		}
+		if len(primaryKey(tbl)) == 0 {
+			continue
+		}
+		for _, path := range childTablePaths(tbl.Schema.Fields, name) {
+			tables = append(tables, common.SchemaAndName{Schema: isi.DatasetId, Name: path})
+		}
+	}
+	return tables, nil
+}
+
+// childTablePaths returns the paths of the repeated records of fields,
+// prefixed with prefix, including the ones nested in repeated records.
+func childTablePaths(fields []*bq.TableFieldSchema, prefix string) []string {
+	var paths []string
+	for _, f := range fields {
+		if isRepeatedRecord(f) {
+			path := prefix + "." + f.Name
+			paths = append(paths, path)
+			paths = append(paths, childTablePaths(f.Fields, path)...)
+		}
+	}
+	return paths
+}
+
+// GetColumns returns a list of Column objects and names. The columns of
+// child tables are the primary key columns of their parent table, the
+// offset column and the fields of their records.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	tbl, path, err := isi.getTable(table.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	addColumn := func(c schema.Column) {
+		c.Id = internal.GenerateColumnId()
+		colDefs[c.Id] = c
+		colIds = append(colIds, c.Id)
+	}
+	fields := tbl.Schema.Fields
+	if len(path) > 0 {
+		keys, err := keyColumns(tbl, path[:len(path)-1])
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, c := range keys {
+			addColumn(c)
+		}
+		addColumn(offsetColumn(path[len(path)-1]))
+		field, err := getField(tbl, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields = field.Fields
+	}
+	hasChildTables := isi.Nested == NestedTables && len(primaryKey(tbl)) > 0
+	sortKeyOrders := make(map[string]int)
+	if tbl.Clustering != nil && len(path) == 0 {
+		for i, name := range tbl.Clustering.Fields {
+			sortKeyOrders[name] = i + 1
+		}
+	}
+	for _, f := range fields {
+		if hasChildTables && isRepeatedRecord(f) {
+			continue
+		}
+		if findColumn(colDefs, f.Name) != "" {
+			conv.Unexpected(fmt.Sprintf("Skipping field %s of table %s: it has the name of a primary key column of the parent table", f.Name, table.Name))
+			continue
+		}
+		addColumn(schema.Column{
+			Name:         f.Name,
+			Type:         toType(f),
+			NotNull:      f.Mode == modeRequired,
+			Ignored:      schema.Ignored{Default: f.DefaultValueExpression != ""},
+			SortKeyOrder: sortKeyOrders[f.Name],
+		})
+	}
+	return colDefs, colIds, nil
+}
+
+// findColumn returns the id of the column named name, or "" if there is
+// none.
+func findColumn(colDefs map[string]schema.Column, name string) string {
+	for colId, c := range colDefs {
+		if c.Name == name {
+			return colId
+		}
+	}
+	return ""
+}
+
+// keyColumns returns the primary key columns of the table of the records
+// at path in tbl, with the offsets of their ancestors in their repeated
+// fields.
+func keyColumns(tbl *bq.Table, path []string) ([]schema.Column, error) {
+	if len(path) == 0 {
+		var keys []schema.Column
+		for _, name := range primaryKey(tbl) {
+			f, err := getField(tbl, []string{name})
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, schema.Column{Name: f.Name, Type: toType(f), NotNull: true})
+		}
+		return keys, nil
+	}
+	keys, err := keyColumns(tbl, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	return append(keys, offsetColumn(path[len(path)-1])), nil
+}
+
+// offsetColumn returns the column of the offsets of the records of the
+// repeated field named field.
+func offsetColumn(field string) schema.Column {
+	return schema.Column{Name: field + offsetSuffix, Type: schema.Type{Name: typeInt64}, NotNull: true}
+}
+
+// GetRowsFromTable is not used: data is exported rather than queried.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
+	return nil, fmt.Errorf("data of BigQuery tables is exported rather than queried")
+}
+
+// GetRowCount returns the number of rows of a table, as reported by its
+// metadata. The number of rows of child tables isn't known without
+// scanning their table, so it is reported as 0.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	tbl, path, err := isi.getTable(table.Name)
+	if err != nil || len(path) > 0 {
+		return 0, err
+	}
+	return int64(tbl.NumRows), nil
+}
+
+// GetConstraints returns the primary key of a table. BigQuery primary keys
+// aren't enforced, so tables with duplicate keys won't be fully migrated.
+// BigQuery has no other constraints.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	tbl, path, err := isi.getTable(table.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	primaryKeys := primaryKey(tbl)
+	if len(path) > 0 {
+		keys, err := keyColumns(tbl, path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		primaryKeys = nil
+		for _, c := range keys {
+			primaryKeys = append(primaryKeys, c.Name)
+		}
+	}
+	return primaryKeys, nil, make(map[string][]string), nil
+}
+
+// GetForeignKeys returns a list of all the foreign key constraints of a
+// table. Foreign keys referencing tables of other datasets are skipped.
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	tbl, path, err := isi.getTable(table.Name)
+	if err != nil || len(path) > 0 || tbl.TableConstraints == nil {
+		return nil, err
+	}
+	for _, fk := range tbl.TableConstraints.ForeignKeys {
+		ref := fk.ReferencedTable
+		if ref == nil || ref.ProjectId != isi.Project || ref.DatasetId != isi.DatasetId {
+			conv.Unexpected(fmt.Sprintf("Skipping foreign key %s of table %s: it references a table of another dataset", fk.Name, table.Name))
+			continue
+		}
+		var cols, refCols []string
+		for _, c := range fk.ColumnReferences {
+			cols = append(cols, c.ReferencingColumn)
+			refCols = append(refCols, c.ReferencedColumn)
+		}
+		foreignKeys = append(foreignKeys, schema.ForeignKey{
+			Id:               internal.GenerateForeignkeyId(),
+			Name:             fk.Name,
+			ColumnNames:      cols,
+			ReferTableName:   ref.TableId,
+			ReferColumnNames: refCols,
+			OnDelete:         constants.FK_NO_ACTION,
+			OnUpdate:         constants.FK_NO_ACTION,
+		})
+	}
+	return foreignKeys, nil
+}
+
+// GetIndexes return a list of all indexes for the specified table.
+// BigQuery tables have no indexes: their clustering columns are reported
+// by GetColumns instead.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return nil, nil
+}
+
+// getTable returns the BigQuery table of the source table tableName, and
+// the path of its repeated field for child tables.
+func (isi InfoSchemaImpl) getTable(tableName string) (*bq.Table, []string, error) {
+	parts := strings.Split(tableName, ".")
+	tbl, err := isi.Dataset.GetTable(context.Background(), parts[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get table %s: %v", parts[0], err)
+	}
+	if tbl.Schema == nil {
+		tbl.Schema = &bq.TableSchema{}
+	}
+	return tbl, parts[1:], nil
+}
+
+// getField returns the field at path in the schema of tbl.
+func getField(tbl *bq.Table, path []string) (*bq.TableFieldSchema, error) {
+	fields := tbl.Schema.Fields
+	var field *bq.TableFieldSchema
+	for _, name := range path {
+		field = nil
+		for _, f := range fields {
+			if f.Name == name {
+				field = f
+				break
+			}
+		}
+		if field == nil {
+			return nil, fmt.Errorf("field %s not found", strings.Join(path, "."))
+		}
+		fields = field.Fields
+	}
+	return field, nil
+}
+
+// primaryKey returns the primary key columns of tbl, if any.
+func primaryKey(tbl *bq.Table) []string {
+	if tbl.TableConstraints == nil || tbl.TableConstraints.PrimaryKey == nil {
+		return nil
+	}
+	return tbl.TableConstraints.PrimaryKey.Columns
+}
+
+func isRepeatedRecord(f *bq.TableFieldSchema) bool {
+	return f.Mode == modeRepeated && toTypeName(f.Type) == typeStruct
+}
+
+// toType maps a field of a BigQuery table schema to a schema.Type. The
+// mods of NUMERIC and BIGNUMERIC types are their precision and scale, and
+// those of STRING and BYTES types their maximum length, if set. Repeated
+// fields are arrays.
+func toType(f *bq.TableFieldSchema) schema.Type {
+	ty := schema.Type{Name: toTypeName(f.Type)}
+	switch ty.Name {
+	case typeString, typeBytes:
+		if f.MaxLength > 0 {
+			ty.Mods = []int64{f.MaxLength}
+		}
+	case typeNumeric, typeBigNumeric:
+		if f.Precision > 0 {
+			ty.Mods = []int64{f.Precision, f.Scale}
+		}
+	}
+	if f.Mode == modeRepeated {
+		ty.ArrayBounds = []int64{-1}
+	}
+	return ty
+}
+
+// toTypeName maps the type names of BigQuery table schemas, some of which
+// are legacy SQL names e.g. INTEGER, to GoogleSQL type names.
+func toTypeName(ty string) string {
+	switch strings.ToUpper(ty) {
+	case "INTEGER":
+		return typeInt64
+	case "FLOAT":
+		return typeFloat64
+	case "BOOLEAN":
+		return typeBool
+	case "RECORD":
+		return typeStruct
+	default:
+		return strings.ToLower(ty)
+	}
+}
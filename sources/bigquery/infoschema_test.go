@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	bq "google.golang.org/api/bigquery/v2"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+// fakeDataset is a DatasetAPI serving tables from memory. Query jobs
+// succeed, reporting fileCount exported files.
+type fakeDataset struct {
+	names     []string
+	tables    map[string]*bq.Table
+	fileCount int64
+	queries   []string
+}
+
+func (d *fakeDataset) ListTables(ctx context.Context) ([]string, error) {
+	return d.names, nil
+}
+
+func (d *fakeDataset) GetTable(ctx context.Context, table string) (*bq.Table, error) {
+	tbl, ok := d.tables[table]
+	if !ok {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+	return tbl, nil
+}
+
+func (d *fakeDataset) Query(ctx context.Context, q string) (*bq.Job, error) {
+	d.queries = append(d.queries, q)
+	return &bq.Job{Statistics: &bq.JobStatistics{Query: &bq.JobStatistics2{
+		ExportDataStatistics: &bq.ExportDataStatistics{FileCount: d.fileCount},
+	}}}, nil
+}
+
+func mkDataset() *fakeDataset {
+	return &fakeDataset{
+		names: []string{"customers", "orders"},
+		tables: map[string]*bq.Table{
+			"customers": {
+				NumRows: 10,
+				Schema: &bq.TableSchema{Fields: []*bq.TableFieldSchema{
+					{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+					{Name: "name", Type: "STRING", MaxLength: 100},
+					{Name: "addresses", Type: "RECORD", Mode: "REPEATED", Fields: []*bq.TableFieldSchema{
+						{Name: "city", Type: "STRING"},
+					}},
+				}},
+			},
+			"orders": {
+				NumRows: 20,
+				Schema: &bq.TableSchema{Fields: []*bq.TableFieldSchema{
+					{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+					{Name: "customer_id", Type: "INT64"},
+					{Name: "created", Type: "TIMESTAMP", DefaultValueExpression: "CURRENT_TIMESTAMP()"},
+					{Name: "amount", Type: "NUMERIC", Precision: 12, Scale: 2},
+					{Name: "shipping", Type: "RECORD", Fields: []*bq.TableFieldSchema{
+						{Name: "city", Type: "STRING"},
+					}},
+					{Name: "tags", Type: "STRING", Mode: "REPEATED"},
+					{Name: "items", Type: "RECORD", Mode: "REPEATED", Fields: []*bq.TableFieldSchema{
+						{Name: "sku", Type: "STRING", Mode: "REQUIRED"},
+						{Name: "id", Type: "STRING"},
+						{Name: "parts", Type: "RECORD", Mode: "REPEATED", Fields: []*bq.TableFieldSchema{
+							{Name: "name", Type: "STRING"},
+						}},
+					}},
+				}},
+				Clustering: &bq.Clustering{Fields: []string{"created"}},
+				TableConstraints: &bq.TableConstraints{
+					PrimaryKey: &bq.TableConstraintsPrimaryKey{Columns: []string{"id"}},
+					ForeignKeys: []*bq.TableConstraintsForeignKeys{
+						{
+							Name:             "orders_customer_fk",
+							ReferencedTable:  &bq.TableConstraintsForeignKeysReferencedTable{ProjectId: "proj", DatasetId: "sales", TableId: "customers"},
+							ColumnReferences: []*bq.TableConstraintsForeignKeysColumnReferences{{ReferencingColumn: "customer_id", ReferencedColumn: "id"}},
+						},
+						{
+							Name:             "orders_region_fk",
+							ReferencedTable:  &bq.TableConstraintsForeignKeysReferencedTable{ProjectId: "proj", DatasetId: "geo", TableId: "regions"},
+							ColumnReferences: []*bq.TableConstraintsForeignKeysColumnReferences{{ReferencingColumn: "customer_id", ReferencedColumn: "id"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func processSchema(t *testing.T, isi InfoSchemaImpl) *internal.Conv {
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	return conv
+}
+
+func TestProcessSchema(t *testing.T) {
+	isi := InfoSchemaImpl{Dataset: mkDataset(), Project: "proj", DatasetId: "sales", Nested: NestedJSON}
+	conv := processSchema(t, isi)
+	expectedSchema := map[string]ddl.CreateTable{
+		"customers": {
+			Name:   "customers",
+			ColIds: []string{"id", "name", "addresses", "synth_id"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":        {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"name":      {Name: "name", T: ddl.Type{Name: ddl.String, Len: 100}},
+				"addresses": {Name: "addresses", T: ddl.Type{Name: ddl.JSON}},
+				"synth_id":  {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
+		},
+		"orders": {
+			Name:   "orders",
+			ColIds: []string{"id", "customer_id", "created", "amount", "shipping", "tags", "items"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":          {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"customer_id": {Name: "customer_id", T: ddl.Type{Name: ddl.Int64}},
+				"created":     {Name: "created", T: ddl.Type{Name: ddl.Timestamp}},
+				"amount":      {Name: "amount", T: ddl.Type{Name: ddl.Numeric}},
+				"shipping":    {Name: "shipping", T: ddl.Type{Name: ddl.JSON}},
+				"tags":        {Name: "tags", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+				"items":       {Name: "items", T: ddl.Type{Name: ddl.JSON}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "orders_customer_fk", ColIds: []string{"customer_id"}, ReferTableId: "customers", ReferColumnIds: []string{"id"}, OnDelete: constants.FK_NO_ACTION, OnUpdate: constants.FK_NO_ACTION}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	// The foreign key referencing a table of another dataset is skipped.
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+
+	ordersTableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "orders")
+	createdColId, _ := internal.GetColIdFromSpName(conv.SpSchema[ordersTableId].ColDefs, "created")
+	assert.Equal(t, []internal.SchemaIssue{internal.DefaultValue, internal.SortKey}, conv.SchemaIssues[ordersTableId].ColumnLevelIssues[createdColId])
+}
+
+func TestProcessSchema_NestedTables(t *testing.T) {
+	isi := InfoSchemaImpl{Dataset: mkDataset(), Project: "proj", DatasetId: "sales", Nested: NestedTables}
+	conv := processSchema(t, isi)
+	common.InterleaveChildTables(conv, isi)
+	expectedSchema := map[string]ddl.CreateTable{
+		// Repeated records of tables without a primary key stay in JSON
+		// columns.
+		"customers": {
+			Name:   "customers",
+			ColIds: []string{"id", "name", "addresses", "synth_id"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":        {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"name":      {Name: "name", T: ddl.Type{Name: ddl.String, Len: 100}},
+				"addresses": {Name: "addresses", T: ddl.Type{Name: ddl.JSON}},
+				"synth_id":  {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
+		},
+		"orders": {
+			Name:   "orders",
+			ColIds: []string{"id", "customer_id", "created", "amount", "shipping", "tags"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":          {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"customer_id": {Name: "customer_id", T: ddl.Type{Name: ddl.Int64}},
+				"created":     {Name: "created", T: ddl.Type{Name: ddl.Timestamp}},
+				"amount":      {Name: "amount", T: ddl.Type{Name: ddl.Numeric}},
+				"shipping":    {Name: "shipping", T: ddl.Type{Name: ddl.JSON}},
+				"tags":        {Name: "tags", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "orders_customer_fk", ColIds: []string{"customer_id"}, ReferTableId: "customers", ReferColumnIds: []string{"id"}, OnDelete: constants.FK_NO_ACTION, OnUpdate: constants.FK_NO_ACTION}},
+		},
+		"orders_items": {
+			Name:   "orders_items",
+			ColIds: []string{"id", "items_offset", "sku"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":           {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"items_offset": {Name: "items_offset", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"sku":          {Name: "sku", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}, {ColId: "items_offset", Order: 2}},
+		},
+		"orders_items_parts": {
+			Name:   "orders_items_parts",
+			ColIds: []string{"id", "items_offset", "parts_offset", "name"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":           {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"items_offset": {Name: "items_offset", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"parts_offset": {Name: "parts_offset", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"name":         {Name: "name", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}, {ColId: "items_offset", Order: 2}, {ColId: "parts_offset", Order: 3}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	// The foreign key referencing a table of another dataset, and the id
+	// field of items, which has the name of the primary key of orders, are
+	// skipped.
+	assert.Equal(t, int64(2), conv.Unexpecteds())
+
+	ordersTableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "orders")
+	itemsTableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "orders_items")
+	partsTableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "orders_items_parts")
+	assert.Equal(t, ddl.InterleavedParent{Id: ordersTableId, OnDelete: constants.FK_CASCADE}, conv.SpSchema[itemsTableId].ParentTable)
+	assert.Equal(t, ddl.InterleavedParent{Id: itemsTableId, OnDelete: constants.FK_CASCADE}, conv.SpSchema[partsTableId].ParentTable)
+	assert.Equal(t, ddl.InterleavedParent{}, conv.SpSchema[ordersTableId].ParentTable)
+}
+
+func TestGetRowCount(t *testing.T) {
+	isi := InfoSchemaImpl{Dataset: mkDataset(), Project: "proj", DatasetId: "sales", Nested: NestedTables}
+	count, err := isi.GetRowCount(common.SchemaAndName{Schema: "sales", Name: "orders"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), count)
+	count, err = isi.GetRowCount(common.SchemaAndName{Schema: "sales", Name: "orders.items"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+	_, err = isi.GetRowCount(common.SchemaAndName{Schema: "sales", Name: "invoices"})
+	assert.NotNil(t, err)
+}
+
+func TestGetParentTableName(t *testing.T) {
+	isi := InfoSchemaImpl{}
+	assert.Equal(t, "", isi.GetParentTableName("orders"))
+	assert.Equal(t, "orders", isi.GetParentTableName("orders.items"))
+	assert.Equal(t, "orders.items", isi.GetParentTableName("orders.items.parts"))
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquery
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpannerType(t *testing.T) {
+	repeated := []int64{-1}
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		isPk    bool
+		srcType schema.Type
+		want    ddl.Type
+		issues  []internal.SchemaIssue
+	}{
+		{name: "string", srcType: schema.Type{Name: "string"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{name: "string with length", srcType: schema.Type{Name: "string", Mods: []int64{100}}, want: ddl.Type{Name: ddl.String, Len: 100}},
+		{name: "bytes with length", srcType: schema.Type{Name: "bytes", Mods: []int64{16}}, want: ddl.Type{Name: ddl.Bytes, Len: 16}},
+		{name: "int64", srcType: schema.Type{Name: "int64"}, want: ddl.Type{Name: ddl.Int64}},
+		{name: "int64 to string", spType: ddl.String, srcType: schema.Type{Name: "int64"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "float64", srcType: schema.Type{Name: "float64"}, want: ddl.Type{Name: ddl.Float64}},
+		{name: "numeric", srcType: schema.Type{Name: "numeric"}, want: ddl.Type{Name: ddl.Numeric}},
+		{name: "numeric with precision", srcType: schema.Type{Name: "numeric", Mods: []int64{12, 2}}, want: ddl.Type{Name: ddl.Numeric}},
+		{name: "numeric pg", dialect: constants.DIALECT_POSTGRESQL, srcType: schema.Type{Name: "numeric", Mods: []int64{12, 2}}, want: ddl.Type{Name: ddl.Numeric, Precision: 12, Scale: 2}},
+		{name: "bignumeric", srcType: schema.Type{Name: "bignumeric"}, want: ddl.Type{Name: ddl.Numeric}, issues: []internal.SchemaIssue{internal.Numeric}},
+		{name: "bignumeric with large precision", srcType: schema.Type{Name: "bignumeric", Mods: []int64{50, 20}}, want: ddl.Type{Name: ddl.Numeric}, issues: []internal.SchemaIssue{internal.Numeric}},
+		{name: "bignumeric with small precision", srcType: schema.Type{Name: "bignumeric", Mods: []int64{20, 5}}, want: ddl.Type{Name: ddl.Numeric}},
+		{name: "bignumeric to string", spType: ddl.String, srcType: schema.Type{Name: "bignumeric"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "bool", srcType: schema.Type{Name: "bool"}, want: ddl.Type{Name: ddl.Bool}},
+		{name: "date", srcType: schema.Type{Name: "date"}, want: ddl.Type{Name: ddl.Date}},
+		{name: "timestamp", srcType: schema.Type{Name: "timestamp"}, want: ddl.Type{Name: ddl.Timestamp}},
+		{name: "datetime", srcType: schema.Type{Name: "datetime"}, want: ddl.Type{Name: ddl.Timestamp}, issues: []internal.SchemaIssue{internal.Datetime}},
+		{name: "time", srcType: schema.Type{Name: "time"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Time}},
+		{name: "json", srcType: schema.Type{Name: "json"}, want: ddl.Type{Name: ddl.JSON}},
+		{name: "struct", srcType: schema.Type{Name: "struct"}, want: ddl.Type{Name: ddl.JSON}},
+		{name: "struct to string", spType: ddl.String, srcType: schema.Type{Name: "struct"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{name: "geography", srcType: schema.Type{Name: "geography"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{name: "interval", srcType: schema.Type{Name: "interval"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.NoGoodType}},
+		{name: "repeated int64", srcType: schema.Type{Name: "int64", ArrayBounds: repeated}, want: ddl.Type{Name: ddl.Int64, IsArray: true}},
+		{name: "repeated json", srcType: schema.Type{Name: "json", ArrayBounds: repeated}, want: ddl.Type{Name: ddl.JSON, IsArray: true}},
+		{name: "repeated struct", srcType: schema.Type{Name: "struct", ArrayBounds: repeated}, want: ddl.Type{Name: ddl.JSON}},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		ty, issues := ToDdlImpl{}.ToSpannerType(conv, tc.spType, tc.srcType, tc.isPk)
+		assert.Equal(t, tc.want, ty, tc.name)
+		assert.Equal(t, tc.issues, issues, tc.name)
+	}
+}
@@ -0,0 +1,464 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/gocql/gocql"
+	"gopkg.in/inf.v0"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ProcessDataRow converts a row read by gocql, keyed by column name, and
+// writes it out to Spanner.
+func ProcessDataRow(conv *internal.Conv, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, row map[string]interface{}) {
+	spVals, badCols, srcStrVals := cvtRow(conv, row, srcSchema, spSchema, colIds)
+	srcTableName := srcSchema.Name
+	spColNames := []string{}
+	srcColNames := []string{}
+	for _, colId := range colIds {
+		srcColNames = append(srcColNames, srcSchema.ColDefs[colId].Name)
+		spColNames = append(spColNames, spSchema.ColDefs[colId].Name)
+	}
+	if len(badCols) > 0 {
+		conv.Unexpected(fmt.Sprintf("Data conversion error for table %s in column(s) %s\n", srcTableName, badCols))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcColNames, srcStrVals)
+		return
+	}
+	conv.WriteRow(srcTableName, spSchema.Name, spColNames, spVals)
+}
+
+// cvtRow converts the values of the columns colIds of row to the types of
+// their Spanner columns. It returns the converted values, the names of the
+// columns whose values couldn't be converted and the values as strings.
+func cvtRow(conv *internal.Conv, row map[string]interface{}, srcSchema schema.Table, spSchema ddl.CreateTable, colIds []string) ([]interface{}, []string, []string) {
+	var srcStrVals []string
+	var spVals []interface{}
+	var badCols []string
+	for _, colId := range colIds {
+		srcColDef := srcSchema.ColDefs[colId]
+		if srcColDef.CommitTimestamp {
+			srcStrVals = append(srcStrVals, "PENDING_COMMIT_TIMESTAMP()")
+			spVals = append(spVals, spanner.CommitTimestamp)
+			continue
+		}
+		srcVal := row[srcColDef.Name]
+		if field := srcColDef.UserTypeField; field != nil {
+			srcVal = fieldValue(row[field.Column], field.Path)
+		}
+		val := value(srcVal, srcColDef.Type.Name, conv.UserDefinedTypes)
+		if val == nil {
+			srcStrVals = append(srcStrVals, "NULL")
+			spVals = append(spVals, nil)
+			continue
+		}
+		spType := spSchema.ColDefs[colId].T
+		var spVal interface{}
+		var err error
+		if elems, ok := val.([]interface{}); ok && spType.IsArray {
+			spVal, err = convArray(conv, elems, spType.Name)
+		} else {
+			spVal, err = convScalar(conv, val, spType.Name)
+		}
+		if err != nil {
+			badCols = append(badCols, srcColDef.Name)
+		}
+		srcStrVals = append(srcStrVals, toString(val))
+		spVals = append(spVals, spVal)
+	}
+	return spVals, badCols, srcStrVals
+}
+
+// fieldValue returns the value of the field at path of a value of a
+// user-defined type, or nil if it or a value holding it is null. gocql
+// reads null fields as zero values though, e.g. 0 or "", unless they were
+// added to the type after the value was written.
+func fieldValue(val interface{}, path []string) interface{} {
+	for _, name := range path {
+		fields, ok := val.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		val = fields[name]
+	}
+	return val
+}
+
+// value converts a value read by gocql for a column of Cassandra type
+// cassandraType to the Go types below, by Cassandra type:
+//
+//	integers, time: int64 (time in nanoseconds since midnight)
+//	decimal, varint: *big.Rat
+//	date: civil.Date
+//	duration: string, e.g. 1mo2d3ns
+//	list, set: []interface{}
+//	map: map[string]interface{}, with keys formatted by toString
+//	user-defined types of userTypes: map[string]interface{}, keyed by field
+//
+// Other values are returned as they are.
+func value(val interface{}, cassandraType string, userTypes map[string]internal.UserDefinedType) interface{} {
+	if val == nil {
+		return nil
+	}
+	t := unfrozen(strings.ToUpper(strings.ReplaceAll(cassandraType, " ", "")))
+	switch v := val.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case time.Duration:
+		return int64(v)
+	case *inf.Dec:
+		return decimalRat(v)
+	case *big.Int:
+		return new(big.Rat).SetInt(v)
+	case gocql.Duration:
+		return fmt.Sprintf("%dmo%dd%dns", v.Months, v.Days, v.Nanoseconds)
+	case time.Time:
+		if t == "DATE" {
+			return civil.DateOf(v.UTC())
+		}
+		return v
+	case map[string]interface{}:
+		// User-defined types.
+		if v == nil {
+			return nil
+		}
+		t, ok := findUserType(userTypes, cassandraType)
+		if !ok {
+			return v
+		}
+		fieldTypes := make(map[string]string)
+		for i, name := range t.FieldNames {
+			if i < len(t.FieldTypes) {
+				fieldTypes[name] = t.FieldTypes[i]
+			}
+		}
+		fields := make(map[string]interface{}, len(v))
+		for name, f := range v {
+			fields[name] = value(f, fieldTypes[name], userTypes)
+		}
+		return fields
+	case []byte, []interface{}:
+		// Blobs and tuples.
+		return v
+	}
+	kind, elemTypes, _ := splitCollection(t)
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Slice:
+		var elemType string
+		if (kind == "LIST" || kind == "SET") && len(elemTypes) == 1 {
+			elemType = elemTypes[0]
+		}
+		elems := make([]interface{}, rv.Len())
+		for i := range elems {
+			elems[i] = value(rv.Index(i).Interface(), elemType, userTypes)
+		}
+		return elems
+	case reflect.Map:
+		var keyType, elemType string
+		if kind == "MAP" && len(elemTypes) == 2 {
+			keyType, elemType = elemTypes[0], elemTypes[1]
+		}
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m[toString(value(iter.Key().Interface(), keyType, userTypes))] = value(iter.Value().Interface(), elemType, userTypes)
+		}
+		return m
+	}
+	return val
+}
+
+// decimalRat returns the value of a Cassandra decimal.
+func decimalRat(d *inf.Dec) *big.Rat {
+	r := new(big.Rat).SetInt(d.UnscaledBig())
+	scale := int64(d.Scale())
+	if scale < 0 {
+		return r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(-scale), nil)))
+	}
+	return r.Quo(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(scale), nil)))
+}
+
+// convScalar converts a value returned by value to a value of Spanner type
+// spType.
+func convScalar(conv *internal.Conv, val interface{}, spType string) (interface{}, error) {
+	switch spType {
+	case ddl.Bool:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+	case ddl.Bytes:
+		switch v := val.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		case gocql.UUID:
+			return v.Bytes(), nil
+		}
+	case ddl.Date:
+		if d, ok := val.(civil.Date); ok {
+			return d, nil
+		}
+	case ddl.Float32:
+		if f, ok := val.(float32); ok {
+			return f, nil
+		}
+	case ddl.Float64:
+		switch v := val.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			// Converted through their shortest decimal representation, so
+			// that e.g. 0.1 isn't stored as 0.10000000149011612.
+			return strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+		}
+	case ddl.Int64:
+		switch v := val.(type) {
+		case int64:
+			return v, nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+	case ddl.Numeric:
+		switch v := val.(type) {
+		case *big.Rat:
+			return convNumeric(conv, v), nil
+		case int64:
+			return convNumeric(conv, big.NewRat(v, 1)), nil
+		}
+	case ddl.Timestamp:
+		if t, ok := val.(time.Time); ok {
+			return t.UTC(), nil
+		}
+	case ddl.String:
+		return toString(val), nil
+	case ddl.JSON:
+		return toJSON(val)
+	}
+	return nil, fmt.Errorf("can't convert value %v of type %T to Spanner type %s", val, val, spType)
+}
+
+// convArray converts the elements of a list or set to a slice of the
+// Spanner type spType. The Spanner client doesn't accept []interface{} for
+// arrays, only slices of specific types.
+func convArray(conv *internal.Conv, vals []interface{}, spType string) (interface{}, error) {
+	elems := make([]interface{}, len(vals))
+	for i, val := range vals {
+		if val == nil {
+			continue
+		}
+		elem, err := convScalar(conv, val, spType)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = elem
+	}
+	switch spType {
+	case ddl.Bool:
+		r := []spanner.NullBool{}
+		for _, e := range elems {
+			b, ok := e.(bool)
+			r = append(r, spanner.NullBool{Bool: b, Valid: ok})
+		}
+		return r, nil
+	case ddl.Bytes:
+		r := [][]byte{}
+		for _, e := range elems {
+			b, _ := e.([]byte)
+			r = append(r, b)
+		}
+		return r, nil
+	case ddl.Date:
+		r := []spanner.NullDate{}
+		for _, e := range elems {
+			d, ok := e.(civil.Date)
+			r = append(r, spanner.NullDate{Date: d, Valid: ok})
+		}
+		return r, nil
+	case ddl.Float32:
+		r := []spanner.NullFloat32{}
+		for _, e := range elems {
+			f, ok := e.(float32)
+			r = append(r, spanner.NullFloat32{Float32: f, Valid: ok})
+		}
+		return r, nil
+	case ddl.Float64:
+		r := []spanner.NullFloat64{}
+		for _, e := range elems {
+			f, ok := e.(float64)
+			r = append(r, spanner.NullFloat64{Float64: f, Valid: ok})
+		}
+		return r, nil
+	case ddl.Int64:
+		r := []spanner.NullInt64{}
+		for _, e := range elems {
+			n, ok := e.(int64)
+			r = append(r, spanner.NullInt64{Int64: n, Valid: ok})
+		}
+		return r, nil
+	case ddl.Numeric:
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			r := []spanner.PGNumeric{}
+			for _, e := range elems {
+				n, _ := e.(spanner.PGNumeric)
+				r = append(r, n)
+			}
+			return r, nil
+		}
+		r := []spanner.NullNumeric{}
+		for _, e := range elems {
+			n, ok := e.(*big.Rat)
+			if !ok {
+				r = append(r, spanner.NullNumeric{})
+				continue
+			}
+			r = append(r, spanner.NullNumeric{Numeric: *n, Valid: true})
+		}
+		return r, nil
+	case ddl.String:
+		r := []spanner.NullString{}
+		for _, e := range elems {
+			s, ok := e.(string)
+			r = append(r, spanner.NullString{StringVal: s, Valid: ok})
+		}
+		return r, nil
+	case ddl.Timestamp:
+		r := []spanner.NullTime{}
+		for _, e := range elems {
+			t, ok := e.(time.Time)
+			r = append(r, spanner.NullTime{Time: t, Valid: ok})
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("array type conversion not implemented for type %v", spType)
+}
+
+// convNumeric maps a rational number into a valid Spanner numeric.
+func convNumeric(conv *internal.Conv, r *big.Rat) interface{} {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return spanner.PGNumeric{Numeric: decimalString(r), Valid: true}
+	}
+	return r
+}
+
+// maxDecimalDigits is the maximum number of digits after the decimal point
+// of the numbers formatted by decimalString.
+const maxDecimalDigits = 76
+
+// decimalString formats a decimal with as many digits after the decimal
+// point as it has, since Cassandra decimals have no maximum scale.
+func decimalString(r *big.Rat) string {
+	digits := 0
+	ten := big.NewInt(10)
+	// The denominators of decimals divide a power of 10. Others are
+	// formatted with at most maxDecimalDigits digits.
+	for p := big.NewInt(1); new(big.Int).Rem(p, r.Denom()).Sign() != 0 && digits < maxDecimalDigits; p.Mul(p, ten) {
+		digits++
+	}
+	return r.FloatString(digits)
+}
+
+// toString formats a value returned by value as a string.
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case *big.Rat:
+		return decimalString(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case civil.Date:
+		return v.String()
+	case gocql.UUID:
+		return v.String()
+	}
+	s, err := toJSON(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+	return s
+}
+
+// toJSON formats a value returned by value as JSON. Lists and sets are
+// JSON arrays, maps JSON objects, bytes are base64 encoded and decimals are
+// JSON numbers.
+func toJSON(val interface{}) (string, error) {
+	b, err := json.Marshal(toJSONValue(val))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func toJSONValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for k, e := range v {
+			m[k] = toJSONValue(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = toJSONValue(e)
+		}
+		return a
+	case *big.Rat:
+		return json.Number(decimalString(v))
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case civil.Date:
+		return v.String()
+	case gocql.UUID:
+		return v.String()
+	}
+	return val
+}
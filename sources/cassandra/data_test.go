@@ -0,0 +1,327 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gopkg.in/inf.v0"
+
+	cc "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/cassandra"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+func TestValue(t *testing.T) {
+	uuid := gocql.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	testCases := []struct {
+		name          string
+		cassandraType string
+		in            interface{}
+		want          interface{}
+	}{
+		{name: "int", cassandraType: "int", in: 42, want: int64(42)},
+		{name: "tinyint", cassandraType: "tinyint", in: int8(-1), want: int64(-1)},
+		{name: "time", cassandraType: "time", in: time.Hour, want: int64(time.Hour)},
+		{name: "decimal", cassandraType: "decimal", in: inf.NewDec(1234, 2), want: big.NewRat(1234, 100)},
+		{name: "decimal negative scale", cassandraType: "decimal", in: inf.NewDec(12, -3), want: big.NewRat(12000, 1)},
+		{name: "varint", cassandraType: "varint", in: big.NewInt(7), want: big.NewRat(7, 1)},
+		{name: "date", cassandraType: "date", in: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), want: civil.Date{Year: 2024, Month: 1, Day: 2}},
+		{name: "duration", cassandraType: "duration", in: gocql.Duration{Months: 1, Days: 2, Nanoseconds: 3}, want: "1mo2d3ns"},
+		{name: "uuid", cassandraType: "uuid", in: uuid, want: uuid},
+		{name: "list", cassandraType: "list<int>", in: []int{1, 2}, want: []interface{}{int64(1), int64(2)}},
+		{name: "set of dates", cassandraType: "set<date>", in: []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}, want: []interface{}{civil.Date{Year: 2024, Month: 1, Day: 2}}},
+		{name: "empty frozen list", cassandraType: "frozen<list<text>>", in: []string{}, want: []interface{}{}},
+		{name: "map", cassandraType: "map<int,decimal>", in: map[int]*inf.Dec{1: inf.NewDec(5, 1)}, want: map[string]interface{}{"1": big.NewRat(1, 2)}},
+		{name: "map of lists", cassandraType: "map<text,frozen<list<int>>>", in: map[string][]int{"a": {1}}, want: map[string]interface{}{"a": []interface{}{int64(1)}}},
+		{name: "null", cassandraType: "list<int>", in: nil, want: nil},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, value(tc.in, tc.cassandraType, nil), tc.name)
+	}
+}
+
+func TestValueUserTypes(t *testing.T) {
+	userTypes := map[string]internal.UserDefinedType{
+		"address": {Name: "address", FieldNames: []string{"city", "since", "geo"}, FieldTypes: []string{"text", "date", "frozen<point>"}},
+		"point":   {Name: "point", FieldNames: []string{"lat", "lon"}, FieldTypes: []string{"decimal", "float"}},
+	}
+	in := map[string]interface{}{
+		"city":  "Paris",
+		"since": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		"geo":   map[string]interface{}{"lat": inf.NewDec(4885, 2), "lon": float32(2.35)},
+	}
+	want := map[string]interface{}{
+		"city":  "Paris",
+		"since": civil.Date{Year: 2024, Month: 1, Day: 2},
+		"geo":   map[string]interface{}{"lat": big.NewRat(4885, 100), "lon": float32(2.35)},
+	}
+	assert.Equal(t, want, value(in, "frozen<address>", userTypes))
+	assert.Equal(t, []interface{}{want}, value([]map[string]interface{}{in}, "list<frozen<address>>", userTypes))
+	assert.Nil(t, value(map[string]interface{}(nil), "frozen<address>", userTypes))
+	assert.Equal(t, "Paris", fieldValue(in, []string{"city"}))
+	assert.Equal(t, inf.NewDec(4885, 2), fieldValue(in, []string{"geo", "lat"}))
+	assert.Nil(t, fieldValue(nil, []string{"geo", "lat"}))
+	assert.Nil(t, fieldValue(map[string]interface{}{"geo": map[string]interface{}(nil)}, []string{"geo", "lat"}))
+}
+
+func TestConvScalar(t *testing.T) {
+	uuid := gocql.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		in      interface{}
+		want    interface{}
+	}{
+		{name: "bool", spType: ddl.Bool, in: true, want: true},
+		{name: "bool to int64", spType: ddl.Int64, in: true, want: int64(1)},
+		{name: "float32 to float64", spType: ddl.Float64, in: float32(0.1), want: 0.1},
+		{name: "numeric", spType: ddl.Numeric, in: big.NewRat(25, 2), want: big.NewRat(25, 2)},
+		{name: "numeric pg", dialect: constants.DIALECT_POSTGRESQL, spType: ddl.Numeric, in: big.NewRat(1, 1024), want: spanner.PGNumeric{Numeric: "0.0009765625", Valid: true}},
+		{name: "timestamp", spType: ddl.Timestamp, in: time.Date(2024, 1, 2, 3, 0, 0, 0, time.FixedZone("", 3600)), want: time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC)},
+		{name: "uuid", spType: ddl.String, in: uuid, want: "123e4567-e89b-12d3-a456-426614174000"},
+		{name: "uuid to bytes", spType: ddl.Bytes, in: uuid, want: uuid.Bytes()},
+		{name: "date to string", spType: ddl.String, in: civil.Date{Year: 2024, Month: 1, Day: 2}, want: "2024-01-02"},
+		{name: "map", spType: ddl.JSON, in: map[string]interface{}{"1": big.NewRat(1, 2), "2": []interface{}{uuid}}, want: `{"1":0.5,"2":["123e4567-e89b-12d3-a456-426614174000"]}`},
+		{name: "list", spType: ddl.JSON, in: []interface{}{[]interface{}{int64(1)}, []interface{}{}}, want: `[[1],[]]`},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		got, err := convScalar(conv, tc.in, tc.spType)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+	_, err := convScalar(internal.MakeConv(), "abc", ddl.Int64)
+	assert.NotNil(t, err)
+}
+
+func TestConvArray(t *testing.T) {
+	conv := internal.MakeConv()
+	got, err := convArray(conv, []interface{}{int64(1), int64(2)}, ddl.Int64)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullInt64{{Int64: 1, Valid: true}, {Int64: 2, Valid: true}}, got)
+	got, err = convArray(conv, []interface{}{big.NewRat(1, 2)}, ddl.Numeric)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullNumeric{{Numeric: *big.NewRat(1, 2), Valid: true}}, got)
+	got, err = convArray(conv, []interface{}{"a", "b"}, ddl.String)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullString{{StringVal: "a", Valid: true}, {StringVal: "b", Valid: true}}, got)
+	_, err = convArray(conv, []interface{}{"abc"}, ddl.Int64)
+	assert.NotNil(t, err)
+}
+
+func TestProcessData(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = schema.Table{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "int"}},
+			"c2": {Id: "c2", Name: "tags", Type: schema.Type{Name: "set<text>"}},
+			"c3": {Id: "c3", Name: "scores", Type: schema.Type{Name: "map<text,int>"}},
+			"c4": {Id: "c4", Name: "born", Type: schema.Type{Name: "date"}},
+		},
+	}
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Id: "c1", Name: "id", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Id: "c2", Name: "tags", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+			"c3": {Id: "c3", Name: "scores", T: ddl.Type{Name: ddl.JSON}},
+			"c4": {Id: "c4", Name: "born", T: ddl.Type{Name: ddl.Date}},
+		},
+	}
+	client := new(cc.MockCassandraCluster)
+	client.On("Query", `SELECT "id", "tags", "scores", "born" FROM "ks"."users"`).Return(&cc.MockRowIterator{
+		Rows: []map[string]interface{}{
+			{"id": 1, "tags": []string{"a", "b"}, "scores": map[string]int{"x": 1}, "born": time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{"id": 2, "tags": nil, "scores": nil, "born": nil},
+			{"id": 3, "tags": nil, "scores": nil, "born": "not a date"},
+		},
+	})
+	isi := InfoSchemaImpl{
+		Client:        client,
+		SourceProfile: profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Cassandra: profiles.SourceProfileConnectionCassandra{Keyspace: "ks"}}},
+	}
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	err := isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	cols := []string{"id", "tags", "scores", "born"}
+	assert.Equal(t, []spannerData{
+		{table: "users", cols: cols, vals: []interface{}{
+			int64(1),
+			[]spanner.NullString{{StringVal: "a", Valid: true}, {StringVal: "b", Valid: true}},
+			`{"x":1}`,
+			civil.Date{Year: 2000, Month: 1, Day: 2},
+		}},
+		{table: "users", cols: cols, vals: []interface{}{int64(2), nil, nil, nil}},
+	}, rows)
+	assert.Equal(t, int64(1), conv.BadRows())
+	client.AssertExpectations(t)
+}
+
+func TestProcessDataUserTypes(t *testing.T) {
+	conv := internal.MakeConv()
+	internal.SetUserDefinedType(conv, internal.UserDefinedType{Name: "address", FieldNames: []string{"city", "geo"}, FieldTypes: []string{"text", "frozen<point>"}, Flatten: true})
+	internal.SetUserDefinedType(conv, internal.UserDefinedType{Name: "point", FieldNames: []string{"lat", "lon"}, FieldTypes: []string{"double", "double"}})
+	field := func(path ...string) *schema.UserTypeField {
+		return &schema.UserTypeField{Column: "home", ColumnType: "frozen<address>", Path: path}
+	}
+	conv.SrcSchema["t1"] = schema.Table{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "int"}},
+			"c2": {Id: "c2", Name: "home_city", Type: schema.Type{Name: "text"}, UserTypeField: field("city")},
+			"c3": {Id: "c3", Name: "home_geo", Type: schema.Type{Name: "frozen<point>"}, UserTypeField: field("geo")},
+			"c4": {Id: "c4", Name: "work", Type: schema.Type{Name: "frozen<point>"}},
+		},
+	}
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Id: "c1", Name: "id", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Id: "c2", Name: "home_city", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c3": {Id: "c3", Name: "home_geo", T: ddl.Type{Name: ddl.JSON}},
+			"c4": {Id: "c4", Name: "work", T: ddl.Type{Name: ddl.JSON}},
+		},
+	}
+	client := new(cc.MockCassandraCluster)
+	client.On("Query", `SELECT "id", "home", "work" FROM "ks"."users"`).Return(&cc.MockRowIterator{
+		Rows: []map[string]interface{}{
+			{
+				"id":   1,
+				"home": map[string]interface{}{"city": "Paris", "geo": map[string]interface{}{"lat": 48.85, "lon": 2.35}},
+				"work": map[string]interface{}{"lat": 1.5, "lon": 2.0},
+			},
+			{"id": 2, "home": nil, "work": nil},
+		},
+	})
+	isi := InfoSchemaImpl{
+		Client:        client,
+		SourceProfile: profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Cassandra: profiles.SourceProfileConnectionCassandra{Keyspace: "ks"}}},
+	}
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	err := isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	cols := []string{"id", "home_city", "home_geo", "work"}
+	assert.Equal(t, []spannerData{
+		{table: "users", cols: cols, vals: []interface{}{int64(1), "Paris", `{"lat":48.85,"lon":2.35}`, `{"lat":1.5,"lon":2}`}},
+		{table: "users", cols: cols, vals: []interface{}{int64(2), nil, nil, nil}},
+	}, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+	client.AssertExpectations(t)
+}
+
+func TestProcessDataCounters(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = schema.Table{
+		Id:     "t1",
+		Name:   "posts",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "int"}},
+			"c2": {Id: "c2", Name: "likes", Type: schema.Type{Name: "counter"}},
+			"c3": {Id: "c3", Name: "counter_commit_ts", Type: schema.Type{Name: "timestamp"}, CommitTimestamp: true},
+		},
+	}
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Id:     "t1",
+		Name:   "posts",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Id: "c1", Name: "id", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Id: "c2", Name: "likes", T: ddl.Type{Name: ddl.Int64}},
+			"c3": {Id: "c3", Name: "counter_commit_ts", T: ddl.Type{Name: ddl.Timestamp}, Opts: map[string]string{ddl.AllowCommitTimestampOpt: "true"}},
+		},
+	}
+	client := new(cc.MockCassandraCluster)
+	client.On("Query", `SELECT "id", "likes" FROM "ks"."posts"`).Return(&cc.MockRowIterator{
+		Rows: []map[string]interface{}{{"id": 1, "likes": int64(42)}},
+	})
+	isi := InfoSchemaImpl{
+		Client:        client,
+		SourceProfile: profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Cassandra: profiles.SourceProfileConnectionCassandra{Keyspace: "ks"}}},
+	}
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	err := isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	assert.Equal(t, []spannerData{
+		{table: "posts", cols: []string{"id", "likes", "counter_commit_ts"}, vals: []interface{}{int64(1), int64(42), spanner.CommitTimestamp}},
+	}, rows)
+	client.AssertExpectations(t)
+}
+
+func TestGetRowCount(t *testing.T) {
+	profile := profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Cassandra: profiles.SourceProfileConnectionCassandra{Keyspace: "ks"}}}
+	client := new(cc.MockCassandraCluster)
+	client.On("Query", `SELECT COUNT(*) FROM "ks"."users"`).Return(&cc.MockRowIterator{Rows: []map[string]interface{}{{"count": int64(42)}}})
+	client.On("Query", `SELECT COUNT(*) FROM "ks"."missing"`).Return(&cc.MockRowIterator{Err: errors.New("unconfigured table missing")})
+	isi := InfoSchemaImpl{Client: client, SourceProfile: profile}
+
+	count, err := isi.GetRowCount(common.SchemaAndName{Name: "users"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), count)
+	_, err = isi.GetRowCount(common.SchemaAndName{Name: "missing"})
+	assert.NotNil(t, err)
+}
@@ -17,6 +17,7 @@ package cassandra
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	sp "cloud.google.com/go/spanner"
 	cc "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/cassandra"
@@ -28,9 +29,15 @@ import (
 	"github.com/gocql/gocql"
 )
 
+// counterCommitTimestampColumn is the name of the commit timestamp column
+// added to the tables with counter columns, see
+// profiles.SourceProfileConnectionCassandra.CounterCommitTimestamp.
+const counterCommitTimestampColumn = "counter_commit_ts"
+
 // InfoSchemaImpl is Cassandra specific implementation for InfoSchema
 type InfoSchemaImpl struct {
 	KeyspaceMetadata cc.KeyspaceMetadataInterface
+	Client           cc.CassandraClusterInterface
 	SourceProfile    profiles.SourceProfile
 	TargetProfile    profiles.TargetProfile
 }
@@ -146,28 +153,92 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 		pkCols[ckCol.Name] = true
 	}
 
+	colTypes := make(map[string]string)
+	var types []string
 	for _, colMeta := range tableMetadata.Columns {
-		colId := internal.GenerateColumnId()
-		isPrimaryKey := pkCols[colMeta.Name]
-
-		colType, err := getTypeString(colMeta.Type)
+		colType, err := getColumnType(colMeta)
 		if err != nil {
 			return nil, nil, err
 		}
+		colTypes[colMeta.Name] = colType
+		types = append(types, colType)
+	}
+	userTypes := isi.recordUserTypes(conv, types)
 
+	for _, colMeta := range tableMetadata.Columns {
 		c := schema.Column{
-			Id:      colId,
 			Name:    colMeta.Name,
-			Type:    schema.Type{Name: colType},
-			NotNull: isPrimaryKey,
+			Type:    schema.Type{Name: colTypes[colMeta.Name]},
+			NotNull: pkCols[colMeta.Name],
 			Ignored: schema.Ignored{},
 		}
-		colDefs[colId] = c
-		colIds = append(colIds, colId)
+		// Columns of flattened user-defined types are replaced by the
+		// columns of their fields.
+		for _, c := range flattenColumn(userTypes, c) {
+			c.Id = internal.GenerateColumnId()
+			colDefs[c.Id] = c
+			colIds = append(colIds, c.Id)
+		}
+	}
+	if isi.SourceProfile.Conn.Cassandra.CounterCommitTimestamp && hasCounters(tableMetadata) {
+		c := schema.Column{
+			Name:            commitTimestampColName(colDefs),
+			Type:            schema.Type{Name: "timestamp"},
+			Id:              internal.GenerateColumnId(),
+			CommitTimestamp: true,
+		}
+		colDefs[c.Id] = c
+		colIds = append(colIds, c.Id)
 	}
 	return colDefs, colIds, nil
 }
 
+// hasCounters reports whether a table has counter columns.
+func hasCounters(tableMetadata *gocql.TableMetadata) bool {
+	for _, colMeta := range tableMetadata.Columns {
+		if colMeta.Type != nil && colMeta.Type.Type() == gocql.TypeCounter {
+			return true
+		}
+	}
+	return false
+}
+
+// commitTimestampColName returns a name for the commit timestamp column
+// added to reconcile the counters of a table, which isn't the name of one of
+// its columns colDefs.
+func commitTimestampColName(colDefs map[string]schema.Column) string {
+	names := make(map[string]bool)
+	for _, c := range colDefs {
+		names[c.Name] = true
+	}
+	name := counterCommitTimestampColumn
+	for i := 0; names[name]; i++ {
+		name = fmt.Sprintf("%s%d", counterCommitTimestampColumn, i)
+	}
+	return name
+}
+
+// getColumnType returns the CQL type of a column. gocql parses user-defined
+// types as custom types, losing their names, so the type of columns using
+// them is the CQL type of the schema tables, e.g. frozen<address>.
+func getColumnType(colMeta *gocql.ColumnMetadata) (string, error) {
+	if hasCustomType(colMeta.Type) && colMeta.Validator != "" {
+		return colMeta.Validator, nil
+	}
+	return getTypeString(colMeta.Type)
+}
+
+// hasCustomType reports whether a type is or holds a custom type.
+func hasCustomType(typeInfo gocql.TypeInfo) bool {
+	if typeInfo == nil {
+		return false
+	}
+	if collectionInfo, ok := typeInfo.(gocql.CollectionType); ok {
+		return hasCustomType(collectionInfo.Key) || hasCustomType(collectionInfo.Elem)
+	}
+	return typeInfo.Type() == gocql.TypeCustom
+}
+
 // GetConstraints returns a list of primary keys for a given table.
 // Cassandra does not have check constraints and other constraints in the SQL sense.
 func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
@@ -180,14 +251,22 @@ func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.Schem
 		return nil, nil, nil, fmt.Errorf("table '%s' not found in keyspace metadata", table.Name)
 	}
 
-	var primaryKeys []string
-
-	for _, colMeta := range tableMetadata.PartitionKey {
-		primaryKeys = append(primaryKeys, colMeta.Name)
+	var pkCols []*gocql.ColumnMetadata
+	pkCols = append(pkCols, tableMetadata.PartitionKey...)
+	pkCols = append(pkCols, tableMetadata.ClusteringColumns...)
+	var types []string
+	for _, colMeta := range pkCols {
+		types = append(types, colMeta.Validator)
 	}
+	userTypes := isi.recordUserTypes(conv, types)
 
-	for _, colMeta := range tableMetadata.ClusteringColumns {
-		primaryKeys = append(primaryKeys, colMeta.Name)
+	var primaryKeys []string
+	for _, colMeta := range pkCols {
+		// Columns of flattened user-defined types are replaced by the
+		// columns of their fields, in the primary key too.
+		for _, c := range flattenColumn(userTypes, schema.Column{Name: colMeta.Name, Type: schema.Type{Name: colMeta.Validator}}) {
+			primaryKeys = append(primaryKeys, c.Name)
+		}
 	}
 
 	return primaryKeys, nil, make(map[string][]string), nil
@@ -216,13 +295,19 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 			indexMeta := colMeta.Index
 			targetColumn := colMeta.Name
 
+			colId, ok := colNameIdMap[targetColumn]
+			if !ok {
+				// Columns of flattened user-defined types are replaced by
+				// the columns of their fields.
+				continue
+			}
 			spIndex := schema.Index{
 				Id:     internal.GenerateIndexesId(),
 				Name:   indexMeta.Name,
 				Unique: false,
 				Keys: []schema.Key{
 					{
-						ColId: colNameIdMap[targetColumn],
+						ColId: colId,
 					},
 				},
 			}
@@ -233,20 +318,80 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 	return indexes, nil
 }
 
-// Data Migration Related Methods (Stubs for Schema Migration)
-
 var errNotSupported = fmt.Errorf("operation not supported")
 
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
 	return nil, errNotSupported
 }
 
+// GetRowCount returns the number of rows of a table. Counting requires a
+// full scan of the table by Cassandra.
 func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
-	return 0, errNotSupported
+	if isi.Client == nil {
+		return 0, fmt.Errorf("cassandra client not initialized")
+	}
+	iter := isi.Client.Query(fmt.Sprintf("SELECT COUNT(*) FROM %s.%s", quoteIdent(isi.SourceProfile.Conn.Cassandra.Keyspace), quoteIdent(table.Name)))
+	row, ok := iter.Next()
+	if err := iter.Close(); err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("no row count returned for table %s", table.Name)
+	}
+	count, ok := row["count"].(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected row count %v for table %s", row["count"], table.Name)
+	}
+	return count, nil
+}
+
+// ProcessData performs data conversion for a Cassandra table, reading the
+// columns commonColIds of all its rows.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	if isi.Client == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+	if len(commonColIds) == 0 {
+		return nil
+	}
+	var cols []string
+	selected := make(map[string]bool)
+	for _, colId := range commonColIds {
+		if srcSchema.ColDefs[colId].CommitTimestamp {
+			// Commit timestamps are written by Spanner.
+			continue
+		}
+		name := srcSchema.ColDefs[colId].Name
+		if field := srcSchema.ColDefs[colId].UserTypeField; field != nil {
+			// The fields of flattened columns are read from the columns.
+			name = field.Column
+		}
+		if !selected[name] {
+			selected[name] = true
+			cols = append(cols, quoteIdent(name))
+		}
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+	iter := isi.Client.Query(fmt.Sprintf("SELECT %s FROM %s.%s", strings.Join(cols, ", "), quoteIdent(isi.SourceProfile.Conn.Cassandra.Keyspace), quoteIdent(srcSchema.Name)))
+	for {
+		row, ok := iter.Next()
+		if !ok {
+			break
+		}
+		ProcessDataRow(conv, commonColIds, srcSchema, spSchema, row)
+	}
+	if err := iter.Close(); err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't read rows of table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	return nil
 }
 
-func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, spCols []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
-	return errNotSupported
+// quoteIdent quotes a CQL identifier, so that its case is kept.
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
 }
 
 func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
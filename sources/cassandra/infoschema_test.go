@@ -309,6 +309,140 @@ func TestGetColumns(t *testing.T) {
 	mockKeyspace.AssertExpectations(t)
 }
 
+func TestGetColumnsUserTypes(t *testing.T) {
+	custom := gocql.NewNativeType(0, gocql.TypeCustom, "")
+	idCol := &gocql.ColumnMetadata{Name: "id", Type: gocql.NewNativeType(0, gocql.TypeInt, ""), Validator: "int"}
+	homeCol := &gocql.ColumnMetadata{Name: "home", Type: custom, Validator: "frozen<address>"}
+	phonesCol := &gocql.ColumnMetadata{
+		Name:      "phones",
+		Type:      gocql.CollectionType{NativeType: gocql.NewNativeType(0, gocql.TypeList, ""), Elem: custom},
+		Validator: "list<frozen<phone>>",
+	}
+	mockKeyspace := &cc.MockKeyspaceMetadata{
+		MockUserTypes: map[string]cc.UserType{
+			"address": {Name: "address", FieldNames: []string{"city", "geo"}, FieldTypes: []string{"text", "frozen<point>"}},
+			"point":   {Name: "point", FieldNames: []string{"lat", "lon"}, FieldTypes: []string{"double", "double"}},
+			"phone":   {Name: "phone", FieldNames: []string{"number"}, FieldTypes: []string{"text"}},
+			"unused":  {Name: "unused", FieldNames: []string{"x"}, FieldTypes: []string{"int"}},
+		},
+	}
+	mockKeyspace.On("Tables").Return(map[string]*gocql.TableMetadata{
+		"users": {
+			Name:         "users",
+			PartitionKey: []*gocql.ColumnMetadata{idCol},
+			Columns:      map[string]*gocql.ColumnMetadata{"id": idCol, "home": homeCol, "phones": phonesCol},
+		},
+	})
+	isi := InfoSchemaImpl{
+		KeyspaceMetadata: mockKeyspace,
+		SourceProfile: profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Cassandra: profiles.SourceProfileConnectionCassandra{
+			UserTypeStrategies: map[string]string{"address": FlattenStrategy},
+		}}},
+	}
+	conv := internal.MakeConv()
+	colDefs, colIds, err := isi.GetColumns(conv, common.SchemaAndName{Name: "users"}, nil, nil)
+	assert.NoError(t, err)
+
+	var cols []schema.Column
+	for _, colId := range colIds {
+		col := colDefs[colId]
+		assert.Equal(t, colId, col.Id)
+		col.Id = ""
+		cols = append(cols, col)
+	}
+	sort.Slice(cols, func(i, j int) bool { return cols[i].Name < cols[j].Name })
+	assert.Equal(t, []schema.Column{
+		{Name: "home_city", Type: schema.Type{Name: "text"}, UserTypeField: &schema.UserTypeField{Column: "home", ColumnType: "frozen<address>", Path: []string{"city"}}},
+		{Name: "home_geo", Type: schema.Type{Name: "frozen<point>"}, UserTypeField: &schema.UserTypeField{Column: "home", ColumnType: "frozen<address>", Path: []string{"geo"}}},
+		{Name: "id", Type: schema.Type{Name: "int"}, NotNull: true},
+		{Name: "phones", Type: schema.Type{Name: "list<frozen<phone>>"}},
+	}, cols)
+	assert.Equal(t, map[string]internal.UserDefinedType{
+		"address": {Name: "address", FieldNames: []string{"city", "geo"}, FieldTypes: []string{"text", "frozen<point>"}, Flatten: true},
+		"point":   {Name: "point", FieldNames: []string{"lat", "lon"}, FieldTypes: []string{"double", "double"}},
+		"phone":   {Name: "phone", FieldNames: []string{"number"}, FieldTypes: []string{"text"}},
+	}, conv.UserDefinedTypes)
+}
+
+func TestGetColumnsCounters(t *testing.T) {
+	idCol := &gocql.ColumnMetadata{Name: "id", Type: gocql.NewNativeType(0, gocql.TypeInt, "")}
+	likesCol := &gocql.ColumnMetadata{Name: "likes", Type: gocql.NewNativeType(0, gocql.TypeCounter, "")}
+	tsCol := &gocql.ColumnMetadata{Name: "counter_commit_ts", Type: gocql.NewNativeType(0, gocql.TypeCounter, "")}
+	mockKeyspace := &cc.MockKeyspaceMetadata{}
+	mockKeyspace.On("Tables").Return(map[string]*gocql.TableMetadata{
+		"posts": {
+			Name:         "posts",
+			PartitionKey: []*gocql.ColumnMetadata{idCol},
+			Columns:      map[string]*gocql.ColumnMetadata{"id": idCol, "likes": likesCol, "counter_commit_ts": tsCol},
+		},
+		"users": {
+			Name:         "users",
+			PartitionKey: []*gocql.ColumnMetadata{idCol},
+			Columns:      map[string]*gocql.ColumnMetadata{"id": idCol},
+		},
+	})
+	getColumns := func(counterCommitTimestamp bool, table string) []schema.Column {
+		isi := InfoSchemaImpl{
+			KeyspaceMetadata: mockKeyspace,
+			SourceProfile: profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Cassandra: profiles.SourceProfileConnectionCassandra{
+				CounterCommitTimestamp: counterCommitTimestamp,
+			}}},
+		}
+		colDefs, colIds, err := isi.GetColumns(internal.MakeConv(), common.SchemaAndName{Name: table}, nil, nil)
+		assert.NoError(t, err)
+		var cols []schema.Column
+		for _, colId := range colIds {
+			col := colDefs[colId]
+			col.Id = ""
+			cols = append(cols, col)
+		}
+		sort.Slice(cols, func(i, j int) bool { return cols[i].Name < cols[j].Name })
+		return cols
+	}
+
+	assert.Equal(t, []schema.Column{
+		{Name: "counter_commit_ts", Type: schema.Type{Name: "counter"}},
+		{Name: "counter_commit_ts0", Type: schema.Type{Name: "timestamp"}, CommitTimestamp: true},
+		{Name: "id", Type: schema.Type{Name: "int"}, NotNull: true},
+		{Name: "likes", Type: schema.Type{Name: "counter"}},
+	}, getColumns(true, "posts"))
+	// The column is only added to tables with counters, when asked for.
+	assert.Equal(t, 3, len(getColumns(false, "posts")))
+	assert.Equal(t, []schema.Column{{Name: "id", Type: schema.Type{Name: "int"}, NotNull: true}}, getColumns(true, "users"))
+}
+
+func TestGetConstraintsUserTypes(t *testing.T) {
+	idCol := &gocql.ColumnMetadata{Name: "id", Validator: "int"}
+	homeCol := &gocql.ColumnMetadata{Name: "home", Validator: "frozen<address>"}
+	mockKeyspace := &cc.MockKeyspaceMetadata{
+		MockUserTypes: map[string]cc.UserType{
+			"address": {Name: "address", FieldNames: []string{"city", "zip"}, FieldTypes: []string{"text", "int"}},
+		},
+	}
+	mockKeyspace.On("Tables").Return(map[string]*gocql.TableMetadata{
+		"users": {
+			Name:              "users",
+			PartitionKey:      []*gocql.ColumnMetadata{idCol},
+			ClusteringColumns: []*gocql.ColumnMetadata{homeCol},
+		},
+	})
+	for _, tc := range []struct {
+		strategy string
+		want     []string
+	}{
+		{strategy: JSONStrategy, want: []string{"id", "home"}},
+		{strategy: FlattenStrategy, want: []string{"id", "home_city", "home_zip"}},
+	} {
+		isi := InfoSchemaImpl{
+			KeyspaceMetadata: mockKeyspace,
+			SourceProfile:    profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Cassandra: profiles.SourceProfileConnectionCassandra{UserTypeStrategy: tc.strategy}}},
+		}
+		pks, _, _, err := isi.GetConstraints(internal.MakeConv(), common.SchemaAndName{Name: "users"})
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, pks, tc.strategy)
+	}
+}
+
 func TestGetConstraints(t *testing.T) {
 	mockKeyspace := &cc.MockKeyspaceMetadata{}
 	tables := map[string]*gocql.TableMetadata{
@@ -403,11 +537,12 @@ func TestDataMigrationStubs(t *testing.T) {
 	_, err := isi.GetRowsFromTable(conv, "table1")
 	assert.ErrorIs(t, err, errNotSupported)
 
+	// Without a client, rows can't be counted or read.
 	_, err = isi.GetRowCount(common.SchemaAndName{Name: "table1"})
-	assert.ErrorIs(t, err, errNotSupported)
+	assert.Error(t, err)
 
 	err = isi.ProcessData(conv, "table1", schema.Table{}, nil, ddl.CreateTable{}, internal.AdditionalDataAttributes{})
-	assert.ErrorIs(t, err, errNotSupported)
+	assert.Error(t, err)
 
 	_, err = isi.StartChangeDataCapture(ctx, conv)
 	assert.ErrorIs(t, err, errNotSupported)
@@ -17,23 +17,23 @@ package cassandra
 
 import (
 	"strings"
-	"regexp"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
 
-var mapRegex     = regexp.MustCompile(`^MAP<([^,]+)\s*,\s*([^>]+)>$`)
-var listSetRegex = regexp.MustCompile(`^(LIST|SET)<([^>]+)>$`)
-
 // ToDdlImpl Cassandra specific implementation for the ToDdl.
 type ToDdlImpl struct {
 	typeMapper CassandraMappingProvider
 }
 
 func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType schema.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
-	return tdi.typeMapper.GetSpannerType(srcType.Name, spType)
+	typeMapper := tdi.typeMapper
+	if mapper, ok := typeMapper.(*CassandraTypeMapper); ok && conv != nil && len(conv.UserDefinedTypes) > 0 {
+		typeMapper = mapper.withUserTypes(conv.UserDefinedTypes)
+	}
+	return typeMapper.GetSpannerType(srcType.Name, spType)
 }
 
 func (tdi ToDdlImpl) GetColumnAutoGen(conv *internal.Conv, autoGenCol ddl.AutoGenCol, colId string, tableId string) (*ddl.AutoGenCol, error) {
@@ -306,11 +306,12 @@ var typeMappings = map[string][]CassandraDdlInfo{
 		},
 	},
 	"COUNTER": {
-		// TODO: Generate appropriate SchemaIssue to warn about adapter not supporting counter
+		// Spanner has no counter type, so counters are migrated as a
+		// snapshot of their values.
 		{
 			SpannerType:         ddl.Type{Name: ddl.Int64},
 			CassandraTypeOption: "counter",
-			Issues:              nil,
+			Issues:              []internal.SchemaIssue{internal.CounterSnapshot},
 		},
 	},
 }
@@ -322,19 +323,42 @@ type CassandraMappingProvider interface {
 }
 
 // CassandraTypeMapper implements CassandraMappingProvider.
-type CassandraTypeMapper struct{}
+type CassandraTypeMapper struct {
+	userTypes map[string]bool // Upper case names of the user-defined types mapped to JSON.
+}
 
 func NewCassandraTypeMapper() *CassandraTypeMapper {
 	return &CassandraTypeMapper{}
 }
 
+// withUserTypes returns a mapper which also maps the user-defined types
+// userTypes to JSON.
+func (m *CassandraTypeMapper) withUserTypes(userTypes map[string]internal.UserDefinedType) *CassandraTypeMapper {
+	names := make(map[string]bool, len(userTypes))
+	for name := range userTypes {
+		names[strings.ToUpper(name)] = true
+	}
+	return &CassandraTypeMapper{userTypes: names}
+}
+
+// isUserType reports whether the upper case type s is a user-defined type
+// known to the mapper.
+func (m *CassandraTypeMapper) isUserType(s string) bool {
+	return m.userTypes[strings.Trim(unfrozen(s), `"`)]
+}
+
 // getMapping retrieves a Spanner DDL mapping rule for a given Cassandra type and Spanner Type(if non-default).
 // For collection types, 'spTypeName' refers to the element type of the array.
 // For example, when converting a Cassandra 'list<int>' to Spanner 'ARRAY<INT64>',
 // 'spTypeName' would be 'INT64'. This allows users to specify or modify the Spanner
 // data type of the elements within a list or set.
+//
+// Lists and sets of primitive types are mapped to arrays, while maps,
+// user-defined types and collections of collections or user-defined types,
+// which Spanner arrays can't hold, are mapped to JSON. Lists and sets are
+// also mapped to JSON if 'spTypeName' is JSON.
 func (m *CassandraTypeMapper) getMapping(cassandraTypeName string, spTypeName string) (CassandraDdlInfo, bool) {
-	s := strings.ToUpper(strings.ReplaceAll(cassandraTypeName, " ", ""))
+	s := unfrozen(strings.ToUpper(strings.ReplaceAll(cassandraTypeName, " ", "")))
 	if mappings, ok := typeMappings[s]; ok && len(mappings) > 0 {
 		if spTypeName != "" {
 			for _, mapping := range mappings {
@@ -345,74 +369,121 @@ func (m *CassandraTypeMapper) getMapping(cassandraTypeName string, spTypeName st
 		}
 		return mappings[0], true
 	}
-    // TODO: Generate appropriate SchemaIssue to warn about conversion from map to JSON
-    // Handles map collection type
-    if mapMatch := mapRegex.FindStringSubmatch(s); len(mapMatch) > 0 {
-		KeyTypeName := strings.TrimSpace(mapMatch[1])
-		ValueTypeName := strings.TrimSpace(mapMatch[2])
-
-        var KeyTypeOption string
-        var ValueTypeOption string
-        var hasIssue bool
-
-        keyMapping, foundKey := m.getMapping(KeyTypeName, spTypeName)
-        if !foundKey {
-            KeyTypeOption = "text"
-            hasIssue = true
-        } else {
-            KeyTypeOption = keyMapping.CassandraTypeOption
-            for _, keyIssue := range keyMapping.Issues {
-                if keyIssue == internal.NoGoodType {
-                    hasIssue = true
-                }
-            }
-        }
-
-        valueMapping, foundValue := m.getMapping(ValueTypeName, spTypeName)
-        if !foundValue {
-            ValueTypeOption = "text"
-            hasIssue = true
-        } else {
-            ValueTypeOption = valueMapping.CassandraTypeOption
-            for _, valueIssue := range valueMapping.Issues {
-                if valueIssue == internal.NoGoodType {
-                    hasIssue = true
-                }
-            }
-        }
-
-        newCassandraTypeOption := "map<" + KeyTypeOption + "," + ValueTypeOption + ">"
+	if m.isUserType(s) {
+		return CassandraDdlInfo{
+			SpannerType:         ddl.Type{Name: ddl.JSON},
+			CassandraTypeOption: "text",
+			Issues:              []internal.SchemaIssue{internal.UserTypeToJSON},
+		}, true
+	}
+	kind, elemTypes, ok := splitCollection(s)
+	if !ok {
+		return CassandraDdlInfo{}, false
+	}
+	switch {
+	case kind == "MAP" && len(elemTypes) == 2:
+		return m.getJSONMapping(kind, elemTypes), true
+	case (kind == "LIST" || kind == "SET") && len(elemTypes) == 1:
+		if _, _, nested := splitCollection(unfrozen(elemTypes[0])); nested || m.isUserType(elemTypes[0]) || spTypeName == ddl.JSON {
+			return m.getJSONMapping(kind, elemTypes), true
+		}
+		mapping, ok := m.getMapping(elemTypes[0], spTypeName)
+		if !ok {
+			return CassandraDdlInfo{}, false
+		}
+		mapping.SpannerType.IsArray = true
+		mapping.CassandraTypeOption = strings.ToLower(kind) + "<" + mapping.CassandraTypeOption + ">"
+		if kind == "SET" {
+			// Copied, so that the issues of typeMappings aren't modified.
+			mapping.Issues = append(append([]internal.SchemaIssue{}, mapping.Issues...), internal.SetToArray)
+		}
+		return mapping, true
+	}
+	return CassandraDdlInfo{}, false
+}
 
-        issues := []internal.SchemaIssue{}
-        if hasIssue {
-            issues = append(issues, internal.NoGoodType)
-        }
-        
-        return CassandraDdlInfo{
-            SpannerType:         ddl.Type{Name: ddl.JSON},
-            CassandraTypeOption: newCassandraTypeOption,
-            Issues:              issues,
-        }, true
-    }
-    // Handles list and set collection type
-    if listSetMatch := listSetRegex.FindStringSubmatch(s); len(listSetMatch) > 0 {
-		collectionPrefix := listSetMatch[1]
-		innerCassandraTypeName := strings.TrimSpace(listSetMatch[2])
+// getJSONMapping returns the mapping of a collection of kind 'kind' (LIST,
+// SET or MAP) with key and element types 'elemTypes' to JSON. Key and
+// element types without a mapping are reported as NoGoodType, and
+// user-defined types as UserTypeToJSON.
+func (m *CassandraTypeMapper) getJSONMapping(kind string, elemTypes []string) CassandraDdlInfo {
+	var options []string
+	noGoodType, userType := false, false
+	for _, elemType := range elemTypes {
+		mapping, ok := m.getMapping(elemType, "")
+		if !ok {
+			options = append(options, "text")
+			noGoodType = true
+			continue
+		}
+		options = append(options, mapping.CassandraTypeOption)
+		for _, issue := range mapping.Issues {
+			switch issue {
+			case internal.NoGoodType:
+				noGoodType = true
+			case internal.UserTypeToJSON:
+				userType = true
+			}
+		}
+	}
+	issues := []internal.SchemaIssue{internal.CollectionToJSON}
+	if userType {
+		issues = append(issues, internal.UserTypeToJSON)
+	}
+	if noGoodType {
+		issues = append(issues, internal.NoGoodType)
+	}
+	return CassandraDdlInfo{
+		SpannerType:         ddl.Type{Name: ddl.JSON},
+		CassandraTypeOption: strings.ToLower(kind) + "<" + strings.Join(options, ",") + ">",
+		Issues:              issues,
+	}
+}
 
-        var newCassandraTypeOption string
+// unfrozen strips the frozen<...> of frozen types, which are stored the
+// same as the types they freeze.
+func unfrozen(s string) string {
+	for strings.HasPrefix(s, "FROZEN<") && strings.HasSuffix(s, ">") {
+		s = strings.TrimSuffix(strings.TrimPrefix(s, "FROZEN<"), ">")
+	}
+	return s
+}
 
-        if mapping, ok := m.getMapping(innerCassandraTypeName, spTypeName); ok {
-            mapping.SpannerType.IsArray = true
-            if collectionPrefix == "LIST" { 
-                newCassandraTypeOption = "list<" + mapping.CassandraTypeOption + ">"
-            } else {
-                newCassandraTypeOption = "set<" + mapping.CassandraTypeOption + ">"
-            }
-            mapping.CassandraTypeOption = newCassandraTypeOption
-            return mapping, true
-        }
-    }
-    return CassandraDdlInfo{}, false
+// splitCollection splits an upper case collection type such as
+// MAP<TEXT,LIST<INT>> into its kind, e.g. MAP, and the types of its keys and
+// elements, e.g. TEXT and LIST<INT>. It returns false if s isn't a list, set
+// or map type.
+func splitCollection(s string) (string, []string, bool) {
+	open := strings.Index(s, "<")
+	if open < 0 || !strings.HasSuffix(s, ">") {
+		return "", nil, false
+	}
+	kind := s[:open]
+	if kind != "LIST" && kind != "SET" && kind != "MAP" {
+		return "", nil, false
+	}
+	var elemTypes []string
+	depth, start := 0, open+1
+	for i := open + 1; i < len(s)-1; i++ {
+		switch s[i] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth < 0 {
+				return "", nil, false
+			}
+		case ',':
+			if depth == 0 {
+				elemTypes = append(elemTypes, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return "", nil, false
+	}
+	return kind, append(elemTypes, s[start:len(s)-1]), true
 }
 
 // GetSpannerType finds the correct mapping for the Spanner type and issues
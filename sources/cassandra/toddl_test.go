@@ -377,6 +377,7 @@ func TestCassandraTypeMapper(t *testing.T) {
 			cassandraType:       "counter",
 			expectedSpannerType: ddl.Type{Name: ddl.Int64},
 			expectedOption:      "counter",
+			expectedIssues:      []internal.SchemaIssue{internal.CounterSnapshot},
 		},
 		{
 			name:                "List Type",
@@ -389,38 +390,67 @@ func TestCassandraTypeMapper(t *testing.T) {
 			cassandraType:       "set<tinyint>",
 			expectedSpannerType: ddl.Type{Name: ddl.Int64, IsArray: true},
 			expectedOption:      "set<tinyint>",
-			expectedIssues:      []internal.SchemaIssue{internal.Widened},
+			expectedIssues:      []internal.SchemaIssue{internal.Widened, internal.SetToArray},
 		},
 		{
 			name:                "Map Type",
 			cassandraType:       "map<text,int>",
 			expectedSpannerType: ddl.Type{Name: ddl.JSON},
 			expectedOption:      "map<text,int>",
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON},
 		},
 		{
 			name:                "Unsupported types in map",
 			cassandraType:       "map<udt, duration>",
 			expectedSpannerType: ddl.Type{Name: ddl.JSON},
 			expectedOption:      "map<text,text>",
-			expectedIssues:      []internal.SchemaIssue{internal.NoGoodType},
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON, internal.NoGoodType},
 		},
 		{
 			name:                "Unsupported types in map",
 			cassandraType:       "map<duration, udt>",
 			expectedSpannerType: ddl.Type{Name: ddl.JSON},
 			expectedOption:      "map<text,text>",
-			expectedIssues:      []internal.SchemaIssue{internal.NoGoodType},
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON, internal.NoGoodType},
+		},
+		{
+			name:                "Override list to JSON",
+			cassandraType:       "list<int>",
+			userSpannerType:     ddl.JSON,
+			expectedSpannerType: ddl.Type{Name: ddl.JSON},
+			expectedOption:      "list<int>",
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON},
 		},
 		{
-			name:                "Fallback Nested List",
+			name:                "Frozen List",
+			cassandraType:       "frozen<list<bigint>>",
+			expectedSpannerType: ddl.Type{Name: ddl.Int64, IsArray: true},
+			expectedOption:      "list<bigint>",
+		},
+		{
+			name:                "Nested List",
 			cassandraType:       "list<map<map<int,text>,list<int>>>",
-			expectedSpannerType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
-			expectedOption:      "text",
-			expectedIssues:      []internal.SchemaIssue{internal.NoGoodType},
+			expectedSpannerType: ddl.Type{Name: ddl.JSON},
+			expectedOption:      "list<map<map<int,text>,list<int>>>",
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON},
 		},
 		{
-			name:                "Fallback Nested Map",
+			name:                "Nested Map",
 			cassandraType:       "map<list<text>,map<text,text>>",
+			expectedSpannerType: ddl.Type{Name: ddl.JSON},
+			expectedOption:      "map<list<text>,map<text,text>>",
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON},
+		},
+		{
+			name:                "Set of frozen sets",
+			cassandraType:       "set<frozen<set<uuid>>>",
+			expectedSpannerType: ddl.Type{Name: ddl.JSON},
+			expectedOption:      "set<set<uuid>>",
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON},
+		},
+		{
+			name:                "Fallback list of unknown type",
+			cassandraType:       "list<some_udt>",
 			expectedSpannerType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
 			expectedOption:      "text",
 			expectedIssues:      []internal.SchemaIssue{internal.NoGoodType},
@@ -447,3 +477,51 @@ func TestCassandraTypeMapper(t *testing.T) {
 		})
 	}
 }
+
+func TestToSpannerTypeUserTypes(t *testing.T) {
+	conv := internal.MakeConv()
+	internal.SetUserDefinedType(conv, internal.UserDefinedType{Name: "address", FieldNames: []string{"city"}, FieldTypes: []string{"text"}})
+	tdi := InfoSchemaImpl{}.GetToDdl()
+	testCases := []struct {
+		name                string
+		cassandraType       string
+		expectedSpannerType ddl.Type
+		expectedIssues      []internal.SchemaIssue
+	}{
+		{
+			name:                "User type",
+			cassandraType:       "address",
+			expectedSpannerType: ddl.Type{Name: ddl.JSON},
+			expectedIssues:      []internal.SchemaIssue{internal.UserTypeToJSON},
+		},
+		{
+			name:                "Frozen user type",
+			cassandraType:       "frozen<address>",
+			expectedSpannerType: ddl.Type{Name: ddl.JSON},
+			expectedIssues:      []internal.SchemaIssue{internal.UserTypeToJSON},
+		},
+		{
+			name:                "List of user types",
+			cassandraType:       "list<frozen<address>>",
+			expectedSpannerType: ddl.Type{Name: ddl.JSON},
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON, internal.UserTypeToJSON},
+		},
+		{
+			name:                "Map of user types",
+			cassandraType:       "map<text, frozen<address>>",
+			expectedSpannerType: ddl.Type{Name: ddl.JSON},
+			expectedIssues:      []internal.SchemaIssue{internal.CollectionToJSON, internal.UserTypeToJSON},
+		},
+		{
+			name:                "Unknown type",
+			cassandraType:       "frozen<phone>",
+			expectedSpannerType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
+			expectedIssues:      []internal.SchemaIssue{internal.NoGoodType},
+		},
+	}
+	for _, tc := range testCases {
+		spType, issues := tdi.ToSpannerType(conv, "", schema.Type{Name: tc.cassandraType}, false)
+		assert.Equal(t, tc.expectedSpannerType, spType, tc.name)
+		assert.Equal(t, tc.expectedIssues, issues, tc.name)
+	}
+}
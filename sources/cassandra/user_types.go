@@ -0,0 +1,352 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// Columns of user-defined types are mapped using one of the strategies
+// below, selected per type.
+const (
+	// JSONStrategy maps each column of a user-defined type to a JSON
+	// column, holding its values as objects keyed by field name.
+	JSONStrategy = "json"
+	// FlattenStrategy maps each column of a user-defined type to a column
+	// per field, named after the column and the field e.g. home_city.
+	FlattenStrategy = "flatten"
+)
+
+// ValidateUserTypeStrategies checks that the strategies of user-defined
+// types e.g. from the source profile are known ones.
+func ValidateUserTypeStrategies(strategy string, userTypeStrategies map[string]string) error {
+	if strategy != "" && strategy != JSONStrategy && strategy != FlattenStrategy {
+		return fmt.Errorf("invalid user type strategy %s, expected one of %s, %s", strategy, JSONStrategy, FlattenStrategy)
+	}
+	var userTypes []string
+	for userType := range userTypeStrategies {
+		userTypes = append(userTypes, userType)
+	}
+	sort.Strings(userTypes)
+	for _, userType := range userTypes {
+		if s := userTypeStrategies[userType]; s != JSONStrategy && s != FlattenStrategy {
+			return fmt.Errorf("invalid strategy %s for user type %s, expected one of %s, %s", s, userType, JSONStrategy, FlattenStrategy)
+		}
+	}
+	return nil
+}
+
+// getUserTypeStrategy returns the strategy of the user-defined type name
+// selected in the source profile, json by default.
+func (isi InfoSchemaImpl) getUserTypeStrategy(name string) string {
+	cs := isi.SourceProfile.Conn.Cassandra
+	if strategy, ok := cs.UserTypeStrategies[name]; ok {
+		return strategy
+	}
+	if cs.UserTypeStrategy != "" {
+		return cs.UserTypeStrategy
+	}
+	return JSONStrategy
+}
+
+// recordUserTypes records the user-defined types used by the CQL types
+// colTypes, and the types nested in them, in conv.UserDefinedTypes the
+// first time they are seen. It returns a copy of conv.UserDefinedTypes.
+func (isi InfoSchemaImpl) recordUserTypes(conv *internal.Conv, colTypes []string) map[string]internal.UserDefinedType {
+	if conv == nil || isi.KeyspaceMetadata == nil {
+		return nil
+	}
+	keyspaceTypes := isi.KeyspaceMetadata.UserTypes()
+	if len(keyspaceTypes) == 0 {
+		return nil
+	}
+	conv.ConvLock.Lock()
+	defer conv.ConvLock.Unlock()
+	var record func(cqlType string)
+	record = func(cqlType string) {
+		for _, name := range typeNames(cqlType) {
+			t, ok := keyspaceTypes[name]
+			if !ok {
+				continue
+			}
+			if _, seen := conv.UserDefinedTypes[name]; seen {
+				continue
+			}
+			internal.SetUserDefinedType(conv, internal.UserDefinedType{
+				Name:       t.Name,
+				FieldNames: t.FieldNames,
+				FieldTypes: t.FieldTypes,
+				Flatten:    isi.getUserTypeStrategy(name) == FlattenStrategy,
+			})
+			// Cassandra doesn't allow user-defined types to use themselves,
+			// so this terminates.
+			for _, fieldType := range t.FieldTypes {
+				record(fieldType)
+			}
+		}
+	}
+	for _, colType := range colTypes {
+		record(colType)
+	}
+	userTypes := make(map[string]internal.UserDefinedType, len(conv.UserDefinedTypes))
+	for name, t := range conv.UserDefinedTypes {
+		userTypes[name] = t
+	}
+	return userTypes
+}
+
+// typeNames returns the names of the types making up a CQL type e.g.
+// frozen, list and address for frozen<list<address>>.
+func typeNames(cqlType string) []string {
+	return strings.FieldsFunc(cqlType, func(r rune) bool {
+		return r == '<' || r == '>' || r == ',' || r == ' ' || r == '"'
+	})
+}
+
+// findUserType returns the user-defined type of a column of CQL type
+// cqlType, which may be frozen, if it is one.
+func findUserType(userTypes map[string]internal.UserDefinedType, cqlType string) (internal.UserDefinedType, bool) {
+	if len(userTypes) == 0 {
+		return internal.UserDefinedType{}, false
+	}
+	s := strings.ReplaceAll(cqlType, " ", "")
+	for len(s) > len("frozen<") && strings.EqualFold(s[:len("frozen<")], "frozen<") && strings.HasSuffix(s, ">") {
+		s = s[len("frozen<") : len(s)-1]
+	}
+	s = strings.Trim(s, `"`)
+	if t, ok := userTypes[s]; ok {
+		return t, true
+	}
+	// Types nested in collections are upper cased by splitCollection.
+	t, ok := userTypes[strings.ToLower(s)]
+	return t, ok
+}
+
+// usesUserType reports whether CQL type cqlType uses the user-defined type
+// name, directly or through the fields of the user-defined types it uses.
+func usesUserType(userTypes map[string]internal.UserDefinedType, cqlType string, name string) bool {
+	for _, n := range typeNames(cqlType) {
+		if n == name {
+			return true
+		}
+		if t, ok := userTypes[n]; ok {
+			for _, fieldType := range t.FieldTypes {
+				if usesUserType(userTypes, fieldType, name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// flattenColumn returns the columns a source column is mapped to: a column
+// per field if its type is a flattened user-defined type, with the fields
+// of flattened nested types flattened in turn, or else the column itself.
+// The columns of fields are named after the column and the fields e.g.
+// home_geo_lat, can be null and have no ids.
+func flattenColumn(userTypes map[string]internal.UserDefinedType, col schema.Column) []schema.Column {
+	t, ok := findUserType(userTypes, col.Type.Name)
+	if !ok || !t.Flatten {
+		return []schema.Column{col}
+	}
+	field := schema.UserTypeField{Column: col.Name, ColumnType: col.Type.Name}
+	if col.UserTypeField != nil {
+		field = *col.UserTypeField
+	}
+	var cols []schema.Column
+	for i, name := range t.FieldNames {
+		if i >= len(t.FieldTypes) {
+			break
+		}
+		f := field
+		f.Path = append(append([]string{}, field.Path...), name)
+		cols = append(cols, flattenColumn(userTypes, schema.Column{
+			Name:          col.Name + "_" + name,
+			Type:          schema.Type{Name: t.FieldTypes[i]},
+			UserTypeField: &f,
+		})...)
+	}
+	return cols
+}
+
+// SetUserTypeFlatten sets whether the columns of the user-defined type name
+// are flattened into a column per field or stored in JSON columns, and
+// converts the tables using the type to Spanner again. Changes made to the
+// Spanner schema of these tables are discarded.
+func SetUserTypeFlatten(conv *internal.Conv, name string, flatten bool, ddlVerifier expressions_api.DDLVerifier) error {
+	t, ok := conv.UserDefinedTypes[name]
+	if !ok {
+		return fmt.Errorf("user-defined type %s not found", name)
+	}
+	t.Flatten = flatten
+	conv.UserDefinedTypes[name] = t
+
+	var tableIds []string
+	for tableId := range conv.SrcSchema {
+		tableIds = append(tableIds, tableId)
+	}
+	sort.Strings(tableIds)
+	for _, tableId := range tableIds {
+		srcTable := unflattenTable(conv.SrcSchema[tableId])
+		used := false
+		for _, colId := range srcTable.ColIds {
+			if usesUserType(conv.UserDefinedTypes, srcTable.ColDefs[colId].Type.Name, name) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			continue
+		}
+		srcTable = flattenTable(conv.UserDefinedTypes, srcTable)
+		conv.SrcSchema[tableId] = srcTable
+		sp, ok := conv.SpSchema[tableId]
+		if !ok {
+			// Dropped from the Spanner schema.
+			continue
+		}
+		// Drop the Spanner table, so that its names can be reused.
+		delete(conv.UsedNames, strings.ToLower(sp.Name))
+		for _, index := range sp.Indexes {
+			delete(conv.UsedNames, strings.ToLower(index.Name))
+		}
+		delete(conv.SpSchema, tableId)
+		delete(conv.SchemaIssues, tableId)
+		delete(conv.SyntheticPKeys, tableId)
+		delete(conv.UniquePKey, tableId)
+		if err := common.SrcTableToSpannerDDL(conv, InfoSchemaImpl{}.GetToDdl(), srcTable, ddlVerifier); err != nil {
+			return fmt.Errorf("couldn't convert table %s: %v", srcTable.Name, err)
+		}
+	}
+	return nil
+}
+
+// flattenTable replaces the columns of flattened user-defined types of a
+// source table by the columns of their fields.
+func flattenTable(userTypes map[string]internal.UserDefinedType, table schema.Table) schema.Table {
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	replaced := make(map[string][]string)
+	for _, colId := range table.ColIds {
+		col := table.ColDefs[colId]
+		if t, ok := findUserType(userTypes, col.Type.Name); !ok || !t.Flatten {
+			colDefs[colId] = col
+			colIds = append(colIds, colId)
+			continue
+		}
+		for _, c := range flattenColumn(userTypes, col) {
+			c.Id = internal.GenerateColumnId()
+			colDefs[c.Id] = c
+			colIds = append(colIds, c.Id)
+			replaced[colId] = append(replaced[colId], c.Id)
+		}
+	}
+	return replaceColumns(table, colIds, colDefs, replaced)
+}
+
+// unflattenTable replaces the columns holding the fields of flattened
+// columns of user-defined types of a source table by these columns, in the
+// position of their first field.
+func unflattenTable(table schema.Table) schema.Table {
+	pkColIds := make(map[string]bool)
+	for _, k := range table.PrimaryKeys {
+		pkColIds[k.ColId] = true
+	}
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	replaced := make(map[string][]string)
+	userTypeColIds := make(map[string]string)
+	for _, colId := range table.ColIds {
+		col := table.ColDefs[colId]
+		field := col.UserTypeField
+		if field == nil {
+			colDefs[colId] = col
+			colIds = append(colIds, colId)
+			continue
+		}
+		id, ok := userTypeColIds[field.Column]
+		if !ok {
+			id = internal.GenerateColumnId()
+			userTypeColIds[field.Column] = id
+			colDefs[id] = schema.Column{Id: id, Name: field.Column, Type: schema.Type{Name: field.ColumnType}}
+			colIds = append(colIds, id)
+		}
+		if pkColIds[colId] {
+			// Only primary key columns are NOT NULL in Cassandra.
+			c := colDefs[id]
+			c.NotNull = true
+			colDefs[id] = c
+		}
+		replaced[colId] = []string{id}
+	}
+	return replaceColumns(table, colIds, colDefs, replaced)
+}
+
+// replaceColumns sets the columns of a source table to colIds and colDefs,
+// where replaced maps the ids of the columns which were replaced to the
+// ids of the columns replacing them. Primary keys are updated, and indexes
+// on replaced columns dropped.
+func replaceColumns(table schema.Table, colIds []string, colDefs map[string]schema.Column, replaced map[string][]string) schema.Table {
+	var primaryKeys []schema.Key
+	seen := make(map[string]bool)
+	for _, k := range table.PrimaryKeys {
+		ids, ok := replaced[k.ColId]
+		if !ok {
+			ids = []string{k.ColId}
+		}
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			primaryKeys = append(primaryKeys, schema.Key{ColId: id, Desc: k.Desc, Order: len(primaryKeys) + 1})
+		}
+	}
+	var indexes []schema.Index
+	for _, index := range table.Indexes {
+		keep := true
+		for _, k := range index.Keys {
+			if _, ok := colDefs[k.ColId]; !ok {
+				keep = false
+			}
+		}
+		for _, colId := range index.StoredColumnIds {
+			if _, ok := colDefs[colId]; !ok {
+				keep = false
+			}
+		}
+		if keep {
+			indexes = append(indexes, index)
+		}
+	}
+	colNameIdMap := make(map[string]string)
+	for colId, col := range colDefs {
+		colNameIdMap[col.Name] = colId
+	}
+	table.ColIds = colIds
+	table.ColDefs = colDefs
+	table.ColNameIdMap = colNameIdMap
+	table.PrimaryKeys = primaryKeys
+	table.Indexes = indexes
+	return table
+}
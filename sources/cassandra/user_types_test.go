@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestValidateUserTypeStrategies(t *testing.T) {
+	assert.Nil(t, ValidateUserTypeStrategies("", nil))
+	assert.Nil(t, ValidateUserTypeStrategies(FlattenStrategy, map[string]string{"address": JSONStrategy}))
+	assert.NotNil(t, ValidateUserTypeStrategies("tables", nil))
+	assert.NotNil(t, ValidateUserTypeStrategies("", map[string]string{"address": "tables"}))
+}
+
+func TestFlattenColumn(t *testing.T) {
+	userTypes := map[string]internal.UserDefinedType{
+		"address": {Name: "address", FieldNames: []string{"city", "geo"}, FieldTypes: []string{"text", "frozen<point>"}, Flatten: true},
+		"point":   {Name: "point", FieldNames: []string{"lat", "lon"}, FieldTypes: []string{"double", "double"}, Flatten: true},
+	}
+	field := func(path ...string) *schema.UserTypeField {
+		return &schema.UserTypeField{Column: "home", ColumnType: "frozen<address>", Path: path}
+	}
+	assert.Equal(t, []schema.Column{
+		{Name: "home_city", Type: schema.Type{Name: "text"}, UserTypeField: field("city")},
+		{Name: "home_geo_lat", Type: schema.Type{Name: "double"}, UserTypeField: field("geo", "lat")},
+		{Name: "home_geo_lon", Type: schema.Type{Name: "double"}, UserTypeField: field("geo", "lon")},
+	}, flattenColumn(userTypes, schema.Column{Name: "home", Type: schema.Type{Name: "frozen<address>"}, NotNull: true}))
+
+	// Collections of user-defined types aren't flattened.
+	col := schema.Column{Name: "homes", Type: schema.Type{Name: "list<frozen<address>>"}}
+	assert.Equal(t, []schema.Column{col}, flattenColumn(userTypes, col))
+}
+
+func TestSetUserTypeFlatten(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.Source = constants.CASSANDRA
+	internal.SetUserDefinedType(conv, internal.UserDefinedType{Name: "address", FieldNames: []string{"city", "geo"}, FieldTypes: []string{"text", "frozen<point>"}})
+	internal.SetUserDefinedType(conv, internal.UserDefinedType{Name: "point", FieldNames: []string{"lat", "lon"}, FieldTypes: []string{"double", "double"}})
+	users := schema.Table{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "int"}, NotNull: true},
+			"c2": {Id: "c2", Name: "home", Type: schema.Type{Name: "frozen<address>"}, NotNull: true},
+			"c3": {Id: "c3", Name: "name", Type: schema.Type{Name: "text"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		Indexes:     []schema.Index{{Id: "i1", Name: "users_by_home", Keys: []schema.Key{{ColId: "c2"}}}},
+	}
+	events := schema.Table{
+		Id:          "t2",
+		Name:        "events",
+		ColIds:      []string{"c4"},
+		ColDefs:     map[string]schema.Column{"c4": {Id: "c4", Name: "id", Type: schema.Type{Name: "int"}, NotNull: true}},
+		PrimaryKeys: []schema.Key{{ColId: "c4", Order: 1}},
+	}
+	for _, table := range []schema.Table{users, events} {
+		conv.SrcSchema[table.Id] = table
+		assert.Nil(t, common.SrcTableToSpannerDDL(conv, InfoSchemaImpl{}.GetToDdl(), table, &expressions_api.MockDDLVerifier{}))
+	}
+	assert.Equal(t, ddl.JSON, conv.SpSchema["t1"].ColDefs["c2"].T.Name)
+	eventsSp := conv.SpSchema["t2"]
+
+	assert.Nil(t, SetUserTypeFlatten(conv, "address", true, &expressions_api.MockDDLVerifier{}))
+	src := conv.SrcSchema["t1"]
+	assert.Equal(t, []string{"id", "home_city", "home_geo", "name"}, spColNames(conv.SpSchema["t1"]))
+	assert.Equal(t, "users", conv.SpSchema["t1"].Name)
+	assert.Equal(t, ddl.JSON, conv.SpSchema["t1"].ColDefs[src.ColNameIdMap["home_geo"]].T.Name)
+	assert.Equal(t, []schema.Key{
+		{ColId: "c1", Order: 1},
+		{ColId: src.ColNameIdMap["home_city"], Order: 2},
+		{ColId: src.ColNameIdMap["home_geo"], Order: 3},
+	}, src.PrimaryKeys)
+	assert.Empty(t, src.Indexes)
+	assert.Equal(t, eventsSp, conv.SpSchema["t2"])
+
+	// Flattening the nested type flattens the fields of the flattened column.
+	assert.Nil(t, SetUserTypeFlatten(conv, "point", true, &expressions_api.MockDDLVerifier{}))
+	assert.Equal(t, []string{"id", "home_city", "home_geo_lat", "home_geo_lon", "name"}, spColNames(conv.SpSchema["t1"]))
+	assert.Equal(t, 4, len(conv.SpSchema["t1"].PrimaryKeys))
+
+	assert.Nil(t, SetUserTypeFlatten(conv, "address", false, &expressions_api.MockDDLVerifier{}))
+	src = conv.SrcSchema["t1"]
+	assert.Equal(t, []string{"id", "home", "name"}, spColNames(conv.SpSchema["t1"]))
+	home := src.ColDefs[src.ColNameIdMap["home"]]
+	assert.Equal(t, schema.Column{Id: home.Id, Name: "home", Type: schema.Type{Name: "frozen<address>"}, NotNull: true}, home)
+	assert.Equal(t, ddl.JSON, conv.SpSchema["t1"].ColDefs[home.Id].T.Name)
+	assert.Equal(t, 2, len(src.PrimaryKeys))
+
+	assert.NotNil(t, SetUserTypeFlatten(conv, "phone", true, &expressions_api.MockDDLVerifier{}))
+}
+
+func spColNames(t ddl.CreateTable) []string {
+	var names []string
+	for _, id := range t.ColIds {
+		names = append(names, t.ColDefs[id].Name)
+	}
+	return names
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// Spanner strings are UTF-8, and compared by their bytes. Values of string
+// columns in other character sets are transcoded to UTF-8, and columns
+// with collations other than binary ones, e.g. case-insensitive ones, are
+// reported when keys or indexes depend on them.
+
+// charsetEncodings maps the names of character sets, as MySQL names them,
+// to their encoding. Other names are looked up in the WHATWG encoding
+// index e.g. windows-1251 or shift_jis.
+var charsetEncodings = map[string]encoding.Encoding{
+	// MySQL's latin1 is cp1252, not ISO 8859-1.
+	"latin1":   charmap.Windows1252,
+	"latin2":   charmap.ISO8859_2,
+	"latin5":   charmap.ISO8859_9,
+	"latin7":   charmap.ISO8859_13,
+	"greek":    charmap.ISO8859_7,
+	"hebrew":   charmap.ISO8859_8,
+	"cp850":    charmap.CodePage850,
+	"cp852":    charmap.CodePage852,
+	"cp866":    charmap.CodePage866,
+	"cp1250":   charmap.Windows1250,
+	"cp1251":   charmap.Windows1251,
+	"cp1256":   charmap.Windows1256,
+	"cp1257":   charmap.Windows1257,
+	"koi8r":    charmap.KOI8R,
+	"koi8u":    charmap.KOI8U,
+	"macroman": charmap.Macintosh,
+	"tis620":   charmap.Windows874,
+	"sjis":     japanese.ShiftJIS,
+	"cp932":    japanese.ShiftJIS,
+	"ujis":     japanese.EUCJP,
+	"eucjpms":  japanese.EUCJP,
+	"euckr":    korean.EUCKR,
+	"gb2312":   simplifiedchinese.GBK,
+	"gbk":      simplifiedchinese.GBK,
+	"gb18030":  simplifiedchinese.GB18030,
+	"big5":     traditionalchinese.Big5,
+	"ucs2":     unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	"utf16":    unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	"utf16le":  unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+}
+
+// IsUTF8Charset returns whether values in the character set charset are
+// UTF-8, as they are in ASCII.
+func IsUTF8Charset(charset string) bool {
+	switch strings.ToLower(strings.ReplaceAll(charset, "-", "")) {
+	case "", "utf8", "utf8mb3", "utf8mb4", "ascii", "usascii", "sqlascii":
+		return true
+	}
+	return false
+}
+
+// ToUTF8 transcodes val, a string in the character set charset, to UTF-8.
+// Values in the binary character set are returned unchanged. It returns an
+// error for character sets it doesn't know, and for values which aren't
+// valid in their character set.
+func ToUTF8(charset, val string) (string, error) {
+	if IsUTF8Charset(charset) {
+		if !utf8.ValidString(val) {
+			return "", fmt.Errorf("invalid UTF-8 value %q", val)
+		}
+		return val, nil
+	}
+	name := strings.ToLower(charset)
+	if name == "binary" {
+		return val, nil
+	}
+	enc, ok := charsetEncodings[name]
+	if !ok {
+		var err error
+		if enc, err = htmlindex.Get(name); err != nil {
+			return "", fmt.Errorf("unsupported character set %s", charset)
+		}
+	}
+	s, err := enc.NewDecoder().String(val)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s value %q: %w", charset, val, err)
+	}
+	return s, nil
+}
+
+// IsBinaryCollation returns whether strings are compared by their bytes, or
+// code points, with the collation collation, as they are in Spanner. Columns
+// without a collation are assumed to be.
+func IsBinaryCollation(collation string) bool {
+	c := strings.ToLower(collation)
+	switch c {
+	case "", "binary", "c", "posix", "ucs_basic":
+		return true
+	}
+	return strings.HasSuffix(c, "_bin") || strings.HasSuffix(c, "_bin2")
+}
+
+// isKeyOrIndexed returns whether a column is part of the primary key or an
+// index of a table.
+func isKeyOrIndexed(colId string, srcTable schema.Table) bool {
+	if checkIfColumnIsPartOfPK(colId, srcTable.PrimaryKeys) {
+		return true
+	}
+	for _, index := range srcTable.Indexes {
+		for _, key := range index.Keys {
+			if key.ColId == colId {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToUTF8(t *testing.T) {
+	testCases := []struct {
+		charset  string
+		val      string
+		expected string
+	}{
+		{"utf8mb4", "café", "café"},
+		{"", "café", "café"},
+		{"latin1", "caf\xe9 \x80", "café €"},
+		{"cp1251", "\xcf\xf0\xe8\xe2\xe5\xf2", "Привет"},
+		{"sjis", "\x93\xfa\x96\x7b", "日本"},
+		{"ujis", "\xc6\xfc\xcb\xdc", "日本"},
+		{"gbk", "\xd6\xd0\xce\xc4", "中文"},
+		{"windows-1251", "\xcf\xf0\xe8", "При"},
+		{"binary", "\x00\xff", "\x00\xff"},
+	}
+	for _, tc := range testCases {
+		s, err := ToUTF8(tc.charset, tc.val)
+		assert.Nil(t, err, tc.charset)
+		assert.Equal(t, tc.expected, s, tc.charset)
+	}
+
+	_, err := ToUTF8("utf8mb4", "caf\xe9")
+	assert.NotNil(t, err)
+	_, err = ToUTF8("armscii8", "abc")
+	assert.NotNil(t, err)
+}
+
+func TestIsBinaryCollation(t *testing.T) {
+	for _, collation := range []string{"", "binary", "utf8mb4_bin", "latin1_bin", "Latin1_General_BIN2", "C", "POSIX"} {
+		assert.True(t, IsBinaryCollation(collation), collation)
+	}
+	for _, collation := range []string{"utf8mb4_0900_ai_ci", "latin1_swedish_ci", "SQL_Latin1_General_CP1_CI_AS", "en_US.utf8"} {
+		assert.False(t, IsBinaryCollation(collation), collation)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ChildTableSource is implemented by the InfoSchemas that map parts of
+// source tables, e.g. repeated fields, to child tables. The primary key of
+// a child table starts with the primary key of its parent table.
+type ChildTableSource interface {
+	// GetParentTableName returns the name of the parent table of the source
+	// table tableName, or "" if it isn't a child table.
+	GetParentTableName(tableName string) string
+}
+
+// InterleavedTableSource is implemented by the ChildTableSources whose child
+// tables are already interleaved in the source, e.g. Spanner databases, to
+// keep how they are interleaved.
+type InterleavedTableSource interface {
+	ChildTableSource
+	// GetInterleaving returns the ON DELETE action and the interleave type
+	// of the child table tableName.
+	GetInterleaving(tableName string) (onDelete, interleaveType string)
+}
+
+// InterleaveChildTables interleaves the Spanner tables of child tables in
+// the Spanner tables of their parent tables, so that the rows of child
+// tables are stored, and deleted, along with their parent row.
+func InterleaveChildTables(conv *internal.Conv, source ChildTableSource) {
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		srcTable, ok := conv.SrcSchema[tableId]
+		if !ok {
+			continue
+		}
+		parentName := source.GetParentTableName(srcTable.Name)
+		if parentName == "" {
+			continue
+		}
+		parentId, err := internal.GetTableIdFromSrcName(conv.SrcSchema, parentName)
+		if _, ok := conv.SpSchema[parentId]; err != nil || !ok {
+			conv.Unexpected(fmt.Sprintf("Can't interleave table %s: parent table %s not found", srcTable.Name, parentName))
+			continue
+		}
+		parent := ddl.InterleavedParent{Id: parentId, OnDelete: constants.FK_CASCADE}
+		if source, ok := source.(InterleavedTableSource); ok {
+			parent.OnDelete, parent.InterleaveType = source.GetInterleaving(srcTable.Name)
+		}
+		spTable := conv.SpSchema[tableId]
+		spTable.ParentTable = parent
+		conv.SpSchema[tableId] = spTable
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// fakeChildTableSource maps child table names to parent table names.
+type fakeChildTableSource map[string]string
+
+func (s fakeChildTableSource) GetParentTableName(tableName string) string {
+	return s[tableName]
+}
+
+// fakeInterleavedTableSource interleaves child tables IN tables without
+// ON DELETE actions.
+type fakeInterleavedTableSource struct {
+	fakeChildTableSource
+}
+
+func (s fakeInterleavedTableSource) GetInterleaving(tableName string) (string, string) {
+	return "", "IN"
+}
+
+func TestInterleaveChildTables(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "orders", Id: "t1"},
+		"t2": {Name: "orders.items", Id: "t2"},
+		"t3": {Name: "orders.items.parts", Id: "t3"},
+		"t4": {Name: "invoices.lines", Id: "t4"},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "orders", Id: "t1"},
+		"t2": {Name: "orders_items", Id: "t2"},
+		"t3": {Name: "orders_items_parts", Id: "t3"},
+		"t4": {Name: "invoices_lines", Id: "t4"},
+	}
+	InterleaveChildTables(conv, fakeChildTableSource{
+		"orders.items":       "orders",
+		"orders.items.parts": "orders.items",
+		"invoices.lines":     "invoices",
+	})
+	assert.Equal(t, ddl.InterleavedParent{}, conv.SpSchema["t1"].ParentTable)
+	assert.Equal(t, ddl.InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE}, conv.SpSchema["t2"].ParentTable)
+	assert.Equal(t, ddl.InterleavedParent{Id: "t2", OnDelete: constants.FK_CASCADE}, conv.SpSchema["t3"].ParentTable)
+	// The parent table of invoices.lines is missing.
+	assert.Equal(t, ddl.InterleavedParent{}, conv.SpSchema["t4"].ParentTable)
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+}
+
+func TestInterleaveChildTablesInterleaving(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "Singers", Id: "t1"},
+		"t2": {Name: "Albums", Id: "t2"},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "Singers", Id: "t1"},
+		"t2": {Name: "Albums", Id: "t2"},
+	}
+	InterleaveChildTables(conv, fakeInterleavedTableSource{fakeChildTableSource{"Albums": "Singers"}})
+	assert.Equal(t, ddl.InterleavedParent{Id: "t1", InterleaveType: "IN"}, conv.SpSchema["t2"].ParentTable)
+}
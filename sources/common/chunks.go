@@ -0,0 +1,157 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// The rows of tables with a primary key are read in chunks, in the order of
+// their key: each chunk is read with its own query, selecting the rows whose
+// key is after the last key of the previous chunk. The memory the rows of a
+// table take is bounded by the size of the chunks, and a chunk whose read
+// fails is read again on its own, instead of the whole table.
+
+const (
+	// DefaultChunkSize is the number of rows of the chunks tables are read
+	// in, unless set otherwise.
+	DefaultChunkSize = 10000
+	// ChunkRetries is the number of times the read of a chunk is retried
+	// after it fails.
+	ChunkRetries = 3
+)
+
+// chunkRetryDelay is the delay before the first retry of the read of a
+// chunk, doubled for each of the next ones.
+var chunkRetryDelay = time.Second
+
+// ChunkKey returns the names of the columns of the primary key of table, in
+// their order in the key, if its rows can be read in chunks. They can't
+// when table has no primary key, or when inexact reports that one of the
+// columns of its key can't be compared exactly, e.g. floating point columns,
+// whose last values of chunks could be rounded.
+func ChunkKey(table schema.Table, inexact func(col schema.Column) bool) []string {
+	keys := slices.Clone(table.PrimaryKeys)
+	slices.SortStableFunc(keys, func(a, b schema.Key) int { return a.Order - b.Order })
+	var cols []string
+	for _, k := range keys {
+		col, ok := table.ColDefs[k.ColId]
+		if !ok || inexact(col) {
+			return nil
+		}
+		cols = append(cols, col.Name)
+	}
+	return cols
+}
+
+// RowValueKeysetFilter returns the condition selecting the rows whose key,
+// made of the columns cols, quoted as needed, is after the values after, as
+// a comparison of row values, e.g. (a, b) > (?, ?), and args with its
+// arguments appended. placeholder returns the placeholder of the i-th
+// argument, starting at 1, as for KeyRange.Filter: those of the condition
+// follow those of args. Databases comparing row values, such as MySQL and
+// PostgreSQL, can use the key index to seek to the first row of the chunk.
+func RowValueKeysetFilter(cols []string, after []interface{}, placeholder func(i int) string, args []interface{}) (string, []interface{}) {
+	var placeholders []string
+	for i := range cols {
+		args = append(args, after[i])
+		placeholders = append(placeholders, placeholder(len(args)))
+	}
+	if len(cols) == 1 {
+		return fmt.Sprintf("%s > %s", cols[0], placeholders[0]), args
+	}
+	return fmt.Sprintf("(%s) > (%s)", strings.Join(cols, ", "), strings.Join(placeholders, ", ")), args
+}
+
+// KeysetFilter returns the same condition as RowValueKeysetFilter, without
+// comparing row values, which not all databases support, e.g. SQL Server:
+// (a > ?) OR (a = ? AND b > ?). placeholder is also passed the index in cols
+// of the column the argument is compared to, e.g. to cast it to the type of
+// the column.
+func KeysetFilter(cols []string, after []interface{}, placeholder func(i, col int) string, args []interface{}) (string, []interface{}) {
+	var disjuncts []string
+	for i := range cols {
+		var conds []string
+		for j := 0; j < i; j++ {
+			args = append(args, after[j])
+			conds = append(conds, fmt.Sprintf("%s = %s", cols[j], placeholder(len(args), j)))
+		}
+		args = append(args, after[i])
+		conds = append(conds, fmt.Sprintf("%s > %s", cols[i], placeholder(len(args), i)))
+		disjuncts = append(disjuncts, "("+strings.Join(conds, " AND ")+")")
+	}
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args
+}
+
+// ReadChunks reads the rows of a table in chunks of size rows, passing
+// them to emit. read reads up to limit rows in the order of the key of the
+// table, those whose key is after after, or from the first one when after
+// is nil. key returns the values of the key of a row, nil if they couldn't
+// be read, e.g. because the row couldn't be scanned: the next chunk starts
+// after the last row of the chunk whose key is known, and the rows after it
+// are left to the next chunk, which reads them again. A chunk whose read
+// fails is read again, up to ChunkRetries times; its rows are passed to emit
+// once it's read, so that they aren't passed twice.
+func ReadChunks[T any](size int, read func(after []interface{}, limit int) ([]T, error), key func(T) []interface{}, emit func(T)) error {
+	if size < 1 {
+		size = DefaultChunkSize
+	}
+	var after []interface{}
+	for {
+		var (
+			rows []T
+			err  error
+		)
+		delay := chunkRetryDelay
+		for attempt := 0; ; attempt++ {
+			rows, err = read(after, size)
+			if err == nil || attempt == ChunkRetries {
+				break
+			}
+			time.Sleep(delay)
+			delay *= 2
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't read the chunk of rows after key %v: %w", after, err)
+		}
+		if len(rows) < size {
+			for _, row := range rows {
+				emit(row)
+			}
+			return nil
+		}
+		// n is the number of rows up to the last one whose key is known.
+		n := len(rows)
+		var last []interface{}
+		for n > 0 {
+			if last = key(rows[n-1]); last != nil {
+				break
+			}
+			n--
+		}
+		if last == nil {
+			return fmt.Errorf("couldn't read the key of any row of the chunk of rows after key %v", after)
+		}
+		for _, row := range rows[:n] {
+			emit(row)
+		}
+		after = last
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+func TestChunkKey(t *testing.T) {
+	table := schema.Table{
+		Name: "order_lines",
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "order_id", Type: schema.Type{Name: "bigint"}},
+			"c2": {Name: "line", Type: schema.Type{Name: "int"}},
+			"c3": {Name: "weight", Type: schema.Type{Name: "double"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c2", Order: 2}, {ColId: "c1", Order: 1}},
+	}
+	inexact := func(col schema.Column) bool { return col.Type.Name == "double" }
+	assert.Equal(t, []string{"order_id", "line"}, ChunkKey(table, inexact))
+
+	table.PrimaryKeys = append(table.PrimaryKeys, schema.Key{ColId: "c3", Order: 3})
+	assert.Nil(t, ChunkKey(table, inexact))
+
+	table.PrimaryKeys = nil
+	assert.Nil(t, ChunkKey(table, inexact))
+}
+
+func TestKeysetFilter(t *testing.T) {
+	types := []string{"int", "varchar(10)"}
+	placeholder := func(i, col int) string { return fmt.Sprintf("CAST(@p%d AS %s)", i, types[col]) }
+	filter, args := KeysetFilter([]string{`[a]`}, []interface{}{1}, placeholder, nil)
+	assert.Equal(t, `(([a] > CAST(@p1 AS int)))`, filter)
+	assert.Equal(t, []interface{}{1}, args)
+
+	// The placeholders of the condition follow those of the range of keys.
+	filter, args = KeysetFilter([]string{`[a]`, `[b]`}, []interface{}{1, "x"}, placeholder, []interface{}{0})
+	assert.Equal(t, `(([a] > CAST(@p2 AS int)) OR ([a] = CAST(@p3 AS int) AND [b] > CAST(@p4 AS varchar(10))))`, filter)
+	assert.Equal(t, []interface{}{0, 1, 1, "x"}, args)
+}
+
+func TestRowValueKeysetFilter(t *testing.T) {
+	placeholder := func(i int) string { return fmt.Sprintf("$%d", i) }
+	filter, args := RowValueKeysetFilter([]string{`"a"`}, []interface{}{1}, placeholder, nil)
+	assert.Equal(t, `"a" > $1`, filter)
+	assert.Equal(t, []interface{}{1}, args)
+
+	// The placeholders of the condition follow those of the range of keys.
+	filter, args = RowValueKeysetFilter([]string{`"a"`, `"b"`}, []interface{}{1, "x"}, placeholder, []interface{}{0})
+	assert.Equal(t, `("a", "b") > ($2, $3)`, filter)
+	assert.Equal(t, []interface{}{0, 1, "x"}, args)
+}
+
+func TestReadChunks(t *testing.T) {
+	defer func(delay time.Duration) { chunkRetryDelay = delay }(chunkRetryDelay)
+	chunkRetryDelay = 0
+	table := []int{1, 2, 3, 4, 5, 6, 7}
+	failures := map[int]int{3: 2} // Number of times the read of the chunk after key 3 fails.
+	var reads []interface{}
+	read := func(after []interface{}, limit int) ([]int, error) {
+		start := 0
+		if after != nil {
+			start = after[0].(int)
+		}
+		reads = append(reads, start)
+		if failures[start] > 0 {
+			failures[start]--
+			return []int{4}, errors.New("connection reset")
+		}
+		return table[start:min(start+limit, len(table))], nil
+	}
+	key := func(row int) []interface{} { return []interface{}{row} }
+	var emitted []int
+	err := ReadChunks(3, read, key, func(row int) { emitted = append(emitted, row) })
+	assert.Nil(t, err)
+	// The rows of the chunk whose read failed are passed once.
+	assert.Equal(t, table, emitted)
+	assert.Equal(t, []interface{}{0, 3, 3, 3, 6}, reads)
+
+	failures = map[int]int{3: ChunkRetries + 1}
+	emitted = nil
+	err = ReadChunks(3, read, key, func(row int) { emitted = append(emitted, row) })
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, []int{1, 2, 3}, emitted)
+}
+
+func TestReadChunksLastKeyUnknown(t *testing.T) {
+	table := []int{1, 2, 3, 4, 5, 6, 7}
+	var reads []interface{}
+	read := func(after []interface{}, limit int) ([]int, error) {
+		start := 0
+		if after != nil {
+			start = after[0].(int)
+		}
+		reads = append(reads, start)
+		return table[start:min(start+limit, len(table))], nil
+	}
+	// The key of row 3, e.g. which couldn't be scanned, is unknown.
+	key := func(row int) []interface{} {
+		if row == 3 {
+			return nil
+		}
+		return []interface{}{row}
+	}
+	var emitted []int
+	err := ReadChunks(3, read, key, func(row int) { emitted = append(emitted, row) })
+	assert.Nil(t, err)
+	// The next chunk starts after the last row whose key is known, and row 3
+	// is passed once, with that chunk.
+	assert.Equal(t, table, emitted)
+	assert.Equal(t, []interface{}{0, 2, 5}, reads)
+
+	// Chunks without any row whose key is known can't be followed.
+	key = func(int) []interface{} { return nil }
+	assert.NotNil(t, ReadChunks(3, read, key, func(int) {}))
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// TableComments are the comments of a source table and of its columns, as
+// read from the source. Tables and columns without a comment are omitted.
+type TableComments struct {
+	Comment string
+	Columns map[string]string // Maps column name to comment.
+}
+
+// CommentSource is implemented by the InfoSchema of sources whose table and
+// column comments are carried over to Spanner.
+type CommentSource interface {
+	// GetComments returns the comments of tables and their columns, by
+	// schema and name.
+	GetComments(tables []SchemaAndName) (map[SchemaAndName]TableComments, error)
+}
+
+// applyComments sets the comments of table and its columns from c.
+func applyComments(table schema.Table, c TableComments) schema.Table {
+	table.Comment = c.Comment
+	for name, comment := range c.Columns {
+		colId, ok := table.ColNameIdMap[name]
+		if !ok {
+			continue
+		}
+		col := table.ColDefs[colId]
+		col.Comment = comment
+		table.ColDefs[colId] = col
+	}
+	return table
+}
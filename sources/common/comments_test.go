@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+)
+
+// fakeCommentSource is an InfoSchema of tables with the columns id and
+// created_at, whose table orders and its column id are commented.
+type fakeCommentSource struct {
+	fakeTemporalTableSource
+}
+
+func (f fakeCommentSource) GetTemporalTables(tables []SchemaAndName) (map[SchemaAndName]TemporalTable, error) {
+	return nil, nil
+}
+
+func (f fakeCommentSource) GetComments(tables []SchemaAndName) (map[SchemaAndName]TableComments, error) {
+	return map[SchemaAndName]TableComments{
+		{Schema: "public", Name: "orders"}: {Comment: "Orders placed by customers", Columns: map[string]string{"id": "Order number", "missing": "Dropped column"}},
+	}, nil
+}
+
+func TestGenerateSrcSchemaComments(t *testing.T) {
+	logger.Log = zap.NewNop()
+	tables := []SchemaAndName{{Schema: "public", Name: "orders"}, {Schema: "public", Name: "customers"}}
+	f := fakeCommentSource{fakeTemporalTableSource{fakePartitionedTableSource{
+		fakeBulkMetadataSource: fakeBulkMetadataSource{tables: tables, mu: &sync.Mutex{}, perTable: map[string]bool{}},
+	}}}
+	conv := internal.MakeConv()
+	_, err := (&InfoSchemaImpl{}).GenerateSrcSchema(conv, f, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(conv.SrcSchema))
+	for _, table := range conv.SrcSchema {
+		if table.Name != "orders" {
+			assert.Empty(t, table.Comment)
+			assert.Empty(t, table.ColDefs[table.ColNameIdMap["id"]].Comment)
+			continue
+		}
+		assert.Equal(t, "Orders placed by customers", table.Comment)
+		assert.Equal(t, "Order number", table.ColDefs[table.ColNameIdMap["id"]].Comment)
+		assert.Empty(t, table.ColDefs[table.ColNameIdMap["created_at"]].Comment)
+		assert.Equal(t, 2, len(table.ColDefs))
+	}
+}
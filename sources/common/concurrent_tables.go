@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// The rows of the tables of a source database are read and converted one
+// table at a time, as conv isn't safe for concurrent use, but the rows of
+// up to conv.ConcurrentTables tables are written to Spanner at a time: the
+// rows of a table are read while the last rows of the previous ones are
+// still being written, unless it depends on them.
+
+// tableWriter tracks the tables whose rows are being written to Spanner.
+type tableWriter struct {
+	conv    *internal.Conv
+	graph   *ddl.DependencyGraph
+	slots   chan struct{}            // Holds a token per table being written, nil when tables are written one at a time.
+	written map[string]chan struct{} // Closed once the rows of the table, by id, are written.
+}
+
+func newTableWriter(conv *internal.Conv) *tableWriter {
+	tw := &tableWriter{conv: conv, written: make(map[string]chan struct{})}
+	if conv.ConcurrentTables > 1 && conv.DataFlushTable != nil {
+		tw.graph = ddl.NewDependencyGraph(conv.SpSchema)
+		tw.slots = make(chan struct{}, conv.ConcurrentTables)
+	}
+	return tw
+}
+
+// start waits until the rows of the tables table tableId depends on are
+// written, and fewer than conv.ConcurrentTables tables are being written,
+// before its rows are added. Dependencies on tables whose rows are added
+// later, i.e. forming a cycle, are ignored.
+func (tw *tableWriter) start(tableId string) {
+	if tw.slots == nil {
+		return
+	}
+	for _, e := range tw.graph.Dependencies(tableId) {
+		if done, ok := tw.written[e.ToTableId]; ok {
+			<-done
+		}
+	}
+	tw.slots <- struct{}{}
+}
+
+// flush writes the remaining rows of table tableId, in the background when
+// tables are written concurrently.
+func (tw *tableWriter) flush(tableId string) {
+	if tw.slots == nil {
+		if tw.conv.DataFlush != nil {
+			tw.conv.DataFlush()
+		}
+		return
+	}
+	done := make(chan struct{})
+	tw.written[tableId] = done
+	table := tw.conv.SpSchema[tableId].Name
+	go func() {
+		defer close(done)
+		tw.conv.DataFlushTable(table)
+		<-tw.slots
+	}()
+}
+
+// cancel releases the slot of the table started last, whose rows couldn't
+// be read. Its rows already added are written by the final flush.
+func (tw *tableWriter) cancel() {
+	if tw.slots != nil {
+		<-tw.slots
+	}
+}
+
+// wait waits until the rows of all the tables are written.
+func (tw *tableWriter) wait() {
+	for _, done := range tw.written {
+		<-done
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+type fakeDataSource struct {
+	InfoSchema
+	events *tableEvents
+}
+
+func (f fakeDataSource) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, spCols []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	f.events.add("read " + srcSchema.Name)
+	return nil
+}
+
+type tableEvents struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (e *tableEvents) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *tableEvents) index(event string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return slices.Index(e.events, event)
+}
+
+func concurrentTablesTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "customers", Id: "t1"},
+		"t2": {Name: "orders", Id: "t2"},
+		"t3": {Name: "products", Id: "t3"},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "customers", Id: "t1"},
+		"t2": {Name: "orders", Id: "t2", ForeignKeys: []ddl.Foreignkey{{Name: "orders_customer", ReferTableId: "t1"}}},
+		"t3": {Name: "products", Id: "t3"},
+	}
+	return conv
+}
+
+func TestProcessDataConcurrentTables(t *testing.T) {
+	events := &tableEvents{}
+	conv := concurrentTablesTestConv()
+	conv.ConcurrentTables = 2
+	conv.DataFlushTable = func(table string) {
+		time.Sleep(50 * time.Millisecond)
+		events.add("flushed " + table)
+	}
+	(&InfoSchemaImpl{}).ProcessData(conv, fakeDataSource{events: events}, internal.AdditionalDataAttributes{})
+
+	assert.Equal(t, 6, len(events.events))
+	// The rows of orders are read once those of customers they refer to are
+	// written, and the rows of products while those of orders are written.
+	assert.Less(t, events.index("flushed customers"), events.index("read orders"))
+	assert.Less(t, events.index("read products"), events.index("flushed orders"))
+}
+
+func TestProcessDataOneTableAtATime(t *testing.T) {
+	events := &tableEvents{}
+	conv := concurrentTablesTestConv()
+	flushes := 0
+	conv.DataFlush = func() { flushes++ }
+	conv.DataFlushTable = func(table string) { t.Errorf("unexpected flush of table %s", table) }
+	(&InfoSchemaImpl{}).ProcessData(conv, fakeDataSource{events: events}, internal.AdditionalDataAttributes{})
+
+	assert.Equal(t, []string{"read customers", "read orders", "read products"}, events.events)
+	assert.Equal(t, 3, flushes)
+}
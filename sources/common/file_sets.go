@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+)
+
+// FileSet is the set of data files of a table, for sources whose tables
+// are read from files rather than from a database.
+type FileSet struct {
+	Table string
+	Files []string
+}
+
+// GetFileSets returns the file sets of the data files with extension ext
+// e.g. ".parquet". Files are either listed in manifest, a JSON manifest in
+// the format of CSV manifests, or found under dir, a local directory or a
+// GCS path. The file patterns of manifests are local or GCS files, glob
+// patterns e.g. gs://bucket/orders/part-*.parquet, or directories ending
+// with "/". Files found under dir are the data of the table they are named
+// after e.g. orders.parquet, or of the table named after their
+// subdirectory of dir e.g. orders/part-00000.parquet.
+func GetFileSets(ctx context.Context, dir, manifest, ext string) ([]FileSet, error) {
+	if manifest != "" {
+		return fileSetsFromManifest(ctx, manifest, ext)
+	}
+	if dir == "" {
+		return nil, fmt.Errorf("please specify a directory or a manifest of the %s files", ext)
+	}
+	files, err := file_reader.ListFiles(ctx, dir, ext)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list files under %s: %w", dir, err)
+	}
+	root := strings.TrimSuffix(filepath.ToSlash(dir), "/") + "/"
+	tableFiles := make(map[string][]string)
+	for _, f := range files {
+		rel := strings.TrimPrefix(filepath.ToSlash(f), root)
+		table, _, nested := strings.Cut(rel, "/")
+		if !nested {
+			table = strings.TrimSuffix(rel, ext)
+		}
+		tableFiles[table] = append(tableFiles[table], f)
+	}
+	if len(tableFiles) == 0 {
+		return nil, fmt.Errorf("no %s files found under %s", ext, dir)
+	}
+	var fileSets []FileSet
+	for table, files := range tableFiles {
+		fileSets = append(fileSets, FileSet{Table: table, Files: files})
+	}
+	sort.Slice(fileSets, func(i, j int) bool { return fileSets[i].Table < fileSets[j].Table })
+	return fileSets, nil
+}
+
+func fileSetsFromManifest(ctx context.Context, manifest, ext string) ([]FileSet, error) {
+	r, err := file_reader.NewFileReader(ctx, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("can't read manifest file due to: %v", err)
+	}
+	defer r.Close()
+	content, err := r.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't read manifest file due to: %v", err)
+	}
+	// The manifest has the format of utils.ManifestTable, which can't be
+	// used here as common/utils imports this package.
+	tables := []struct {
+		Table_name    string   `json:"table_name"`
+		File_patterns []string `json:"file_patterns"`
+	}{}
+	if err := json.Unmarshal(content, &tables); err != nil {
+		return nil, fmt.Errorf("unable to unmarshall json due to: %v", err)
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables found in manifest %s", manifest)
+	}
+	var fileSets []FileSet
+	seen := make(map[string]bool)
+	for i, table := range tables {
+		if table.Table_name == "" {
+			return nil, fmt.Errorf("table number %d (0-indexed) does not have a name", i)
+		}
+		if seen[table.Table_name] {
+			return nil, fmt.Errorf("table %s is listed more than once in the manifest", table.Table_name)
+		}
+		seen[table.Table_name] = true
+		if len(table.File_patterns) == 0 {
+			return nil, fmt.Errorf("no file path provided for table %s", table.Table_name)
+		}
+		fileSet := FileSet{Table: table.Table_name}
+		for _, pattern := range table.File_patterns {
+			files, err := matchFiles(ctx, pattern, ext)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't match files for table %s: %w", table.Table_name, err)
+			}
+			if len(files) == 0 {
+				return nil, fmt.Errorf("no files match %s for table %s", pattern, table.Table_name)
+			}
+			fileSet.Files = append(fileSet.Files, files...)
+		}
+		fileSets = append(fileSets, fileSet)
+	}
+	return fileSets, nil
+}
+
+// matchFiles returns the files matching a file pattern of a manifest.
+func matchFiles(ctx context.Context, pattern, ext string) ([]string, error) {
+	if strings.HasSuffix(pattern, "/") {
+		return file_reader.ListFiles(ctx, pattern, ext)
+	}
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+	if !strings.HasPrefix(pattern, constants.GCS_FILE_PREFIX) {
+		return filepath.Glob(pattern)
+	}
+	// GCS has no globs: the objects under the directory of the pattern
+	// are matched against it.
+	files, err := file_reader.ListFiles(ctx, pattern[:strings.LastIndex(pattern, "/")], "")
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, f := range files {
+		if ok, err := path.Match(pattern, f); err != nil {
+			return nil, err
+		} else if ok {
+			matches = append(matches, f)
+		}
+	}
+	return matches, nil
+}
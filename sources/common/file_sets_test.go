@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mkDataFiles creates empty files under a temporary directory, and returns
+// the directory.
+func mkDataFiles(t *testing.T, names ...string) string {
+	dir := t.TempDir()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.Nil(t, os.WriteFile(path, nil, 0644))
+	}
+	return dir
+}
+
+func TestGetFileSets_Dir(t *testing.T) {
+	dir := mkDataFiles(t, "customers.parquet", "orders/part-1.parquet", "orders/part-0.parquet", "orders/_SUCCESS")
+	fileSets, err := GetFileSets(context.Background(), dir, "", ".parquet")
+	assert.Nil(t, err)
+	assert.Equal(t, []FileSet{
+		{Table: "customers", Files: []string{filepath.Join(dir, "customers.parquet")}},
+		{Table: "orders", Files: []string{filepath.Join(dir, "orders", "part-0.parquet"), filepath.Join(dir, "orders", "part-1.parquet")}},
+	}, fileSets)
+
+	_, err = GetFileSets(context.Background(), dir, "", ".avro")
+	assert.NotNil(t, err)
+	_, err = GetFileSets(context.Background(), "", "", ".parquet")
+	assert.NotNil(t, err)
+}
+
+func TestGetFileSets_Manifest(t *testing.T) {
+	dir := mkDataFiles(t, "a.parquet", "b/part-0.parquet", "b/part-1.parquet", "c/x.parquet")
+	writeManifest := func(content string) string {
+		path := filepath.Join(t.TempDir(), "manifest.json")
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	manifest := writeManifest(`[
+		{"table_name": "t1", "file_patterns": ["` + filepath.Join(dir, "a.parquet") + `", "` + filepath.Join(dir, "c") + `/"]},
+		{"table_name": "t2", "file_patterns": ["` + filepath.Join(dir, "b", "part-*.parquet") + `"]}
+	]`)
+	// The directory is ignored when there's a manifest.
+	fileSets, err := GetFileSets(context.Background(), "ignored", manifest, ".parquet")
+	assert.Nil(t, err)
+	assert.Equal(t, []FileSet{
+		{Table: "t1", Files: []string{filepath.Join(dir, "a.parquet"), filepath.Join(dir, "c", "x.parquet")}},
+		{Table: "t2", Files: []string{filepath.Join(dir, "b", "part-0.parquet"), filepath.Join(dir, "b", "part-1.parquet")}},
+	}, fileSets)
+
+	for name, content := range map[string]string{
+		"empty":        `[]`,
+		"no name":      `[{"file_patterns": ["a.parquet"]}]`,
+		"duplicate":    `[{"table_name": "t", "file_patterns": ["a"]}, {"table_name": "t", "file_patterns": ["b"]}]`,
+		"no patterns":  `[{"table_name": "t"}]`,
+		"no match":     `[{"table_name": "t", "file_patterns": ["` + filepath.Join(dir, "z*.parquet") + `"]}]`,
+		"invalid json": `{`,
+	} {
+		_, err := GetFileSets(context.Background(), "", writeManifest(content), ".parquet")
+		assert.NotNil(t, err, name)
+	}
+}
@@ -108,13 +108,80 @@ func (is *InfoSchemaImpl) GenerateSrcSchema(conv *internal.Conv, infoSchema Info
 	if err != nil {
 		return 0, err
 	}
+	tables = filterTables(conv, infoSchema, tables)
 
 	if numWorkers < 1 {
 		numWorkers = DefaultWorkers
 	}
 
+	// Querying the metadata of many tables one table at a time takes long,
+	// so it is fetched in bulk when the source supports it.
+	if bulk, ok := infoSchema.(BulkMetadataSource); ok && len(tables) >= MinBulkMetadataTables {
+		withMetadata, err := bulk.WithBulkMetadata(tables)
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't fetch the metadata of tables in bulk, fetching it per table: %v", err))
+		} else {
+			infoSchema = withMetadata
+		}
+	}
+
+	var partitionings map[SchemaAndName]TablePartitioning
+	if source, ok := infoSchema.(PartitionedTableSource); ok {
+		partitionings, err = source.GetPartitionedTables(tables)
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't get partitioned tables, migrating them as unpartitioned tables: %v", err))
+		}
+	}
+
+	var temporalTables map[SchemaAndName]TemporalTable
+	if source, ok := infoSchema.(TemporalTableSource); ok {
+		temporalTables, err = source.GetTemporalTables(tables)
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't get system-versioned temporal tables, migrating them as regular tables: %v", err))
+		}
+	}
+
+	var materializedViews map[SchemaAndName]string
+	if source, ok := infoSchema.(MaterializedViewSource); ok {
+		materializedViews, err = source.GetMaterializedViews(tables)
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't get materialized views, migrating them as regular tables: %v", err))
+		}
+	}
+
+	var comments map[SchemaAndName]TableComments
+	if source, ok := infoSchema.(CommentSource); ok {
+		comments, err = source.GetComments(tables)
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't get table and column comments, migrating tables without them: %v", err))
+		}
+	}
+
+	var uniqueConstraints map[SchemaAndName][]string
+	if source, ok := infoSchema.(UniqueConstraintSource); ok {
+		uniqueConstraints, err = source.GetUniqueConstraints(tables)
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't get unique constraints, migrating them as unique indexes: %v", err))
+		}
+	}
+
 	asyncProcessTable := func(t SchemaAndName, mutex *sync.Mutex) task.TaskResult[SchemaAndName] {
 		table, e := is.ProcessTable(conv, t, infoSchema)
+		if p, ok := partitionings[SchemaAndName{Schema: t.Schema, Name: t.Name}]; ok && e == nil {
+			table.Partitioning = toPartitioning(p, table.ColNameIdMap)
+		}
+		if tt, ok := temporalTables[SchemaAndName{Schema: t.Schema, Name: t.Name}]; ok && e == nil {
+			table.Temporal = toTemporal(tt, table.ColNameIdMap)
+		}
+		if q, ok := materializedViews[SchemaAndName{Schema: t.Schema, Name: t.Name}]; ok && e == nil {
+			table.MaterializedView = q
+		}
+		if c, ok := comments[SchemaAndName{Schema: t.Schema, Name: t.Name}]; ok && e == nil {
+			table = applyComments(table, c)
+		}
+		if names, ok := uniqueConstraints[SchemaAndName{Schema: t.Schema, Name: t.Name}]; ok && e == nil {
+			table = markUniqueConstraints(table, names)
+		}
 		mutex.Lock()
 		conv.SrcSchema[table.Id] = table
 		mutex.Unlock()
@@ -130,6 +197,23 @@ func (is *InfoSchemaImpl) GenerateSrcSchema(conv *internal.Conv, infoSchema Info
 	}
 
 	internal.ResolveForeignKeyIds(conv.SrcSchema)
+
+	if source, ok := infoSchema.(ViewSource); ok {
+		views, err := source.GetViews()
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't get views, they won't be converted to Spanner: %v", err))
+		}
+		if conv.SrcViews == nil {
+			conv.SrcViews = make(map[string]schema.View)
+		}
+		for _, view := range views {
+			view.Id = internal.GenerateViewId()
+			conv.SrcViews[view.Id] = view
+		}
+	}
+	if source, ok := infoSchema.(RoutineSource); ok {
+		processRoutines(conv, source)
+	}
 	return len(tables), nil
 }
 
@@ -139,12 +223,20 @@ func (is *InfoSchemaImpl) GenerateSrcSchema(conv *internal.Conv, infoSchema Info
 // If we can't get/process data for a table, we skip that table and process
 // the remaining tables.
 func (is *InfoSchemaImpl) ProcessData(conv *internal.Conv, infoSchema InfoSchema, additionalAttributes internal.AdditionalDataAttributes) {
-	// Tables are ordered in alphabetical order with one exception: interleaved
-	// tables appear after the population of their parent table.
-	tableIds := ddl.GetSortedTableIdsBySpName(conv.SpSchema)
+	// Tables are populated after their parent table and the tables referenced
+	// by their foreign keys, see ddl.GetSortedTableIdsForDataLoad. The rows
+	// of up to conv.ConcurrentTables tables are written at a time.
+	tableIds, _ := ddl.GetSortedTableIdsForDataLoad(conv.SpSchema)
+	tw := newTableWriter(conv)
+	defer tw.wait()
 
 	for _, tableId := range tableIds {
 		srcSchema := conv.SrcSchema[tableId]
+		// The filters may select fewer tables than the schema was converted
+		// for, e.g. when migrating the data of a session subset by subset.
+		if !conv.TableFilters.Matches(srcSchema.Name) {
+			continue
+		}
 		spSchema, ok := conv.SpSchema[tableId]
 		if !ok {
 			conv.Stats.BadRows[srcSchema.Name] += conv.Stats.Rows[srcSchema.Name]
@@ -155,14 +247,26 @@ func (is *InfoSchemaImpl) ProcessData(conv *internal.Conv, infoSchema InfoSchema
 		// Extract common spColds. We get column ids common to both source and
 		// spanner table so that we can read these records from source
 		colIds := GetCommonColumnIds(conv, tableId, spSchema.ColIds)
+		tw.start(tableId)
 		err := infoSchema.ProcessData(conv, tableId, srcSchema, colIds, spSchema, additionalAttributes)
 		if err != nil {
+			tw.cancel()
 			return
 		}
-		if conv.DataFlush != nil {
-			conv.DataFlush()
+		tw.flush(tableId)
+	}
+}
+
+// filterTables returns the tables selected by conv.TableFilters, by their
+// name in the source schema.
+func filterTables(conv *internal.Conv, infoSchema InfoSchema, tables []SchemaAndName) []SchemaAndName {
+	var selected []SchemaAndName
+	for _, t := range tables {
+		if conv.TableFilters.Matches(infoSchema.GetTableName(t.Schema, t.Name)) {
+			selected = append(selected, t)
 		}
 	}
+	return selected
 }
 
 // SetRowStats populates conv with the number of rows in each table.
@@ -172,7 +276,7 @@ func (is *InfoSchemaImpl) SetRowStats(conv *internal.Conv, infoSchema InfoSchema
 		conv.Unexpected(fmt.Sprintf("Couldn't get list of table: %s", err))
 		return
 	}
-	for _, t := range tables {
+	for _, t := range filterTables(conv, infoSchema, tables) {
 		tableName := infoSchema.GetTableName(t.Schema, t.Name)
 		count, err := infoSchema.GetRowCount(t)
 		if err != nil {
@@ -15,9 +15,15 @@
 package common
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 )
 
 
@@ -45,3 +51,38 @@ func TestSanitizeDefaultValue(t *testing.T) {
 		assert.Equal(t, test.expectedString, result)
 	}
 }
+
+func TestGenerateSrcSchemaTableFilters(t *testing.T) {
+	logger.Log = zap.NewNop()
+	tables := []SchemaAndName{{Schema: "public", Name: "orders"}, {Schema: "public", Name: "order_items"}, {Schema: "public", Name: "tmp_orders"}}
+	testCases := []struct {
+		name     string
+		filters  schema.TableFilters
+		expected []string
+	}{
+		{name: "no filters", expected: []string{"orders", "order_items", "tmp_orders"}},
+		{name: "include", filters: schema.TableFilters{Include: "order.*"}, expected: []string{"orders", "order_items"}},
+		{name: "include matches whole name", filters: schema.TableFilters{Include: "orders"}, expected: []string{"orders"}},
+		{name: "exclude", filters: schema.TableFilters{Exclude: "tmp_.*"}, expected: []string{"orders", "order_items"}},
+		{name: "include and exclude", filters: schema.TableFilters{Include: ".*orders", Exclude: "tmp_.*"}, expected: []string{"orders"}},
+		{name: "invalid filter", filters: schema.TableFilters{Include: "("}, expected: nil},
+	}
+	for _, tc := range testCases {
+		f := fakeBulkMetadataSource{tables: tables, mu: &sync.Mutex{}, perTable: map[string]bool{}}
+		conv := internal.MakeConv()
+		conv.TableFilters = tc.filters
+		_, err := (&InfoSchemaImpl{}).GenerateSrcSchema(conv, f, 2)
+		assert.Nil(t, err, tc.name)
+		var names []string
+		for _, table := range conv.SrcSchema {
+			names = append(names, table.Name)
+		}
+		assert.ElementsMatch(t, tc.expected, names, tc.name)
+	}
+}
+
+func TestTableFiltersValidate(t *testing.T) {
+	assert.Nil(t, schema.TableFilters{Include: "sales\\..*", Exclude: "tmp_.*"}.Validate())
+	assert.NotNil(t, schema.TableFilters{Include: "("}.Validate())
+	assert.NotNil(t, schema.TableFilters{Exclude: "[a-"}.Validate())
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MinBulkMetadataTables is the number of tables from which the metadata of
+// tables is fetched in bulk, with a query per kind of metadata, rather than
+// with queries per table.
+const MinBulkMetadataTables = 100
+
+// Kinds of metadata fetched in bulk.
+const (
+	MetadataColumns     = "columns"
+	MetadataConstraints = "constraints"
+	MetadataForeignKeys = "foreign keys"
+	MetadataIndexes     = "indexes"
+)
+
+// BulkMetadataSource is implemented by the InfoSchema of sources whose
+// metadata can be fetched in bulk for all tables.
+type BulkMetadataSource interface {
+	// WithBulkMetadata fetches the metadata of tables in bulk, and returns
+	// the InfoSchema reading the metadata of tables from it.
+	WithBulkMetadata(tables []SchemaAndName) (InfoSchema, error)
+}
+
+// MetadataRows are the rows of the metadata query of a table, read from the
+// database or from the rows fetched in bulk. *sql.Rows implements it.
+type MetadataRows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Columns() ([]string, error)
+	Err() error
+	Close() error
+}
+
+type metadataTable struct {
+	schema, name string
+}
+
+type bulkRows struct {
+	columns []string
+	rows    map[metadataTable][][]interface{}
+}
+
+// BulkMetadata is the metadata of tables fetched in bulk, by kind of
+// metadata. The zero value has none, and a nil BulkMetadata none either.
+type BulkMetadata struct {
+	kinds map[string]bulkRows
+}
+
+// Fetch runs the bulk query q of the metadata of kind. The first two columns
+// of its rows are the schema and name of their table, and the others those
+// of the query of the metadata of a table, its rows being in the same order.
+func (m *BulkMetadata) Fetch(db *sql.DB, kind, q string, args ...interface{}) error {
+	rows, err := db.Query(q, args...)
+	if err != nil {
+		return fmt.Errorf("couldn't get %s of tables: %w", kind, err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if len(columns) < 2 {
+		return fmt.Errorf("couldn't get %s of tables: no schema and table columns", kind)
+	}
+	res := bulkRows{columns: columns[2:], rows: make(map[metadataTable][][]interface{})}
+	for rows.Next() {
+		var table metadataTable
+		vals := make([]interface{}, len(columns)-2)
+		dest := []interface{}{&table.schema, &table.name}
+		for i := range vals {
+			dest = append(dest, &vals[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("couldn't get %s of tables: %w", kind, err)
+		}
+		res.rows[table] = append(res.rows[table], vals)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("couldn't get %s of tables: %w", kind, err)
+	}
+	if m.kinds == nil {
+		m.kinds = make(map[string]bulkRows)
+	}
+	m.kinds[kind] = res
+	return nil
+}
+
+// Query returns the rows of the metadata of kind of table, from those
+// fetched in bulk if they have been, and from the metadata query q of the
+// table otherwise.
+func (m *BulkMetadata) Query(db *sql.DB, kind string, table SchemaAndName, q string, args ...interface{}) (MetadataRows, error) {
+	if m != nil {
+		if res, ok := m.kinds[kind]; ok {
+			return &cachedRows{columns: res.columns, rows: res.rows[metadataTable{table.Schema, table.Name}], i: -1}, nil
+		}
+	}
+	return db.Query(q, args...)
+}
+
+// cachedRows are rows fetched in bulk.
+type cachedRows struct {
+	columns []string
+	rows    [][]interface{}
+	i       int
+}
+
+func (r *cachedRows) Next() bool {
+	r.i++
+	return r.i < len(r.rows)
+}
+
+func (r *cachedRows) Scan(dest ...interface{}) error {
+	if r.i < 0 || r.i >= len(r.rows) {
+		return fmt.Errorf("scan called without calling next")
+	}
+	vals := r.rows[r.i]
+	if len(dest) != len(vals) {
+		return fmt.Errorf("expected %d destination arguments in scan, not %d", len(vals), len(dest))
+	}
+	for i, val := range vals {
+		if err := assignMetadataValue(dest[i], val); err != nil {
+			return fmt.Errorf("can't scan column %s: %w", r.columns[i], err)
+		}
+	}
+	return nil
+}
+
+func (r *cachedRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *cachedRows) Err() error                 { return nil }
+func (r *cachedRows) Close() error               { return nil }
+
+// assignMetadataValue assigns the value src, as returned by a driver, to
+// dest, converting it as database/sql does for the destinations of metadata
+// queries.
+func assignMetadataValue(dest, src interface{}) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+	switch d := dest.(type) {
+	case *interface{}:
+		*d = src
+		return nil
+	case *string:
+		var v sql.NullString
+		if err := v.Scan(src); err != nil {
+			return err
+		}
+		if !v.Valid {
+			return fmt.Errorf("converting NULL to string is unsupported")
+		}
+		*d = v.String
+		return nil
+	case *int64:
+		var v sql.NullInt64
+		if err := v.Scan(src); err != nil {
+			return err
+		}
+		if !v.Valid {
+			return fmt.Errorf("converting NULL to int64 is unsupported")
+		}
+		*d = v.Int64
+		return nil
+	case *int:
+		var v sql.NullInt64
+		if err := v.Scan(src); err != nil {
+			return err
+		}
+		if !v.Valid {
+			return fmt.Errorf("converting NULL to int is unsupported")
+		}
+		*d = int(v.Int64)
+		return nil
+	case *bool:
+		var v sql.NullBool
+		if err := v.Scan(src); err != nil {
+			return err
+		}
+		if !v.Valid {
+			return fmt.Errorf("converting NULL to bool is unsupported")
+		}
+		*d = v.Bool
+		return nil
+	}
+	return fmt.Errorf("unsupported destination type %T", dest)
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+func TestBulkMetadata(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT table_schema, table_name, column_name, is_nullable, max_length FROM columns")).
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "column_name", "is_nullable", "max_length"}).
+			AddRow("public", "t1", "a", "NO", int64(10)).
+			AddRow("public", "t2", "b", "YES", nil).
+			AddRow("public", "t1", "c", "YES", int64(20)))
+
+	m := &BulkMetadata{}
+	assert.Nil(t, m.Fetch(db, MetadataColumns, "SELECT table_schema, table_name, column_name, is_nullable, max_length FROM columns"))
+
+	// Rows of tables are in the order of the bulk query.
+	rows, err := m.Query(db, MetadataColumns, SchemaAndName{Schema: "public", Name: "t1"}, "SELECT * FROM columns WHERE table_name = 't1'")
+	assert.Nil(t, err)
+	cols, err := rows.Columns()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"column_name", "is_nullable", "max_length"}, cols)
+	var names []string
+	var lengths []int64
+	for rows.Next() {
+		var name, isNullable string
+		var maxLength sql.NullInt64
+		assert.Nil(t, rows.Scan(&name, &isNullable, &maxLength))
+		names = append(names, name)
+		lengths = append(lengths, maxLength.Int64)
+	}
+	assert.Nil(t, rows.Err())
+	assert.Nil(t, rows.Close())
+	assert.Equal(t, []string{"a", "c"}, names)
+	assert.Equal(t, []int64{10, 20}, lengths)
+
+	rows, err = m.Query(db, MetadataColumns, SchemaAndName{Schema: "public", Name: "t2"}, "SELECT * FROM columns WHERE table_name = 't2'")
+	assert.Nil(t, err)
+	assert.True(t, rows.Next())
+	var name, isNullable, maxLength string
+	assert.NotNil(t, rows.Scan(&name, &isNullable, &maxLength), "NULL scanned into a string")
+	var length int
+	assert.Nil(t, rows.Scan(&name, &isNullable, new(interface{})))
+	assert.NotNil(t, rows.Scan(&name, &isNullable, &length))
+	assert.NotNil(t, rows.Scan(&name, &isNullable))
+	assert.False(t, rows.Next())
+
+	// Tables without rows have none.
+	rows, err = m.Query(db, MetadataColumns, SchemaAndName{Schema: "public", Name: "t3"}, "SELECT * FROM columns WHERE table_name = 't3'")
+	assert.Nil(t, err)
+	assert.False(t, rows.Next())
+
+	// Kinds of metadata not fetched in bulk are queried per table.
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM indexes WHERE table_name = ?")).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"index_name"}).AddRow("idx"))
+	rows, err = m.Query(db, MetadataIndexes, SchemaAndName{Schema: "public", Name: "t1"}, "SELECT * FROM indexes WHERE table_name = ?", "t1")
+	assert.Nil(t, err)
+	assert.True(t, rows.Next())
+	rows.Close()
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkMetadataNil(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT column_name FROM columns WHERE table_name = ?")).WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("a"))
+	var m *BulkMetadata
+	rows, err := m.Query(db, MetadataColumns, SchemaAndName{Schema: "public", Name: "t1"}, "SELECT column_name FROM columns WHERE table_name = ?", "t1")
+	assert.Nil(t, err)
+	defer rows.Close()
+	assert.True(t, rows.Next())
+	var name string
+	assert.Nil(t, rows.Scan(&name))
+	assert.Equal(t, "a", name)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkMetadataFetchErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	m := &BulkMetadata{}
+	mock.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("permission denied"))
+	assert.NotNil(t, m.Fetch(db, MetadataIndexes, "SELECT"))
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("t1"))
+	assert.NotNil(t, m.Fetch(db, MetadataIndexes, "SELECT"))
+}
+
+// fakeBulkMetadataSource is an InfoSchema of tables without columns, which
+// records the tables whose metadata is fetched per table.
+type fakeBulkMetadataSource struct {
+	InfoSchema
+	tables   []SchemaAndName
+	bulk     bool
+	bulkErr  error
+	mu       *sync.Mutex
+	perTable map[string]bool
+}
+
+func (f fakeBulkMetadataSource) GetTables() ([]SchemaAndName, error) { return f.tables, nil }
+
+func (f fakeBulkMetadataSource) GetTableName(schema string, tableName string) string {
+	return tableName
+}
+
+func (f fakeBulkMetadataSource) GetConstraints(conv *internal.Conv, table SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	if !f.bulk {
+		f.mu.Lock()
+		f.perTable[table.Name] = true
+		f.mu.Unlock()
+	}
+	return nil, nil, nil, nil
+}
+
+func (f fakeBulkMetadataSource) GetForeignKeys(conv *internal.Conv, table SchemaAndName) ([]schema.ForeignKey, error) {
+	return nil, nil
+}
+
+func (f fakeBulkMetadataSource) GetColumns(conv *internal.Conv, table SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	return map[string]schema.Column{}, nil, nil
+}
+
+func (f fakeBulkMetadataSource) GetIndexes(conv *internal.Conv, table SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return nil, nil
+}
+
+func (f fakeBulkMetadataSource) WithBulkMetadata(tables []SchemaAndName) (InfoSchema, error) {
+	if f.bulkErr != nil {
+		return f, f.bulkErr
+	}
+	f.bulk = true
+	return f, nil
+}
+
+func TestGenerateSrcSchemaBulkMetadata(t *testing.T) {
+	logger.Log = zap.NewNop()
+	testCases := []struct {
+		name         string
+		tableCount   int
+		bulkErr      error
+		expectedBulk bool
+	}{
+		{name: "few tables", tableCount: MinBulkMetadataTables - 1, expectedBulk: false},
+		{name: "many tables", tableCount: MinBulkMetadataTables, expectedBulk: true},
+		{name: "bulk fetch error", tableCount: MinBulkMetadataTables, bulkErr: fmt.Errorf("permission denied"), expectedBulk: false},
+	}
+	for _, tc := range testCases {
+		var tables []SchemaAndName
+		for i := 0; i < tc.tableCount; i++ {
+			tables = append(tables, SchemaAndName{Schema: "public", Name: fmt.Sprintf("t%d", i)})
+		}
+		f := fakeBulkMetadataSource{tables: tables, bulkErr: tc.bulkErr, mu: &sync.Mutex{}, perTable: map[string]bool{}}
+		conv := internal.MakeConv()
+		is := InfoSchemaImpl{}
+		tableCount, err := is.GenerateSrcSchema(conv, f, 4)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.tableCount, tableCount, tc.name)
+		assert.Equal(t, tc.tableCount, len(conv.SrcSchema), tc.name)
+		if tc.expectedBulk {
+			assert.Empty(t, f.perTable, tc.name)
+		} else {
+			assert.Equal(t, tc.tableCount, len(f.perTable), tc.name)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// The rows of large tables are read by several readers at a time, each
+// reading a partition of the table, or a range of the values of its primary
+// key. Each reader has its own connection, and hence its own snapshot of
+// the table.
+
+// KeyRange is a range of the values of the primary key of a table, whose
+// rows are read by one of the readers of the table. Start is nil for the
+// first range, and End for the last: they're unbounded, so that the ranges
+// cover all the values of the key.
+type KeyRange struct {
+	Start interface{} // Included in the range.
+	End   interface{} // Excluded from the range.
+}
+
+// Filter returns the condition selecting the rows of r, on the column col,
+// quoted as needed, and its arguments. placeholder returns the placeholder
+// of the i-th argument, starting at 1, e.g. ? for MySQL or $1 for
+// PostgreSQL. The condition is empty for ranges which aren't bounded.
+func (r KeyRange) Filter(col string, placeholder func(i int) string) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if r.Start != nil {
+		args = append(args, r.Start)
+		conds = append(conds, fmt.Sprintf("%s >= %s", col, placeholder(len(args))))
+	}
+	if r.End != nil {
+		args = append(args, r.End)
+		conds = append(conds, fmt.Sprintf("%s < %s", col, placeholder(len(args))))
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// SplitIntegerKeys splits the values of an integer key from min to max into
+// up to n ranges with about the same number of values.
+func SplitIntegerKeys(min, max int64, n int) []KeyRange {
+	if n < 2 || max <= min {
+		return []KeyRange{{}}
+	}
+	// The span is computed on unsigned integers, so that it doesn't overflow
+	// for keys from negative to positive values.
+	span := uint64(max) - uint64(min)
+	step := span/uint64(n) + 1
+	var ranges []KeyRange
+	var start interface{}
+	for i := uint64(1); i < uint64(n) && i*step <= span; i++ {
+		end := int64(uint64(min) + i*step)
+		ranges = append(ranges, KeyRange{Start: start, End: end})
+		start = end
+	}
+	return append(ranges, KeyRange{Start: start})
+}
+
+// SplitUUIDKeys splits the values of a key of UUIDs, in their textual form
+// e.g. 123e4567-e89b-12d3-a456-426614174000, into n ranges with about the
+// same number of values, assuming the UUIDs are random.
+func SplitUUIDKeys(n int) []KeyRange {
+	if n < 2 {
+		return []KeyRange{{}}
+	}
+	if n > 1<<16 {
+		n = 1 << 16
+	}
+	var ranges []KeyRange
+	var start interface{}
+	for i := 1; i < n; i++ {
+		// The ranges are split by the first 4 hex digits of the UUIDs.
+		end := fmt.Sprintf("%04x0000-0000-0000-0000-000000000000", i*(1<<16)/n)
+		ranges = append(ranges, KeyRange{Start: start, End: end})
+		start = end
+	}
+	return append(ranges, KeyRange{Start: start})
+}
+
+// ReadKeyRanges reads the rows of the key ranges of a table with up to
+// readers calls to read at a time, like ReadPartitions.
+func ReadKeyRanges[T any](ranges []KeyRange, readers int, read func(r KeyRange, emit func(T)) error, process func(T)) error {
+	describe := func(r KeyRange) string {
+		return fmt.Sprintf("key range [%v, %v)", r.Start, r.End)
+	}
+	return readInParallel(ranges, readers, describe, read, process)
+}
+
+// readInParallel reads the rows of items with up to readers calls to read
+// at a time, see ReadPartitions. describe names the items in errors.
+func readInParallel[P, T any](items []P, readers int, describe func(P) string, read func(p P, emit func(T)) error, process func(T)) error {
+	if readers < 1 {
+		readers = 1
+	}
+	queue := make(chan P, len(items))
+	for _, p := range items {
+		queue <- p
+	}
+	close(queue)
+	rows := make(chan T, readers)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i := 0; i < readers && i < len(items); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range queue {
+				err := read(p, func(row T) { rows <- row })
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("couldn't read %s: %w", describe(p), err)
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(rows)
+	}()
+	for row := range rows {
+		process(row)
+	}
+	return firstErr
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+func TestSplitIntegerKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		min, max int64
+		n        int
+		want     []KeyRange
+	}{
+		{name: "even", min: 0, max: 11, n: 4, want: []KeyRange{{End: int64(3)}, {Start: int64(3), End: int64(6)}, {Start: int64(6), End: int64(9)}, {Start: int64(9)}}},
+		{name: "fewer values than readers", min: 10, max: 12, n: 8, want: []KeyRange{{End: int64(11)}, {Start: int64(11), End: int64(12)}, {Start: int64(12)}}},
+		{name: "single value", min: 5, max: 5, n: 4, want: []KeyRange{{}}},
+		{name: "single reader", min: 0, max: 100, n: 1, want: []KeyRange{{}}},
+		{name: "full range", min: math.MinInt64, max: math.MaxInt64, n: 2, want: []KeyRange{{End: int64(0)}, {Start: int64(0)}}},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, SplitIntegerKeys(tc.min, tc.max, tc.n), tc.name)
+	}
+}
+
+func TestSplitUUIDKeys(t *testing.T) {
+	assert.Equal(t, []KeyRange{
+		{End: "40000000-0000-0000-0000-000000000000"},
+		{Start: "40000000-0000-0000-0000-000000000000", End: "80000000-0000-0000-0000-000000000000"},
+		{Start: "80000000-0000-0000-0000-000000000000", End: "c0000000-0000-0000-0000-000000000000"},
+		{Start: "c0000000-0000-0000-0000-000000000000"},
+	}, SplitUUIDKeys(4))
+	assert.Equal(t, []KeyRange{{}}, SplitUUIDKeys(1))
+}
+
+func TestKeyRangeFilter(t *testing.T) {
+	placeholder := func(i int) string { return fmt.Sprintf("$%d", i) }
+	filter, args := KeyRange{Start: int64(3), End: int64(6)}.Filter(`"id"`, placeholder)
+	assert.Equal(t, `"id" >= $1 AND "id" < $2`, filter)
+	assert.Equal(t, []interface{}{int64(3), int64(6)}, args)
+	filter, args = KeyRange{End: int64(3)}.Filter(`"id"`, placeholder)
+	assert.Equal(t, `"id" < $1`, filter)
+	assert.Equal(t, []interface{}{int64(3)}, args)
+	filter, args = KeyRange{}.Filter(`"id"`, placeholder)
+	assert.Equal(t, "", filter)
+	assert.Nil(t, args)
+}
+
+func TestPartitionReadersOf(t *testing.T) {
+	assert.Equal(t, PartitionReaders, PartitionReadersOf(partitionedTable(schema.PartitionMappingParallelExport), 0))
+	assert.Equal(t, 8, PartitionReadersOf(partitionedTable(schema.PartitionMappingParallelExport), 8))
+	assert.Equal(t, 8, PartitionReadersOf(partitionedTable(schema.PartitionMappingSingleTable), 8))
+	assert.Equal(t, 0, PartitionReadersOf(partitionedTable(schema.PartitionMappingSingleTable), 1))
+	assert.Equal(t, 0, PartitionReadersOf(schema.Table{Name: "customers"}, 8))
+}
+
+func TestReadKeyRanges(t *testing.T) {
+	ranges := SplitIntegerKeys(0, 99, 10)
+	read := func(r KeyRange, emit func(int64)) error {
+		start, _ := r.Start.(int64)
+		end, ok := r.End.(int64)
+		if !ok {
+			end = 100
+		}
+		for id := start; id < end; id++ {
+			emit(id)
+		}
+		if start == 50 {
+			return fmt.Errorf("connection reset")
+		}
+		return nil
+	}
+	var ids []int64
+	err := ReadKeyRanges(ranges, 3, read, func(id int64) { ids = append(ids, id) })
+	assert.EqualError(t, err, "couldn't read key range [50, 60): connection reset")
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	assert.Equal(t, 100, len(ids))
+	assert.Equal(t, int64(99), ids[99])
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// PartitionReaders is the number of partitions of a table whose rows are
+// read in parallel with the parallel-export mapping.
+const PartitionReaders = 4
+
+// TablePartitioning is the partitioning of a table, as read from the source.
+type TablePartitioning struct {
+	Method     string
+	Expression string
+	Columns    []string // Names of the columns the partition key is made of.
+	Partitions []schema.Partition
+	Mapping    string // Mapping selected in the source profile.
+}
+
+// PartitionedTableSource is implemented by the InfoSchema of sources with
+// partitioned tables.
+type PartitionedTableSource interface {
+	// GetPartitionedTables returns the partitioning of the partitioned
+	// tables among tables, by schema and name.
+	GetPartitionedTables(tables []SchemaAndName) (map[SchemaAndName]TablePartitioning, error)
+}
+
+// ValidatePartitionMapping returns an error if mapping isn't a mapping of
+// partitioned tables.
+func ValidatePartitionMapping(mapping string) error {
+	switch mapping {
+	case schema.PartitionMappingSingleTable, schema.PartitionMappingParallelExport, schema.PartitionMappingPrimaryKey:
+		return nil
+	}
+	return fmt.Errorf("invalid partition mapping %s, expected one of %s, %s, %s", mapping, schema.PartitionMappingSingleTable, schema.PartitionMappingParallelExport, schema.PartitionMappingPrimaryKey)
+}
+
+// toPartitioning returns the partitioning of the source table whose columns
+// are colNameIdMap.
+func toPartitioning(p TablePartitioning, colNameIdMap map[string]string) *schema.Partitioning {
+	partitioning := &schema.Partitioning{
+		Method:     p.Method,
+		Expression: p.Expression,
+		Partitions: p.Partitions,
+		Mapping:    p.Mapping,
+	}
+	if partitioning.Mapping == "" {
+		partitioning.Mapping = schema.PartitionMappingSingleTable
+	}
+	for _, col := range p.Columns {
+		if colId, ok := colNameIdMap[col]; ok {
+			partitioning.ColIds = append(partitioning.ColIds, colId)
+		}
+	}
+	return partitioning
+}
+
+// partitionPrimaryKeys returns the primary keys of the Spanner table of
+// srcTable: those of srcTable, led by the columns of its partition key when
+// it is folded into the primary key. Tables without primary keys are given
+// a synthetic one, which the partition key isn't folded into.
+func partitionPrimaryKeys(srcTable schema.Table) []schema.Key {
+	p := srcTable.Partitioning
+	if p == nil || p.Mapping != schema.PartitionMappingPrimaryKey || len(srcTable.PrimaryKeys) == 0 {
+		return srcTable.PrimaryKeys
+	}
+	var keys []schema.Key
+	for _, colId := range p.ColIds {
+		key := schema.Key{ColId: colId}
+		for _, k := range srcTable.PrimaryKeys {
+			if k.ColId == colId {
+				key = k
+			}
+		}
+		keys = append(keys, key)
+	}
+	for _, k := range srcTable.PrimaryKeys {
+		if !checkIfColumnIsPartOfPK(k.ColId, keys) {
+			keys = append(keys, k)
+		}
+	}
+	for i := range keys {
+		keys[i].Order = i + 1
+	}
+	return keys
+}
+
+// SetPartitionMapping sets the mapping of the partitions of the source
+// table tableId to Spanner. The primary key of its Spanner table is
+// converted again when the partition key is folded into it, or no longer is.
+func SetPartitionMapping(conv *internal.Conv, tableId, mapping string) error {
+	if err := ValidatePartitionMapping(mapping); err != nil {
+		return err
+	}
+	srcTable, ok := conv.SrcSchema[tableId]
+	if !ok {
+		return fmt.Errorf("table %s not found", tableId)
+	}
+	if srcTable.Partitioning == nil {
+		return fmt.Errorf("table %s isn't partitioned", srcTable.Name)
+	}
+	previous := srcTable.Partitioning.Mapping
+	partitioning := *srcTable.Partitioning
+	partitioning.Mapping = mapping
+	srcTable.Partitioning = &partitioning
+	spTable, ok := conv.SpSchema[tableId]
+	if ok && previous != mapping && (previous == schema.PartitionMappingPrimaryKey || mapping == schema.PartitionMappingPrimaryKey) && len(srcTable.PrimaryKeys) > 0 {
+		for _, colId := range partitioning.ColIds {
+			if _, ok := spTable.ColDefs[colId]; !ok {
+				return fmt.Errorf("column %s of the partition key of table %s was dropped from its Spanner table", srcTable.ColDefs[colId].Name, srcTable.Name)
+			}
+		}
+		spTable.PrimaryKeys = cvtPrimaryKeys(partitionPrimaryKeys(srcTable))
+		conv.SpSchema[tableId] = spTable
+	}
+	conv.SrcSchema[tableId] = srcTable
+	return nil
+}
+
+// ReadsPartitionsInParallel reports whether the rows of table are read from
+// its partitions in parallel.
+func ReadsPartitionsInParallel(table schema.Table) bool {
+	return PartitionReadersOf(table, 0) > 0
+}
+
+// PartitionReadersOf returns the number of partitions of table whose rows
+// are read at a time, or 0 when they aren't read from its partitions. They
+// are with the parallel-export mapping, by PartitionReaders readers, and
+// when parallelReaders, the number of readers of the tables of the source,
+// is above 1.
+func PartitionReadersOf(table schema.Table, parallelReaders int) int {
+	p := table.Partitioning
+	switch {
+	case p == nil || len(p.Partitions) < 2:
+		return 0
+	case parallelReaders > 1:
+		return parallelReaders
+	case p.Mapping == schema.PartitionMappingParallelExport:
+		return PartitionReaders
+	}
+	return 0
+}
+
+// ReadPartitions reads the rows of partitions with up to readers calls to
+// read at a time, which pass the rows they read to emit. The rows are
+// processed by process from the calling goroutine, so that it needn't be
+// safe for concurrent use. It returns the first error of read, after the
+// rows of all partitions are processed.
+func ReadPartitions[T any](partitions []schema.Partition, readers int, read func(p schema.Partition, emit func(T)) error, process func(T)) error {
+	return readInParallel(partitions, readers, func(p schema.Partition) string { return "partition " + p.Name }, read, process)
+}
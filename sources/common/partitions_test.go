@@ -0,0 +1,267 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func partitionedTable(mapping string) schema.Table {
+	return schema.Table{
+		Id:     "t1",
+		Name:   "orders",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "bigint"}, NotNull: true},
+			"c2": {Id: "c2", Name: "region", Type: schema.Type{Name: "varchar"}, NotNull: true},
+			"c3": {Id: "c3", Name: "created_at", Type: schema.Type{Name: "date"}, NotNull: true},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		Partitioning: &schema.Partitioning{
+			Method:     "RANGE COLUMNS",
+			Expression: "`created_at`,`region`",
+			ColIds:     []string{"c3", "c2"},
+			Partitions: []schema.Partition{{Name: "p0"}, {Name: "p1"}},
+			Mapping:    mapping,
+		},
+	}
+}
+
+func TestPartitionPrimaryKeys(t *testing.T) {
+	noPrimaryKey := partitionedTable(schema.PartitionMappingPrimaryKey)
+	noPrimaryKey.PrimaryKeys = nil
+	testCases := []struct {
+		name     string
+		table    schema.Table
+		expected []schema.Key
+	}{
+		{
+			name:     "single table",
+			table:    partitionedTable(schema.PartitionMappingSingleTable),
+			expected: []schema.Key{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		},
+		{
+			name:     "partition key folded into primary key",
+			table:    partitionedTable(schema.PartitionMappingPrimaryKey),
+			expected: []schema.Key{{ColId: "c3", Order: 1}, {ColId: "c2", Order: 2}, {ColId: "c1", Order: 3}},
+		},
+		{
+			name:     "no primary key",
+			table:    noPrimaryKey,
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, partitionPrimaryKeys(tc.table), tc.name)
+	}
+}
+
+func TestToPartitioning(t *testing.T) {
+	p := TablePartitioning{
+		Method:     "LIST",
+		Expression: "region",
+		Columns:    []string{"region", "unknown"},
+		Partitions: []schema.Partition{{Name: "p_eu", Bound: "VALUES IN ('eu')"}},
+	}
+	assert.Equal(t, &schema.Partitioning{
+		Method:     "LIST",
+		Expression: "region",
+		ColIds:     []string{"c2"},
+		Partitions: []schema.Partition{{Name: "p_eu", Bound: "VALUES IN ('eu')"}},
+		Mapping:    schema.PartitionMappingSingleTable,
+	}, toPartitioning(p, map[string]string{"id": "c1", "region": "c2"}))
+}
+
+func TestSetPartitionMapping(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tableId     string
+		mapping     string
+		dropColumn  string
+		expectError bool
+		expectedPKs []ddl.IndexKey
+	}{
+		{
+			name:        "fold partition key into primary key",
+			tableId:     "t1",
+			mapping:     schema.PartitionMappingPrimaryKey,
+			expectedPKs: []ddl.IndexKey{{ColId: "c3", Order: 1}, {ColId: "c2", Order: 2}, {ColId: "c1", Order: 3}},
+		},
+		{
+			name:        "parallel export",
+			tableId:     "t1",
+			mapping:     schema.PartitionMappingParallelExport,
+			expectedPKs: []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		},
+		{
+			name:        "invalid mapping",
+			tableId:     "t1",
+			mapping:     "per-partition",
+			expectError: true,
+			expectedPKs: []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		},
+		{
+			name:        "unknown table",
+			tableId:     "t2",
+			mapping:     schema.PartitionMappingPrimaryKey,
+			expectError: true,
+			expectedPKs: []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		},
+		{
+			name:        "partition column dropped",
+			tableId:     "t1",
+			mapping:     schema.PartitionMappingPrimaryKey,
+			dropColumn:  "c3",
+			expectError: true,
+			expectedPKs: []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		srcTable := partitionedTable(schema.PartitionMappingSingleTable)
+		conv.SrcSchema["t1"] = srcTable
+		conv.SpSchema["t1"] = ddl.CreateTable{
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Id: "c1", Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Id: "c2", Name: "region", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+				"c3": {Id: "c3", Name: "created_at", T: ddl.Type{Name: ddl.Date}, NotNull: true},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		}
+		if tc.dropColumn != "" {
+			delete(conv.SpSchema["t1"].ColDefs, tc.dropColumn)
+		}
+		err := SetPartitionMapping(conv, tc.tableId, tc.mapping)
+		assert.Equal(t, tc.expectError, err != nil, tc.name)
+		assert.Equal(t, tc.expectedPKs, conv.SpSchema["t1"].PrimaryKeys, tc.name)
+		if !tc.expectError {
+			assert.Equal(t, tc.mapping, conv.SrcSchema["t1"].Partitioning.Mapping, tc.name)
+		}
+		// The partitioning of the table it was set from isn't changed.
+		assert.Equal(t, schema.PartitionMappingSingleTable, srcTable.Partitioning.Mapping, tc.name)
+	}
+}
+
+func TestReadsPartitionsInParallel(t *testing.T) {
+	onePartition := partitionedTable(schema.PartitionMappingParallelExport)
+	onePartition.Partitioning.Partitions = onePartition.Partitioning.Partitions[:1]
+	assert.True(t, ReadsPartitionsInParallel(partitionedTable(schema.PartitionMappingParallelExport)))
+	assert.False(t, ReadsPartitionsInParallel(partitionedTable(schema.PartitionMappingSingleTable)))
+	assert.False(t, ReadsPartitionsInParallel(onePartition))
+	assert.False(t, ReadsPartitionsInParallel(schema.Table{Name: "customers"}))
+}
+
+func TestReadPartitions(t *testing.T) {
+	var partitions []schema.Partition
+	for i := 0; i < 2*PartitionReaders+1; i++ {
+		partitions = append(partitions, schema.Partition{Name: fmt.Sprintf("p%d", i)})
+	}
+	read := func(p schema.Partition, emit func(string)) error {
+		for i := 0; i < 3; i++ {
+			emit(fmt.Sprintf("%s-%d", p.Name, i))
+		}
+		if p.Name == "p3" {
+			return fmt.Errorf("connection reset")
+		}
+		return nil
+	}
+	var rows []string
+	err := ReadPartitions(partitions, PartitionReaders, read, func(row string) { rows = append(rows, row) })
+	assert.EqualError(t, err, "couldn't read partition p3: connection reset")
+	assert.Equal(t, 3*len(partitions), len(rows))
+	sort.Strings(rows)
+	assert.Equal(t, "p0-0", rows[0])
+
+	err = ReadPartitions(nil, PartitionReaders, read, func(row string) { t.Errorf("unexpected row %s", row) })
+	assert.Nil(t, err)
+}
+
+// fakePartitionedTableSource is an InfoSchema of tables with the columns id
+// and created_at, whose table orders is partitioned.
+type fakePartitionedTableSource struct {
+	fakeBulkMetadataSource
+	partitionErr error
+}
+
+func (f fakePartitionedTableSource) GetColumns(conv *internal.Conv, table SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	id, createdAt := internal.GenerateColumnId(), internal.GenerateColumnId()
+	colDefs := map[string]schema.Column{
+		id:        {Id: id, Name: "id", Type: schema.Type{Name: "bigint"}},
+		createdAt: {Id: createdAt, Name: "created_at", Type: schema.Type{Name: "date"}},
+	}
+	return colDefs, []string{id, createdAt}, nil
+}
+
+func (f fakePartitionedTableSource) GetPartitionedTables(tables []SchemaAndName) (map[SchemaAndName]TablePartitioning, error) {
+	if f.partitionErr != nil {
+		return nil, f.partitionErr
+	}
+	return map[SchemaAndName]TablePartitioning{
+		{Schema: "public", Name: "orders"}: {
+			Method:     "RANGE",
+			Expression: "created_at",
+			Columns:    []string{"created_at"},
+			Partitions: []schema.Partition{{Name: "orders_2024"}, {Name: "orders_2025"}},
+			Mapping:    schema.PartitionMappingParallelExport,
+		},
+	}, nil
+}
+
+func TestGenerateSrcSchemaPartitionedTables(t *testing.T) {
+	logger.Log = zap.NewNop()
+	testCases := []struct {
+		name         string
+		partitionErr error
+		partitioned  bool
+	}{
+		{name: "partitioned table", partitioned: true},
+		{name: "partitioned tables error", partitionErr: fmt.Errorf("permission denied"), partitioned: false},
+	}
+	for _, tc := range testCases {
+		tables := []SchemaAndName{{Schema: "public", Name: "orders"}, {Schema: "public", Name: "customers"}}
+		f := fakePartitionedTableSource{
+			fakeBulkMetadataSource: fakeBulkMetadataSource{tables: tables, mu: &sync.Mutex{}, perTable: map[string]bool{}},
+			partitionErr:           tc.partitionErr,
+		}
+		conv := internal.MakeConv()
+		is := InfoSchemaImpl{}
+		_, err := is.GenerateSrcSchema(conv, f, 2)
+		assert.Nil(t, err, tc.name)
+		for _, table := range conv.SrcSchema {
+			if table.Name != "orders" || !tc.partitioned {
+				assert.Nil(t, table.Partitioning, tc.name)
+				continue
+			}
+			assert.Equal(t, "RANGE", table.Partitioning.Method, tc.name)
+			assert.Equal(t, []string{table.ColNameIdMap["created_at"]}, table.Partitioning.ColIds, tc.name)
+			assert.Equal(t, schema.PartitionMappingParallelExport, table.Partitioning.Mapping, tc.name)
+			assert.Equal(t, 2, len(table.Partitioning.Partitions), tc.name)
+		}
+	}
+}
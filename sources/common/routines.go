@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// RoutineSource is implemented by the InfoSchema of sources whose stored
+// procedures, functions and triggers are inventoried in the conversion
+// report.
+type RoutineSource interface {
+	// GetRoutines returns the stored procedures, functions and triggers of
+	// the source database, with their bodies.
+	GetRoutines() ([]schema.Routine, error)
+}
+
+// processRoutines adds the routines of source to conv.
+func processRoutines(conv *internal.Conv, source RoutineSource) {
+	routines, err := source.GetRoutines()
+	if err != nil {
+		logger.Log.Warn(fmt.Sprintf("couldn't get stored procedures, functions and triggers, they won't be reported: %v", err))
+	}
+	if conv.SrcRoutines == nil {
+		conv.SrcRoutines = make(map[string]schema.Routine)
+	}
+	for _, routine := range routines {
+		switch routine.Kind {
+		case schema.RoutineProcedure:
+			routine.Id = internal.GenerateStoredProcedureId()
+		case schema.RoutineFunction:
+			routine.Id = internal.GenerateFunctionId()
+		default:
+			routine.Id = internal.GenerateTriggerId()
+		}
+		conv.SrcRoutines[routine.Id] = routine
+	}
+}
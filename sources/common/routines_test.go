@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal/reports"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/proto/migration"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+type mockRoutineSource struct {
+	routines []schema.Routine
+	err      error
+}
+
+func (m mockRoutineSource) GetRoutines() ([]schema.Routine, error) {
+	return m.routines, m.err
+}
+
+func TestProcessRoutines(t *testing.T) {
+	conv := internal.MakeConv()
+	processRoutines(conv, mockRoutineSource{routines: []schema.Routine{
+		{Name: "archive_orders", Kind: schema.RoutineProcedure},
+		{Name: "discount", Kind: schema.RoutineFunction},
+		{Name: "audit_orders", Kind: schema.RoutineTrigger, Table: "orders"},
+	}})
+	prefixes := make(map[string]string)
+	for id, r := range conv.SrcRoutines {
+		assert.Equal(t, id, r.Id)
+		prefixes[r.Name] = id[:2]
+	}
+	assert.Equal(t, map[string]string{"archive_orders": "sp", "discount": "fn", "audit_orders": "tr"}, prefixes)
+
+	// Routines read before an error are still reported.
+	conv = internal.MakeConv()
+	processRoutines(conv, mockRoutineSource{routines: []schema.Routine{{Name: "discount", Kind: schema.RoutineFunction}}, err: fmt.Errorf("couldn't get triggers")})
+	assert.Len(t, conv.SrcRoutines, 1)
+}
+
+func TestRoutineReports(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.Audit.MigrationType = migration.MigrationData_SCHEMA_ONLY.Enum()
+	loop := "BEGIN\n  DECLARE done INT DEFAULT 0;\n  DECLARE c CURSOR FOR SELECT id FROM orders;\n" +
+		"  WHILE done = 0 DO\n    IF id > 10 THEN\n      SET @q = CONCAT('DELETE FROM ', t);\n      PREPARE s FROM @q;\n      EXECUTE s;\n    END IF;\n  END WHILE;\n" +
+		"  COMMIT;\nEND"
+	processRoutines(conv, mockRoutineSource{routines: []schema.Routine{
+		{Name: "archive_orders", Schema: "shop", Kind: schema.RoutineProcedure, Body: loop},
+		{Name: "discount", Schema: "shop", Kind: schema.RoutineFunction, Body: "RETURN price * 0.9"},
+		{Name: "audit_orders", Schema: "shop", Kind: schema.RoutineTrigger, Table: "orders", Timing: "AFTER", Events: []string{"INSERT"},
+			Body: "INSERT INTO audit (id) VALUES (NEW.id)"},
+		{Name: "check_orders", Schema: "shop", Kind: schema.RoutineTrigger, Table: "orders", Timing: "BEFORE", Events: []string{"UPDATE"},
+			Body: "IF NEW.total < 0 THEN SET NEW.total = 0; END IF"},
+	}})
+	reportGenerator := reports.ReportImpl{}
+	structuredReport := reportGenerator.GenerateStructuredReport(constants.MYSQL, "shop", conv, nil, true, true)
+	var summaries []string
+	for _, r := range structuredReport.RoutineReports {
+		summaries = append(summaries, fmt.Sprintf("%s %s %s %d", r.Kind, r.Name, r.Complexity, r.Score))
+	}
+	// The procedure scores 8 for its statements, 2 for each branch and loop,
+	// 3 for its cursor, 5 for its dynamic statement and 3 for its commit.
+	assert.Equal(t, []string{
+		"TRIGGER audit_orders LOW 1",
+		"TRIGGER check_orders LOW 3",
+		"PROCEDURE archive_orders MEDIUM 23",
+		"FUNCTION discount LOW 1",
+	}, summaries)
+	assert.True(t, strings.HasPrefix(structuredReport.RoutineReports[0].Suggestion, "Capture the changes to orders with a change stream"))
+	assert.True(t, strings.HasPrefix(structuredReport.RoutineReports[1].Suggestion, "Reimplement the trigger in the application"))
+	assert.True(t, strings.HasPrefix(structuredReport.RoutineReports[3].Suggestion, "Inline the function"))
+
+	buf := new(bytes.Buffer)
+	w := bufio.NewWriter(buf)
+	reportGenerator.GenerateTextReport(structuredReport, w)
+	w.Flush()
+	assert.Contains(t, buf.String(), "Trigger audit_orders on orders (AFTER INSERT): complexity LOW (score 1).\n"+
+		"Suggestion: Capture the changes to orders with a change stream and reproduce the\n"+
+		"effects of the trigger in a Cloud Function processing them, or in the application\n"+
+		"in the transactions writing to orders.\n"+
+		"    INSERT INTO audit (id) VALUES (NEW.id)\n")
+}
+
+func TestTriggerChangeStreamReports(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.Audit.MigrationType = migration.MigrationData_SCHEMA_ONLY.Enum()
+	conv.SrcSchema = map[string]schema.Table{"t1": {Name: "orders", Id: "t1"}}
+	conv.SpSchema = ddl.Schema{"t1": {Name: "orders", Id: "t1"}}
+	processRoutines(conv, mockRoutineSource{routines: []schema.Routine{
+		{Name: "audit_orders", Schema: "shop", Kind: schema.RoutineTrigger, Table: "orders", Timing: "AFTER", Events: []string{"UPDATE"},
+			Body: "INSERT INTO audit (id, total) VALUES (OLD.id, OLD.total)"},
+		{Name: "check_orders", Schema: "shop", Kind: schema.RoutineTrigger, Table: "orders", Timing: "BEFORE", Events: []string{"INSERT"},
+			Body: "IF NEW.total < 0 THEN SET NEW.total = 0; END IF"},
+		{Name: "discount", Schema: "shop", Kind: schema.RoutineFunction, Body: "RETURN price * 0.9"},
+	}})
+	reportGenerator := reports.ReportImpl{}
+	structuredReport := reportGenerator.GenerateStructuredReport(constants.MYSQL, "shop", conv, nil, true, true)
+	// Both triggers share the change stream of their table, which captures
+	// old values for the update trigger.
+	for _, r := range structuredReport.RoutineReports[:2] {
+		assert.Equal(t, "CREATE CHANGE STREAM orders_changes FOR orders OPTIONS (value_capture_type = 'NEW_ROW_AND_OLD_VALUES')", r.ChangeStream)
+	}
+	assert.True(t, strings.HasPrefix(structuredReport.RoutineReports[0].ConsumerStub, "Read the change stream orders_changes"))
+	assert.Contains(t, structuredReport.RoutineReports[0].ConsumerStub, "with mod type UPDATE")
+	assert.Contains(t, structuredReport.RoutineReports[0].ConsumerStub, "the OLD row in its old values")
+	assert.NotContains(t, structuredReport.RoutineReports[1].ConsumerStub, "OLD row")
+	assert.Contains(t, structuredReport.RoutineReports[1].ConsumerStub, "As the trigger runs BEFORE the change")
+	assert.Empty(t, structuredReport.RoutineReports[2].ChangeStream)
+
+	buf := new(bytes.Buffer)
+	w := bufio.NewWriter(buf)
+	reportGenerator.GenerateTextReport(structuredReport, w)
+	w.Flush()
+	assert.Contains(t, buf.String(), "Change stream: CREATE CHANGE STREAM orders_changes FOR orders OPTIONS (value_capture_type = 'NEW_ROW_AND_OLD_VALUES');\n"+
+		"Consumer: Read the change stream orders_changes, e.g. with a Dataflow pipeline")
+}
@@ -0,0 +1,714 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Spanner has no spatial types. Spatial values, which most sources read as
+// WKT and PostGIS as hex-encoded EWKB, are stored as WKT in STRING columns,
+// WKB in BYTES columns or GeoJSON in JSON columns.
+
+// Geometry types, numbered as in WKB.
+const (
+	wkbPoint = iota + 1
+	wkbLineString
+	wkbPolygon
+	wkbMultiPoint
+	wkbMultiLineString
+	wkbMultiPolygon
+	wkbGeometryCollection
+)
+
+var wktNames = []string{"", "POINT", "LINESTRING", "POLYGON", "MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION"}
+var geoJSONTypes = []string{"", "Point", "LineString", "Polygon", "MultiPoint", "MultiLineString", "MultiPolygon", "GeometryCollection"}
+
+// ToSpannerSpatialType maps a source spatial type to the Spanner type spType
+// chosen for it: STRING, BYTES or JSON. Columns are STRING by default.
+func ToSpannerSpatialType(spType string) (ddl.Type, []internal.SchemaIssue) {
+	switch spType {
+	case ddl.Bytes:
+		return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Spatial}
+	case ddl.JSON:
+		return ddl.Type{Name: ddl.JSON}, []internal.SchemaIssue{internal.Spatial}
+	default:
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Spatial}
+	}
+}
+
+// ConvSpatial converts a spatial value in WKT, or in hex-encoded WKB or
+// EWKB, to a value of Spanner type spType: WKT for STRING, WKB for BYTES and
+// GeoJSON for JSON. WKT values of STRING columns are kept as they are.
+func ConvSpatial(spType ddl.Type, val string) (interface{}, error) {
+	if spType.IsArray {
+		return nil, fmt.Errorf("can't convert spatial value to array of %s", spType.Name)
+	}
+	isWKB := isHexWKB(val)
+	if spType.Name == ddl.String && !isWKB {
+		return val, nil
+	}
+	var g geometry
+	var err error
+	if isWKB {
+		g, err = parseHexWKB(val)
+	} else {
+		g, err = parseWKT(val)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't convert spatial value: %w", err)
+	}
+	switch spType.Name {
+	case ddl.String:
+		return g.wkt(), nil
+	case ddl.Bytes:
+		return g.appendWKB(nil), nil
+	case ddl.JSON:
+		b, err := json.Marshal(g.geoJSON())
+		if err != nil {
+			return nil, fmt.Errorf("can't convert spatial value: %w", err)
+		}
+		return string(b), nil
+	}
+	return nil, fmt.Errorf("can't convert spatial value to %s", spType.Name)
+}
+
+// geometry is a parsed spatial value. Points and line strings have points,
+// which empty points have none of. Polygons have their rings as parts, and
+// multi geometries and collections their members.
+type geometry struct {
+	kind   int
+	hasZ   bool
+	hasM   bool
+	points [][]float64
+	parts  []geometry
+}
+
+func (g geometry) dims() int {
+	n := 2
+	if g.hasZ {
+		n++
+	}
+	if g.hasM {
+		n++
+	}
+	return n
+}
+
+func (g geometry) isEmpty() bool {
+	if g.kind == wkbPoint || g.kind == wkbLineString {
+		return len(g.points) == 0
+	}
+	return len(g.parts) == 0
+}
+
+// setDims sets the dimensions of g and its parts.
+func (g *geometry) setDims(hasZ, hasM bool) {
+	g.hasZ, g.hasM = hasZ, hasM
+	for i := range g.parts {
+		g.parts[i].setDims(hasZ, hasM)
+	}
+}
+
+// checkDims returns an error if a point of g doesn't have n ordinates.
+func (g geometry) checkDims(n int) error {
+	for _, p := range g.points {
+		if len(p) != n {
+			return fmt.Errorf("point has %d ordinates, expected %d", len(p), n)
+		}
+	}
+	for _, part := range g.parts {
+		if err := part.checkDims(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// firstPoint returns the first point of g, or nil if g is empty.
+func (g geometry) firstPoint() []float64 {
+	if len(g.points) > 0 {
+		return g.points[0]
+	}
+	for _, part := range g.parts {
+		if p := part.firstPoint(); p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+func geometryKind(name string) int {
+	for i, n := range wktNames {
+		if i > 0 && n == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// isHexWKB returns true if val is hex-encoded WKB or EWKB, which starts with
+// its byte order, 00 or 01.
+func isHexWKB(val string) bool {
+	if len(val) < 10 || len(val)%2 != 0 || !(strings.HasPrefix(val, "00") || strings.HasPrefix(val, "01")) {
+		return false
+	}
+	for _, c := range val {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// wktParser parses WKT, and EWKT with an SRID=n; prefix, which is ignored.
+type wktParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseWKT(s string) (geometry, error) {
+	if strings.HasPrefix(strings.ToUpper(s), "SRID=") {
+		if i := strings.Index(s, ";"); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+	p := &wktParser{tokens: tokenizeWKT(s)}
+	g, err := p.parseGeometry()
+	if err != nil {
+		return geometry{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return geometry{}, fmt.Errorf("unexpected %q in WKT", p.tokens[p.pos])
+	}
+	return g, nil
+}
+
+func tokenizeWKT(s string) []string {
+	var tokens []string
+	start := -1
+	for i, c := range s {
+		isDelim := c == '(' || c == ')' || c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r'
+		if isDelim && start >= 0 {
+			tokens = append(tokens, s[start:i])
+			start = -1
+		}
+		switch {
+		case c == '(' || c == ')' || c == ',':
+			tokens = append(tokens, string(c))
+		case !isDelim && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+func (p *wktParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return strings.ToUpper(p.tokens[p.pos])
+	}
+	return ""
+}
+
+func (p *wktParser) next() string {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+func (p *wktParser) expect(t string) error {
+	if got := p.next(); got != t {
+		return fmt.Errorf("expected %q in WKT, found %q", t, got)
+	}
+	return nil
+}
+
+// parseGeometry parses a tagged geometry e.g. POINT Z (1 2 3). Geometries
+// without Z or M get their dimensions from the number of ordinates of their
+// points.
+func (p *wktParser) parseGeometry() (geometry, error) {
+	word := p.next()
+	g := geometry{kind: geometryKind(word)}
+	dims := ""
+	if g.kind == 0 {
+		// PostGIS also writes the dimensions after the name e.g. POINTM.
+		for _, suffix := range []string{"ZM", "Z", "M"} {
+			if kind := geometryKind(strings.TrimSuffix(word, suffix)); strings.HasSuffix(word, suffix) && kind != 0 {
+				g.kind, dims = kind, suffix
+				break
+			}
+		}
+		if g.kind == 0 {
+			return geometry{}, fmt.Errorf("unsupported geometry type %q", word)
+		}
+	}
+	if t := p.peek(); dims == "" && (t == "Z" || t == "M" || t == "ZM") {
+		dims = p.next()
+	}
+	if p.peek() == "EMPTY" {
+		p.next()
+	} else if err := p.parseBody(&g); err != nil {
+		return geometry{}, err
+	}
+	switch dims {
+	case "":
+		switch len(g.firstPoint()) {
+		case 0, 2:
+		case 3:
+			g.setDims(true, false)
+		case 4:
+			g.setDims(true, true)
+		default:
+			return geometry{}, fmt.Errorf("point has %d ordinates", len(g.firstPoint()))
+		}
+	default:
+		g.setDims(strings.Contains(dims, "Z"), strings.Contains(dims, "M"))
+	}
+	return g, g.checkDims(g.dims())
+}
+
+// parseBody parses the parenthesized body of a geometry of kind g.kind.
+func (p *wktParser) parseBody(g *geometry) error {
+	switch g.kind {
+	case wkbPoint:
+		if err := p.expect("("); err != nil {
+			return err
+		}
+		point, err := p.parsePoint()
+		if err != nil {
+			return err
+		}
+		g.points = [][]float64{point}
+		return p.expect(")")
+	case wkbLineString:
+		points, err := p.parsePoints()
+		g.points = points
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		part, err := p.parsePart(g.kind)
+		if err != nil {
+			return err
+		}
+		g.parts = append(g.parts, part)
+		if p.peek() != "," {
+			break
+		}
+		p.next()
+	}
+	return p.expect(")")
+}
+
+// parsePart parses a ring of a polygon or a member of a multi geometry or
+// collection.
+func (p *wktParser) parsePart(kind int) (geometry, error) {
+	var part geometry
+	switch kind {
+	case wkbPolygon:
+		part.kind = wkbLineString
+	case wkbMultiPoint:
+		part.kind = wkbPoint
+	case wkbMultiLineString:
+		part.kind = wkbLineString
+	case wkbMultiPolygon:
+		part.kind = wkbPolygon
+	default:
+		return p.parseGeometry()
+	}
+	switch {
+	case kind != wkbPolygon && p.peek() == "EMPTY":
+		p.next()
+	case kind == wkbMultiPoint && p.peek() != "(":
+		// Points of multi points may be written without parentheses e.g.
+		// MULTIPOINT(1 2, 3 4).
+		point, err := p.parsePoint()
+		if err != nil {
+			return geometry{}, err
+		}
+		part.points = [][]float64{point}
+	default:
+		if err := p.parseBody(&part); err != nil {
+			return geometry{}, err
+		}
+	}
+	return part, nil
+}
+
+// parsePoints parses a parenthesized list of points.
+func (p *wktParser) parsePoints() ([][]float64, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var points [][]float64
+	for {
+		point, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+		if p.peek() != "," {
+			break
+		}
+		p.next()
+	}
+	return points, p.expect(")")
+}
+
+// parsePoint parses the ordinates of a point.
+func (p *wktParser) parsePoint() ([]float64, error) {
+	var point []float64
+	for t := p.peek(); t != "" && t != "," && t != ")"; t = p.peek() {
+		f, err := strconv.ParseFloat(p.next(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ordinate %q in WKT", t)
+		}
+		point = append(point, f)
+	}
+	if len(point) < 2 {
+		return nil, fmt.Errorf("point has %d ordinates", len(point))
+	}
+	return point, nil
+}
+
+// wkbReader reads WKB in either byte order, with ISO or EWKB dimensions.
+type wkbReader struct {
+	b     []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+// EWKB flags of the geometry type.
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+func parseHexWKB(val string) (geometry, error) {
+	b, err := hex.DecodeString(val)
+	if err != nil {
+		return geometry{}, err
+	}
+	r := &wkbReader{b: b}
+	g, err := r.readGeometry()
+	if err != nil {
+		return geometry{}, err
+	}
+	if r.pos < len(r.b) {
+		return geometry{}, fmt.Errorf("%d unexpected bytes after WKB", len(r.b)-r.pos)
+	}
+	return g, nil
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if r.pos+4 > len(r.b) {
+		return 0, fmt.Errorf("WKB is truncated")
+	}
+	v := r.order.Uint32(r.b[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// readCount reads a number of items, each at least size bytes long.
+func (r *wkbReader) readCount(size int) (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if int64(n)*int64(size) > int64(len(r.b)-r.pos) {
+		return 0, fmt.Errorf("WKB is truncated")
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readPoints(n, dims int) ([][]float64, error) {
+	if r.pos+n*dims*8 > len(r.b) {
+		return nil, fmt.Errorf("WKB is truncated")
+	}
+	points := make([][]float64, n)
+	for i := range points {
+		points[i] = make([]float64, dims)
+		for j := range points[i] {
+			points[i][j] = math.Float64frombits(r.order.Uint64(r.b[r.pos:]))
+			r.pos += 8
+		}
+	}
+	return points, nil
+}
+
+func (r *wkbReader) readGeometry() (geometry, error) {
+	if r.pos >= len(r.b) {
+		return geometry{}, fmt.Errorf("WKB is truncated")
+	}
+	switch r.b[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return geometry{}, fmt.Errorf("invalid WKB byte order %d", r.b[r.pos])
+	}
+	r.pos++
+	code, err := r.readUint32()
+	if err != nil {
+		return geometry{}, err
+	}
+	var g geometry
+	g.hasZ, g.hasM = code&ewkbZ != 0, code&ewkbM != 0
+	if code&ewkbSRID != 0 {
+		if _, err := r.readUint32(); err != nil {
+			return geometry{}, err
+		}
+	}
+	code &^= ewkbZ | ewkbM | ewkbSRID
+	switch code / 1000 {
+	case 1:
+		g.hasZ = true
+	case 2:
+		g.hasM = true
+	case 3:
+		g.hasZ, g.hasM = true, true
+	}
+	g.kind = int(code % 1000)
+	if g.kind < wkbPoint || g.kind > wkbGeometryCollection {
+		return geometry{}, fmt.Errorf("unsupported WKB geometry type %d", code)
+	}
+	dims := g.dims()
+	switch g.kind {
+	case wkbPoint:
+		points, err := r.readPoints(1, dims)
+		if err != nil {
+			return geometry{}, err
+		}
+		// Empty points have NaN ordinates.
+		if !math.IsNaN(points[0][0]) || !math.IsNaN(points[0][1]) {
+			g.points = points
+		}
+	case wkbLineString:
+		n, err := r.readCount(dims * 8)
+		if err != nil {
+			return geometry{}, err
+		}
+		if g.points, err = r.readPoints(n, dims); err != nil {
+			return geometry{}, err
+		}
+	case wkbPolygon:
+		n, err := r.readCount(4)
+		if err != nil {
+			return geometry{}, err
+		}
+		for i := 0; i < n; i++ {
+			m, err := r.readCount(dims * 8)
+			if err != nil {
+				return geometry{}, err
+			}
+			ring := geometry{kind: wkbLineString, hasZ: g.hasZ, hasM: g.hasM}
+			if ring.points, err = r.readPoints(m, dims); err != nil {
+				return geometry{}, err
+			}
+			g.parts = append(g.parts, ring)
+		}
+	default:
+		n, err := r.readCount(5)
+		if err != nil {
+			return geometry{}, err
+		}
+		for i := 0; i < n; i++ {
+			part, err := r.readGeometry()
+			if err != nil {
+				return geometry{}, err
+			}
+			g.parts = append(g.parts, part)
+		}
+	}
+	return g, nil
+}
+
+// appendWKB appends g to b as little-endian WKB, with ISO dimensions.
+func (g geometry) appendWKB(b []byte) []byte {
+	code := uint32(g.kind)
+	if g.hasZ {
+		code += 1000
+	}
+	if g.hasM {
+		code += 2000
+	}
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, code)
+	appendPoint := func(b []byte, p []float64) []byte {
+		for _, f := range p {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(f))
+		}
+		return b
+	}
+	switch g.kind {
+	case wkbPoint:
+		if g.isEmpty() {
+			for i := 0; i < g.dims(); i++ {
+				b = binary.LittleEndian.AppendUint64(b, math.Float64bits(math.NaN()))
+			}
+			return b
+		}
+		return appendPoint(b, g.points[0])
+	case wkbLineString:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.points)))
+		for _, p := range g.points {
+			b = appendPoint(b, p)
+		}
+		return b
+	case wkbPolygon:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.parts)))
+		for _, ring := range g.parts {
+			b = binary.LittleEndian.AppendUint32(b, uint32(len(ring.points)))
+			for _, p := range ring.points {
+				b = appendPoint(b, p)
+			}
+		}
+		return b
+	}
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(g.parts)))
+	for _, part := range g.parts {
+		b = part.appendWKB(b)
+	}
+	return b
+}
+
+// wkt returns g as WKT, in the format of PostGIS' ST_AsText.
+func (g geometry) wkt() string {
+	var sb strings.Builder
+	g.writeWKT(&sb)
+	return sb.String()
+}
+
+func (g geometry) writeWKT(sb *strings.Builder) {
+	sb.WriteString(wktNames[g.kind])
+	switch {
+	case g.hasZ && g.hasM:
+		sb.WriteString(" ZM ")
+	case g.hasZ:
+		sb.WriteString(" Z ")
+	case g.hasM:
+		sb.WriteString(" M ")
+	}
+	if g.isEmpty() {
+		if !g.hasZ && !g.hasM {
+			sb.WriteString(" ")
+		}
+		sb.WriteString("EMPTY")
+		return
+	}
+	g.writeWKTBody(sb)
+}
+
+func (g geometry) writeWKTBody(sb *strings.Builder) {
+	if g.isEmpty() {
+		sb.WriteString("EMPTY")
+		return
+	}
+	sb.WriteString("(")
+	switch g.kind {
+	case wkbPoint, wkbLineString:
+		for i, p := range g.points {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			for j, f := range p {
+				if j > 0 {
+					sb.WriteString(" ")
+				}
+				sb.WriteString(strconv.FormatFloat(f, 'f', -1, 64))
+			}
+		}
+	default:
+		for i, part := range g.parts {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			if g.kind == wkbGeometryCollection {
+				part.writeWKT(sb)
+			} else {
+				part.writeWKTBody(sb)
+			}
+		}
+	}
+	sb.WriteString(")")
+}
+
+// geoJSONGeometry is a GeoJSON geometry object.
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates,omitempty"`
+	Geometries  interface{} `json:"geometries,omitempty"`
+}
+
+// geoJSON returns g as a GeoJSON geometry. GeoJSON positions have no M
+// ordinate, so M values are dropped.
+func (g geometry) geoJSON() geoJSONGeometry {
+	j := geoJSONGeometry{Type: geoJSONTypes[g.kind]}
+	if g.kind == wkbGeometryCollection {
+		geometries := []geoJSONGeometry{}
+		for _, part := range g.parts {
+			geometries = append(geometries, part.geoJSON())
+		}
+		j.Geometries = geometries
+		return j
+	}
+	j.Coordinates = g.geoJSONCoordinates()
+	return j
+}
+
+func (g geometry) geoJSONCoordinates() interface{} {
+	position := func(p []float64) []float64 {
+		if g.hasZ {
+			return p[:3]
+		}
+		return p[:2]
+	}
+	switch g.kind {
+	case wkbPoint:
+		if g.isEmpty() {
+			return []float64{}
+		}
+		return position(g.points[0])
+	case wkbLineString:
+		positions := [][]float64{}
+		for _, p := range g.points {
+			positions = append(positions, position(p))
+		}
+		return positions
+	}
+	parts := []interface{}{}
+	for _, part := range g.parts {
+		parts = append(parts, part.geoJSONCoordinates())
+	}
+	return parts
+}
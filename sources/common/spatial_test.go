@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestToSpannerSpatialType(t *testing.T) {
+	ty, issues := ToSpannerSpatialType("")
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.Spatial}, issues)
+	ty, _ = ToSpannerSpatialType(ddl.Bytes)
+	assert.Equal(t, ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, ty)
+	ty, _ = ToSpannerSpatialType(ddl.JSON)
+	assert.Equal(t, ddl.Type{Name: ddl.JSON}, ty)
+}
+
+func TestConvSpatial(t *testing.T) {
+	stringType := ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+	bytesType := ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}
+	jsonType := ddl.Type{Name: ddl.JSON}
+	// POINT(1 2) as little-endian WKB, and as PostGIS EWKB with SRID 4326.
+	pointWKB, _ := hex.DecodeString("0101000000000000000000f03f0000000000000040")
+	pointEWKB := "0101000020E6100000000000000000F03F0000000000000040"
+	testCases := []struct {
+		name     string
+		spType   ddl.Type
+		val      string
+		expected interface{}
+	}{
+		{"WKT to STRING", stringType, "POINT (1 2)", "POINT (1 2)"},
+		{"EWKB to STRING", stringType, pointEWKB, "POINT(1 2)"},
+		{"WKT to BYTES", bytesType, "POINT(1 2)", pointWKB},
+		{"EWKB to BYTES", bytesType, pointEWKB, pointWKB},
+		{"point to JSON", jsonType, "POINT(1 2)", `{"type":"Point","coordinates":[1,2]}`},
+		{"EWKT to JSON", jsonType, "SRID=4326;POINT(1 2)", `{"type":"Point","coordinates":[1,2]}`},
+		{"point Z to JSON", jsonType, "POINT Z (1 2 3)", `{"type":"Point","coordinates":[1,2,3]}`},
+		{"point M to JSON", jsonType, "POINTM(1 2 3)", `{"type":"Point","coordinates":[1,2]}`},
+		{"empty point to JSON", jsonType, "POINT EMPTY", `{"type":"Point","coordinates":[]}`},
+		{"line string to JSON", jsonType, "LINESTRING(0 0, 1 1.5)", `{"type":"LineString","coordinates":[[0,0],[1,1.5]]}`},
+		{"polygon to JSON", jsonType, "POLYGON ((0 0, 1 0, 1 1, 0 0))", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`},
+		{"multi point to JSON", jsonType, "MULTIPOINT((1 2),(3 4))", `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`},
+		{"multi point without parentheses to JSON", jsonType, "MULTIPOINT(1 2, 3 4)", `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`},
+		{"multi polygon to JSON", jsonType, "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[2,2],[3,2],[3,3],[2,2]]]]}`},
+		{"collection to JSON", jsonType, "GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(0 0,1 1))", `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},{"type":"LineString","coordinates":[[0,0],[1,1]]}]}`},
+		{"empty collection to JSON", jsonType, "GEOMETRYCOLLECTION EMPTY", `{"type":"GeometryCollection","geometries":[]}`},
+		{"EWKB to JSON", jsonType, pointEWKB, `{"type":"Point","coordinates":[1,2]}`},
+	}
+	for _, tc := range testCases {
+		v, err := ConvSpatial(tc.spType, tc.val)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expected, v, tc.name)
+	}
+
+	for _, val := range []string{"CIRCULARSTRING(0 0, 1 1, 2 0)", "POINT(1)", "POINT(1 2", "POINT Z (1 2)", "LINESTRING(0 0, 1 1 1)", "POINT(a b)", "0101000000000000000000f03f"} {
+		_, err := ConvSpatial(jsonType, val)
+		assert.NotNil(t, err, val)
+	}
+	_, err := ConvSpatial(ddl.Type{Name: ddl.Int64}, "POINT(1 2)")
+	assert.NotNil(t, err)
+	_, err = ConvSpatial(ddl.Type{Name: ddl.String, IsArray: true}, "POINT(1 2)")
+	assert.NotNil(t, err)
+}
+
+func TestSpatialWKBRoundTrip(t *testing.T) {
+	for _, wkt := range []string{
+		"POINT(1 2)",
+		"POINT EMPTY",
+		"POINT ZM (1 2 3 4)",
+		"LINESTRING Z (0 0 1,1 1 2)",
+		"POLYGON((0 0,4 0,4 4,0 0),(1 1,2 1,2 2,1 1))",
+		"MULTIPOINT(EMPTY,(1 2))",
+		"MULTILINESTRING((0 0,1 1),(2 2,3 3))",
+		"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2.5 2.5,3 2.5,3 3,2.5 2.5)))",
+		"GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION EMPTY)",
+	} {
+		g, err := parseWKT(wkt)
+		assert.Nil(t, err, wkt)
+		assert.Equal(t, wkt, g.wkt())
+		g, err = parseHexWKB(hex.EncodeToString(g.appendWKB(nil)))
+		assert.Nil(t, err, wkt)
+		assert.Equal(t, wkt, g.wkt())
+	}
+}
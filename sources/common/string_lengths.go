@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ColumnLengthSampler is implemented by the InfoSchemas that can sample the
+// data of a table to measure the length of the values of its columns.
+type ColumnLengthSampler interface {
+	// GetMaxColumnLengths returns the length of the longest value of each of
+	// cols in at most sampleSize rows of table. cols maps column names to
+	// whether they hold bytes, whose length is counted in bytes, rather than
+	// characters. Columns with no non-null value are left out.
+	GetMaxColumnLengths(table SchemaAndName, cols map[string]bool, sampleSize int64) (map[string]int64, error)
+}
+
+// InferStringLengths gives the STRING and BYTES columns of unbounded length
+// of the Spanner schema, e.g. mapped from source TEXT columns, the length of
+// their longest value in sampleSize rows of the source table times margin,
+// see internal.ApplyInferredLength. If margin is 0,
+// internal.DefaultStringLengthMargin is used. Tables whose data can't be
+// sampled keep their columns unchanged.
+func InferStringLengths(conv *internal.Conv, sampler ColumnLengthSampler, sampleSize int64, margin float64) error {
+	if margin == 0 {
+		margin = internal.DefaultStringLengthMargin
+	}
+	if margin < 1 {
+		return fmt.Errorf("string length margin must be at least 1, got %v", margin)
+	}
+	if sampleSize <= 0 {
+		return fmt.Errorf("string length sample size must be positive, got %d", sampleSize)
+	}
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		srcTable, ok := conv.SrcSchema[tableId]
+		if !ok {
+			continue
+		}
+		cols := make(map[string]bool)
+		colIds := make(map[string]string)
+		for _, colId := range conv.SpSchema[tableId].ColIds {
+			srcCol, ok := srcTable.ColDefs[colId]
+			cd := conv.SpSchema[tableId].ColDefs[colId]
+			if !ok || !internal.HasInferableLength(conv, tableId, cd) {
+				continue
+			}
+			cols[srcCol.Name] = cd.T.Name == ddl.Bytes
+			colIds[srcCol.Name] = colId
+		}
+		if len(cols) == 0 {
+			continue
+		}
+		lengths, err := sampler.GetMaxColumnLengths(SchemaAndName{Schema: srcTable.Schema, Name: srcTable.Name, Id: tableId}, cols, sampleSize)
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("can't sample data of table %s to infer string lengths: %v", srcTable.Name, err))
+			continue
+		}
+		for name, colId := range colIds {
+			internal.ApplyInferredLength(conv, tableId, colId, lengths[name], margin)
+		}
+		ComputeNonKeyColumnSize(conv, tableId)
+	}
+	return nil
+}
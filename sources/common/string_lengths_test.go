@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+type fakeColumnLengthSampler struct {
+	lengths map[string]map[string]int64 // Maps table name to column name to length.
+	cols    map[string]map[string]bool  // Columns sampled, by table name.
+}
+
+func (s *fakeColumnLengthSampler) GetMaxColumnLengths(table SchemaAndName, cols map[string]bool, sampleSize int64) (map[string]int64, error) {
+	if s.cols == nil {
+		s.cols = make(map[string]map[string]bool)
+	}
+	s.cols[table.Name] = cols
+	lengths, ok := s.lengths[table.Name]
+	if !ok {
+		return nil, fmt.Errorf("can't sample table %s", table.Name)
+	}
+	return lengths, nil
+}
+
+func TestInferStringLengths(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "notes", Schema: "db", Id: "t1", ColDefs: map[string]schema.Column{
+			"c1": {Name: "body", Id: "c1"},
+			"c2": {Name: "title", Id: "c2"},
+			"c3": {Name: "data", Id: "c3"},
+		}},
+		"t2": {Name: "broken", Schema: "db", Id: "t2", ColDefs: map[string]schema.Column{
+			"c1": {Name: "body", Id: "c1"},
+		}},
+	}
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "notes", Id: "t1", ColIds: []string{"c1", "c2", "c3", "c4"}, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "body", Id: "c1", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c2": {Name: "title", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 100}},
+			"c3": {Name: "data", Id: "c3", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			// Columns added during the conversion aren't sampled.
+			"c4": {Name: "migration_shard_id", Id: "c4", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		}},
+		"t2": {Name: "broken", Id: "t2", ColIds: []string{"c1"}, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "body", Id: "c1", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		}},
+	}
+	sampler := &fakeColumnLengthSampler{lengths: map[string]map[string]int64{"notes": {"body": 200, "data": 10}}}
+	assert.Nil(t, InferStringLengths(conv, sampler, 1000, 0))
+	assert.Equal(t, map[string]bool{"body": false, "data": true}, sampler.cols["notes"])
+	assert.Equal(t, int64(300), conv.SpSchema["t1"].ColDefs["c1"].T.Len)
+	assert.Equal(t, int64(100), conv.SpSchema["t1"].ColDefs["c2"].T.Len)
+	assert.Equal(t, int64(15), conv.SpSchema["t1"].ColDefs["c3"].T.Len)
+	assert.Equal(t, int64(ddl.MaxLength), conv.SpSchema["t1"].ColDefs["c4"].T.Len)
+	// Tables that can't be sampled are left unchanged.
+	assert.Equal(t, int64(ddl.MaxLength), conv.SpSchema["t2"].ColDefs["c1"].T.Len)
+	assert.Equal(t, map[string]map[string]internal.InferredLength{
+		"t1": {"c1": {MaxSampled: 200, Length: 300}, "c3": {MaxSampled: 10, Length: 15}},
+	}, conv.InferredLengths)
+
+	// Inference can be re-run with another margin.
+	assert.Nil(t, InferStringLengths(conv, sampler, 1000, 2))
+	assert.Equal(t, int64(400), conv.SpSchema["t1"].ColDefs["c1"].T.Len)
+
+	assert.NotNil(t, InferStringLengths(conv, sampler, 1000, 0.5))
+	assert.NotNil(t, InferStringLengths(conv, sampler, 0, 1.5))
+}
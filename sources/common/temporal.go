@@ -0,0 +1,47 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// TemporalTable is a system-versioned temporal table, or its history table,
+// as read from the source.
+type TemporalTable struct {
+	CurrentTable string // Name of the system-versioned table, for history tables.
+	HistoryTable string // Name of the history table, for system-versioned tables.
+	PeriodStart  string // Name of the column holding the start of the period.
+	PeriodEnd    string // Name of the column holding the end of the period.
+}
+
+// TemporalTableSource is implemented by the InfoSchema of sources with
+// system-versioned temporal tables.
+type TemporalTableSource interface {
+	// GetTemporalTables returns the system-versioned tables among tables,
+	// and their history tables, by schema and name.
+	GetTemporalTables(tables []SchemaAndName) (map[SchemaAndName]TemporalTable, error)
+}
+
+// toTemporal returns the temporal table description of the source table
+// whose columns are colNameIdMap.
+func toTemporal(t TemporalTable, colNameIdMap map[string]string) *schema.Temporal {
+	return &schema.Temporal{
+		CurrentTable:     t.CurrentTable,
+		HistoryTable:     t.HistoryTable,
+		PeriodStartColId: colNameIdMap[t.PeriodStart],
+		PeriodEndColId:   colNameIdMap[t.PeriodEnd],
+	}
+}
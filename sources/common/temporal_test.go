@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+func TestToTemporal(t *testing.T) {
+	tt := TemporalTable{HistoryTable: "employee_history", PeriodStart: "valid_from", PeriodEnd: "valid_to"}
+	assert.Equal(t, &schema.Temporal{HistoryTable: "employee_history", PeriodStartColId: "c2", PeriodEndColId: "c3"},
+		toTemporal(tt, map[string]string{"id": "c1", "valid_from": "c2", "valid_to": "c3"}))
+}
+
+// fakeTemporalTableSource is an InfoSchema of tables with the columns id
+// and created_at, whose table orders is system-versioned.
+type fakeTemporalTableSource struct {
+	fakePartitionedTableSource
+}
+
+func (f fakeTemporalTableSource) GetPartitionedTables(tables []SchemaAndName) (map[SchemaAndName]TablePartitioning, error) {
+	return nil, nil
+}
+
+func (f fakeTemporalTableSource) GetTemporalTables(tables []SchemaAndName) (map[SchemaAndName]TemporalTable, error) {
+	return map[SchemaAndName]TemporalTable{
+		{Schema: "public", Name: "orders"}: {HistoryTable: "orders_history", PeriodStart: "created_at", PeriodEnd: "missing"},
+	}, nil
+}
+
+func TestGenerateSrcSchemaTemporalTables(t *testing.T) {
+	logger.Log = zap.NewNop()
+	tables := []SchemaAndName{{Schema: "public", Name: "orders"}, {Schema: "public", Name: "customers"}}
+	f := fakeTemporalTableSource{fakePartitionedTableSource{
+		fakeBulkMetadataSource: fakeBulkMetadataSource{tables: tables, mu: &sync.Mutex{}, perTable: map[string]bool{}},
+	}}
+	conv := internal.MakeConv()
+	_, err := (&InfoSchemaImpl{}).GenerateSrcSchema(conv, f, 2)
+	assert.Nil(t, err)
+	for _, table := range conv.SrcSchema {
+		if table.Name != "orders" {
+			assert.Nil(t, table.Temporal)
+			continue
+		}
+		assert.Equal(t, &schema.Temporal{HistoryTable: "orders_history", PeriodStartColId: table.ColNameIdMap["created_at"]}, table.Temporal)
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -59,6 +60,23 @@ type OptionProvider interface {
 	GetTypeOption(srcTypeName string, spType ddl.Type) string
 }
 
+// GeneratedColumnTranslator is an interface that can be implemented by ToDdl
+// implementations for sources with generated columns. Columns whose expression
+// can't be translated are converted to regular columns, whose values are
+// copied from the source during data migration.
+type GeneratedColumnTranslator interface {
+	ToSpannerGeneratedExpression(conv *internal.Conv, expr string) (string, bool)
+}
+
+// ColumnCheckProvider is an interface that can be implemented by ToDdl
+// implementations for sources with types mapped to Spanner types accepting
+// more values, e.g. network addresses mapped to STRING. The CHECK constraint
+// expression returned for a column restricts its values to those of the
+// source type, and is empty for columns without restrictions.
+type ColumnCheckProvider interface {
+	GetColumnCheckExpr(conv *internal.Conv, srcType schema.Type, spCol ddl.ColumnDef) string
+}
+
 type SchemaToSpannerInterface interface {
 	SchemaToSpannerDDL(conv *internal.Conv, toddl ToDdl, attributes internal.AdditionalSchemaAttributes) error
 	SchemaToSpannerDDLHelper(conv *internal.Conv, toddl ToDdl, srcTable schema.Table, isRestore bool) error
@@ -92,13 +110,15 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDL(conv *internal.Conv, toddl ToD
 		ss.SchemaToSpannerDDLHelper(conv, toddl, srcTable, false)
 	}
 
+	cvtViews(conv)
+
 	conv.AddPrimaryKeys()
 	if attributes.IsSharded {
 		conv.AddShardIdColumn()
 	}
 
-	if conv.Source == constants.MYSQL && conv.SpProjectId != "" && conv.SpInstanceId != "" {
-		// Process and verify Spanner DDL expressions for MYSQL
+	if (conv.Source == constants.MYSQL || conv.Source == constants.MARIADB) && conv.SpProjectId != "" && conv.SpInstanceId != "" {
+		// Process and verify Spanner DDL expressions for MYSQL and MariaDB
 		expressionDetails := ss.DdlV.GetSourceExpressionDetails(conv, tableIds)
 		expressions, err := ss.DdlV.VerifySpannerDDL(conv, expressionDetails)
 		if err != nil && !strings.Contains(err.Error(), "expressions either failed verification") {
@@ -107,9 +127,9 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDL(conv *internal.Conv, toddl ToD
 		spannerSchemaApplyExpressions(conv, expressions)
 	}
 
-	if (conv.Source == constants.MYSQL || conv.Source == constants.MYSQLDUMP) && conv.SpProjectId != "" && conv.SpInstanceId != "" {
+	if (conv.Source == constants.MYSQL || conv.Source == constants.MYSQLDUMP || conv.Source == constants.MARIADB) && conv.SpProjectId != "" && conv.SpInstanceId != "" {
 		if ss.ExpressionVerificationAccessor != nil {
-			// Process and verify Check constraints for MySQL and MySQLDump flow only
+			// Process and verify Check constraints for MySQL, MySQLDump and MariaDB flow only
 			err := ss.VerifyExpressions(conv)
 			if err != nil {
 				return err
@@ -340,6 +360,7 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 	)
 
 	columnLevelIssues := make(map[string][]internal.SchemaIssue)
+	checkConstraints := cvtCheckConstraint(conv, srcTable.CheckConstraints)
 
 	// Iterate over columns using ColNames order.
 	for _, srcColId := range srcTable.ColIds {
@@ -361,12 +382,27 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 		if isChanged && (srcCol.Name != colName) {
 			issues = append(issues, internal.IllegalName)
 		}
-		if srcCol.Ignored.Default {
+		defaultValue, defaultConverted := ConvertDefaultValue(conv, srcCol, ty)
+		if srcCol.Ignored.Default && !defaultConverted {
 			issues = append(issues, internal.DefaultValue)
 		}
 		if srcCol.Ignored.AutoIncrement { // TODO(adibh) - check why this is not there in postgres
 			issues = append(issues, internal.AutoIncrement)
 		}
+		// Distribution and sort keys have no Spanner equivalent, but hint at
+		// how the table should be interleaved and keyed.
+		if srcCol.DistKey {
+			issues = append(issues, internal.DistributionKey)
+		}
+		if srcCol.SortKeyOrder > 0 && !isPk {
+			issues = append(issues, internal.SortKey)
+		}
+		if !IsBinaryCollation(srcCol.Collation) && isKeyOrIndexed(srcColId, srcTable) {
+			issues = append(issues, internal.CollationChanged)
+		}
+		if t := srcTable.Temporal; t != nil && (srcColId == t.PeriodStartColId || srcColId == t.PeriodEndColId) {
+			issues = append(issues, internal.TemporalPeriod)
+		}
 		// Set the not null constraint to false for unsupported source datatypes
 		isNotNull := srcCol.NotNull
 		if findSchemaIssue(issues, internal.NoGoodType) != -1 {
@@ -387,21 +423,49 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 				if err != nil {
 					srcCol.Ignored.AutoIncrement = true
 					issues = append(issues, internal.AutoIncrement)
+				} else if srcAutoGen.GenerationType == constants.AUTO_RANDOM {
+					issues = append(issues, internal.AutoRandom)
 				} else {
 					issues = append(issues, internal.SequenceCreated)
+					if srcSequence, found := findSequenceByName(conv.SrcSequences, srcAutoGen.Name); found {
+						if _, unsupported := internal.ToSpannerSequence(srcSequence); len(unsupported) > 0 {
+							issues = append(issues, internal.SequenceOptionUnsupported)
+						}
+					}
+				}
+			}
+		}
+		generatedCol := srcCol.GeneratedColumn
+		if translator, ok := toddl.(GeneratedColumnTranslator); ok && generatedCol.IsPresent {
+			if expr, ok := translator.ToSpannerGeneratedExpression(conv, generatedCol.Value.Statement); ok {
+				generatedCol = ddl.GeneratedColumn{
+					IsPresent: true,
+					Value:     ddl.Expression{ExpressionId: generatedCol.Value.ExpressionId, Statement: expr},
+					Type:      ddl.GeneratedStored,
 				}
+			} else {
+				generatedCol = ddl.GeneratedColumn{}
+				issues = append(issues, internal.GeneratedColumnMaterialized)
 			}
 		}
 		if len(issues) > 0 {
 			columnLevelIssues[srcColId] = issues
 		}
+		// Source comments are carried over, other columns are commented
+		// with the source column they're converted from.
+		colComment := srcCol.Comment
+		if colComment == "" {
+			colComment = "From: " + quoteIfNeeded(srcCol.Name) + " " + srcCol.Type.Print()
+		}
 		spColDef[srcColId] = ddl.ColumnDef{
-			Name:    colName,
-			T:       ty,
-			NotNull: isNotNull,
-			Comment: "From: " + quoteIfNeeded(srcCol.Name) + " " + srcCol.Type.Print(),
-			Id:      srcColId,
-			AutoGen: *autoGenCol,
+			Name:            colName,
+			T:               ty,
+			NotNull:         isNotNull,
+			Comment:         colComment,
+			Id:              srcColId,
+			AutoGen:         *autoGenCol,
+			DefaultValue:    defaultValue,
+			GeneratedColumn: generatedCol,
 		}
 		// Initialise Opts only for Cassandra source
 		if conv.Source == constants.CASSANDRA {
@@ -411,10 +475,30 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 				if colDef.Opts == nil {
 					colDef.Opts = make(map[string]string)
 				}
-				colDef.Opts["cassandra_type"] = option
+				colDef.Opts[ddl.CassandraTypeOpt] = option
+			}
+			spColDef[srcColId] = colDef
+		}
+		// Columns the source sets to the current time on every update, such
+		// as last_modified columns, are converted to commit timestamp columns.
+		if (srcCol.OnUpdateCurrentTimestamp || srcCol.CommitTimestamp) && ty.Name == ddl.Timestamp && !ty.IsArray {
+			colDef := spColDef[srcColId]
+			if colDef.Opts == nil {
+				colDef.Opts = make(map[string]string)
 			}
+			colDef.Opts[ddl.AllowCommitTimestampOpt] = "true"
 			spColDef[srcColId] = colDef
 		}
+		if checkProvider, ok := toddl.(ColumnCheckProvider); ok {
+			if expr := checkProvider.GetColumnCheckExpr(conv, srcCol.Type, spColDef[srcColId]); expr != "" {
+				checkConstraints = append(checkConstraints, ddl.CheckConstraint{
+					Id:     internal.GenerateCheckConstrainstId(),
+					Name:   internal.ToSpannerCheckConstraintName(conv, fmt.Sprintf("chk_%s_%s", spTableName, colName)),
+					Expr:   expr,
+					ExprId: internal.GenerateExpressionId(),
+				})
+			}
+		}
 		if !checkIfColumnIsPartOfPK(srcColId, srcTable.PrimaryKeys) {
 			totalNonKeyColumnSize += getColumnSize(ty.Name, ty.Len)
 		}
@@ -426,47 +510,131 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 		TableLevelIssues:  tableLevelIssues,
 		ColumnLevelIssues: columnLevelIssues,
 	}
-	comment := "Spanner schema for source table " + quoteIfNeeded(srcTable.Name)
+	spColIds, searchIndexes := cvtSearchIndexes(conv, srcTable.Id, srcTable.Indexes, spColIds, spColDef)
+	spColIds, srcIndexes := cvtIndexExpressions(conv, toddl, srcTable, spColIds, spColDef)
+	comment := srcTable.Comment
+	if comment == "" {
+		comment = "Spanner schema for source table " + quoteIfNeeded(srcTable.Name)
+	}
 	conv.SpSchema[srcTable.Id] = ddl.CreateTable{
 		Name:             spTableName,
 		ColIds:           spColIds,
 		ColDefs:          spColDef,
-		PrimaryKeys:      cvtPrimaryKeys(srcTable.PrimaryKeys),
+		PrimaryKeys:      cvtPrimaryKeys(partitionPrimaryKeys(srcTable)),
 		ForeignKeys:      cvtForeignKeys(conv, spTableName, srcTable.Id, srcTable.ForeignKeys, isRestore),
-		CheckConstraints: cvtCheckConstraint(conv, srcTable.CheckConstraints),
-		Indexes:          cvtIndexes(conv, srcTable.Id, srcTable.Indexes, spColIds, spColDef),
+		CheckConstraints: checkConstraints,
+		Indexes:          cvtIndexes(conv, srcTable.Id, srcIndexes, spColIds, spColDef),
+		SearchIndexes:    searchIndexes,
+		VectorIndexes:    cvtVectorIndexes(conv, srcTable.Id, srcTable.Indexes, spColDef),
 		Comment:          comment,
 		Id:               srcTable.Id,
 	}
 	return nil
 }
 
-func (ss *SchemaToSpannerImpl) SchemaToSpannerSequenceHelper(conv *internal.Conv, srcSequence ddl.Sequence) error {
-	switch srcSequence.SequenceKind {
-	case constants.AUTO_INCREMENT:
-		spSequence := ddl.Sequence{
-			Name:             srcSequence.Name,
-			Id:               srcSequence.Id,
-			SequenceKind:     "BIT REVERSED POSITIVE",
-			SkipRangeMin:     srcSequence.SkipRangeMin,
-			SkipRangeMax:     srcSequence.SkipRangeMax,
-			StartWithCounter: srcSequence.StartWithCounter,
-		}
-		conv.SpSequences[srcSequence.Id] = spSequence
+// ConvertDefaultValue converts the default of source column srcCol to the
+// default of a Spanner column of type ty, for the kinds of defaults which
+// translate to Spanner without being verified: literals, the current date or
+// time, and UUIDs. It reports whether the default was converted; NULL
+// defaults need no Spanner default. Other defaults are left to the
+// verification of expressions, or reported as not migrated.
+func ConvertDefaultValue(conv *internal.Conv, srcCol schema.Column, ty ddl.Type) (ddl.DefaultValue, bool) {
+	if !srcCol.DefaultValue.IsPresent || srcCol.AutoGen.Name != "" || srcCol.GeneratedColumn.IsPresent {
+		return ddl.DefaultValue{}, false
+	}
+	stmt := srcCol.DefaultValue.Value.Statement
+	switch ddl.ClassifyDefaultExpression(stmt, ty) {
+	case ddl.DefaultKindLiteral, ddl.DefaultKindCurrentTime, ddl.DefaultKindUUID:
 	default:
-		spSequence := ddl.Sequence{
-			Name:             srcSequence.Name,
-			Id:               srcSequence.Id,
-			SequenceKind:     "BIT REVERSED POSITIVE",
-			SkipRangeMin:     srcSequence.SkipRangeMin,
-			SkipRangeMax:     srcSequence.SkipRangeMax,
-			StartWithCounter: srcSequence.StartWithCounter,
-		}
-		conv.SpSequences[srcSequence.Id] = spSequence
+		return ddl.DefaultValue{}, false
+	}
+	expr := ddl.TranslateDefaultExpression(stmt, ty, conv.SpDialect)
+	if expr == "NULL" {
+		return ddl.DefaultValue{}, true
 	}
+	return ddl.DefaultValue{
+		IsPresent: true,
+		Value:     ddl.Expression{ExpressionId: srcCol.DefaultValue.Value.ExpressionId, Statement: expr},
+	}, true
+}
+
+func (ss *SchemaToSpannerImpl) SchemaToSpannerSequenceHelper(conv *internal.Conv, srcSequence ddl.Sequence) error {
+	spSequence, _ := internal.ToSpannerSequence(srcSequence)
+	conv.SpSequences[srcSequence.Id] = spSequence
 	return nil
 }
 
+func findSequenceByName(sequences map[string]ddl.Sequence, name string) (ddl.Sequence, bool) {
+	for _, seq := range sequences {
+		if seq.Name == name {
+			return seq, true
+		}
+	}
+	return ddl.Sequence{}, false
+}
+
+// cvtViews converts source views to Spanner views, translating their queries
+// to the dialect of the Spanner database. Views whose queries can't be
+// translated, or which reference such views, aren't converted: the reason
+// is recorded in conv.ViewIssues.
+func cvtViews(conv *internal.Conv) {
+	if len(conv.SrcViews) == 0 {
+		return
+	}
+	if conv.SpViews == nil {
+		conv.SpViews = make(map[string]ddl.CreateView)
+	}
+	if conv.ViewIssues == nil {
+		conv.ViewIssues = make(map[string]string)
+	}
+	var viewIds []string
+	for id := range conv.SrcViews {
+		viewIds = append(viewIds, id)
+	}
+	sort.Slice(viewIds, func(i, j int) bool {
+		return conv.SrcViews[viewIds[i]].Name < conv.SrcViews[viewIds[j]].Name
+	})
+	viewNames := make(map[string]string)
+	for _, viewId := range viewIds {
+		viewNames[viewId] = internal.GetSpannerValidName(conv, conv.SrcViews[viewId].Name)
+	}
+	relations := viewRelations(conv, viewNames)
+	referencedViews := make(map[string][]string)
+	for _, viewId := range viewIds {
+		srcView := conv.SrcViews[viewId]
+		query, views, err := translateViewQuery(conv, srcView, relations)
+		if err != nil {
+			conv.ViewIssues[viewId] = err.Error()
+			continue
+		}
+		delete(conv.ViewIssues, viewId)
+		referencedViews[viewId] = views
+		conv.SpViews[viewId] = ddl.CreateView{
+			Name:         viewNames[viewId],
+			Id:           viewId,
+			SecurityType: "INVOKER",
+			Query:        query,
+			Comment:      "Spanner schema for source view " + quoteIfNeeded(srcView.Name),
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, viewId := range viewIds {
+			if _, ok := conv.SpViews[viewId]; !ok {
+				continue
+			}
+			for _, ref := range referencedViews[viewId] {
+				if _, ok := conv.SpViews[ref]; !ok {
+					conv.ViewIssues[viewId] = fmt.Sprintf("references view %s, which isn't converted to Spanner", conv.SrcViews[ref].Name)
+					delete(conv.SpViews, viewId)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
 func quoteIfNeeded(s string) string {
 	for _, r := range s {
 		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsPunct(r) {
@@ -554,6 +722,16 @@ func CvtForeignKeysHelper(conv *internal.Conv, spTableName string, srcTableId st
 func cvtIndexes(conv *internal.Conv, tableId string, srcIndexes []schema.Index, spColIds []string, spColDef map[string]ddl.ColumnDef) []ddl.CreateIndex {
 	var spIndexes []ddl.CreateIndex
 	for _, srcIndex := range srcIndexes {
+		if srcIndex.FullText || srcIndex.VectorDistance != "" {
+			// Full-text and vector indexes are converted by cvtSearchIndexes
+			// and cvtVectorIndexes respectively.
+			continue
+		}
+		if srcIndex.Spatial {
+			// Spanner has no spatial indexes, which is reported with the
+			// issues of spatial columns.
+			continue
+		}
 		spIndex := CvtIndexHelper(conv, tableId, srcIndex, spColIds, spColDef)
 		if (!reflect.DeepEqual(spIndex, ddl.CreateIndex{})) {
 			spIndexes = append(spIndexes, spIndex)
@@ -562,6 +740,229 @@ func cvtIndexes(conv *internal.Conv, tableId string, srcIndexes []schema.Index,
 	return spIndexes
 }
 
+// cvtSearchIndexes converts source full-text indexes to Spanner search indexes.
+// Spanner search indexes are built over TOKENLIST columns, so for each key
+// column of a full-text index we add a TOKENLIST column generated using
+// TOKENIZE_FULLTEXT. It returns the updated column ids of the table along
+// with the search indexes.
+func cvtSearchIndexes(conv *internal.Conv, tableId string, srcIndexes []schema.Index, spColIds []string, spColDef map[string]ddl.ColumnDef) ([]string, []ddl.SearchIndex) {
+	var spIndexes []ddl.SearchIndex
+	for _, srcIndex := range srcIndexes {
+		if !srcIndex.FullText {
+			continue
+		}
+		var options map[string]string
+		if tag := toLanguageTag(srcIndex.FullTextConfig); tag != "" {
+			options = map[string]string{"language_tag": tag}
+		}
+		var spKeys []ddl.IndexKey
+		for _, k := range srcIndex.Keys {
+			cd, found := spColDef[k.ColId]
+			if !found || cd.T.Name != ddl.String || cd.T.IsArray {
+				conv.Unexpected(fmt.Sprintf("Can't map full-text index key column for tableId %s columnId %s", tableId, k.ColId))
+				continue
+			}
+			colId := internal.GenerateColumnId()
+			spColDef[colId] = ddl.ColumnDef{
+				Name:    getTokenListColName(cd.Name, spColDef),
+				T:       ddl.Type{Name: ddl.TokenList},
+				Comment: "Tokens of column " + quoteIfNeeded(cd.Name) + " for full-text search",
+				Id:      colId,
+				GeneratedColumn: ddl.GeneratedColumn{
+					IsPresent: true,
+					Value:     ddl.Expression{Statement: ddl.TokenizeFullText(cd.Name, options, conv.SpDialect)},
+					Type:      ddl.GeneratedVirtual,
+				},
+				Hidden: true,
+			}
+			spColIds = append(spColIds, colId)
+			spKeys = append(spKeys, ddl.IndexKey{ColId: colId, Order: k.Order})
+		}
+		if len(spKeys) == 0 {
+			continue
+		}
+		if srcIndex.Name == "" {
+			srcIndex.Name = fmt.Sprintf("SearchIndex_%s", conv.SrcSchema[tableId].Name)
+		}
+		spIndexes = append(spIndexes, ddl.SearchIndex{
+			Name:    internal.ToSpannerIndexName(conv, srcIndex.Name),
+			TableId: tableId,
+			Id:      srcIndex.Id,
+			Keys:    spKeys,
+		})
+	}
+	return spColIds, spIndexes
+}
+
+// cvtIndexExpressions converts the keys of indexes on expressions, e.g.
+// Oracle function-based indexes, to stored generated columns, since Spanner
+// indexes are built over columns. Indexes with an expression which can't be
+// translated are left out, and reported along with their expression. It
+// returns the updated column ids of the table along with the indexes to
+// convert, whose keys are all columns.
+func cvtIndexExpressions(conv *internal.Conv, toddl ToDdl, srcTable schema.Table, spColIds []string, spColDef map[string]ddl.ColumnDef) ([]string, []schema.Index) {
+	var srcIndexes []schema.Index
+	for _, srcIndex := range srcTable.Indexes {
+		if !hasKeyExpression(srcIndex) {
+			srcIndexes = append(srcIndexes, srcIndex)
+			continue
+		}
+		translator, ok := toddl.(GeneratedColumnTranslator)
+		if !ok {
+			continue
+		}
+		var keys []schema.Key
+		exprColDefs := make(map[string]ddl.ColumnDef)
+		for _, k := range srcIndex.Keys {
+			if k.Expr == "" {
+				keys = append(keys, k)
+				continue
+			}
+			expr, ok := translator.ToSpannerGeneratedExpression(conv, k.Expr)
+			if !ok || k.ExprType == nil {
+				keys = nil
+				break
+			}
+			ty, _ := toddl.ToSpannerType(conv, "", *k.ExprType, false)
+			colId := internal.GenerateColumnId()
+			exprColDefs[colId] = ddl.ColumnDef{
+				Name:    getIndexExpressionColName(srcIndex.Name, spColDef, exprColDefs),
+				T:       ty,
+				Comment: "Expression " + k.Expr + " of index " + quoteIfNeeded(srcIndex.Name),
+				Id:      colId,
+				GeneratedColumn: ddl.GeneratedColumn{
+					IsPresent: true,
+					Value:     ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: expr},
+					Type:      ddl.GeneratedStored,
+				},
+			}
+			keys = append(keys, schema.Key{ColId: colId, Desc: k.Desc, Order: k.Order})
+		}
+		if keys == nil {
+			continue
+		}
+		for _, k := range keys {
+			if cd, found := exprColDefs[k.ColId]; found {
+				spColDef[k.ColId] = cd
+				spColIds = append(spColIds, k.ColId)
+			}
+		}
+		srcIndex.Keys = keys
+		srcIndexes = append(srcIndexes, srcIndex)
+	}
+	return spColIds, srcIndexes
+}
+
+// hasKeyExpression reports whether an index has keys on an expression.
+func hasKeyExpression(index schema.Index) bool {
+	for _, k := range index.Keys {
+		if k.Expr != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// getIndexExpressionColName returns a name for a column generated from an
+// expression of index indexName which doesn't clash with the existing
+// columns of the table, nor the other columns generated for the index.
+func getIndexExpressionColName(indexName string, spColDef, exprColDefs map[string]ddl.ColumnDef) string {
+	used := make(map[string]bool)
+	for _, colDefs := range []map[string]ddl.ColumnDef{spColDef, exprColDefs} {
+		for _, cd := range colDefs {
+			used[strings.ToLower(cd.Name)] = true
+		}
+	}
+	base, _ := internal.FixName(indexName + "_Expr")
+	name := base
+	for i := 1; used[strings.ToLower(name)]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
+}
+
+// textSearchLanguageTags maps the built-in Postgres text search
+// configurations to the language tags of TOKENIZE_FULLTEXT.
+var textSearchLanguageTags = map[string]string{
+	"arabic":     "ar",
+	"danish":     "da",
+	"dutch":      "nl",
+	"english":    "en",
+	"finnish":    "fi",
+	"french":     "fr",
+	"german":     "de",
+	"greek":      "el",
+	"hungarian":  "hu",
+	"indonesian": "id",
+	"italian":    "it",
+	"norwegian":  "no",
+	"portuguese": "pt",
+	"romanian":   "ro",
+	"russian":    "ru",
+	"spanish":    "es",
+	"swedish":    "sv",
+	"turkish":    "tr",
+}
+
+// toLanguageTag returns the language tag of a text search configuration,
+// possibly schema qualified e.g. pg_catalog.english, or an empty string
+// when there's none e.g. for the simple configuration.
+func toLanguageTag(config string) string {
+	config = strings.ToLower(config)
+	if i := strings.LastIndex(config, "."); i >= 0 {
+		config = config[i+1:]
+	}
+	return textSearchLanguageTags[config]
+}
+
+// cvtVectorIndexes converts source vector indexes to Spanner vector indexes.
+// Spanner vector indexes are built over a single FLOAT32 or FLOAT64 array
+// column with a vector length.
+func cvtVectorIndexes(conv *internal.Conv, tableId string, srcIndexes []schema.Index, spColDef map[string]ddl.ColumnDef) []ddl.VectorIndex {
+	var spIndexes []ddl.VectorIndex
+	for _, srcIndex := range srcIndexes {
+		if srcIndex.VectorDistance == "" {
+			continue
+		}
+		if len(srcIndex.Keys) != 1 {
+			conv.Unexpected(fmt.Sprintf("Can't map vector index %s for tableId %s: vector indexes must have exactly one key column", srcIndex.Name, tableId))
+			continue
+		}
+		colId := srcIndex.Keys[0].ColId
+		cd, found := spColDef[colId]
+		if !found || !cd.T.IsArray || cd.T.VectorLength == 0 || (cd.T.Name != ddl.Float32 && cd.T.Name != ddl.Float64) {
+			conv.Unexpected(fmt.Sprintf("Can't map vector index key column for tableId %s columnId %s", tableId, colId))
+			continue
+		}
+		if srcIndex.Name == "" {
+			srcIndex.Name = fmt.Sprintf("VectorIndex_%s", conv.SrcSchema[tableId].Name)
+		}
+		spIndexes = append(spIndexes, ddl.VectorIndex{
+			Name:            internal.ToSpannerIndexName(conv, srcIndex.Name),
+			TableId:         tableId,
+			Id:              srcIndex.Id,
+			ColId:           colId,
+			StoredColumnIds: srcIndex.StoredColumnIds,
+			DistanceType:    srcIndex.VectorDistance,
+		})
+	}
+	return spIndexes
+}
+
+// getTokenListColName returns a name for the TOKENLIST column generated from
+// column colName which doesn't clash with the existing columns of the table.
+func getTokenListColName(colName string, spColDef map[string]ddl.ColumnDef) string {
+	used := make(map[string]bool)
+	for _, cd := range spColDef {
+		used[strings.ToLower(cd.Name)] = true
+	}
+	name := colName + "_Tokens"
+	for i := 1; used[strings.ToLower(name)]; i++ {
+		name = fmt.Sprintf("%s_Tokens_%d", colName, i)
+	}
+	return name
+}
+
 func SrcTableToSpannerDDL(conv *internal.Conv, toddl ToDdl, srcTable schema.Table, ddlVerifier expressions_api.DDLVerifier) error {
 	schemaToSpanner := SchemaToSpannerImpl{
 		DdlV: ddlVerifier,
@@ -621,6 +1022,11 @@ func CvtIndexHelper(conv *internal.Conv, tableId string, srcIndex schema.Index,
 		}
 		if !isPresent {
 			conv.Unexpected(fmt.Sprintf("Can't map index key column for tableId %s columnId %s", tableId, k.ColId))
+			if srcIndex.Constraint {
+				// A UNIQUE constraint on fewer columns would reject rows
+				// the source accepts, so it isn't converted.
+				return ddl.CreateIndex{}
+			}
 			continue
 		}
 		spKeys = append(spKeys, ddl.IndexKey{ColId: k.ColId, Desc: k.Desc, Order: k.Order})
@@ -639,6 +1045,12 @@ func CvtIndexHelper(conv *internal.Conv, tableId string, srcIndex schema.Index,
 		}
 		spStoredColIds = append(spStoredColIds, colId)
 	}
+	if srcIndex.Name == "" && srcIndex.Constraint {
+		// Unnamed UNIQUE constraints are named after their table and
+		// columns, as PostgreSQL does, so that the name doesn't depend on
+		// the order of conversion.
+		srcIndex.Name = uniqueConstraintName(conv.SrcSchema[tableId], srcIndex.Keys)
+	}
 	if srcIndex.Name == "" {
 		// Generate a name if index name is empty in MySQL.
 		// Collision of index name will be handled by ToSpannerIndexName.
@@ -652,10 +1064,21 @@ func CvtIndexHelper(conv *internal.Conv, tableId string, srcIndex schema.Index,
 		Keys:            spKeys,
 		StoredColumnIds: spStoredColIds,
 		Id:              srcIndex.Id,
+		NullFiltered:    srcIndex.NullFiltered,
 	}
 	return spIndex
 }
 
+// uniqueConstraintName returns the name of an unnamed UNIQUE constraint of
+// srcTable on keys, e.g. users_email_key.
+func uniqueConstraintName(srcTable schema.Table, keys []schema.Key) string {
+	parts := []string{srcTable.Name}
+	for _, k := range keys {
+		parts = append(parts, srcTable.ColDefs[k.ColId].Name)
+	}
+	return strings.Join(append(parts, "key"), "_")
+}
+
 // Applies all valid expressions which can be migrated to spanner conv object
 func spannerSchemaApplyExpressions(conv *internal.Conv, expressions internal.VerifyExpressionsOutput) {
 	for _, expression := range expressions.ExpressionVerificationOutputList {
@@ -216,9 +216,9 @@ func Test_cvtIndexes(t *testing.T) {
 			Name:   "indexName",
 			Unique: true,
 			Keys: []schema.Key{
-				{"c1", true, 1},
-				{"c2", true, 2},
-				{"c3", true, 3},
+				{ColId: "c1", Desc: true, Order: 1},
+				{ColId: "c2", Desc: true, Order: 2},
+				{ColId: "c3", Desc: true, Order: 3},
 			},
 			Id:              "t1",
 			StoredColumnIds: []string{"c1", "c2", "c3"},
@@ -483,6 +483,131 @@ func Test_cvtCheckContraint(t *testing.T) {
 	assert.Equal(t, spSchema, result)
 }
 
+func Test_cvtViews(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.UsedNames["orders"] = true
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Id: "t1", Name: "users", Schema: "db", ColDefs: map[string]schema.Column{"c1": {Id: "c1", Name: "id"}, "c2": {Id: "c2", Name: "active"}}},
+		"t2": {Id: "t2", Name: "orders_base", Schema: "db"},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {Id: "t1", Name: "users", ColDefs: map[string]ddl.ColumnDef{"c1": {Id: "c1", Name: "id"}, "c2": {Id: "c2", Name: "active"}}},
+		"t2": {Id: "t2", Name: "orders_base"},
+	}
+	conv.SrcViews = map[string]schema.View{
+		"v1": {Id: "v1", Name: "active_users", Schema: "db", Query: "SELECT id FROM users WHERE active"},
+		"v2": {Id: "v2", Name: "orders", Schema: "db", Query: "SELECT * FROM orders_base"},
+	}
+	cvtViews(conv)
+	expected := map[string]ddl.CreateView{
+		"v1": {
+			Id:           "v1",
+			Name:         "active_users",
+			SecurityType: "INVOKER",
+			Query:        "SELECT id FROM users WHERE active",
+			Comment:      "Spanner schema for source view active_users",
+		},
+		"v2": {
+			Id:           "v2",
+			Name:         "orders_2",
+			SecurityType: "INVOKER",
+			Query:        "SELECT * FROM orders_base",
+			Comment:      "Spanner schema for source view orders",
+		},
+	}
+	assert.Equal(t, expected, conv.SpViews)
+}
+
+func Test_cvtSearchIndexes(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{"t1": {Id: "t1", Name: "albums"}}
+	spColDef := map[string]ddl.ColumnDef{
+		"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+		"c2": {Name: "title", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		"c3": {Name: "title_Tokens", Id: "c3", T: ddl.Type{Name: ddl.String, Len: 10}},
+	}
+	srcIndexes := []schema.Index{
+		{Name: "idx_id", Id: "i1", Keys: []schema.Key{{ColId: "c1"}}},
+		{Name: "ft_title", Id: "i2", FullText: true, Keys: []schema.Key{{ColId: "c2"}, {ColId: "c1"}}},
+	}
+	colIds, searchIndexes := cvtSearchIndexes(conv, "t1", srcIndexes, []string{"c1", "c2", "c3"}, spColDef)
+
+	assert.Equal(t, 4, len(colIds))
+	tokenColId := colIds[3]
+	assert.Equal(t, ddl.ColumnDef{
+		Name:    "title_Tokens_1",
+		T:       ddl.Type{Name: ddl.TokenList},
+		Comment: "Tokens of column title for full-text search",
+		Id:      tokenColId,
+		GeneratedColumn: ddl.GeneratedColumn{
+			IsPresent: true,
+			Value:     ddl.Expression{Statement: "TOKENIZE_FULLTEXT(title)"},
+			Type:      ddl.GeneratedVirtual,
+		},
+		Hidden: true,
+	}, spColDef[tokenColId])
+	assert.Equal(t, []ddl.SearchIndex{{Name: "ft_title", TableId: "t1", Id: "i2", Keys: []ddl.IndexKey{{ColId: tokenColId}}}}, searchIndexes)
+	// The non-STRING key column can't be tokenized.
+	assert.Equal(t, int64(1), conv.Stats.Unexpected["Can't map full-text index key column for tableId t1 columnId c1"])
+	// Full-text indexes are not converted to regular indexes.
+	assert.Equal(t, 1, len(cvtIndexes(conv, "t1", srcIndexes, colIds, spColDef)))
+}
+
+func Test_cvtSearchIndexesLanguageTag(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{"t1": {Id: "t1", Name: "posts"}}
+	spColDef := map[string]ddl.ColumnDef{
+		"title": {Name: "title", Id: "title", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		"body": {Name: "body", Id: "body", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+	}
+	srcIndexes := []schema.Index{
+		{Name: "ft_title", Id: "i1", FullText: true, FullTextConfig: "pg_catalog.english", Keys: []schema.Key{{ColId: "title"}}},
+		{Name: "ft_body", Id: "i2", FullText: true, FullTextConfig: "simple", Keys: []schema.Key{{ColId: "body"}}},
+	}
+	colIds, _ := cvtSearchIndexes(conv, "t1", srcIndexes, []string{"title", "body"}, spColDef)
+
+	assert.Equal(t, 4, len(colIds))
+	assert.Equal(t, "TOKENIZE_FULLTEXT(title, language_tag=>'en')", spColDef[colIds[2]].GeneratedColumn.Value.Statement)
+	assert.Equal(t, "TOKENIZE_FULLTEXT(body)", spColDef[colIds[3]].GeneratedColumn.Value.Statement)
+}
+
+func Test_cvtVectorIndexes(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{"t1": {Id: "t1", Name: "documents"}}
+	spColDef := map[string]ddl.ColumnDef{
+		"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+		"c2": {Name: "embedding", Id: "c2", T: ddl.Type{Name: ddl.Float32, IsArray: true, VectorLength: 3}},
+		"c3": {Name: "scores", Id: "c3", T: ddl.Type{Name: ddl.Float64, IsArray: true}},
+	}
+	srcIndexes := []schema.Index{
+		{Name: "idx_id", Id: "i1", Keys: []schema.Key{{ColId: "c1"}}},
+		{Name: "idx_embedding", Id: "i2", VectorDistance: ddl.VectorDistanceCosine, Keys: []schema.Key{{ColId: "c2"}}},
+		{Name: "idx_scores", Id: "i3", VectorDistance: ddl.VectorDistanceCosine, Keys: []schema.Key{{ColId: "c3"}}},
+	}
+	expected := []ddl.VectorIndex{{Name: "idx_embedding", TableId: "t1", Id: "i2", ColId: "c2", DistanceType: ddl.VectorDistanceCosine}}
+	assert.Equal(t, expected, cvtVectorIndexes(conv, "t1", srcIndexes, spColDef))
+	// Arrays without a vector length can't be used in vector indexes.
+	assert.Equal(t, int64(1), conv.Stats.Unexpected["Can't map vector index key column for tableId t1 columnId c3"])
+	// Vector indexes are not converted to regular indexes.
+	assert.Equal(t, 1, len(cvtIndexes(conv, "t1", srcIndexes, []string{"c1", "c2", "c3"}, spColDef)))
+}
+
+func Test_cvtIndexesSpatial(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{"t1": {Id: "t1", Name: "places"}}
+	spColDef := map[string]ddl.ColumnDef{
+		"c1": {Name: "name", Id: "c1", T: ddl.Type{Name: ddl.String, Len: 50}},
+		"c2": {Name: "location", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+	}
+	srcIndexes := []schema.Index{
+		{Name: "idx_name", Id: "i1", Keys: []schema.Key{{ColId: "c1"}}},
+		{Name: "idx_location", Id: "i2", Spatial: true, Keys: []schema.Key{{ColId: "c2"}}},
+	}
+	spIndexes := cvtIndexes(conv, "t1", srcIndexes, []string{"c1", "c2"}, spColDef)
+	assert.Equal(t, 1, len(spIndexes))
+	assert.Equal(t, "i1", spIndexes[0].Id)
+}
+
 func TestSpannerSchemaApplyExpressions(t *testing.T) {
 	makeConv := func() *internal.Conv {
 		conv := internal.MakeConv()
@@ -758,3 +883,53 @@ func TestSchemaToSpannerDDLHelper_CassandraOpts(t *testing.T) {
 	mockToddl.AssertCalled(t, "ToSpannerType", mock.Anything, "", mock.AnythingOfType("schema.Type"), mock.AnythingOfType("bool"))
 	mockToddl.AssertCalled(t, "GetTypeOption", "uuid", expectedSpannerType)
 }
+
+func TestSchemaToSpannerDDLHelperComments(t *testing.T) {
+	conv := internal.MakeConv()
+	srcTable := schema.Table{
+		Name:    "users",
+		Id:      "t1",
+		ColIds:  []string{"c1", "c2"},
+		Comment: "Registered users",
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "user_id", Id: "c1", Type: schema.Type{Name: "bigint"}, Comment: "Unique id of the user"},
+			"c2": {Name: "name", Id: "c2", Type: schema.Type{Name: "text"}},
+		},
+	}
+	mockToddl := new(MockOptionProvider)
+	mockToddl.On("ToSpannerType", mock.Anything, "", mock.Anything, mock.Anything).Return(ddl.Type{Name: ddl.Int64}, []internal.SchemaIssue(nil))
+
+	ss := SchemaToSpannerImpl{}
+	assert.Nil(t, ss.SchemaToSpannerDDLHelper(conv, mockToddl, srcTable, false))
+	spTable := conv.SpSchema["t1"]
+	assert.Equal(t, "Registered users", spTable.Comment)
+	assert.Equal(t, "Unique id of the user", spTable.ColDefs["c1"].Comment)
+	assert.Equal(t, "From: name text", spTable.ColDefs["c2"].Comment)
+}
+
+func TestConvertDefaultValue(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	col := func(stmt string) schema.Column {
+		return schema.Column{Name: "c", DefaultValue: ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "e1", Statement: stmt}}}
+	}
+	tests := []struct {
+		srcCol    schema.Column
+		ty        ddl.Type
+		dv        ddl.DefaultValue
+		converted bool
+	}{
+		{col("now()"), ddl.Type{Name: ddl.Timestamp}, ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "e1", Statement: "CURRENT_TIMESTAMP()"}}, true},
+		{col("uuid()"), ddl.Type{Name: ddl.String, Len: 36}, ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "e1", Statement: "GENERATE_UUID()"}}, true},
+		{col("'abc'::character varying"), ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "e1", Statement: "'abc'"}}, true},
+		{col("NULL"), ddl.Type{Name: ddl.Int64}, ddl.DefaultValue{}, true},
+		{col("(`a` + 1)"), ddl.Type{Name: ddl.Int64}, ddl.DefaultValue{}, false},
+		{col("nextval('s'::regclass)"), ddl.Type{Name: ddl.Int64}, ddl.DefaultValue{}, false},
+		{schema.Column{Name: "c"}, ddl.Type{Name: ddl.Int64}, ddl.DefaultValue{}, false},
+	}
+	for _, tc := range tests {
+		dv, converted := ConvertDefaultValue(conv, tc.srcCol, tc.ty)
+		assert.Equal(t, tc.dv, dv, tc.srcCol.DefaultValue.Value.Statement)
+		assert.Equal(t, tc.converted, converted, tc.srcCol.DefaultValue.Value.Statement)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// UniqueConstraintSource is implemented by the InfoSchema of sources whose
+// UNIQUE constraints are implemented by unique indexes, read by GetIndexes
+// along with the unique indexes created as such.
+type UniqueConstraintSource interface {
+	// GetUniqueConstraints returns the names of the indexes implementing
+	// the UNIQUE constraints of tables, by schema and name.
+	GetUniqueConstraints(tables []SchemaAndName) (map[SchemaAndName][]string, error)
+}
+
+// markUniqueConstraints flags the unique indexes of table named indexNames
+// as implementing UNIQUE constraints.
+func markUniqueConstraints(table schema.Table, indexNames []string) schema.Table {
+	constraints := make(map[string]bool)
+	for _, name := range indexNames {
+		constraints[name] = true
+	}
+	for i, index := range table.Indexes {
+		if index.Unique && constraints[index.Name] {
+			table.Indexes[i].Constraint = true
+		}
+	}
+	return table
+}
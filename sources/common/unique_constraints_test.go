@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal/reports"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/proto/migration"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestMarkUniqueConstraints(t *testing.T) {
+	table := schema.Table{Name: "users", Indexes: []schema.Index{
+		{Name: "users_email_key", Unique: true},
+		{Name: "users_name_idx", Unique: true},
+		{Name: "users_login_key"},
+	}}
+	table = markUniqueConstraints(table, []string{"users_email_key", "users_login_key"})
+	assert.Equal(t, []schema.Index{
+		{Name: "users_email_key", Unique: true, Constraint: true},
+		{Name: "users_name_idx", Unique: true},
+		{Name: "users_login_key"},
+	}, table.Indexes)
+}
+
+func TestCvtIndexHelperUniqueConstraint(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = schema.Table{
+		Name:   "users",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1"},
+			"c2": {Name: "email", Id: "c2"},
+			"c3": {Name: "tenant", Id: "c3"},
+		},
+	}
+	spColDef := map[string]ddl.ColumnDef{"c1": {Name: "id", Id: "c1"}, "c2": {Name: "email", Id: "c2"}}
+	unnamed := schema.Index{Id: "i1", Unique: true, Constraint: true, Keys: []schema.Key{{ColId: "c2"}}}
+	assert.Equal(t, ddl.CreateIndex{Name: "users_email_key", TableId: "t1", Id: "i1", Unique: true, Keys: []ddl.IndexKey{{ColId: "c2"}}},
+		CvtIndexHelper(conv, "t1", unnamed, []string{"c1", "c2"}, spColDef))
+
+	// Column tenant isn't converted, so the constraint can't be enforced.
+	constraint := schema.Index{Name: "users_tenant_email_key", Id: "i2", Unique: true, Constraint: true, Keys: []schema.Key{{ColId: "c3"}, {ColId: "c2"}}}
+	assert.Equal(t, ddl.CreateIndex{}, CvtIndexHelper(conv, "t1", constraint, []string{"c1", "c2"}, spColDef))
+	index := schema.Index{Name: "users_tenant_email_idx", Id: "i3", Unique: true, Keys: []schema.Key{{ColId: "c3"}, {ColId: "c2"}}}
+	assert.Equal(t, []ddl.IndexKey{{ColId: "c2"}}, CvtIndexHelper(conv, "t1", index, []string{"c1", "c2"}, spColDef).Keys)
+}
+
+func TestUniqueConstraintReports(t *testing.T) {
+	conv := viewsConv(constants.POSTGRES, constants.DIALECT_GOOGLESQL)
+	srcTable := conv.SrcSchema["t1"]
+	srcTable.Indexes = []schema.Index{
+		{Id: "i1", Name: "users_id_key", Unique: true, Constraint: true, Keys: []schema.Key{{ColId: "c1"}}},
+		{Id: "i2", Name: "users_name_key", Unique: true, Constraint: true, Keys: []schema.Key{{ColId: "c2"}, {ColId: "c3"}}},
+		{Id: "i3", Unique: true, Constraint: true, Keys: []schema.Key{{ColId: "c3"}}},
+		{Id: "i4", Name: "users_active_idx", Unique: true, Keys: []schema.Key{{ColId: "c3"}}},
+	}
+	conv.SrcSchema["t1"] = srcTable
+	spTable := conv.SpSchema["t1"]
+	spTable.Indexes = []ddl.CreateIndex{
+		{Id: "i1", Name: "users_id_key", TableId: "t1", Unique: true, Keys: []ddl.IndexKey{{ColId: "c1"}}},
+		// Column active was dropped from the index.
+		{Id: "i2", Name: "users_name_key", TableId: "t1", Unique: true, Keys: []ddl.IndexKey{{ColId: "c2"}}},
+	}
+	conv.SpSchema["t1"] = spTable
+	conv.Audit.MigrationType = migration.MigrationData_SCHEMA_ONLY.Enum()
+	reportGenerator := reports.ReportImpl{}
+	structuredReport := reportGenerator.GenerateStructuredReport(constants.POSTGRES, "shop", conv, nil, true, true)
+	var descriptions []string
+	for _, issues := range structuredReport.TableReports[0].Issues {
+		for _, issue := range issues.IssueList {
+			if issue.Category == "UNIQUE_CONSTRAINT_DROPPED" {
+				descriptions = append(descriptions, issue.Description)
+			}
+		}
+	}
+	brief := reports.IssueDB[internal.UniqueConstraintDropped].Brief
+	assert.Equal(t, []string{
+		"Table 'users': UNIQUE constraint 'users_name_key' on columns Full Name, active is not converted. " + brief,
+		"Table 'users': UNIQUE constraint on columns active is not converted. " + brief,
+	}, descriptions)
+}
@@ -17,7 +17,9 @@ package common
 import (
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
@@ -29,6 +31,28 @@ type UtilsOrderInterface interface {
 }
 type UtilsOrderImpl struct{}
 
+// ParseEnumValues returns the values of an ENUM or SET column from its type,
+// as reported by the source database e.g. enum('small','medium','large').
+func ParseEnumValues(columnType string) []string {
+	if !strings.HasSuffix(columnType, ")") {
+		return nil
+	}
+	var prefix string
+	for _, p := range []string{"enum(", "set("} {
+		if strings.HasPrefix(columnType, p) {
+			prefix = p
+		}
+	}
+	if prefix == "" {
+		return nil
+	}
+	var values []string
+	for _, v := range strings.Split(columnType[len(prefix):len(columnType)-1], "','") {
+		values = append(values, strings.ReplaceAll(strings.Trim(v, "'"), "''", "'"))
+	}
+	return values
+}
+
 // ToNotNull returns true if a column is not nullable and false if it is.
 func ToNotNull(conv *internal.Conv, isNullable string) bool {
 	switch isNullable {
@@ -122,12 +146,15 @@ func IntersectionOfTwoStringSlices(a []string, b []string) []string {
 	return set
 }
 
+// GetCommonColumnIds returns the column ids present in both the source and
+// Spanner schemas. Generated columns are skipped since Spanner computes their
+// values and rejects writes to them.
 func GetCommonColumnIds(conv *internal.Conv, tableId string, colIds []string) []string {
 	srcSchema := conv.SrcSchema[tableId]
 	var commonColIds []string
 	for i, colId := range colIds {
 		_, found := srcSchema.ColDefs[colId]
-		if found {
+		if found && !conv.SpSchema[tableId].ColDefs[colId].GeneratedColumn.IsPresent {
 			commonColIds = append(commonColIds, colIds[i])
 		}
 	}
@@ -144,7 +171,13 @@ func PrepareColumns(conv *internal.Conv, tableId string, srcCols []string) ([]st
 		}
 		srcColIds = append(srcColIds, colId)
 	}
-	commonIds := IntersectionOfTwoStringSlices(spColIds, srcColIds)
+	var writableColIds []string
+	for _, colId := range spColIds {
+		if !conv.SpSchema[tableId].ColDefs[colId].GeneratedColumn.IsPresent {
+			writableColIds = append(writableColIds, colId)
+		}
+	}
+	commonIds := IntersectionOfTwoStringSlices(writableColIds, srcColIds)
 	if len(commonIds) == 0 {
 		return []string{}, fmt.Errorf("no common columns between source and spanner table")
 	}
@@ -166,11 +199,37 @@ func PrepareValues[T interface{}](conv *internal.Conv, tableId string, colNameId
 	return newValues, nil
 }
 
-func ToPGDialectType(standardType ddl.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
-	if standardType.IsArray {
-		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false},
-			[]internal.SchemaIssue{internal.ArrayTypeNotSupported}
+// ToSpannerNumeric applies the explicit precision and scale of a source
+// numeric or decimal type, given by its mods, to the Spanner NUMERIC type
+// ty. PG dialect types keep the precision and scale, while GoogleSQL
+// NUMERIC has a fixed precision and scale. A Numeric or Decimal issue is
+// returned when the source type can hold more digits than Spanner's NUMERIC
+// type in the dialect, and the precision and scale are then not kept.
+func ToSpannerNumeric(ty ddl.Type, srcType schema.Type, dialect string) (ddl.Type, []internal.SchemaIssue) {
+	if ty.Name != ddl.Numeric || len(srcType.Mods) == 0 {
+		return ty, nil
+	}
+	precision, scale := srcType.Mods[0], int64(0)
+	if len(srcType.Mods) > 1 {
+		scale = srcType.Mods[1]
+	}
+	maxIntegerDigits, maxScale := int64(ddl.NumericMaxIntegerDigits), int64(ddl.NumericMaxScale)
+	if dialect == constants.DIALECT_POSTGRESQL {
+		maxIntegerDigits, maxScale = ddl.PGNumericMaxIntegerDigits, ddl.PGNumericMaxScale
+	}
+	if precision-scale > maxIntegerDigits || scale > maxScale {
+		if strings.EqualFold(srcType.Name, "decimal") {
+			return ty, []internal.SchemaIssue{internal.Decimal}
+		}
+		return ty, []internal.SchemaIssue{internal.Numeric}
 	}
+	if dialect == constants.DIALECT_POSTGRESQL {
+		ty.Precision, ty.Scale = precision, scale
+	}
+	return ty, nil
+}
+
+func ToPGDialectType(standardType ddl.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
 	if isPk && standardType.Name == ddl.Numeric {
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false},
 			[]internal.SchemaIssue{internal.NumericPKNotSupported}
@@ -197,6 +256,7 @@ var DATATYPE_TO_STORAGE_SIZE = map[string]int{
 	ddl.JSON:      ddl.StringMaxLength,
 	ddl.Numeric:   22,
 	ddl.Timestamp: 12,
+	ddl.UUID:      16,
 }
 
 func getColumnSize(dataType string, length int64) int {
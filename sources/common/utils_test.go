@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
@@ -183,6 +184,34 @@ func TestPrepareColumns(t *testing.T) {
 			srcCols:        []string{"a", "b"},
 			expectedColIds: []string{"c1"},
 		},
+		{
+			name: "when a spanner column is generated",
+			conv: &internal.Conv{
+				SpSchema: map[string]ddl.CreateTable{
+					"t1": {
+						Name:   "t1",
+						ColIds: []string{"c1", "c2"},
+						ColDefs: map[string]ddl.ColumnDef{
+							"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+							"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Int64}, GeneratedColumn: ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{Statement: "a + 1"}, Type: ddl.GeneratedStored}},
+						},
+						PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+					}},
+				SrcSchema: map[string]schema.Table{
+					"t1": {
+						Name:   "t1",
+						ColIds: []string{"c1", "c2"},
+						ColDefs: map[string]schema.Column{
+							"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "bigint", Mods: []int64{}}},
+							"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "bigint", Mods: []int64{}}},
+						},
+						PrimaryKeys: []schema.Key{{ColId: "c1"}},
+					}},
+			},
+			tableId:        "t1",
+			srcCols:        []string{"a", "b"},
+			expectedColIds: []string{"c1"},
+		},
 	}
 	for _, tc := range tc {
 		res, err := PrepareColumns(tc.conv, tc.tableId, tc.srcCols)
@@ -300,3 +329,31 @@ func TestGetSortedTableIdsBySpName(t *testing.T) {
 		})
 	}
 }
+
+func TestToSpannerNumeric(t *testing.T) {
+	numeric := ddl.Type{Name: ddl.Numeric}
+	tests := []struct {
+		name           string
+		srcType        schema.Type
+		dialect        string
+		expectedType   ddl.Type
+		expectedIssues []internal.SchemaIssue
+	}{
+		{"no precision", schema.Type{Name: "numeric"}, constants.DIALECT_POSTGRESQL, numeric, nil},
+		{"pg precision", schema.Type{Name: "numeric", Mods: []int64{10}}, constants.DIALECT_POSTGRESQL, ddl.Type{Name: ddl.Numeric, Precision: 10}, nil},
+		{"pg precision and scale", schema.Type{Name: "decimal", Mods: []int64{65, 30}}, constants.DIALECT_POSTGRESQL, ddl.Type{Name: ddl.Numeric, Precision: 65, Scale: 30}, nil},
+		{"pg scale too large", schema.Type{Name: "numeric", Mods: []int64{20000, 17000}}, constants.DIALECT_POSTGRESQL, numeric, []internal.SchemaIssue{internal.Numeric}},
+		{"googlesql fits", schema.Type{Name: "numeric", Mods: []int64{38, 9}}, constants.DIALECT_GOOGLESQL, numeric, nil},
+		{"googlesql scale too large", schema.Type{Name: "decimal", Mods: []int64{18, 17}}, constants.DIALECT_GOOGLESQL, numeric, []internal.SchemaIssue{internal.Decimal}},
+		{"googlesql precision too large", schema.Type{Name: "numeric", Mods: []int64{40}}, constants.DIALECT_GOOGLESQL, numeric, []internal.SchemaIssue{internal.Numeric}},
+	}
+	for _, tc := range tests {
+		ty, issues := ToSpannerNumeric(numeric, tc.srcType, tc.dialect)
+		assert.Equal(t, tc.expectedType, ty, tc.name)
+		assert.Equal(t, tc.expectedIssues, issues, tc.name)
+	}
+	str := ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+	ty, issues := ToSpannerNumeric(str, schema.Type{Name: "numeric", Mods: []int64{10, 2}}, constants.DIALECT_POSTGRESQL)
+	assert.Equal(t, str, ty)
+	assert.Nil(t, issues)
+}
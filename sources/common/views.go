@@ -0,0 +1,711 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// ViewSource is implemented by the InfoSchema of sources whose views are
+// converted to Spanner views.
+type ViewSource interface {
+	// GetViews returns the views of the source database, with their queries.
+	GetViews() ([]schema.View, error)
+}
+
+// MaterializedViewSource is implemented by the InfoSchema of sources whose
+// materialized views can be migrated as tables, listed by GetTables.
+type MaterializedViewSource interface {
+	// GetMaterializedViews returns the queries of the materialized views
+	// among tables, by schema and name.
+	GetMaterializedViews(tables []SchemaAndName) (map[SchemaAndName]string, error)
+}
+
+// Translation of view queries to the dialect of the Spanner database. The
+// queries are rewritten token by token: table, view and column names are
+// replaced by their Spanner names, identifiers and strings are quoted the
+// Spanner way, and functions are renamed to their Spanner equivalents.
+// Queries using constructs without an equivalent aren't translated.
+
+type viewTokenKind int
+
+const (
+	viewWord   viewTokenKind = iota // Keywords, function names and unquoted identifiers.
+	viewIdent                       // Quoted identifiers, without their quotes.
+	viewString                      // String literals, without their quotes.
+	viewNumber
+	viewSymbol
+)
+
+type viewToken struct {
+	kind viewTokenKind
+	text string
+}
+
+// viewSymbols are the symbols of more than one character, longest first.
+var viewSymbols = []string{"<=>", "::", "<=", ">=", "<>", "!=", "||", ":="}
+
+// viewKeywords are the keywords of view queries which aren't identifiers.
+var viewKeywords = toSet("SELECT", "FROM", "WHERE", "GROUP", "BY", "HAVING", "ORDER", "LIMIT", "OFFSET",
+	"UNION", "INTERSECT", "EXCEPT", "ALL", "DISTINCT", "AS", "ON", "USING", "JOIN", "INNER", "LEFT", "RIGHT",
+	"FULL", "OUTER", "CROSS", "NATURAL", "AND", "OR", "NOT", "IN", "IS", "NULL", "LIKE", "BETWEEN", "EXISTS",
+	"CASE", "WHEN", "THEN", "ELSE", "END", "ASC", "DESC", "TRUE", "FALSE", "INTERVAL", "WITH", "RECURSIVE",
+	"OVER", "PARTITION", "ROWS", "RANGE", "PRECEDING", "FOLLOWING", "UNBOUNDED", "CURRENT", "ROW", "ANY",
+	"SOME", "NULLS", "FIRST", "LAST", "ESCAPE", "LATERAL", "ONLY", "TOP", "ILIKE", "REGEXP", "RLIKE",
+	"SIMILAR", "DIV", "CURRENT_DATE", "CURRENT_TIMESTAMP", "FILTER", "WITHIN")
+
+// viewKeywordFunctions are the keywords which are functions when followed
+// by parentheses.
+var viewKeywordFunctions = toSet("LEFT", "RIGHT", "CURRENT_DATE", "CURRENT_TIMESTAMP")
+
+// viewClauses are the keywords starting the clauses of queries.
+var viewClauses = toSet("SELECT", "FROM", "WHERE", "GROUP", "HAVING", "ORDER", "LIMIT", "UNION", "INTERSECT", "EXCEPT", "ON", "USING", "WITH")
+
+// googleSQLViewFunctions and pgViewFunctions are the functions of view
+// queries supported by Spanner, by dialect.
+var googleSQLViewFunctions = toSet("COUNT", "SUM", "AVG", "MIN", "MAX", "COALESCE", "IFNULL", "NULLIF", "IF",
+	"UPPER", "LOWER", "LENGTH", "CHAR_LENGTH", "CHARACTER_LENGTH", "BYTE_LENGTH", "SUBSTR", "CONCAT", "TRIM",
+	"LTRIM", "RTRIM", "REPLACE", "REVERSE", "LPAD", "RPAD", "REPEAT", "STRPOS", "STARTS_WITH", "ENDS_WITH",
+	"ABS", "ROUND", "CEIL", "CEILING", "FLOOR", "MOD", "POW", "POWER", "SQRT", "SIGN", "GREATEST", "LEAST",
+	"CAST", "SAFE_CAST", "EXTRACT", "CURRENT_TIMESTAMP", "CURRENT_DATE", "DATE", "TIMESTAMP", "DATE_ADD",
+	"DATE_SUB", "DATE_DIFF", "DATE_TRUNC", "TIMESTAMP_ADD", "TIMESTAMP_SUB", "TIMESTAMP_DIFF", "TIMESTAMP_TRUNC",
+	"STRING_AGG", "ARRAY_AGG", "COUNTIF", "LOGICAL_AND", "LOGICAL_OR", "ROW_NUMBER", "RANK", "DENSE_RANK",
+	"LAG", "LEAD", "FIRST_VALUE", "LAST_VALUE", "NTILE", "PERCENT_RANK", "CUME_DIST")
+var pgViewFunctions = toSet("COUNT", "SUM", "AVG", "MIN", "MAX", "COALESCE", "NULLIF", "UPPER", "LOWER",
+	"LENGTH", "CHAR_LENGTH", "CHARACTER_LENGTH", "SUBSTR", "SUBSTRING", "CONCAT", "TRIM", "LTRIM", "RTRIM",
+	"BTRIM", "REPLACE", "REVERSE", "LPAD", "RPAD", "REPEAT", "STRPOS", "ABS", "ROUND", "CEIL", "CEILING",
+	"FLOOR", "MOD", "POWER", "SQRT", "SIGN", "GREATEST", "LEAST", "CAST", "EXTRACT", "NOW", "DATE_TRUNC",
+	"TO_CHAR", "STRING_AGG", "ARRAY_AGG", "BOOL_AND", "BOOL_OR", "ROW_NUMBER", "RANK", "DENSE_RANK", "LAG",
+	"LEAD", "FIRST_VALUE", "LAST_VALUE", "NTILE", "PERCENT_RANK", "CUME_DIST")
+
+// googleSQLViewFunctionRenames and pgViewFunctionRenames map functions of
+// sources to the Spanner functions with the same behavior, by dialect.
+var googleSQLViewFunctionRenames = map[string]map[string]string{
+	constants.MYSQL:     {"NOW": "CURRENT_TIMESTAMP", "CURDATE": "CURRENT_DATE", "LCASE": "LOWER", "UCASE": "UPPER", "SUBSTRING": "SUBSTR"},
+	constants.POSTGRES:  {"NOW": "CURRENT_TIMESTAMP", "SUBSTRING": "SUBSTR"},
+	constants.SQLSERVER: {"GETDATE": "CURRENT_TIMESTAMP", "SYSDATETIME": "CURRENT_TIMESTAMP", "ISNULL": "IFNULL", "LEN": "CHAR_LENGTH", "SUBSTRING": "SUBSTR"},
+}
+var pgViewFunctionRenames = map[string]map[string]string{
+	constants.MYSQL:     {"IFNULL": "COALESCE", "LCASE": "LOWER", "UCASE": "UPPER"},
+	constants.SQLSERVER: {"GETDATE": "NOW", "SYSDATETIME": "NOW", "ISNULL": "COALESCE", "LEN": "LENGTH"},
+}
+
+// viewCastTypes maps the types of casts in view queries to the GoogleSQL
+// and PostgreSQL types of Spanner.
+var viewCastTypes = map[string][2]string{
+	"text": {"STRING", "text"}, "character varying": {"STRING", "varchar"}, "varchar": {"STRING", "varchar"},
+	"character": {"STRING", "varchar"}, "char": {"STRING", "varchar"}, "bpchar": {"STRING", "varchar"},
+	"name": {"STRING", "varchar"}, "nchar": {"STRING", "varchar"}, "nvarchar": {"STRING", "varchar"},
+	"integer": {"INT64", "bigint"}, "int": {"INT64", "bigint"}, "bigint": {"INT64", "bigint"},
+	"smallint": {"INT64", "bigint"}, "int4": {"INT64", "bigint"}, "int8": {"INT64", "bigint"},
+	"signed": {"INT64", "bigint"}, "unsigned": {"INT64", "bigint"},
+	"numeric": {"NUMERIC", "numeric"}, "decimal": {"NUMERIC", "numeric"},
+	"real": {"FLOAT64", "float8"}, "double": {"FLOAT64", "float8"}, "double precision": {"FLOAT64", "float8"},
+	"float": {"FLOAT64", "float8"}, "float8": {"FLOAT64", "float8"},
+	"boolean": {"BOOL", "boolean"}, "bool": {"BOOL", "boolean"}, "bit": {"BOOL", "boolean"},
+	"date":      {"DATE", "date"},
+	"timestamp": {"TIMESTAMP", "timestamptz"}, "timestamp with time zone": {"TIMESTAMP", "timestamptz"},
+	"timestamp without time zone": {"TIMESTAMP", "timestamptz"}, "timestamptz": {"TIMESTAMP", "timestamptz"},
+	"datetime": {"TIMESTAMP", "timestamptz"}, "datetime2": {"TIMESTAMP", "timestamptz"},
+}
+
+var simpleIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// tokenizeViewQuery splits the query of a view of source into tokens,
+// dropping comments.
+func tokenizeViewQuery(source, q string) ([]viewToken, error) {
+	var toks []viewToken
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ';':
+			i++
+		case strings.HasPrefix(q[i:], "--"):
+			for i < len(q) && q[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(q[i:], "/*"):
+			end := strings.Index(q[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("has an unterminated comment")
+			}
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`' || c == '[' && source == constants.SQLSERVER:
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(q); j++ {
+				if q[j] == '\\' && source == constants.MYSQL && c != '`' && j+1 < len(q) {
+					j++
+					b.WriteString(unescapeMySQL(q[j]))
+					continue
+				}
+				if q[j] == closing {
+					// Quotes are escaped by doubling them.
+					if j+1 < len(q) && q[j+1] == closing {
+						j++
+					} else {
+						break
+					}
+				}
+				b.WriteByte(q[j])
+			}
+			if j >= len(q) {
+				return nil, fmt.Errorf("has an unterminated quote %c", c)
+			}
+			kind := viewIdent
+			// Double quotes delimit strings in MySQL, unless ANSI_QUOTES is set.
+			if c == '\'' || c == '"' && source == constants.MYSQL {
+				kind = viewString
+			}
+			toks = append(toks, viewToken{kind: kind, text: b.String()})
+			i = j + 1
+		case isViewWordByte(c) && !isViewDigit(c):
+			j := i
+			for j < len(q) && isViewWordByte(q[j]) {
+				j++
+			}
+			// N'...' are Unicode strings in SQL Server.
+			if source == constants.SQLSERVER && j-i == 1 && (c == 'N' || c == 'n') && j < len(q) && q[j] == '\'' {
+				i = j
+				continue
+			}
+			toks = append(toks, viewToken{kind: viewWord, text: q[i:j]})
+			i = j
+		case isViewDigit(c):
+			j := i
+			for j < len(q) && (isViewDigit(q[j]) || q[j] == '.' || q[j] == 'e' || q[j] == 'E') {
+				j++
+			}
+			toks = append(toks, viewToken{kind: viewNumber, text: q[i:j]})
+			i = j
+		default:
+			symbol := string(c)
+			for _, s := range viewSymbols {
+				if strings.HasPrefix(q[i:], s) {
+					symbol = s
+					break
+				}
+			}
+			toks = append(toks, viewToken{kind: viewSymbol, text: symbol})
+			i += len(symbol)
+		}
+	}
+	return toks, nil
+}
+
+func unescapeMySQL(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 't':
+		return "\t"
+	case 'r':
+		return "\r"
+	case '0':
+		return "\x00"
+	case '%', '_':
+		// \% and \_ are kept escaped, for LIKE patterns.
+		return "\\" + string(c)
+	}
+	return string(c)
+}
+
+func isViewDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isViewWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isViewDigit(c) || c == '_' || c == '$' || c >= 0x80
+}
+
+// viewRelation is a table or view view queries may reference.
+type viewRelation struct {
+	name    string            // Spanner name.
+	viewId  string            // Set for views.
+	columns map[string]string // Spanner column names of tables by lower case source name.
+}
+
+// viewRelations returns the tables and views of conv which view queries may
+// reference, by lower case source schema and name. viewNames are the
+// Spanner names of views by id.
+func viewRelations(conv *internal.Conv, viewNames map[string]string) map[string]viewRelation {
+	relations := make(map[string]viewRelation)
+	for tableId, srcTable := range conv.SrcSchema {
+		spTable, ok := conv.SpSchema[tableId]
+		if !ok {
+			continue
+		}
+		columns := make(map[string]string)
+		for colId, srcCol := range srcTable.ColDefs {
+			if spCol, ok := spTable.ColDefs[colId]; ok {
+				columns[strings.ToLower(srcCol.Name)] = spCol.Name
+			}
+		}
+		relations[relationKey(srcTable.Schema, srcTable.Name)] = viewRelation{name: spTable.Name, columns: columns}
+	}
+	for viewId, name := range viewNames {
+		srcView := conv.SrcViews[viewId]
+		relations[relationKey(srcView.Schema, srcView.Name)] = viewRelation{name: name, viewId: viewId}
+	}
+	return relations
+}
+
+func relationKey(schema, name string) string {
+	return strings.ToLower(schema) + "." + strings.ToLower(name)
+}
+
+// viewOutput is a token of a translated query.
+type viewOutput struct {
+	text     string
+	attached bool // Written without a space before it.
+}
+
+// viewTranslator translates the query of a source view to the dialect of
+// the Spanner database.
+type viewTranslator struct {
+	source    string
+	pg        bool // Whether the Spanner database uses the PostgreSQL dialect.
+	view      schema.View
+	relations map[string]viewRelation
+	columns   map[string]string // Spanner column names by lower case source name, for columns of any table.
+	ctes      map[string]bool   // Lower case names of the common table expressions of the query.
+	toks      []viewToken
+	pos       int
+	out       []viewOutput
+	groups    []int    // Positions in out of the open parentheses.
+	lastGroup int      // Position in out of the last closed parenthesis group, or -1.
+	views     []string // Ids of the views referenced by the query.
+}
+
+// translateViewQuery translates the query of srcView to the dialect of the
+// Spanner database of conv. It returns the translated query and the ids of
+// the views it references, or the reason it can't be translated.
+func translateViewQuery(conv *internal.Conv, srcView schema.View, relations map[string]viewRelation) (string, []string, error) {
+	toks, err := tokenizeViewQuery(conv.Source, srcView.Query)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(toks) == 0 {
+		return "", nil, fmt.Errorf("has no readable definition")
+	}
+	t := &viewTranslator{
+		source:    conv.Source,
+		pg:        conv.SpDialect == constants.DIALECT_POSTGRESQL,
+		view:      srcView,
+		relations: relations,
+		columns:   make(map[string]string),
+		ctes:      make(map[string]bool),
+		toks:      toks,
+		lastGroup: -1,
+	}
+	for _, r := range relations {
+		for srcName, spName := range r.columns {
+			t.columns[srcName] = spName
+		}
+	}
+	if err := t.translate(); err != nil {
+		return "", nil, err
+	}
+	return joinViewOutput(t.out), t.views, nil
+}
+
+func (t *viewTranslator) emit(text string) {
+	t.out = append(t.out, viewOutput{text: text, attached: text == "," || text == ")"})
+}
+
+func (t *viewTranslator) peek() viewToken {
+	if t.pos < len(t.toks) {
+		return t.toks[t.pos]
+	}
+	return viewToken{}
+}
+
+func (t *viewTranslator) peekSymbol(s string) bool {
+	next := t.peek()
+	return next.kind == viewSymbol && next.text == s
+}
+
+func (t *viewTranslator) translate() error {
+	clauses := []string{""} // Clause of the query, at each level of parentheses.
+	calls := []string{""}   // Function whose arguments are in each level of parentheses, if any.
+	relationNext := false   // Whether the next name is that of a table or view.
+	for t.pos < len(t.toks) {
+		tok := t.toks[t.pos]
+		t.pos++
+		depth := len(clauses) - 1
+		isRelation := relationNext
+		relationNext = false
+		upper := strings.ToUpper(tok.text)
+		switch {
+		case tok.kind == viewWord && viewKeywords[upper] && !(viewKeywordFunctions[upper] && t.peekSymbol("(")):
+			switch upper {
+			case "TOP":
+				return fmt.Errorf("uses TOP, which has no Spanner equivalent")
+			case "ILIKE", "REGEXP", "RLIKE", "SIMILAR":
+				if !(t.pg && upper == "ILIKE") {
+					return fmt.Errorf("uses the %s operator, which has no Spanner equivalent", upper)
+				}
+			case "FROM":
+				if calls[depth] != "" {
+					if !t.pg && calls[depth] != "EXTRACT" {
+						return fmt.Errorf("uses FROM in the arguments of %s, which has no Spanner equivalent", calls[depth])
+					}
+				} else {
+					clauses[depth] = upper
+					relationNext = true
+				}
+			case "JOIN":
+				clauses[depth] = "FROM"
+				relationNext = true
+			case "ONLY":
+				relationNext = isRelation
+			case "WITH":
+				if t.peekSymbol("(") {
+					return fmt.Errorf("uses table hints, which Spanner views don't support")
+				}
+				clauses[depth] = upper
+			case "AS":
+				if calls[depth] == "CAST" || calls[depth] == "SAFE_CAST" {
+					t.emit(tok.text)
+					typeName, err := t.castType()
+					if err != nil {
+						return err
+					}
+					t.emit(typeName)
+					continue
+				}
+			case "LIMIT":
+				clauses[depth] = upper
+				t.limit()
+				continue
+			default:
+				if viewClauses[upper] {
+					clauses[depth] = upper
+				}
+			}
+			t.emit(tok.text)
+		case tok.kind == viewWord && t.peekSymbol("("):
+			name, err := t.function(tok.text)
+			if err != nil {
+				return err
+			}
+			t.emit(name)
+			calls = append(calls, strings.ToUpper(name))
+			clauses = append(clauses, "")
+			t.pos++
+			// Casts of the result of the function apply to its name too.
+			t.groups = append(t.groups, len(t.out)-1)
+			t.out = append(t.out, viewOutput{text: "(", attached: true})
+		case tok.kind == viewWord || tok.kind == viewIdent:
+			names, quoted := t.chain(tok)
+			if t.peekSymbol("(") {
+				return fmt.Errorf("uses function %s, which has no Spanner equivalent", strings.Join(names, "."))
+			}
+			switch {
+			case clauses[depth] == "WITH" && len(names) == 1:
+				// Names of common table expressions.
+				t.ctes[strings.ToLower(names[0])] = true
+				t.emit(t.identifier(names[0], quoted))
+			case isRelation:
+				name, err := t.relation(names, quoted)
+				if err != nil {
+					return err
+				}
+				t.emit(name)
+			default:
+				t.emit(t.column(names, quoted))
+			}
+		case tok.kind == viewString:
+			t.emit(t.stringLiteral(tok.text))
+		case tok.kind == viewSymbol && tok.text == "(":
+			clauses = append(clauses, "")
+			calls = append(calls, "")
+			t.groups = append(t.groups, len(t.out))
+			t.emit(tok.text)
+		case tok.kind == viewSymbol && tok.text == ")":
+			if depth == 0 {
+				return fmt.Errorf("has unbalanced parentheses")
+			}
+			clauses = clauses[:depth]
+			calls = calls[:depth]
+			t.emit(tok.text)
+			t.lastGroup = t.groups[len(t.groups)-1]
+			t.groups = t.groups[:len(t.groups)-1]
+			continue
+		case tok.kind == viewSymbol && tok.text == ",":
+			relationNext = clauses[depth] == "FROM" && calls[depth] == ""
+			t.emit(tok.text)
+		case tok.kind == viewSymbol && tok.text == "::":
+			if err := t.cast(); err != nil {
+				return err
+			}
+		case tok.kind == viewSymbol && (tok.text == "@" || tok.text == ":="):
+			return fmt.Errorf("uses variables, which Spanner views don't support")
+		case tok.kind == viewSymbol && tok.text == "<=>":
+			return fmt.Errorf("uses the <=> operator, which has no Spanner equivalent")
+		default:
+			t.emit(tok.text)
+		}
+		t.lastGroup = -1
+	}
+	if len(clauses) > 1 {
+		return fmt.Errorf("has unbalanced parentheses")
+	}
+	return nil
+}
+
+// function returns the Spanner name of the function name.
+func (t *viewTranslator) function(name string) (string, error) {
+	upper := strings.ToUpper(name)
+	renames, functions := googleSQLViewFunctionRenames[t.source], googleSQLViewFunctions
+	if t.pg {
+		renames, functions = pgViewFunctionRenames[t.source], pgViewFunctions
+	}
+	if rename, ok := renames[upper]; ok {
+		return rename, nil
+	}
+	if !functions[upper] {
+		return "", fmt.Errorf("uses function %s, which has no Spanner equivalent", name)
+	}
+	return name, nil
+}
+
+// chain reads the dot-separated names starting with tok, and reports
+// whether any of them is quoted.
+func (t *viewTranslator) chain(tok viewToken) ([]string, bool) {
+	names := []string{tok.text}
+	quoted := tok.kind == viewIdent
+	for t.peekSymbol(".") && t.pos+1 < len(t.toks) {
+		next := t.toks[t.pos+1]
+		if next.kind == viewSymbol && next.text == "*" {
+			break
+		}
+		if next.kind != viewWord && next.kind != viewIdent {
+			break
+		}
+		names = append(names, next.text)
+		quoted = quoted || next.kind == viewIdent
+		t.pos += 2
+	}
+	return names, quoted
+}
+
+// lookup returns the table or view named names, qualified by its schema or
+// not.
+func (t *viewTranslator) lookup(names []string) (viewRelation, bool) {
+	n := len(names)
+	if n > 1 {
+		r, ok := t.relations[relationKey(names[n-2], names[n-1])]
+		return r, ok
+	}
+	if r, ok := t.relations[relationKey(t.view.Schema, names[0])]; ok {
+		return r, true
+	}
+	var found []viewRelation
+	for key, r := range t.relations {
+		if strings.HasSuffix(key, "."+strings.ToLower(names[0])) {
+			found = append(found, r)
+		}
+	}
+	if len(found) == 1 {
+		return found[0], true
+	}
+	return viewRelation{}, false
+}
+
+// relation returns the Spanner name of the table or view named names.
+func (t *viewTranslator) relation(names []string, quoted bool) (string, error) {
+	if len(names) == 1 && t.ctes[strings.ToLower(names[0])] {
+		return t.identifier(names[0], quoted), nil
+	}
+	r, ok := t.lookup(names)
+	if !ok {
+		return "", fmt.Errorf("references %s, which isn't a table or view converted to Spanner", strings.Join(names, "."))
+	}
+	if r.viewId != "" {
+		t.views = append(t.views, r.viewId)
+	}
+	return t.identifier(r.name, quoted), nil
+}
+
+// column returns the Spanner name of the column named names, qualified by
+// its table or not, or of the qualified star following names.
+func (t *viewTranslator) column(names []string, quoted bool) string {
+	qualifier, name := names[:len(names)-1], names[len(names)-1]
+	star := t.peekSymbol(".") && t.pos+1 < len(t.toks) && t.toks[t.pos+1].text == "*"
+	if star {
+		qualifier = names
+		t.pos += 2
+	}
+	columns := t.columns
+	var parts []string
+	if len(qualifier) > 0 {
+		// Columns are qualified by the name of a table or view, or an alias.
+		if r, ok := t.lookup(qualifier); ok {
+			if r.viewId == "" {
+				columns = r.columns
+			}
+			parts = append(parts, t.identifier(r.name, quoted))
+		} else {
+			for _, q := range qualifier {
+				parts = append(parts, t.identifier(q, quoted))
+			}
+		}
+	}
+	if star {
+		return strings.Join(append(parts, "*"), ".")
+	}
+	if spName, ok := columns[strings.ToLower(name)]; ok {
+		name = spName
+	}
+	return strings.Join(append(parts, t.identifier(name, quoted)), ".")
+}
+
+// identifier returns name, quoted as needed in the dialect of the Spanner
+// database.
+func (t *viewTranslator) identifier(name string, quoted bool) string {
+	if t.pg {
+		if quoted || name != strings.ToLower(name) || !simpleIdentifierRegex.MatchString(name) {
+			return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+		}
+		return name
+	}
+	if quoted || !simpleIdentifierRegex.MatchString(name) {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// stringLiteral returns the string literal of s in the dialect of the
+// Spanner database.
+func (t *viewTranslator) stringLiteral(s string) string {
+	if t.pg {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return "'" + r.Replace(s) + "'"
+}
+
+// limit translates the arguments of LIMIT, rewriting the MySQL LIMIT offset,
+// count as LIMIT count OFFSET offset.
+func (t *viewTranslator) limit() {
+	t.emit("LIMIT")
+	if t.pos+2 < len(t.toks) && t.toks[t.pos].kind == viewNumber && t.toks[t.pos+1].text == "," && t.toks[t.pos+2].kind == viewNumber {
+		t.emit(t.toks[t.pos+2].text)
+		t.emit("OFFSET")
+		t.emit(t.toks[t.pos].text)
+		t.pos += 3
+	}
+}
+
+// castType reads the type of a cast, and returns the Spanner type it maps
+// to. Type modifiers are dropped, but for PostgreSQL sources of databases
+// with the PostgreSQL dialect, whose types are kept.
+func (t *viewTranslator) castType() (string, error) {
+	typeName := ""
+	for t.peek().kind == viewWord || t.peek().kind == viewIdent {
+		candidate := strings.TrimSpace(typeName + " " + strings.ToLower(t.peek().text))
+		if typeName != "" && !isViewCastTypePrefix(candidate) {
+			break
+		}
+		typeName = candidate
+		t.pos++
+	}
+	mods := ""
+	if t.peekSymbol("(") {
+		for t.pos < len(t.toks) && !t.peekSymbol(")") {
+			mods += t.peek().text
+			t.pos++
+		}
+		mods += ")"
+		t.pos++
+	}
+	if t.pg && t.source == constants.POSTGRES {
+		return typeName + mods, nil
+	}
+	spTypes, ok := viewCastTypes[typeName]
+	if !ok {
+		return "", fmt.Errorf("uses a cast to %s, which has no Spanner equivalent", typeName)
+	}
+	if t.pg {
+		return spTypes[1], nil
+	}
+	return spTypes[0], nil
+}
+
+func isViewCastTypePrefix(s string) bool {
+	for typeName := range viewCastTypes {
+		if typeName == s || strings.HasPrefix(typeName, s+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// cast translates a PostgreSQL :: cast, rewriting it with CAST in GoogleSQL.
+// Casts to strings are dropped in GoogleSQL, since all strings are STRING.
+func (t *viewTranslator) cast() error {
+	typeName, err := t.castType()
+	if err != nil {
+		return err
+	}
+	if t.pg {
+		t.out = append(t.out, viewOutput{text: "::" + typeName, attached: true})
+		return nil
+	}
+	start := len(t.out) - 1
+	if t.lastGroup >= 0 {
+		start = t.lastGroup
+	}
+	if start < 0 {
+		return fmt.Errorf("uses a cast without an operand")
+	}
+	if typeName == "STRING" {
+		return nil
+	}
+	operand := joinViewOutput(t.out[start:])
+	t.out = append(t.out[:start], viewOutput{text: fmt.Sprintf("CAST(%s AS %s)", operand, typeName), attached: t.out[start].attached})
+	return nil
+}
+
+// joinViewOutput returns the text of the tokens of a translated query.
+func joinViewOutput(out []viewOutput) string {
+	var b strings.Builder
+	for i, o := range out {
+		if i > 0 && !o.attached && out[i-1].text != "(" {
+			b.WriteString(" ")
+		}
+		b.WriteString(o.text)
+	}
+	return b.String()
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal/reports"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/proto/migration"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// viewsConv returns a conv with a users table, whose source column
+// "Full Name" is named Full_Name in Spanner.
+func viewsConv(source, dialect string) *internal.Conv {
+	conv := internal.MakeConv()
+	conv.Source = source
+	conv.SpDialect = dialect
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Id: "t1", Name: "users", Schema: "shop", ColIds: []string{"c1", "c2", "c3"}, ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id"},
+			"c2": {Id: "c2", Name: "Full Name"},
+			"c3": {Id: "c3", Name: "active"},
+		}},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {Id: "t1", Name: "users", ColIds: []string{"c1", "c2", "c3"}, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Id: "c1", Name: "id"},
+			"c2": {Id: "c2", Name: "Full_Name"},
+			"c3": {Id: "c3", Name: "active"},
+		}},
+	}
+	return conv
+}
+
+func TestTranslateViewQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		dialect  string
+		query    string
+		expected string
+		err      string
+	}{
+		{
+			name:     "mysql quoted identifiers",
+			source:   constants.MYSQL,
+			dialect:  constants.DIALECT_GOOGLESQL,
+			query:    "select `shop`.`users`.`id` AS `id`,`shop`.`users`.`Full Name` AS `name` from `shop`.`users` where (`shop`.`users`.`active` = 1)",
+			expected: "select `users`.`id` AS `id`, `users`.`Full_Name` AS `name` from `users` where (`users`.`active` = 1)",
+		},
+		{
+			name:     "mysql functions and limit",
+			source:   constants.MYSQL,
+			dialect:  constants.DIALECT_GOOGLESQL,
+			query:    "SELECT IFNULL(id, 0) AS id, UPPER(\"it's\") FROM users LIMIT 5, 10",
+			expected: "SELECT IFNULL(id, 0) AS id, UPPER('it\\'s') FROM users LIMIT 10 OFFSET 5",
+		},
+		{
+			name:     "postgres casts",
+			source:   constants.POSTGRES,
+			dialect:  constants.DIALECT_GOOGLESQL,
+			query:    " SELECT users.id::text AS id,\n    (users.id + 1)::bigint AS next\n   FROM shop.users\n  WHERE users.active;",
+			expected: "SELECT users.id AS id, CAST((users.id + 1) AS INT64) AS next FROM users WHERE users.active",
+		},
+		{
+			name:     "postgres to postgresql dialect",
+			source:   constants.POSTGRES,
+			dialect:  constants.DIALECT_POSTGRESQL,
+			query:    "SELECT id::text, \"Full Name\" FROM shop.users WHERE \"Full Name\" ILIKE 'a%'",
+			expected: "SELECT id::text, \"Full_Name\" FROM users WHERE \"Full_Name\" ILIKE 'a%'",
+		},
+		{
+			name:     "sqlserver functions",
+			source:   constants.SQLSERVER,
+			dialect:  constants.DIALECT_GOOGLESQL,
+			query:    "SELECT ISNULL([id], 0) AS id, N'x' AS x FROM [shop].[users]",
+			expected: "SELECT IFNULL(`id`, 0) AS id, 'x' AS x FROM `users`",
+		},
+		{
+			name:    "sqlserver top",
+			source:  constants.SQLSERVER,
+			dialect: constants.DIALECT_GOOGLESQL,
+			query:   "SELECT TOP 10 id FROM users",
+			err:     "uses TOP, which has no Spanner equivalent",
+		},
+		{
+			name:    "unknown function",
+			source:  constants.MYSQL,
+			dialect: constants.DIALECT_GOOGLESQL,
+			query:   "SELECT shop.discount(id) FROM users",
+			err:     "uses function shop.discount, which has no Spanner equivalent",
+		},
+		{
+			name:    "missing table",
+			source:  constants.MYSQL,
+			dialect: constants.DIALECT_GOOGLESQL,
+			query:   "SELECT id FROM users JOIN orders ON orders.user_id = users.id",
+			err:     "references orders, which isn't a table or view converted to Spanner",
+		},
+		{
+			name:    "variables",
+			source:  constants.MYSQL,
+			dialect: constants.DIALECT_GOOGLESQL,
+			query:   "SELECT @rank := @rank + 1 FROM users",
+			err:     "uses variables, which Spanner views don't support",
+		},
+	}
+	for _, tc := range tests {
+		conv := viewsConv(tc.source, tc.dialect)
+		view := schema.View{Id: "v1", Name: "v", Schema: "shop", Query: tc.query}
+		query, _, err := translateViewQuery(conv, view, viewRelations(conv, nil))
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, query, tc.name)
+	}
+}
+
+func TestCvtViewsDependencies(t *testing.T) {
+	conv := viewsConv(constants.MYSQL, constants.DIALECT_GOOGLESQL)
+	conv.SrcViews = map[string]schema.View{
+		"v1": {Id: "v1", Name: "active_users", Schema: "shop", Query: "SELECT id FROM users WHERE active"},
+		"v2": {Id: "v2", Name: "user_orders", Schema: "shop", Query: "SELECT id FROM users JOIN orders ON orders.user_id = users.id"},
+		"v3": {Id: "v3", Name: "active_user_orders", Schema: "shop", Query: "SELECT id FROM active_users JOIN user_orders USING (id)"},
+	}
+	cvtViews(conv)
+	assert.Len(t, conv.SpViews, 1)
+	assert.Equal(t, "SELECT id FROM users WHERE active", conv.SpViews["v1"].Query)
+	assert.Equal(t, map[string]string{
+		"v2": "references orders, which isn't a table or view converted to Spanner",
+		"v3": "references view user_orders, which isn't converted to Spanner",
+	}, conv.ViewIssues)
+}
+
+func TestViewReports(t *testing.T) {
+	conv := viewsConv(constants.SQLSERVER, constants.DIALECT_GOOGLESQL)
+	conv.SrcViews = map[string]schema.View{
+		"v1": {Id: "v1", Name: "active users", Schema: "shop", Query: "SELECT id FROM users WHERE active = 1"},
+		"v2": {Id: "v2", Name: "top_users", Schema: "shop", Query: "SELECT TOP 10 id\nFROM users"},
+		"v3": {Id: "v3", Name: "user_ids", Schema: "shop", Query: "SELECT id FROM users", Materialized: true},
+	}
+	cvtViews(conv)
+	conv.Audit.MigrationType = migration.MigrationData_SCHEMA_ONLY.Enum()
+	reportGenerator := reports.ReportImpl{}
+	structuredReport := reportGenerator.GenerateStructuredReport(constants.SQLSERVER, "shop", conv, nil, true, true)
+	assert.Equal(t, []reports.ViewReport{
+		{SrcViewName: "active users", SpViewName: "active_users"},
+		{SrcViewName: "top_users", Issue: "uses TOP, which has no Spanner equivalent", Definition: "SELECT TOP 10 id\nFROM users"},
+		{SrcViewName: "user_ids", SpViewName: "user_ids", Materialized: true},
+	}, structuredReport.ViewReports)
+
+	buf := new(bytes.Buffer)
+	w := bufio.NewWriter(buf)
+	reportGenerator.GenerateTextReport(structuredReport, w)
+	w.Flush()
+	assert.Contains(t, buf.String(), "View active users: converted (mapped to Spanner view active_users).\n"+
+		"View top_users: not converted, because it uses TOP, which has no Spanner\n"+
+		"equivalent.\n"+
+		"    SELECT TOP 10 id\n"+
+		"    FROM users\n"+
+		"Materialized view user_ids: converted. Its rows are computed on each read, which\n"+
+		"is always up to date but can be slower than reading the stored rows of the\n"+
+		"source.\n")
+}
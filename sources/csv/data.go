@@ -30,6 +30,7 @@ import (
 	"cloud.google.com/go/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
@@ -129,11 +130,21 @@ func VerifyManifest(conv *internal.Conv, tables []utils.ManifestTable) error {
 	return nil
 }
 
+// openCSV returns a reader of the csv file at filePath, which is
+// decompressed as it is read if it is gzip or zstd compressed.
+func openCSV(filePath string) (io.Reader, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return file_reader.Decompress(f)
+}
+
 // SetRowStats calculates the number of rows per table.
 func (c *CsvImpl) SetRowStats(conv *internal.Conv, tables []utils.ManifestTable, delimiter rune) error {
 	for _, table := range tables {
 		for _, filePath := range table.File_patterns {
-			csvFile, err := os.Open(filePath)
+			csvFile, err := openCSV(filePath)
 			if err != nil {
 				return fmt.Errorf("can't read csv file: %s due to: %v", filePath, err)
 			}
@@ -195,7 +206,7 @@ func getCSVDataRowCount(r *csvReader.Reader, colNames []string) (int64, error) {
 // ProcessCSV writes data across the tables provided in the manifest file. Each table's data can be provided
 // across multiple CSV files hence, the manifest accepts a list of file paths in the input.
 func (c *CsvImpl) ProcessCSV(conv *internal.Conv, tables []utils.ManifestTable, nullStr string, delimiter rune) error {
-	tableIds := ddl.GetSortedTableIdsBySpName(conv.SpSchema)
+	tableIds, _ := ddl.GetSortedTableIdsForDataLoad(conv.SpSchema)
 	nameToFiles := map[string][]string{}
 	for _, table := range tables {
 		nameToFiles[table.Table_name] = table.File_patterns
@@ -219,7 +230,7 @@ func (c *CsvImpl) ProcessCSV(conv *internal.Conv, tables []utils.ManifestTable,
 			}
 			colDefs := conv.SpSchema[tableId].ColDefs
 
-			csvFile, err := os.Open(filePath)
+			csvFile, err := openCSV(filePath)
 			if err != nil {
 				return fmt.Errorf(fmt.Sprintf("can't read csv file: %s due to: %v\n", filePath, err))
 			}
@@ -15,9 +15,11 @@
 package csv
 
 import (
+	"compress/gzip"
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -135,6 +137,36 @@ func TestSetRowStats(t *testing.T) {
 	assert.Equal(t, map[string]int64{ALL_TYPES_TABLE: 1, SINGERS_TABLE: 2}, conv.Stats.Rows)
 }
 
+func TestProcessCSVCompressed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SINGERS_1_CSV+".gz")
+	f, err := os.Create(path)
+	assert.Nil(t, err)
+	w := gzip.NewWriter(f)
+	_, err = w.Write([]byte("SingerId,FirstName,LastName\n1,fn1,ln1\n2,fn2,ln2\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	assert.Nil(t, f.Close())
+	tables := []utils.ManifestTable{{Table_name: SINGERS_TABLE, File_patterns: []string{path}}}
+
+	conv := buildConv(getCreateSingersTable())
+	csv := CsvImpl{}
+	assert.Nil(t, csv.SetRowStats(conv, tables, ','))
+	assert.Equal(t, map[string]int64{SINGERS_TABLE: 2}, conv.Stats.Rows)
+
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	err = csv.ProcessCSV(conv, tables, "", ',')
+	assert.Nil(t, err)
+	assert.Equal(t, []spannerData{
+		{table: SINGERS_TABLE, cols: []string{"SingerId", "FirstName", "LastName"}, vals: []interface{}{int64(1), "fn1", "ln1"}},
+		{table: SINGERS_TABLE, cols: []string{"SingerId", "FirstName", "LastName"}, vals: []interface{}{int64(2), "fn2", "ln2"}},
+	}, rows)
+}
+
 func TestProcessCSV(t *testing.T) {
 	writeCSVs(t)
 	defer cleanupCSVs()
@@ -0,0 +1,470 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Types of the fields of fixed-width records.
+const (
+	fieldString = "string" // Text, padded to the width of the field.
+	fieldZoned  = "zoned"  // Zoned decimal: digits, the last one possibly overpunched with the sign.
+	fieldPacked = "packed" // Packed decimal (COMP-3): two digits per byte, and a sign nibble.
+	fieldBinary = "binary" // Big-endian two's complement integer (COMP) of up to 8 bytes.
+)
+
+// encodings are the character encodings of fixed-width files, keyed by
+// their IANA names. Files are UTF-8 by default.
+var encodings = map[string]encoding.Encoding{
+	"iso-8859-1":   charmap.ISO8859_1,
+	"windows-1252": charmap.Windows1252,
+	"ibm037":       charmap.CodePage037,
+	"ibm1047":      charmap.CodePage1047,
+	"ibm01140":     charmap.CodePage1140,
+}
+
+// FixedWidthColumn is the layout of a column in the records of a
+// fixed-width file.
+type FixedWidthColumn struct {
+	Column_name string `json:"column_name"`
+	Offset      int    `json:"offset"` // 0-based offset of the field in the record, in bytes.
+	Width       int    `json:"width"`  // Width of the field, in bytes.
+	Type        string `json:"type"`   // string by default.
+	Scale       int    `json:"scale"`  // Number of implied decimal places of numeric fields.
+	Padding     string `json:"padding"`
+	Align       string `json:"align"` // left (padded on the right) by default, or right.
+}
+
+// FixedWidthTable is an item of the manifest of fixed-width files: a
+// CSV manifest item with the layout of the records of its files.
+type FixedWidthTable struct {
+	utils.ManifestTable
+	Encoding      string             `json:"encoding"`
+	Record_length int                `json:"record_length"` // Records are lines if 0.
+	Columns       []FixedWidthColumn `json:"columns"`
+}
+
+type FixedWidthInterface interface {
+	GetFixedWidthFiles(conv *internal.Conv, sourceProfile profiles.SourceProfile) ([]FixedWidthTable, error)
+	SetFixedWidthRowStats(conv *internal.Conv, tables []FixedWidthTable) error
+	ProcessFixedWidth(conv *internal.Conv, tables []FixedWidthTable, nullStr string) error
+}
+
+type FixedWidthImpl struct{}
+
+// GetFixedWidthFiles reads the manifest of the fixed-width files, which
+// is required since it gives the layouts of their records, and downloads
+// gcs files in any.
+func (fw *FixedWidthImpl) GetFixedWidthFiles(conv *internal.Conv, sourceProfile profiles.SourceProfile) ([]FixedWidthTable, error) {
+	if sourceProfile.Csv.Manifest == "" {
+		return nil, fmt.Errorf("a manifest with the layout of the records is required for fixed-width files")
+	}
+	manifest, err := ioutil.ReadFile(sourceProfile.Csv.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("can't read manifest file due to: %v", err)
+	}
+	tables := []FixedWidthTable{}
+	if err := json.Unmarshal(manifest, &tables); err != nil {
+		return nil, fmt.Errorf("unable to unmarshall json due to: %v", err)
+	}
+	if err := VerifyFixedWidthManifest(conv, tables); err != nil {
+		return nil, fmt.Errorf("manifest is incomplete: %v", err)
+	}
+	manifestTables := []utils.ManifestTable{}
+	for _, table := range tables {
+		manifestTables = append(manifestTables, table.ManifestTable)
+	}
+	manifestTables, err = utils.PreloadGCSFiles(manifestTables)
+	if err != nil {
+		return nil, fmt.Errorf("gcs file download error: %v", err)
+	}
+	for i := range tables {
+		tables[i].ManifestTable = manifestTables[i]
+	}
+	return tables, nil
+}
+
+// VerifyFixedWidthManifest performs the checks of VerifyManifest, and
+// checks the layouts of the records against the Spanner schema.
+func VerifyFixedWidthManifest(conv *internal.Conv, tables []FixedWidthTable) error {
+	manifestTables := []utils.ManifestTable{}
+	for _, table := range tables {
+		manifestTables = append(manifestTables, table.ManifestTable)
+	}
+	if err := VerifyManifest(conv, manifestTables); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		name := table.Table_name
+		if _, ok := encodings[strings.ToLower(table.Encoding)]; !ok && table.Encoding != "" && !strings.EqualFold(table.Encoding, "utf-8") {
+			return fmt.Errorf("unsupported encoding %s for table %s", table.Encoding, name)
+		}
+		if table.Record_length < 0 {
+			return fmt.Errorf("invalid record length %d for table %s", table.Record_length, name)
+		}
+		if len(table.Columns) == 0 {
+			return fmt.Errorf("no columns provided for table %s", name)
+		}
+		tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, name)
+		if err != nil {
+			return fmt.Errorf("table %s provided in manifest does not exist in spanner", name)
+		}
+		seen := make(map[string]bool)
+		for _, col := range table.Columns {
+			if err := verifyFixedWidthColumn(col, table.Record_length); err != nil {
+				return fmt.Errorf("invalid column %s of table %s: %v", col.Column_name, name, err)
+			}
+			if seen[col.Column_name] {
+				return fmt.Errorf("column %s of table %s is provided more than once", col.Column_name, name)
+			}
+			seen[col.Column_name] = true
+			if _, err := internal.GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, col.Column_name); err != nil {
+				return fmt.Errorf("column %s of table %s provided in manifest does not exist in spanner", col.Column_name, name)
+			}
+		}
+	}
+	return nil
+}
+
+func verifyFixedWidthColumn(col FixedWidthColumn, recordLength int) error {
+	switch {
+	case col.Column_name == "":
+		return fmt.Errorf("column does not have a name")
+	case col.Offset < 0 || col.Width <= 0:
+		return fmt.Errorf("invalid offset %d or width %d", col.Offset, col.Width)
+	case recordLength > 0 && col.Offset+col.Width > recordLength:
+		return fmt.Errorf("field ends after the end of records of length %d", recordLength)
+	case len([]rune(col.Padding)) > 1:
+		return fmt.Errorf("padding %q is not a single character", col.Padding)
+	case col.Align != "" && col.Align != "left" && col.Align != "right":
+		return fmt.Errorf("invalid align %s: expected left or right", col.Align)
+	case col.Scale < 0:
+		return fmt.Errorf("invalid scale %d", col.Scale)
+	}
+	switch col.Type {
+	case "", fieldString:
+		if col.Scale != 0 {
+			return fmt.Errorf("scale is only supported for numeric fields")
+		}
+	case fieldZoned, fieldPacked:
+	case fieldBinary:
+		if col.Width > 8 {
+			return fmt.Errorf("binary fields are at most 8 bytes wide")
+		}
+	default:
+		return fmt.Errorf("invalid type %s: expected %s, %s, %s or %s", col.Type, fieldString, fieldZoned, fieldPacked, fieldBinary)
+	}
+	return nil
+}
+
+// SetFixedWidthRowStats calculates the number of rows per table.
+func (fw *FixedWidthImpl) SetFixedWidthRowStats(conv *internal.Conv, tables []FixedWidthTable) error {
+	for _, table := range tables {
+		for _, filePath := range table.File_patterns {
+			var count int64
+			err := readFixedWidthFile(filePath, table, func(record []byte) error {
+				count++
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("error reading file %s for table %s: %v", filePath, table.Table_name, err)
+			}
+			if count == 0 {
+				conv.Unexpected(fmt.Sprintf("error processing table %s: file %s is empty.", table.Table_name, filePath))
+				continue
+			}
+			conv.Stats.Rows[table.Table_name] += count
+		}
+	}
+	return nil
+}
+
+// ProcessFixedWidth writes data across the tables provided in the
+// manifest, as ProcessCSV does. The fields of records are converted to
+// strings, which are converted to Spanner data as CSV values are.
+func (fw *FixedWidthImpl) ProcessFixedWidth(conv *internal.Conv, tables []FixedWidthTable, nullStr string) error {
+	tableIds, _ := ddl.GetSortedTableIdsForDataLoad(conv.SpSchema)
+	nameToTable := map[string]FixedWidthTable{}
+	for _, table := range tables {
+		nameToTable[table.Table_name] = table
+	}
+	for _, id := range tableIds {
+		table, ok := nameToTable[conv.SpSchema[id].Name]
+		if !ok {
+			continue
+		}
+		colNames := []string{}
+		for _, col := range table.Columns {
+			colNames = append(colNames, col.Column_name)
+		}
+		colDefs := conv.SpSchema[id].ColDefs
+		dec := fieldDecoder(table.Encoding)
+		for _, filePath := range table.File_patterns {
+			err := readFixedWidthFile(filePath, table, func(record []byte) error {
+				values := make([]string, len(table.Columns))
+				for i, col := range table.Columns {
+					v, err := fieldValue(dec, col, record)
+					if err != nil {
+						logger.Log.Error(fmt.Sprintf("Error while reading column %s of table %s: %s\n", col.Column_name, table.Table_name, err))
+						return nil
+					}
+					if v == "" {
+						// Blank fields are null.
+						v = nullStr
+					}
+					values[i] = v
+				}
+				processDataRow(conv, nullStr, table.Table_name, colNames, colDefs, values)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("can't read fixed-width file %s for table %s: %v", filePath, table.Table_name, err)
+			}
+		}
+		if conv.DataFlush != nil {
+			conv.DataFlush()
+		}
+	}
+	return nil
+}
+
+// readFixedWidthFile calls processRecord with each record of the file at
+// filePath. Records are either blocks of the record length of the table,
+// or lines, separated by the encoding of \n. Empty lines are skipped.
+func readFixedWidthFile(filePath string, table FixedWidthTable, processRecord func(record []byte) error) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	content, err := file_reader.Decompress(f)
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(content)
+	if table.Record_length > 0 {
+		record := make([]byte, table.Record_length)
+		for {
+			_, err := io.ReadFull(r, record)
+			if err == io.EOF {
+				return nil
+			}
+			if err == io.ErrUnexpectedEOF {
+				return fmt.Errorf("file ends with a partial record")
+			}
+			if err != nil {
+				return err
+			}
+			if err := processRecord(record); err != nil {
+				return err
+			}
+		}
+	}
+	newline, carriageReturn := encodeByte(table.Encoding, '\n'), encodeByte(table.Encoding, '\r')
+	for {
+		line, err := r.ReadBytes(newline)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		line = bytes.TrimSuffix(bytes.TrimSuffix(line, []byte{newline}), []byte{carriageReturn})
+		if len(line) > 0 {
+			if err := processRecord(line); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// encodeByte returns the byte of an ASCII character in an encoding, e.g.
+// 0x25 for \n in EBCDIC.
+func encodeByte(enc string, c byte) byte {
+	e, ok := encodings[strings.ToLower(enc)]
+	if !ok {
+		return c
+	}
+	b, err := e.NewEncoder().Bytes([]byte{c})
+	if err != nil || len(b) != 1 {
+		return c
+	}
+	return b[0]
+}
+
+// fieldDecoder returns a function decoding text in an encoding to UTF-8.
+func fieldDecoder(enc string) func([]byte) string {
+	e, ok := encodings[strings.ToLower(enc)]
+	if !ok {
+		return func(b []byte) string { return string(b) }
+	}
+	return func(b []byte) string {
+		s, _ := e.NewDecoder().Bytes(b)
+		return string(s)
+	}
+}
+
+// fieldValue returns the value of the field of a column in a record, as
+// a string. Fields ending after the end of the record, which happens when
+// trailing spaces of lines are trimmed, are truncated. Blank fields, other
+// than binary ones, are empty strings.
+func fieldValue(dec func([]byte) string, col FixedWidthColumn, record []byte) (string, error) {
+	if col.Offset >= len(record) {
+		return "", nil
+	}
+	raw := record[col.Offset:min(col.Offset+col.Width, len(record))]
+	if col.Type == fieldBinary {
+		return binaryInteger(raw, col.Scale), nil
+	}
+	text := dec(raw)
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+	switch col.Type {
+	case fieldZoned:
+		return zonedDecimal(strings.TrimSpace(text), col.Scale)
+	case fieldPacked:
+		return packedDecimal(raw, col.Scale)
+	}
+	return trimPadding(text, col), nil
+}
+
+// trimPadding removes the padding of a text field: on the right of left
+// aligned fields, and on the left of right aligned ones. Fields padded
+// with zeros keep at least one zero.
+func trimPadding(text string, col FixedWidthColumn) string {
+	pad := col.Padding
+	if pad == "" {
+		pad = " "
+	}
+	var trimmed string
+	if col.Align == "right" {
+		trimmed = strings.TrimLeft(text, pad)
+	} else {
+		trimmed = strings.TrimRight(text, pad)
+	}
+	if trimmed == "" && pad == "0" {
+		return "0"
+	}
+	return trimmed
+}
+
+// zonedDecimal converts a zoned decimal to a decimal string. The sign is
+// either overpunched in the last digit ({ and A to I for positive digits,
+// } and J to R for negative ones), or a leading or trailing + or -.
+func zonedDecimal(text string, scale int) (string, error) {
+	negative := false
+	switch {
+	case strings.HasPrefix(text, "-"), strings.HasPrefix(text, "+"):
+		negative = text[0] == '-'
+		text = text[1:]
+	case strings.HasSuffix(text, "-"), strings.HasSuffix(text, "+"):
+		negative = text[len(text)-1] == '-'
+		text = text[:len(text)-1]
+	}
+	digits := []byte(text)
+	if n := len(digits); n > 0 {
+		switch last := digits[n-1]; {
+		case last == '{':
+			digits[n-1] = '0'
+		case last >= 'A' && last <= 'I':
+			digits[n-1] = '1' + last - 'A'
+		case last == '}':
+			digits[n-1], negative = '0', true
+		case last >= 'J' && last <= 'R':
+			digits[n-1], negative = '1'+last-'J', true
+		}
+	}
+	if len(digits) == 0 {
+		return "", fmt.Errorf("invalid zoned decimal %q", text)
+	}
+	for _, d := range digits {
+		if d < '0' || d > '9' {
+			return "", fmt.Errorf("invalid zoned decimal %q", text)
+		}
+	}
+	return scaleDigits(negative, string(digits), scale), nil
+}
+
+// packedDecimal converts a packed decimal to a decimal string. The last
+// nibble is the sign: B and D are negative, and A, C, E and F positive.
+func packedDecimal(raw []byte, scale int) (string, error) {
+	var digits []byte
+	for i, b := range raw {
+		hi, lo := b>>4, b&0x0f
+		if hi > 9 || (i < len(raw)-1 && lo > 9) {
+			return "", fmt.Errorf("invalid packed decimal %X", raw)
+		}
+		digits = append(digits, '0'+hi)
+		if i < len(raw)-1 {
+			digits = append(digits, '0'+lo)
+		}
+	}
+	sign := raw[len(raw)-1] & 0x0f
+	if sign < 0x0a {
+		return "", fmt.Errorf("invalid sign of packed decimal %X", raw)
+	}
+	return scaleDigits(sign == 0x0b || sign == 0x0d, string(digits), scale), nil
+}
+
+// binaryInteger converts a big-endian two's complement integer to a
+// decimal string.
+func binaryInteger(raw []byte, scale int) string {
+	var n int64
+	if raw[0]&0x80 != 0 {
+		n = -1
+	}
+	for _, b := range raw {
+		n = n<<8 | int64(b)
+	}
+	negative := n < 0
+	digits := fmt.Sprint(n)
+	if negative {
+		digits = digits[1:]
+	}
+	return scaleDigits(negative, digits, scale)
+}
+
+// scaleDigits formats digits with scale implied decimal places.
+func scaleDigits(negative bool, digits string, scale int) string {
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
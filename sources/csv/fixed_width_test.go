@@ -0,0 +1,233 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// getFixedWidthTables returns the layouts of the test files: singers are
+// UTF-8 lines, and all data types EBCDIC records of 48 bytes.
+func getFixedWidthTables(dir string) []FixedWidthTable {
+	return []FixedWidthTable{
+		{
+			ManifestTable: utils.ManifestTable{Table_name: SINGERS_TABLE, File_patterns: []string{filepath.Join(dir, "singers.txt")}},
+			Columns: []FixedWidthColumn{
+				{Column_name: "SingerId", Offset: 0, Width: 4, Type: fieldZoned},
+				{Column_name: "FirstName", Offset: 4, Width: 8},
+				{Column_name: "LastName", Offset: 12, Width: 8, Padding: "*", Align: "right"},
+			},
+		},
+		{
+			ManifestTable: utils.ManifestTable{Table_name: ALL_TYPES_TABLE, File_patterns: []string{filepath.Join(dir, "all_data_types.dat")}},
+			Encoding:      "IBM037",
+			Record_length: 48,
+			Columns: []FixedWidthColumn{
+				{Column_name: "bool_col", Offset: 0, Width: 5},
+				{Column_name: "int_col", Offset: 5, Width: 3, Type: fieldPacked},
+				{Column_name: "numeric_col", Offset: 8, Width: 6, Type: fieldZoned, Scale: 2},
+				{Column_name: "float_col", Offset: 14, Width: 4, Type: fieldBinary, Scale: 2},
+				{Column_name: "date_col", Offset: 18, Width: 10},
+				{Column_name: "string_col", Offset: 28, Width: 20},
+			},
+		},
+	}
+}
+
+func ebcdic(t *testing.T, s string) []byte {
+	b, err := charmap.CodePage037.NewEncoder().Bytes([]byte(s))
+	assert.Nil(t, err)
+	return b
+}
+
+// writeFixedWidthFiles writes the test files, whose records are in the
+// layouts of getFixedWidthTables.
+func writeFixedWidthFiles(t *testing.T, dir string) {
+	singers := "0001Ann     ****Lee\r\n000BBob     Smith***\n\n"
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "singers.txt"), []byte(singers), 0644))
+	var data []byte
+	for _, record := range []struct {
+		packed []byte
+		binary []byte
+		text   [3]string
+	}{
+		{packed: []byte{0x00, 0x10, 0x0c}, binary: []byte{0x00, 0x00, 0x05, 0xeb}, text: [3]string{"true ", "00399}", "2019-10-29Hello world         "}},
+		{packed: []byte{0x00, 0x02, 0x5d}, binary: []byte{0xff, 0xff, 0xff, 0x9c}, text: [3]string{"false", "000012", "          "}},
+	} {
+		data = append(data, ebcdic(t, record.text[0])...)
+		data = append(data, record.packed...)
+		data = append(data, ebcdic(t, record.text[1])...)
+		data = append(data, record.binary...)
+		data = append(data, ebcdic(t, record.text[2])...)
+		data = append(data, ebcdic(t, strings.Repeat(" ", (48-len(data)%48)%48))...)
+	}
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "all_data_types.dat"), data, 0644))
+}
+
+func TestSetFixedWidthRowStats(t *testing.T) {
+	dir := t.TempDir()
+	writeFixedWidthFiles(t, dir)
+	conv := buildConv(getCreateTable())
+	fw := FixedWidthImpl{}
+	assert.Nil(t, fw.SetFixedWidthRowStats(conv, getFixedWidthTables(dir)))
+	assert.Equal(t, map[string]int64{ALL_TYPES_TABLE: 2, SINGERS_TABLE: 2}, conv.Stats.Rows)
+
+	// Files must end with a complete record.
+	tables := getFixedWidthTables(dir)
+	tables[1].Record_length = 50
+	assert.NotNil(t, fw.SetFixedWidthRowStats(conv, tables))
+}
+
+func TestProcessFixedWidth(t *testing.T) {
+	dir := t.TempDir()
+	writeFixedWidthFiles(t, dir)
+	allTypesCols := []string{"bool_col", "int_col", "numeric_col", "float_col", "date_col", "string_col"}
+	want := []spannerData{
+		{
+			table: ALL_TYPES_TABLE,
+			cols:  allTypesCols,
+			vals:  []interface{}{true, int64(100), *big.NewRat(-399, 10), 15.15, getDate("2019-10-29"), "Hello world"},
+		},
+		{
+			table: ALL_TYPES_TABLE,
+			cols:  []string{"bool_col", "int_col", "numeric_col", "float_col"},
+			vals:  []interface{}{false, int64(-25), *big.NewRat(12, 100), -1.0},
+		},
+		{table: SINGERS_TABLE, cols: []string{"SingerId", "FirstName", "LastName"}, vals: []interface{}{int64(1), "Ann", "Lee"}},
+		{table: SINGERS_TABLE, cols: []string{"SingerId", "FirstName", "LastName"}, vals: []interface{}{int64(2), "Bob", "Smith***"}},
+	}
+	// Blank fields are null whatever the null string.
+	for _, nullStr := range []string{"", "NULL"} {
+		conv := buildConv(getCreateTable())
+		var rows []spannerData
+		conv.SetDataMode()
+		conv.SetDataSink(
+			func(table string, cols []string, vals []interface{}) {
+				rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+			})
+		fw := FixedWidthImpl{}
+		err := fw.ProcessFixedWidth(conv, getFixedWidthTables(dir), nullStr)
+		assert.Nil(t, err)
+		assert.Equal(t, want, rows, nullStr)
+	}
+}
+
+func TestGetFixedWidthFiles(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "manifest.json")
+	fw := FixedWidthImpl{}
+	sourceProfile := profiles.SourceProfile{Csv: profiles.SourceProfileCsv{Manifest: manifest, Format: "fixed-width"}}
+
+	assert.Nil(t, os.WriteFile(manifest, []byte(`[{"table_name": "singers", "file_patterns": ["singers.txt"], "encoding": "ibm037", "record_length": 20,
+		"columns": [{"column_name": "SingerId", "offset": 0, "width": 4, "type": "packed"}, {"column_name": "LastName", "offset": 4, "width": 16}]}]`), 0644))
+	conv := addSrcTableToConv(buildConv(getCreateSingersTable()))
+	tables, err := fw.GetFixedWidthFiles(conv, sourceProfile)
+	assert.Nil(t, err)
+	assert.Equal(t, []FixedWidthTable{{
+		ManifestTable: utils.ManifestTable{Table_name: SINGERS_TABLE, File_patterns: []string{"singers.txt"}},
+		Encoding:      "ibm037",
+		Record_length: 20,
+		Columns: []FixedWidthColumn{
+			{Column_name: "SingerId", Offset: 0, Width: 4, Type: fieldPacked},
+			{Column_name: "LastName", Offset: 4, Width: 16},
+		},
+	}}, tables)
+
+	for name, content := range map[string]string{
+		"unknown column":   `[{"table_name": "singers", "file_patterns": ["singers.txt"], "columns": [{"column_name": "Age", "offset": 0, "width": 2}]}]`,
+		"no columns":       `[{"table_name": "singers", "file_patterns": ["singers.txt"]}]`,
+		"invalid type":     `[{"table_name": "singers", "file_patterns": ["singers.txt"], "columns": [{"column_name": "SingerId", "offset": 0, "width": 4, "type": "float"}]}]`,
+		"invalid encoding": `[{"table_name": "singers", "file_patterns": ["singers.txt"], "encoding": "ebcdic", "columns": [{"column_name": "SingerId", "offset": 0, "width": 4}]}]`,
+		"past record end":  `[{"table_name": "singers", "file_patterns": ["singers.txt"], "record_length": 4, "columns": [{"column_name": "SingerId", "offset": 2, "width": 4}]}]`,
+		"scaled string":    `[{"table_name": "singers", "file_patterns": ["singers.txt"], "columns": [{"column_name": "LastName", "offset": 0, "width": 4, "scale": 1}]}]`,
+		"wide binary":      `[{"table_name": "singers", "file_patterns": ["singers.txt"], "columns": [{"column_name": "SingerId", "offset": 0, "width": 9, "type": "binary"}]}]`,
+		"duplicate column": `[{"table_name": "singers", "file_patterns": ["singers.txt"], "columns": [{"column_name": "SingerId", "offset": 0, "width": 4}, {"column_name": "SingerId", "offset": 4, "width": 4}]}]`,
+		"unknown table":    `[{"table_name": "albums", "file_patterns": ["albums.txt"], "columns": [{"column_name": "AlbumId", "offset": 0, "width": 4}]}]`,
+	} {
+		assert.Nil(t, os.WriteFile(manifest, []byte(content), 0644))
+		_, err := fw.GetFixedWidthFiles(conv, sourceProfile)
+		assert.NotNil(t, err, name)
+	}
+	_, err = fw.GetFixedWidthFiles(conv, profiles.SourceProfile{Csv: profiles.SourceProfileCsv{Format: "fixed-width"}})
+	assert.NotNil(t, err)
+}
+
+func TestFieldValue(t *testing.T) {
+	utf8 := fieldDecoder("")
+	testCases := []struct {
+		name   string
+		col    FixedWidthColumn
+		record []byte
+		want   string
+	}{
+		{name: "string", col: FixedWidthColumn{Offset: 2, Width: 5}, record: []byte("xx ab  yy"), want: " ab"},
+		{name: "right aligned string", col: FixedWidthColumn{Width: 5, Align: "right"}, record: []byte("  abc"), want: "abc"},
+		{name: "zero padded", col: FixedWidthColumn{Width: 4, Padding: "0", Align: "right"}, record: []byte("0042"), want: "42"},
+		{name: "zeros", col: FixedWidthColumn{Width: 4, Padding: "0", Align: "right"}, record: []byte("0000"), want: "0"},
+		{name: "blank", col: FixedWidthColumn{Width: 4, Type: fieldZoned}, record: []byte("    "), want: ""},
+		{name: "truncated record", col: FixedWidthColumn{Offset: 2, Width: 6}, record: []byte("xxab"), want: "ab"},
+		{name: "past end of record", col: FixedWidthColumn{Offset: 6, Width: 2}, record: []byte("xxab"), want: ""},
+		{name: "zoned", col: FixedWidthColumn{Width: 5, Type: fieldZoned}, record: []byte("00123"), want: "00123"},
+		{name: "zoned overpunch", col: FixedWidthColumn{Width: 5, Type: fieldZoned, Scale: 2}, record: []byte("0012L"), want: "-001.23"},
+		{name: "zoned positive overpunch", col: FixedWidthColumn{Width: 3, Type: fieldZoned}, record: []byte("12{"), want: "120"},
+		{name: "zoned trailing sign", col: FixedWidthColumn{Width: 4, Type: fieldZoned, Scale: 3}, record: []byte("12- "), want: "-0.012"},
+		{name: "zoned leading sign", col: FixedWidthColumn{Width: 4, Type: fieldZoned}, record: []byte(" +12"), want: "12"},
+		{name: "packed", col: FixedWidthColumn{Width: 3, Type: fieldPacked, Scale: 2}, record: []byte{0x12, 0x34, 0x5c}, want: "123.45"},
+		{name: "packed negative", col: FixedWidthColumn{Width: 2, Type: fieldPacked}, record: []byte{0x00, 0x7d}, want: "-007"},
+		{name: "packed unsigned", col: FixedWidthColumn{Width: 1, Type: fieldPacked}, record: []byte{0x9f}, want: "9"},
+		{name: "binary", col: FixedWidthColumn{Width: 2, Type: fieldBinary}, record: []byte{0x01, 0x00}, want: "256"},
+		{name: "binary negative", col: FixedWidthColumn{Width: 2, Type: fieldBinary, Scale: 1}, record: []byte{0xff, 0xfe}, want: "-0.2"},
+		{name: "binary spaces", col: FixedWidthColumn{Width: 2, Type: fieldBinary}, record: []byte("  "), want: "8224"},
+		{name: "binary min", col: FixedWidthColumn{Width: 8, Type: fieldBinary}, record: []byte{0x80, 0, 0, 0, 0, 0, 0, 0}, want: "-9223372036854775808"},
+	}
+	for _, tc := range testCases {
+		got, err := fieldValue(utf8, tc.col, tc.record)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+
+	errorCases := []struct {
+		name   string
+		col    FixedWidthColumn
+		record []byte
+	}{
+		{name: "zoned letters", col: FixedWidthColumn{Width: 3, Type: fieldZoned}, record: []byte("1x3")},
+		{name: "zoned sign only", col: FixedWidthColumn{Width: 1, Type: fieldZoned}, record: []byte("-")},
+		{name: "packed digit", col: FixedWidthColumn{Width: 2, Type: fieldPacked}, record: []byte{0x1a, 0x2c}},
+		{name: "packed sign", col: FixedWidthColumn{Width: 2, Type: fieldPacked}, record: []byte{0x12, 0x34}},
+	}
+	for _, tc := range errorCases {
+		_, err := fieldValue(utf8, tc.col, tc.record)
+		assert.NotNil(t, err, tc.name)
+	}
+
+	// Text is decoded, but packed decimals aren't.
+	ebcdicDecoder := fieldDecoder("ibm037")
+	got, err := fieldValue(ebcdicDecoder, FixedWidthColumn{Width: 3}, ebcdic(t, "abc"))
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", got)
+	got, err = fieldValue(ebcdicDecoder, FixedWidthColumn{Width: 2, Type: fieldPacked}, []byte{0x01, 0x2c})
+	assert.Nil(t, err)
+	assert.Equal(t, "012", got)
+}
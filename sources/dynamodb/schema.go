@@ -85,15 +85,36 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 }
 
 func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
-	stats, count, err := scanSampleData(isi.DynamoClient, isi.SampleSize, table.Name)
+	detector := newEntityDetector(primaryKeys)
+	stats, count, err := scanSampleData(isi.DynamoClient, isi.SampleSize, table.Name, detector)
 	if err != nil {
 		return nil, nil, err
 	}
-	return inferDataTypes(stats, count, primaryKeys)
+	colDefs, colIds, err := inferDataTypes(stats, count, primaryKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Items of several entity types in a table hint at a single-table
+	// design, which can be split into a table per entity type.
+	if design := detector.detect(colDefs, colIds, table.Name); design != nil {
+		design.TableId = table.Id
+		conv.ConvLock.Lock()
+		internal.SetSingleTableDesign(conv, *design)
+		conv.ConvLock.Unlock()
+	}
+	return colDefs, colIds, nil
 }
 
+// GetRowsFromTable scans the items of a table. The items of a table split
+// from a single-table design are scanned from the source table, keeping the
+// items of its entity type.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
 	srcTableName := conv.SrcSchema[srcTable].Name
+	filter := conv.SrcSchema[srcTable].ItemFilter
+	if filter != nil {
+		srcTableName = filter.SourceTable
+	}
+	var items []map[string]*dynamodb.AttributeValue
 	var lastEvaluatedKey map[string]*dynamodb.AttributeValue
 	for {
 		// Build the query input parameters.
@@ -110,8 +131,13 @@ func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string)
 			return nil, fmt.Errorf("failed to make Query API call for table %v: %v", srcTableName, err)
 		}
 
+		for _, item := range result.Items {
+			if filter == nil || matchesItemFilter(item, filter) {
+				items = append(items, item)
+			}
+		}
 		if result.LastEvaluatedKey == nil {
-			return result.Items, nil
+			return items, nil
 		}
 		// If there are more rows, then continue.
 		lastEvaluatedKey = result.LastEvaluatedKey
@@ -166,12 +192,22 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 
 	// Convert secondary indexes from GlobalSecondaryIndexes.
 	for _, i := range result.Table.GlobalSecondaryIndexes {
-		indexes = append(indexes, getSchemaIndexStruct(*i.IndexName, i.KeySchema, colNameIdMap))
+		index, err := getSchemaIndexStruct(*i.IndexName, i.KeySchema, i.Projection, result.Table.KeySchema, colNameIdMap)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Skipping index %s of table %s: %s", *i.IndexName, table.Name, err))
+			continue
+		}
+		indexes = append(indexes, index)
 	}
 
 	// Convert secondary indexes from LocalSecondaryIndexes.
 	for _, i := range result.Table.LocalSecondaryIndexes {
-		indexes = append(indexes, getSchemaIndexStruct(*i.IndexName, i.KeySchema, colNameIdMap))
+		index, err := getSchemaIndexStruct(*i.IndexName, i.KeySchema, i.Projection, result.Table.KeySchema, colNameIdMap)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Skipping index %s of table %s: %s", *i.IndexName, table.Name, err))
+			continue
+		}
+		indexes = append(indexes, index)
 	}
 	return indexes, nil
 }
@@ -189,6 +225,11 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 	}
 	// Iterate the items returned.
 	for _, attrsMap := range rows.([]map[string]*dynamodb.AttributeValue) {
+		// Row stats are kept for source tables, so the items of a table
+		// split from a single-table design are counted as they are read.
+		if srcSchema.ItemFilter != nil {
+			conv.StatsAddRow(srcSchema.Name, conv.DataMode())
+		}
 		ProcessDataRow(attrsMap, conv, tableId, srcSchema, colIds, spSchema)
 	}
 	return nil
@@ -202,6 +243,11 @@ func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *inte
 	latestStreamArn := make(map[string]interface{})
 	tableIds := ddl.GetSortedTableIdsBySpName(conv.SpSchema)
 
+	for _, tableId := range tableIds {
+		if conv.SrcSchema[tableId].ItemFilter != nil {
+			return nil, fmt.Errorf("streaming migration of table %s, split from single-table design %s, is not supported", conv.SrcSchema[tableId].Name, conv.SrcSchema[tableId].ItemFilter.SourceTable)
+		}
+	}
 	for _, tableId := range tableIds {
 		srcTable := conv.SrcSchema[tableId].Name
 		streamArn, err := NewDynamoDBStream(isi.DynamoClient, srcTable)
@@ -246,17 +292,61 @@ func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migration
 	return internal.DataflowOutput{}, nil
 }
 
-func getSchemaIndexStruct(indexName string, keySchema []*dynamodb.KeySchemaElement, colNameIdMap map[string]string) schema.Index {
+// getSchemaIndexStruct converts a secondary index into a schema.Index. The
+// partition key of the index comes before its sort key, and the attributes
+// projected into the index become its stored columns. DynamoDB indexes are
+// sparse: items without the key attributes of an index are absent from it,
+// so the index is null filtered.
+func getSchemaIndexStruct(indexName string, keySchema []*dynamodb.KeySchemaElement, projection *dynamodb.Projection, tableKeySchema []*dynamodb.KeySchemaElement, colNameIdMap map[string]string) (schema.Index, error) {
+	keySchema = append([]*dynamodb.KeySchemaElement{}, keySchema...)
+	sort.SliceStable(keySchema, func(i, j int) bool {
+		return aws.StringValue(keySchema[i].KeyType) == dynamodb.KeyTypeHash && aws.StringValue(keySchema[j].KeyType) != dynamodb.KeyTypeHash
+	})
+	keyNames := make(map[string]bool)
 	var keys []schema.Key
-	for _, j := range keySchema {
-		keys = append(keys, schema.Key{ColId: colNameIdMap[*j.AttributeName]})
+	for i, j := range keySchema {
+		colId, ok := colNameIdMap[*j.AttributeName]
+		if !ok {
+			return schema.Index{}, fmt.Errorf("key attribute %s wasn't found in the sampled items", *j.AttributeName)
+		}
+		keyNames[*j.AttributeName] = true
+		keys = append(keys, schema.Key{ColId: colId, Order: i + 1})
+	}
+	// Spanner indexes hold the primary key of the table, so table key
+	// attributes needn't be stored.
+	for _, j := range tableKeySchema {
+		keyNames[*j.AttributeName] = true
+	}
+
+	var storedNames []string
+	if projection != nil {
+		switch aws.StringValue(projection.ProjectionType) {
+		case dynamodb.ProjectionTypeInclude:
+			storedNames = aws.StringValueSlice(projection.NonKeyAttributes)
+		case dynamodb.ProjectionTypeAll:
+			for name := range colNameIdMap {
+				storedNames = append(storedNames, name)
+			}
+			sort.Strings(storedNames)
+		}
+	}
+	var storedColumnIds []string
+	for _, name := range storedNames {
+		// Projected attributes absent from the sampled items have no column.
+		if colId, ok := colNameIdMap[name]; ok && !keyNames[name] {
+			storedColumnIds = append(storedColumnIds, colId)
+		}
 	}
 	return schema.Index{
-		Id:   internal.GenerateIndexesId(),
-		Name: indexName, Keys: keys}
+		Id:              internal.GenerateIndexesId(),
+		Name:            indexName,
+		Keys:            keys,
+		StoredColumnIds: storedColumnIds,
+		NullFiltered:    true,
+	}, nil
 }
 
-func scanSampleData(client dynamodbiface.DynamoDBAPI, sampleSize int64, table string) (map[string]map[string]int64, int64, error) {
+func scanSampleData(client dynamodbiface.DynamoDBAPI, sampleSize int64, table string, detector *entityDetector) (map[string]map[string]int64, int64, error) {
 	// A map from column name to a count map of possible data types.
 	stats := make(map[string]map[string]int64)
 	var count int64
@@ -280,6 +370,9 @@ func scanSampleData(client dynamodbiface.DynamoDBAPI, sampleSize int64, table st
 				}
 				incTypeCount(attrName, attr, stats[attrName])
 			}
+			if detector != nil {
+				detector.add(attrsMap)
+			}
 
 			count++
 			if count >= sampleSize {
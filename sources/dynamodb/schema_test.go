@@ -530,7 +530,7 @@ func TestScanSampleData(t *testing.T) {
 		scanOutputs: scanOutputs,
 	}
 
-	stats, _, err := scanSampleData(client, 3, "test")
+	stats, _, err := scanSampleData(client, 3, "test", nil)
 	assert.Nil(t, err)
 
 	expectedStats := map[string]map[string]int64{
@@ -559,9 +559,13 @@ func TestInfoSchemaImpl_GetIndexes(t *testing.T) {
 	attrNameB := "b"
 	attrNameC := "c"
 	attrNameD := "d"
+	attrNameE := "e"
+	attrNameF := "f"
 	hashKeyType := "HASH"
 	sortKeyType := "RANGE"
 	globalIndexName := "secondary_index_c"
+	globalIndexNameE := "secondary_index_e"
+	globalIndexNameF := "secondary_index_f"
 	localIndexName := "secondary_index_d"
 	describeTableOutputs := []dynamodb.DescribeTableOutput{
 		{
@@ -577,14 +581,35 @@ func TestInfoSchemaImpl_GetIndexes(t *testing.T) {
 						KeySchema: []*dynamodb.KeySchemaElement{
 							{AttributeName: &attrNameC, KeyType: &hashKeyType},
 						},
+						Projection: &dynamodb.Projection{ProjectionType: aws.String(dynamodb.ProjectionTypeKeysOnly)},
+					},
+					{
+						IndexName: &globalIndexNameE,
+						KeySchema: []*dynamodb.KeySchemaElement{
+							{AttributeName: &attrNameD, KeyType: &sortKeyType},
+							{AttributeName: &attrNameE, KeyType: &hashKeyType},
+						},
+						Projection: &dynamodb.Projection{
+							ProjectionType:   aws.String(dynamodb.ProjectionTypeInclude),
+							NonKeyAttributes: aws.StringSlice([]string{"c", "g"}),
+						},
+					},
+					{
+						// Attribute f isn't in the sampled items.
+						IndexName: &globalIndexNameF,
+						KeySchema: []*dynamodb.KeySchemaElement{
+							{AttributeName: &attrNameF, KeyType: &hashKeyType},
+						},
 					},
 				},
 				LocalSecondaryIndexes: []*dynamodb.LocalSecondaryIndexDescription{
 					{
 						IndexName: &localIndexName,
 						KeySchema: []*dynamodb.KeySchemaElement{
-							{AttributeName: &attrNameD, KeyType: &hashKeyType},
+							{AttributeName: &attrNameA, KeyType: &hashKeyType},
+							{AttributeName: &attrNameD, KeyType: &sortKeyType},
 						},
+						Projection: &dynamodb.Projection{ProjectionType: aws.String(dynamodb.ProjectionTypeAll)},
 					},
 				},
 			},
@@ -598,19 +623,21 @@ func TestInfoSchemaImpl_GetIndexes(t *testing.T) {
 	dySchema := common.SchemaAndName{Name: "test"}
 	conv := internal.MakeConv()
 	isi := InfoSchemaImpl{client, nil, 10}
-	colNameToId := map[string]string{attrNameC: "c1", attrNameD: "c2"}
+	colNameToId := map[string]string{attrNameA: "c0", attrNameB: "c3", attrNameC: "c1", attrNameD: "c2", attrNameE: "c4"}
 	indexes, err := isi.GetIndexes(conv, dySchema, colNameToId)
 	assert.Nil(t, err)
 
 	secIndexes := []schema.Index{
-		{Name: "secondary_index_c", Keys: []schema.Key{{ColId: "c1"}}},
-		{Name: "secondary_index_d", Keys: []schema.Key{{ColId: "c2"}}},
+		{Name: "secondary_index_c", Keys: []schema.Key{{ColId: "c1", Order: 1}}, NullFiltered: true},
+		{Name: "secondary_index_e", Keys: []schema.Key{{ColId: "c4", Order: 1}, {ColId: "c2", Order: 2}}, StoredColumnIds: []string{"c1"}, NullFiltered: true},
+		{Name: "secondary_index_d", Keys: []schema.Key{{ColId: "c0", Order: 1}, {ColId: "c2", Order: 2}}, StoredColumnIds: []string{"c1", "c4"}, NullFiltered: true},
 	}
 	for i := range indexes {
 		indexes[i].Id = ""
 	}
 
 	assert.Equal(t, secIndexes, indexes)
+	assert.Equal(t, int64(1), conv.Unexpecteds())
 }
 
 func TestInfoSchemaImpl_GetConstraints(t *testing.T) {
@@ -0,0 +1,440 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// maxEntityTypes bounds the number of entity types of a single-table design:
+// an attribute with more distinct values is data, not an entity type.
+const maxEntityTypes = 20
+
+// entityTypeAttributes are the attribute names, in lower case, commonly used
+// for the entity type of the items of a single-table design.
+var entityTypeAttributes = []string{
+	"type", "entitytype", "entity_type", "_type", "__typename", "_et", "kind",
+	"recordtype", "record_type", "itemtype", "item_type", "entity",
+}
+
+// sampledItem keeps the parts of a sampled item needed to detect a
+// single-table design.
+type sampledItem struct {
+	pk, sk string
+	attrs  []string
+	types  map[string]string // Values of the entity type attributes of the item.
+}
+
+// entityDetector collects the items sampled from a table, and detects
+// whether they hold several entity types.
+type entityDetector struct {
+	keys  []string // Partition key, followed by the sort key if any.
+	items []sampledItem
+}
+
+func newEntityDetector(keys []string) *entityDetector {
+	return &entityDetector{keys: keys}
+}
+
+func (d *entityDetector) add(attrsMap map[string]*dynamodb.AttributeValue) {
+	item := sampledItem{pk: keyValue(attrsMap, d.keys, 0), sk: keyValue(attrsMap, d.keys, 1)}
+	for name, attr := range attrsMap {
+		item.attrs = append(item.attrs, name)
+		if attr.S != nil && isEntityTypeAttribute(name) {
+			if item.types == nil {
+				item.types = make(map[string]string)
+			}
+			item.types[name] = *attr.S
+		}
+	}
+	d.items = append(d.items, item)
+}
+
+// detect returns the single-table design of the sampled items, or nil if
+// they don't hold several entity types. The candidates for the entity type
+// are the entity type attributes found in the items, then the prefixes of
+// the sort key and of the partition key.
+func (d *entityDetector) detect(colDefs map[string]schema.Column, colIds []string, tableName string) *internal.SingleTableDesign {
+	if len(d.items) == 0 {
+		return nil
+	}
+	var attributes []string
+	seen := make(map[string]bool)
+	for _, item := range d.items {
+		for name := range item.types {
+			if !seen[name] {
+				seen[name] = true
+				attributes = append(attributes, name)
+			}
+		}
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return entityTypeRank(attributes[i]) < entityTypeRank(attributes[j]) ||
+			(entityTypeRank(attributes[i]) == entityTypeRank(attributes[j]) && attributes[i] < attributes[j])
+	})
+	for _, attribute := range attributes {
+		if design := d.detectWith(attribute, false, colDefs, colIds, tableName); design != nil {
+			return design
+		}
+	}
+	for i := len(d.keys) - 1; i >= 0; i-- {
+		if design := d.detectWith(d.keys[i], true, colDefs, colIds, tableName); design != nil {
+			return design
+		}
+	}
+	return nil
+}
+
+func (d *entityDetector) detectWith(attribute string, keyPrefix bool, colDefs map[string]schema.Column, colIds []string, tableName string) *internal.SingleTableDesign {
+	entityItems := make(map[string][]sampledItem)
+	var untyped int
+	for _, item := range d.items {
+		entity := d.entityOf(item, attribute, keyPrefix)
+		if entity == "" {
+			untyped++
+			continue
+		}
+		entityItems[entity] = append(entityItems[entity], item)
+	}
+	if float64(untyped)/float64(len(d.items)) > errThreshold || len(entityItems) < 2 || len(entityItems) > maxEntityTypes {
+		return nil
+	}
+
+	design := &internal.SingleTableDesign{Attribute: attribute, KeyPrefix: keyPrefix}
+	for name, items := range entityItems {
+		present := make(map[string]bool)
+		for _, key := range d.keys {
+			present[key] = true
+		}
+		for _, item := range items {
+			for _, attr := range item.attrs {
+				present[attr] = true
+			}
+		}
+		if !keyPrefix {
+			// The entity type is implied by the table of the items.
+			delete(present, attribute)
+		}
+		entity := internal.Entity{Name: name, TableName: tableName + "_" + name, Items: int64(len(items))}
+		for _, id := range colIds {
+			if present[colDefs[id].Name] {
+				entity.ColIds = append(entity.ColIds, id)
+			}
+		}
+		design.Entities = append(design.Entities, entity)
+	}
+	sort.Slice(design.Entities, func(i, j int) bool { return design.Entities[i].Name < design.Entities[j].Name })
+	if len(d.keys) == 2 {
+		d.detectParents(design.Entities, entityItems)
+	}
+	return design
+}
+
+// detectParents proposes to interleave the table of an entity type in the
+// table of another, root, entity type when the items of both share partition
+// keys. The partition keys of the items of a root entity type start with
+// its name, e.g. USER#alice, and each has a single item of the type.
+func (d *entityDetector) detectParents(entities []internal.Entity, entityItems map[string][]sampledItem) {
+	roots := make(map[string]map[string]bool)
+	for _, entity := range entities {
+		pks := make(map[string]bool)
+		root := true
+		for _, item := range entityItems[entity.Name] {
+			if pks[item.pk] || !strings.EqualFold(entityPrefix(item.pk), entity.Name) {
+				root = false
+				break
+			}
+			pks[item.pk] = true
+		}
+		if root {
+			roots[entity.Name] = pks
+		}
+	}
+	for i, entity := range entities {
+		if _, ok := roots[entity.Name]; ok {
+			continue
+		}
+		for _, parent := range entities {
+			pks, ok := roots[parent.Name]
+			if !ok {
+				continue
+			}
+			child := true
+			for _, item := range entityItems[entity.Name] {
+				if !pks[item.pk] {
+					child = false
+					break
+				}
+			}
+			if child {
+				entities[i].Parent = parent.Name
+				break
+			}
+		}
+	}
+}
+
+func (d *entityDetector) entityOf(item sampledItem, attribute string, keyPrefix bool) string {
+	if !keyPrefix {
+		return item.types[attribute]
+	}
+	if attribute == d.keys[0] {
+		return entityPrefix(item.pk)
+	}
+	return entityPrefix(item.sk)
+}
+
+// entityPrefix returns the entity type at the start of a key value, e.g.
+// ORDER for ORDER#1234 or #ORDER#1234, or "" if the value has none. A value
+// without '#' is an entity type only if it is in upper case, e.g. METADATA,
+// as other values are usually ids.
+func entityPrefix(v string) string {
+	v = strings.TrimPrefix(v, "#")
+	i := strings.Index(v, "#")
+	if i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return ""
+	}
+	for _, r := range v {
+		if i < 0 && !unicode.IsUpper(r) && r != '_' {
+			return ""
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return ""
+		}
+	}
+	return v
+}
+
+func isEntityTypeAttribute(name string) bool {
+	return entityTypeRank(name) < len(entityTypeAttributes)
+}
+
+func entityTypeRank(name string) int {
+	for i, a := range entityTypeAttributes {
+		if strings.EqualFold(a, name) {
+			return i
+		}
+	}
+	return len(entityTypeAttributes)
+}
+
+// keyValue returns the value of the i'th key attribute of an item as a
+// string, or "" if the table has no such key.
+func keyValue(attrsMap map[string]*dynamodb.AttributeValue, keys []string, i int) string {
+	if i >= len(keys) {
+		return ""
+	}
+	attr, ok := attrsMap[keys[i]]
+	if !ok {
+		return ""
+	}
+	switch {
+	case attr.S != nil:
+		return *attr.S
+	case attr.N != nil:
+		return *attr.N
+	case len(attr.B) != 0:
+		return string(attr.B)
+	}
+	return ""
+}
+
+// matchesItemFilter reports whether an item is of the entity type of filter.
+func matchesItemFilter(attrsMap map[string]*dynamodb.AttributeValue, filter *schema.ItemFilter) bool {
+	attr, ok := attrsMap[filter.Attribute]
+	if !ok {
+		return false
+	}
+	v := aws.StringValue(attr.S)
+	if filter.KeyPrefix {
+		v = entityPrefix(v)
+	}
+	return v != "" && v == filter.Entity
+}
+
+// SplitSingleTable replaces the source table of a single-table design by a
+// table per entity type, holding the items of that type. The table of an
+// entity type with a parent is interleaved in the table of its parent, and
+// keyed by both the partition and sort keys of the source table; the parent
+// table is keyed by the partition key only.
+func SplitSingleTable(conv *internal.Conv, design internal.SingleTableDesign, ddlVerifier expressions_api.DDLVerifier) error {
+	srcTable, ok := conv.SrcSchema[design.TableId]
+	if !ok {
+		return fmt.Errorf("table id %s not found in the source schema", design.TableId)
+	}
+	if srcTable.ItemFilter != nil {
+		return fmt.Errorf("table %s has already been split", srcTable.Name)
+	}
+	if len(design.Entities) == 0 {
+		return fmt.Errorf("no entity types to split table %s into", srcTable.Name)
+	}
+	entities := make(map[string]internal.Entity)
+	tableNames := make(map[string]bool)
+	for _, e := range design.Entities {
+		if e.TableName == "" {
+			return fmt.Errorf("table name of entity type %s is empty", e.Name)
+		}
+		if tableNames[strings.ToLower(e.TableName)] {
+			return fmt.Errorf("table name %s is used for several entity types", e.TableName)
+		}
+		tableNames[strings.ToLower(e.TableName)] = true
+		for _, colId := range e.ColIds {
+			if _, ok := srcTable.ColDefs[colId]; !ok {
+				return fmt.Errorf("column id %s of entity type %s not found in table %s", colId, e.Name, srcTable.Name)
+			}
+		}
+		entities[e.Name] = e
+	}
+	parents := make(map[string]bool)
+	for _, e := range design.Entities {
+		if e.Parent == "" {
+			continue
+		}
+		parent, ok := entities[e.Parent]
+		if !ok {
+			return fmt.Errorf("parent %s of entity type %s not found", e.Parent, e.Name)
+		}
+		if parent.Parent != "" {
+			return fmt.Errorf("parent %s of entity type %s has a parent itself", e.Parent, e.Name)
+		}
+		if len(srcTable.PrimaryKeys) != 2 {
+			return fmt.Errorf("entity type %s can't have a parent: table %s has no sort key", e.Name, srcTable.Name)
+		}
+		parents[e.Parent] = true
+	}
+
+	// Drop the source table, so that its names can be reused.
+	if sp, ok := conv.SpSchema[design.TableId]; ok {
+		delete(conv.UsedNames, strings.ToLower(sp.Name))
+		for _, index := range sp.Indexes {
+			delete(conv.UsedNames, strings.ToLower(index.Name))
+		}
+	}
+	delete(conv.SrcSchema, design.TableId)
+	delete(conv.SpSchema, design.TableId)
+	delete(conv.SchemaIssues, design.TableId)
+	delete(conv.SyntheticPKeys, design.TableId)
+	delete(conv.UniquePKey, design.TableId)
+	delete(conv.SingleTableDesigns, design.TableId)
+
+	tableIds := make(map[string]string)
+	for _, e := range design.Entities {
+		t := splitEntityTable(srcTable, design, e, parents[e.Name])
+		conv.SrcSchema[t.Id] = t
+		tableIds[e.Name] = t.Id
+		if err := common.SrcTableToSpannerDDL(conv, ToDdlImpl{}, t, ddlVerifier); err != nil {
+			return fmt.Errorf("couldn't convert table %s: %v", t.Name, err)
+		}
+	}
+	for _, e := range design.Entities {
+		if e.Parent == "" {
+			continue
+		}
+		spTable := conv.SpSchema[tableIds[e.Name]]
+		spTable.ParentTable = ddl.InterleavedParent{Id: tableIds[e.Parent], OnDelete: constants.FK_CASCADE}
+		conv.SpSchema[tableIds[e.Name]] = spTable
+	}
+	return nil
+}
+
+// splitEntityTable builds the source table of an entity type from the
+// columns and indexes of the table of a single-table design. Indexes are kept
+// if the entity type has all their key columns.
+func splitEntityTable(srcTable schema.Table, design internal.SingleTableDesign, e internal.Entity, parent bool) schema.Table {
+	t := schema.Table{
+		Id:           internal.GenerateTableId(),
+		Name:         e.TableName,
+		Schema:       srcTable.Schema,
+		ColDefs:      make(map[string]schema.Column),
+		ColNameIdMap: make(map[string]string),
+		ItemFilter:   &schema.ItemFilter{SourceTable: srcTable.Name, Attribute: design.Attribute, KeyPrefix: design.KeyPrefix, Entity: e.Name},
+	}
+	colIds := make(map[string]string)
+	addCol := func(id string) {
+		if _, ok := colIds[id]; ok {
+			return
+		}
+		col := srcTable.ColDefs[id]
+		col.Id = internal.GenerateColumnId()
+		colIds[id] = col.Id
+		t.ColIds = append(t.ColIds, col.Id)
+		t.ColDefs[col.Id] = col
+		t.ColNameIdMap[col.Name] = col.Id
+	}
+	for _, k := range srcTable.PrimaryKeys {
+		addCol(k.ColId)
+	}
+	for _, id := range e.ColIds {
+		addCol(id)
+	}
+	pks := srcTable.PrimaryKeys
+	if parent {
+		// Each partition key has a single item of a parent entity type.
+		pks = pks[:1]
+	}
+	for i, k := range pks {
+		t.PrimaryKeys = append(t.PrimaryKeys, schema.Key{ColId: colIds[k.ColId], Desc: k.Desc, Order: i + 1})
+	}
+	for _, index := range srcTable.Indexes {
+		keys, ok := mapIndexKeys(index.Keys, colIds)
+		if !ok {
+			continue
+		}
+		var stored []string
+		for _, id := range index.StoredColumnIds {
+			if newId, ok := colIds[id]; ok {
+				stored = append(stored, newId)
+			}
+		}
+		t.Indexes = append(t.Indexes, schema.Index{
+			Id:              internal.GenerateIndexesId(),
+			Name:            index.Name + "_" + e.TableName,
+			Unique:          index.Unique,
+			Keys:            keys,
+			StoredColumnIds: stored,
+			NullFiltered:    index.NullFiltered,
+		})
+	}
+	return t
+}
+
+func mapIndexKeys(keys []schema.Key, colIds map[string]string) ([]schema.Key, bool) {
+	var mapped []schema.Key
+	for _, k := range keys {
+		id, ok := colIds[k.ColId]
+		if !ok {
+			return nil, false
+		}
+		k.ColId = id
+		mapped = append(mapped, k)
+	}
+	return mapped, true
+}
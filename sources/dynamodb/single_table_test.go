@@ -0,0 +1,263 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func item(attrs ...string) map[string]*dynamodb.AttributeValue {
+	m := make(map[string]*dynamodb.AttributeValue)
+	for i := 0; i < len(attrs); i += 2 {
+		m[attrs[i]] = &dynamodb.AttributeValue{S: aws.String(attrs[i+1])}
+	}
+	return m
+}
+
+func TestEntityPrefix(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"ORDER#1234", "ORDER"},
+		{"#ORDER#1234", "ORDER"},
+		{"USER#alice#2023", "USER"},
+		{"METADATA", "METADATA"},
+		{"alice", ""},
+		{"1234", ""},
+		{"#1234", ""},
+		{"ORDER ITEM#1", ""},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, entityPrefix(tc.value), tc.value)
+	}
+}
+
+func TestEntityDetector(t *testing.T) {
+	colDefs := map[string]schema.Column{
+		"c1": {Id: "c1", Name: "PK"},
+		"c2": {Id: "c2", Name: "SK"},
+		"c3": {Id: "c3", Name: "email"},
+		"c4": {Id: "c4", Name: "total"},
+		"c5": {Id: "c5", Name: "type"},
+	}
+	colIds := []string{"c1", "c2", "c3", "c4", "c5"}
+	tests := []struct {
+		name     string
+		keys     []string
+		items    []map[string]*dynamodb.AttributeValue
+		expected *internal.SingleTableDesign
+	}{
+		{
+			name: "Sort key prefix with parent",
+			keys: []string{"PK", "SK"},
+			items: []map[string]*dynamodb.AttributeValue{
+				item("PK", "USER#alice", "SK", "USER#alice", "email", "alice@example.com"),
+				item("PK", "USER#alice", "SK", "ORDER#1", "total", "10"),
+				item("PK", "USER#alice", "SK", "ORDER#2", "total", "20"),
+				item("PK", "USER#bob", "SK", "USER#bob", "email", "bob@example.com"),
+			},
+			expected: &internal.SingleTableDesign{
+				Attribute: "SK",
+				KeyPrefix: true,
+				Entities: []internal.Entity{
+					{Name: "ORDER", TableName: "app_ORDER", Items: 2, ColIds: []string{"c1", "c2", "c4"}, Parent: "USER"},
+					{Name: "USER", TableName: "app_USER", Items: 2, ColIds: []string{"c1", "c2", "c3"}},
+				},
+			},
+		},
+		{
+			name: "Entity type attribute",
+			keys: []string{"PK", "SK"},
+			items: []map[string]*dynamodb.AttributeValue{
+				item("PK", "alice", "SK", "alice", "type", "customer", "email", "alice@example.com"),
+				item("PK", "alice", "SK", "1", "type", "order", "total", "10"),
+			},
+			expected: &internal.SingleTableDesign{
+				Attribute: "type",
+				Entities: []internal.Entity{
+					{Name: "customer", TableName: "app_customer", Items: 1, ColIds: []string{"c1", "c2", "c3"}},
+					{Name: "order", TableName: "app_order", Items: 1, ColIds: []string{"c1", "c2", "c4"}},
+				},
+			},
+		},
+		{
+			name: "Single entity type",
+			keys: []string{"PK"},
+			items: []map[string]*dynamodb.AttributeValue{
+				item("PK", "alice", "email", "alice@example.com"),
+				item("PK", "bob", "email", "bob@example.com"),
+			},
+		},
+		{
+			name: "Items without entity type",
+			keys: []string{"PK", "SK"},
+			items: []map[string]*dynamodb.AttributeValue{
+				item("PK", "USER#alice", "SK", "USER#alice"),
+				item("PK", "USER#alice", "SK", "ORDER#1"),
+				item("PK", "USER#alice", "SK", "1"),
+			},
+		},
+	}
+	for _, tc := range tests {
+		d := newEntityDetector(tc.keys)
+		for _, i := range tc.items {
+			d.add(i)
+		}
+		assert.Equal(t, tc.expected, d.detect(colDefs, colIds, "app"), tc.name)
+	}
+}
+
+func TestSplitSingleTable(t *testing.T) {
+	conv := internal.MakeConv()
+	srcTable := schema.Table{
+		Id:     "t1",
+		Name:   "app",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "PK", Type: schema.Type{Name: typeString}, NotNull: true},
+			"c2": {Id: "c2", Name: "SK", Type: schema.Type{Name: typeString}, NotNull: true},
+			"c3": {Id: "c3", Name: "email", Type: schema.Type{Name: typeString}},
+			"c4": {Id: "c4", Name: "total", Type: schema.Type{Name: typeNumber}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		Indexes: []schema.Index{
+			{Id: "i1", Name: "by_email", Keys: []schema.Key{{ColId: "c3", Order: 1}}, NullFiltered: true},
+		},
+	}
+	conv.SrcSchema["t1"] = srcTable
+	assert.Nil(t, common.SrcTableToSpannerDDL(conv, ToDdlImpl{}, srcTable, &expressions_api.MockDDLVerifier{}))
+	design := internal.SingleTableDesign{
+		TableId:   "t1",
+		Attribute: "SK",
+		KeyPrefix: true,
+		Entities: []internal.Entity{
+			{Name: "ORDER", TableName: "orders", ColIds: []string{"c1", "c2", "c4"}, Parent: "USER"},
+			{Name: "USER", TableName: "users", ColIds: []string{"c1", "c2", "c3"}},
+		},
+	}
+	internal.SetSingleTableDesign(conv, design)
+
+	assert.Nil(t, SplitSingleTable(conv, design, &expressions_api.MockDDLVerifier{}))
+	assert.NotContains(t, conv.SrcSchema, "t1")
+	assert.NotContains(t, conv.SpSchema, "t1")
+	assert.NotContains(t, conv.SingleTableDesigns, "t1")
+	assert.Equal(t, 2, len(conv.SpSchema))
+
+	orders, err := internal.GetTableIdFromSpName(conv.SpSchema, "orders")
+	assert.Nil(t, err)
+	users, err := internal.GetTableIdFromSpName(conv.SpSchema, "users")
+	assert.Nil(t, err)
+
+	ordersSrc := conv.SrcSchema[orders]
+	assert.Equal(t, &schema.ItemFilter{SourceTable: "app", Attribute: "SK", KeyPrefix: true, Entity: "ORDER"}, ordersSrc.ItemFilter)
+	assert.Equal(t, []string{"PK", "SK", "total"}, colNames(ordersSrc))
+	assert.Equal(t, 2, len(conv.SpSchema[orders].PrimaryKeys))
+	assert.Empty(t, conv.SpSchema[orders].Indexes)
+	assert.Equal(t, ddl.InterleavedParent{Id: users, OnDelete: constants.FK_CASCADE}, conv.SpSchema[orders].ParentTable)
+
+	usersSrc := conv.SrcSchema[users]
+	assert.Equal(t, []string{"PK", "SK", "email"}, colNames(usersSrc))
+	assert.Equal(t, []ddl.IndexKey{{ColId: usersSrc.ColNameIdMap["PK"], Order: 1}}, conv.SpSchema[users].PrimaryKeys)
+	assert.Equal(t, 1, len(conv.SpSchema[users].Indexes))
+	assert.Equal(t, "by_email_users", conv.SpSchema[users].Indexes[0].Name)
+	assert.Equal(t, ddl.InterleavedParent{}, conv.SpSchema[users].ParentTable)
+
+	// The table can't be split again.
+	assert.NotNil(t, SplitSingleTable(conv, design, &expressions_api.MockDDLVerifier{}))
+}
+
+func TestSplitSingleTable_Errors(t *testing.T) {
+	srcTable := schema.Table{
+		Id:     "t1",
+		Name:   "app",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "PK", Type: schema.Type{Name: typeString}, NotNull: true},
+			"c2": {Id: "c2", Name: "type", Type: schema.Type{Name: typeString}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1", Order: 1}},
+	}
+	tests := []struct {
+		name     string
+		entities []internal.Entity
+	}{
+		{name: "No entity types"},
+		{name: "Empty table name", entities: []internal.Entity{{Name: "a", ColIds: []string{"c1"}}}},
+		{name: "Duplicate table names", entities: []internal.Entity{{Name: "a", TableName: "t"}, {Name: "b", TableName: "T"}}},
+		{name: "Unknown column", entities: []internal.Entity{{Name: "a", TableName: "a", ColIds: []string{"c9"}}}},
+		{name: "Unknown parent", entities: []internal.Entity{{Name: "a", TableName: "a", Parent: "b"}}},
+		{name: "No sort key", entities: []internal.Entity{{Name: "a", TableName: "a"}, {Name: "b", TableName: "b", Parent: "a"}}},
+	}
+	for _, tc := range tests {
+		conv := internal.MakeConv()
+		conv.SrcSchema["t1"] = srcTable
+		design := internal.SingleTableDesign{TableId: "t1", Attribute: "type", Entities: tc.entities}
+		assert.NotNil(t, SplitSingleTable(conv, design, &expressions_api.MockDDLVerifier{}), tc.name)
+		assert.Contains(t, conv.SrcSchema, "t1", tc.name)
+	}
+}
+
+func TestGetRowsFromTable_ItemFilter(t *testing.T) {
+	client := &mockDynamoClient{
+		scanOutputs: []dynamodb.ScanOutput{
+			{
+				Items: []map[string]*dynamodb.AttributeValue{
+					item("PK", "USER#alice", "SK", "USER#alice"),
+					item("PK", "USER#alice", "SK", "ORDER#1"),
+				},
+				LastEvaluatedKey: item("PK", "USER#alice", "SK", "ORDER#1"),
+			},
+			{
+				Items: []map[string]*dynamodb.AttributeValue{
+					item("PK", "USER#bob", "SK", "ORDER#2"),
+				},
+			},
+		},
+	}
+	conv := internal.MakeConv()
+	conv.SrcSchema["t2"] = schema.Table{
+		Id:         "t2",
+		Name:       "orders",
+		ItemFilter: &schema.ItemFilter{SourceTable: "app", Attribute: "SK", KeyPrefix: true, Entity: "ORDER"},
+	}
+	isi := InfoSchemaImpl{client, nil, 10}
+	rows, err := isi.GetRowsFromTable(conv, "t2")
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]*dynamodb.AttributeValue{
+		item("PK", "USER#alice", "SK", "ORDER#1"),
+		item("PK", "USER#bob", "SK", "ORDER#2"),
+	}, rows)
+}
+
+func colNames(t schema.Table) []string {
+	var names []string
+	for _, id := range t.ColIds {
+		names = append(names, t.ColDefs[id].Name)
+	}
+	return names
+}
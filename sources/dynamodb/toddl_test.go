@@ -154,16 +154,16 @@ func TestToSpannerPostgreSQLDialectType(t *testing.T) {
 		ColIds: []string{"c1", "c2", "c3", "c4", "c5", "c6", "c7", "c8", "c9", "c10", "c11"},
 		ColDefs: map[string]ddl.ColumnDef{
 			"c1":  ddl.ColumnDef{Name: "a", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c1"},
-			"c10": ddl.ColumnDef{Name: "j", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c10"},
-			"c11": ddl.ColumnDef{Name: "k", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c11"},
+			"c10": ddl.ColumnDef{Name: "j", T: ddl.Type{Name: "NUMERIC", Len: 0, IsArray: true}, NotNull: false, Comment: "", Id: "c10"},
+			"c11": ddl.ColumnDef{Name: "k", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: true}, NotNull: false, Comment: "", Id: "c11"},
 			"c2":  ddl.ColumnDef{Name: "b", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c2"},
 			"c3":  ddl.ColumnDef{Name: "c", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c3"},
 			"c4":  ddl.ColumnDef{Name: "d", T: ddl.Type{Name: "BOOL", Len: 0, IsArray: false}, NotNull: false, Comment: "", Id: "c4"},
 			"c5":  ddl.ColumnDef{Name: "e", T: ddl.Type{Name: "BYTES", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c5"},
 			"c6":  ddl.ColumnDef{Name: "f", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c6"},
 			"c7":  ddl.ColumnDef{Name: "g", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c7"},
-			"c8":  ddl.ColumnDef{Name: "h", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c8"},
-			"c9":  ddl.ColumnDef{Name: "i", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: "", Id: "c9"}},
+			"c8":  ddl.ColumnDef{Name: "h", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: true}, NotNull: false, Comment: "", Id: "c8"},
+			"c9":  ddl.ColumnDef{Name: "i", T: ddl.Type{Name: "BYTES", Len: 9223372036854775807, IsArray: true}, NotNull: false, Comment: "", Id: "c9"}},
 		PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "c1", Desc: false, Order: 0}, ddl.IndexKey{ColId: "c2", Desc: false, Order: 0}},
 		ForeignKeys: []ddl.Foreignkey(nil),
 		Indexes: []ddl.CreateIndex{
@@ -0,0 +1,215 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package excel
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ProcessDataRow converts a row, keyed by column name, and writes it out
+// to Spanner.
+func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, row map[string]interface{}) {
+	spVals, badCols, srcStrVals := cvtRow(row, srcSchema, spSchema, colIds, conv)
+	srcTableName := srcSchema.Name
+	spColNames := []string{}
+	srcColNames := []string{}
+	for _, colId := range colIds {
+		srcColNames = append(srcColNames, srcSchema.ColDefs[colId].Name)
+		spColNames = append(spColNames, spSchema.ColDefs[colId].Name)
+	}
+	if len(badCols) > 0 {
+		conv.Unexpected(fmt.Sprintf("Data conversion error for table %s in column(s) %s\n", srcTableName, badCols))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcColNames, srcStrVals)
+		return
+	}
+	if aux, ok := conv.SyntheticPKeys[tableId]; ok {
+		spColNames = append(spColNames, conv.SpSchema[tableId].ColDefs[aux.ColId].Name)
+		spVals = append(spVals, fmt.Sprintf("%d", int64(bits.Reverse64(uint64(aux.Sequence)))))
+		aux.Sequence++
+		conv.SyntheticPKeys[tableId] = aux
+	}
+	conv.WriteRow(srcTableName, spSchema.Name, spColNames, spVals)
+}
+
+// cvtRow converts the values of the columns colIds of row to the types of
+// their Spanner columns. It returns the converted values, the names of the
+// columns whose values couldn't be converted and the values as strings.
+func cvtRow(row map[string]interface{}, srcSchema schema.Table, spSchema ddl.CreateTable, colIds []string, conv *internal.Conv) ([]interface{}, []string, []string) {
+	var srcStrVals []string
+	var spVals []interface{}
+	var badCols []string
+	for _, colId := range colIds {
+		srcColDef := srcSchema.ColDefs[colId]
+		val := row[srcColDef.Name]
+		if val == nil {
+			srcStrVals = append(srcStrVals, "NULL")
+			spVals = append(spVals, nil)
+			continue
+		}
+		spVal, err := convScalar(conv, val, spSchema.ColDefs[colId].T.Name)
+		if err != nil {
+			badCols = append(badCols, srcColDef.Name)
+		}
+		srcStrVals = append(srcStrVals, toString(val))
+		spVals = append(spVals, spVal)
+	}
+	return spVals, badCols, srcStrVals
+}
+
+// convScalar converts a value read by readRows to a value of Spanner type
+// spType. Strings are parsed, so that text cells can be migrated to
+// columns of other types, e.g. columns whose type is set by a manifest.
+func convScalar(conv *internal.Conv, val interface{}, spType string) (interface{}, error) {
+	if s, ok := val.(string); ok && spType != ddl.String && spType != ddl.Bytes {
+		return parseString(conv, strings.TrimSpace(s), spType)
+	}
+	switch spType {
+	case ddl.Bool:
+		switch v := val.(type) {
+		case bool:
+			return v, nil
+		case float64:
+			if v == 0 || v == 1 {
+				return v == 1, nil
+			}
+		}
+	case ddl.Bytes:
+		return []byte(toString(val)), nil
+	case ddl.Date:
+		switch v := val.(type) {
+		case civil.Date:
+			return v, nil
+		case civil.DateTime:
+			if v.Time == (civil.Time{}) {
+				return v.Date, nil
+			}
+		}
+	case ddl.Float32:
+		if f, ok := val.(float64); ok {
+			return float32(f), nil
+		}
+	case ddl.Float64:
+		if f, ok := val.(float64); ok {
+			return f, nil
+		}
+	case ddl.Int64:
+		switch v := val.(type) {
+		case float64:
+			if v == math.Trunc(v) && math.Abs(v) < 1<<63 {
+				return int64(v), nil
+			}
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+	case ddl.Numeric:
+		if f, ok := val.(float64); ok {
+			// Converted through their shortest decimal representation, so
+			// that e.g. 0.1 isn't stored as 0.1000000000000000055511151231257827.
+			return parseString(conv, strconv.FormatFloat(f, 'f', -1, 64), spType)
+		}
+	case ddl.Timestamp:
+		// Dates and times have no time zone: they are taken to be UTC.
+		switch v := val.(type) {
+		case civil.DateTime:
+			return v.In(time.UTC), nil
+		case civil.Date:
+			return v.In(time.UTC), nil
+		}
+	case ddl.String:
+		return toString(val), nil
+	case ddl.JSON:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return nil, fmt.Errorf("can't convert value %v of type %T to Spanner type %s", val, val, spType)
+}
+
+// parseString converts the text of a cell to a value of Spanner type
+// spType.
+func parseString(conv *internal.Conv, s, spType string) (interface{}, error) {
+	switch spType {
+	case ddl.Bool:
+		return strconv.ParseBool(s)
+	case ddl.Date:
+		return civil.ParseDate(s)
+	case ddl.Float32:
+		f, err := strconv.ParseFloat(s, 32)
+		return float32(f), err
+	case ddl.Float64:
+		return strconv.ParseFloat(s, 64)
+	case ddl.Int64:
+		return strconv.ParseInt(s, 10, 64)
+	case ddl.Numeric:
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return nil, fmt.Errorf("invalid numeric %q", s)
+		}
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			return spanner.PGNumeric{Numeric: s, Valid: true}, nil
+		}
+		return r, nil
+	case ddl.Timestamp:
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return t.UTC(), nil
+		}
+		dt, err := civil.ParseDateTime(strings.Replace(s, " ", "T", 1))
+		if err != nil {
+			return nil, err
+		}
+		return dt.In(time.UTC), nil
+	case ddl.JSON:
+		if !json.Valid([]byte(s)) {
+			return nil, fmt.Errorf("invalid JSON %q", s)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("can't convert string %q to Spanner type %s", s, spType)
+}
+
+// toString formats a value read by readRows as a string. Numbers aren't
+// formatted with exponents, as in cells.
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(val)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package excel
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestConvScalar(t *testing.T) {
+	day := civil.Date{Year: 2025, Month: 1, Day: 1}
+	testCases := []struct {
+		name    string
+		dialect string
+		val     interface{}
+		spType  string
+		want    interface{}
+	}{
+		{name: "bool", val: true, spType: ddl.Bool, want: true},
+		{name: "number to bool", val: 1.0, spType: ddl.Bool, want: true},
+		{name: "string to bool", val: "FALSE", spType: ddl.Bool, want: false},
+		{name: "number to int64", val: 42.0, spType: ddl.Int64, want: int64(42)},
+		{name: "string to int64", val: " 007 ", spType: ddl.Int64, want: int64(7)},
+		{name: "number", val: 2.5, spType: ddl.Float64, want: 2.5},
+		{name: "number to float32", val: 2.5, spType: ddl.Float32, want: float32(2.5)},
+		{name: "number to numeric", val: 0.1, spType: ddl.Numeric, want: big.NewRat(1, 10)},
+		{name: "number to numeric pg", dialect: constants.DIALECT_POSTGRESQL, val: 0.1, spType: ddl.Numeric, want: spanner.PGNumeric{Numeric: "0.1", Valid: true}},
+		{name: "string to numeric", val: "12.50", spType: ddl.Numeric, want: big.NewRat(25, 2)},
+		{name: "date", val: day, spType: ddl.Date, want: day},
+		{name: "midnight to date", val: civil.DateTime{Date: day}, spType: ddl.Date, want: day},
+		{name: "string to date", val: "2025-01-01", spType: ddl.Date, want: day},
+		{name: "datetime", val: civil.DateTime{Date: day, Time: civil.Time{Hour: 12}}, spType: ddl.Timestamp, want: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "date to timestamp", val: day, spType: ddl.Timestamp, want: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "string to timestamp", val: "2025-01-01 12:00:00", spType: ddl.Timestamp, want: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "rfc3339 to timestamp", val: "2025-01-01T12:00:00+01:00", spType: ddl.Timestamp, want: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{name: "large number to string", val: 1234567890123.0, spType: ddl.String, want: "1234567890123"},
+		{name: "time to string", val: civil.Time{Hour: 9, Minute: 30}, spType: ddl.String, want: "09:30:00"},
+		{name: "string to bytes", val: "abc", spType: ddl.Bytes, want: []byte("abc")},
+		{name: "string to json", val: `{"a": 1}`, spType: ddl.JSON, want: `{"a": 1}`},
+		{name: "number to json", val: 2.5, spType: ddl.JSON, want: "2.5"},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		got, err := convScalar(conv, tc.val, tc.spType)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+
+	errorCases := []struct {
+		name   string
+		val    interface{}
+		spType string
+	}{
+		{name: "fraction to int64", val: 2.5, spType: ddl.Int64},
+		{name: "text to int64", val: "seven", spType: ddl.Int64},
+		{name: "number to bool", val: 2.0, spType: ddl.Bool},
+		{name: "datetime to date", val: civil.DateTime{Date: day, Time: civil.Time{Hour: 1}}, spType: ddl.Date},
+		{name: "time to timestamp", val: civil.Time{Hour: 1}, spType: ddl.Timestamp},
+		{name: "text to numeric", val: "n/a", spType: ddl.Numeric},
+		{name: "text to json", val: "{", spType: ddl.JSON},
+	}
+	for _, tc := range errorCases {
+		_, err := convScalar(internal.MakeConv(), tc.val, tc.spType)
+		assert.NotNil(t, err, tc.name)
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package excel handles schema and data migrations from Excel workbooks
+// (.xlsx files), e.g. reference data handed over as spreadsheets. Each
+// worksheet is a table, whose columns are named by its first row. The
+// types of columns are inferred from the values of their cells, unless
+// they are given in a manifest.
+package excel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Source types are the kinds of values of cells. Decimal columns have no
+// cells of their own: they can only be set by manifests.
+const (
+	typeBoolean  = "boolean"
+	typeInteger  = "integer"
+	typeNumber   = "number"
+	typeDecimal  = "decimal"
+	typeDate     = "date"
+	typeTime     = "time"
+	typeDatetime = "datetime"
+	typeString   = "string"
+)
+
+var sourceTypes = []string{typeBoolean, typeInteger, typeNumber, typeDecimal, typeDate, typeTime, typeDatetime, typeString}
+
+// InfoSchemaImpl is the Excel specific implementation of InfoSchema.
+type InfoSchemaImpl struct {
+	Path string // Local or GCS path of the workbook.
+	// Types of columns overriding their inferred types, keyed by table and
+	// column name (see LoadManifest).
+	ColumnTypes map[string]map[string]string
+}
+
+// LoadManifest reads the column types of the JSON manifest at path, keyed
+// by table and column name. The manifest lists the worksheets whose
+// column types aren't inferred, e.g.
+//
+//	[{"table_name": "Rates", "column_types": {"rate": "decimal", "zip": "string"}}]
+//
+// Columns not listed in the manifest have inferred types.
+func LoadManifest(ctx context.Context, path string) (map[string]map[string]string, error) {
+	r, err := file_reader.NewFileReader(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read manifest file due to: %v", err)
+	}
+	defer r.Close()
+	content, err := r.ReadAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't read manifest file due to: %v", err)
+	}
+	tables := []struct {
+		Table_name   string            `json:"table_name"`
+		Column_types map[string]string `json:"column_types"`
+	}{}
+	if err := json.Unmarshal(content, &tables); err != nil {
+		return nil, fmt.Errorf("unable to unmarshall json due to: %v", err)
+	}
+	columnTypes := make(map[string]map[string]string)
+	for i, table := range tables {
+		if table.Table_name == "" {
+			return nil, fmt.Errorf("table number %d (0-indexed) does not have a name", i)
+		}
+		if _, ok := columnTypes[table.Table_name]; ok {
+			return nil, fmt.Errorf("table %s is listed more than once in the manifest", table.Table_name)
+		}
+		types := make(map[string]string)
+		for col, ty := range table.Column_types {
+			ty = strings.ToLower(strings.TrimSpace(ty))
+			if !isSourceType(ty) {
+				return nil, fmt.Errorf("invalid type %s of column %s of table %s: types are %s", ty, col, table.Table_name, strings.Join(sourceTypes, ", "))
+			}
+			types[col] = ty
+		}
+		columnTypes[table.Table_name] = types
+	}
+	return columnTypes, nil
+}
+
+func isSourceType(ty string) bool {
+	for _, t := range sourceTypes {
+		if t == ty {
+			return true
+		}
+	}
+	return false
+}
+
+// GetToDdl implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: workbooks can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for Excel workbooks")
+}
+
+// StartStreamingMigration is not supported: workbooks can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for Excel workbooks")
+}
+
+// GetTableName returns table name. Tables have no schema.
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	return tableName
+}
+
+// GetTables returns a table for each worksheet, except for empty
+// worksheets.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	wb, err := openWorkbook(context.Background(), isi.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer wb.Close()
+	sheets := make(map[string]bool)
+	var tables []common.SchemaAndName
+	for _, sheet := range wb.sheets {
+		sheets[sheet.name] = true
+		header, err := readHeader(wb, sheet)
+		if err != nil {
+			return nil, err
+		}
+		if len(header) > 0 {
+			tables = append(tables, common.SchemaAndName{Name: sheet.name})
+		}
+	}
+	for table := range isi.ColumnTypes {
+		if !sheets[table] {
+			return nil, fmt.Errorf("worksheet %s of the manifest not found in %s", table, isi.Path)
+		}
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names. The type of a
+// column is its type in the manifest, or the type of the values of its
+// cells: integers are numbers without fractional part, and dates and
+// times may be datetimes. Columns whose values have no common type, or
+// have no values, are strings. Cells may be empty, so columns have no NOT
+// NULL constraints.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	inferred := make(map[string]string)
+	header, err := isi.readTable(table.Name, func(row map[string]interface{}) {
+		for col, v := range row {
+			inferred[col] = unify(inferred[col], valueType(v))
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	overrides := isi.ColumnTypes[table.Name]
+	for col := range overrides {
+		if !contains(header, col) {
+			return nil, nil, fmt.Errorf("column %s of the manifest not found in worksheet %s", col, table.Name)
+		}
+	}
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	for _, name := range header {
+		ty, ok := overrides[name]
+		if !ok {
+			ty = inferred[name]
+		}
+		if ty == "" {
+			ty = typeString
+		}
+		colId := internal.GenerateColumnId()
+		colDefs[colId] = schema.Column{Id: colId, Name: name, Type: schema.Type{Name: ty}}
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// valueType returns the source type of a value read by readRows.
+func valueType(v interface{}) string {
+	switch v := v.(type) {
+	case bool:
+		return typeBoolean
+	case float64:
+		// Integers beyond 2^53 can't be told apart from other numbers.
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return typeInteger
+		}
+		return typeNumber
+	case civil.Date:
+		return typeDate
+	case civil.Time:
+		return typeTime
+	case civil.DateTime:
+		return typeDatetime
+	}
+	return typeString
+}
+
+// unify returns the type of a column whose values have types a and b.
+func unify(a, b string) string {
+	switch {
+	case a == "" || a == b:
+		return b
+	case a == typeInteger && b == typeNumber, a == typeNumber && b == typeInteger:
+		return typeNumber
+	case a == typeDate && b == typeDatetime, a == typeDatetime && b == typeDate:
+		return typeDatetime
+	}
+	return typeString
+}
+
+// GetRowsFromTable is not used: data is read from workbooks by
+// ProcessData.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
+	return nil, fmt.Errorf("data of Excel workbooks is read by ProcessData")
+}
+
+// GetRowCount returns the number of rows of a table, not counting its
+// header and empty rows.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	var count int64
+	_, err := isi.readTable(table.Name, func(row map[string]interface{}) {
+		count++
+	})
+	return count, err
+}
+
+// GetConstraints returns no constraints: worksheets have no primary keys,
+// so tables get a synthetic primary key.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	return nil, nil, make(map[string][]string), nil
+}
+
+// GetForeignKeys returns no foreign keys: worksheets have none.
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	return nil, nil
+}
+
+// GetIndexes returns no indexes: worksheets have none.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return nil, nil
+}
+
+// ProcessData reads the rows of the worksheet of a table, converts them to
+// Spanner data (based on the source and Spanner schemas) and writes them
+// to Spanner.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	_, err := isi.readTable(srcSchema.Name, func(row map[string]interface{}) {
+		ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, row)
+	})
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't read worksheet %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	return nil
+}
+
+// readTable calls processRow with each row of the worksheet of a table
+// after its header, keyed by column name, and returns its header. Cells
+// outside the columns of the header are skipped. See readRows for the
+// types of values.
+func (isi InfoSchemaImpl) readTable(tableName string, processRow func(row map[string]interface{})) ([]string, error) {
+	wb, err := openWorkbook(context.Background(), isi.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer wb.Close()
+	for _, sheet := range wb.sheets {
+		if sheet.name != tableName {
+			continue
+		}
+		var header []string
+		err := wb.readRows(sheet, func(values []interface{}) error {
+			if header == nil {
+				var err error
+				header, err = columnNames(sheet, values)
+				return err
+			}
+			row := make(map[string]interface{})
+			for i, v := range values {
+				if i < len(header) && v != nil {
+					row[header[i]] = v
+				}
+			}
+			if len(row) > 0 {
+				processRow(row)
+			}
+			return nil
+		})
+		return header, err
+	}
+	return nil, fmt.Errorf("worksheet %s not found in %s", tableName, isi.Path)
+}
+
+// readHeader returns the column names of a worksheet, or nil if it is
+// empty.
+func readHeader(wb *workbook, sheet sheetInfo) ([]string, error) {
+	var header []string
+	err := wb.readRows(sheet, func(values []interface{}) error {
+		var err error
+		if header, err = columnNames(sheet, values); err != nil {
+			return err
+		}
+		return io.EOF
+	})
+	return header, err
+}
+
+// columnNames returns the names of the columns of a worksheet, given by
+// the values of its first row. Columns without names are named after
+// their letters e.g. C.
+func columnNames(sheet sheetInfo, values []interface{}) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for i, v := range values {
+		name := ""
+		if v != nil {
+			name = strings.TrimSpace(toString(v))
+		}
+		if name == "" {
+			name = columnName(i)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("column %s of worksheet %s is named more than once", name, sheet.name)
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package excel
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+// mkInfoSchema writes the test workbook to a temporary directory, and
+// returns an InfoSchemaImpl reading it.
+func mkInfoSchema(t *testing.T) InfoSchemaImpl {
+	path := filepath.Join(t.TempDir(), "rates.xlsx")
+	writeTestWorkbook(t, path, false, testRates, testEmpty)
+	return InfoSchemaImpl{Path: path}
+}
+
+func processSchema(t *testing.T, isi InfoSchemaImpl) *internal.Conv {
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	return conv
+}
+
+var cols = []string{"id", "name", "price", "active", "day", "at", "opens", "mixed", "I", "code"}
+
+func TestGetTables(t *testing.T) {
+	isi := mkInfoSchema(t)
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Name: "Rates"}}, tables)
+	isi.ColumnTypes = map[string]map[string]string{"Prices": {}}
+	_, err = isi.GetTables()
+	assert.NotNil(t, err)
+}
+
+func TestProcessSchema(t *testing.T) {
+	conv := processSchema(t, mkInfoSchema(t))
+	expectedSchema := map[string]ddl.CreateTable{
+		"Rates": {
+			Name:   "Rates",
+			ColIds: append(append([]string{}, cols...), "synth_id"),
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":       {Name: "id", T: ddl.Type{Name: ddl.Int64}},
+				"name":     {Name: "name", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"price":    {Name: "price", T: ddl.Type{Name: ddl.Float64}},
+				"active":   {Name: "active", T: ddl.Type{Name: ddl.Bool}},
+				"day":      {Name: "day", T: ddl.Type{Name: ddl.Date}},
+				"at":       {Name: "at", T: ddl.Type{Name: ddl.Timestamp}},
+				"opens":    {Name: "opens", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"mixed":    {Name: "mixed", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"I":        {Name: "I", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"code":     {Name: "code", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"synth_id": {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "manifest.json")
+	assert.Nil(t, os.WriteFile(manifest, []byte(`[{"table_name": "Rates", "column_types": {"price": "Decimal", "code": "integer"}}]`), 0644))
+	columnTypes, err := LoadManifest(context.Background(), manifest)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[string]string{"Rates": {"price": typeDecimal, "code": typeInteger}}, columnTypes)
+
+	for _, content := range []string{
+		`[{"table_name": "Rates", "column_types": {"price": "money"}}]`,
+		`[{"column_types": {"price": "decimal"}}]`,
+		`[{"table_name": "Rates"}, {"table_name": "Rates"}]`,
+		`{"table_name": "Rates"}`,
+	} {
+		assert.Nil(t, os.WriteFile(manifest, []byte(content), 0644))
+		_, err = LoadManifest(context.Background(), manifest)
+		assert.NotNil(t, err, content)
+	}
+	_, err = LoadManifest(context.Background(), filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func TestGetColumnsWithManifest(t *testing.T) {
+	isi := mkInfoSchema(t)
+	isi.ColumnTypes = map[string]map[string]string{"Rates": {"price": typeDecimal, "code": typeInteger}}
+	conv := processSchema(t, isi)
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "Rates")
+	assert.Nil(t, err)
+	colDefs := conv.SpSchema[tableId].ColDefs
+	for name, want := range map[string]ddl.Type{
+		"price": {Name: ddl.Numeric},
+		"code":  {Name: ddl.Int64},
+		"id":    {Name: ddl.Int64},
+	} {
+		colId, err := internal.GetColIdFromSpName(colDefs, name)
+		assert.Nil(t, err)
+		assert.Equal(t, want, colDefs[colId].T, name)
+	}
+	rows := processTableData(t, conv, isi, "Rates")
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, []interface{}{big.NewRat(5, 2), int64(7)}, []interface{}{rows[0].vals[2], rows[0].vals[9]})
+	assert.Equal(t, []interface{}{big.NewRat(3, 1), int64(42)}, []interface{}{rows[1].vals[2], rows[1].vals[9]})
+	assert.Equal(t, int64(0), conv.BadRows())
+
+	isi.ColumnTypes = map[string]map[string]string{"Rates": {"cost": typeDecimal}}
+	_, _, err = isi.GetColumns(conv, common.SchemaAndName{Name: "Rates"}, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestGetRowCount(t *testing.T) {
+	isi := mkInfoSchema(t)
+	count, err := isi.GetRowCount(common.SchemaAndName{Name: "Rates"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	_, err = isi.GetRowCount(common.SchemaAndName{Name: "missing"})
+	assert.NotNil(t, err)
+}
+
+// processTableData migrates the data of the Spanner table spTableName and
+// returns the rows written, without their synthetic primary keys.
+func processTableData(t *testing.T, conv *internal.Conv, isi InfoSchemaImpl, spTableName string) []spannerData {
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols[:len(cols)-1], vals: vals[:len(vals)-1]})
+		})
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, spTableName)
+	assert.Nil(t, err)
+	colIds := conv.SpSchema[tableId].ColIds
+	err = isi.ProcessData(conv, tableId, conv.SrcSchema[tableId], colIds[:len(colIds)-1], conv.SpSchema[tableId], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	return rows
+}
+
+func TestProcessData(t *testing.T) {
+	isi := mkInfoSchema(t)
+	conv := processSchema(t, isi)
+	rows := processTableData(t, conv, isi, "Rates")
+	assert.Equal(t, []spannerData{
+		{
+			table: "Rates",
+			cols:  cols,
+			vals: []interface{}{
+				int64(1), "apple", 2.5, true, civil.Date{Year: 2025, Month: 1, Day: 1},
+				time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), "09:00:00", "7", "a\rb", "007",
+			},
+		},
+		{
+			table: "Rates",
+			cols:  cols,
+			vals: []interface{}{
+				int64(2), nil, 3.0, false, civil.Date{Year: 2025, Month: 2, Day: 3},
+				time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), nil, "seven", nil, "42",
+			},
+		},
+	}, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package excel
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpannerType(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		isPk    bool
+		srcType schema.Type
+		want    ddl.Type
+		issues  []internal.SchemaIssue
+	}{
+		{name: "boolean", srcType: schema.Type{Name: typeBoolean}, want: ddl.Type{Name: ddl.Bool}},
+		{name: "boolean to int64", spType: ddl.Int64, srcType: schema.Type{Name: typeBoolean}, want: ddl.Type{Name: ddl.Int64}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "integer", srcType: schema.Type{Name: typeInteger}, want: ddl.Type{Name: ddl.Int64}},
+		{name: "integer to numeric", spType: ddl.Numeric, srcType: schema.Type{Name: typeInteger}, want: ddl.Type{Name: ddl.Numeric}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "number", srcType: schema.Type{Name: typeNumber}, want: ddl.Type{Name: ddl.Float64}},
+		{name: "number to numeric", spType: ddl.Numeric, srcType: schema.Type{Name: typeNumber}, want: ddl.Type{Name: ddl.Numeric}},
+		{name: "decimal", srcType: schema.Type{Name: typeDecimal}, want: ddl.Type{Name: ddl.Numeric}},
+		{name: "decimal pk pg", dialect: constants.DIALECT_POSTGRESQL, isPk: true, srcType: schema.Type{Name: typeDecimal}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.NumericPKNotSupported}},
+		{name: "date", srcType: schema.Type{Name: typeDate}, want: ddl.Type{Name: ddl.Date}},
+		{name: "date to timestamp", spType: ddl.Timestamp, srcType: schema.Type{Name: typeDate}, want: ddl.Type{Name: ddl.Timestamp}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "time", srcType: schema.Type{Name: typeTime}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Time}},
+		{name: "datetime", srcType: schema.Type{Name: typeDatetime}, want: ddl.Type{Name: ddl.Timestamp}, issues: []internal.SchemaIssue{internal.Datetime}},
+		{name: "string", srcType: schema.Type{Name: typeString}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{name: "string to json", spType: ddl.JSON, srcType: schema.Type{Name: typeString}, want: ddl.Type{Name: ddl.JSON}},
+		{name: "unknown", srcType: schema.Type{Name: "formula"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.NoGoodType}},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		ty, issues := ToDdlImpl{}.ToSpannerType(conv, tc.spType, tc.srcType, tc.isPk)
+		assert.Equal(t, tc.want, ty, tc.name)
+		assert.Equal(t, tc.issues, issues, tc.name)
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package excel
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/civil"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+)
+
+// An xlsx workbook is a zip archive of XML parts (ECMA-376, Office Open
+// XML). Only the parts needed to read the values of cells are read: the
+// workbook, its relationships, the shared strings, the styles and the
+// worksheets.
+
+// numFmtKind classifies number formats: numbers formatted as dates, times
+// or both are serial dates, i.e. days since the epoch of the workbook.
+type numFmtKind int
+
+const (
+	numFmtNumber numFmtKind = iota
+	numFmtDate
+	numFmtTime
+	numFmtDateTime
+)
+
+type sheetInfo struct {
+	name string
+	path string // Path of the worksheet in the archive.
+}
+
+// workbook is an open xlsx workbook.
+type workbook struct {
+	r        file_reader.ReaderAtSeekCloser
+	zip      *zip.Reader
+	sheets   []sheetInfo
+	strings  []string     // Shared strings.
+	styles   []numFmtKind // Kind of the number format of each cell style.
+	date1904 bool         // Serial dates are days since 1904-01-01.
+}
+
+// openWorkbook opens the local or GCS xlsx workbook at path, and reads its
+// list of worksheets, shared strings and styles.
+func openWorkbook(ctx context.Context, path string) (*workbook, error) {
+	r, err := file_reader.OpenReaderAt(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	wb := &workbook{r: r}
+	if err := wb.readMetadata(); err != nil {
+		wb.Close()
+		return nil, fmt.Errorf("couldn't read xlsx workbook %s: %w", path, err)
+	}
+	return wb, nil
+}
+
+func (wb *workbook) Close() error {
+	return wb.r.Close()
+}
+
+func (wb *workbook) readMetadata() error {
+	size, err := wb.r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if wb.zip, err = zip.NewReader(wb.r, size); err != nil {
+		return err
+	}
+	var book struct {
+		Pr struct {
+			Date1904 string `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			Id   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := wb.unmarshal("xl/workbook.xml", &book); err != nil {
+		return err
+	}
+	wb.date1904 = book.Pr.Date1904 == "1" || book.Pr.Date1904 == "true"
+	var rels struct {
+		Rels []struct {
+			Id     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := wb.unmarshal("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return err
+	}
+	targets := make(map[string]string)
+	for _, rel := range rels.Rels {
+		// Targets are relative to xl/, unless they are absolute.
+		if strings.HasPrefix(rel.Target, "/") {
+			targets[rel.Id] = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			targets[rel.Id] = path.Join("xl", rel.Target)
+		}
+	}
+	for _, s := range book.Sheets {
+		target, ok := targets[s.Id]
+		if !ok {
+			return fmt.Errorf("worksheet %s not found", s.Name)
+		}
+		wb.sheets = append(wb.sheets, sheetInfo{name: s.Name, path: target})
+	}
+	if err := wb.readSharedStrings(); err != nil {
+		return err
+	}
+	return wb.readStyles()
+}
+
+// unmarshal decodes the XML part name of the archive into v.
+func (wb *workbook) unmarshal(name string, v interface{}) error {
+	f, err := wb.zip.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := xml.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("couldn't parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// richString is a string made of runs of text, as in shared strings and
+// inline strings. Phonetic runs (rPh) aren't part of the text.
+type richString struct {
+	T    *string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rs richString) String() string {
+	if rs.T != nil {
+		return unescape(*rs.T)
+	}
+	var sb strings.Builder
+	for _, r := range rs.Runs {
+		sb.WriteString(r.T)
+	}
+	return unescape(sb.String())
+}
+
+var escapedChar = regexp.MustCompile(`_x[0-9A-Fa-f]{4}_`)
+
+// unescape decodes the characters that XML can't represent e.g. carriage
+// returns, which are escaped as _xHHHH_ with HHHH their UTF-16 code.
+func unescape(s string) string {
+	if !strings.Contains(s, "_x") {
+		return s
+	}
+	return escapedChar.ReplaceAllStringFunc(s, func(m string) string {
+		c, _ := strconv.ParseUint(m[2:6], 16, 16)
+		return string(rune(c))
+	})
+}
+
+// readSharedStrings reads the strings of the workbook, which cells of type
+// s refer to by index. Workbooks without strings have no shared strings.
+func (wb *workbook) readSharedStrings() error {
+	var sst struct {
+		Items []richString `xml:"si"`
+	}
+	if err := wb.unmarshal("xl/sharedStrings.xml", &sst); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, si := range sst.Items {
+		wb.strings = append(wb.strings, si.String())
+	}
+	return nil
+}
+
+// readStyles reads the number formats of the cell styles, which cells
+// refer to by index.
+func (wb *workbook) readStyles() error {
+	var styles struct {
+		NumFmts []struct {
+			Id   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		Xfs []struct {
+			NumFmtId int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := wb.unmarshal("xl/styles.xml", &styles); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	custom := make(map[int]numFmtKind)
+	for _, nf := range styles.NumFmts {
+		custom[nf.Id] = formatKind(nf.Code)
+	}
+	for _, xf := range styles.Xfs {
+		kind, ok := custom[xf.NumFmtId]
+		if !ok {
+			kind = builtinFormatKind(xf.NumFmtId)
+		}
+		wb.styles = append(wb.styles, kind)
+	}
+	return nil
+}
+
+// builtinFormatKind classifies the built-in number formats, which aren't
+// listed in the styles of workbooks. Formats 27 to 36 and 50 to 58 are
+// dates in East Asian locales.
+func builtinFormatKind(id int) numFmtKind {
+	switch {
+	case id >= 14 && id <= 17, id >= 27 && id <= 36, id >= 50 && id <= 58:
+		return numFmtDate
+	case id >= 18 && id <= 21, id >= 45 && id <= 47:
+		return numFmtTime
+	case id == 22:
+		return numFmtDateTime
+	}
+	return numFmtNumber
+}
+
+var (
+	quotedText = regexp.MustCompile(`"[^"]*"|\\.|_.|\*.`)
+	brackets   = regexp.MustCompile(`\[[^\]]*\]`)
+	elapsed    = regexp.MustCompile(`^\[[hHmMsS]+\]$`)
+)
+
+// formatKind classifies a custom number format by the date and time codes
+// of its first section, ignoring literal text and colors or locales in
+// brackets, but not elapsed times e.g. [h]:mm. m is a month unless the
+// format has hours or seconds, in which case it is a minute.
+func formatKind(code string) numFmtKind {
+	code = quotedText.ReplaceAllString(code, "")
+	code = brackets.ReplaceAllStringFunc(code, func(b string) string {
+		if elapsed.MatchString(b) {
+			return b[1 : len(b)-1]
+		}
+		return ""
+	})
+	code, _, _ = strings.Cut(strings.ToLower(code), ";")
+	hasTime := strings.ContainsAny(code, "hs")
+	hasDate := strings.ContainsAny(code, "yd") || (!hasTime && strings.Contains(code, "m"))
+	switch {
+	case hasDate && hasTime:
+		return numFmtDateTime
+	case hasDate:
+		return numFmtDate
+	case hasTime:
+		return numFmtTime
+	}
+	return numFmtNumber
+}
+
+// serialDate converts a serial date to a date and time of day, rounded to
+// the millisecond. Serial dates of the 1900 date system count February 29,
+// 1900, which didn't exist, so that days before March 1, 1900 are counted
+// from December 31, 1899 rather than December 30, 1899.
+func (wb *workbook) serialDate(serial float64) (civil.Date, civil.Time) {
+	days := math.Floor(serial)
+	ms := int(math.Round((serial - days) * 86400000))
+	if ms == 86400000 {
+		days++
+		ms = 0
+	}
+	base := civil.Date{Year: 1899, Month: 12, Day: 30}
+	if wb.date1904 {
+		base = civil.Date{Year: 1904, Month: 1, Day: 1}
+	} else if days < 61 {
+		base = civil.Date{Year: 1899, Month: 12, Day: 31}
+	}
+	t := civil.Time{
+		Hour:       ms / 3600000,
+		Minute:     ms / 60000 % 60,
+		Second:     ms / 1000 % 60,
+		Nanosecond: ms % 1000 * 1000000,
+	}
+	return base.AddDays(int(days)), t
+}
+
+type xlsxCell struct {
+	Ref   string      `xml:"r,attr"`
+	Type  string      `xml:"t,attr"`
+	Style int         `xml:"s,attr"`
+	V     *string     `xml:"v"`
+	Is    *richString `xml:"is"`
+}
+
+type xlsxRow struct {
+	Cells []xlsxCell `xml:"c"`
+}
+
+// readRows calls processRow with the values of the cells of each row of
+// the worksheet, indexed by column. Rows without values are skipped.
+// Values are:
+//   - bool for booleans,
+//   - float64 for numbers,
+//   - civil.Date, civil.Time or civil.DateTime for numbers formatted as
+//     dates, times or both, and for ISO 8601 dates,
+//   - string for strings,
+//   - nil for empty cells, empty strings and errors e.g. #N/A.
+//
+// processRow may stop the read by returning io.EOF.
+func (wb *workbook) readRows(sheet sheetInfo, processRow func(row []interface{}) error) error {
+	f, err := wb.zip.Open(sheet.path)
+	if err != nil {
+		return fmt.Errorf("couldn't read worksheet %s: %w", sheet.name, err)
+	}
+	defer f.Close()
+	d := xml.NewDecoder(f)
+	for rowNum := 1; ; {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't parse worksheet %s: %w", sheet.name, err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "row" {
+			continue
+		}
+		var xr xlsxRow
+		if err := d.DecodeElement(&xr, &se); err != nil {
+			return fmt.Errorf("couldn't parse row %d of worksheet %s: %w", rowNum, sheet.name, err)
+		}
+		row, err := wb.rowValues(xr)
+		if err != nil {
+			return fmt.Errorf("couldn't read row %d of worksheet %s: %w", rowNum, sheet.name, err)
+		}
+		rowNum++
+		if row == nil {
+			continue
+		}
+		if err := processRow(row); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// rowValues returns the values of the cells of a row, or nil if it has
+// none. Cells without references follow the previous cell.
+func (wb *workbook) rowValues(xr xlsxRow) ([]interface{}, error) {
+	var row []interface{}
+	col := -1
+	for _, c := range xr.Cells {
+		col++
+		if c.Ref != "" {
+			var err error
+			if col, err = columnIndex(c.Ref); err != nil {
+				return nil, err
+			}
+		}
+		v, err := wb.cellValue(c)
+		if err != nil {
+			return nil, fmt.Errorf("cell %s: %w", columnName(col), err)
+		}
+		if v == nil {
+			continue
+		}
+		for len(row) <= col {
+			row = append(row, nil)
+		}
+		row[col] = v
+	}
+	return row, nil
+}
+
+func (wb *workbook) cellValue(c xlsxCell) (interface{}, error) {
+	if c.Type == "inlineStr" {
+		if c.Is == nil {
+			return nil, nil
+		}
+		return nonEmpty(c.Is.String()), nil
+	}
+	if c.V == nil {
+		return nil, nil
+	}
+	v := *c.V
+	switch c.Type {
+	case "s":
+		i, err := strconv.Atoi(v)
+		if err != nil || i < 0 || i >= len(wb.strings) {
+			return nil, fmt.Errorf("invalid shared string %q", v)
+		}
+		return nonEmpty(wb.strings[i]), nil
+	case "str":
+		return nonEmpty(unescape(v)), nil
+	case "b":
+		return v == "1", nil
+	case "e":
+		return nil, nil
+	case "d":
+		if !strings.Contains(v, "T") {
+			return civil.ParseDate(v)
+		}
+		return civil.ParseDateTime(strings.TrimSuffix(v, "Z"))
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", v)
+	}
+	kind := numFmtNumber
+	if c.Style >= 0 && c.Style < len(wb.styles) {
+		kind = wb.styles[c.Style]
+	}
+	if kind == numFmtNumber {
+		return n, nil
+	}
+	d, t := wb.serialDate(n)
+	switch kind {
+	case numFmtDate:
+		return d, nil
+	case numFmtTime:
+		return t, nil
+	}
+	return civil.DateTime{Date: d, Time: t}, nil
+}
+
+func nonEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// columnIndex returns the 0-based column of a cell reference e.g. 27 for
+// AB12.
+func columnIndex(ref string) (int, error) {
+	col := 0
+	i := 0
+	for ; i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z'; i++ {
+		col = col*26 + int(ref[i]-'A') + 1
+	}
+	if i == 0 || col > 16384 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return col - 1, nil
+}
+
+// columnName returns the name of a 0-based column e.g. AB for 27.
+func columnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package excel
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSheet struct {
+	name string
+	rows string // Content of the sheetData element.
+}
+
+const testSharedStrings = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="4" uniqueCount="4">
+<si><t>id</t></si>
+<si><t>name</t></si>
+<si><r><t>ap</t></r><r><rPr><b/></rPr><t>ple</t></r><rPh sb="0" eb="1"><t>x</t></rPh></si>
+<si><t>a_x000D_b</t></si>
+</sst>`
+
+// The styles are General, a date, a datetime, a time, a number with a
+// unit and a date with a locale.
+const testStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="3">
+<numFmt numFmtId="164" formatCode="yyyy\-mm\-dd\ hh:mm:ss"/>
+<numFmt numFmtId="165" formatCode="0.00&quot; kg&quot;"/>
+<numFmt numFmtId="166" formatCode="[$-409]mmm\ yy;@"/>
+</numFmts>
+<cellXfs count="6">
+<xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="164"/><xf numFmtId="20"/><xf numFmtId="165"/><xf numFmtId="166"/>
+</cellXfs>
+</styleSheet>`
+
+// writeTestWorkbook writes an xlsx workbook with the test shared strings
+// and styles, and the worksheets sheets.
+func writeTestWorkbook(t *testing.T, path string, date1904 bool, sheets ...testSheet) {
+	f, err := os.Create(path)
+	assert.Nil(t, err)
+	defer f.Close()
+	w := zip.NewWriter(f)
+	var sheetList, rels strings.Builder
+	for i, s := range sheets {
+		fmt.Fprintf(&sheetList, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, s.name, i+1, i+1)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	parts := map[string]string{
+		"xl/workbook.xml": fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<workbookPr date1904="%t"/><sheets>%s</sheets></workbook>`, date1904, sheetList.String()),
+		"xl/_rels/workbook.xml.rels": fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">%s</Relationships>`, rels.String()),
+		"xl/sharedStrings.xml": testSharedStrings,
+		"xl/styles.xml":        testStyles,
+	}
+	for i, s := range sheets {
+		parts[fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)] = fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><dimension ref="A1"/><sheetData>%s</sheetData></worksheet>`, s.rows)
+	}
+	for name, content := range parts {
+		pw, err := w.Create(name)
+		assert.Nil(t, err)
+		_, err = pw.Write([]byte(content))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, w.Close())
+}
+
+// testRates is a worksheet with a header, a row of values of each type, an
+// empty row and a row of other values.
+var testRates = testSheet{
+	name: "Rates",
+	rows: `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>price</t></is></c>` +
+		`<c r="D1" t="str"><v>active</v></c><c r="E1" t="inlineStr"><is><t>day</t></is></c><c r="F1" t="inlineStr"><is><t>at</t></is></c>` +
+		`<c r="G1" t="inlineStr"><is><t>opens</t></is></c><c r="H1" t="inlineStr"><is><t> mixed </t></is></c><c r="J1" t="inlineStr"><is><t>code</t></is></c></row>` +
+		`<row r="2"><c r="A2"><v>1</v></c><c r="B2" t="s"><v>2</v></c><c r="C2" s="4"><v>2.5</v></c><c r="D2" t="b"><v>1</v></c>` +
+		`<c r="E2" s="1"><v>45658</v></c><c r="F2" s="2"><v>45658.5</v></c><c r="G2" s="3"><v>0.375</v></c><c r="H2"><v>7</v></c>` +
+		`<c r="I2" t="s"><v>3</v></c><c r="J2" t="inlineStr"><is><t>007</t></is></c></row>` +
+		`<row r="3"><c r="A3" t="inlineStr"><is><t></t></is></c></row>` +
+		`<row r="4"><c><v>2</v></c><c/><c><v>3</v></c><c t="b"><v>0</v></c><c t="d"><v>2025-02-03</v></c><c s="1"><v>45659</v></c>` +
+		`<c t="e"><v>#N/A</v></c><c t="inlineStr"><is><t>seven</t></is></c><c/><c t="str"><v>42</v></c><c r="L4"><v>9</v></c></row>`,
+}
+
+var testEmpty = testSheet{name: "Empty"}
+
+func TestReadRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.xlsx")
+	writeTestWorkbook(t, path, false, testRates, testEmpty)
+	wb, err := openWorkbook(context.Background(), path)
+	assert.Nil(t, err)
+	defer wb.Close()
+	assert.Equal(t, []sheetInfo{{name: "Rates", path: "xl/worksheets/sheet1.xml"}, {name: "Empty", path: "xl/worksheets/sheet2.xml"}}, wb.sheets)
+	var rows [][]interface{}
+	err = wb.readRows(wb.sheets[0], func(row []interface{}) error {
+		rows = append(rows, row)
+		return nil
+	})
+	assert.Nil(t, err)
+	day := civil.Date{Year: 2025, Month: 1, Day: 1}
+	assert.Equal(t, [][]interface{}{
+		{"id", "name", "price", "active", "day", "at", "opens", " mixed ", nil, "code"},
+		{1.0, "apple", 2.5, true, day, civil.DateTime{Date: day, Time: civil.Time{Hour: 12}}, civil.Time{Hour: 9}, 7.0, "a\rb", "007"},
+		{2.0, nil, 3.0, false, civil.Date{Year: 2025, Month: 2, Day: 3}, civil.Date{Year: 2025, Month: 1, Day: 2}, nil, "seven", nil, "42", nil, 9.0},
+	}, rows)
+	err = wb.readRows(wb.sheets[1], func(row []interface{}) error {
+		t.Errorf("unexpected row %v", row)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	_, err = openWorkbook(context.Background(), filepath.Join(t.TempDir(), "missing.xlsx"))
+	assert.NotNil(t, err)
+	notZip := filepath.Join(t.TempDir(), "rates.xls")
+	assert.Nil(t, os.WriteFile(notZip, []byte("not a workbook"), 0644))
+	_, err = openWorkbook(context.Background(), notZip)
+	assert.NotNil(t, err)
+}
+
+func TestFormatKind(t *testing.T) {
+	testCases := []struct {
+		code string
+		want numFmtKind
+	}{
+		{code: "General", want: numFmtNumber},
+		{code: "#,##0.00", want: numFmtNumber},
+		{code: `0.00" days"`, want: numFmtNumber},
+		{code: `[Red]0.00;[Blue]-0.00`, want: numFmtNumber},
+		{code: "yyyy-mm-dd", want: numFmtDate},
+		{code: "mmm", want: numFmtDate},
+		{code: `[$-F800]dddd\,\ mmmm\ dd\,\ yyyy`, want: numFmtDate},
+		{code: "h:mm AM/PM", want: numFmtTime},
+		{code: "[h]:mm:ss", want: numFmtTime},
+		{code: "mm:ss.0", want: numFmtTime},
+		{code: "m/d/yy h:mm", want: numFmtDateTime},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, formatKind(tc.code), tc.code)
+	}
+	assert.Equal(t, numFmtDate, builtinFormatKind(14))
+	assert.Equal(t, numFmtTime, builtinFormatKind(46))
+	assert.Equal(t, numFmtDateTime, builtinFormatKind(22))
+	assert.Equal(t, numFmtNumber, builtinFormatKind(2))
+}
+
+func TestSerialDate(t *testing.T) {
+	testCases := []struct {
+		serial   float64
+		date1904 bool
+		date     civil.Date
+		time     civil.Time
+	}{
+		{serial: 1, date: civil.Date{Year: 1900, Month: 1, Day: 1}},
+		{serial: 59, date: civil.Date{Year: 1900, Month: 2, Day: 28}},
+		{serial: 61, date: civil.Date{Year: 1900, Month: 3, Day: 1}},
+		{serial: 45658.25, date: civil.Date{Year: 2025, Month: 1, Day: 1}, time: civil.Time{Hour: 6}},
+		{serial: 45658.999999999, date: civil.Date{Year: 2025, Month: 1, Day: 2}},
+		{serial: 0.5 + 1.5/86400, date: civil.Date{Year: 1899, Month: 12, Day: 31}, time: civil.Time{Hour: 12, Second: 1, Nanosecond: 500000000}},
+		{serial: 44196, date1904: true, date: civil.Date{Year: 2025, Month: 1, Day: 1}},
+	}
+	for _, tc := range testCases {
+		wb := workbook{date1904: tc.date1904}
+		d, tm := wb.serialDate(tc.serial)
+		assert.Equal(t, tc.date, d, tc.serial)
+		assert.Equal(t, tc.time, tm, tc.serial)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	for ref, want := range map[string]int{"A1": 0, "Z9": 25, "AA1": 26, "AB12": 27, "XFD1": 16383} {
+		col, err := columnIndex(ref)
+		assert.Nil(t, err, ref)
+		assert.Equal(t, want, col, ref)
+		assert.Equal(t, strings.TrimRight(ref, "0123456789"), columnName(col), ref)
+	}
+	for _, ref := range []string{"12", "a1", "XFE1"} {
+		_, err := columnIndex(ref)
+		assert.NotNil(t, err, ref)
+	}
+}
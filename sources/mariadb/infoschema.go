@@ -0,0 +1,351 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mariadb handles schema and data migrations from MariaDB.
+//
+// MariaDB speaks the MySQL protocol and most of its information schema
+// matches MySQL's, so InfoSchemaImpl builds on the MySQL implementation.
+// It differs where MariaDB does: MariaDB specific types (UUID, INET4,
+// INET6), JSON columns (which are LONGTEXT columns with a json_valid check
+// constraint), column defaults (which MariaDB reports as SQL expressions),
+// sequences and system-versioned tables.
+package mariadb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sp "cloud.google.com/go/spanner"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// jsonValid marks the columns of a table with a json_valid check constraint
+// in the by-column constraints returned by GetConstraints.
+const jsonValid = "JSON_VALID"
+
+var (
+	collationRegex = regexp.MustCompile(constants.DB_COLLATION_REGEX)
+	// jsonValidRegex matches the check constraint that MariaDB adds to JSON
+	// columns e.g. json_valid(`doc`).
+	jsonValidRegex = regexp.MustCompile("^\\(?json_valid\\(`([^`]+)`\\)\\)?$")
+	// nextvalRegex matches the default of columns taking their values from a
+	// sequence e.g. nextval(`shop`.`order_seq`).
+	nextvalRegex = regexp.MustCompile("(?i)^nextval\\((?:`([^`]+)`\\.)?`([^`]+)`\\)$")
+)
+
+// InfoSchemaImpl is MariaDB specific implementation for InfoSchema. Data
+// is read as it is for MySQL.
+type InfoSchemaImpl struct {
+	mysql.InfoSchemaImpl
+	Version Version // Version of the MariaDB server, see GetVersion.
+}
+
+// GetToDdl implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// GetTables return list of tables in the selected database. System-versioned
+// tables are migrated with their current rows, without their history.
+// Sequences, which MariaDB lists as tables, are migrated when used by a
+// column default, see GetColumns.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	q := "SELECT table_name FROM information_schema.tables WHERE table_type IN ('BASE TABLE', 'SYSTEM VERSIONED') AND table_schema = ?"
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tables: %w", err)
+	}
+	defer rows.Close()
+	var tableName string
+	var tables []common.SchemaAndName
+	for rows.Next() {
+		rows.Scan(&tableName)
+		tables = append(tables, common.SchemaAndName{Schema: isi.DbName, Name: tableName})
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names. The ROW START and
+// ROW END columns of system-versioned tables are left out as their values
+// are maintained by MariaDB.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	q := `SELECT c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.extra
+              FROM information_schema.COLUMNS c
+              WHERE c.table_schema = ? AND c.table_name = ?
+              AND COALESCE(c.generation_expression, '') NOT IN ('ROW START', 'ROW END')
+              ORDER BY c.ordinal_position;`
+	cols, err := isi.Db.Query(q, table.Schema, table.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
+	}
+	defer cols.Close()
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	var colName, dataType, isNullable, columnType string
+	var colDefault, colExtra sql.NullString
+	var charMaxLen, numericPrecision, numericScale sql.NullInt64
+	for cols.Next() {
+		err := cols.Scan(&colName, &dataType, &columnType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colExtra)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		colId := internal.GenerateColumnId()
+		ty := mysql.ToType(dataType, columnType, charMaxLen, numericPrecision, numericScale)
+		if dataType == "longtext" && hasConstraint(constraints[colName], jsonValid) {
+			ty = schema.Type{Name: "json"}
+		}
+		var colAutoGen ddl.AutoGenCol
+		defaultVal := isi.toDefaultValue(conv, colDefault, dataType)
+		if colExtra.String == "auto_increment" {
+			sequence := mysql.CreateSequence(conv)
+			colAutoGen = ddl.AutoGenCol{Name: sequence.Name, GenerationType: constants.AUTO_INCREMENT}
+			sequence.ColumnsUsingSeq = map[string][]string{table.Id: {colId}}
+			conv.ConvLock.Lock()
+			conv.SrcSequences[sequence.Id] = sequence
+			conv.ConvLock.Unlock()
+		} else if match := nextvalRegex.FindStringSubmatch(colDefault.String); match != nil {
+			seqSchema := match[1]
+			if seqSchema == "" {
+				seqSchema = table.Schema
+			}
+			if err := isi.addSequenceColumn(conv, seqSchema, match[2], table.Id, colId); err != nil {
+				conv.Unexpected(fmt.Sprintf("Couldn't get sequence %s.%s used by column %s: %v", seqSchema, match[2], colName, err))
+			} else {
+				colAutoGen = ddl.AutoGenCol{Name: match[2], GenerationType: constants.SEQUENCE}
+				defaultVal = ddl.DefaultValue{}
+			}
+		}
+		colDefs[colId] = schema.Column{
+			Id:           colId,
+			Name:         colName,
+			Type:         ty,
+			NotNull:      common.ToNotNull(conv, isNullable),
+			Ignored:      schema.Ignored{Default: defaultVal.IsPresent},
+			AutoGen:      colAutoGen,
+			DefaultValue: defaultVal,
+			EnumValues:   mysql.GetEnumValues(dataType, columnType),
+			// The extra column is e.g. "on update current_timestamp()".
+			OnUpdateCurrentTimestamp: strings.Contains(strings.ToLower(colExtra.String), "on update current_timestamp"),
+		}
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// toDefaultValue maps the COLUMN_DEFAULT of a column to its default value.
+// Since MariaDB 10.2.7, defaults are reported as SQL expressions: string
+// literals are quoted and escaped, and a NULL default is reported as NULL
+// rather than as no default. Older versions report defaults as MySQL does.
+func (isi InfoSchemaImpl) toDefaultValue(conv *internal.Conv, colDefault sql.NullString, dataType string) ddl.DefaultValue {
+	if !colDefault.Valid {
+		return ddl.DefaultValue{}
+	}
+	statement := colDefault.String
+	if isi.Version.AtLeast(10, 2, 7) {
+		if strings.EqualFold(statement, "NULL") {
+			return ddl.DefaultValue{}
+		}
+	} else {
+		ty := dataType
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			ty = ddl.GetPGType(ddl.Type{Name: ty})
+		}
+		statement = common.SanitizeDefaultValue(statement, ty, false)
+	}
+	return ddl.DefaultValue{
+		IsPresent: true,
+		Value: ddl.Expression{
+			ExpressionId: internal.GenerateExpressionId(),
+			Statement:    statement,
+		},
+	}
+}
+
+// addSequenceColumn records that column colId of table tableId takes its
+// values from the sequence seqName, reading the sequence from the database
+// the first time it's used.
+func (isi InfoSchemaImpl) addSequenceColumn(conv *internal.Conv, seqSchema, seqName, tableId, colId string) error {
+	conv.ConvLock.Lock()
+	defer conv.ConvLock.Unlock()
+	for id, seq := range conv.SrcSequences {
+		if seq.Name == seqName {
+			seq.ColumnsUsingSeq[tableId] = append(seq.ColumnsUsingSeq[tableId], colId)
+			conv.SrcSequences[id] = seq
+			return nil
+		}
+	}
+	seq, err := isi.getSequence(seqSchema, seqName)
+	if err != nil {
+		return err
+	}
+	seq.ColumnsUsingSeq = map[string][]string{tableId: {colId}}
+	conv.SrcSequences[seq.Id] = seq
+	return nil
+}
+
+// getSequence reads the options of a sequence, which MariaDB returns as the
+// only row of the sequence. Options set to their MariaDB default are left
+// out, so that only options chosen by the user are reported as unsupported.
+func (isi InfoSchemaImpl) getSequence(seqSchema, seqName string) (ddl.Sequence, error) {
+	q := fmt.Sprintf("SELECT start_value, minimum_value, maximum_value, increment, cache_size, cycle_option FROM `%s`.`%s`;", seqSchema, seqName)
+	var start, min, max, increment, cacheSize string
+	var cycle bool
+	if err := isi.Db.QueryRow(q).Scan(&start, &min, &max, &increment, &cacheSize, &cycle); err != nil {
+		return ddl.Sequence{}, err
+	}
+	seq := ddl.Sequence{
+		Id:           internal.GenerateSequenceId(),
+		Name:         seqName,
+		SequenceKind: "BIT REVERSED SEQUENCE",
+		Increment:    increment,
+		Cycle:        cycle,
+	}
+	if start != "1" {
+		seq.StartWithCounter = start
+	}
+	if min != "1" {
+		seq.MinValue = min
+	}
+	if max != "9223372036854775806" {
+		seq.MaxValue = max
+	}
+	if cacheSize != "1000" {
+		seq.CacheSize = cacheSize
+	}
+	return seq, nil
+}
+
+// GetConstraints returns a list of primary keys and by-column map of
+// other constraints. The json_valid check constraints of JSON columns
+// aren't returned as check constraints: the columns are marked with
+// jsonValid instead, so that GetColumns maps them to JSON.
+// Note that foreign key constraints are handled in GetForeignKeys.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	rows, err := isi.Db.Query(isi.getConstraintsQuery(), table.Schema, table.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer rows.Close()
+	var primaryKeys []string
+	var checkKeys []schema.CheckConstraint
+	m := make(map[string][]string)
+	var col, constraintName, constraintType, checkClause string
+	for rows.Next() {
+		if err := rows.Scan(&col, &constraintName, &constraintType, &checkClause); err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		switch constraintType {
+		case "PRIMARY KEY":
+			primaryKeys = append(primaryKeys, col)
+		case "CHECK":
+			if match := jsonValidRegex.FindStringSubmatch(checkClause); match != nil {
+				m[match[1]] = append(m[match[1]], jsonValid)
+				continue
+			}
+			checkClause = collationRegex.ReplaceAllString(checkClause, "")
+			if !strings.HasPrefix(checkClause, "(") || !strings.HasSuffix(checkClause, ")") {
+				checkClause = "(" + checkClause + ")"
+			}
+			checkKeys = append(checkKeys, schema.CheckConstraint{Name: constraintName, Expr: checkClause, ExprId: internal.GenerateExpressionId(), Id: internal.GenerateCheckConstrainstId()})
+		default:
+			m[col] = append(m[col], constraintType)
+		}
+	}
+	return primaryKeys, checkKeys, m, nil
+}
+
+// getConstraintsQuery returns the query for the constraints of a table.
+// Unlike MySQL, MariaDB names check constraints per table, so they are
+// matched by table as well as by name. The ROW END column that MariaDB
+// adds to the keys of system-versioned tables is left out.
+func (isi InfoSchemaImpl) getConstraintsQuery() string {
+	checkClause, checkJoin := "''", ""
+	// The CHECK_CONSTRAINTS table was added in MariaDB 10.2.22.
+	if isi.Version.AtLeast(10, 2, 22) {
+		checkClause = "COALESCE(c.CHECK_CLAUSE, '')"
+		checkJoin = `LEFT JOIN INFORMATION_SCHEMA.CHECK_CONSTRAINTS AS c
+            ON t.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+            AND t.CONSTRAINT_SCHEMA = c.CONSTRAINT_SCHEMA
+            AND t.TABLE_NAME = c.TABLE_NAME`
+	}
+	return fmt.Sprintf(`SELECT COALESCE(k.COLUMN_NAME, '') AS COLUMN_NAME, t.CONSTRAINT_NAME, t.CONSTRAINT_TYPE, %s AS CHECK_CLAUSE
+            FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS t
+            LEFT JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
+            ON t.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+            AND t.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+            AND t.TABLE_NAME = k.TABLE_NAME
+            %s
+            LEFT JOIN INFORMATION_SCHEMA.COLUMNS AS col
+            ON col.TABLE_SCHEMA = t.TABLE_SCHEMA
+            AND col.TABLE_NAME = t.TABLE_NAME
+            AND col.COLUMN_NAME = k.COLUMN_NAME
+            WHERE t.TABLE_SCHEMA = ?
+            AND t.TABLE_NAME = ?
+            AND COALESCE(col.GENERATION_EXPRESSION, '') NOT IN ('ROW START', 'ROW END')
+            ORDER BY t.CONSTRAINT_NAME, k.ORDINAL_POSITION;`, checkClause, checkJoin)
+}
+
+// GetIndexes return a list of all indexes for the specified table. Index
+// keys on the ROW START and ROW END columns of system-versioned tables,
+// which aren't in colNameIdMap, are left out.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	srcIndexes, err := isi.InfoSchemaImpl.GetIndexes(conv, table, colNameIdMap)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []schema.Index
+	for _, index := range srcIndexes {
+		var keys []schema.Key
+		for _, key := range index.Keys {
+			if key.ColId != "" {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) > 0 {
+			index.Keys = keys
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes, nil
+}
+
+// StartChangeDataCapture is not supported for MariaDB.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for MariaDB")
+}
+
+// StartStreamingMigration is not supported for MariaDB.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for MariaDB")
+}
+
+func hasConstraint(constraints []string, constraint string) bool {
+	for _, c := range constraints {
+		if c == constraint {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mariadb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type mockSpec struct {
+	query string
+	args  []driver.Value   // Query args.
+	cols  []string         // Columns names for returned rows.
+	rows  [][]driver.Value // Set of rows returned.
+}
+
+var (
+	constraintCols = []string{"COLUMN_NAME", "CONSTRAINT_NAME", "CONSTRAINT_TYPE", "CHECK_CLAUSE"}
+	fkCols         = []string{"REFERENCED_TABLE_NAME", "COLUMN_NAME", "REFERENCED_COLUMN_NAME", "CONSTRAINT_NAME", "DELETE_RULE", "UPDATE_RULE"}
+	columnCols     = []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"}
+	indexCols      = []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"}
+)
+
+func TestProcessSchema(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT table_name FROM information_schema.tables WHERE table_type IN ('BASE TABLE', 'SYSTEM VERSIONED') AND table_schema = ?"),
+			args:  []driver.Value{"test"},
+			cols:  []string{"table_name"},
+			rows:  [][]driver.Value{{"orders"}, {"prices"}},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS (.+) LEFT JOIN INFORMATION_SCHEMA.CHECK_CONSTRAINTS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  constraintCols,
+			rows: [][]driver.Value{
+				{"", "details", "CHECK", "json_valid(`details`)"},
+				{"id", "PRIMARY", "PRIMARY KEY", ""},
+				{"", "qty_positive", "CHECK", "`qty` > 0"},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  fkCols,
+		},
+		{
+			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  columnCols,
+			rows: [][]driver.Value{
+				{"id", "bigint", "bigint(20)", "NO", "nextval(`test`.`order_seq`)", nil, 19, 0, ""},
+				{"customer", "uuid", "uuid", "NO", nil, nil, nil, nil, ""},
+				{"ip", "inet6", "inet6", "YES", "NULL", nil, nil, nil, ""},
+				{"details", "longtext", "longtext", "YES", "NULL", 4294967295, nil, nil, ""},
+				{"qty", "int", "int(11)", "NO", nil, nil, 10, 0, ""},
+			},
+		},
+		{
+			query: regexp.QuoteMeta("SELECT start_value, minimum_value, maximum_value, increment, cache_size, cycle_option FROM `test`.`order_seq`;"),
+			cols:  []string{"start_value", "minimum_value", "maximum_value", "increment", "cache_size", "cycle_option"},
+			rows:  [][]driver.Value{{"1000", "1", "9223372036854775806", "1", "1000", 0}},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  indexCols,
+			rows:  [][]driver.Value{{"by_customer", "customer", "1", "A", "1", "BTREE"}},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS (.+)",
+			args:  []driver.Value{"test", "prices"},
+			cols:  constraintCols,
+			rows:  [][]driver.Value{{"sku", "PRIMARY", "PRIMARY KEY", ""}},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS (.+)",
+			args:  []driver.Value{"test", "prices"},
+			cols:  fkCols,
+		},
+		{
+			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
+			args:  []driver.Value{"test", "prices"},
+			cols:  columnCols,
+			rows: [][]driver.Value{
+				{"sku", "varchar", "varchar(20)", "NO", nil, 20, nil, nil, ""},
+				{"price", "decimal", "decimal(10,2)", "YES", "'0.00'", nil, 10, 2, ""},
+			},
+		},
+		// The row_start and row_end columns of the system-versioned table
+		// are left out of the columns, and so out of the indexes.
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
+			args:  []driver.Value{"test", "prices"},
+			cols:  indexCols,
+			rows: [][]driver.Value{
+				{"by_price", "price", "1", "A", "1", "BTREE"},
+				{"by_price", "row_end", "2", "A", "1", "BTREE"},
+				{"by_row_start", "row_start", "1", "A", "1", "BTREE"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	isi := InfoSchemaImpl{InfoSchemaImpl: mysql.InfoSchemaImpl{DbName: "test", Db: db}, Version: Version{10, 11, 6}}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	// Defaults are kept as MariaDB reports them, string literals included,
+	// and literals are converted to Spanner defaults.
+	pricesTableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "prices")
+	assert.Nil(t, err)
+	priceColId, err := internal.GetColIdFromSpName(conv.SpSchema[pricesTableId].ColDefs, "price")
+	assert.Nil(t, err)
+	priceDefault := conv.SrcSchema[pricesTableId].ColDefs[priceColId].DefaultValue.Value
+	assert.Equal(t, "'0.00'", priceDefault.Statement)
+	expectedSchema := map[string]ddl.CreateTable{
+		"orders": {
+			Name:   "orders",
+			ColIds: []string{"id", "customer", "ip", "details", "qty"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":       {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true, AutoGen: ddl.AutoGenCol{Name: "order_seq", GenerationType: constants.SEQUENCE}},
+				"customer": {Name: "customer", T: ddl.Type{Name: ddl.UUID}, NotNull: true},
+				"ip":       {Name: "ip", T: ddl.Type{Name: ddl.String, Len: 45}},
+				"details":  {Name: "details", T: ddl.Type{Name: ddl.JSON}},
+				"qty":      {Name: "qty", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+			},
+			PrimaryKeys:      []ddl.IndexKey{{ColId: "id", Order: 1}},
+			Indexes:          []ddl.CreateIndex{{Name: "by_customer", TableId: "orders", Keys: []ddl.IndexKey{{ColId: "customer", Order: 1}}}},
+			CheckConstraints: []ddl.CheckConstraint{{Name: "qty_positive", Expr: "(`qty` > 0)"}},
+		},
+		"prices": {
+			Name:   "prices",
+			ColIds: []string{"sku", "price"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"sku":   {Name: "sku", T: ddl.Type{Name: ddl.String, Len: 20}, NotNull: true},
+				"price": {Name: "price", T: ddl.Type{Name: ddl.Numeric}, DefaultValue: ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: priceDefault.ExpressionId, Statement: "0.00"}}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "sku", Order: 1}},
+			Indexes:     []ddl.CreateIndex{{Name: "by_price", TableId: "prices", Keys: []ddl.IndexKey{{ColId: "price", Order: 1}}}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+
+	ordersTableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "orders")
+	assert.Nil(t, err)
+	idColId, err := internal.GetColIdFromSpName(conv.SpSchema[ordersTableId].ColDefs, "id")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(conv.SpSequences))
+	for _, seq := range conv.SpSequences {
+		assert.Equal(t, "order_seq", seq.Name)
+		assert.Equal(t, "1000", seq.StartWithCounter)
+		assert.Equal(t, map[string][]string{ordersTableId: {idColId}}, seq.ColumnsUsingSeq)
+	}
+	// Only options differing from the MariaDB defaults are carried over, so
+	// none are reported as unsupported.
+	assert.NotContains(t, conv.SchemaIssues[ordersTableId].ColumnLevelIssues[idColId], internal.SequenceOptionUnsupported)
+}
+
+func TestGetConstraints_BeforeCheckConstraints(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT COALESCE(.+), t.CONSTRAINT_NAME, t.CONSTRAINT_TYPE, '' AS CHECK_CLAUSE FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS t LEFT JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k (.+) LEFT JOIN INFORMATION_SCHEMA.COLUMNS AS col (.+)",
+			args:  []driver.Value{"test", "t"},
+			cols:  constraintCols,
+			rows: [][]driver.Value{
+				{"a", "PRIMARY", "PRIMARY KEY", ""},
+				{"b", "PRIMARY", "PRIMARY KEY", ""},
+				{"c", "c_key", "UNIQUE", ""},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{InfoSchemaImpl: mysql.InfoSchemaImpl{DbName: "test", Db: db}, Version: Version{10, 2, 12}}
+	primaryKeys, checkKeys, m, err := isi.GetConstraints(internal.MakeConv(), common.SchemaAndName{Schema: "test", Name: "t"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, primaryKeys)
+	assert.Empty(t, checkKeys)
+	assert.Equal(t, map[string][]string{"c": {"UNIQUE"}}, m)
+}
+
+func TestToDefaultValue(t *testing.T) {
+	testCases := []struct {
+		name       string
+		version    Version
+		colDefault sql.NullString
+		dataType   string
+		want       string
+		isPresent  bool
+	}{
+		{name: "no default", version: Version{10, 11, 6}, colDefault: sql.NullString{}, dataType: "varchar"},
+		{name: "NULL default", version: Version{10, 11, 6}, colDefault: sql.NullString{String: "NULL", Valid: true}, dataType: "varchar"},
+		{name: "string literal", version: Version{10, 11, 6}, colDefault: sql.NullString{String: "'it\\'s'", Valid: true}, dataType: "varchar", want: "'it\\'s'", isPresent: true},
+		{name: "string literal NULL", version: Version{10, 11, 6}, colDefault: sql.NullString{String: "'NULL'", Valid: true}, dataType: "varchar", want: "'NULL'", isPresent: true},
+		{name: "expression", version: Version{10, 11, 6}, colDefault: sql.NullString{String: "current_timestamp()", Valid: true}, dataType: "timestamp", want: "current_timestamp()", isPresent: true},
+		{name: "unquoted string literal before 10.2.7", version: Version{10, 1, 48}, colDefault: sql.NullString{String: "abc", Valid: true}, dataType: "varchar", want: "'abc'", isPresent: true},
+	}
+	for _, tc := range testCases {
+		isi := InfoSchemaImpl{Version: tc.version}
+		defaultValue := isi.toDefaultValue(internal.MakeConv(), tc.colDefault, tc.dataType)
+		assert.Equal(t, tc.isPresent, defaultValue.IsPresent, tc.name)
+		assert.Equal(t, tc.want, defaultValue.Value.Statement, tc.name)
+	}
+}
+
+func TestProcessData(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT `customer`,`ip`,`details` FROM `test`.`orders`;"),
+			cols:  []string{"customer", "ip", "details"},
+			rows: [][]driver.Value{
+				{"6ccd780c-baba-1026-9564-5b8c656024db", "2001:db8::ff00:42:8329", `{"gift": true}`},
+				{"not-a-uuid", "192.0.2.1", nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Name:   "orders",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "customer", Id: "c1", T: ddl.Type{Name: ddl.UUID}},
+			"c2": {Name: "ip", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 45}},
+			"c3": {Name: "details", Id: "c3", T: ddl.Type{Name: ddl.JSON}},
+		},
+	}
+	conv.SrcSchema["t1"] = schema.Table{
+		Name:   "orders",
+		Id:     "t1",
+		Schema: "test",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "customer", Id: "c1", Type: schema.Type{Name: "uuid"}},
+			"c2": {Name: "ip", Id: "c2", Type: schema.Type{Name: "inet6"}},
+			"c3": {Name: "details", Id: "c3", Type: schema.Type{Name: "json"}},
+		},
+		ColNameIdMap: map[string]string{"customer": "c1", "ip": "c2", "details": "c3"},
+	}
+	conv.SetDataMode()
+	var rows [][]interface{}
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, vals)
+		})
+	isi := InfoSchemaImpl{InfoSchemaImpl: mysql.InfoSchemaImpl{DbName: "test", Db: db}, Version: Version{10, 11, 6}}
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
+	assert.Equal(t, [][]interface{}{{"6ccd780c-baba-1026-9564-5b8c656024db", "2001:db8::ff00:42:8329", `{"gift": true}`}}, rows)
+	assert.Equal(t, int64(1), conv.BadRows())
+}
+
+func TestParseVersion(t *testing.T) {
+	v, err := parseVersion("10.11.6-MariaDB-1:10.11.6+maria~ubu2204")
+	assert.Nil(t, err)
+	assert.Equal(t, Version{10, 11, 6}, v)
+	v, err = parseVersion("5.5.5-10.4.32-MariaDB")
+	assert.Nil(t, err)
+	assert.Equal(t, Version{10, 4, 32}, v)
+	_, err = parseVersion("8.0.36")
+	assert.NotNil(t, err)
+	assert.True(t, Version{10, 11, 6}.AtLeast(10, 2, 22))
+	assert.True(t, Version{10, 2, 22}.AtLeast(10, 2, 22))
+	assert.False(t, Version{10, 2, 12}.AtLeast(10, 2, 22))
+	assert.False(t, Version{5, 5, 68}.AtLeast(10, 0, 0))
+}
+
+func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	for _, m := range ms {
+		rows := sqlmock.NewRows(m.cols)
+		for _, r := range m.rows {
+			rows.AddRow(r...)
+		}
+		if len(m.args) > 0 {
+			mock.ExpectQuery(m.query).WithArgs(m.args...).WillReturnRows(rows)
+		} else {
+			mock.ExpectQuery(m.query).WillReturnRows(rows)
+		}
+	}
+	return db
+}
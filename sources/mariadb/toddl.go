@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mariadb
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mysql"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Lengths of the text forms of INET4 and INET6 values. The longest INET6
+// values are IPv4-mapped addresses e.g. ::ffff:255.255.255.255.
+const (
+	inet4Length = 15
+	inet6Length = 45
+	uuidLength  = 36
+)
+
+// ToDdlImpl MariaDB specific implementation for ToDdl. Types shared with
+// MySQL are mapped as they are for MySQL.
+type ToDdlImpl struct {
+	mysql.ToDdlImpl
+}
+
+// ToSpannerType maps a scalar source schema type (defined by id and
+// mods) into a Spanner type. This is the core source-to-Spanner type
+// mapping.  toSpannerType returns the Spanner type and a list of type
+// conversion issues encountered.
+func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType schema.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
+	var ty ddl.Type
+	switch srcType.Name {
+	case "uuid":
+		if spType == ddl.String {
+			ty = ddl.Type{Name: ddl.String, Len: uuidLength}
+		} else {
+			ty = ddl.Type{Name: ddl.UUID}
+		}
+	case "inet4":
+		ty = ddl.Type{Name: ddl.String, Len: inet4Length}
+	case "inet6":
+		ty = ddl.Type{Name: ddl.String, Len: inet6Length}
+	default:
+		return tdi.ToDdlImpl.ToSpannerType(conv, spType, srcType, isPk)
+	}
+	var issues []internal.SchemaIssue
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		ty, issues = common.ToPGDialectType(ty, isPk)
+	}
+	return ty, issues
+}
+
+// GetColumnAutoGen maps auto increment columns and columns taking their
+// values from a sequence to columns using the corresponding Spanner
+// sequence.
+func (tdi ToDdlImpl) GetColumnAutoGen(conv *internal.Conv, autoGenCol ddl.AutoGenCol, colId string, tableId string) (*ddl.AutoGenCol, error) {
+	switch autoGenCol.GenerationType {
+	case constants.AUTO_INCREMENT, constants.SEQUENCE:
+		for seqId, seq := range conv.SrcSequences {
+			if seq.Name != autoGenCol.Name {
+				continue
+			}
+			spSequence := conv.SpSequences[seqId]
+			if spSequence.ColumnsUsingSeq == nil {
+				spSequence.ColumnsUsingSeq = make(map[string][]string)
+			}
+			spSequence.ColumnsUsingSeq[tableId] = append(spSequence.ColumnsUsingSeq[tableId], colId)
+			conv.SpSequences[seqId] = spSequence
+			return &ddl.AutoGenCol{Name: spSequence.Name, GenerationType: constants.SEQUENCE}, nil
+		}
+		return &ddl.AutoGenCol{}, fmt.Errorf("sequence corresponding to column auto generation not found")
+	default:
+		return &ddl.AutoGenCol{}, fmt.Errorf("auto generation not supported")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mariadb
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpannerType(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		srcType schema.Type
+		want    ddl.Type
+		issues  []internal.SchemaIssue
+	}{
+		{name: "uuid", srcType: schema.Type{Name: "uuid"}, want: ddl.Type{Name: ddl.UUID}},
+		{name: "uuid to string", spType: ddl.String, srcType: schema.Type{Name: "uuid"}, want: ddl.Type{Name: ddl.String, Len: 36}},
+		{name: "uuid pg", dialect: constants.DIALECT_POSTGRESQL, srcType: schema.Type{Name: "uuid"}, want: ddl.Type{Name: ddl.UUID}},
+		{name: "inet4", srcType: schema.Type{Name: "inet4"}, want: ddl.Type{Name: ddl.String, Len: 15}},
+		{name: "inet6", srcType: schema.Type{Name: "inet6"}, want: ddl.Type{Name: ddl.String, Len: 45}},
+		{name: "json", srcType: schema.Type{Name: "json"}, want: ddl.Type{Name: ddl.JSON}},
+		// Types shared with MySQL are mapped as they are for MySQL.
+		{name: "varchar", srcType: schema.Type{Name: "varchar", Mods: []int64{20}}, want: ddl.Type{Name: ddl.String, Len: 20}},
+		{name: "unknown", srcType: schema.Type{Name: "vector"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.NoGoodType}},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		ty, issues := ToDdlImpl{}.ToSpannerType(conv, tc.spType, tc.srcType, false)
+		assert.Equal(t, tc.want, ty, tc.name)
+		assert.Equal(t, tc.issues, issues, tc.name)
+	}
+}
+
+func TestGetColumnAutoGen(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSequences["s1"] = ddl.Sequence{Id: "s1", Name: "order_seq"}
+	conv.SpSequences["s1"] = ddl.Sequence{Id: "s1", Name: "order_seq"}
+	toDdl := ToDdlImpl{}
+	autoGen, err := toDdl.GetColumnAutoGen(conv, ddl.AutoGenCol{Name: "order_seq", GenerationType: constants.SEQUENCE}, "c1", "t1")
+	assert.Nil(t, err)
+	assert.Equal(t, &ddl.AutoGenCol{Name: "order_seq", GenerationType: constants.SEQUENCE}, autoGen)
+	// A sequence can be used by several columns.
+	_, err = toDdl.GetColumnAutoGen(conv, ddl.AutoGenCol{Name: "order_seq", GenerationType: constants.SEQUENCE}, "c1", "t2")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"t1": {"c1"}, "t2": {"c1"}}, conv.SpSequences["s1"].ColumnsUsingSeq)
+	_, err = toDdl.GetColumnAutoGen(conv, ddl.AutoGenCol{Name: "missing_seq", GenerationType: constants.SEQUENCE}, "c1", "t1")
+	assert.NotNil(t, err)
+}
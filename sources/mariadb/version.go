@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mariadb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Version is the version of a MariaDB server.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// AtLeast returns true if v is the given version or a later one.
+func (v Version) AtLeast(major, minor, patch int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Patch >= patch
+}
+
+// GetVersion returns the version of the server db is connected to. It
+// returns an error if the server isn't a MariaDB server, as MySQL servers
+// should be migrated as MySQL sources.
+func GetVersion(db *sql.DB) (Version, error) {
+	var version string
+	if err := db.QueryRow("SELECT VERSION();").Scan(&version); err != nil {
+		return Version{}, fmt.Errorf("couldn't get server version: %w", err)
+	}
+	return parseVersion(version)
+}
+
+// parseVersion parses a MariaDB version string e.g.
+// 10.11.6-MariaDB-1:10.11.6+maria~ubu2204, which may be prefixed with
+// 5.5.5- for compatibility with MySQL replication.
+func parseVersion(version string) (Version, error) {
+	if !strings.Contains(strings.ToLower(version), "mariadb") {
+		return Version{}, fmt.Errorf("server version %s is not a MariaDB version, use -source=mysql to migrate MySQL databases", version)
+	}
+	var v Version
+	if _, err := fmt.Sscanf(strings.TrimPrefix(version, "5.5.5-"), "%d.%d.%d", &v.Major, &v.Minor, &v.Patch); err != nil {
+		return Version{}, fmt.Errorf("couldn't parse server version %s: %w", version, err)
+	}
+	return v, nil
+}
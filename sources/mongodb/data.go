@@ -0,0 +1,212 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodb
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ProcessDataRow converts a document to a row and writes it out to Spanner.
+func ProcessDataRow(doc bson.D, conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable) {
+	spVals, badCols, srcStrVals := cvtRow(conv, doc, srcSchema, spSchema, colIds)
+	srcTableName := srcSchema.Name
+	spTableName := spSchema.Name
+	spColNames := []string{}
+	srcColNames := []string{}
+	for _, colId := range colIds {
+		srcColNames = append(srcColNames, srcSchema.ColDefs[colId].Name)
+		spColNames = append(spColNames, spSchema.ColDefs[colId].Name)
+	}
+	if len(badCols) == 0 {
+		conv.WriteRow(srcTableName, spTableName, spColNames, spVals)
+	} else {
+		conv.Unexpected(fmt.Sprintf("Data conversion error for table %s in column(s) %s\n", srcTableName, badCols))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcColNames, srcStrVals)
+	}
+}
+
+// cvtRow maps the fields of doc to the columns colIds. Fields mapped to a
+// column of their own are converted to the type of the column, and the
+// remaining fields are stored in the column of typeUnmapped, if any.
+func cvtRow(conv *internal.Conv, doc bson.D, srcSchema schema.Table, spSchema ddl.CreateTable, colIds []string) ([]interface{}, []string, []string) {
+	colPaths := make(map[string]bool)
+	flattened := make(map[string]bool)
+	for _, colId := range colIds {
+		srcColDef := srcSchema.ColDefs[colId]
+		if srcColDef.Type.Name == typeUnmapped {
+			continue
+		}
+		colPaths[srcColDef.Name] = true
+		parts := strings.Split(srcColDef.Name, ".")
+		for i := 1; i < len(parts); i++ {
+			flattened[strings.Join(parts[:i], ".")] = true
+		}
+	}
+	fields := make(map[string]interface{})
+	unmapped := splitDocument(doc, "", colPaths, flattened, fields)
+
+	var srcStrVals []string
+	var spVals []interface{}
+	var badCols []string
+	for _, colId := range colIds {
+		srcColDef := srcSchema.ColDefs[colId]
+		val, ok := fields[srcColDef.Name]
+		if srcColDef.Type.Name == typeUnmapped {
+			val, ok = unmapped, len(unmapped) > 0
+		}
+		if !ok || bsonType(val) == typeNull {
+			srcStrVals = append(srcStrVals, "null")
+			spVals = append(spVals, nil)
+			continue
+		}
+		spVal, err := convScalar(conv, val, spSchema.ColDefs[colId].T.Name)
+		if err != nil {
+			badCols = append(badCols, srcColDef.Name)
+		}
+		srcStrVal, err := toJSON(val)
+		if err != nil {
+			srcStrVal = fmt.Sprint(val)
+		}
+		srcStrVals = append(srcStrVals, srcStrVal)
+		spVals = append(spVals, spVal)
+	}
+	return spVals, badCols, srcStrVals
+}
+
+// splitDocument stores the values of the fields of doc that are mapped to a
+// column in fields, keyed by field path, recursing into the embedded
+// documents that were flattened. It returns the remaining fields, with the
+// same structure as in doc.
+func splitDocument(doc bson.D, prefix string, colPaths, flattened map[string]bool, fields map[string]interface{}) bson.D {
+	var unmapped bson.D
+	for _, e := range doc {
+		if strings.Contains(e.Key, ".") {
+			unmapped = append(unmapped, e)
+			continue
+		}
+		path := e.Key
+		if prefix != "" {
+			path = prefix + "." + e.Key
+		}
+		if colPaths[path] {
+			fields[path] = e.Value
+			continue
+		}
+		if sub, ok := e.Value.(bson.D); ok && flattened[path] {
+			if rest := splitDocument(sub, path, colPaths, flattened, fields); len(rest) > 0 {
+				unmapped = append(unmapped, bson.E{Key: e.Key, Value: rest})
+			}
+			continue
+		}
+		unmapped = append(unmapped, e)
+	}
+	return unmapped
+}
+
+// convScalar converts a value decoded from a BSON document to a value of
+// Spanner type spType.
+func convScalar(conv *internal.Conv, val interface{}, spType string) (interface{}, error) {
+	switch spType {
+	case ddl.Bool:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+	case ddl.Bytes:
+		if b, ok := val.(bson.Binary); ok {
+			return b.Data, nil
+		}
+	case ddl.Float64:
+		switch v := val.(type) {
+		case float64:
+			return v, nil
+		case int32:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		}
+	case ddl.Int64:
+		switch v := val.(type) {
+		case int32:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		}
+	case ddl.Numeric:
+		switch v := val.(type) {
+		case bson.Decimal128:
+			return convNumeric(conv, v.String())
+		case float64:
+			return convNumeric(conv, strconv.FormatFloat(v, 'g', -1, 64))
+		case int32, int64:
+			return convNumeric(conv, fmt.Sprint(v))
+		}
+	case ddl.Timestamp:
+		switch v := val.(type) {
+		case bson.DateTime:
+			return v.Time().UTC(), nil
+		case bson.Timestamp:
+			return time.Unix(int64(v.T), 0).UTC(), nil
+		}
+	case ddl.String:
+		switch v := val.(type) {
+		case string:
+			return v, nil
+		case bson.ObjectID:
+			return v.Hex(), nil
+		default:
+			return toJSON(v)
+		}
+	case ddl.JSON:
+		return toJSON(val)
+	}
+	return nil, fmt.Errorf("can't convert value %v of type %s to Spanner type %s", val, bsonType(val), spType)
+}
+
+// convNumeric maps a decimal string into a valid Spanner numeric.
+func convNumeric(conv *internal.Conv, val string) (interface{}, error) {
+	r, ok := new(big.Rat).SetString(val)
+	if !ok {
+		return nil, fmt.Errorf("can't convert %q to big.Rat", val)
+	}
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return spanner.PGNumeric{Numeric: val, Valid: true}, nil
+	}
+	return r, nil
+}
+
+// toJSON encodes val in relaxed MongoDB Extended JSON, which represents
+// BSON types that have no JSON equivalent, like ObjectIds and dates, as
+// objects e.g. {"$oid": "..."}.
+func toJSON(val interface{}) (string, error) {
+	// Extended JSON only encodes documents, so val is encoded as a field.
+	b, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: val}}, false, false)
+	if err != nil {
+		return "", fmt.Errorf("can't convert %v to JSON: %v", val, err)
+	}
+	return string(b[len(`{"v":`) : len(b)-1]), nil
+}
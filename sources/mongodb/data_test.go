@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodb
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+func TestConvScalar(t *testing.T) {
+	decimal, err := bson.ParseDecimal128("12.345")
+	assert.Nil(t, err)
+	tests := []struct {
+		name   string
+		in     interface{}
+		spType string
+		e      interface{} // Expected result.
+	}{
+		{"bool", true, ddl.Bool, true},
+		{"bytes", bson.Binary{Data: []byte{1, 2}}, ddl.Bytes, []byte{1, 2}},
+		{"float64", 4.5, ddl.Float64, 4.5},
+		{"int to float64", int32(4), ddl.Float64, float64(4)},
+		{"int", int32(42), ddl.Int64, int64(42)},
+		{"long", int64(42), ddl.Int64, int64(42)},
+		{"decimal", decimal, ddl.Numeric, big.NewRat(12345, 1000)},
+		{"double to numeric", 0.5, ddl.Numeric, big.NewRat(1, 2)},
+		{"date", bson.NewDateTimeFromTime(time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)), ddl.Timestamp, time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)},
+		{"timestamp", bson.Timestamp{T: 1700000000, I: 1}, ddl.Timestamp, time.Unix(1700000000, 0).UTC()},
+		{"string", "eh", ddl.String, "eh"},
+		{"objectId", oid1, ddl.String, oid1.Hex()},
+		{"document to string", bson.D{{Key: "a", Value: int32(1)}}, ddl.String, `{"a":1}`},
+		{"document", bson.D{{Key: "a", Value: bson.A{int64(1), "b"}}}, ddl.JSON, `{"a":[1,"b"]}`},
+		{"string to json", "eh", ddl.JSON, `"eh"`},
+		{"objectId to json", oid1, ddl.JSON, `{"$oid":"` + oid1.Hex() + `"}`},
+	}
+	conv := internal.MakeConv()
+	for _, tc := range tests {
+		v, err := convScalar(conv, tc.in, tc.spType)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.e, v, tc.name)
+	}
+
+	errorTests := []struct {
+		name   string
+		in     interface{}
+		spType string
+	}{
+		{"string to bool", "true", ddl.Bool},
+		{"double to int64", 4.5, ddl.Int64},
+		{"string to float64", "4.5", ddl.Float64},
+		{"string to timestamp", "2024-01-02", ddl.Timestamp},
+		{"string to bytes", "ab", ddl.Bytes},
+	}
+	for _, tc := range errorTests {
+		_, err := convScalar(conv, tc.in, tc.spType)
+		assert.NotNil(t, err, tc.name)
+	}
+
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	v, err := convScalar(conv, decimal, ddl.Numeric)
+	assert.Nil(t, err)
+	assert.Equal(t, spanner.PGNumeric{Numeric: "12.345", Valid: true}, v)
+}
+
+func TestSplitDocument(t *testing.T) {
+	doc := bson.D{
+		{Key: "_id", Value: int32(1)},
+		{Key: "a", Value: bson.D{{Key: "b", Value: int32(2)}, {Key: "c", Value: bson.D{{Key: "d", Value: int32(3)}, {Key: "e", Value: int32(4)}}}}},
+		{Key: "f.g", Value: int32(5)},
+		{Key: "h", Value: "not a document"},
+	}
+	fields := make(map[string]interface{})
+	colPaths := map[string]bool{"_id": true, "a.b": true, "a.c.d": true, "h.i": true}
+	flattened := map[string]bool{"a": true, "a.c": true, "h": true}
+	unmapped := splitDocument(doc, "", colPaths, flattened, fields)
+	assert.Equal(t, map[string]interface{}{"_id": int32(1), "a.b": int32(2), "a.c.d": int32(3)}, fields)
+	assert.Equal(t, bson.D{
+		{Key: "a", Value: bson.D{{Key: "c", Value: bson.D{{Key: "e", Value: int32(4)}}}}},
+		{Key: "f.g", Value: int32(5)},
+		{Key: "h", Value: "not a document"},
+	}, unmapped)
+}
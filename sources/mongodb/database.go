@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodb
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Cursor iterates over the documents returned by a query. It is implemented
+// by *mongo.Cursor.
+type Cursor interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	Err() error
+	Close(ctx context.Context) error
+}
+
+// IndexSpec describes an index of a collection, as returned by the
+// listIndexes command.
+type IndexSpec struct {
+	Name                    string `bson:"name"`
+	Key                     bson.D `bson:"key"`
+	Unique                  bool   `bson:"unique"`
+	Sparse                  bool   `bson:"sparse"`
+	PartialFilterExpression bson.D `bson:"partialFilterExpression"`
+	Weights                 bson.D `bson:"weights"` // Fields of text indexes.
+}
+
+// DatabaseAPI is the subset of the MongoDB database API used for schema and
+// data migrations.
+type DatabaseAPI interface {
+	// ListCollectionNames returns the names of the collections of the
+	// database, excluding views and system collections.
+	ListCollectionNames(ctx context.Context) ([]string, error)
+	// Find returns a cursor over the documents of a collection, limited to
+	// limit documents if limit is positive.
+	Find(ctx context.Context, collection string, limit int64) (Cursor, error)
+	EstimatedDocumentCount(ctx context.Context, collection string) (int64, error)
+	ListIndexes(ctx context.Context, collection string) ([]IndexSpec, error)
+}
+
+type databaseImpl struct {
+	db *mongo.Database
+}
+
+// NewDatabase connects to the MongoDB deployment given by uri and returns
+// the API of database dbName. tlsConfig, if not nil, overrides the TLS
+// options of uri.
+func NewDatabase(uri string, dbName string, tlsConfig *tls.Config) (DatabaseAPI, error) {
+	opts := options.Client().ApplyURI(uri)
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+	client, err := mongo.Connect(opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to MongoDB: %v", err)
+	}
+	return databaseImpl{db: client.Database(dbName)}, nil
+}
+
+func (d databaseImpl) ListCollectionNames(ctx context.Context) ([]string, error) {
+	names, err := d.db.ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+	if err != nil {
+		return nil, err
+	}
+	var collections []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, "system.") {
+			collections = append(collections, name)
+		}
+	}
+	return collections, nil
+}
+
+func (d databaseImpl) Find(ctx context.Context, collection string, limit int64) (Cursor, error) {
+	opts := options.Find()
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	return d.db.Collection(collection).Find(ctx, bson.D{}, opts)
+}
+
+func (d databaseImpl) EstimatedDocumentCount(ctx context.Context, collection string) (int64, error) {
+	return d.db.Collection(collection).EstimatedDocumentCount(ctx)
+}
+
+func (d databaseImpl) ListIndexes(ctx context.Context, collection string) ([]IndexSpec, error) {
+	cursor, err := d.db.Collection(collection).Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []IndexSpec
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
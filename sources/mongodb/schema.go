@@ -0,0 +1,466 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	sp "cloud.google.com/go/spanner"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Source types are named after the BSON type aliases used by MongoDB, see
+// https://www.mongodb.com/docs/manual/reference/bson-types/.
+const (
+	typeObjectId  = "objectId"
+	typeString    = "string"
+	typeInt       = "int"
+	typeLong      = "long"
+	typeDouble    = "double"
+	typeDecimal   = "decimal"
+	typeBool      = "bool"
+	typeDate      = "date"
+	typeTimestamp = "timestamp"
+	typeBinData   = "binData"
+	typeObject    = "object"
+	typeArray     = "array"
+	typeNull      = "null"
+	typeOther     = "other"
+	// typeMixed is the type of fields holding values of incompatible types.
+	typeMixed = "mixed"
+	// typeUnmapped is the type of the column holding the fields of a
+	// document that aren't mapped to a column of their own.
+	typeUnmapped = "unmapped"
+
+	objectIdLength = 24 // Length of the hex representation of ObjectIds.
+	idField        = "_id"
+)
+
+// Collections are mapped to tables using one of the strategies below.
+const (
+	// FlattenStrategy maps each field to a column, flattening embedded
+	// documents into one column per field named after the field path e.g.
+	// address.city. Fields that aren't seen in the sampled documents are
+	// stored in a JSON column.
+	FlattenStrategy = "flatten"
+	// JSONStrategy maps the _id field to a column and stores the rest of
+	// each document in a JSON column.
+	JSONStrategy = "json"
+)
+
+// Names of the JSON columns of the flatten and json strategies. Fields
+// having these names are stored in the JSON columns.
+const (
+	unmappedFieldsColumn = "unmapped_fields"
+	documentColumn       = "document"
+)
+
+// InfoSchemaImpl is MongoDB specific implementation for InfoSchema.
+type InfoSchemaImpl struct {
+	Database   DatabaseAPI
+	DbName     string
+	SampleSize int64 // Number of documents sampled per collection to infer its schema.
+	// Strategy is the default strategy used to map collections to tables,
+	// and CollectionStrategies overrides it for specific collections.
+	Strategy             string
+	CollectionStrategies map[string]string
+}
+
+// ValidateStrategies checks that the strategies e.g. from the source profile
+// are known ones.
+func ValidateStrategies(strategy string, collectionStrategies map[string]string) error {
+	if strategy != "" && strategy != FlattenStrategy && strategy != JSONStrategy {
+		return fmt.Errorf("invalid strategy %s, expected one of %s, %s", strategy, FlattenStrategy, JSONStrategy)
+	}
+	var collections []string
+	for collection := range collectionStrategies {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+	for _, collection := range collections {
+		if s := collectionStrategies[collection]; s != FlattenStrategy && s != JSONStrategy {
+			return fmt.Errorf("invalid strategy %s for collection %s, expected one of %s, %s", s, collection, FlattenStrategy, JSONStrategy)
+		}
+	}
+	return nil
+}
+
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	return tableName
+}
+
+// GetTables returns the collections of the database.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	names, err := isi.Database.ListCollectionNames(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list collections: %v", err)
+	}
+	sort.Strings(names)
+	var tables []common.SchemaAndName
+	for _, name := range names {
+		tables = append(tables, common.SchemaAndName{Schema: isi.DbName, Name: name})
+	}
+	return tables, nil
+}
+
+// GetColumns infers the columns of a collection from a sample of its
+// documents, using the strategy of the collection.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	stats, err := isi.sampleDocuments(table.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	strategy := isi.getStrategy(table.Name)
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	addColumn := func(name, ty string, notNull bool) {
+		colId := internal.GenerateColumnId()
+		colIds = append(colIds, colId)
+		colDefs[colId] = schema.Column{Id: colId, Name: name, Type: schema.Type{Name: ty}, NotNull: notNull}
+	}
+	idType := typeObjectId
+	if idStats, ok := stats.fields[idField]; ok {
+		idType = idStats.inferType()
+	}
+	addColumn(idField, idType, true)
+	if strategy == JSONStrategy {
+		addColumn(documentColumn, typeUnmapped, false)
+		return colDefs, colIds, nil
+	}
+	for _, field := range stats.flatten("") {
+		if field.path != idField && field.path != unmappedFieldsColumn {
+			addColumn(field.path, field.ty, false)
+		}
+	}
+	addColumn(unmappedFieldsColumn, typeUnmapped, false)
+	return colDefs, colIds, nil
+}
+
+// GetRowsFromTable returns a cursor over the documents of a collection.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
+	return isi.Database.Find(context.Background(), conv.SrcSchema[srcTable].Name, 0)
+}
+
+// GetRowCount returns the estimated number of documents of a collection.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	return isi.Database.EstimatedDocumentCount(context.Background(), table.Name)
+}
+
+// GetConstraints returns the primary key of a collection, which is always
+// the _id field.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) (primaryKeys []string, checkConstraints []schema.CheckConstraint, constraints map[string][]string, err error) {
+	return []string{idField}, checkConstraints, constraints, nil
+}
+
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	return foreignKeys, err
+}
+
+// GetIndexes returns the indexes of a collection on fields mapped to
+// columns. Sparse indexes are mapped to NULL_FILTERED indexes and text
+// indexes to search indexes. Indexes on other fields, geospatial indexes and
+// sparse or partial unique indexes are skipped.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	specs, err := isi.Database.ListIndexes(context.Background(), table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list indexes of collection %s: %v", table.Name, err)
+	}
+	var indexes []schema.Index
+	for _, spec := range specs {
+		if spec.Name == "_id_" {
+			// Index of the primary key.
+			continue
+		}
+		index, err := toSchemaIndex(spec, colNameIdMap)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Skipping index %s of collection %s: %s", spec.Name, table.Name, err))
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+func toSchemaIndex(spec IndexSpec, colNameIdMap map[string]string) (schema.Index, error) {
+	index := schema.Index{Id: internal.GenerateIndexesId(), Name: spec.Name, Unique: spec.Unique, NullFiltered: spec.Sparse}
+	if spec.Unique && (spec.Sparse || spec.PartialFilterExpression != nil) {
+		return index, fmt.Errorf("sparse and partial unique indexes are not supported")
+	}
+	addKey := func(field string, desc bool) error {
+		colId, ok := colNameIdMap[field]
+		if !ok {
+			return fmt.Errorf("field %s is not mapped to a column", field)
+		}
+		index.Keys = append(index.Keys, schema.Key{ColId: colId, Desc: desc, Order: len(index.Keys) + 1})
+		return nil
+	}
+	for _, e := range spec.Key {
+		if e.Key == "_fts" || e.Key == "_ftsx" {
+			// Text index fields are given by the weights of the index.
+			index.FullText = true
+			continue
+		}
+		switch v := e.Value.(type) {
+		case int32:
+			if err := addKey(e.Key, v < 0); err != nil {
+				return index, err
+			}
+		case int64:
+			if err := addKey(e.Key, v < 0); err != nil {
+				return index, err
+			}
+		case float64:
+			if err := addKey(e.Key, v < 0); err != nil {
+				return index, err
+			}
+		case string:
+			if v != "hashed" {
+				return index, fmt.Errorf("%s indexes are not supported", v)
+			}
+			// Hashed indexes support equality matches, which Spanner
+			// indexes do too.
+			if err := addKey(e.Key, false); err != nil {
+				return index, err
+			}
+		default:
+			return index, fmt.Errorf("unexpected key %v for field %s", e.Value, e.Key)
+		}
+	}
+	if index.FullText {
+		if len(index.Keys) > 0 {
+			return index, fmt.Errorf("compound text indexes are not supported")
+		}
+		for _, e := range spec.Weights {
+			if err := addKey(e.Key, false); err != nil {
+				return index, err
+			}
+		}
+	}
+	return index, nil
+}
+
+// ProcessData performs data conversion for MongoDB database. For each
+// collection, we read all documents, convert them to Spanner data (based on
+// the source and Spanner schemas), and write them to Spanner. If we can't
+// get/process data for a collection, we skip it and process the remaining
+// collections.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	ctx := context.Background()
+	rows, err := isi.GetRowsFromTable(conv, tableId)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for collection %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	cursor := rows.(Cursor)
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var doc bson.D
+		if err := cursor.Decode(&doc); err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't decode document of collection %s: %s", srcSchema.Name, err))
+			conv.StatsAddBadRow(srcSchema.Name, conv.DataMode())
+			continue
+		}
+		ProcessDataRow(doc, conv, tableId, srcSchema, colIds, spSchema)
+	}
+	return cursor.Err()
+}
+
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, nil
+}
+
+func (isi InfoSchemaImpl) getStrategy(collection string) string {
+	if strategy, ok := isi.CollectionStrategies[collection]; ok {
+		return strategy
+	}
+	if isi.Strategy != "" {
+		return isi.Strategy
+	}
+	return FlattenStrategy
+}
+
+// fieldStats records the types of the values of a field in the sampled
+// documents, and for embedded documents, the stats of their own fields.
+type fieldStats struct {
+	types  map[string]int64
+	fields map[string]*fieldStats
+	order  []string // Field names in the order they were first seen.
+}
+
+func newFieldStats() *fieldStats {
+	return &fieldStats{types: make(map[string]int64), fields: make(map[string]*fieldStats)}
+}
+
+func (s *fieldStats) add(doc bson.D) {
+	for _, e := range doc {
+		f, ok := s.fields[e.Key]
+		if !ok {
+			f = newFieldStats()
+			s.fields[e.Key] = f
+			s.order = append(s.order, e.Key)
+		}
+		ty := bsonType(e.Value)
+		f.types[ty]++
+		if ty == typeObject {
+			f.add(e.Value.(bson.D))
+		}
+	}
+}
+
+// inferType returns the type of a field given the types of its values.
+// Numeric types are widened to the largest type seen, and fields with
+// values of other incompatible types are of typeMixed.
+func (s *fieldStats) inferType() string {
+	var types []string
+	for ty := range s.types {
+		if ty != typeNull {
+			types = append(types, ty)
+		}
+	}
+	switch {
+	case len(types) == 0:
+		return typeNull
+	case len(types) == 1:
+		return types[0]
+	}
+	// Numeric types ordered by increasing range.
+	numericTypes := []string{typeInt, typeLong, typeDouble, typeDecimal}
+	widest := -1
+	for _, ty := range types {
+		i := indexOf(numericTypes, ty)
+		if i < 0 {
+			return typeMixed
+		}
+		if i > widest {
+			widest = i
+		}
+	}
+	return numericTypes[widest]
+}
+
+type flatField struct {
+	path string
+	ty   string
+}
+
+// flatten returns the fields of the sampled documents, recursing into
+// fields that only hold embedded documents. Fields whose names contain a
+// dot, which would be ambiguous with flattened paths, and fields that are
+// always null are left out.
+func (s *fieldStats) flatten(prefix string) []flatField {
+	var fields []flatField
+	for _, name := range s.order {
+		if strings.Contains(name, ".") {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		f := s.fields[name]
+		switch ty := f.inferType(); ty {
+		case typeNull:
+		case typeObject:
+			if path == idField {
+				fields = append(fields, flatField{path: path, ty: ty})
+			} else {
+				fields = append(fields, f.flatten(path)...)
+			}
+		default:
+			fields = append(fields, flatField{path: path, ty: ty})
+		}
+	}
+	return fields
+}
+
+func (isi InfoSchemaImpl) sampleDocuments(collection string) (*fieldStats, error) {
+	ctx := context.Background()
+	cursor, err := isi.Database.Find(ctx, collection, isi.SampleSize)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't sample collection %s: %v", collection, err)
+	}
+	defer cursor.Close(ctx)
+	stats := newFieldStats()
+	for cursor.Next(ctx) {
+		var doc bson.D
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("couldn't decode document of collection %s: %v", collection, err)
+		}
+		stats.add(doc)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't sample collection %s: %v", collection, err)
+	}
+	return stats, nil
+}
+
+// bsonType returns the type of a value decoded from a BSON document.
+func bsonType(v interface{}) string {
+	switch v.(type) {
+	case bson.ObjectID:
+		return typeObjectId
+	case string:
+		return typeString
+	case int32:
+		return typeInt
+	case int64:
+		return typeLong
+	case float64:
+		return typeDouble
+	case bson.Decimal128:
+		return typeDecimal
+	case bool:
+		return typeBool
+	case bson.DateTime:
+		return typeDate
+	case bson.Timestamp:
+		return typeTimestamp
+	case bson.Binary:
+		return typeBinData
+	case bson.D:
+		return typeObject
+	case bson.A:
+		return typeArray
+	case nil, bson.Null, bson.Undefined:
+		return typeNull
+	default:
+		return typeOther
+	}
+}
+
+func indexOf(l []string, s string) int {
+	for i, x := range l {
+		if x == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,349 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type fakeCursor struct {
+	docs []bson.D
+	pos  int
+}
+
+func (c *fakeCursor) Next(ctx context.Context) bool {
+	c.pos++
+	return c.pos <= len(c.docs)
+}
+
+func (c *fakeCursor) Decode(val interface{}) error {
+	*val.(*bson.D) = c.docs[c.pos-1]
+	return nil
+}
+
+func (c *fakeCursor) Err() error {
+	return nil
+}
+
+func (c *fakeCursor) Close(ctx context.Context) error {
+	return nil
+}
+
+type fakeDatabase struct {
+	docs    map[string][]bson.D
+	indexes map[string][]IndexSpec
+	limits  map[string]int64 // Limit of the last Find call, by collection.
+}
+
+func (d *fakeDatabase) ListCollectionNames(ctx context.Context) ([]string, error) {
+	var names []string
+	for name := range d.docs {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (d *fakeDatabase) Find(ctx context.Context, collection string, limit int64) (Cursor, error) {
+	docs, ok := d.docs[collection]
+	if !ok {
+		return nil, fmt.Errorf("collection %s not found", collection)
+	}
+	if d.limits == nil {
+		d.limits = make(map[string]int64)
+	}
+	d.limits[collection] = limit
+	if limit > 0 && limit < int64(len(docs)) {
+		docs = docs[:limit]
+	}
+	return &fakeCursor{docs: docs}, nil
+}
+
+func (d *fakeDatabase) EstimatedDocumentCount(ctx context.Context, collection string) (int64, error) {
+	return int64(len(d.docs[collection])), nil
+}
+
+func (d *fakeDatabase) ListIndexes(ctx context.Context, collection string) ([]IndexSpec, error) {
+	return d.indexes[collection], nil
+}
+
+var (
+	oid1 = bson.ObjectID{0x65, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0x1}
+	oid2 = bson.ObjectID{0x65, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0x2}
+	oid3 = bson.ObjectID{0x65, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0x3}
+)
+
+func usersDocs() []bson.D {
+	return []bson.D{
+		{
+			{Key: "_id", Value: oid1},
+			{Key: "name", Value: "Ann"},
+			{Key: "age", Value: int32(30)},
+			{Key: "address", Value: bson.D{{Key: "city", Value: "Paris"}, {Key: "zip", Value: "75001"}}},
+			{Key: "tags", Value: bson.A{"a", "b"}},
+			{Key: "joined", Value: bson.NewDateTimeFromTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))},
+		},
+		{
+			{Key: "_id", Value: oid2},
+			{Key: "name", Value: "Bob"},
+			{Key: "age", Value: int64(41)},
+			{Key: "address", Value: bson.D{{Key: "city", Value: "Rome"}}},
+			{Key: "score", Value: 1.5},
+			{Key: "misc", Value: "x"},
+		},
+		{
+			{Key: "_id", Value: oid3},
+			{Key: "name", Value: "Cy"},
+			{Key: "age", Value: nil},
+			{Key: "address", Value: nil},
+			{Key: "misc", Value: int32(3)},
+			{Key: "unmapped_fields", Value: true},
+		},
+	}
+}
+
+func TestProcessSchema(t *testing.T) {
+	db := &fakeDatabase{
+		docs: map[string][]bson.D{
+			"users": usersDocs(),
+			"events": {
+				{{Key: "_id", Value: "e1"}, {Key: "kind", Value: "click"}},
+			},
+		},
+		indexes: map[string][]IndexSpec{
+			"users": {
+				{Name: "_id_", Key: bson.D{{Key: "_id", Value: int32(1)}}},
+				{Name: "name_1", Key: bson.D{{Key: "name", Value: int32(1)}}, Unique: true},
+				{Name: "by_age_city", Key: bson.D{{Key: "age", Value: int32(-1)}, {Key: "address.city", Value: int32(1)}}, Sparse: true},
+				{Name: "loc_2dsphere", Key: bson.D{{Key: "loc", Value: "2dsphere"}}},
+			},
+			"events": {
+				{Name: "kind_1", Key: bson.D{{Key: "kind", Value: int32(1)}}},
+			},
+		},
+	}
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	isi := InfoSchemaImpl{Database: db, DbName: "shop", SampleSize: 10, CollectionStrategies: map[string]string{"events": JSONStrategy}}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), db.limits["users"])
+	expectedSchema := map[string]ddl.CreateTable{
+		"events": {
+			Name:   "events",
+			ColIds: []string{"Aid", "document"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"Aid":      {Name: "Aid", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+				"document": {Name: "document", T: ddl.Type{Name: ddl.JSON}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "Aid", Order: 1}},
+		},
+		"users": {
+			Name:   "users",
+			ColIds: []string{"Aid", "name", "age", "address_city", "address_zip", "tags", "joined", "score", "misc", "unmapped_fields"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"Aid":             {Name: "Aid", T: ddl.Type{Name: ddl.String, Len: objectIdLength}, NotNull: true},
+				"name":            {Name: "name", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"age":             {Name: "age", T: ddl.Type{Name: ddl.Int64}},
+				"address_city":    {Name: "address_city", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"address_zip":     {Name: "address_zip", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"tags":            {Name: "tags", T: ddl.Type{Name: ddl.JSON}},
+				"joined":          {Name: "joined", T: ddl.Type{Name: ddl.Timestamp}},
+				"score":           {Name: "score", T: ddl.Type{Name: ddl.Float64}},
+				"misc":            {Name: "misc", T: ddl.Type{Name: ddl.JSON}},
+				"unmapped_fields": {Name: "unmapped_fields", T: ddl.Type{Name: ddl.JSON}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "Aid", Order: 1}},
+			Indexes: []ddl.CreateIndex{
+				{Name: "name_1", TableId: "users", Unique: true, Keys: []ddl.IndexKey{{ColId: "name", Order: 1}}},
+				{Name: "by_age_city", TableId: "users", NullFiltered: true, Keys: []ddl.IndexKey{{ColId: "age", Desc: true, Order: 1}, {ColId: "address_city", Order: 2}}},
+			},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, stripSchemaComments(conv.SpSchema))
+	usersTableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "users")
+	assert.Nil(t, err)
+	var spColNames []string
+	for _, colId := range conv.SpSchema[usersTableId].ColIds {
+		spColNames = append(spColNames, conv.SpSchema[usersTableId].ColDefs[colId].Name)
+	}
+	assert.Equal(t, expectedSchema["users"].ColIds, spColNames)
+	// The geospatial index of users and the index of events on a field
+	// stored in the document column are skipped.
+	assert.Equal(t, int64(2), conv.Unexpecteds())
+}
+
+func TestProcessData(t *testing.T) {
+	db := &fakeDatabase{docs: map[string][]bson.D{"users": usersDocs()}}
+	colNames := []string{"_id", "name", "age", "address.city", "score", "unmapped_fields"}
+	srcTypes := []string{typeObjectId, typeString, typeLong, typeString, typeDouble, typeUnmapped}
+	spTypes := []ddl.Type{
+		{Name: ddl.String, Len: objectIdLength},
+		{Name: ddl.String, Len: ddl.MaxLength},
+		{Name: ddl.Int64},
+		{Name: ddl.String, Len: ddl.MaxLength},
+		{Name: ddl.Numeric},
+		{Name: ddl.JSON},
+	}
+	srcTable := schema.Table{Name: "users", Schema: "shop", Id: "t1", ColDefs: map[string]schema.Column{}}
+	spTable := ddl.CreateTable{Name: "users", Id: "t1", ColDefs: map[string]ddl.ColumnDef{}}
+	for i, name := range colNames {
+		colId := fmt.Sprintf("c%d", i+1)
+		srcTable.ColIds = append(srcTable.ColIds, colId)
+		srcTable.ColDefs[colId] = schema.Column{Name: name, Id: colId, Type: schema.Type{Name: srcTypes[i]}}
+		spTable.ColIds = append(spTable.ColIds, colId)
+		spTable.ColDefs[colId] = ddl.ColumnDef{Name: internal.GetSpannerValidName(internal.MakeConv(), name), Id: colId, T: spTypes[i]}
+	}
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = srcTable
+	conv.SpSchema["t1"] = spTable
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	isi := InfoSchemaImpl{Database: db}
+	err := isi.ProcessData(conv, "t1", srcTable, spTable.ColIds, spTable, internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	cols := []string{"Aid", "name", "age", "address_city", "score", "unmapped_fields"}
+	assert.Equal(t, []spannerData{
+		{
+			table: "users", cols: cols,
+			vals: []interface{}{oid1.Hex(), "Ann", int64(30), "Paris", nil,
+				`{"address":{"zip":"75001"},"tags":["a","b"],"joined":{"$date":"2024-01-02T03:04:05Z"}}`},
+		},
+		{
+			table: "users", cols: cols,
+			vals: []interface{}{oid2.Hex(), "Bob", int64(41), "Rome", big.NewRat(3, 2), `{"misc":"x"}`},
+		},
+		{
+			table: "users", cols: cols,
+			vals: []interface{}{oid3.Hex(), "Cy", nil, nil, nil, `{"address":null,"misc":3,"unmapped_fields":true}`},
+		},
+	}, rows)
+}
+
+func TestProcessDataBadRow(t *testing.T) {
+	db := &fakeDatabase{docs: map[string][]bson.D{"users": {
+		{{Key: "_id", Value: oid1}, {Key: "age", Value: "forty"}},
+	}}}
+	srcTable := schema.Table{Name: "users", Id: "t1", ColIds: []string{"c1", "c2"}, ColDefs: map[string]schema.Column{
+		"c1": {Name: "_id", Id: "c1", Type: schema.Type{Name: typeObjectId}},
+		"c2": {Name: "age", Id: "c2", Type: schema.Type{Name: typeLong}},
+	}}
+	spTable := ddl.CreateTable{Name: "users", Id: "t1", ColIds: []string{"c1", "c2"}, ColDefs: map[string]ddl.ColumnDef{
+		"c1": {Name: "Aid", Id: "c1", T: ddl.Type{Name: ddl.String, Len: objectIdLength}},
+		"c2": {Name: "age", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+	}}
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = srcTable
+	conv.SpSchema["t1"] = spTable
+	conv.SetDataMode()
+	conv.SetDataSink(func(table string, cols []string, vals []interface{}) {
+		t.Errorf("unexpected row %v", vals)
+	})
+	isi := InfoSchemaImpl{Database: db}
+	assert.Nil(t, isi.ProcessData(conv, "t1", srcTable, spTable.ColIds, spTable, internal.AdditionalDataAttributes{}))
+	assert.Equal(t, int64(1), conv.BadRows())
+}
+
+func TestInferType(t *testing.T) {
+	tests := []struct {
+		name     string
+		types    []string
+		expected string
+	}{
+		{"single type", []string{typeString}, typeString},
+		{"nulls are ignored", []string{typeNull, typeBool}, typeBool},
+		{"only nulls", []string{typeNull}, typeNull},
+		{"int and long", []string{typeInt, typeLong}, typeLong},
+		{"integers and doubles", []string{typeInt, typeDouble}, typeDouble},
+		{"numbers and decimals", []string{typeLong, typeDecimal, typeDouble}, typeDecimal},
+		{"incompatible types", []string{typeString, typeInt}, typeMixed},
+		{"documents and scalars", []string{typeObject, typeString}, typeMixed},
+	}
+	for _, tc := range tests {
+		s := newFieldStats()
+		for _, ty := range tc.types {
+			s.types[ty]++
+		}
+		assert.Equal(t, tc.expected, s.inferType(), tc.name)
+	}
+}
+
+func TestToSchemaIndex(t *testing.T) {
+	colNameIdMap := map[string]string{"_id": "c1", "name": "c2", "bio": "c3"}
+	index, err := toSchemaIndex(IndexSpec{
+		Name:    "bio_text",
+		Key:     bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}},
+		Weights: bson.D{{Key: "bio", Value: int32(1)}, {Key: "name", Value: int32(2)}},
+	}, colNameIdMap)
+	assert.Nil(t, err)
+	assert.True(t, index.FullText)
+	assert.Equal(t, []schema.Key{{ColId: "c3", Order: 1}, {ColId: "c2", Order: 2}}, index.Keys)
+
+	index, err = toSchemaIndex(IndexSpec{Name: "name_hashed", Key: bson.D{{Key: "name", Value: "hashed"}}}, colNameIdMap)
+	assert.Nil(t, err)
+	assert.Equal(t, []schema.Key{{ColId: "c2", Order: 1}}, index.Keys)
+
+	_, err = toSchemaIndex(IndexSpec{Name: "name_1", Key: bson.D{{Key: "name", Value: int32(1)}}, Unique: true, PartialFilterExpression: bson.D{{Key: "name", Value: bson.D{{Key: "$exists", Value: true}}}}}, colNameIdMap)
+	assert.NotNil(t, err)
+	_, err = toSchemaIndex(IndexSpec{Name: "age_1", Key: bson.D{{Key: "age", Value: int32(1)}}}, colNameIdMap)
+	assert.NotNil(t, err)
+}
+
+func TestValidateStrategies(t *testing.T) {
+	assert.Nil(t, ValidateStrategies("", nil))
+	assert.Nil(t, ValidateStrategies(JSONStrategy, map[string]string{"users": FlattenStrategy}))
+	assert.NotNil(t, ValidateStrategies("nested", nil))
+	assert.NotNil(t, ValidateStrategies(FlattenStrategy, map[string]string{"users": "nested"}))
+}
+
+// stripSchemaComments returns a schema with all comments removed.
+// We mostly ignore schema comments in testing since schema comments
+// are often changed and are not a core part of conversion functionality.
+func stripSchemaComments(spSchema map[string]ddl.CreateTable) map[string]ddl.CreateTable {
+	for t, ct := range spSchema {
+		for c, cd := range ct.ColDefs {
+			cd.Comment = ""
+			ct.ColDefs[c] = cd
+		}
+		ct.Comment = ""
+		spSchema[t] = ct
+	}
+	return spSchema
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mongodb handles schema and data migrations from MongoDB.
+package mongodb
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ToDdl implementation for MongoDB
+type ToDdlImpl struct {
+}
+
+// Functions below implement the common.ToDdl interface
+// toSpannerType maps a scalar source schema type (defined by id and
+// mods) into a Spanner type. This is the core source-to-Spanner type
+// mapping.  toSpannerType returns the Spanner type and a list of type
+// conversion issues encountered.
+func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType schema.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
+	ty, issues := toSpannerTypeInternal(srcType, spType)
+	if isPk && ty.Name == ddl.JSON {
+		// JSON columns can't be part of the primary key, so documents and
+		// arrays used as _id are stored as JSON strings.
+		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+	}
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		var pg_issues []internal.SchemaIssue
+		ty, pg_issues = common.ToPGDialectType(ty, isPk)
+		issues = append(issues, pg_issues...)
+	}
+	return ty, issues
+}
+
+func (tdi ToDdlImpl) GetColumnAutoGen(conv *internal.Conv, autoGenCol ddl.AutoGenCol, colId string, tableId string) (*ddl.AutoGenCol, error) {
+	return nil, nil
+}
+
+// toSpannerTypeInternal maps a BSON type to its default Spanner type.
+// Since values of any type can be stored as STRING or JSON, spType can
+// override the default with either of them.
+func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []internal.SchemaIssue) {
+	ty, issues := defaultSpannerType(srcType)
+	switch {
+	case spType == ddl.JSON:
+		return ddl.Type{Name: ddl.JSON}, nil
+	case spType == ddl.String && ty.Name == ddl.JSON:
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+	case spType == ddl.String && ty.Name != ddl.String:
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+	}
+	return ty, issues
+}
+
+func defaultSpannerType(srcType schema.Type) (ddl.Type, []internal.SchemaIssue) {
+	switch srcType.Name {
+	case typeObjectId:
+		return ddl.Type{Name: ddl.String, Len: objectIdLength}, nil
+	case typeString:
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+	case typeInt, typeLong:
+		return ddl.Type{Name: ddl.Int64}, nil
+	case typeDouble:
+		return ddl.Type{Name: ddl.Float64}, nil
+	case typeDecimal:
+		return ddl.Type{Name: ddl.Numeric}, nil
+	case typeBool:
+		return ddl.Type{Name: ddl.Bool}, nil
+	case typeDate, typeTimestamp:
+		return ddl.Type{Name: ddl.Timestamp}, nil
+	case typeBinData:
+		return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil
+	case typeObject, typeArray, typeMixed, typeUnmapped:
+		return ddl.Type{Name: ddl.JSON}, nil
+	default:
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.NoGoodType}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestToSpannerType(t *testing.T) {
+	tests := []struct {
+		name     string
+		srcType  string
+		spType   string
+		isPk     bool
+		expected ddl.Type
+		issues   []internal.SchemaIssue
+	}{
+		{"objectId", typeObjectId, "", false, ddl.Type{Name: ddl.String, Len: objectIdLength}, nil},
+		{"string", typeString, "", false, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil},
+		{"int", typeInt, "", false, ddl.Type{Name: ddl.Int64}, nil},
+		{"long", typeLong, "", false, ddl.Type{Name: ddl.Int64}, nil},
+		{"double", typeDouble, "", false, ddl.Type{Name: ddl.Float64}, nil},
+		{"decimal", typeDecimal, "", false, ddl.Type{Name: ddl.Numeric}, nil},
+		{"bool", typeBool, "", false, ddl.Type{Name: ddl.Bool}, nil},
+		{"date", typeDate, "", false, ddl.Type{Name: ddl.Timestamp}, nil},
+		{"timestamp", typeTimestamp, "", false, ddl.Type{Name: ddl.Timestamp}, nil},
+		{"binData", typeBinData, "", false, ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil},
+		{"object", typeObject, "", false, ddl.Type{Name: ddl.JSON}, nil},
+		{"array", typeArray, "", false, ddl.Type{Name: ddl.JSON}, nil},
+		{"mixed", typeMixed, "", false, ddl.Type{Name: ddl.JSON}, nil},
+		{"unmapped", typeUnmapped, "", false, ddl.Type{Name: ddl.JSON}, nil},
+		{"other", typeOther, "", false, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.NoGoodType}},
+		{"long to string", typeLong, ddl.String, false, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}},
+		{"object to string", typeObject, ddl.String, false, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil},
+		{"bool to json", typeBool, ddl.JSON, false, ddl.Type{Name: ddl.JSON}, nil},
+		{"object primary key", typeObject, "", true, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil},
+	}
+	conv := internal.MakeConv()
+	for _, tc := range tests {
+		ty, issues := ToDdlImpl{}.ToSpannerType(conv, tc.spType, schema.Type{Name: tc.srcType}, tc.isPk)
+		assert.Equal(t, tc.expected, ty, tc.name)
+		assert.Equal(t, tc.issues, issues, tc.name)
+	}
+
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: typeDecimal}, true)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.NumericPKNotSupported}, issues)
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Aurora MySQL clusters are migrated as MySQL sources. Their data is read
+// from the reader endpoint of the cluster when it's known, so that the
+// bulk reads don't load the writer instance.
+
+// GetAuroraVersion returns the Aurora version, e.g. 3.05.2, of the server
+// of db, or "" if it isn't an Aurora MySQL server.
+func GetAuroraVersion(db *sql.DB) (string, error) {
+	var name, version string
+	err := db.QueryRow("SHOW VARIABLES LIKE 'aurora_version';").Scan(&name, &version)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("couldn't get Aurora version: %w", err)
+	}
+	return version, nil
+}
+
+// dataDb returns the database to read data from: the reader endpoint of
+// Aurora clusters if it's known.
+func (isi InfoSchemaImpl) dataDb() *sql.DB {
+	if isi.ReaderDb != nil {
+		return isi.ReaderDb
+	}
+	return isi.Db
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+func TestGetAuroraVersion(t *testing.T) {
+	testCases := []struct {
+		name string
+		rows [][]driver.Value
+		want string
+	}{
+		{name: "aurora", rows: [][]driver.Value{{"aurora_version", "3.05.2"}}, want: "3.05.2"},
+		{name: "mysql", rows: [][]driver.Value{}, want: ""},
+	}
+	for _, tc := range testCases {
+		db := mkMockDB(t, []mockSpec{
+			{
+				query: regexp.QuoteMeta("SHOW VARIABLES LIKE 'aurora_version';"),
+				cols:  []string{"Variable_name", "Value"},
+				rows:  tc.rows,
+			},
+		})
+		got, err := GetAuroraVersion(db)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+}
+
+func TestGetRowsFromTable_ReaderDb(t *testing.T) {
+	db := mkMockDB(t, []mockSpec{})
+	readerDb := mkMockDB(t, []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT `id` FROM `test`.`orders`;"),
+			cols:  []string{"id"},
+			rows:  [][]driver.Value{{1}},
+		},
+	})
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = schema.Table{
+		Name:    "orders",
+		ColIds:  []string{"id"},
+		ColDefs: map[string]schema.Column{"id": {Name: "id", Type: schema.Type{Name: "int"}}},
+	}
+	isi := InfoSchemaImpl{DbName: "test", Db: db, AuroraVersion: "3.05.2", ReaderDb: readerDb}
+	_, err := isi.GetRowsFromTable(conv, "t1")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/parser/charset"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// Values of mysqldump files are in the character set set by their SET NAMES
+// statement, whatever that of their column. Values read from the database
+// are converted to UTF-8 by the server, unless they were stored with
+// another encoding than that of their column, e.g. by clients writing
+// latin1 bytes to utf8 columns or the reverse.
+
+// charTypes are the types of columns with a character set.
+var charTypes = map[string]bool{
+	"char": true, "varchar": true, "tinytext": true, "text": true, "mediumtext": true, "longtext": true, "enum": true, "set": true,
+}
+
+// parserCharsets are the character sets the parser of mysqldump files
+// doesn't support by default, but whose values are transcoded.
+var parserCharsets = []string{
+	"big5", "cp1250", "cp1251", "cp1256", "cp1257", "cp850", "cp852", "cp866", "cp932", "eucjpms", "euckr", "gb18030",
+	"gb2312", "greek", "hebrew", "koi8r", "koi8u", "latin2", "latin5", "latin7", "macroman", "sjis", "tis620", "ucs2",
+	"ujis", "utf16", "utf16le",
+}
+
+func init() {
+	// Tables in other character sets than those the parser supports, e.g.
+	// DEFAULT CHARSET=cp1251, would fail to parse.
+	for _, name := range parserCharsets {
+		if cs, err := charset.GetCharsetInfo(name); cs != nil && err != nil {
+			charset.AddCharset(cs)
+		}
+	}
+}
+
+// defaultCollations maps character sets to their default collation, for
+// those whose default isn't named <charset>_general_ci.
+var defaultCollations = map[string]string{
+	"big5":    "big5_chinese_ci",
+	"binary":  "binary",
+	"cp932":   "cp932_japanese_ci",
+	"eucjpms": "eucjpms_japanese_ci",
+	"euckr":   "euckr_korean_ci",
+	"gb18030": "gb18030_chinese_ci",
+	"gb2312":  "gb2312_chinese_ci",
+	"gbk":     "gbk_chinese_ci",
+	"latin1":  "latin1_swedish_ci",
+	"latin5":  "latin5_turkish_ci",
+	"sjis":    "sjis_japanese_ci",
+	"tis620":  "tis620_thai_ci",
+	"ujis":    "ujis_japanese_ci",
+}
+
+// defaultCollation returns the default collation of a character set.
+func defaultCollation(charset string) string {
+	charset = strings.ToLower(charset)
+	if collation, ok := defaultCollations[charset]; ok {
+		return collation
+	}
+	return charset + "_general_ci"
+}
+
+// toUTF8 returns the UTF-8 text of val, a value of a column in the
+// character set charset.
+func toUTF8(conv *internal.Conv, charset, val string) (string, error) {
+	if conv.SrcCharset != "" {
+		return common.ToUTF8(conv.SrcCharset, val)
+	}
+	if utf8.ValidString(val) {
+		return val, nil
+	}
+	return common.ToUTF8(charset, val)
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// inexactKeyTypes are the types of the key columns whose tables aren't read
+// in chunks: floating point values could be rounded, and ENUM and SET values
+// are sorted by their index but compared by their text.
+var inexactKeyTypes = map[string]bool{"float": true, "double": true, "real": true, "bit": true, "enum": true, "set": true}
+
+// chunkKeyOf returns the columns of the key the rows of table are read in
+// chunks by, or nil when they're read with a single query.
+func chunkKeyOf(table schema.Table) []string {
+	return common.ChunkKey(table, func(col schema.Column) bool {
+		return inexactKeyTypes[col.Type.Name] || len(col.Type.ArrayBounds) > 0
+	})
+}
+
+// readChunks reads the rows of a table, or of its partition if partition
+// isn't empty, selected by the condition filter with arguments args, if
+// any, in chunks in the order of its key columns key. It passes them to
+// emit.
+func (isi InfoSchemaImpl) readChunks(conv *internal.Conv, tableId, partition string, key []string, filter string, args []interface{}, emit func(parallelRow)) error {
+	var quoted []string
+	for _, col := range key {
+		quoted = append(quoted, "`"+col+"`")
+	}
+	placeholder := func(int) string { return "?" }
+	read := func(after []interface{}, limit int) ([]parallelRow, error) {
+		chunkFilter, chunkArgs := filter, args
+		if after != nil {
+			var keyset string
+			keyset, chunkArgs = common.RowValueKeysetFilter(quoted, after, placeholder, slices.Clone(args))
+			chunkFilter = keyset
+			if filter != "" {
+				chunkFilter = filter + " AND " + keyset
+			}
+		}
+		rows, err := isi.getOrderedRows(conv, tableId, partition, chunkFilter, quoted, limit, chunkArgs...)
+		var chunk []parallelRow
+		if err := readRows(rows, err, func(row parallelRow) { chunk = append(chunk, row) }); err != nil {
+			return nil, err
+		}
+		return chunk, nil
+	}
+	rowKey := func(row parallelRow) []interface{} { return keyOf(row, key) }
+	return common.ReadChunks(isi.SourceProfile.ChunkSize(), read, rowKey, emit)
+}
+
+// keyOf returns the values of the key columns key of row, or nil if they
+// couldn't be read.
+func keyOf(row parallelRow, key []string) []interface{} {
+	if row.err != nil {
+		return nil
+	}
+	var values []interface{}
+	for _, col := range key {
+		i := slices.Index(row.cols, col)
+		if i < 0 {
+			return nil
+		}
+		values = append(values, row.values[i])
+	}
+	return values
+}
+
+// processChunksData performs data conversion for a table whose rows are
+// read in chunks in the order of its key columns key.
+func (isi InfoSchemaImpl) processChunksData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes, key []string) error {
+	srcTable := conv.SrcSchema[tableId]
+	colNameIdMap := internal.GetSrcColNameIdMap(srcTable)
+	process := func(row parallelRow) {
+		processParallelRow(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, row, additionalAttributes)
+	}
+	if err := isi.readChunks(conv, tableId, "", key, "", nil, process); err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTable.Name, err))
+		return err
+	}
+	return nil
+}
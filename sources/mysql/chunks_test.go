@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func chunksConv(lineType schema.Type) *internal.Conv {
+	return buildConv(
+		ddl.CreateTable{
+			Name:   "order_lines",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "order_id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "line", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+				"c3": {Name: "amount", Id: "c3", T: ddl.Type{Name: ddl.Int64}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		},
+		schema.Table{
+			Name:   "order_lines",
+			Id:     "t1",
+			Schema: "test",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "order_id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+				"c2": {Name: "line", Id: "c2", Type: lineType},
+				"c3": {Name: "amount", Id: "c3", Type: schema.Type{Name: "bigint"}},
+			},
+			ColNameIdMap: map[string]string{"order_id": "c1", "line": "c2", "amount": "c3"},
+			PrimaryKeys:  []schema.Key{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		})
+}
+
+func TestProcessDataChunks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	cols := []string{"order_id", "line", "amount"}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `order_id`,`line`,`amount` FROM `test`.`order_lines` ORDER BY `order_id`,`line` LIMIT 2;")).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(1, 1, 10).AddRow(1, 2, 20))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `order_id`,`line`,`amount` FROM `test`.`order_lines` WHERE (`order_id`, `line`) > (?, ?) ORDER BY `order_id`,`line` LIMIT 2;")).
+		WithArgs("1", "2").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(2, 1, 30))
+	conv := chunksConv(schema.Type{Name: "int"})
+	conv.SetDataMode()
+	var amounts []int64
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			amounts = append(amounts, vals[2].(int64))
+		})
+	sourceProfile := profiles.SourceProfile{Ty: profiles.SourceProfileTypeConnection, Conn: profiles.SourceProfileConnection{ChunkSize: 2}}
+	isi := InfoSchemaImpl{DbName: "test", Db: db, SourceProfile: sourceProfile}
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []int64{10, 20, 30}, amounts)
+}
+
+func TestChunkKeyOf(t *testing.T) {
+	assert.Equal(t, []string{"order_id", "line"}, chunkKeyOf(chunksConv(schema.Type{Name: "int"}).SrcSchema["t1"]))
+	// Tables whose keys can't be compared exactly are read with a single
+	// query.
+	assert.Nil(t, chunkKeyOf(chunksConv(schema.Type{Name: "double"}).SrcSchema["t1"]))
+	assert.Nil(t, chunkKeyOf(chunksConv(schema.Type{Name: "enum"}).SrcSchema["t1"]))
+}
+
+func TestReadChunksLastRowError(t *testing.T) {
+	cols := []string{"order_id", "line", "amount"}
+	key := []string{"order_id", "line"}
+	table := []parallelRow{
+		{cols: cols, values: []string{"1", "1", "10"}},
+		{cols: cols, values: []string{"1", "2", "20"}},
+		{err: errors.New("can't scan row")},
+		{cols: cols, values: []string{"2", "1", "30"}},
+	}
+	read := func(after []interface{}, limit int) ([]parallelRow, error) {
+		start := 0
+		if after != nil {
+			start = 1 + slices.IndexFunc(table, func(row parallelRow) bool { return slices.Equal(keyOf(row, key), after) })
+		}
+		return table[start:min(start+limit, len(table))], nil
+	}
+	var emitted []parallelRow
+	err := common.ReadChunks(3, read, func(row parallelRow) []interface{} { return keyOf(row, key) }, func(row parallelRow) { emitted = append(emitted, row) })
+	// The last row of the first chunk couldn't be scanned: the next chunk
+	// starts after the row before it, and it's passed once.
+	assert.Nil(t, err)
+	assert.Equal(t, table, emitted)
+}
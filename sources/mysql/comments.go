@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// GetComments implements the common.CommentSource interface, reading the
+// TABLE_COMMENT and COLUMN_COMMENT of the tables.
+func (isi InfoSchemaImpl) GetComments(tables []common.SchemaAndName) (map[common.SchemaAndName]common.TableComments, error) {
+	included := make(map[common.SchemaAndName]bool)
+	for _, t := range tables {
+		included[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] = true
+	}
+	comments := make(map[common.SchemaAndName]common.TableComments)
+	for _, db := range isi.databases() {
+		if err := isi.getComments(db, included, comments); err != nil {
+			return nil, err
+		}
+	}
+	return comments, nil
+}
+
+// getComments adds the comments of the included tables of database db to
+// comments.
+func (isi InfoSchemaImpl) getComments(db string, included map[common.SchemaAndName]bool, comments map[common.SchemaAndName]common.TableComments) error {
+	q := `SELECT TABLE_NAME, TABLE_COMMENT
+		FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_COMMENT <> '';`
+	rows, err := isi.Db.Query(q, db)
+	if err != nil {
+		return fmt.Errorf("couldn't get table comments: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, comment string
+		if err := rows.Scan(&tableName, &comment); err != nil {
+			return fmt.Errorf("couldn't get table comments: %w", err)
+		}
+		t := common.SchemaAndName{Schema: db, Name: tableName}
+		if included[t] {
+			c := comments[t]
+			c.Comment = comment
+			comments[t] = c
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("couldn't get table comments: %w", err)
+	}
+
+	q = `SELECT TABLE_NAME, COLUMN_NAME, COLUMN_COMMENT
+		FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = ? AND COLUMN_COMMENT <> '';`
+	colRows, err := isi.Db.Query(q, db)
+	if err != nil {
+		return fmt.Errorf("couldn't get column comments: %w", err)
+	}
+	defer colRows.Close()
+	for colRows.Next() {
+		var tableName, colName, comment string
+		if err := colRows.Scan(&tableName, &colName, &comment); err != nil {
+			return fmt.Errorf("couldn't get column comments: %w", err)
+		}
+		t := common.SchemaAndName{Schema: db, Name: tableName}
+		if included[t] {
+			c := comments[t]
+			if c.Columns == nil {
+				c.Columns = make(map[string]string)
+			}
+			c.Columns[colName] = comment
+			comments[t] = c
+		}
+	}
+	if err := colRows.Err(); err != nil {
+		return fmt.Errorf("couldn't get column comments: %w", err)
+	}
+	return nil
+}
@@ -27,7 +27,9 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 )
 
 // ProcessDataRow converts a row of data and writes it out to Spanner.
@@ -78,10 +80,21 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, srcSchema
 
 		var x interface{}
 		var err error
+		val := vals[i]
+		if charTypes[srcColDef.Type.Name] {
+			if val, err = toUTF8(conv, srcColDef.Charset, val); err != nil {
+				return "", []string{}, []interface{}{}, err
+			}
+		}
 		if spColDef.T.IsArray {
-			x, err = convArray(spColDef.T, srcColDef.Type.Name, vals[i])
+			x, err = convArray(spColDef.T, srcColDef.Type.Name, val)
+		} else if spColDef.T.Name == ddl.Enum && srcColDef.Type.Name == "enum" {
+			x, err = convEnum(srcColDef.EnumValues, val)
+		} else if srcColDef.Type.Name == "binary" && isUUIDAsString(conv, tableId, colId) {
+			// BINARY(16) UUIDs mapped to STRING(36) are stored in their textual form.
+			x, err = convUUID(srcColDef.Type.Name, val)
 		} else {
-			x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.TimezoneOffset, vals[i])
+			x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.TimezoneOffset, val)
 		}
 		if err != nil {
 			return "", []string{}, []interface{}{}, err
@@ -113,6 +126,9 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, T
 	// strconv.ParseFloat and strconv.ParseInt) return "invalid syntax"
 	// errors if whitespace were to appear at the start or end of a string.
 	// We do not expect mysqldump to generate such output.
+	if isSpatialType(srcTypeName) {
+		return common.ConvSpatial(spannerType, val)
+	}
 	switch spannerType.Name {
 	case ddl.Bool:
 		return convBool(conv, spannerType, srcTypeName, val)
@@ -134,11 +150,28 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, T
 		return convTimestamp(srcTypeName, TimezoneOffset, val)
 	case ddl.JSON:
 		return val, nil
+	case ddl.Proto:
+		return convBytes(val)
+	case ddl.Enum:
+		return convInt64(val)
+	case ddl.UUID:
+		return convUUID(srcTypeName, val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
 }
 
+// isSpatialType returns true for MySQL spatial types, whose values are read
+// as WKT.
+func isSpatialType(srcTypeName string) bool {
+	for _, spatial := range MysqlSpatialDataTypes {
+		if srcTypeName == spatial {
+			return true
+		}
+	}
+	return false
+}
+
 func convBool(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, val string) (bool, error) {
 	b, err := strconv.ParseBool(val)
 	if err != nil {
@@ -172,6 +205,50 @@ func convBool(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, val
 	return b, err
 }
 
+// convEnum converts a MySQL ENUM value to the number of the corresponding
+// proto enum value. Proto enum values are numbered from 1 in the order of the
+// MySQL ENUM values, matching MySQL's ENUM index. The empty string, which
+// MySQL uses for invalid values, maps to 0.
+func convEnum(enumValues []string, val string) (int64, error) {
+	if val == "" {
+		return 0, nil
+	}
+	for i, v := range enumValues {
+		if v == val {
+			return int64(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("can't convert to enum: %q is not one of %v", val, enumValues)
+}
+
+// convUUID converts a UUID to its canonical textual form, which is how
+// Spanner accepts UUID values. BINARY(16) columns store the 16 raw bytes of
+// the UUID, while CHAR(36) columns store its textual form.
+func convUUID(srcTypeName string, val string) (string, error) {
+	var u uuid.UUID
+	var err error
+	if srcTypeName == "binary" {
+		u, err = uuid.FromBytes([]byte(val))
+	} else {
+		u, err = uuid.Parse(val)
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't convert to uuid: %w", err)
+	}
+	return u.String(), nil
+}
+
+// isUUIDAsString returns true if the column was mapped to UUID and then to
+// STRING(36) by the uuidAsString param of the target profile.
+func isUUIDAsString(conv *internal.Conv, tableId, colId string) bool {
+	for _, issue := range conv.SchemaIssues[tableId].ColumnLevelIssues[colId] {
+		if issue == internal.UUIDAsString {
+			return true
+		}
+	}
+	return false
+}
+
 func convBytes(val string) ([]byte, error) {
 	// convert a string to a byte slice.
 	b := []byte(val)
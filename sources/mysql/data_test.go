@@ -99,6 +99,11 @@ func TestConvertData(t *testing.T) {
 		{"datetime", ddl.Type{Name: ddl.Timestamp}, "datetime", "2019-10-29 05:30:00", getTimeWithoutTimezone(t, "2019-10-29 05:30:00")},
 		{"timestamp", ddl.Type{Name: ddl.Timestamp}, "timestamp", "2019-10-29 05:30:00", getTime(t, "2019-10-29T05:30:00+05:30")},
 		{"json", ddl.Type{Name: ddl.JSON}, "", "{\"key1\": \"value1\"}", "{\"key1\": \"value1\"}"},
+		{"uuid char", ddl.Type{Name: ddl.UUID}, "char", "123E4567-E89B-12D3-A456-426614174000", "123e4567-e89b-12d3-a456-426614174000"},
+		{"uuid binary", ddl.Type{Name: ddl.UUID}, "binary", string([]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}), "123e4567-e89b-12d3-a456-426614174000"},
+		{"point string", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "point", "POINT(1 2)", "POINT(1 2)"},
+		{"point bytes", ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, "point", "POINT(1 2)", []byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40}},
+		{"polygon json", ddl.Type{Name: ddl.JSON}, "polygon", "POLYGON((0 0,1 0,1 1,0 0))", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`},
 		{"string array(set)", ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, "", "1,Travel,3,Dance", []spanner.NullString{
 			spanner.NullString{StringVal: "1", Valid: true},
 			spanner.NullString{StringVal: "Travel", Valid: true},
@@ -126,6 +131,78 @@ func TestConvertData(t *testing.T) {
 	}
 }
 
+func TestConvertEnumData(t *testing.T) {
+	tableName := "testtable"
+	tableId := "t1"
+	colId := "c1"
+	col := "a"
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:        tableName,
+			Id:          tableId,
+			ColIds:      []string{colId},
+			ColDefs:     map[string]ddl.ColumnDef{colId: {Name: col, Id: colId, T: ddl.Type{Name: ddl.Enum, ProtoName: "shop.testtable_a"}}},
+			PrimaryKeys: []ddl.IndexKey{}},
+		schema.Table{Name: tableName, Id: tableId, ColIds: []string{col}, ColDefs: map[string]schema.Column{colId: {Name: col, Id: colId, Type: schema.Type{Name: "enum"}, EnumValues: []string{"small", "medium", "large"}}}})
+	for in, e := range map[string]int64{"": 0, "small": 1, "large": 3} {
+		at, ac, av, err := ConvertData(conv, tableId, []string{colId}, conv.SrcSchema[tableId], conv.SpSchema[tableId], []string{in}, internal.AdditionalDataAttributes{})
+		checkResults(t, at, ac, av, err, tableName, []string{col}, []interface{}{e}, in)
+	}
+	_, _, _, err := ConvertData(conv, tableId, []string{colId}, conv.SrcSchema[tableId], conv.SpSchema[tableId], []string{"huge"}, internal.AdditionalDataAttributes{})
+	assert.NotNil(t, err)
+}
+
+func TestConvertUUIDAsStringData(t *testing.T) {
+	// BINARY(16) UUIDs mapped to STRING(36) with uuidAsString are stored in
+	// their textual form, while other BINARY columns mapped to STRING(36)
+	// keep their bytes.
+	tableName := "testtable"
+	tableId := "t1"
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   tableName,
+			Id:     tableId,
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.String, Len: 36}},
+				"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 36}},
+			},
+			PrimaryKeys: []ddl.IndexKey{}},
+		schema.Table{Name: tableName, Id: tableId, ColIds: []string{"c1", "c2"}, ColDefs: map[string]schema.Column{
+			"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "binary", Mods: []int64{16}}},
+			"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "binary", Mods: []int64{4}}},
+		}})
+	conv.SchemaIssues[tableId] = internal.TableIssues{ColumnLevelIssues: map[string][]internal.SchemaIssue{"c1": {internal.UUIDAsString}}}
+	vals := []string{string([]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}), "abcd"}
+	at, ac, av, err := ConvertData(conv, tableId, []string{"c1", "c2"}, conv.SrcSchema[tableId], conv.SpSchema[tableId], vals, internal.AdditionalDataAttributes{})
+	checkResults(t, at, ac, av, err, tableName, []string{"a", "b"}, []interface{}{"123e4567-e89b-12d3-a456-426614174000", "abcd"}, "uuid as string")
+}
+
+func TestConvertCharsetData(t *testing.T) {
+	tableName := "testtable"
+	tableId := "t1"
+	colId := "c1"
+	col := "a"
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:        tableName,
+			Id:          tableId,
+			ColIds:      []string{colId},
+			ColDefs:     map[string]ddl.ColumnDef{colId: {Name: col, Id: colId, T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}},
+			PrimaryKeys: []ddl.IndexKey{}},
+		schema.Table{Name: tableName, Id: tableId, ColIds: []string{col}, ColDefs: map[string]schema.Column{colId: {Name: col, Id: colId, Type: schema.Type{Name: "varchar"}, Charset: "cp1251", Collation: "cp1251_general_ci"}}})
+	// Values read from the database are UTF-8, unless stored in another
+	// encoding.
+	for in, e := range map[string]string{"Привет": "Привет", "\xcf\xf0\xe8": "При"} {
+		at, ac, av, err := ConvertData(conv, tableId, []string{colId}, conv.SrcSchema[tableId], conv.SpSchema[tableId], []string{in}, internal.AdditionalDataAttributes{})
+		checkResults(t, at, ac, av, err, tableName, []string{col}, []interface{}{e}, in)
+	}
+	// Values of dumps are in their character set.
+	conv.SrcCharset = "utf8mb4"
+	_, _, _, err := ConvertData(conv, tableId, []string{colId}, conv.SrcSchema[tableId], conv.SpSchema[tableId], []string{"\xcf\xf0\xe8"}, internal.AdditionalDataAttributes{})
+	assert.NotNil(t, err)
+}
+
 func TestConvertTimestampData(t *testing.T) {
 	timestampTests := []struct {
 		name  string
@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// MySQL generated column expressions are translated to GoogleSQL when they
+// only use operators and functions with the same semantics in Spanner.
+// Identifiers are backquoted in both, and string literals single quoted.
+
+var (
+	// Function calls, whose name is checked against generatedExprFunctions.
+	generatedExprFuncRegex = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)(\s*\()`)
+	// Operators without a GoogleSQL equivalent: || and && are logical
+	// operators in MySQL, and % is written MOD in GoogleSQL.
+	generatedExprOperatorRegex = regexp.MustCompile(`\|\||&&|%|->|<=>|:=|(?i)\b(div|xor|regexp|rlike|sounds|interval|binary|collate)\b`)
+	// Character set introducers of string literals e.g. _utf8mb4'abc'.
+	charsetIntroducerRegex = regexp.MustCompile(`(^|[^A-Za-z0-9_$])_[A-Za-z0-9]+\s*$`)
+)
+
+// generatedExprFunctions maps the MySQL functions supported in generated
+// column expressions to their GoogleSQL name.
+var generatedExprFunctions = map[string]string{
+	"abs":              "ABS",
+	"ceil":             "CEIL",
+	"ceiling":          "CEILING",
+	"char_length":      "CHAR_LENGTH",
+	"character_length": "CHARACTER_LENGTH",
+	"coalesce":         "COALESCE",
+	"concat":           "CONCAT",
+	"exp":              "EXP",
+	"floor":            "FLOOR",
+	"greatest":         "GREATEST",
+	"if":               "IF",
+	"ifnull":           "IFNULL",
+	"lcase":            "LOWER",
+	"least":            "LEAST",
+	"length":           "BYTE_LENGTH",
+	"ln":               "LN",
+	"log10":            "LOG10",
+	"lower":            "LOWER",
+	"lpad":             "LPAD",
+	"ltrim":            "LTRIM",
+	"mod":              "MOD",
+	"nullif":           "NULLIF",
+	"pow":              "POW",
+	"power":            "POWER",
+	"repeat":           "REPEAT",
+	"replace":          "REPLACE",
+	"reverse":          "REVERSE",
+	"round":            "ROUND",
+	"rpad":             "RPAD",
+	"rtrim":            "RTRIM",
+	"sign":             "SIGN",
+	"sqrt":             "SQRT",
+	"substr":           "SUBSTR",
+	"substring":        "SUBSTR",
+	"trim":             "TRIM",
+	"ucase":            "UPPER",
+	"upper":            "UPPER",
+}
+
+// Keywords which can be followed by a parenthesis in expressions.
+var generatedExprKeywords = map[string]bool{
+	"and": true, "case": true, "else": true, "in": true, "is": true, "like": true,
+	"not": true, "or": true, "then": true, "when": true,
+}
+
+// toGeneratedColumn returns the generated column of a column, from its
+// INFORMATION_SCHEMA.COLUMNS generation_expression and extra e.g.
+// "VIRTUAL GENERATED" or "STORED GENERATED".
+func toGeneratedColumn(expr, extra string) ddl.GeneratedColumn {
+	extra = strings.ToUpper(extra)
+	if expr == "" || extra == constants.DEFAULT_GENERATED || !strings.Contains(extra, "GENERATED") {
+		return ddl.GeneratedColumn{}
+	}
+	ty := ddl.GeneratedStored
+	if strings.Contains(extra, "VIRTUAL") {
+		ty = ddl.GeneratedVirtual
+	}
+	return ddl.GeneratedColumn{
+		IsPresent: true,
+		Value:     ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: expr},
+		Type:      ty,
+	}
+}
+
+// ToSpannerGeneratedExpression implements the common.GeneratedColumnTranslator
+// interface. Expressions are only translated for the GoogleSQL dialect.
+func (tdi ToDdlImpl) ToSpannerGeneratedExpression(conv *internal.Conv, expr string) (string, bool) {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return "", false
+	}
+	return toSpannerGeneratedExpression(expr)
+}
+
+// toSpannerGeneratedExpression translates a MySQL generated column
+// expression, as reported by INFORMATION_SCHEMA.COLUMNS e.g.
+// concat(`first`,_utf8mb4\' \',`last`), to GoogleSQL. It returns false when
+// the expression can't be translated.
+func toSpannerGeneratedExpression(expr string) (string, bool) {
+	// Quotes of string literals are escaped in INFORMATION_SCHEMA.
+	expr = strings.ReplaceAll(expr, `\'`, `'`)
+	var sb strings.Builder
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		quote := expr[i]
+		if quote != '\'' && quote != '"' && quote != '`' {
+			continue
+		}
+		seg := expr[start:i]
+		if quote != '`' {
+			seg = charsetIntroducerRegex.ReplaceAllString(seg, "$1")
+		}
+		translated, ok := translateGeneratedExprSegment(seg)
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(translated)
+		j := i + 1
+		for j < len(expr) && expr[j] != quote {
+			if expr[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(expr) {
+			return "", false
+		}
+		sb.WriteString(expr[i : j+1])
+		start, i = j+1, j
+	}
+	translated, ok := translateGeneratedExprSegment(expr[start:])
+	if !ok {
+		return "", false
+	}
+	sb.WriteString(translated)
+	s := strings.TrimSpace(sb.String())
+	return s, s != ""
+}
+
+// translateGeneratedExprSegment translates the function calls of a part of
+// an expression without literals or identifiers.
+func translateGeneratedExprSegment(seg string) (string, bool) {
+	if generatedExprOperatorRegex.MatchString(seg) {
+		return "", false
+	}
+	ok := true
+	seg = generatedExprFuncRegex.ReplaceAllStringFunc(seg, func(call string) string {
+		m := generatedExprFuncRegex.FindStringSubmatch(call)
+		name := strings.ToLower(m[1])
+		if generatedExprKeywords[name] {
+			return call
+		}
+		fn, found := generatedExprFunctions[name]
+		if !found {
+			ok = false
+			return call
+		}
+		return fn + m[2]
+	})
+	return seg, ok
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+)
+
+func TestToSpannerGeneratedExpression(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{"(`price` * `quantity`)", "(`price` * `quantity`)"},
+		{"concat(`first_name`,_utf8mb4\\' \\',`last_name`)", "CONCAT(`first_name`,' ',`last_name`)"},
+		{"ucase(substring(`code`,1,3))", "UPPER(SUBSTR(`code`,1,3))"},
+		{"length(`data`)", "BYTE_LENGTH(`data`)"},
+		{"(case when (`qty` > 10) then _latin1\\'bulk\\' else _latin1\\'retail\\' end)", "(case when (`qty` > 10) then 'bulk' else 'retail' end)"},
+		{"ifnull(`note`,_utf8mb4\\'some_text\\')", "IFNULL(`note`,'some_text')"},
+		{"CONCAT(`a`, 'x')", "CONCAT(`a`, 'x')"},
+		// Backquoted identifiers can be named like unsupported functions.
+		{"(`json_extract` + 1)", "(`json_extract` + 1)"},
+	}
+	for _, tc := range testCases {
+		s, ok := toSpannerGeneratedExpression(tc.expr)
+		assert.True(t, ok, tc.expr)
+		assert.Equal(t, tc.expected, s, tc.expr)
+	}
+
+	for _, expr := range []string{
+		"json_unquote(json_extract(`doc`,_utf8mb4\\'$.name\\'))",
+		"(`a` % 2)",
+		"(`a` div 2)",
+		"(`a` || `b`)",
+		"`doc`->>'$.name'",
+		"date_format(`created`,_utf8mb4\\'%Y\\')",
+		"concat(`a`,_utf8mb4\\'unterminated)",
+		"",
+	} {
+		_, ok := toSpannerGeneratedExpression(expr)
+		assert.False(t, ok, expr)
+	}
+
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	_, ok := ToDdlImpl{}.ToSpannerGeneratedExpression(conv, "(`a` + 1)")
+	assert.False(t, ok)
+}
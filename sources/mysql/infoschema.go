@@ -44,6 +44,14 @@ type InfoSchemaImpl struct {
 	MigrationProjectId string
 	SourceProfile      profiles.SourceProfile
 	TargetProfile      profiles.TargetProfile
+	// TiDB is true if the source is a TiDB server, see tidb.go.
+	TiDB bool
+	// AuroraVersion is the version of Aurora MySQL servers, see aurora.go.
+	AuroraVersion string
+	// ReaderDb is the reader endpoint of Aurora clusters, if it's known.
+	ReaderDb *sql.DB
+	// Metadata is the metadata of tables fetched in bulk, if it has been.
+	Metadata *common.BulkMetadata
 }
 
 // GetToDdl implement the common.InfoSchema interface.
@@ -51,13 +59,56 @@ func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
 	return ToDdlImpl{}
 }
 
-// GetTableName returns table name.
+// GetTableName returns table name. Tables of databases other than the one
+// connected to are prefixed with their database.
 func (isi InfoSchemaImpl) GetTableName(dbName string, tableName string) string {
-	return tableName
+	if dbName == "" || dbName == isi.DbName {
+		return tableName
+	}
+	return fmt.Sprintf("%s.%s", dbName, tableName)
+}
+
+// databases returns the databases whose tables are migrated: those selected
+// in the source profile, if any, or the database connected to.
+func (isi InfoSchemaImpl) databases() []string {
+	if dbs := isi.SourceProfile.Schemas(); len(dbs) > 0 {
+		return dbs
+	}
+	return []string{isi.DbName}
+}
+
+// quotedTableName returns the quoted name of table, qualified by its
+// database, which is the database connected to if unknown.
+func (isi InfoSchemaImpl) quotedTableName(table schema.Table) string {
+	db := table.Schema
+	if db == "" {
+		db = isi.DbName
+	}
+	// MySQL schema and name can be arbitrary strings.
+	// Ideally we would pass schema/name as a query parameter,
+	// but MySQL doesn't support this. So we quote it instead.
+	return fmt.Sprintf("`%s`.`%s`", db, strings.TrimPrefix(table.Name, db+"."))
 }
 
 // GetRowsFromTable returns a sql Rows object for a table.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
+	rows, err := isi.getRows(conv, tableId, "", "")
+	if rows == nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// getRows returns the rows of a table, or of its partition if partition
+// isn't empty, selected by the condition filter with arguments args, if
+// any.
+func (isi InfoSchemaImpl) getRows(conv *internal.Conv, tableId, partition, filter string, args ...interface{}) (*sql.Rows, error) {
+	return isi.getOrderedRows(conv, tableId, partition, filter, nil, 0, args...)
+}
+
+// getOrderedRows returns the rows of getRows in the order of the columns
+// order, quoted, if any, and up to limit of them, when above 0.
+func (isi InfoSchemaImpl) getOrderedRows(conv *internal.Conv, tableId, partition, filter string, order []string, limit int, args ...interface{}) (*sql.Rows, error) {
 	srcSchema := conv.SrcSchema[tableId]
 	srcCols := []string{}
 
@@ -68,12 +119,21 @@ func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string)
 		conv.Unexpected(fmt.Sprintf("Couldn't get source columns for table %s ", srcSchema.Name))
 		return nil, nil
 	}
-	// MySQL schema and name can be arbitrary strings.
-	// Ideally we would pass schema/name as a query parameter,
-	// but MySQL doesn't support this. So we quote it instead.
 	colNameList := buildColNameList(srcSchema, srcCols)
-	q := fmt.Sprintf("SELECT %s FROM `%s`.`%s`;", colNameList, isi.DbName, srcSchema.Name)
-	rows, err := isi.Db.Query(q)
+	q := fmt.Sprintf("SELECT %s FROM %s", colNameList, isi.quotedTableName(srcSchema))
+	if partition != "" {
+		q += fmt.Sprintf(" PARTITION (`%s`)", partition)
+	}
+	if filter != "" {
+		q += " WHERE " + filter
+	}
+	if len(order) > 0 {
+		q += " ORDER BY " + strings.Join(order, ",")
+	}
+	if limit > 0 {
+		q += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	rows, err := isi.dataDb().Query(q+";", args...)
 	return rows, err
 }
 
@@ -99,6 +159,19 @@ func buildColNameList(srcSchema schema.Table, srcColName []string) string {
 
 // ProcessData performs data conversion for source database.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	readers := isi.SourceProfile.ParallelReaders()
+	chunkKey := chunkKeyOf(conv.SrcSchema[tableId])
+	if partitionReaders := common.PartitionReadersOf(conv.SrcSchema[tableId], readers); partitionReaders > 0 {
+		return isi.processPartitionsData(conv, tableId, srcSchema, commonColIds, spSchema, additionalAttributes, partitionReaders, chunkKey)
+	}
+	if readers > 1 {
+		if key, ranges := isi.getKeyRanges(conv.SrcSchema[tableId], readers); len(ranges) > 1 {
+			return isi.processKeyRangesData(conv, tableId, srcSchema, commonColIds, spSchema, additionalAttributes, key, ranges, readers, chunkKey)
+		}
+	}
+	if chunkKey != nil {
+		return isi.processChunksData(conv, tableId, srcSchema, commonColIds, spSchema, additionalAttributes, chunkKey)
+	}
 	srcTableName := conv.SrcSchema[tableId].Name
 	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
 	if err != nil {
@@ -119,28 +192,32 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
 			continue
 		}
-		values := valsToStrings(v)
-
-		newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
-		if err != nil {
-			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
-			conv.StatsAddBadRow(srcTableName, conv.DataMode())
-			conv.CollectBadRow(srcTableName, srcCols, values)
-			continue
-		}
-
-		ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues, additionalAttributes)
+		processRowValues(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, srcCols, valsToStrings(v), additionalAttributes)
 	}
 	return nil
 }
 
+// processRowValues converts the values of the columns srcCols of a row of
+// a table, and writes them.
+func processRowValues(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, colNameIdMap map[string]string, srcCols, values []string, additionalAttributes internal.AdditionalDataAttributes) {
+	srcTableName := conv.SrcSchema[tableId].Name
+	newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcCols, values)
+		return
+	}
+	ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues, additionalAttributes)
+}
+
 // GetRowCount with number of rows in each table.
 func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
 	// MySQL schema and name can be arbitrary strings.
 	// Ideally we would pass schema/name as a query parameter,
 	// but MySQL doesn't support this. So we quote it instead.
 	q := fmt.Sprintf("SELECT COUNT(*) FROM `%s`.`%s`;", table.Schema, table.Name)
-	rows, err := isi.Db.Query(q)
+	rows, err := isi.dataDb().Query(q)
 	if err != nil {
 		return 0, err
 	}
@@ -153,14 +230,61 @@ func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error)
 	return 0, nil // Check if 0 is ok to return
 }
 
+// GetMaxColumnLengths implements the common.ColumnLengthSampler interface.
+func (isi InfoSchemaImpl) GetMaxColumnLengths(table common.SchemaAndName, cols map[string]bool, sampleSize int64) (map[string]int64, error) {
+	var names, quoted, maxLengths []string
+	for col := range cols {
+		names = append(names, col)
+	}
+	sort.Strings(names)
+	for _, col := range names {
+		c := "`" + col + "`"
+		quoted = append(quoted, c)
+		if cols[col] {
+			maxLengths = append(maxLengths, fmt.Sprintf("MAX(LENGTH(%s))", c))
+		} else {
+			maxLengths = append(maxLengths, fmt.Sprintf("MAX(CHAR_LENGTH(%s))", c))
+		}
+	}
+	q := fmt.Sprintf("SELECT %s FROM (SELECT %s FROM `%s`.`%s` LIMIT %d) AS sample;", strings.Join(maxLengths, ", "), strings.Join(quoted, ", "), table.Schema, table.Name, sampleSize)
+	values := make([]sql.NullInt64, len(names))
+	dest := make([]interface{}, len(names))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := isi.Db.QueryRow(q).Scan(dest...); err != nil {
+		return nil, err
+	}
+	lengths := make(map[string]int64)
+	for i, v := range values {
+		if v.Valid {
+			lengths[names[i]] = v.Int64
+		}
+	}
+	return lengths, nil
+}
+
 // GetTables return list of tables in the selected database.
 // Note that sql.DB already effectively has the dbName
 // embedded within it (dbName is part of the DSN passed to sql.Open),
 // but unfortunately there is no way to extract it from sql.DB.
 func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	var tables []common.SchemaAndName
+	for _, db := range isi.databases() {
+		dbTables, err := isi.getTables(db)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, dbTables...)
+	}
+	return tables, nil
+}
+
+// getTables returns the list of tables of database db.
+func (isi InfoSchemaImpl) getTables(db string) ([]common.SchemaAndName, error) {
 	// In MySQL, schema is the same as database name.
 	q := "SELECT table_name FROM information_schema.tables where table_type = 'BASE TABLE' and table_schema=?"
-	rows, err := isi.Db.Query(q, isi.DbName)
+	rows, err := isi.Db.Query(q, db)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get tables: %w", err)
 	}
@@ -169,17 +293,88 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	var tables []common.SchemaAndName
 	for rows.Next() {
 		rows.Scan(&tableName)
-		tables = append(tables, common.SchemaAndName{Schema: isi.DbName, Name: tableName})
+		tables = append(tables, common.SchemaAndName{Schema: db, Name: tableName})
 	}
 	return tables, nil
 }
 
+// GetViews implements the common.ViewSource interface.
+func (isi InfoSchemaImpl) GetViews() ([]schema.View, error) {
+	q := "SELECT TABLE_NAME, VIEW_DEFINITION FROM INFORMATION_SCHEMA.VIEWS WHERE TABLE_SCHEMA = ?"
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get views: %w", err)
+	}
+	defer rows.Close()
+	var views []schema.View
+	for rows.Next() {
+		view := schema.View{Schema: isi.DbName}
+		if err := rows.Scan(&view.Name, &view.Query); err != nil {
+			return nil, fmt.Errorf("couldn't get views: %w", err)
+		}
+		views = append(views, view)
+	}
+	return views, rows.Err()
+}
+
+// GetRoutines implements the common.RoutineSource interface.
+func (isi InfoSchemaImpl) GetRoutines() ([]schema.Routine, error) {
+	q := "SELECT ROUTINE_NAME, ROUTINE_TYPE, ROUTINE_DEFINITION FROM INFORMATION_SCHEMA.ROUTINES WHERE ROUTINE_SCHEMA = ?"
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get routines: %w", err)
+	}
+	defer rows.Close()
+	var routines []schema.Routine
+	for rows.Next() {
+		routine := schema.Routine{Schema: isi.DbName}
+		// Definitions are NULL for users without privileges on the routine.
+		var body sql.NullString
+		if err := rows.Scan(&routine.Name, &routine.Kind, &body); err != nil {
+			return nil, fmt.Errorf("couldn't get routines: %w", err)
+		}
+		routine.Body = body.String
+		routines = append(routines, routine)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't get routines: %w", err)
+	}
+
+	q = `SELECT TRIGGER_NAME, EVENT_OBJECT_TABLE, ACTION_TIMING, EVENT_MANIPULATION, ACTION_STATEMENT
+		FROM INFORMATION_SCHEMA.TRIGGERS
+		WHERE TRIGGER_SCHEMA = ?`
+	triggerRows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get triggers: %w", err)
+	}
+	defer triggerRows.Close()
+	for triggerRows.Next() {
+		trigger := schema.Routine{Schema: isi.DbName, Kind: schema.RoutineTrigger}
+		var event string
+		if err := triggerRows.Scan(&trigger.Name, &trigger.Table, &trigger.Timing, &event, &trigger.Body); err != nil {
+			return nil, fmt.Errorf("couldn't get triggers: %w", err)
+		}
+		// MySQL triggers have a single event.
+		trigger.Events = []string{event}
+		routines = append(routines, trigger)
+	}
+	return routines, triggerRows.Err()
+}
+
 // GetColumns returns a list of Column objects and names// ProcessColumns
 func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
-	q := `SELECT c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.extra
+	var tidbInfo tidbTableInfo
+	if isi.TiDB {
+		info, err := isi.getTiDBTableInfo(table)
+		if err != nil {
+			conv.Unexpected(err.Error())
+		}
+		tidbInfo = info
+	}
+	q := `SELECT c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.extra, c.generation_expression, c.character_set_name, c.collation_name
               FROM information_schema.COLUMNS c
               where table_schema = ? and table_name = ? ORDER BY c.ordinal_position;`
-	cols, err := isi.Db.Query(q, table.Schema, table.Name)
+	cols, err := isi.Metadata.Query(isi.Db, common.MetadataColumns, table, q, table.Schema, table.Name)
 	if err != nil {
 		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
 	}
@@ -187,11 +382,11 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	colDefs := make(map[string]schema.Column)
 	var colIds []string
 	var colName, dataType, isNullable, columnType string
-	var colDefault, colExtra sql.NullString
+	var colDefault, colExtra, generationExpr, charset, collation sql.NullString
 	var charMaxLen, numericPrecision, numericScale sql.NullInt64
 	var colAutoGen ddl.AutoGenCol
 	for cols.Next() {
-		err := cols.Scan(&colName, &dataType, &columnType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colExtra)
+		err := cols.Scan(&colName, &dataType, &columnType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colExtra, &generationExpr, &charset, &collation)
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
@@ -199,12 +394,16 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 		ignored := schema.Ignored{}
 		ignored.Default = colDefault.Valid
 		colId := internal.GenerateColumnId()
-		if colExtra.String == "auto_increment" {
-			sequence := createSequence(conv)
+		autoRandom := isi.TiDB && tidbInfo.isAutoRandom(colName, colExtra.String, primaryKeys)
+		if colExtra.String == "auto_increment" || autoRandom {
+			sequence := CreateSequence(conv)
 			colAutoGen = ddl.AutoGenCol{
 				Name:           sequence.Name,
 				GenerationType: constants.AUTO_INCREMENT,
 			}
+			if autoRandom {
+				colAutoGen.GenerationType = constants.AUTO_RANDOM
+			}
 			sequence.ColumnsUsingSeq = map[string][]string{
 				table.Id: {colId},
 			}
@@ -231,13 +430,19 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 		}
 
 		c := schema.Column{
-			Id:           colId,
-			Name:         colName,
-			Type:         toType(dataType, columnType, charMaxLen, numericPrecision, numericScale),
-			NotNull:      common.ToNotNull(conv, isNullable),
-			Ignored:      ignored,
-			AutoGen:      colAutoGen,
-			DefaultValue: defaultVal,
+			Id:              colId,
+			Name:            colName,
+			Type:            ToType(dataType, columnType, charMaxLen, numericPrecision, numericScale),
+			NotNull:         common.ToNotNull(conv, isNullable),
+			Ignored:         ignored,
+			AutoGen:         colAutoGen,
+			DefaultValue:    defaultVal,
+			EnumValues:      GetEnumValues(dataType, columnType),
+			GeneratedColumn: toGeneratedColumn(generationExpr.String, colExtra.String),
+			Charset:         charset.String,
+			Collation:       collation.String,
+			// The extra column is e.g. "DEFAULT_GENERATED on update CURRENT_TIMESTAMP".
+			OnUpdateCurrentTimestamp: strings.Contains(strings.ToLower(colExtra.String), "on update current_timestamp"),
 		}
 		colDefs[colId] = c
 		colIds = append(colIds, colId)
@@ -245,16 +450,29 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	return colDefs, colIds, nil
 }
 
+// GetEnumValues returns the values of an ENUM or SET column from its column
+// type e.g. enum('small','medium','large').
+func GetEnumValues(dataType, columnType string) []string {
+	if dataType != "enum" && dataType != "set" {
+		return nil
+	}
+	return common.ParseEnumValues(columnType)
+}
+
 // GetConstraints returns a list of primary keys and by-column map of
 // other constraints.  Note: we need to preserve ordinal order of
 // columns in primary key constraints.
 // Note that foreign key constraints are handled in getForeignKeys.
 func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
-	finalQuery, err := isi.getConstraintsDQL()
-	if err != nil {
-		return nil, nil, nil, err
+	var finalQuery string
+	var err error
+	// The query is only needed if the constraints weren't fetched in bulk.
+	if isi.Metadata == nil {
+		if finalQuery, err = isi.getConstraintsDQL(); err != nil {
+			return nil, nil, nil, err
+		}
 	}
-	rows, err := isi.Db.Query(finalQuery, table.Schema, table.Name)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataConstraints, table, finalQuery, table.Schema, table.Name)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -276,16 +494,11 @@ func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.Schem
 
 // getConstraintsDQL returns the appropriate SQL query based on the existence of CHECK_CONSTRAINTS.
 func (isi InfoSchemaImpl) getConstraintsDQL() (string, error) {
-	var tableExistsCount int
-	// check if CHECK_CONSTRAINTS table exists.
-	checkQuery := `SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`
-	err := isi.Db.QueryRow(checkQuery).Scan(&tableExistsCount)
+	hasCheckConstraints, err := isi.hasCheckConstraints()
 	if err != nil {
 		return "", err
 	}
-
-	// mysql version 8.0.16 and above has CHECK_CONSTRAINTS table.
-	if tableExistsCount > 0 {
+	if hasCheckConstraints {
 		return `SELECT DISTINCT COALESCE(k.COLUMN_NAME,'') AS COLUMN_NAME,t.CONSTRAINT_NAME, t.CONSTRAINT_TYPE, COALESCE(c.CHECK_CLAUSE, '') AS CHECK_CLAUSE, COALESCE(k.ORDINAL_POSITION, 0) AS ORDINAL_POSITION
             FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS t
             LEFT JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
@@ -310,9 +523,20 @@ func (isi InfoSchemaImpl) getConstraintsDQL() (string, error) {
             ORDER BY k.ORDINAL_POSITION;`, nil
 }
 
+// hasCheckConstraints returns whether the server has the CHECK_CONSTRAINTS
+// table, which mysql version 8.0.16 and above have.
+func (isi InfoSchemaImpl) hasCheckConstraints() (bool, error) {
+	var tableExistsCount int
+	checkQuery := `SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`
+	if err := isi.Db.QueryRow(checkQuery).Scan(&tableExistsCount); err != nil {
+		return false, err
+	}
+	return tableExistsCount > 0, nil
+}
+
 // processRow handles scanning and processing of a database row for GetConstraints.
 func (isi InfoSchemaImpl) processRow(
-	rows *sql.Rows, conv *internal.Conv, primaryKeys *[]string,
+	rows common.MetadataRows, conv *internal.Conv, primaryKeys *[]string,
 	checkKeys *[]schema.CheckConstraint, m map[string][]string,
 ) error {
 	var col, constraintType, checkClause, constraintName, ordinal_position string
@@ -390,7 +614,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 		ORDER BY
 			k.REFERENCED_TABLE_NAME,
 			k.ORDINAL_POSITION;` //TODO(khajanchi): Add a UT for the change of removing column name from order by clause
-	rows, err := isi.Db.Query(q, table.Schema, table.Name)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataForeignKeys, table, q, table.Schema, table.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -414,7 +638,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 			fk.OnUpdate = OnUpdate
 			continue
 		}
-		fKeys[fKeyName] = common.FkConstraint{Name: fKeyName, Table: refTable, Refcols: []string{refCol}, Cols: []string{col}, OnDelete: OnDelete, OnUpdate: OnUpdate}
+		fKeys[fKeyName] = common.FkConstraint{Name: fKeyName, Table: isi.GetTableName(table.Schema, refTable), Refcols: []string{refCol}, Cols: []string{col}, OnDelete: OnDelete, OnUpdate: OnUpdate}
 		keyNames = append(keyNames, fKeyName)
 	}
 	sort.Strings(keyNames)
@@ -435,33 +659,35 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 
 // GetIndexes return a list of all indexes for the specified table.
 func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
-	q := `SELECT DISTINCT INDEX_NAME,COLUMN_NAME,SEQ_IN_INDEX,COLLATION,NON_UNIQUE
+	q := `SELECT DISTINCT INDEX_NAME,COLUMN_NAME,SEQ_IN_INDEX,COLLATION,NON_UNIQUE,INDEX_TYPE
 		FROM INFORMATION_SCHEMA.STATISTICS 
 		WHERE TABLE_SCHEMA = ?
 			AND TABLE_NAME = ?
 			AND INDEX_NAME != 'PRIMARY' 
 		ORDER BY INDEX_NAME, SEQ_IN_INDEX;`
-	rows, err := isi.Db.Query(q, table.Schema, table.Name)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataIndexes, table, q, table.Schema, table.Name)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var name, column, sequence, nonUnique string
+	var name, column, sequence, nonUnique, indexType string
 	var collation sql.NullString
 	indexMap := make(map[string]schema.Index)
 	var indexNames []string
 	var indexes []schema.Index
 	for rows.Next() {
-		if err := rows.Scan(&name, &column, &sequence, &collation, &nonUnique); err != nil {
+		if err := rows.Scan(&name, &column, &sequence, &collation, &nonUnique, &indexType); err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
 		}
 		if _, found := indexMap[name]; !found {
 			indexNames = append(indexNames, name)
 			indexMap[name] = schema.Index{
-				Id:     internal.GenerateIndexesId(),
-				Name:   name,
-				Unique: (nonUnique == "0"),
+				Id:       internal.GenerateIndexesId(),
+				Name:     name,
+				Unique:   (nonUnique == "0"),
+				FullText: (indexType == "FULLTEXT"),
+				Spatial:  (indexType == "SPATIAL"),
 			}
 		}
 		index := indexMap[name]
@@ -477,6 +703,70 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 	return indexes, nil
 }
 
+// WithBulkMetadata implements the common.BulkMetadataSource interface. The
+// metadata of the tables of the database is fetched with the queries of
+// that of a table, without their filter on the table. That of the tables of
+// several databases is fetched per table.
+func (isi InfoSchemaImpl) WithBulkMetadata(tables []common.SchemaAndName) (common.InfoSchema, error) {
+	if len(isi.databases()) > 1 {
+		return isi, nil
+	}
+	hasCheckConstraints, err := isi.hasCheckConstraints()
+	if err != nil {
+		return isi, err
+	}
+	constraintsQuery := `SELECT k.TABLE_SCHEMA, k.TABLE_NAME, k.COLUMN_NAME, t.CONSTRAINT_TYPE
+		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS t
+		INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
+		ON t.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+		AND t.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+		AND t.TABLE_NAME = k.TABLE_NAME
+		WHERE t.TABLE_SCHEMA = ?
+		ORDER BY k.TABLE_NAME, k.ORDINAL_POSITION;`
+	if hasCheckConstraints {
+		constraintsQuery = `SELECT DISTINCT t.TABLE_SCHEMA, t.TABLE_NAME, COALESCE(k.COLUMN_NAME,'') AS COLUMN_NAME, t.CONSTRAINT_NAME, t.CONSTRAINT_TYPE, COALESCE(c.CHECK_CLAUSE, '') AS CHECK_CLAUSE, COALESCE(k.ORDINAL_POSITION, 0) AS ORDINAL_POSITION
+		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS t
+		LEFT JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
+		ON t.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+		AND t.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+		AND t.TABLE_NAME = k.TABLE_NAME
+		LEFT JOIN INFORMATION_SCHEMA.CHECK_CONSTRAINTS AS c
+		ON t.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+		AND t.TABLE_SCHEMA = c.CONSTRAINT_SCHEMA
+		WHERE t.TABLE_SCHEMA = ?
+		ORDER BY t.TABLE_NAME, COALESCE(k.ORDINAL_POSITION, 0);`
+	}
+	queries := map[string]string{
+		common.MetadataColumns: `SELECT c.table_schema, c.table_name, c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.extra, c.generation_expression, c.character_set_name, c.collation_name
+		FROM information_schema.COLUMNS c
+		WHERE c.table_schema = ? ORDER BY c.table_name, c.ordinal_position;`,
+		common.MetadataConstraints: constraintsQuery,
+		common.MetadataForeignKeys: `SELECT k.TABLE_SCHEMA, k.TABLE_NAME, k.REFERENCED_TABLE_NAME, k.COLUMN_NAME, k.REFERENCED_COLUMN_NAME, k.CONSTRAINT_NAME, r.DELETE_RULE, r.UPDATE_RULE
+		FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS AS r
+		INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
+			ON r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+			AND r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+			AND r.TABLE_NAME = k.TABLE_NAME
+			AND r.REFERENCED_TABLE_NAME = k.REFERENCED_TABLE_NAME
+			AND k.REFERENCED_TABLE_SCHEMA = k.TABLE_SCHEMA
+		WHERE k.TABLE_SCHEMA = ?
+		ORDER BY k.TABLE_NAME, k.REFERENCED_TABLE_NAME, k.ORDINAL_POSITION;`,
+		common.MetadataIndexes: `SELECT DISTINCT TABLE_SCHEMA, TABLE_NAME, INDEX_NAME, COLUMN_NAME, SEQ_IN_INDEX, COLLATION, NON_UNIQUE, INDEX_TYPE
+		FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE TABLE_SCHEMA = ?
+			AND INDEX_NAME != 'PRIMARY'
+		ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX;`,
+	}
+	metadata := &common.BulkMetadata{}
+	for kind, q := range queries {
+		if err := metadata.Fetch(isi.Db, kind, q, isi.databases()[0]); err != nil {
+			return isi, err
+		}
+	}
+	isi.Metadata = metadata
+	return isi, nil
+}
+
 // StartChangeDataCapture is used for automatic triggering of Datastream job when
 // performing a streaming migration.
 func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
@@ -523,7 +813,9 @@ func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migration
 	return dfOutput, nil
 }
 
-func toType(dataType string, columnType string, charLen sql.NullInt64, numericPrecision, numericScale sql.NullInt64) schema.Type {
+// ToType returns the source schema type of a column from its information
+// schema attributes.
+func ToType(dataType string, columnType string, charLen sql.NullInt64, numericPrecision, numericScale sql.NullInt64) schema.Type {
 	switch {
 	case dataType == "set":
 		return schema.Type{Name: dataType, ArrayBounds: []int64{-1}}
@@ -576,7 +868,9 @@ func valsToStrings(vals []sql.RawBytes) []string {
 	return s
 }
 
-func createSequence(conv *internal.Conv) ddl.Sequence {
+// CreateSequence adds a sequence for an auto increment column to
+// conv.SrcSequences.
+func CreateSequence(conv *internal.Conv) ddl.Sequence {
 	id := internal.GenerateSequenceId()
 	sequenceName := "Sequence" + id[1:]
 	sequence := ddl.Sequence{
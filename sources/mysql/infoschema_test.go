@@ -84,17 +84,17 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "user"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
 			rows: [][]driver.Value{
-				{"user_id", "text", "text", "NO", "uuid()", nil, nil, nil, constants.DEFAULT_GENERATED},
-				{"name", "text", "text", "NO", "default_name", nil, nil, nil, nil},
-				{"ref", "bigint", "bigint", "NO", nil, nil, nil, nil, nil}},
+				{"user_id", "text", "text", "NO", "uuid()", nil, nil, nil, constants.DEFAULT_GENERATED, nil, nil, nil},
+				{"name", "text", "text", "NO", "default_name", nil, nil, nil, nil, nil, nil, nil},
+				{"ref", "bigint", "bigint", "NO", nil, nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "user"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
 		},
 		{
 			query: regexp.QuoteMeta(`SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`),
@@ -125,24 +125,24 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "cart"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
 			rows: [][]driver.Value{
-				{"productid", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"userid", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"quantity", "bigint", "bigint", "YES", nil, nil, 64, 0, nil},
+				{"productid", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"userid", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"quantity", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "cart"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
 			rows: [][]driver.Value{
-				{"index1", "userid", 1, sql.NullString{Valid: false}, "0"},
-				{"index2", "userid", 1, "A", "1"},
-				{"index2", "productid", 2, "D", "1"},
-				{"index3", "productid", 1, "A", "0"},
-				{"index3", "userid", 2, "D", "0"},
+				{"index1", "userid", 1, sql.NullString{Valid: false}, "0", "BTREE"},
+				{"index2", "userid", 1, "A", "1", "BTREE"},
+				{"index2", "productid", 2, "D", "1", "BTREE"},
+				{"index3", "productid", 1, "A", "0", "BTREE"},
+				{"index3", "userid", 2, "D", "0", "BTREE"},
 			},
 		},
 		{
@@ -169,17 +169,17 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "product"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
 			rows: [][]driver.Value{
-				{"product_id", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"product_name", "text", "text", "NO", nil, nil, nil, nil, nil},
+				{"product_id", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"product_name", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "product"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
 		},
 		{
 			query: regexp.QuoteMeta(`SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`),
@@ -207,35 +207,35 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
 			rows: [][]driver.Value{
-				{"id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil},
-				{"s", "set", "set", "YES", nil, nil, nil, nil, nil},
-				{"txt", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"b", "boolean", "boolean", "YES", nil, nil, nil, nil, nil},
-				{"bs", "bigint", "bigint", "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil},
-				{"bl", "blob", "blob", "YES", nil, nil, nil, nil, nil},
-				{"c", "char", "char(1)", "YES", nil, 1, nil, nil, nil},
-				{"c8", "char", "char(8)", "YES", nil, 8, nil, nil, nil},
-				{"d", "date", "date", "YES", nil, nil, nil, nil, nil},
-				{"dec", "decimal", "decimal(20,5)", "YES", nil, nil, 20, 5, nil},
-				{"f8", "double", "double", "YES", nil, nil, 53, nil, nil},
-				{"f4", "float", "float", "YES", nil, nil, 24, nil, nil},
-				{"i8", "bigint", "bigint", "YES", nil, nil, 64, 0, nil},
-				{"i4", "integer", "integer", "YES", nil, nil, 32, 0, "auto_increment"},
-				{"i2", "smallint", "smallint", "YES", nil, nil, 16, 0, nil},
-				{"si", "integer", "integer", "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil},
-				{"ts", "datetime", "datetime", "YES", nil, nil, nil, nil, nil},
-				{"tz", "timestamp", "timestamp", "YES", nil, nil, nil, nil, nil},
-				{"vc", "varchar", "varchar", "YES", nil, nil, nil, nil, nil},
-				{"vc6", "varchar", "varchar(6)", "YES", nil, 6, nil, nil, nil},
+				{"id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil, nil, nil},
+				{"s", "set", "set", "YES", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"txt", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"b", "boolean", "boolean", "YES", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"bs", "bigint", "bigint", "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil, nil, nil, nil},
+				{"bl", "blob", "blob", "YES", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"c", "char", "char(1)", "YES", nil, 1, nil, nil, nil, nil, nil, nil},
+				{"c8", "char", "char(8)", "YES", nil, 8, nil, nil, nil, nil, nil, nil},
+				{"d", "date", "date", "YES", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"dec", "decimal", "decimal(20,5)", "YES", nil, nil, 20, 5, nil, nil, nil, nil},
+				{"f8", "double", "double", "YES", nil, nil, 53, nil, nil, nil, nil, nil},
+				{"f4", "float", "float", "YES", nil, nil, 24, nil, nil, nil, nil, nil},
+				{"i8", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil, nil, nil},
+				{"i4", "integer", "integer", "YES", nil, nil, 32, 0, "auto_increment", nil, nil, nil},
+				{"i2", "smallint", "smallint", "YES", nil, nil, 16, 0, nil, nil, nil, nil},
+				{"si", "integer", "integer", "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil, nil, nil, nil},
+				{"ts", "datetime", "datetime", "YES", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"tz", "timestamp", "timestamp", "YES", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"vc", "varchar", "varchar", "YES", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"vc6", "varchar", "varchar(6)", "YES", nil, 6, nil, nil, nil, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
 		},
 		{
 			query: regexp.QuoteMeta(`SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`),
@@ -262,23 +262,23 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "test_ref"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
 			rows: [][]driver.Value{
-				{"ref_id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil},
-				{"ref_txt", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"abc", "text", "text", "NO", nil, nil, nil, nil, nil},
+				{"ref_id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil, nil, nil},
+				{"ref_txt", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"abc", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "test_ref"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
 		},
 	}
 	db := mkMockDB(t, ms)
 	conv := internal.MakeConv()
-	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}}
+	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, false, "", nil, nil}
 	commonInfoSchema := common.InfoSchemaImpl{}
 	_, err := commonInfoSchema.GenerateSrcSchema(conv, isi, 1)
 	assert.Nil(t, err)
@@ -379,21 +379,21 @@ func TestProcessSchemaMYSQLPKOrdering(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "pk_order"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
 			rows: [][]driver.Value{
-				{"pk_1", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"pk_2", "text", "text", "NO", nil, nil, nil, nil, nil},
+				{"pk_1", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"pk_2", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
 			},
 		},
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "pk_order"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
 		},
 	}
 	db := mkMockDB(t, ms)
 	conv := internal.MakeConv()
-	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}}
+	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, false, "", nil, nil}
 	commonInfoSchema := common.InfoSchemaImpl{}
 	_, err := commonInfoSchema.GenerateSrcSchema(conv, isi, 1)
 	assert.Nil(t, err)
@@ -459,7 +459,7 @@ func TestProcessData(t *testing.T) {
 		func(table string, cols []string, vals []interface{}) {
 			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
 		})
-	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}}
+	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, false, "", nil, nil}
 	commonInfoSchema := common.InfoSchemaImpl{}
 	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
 	assert.Equal(t,
@@ -508,17 +508,17 @@ func TestProcessData_MultiCol(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
 			rows: [][]driver.Value{
-				{"a", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"b", "double", "double", "YES", nil, nil, 53, nil, nil},
-				{"c", "bigint", "bigint", "YES", nil, nil, 64, 0, nil},
+				{"a", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"b", "double", "double", "YES", nil, nil, 53, nil, nil, nil, nil, nil},
+				{"c", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil, nil, nil},
 			},
 		},
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
 		},
 		{
 			query: "SELECT (.+) FROM `test`.`test`",
@@ -531,7 +531,7 @@ func TestProcessData_MultiCol(t *testing.T) {
 	}
 	db := mkMockDB(t, ms)
 	conv := internal.MakeConv()
-	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}}
+	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, false, "", nil, nil}
 	processSchema := common.ProcessSchemaImpl{}
 	mockAccessor := new(mocks.MockExpressionVerificationAccessor)
 	ctx := context.Background()
@@ -624,17 +624,17 @@ func TestProcessSchema_Sharded(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
 			rows: [][]driver.Value{
-				{"a", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"b", "double", "double", "YES", nil, nil, 53, nil, nil},
-				{"c", "bigint", "bigint", "YES", nil, nil, 64, 0, nil},
+				{"a", "text", "text", "NO", nil, nil, nil, nil, nil, nil, nil, nil},
+				{"b", "double", "double", "YES", nil, nil, 53, nil, nil, nil, nil, nil},
+				{"c", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil, nil, nil},
 			},
 		},
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
 		},
 		{
 			query: "SELECT (.+) FROM `test`.`test`",
@@ -647,7 +647,7 @@ func TestProcessSchema_Sharded(t *testing.T) {
 	}
 	db := mkMockDB(t, ms)
 	conv := internal.MakeConv()
-	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}}
+	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, false, "", nil, nil}
 	mockAccessor := new(mocks.MockExpressionVerificationAccessor)
 	ctx := context.Background()
 	mockAccessor.On("VerifyExpressions", ctx, mock.Anything).Return(internal.VerifyExpressionsOutput{
@@ -671,7 +671,7 @@ func TestProcessSchema_Sharded(t *testing.T) {
 				"b":                  {Name: "b", T: ddl.Type{Name: ddl.Float64}},
 				"c":                  {Name: "c", T: ddl.Type{Name: ddl.Int64}},
 				"synth_id":           {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
-				"migration_shard_id": {Name: "migration_shard_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+				"migration_shard_id": {Name: "migration_shard_id", T: ddl.Type{Name: ddl.String, Len: 50}, Hidden: true},
 			},
 			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
 		},
@@ -699,7 +699,7 @@ func TestSetRowStats(t *testing.T) {
 	db := mkMockDB(t, ms)
 	conv := internal.MakeConv()
 	conv.SetDataMode()
-	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}}
+	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, false, "", nil, nil}
 	commonInfoSchema := common.InfoSchemaImpl{}
 	commonInfoSchema.SetRowStats(conv, isi)
 	assert.Equal(t, int64(5), conv.Stats.Rows["test1"])
@@ -777,3 +777,160 @@ func TestGetConstraints_CheckConstraintsTableAbsent(t *testing.T) {
 	_, _, _, err := isi.GetConstraints(conv, common.SchemaAndName{Schema: "your_schema", Name: "your_table"})
 	assert.Error(t, err)
 }
+
+func TestGetEnumValues(t *testing.T) {
+	assert.Equal(t, []string{"small", "medium", "it's large"}, GetEnumValues("enum", "enum('small','medium','it''s large')"))
+	assert.Equal(t, []string{"a", "b"}, GetEnumValues("set", "set('a','b')"))
+	assert.Nil(t, GetEnumValues("varchar", "varchar(10)"))
+}
+
+func TestGetMaxColumnLengths(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT MAX(LENGTH(`data`)), MAX(CHAR_LENGTH(`notes`)), MAX(CHAR_LENGTH(`title`)) FROM (SELECT `data`, `notes`, `title` FROM `test`.`t` LIMIT 100) AS sample;"),
+			cols:  []string{"data", "notes", "title"},
+			rows:  [][]driver.Value{{12, 40, nil}},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, false, "", nil, nil}
+	lengths, err := isi.GetMaxColumnLengths(common.SchemaAndName{Schema: "test", Name: "t"}, map[string]bool{"title": false, "data": true, "notes": false}, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"data": 12, "notes": 40}, lengths)
+}
+
+func TestWithBulkMetadata(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES`)).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM information_schema.COLUMNS c`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"}).
+			AddRow("test", "cart", "productid", "text", "text", "NO", nil, nil, nil, nil, "", nil, nil, nil).
+			AddRow("test", "cart", "userid", "text", "text", "NO", nil, nil, nil, nil, "", nil, nil, nil).
+			AddRow("test", "product", "productid", "varchar", "varchar(20)", "NO", nil, 20, nil, nil, "", nil, nil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS t`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME", "CONSTRAINT_NAME", "CONSTRAINT_TYPE", "CHECK_CLAUSE", "ORDINAL_POSITION"}).
+			AddRow("test", "cart", "productid", "PRIMARY", "PRIMARY KEY", "", 1).
+			AddRow("test", "cart", "userid", "PRIMARY", "PRIMARY KEY", "", 2).
+			AddRow("test", "product", "productid", "PRIMARY", "PRIMARY KEY", "", 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS AS r`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME", "REFERENCED_TABLE_NAME", "COLUMN_NAME", "REFERENCED_COLUMN_NAME", "CONSTRAINT_NAME", "DELETE_RULE", "UPDATE_RULE"}).
+			AddRow("test", "cart", "product", "productid", "productid", "fk_product", "CASCADE", "NO ACTION"))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM INFORMATION_SCHEMA.STATISTICS`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME", "INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"}).
+			AddRow("test", "cart", "idx_user", "userid", 1, "D", 1, "BTREE"))
+
+	isi := InfoSchemaImpl{DbName: "test", Db: db}
+	bulk, err := isi.WithBulkMetadata([]common.SchemaAndName{{Schema: "test", Name: "cart"}, {Schema: "test", Name: "product"}})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	// The metadata of tables is read from that fetched in bulk, without
+	// querying the database again.
+	isi = bulk.(InfoSchemaImpl)
+	conv := internal.MakeConv()
+	cart := common.SchemaAndName{Schema: "test", Name: "cart"}
+	product := common.SchemaAndName{Schema: "test", Name: "product"}
+
+	primaryKeys, _, _, err := isi.GetConstraints(conv, cart)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"productid", "userid"}, primaryKeys)
+	primaryKeys, _, _, err = isi.GetConstraints(conv, product)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"productid"}, primaryKeys)
+
+	colDefs, colIds, err := isi.GetColumns(conv, product, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(colIds))
+	assert.Equal(t, schema.Type{Name: "varchar", Mods: []int64{20}}, colDefs[colIds[0]].Type)
+
+	foreignKeys, err := isi.GetForeignKeys(conv, cart)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(foreignKeys))
+	assert.Equal(t, "product", foreignKeys[0].ReferTableName)
+	assert.Equal(t, []string{"productid"}, foreignKeys[0].ColumnNames)
+	assert.Equal(t, "CASCADE", foreignKeys[0].OnDelete)
+	foreignKeys, err = isi.GetForeignKeys(conv, product)
+	assert.Nil(t, err)
+	assert.Nil(t, foreignKeys)
+
+	indexes, err := isi.GetIndexes(conv, cart, map[string]string{"userid": "c2"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(indexes))
+	assert.Equal(t, "idx_user", indexes[0].Name)
+	assert.False(t, indexes[0].Unique)
+	assert.Equal(t, []schema.Key{{ColId: "c2", Desc: true}}, indexes[0].Keys)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
+
+func TestSelectedDatabases(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT table_name FROM information_schema.tables`)).WithArgs("shop").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("orders"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT table_name FROM information_schema.tables`)).WithArgs("hr").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("employees").AddRow("departments"))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS AS r`)).WithArgs("hr", "employees").
+		WillReturnRows(sqlmock.NewRows([]string{"REFERENCED_TABLE_NAME", "COLUMN_NAME", "REFERENCED_COLUMN_NAME", "CONSTRAINT_NAME", "DELETE_RULE", "UPDATE_RULE"}).
+			AddRow("departments", "dept_id", "id", "fk_dept", "NO ACTION", "NO ACTION"))
+
+	sourceProfile := profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Schemas: []string{"shop", "hr"}}}
+	isi := InfoSchemaImpl{DbName: "shop", Db: db, SourceProfile: sourceProfile}
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "shop", Name: "orders"}, {Schema: "hr", Name: "employees"}, {Schema: "hr", Name: "departments"}}, tables)
+
+	// Tables of databases other than the one connected to are prefixed
+	// with their database.
+	assert.Equal(t, "orders", isi.GetTableName("shop", "orders"))
+	assert.Equal(t, "hr.employees", isi.GetTableName("hr", "employees"))
+	assert.Equal(t, "`hr`.`employees`", isi.quotedTableName(schema.Table{Name: "hr.employees", Schema: "hr"}))
+	assert.Equal(t, "`shop`.`orders`", isi.quotedTableName(schema.Table{Name: "orders"}))
+
+	// The metadata of tables of several databases isn't fetched in bulk.
+	bulk, err := isi.WithBulkMetadata(tables)
+	assert.Nil(t, err)
+	assert.Nil(t, bulk.(InfoSchemaImpl).Metadata)
+
+	foreignKeys, err := isi.GetForeignKeys(internal.MakeConv(), common.SchemaAndName{Schema: "hr", Name: "employees"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(foreignKeys))
+	assert.Equal(t, "hr.departments", foreignKeys[0].ReferTableName)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetViews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT TABLE_NAME, VIEW_DEFINITION FROM INFORMATION_SCHEMA.VIEWS`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "VIEW_DEFINITION"}).
+			AddRow("active_users", "select `test`.`users`.`id` AS `id` from `test`.`users`"))
+	isi := InfoSchemaImpl{DbName: "test", Db: db}
+	views, err := isi.GetViews()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []schema.View{{Name: "active_users", Schema: "test", Query: "select `test`.`users`.`id` AS `id` from `test`.`users`"}}, views)
+}
+
+func TestGetRoutines(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT ROUTINE_NAME, ROUTINE_TYPE, ROUTINE_DEFINITION FROM INFORMATION_SCHEMA.ROUTINES`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"ROUTINE_NAME", "ROUTINE_TYPE", "ROUTINE_DEFINITION"}).
+			AddRow("archive_orders", "PROCEDURE", "BEGIN DELETE FROM orders; END").
+			AddRow("discount", "FUNCTION", nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM INFORMATION_SCHEMA.TRIGGERS`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TRIGGER_NAME", "EVENT_OBJECT_TABLE", "ACTION_TIMING", "EVENT_MANIPULATION", "ACTION_STATEMENT"}).
+			AddRow("audit_orders", "orders", "AFTER", "INSERT", "INSERT INTO audit (id) VALUES (NEW.id)"))
+	isi := InfoSchemaImpl{DbName: "test", Db: db}
+	routines, err := isi.GetRoutines()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []schema.Routine{
+		{Name: "archive_orders", Schema: "test", Kind: schema.RoutineProcedure, Body: "BEGIN DELETE FROM orders; END"},
+		{Name: "discount", Schema: "test", Kind: schema.RoutineFunction},
+		{Name: "audit_orders", Schema: "test", Kind: schema.RoutineTrigger, Table: "orders", Timing: "AFTER", Events: []string{"INSERT"}, Body: "INSERT INTO audit (id) VALUES (NEW.id)"},
+	}, routines)
+}
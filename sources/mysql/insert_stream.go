@@ -0,0 +1,298 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+// With the default --extended-insert, mysqldump writes the rows of a table
+// as INSERT statements holding many rows each, on a single line that can be
+// hundreds of MB long. Rather than reading and parsing such statements
+// whole, processMySQLDump streams them: rows are read one at a time and
+// grouped into batches of about insertBatchSize bytes, and each batch is
+// parsed as an INSERT statement of its own. Batches are parsed
+// concurrently, by up to insertParseWorkers goroutines, and the parsed
+// statements are processed in order on the goroutine reading the dump,
+// since conv isn't safe for concurrent use.
+var (
+	insertBatchSize    = 1 << 20
+	insertParseWorkers = runtime.GOMAXPROCS(0)
+)
+
+// insertPrefix is how mysqldump starts INSERT statements.
+var insertPrefix = []byte("INSERT INTO ")
+
+// maxInsertPrefix bounds the bytes read looking for the VALUES keyword
+// that precedes the rows of an INSERT statement.
+const maxInsertPrefix = 1 << 16
+
+// insertBatch is an INSERT statement made of some of the rows of an INSERT
+// statement of the dump.
+type insertBatch struct {
+	line   int    // Line number of the statement in the dump.
+	offset int    // Offset of the statement in the dump.
+	prefix string // e.g. "INSERT INTO `t` VALUES ".
+	rows   []string
+	suffix string // Ends the statement, e.g. ";".
+}
+
+// parsedBatch is the result of parsing an insertBatch.
+type parsedBatch struct {
+	batch insertBatch
+	stmts []ast.StmtNode
+	// badStmts are single row statements that couldn't be parsed.
+	badStmts []string
+}
+
+// parseBatch parses b. If that fails, the rows of b are parsed one at a
+// time, like handleInsertStatement does, so that only invalid rows are
+// skipped.
+func parseBatch(b insertBatch) parsedBatch {
+	pb := parsedBatch{batch: b}
+	tree, _, err := parser.New().Parse(b.prefix+strings.Join(b.rows, ",")+b.suffix, "", "")
+	if err == nil {
+		pb.stmts = tree
+		return pb
+	}
+	for _, row := range b.rows {
+		stmt := b.prefix + row + ";"
+		tree, _, err := parser.New().Parse(stmt, "", "")
+		if err != nil {
+			pb.badStmts = append(pb.badStmts, stmt)
+			continue
+		}
+		pb.stmts = append(pb.stmts, tree...)
+	}
+	return pb
+}
+
+// insertPipeline parses insertBatches concurrently and processes the
+// parsed statements in the order the batches were submitted.
+type insertPipeline struct {
+	conv    *internal.Conv
+	workers chan struct{}
+	pending []chan parsedBatch
+}
+
+func newInsertPipeline(conv *internal.Conv) *insertPipeline {
+	return &insertPipeline{conv: conv, workers: make(chan struct{}, max(insertParseWorkers, 1))}
+}
+
+// submit starts parsing b, and processes the batches that have been
+// parsed. To bound memory use, it waits for the oldest batch when as many
+// batches are pending as there are workers.
+func (p *insertPipeline) submit(b insertBatch) {
+	result := make(chan parsedBatch, 1)
+	p.pending = append(p.pending, result)
+	p.workers <- struct{}{}
+	go func() {
+		result <- parseBatch(b)
+		<-p.workers
+	}()
+	for len(p.pending) > 0 {
+		if len(p.pending) > cap(p.workers) {
+			p.process(<-p.pending[0])
+			continue
+		}
+		select {
+		case pb := <-p.pending[0]:
+			p.process(pb)
+		default:
+			return
+		}
+	}
+}
+
+// flush waits for all pending batches and processes them.
+func (p *insertPipeline) flush() {
+	for len(p.pending) > 0 {
+		p.process(<-p.pending[0])
+	}
+}
+
+func (p *insertPipeline) process(pb parsedBatch) {
+	p.pending = p.pending[1:]
+	conv := p.conv
+	for _, stmt := range pb.badStmts {
+		if conv.SchemaMode() {
+			conv.Unexpected(fmt.Sprintf("Either unsupported value is encountered or syntax is incorrect for following statement : \n%s", stmt))
+		}
+		conv.SkipStatement("InsertStmt")
+	}
+	for _, stmt := range pb.stmts {
+		processStatement(conv, stmt)
+	}
+	internal.VerbosePrintf("Parsed INSERT rows at line=%d/fpos=%d: %d rows\n", pb.batch.line, pb.batch.offset, len(pb.batch.rows))
+	logger.Log.Debug(fmt.Sprintf("Parsed INSERT rows at line=%d/fpos=%d: %d rows\n", pb.batch.line, pb.batch.offset, len(pb.batch.rows)))
+}
+
+// readInsert reads an INSERT statement from r, and submits its rows to p
+// in batches. Statements whose rows can't be streamed, e.g. INSERT ...
+// SELECT statements, are read and parsed like readAndParseChunk does
+// instead, and the parsed statements are returned.
+func readInsert(conv *internal.Conv, r *internal.Reader, p *insertPipeline) ([]byte, []ast.StmtNode, error) {
+	line, offset := r.LineNumber, r.Offset
+	prefix, ok := readInsertPrefix(r)
+	if !ok {
+		if !bytes.HasSuffix(prefix, []byte("\n")) && !r.EOF {
+			prefix = append(prefix, r.ReadLine()...)
+		}
+		return readAndParseLines(conv, r, prefix)
+	}
+	b := insertBatch{line: line, offset: offset, prefix: string(prefix)}
+	size := 0
+	var q quoteState
+	var row bytes.Buffer
+	c := byte('(')
+	for {
+		switch {
+		case c == '(':
+			row.Reset()
+			row.WriteByte(c)
+			if err := readRow(r, &row); err != nil {
+				return nil, nil, fmt.Errorf("can't read INSERT statement at line %d: %w", line, err)
+			}
+			b.rows = append(b.rows, row.String())
+			size += row.Len()
+			if size >= insertBatchSize {
+				b.suffix = ";"
+				p.submit(b)
+				b = insertBatch{line: r.LineNumber, offset: r.Offset, prefix: b.prefix}
+				size = 0
+			}
+		case c == ',' || isSpace(c):
+		case c == ';':
+			b.suffix = ";"
+			if len(b.rows) > 0 {
+				p.submit(b)
+			}
+			if bytes.Equal(r.Peek(1), []byte("\n")) {
+				r.ReadByte()
+			}
+			return nil, nil, nil
+		default:
+			// Clauses following the rows, such as ON DUPLICATE KEY UPDATE,
+			// end the last batch.
+			var suffix bytes.Buffer
+			for {
+				suffix.WriteByte(c)
+				if !q.next(c) && c == ';' {
+					break
+				}
+				var err error
+				if c, err = r.ReadByte(); err != nil {
+					return nil, nil, fmt.Errorf("can't read INSERT statement at line %d: %w", line, io.ErrUnexpectedEOF)
+				}
+			}
+			b.suffix = suffix.String()
+			p.submit(b)
+			return nil, nil, nil
+		}
+		var err error
+		if c, err = r.ReadByte(); err != nil {
+			return nil, nil, fmt.Errorf("can't read INSERT statement at line %d: %w", line, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+// readInsertPrefix reads the part of an INSERT statement that precedes its
+// rows, and the opening parenthesis of the first row. It returns false if
+// the statement has no rows to stream, in which case the bytes read up to
+// the end of the statement (or maxInsertPrefix bytes) are returned.
+func readInsertPrefix(r *internal.Reader) ([]byte, bool) {
+	var prefix []byte
+	var q quoteState
+	for len(prefix) < maxInsertPrefix {
+		c, err := r.ReadByte()
+		if err != nil {
+			return prefix, false
+		}
+		if !q.next(c) {
+			switch c {
+			case '(':
+				if bytes.HasSuffix(bytes.ToUpper(bytes.TrimRight(prefix, " \t\r\n")), []byte("VALUES")) {
+					return prefix, true
+				}
+			case ';':
+				return append(prefix, c), false
+			}
+		}
+		prefix = append(prefix, c)
+	}
+	return prefix, false
+}
+
+// readRow reads a row of an INSERT statement into row, which holds its
+// opening parenthesis, up to the matching closing parenthesis.
+func readRow(r *internal.Reader, row *bytes.Buffer) error {
+	var q quoteState
+	depth := 1
+	for depth > 0 {
+		c, err := r.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		row.WriteByte(c)
+		if q.next(c) {
+			continue
+		}
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+	}
+	return nil
+}
+
+// quoteState tracks whether the bytes of a statement are quoted, i.e.
+// part of a string or of a quoted identifier.
+type quoteState struct {
+	quote   byte
+	escaped bool
+}
+
+// next reports whether c is quoted, including opening and closing quotes.
+func (q *quoteState) next(c byte) bool {
+	switch {
+	case q.escaped:
+		q.escaped = false
+	case q.quote == 0:
+		if c != '\'' && c != '"' && c != '`' {
+			return false
+		}
+		q.quote = c
+	case c == '\\' && q.quote != '`':
+		q.escaped = true
+	case c == q.quote:
+		q.quote = 0
+	}
+	return true
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
@@ -15,6 +15,7 @@
 package mysql
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -26,6 +27,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/format"
@@ -71,13 +73,24 @@ func (ddi DbDumpImpl) ProcessDump(conv *internal.Conv, r *internal.Reader) error
 // In data mode, ProcessMySQLDump uses this schema to convert MySQL data
 // and writes it to Spanner, using the data sink specified in conv.
 func processMySQLDump(conv *internal.Conv, r *internal.Reader) error {
+	p := newInsertPipeline(conv)
 	for {
 		startLine := r.LineNumber
 		startOffset := r.Offset
-		b, stmts, err := readAndParseChunk(conv, r)
+		var b []byte
+		var stmts []ast.StmtNode
+		var err error
+		if bytes.Equal(r.Peek(len(insertPrefix)), insertPrefix) {
+			b, stmts, err = readInsert(conv, r, p)
+		} else {
+			b, stmts, err = readAndParseChunk(conv, r)
+		}
 		if err != nil {
 			return err
 		}
+		if len(stmts) > 0 {
+			p.flush()
+		}
 		for _, stmt := range stmts {
 			isInsert := processStatement(conv, stmt)
 			internal.VerbosePrintf("Parsed SQL command at line=%d/fpos=%d: %d stmts (%d lines, %d bytes) Insert Statement=%v\n", startLine, startOffset, 1, r.LineNumber-startLine, len(b), isInsert)
@@ -87,6 +100,7 @@ func processMySQLDump(conv *internal.Conv, r *internal.Reader) error {
 			break
 		}
 	}
+	p.flush()
 	internal.ResolveForeignKeyIds(conv.SrcSchema)
 	return nil
 }
@@ -98,8 +112,15 @@ func processMySQLDump(conv *internal.Conv, r *internal.Reader) error {
 // be large. Fortunately mysqldump limits the size of insert statements
 // (default is 24MB, but configurable via --max-allowed-packet), and so
 // the chunks of file we read/parse are manageable, even for mysqldump
-// files containing tens or hundreds of GB of data.
+// files containing tens or hundreds of GB of data. INSERT statements
+// written by mysqldump are streamed by readInsert instead.
 func readAndParseChunk(conv *internal.Conv, r *internal.Reader) ([]byte, []ast.StmtNode, error) {
+	return readAndParseLines(conv, r, r.ReadLine())
+}
+
+// readAndParseLines is readAndParseChunk for a chunk whose first line, b,
+// has already been read.
+func readAndParseLines(conv *internal.Conv, r *internal.Reader, b []byte) ([]byte, []ast.StmtNode, error) {
 	var l [][]byte
 
 	// Regex for ignoring strings of the form /*!50717 SELECT COUNT(*) INTO @rocksdb_has_p_s_session_variables FROM INFORMATION_SCHEMA.TABLES */;
@@ -107,7 +128,6 @@ func readAndParseChunk(conv *internal.Conv, r *internal.Reader) ([]byte, []ast.S
 	// Pingcap Issue : https://github.com/pingcap/parser/issues/1370
 	regexExp := regexp.MustCompile(`^(\/\*[!0-9\s]*SELECT[^\n]*INTO[\s]+@[^\n]*\*\/;\n)$`)
 	for {
-		b := r.ReadLine()
 		l = append(l, b)
 		// If we see a semicolon or eof, we're likely to have a command, so try to parse it.
 		// Note: we could just parse every iteration, but that would mean more attempts at parsing.
@@ -145,6 +165,7 @@ func readAndParseChunk(conv *internal.Conv, r *internal.Reader) ([]byte, []ast.S
 		if r.EOF {
 			return nil, nil, fmt.Errorf("Error parsing last %d line(s) of input", len(l))
 		}
+		b = r.ReadLine()
 	}
 }
 
@@ -207,6 +228,12 @@ func processCreateIndex(conv *internal.Conv, stmt *ast.CreateIndexStmt) {
 func processSetStmt(conv *internal.Conv, stmt *ast.SetStmt) {
 	if stmt.Variables != nil && len(stmt.Variables) > 0 {
 		for _, variable := range stmt.Variables {
+			if variable.Name == ast.SetNames {
+				// Values of the dump are in the character set of the client.
+				if val, ok := variable.Value.(*driver.ValueExpr); ok && val.GetValue() != nil {
+					conv.SrcCharset = strings.ToLower(fmt.Sprintf("%v", val.GetValue()))
+				}
+			}
 			if variable.Name == "TIME_ZONE" {
 				value := variable.Value
 				switch val := value.(type) {
@@ -251,6 +278,7 @@ func processCreateTable(conv *internal.Conv, stmt *ast.CreateTableStmt) {
 	var index []schema.Index
 
 	checkConstraints := getCheckConstraints(stmt.Constraints)
+	charset, collation := getTableCharset(stmt.Options)
 
 	for _, element := range stmt.Cols {
 		_, col, constraint, err := processColumn(conv, tableName, element)
@@ -258,6 +286,12 @@ func processCreateTable(conv *internal.Conv, stmt *ast.CreateTableStmt) {
 			logStmtError(conv, stmt, err)
 			return
 		}
+		if charTypes[col.Type.Name] && col.Charset == "" {
+			col.Charset = charset
+		}
+		if charTypes[col.Type.Name] && col.Collation == "" {
+			col.Collation = collation
+		}
 		col.Id = internal.GenerateColumnId() //assigns new id
 		colDef[col.Id] = col
 		colIds = append(colIds, col.Id)
@@ -276,9 +310,10 @@ func processCreateTable(conv *internal.Conv, stmt *ast.CreateTableStmt) {
 			// database schemas into schema.go.
 			idxId := internal.GenerateIndexesId()
 			index = append(index, schema.Index{
-				Name:   "",
-				Id:     idxId,
-				Unique: true,
+				Name:       "",
+				Id:         idxId,
+				Unique:     true,
+				Constraint: true,
 				Keys: []schema.Key{
 					{
 						ColId: col.Id,
@@ -305,6 +340,24 @@ func processCreateTable(conv *internal.Conv, stmt *ast.CreateTableStmt) {
 	}
 }
 
+// getTableCharset returns the default character set and collation of the
+// columns of a table, from the options of its CREATE TABLE statement.
+func getTableCharset(options []*ast.TableOption) (string, string) {
+	var charset, collation string
+	for _, option := range options {
+		switch option.Tp {
+		case ast.TableOptionCharset:
+			charset = strings.ToLower(option.StrValue)
+		case ast.TableOptionCollate:
+			collation = strings.ToLower(option.StrValue)
+		}
+	}
+	if charset != "" && collation == "" {
+		collation = defaultCollation(charset)
+	}
+	return charset, collation
+}
+
 func processConstraint(conv *internal.Conv, tableId string, constraint *ast.Constraint, stmtType string, colNameToIdMap map[string]string) {
 	st := conv.SrcSchema[tableId]
 	switch ct := constraint.Tp; ct {
@@ -322,11 +375,14 @@ func processConstraint(conv *internal.Conv, tableId string, constraint *ast.Cons
 	case ast.ConstraintIndex:
 		idxId := internal.GenerateIndexesId()
 		st.Indexes = append(st.Indexes, schema.Index{Name: constraint.Name, Id: idxId, Keys: toSchemaKeys(constraint.Keys, colNameToIdMap)})
+	case ast.ConstraintFulltext:
+		idxId := internal.GenerateIndexesId()
+		st.Indexes = append(st.Indexes, schema.Index{Name: constraint.Name, Id: idxId, FullText: true, Keys: toSchemaKeys(constraint.Keys, colNameToIdMap)})
 	case ast.ConstraintUniq:
 		idxId := internal.GenerateIndexesId()
 		// Convert unique column constraint in mysql to a corresponding unique index in schema
 		// Note that schema represents all unique constraints as indexes.
-		st.Indexes = append(st.Indexes, schema.Index{Name: constraint.Name, Id: idxId, Unique: true, Keys: toSchemaKeys(constraint.Keys, colNameToIdMap)})
+		st.Indexes = append(st.Indexes, schema.Index{Name: constraint.Name, Id: idxId, Unique: true, Constraint: true, Keys: toSchemaKeys(constraint.Keys, colNameToIdMap)})
 	default:
 		updateCols(conv, ct, constraint.Keys, st.ColDefs, colNameToIdMap)
 	}
@@ -363,6 +419,18 @@ func expressionToString(expr ast.Node) string {
 	return sb.String()
 }
 
+// generatedExpressionToString returns the string representation of a
+// generated column expression, with backquoted identifiers as in
+// INFORMATION_SCHEMA.COLUMNS.
+func generatedExpressionToString(expr ast.Node) string {
+	var sb strings.Builder
+	restoreCtx := format.NewRestoreCtx(format.RestoreStringSingleQuotes|format.RestoreKeyWordLowercase|format.RestoreNameBackQuotes, &sb)
+	if err := expr.Restore(restoreCtx); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
 // toSchemaKeys converts a string list of MySQL keys to schema keys.
 // Note that we map all MySQL keys to ascending ordered schema keys.
 // For primary keys: this is fine because MySQL primary keys are always ascending.
@@ -473,7 +541,7 @@ func processAlterTable(conv *internal.Conv, stmt *ast.AlterTableStmt) {
 					// Convert unique column constraint in mysql to a corresponding unique index in schema
 					// Note that schema represents all unique constraints as indexes.
 					ctable := conv.SrcSchema[tbl.Id]
-					ctable.Indexes = append(ctable.Indexes, schema.Index{Name: "", Unique: true, Keys: []schema.Key{schema.Key{ColId: colname, Desc: false}}})
+					ctable.Indexes = append(ctable.Indexes, schema.Index{Name: "", Id: internal.GenerateIndexesId(), Unique: true, Constraint: true, Keys: []schema.Key{{ColId: col.Id, Desc: false}}})
 					conv.SrcSchema[tbl.Id] = ctable
 				}
 				conv.SchemaStatement(NodeType(stmt))
@@ -528,6 +596,16 @@ func processColumn(conv *internal.Conv, tableName string, col *ast.ColumnDef) (s
 		Mods:        mods,
 		ArrayBounds: getArrayBounds(col.Tp.String(), col.Tp.GetElems())}
 	column := schema.Column{Name: name, Type: ty}
+	if tid == "enum" || tid == "set" {
+		column.EnumValues = col.Tp.GetElems()
+	}
+	if charTypes[tid] && col.Tp.GetCharset() != "" {
+		column.Charset = strings.ToLower(col.Tp.GetCharset())
+		column.Collation = strings.ToLower(col.Tp.GetCollate())
+		if column.Collation == "" {
+			column.Collation = defaultCollation(column.Charset)
+		}
+	}
 	return name, column, updateColsByOption(conv, tableName, col, &column), nil
 }
 
@@ -564,8 +642,22 @@ func updateColsByOption(conv *internal.Conv, tableName string, col *ast.ColumnDe
 			}
 		case ast.ColumnOptionUniqKey:
 			cc.isUniqueKey = true
+		case ast.ColumnOptionOnUpdate:
+			column.OnUpdateCurrentTimestamp = true
 		case ast.ColumnOptionCheck:
 			column.Ignored.Check = true
+		case ast.ColumnOptionCollate:
+			column.Collation = strings.ToLower(elem.StrValue)
+		case ast.ColumnOptionGenerated:
+			ty := ddl.GeneratedVirtual
+			if elem.Stored {
+				ty = ddl.GeneratedStored
+			}
+			column.GeneratedColumn = ddl.GeneratedColumn{
+				IsPresent: true,
+				Value:     ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: generatedExpressionToString(elem.Expr)},
+				Type:      ty,
+			}
 		case ast.ColumnOptionReference:
 			column := col.Name.String()
 			referTable, err := getTableName(elem.Refer.Table)
@@ -618,6 +710,13 @@ func getTypeModsAndID(conv *internal.Conv, columnType string) (string, []int64)
 	if strings.Contains(id, " ") {
 		id = strings.TrimSuffix(columnType, " BINARY")
 	}
+	// Character sets and collations of columns without modifiers are
+	// suffixed too e.g. text CHARACTER SET cp1251.
+	for _, suffix := range []string{" CHARACTER SET ", " COLLATE "} {
+		if i := strings.Index(id, suffix); i >= 0 {
+			id = id[:i]
+		}
+	}
 	return id, mods
 }
 
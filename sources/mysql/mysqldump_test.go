@@ -24,6 +24,8 @@ import (
 	"testing"
 	"time"
 
+	"cloud.google.com/go/spanner"
+
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -90,7 +92,7 @@ func TestProcessMySQLDump_SingleCol(t *testing.T) {
 		ty       string
 		expected ddl.ColumnDef
 	}{
-		{"set('a','b','c')", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: false}, NotNull: false, Comment: ""}},
+		{"set('a','b','c')", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: "STRING", Len: 9223372036854775807, IsArray: true}, NotNull: false, Comment: ""}},
 		{"text NOT NULL", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true}},
 	}
 
@@ -431,6 +433,44 @@ func TestProcessMySQLDump_MultiCol(t *testing.T) {
 					PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "synth_id", Order: 1}},
 					Indexes:     []ddl.CreateIndex{ddl.CreateIndex{Name: "custom_index", TableId: "test", Unique: false, Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "b", Desc: false, Order: 1}, ddl.IndexKey{ColId: "c", Desc: false, Order: 2}}}}}},
 		},
+		{
+			name: "Create table with fulltext index",
+			input: "CREATE TABLE test (" +
+				"a smallint NOT NULL," +
+				"b text DEFAULT NULL," +
+				"PRIMARY KEY (a)," +
+				"FULLTEXT KEY ft_b (b)" +
+				");\n",
+			expectedSchema: map[string]ddl.CreateTable{
+				"test": ddl.CreateTable{
+					Name:   "test",
+					ColIds: []string{"a", "b", "b_Tokens"},
+					ColDefs: map[string]ddl.ColumnDef{
+						"a": ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+						"b": ddl.ColumnDef{Name: "b", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+						"b_Tokens": ddl.ColumnDef{Name: "b_Tokens", T: ddl.Type{Name: ddl.TokenList},
+							GeneratedColumn: ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{Statement: "TOKENIZE_FULLTEXT(b)"}, Type: ddl.GeneratedVirtual}, Hidden: true},
+					},
+					PrimaryKeys:   []ddl.IndexKey{ddl.IndexKey{ColId: "a", Order: 1}},
+					SearchIndexes: []ddl.SearchIndex{ddl.SearchIndex{Name: "ft_b", TableId: "test", Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "b_Tokens", Order: 1}}}}}},
+		},
+		{
+			name: "Create table with on update current_timestamp column",
+			input: "CREATE TABLE test (" +
+				"a smallint NOT NULL," +
+				"last_modified timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP," +
+				"PRIMARY KEY (a)" +
+				");\n",
+			expectedSchema: map[string]ddl.CreateTable{
+				"test": ddl.CreateTable{
+					Name:   "test",
+					ColIds: []string{"a", "last_modified"},
+					ColDefs: map[string]ddl.ColumnDef{
+						"a":             ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+						"last_modified": ddl.ColumnDef{Name: "last_modified", T: ddl.Type{Name: ddl.Timestamp}, NotNull: true, Opts: map[string]string{ddl.AllowCommitTimestampOpt: "true"}},
+					},
+					PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "a", Order: 1}}}},
+		},
 		{
 			name: "Alter table add unique index keys",
 			input: "CREATE TABLE test (" +
@@ -515,6 +555,28 @@ func TestProcessMySQLDump_MultiCol(t *testing.T) {
 					Indexes:     []ddl.CreateIndex{},
 				}},
 		},
+		{
+			name: "Create table with unnamed unique column",
+			input: "CREATE TABLE test (" +
+				"a bigint NOT NULL PRIMARY KEY," +
+				"b varchar(20) UNIQUE" +
+				");\n" +
+				"ALTER TABLE test MODIFY COLUMN b varchar(30) UNIQUE;\n",
+			expectedSchema: map[string]ddl.CreateTable{
+				"test": ddl.CreateTable{
+					Name:   "test",
+					ColIds: []string{"a", "b"},
+					ColDefs: map[string]ddl.ColumnDef{
+						"a": ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+						"b": ddl.ColumnDef{Name: "b", T: ddl.Type{Name: ddl.String, Len: 30}},
+					},
+					PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "a", Order: 1}},
+					Indexes: []ddl.CreateIndex{
+						ddl.CreateIndex{Name: "test_b_key", TableId: "test", Unique: true, Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "b", Desc: false, Order: 1}}},
+						ddl.CreateIndex{Name: "test_b_key_2", TableId: "test", Unique: true, Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "b", Desc: false, Order: 1}}},
+					},
+				}},
+		},
 		{
 			name:  "Create table with mysql schema",
 			input: "CREATE TABLE myschema.test (a text PRIMARY KEY, b text);\n",
@@ -870,7 +932,7 @@ func TestProcessMySQLDump_DataError(t *testing.T) {
 					table: "test", cols: []string{"a", "b", "c", "d", "e", "f", "g", "h", "synth_id"},
 					vals: []interface{}{int64(7), float64(42.1), true,
 						getDate("2019-10-29"), []byte{0x89, 0x50},
-						"42,6", false, float32(3.14),
+						[]spanner.NullString{{StringVal: "42", Valid: true}, {StringVal: "6", Valid: true}}, false, float32(3.14),
 						fmt.Sprintf("%d", bitReverse(0))}},
 				spannerData{table: "test", cols: []string{"a", "synth_id"}, vals: []interface{}{int64(7), fmt.Sprintf("%d", bitReverse(1))}},
 				spannerData{table: "test", cols: []string{"b", "synth_id"}, vals: []interface{}{float64(42.1), fmt.Sprintf("%d", bitReverse(2))}},
@@ -878,7 +940,7 @@ func TestProcessMySQLDump_DataError(t *testing.T) {
 				spannerData{table: "test", cols: []string{"d", "synth_id"}, vals: []interface{}{getDate("2019-10-29"), fmt.Sprintf("%d", bitReverse(4))}},
 				spannerData{table: "test", cols: []string{"e", "synth_id"}, vals: []interface{}{[]byte{0x89, 0x50}, fmt.Sprintf("%d", bitReverse(5))}},
 				spannerData{table: "test", cols: []string{"f", "synth_id"},
-					vals: []interface{}{"42,6", fmt.Sprintf("%d", bitReverse(6))}},
+					vals: []interface{}{[]spanner.NullString{{StringVal: "42", Valid: true}, {StringVal: "6", Valid: true}}, fmt.Sprintf("%d", bitReverse(6))}},
 				spannerData{table: "test", cols: []string{"h", "synth_id"}, vals: []interface{}{float32(3.14), fmt.Sprintf("%d", bitReverse(7))}},
 			},
 		},
@@ -901,7 +963,7 @@ func TestProcessMySQLDump_GetDDL(t *testing.T) {
 			"	quantity INT64,\n" +
 			") PRIMARY KEY (productid, userid)"
 	c := ddl.Config{Tables: true}
-	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences), " "))
+	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects()), " "))
 }
 
 func TestProcessMySQLDump_Rows(t *testing.T) {
@@ -1025,3 +1087,129 @@ func stripSchemaComments(spSchema map[string]ddl.CreateTable) map[string]ddl.Cre
 func bitReverse(i int64) int64 {
 	return int64(bits.Reverse64(uint64(i)))
 }
+
+func TestProcessMySQLDump_ExtendedInsert(t *testing.T) {
+	var values []string
+	var expected []spannerData
+	for i := 0; i < 100; i++ {
+		values = append(values, fmt.Sprintf("(%d,'row %d')", i, i))
+		expected = append(expected, spannerData{table: "t", cols: []string{"a", "b"}, vals: []interface{}{int64(i), fmt.Sprintf("row %d", i)}})
+	}
+	// Rows with quotes, escapes and separators in strings.
+	values = append(values, `(100,'x),(y')`, `(101,'a;b')`, `(102,'it\'s')`, `(103,'it''s),(')`, `(104,"d\"q")`, `(105,'line\nbreak')`)
+	for i, s := range []string{"x),(y", "a;b", "it's", "it's),(", `d"q`, "line\nbreak"} {
+		expected = append(expected, spannerData{table: "t", cols: []string{"a", "b"}, vals: []interface{}{int64(100 + i), s}})
+	}
+	dump := "CREATE TABLE t (a bigint PRIMARY KEY, b text);\n" +
+		"INSERT INTO `t` VALUES " + strings.Join(values, ",") + ";\n" +
+		"INSERT INTO `t` (`a`, `b`) VALUES (107,'co(ls'),\n(108, 'multi\nline');\n" +
+		"INSERT INTO `t` VALUES (109,'dup') ON DUPLICATE KEY UPDATE b = 'dup;licate';\n" +
+		"INSERT INTO `t` VALUES (110,'ok'),(111,),(112,'ok');\n" +
+		"INSERT INTO `t` SELECT 1, 'select';\n" +
+		"UNLOCK TABLES;\n"
+	expected = append(expected,
+		spannerData{table: "t", cols: []string{"a", "b"}, vals: []interface{}{int64(107), "co(ls"}},
+		spannerData{table: "t", cols: []string{"a", "b"}, vals: []interface{}{int64(108), "multi\nline"}},
+		spannerData{table: "t", cols: []string{"a", "b"}, vals: []interface{}{int64(109), "dup"}},
+		spannerData{table: "t", cols: []string{"a", "b"}, vals: []interface{}{int64(110), "ok"}},
+		spannerData{table: "t", cols: []string{"a", "b"}, vals: []interface{}{int64(112), "ok"}},
+	)
+
+	defer func(size, workers int) {
+		insertBatchSize, insertParseWorkers = size, workers
+	}(insertBatchSize, insertParseWorkers)
+	for _, tc := range []struct {
+		name      string
+		batchSize int
+		workers   int
+	}{
+		{name: "single batch", batchSize: 1 << 20, workers: 1},
+		{name: "row per batch", batchSize: 1, workers: 4},
+		{name: "small batches", batchSize: 64, workers: 3},
+	} {
+		insertBatchSize, insertParseWorkers = tc.batchSize, tc.workers
+		conv, rows := runProcessMySQLDump(dump)
+		assert.Equal(t, expected, rows, tc.name)
+		assert.Equal(t, int64(len(expected)), conv.Rows(), tc.name)
+		// The invalid row, and the rows of INSERT ... SELECT.
+		assert.Equal(t, int64(2), conv.Unexpecteds(), tc.name)
+	}
+}
+
+func TestProcessMySQLDump_TruncatedInsert(t *testing.T) {
+	for _, dump := range []string{
+		"INSERT INTO `t` VALUES (1,'a'),(2,'b",
+		"INSERT INTO `t` VALUES (1,'a'),(2,'b')",
+		"INSERT INTO `t` VALUES (1,'a') ON DUPLICATE KEY UPDATE b = 'c'",
+	} {
+		conv := internal.MakeConv()
+		conv.SetSchemaMode()
+		err := processMySQLDump(conv, internal.NewReader(bufio.NewReader(strings.NewReader(dump)), nil))
+		assert.NotNil(t, err, dump)
+	}
+}
+
+func TestToGeneratedColumn(t *testing.T) {
+	gc := toGeneratedColumn("(`a` + 1)", "VIRTUAL GENERATED")
+	assert.True(t, gc.IsPresent)
+	assert.Equal(t, "(`a` + 1)", gc.Value.Statement)
+	assert.Equal(t, ddl.GeneratedVirtual, gc.Type)
+	assert.Equal(t, ddl.GeneratedStored, toGeneratedColumn("(`a` + 1)", "STORED GENERATED").Type)
+	assert.False(t, toGeneratedColumn("", "").IsPresent)
+	assert.False(t, toGeneratedColumn("", constants.DEFAULT_GENERATED).IsPresent)
+	assert.False(t, toGeneratedColumn("", "auto_increment").IsPresent)
+}
+
+func TestProcessMySQLDump_GeneratedColumns(t *testing.T) {
+	conv, rows := runProcessMySQLDump("CREATE TABLE orders (id bigint PRIMARY KEY, price bigint, quantity bigint, " +
+		"total bigint AS (price * quantity) STORED, " +
+		"doc json, name varchar(20) AS (json_unquote(json_extract(doc, '$.name'))) VIRTUAL);\n" +
+		"INSERT INTO orders (id, price, quantity, doc, name) VALUES (1, 2, 3, '{\"name\":\"a\"}', 'a');\n")
+	expected :=
+		"CREATE TABLE orders (\n" +
+			"	id INT64 NOT NULL ,\n" +
+			"	price INT64,\n" +
+			"	quantity INT64,\n" +
+			"	total INT64 AS (`price`*`quantity`) STORED,\n" +
+			"	doc JSON,\n" +
+			"	name STRING(20),\n" +
+			") PRIMARY KEY (id)"
+	c := ddl.Config{Tables: true}
+	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects()), " "))
+
+	tableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "orders")
+	nameColId, _ := internal.GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, "name")
+	assert.Equal(t, []internal.SchemaIssue{internal.GeneratedColumnMaterialized}, conv.SchemaIssues[tableId].ColumnLevelIssues[nameColId])
+	// Values of materialized columns are copied, and those of Spanner
+	// generated columns computed by Spanner.
+	assert.Equal(t, []spannerData{
+		{table: "orders", cols: []string{"id", "price", "quantity", "doc", "name"}, vals: []interface{}{int64(1), int64(2), int64(3), "{\"name\":\"a\"}", "a"}},
+	}, rows)
+}
+
+func TestProcessMySQLDump_Charsets(t *testing.T) {
+	conv, rows := runProcessMySQLDump("/*!40101 SET NAMES latin1 */;\n" +
+		"CREATE TABLE `users` (\n" +
+		"  `name` varchar(20) NOT NULL,\n" +
+		"  `code` varchar(10) COLLATE latin1_bin DEFAULT NULL,\n" +
+		"  `note` text CHARACTER SET cp1251,\n" +
+		"  PRIMARY KEY (`name`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=latin1;\n" +
+		"INSERT INTO `users` VALUES ('Jos\xe9','\xc5','caf\xe9');\n")
+	tableId, _ := internal.GetTableIdFromSrcName(conv.SrcSchema, "users")
+	srcTable := conv.SrcSchema[tableId]
+	colIds := srcTable.ColNameIdMap
+	assert.Equal(t, "latin1", srcTable.ColDefs[colIds["name"]].Charset)
+	assert.Equal(t, "latin1_swedish_ci", srcTable.ColDefs[colIds["name"]].Collation)
+	assert.Equal(t, "latin1_bin", srcTable.ColDefs[colIds["code"]].Collation)
+	assert.Equal(t, "cp1251", srcTable.ColDefs[colIds["note"]].Charset)
+	assert.Equal(t, "cp1251_general_ci", srcTable.ColDefs[colIds["note"]].Collation)
+	// Only keys and indexes on columns with non-binary collations are
+	// reported.
+	assert.Equal(t, []internal.SchemaIssue{internal.CollationChanged}, conv.SchemaIssues[tableId].ColumnLevelIssues[colIds["name"]])
+	assert.Empty(t, conv.SchemaIssues[tableId].ColumnLevelIssues[colIds["note"]])
+	// Values are in the character set of the dump.
+	assert.Equal(t, []spannerData{
+		{table: "users", cols: []string{"name", "code", "note"}, vals: []interface{}{"José", "Å", "café"}},
+	}, rows)
+}
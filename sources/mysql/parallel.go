@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// integerKeyTypes are the types of the integer primary keys whose values
+// the reads of tables are split by.
+var integerKeyTypes = map[string]bool{"tinyint": true, "smallint": true, "mediumint": true, "int": true, "bigint": true}
+
+// parallelRow is a row read by one of the readers of a table.
+type parallelRow struct {
+	cols   []string
+	values []string
+	err    error
+}
+
+// readRows passes the rows of rows, returned by getRows with err, to emit.
+func readRows(rows *sql.Rows, err error, emit func(parallelRow)) error {
+	if err != nil || rows == nil {
+		return err
+	}
+	defer rows.Close()
+	srcCols, _ := rows.Columns()
+	v, scanArgs := buildVals(len(srcCols))
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			emit(parallelRow{err: err})
+			continue
+		}
+		emit(parallelRow{cols: srcCols, values: valsToStrings(v)})
+	}
+	return rows.Err()
+}
+
+// processParallelRow converts row, read by one of the readers of the table
+// tableId, and writes it.
+func processParallelRow(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, colNameIdMap map[string]string, row parallelRow, additionalAttributes internal.AdditionalDataAttributes) {
+	if row.err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", row.err))
+		// Scan failed, so we don't have any data to add to bad rows.
+		conv.StatsAddBadRow(conv.SrcSchema[tableId].Name, conv.DataMode())
+		return
+	}
+	processRowValues(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, row.cols, row.values, additionalAttributes)
+}
+
+// getKeyRanges splits the values of the primary key of table into up to n
+// ranges, when it's a single integer column, or a CHAR(36) or VARCHAR(36)
+// column holding UUIDs. It returns the key column, and no ranges for other
+// tables.
+func (isi InfoSchemaImpl) getKeyRanges(table schema.Table, n int) (string, []common.KeyRange) {
+	if len(table.PrimaryKeys) != 1 {
+		return "", nil
+	}
+	col, ok := table.ColDefs[table.PrimaryKeys[0].ColId]
+	if !ok {
+		return "", nil
+	}
+	switch {
+	case (col.Type.Name == "char" || col.Type.Name == "varchar") && len(col.Type.Mods) == 1 && col.Type.Mods[0] == 36:
+		return col.Name, common.SplitUUIDKeys(n)
+	case integerKeyTypes[col.Type.Name]:
+		// Unsigned BIGINT keys above the maximum of int64 can't be scanned,
+		// and aren't split.
+		var min, max sql.NullInt64
+		q := fmt.Sprintf("SELECT MIN(`%s`), MAX(`%s`) FROM %s;", col.Name, col.Name, isi.quotedTableName(table))
+		if err := isi.dataDb().QueryRow(q).Scan(&min, &max); err != nil || !min.Valid || !max.Valid {
+			// Tables without rows aren't split.
+			return "", nil
+		}
+		return col.Name, common.SplitIntegerKeys(min.Int64, max.Int64, n)
+	}
+	return "", nil
+}
+
+// processKeyRangesData performs data conversion for a table whose rows are
+// read by readers readers at a time, each reading the rows of a range of the
+// values of its primary key column key, in chunks by chunkKey if not nil.
+func (isi InfoSchemaImpl) processKeyRangesData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes, key string, ranges []common.KeyRange, readers int, chunkKey []string) error {
+	srcTable := conv.SrcSchema[tableId]
+	colNameIdMap := internal.GetSrcColNameIdMap(srcTable)
+	placeholder := func(int) string { return "?" }
+	read := func(r common.KeyRange, emit func(parallelRow)) error {
+		filter, args := r.Filter("`"+key+"`", placeholder)
+		if chunkKey != nil {
+			return isi.readChunks(conv, tableId, "", chunkKey, filter, args, emit)
+		}
+		rows, err := isi.getRows(conv, tableId, "", filter, args...)
+		return readRows(rows, err, emit)
+	}
+	process := func(row parallelRow) {
+		processParallelRow(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, row, additionalAttributes)
+	}
+	if err := common.ReadKeyRanges(ranges, readers, read, process); err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTable.Name, err))
+		return err
+	}
+	return nil
+}
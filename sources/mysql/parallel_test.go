@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func keyRangesConv(keyType schema.Type) *internal.Conv {
+	return buildConv(
+		ddl.CreateTable{
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c2": {Name: "amount", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+		},
+		schema.Table{
+			Name:   "orders",
+			Id:     "t1",
+			Schema: "test",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: keyType},
+				"c2": {Name: "amount", Id: "c2", Type: schema.Type{Name: "bigint"}},
+			},
+			ColNameIdMap: map[string]string{"id": "c1", "amount": "c2"},
+			PrimaryKeys:  []schema.Key{{ColId: "c1"}},
+		})
+}
+
+func TestProcessDataKeyRanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MIN(`id`), MAX(`id`) FROM `test`.`orders`;")).
+		WillReturnRows(sqlmock.NewRows([]string{"min", "max"}).AddRow(1, 4))
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`amount` FROM `test`.`orders` WHERE `id` < ? ORDER BY `id` LIMIT 10000;")).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow(1, 10).AddRow(2, 20))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`amount` FROM `test`.`orders` WHERE `id` >= ? ORDER BY `id` LIMIT 10000;")).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow(3, 30).AddRow(4, "many"))
+	conv := keyRangesConv(schema.Type{Name: "int"})
+	conv.SetDataMode()
+	var amounts []int64
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			amounts = append(amounts, vals[1].(int64))
+		})
+	sourceProfile := profiles.SourceProfile{Ty: profiles.SourceProfileTypeConnection, Conn: profiles.SourceProfileConnection{ParallelReaders: 2}}
+	isi := InfoSchemaImpl{DbName: "test", Db: db, SourceProfile: sourceProfile}
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
+	assert.Nil(t, mock.ExpectationsWereMet())
+	sort.Slice(amounts, func(i, j int) bool { return amounts[i] < amounts[j] })
+	assert.Equal(t, []int64{10, 20, 30}, amounts)
+	assert.Equal(t, int64(1), conv.BadRows())
+}
+
+func TestGetKeyRanges(t *testing.T) {
+	isi := InfoSchemaImpl{DbName: "test"}
+	key, ranges := isi.getKeyRanges(keyRangesConv(schema.Type{Name: "char", Mods: []int64{36}}).SrcSchema["t1"], 2)
+	assert.Equal(t, "id", key)
+	assert.Equal(t, common.SplitUUIDKeys(2), ranges)
+	// Keys of other types aren't split.
+	_, ranges = isi.getKeyRanges(keyRangesConv(schema.Type{Name: "varchar", Mods: []int64{255}}).SrcSchema["t1"], 2)
+	assert.Nil(t, ranges)
+
+	// Neither are tables without rows.
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MIN(`id`), MAX(`id`) FROM `test`.`orders`;")).
+		WillReturnRows(sqlmock.NewRows([]string{"min", "max"}).AddRow(nil, nil))
+	_, ranges = InfoSchemaImpl{DbName: "test", Db: db}.getKeyRanges(keyRangesConv(schema.Type{Name: "bigint"}).SrcSchema["t1"], 2)
+	assert.Nil(t, ranges)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// quotedIdentifierRegex matches the quoted identifiers of partition
+// expressions, e.g. YEAR(`created_at`) or `region`,`created_at`.
+var quotedIdentifierRegex = regexp.MustCompile("`((?:[^`]|``)+)`")
+
+// GetPartitionedTables implements the common.PartitionedTableSource
+// interface. Subpartitions are read along with their partition.
+func (isi InfoSchemaImpl) GetPartitionedTables(tables []common.SchemaAndName) (map[common.SchemaAndName]common.TablePartitioning, error) {
+	included := make(map[common.SchemaAndName]bool)
+	for _, t := range tables {
+		included[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] = true
+	}
+	partitionings := make(map[common.SchemaAndName]common.TablePartitioning)
+	for _, db := range isi.databases() {
+		if err := isi.getPartitionedTables(db, included, partitionings); err != nil {
+			return nil, err
+		}
+	}
+	return partitionings, nil
+}
+
+// getPartitionedTables adds the partitionings of the included tables of
+// database db to partitionings.
+func (isi InfoSchemaImpl) getPartitionedTables(db string, included map[common.SchemaAndName]bool, partitionings map[common.SchemaAndName]common.TablePartitioning) error {
+	q := `SELECT TABLE_NAME, PARTITION_NAME, PARTITION_METHOD, PARTITION_EXPRESSION, PARTITION_DESCRIPTION
+		FROM INFORMATION_SCHEMA.PARTITIONS
+		WHERE TABLE_SCHEMA = ? AND PARTITION_NAME IS NOT NULL
+		ORDER BY TABLE_NAME, PARTITION_ORDINAL_POSITION, SUBPARTITION_ORDINAL_POSITION;`
+	rows, err := isi.Db.Query(q, db)
+	if err != nil {
+		return fmt.Errorf("couldn't get partitions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, name, method string
+		var expression, description sql.NullString
+		if err := rows.Scan(&tableName, &name, &method, &expression, &description); err != nil {
+			return fmt.Errorf("couldn't get partitions: %w", err)
+		}
+		table := common.SchemaAndName{Schema: db, Name: tableName}
+		if !included[table] {
+			continue
+		}
+		p, ok := partitionings[table]
+		if !ok {
+			p = common.TablePartitioning{Method: method, Expression: expression.String, Mapping: isi.SourceProfile.PartitionMapping()}
+			for _, m := range quotedIdentifierRegex.FindAllStringSubmatch(expression.String, -1) {
+				p.Columns = append(p.Columns, strings.ReplaceAll(m[1], "``", "`"))
+			}
+		}
+		// Subpartitions are listed once per partition.
+		if n := len(p.Partitions); n > 0 && p.Partitions[n-1].Name == name {
+			continue
+		}
+		p.Partitions = append(p.Partitions, schema.Partition{Name: name, Bound: partitionBound(method, description.String)})
+		partitionings[table] = p
+	}
+	return rows.Err()
+}
+
+// partitionBound returns the bound of a partition, from its method and
+// information_schema.PARTITIONS.PARTITION_DESCRIPTION.
+func partitionBound(method, description string) string {
+	switch {
+	case description == "":
+		return ""
+	case strings.HasPrefix(method, "RANGE") && description == "MAXVALUE":
+		return "VALUES LESS THAN MAXVALUE"
+	case strings.HasPrefix(method, "RANGE"):
+		return fmt.Sprintf("VALUES LESS THAN (%s)", description)
+	case strings.HasPrefix(method, "LIST"):
+		return fmt.Sprintf("VALUES IN (%s)", description)
+	}
+	return ""
+}
+
+// processPartitionsData performs data conversion for a partitioned table,
+// whose partitions are read by readers readers at a time, in chunks by
+// chunkKey if not nil.
+func (isi InfoSchemaImpl) processPartitionsData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes, readers int, chunkKey []string) error {
+	srcTable := conv.SrcSchema[tableId]
+	colNameIdMap := internal.GetSrcColNameIdMap(srcTable)
+	read := func(p schema.Partition, emit func(parallelRow)) error {
+		if chunkKey != nil {
+			return isi.readChunks(conv, tableId, p.Name, chunkKey, "", nil, emit)
+		}
+		rows, err := isi.getRows(conv, tableId, p.Name, "")
+		return readRows(rows, err, emit)
+	}
+	process := func(row parallelRow) {
+		processParallelRow(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, row, additionalAttributes)
+	}
+	if err := common.ReadPartitions(srcTable.Partitioning.Partitions, readers, read, process); err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTable.Name, err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestGetPartitionedTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM INFORMATION_SCHEMA.PARTITIONS`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "PARTITION_NAME", "PARTITION_METHOD", "PARTITION_EXPRESSION", "PARTITION_DESCRIPTION"}).
+			AddRow("events", "p0", "HASH", "`id`", nil).
+			AddRow("events", "p1", "HASH", "`id`", nil).
+			AddRow("logs", "p0", "RANGE", "`id`", "100").
+			AddRow("orders", "p2024", "RANGE", "year(`created_at`)", "2025").
+			AddRow("orders", "p2024", "RANGE", "year(`created_at`)", "2025").
+			AddRow("orders", "pmax", "RANGE", "year(`created_at`)", "MAXVALUE").
+			AddRow("users", "p_eu", "LIST COLUMNS", "`region`,`country`", "('eu','fr'),('eu','de')"))
+	sourceProfile := profiles.SourceProfile{Conn: profiles.SourceProfileConnection{PartitionMapping: schema.PartitionMappingPrimaryKey}}
+	isi := InfoSchemaImpl{DbName: "test", Db: db, SourceProfile: sourceProfile}
+	tables := []common.SchemaAndName{{Schema: "test", Name: "events"}, {Schema: "test", Name: "orders"}, {Schema: "test", Name: "users"}}
+	partitionings, err := isi.GetPartitionedTables(tables)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, map[common.SchemaAndName]common.TablePartitioning{
+		{Schema: "test", Name: "events"}: {
+			Method:     "HASH",
+			Expression: "`id`",
+			Columns:    []string{"id"},
+			Partitions: []schema.Partition{{Name: "p0"}, {Name: "p1"}},
+			Mapping:    schema.PartitionMappingPrimaryKey,
+		},
+		{Schema: "test", Name: "orders"}: {
+			Method:     "RANGE",
+			Expression: "year(`created_at`)",
+			Columns:    []string{"created_at"},
+			Partitions: []schema.Partition{{Name: "p2024", Bound: "VALUES LESS THAN (2025)"}, {Name: "pmax", Bound: "VALUES LESS THAN MAXVALUE"}},
+			Mapping:    schema.PartitionMappingPrimaryKey,
+		},
+		{Schema: "test", Name: "users"}: {
+			Method:     "LIST COLUMNS",
+			Expression: "`region`,`country`",
+			Columns:    []string{"region", "country"},
+			Partitions: []schema.Partition{{Name: "p_eu", Bound: "VALUES IN (('eu','fr'),('eu','de'))"}},
+			Mapping:    schema.PartitionMappingPrimaryKey,
+		},
+	}, partitionings)
+}
+
+func TestProcessDataPartitions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`created_at` FROM `test`.`orders` PARTITION (`p2024`);")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, "2024-03-01").AddRow(2, "2024-06-01"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`created_at` FROM `test`.`orders` PARTITION (`p2025`);")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, "2025-01-01").AddRow(4, "not a date"))
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "created_at", Id: "c2", T: ddl.Type{Name: ddl.Date}},
+			},
+		},
+		schema.Table{
+			Name:   "orders",
+			Id:     "t1",
+			Schema: "test",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+				"c2": {Name: "created_at", Id: "c2", Type: schema.Type{Name: "date"}},
+			},
+			ColNameIdMap: map[string]string{"id": "c1", "created_at": "c2"},
+			Partitioning: &schema.Partitioning{
+				Method:     "RANGE",
+				ColIds:     []string{"c2"},
+				Partitions: []schema.Partition{{Name: "p2024"}, {Name: "p2025"}},
+				Mapping:    schema.PartitionMappingParallelExport,
+			},
+		})
+	conv.SetDataMode()
+	var ids []int64
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			ids = append(ids, vals[0].(int64))
+		})
+	isi := InfoSchemaImpl{DbName: "test", Db: db}
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
+	assert.Nil(t, mock.ExpectationsWereMet())
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+	assert.Equal(t, int64(1), conv.BadRows())
+}
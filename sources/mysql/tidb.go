@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// TiDB speaks the MySQL protocol and is migrated as a MySQL source, but it
+// differs from MySQL in how rows are keyed: tables either have a clustered
+// primary key or are keyed by a hidden _tidb_rowid, and primary keys can be
+// AUTO_RANDOM rather than AUTO_INCREMENT. AUTO_RANDOM values are already
+// spread across the key space, so they are closer to Spanner's bit-reversed
+// sequences than to AUTO_INCREMENT columns.
+
+// autoRandomBitsPrefix prefixes the shard bits of an AUTO_RANDOM primary key
+// in information_schema.tables.TIDB_ROW_ID_SHARDING_INFO,
+// e.g. PK_AUTO_RANDOM_BITS=5, RANGE BITS=64.
+const autoRandomBitsPrefix = "PK_AUTO_RANDOM_BITS="
+
+// IsTiDB returns true if db is connected to a TiDB server, whose version
+// strings look like 8.0.11-TiDB-v7.5.0.
+func IsTiDB(db *sql.DB) (bool, error) {
+	var version string
+	if err := db.QueryRow("SELECT VERSION();").Scan(&version); err != nil {
+		return false, fmt.Errorf("couldn't get server version: %w", err)
+	}
+	return strings.Contains(strings.ToLower(version), "tidb"), nil
+}
+
+// tidbTableInfo is the TiDB specific metadata of a table.
+type tidbTableInfo struct {
+	// Clustered is true if rows are keyed by the primary key rather than
+	// by the hidden _tidb_rowid.
+	Clustered bool
+	// AutoRandom is true if the first primary key column is AUTO_RANDOM.
+	AutoRandom bool
+}
+
+// getTiDBTableInfo reads the TiDB specific columns of
+// information_schema.tables for a table.
+func (isi InfoSchemaImpl) getTiDBTableInfo(table common.SchemaAndName) (tidbTableInfo, error) {
+	q := `SELECT tidb_pk_type, tidb_row_id_sharding_info FROM information_schema.tables where table_schema = ? and table_name = ?;`
+	var pkType, shardingInfo sql.NullString
+	if err := isi.Db.QueryRow(q, table.Schema, table.Name).Scan(&pkType, &shardingInfo); err != nil {
+		return tidbTableInfo{}, fmt.Errorf("couldn't get TiDB table info for table %s.%s: %w", table.Schema, table.Name, err)
+	}
+	return tidbTableInfo{
+		Clustered:  pkType.String == "CLUSTERED",
+		AutoRandom: strings.HasPrefix(shardingInfo.String, autoRandomBitsPrefix),
+	}, nil
+}
+
+// isAutoRandom returns true if colName is an AUTO_RANDOM column. Only the
+// first column of a clustered primary key can be AUTO_RANDOM. Some TiDB
+// versions also report it in the extra column of information_schema.columns.
+func (info tidbTableInfo) isAutoRandom(colName, colExtra string, primaryKeys []string) bool {
+	if strings.Contains(strings.ToLower(colExtra), "auto_random") {
+		return true
+	}
+	return info.Clustered && info.AutoRandom && len(primaryKeys) > 0 && primaryKeys[0] == colName
+}
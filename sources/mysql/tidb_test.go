@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestIsTiDB(t *testing.T) {
+	testCases := []struct {
+		version string
+		want    bool
+	}{
+		{version: "8.0.11-TiDB-v7.5.0", want: true},
+		{version: "5.7.25-TiDB-v6.5.3", want: true},
+		{version: "8.0.36", want: false},
+		{version: "10.11.6-MariaDB-1:10.11.6+maria~ubu2204", want: false},
+	}
+	for _, tc := range testCases {
+		db := mkMockDB(t, []mockSpec{
+			{
+				query: regexp.QuoteMeta("SELECT VERSION();"),
+				cols:  []string{"version"},
+				rows:  [][]driver.Value{{tc.version}},
+			},
+		})
+		got, err := IsTiDB(db)
+		assert.Nil(t, err, tc.version)
+		assert.Equal(t, tc.want, got, tc.version)
+	}
+}
+
+func TestProcessSchema_TiDB(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM information_schema.tables where table_type = 'BASE TABLE'  and (.+)",
+			args:  []driver.Value{"test"},
+			cols:  []string{"table_name"},
+			rows: [][]driver.Value{
+				{"orders"},
+			},
+		},
+		{
+			query: regexp.QuoteMeta(`SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`),
+			cols:  []string{"count"},
+			rows: [][]driver.Value{
+				{int64(0)},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  []string{"column_name", "constraint_type"},
+			rows: [][]driver.Value{
+				{"id", "PRIMARY KEY"},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  []string{"REFERENCED_TABLE_NAME", "COLUMN_NAME", "REFERENCED_COLUMN_NAME", "CONSTRAINT_NAME", "DELETE_RULE", "UPDATE_RULE"},
+		},
+		{
+			query: "SELECT tidb_pk_type, tidb_row_id_sharding_info FROM information_schema.tables (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  []string{"tidb_pk_type", "tidb_row_id_sharding_info"},
+			rows: [][]driver.Value{
+				{"CLUSTERED", "PK_AUTO_RANDOM_BITS=5, RANGE BITS=64"},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression", "character_set_name", "collation_name"},
+			rows: [][]driver.Value{
+				{"id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil, nil, nil},
+				{"seq", "bigint", "bigint", "NO", nil, nil, 64, 0, "auto_increment", nil, nil, nil},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE"},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	isi := InfoSchemaImpl{DbName: "test", Db: db, TiDB: true}
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{DdlV: &expressions_api.MockDDLVerifier{}}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+
+	srcTable, found := internal.GetSrcTableByName(conv.SrcSchema, "orders")
+	assert.True(t, found)
+	id, _ := internal.GetColIdFromSrcName(srcTable.ColDefs, "id")
+	seq, _ := internal.GetColIdFromSrcName(srcTable.ColDefs, "seq")
+	assert.Equal(t, constants.AUTO_RANDOM, srcTable.ColDefs[id].AutoGen.GenerationType)
+	assert.Equal(t, constants.AUTO_INCREMENT, srcTable.ColDefs[seq].AutoGen.GenerationType)
+
+	spTable := conv.SpSchema[srcTable.Id]
+	assert.Equal(t, constants.SEQUENCE, spTable.ColDefs[id].AutoGen.GenerationType)
+	assert.Equal(t, constants.SEQUENCE, spTable.ColDefs[seq].AutoGen.GenerationType)
+	assert.Equal(t, ddl.Int64, spTable.ColDefs[id].T.Name)
+	issues := conv.SchemaIssues[srcTable.Id].ColumnLevelIssues
+	assert.Contains(t, issues[id], internal.AutoRandom)
+	assert.NotContains(t, issues[id], internal.SequenceCreated)
+	assert.Contains(t, issues[seq], internal.SequenceCreated)
+}
+
+func TestTiDBTableInfoIsAutoRandom(t *testing.T) {
+	testCases := []struct {
+		name        string
+		info        tidbTableInfo
+		colName     string
+		colExtra    string
+		primaryKeys []string
+		want        bool
+	}{
+		{name: "first pk column", info: tidbTableInfo{Clustered: true, AutoRandom: true}, colName: "id", primaryKeys: []string{"id", "region"}, want: true},
+		{name: "second pk column", info: tidbTableInfo{Clustered: true, AutoRandom: true}, colName: "region", primaryKeys: []string{"id", "region"}, want: false},
+		{name: "nonclustered", info: tidbTableInfo{AutoRandom: true}, colName: "id", primaryKeys: []string{"id"}, want: false},
+		{name: "not auto random", info: tidbTableInfo{Clustered: true}, colName: "id", primaryKeys: []string{"id"}, want: false},
+		{name: "extra", colName: "id", colExtra: "auto_random(5)", primaryKeys: []string{"id"}, want: true},
+		{name: "no pk", info: tidbTableInfo{Clustered: true, AutoRandom: true}, colName: "id", want: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, tc.info.isAutoRandom(tc.colName, tc.colExtra, tc.primaryKeys), tc.name)
+	}
+}
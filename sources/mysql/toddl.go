@@ -33,13 +33,25 @@ type ToDdlImpl struct {
 // mods) into a Spanner type. This is the core source-to-Spanner type
 // mapping.  toSpannerType returns the Spanner type and a list of type
 // conversion issues encountered.
+//
+// BINARY(16) and CHAR(36) columns, which often hold UUIDs, map to BYTES(MAX)
+// and STRING(36) by default, as they also hold e.g. digests. They map to UUID
+// only when spType is UUID, and from there to STRING(36) with the
+// uuidAsString param of the target profile.
 // Functions below implement the common.ToDdl interface
 func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType schema.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
 	ty, issues := toSpannerTypeInternal(srcType, spType)
+	ty, numericIssues := common.ToSpannerNumeric(ty, srcType, conv.SpDialect)
+	issues = append(issues, numericIssues...)
+	if srcType.Name == "set" && ty.IsArray && isPk {
+		// Arrays can't be part of a primary key.
+		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+		issues = []internal.SchemaIssue{internal.SetToString}
+	}
 	if len(srcType.ArrayBounds) > 1 {
 		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
 		issues = append(issues, internal.MultiDimensionalArray)
-	} else if len(srcType.ArrayBounds) == 1 {
+	} else if len(srcType.ArrayBounds) == 1 && srcType.Name != "set" {
 		// This check has been added because we don't support Array<primitive type> to string conversions
 		// and Array datatype is currently not supported in datastream.
 		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
@@ -55,7 +67,7 @@ func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType s
 
 func (tdi ToDdlImpl) GetColumnAutoGen(conv *internal.Conv, autoGenCol ddl.AutoGenCol, colId string, tableId string) (*ddl.AutoGenCol, error) {
 	switch autoGenCol.GenerationType {
-	case constants.AUTO_INCREMENT:
+	case constants.AUTO_INCREMENT, constants.AUTO_RANDOM:
 		sequenceId := ""
 		srcSequences := conv.SrcSequences
 		for seqId, seq := range srcSequences {
@@ -80,6 +92,11 @@ func (tdi ToDdlImpl) GetColumnAutoGen(conv *internal.Conv, autoGenCol ddl.AutoGe
 }
 
 func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []internal.SchemaIssue) {
+	// BINARY(16) and CHAR(36) columns also hold digests or addresses, so
+	// they only map to UUID on request, see ToSpannerType.
+	if spType == ddl.UUID && isUUIDType(srcType) {
+		return ddl.Type{Name: ddl.UUID}, nil
+	}
 	switch srcType.Name {
 	case "bool", "boolean":
 		switch spType {
@@ -127,10 +144,8 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 			// MySQL's NUMERIC type can store up to 65 digits, with up to 30 after the
 			// the decimal point. Spanner's NUMERIC type can store up to 29 digits before the
 			// decimal point and up to 9 after the decimal point -- it is equivalent to
-			// MySQL's NUMERIC(38,9) type.
-			//
-			// TODO: Generate appropriate SchemaIssue to warn of different precision
-			// capabilities between MySQL and Spanner NUMERIC.
+			// MySQL's NUMERIC(38,9) type. Precisions that don't fit are reported by
+			// common.ToSpannerNumeric.
 			return ddl.Type{Name: ddl.Numeric}, nil
 		}
 	case "bigint":
@@ -177,7 +192,16 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 		default:
 			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
 		}
-	case "set", "enum":
+	case "set":
+		// SET columns map to arrays of their members, or to strings of
+		// comma-separated members, as MySQL returns them.
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.SetToString}
+		default:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, []internal.SchemaIssue{internal.SetToArray}
+		}
+	case "enum":
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
 	case "json":
 		switch spType {
@@ -225,7 +249,25 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 		}
 	case "time", "year":
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Time}
-
+	case "geometrycollection", "multipoint", "multilinestring", "multipolygon", "point", "linestring", "polygon", "geometry":
+		return common.ToSpannerSpatialType(spType)
 	}
 	return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.NoGoodType}
 }
+
+// isUUIDType returns true if the source type is one of the types commonly
+// used to store UUIDs: BINARY(16) for the raw bytes, or CHAR(36) for the
+// textual form. The length must be declared, as BINARY and CHAR without one
+// hold a single byte or character.
+func isUUIDType(srcType schema.Type) bool {
+	if len(srcType.Mods) != 1 {
+		return false
+	}
+	switch srcType.Name {
+	case "binary":
+		return srcType.Mods[0] == 16
+	case "char":
+		return srcType.Mods[0] == 36
+	}
+	return false
+}
@@ -129,6 +129,49 @@ func TestToSpannerTypeInternal(t *testing.T) {
 	}
 }
 
+func TestToSpannerTypeUUID(t *testing.T) {
+	// BINARY(16) and CHAR(36) columns map to BYTES(MAX) and STRING(36) by
+	// default, and to UUID only on request.
+	conv := internal.MakeConv()
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "binary", Mods: []int64{16}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, ty)
+	assert.Nil(t, issues)
+	ty, _ = ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "char", Mods: []int64{36}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 36}, ty)
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "binary", Mods: []int64{16}}, ddl.UUID)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, ty)
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "char", Mods: []int64{36}}, ddl.UUID)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, ty)
+	// Only BINARY(16) and CHAR(36) columns can hold UUIDs.
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "char", Mods: []int64{10}}, ddl.UUID)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 10}, ty)
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "char"}, "")
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	// BINARY and CHAR without a length hold a single byte or character.
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "binary"}, ddl.UUID)
+	assert.Equal(t, ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, ty)
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "char"}, ddl.UUID)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	// Other types can still be requested.
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "binary", Mods: []int64{16}}, ddl.Bytes)
+	assert.Equal(t, ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, ty)
+}
+
+func TestToSpannerTypeSet(t *testing.T) {
+	conv := internal.MakeConv()
+	set := schema.Type{Name: "set", ArrayBounds: []int64{-1}}
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, "", set, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.SetToArray}, issues)
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, ddl.String, set, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.SetToString}, issues)
+	// Arrays can't be part of a primary key.
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, "", set, true)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.SetToString}, issues)
+}
+
 // This is just a very basic smoke-test for toSpannerType.
 func TestToSpannerType(t *testing.T) {
 	conv := internal.MakeConv()
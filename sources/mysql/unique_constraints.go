@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// GetUniqueConstraints implements the common.UniqueConstraintSource
+// interface. MySQL names the index implementing a UNIQUE constraint after
+// the constraint.
+func (isi InfoSchemaImpl) GetUniqueConstraints(tables []common.SchemaAndName) (map[common.SchemaAndName][]string, error) {
+	included := make(map[common.SchemaAndName]bool)
+	for _, t := range tables {
+		included[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] = true
+	}
+	constraints := make(map[common.SchemaAndName][]string)
+	for _, db := range isi.databases() {
+		if err := isi.getUniqueConstraints(db, included, constraints); err != nil {
+			return nil, err
+		}
+	}
+	return constraints, nil
+}
+
+// getUniqueConstraints adds the unique constraints of the included tables
+// of database db to constraints.
+func (isi InfoSchemaImpl) getUniqueConstraints(db string, included map[common.SchemaAndName]bool, constraints map[common.SchemaAndName][]string) error {
+	q := `SELECT TABLE_NAME, CONSTRAINT_NAME
+		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS
+		WHERE TABLE_SCHEMA = ? AND CONSTRAINT_TYPE = 'UNIQUE';`
+	rows, err := isi.Db.Query(q, db)
+	if err != nil {
+		return fmt.Errorf("couldn't get unique constraints: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, name string
+		if err := rows.Scan(&tableName, &name); err != nil {
+			return fmt.Errorf("couldn't get unique constraints: %w", err)
+		}
+		t := common.SchemaAndName{Schema: db, Name: tableName}
+		if included[t] {
+			constraints[t] = append(constraints[t], name)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+func TestGetUniqueConstraints(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS`)).WithArgs("test").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "CONSTRAINT_NAME"}).
+			AddRow("users", "email").
+			AddRow("users", "uk_login").
+			AddRow("logs", "uk_seq"))
+	isi := InfoSchemaImpl{DbName: "test", Db: db}
+	constraints, err := isi.GetUniqueConstraints([]common.SchemaAndName{{Schema: "test", Name: "users"}})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, map[common.SchemaAndName][]string{{Schema: "test", Name: "users"}: {"email", "uk_login"}}, constraints)
+}
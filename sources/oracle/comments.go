@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// GetComments implements the common.CommentSource interface, reading the
+// comments of tables from all_tab_comments and of their columns from
+// all_col_comments.
+func (isi InfoSchemaImpl) GetComments(tables []common.SchemaAndName) (map[common.SchemaAndName]common.TableComments, error) {
+	included := make(map[string]bool)
+	for _, t := range tables {
+		included[t.Name] = true
+	}
+	q := fmt.Sprintf(`
+		SELECT table_name, NULL, comments FROM all_tab_comments
+		WHERE owner = '%s' AND table_type = 'TABLE' AND comments IS NOT NULL
+		UNION ALL
+		SELECT table_name, column_name, comments FROM all_col_comments
+		WHERE owner = '%s' AND comments IS NOT NULL`, isi.DbName, isi.DbName)
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get comments: %w", err)
+	}
+	defer rows.Close()
+	comments := make(map[common.SchemaAndName]common.TableComments)
+	for rows.Next() {
+		var tableName, comment string
+		var colName sql.NullString
+		if err := rows.Scan(&tableName, &colName, &comment); err != nil {
+			return nil, fmt.Errorf("couldn't get comments: %w", err)
+		}
+		if !included[tableName] {
+			continue
+		}
+		t := common.SchemaAndName{Schema: isi.DbName, Name: tableName}
+		c := comments[t]
+		if !colName.Valid {
+			c.Comment = comment
+		} else {
+			if c.Columns == nil {
+				c.Columns = make(map[string]string)
+			}
+			c.Columns[colName.String] = comment
+		}
+		comments[t] = c
+	}
+	return comments, rows.Err()
+}
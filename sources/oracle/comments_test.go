@@ -0,0 +1,45 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+func TestGetComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM all_tab_comments`)).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "column_name", "comments"}).
+			AddRow("ORDERS", nil, "Orders placed by customers").
+			AddRow("ORDERS", "ID", "Order number").
+			AddRow("USERS", "EMAIL", "Login of the user").
+			AddRow("AUDIT", nil, "Not migrated"))
+	isi := InfoSchemaImpl{DbName: "TEST", Db: db}
+	tables := []common.SchemaAndName{{Schema: "TEST", Name: "ORDERS"}, {Schema: "TEST", Name: "USERS"}}
+	comments, err := isi.GetComments(tables)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, map[common.SchemaAndName]common.TableComments{
+		{Schema: "TEST", Name: "ORDERS"}: {Comment: "Orders placed by customers", Columns: map[string]string{"ID": "Order number"}},
+		{Schema: "TEST", Name: "USERS"}:  {Columns: map[string]string{"EMAIL": "Login of the user"}},
+	}, comments)
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+)
+
+// The expressions of Oracle virtual columns and of function-based indexes
+// are translated to GoogleSQL when they only use operators and functions
+// with the same semantics in Spanner. Oracle reports them with double
+// quoted identifiers, which are backquoted in GoogleSQL, and single quoted
+// string literals, which are the same in both.
+
+var (
+	// Index key expressions which are a column, e.g. for keys in
+	// descending order.
+	quotedIdentifierRegex = regexp.MustCompile(`^"[^"]+"$`)
+	// Function calls, whose name is checked against generatedExprFunctions.
+	generatedExprFuncRegex = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_$#]*)(\s*\()`)
+	// Operators and keywords without a GoogleSQL equivalent: || concatenates
+	// NULL as an empty string, unlike CONCAT, and (+) is an outer join.
+	generatedExprOperatorRegex = regexp.MustCompile(`\|\||\(\s*\+\s*\)|\^=|(?i)\b(sysdate|systimestamp|rownum|rowid|prior|date|timestamp|interval|from|both|leading|trailing)\b`)
+)
+
+// generatedExprFunctions maps the Oracle functions supported in virtual
+// column and index expressions to their GoogleSQL name. Functions also
+// accepting dates in Oracle, e.g. TRUNC, and MOD, which returns its first
+// argument for a zero divisor, are left out.
+var generatedExprFunctions = map[string]string{
+	"abs":      "ABS",
+	"ceil":     "CEIL",
+	"coalesce": "COALESCE",
+	"exp":      "EXP",
+	"floor":    "FLOOR",
+	"greatest": "GREATEST",
+	"least":    "LEAST",
+	"length":   "CHAR_LENGTH",
+	"ln":       "LN",
+	"lower":    "LOWER",
+	"lpad":     "LPAD",
+	"ltrim":    "LTRIM",
+	"nullif":   "NULLIF",
+	"nvl":      "IFNULL",
+	"power":    "POWER",
+	"replace":  "REPLACE",
+	"round":    "ROUND",
+	"rpad":     "RPAD",
+	"rtrim":    "RTRIM",
+	"sign":     "SIGN",
+	"sqrt":     "SQRT",
+	"substr":   "SUBSTR",
+	"trim":     "TRIM",
+	"upper":    "UPPER",
+}
+
+// Keywords which can be followed by a parenthesis in expressions.
+var generatedExprKeywords = map[string]bool{
+	"and": true, "case": true, "else": true, "in": true, "is": true, "like": true,
+	"not": true, "or": true, "then": true, "when": true,
+}
+
+// ToSpannerGeneratedExpression implements the common.GeneratedColumnTranslator
+// interface. Expressions are only translated for the GoogleSQL dialect.
+func (tdi ToDdlImpl) ToSpannerGeneratedExpression(conv *internal.Conv, expr string) (string, bool) {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return "", false
+	}
+	return toSpannerGeneratedExpression(expr)
+}
+
+// toSpannerGeneratedExpression translates an Oracle virtual column or
+// function-based index expression, as reported by the data dictionary e.g.
+// UPPER("FIRST_NAME"), to GoogleSQL. It returns false when the expression
+// can't be translated.
+func toSpannerGeneratedExpression(expr string) (string, bool) {
+	var sb strings.Builder
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		quote := expr[i]
+		if quote != '\'' && quote != '"' {
+			continue
+		}
+		translated, ok := translateGeneratedExprSegment(expr[start:i])
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(translated)
+		// Quotes are escaped by doubling them.
+		j := i + 1
+		for j < len(expr) && (expr[j] != quote || j+1 < len(expr) && expr[j+1] == quote) {
+			if expr[j] == quote {
+				j++
+			}
+			j++
+		}
+		if j >= len(expr) {
+			return "", false
+		}
+		if quote == '"' {
+			ident := expr[i+1 : j]
+			if strings.ContainsAny(ident, "`\"") {
+				return "", false
+			}
+			sb.WriteString("`" + ident + "`")
+		} else {
+			sb.WriteString(expr[i : j+1])
+		}
+		start, i = j+1, j
+	}
+	translated, ok := translateGeneratedExprSegment(expr[start:])
+	if !ok {
+		return "", false
+	}
+	sb.WriteString(translated)
+	s := strings.TrimSpace(sb.String())
+	return s, s != ""
+}
+
+// translateGeneratedExprSegment translates the function calls of a part of
+// an expression without literals or identifiers.
+func translateGeneratedExprSegment(seg string) (string, bool) {
+	if generatedExprOperatorRegex.MatchString(seg) {
+		return "", false
+	}
+	ok := true
+	seg = generatedExprFuncRegex.ReplaceAllStringFunc(seg, func(call string) string {
+		m := generatedExprFuncRegex.FindStringSubmatch(call)
+		name := strings.ToLower(m[1])
+		if generatedExprKeywords[name] {
+			return call
+		}
+		fn, found := generatedExprFunctions[name]
+		if !found {
+			ok = false
+			return call
+		}
+		return fn + m[2]
+	})
+	return seg, ok
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestToSpannerGeneratedExpression(t *testing.T) {
+	translated := []struct {
+		expr, want string
+	}{
+		{`"SALARY"*12`, "`SALARY`*12"},
+		{`UPPER("EMAIL")`, "UPPER(`EMAIL`)"},
+		{`NVL("BONUS",0)+"SALARY"`, "IFNULL(`BONUS`,0)+`SALARY`"},
+		{`LENGTH(TRIM("NAME"))`, "CHAR_LENGTH(TRIM(`NAME`))"},
+		{`SUBSTR("CODE",1,3)`, "SUBSTR(`CODE`,1,3)"},
+		{`CASE  WHEN "QTY">10 THEN 'it''s big' ELSE 'small' END`, "CASE  WHEN `QTY`>10 THEN 'it''s big' ELSE 'small' END"},
+		{`REPLACE("PHONE",'-(',NULL)`, "REPLACE(`PHONE`,'-(',NULL)"},
+	}
+	for _, tc := range translated {
+		got, ok := toSpannerGeneratedExpression(tc.expr)
+		assert.True(t, ok, tc.expr)
+		assert.Equal(t, tc.want, got, tc.expr)
+	}
+	for _, expr := range []string{
+		`"FIRST_NAME"||' '||"LAST_NAME"`,
+		`TO_CHAR("HIRE_DATE",'YYYY')`,
+		`DECODE("STATUS",'A',1,0)`,
+		`TRUNC("CREATED")`,
+		`MOD("ID",10)`,
+		`TRIM(LEADING '0' FROM "CODE")`,
+		`"A"^=1`,
+		`'unterminated`,
+		``,
+	} {
+		_, ok := toSpannerGeneratedExpression(expr)
+		assert.False(t, ok, expr)
+	}
+
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	_, ok := ToDdlImpl{}.ToSpannerGeneratedExpression(conv, `UPPER("EMAIL")`)
+	assert.False(t, ok)
+}
+
+func TestVirtualColumnsAndIndexExpressions(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SetSchemaMode()
+	srcTable := schema.Table{
+		Name:   "EMP",
+		Id:     "t1",
+		ColIds: []string{"id", "email", "upper", "id_char"},
+		ColDefs: map[string]schema.Column{
+			"id":    {Name: "ID", Id: "id", Type: schema.Type{Name: "NUMBER", Mods: []int64{10}}},
+			"email": {Name: "EMAIL", Id: "email", Type: schema.Type{Name: "VARCHAR2", Mods: []int64{100}}},
+			"upper": {Name: "EMAIL_UPPER", Id: "upper", Type: schema.Type{Name: "VARCHAR2", Mods: []int64{100}},
+				GeneratedColumn: ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{ExpressionId: "upper_expr", Statement: `UPPER("EMAIL")`}, Type: ddl.GeneratedVirtual}},
+			"id_char": {Name: "ID_CHAR", Id: "id_char", Type: schema.Type{Name: "VARCHAR2", Mods: []int64{4}},
+				GeneratedColumn: ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{ExpressionId: "id_char_expr", Statement: `TO_CHAR("ID")`}, Type: ddl.GeneratedVirtual}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "id"}},
+		Indexes: []schema.Index{
+			{Name: "EMP_LOWER_EMAIL", Id: "i1", Unique: true, Keys: []schema.Key{
+				{Expr: `LOWER("EMAIL")`, ExprType: &schema.Type{Name: "VARCHAR2", Mods: []int64{100}}},
+				{ColId: "id", Desc: true},
+			}},
+			{Name: "EMP_ID_CHAR", Id: "i2", Keys: []schema.Key{{Expr: `TO_CHAR("ID")`, ExprType: &schema.Type{Name: "VARCHAR2", Mods: []int64{40}}}}},
+		},
+	}
+	conv.SrcSchema["t1"] = srcTable
+	ss := common.SchemaToSpannerImpl{}
+	assert.Nil(t, ss.SchemaToSpannerDDLHelper(conv, ToDdlImpl{}, srcTable, false))
+
+	spTable := conv.SpSchema["t1"]
+	assert.Equal(t, ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{ExpressionId: "upper_expr", Statement: "UPPER(`EMAIL`)"}, Type: ddl.GeneratedStored}, spTable.ColDefs["upper"].GeneratedColumn)
+	assert.False(t, spTable.ColDefs["id_char"].GeneratedColumn.IsPresent)
+	assert.Equal(t, []internal.SchemaIssue{internal.GeneratedColumnMaterialized}, conv.SchemaIssues["t1"].ColumnLevelIssues["id_char"])
+
+	// The expression of the index is a stored generated column.
+	assert.Equal(t, 5, len(spTable.ColIds))
+	exprColId := spTable.ColIds[4]
+	exprCol := spTable.ColDefs[exprColId]
+	assert.Equal(t, "EMP_LOWER_EMAIL_Expr", exprCol.Name)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 100}, exprCol.T)
+	assert.Equal(t, "LOWER(`EMAIL`)", exprCol.GeneratedColumn.Value.Statement)
+	assert.Equal(t, ddl.GeneratedStored, exprCol.GeneratedColumn.Type)
+	// The index with an untranslatable expression is dropped.
+	assert.Equal(t, []ddl.CreateIndex{{
+		Name:    "EMP_LOWER_EMAIL",
+		TableId: "t1",
+		Id:      "i1",
+		Unique:  true,
+		Keys:    []ddl.IndexKey{{ColId: exprColId}, {ColId: "id", Desc: true}},
+	}}, spTable.Indexes)
+	assert.Equal(t, `LOWER("EMAIL")`, conv.SrcSchema["t1"].Indexes[0].Keys[0].Expr)
+}
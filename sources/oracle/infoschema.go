@@ -38,6 +38,8 @@ type InfoSchemaImpl struct {
 	MigrationProjectId string
 	SourceProfile      profiles.SourceProfile
 	TargetProfile      profiles.TargetProfile
+	// Metadata is the metadata of tables fetched in bulk, if it has been.
+	Metadata *common.BulkMetadata
 }
 
 // GetToDdl function below implement the common.InfoSchema interface.
@@ -76,6 +78,9 @@ func getSelectQuery(srcDb string, schemaName string, tableName string, colIds []
 				FROM TABLE ("%s"."%s")) AS "%s"`, tableName, cn, cn)
 		} else {
 			switch colDefs[colId].Type.Name {
+			case "CLOB", "NCLOB", "BLOB":
+				// The rest of LOB values is read in chunks by a lobReader.
+				s = fmt.Sprintf(`DBMS_LOB.SUBSTR("%s", %d, 1) AS "%s"`, cn, lobChunkSize(colDefs[colId].Type.Name), cn)
 			case "NUMBER":
 				s = fmt.Sprintf(`TO_CHAR("%s") AS "%s"`, cn, cn)
 			case "XMLTYPE":
@@ -97,12 +102,16 @@ func getSelectQuery(srcDb string, schemaName string, tableName string, colIds []
 		}
 		selects[i] = s
 	}
+	selects = append(selects, getLobSelects(colIds, colDefs)...)
 
 	return fmt.Sprintf(`SELECT %s FROM "%s"."%s"`, strings.Join(selects, ", "), schemaName, tableName)
 }
 
 // ProcessData performs data conversion for source database.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	if common.ReadsPartitionsInParallel(conv.SrcSchema[tableId]) {
+		return isi.processPartitionsData(conv, tableId, srcSchema, commonColIds, spSchema)
+	}
 	srcTableName := conv.SrcSchema[tableId].Name
 	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
 	if err != nil {
@@ -114,6 +123,7 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 	srcCols, _ := rows.Columns()
 	v, scanArgs := buildVals(len(srcCols))
 	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
+	lr := isi.newLobReader(conv.SrcSchema[tableId])
 	for rows.Next() {
 		// get RawBytes from data.
 		err := rows.Scan(scanArgs...)
@@ -123,19 +133,46 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
 			continue
 		}
-		values := valsToStrings(v)
-		newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
-		if err != nil {
-			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
-			conv.StatsAddBadRow(srcTableName, conv.DataMode())
-			conv.CollectBadRow(srcTableName, srcCols, values)
+		values, ok := readLobValues(conv, lr, srcTableName, valsToStrings(v))
+		if !ok {
 			continue
 		}
-		ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+		processRowValues(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, srcCols[:len(values)], values)
 	}
 	return nil
 }
 
+// readLobValues reads the LOB values of a row with lr, if the table has LOB
+// columns, and returns the values of its columns. It returns false when the
+// row is skipped, and counted as a bad row.
+func readLobValues(conv *internal.Conv, lr *lobReader, srcTableName string, values []string) ([]string, bool) {
+	if lr == nil {
+		return values, true
+	}
+	values, ok, err := lr.readRow(conv, values)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't read LOB values of sql data row: %s", err))
+	}
+	if !ok {
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+	}
+	return values, ok
+}
+
+// processRowValues converts the values of the columns srcCols of a row of
+// a table, and writes them.
+func processRowValues(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, colNameIdMap map[string]string, srcCols, values []string) {
+	srcTableName := conv.SrcSchema[tableId].Name
+	newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcCols, values)
+		return
+	}
+	ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+}
+
 // GetRowCount with number of rows in each table.
 func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
 	q := fmt.Sprintf(`SELECT count(*) FROM "%s"`, table.Name)
@@ -183,13 +220,14 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 						act.elem_type_name,
 						act.length,
 						act.precision,
-						act.scale
-					FROM all_tab_columns atc
+						act.scale,
+						atc.virtual_column
+					FROM all_tab_cols atc
 					LEFT JOIN all_types at ON atc.data_type=at.type_name AND atc.owner = at.owner
 					LEFT JOIN all_coll_types act ON atc.data_type=act.type_name AND atc.owner = at.owner
-					WHERE atc.owner = '%s' AND atc.table_name = '%s'
+					WHERE atc.owner = '%s' AND atc.table_name = '%s' AND atc.hidden_column = 'NO'
 					`, table.Schema, table.Name)
-	cols, err := isi.Db.Query(q)
+	cols, err := isi.Metadata.Query(isi.Db, common.MetadataColumns, table, q)
 	if err != nil {
 		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
 	}
@@ -198,10 +236,10 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	var colIds []string
 	var colName, dataType string
 	var isNullable string
-	var colDefault, typecode, elementDataType sql.NullString
+	var colDefault, typecode, elementDataType, virtualColumn sql.NullString
 	var charMaxLen, numericPrecision, numericScale, elementCharMaxLen, elementNumericPrecision, elementNumericScale sql.NullInt64
 	for cols.Next() {
-		err := cols.Scan(&colName, &dataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &typecode, &elementDataType, &elementCharMaxLen, &elementNumericPrecision, &elementNumericScale)
+		err := cols.Scan(&colName, &dataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &typecode, &elementDataType, &elementCharMaxLen, &elementNumericPrecision, &elementNumericScale, &virtualColumn)
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
@@ -228,14 +266,25 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 			charMaxLen.Valid = false
 		}
 
-		ignored.Default = colDefault.Valid
+		// The expression of virtual columns is their default.
+		var generatedCol ddl.GeneratedColumn
+		if virtualColumn.String == "YES" && colDefault.Valid {
+			generatedCol = ddl.GeneratedColumn{
+				IsPresent: true,
+				Value:     ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: strings.TrimSpace(colDefault.String)},
+				Type:      ddl.GeneratedVirtual,
+			}
+		} else {
+			ignored.Default = colDefault.Valid
+		}
 		colId := internal.GenerateColumnId()
 		c := schema.Column{
-			Id:      colId,
-			Name:    colName,
-			Type:    toType(dataType, typecode, elementDataType, charMaxLen, numericPrecision, numericScale, elementCharMaxLen, elementNumericPrecision, elementNumericScale),
-			NotNull: strings.ToUpper(isNullable) == "N",
-			Ignored: ignored,
+			Id:              colId,
+			Name:            colName,
+			Type:            toType(dataType, typecode, elementDataType, charMaxLen, numericPrecision, numericScale, elementCharMaxLen, elementNumericPrecision, elementNumericScale),
+			NotNull:         strings.ToUpper(isNullable) == "N",
+			Ignored:         ignored,
+			GeneratedColumn: generatedCol,
 		}
 		colDefs[colId] = c
 		colIds = append(colIds, colId)
@@ -258,7 +307,7 @@ func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.Schem
        				ON (k.constraint_name = t.constraint_name) 
 					WHERE t.owner = '%s' AND k.table_name = '%s'
 					`, table.Schema, table.Name)
-	rows, err := isi.Db.Query(q)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataConstraints, table, q)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -308,7 +357,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 						JOIN all_cons_columns B ON B.owner = C.owner AND B.constraint_name = C.r_constraint_name
 						WHERE A.table_name='%s' AND A.owner='%s'
 					`, table.Name, isi.DbName)
-	rows, err := isi.Db.Query(q)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataForeignKeys, table, q)
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +402,9 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 // 3.Partitioned indexes
 // 4. Function-based indexes
 // 5.Domain indexes,
-// we are only considering normal index as of now.
+// we are only considering normal and function-based normal indexes as of now.
+// Keys of function-based indexes on an expression have the expression and
+// the type of the hidden column Oracle indexes its values in.
 func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
 	q := fmt.Sprintf(`
 					SELECT 
@@ -363,43 +414,58 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 						IC.descend,
 						I.uniqueness, 
 						IE.column_expression, 
-						I.index_type 
+						I.index_type,
+						TC.data_type,
+						TC.data_length,
+						TC.data_precision,
+						TC.data_scale
                 	FROM  all_ind_columns IC 
 					LEFT JOIN all_ind_expressions IE ON IC.index_name = IE.index_name 
 						AND IC.column_position=IE.column_position
 						AND IC.index_owner = IE.index_owner
                 	LEFT JOIN all_indexes I ON IC.index_name = I.index_name
 						 AND I.table_owner = IC.index_owner
+					LEFT JOIN all_tab_cols TC ON TC.owner = IC.table_owner
+						AND TC.table_name = IC.table_name
+						AND TC.column_name = IC.column_name
                 	WHERE IC.index_owner='%s' AND IC.table_name='%s'
             		ORDER BY IC.index_name, IC.column_position
 				`, table.Schema, table.Name)
-	rows, err := isi.Db.Query(q)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataIndexes, table, q)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	var name, column, sequence, Unique, indexType string
-	var collation, colexpression sql.NullString
+	var collation, colexpression, exprDataType sql.NullString
+	var exprLen, exprPrecision, exprScale sql.NullInt64
 	indexMap := make(map[string]schema.Index)
 	var indexNames []string
 	ignoredIndex := make(map[string]bool)
 	var indexes []schema.Index
 	for rows.Next() {
-		if err := rows.Scan(&name, &column, &sequence, &collation, &Unique, &colexpression, &indexType); err != nil {
+		if err := rows.Scan(&name, &column, &sequence, &collation, &Unique, &colexpression, &indexType, &exprDataType, &exprLen, &exprPrecision, &exprScale); err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
 		}
-		// ingnore all index except normal
-		// UPPER("EMAIL") check for the function call with "(",")"
-		if indexType != "NORMAL" && strings.Contains(colexpression.String, "(") && strings.Contains(colexpression.String, ")") {
-			ignoredIndex[name] = true
-		}
-
 		//INDEX1_LAST	SYS_NC00009$	1	DESC	NONUNIQUE	"LAST_NAME"	FUNCTION-BASED NORMAL
 		// DESC column make index functional index but as special case we included that
 		// and update column name with column expression
-		if colexpression.Valid && !strings.Contains(colexpression.String, "(") && !strings.Contains(colexpression.String, ")") {
+		key := schema.Key{Desc: (collation.Valid && collation.String == "DESC")}
+		if colexpression.Valid && quotedIdentifierRegex.MatchString(colexpression.String) {
 			column = colexpression.String[1 : len(colexpression.String)-1]
+		} else if colexpression.Valid {
+			// ingnore all index with expressions except function-based normal
+			// e.g. UPPER("EMAIL")
+			if indexType != "FUNCTION-BASED NORMAL" {
+				ignoredIndex[name] = true
+			}
+			exprType := modifyType(exprDataType.String, exprLen, exprPrecision, exprScale, false)
+			key.Expr = strings.TrimSpace(colexpression.String)
+			key.ExprType = &exprType
+		}
+		if key.Expr == "" {
+			key.ColId = colNameIdMap[column]
 		}
 
 		if _, found := indexMap[name]; !found {
@@ -410,9 +476,7 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 				Unique: (Unique == "UNIQUE")}
 		}
 		index := indexMap[name]
-		index.Keys = append(index.Keys, schema.Key{
-			ColId: colNameIdMap[column],
-			Desc:  (collation.Valid && collation.String == "DESC")})
+		index.Keys = append(index.Keys, key)
 		indexMap[name] = index
 	}
 	for _, k := range indexNames {
@@ -424,6 +488,97 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 	return indexes, nil
 }
 
+// WithBulkMetadata implements the common.BulkMetadataSource interface. The
+// metadata of the tables of the schema is fetched with the queries of that
+// of a table, without their filter on the table.
+func (isi InfoSchemaImpl) WithBulkMetadata(tables []common.SchemaAndName) (common.InfoSchema, error) {
+	queries := map[string]string{
+		common.MetadataColumns: fmt.Sprintf(`
+						SELECT
+						atc.owner,
+						atc.table_name,
+						column_name,
+						data_type,
+						nullable,
+						data_default,
+						data_length,
+						data_precision,
+						data_scale,
+						at.typecode,
+						act.elem_type_name,
+						act.length,
+						act.precision,
+						act.scale,
+						atc.virtual_column
+					FROM all_tab_cols atc
+					LEFT JOIN all_types at ON atc.data_type=at.type_name AND atc.owner = at.owner
+					LEFT JOIN all_coll_types act ON atc.data_type=act.type_name AND atc.owner = at.owner
+					WHERE atc.owner = '%s' AND atc.hidden_column = 'NO'
+					ORDER BY atc.table_name
+					`, isi.DbName),
+		common.MetadataConstraints: fmt.Sprintf(`
+					SELECT
+						t.owner,
+						k.table_name,
+						k.column_name,
+						t.constraint_type,
+						t.search_condition
+					FROM all_constraints t
+					INNER JOIN all_cons_columns k
+					ON (k.constraint_name = t.constraint_name)
+					WHERE t.owner = '%s'
+					`, isi.DbName),
+		common.MetadataForeignKeys: fmt.Sprintf(`
+						SELECT
+							A.owner,
+							A.table_name,
+							B.table_name AS ref_table,
+							A.column_name AS col_name,
+							B.column_name AS ref_col_name,
+							A.constraint_name AS name
+						FROM all_cons_columns A
+						JOIN all_constraints C ON A.owner = C.owner AND A.constraint_name = C.constraint_name
+						JOIN all_cons_columns B ON B.owner = C.owner AND B.constraint_name = C.r_constraint_name
+						WHERE A.owner='%s'
+					`, isi.DbName),
+		common.MetadataIndexes: fmt.Sprintf(`
+					SELECT
+						IC.index_owner,
+						IC.table_name,
+						IC.index_name,
+						IC.column_name,
+						IC.column_position,
+						IC.descend,
+						I.uniqueness,
+						IE.column_expression,
+						I.index_type,
+						TC.data_type,
+						TC.data_length,
+						TC.data_precision,
+						TC.data_scale
+					FROM  all_ind_columns IC
+					LEFT JOIN all_ind_expressions IE ON IC.index_name = IE.index_name
+						AND IC.column_position=IE.column_position
+						AND IC.index_owner = IE.index_owner
+					LEFT JOIN all_indexes I ON IC.index_name = I.index_name
+						 AND I.table_owner = IC.index_owner
+					LEFT JOIN all_tab_cols TC ON TC.owner = IC.table_owner
+						AND TC.table_name = IC.table_name
+						AND TC.column_name = IC.column_name
+					WHERE IC.index_owner='%s'
+					ORDER BY IC.table_name, IC.index_name, IC.column_position
+				`, isi.DbName),
+	}
+	metadata := &common.BulkMetadata{}
+	for kind, q := range queries {
+		if err := metadata.Fetch(isi.Db, kind, q); err != nil {
+			return isi, err
+		}
+	}
+	isi.Metadata = metadata
+	return isi, nil
+}
+
 // StartChangeDataCapture is used for automatic triggering of Datastream job when
 // performing a streaming migration.
 func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
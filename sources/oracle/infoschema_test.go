@@ -29,6 +29,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/mocks"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
@@ -51,6 +52,11 @@ func TestProcessSchemaOracle(t *testing.T) {
 				{"TEST"},
 				{"TEST2"}},
 		},
+		{
+			query: `SELECT table_name, index_name FROM all_constraints (.+)`,
+			args:  []driver.Value{},
+			cols:  []string{"table_name", "index_name"},
+		},
 		// USER table
 		{
 			query: `SELECT (.+) FROM all_constraints (.+)`,
@@ -70,26 +76,26 @@ func TestProcessSchemaOracle(t *testing.T) {
 			},
 		},
 		{
-			query: "SELECT (.+) FROM all_tab_columns (.+)",
+			query: "SELECT (.+) FROM all_tab_cols (.+)",
 			args:  []driver.Value{},
-			cols:  []string{"column_name", "data_type", "nullable", "data_default", "data_length", "data_precision", "data_scale", "typecode", "element_type", "element_length", "element_precision", "element_scale"},
+			cols:  []string{"column_name", "data_type", "nullable", "data_default", "data_length", "data_precision", "data_scale", "typecode", "element_type", "element_length", "element_precision", "element_scale", "virtual_column"},
 			rows: [][]driver.Value{
-				{"USER_ID", "VARCHAR2", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil},
-				{"NAME", "VARCHAR2", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil},
-				{"REF", "NUMBER", "Y", nil, nil, nil, nil, nil, nil, nil, nil, nil}},
+				{"USER_ID", "VARCHAR2", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil, "NO"},
+				{"NAME", "VARCHAR2", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil, "NO"},
+				{"REF", "NUMBER", "Y", nil, nil, nil, nil, nil, nil, nil, nil, nil, "NO"}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: `SELECT (.+) LEFT JOIN all_ind_expressions IE (.+) LEFT JOIN all_indexes I (.+)`,
 			args:  []driver.Value{},
-			cols:  []string{"name", "column_name", "column_position", "descend", "uniqueness", "column_expression", "index_type"},
+			cols:  []string{"name", "column_name", "column_position", "descend", "uniqueness", "column_expression", "index_type", "data_type", "data_length", "data_precision", "data_scale"},
 			rows: [][]driver.Value{
-				{"INDEX1_LAST", "SYS_NC00009$", 1, "DESC", "NONUNIQUE", "\"NAME\"", "FUNCTION-BASED NORMAL"},
-				{"INDEX_CONTACT_TEST", "SYS_NC00008$", 1, "ASC", "UNIQUE", "UPPER(\"USER_ID\")", "FUNCTION-BASED NORMAL"},
-				{"INDEX_CONTACT_TEST", "REF", 2, "ASC", "UNIQUE", nil, "FUNCTION-BASED NORMAL"},
-				{"INDEX_CONTACT_TEST", "NAME", 3, "ASC", "UNIQUE", nil, "FUNCTION-BASED NORMAL"},
-				{"INDEX_TEST_2", "SYS_NC00007$", 1, "DESC", "NONUNIQUE", "\"NAME\"", "FUNCTION-BASED NORMAL"},
-				{"INDEX_TEST_2", "SYS_NC00009$", 2, "DESC", "NONUNIQUE", "\"USER_ID\"", "FUNCTION-BASED NORMAL"},
+				{"INDEX1_LAST", "SYS_NC00009$", 1, "DESC", "NONUNIQUE", "\"NAME\"", "FUNCTION-BASED NORMAL", nil, nil, nil, nil},
+				{"INDEX_CONTACT_TEST", "SYS_NC00008$", 1, "ASC", "UNIQUE", "TO_CHAR(\"USER_ID\")", "FUNCTION-BASED NORMAL", "VARCHAR2", 40, nil, nil},
+				{"INDEX_CONTACT_TEST", "REF", 2, "ASC", "UNIQUE", nil, "FUNCTION-BASED NORMAL", nil, nil, nil, nil},
+				{"INDEX_CONTACT_TEST", "NAME", 3, "ASC", "UNIQUE", nil, "FUNCTION-BASED NORMAL", nil, nil, nil, nil},
+				{"INDEX_TEST_2", "SYS_NC00007$", 1, "DESC", "NONUNIQUE", "\"NAME\"", "FUNCTION-BASED NORMAL", nil, nil, nil, nil},
+				{"INDEX_TEST_2", "SYS_NC00009$", 2, "DESC", "NONUNIQUE", "\"USER_ID\"", "FUNCTION-BASED NORMAL", nil, nil, nil, nil},
 			},
 		},
 
@@ -108,17 +114,17 @@ func TestProcessSchemaOracle(t *testing.T) {
 			rows:  [][]driver.Value{},
 		},
 		{
-			query: "SELECT (.+) FROM all_tab_columns (.+)",
+			query: "SELECT (.+) FROM all_tab_cols (.+)",
 			args:  []driver.Value{},
-			cols:  []string{"column_name", "data_type", "nullable", "data_default", "data_length", "data_precision", "data_scale", "typecode", "element_type", "element_length", "element_precision", "element_scale"},
+			cols:  []string{"column_name", "data_type", "nullable", "data_default", "data_length", "data_precision", "data_scale", "typecode", "element_type", "element_length", "element_precision", "element_scale", "virtual_column"},
 			rows: [][]driver.Value{
-				{"ID", "NUMBER", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil}},
+				{"ID", "NUMBER", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil, "NO"}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: `SELECT (.+) LEFT JOIN all_ind_expressions IE (.+) LEFT JOIN all_indexes I (.+)`,
 			args:  []driver.Value{},
-			cols:  []string{"name", "column_name", "column_position", "descend", "uniqueness", "column_expression", "index_type"},
+			cols:  []string{"name", "column_name", "column_position", "descend", "uniqueness", "column_expression", "index_type", "data_type", "data_length", "data_precision", "data_scale"},
 			rows:  [][]driver.Value{},
 		},
 
@@ -139,27 +145,27 @@ func TestProcessSchemaOracle(t *testing.T) {
 		},
 
 		{
-			query: "SELECT (.+) FROM all_tab_columns (.+)",
+			query: "SELECT (.+) FROM all_tab_cols (.+)",
 			args:  []driver.Value{},
-			cols:  []string{"column_name", "data_type", "nullable", "data_default", "data_length", "data_precision", "data_scale", "typecode", "element_type", "element_length", "element_precision", "element_scale"},
+			cols:  []string{"column_name", "data_type", "nullable", "data_default", "data_length", "data_precision", "data_scale", "typecode", "element_type", "element_length", "element_precision", "element_scale", "virtual_column"},
 			rows: [][]driver.Value{
-				{"ID", "NUMBER", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil},
-				{"JSON", "VARCHAR2", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil},
-				{"REALJSON", "JSON", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil},
-				{"ARRAY_NUM", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "NUMBER", nil, 10, 5},
-				{"ARRAY_FLOAT", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "FLOAT", nil, nil, nil},
-				{"ARRAY_STRING", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "VARCHAR2", 15, nil, nil},
-				{"ARRAY_DATE", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "DATE", nil, nil, nil},
-				{"ARRAY_INT", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "NUMBER", nil, 10, 0},
-				{"OBJECT", "CONTACTS", "N", nil, nil, nil, nil, "OBJECT", nil, nil, nil, nil},
-				{"BINARY_FLOAT", "BINARY_FLOAT", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil},
-				{"ARRAY_BINARY_FLOAT", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "BINARY_FLOAT", nil, nil, nil}},
+				{"ID", "NUMBER", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil, "NO"},
+				{"JSON", "VARCHAR2", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil, "NO"},
+				{"REALJSON", "JSON", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil, "NO"},
+				{"ARRAY_NUM", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "NUMBER", nil, 10, 5, "NO"},
+				{"ARRAY_FLOAT", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "FLOAT", nil, nil, nil, "NO"},
+				{"ARRAY_STRING", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "VARCHAR2", 15, nil, nil, "NO"},
+				{"ARRAY_DATE", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "DATE", nil, nil, nil, "NO"},
+				{"ARRAY_INT", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "NUMBER", nil, 10, 0, "NO"},
+				{"OBJECT", "CONTACTS", "N", nil, nil, nil, nil, "OBJECT", nil, nil, nil, nil, "NO"},
+				{"BINARY_FLOAT", "BINARY_FLOAT", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil, "NO"},
+				{"ARRAY_BINARY_FLOAT", "STUDENT", "N", nil, nil, nil, nil, "COLLECTION", "BINARY_FLOAT", nil, nil, nil, "NO"}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: `SELECT (.+) LEFT JOIN all_ind_expressions IE (.+) LEFT JOIN all_indexes I (.+)`,
 			args:  []driver.Value{},
-			cols:  []string{"name", "column_name", "column_position", "descend", "uniqueness", "column_expression", "index_type"},
+			cols:  []string{"name", "column_name", "column_position", "descend", "uniqueness", "column_expression", "index_type", "data_type", "data_length", "data_precision", "data_scale"},
 			rows:  [][]driver.Value{},
 		},
 	}
@@ -179,7 +185,7 @@ func TestProcessSchemaOracle(t *testing.T) {
 		ExpressionVerificationAccessor: mockAccessor,
 		DdlV:                           &expressions_api.MockDDLVerifier{},
 	}
-	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{"test", db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, nil}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
 	assert.Nil(t, err)
 	expectedSchema := map[string]ddl.CreateTable{
 		"USER": {
@@ -273,3 +279,48 @@ func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
 	}
 	return db
 }
+
+func TestGetColumnsAndIndexesWithExpressions(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM all_tab_cols (.+)",
+			args:  []driver.Value{},
+			cols:  []string{"column_name", "data_type", "nullable", "data_default", "data_length", "data_precision", "data_scale", "typecode", "element_type", "element_length", "element_precision", "element_scale", "virtual_column"},
+			rows: [][]driver.Value{
+				{"SALARY", "NUMBER", "Y", "0", nil, 10, 2, nil, nil, nil, nil, nil, "NO"},
+				{"ANNUAL", "NUMBER", "Y", `"SALARY"*12 `, nil, nil, nil, nil, nil, nil, nil, nil, "YES"}},
+		},
+		{
+			query: `SELECT (.+) LEFT JOIN all_ind_expressions IE (.+) LEFT JOIN all_indexes I (.+) LEFT JOIN all_tab_cols TC (.+)`,
+			args:  []driver.Value{},
+			cols:  []string{"name", "column_name", "column_position", "descend", "uniqueness", "column_expression", "index_type", "data_type", "data_length", "data_precision", "data_scale"},
+			rows: [][]driver.Value{
+				{"EMP_ROUNDED", "SYS_NC00003$", 1, "ASC", "NONUNIQUE", `ROUND("SALARY")`, "FUNCTION-BASED NORMAL", "NUMBER", 22, nil, nil},
+				{"EMP_ROUNDED", "SALARY", 2, "ASC", "NONUNIQUE", nil, "FUNCTION-BASED NORMAL", nil, nil, nil, nil},
+				{"EMP_BITMAP", "SYS_NC00004$", 1, "ASC", "NONUNIQUE", `ABS("SALARY")`, "FUNCTION-BASED BITMAP", "NUMBER", 22, nil, nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	isi := InfoSchemaImpl{DbName: "test", Db: db}
+	table := common.SchemaAndName{Schema: "test", Name: "EMP"}
+	colDefs, colIds, err := isi.GetColumns(conv, table, nil, nil)
+	assert.Nil(t, err)
+	salary, annual := colDefs[colIds[0]], colDefs[colIds[1]]
+	assert.True(t, salary.Ignored.Default)
+	assert.False(t, salary.GeneratedColumn.IsPresent)
+	assert.False(t, annual.Ignored.Default)
+	assert.True(t, annual.GeneratedColumn.IsPresent)
+	assert.Equal(t, `"SALARY"*12`, annual.GeneratedColumn.Value.Statement)
+	assert.Equal(t, ddl.GeneratedVirtual, annual.GeneratedColumn.Type)
+
+	indexes, err := isi.GetIndexes(conv, table, map[string]string{"SALARY": colIds[0], "ANNUAL": colIds[1]})
+	assert.Nil(t, err)
+	// Function-based bitmap indexes are ignored.
+	assert.Equal(t, 1, len(indexes))
+	assert.Equal(t, []schema.Key{
+		{Expr: `ROUND("SALARY")`, ExprType: &schema.Type{Name: "NUMBER"}},
+		{ColId: colIds[0]},
+	}, indexes[0].Keys)
+}
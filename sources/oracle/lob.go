@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+)
+
+// CLOB, NCLOB and BLOB values are read in chunks with DBMS_LOB.SUBSTR,
+// instead of all at once, so that LOBs of several GBs are never held in
+// memory. The first chunk of each value is selected along with the rest of
+// its row, with the length of the value and the ROWID of the row; the
+// following chunks are selected by ROWID, up to the maximum size of LOB
+// values.
+
+// Sizes of the chunks LOB values are read in. DBMS_LOB.SUBSTR returns a
+// VARCHAR2 of up to 4000 bytes for CLOBs, whose characters take up to 4
+// bytes, and a RAW of up to 2000 bytes for BLOBs.
+const (
+	clobChunkSize = 1000
+	blobChunkSize = 2000
+)
+
+// isLob returns whether a column of type srcTypeName holds LOB values read
+// in chunks.
+func isLob(srcTypeName string) bool {
+	switch srcTypeName {
+	case "CLOB", "NCLOB", "BLOB":
+		return true
+	}
+	return false
+}
+
+// lobChunkSize returns the size of the chunks values of a LOB column are
+// read in, in characters for CLOBs and bytes for BLOBs.
+func lobChunkSize(srcTypeName string) int64 {
+	if srcTypeName == "BLOB" {
+		return blobChunkSize
+	}
+	return clobChunkSize
+}
+
+// lobColumn is a LOB column of a table.
+type lobColumn struct {
+	name string
+	ty   string
+	// index of the column in the select list.
+	index int
+}
+
+// lobReader reads the LOB values of the rows of a table.
+type lobReader struct {
+	db      *sql.DB
+	schema  string
+	table   string
+	cols    []lobColumn
+	maxSize int64
+	policy  string
+}
+
+// newLobReader returns the reader of the LOB values of a table, or nil if
+// the table has no LOB column.
+func (isi InfoSchemaImpl) newLobReader(tbl schema.Table) *lobReader {
+	var cols []lobColumn
+	for i, colId := range tbl.ColIds {
+		col := tbl.ColDefs[colId]
+		if isLob(col.Type.Name) && len(col.Type.ArrayBounds) == 0 {
+			cols = append(cols, lobColumn{name: col.Name, ty: col.Type.Name, index: i})
+		}
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+	maxSize, policy := isi.SourceProfile.Conn.Oracle.LobMaxSize, isi.SourceProfile.Conn.Oracle.LobPolicy
+	if maxSize <= 0 {
+		maxSize = profiles.DefaultLobMaxSize
+	}
+	if policy == "" {
+		policy = profiles.LobPolicyTruncate
+	}
+	return &lobReader{db: isi.Db, schema: tbl.Schema, table: tbl.Name, cols: cols, maxSize: maxSize, policy: policy}
+}
+
+// getLobSelects returns the expressions selected after the columns of a
+// table with LOB columns: the length of each LOB value, and the ROWID of
+// the row.
+func getLobSelects(colIds []string, colDefs map[string]schema.Column) []string {
+	var selects []string
+	for _, colId := range colIds {
+		col := colDefs[colId]
+		if isLob(col.Type.Name) && len(col.Type.ArrayBounds) == 0 {
+			selects = append(selects, fmt.Sprintf(`DBMS_LOB.GETLENGTH("%s")`, col.Name))
+		}
+	}
+	if len(selects) > 0 {
+		selects = append(selects, "ROWID")
+	}
+	return selects
+}
+
+// readRow reads the rest of the LOB values of a row, whose values are
+// those selected by getSelectQuery, and returns the values of its columns.
+// It returns false when the row is skipped because of a value larger than
+// the maximum size.
+func (lr *lobReader) readRow(conv *internal.Conv, values []string) ([]string, bool, error) {
+	n := len(values) - len(lr.cols) - 1
+	rowId := values[len(values)-1]
+	for i, col := range lr.cols {
+		length := values[n+i]
+		if length == "NULL" {
+			continue
+		}
+		l, err := strconv.ParseInt(length, 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("couldn't get length of column %s: %w", col.name, err)
+		}
+		val, truncated, err := lr.readLob(col, values[col.index], l, rowId)
+		if err != nil {
+			return nil, false, err
+		}
+		if truncated {
+			if lr.policy == profiles.LobPolicySkip {
+				conv.Unexpected(fmt.Sprintf("Skipped rows of table %s with values of column %s larger than %d bytes", lr.table, col.name, lr.maxSize))
+				return nil, false, nil
+			}
+			conv.Unexpected(fmt.Sprintf("Truncated values of column %s of table %s to %d bytes", col.name, lr.table, lr.maxSize))
+		}
+		values[col.index] = val
+	}
+	return values[:n], true, nil
+}
+
+// readLob reads the value of a LOB column of the row rowId, whose first
+// chunk is first and length is length, in characters for CLOBs and bytes
+// for BLOBs. It returns whether the value was truncated to the maximum
+// size.
+func (lr *lobReader) readLob(col lobColumn, first string, length int64, rowId string) (string, bool, error) {
+	chunkSize := lobChunkSize(col.ty)
+	var sb strings.Builder
+	sb.WriteString(first)
+	q := fmt.Sprintf(`SELECT DBMS_LOB.SUBSTR("%s", :1, :2) FROM "%s"."%s" WHERE ROWID = :3`, col.name, lr.schema, lr.table)
+	for offset := chunkSize + 1; offset <= length && int64(sb.Len()) <= lr.maxSize; offset += chunkSize {
+		var chunk []byte
+		if err := lr.db.QueryRow(q, chunkSize, offset, rowId).Scan(&chunk); err != nil {
+			return "", false, fmt.Errorf("couldn't read column %s: %w", col.name, err)
+		}
+		sb.Write(chunk)
+	}
+	val := sb.String()
+	if int64(len(val)) <= lr.maxSize {
+		return val, false, nil
+	}
+	val = val[:lr.maxSize]
+	for col.ty != "BLOB" && len(val) > 0 {
+		// Drop the bytes of a split last character.
+		if r, size := utf8.DecodeLastRuneInString(val); r != utf8.RuneError || size != 1 {
+			break
+		}
+		val = val[:len(val)-1]
+	}
+	return val, true, nil
+}
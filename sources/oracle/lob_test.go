@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func mkLobConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Name:   "DOCS",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "ID", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "BODY", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c3": {Name: "DATA", Id: "c3", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+		},
+	}
+	conv.SrcSchema["t1"] = schema.Table{
+		Name:   "DOCS",
+		Id:     "t1",
+		Schema: "TEST",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "ID", Id: "c1", Type: schema.Type{Name: "NUMBER"}},
+			"c2": {Name: "BODY", Id: "c2", Type: schema.Type{Name: "CLOB"}},
+			"c3": {Name: "DATA", Id: "c3", Type: schema.Type{Name: "BLOB"}},
+		},
+		ColNameIdMap: map[string]string{"ID": "c1", "BODY": "c2", "DATA": "c3"},
+	}
+	conv.SetDataMode()
+	return conv
+}
+
+func TestProcessDataLobs(t *testing.T) {
+	selectQuery := `SELECT TO_CHAR("ID") AS "ID", DBMS_LOB.SUBSTR("BODY", 1000, 1) AS "BODY", DBMS_LOB.SUBSTR("DATA", 2000, 1) AS "DATA", DBMS_LOB.GETLENGTH("BODY"), DBMS_LOB.GETLENGTH("DATA"), ROWID FROM "TEST"."DOCS"`
+	chunkQuery := `SELECT DBMS_LOB.SUBSTR("BODY", :1, :2) FROM "TEST"."DOCS" WHERE ROWID = :3`
+	cols := []string{"ID", "BODY", "DATA", "DBMS_LOB.GETLENGTH(\"BODY\")", "DBMS_LOB.GETLENGTH(\"DATA\")", "ROWID"}
+	a := strings.Repeat("a", 1000)
+
+	testCases := []struct {
+		name     string
+		policy   string
+		expected []string
+		badRows  int64
+	}{
+		{"truncate", profiles.LobPolicyTruncate, []string{"hi", a + a + "aaaaa"}, 0},
+		{"skip", profiles.LobPolicySkip, []string{"hi"}, 1},
+	}
+	for _, tc := range testCases {
+		db, mock, err := sqlmock.New()
+		assert.Nil(t, err)
+		mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+			WillReturnRows(sqlmock.NewRows(cols).
+				AddRow("1", "hi", []byte{1, 2}, 2, 2, "AAAB").
+				AddRow("2", a, nil, 2600, nil, "AAAC"))
+		mock.ExpectQuery(regexp.QuoteMeta(chunkQuery)).WithArgs(1000, 1001, "AAAC").
+			WillReturnRows(sqlmock.NewRows([]string{"chunk"}).AddRow(a))
+		mock.ExpectQuery(regexp.QuoteMeta(chunkQuery)).WithArgs(1000, 2001, "AAAC").
+			WillReturnRows(sqlmock.NewRows([]string{"chunk"}).AddRow(strings.Repeat("a", 600)))
+		conv := mkLobConv()
+		var bodies []string
+		conv.SetDataSink(
+			func(table string, cols []string, vals []interface{}) {
+				bodies = append(bodies, vals[1].(string))
+			})
+		sourceProfile := profiles.SourceProfile{Conn: profiles.SourceProfileConnection{Oracle: profiles.SourceProfileConnectionOracle{LobMaxSize: 2005, LobPolicy: tc.policy}}}
+		isi := InfoSchemaImpl{DbName: "TEST", Db: db, SourceProfile: sourceProfile}
+		err = isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], []string{"c1", "c2", "c3"}, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+		assert.Nil(t, err, tc.name)
+		assert.Nil(t, mock.ExpectationsWereMet(), tc.name)
+		assert.Equal(t, tc.expected, bodies, tc.name)
+		assert.Equal(t, tc.badRows, conv.Stats.BadRows["DOCS"], tc.name)
+	}
+}
+
+func TestReadLobTruncatesCharacters(t *testing.T) {
+	lr := &lobReader{schema: "TEST", table: "DOCS", maxSize: 2}
+	val, truncated, err := lr.readLob(lobColumn{name: "BODY", ty: "CLOB"}, "aéb", 3, "AAAB")
+	assert.Nil(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, "a", val)
+
+	val, truncated, err = lr.readLob(lobColumn{name: "DATA", ty: "BLOB"}, "\x01\x02\x03", 3, "AAAB")
+	assert.Nil(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, "\x01\x02", val)
+
+	val, truncated, err = lr.readLob(lobColumn{name: "BODY", ty: "CLOB"}, "ab", 2, "AAAB")
+	assert.Nil(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, "ab", val)
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// GetPartitionedTables implements the common.PartitionedTableSource
+// interface. Subpartitions are read along with their partition.
+func (isi InfoSchemaImpl) GetPartitionedTables(tables []common.SchemaAndName) (map[common.SchemaAndName]common.TablePartitioning, error) {
+	included := make(map[string]bool)
+	for _, t := range tables {
+		included[t.Name] = true
+	}
+	partitionings := make(map[common.SchemaAndName]common.TablePartitioning)
+
+	q := fmt.Sprintf(`SELECT table_name, partitioning_type FROM all_part_tables WHERE owner = '%s'`, isi.DbName)
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get partitioned tables: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, method string
+		if err := rows.Scan(&tableName, &method); err != nil {
+			return nil, fmt.Errorf("couldn't get partitioned tables: %w", err)
+		}
+		if included[tableName] {
+			partitionings[common.SchemaAndName{Schema: isi.DbName, Name: tableName}] = common.TablePartitioning{Method: method, Mapping: isi.SourceProfile.PartitionMapping()}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't get partitioned tables: %w", err)
+	}
+
+	q = fmt.Sprintf(`
+		SELECT name, column_name
+		FROM all_part_key_columns
+		WHERE owner = '%s' AND object_type = 'TABLE'
+		ORDER BY name, column_position`, isi.DbName)
+	keyRows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get partition keys: %w", err)
+	}
+	defer keyRows.Close()
+	for keyRows.Next() {
+		var tableName, col string
+		if err := keyRows.Scan(&tableName, &col); err != nil {
+			return nil, fmt.Errorf("couldn't get partition keys: %w", err)
+		}
+		t := common.SchemaAndName{Schema: isi.DbName, Name: tableName}
+		if p, ok := partitionings[t]; ok {
+			p.Columns = append(p.Columns, col)
+			p.Expression = strings.Join(p.Columns, ", ")
+			partitionings[t] = p
+		}
+	}
+	if err := keyRows.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't get partition keys: %w", err)
+	}
+
+	q = fmt.Sprintf(`
+		SELECT table_name, partition_name, high_value
+		FROM all_tab_partitions
+		WHERE table_owner = '%s'
+		ORDER BY table_name, partition_position`, isi.DbName)
+	partitionRows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get partitions: %w", err)
+	}
+	defer partitionRows.Close()
+	for partitionRows.Next() {
+		var tableName, name string
+		var highValue sql.NullString
+		if err := partitionRows.Scan(&tableName, &name, &highValue); err != nil {
+			return nil, fmt.Errorf("couldn't get partitions: %w", err)
+		}
+		t := common.SchemaAndName{Schema: isi.DbName, Name: tableName}
+		if p, ok := partitionings[t]; ok {
+			p.Partitions = append(p.Partitions, schema.Partition{Name: name, Bound: partitionBound(p.Method, highValue.String)})
+			partitionings[t] = p
+		}
+	}
+	if err := partitionRows.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't get partitions: %w", err)
+	}
+	return partitionings, nil
+}
+
+// partitionBound returns the bound of a partition, from the partitioning
+// method of its table and all_tab_partitions.high_value.
+func partitionBound(method, highValue string) string {
+	switch {
+	case highValue == "":
+		return ""
+	case method == "RANGE":
+		return fmt.Sprintf("VALUES LESS THAN (%s)", highValue)
+	case method == "LIST":
+		return fmt.Sprintf("VALUES (%s)", highValue)
+	}
+	return ""
+}
+
+// processPartitionsData performs data conversion for a partitioned table,
+// whose partitions are read in parallel.
+func (isi InfoSchemaImpl) processPartitionsData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable) error {
+	srcTable := conv.SrcSchema[tableId]
+	colNameIdMap := internal.GetSrcColNameIdMap(srcTable)
+	lr := isi.newLobReader(srcTable)
+	type partitionRow struct {
+		cols   []string
+		values []string
+		err    error
+	}
+	read := func(p schema.Partition, emit func(partitionRow)) error {
+		q := getSelectQuery(isi.DbName, srcTable.Schema, srcTable.Name, srcTable.ColIds, srcTable.ColDefs) + fmt.Sprintf(` PARTITION ("%s")`, p.Name)
+		rows, err := isi.Db.Query(q)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		srcCols, _ := rows.Columns()
+		v, scanArgs := buildVals(len(srcCols))
+		for rows.Next() {
+			if err := rows.Scan(scanArgs...); err != nil {
+				emit(partitionRow{err: err})
+				continue
+			}
+			emit(partitionRow{cols: srcCols, values: valsToStrings(v)})
+		}
+		return rows.Err()
+	}
+	process := func(row partitionRow) {
+		if row.err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", row.err))
+			// Scan failed, so we don't have any data to add to bad rows.
+			conv.StatsAddBadRow(srcTable.Name, conv.DataMode())
+			return
+		}
+		values, ok := readLobValues(conv, lr, srcTable.Name, row.values)
+		if !ok {
+			return
+		}
+		processRowValues(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, row.cols[:len(values)], values)
+	}
+	if err := common.ReadPartitions(srcTable.Partitioning.Partitions, common.PartitionReaders, read, process); err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTable.Name, err))
+		return err
+	}
+	return nil
+}
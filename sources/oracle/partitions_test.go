@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestGetPartitionedTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT table_name, partitioning_type FROM all_part_tables WHERE owner = 'TEST'`)).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "partitioning_type"}).
+			AddRow("ORDERS", "RANGE").
+			AddRow("USERS", "LIST").
+			AddRow("AUDIT", "HASH"))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM all_part_key_columns`)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "column_name"}).
+			AddRow("AUDIT", "ID").
+			AddRow("ORDERS", "REGION").
+			AddRow("ORDERS", "CREATED_AT").
+			AddRow("USERS", "COUNTRY"))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM all_tab_partitions`)).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "partition_name", "high_value"}).
+			AddRow("AUDIT", "SYS_P1", nil).
+			AddRow("ORDERS", "P2024", "'EU', TO_DATE(' 2025-01-01 00:00:00', 'SYYYY-MM-DD HH24:MI:SS')").
+			AddRow("ORDERS", "PMAX", "MAXVALUE, MAXVALUE").
+			AddRow("USERS", "P_EU", "'FR', 'DE'"))
+	sourceProfile := profiles.SourceProfile{Conn: profiles.SourceProfileConnection{PartitionMapping: schema.PartitionMappingParallelExport}}
+	isi := InfoSchemaImpl{DbName: "TEST", Db: db, SourceProfile: sourceProfile}
+	partitionings, err := isi.GetPartitionedTables([]common.SchemaAndName{{Schema: "TEST", Name: "ORDERS"}, {Schema: "TEST", Name: "USERS"}})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, map[common.SchemaAndName]common.TablePartitioning{
+		{Schema: "TEST", Name: "ORDERS"}: {
+			Method:     "RANGE",
+			Expression: "REGION, CREATED_AT",
+			Columns:    []string{"REGION", "CREATED_AT"},
+			Partitions: []schema.Partition{
+				{Name: "P2024", Bound: "VALUES LESS THAN ('EU', TO_DATE(' 2025-01-01 00:00:00', 'SYYYY-MM-DD HH24:MI:SS'))"},
+				{Name: "PMAX", Bound: "VALUES LESS THAN (MAXVALUE, MAXVALUE)"},
+			},
+			Mapping: schema.PartitionMappingParallelExport,
+		},
+		{Schema: "TEST", Name: "USERS"}: {
+			Method:     "LIST",
+			Expression: "COUNTRY",
+			Columns:    []string{"COUNTRY"},
+			Partitions: []schema.Partition{{Name: "P_EU", Bound: "VALUES ('FR', 'DE')"}},
+			Mapping:    schema.PartitionMappingParallelExport,
+		},
+	}, partitionings)
+}
+
+func TestProcessDataPartitions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT TO_CHAR("ID") AS "ID", "NAME" FROM "TEST"."USERS" PARTITION ("P_EU")`)).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "NAME"}).AddRow(1, "ana").AddRow(2, "bo"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT TO_CHAR("ID") AS "ID", "NAME" FROM "TEST"."USERS" PARTITION ("P_US")`)).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "NAME"}).AddRow(3, "cy"))
+	conv := internal.MakeConv()
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Name:   "USERS",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "ID", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "NAME", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		},
+	}
+	conv.SrcSchema["t1"] = schema.Table{
+		Name:   "USERS",
+		Id:     "t1",
+		Schema: "TEST",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "ID", Id: "c1", Type: schema.Type{Name: "NUMBER"}},
+			"c2": {Name: "NAME", Id: "c2", Type: schema.Type{Name: "VARCHAR2"}},
+		},
+		ColNameIdMap: map[string]string{"ID": "c1", "NAME": "c2"},
+		Partitioning: &schema.Partitioning{
+			Method:     "LIST",
+			Partitions: []schema.Partition{{Name: "P_EU"}, {Name: "P_US"}},
+			Mapping:    schema.PartitionMappingParallelExport,
+		},
+	}
+	conv.SetDataMode()
+	var names []string
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			names = append(names, vals[1].(string))
+		})
+	isi := InfoSchemaImpl{DbName: "TEST", Db: db}
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
+	assert.Nil(t, mock.ExpectationsWereMet())
+	sort.Strings(names)
+	assert.Equal(t, []string{"ana", "bo", "cy"}, names)
+}
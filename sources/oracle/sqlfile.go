@@ -0,0 +1,859 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// plsqlRegexp matches the start of PL/SQL units, which end with a line
+// holding a slash rather than with a semicolon.
+var plsqlRegexp = regexp.MustCompile(`(?i)^(CREATE\s+(OR\s+REPLACE\s+)?((NON)?EDITIONABLE\s+)?(PROCEDURE|FUNCTION|PACKAGE|TRIGGER|TYPE|LIBRARY|JAVA)\b|BEGIN\b|DECLARE\b)`)
+
+// DbDumpImpl Oracle specific implementation for DdlDumpImpl. Oracle Data
+// Pump export files (.dmp) can only be read by Oracle, so it reads the SQL
+// file that impdp writes the DDL of an export to instead, e.g.
+//
+//	impdp system DIRECTORY=dump_dir DUMPFILE=hr.dmp SQLFILE=hr.sql
+//
+// impdp doesn't need the exported database, nor does it import anything
+// when SQLFILE is set. The SQL file has no data: tables are unloaded to
+// CSV files, and migrated with the csv driver, for that.
+type DbDumpImpl struct {
+}
+
+// GetToDdl function below implement the common.DbDump interface.
+func (ddi DbDumpImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// ProcessDump processes the SQL file of a Data Pump export.
+func (ddi DbDumpImpl) ProcessDump(conv *internal.Conv, r *internal.Reader) error {
+	return processSQLFile(conv, r)
+}
+
+// sqlFile holds the state of processing a SQL file.
+type sqlFile struct {
+	conv *internal.Conv
+	// types are the collection and object types created in the file, by
+	// name.
+	types map[string]schema.Type
+	// keyIndexes are the names of the indexes of primary keys, which
+	// aren't secondary indexes in Spanner.
+	keyIndexes map[string]bool
+}
+
+// processSQLFile reads a SQL file written by impdp from r and builds the
+// schema it creates in conv. Statements other than those creating tables,
+// their constraints and indexes, and the types of their columns, are
+// skipped.
+func processSQLFile(conv *internal.Conv, r *internal.Reader) error {
+	if bytes.IndexByte(r.Peek(512), 0) >= 0 {
+		return fmt.Errorf("the dump is a binary file, such as a Data Pump export file, which only Oracle can read: write the DDL of Data Pump exports to a SQL file with impdp SQLFILE=<file>, and migrate that file instead")
+	}
+	f := sqlFile{conv: conv, types: make(map[string]schema.Type), keyIndexes: make(map[string]bool)}
+	for !r.EOF {
+		if toks := tokenize(readStatement(r)); len(toks) > 0 {
+			f.processStatement(toks)
+		}
+	}
+	// Foreign keys without columns reference the primary key.
+	for _, t := range conv.SrcSchema {
+		for i, fk := range t.ForeignKeys {
+			if refTable, ok := internal.GetSrcTableByName(conv.SrcSchema, fk.ReferTableName); ok && len(fk.ReferColumnNames) == 0 {
+				for _, k := range refTable.PrimaryKeys {
+					t.ForeignKeys[i].ReferColumnNames = append(t.ForeignKeys[i].ReferColumnNames, refTable.ColDefs[k.ColId].Name)
+				}
+			}
+		}
+	}
+	internal.ResolveForeignKeyIds(conv.SrcSchema)
+	return nil
+}
+
+// readStatement reads the next statement from r, without its terminator.
+// Statements end with a semicolon, except for PL/SQL units, which end with
+// a line holding a slash. It returns an empty string at eof.
+func readStatement(r *internal.Reader) string {
+	var b strings.Builder
+	var quote byte
+	plsql := false
+	for !r.EOF {
+		line := string(r.ReadLine())
+		if b.Len() == 0 {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || trimmed == "/" || strings.HasPrefix(trimmed, "--") {
+				continue
+			}
+			plsql = plsqlRegexp.MatchString(trimmed)
+		}
+		if plsql {
+			if strings.TrimSpace(line) == "/" {
+				return b.String()
+			}
+			b.WriteString(line)
+			continue
+		}
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '\'' || c == '"':
+				quote = c
+			case c == ';':
+				return b.String()
+			case c == '-' && strings.HasPrefix(line[i:], "--"):
+				// Comments run to the end of the line.
+				c = '\n'
+				i = len(line)
+			}
+			b.WriteByte(c)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // Keywords and unquoted identifiers, in upper case.
+	tokenIdent                   // Quoted identifiers, without their quotes.
+	tokenString                  // String literals, without their quotes.
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits a statement into tokens.
+func tokenize(s string) []token {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			for i++; i < len(s); i++ {
+				if s[i] == c {
+					// Quotes are escaped by doubling them.
+					if i+1 < len(s) && s[i+1] == c {
+						i++
+					} else {
+						break
+					}
+				}
+				b.WriteByte(s[i])
+			}
+			i++
+			kind := tokenIdent
+			if c == '\'' {
+				kind = tokenString
+			}
+			toks = append(toks, token{kind: kind, text: b.String()})
+		case isWordByte(c) && !isDigit(c):
+			j := i
+			for j < len(s) && isWordByte(s[j]) {
+				j++
+			}
+			toks = append(toks, token{kind: tokenWord, text: strings.ToUpper(s[i:j])})
+			i = j
+		case isDigit(c):
+			j := i
+			for j < len(s) && (isDigit(s[j]) || s[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{kind: tokenNumber, text: s[i:j]})
+			i = j
+		default:
+			toks = append(toks, token{kind: tokenSymbol, text: string(c)})
+			i++
+		}
+	}
+	return toks
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isDigit(c) || c == '_' || c == '$' || c == '#'
+}
+
+// parser reads the tokens of a statement, or of part of it.
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) done() bool {
+	return p.i >= len(p.toks)
+}
+
+// peek returns the n-th next token, or an empty token past the end.
+func (p *parser) peek(n int) token {
+	if p.i+n >= len(p.toks) {
+		return token{kind: tokenSymbol}
+	}
+	return p.toks[p.i+n]
+}
+
+// accept consumes the keywords or symbols ws if they come next.
+func (p *parser) accept(ws ...string) bool {
+	for i, w := range ws {
+		if t := p.peek(i); t.text != w || (t.kind != tokenWord && t.kind != tokenSymbol) {
+			return false
+		}
+	}
+	p.i += len(ws)
+	return true
+}
+
+// skip consumes the next token or, if it opens parentheses, all tokens up
+// to the matching closing parenthesis.
+func (p *parser) skip() {
+	depth := 0
+	for !p.done() {
+		t := p.toks[p.i]
+		p.i++
+		if t.kind == tokenSymbol && t.text == "(" {
+			depth++
+		} else if t.kind == tokenSymbol && t.text == ")" {
+			depth--
+		}
+		if depth <= 0 {
+			return
+		}
+	}
+}
+
+// name consumes an identifier, which may be qualified by the schema (the
+// owner) of the object it names.
+func (p *parser) name() (owner, name string, ok bool) {
+	if t := p.peek(0); t.kind != tokenIdent && t.kind != tokenWord {
+		return "", "", false
+	}
+	name = p.toks[p.i].text
+	p.i++
+	if p.peek(0).text == "." && p.peek(0).kind == tokenSymbol {
+		if t := p.peek(1); t.kind == tokenIdent || t.kind == tokenWord {
+			owner, name = name, t.text
+			p.i += 2
+		}
+	}
+	return owner, name, true
+}
+
+// group consumes a parenthesized list, returning its items. It returns
+// false if no list comes next.
+func (p *parser) group() ([][]token, bool) {
+	if !p.accept("(") {
+		return nil, false
+	}
+	var items [][]token
+	var item []token
+	depth := 0
+	for ; !p.done(); p.i++ {
+		t := p.toks[p.i]
+		if t.kind == tokenSymbol {
+			switch {
+			case t.text == "(":
+				depth++
+			case t.text == ")" && depth == 0:
+				p.i++
+				return append(items, item), true
+			case t.text == ")":
+				depth--
+			case t.text == "," && depth == 0:
+				items = append(items, item)
+				item = nil
+				continue
+			}
+		}
+		item = append(item, t)
+	}
+	return append(items, item), true
+}
+
+// names consumes a parenthesized list of column names.
+func (p *parser) names() ([]string, bool) {
+	items, ok := p.group()
+	if !ok {
+		return nil, false
+	}
+	var names []string
+	for _, item := range items {
+		if len(item) != 1 || (item[0].kind != tokenIdent && item[0].kind != tokenWord) {
+			return nil, false
+		}
+		names = append(names, item[0].text)
+	}
+	return names, true
+}
+
+// statementType returns the type of a statement for statistics, e.g.
+// "CREATE SEQUENCE" or "GRANT".
+func statementType(toks []token) string {
+	var words []string
+	for _, t := range toks {
+		if t.kind != tokenWord || len(words) == 2 {
+			break
+		}
+		if len(words) == 1 && words[0] != "CREATE" && words[0] != "ALTER" && words[0] != "DROP" {
+			break
+		}
+		switch t.text {
+		case "OR", "REPLACE", "EDITIONABLE", "NONEDITIONABLE", "UNIQUE", "BITMAP", "GLOBAL", "PRIVATE", "TEMPORARY", "PUBLIC", "FORCE":
+			continue
+		}
+		words = append(words, t.text)
+	}
+	return strings.Join(words, " ")
+}
+
+func (f *sqlFile) processStatement(toks []token) {
+	p := &parser{toks: toks}
+	stmtType := statementType(toks)
+	if !f.conv.SchemaMode() {
+		f.conv.SkipStatement(stmtType)
+		return
+	}
+	var err error
+	switch {
+	case p.accept("CREATE"):
+		p.accept("OR", "REPLACE")
+		if !p.accept("EDITIONABLE") {
+			p.accept("NONEDITIONABLE")
+		}
+		switch {
+		case p.accept("TABLE"), p.accept("GLOBAL", "TEMPORARY", "TABLE"):
+			err = f.processCreateTable(p)
+		case p.accept("UNIQUE", "INDEX"):
+			err = f.processCreateIndex(p, true)
+		case p.accept("INDEX"), p.accept("BITMAP", "INDEX"):
+			err = f.processCreateIndex(p, false)
+		case p.accept("TYPE"):
+			f.processCreateType(p)
+		default:
+			f.conv.SkipStatement(stmtType)
+			return
+		}
+	case p.accept("ALTER", "TABLE"):
+		err = f.processAlterTable(p)
+	default:
+		f.conv.SkipStatement(stmtType)
+		return
+	}
+	if err != nil {
+		f.conv.Unexpected(fmt.Sprintf("Processing %s statement: %s", stmtType, err))
+		f.conv.ErrorInStatement(stmtType)
+		return
+	}
+	f.conv.SchemaStatement(stmtType)
+}
+
+func (f *sqlFile) processCreateTable(p *parser) error {
+	owner, name, ok := p.name()
+	if !ok {
+		return fmt.Errorf("can't get table name")
+	}
+	if _, found := internal.GetSrcTableByName(f.conv.SrcSchema, name); found {
+		return fmt.Errorf("table %s is created twice, e.g. in different schemas", name)
+	}
+	items, ok := p.group()
+	if !ok {
+		// e.g. object tables and CREATE TABLE ... AS SELECT.
+		return fmt.Errorf("can't get columns of table %s", name)
+	}
+	t := schema.Table{
+		Id:           internal.GenerateTableId(),
+		Name:         name,
+		Schema:       owner,
+		ColDefs:      make(map[string]schema.Column),
+		ColNameIdMap: make(map[string]string),
+	}
+	var constraints []*parser
+	for _, item := range items {
+		ip := &parser{toks: item}
+		switch ip.peek(0).text {
+		case "CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "SUPPLEMENTAL":
+			if ip.peek(0).kind == tokenWord {
+				constraints = append(constraints, ip)
+				continue
+			}
+		}
+		if err := f.processColumn(&t, ip); err != nil {
+			return err
+		}
+	}
+	for _, ip := range constraints {
+		if err := f.processConstraint(&t, ip); err != nil {
+			return err
+		}
+	}
+	f.conv.SrcSchema[t.Id] = t
+	return nil
+}
+
+// processColumn adds the column defined by p to t, along with its
+// constraints.
+func (f *sqlFile) processColumn(t *schema.Table, p *parser) error {
+	_, name, ok := p.name()
+	if !ok {
+		return fmt.Errorf("can't get column name of table %s", t.Name)
+	}
+	col := schema.Column{Id: internal.GenerateColumnId(), Name: name, Type: f.parseType(p)}
+	t.ColIds = append(t.ColIds, col.Id)
+	t.ColNameIdMap[name] = col.Id
+	var constraintName string
+	for !p.done() {
+		switch {
+		case p.accept("CONSTRAINT"):
+			_, constraintName, _ = p.name()
+			continue
+		case p.accept("NOT", "NULL"):
+			col.NotNull = true
+		case p.accept("PRIMARY", "KEY"):
+			// Oracle makes primary key columns NOT NULL.
+			col.NotNull = true
+			t.PrimaryKeys = []schema.Key{{ColId: col.Id}}
+		case p.accept("UNIQUE"):
+			t.Indexes = append(t.Indexes, schema.Index{Id: internal.GenerateIndexesId(), Name: constraintName, Unique: true, Keys: []schema.Key{{ColId: col.Id}}})
+		case p.accept("CHECK"):
+			col.Ignored.Check = true
+			if isJSONCheck(p) {
+				col.Type = schema.Type{Name: "JSON"}
+			}
+		case p.accept("REFERENCES"):
+			fk, err := references(p, constraintName, []string{name})
+			if err != nil {
+				return err
+			}
+			t.ForeignKeys = append(t.ForeignKeys, fk)
+		case p.accept("DEFAULT"), p.accept("GENERATED"):
+			// Identity and virtual columns have defaults in Oracle's
+			// dictionary, as default values do.
+			col.Ignored.Default = true
+			p.skip()
+			for !p.done() && !isColumnConstraint(p.peek(0)) {
+				p.skip()
+			}
+		default:
+			p.skip()
+		}
+		constraintName = ""
+	}
+	t.ColDefs[col.Id] = col
+	return nil
+}
+
+// isColumnConstraint reports whether t starts a column constraint.
+func isColumnConstraint(t token) bool {
+	if t.kind != tokenWord {
+		return false
+	}
+	switch t.text {
+	case "CONSTRAINT", "NOT", "NULL", "PRIMARY", "UNIQUE", "CHECK", "REFERENCES", "ENABLE", "DISABLE", "VISIBLE", "INVISIBLE":
+		return true
+	}
+	return false
+}
+
+// isJSONCheck consumes the condition of a check constraint, and reports
+// whether it checks that a column holds JSON, which is how JSON is stored
+// before Oracle 21c.
+func isJSONCheck(p *parser) bool {
+	start := p.i
+	p.skip()
+	cond := &parser{toks: p.toks[start:p.i]}
+	for !cond.done() {
+		if cond.accept("IS", "JSON") {
+			return true
+		}
+		cond.i++
+	}
+	return false
+}
+
+// references consumes the table and columns referenced by the foreign key
+// on cols, and its ON DELETE action.
+func references(p *parser, name string, cols []string) (schema.ForeignKey, error) {
+	_, refTable, ok := p.name()
+	if !ok {
+		return schema.ForeignKey{}, fmt.Errorf("can't get table referenced by foreign key %s", name)
+	}
+	// Columns are left out when the primary key is referenced.
+	refCols, _ := p.names()
+	if len(refCols) != 0 && len(refCols) != len(cols) {
+		return schema.ForeignKey{}, fmt.Errorf("foreign key %s has %d columns but references %d", name, len(cols), len(refCols))
+	}
+	fk := schema.ForeignKey{
+		Id:               internal.GenerateForeignkeyId(),
+		Name:             name,
+		ColumnNames:      cols,
+		ReferTableName:   refTable,
+		ReferColumnNames: refCols,
+	}
+	switch {
+	case p.accept("ON", "DELETE", "CASCADE"):
+		fk.OnDelete = constants.FK_CASCADE
+	case p.accept("ON", "DELETE", "SET", "NULL"):
+		fk.OnDelete = constants.FK_SET_NULL
+	}
+	return fk, nil
+}
+
+// processConstraint adds the table constraint defined by p to t.
+func (f *sqlFile) processConstraint(t *schema.Table, p *parser) error {
+	var name string
+	if p.accept("CONSTRAINT") {
+		_, name, _ = p.name()
+	}
+	keys := func() ([]schema.Key, error) {
+		cols, ok := p.names()
+		if !ok {
+			return nil, fmt.Errorf("can't get columns of constraint %s", name)
+		}
+		var keys []schema.Key
+		for _, c := range cols {
+			colId, ok := t.ColNameIdMap[c]
+			if !ok {
+				return nil, fmt.Errorf("constraint %s has unknown column %s", name, c)
+			}
+			keys = append(keys, schema.Key{ColId: colId})
+		}
+		return keys, nil
+	}
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		pk, err := keys()
+		if err != nil {
+			return err
+		}
+		t.PrimaryKeys = pk
+		for _, k := range pk {
+			c := t.ColDefs[k.ColId]
+			c.NotNull = true
+			t.ColDefs[k.ColId] = c
+		}
+		index := usingIndex(p, name)
+		f.keyIndexes[index] = true
+		for i, idx := range t.Indexes {
+			if idx.Name == index {
+				t.Indexes = append(t.Indexes[:i], t.Indexes[i+1:]...)
+				break
+			}
+		}
+	case p.accept("UNIQUE"):
+		uk, err := keys()
+		if err != nil {
+			return err
+		}
+		// Unique keys are usually created along with their index, which
+		// comes first in SQL files.
+		index := usingIndex(p, name)
+		for _, idx := range t.Indexes {
+			if idx.Name == index {
+				return nil
+			}
+		}
+		t.Indexes = append(t.Indexes, schema.Index{Id: internal.GenerateIndexesId(), Name: index, Unique: true, Keys: uk})
+	case p.accept("FOREIGN", "KEY"):
+		cols, ok := p.names()
+		if !ok || !p.accept("REFERENCES") {
+			return fmt.Errorf("can't get columns of foreign key %s", name)
+		}
+		for _, c := range cols {
+			if _, ok := t.ColNameIdMap[c]; !ok {
+				return fmt.Errorf("foreign key %s has unknown column %s", name, c)
+			}
+		}
+		fk, err := references(p, name, cols)
+		if err != nil {
+			return err
+		}
+		t.ForeignKeys = append(t.ForeignKeys, fk)
+	case p.accept("CHECK"):
+		// Check constraints are ignored on the columns they check.
+		start := p.i
+		isJSON := isJSONCheck(p)
+		for _, tok := range p.toks[start:p.i] {
+			if colId, ok := t.ColNameIdMap[tok.text]; ok && (tok.kind == tokenIdent || tok.kind == tokenWord) {
+				col := t.ColDefs[colId]
+				col.Ignored.Check = true
+				if isJSON {
+					col.Type = schema.Type{Name: "JSON"}
+				}
+				t.ColDefs[colId] = col
+			}
+		}
+	}
+	// Other constraints, e.g. supplemental logging, are skipped.
+	return nil
+}
+
+// usingIndex returns the name of the index of a primary or unique key,
+// which is named after the key unless USING INDEX names it.
+func usingIndex(p *parser, name string) string {
+	for !p.done() {
+		if p.accept("USING", "INDEX") {
+			if p.peek(0).kind == tokenIdent {
+				_, index, _ := p.name()
+				return index
+			}
+			continue
+		}
+		p.skip()
+	}
+	return name
+}
+
+// processAlterTable processes the constraints and NOT NULL columns that
+// SQL files add to tables after creating them.
+func (f *sqlFile) processAlterTable(p *parser) error {
+	_, name, ok := p.name()
+	if !ok {
+		return fmt.Errorf("can't get table name")
+	}
+	t, ok := internal.GetSrcTableByName(f.conv.SrcSchema, name)
+	if !ok {
+		return fmt.Errorf("table %s not found", name)
+	}
+	switch {
+	case p.accept("ADD"):
+		if err := f.processConstraint(t, p); err != nil {
+			return err
+		}
+	case p.accept("MODIFY"):
+		items, ok := p.group()
+		if !ok {
+			items = [][]token{p.toks[p.i:]}
+		}
+		for _, item := range items {
+			ip := &parser{toks: item}
+			_, col, _ := ip.name()
+			colId, ok := t.ColNameIdMap[col]
+			if !ok {
+				return fmt.Errorf("table %s has no column %s", name, col)
+			}
+			for !ip.done() {
+				if ip.accept("NOT", "NULL") {
+					c := t.ColDefs[colId]
+					c.NotNull = true
+					t.ColDefs[colId] = c
+					continue
+				}
+				ip.skip()
+			}
+		}
+	}
+	// Other changes, e.g. to storage or logging, are skipped.
+	f.conv.SrcSchema[t.Id] = *t
+	return nil
+}
+
+// processCreateIndex adds the index created by p to its table. Like
+// InfoSchemaImpl.GetIndexes, function-based indexes are skipped, except
+// for descending indexes.
+func (f *sqlFile) processCreateIndex(p *parser, unique bool) error {
+	_, name, ok := p.name()
+	if !ok || !p.accept("ON") {
+		return fmt.Errorf("can't get index name")
+	}
+	if p.accept("CLUSTER") {
+		return nil
+	}
+	_, tableName, ok := p.name()
+	if !ok {
+		return fmt.Errorf("can't get table of index %s", name)
+	}
+	t, ok := internal.GetSrcTableByName(f.conv.SrcSchema, tableName)
+	if !ok {
+		return fmt.Errorf("table %s of index %s not found", tableName, name)
+	}
+	items, ok := p.group()
+	if !ok {
+		return fmt.Errorf("can't get columns of index %s", name)
+	}
+	if f.keyIndexes[name] {
+		return nil
+	}
+	var keys []schema.Key
+	for _, item := range items {
+		ip := &parser{toks: item}
+		_, col, _ := ip.name()
+		colId, ok := t.ColNameIdMap[col]
+		desc := ip.accept("DESC")
+		if !desc {
+			ip.accept("ASC")
+		}
+		if !ok || !ip.done() {
+			return nil
+		}
+		keys = append(keys, schema.Key{ColId: colId, Desc: desc})
+	}
+	for _, idx := range t.Indexes {
+		if idx.Name == name {
+			return nil
+		}
+	}
+	t.Indexes = append(t.Indexes, schema.Index{Id: internal.GenerateIndexesId(), Name: name, Unique: unique, Keys: keys})
+	f.conv.SrcSchema[t.Id] = *t
+	return nil
+}
+
+// processCreateType records the collection and object types that columns
+// can have. Like InfoSchemaImpl.GetColumns, columns of collection types
+// are arrays of their elements.
+func (f *sqlFile) processCreateType(p *parser) {
+	_, name, ok := p.name()
+	if !ok {
+		return
+	}
+	for !p.done() && !p.accept("AS") && !p.accept("IS") && p.peek(0).text != "UNDER" {
+		p.skip()
+	}
+	switch {
+	case p.accept("OBJECT"), p.accept("UNDER"):
+		f.types[name] = schema.Type{Name: "OBJECT"}
+	case p.accept("VARRAY"), p.accept("VARYING", "ARRAY"), p.accept("TABLE"):
+		p.group()
+		if p.accept("OF") {
+			t := f.parseType(p)
+			t.ArrayBounds = []int64{-1}
+			f.types[name] = t
+		}
+	}
+}
+
+// parseType consumes a column type, which it returns as
+// InfoSchemaImpl.GetColumns does: e.g. with a precision in its name for
+// timestamps, and with their length as mods for strings.
+func (f *sqlFile) parseType(p *parser) schema.Type {
+	if t := p.peek(0); t.kind == tokenIdent || p.peek(1).text == "." {
+		_, name, _ := p.name()
+		if t, ok := f.types[name]; ok {
+			return t
+		}
+		return schema.Type{Name: name}
+	}
+	if p.peek(0).kind != tokenWord {
+		return schema.Type{}
+	}
+	name := p.toks[p.i].text
+	p.i++
+	if t, ok := f.types[name]; ok {
+		return t
+	}
+	switch name {
+	case "LONG":
+		if p.accept("RAW") {
+			return schema.Type{Name: "LONG RAW"}
+		}
+	case "TIMESTAMP":
+		name = fmt.Sprintf("TIMESTAMP(%d)", precision(p, 6))
+		switch {
+		case p.accept("WITH", "TIME", "ZONE"):
+			name += " WITH TIME ZONE"
+		case p.accept("WITH", "LOCAL", "TIME", "ZONE"):
+			name += " WITH LOCAL TIME ZONE"
+		}
+	case "INTERVAL":
+		switch {
+		case p.accept("YEAR"):
+			name = fmt.Sprintf("INTERVAL YEAR(%d) TO MONTH", precision(p, 2))
+			p.accept("TO", "MONTH")
+		case p.accept("DAY"):
+			days := precision(p, 2)
+			p.accept("TO", "SECOND")
+			name = fmt.Sprintf("INTERVAL DAY(%d) TO SECOND(%d)", days, precision(p, 6))
+		}
+	case "NUMBER", "NUMERIC", "DECIMAL", "DEC":
+		// Like all_tab_columns, NUMBER(*, 0) has no precision.
+		mods := mods(p)
+		switch {
+		case len(mods) == 2 && mods[0] > 0 && mods[1] != 0:
+			return schema.Type{Name: "NUMBER", Mods: mods}
+		case len(mods) > 0 && mods[0] > 0:
+			return schema.Type{Name: "NUMBER", Mods: mods[:1]}
+		}
+		return schema.Type{Name: "NUMBER"}
+	case "INTEGER", "INT", "SMALLINT":
+		return schema.Type{Name: "NUMBER"}
+	case "DOUBLE", "REAL":
+		p.accept("PRECISION")
+		return schema.Type{Name: "FLOAT"}
+	case "CHAR", "NCHAR", "VARCHAR2", "NVARCHAR2", "VARCHAR", "RAW", "UROWID":
+		mods := mods(p)
+		if len(mods) > 0 {
+			return schema.Type{Name: name, Mods: mods[:1]}
+		}
+		if name == "CHAR" || name == "NCHAR" {
+			return schema.Type{Name: name, Mods: []int64{1}}
+		}
+	default:
+		// e.g. FLOAT(126).
+		mods(p)
+	}
+	return schema.Type{Name: name}
+}
+
+// precision consumes an optional parenthesized precision, returning
+// defaultPrecision if there's none.
+func precision(p *parser, defaultPrecision int64) int64 {
+	if mods := mods(p); len(mods) > 0 {
+		return mods[0]
+	}
+	return defaultPrecision
+}
+
+// mods consumes the optional parenthesized numbers that follow a type
+// name, e.g. (10, 2) or (25 BYTE). Stars, e.g. in NUMBER(*, 0), are
+// returned as 0.
+func mods(p *parser) []int64 {
+	if p.peek(0).text != "(" || p.peek(0).kind != tokenSymbol {
+		return nil
+	}
+	items, _ := p.group()
+	var mods []int64
+	for _, item := range items {
+		var s string
+		for _, t := range item {
+			if t.kind == tokenNumber || t.text == "-" {
+				s += t.text
+			}
+		}
+		n, _ := strconv.ParseInt(s, 10, 64)
+		mods = append(mods, n)
+	}
+	return mods
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/mocks"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testSQLFile is in the style of the SQL files written by impdp.
+const testSQLFile = `-- CONNECT SYSTEM
+ALTER SESSION SET EVENTS '10150 TRACE NAME CONTEXT FOREVER, LEVEL 1';
+-- new object type path: SCHEMA_EXPORT/USER
+ CREATE USER "HR" IDENTIFIED BY VALUES 'S:0123;T:4567'
+      DEFAULT TABLESPACE "USERS"
+      TEMPORARY TABLESPACE "TEMP";
+-- new object type path: SCHEMA_EXPORT/TYPE/TYPE_SPEC
+CREATE EDITIONABLE TYPE "HR"."PHONE_LIST_T"   OID '8C0E4B5E1D2A4F0AE0530100007F6A35' AS VARRAY(5) OF VARCHAR2(25);
+/
+CREATE EDITIONABLE TYPE "HR"."ADDRESS_T"   OID '8C0E4B5E1D2B4F0AE0530100007F6A35' AS OBJECT (
+  street VARCHAR2(40),
+  city VARCHAR2(20));
+/
+-- new object type path: SCHEMA_EXPORT/TABLE/TABLE
+CREATE TABLE "HR"."DEPARTMENTS"
+   (	"DEPARTMENT_ID" NUMBER(4,0),
+	"DEPARTMENT_NAME" VARCHAR2(30 BYTE) CONSTRAINT "DEPT_NAME_NN" NOT NULL ENABLE,
+	"BUDGET" NUMBER(12,2) DEFAULT 0,
+	"SETTINGS" CLOB,
+	"ADDRESS" "HR"."ADDRESS_T",
+	 CONSTRAINT "SETTINGS_JSON" CHECK ("SETTINGS" IS JSON) ENABLE
+   ) SEGMENT CREATION IMMEDIATE
+  PCTFREE 10 PCTUSED 40 INITRANS 1 MAXTRANS 255
+ NOCOMPRESS LOGGING
+  STORAGE(INITIAL 65536 NEXT 1048576 MINEXTENTS 1 MAXEXTENTS 2147483645
+  PCTINCREASE 0 FREELISTS 1 FREELIST GROUPS 1
+  BUFFER_POOL DEFAULT FLASH_CACHE DEFAULT CELL_FLASH_CACHE DEFAULT)
+  TABLESPACE "USERS" ;
+CREATE TABLE "HR"."EMPLOYEES"
+   (	"EMPLOYEE_ID" NUMBER GENERATED BY DEFAULT ON NULL AS IDENTITY MINVALUE 1 MAXVALUE 9999999999999999999999999999 INCREMENT BY 1 START WITH 1 CACHE 20 NOORDER  NOCYCLE  NOKEEP  NOSCALE  NOT NULL ENABLE,
+	"NAME" NVARCHAR2(50) NOT NULL ENABLE,
+	"GRADE" CHAR(1 CHAR),
+	"HIRED" DATE,
+	"UPDATED" TIMESTAMP (6) WITH TIME ZONE,
+	"TENURE" INTERVAL YEAR (3) TO MONTH,
+	"PHOTO" BLOB,
+	"PHONES" "HR"."PHONE_LIST_T",
+	"RATE" BINARY_DOUBLE,
+	"BADGE" RAW(16),
+	"DEPARTMENT_ID" NUMBER(4,0),
+	"MANAGER_ID" NUMBER(*,0),
+	"NOTE" VARCHAR2(100) DEFAULT 'a;b' NOT NULL ENABLE
+   ) TABLESPACE "USERS" ;
+-- new object type path: SCHEMA_EXPORT/TABLE/INDEX/INDEX
+CREATE UNIQUE INDEX "HR"."DEPT_ID_PK" ON "HR"."DEPARTMENTS" ("DEPARTMENT_ID")
+  PCTFREE 10 INITRANS 2 MAXTRANS 255
+  TABLESPACE "USERS" PARALLEL 1 ;
+
+  ALTER INDEX "HR"."DEPT_ID_PK" NOPARALLEL;
+CREATE INDEX "HR"."EMP_NAME_IX" ON "HR"."EMPLOYEES" ("NAME", "HIRED" DESC)
+  TABLESPACE "USERS" PARALLEL 1 ;
+CREATE INDEX "HR"."EMP_UPPER_IX" ON "HR"."EMPLOYEES" (UPPER("NAME"))
+  TABLESPACE "USERS" PARALLEL 1 ;
+CREATE UNIQUE INDEX "HR"."EMP_BADGE_UK" ON "HR"."EMPLOYEES" ("BADGE")
+  TABLESPACE "USERS" PARALLEL 1 ;
+-- new object type path: SCHEMA_EXPORT/TABLE/CONSTRAINT/CONSTRAINT
+ALTER TABLE "HR"."DEPARTMENTS" ADD CONSTRAINT "DEPT_ID_PK" PRIMARY KEY ("DEPARTMENT_ID")
+  USING INDEX "HR"."DEPT_ID_PK"  ENABLE;
+ALTER TABLE "HR"."EMPLOYEES" ADD CONSTRAINT "EMP_ID_PK" PRIMARY KEY ("EMPLOYEE_ID")
+  USING INDEX PCTFREE 10 INITRANS 2 MAXTRANS 255
+  TABLESPACE "USERS"  ENABLE;
+ALTER TABLE "HR"."EMPLOYEES" ADD CONSTRAINT "EMP_BADGE_UK" UNIQUE ("BADGE")
+  USING INDEX "HR"."EMP_BADGE_UK"  ENABLE;
+ALTER TABLE "HR"."EMPLOYEES" MODIFY ("DEPARTMENT_ID" NOT NULL ENABLE);
+-- new object type path: SCHEMA_EXPORT/TABLE/CONSTRAINT/REF_CONSTRAINT
+ALTER TABLE "HR"."EMPLOYEES" ADD CONSTRAINT "EMP_DEPT_FK" FOREIGN KEY ("DEPARTMENT_ID")
+	  REFERENCES "HR"."DEPARTMENTS" ("DEPARTMENT_ID") ON DELETE CASCADE ENABLE;
+ALTER TABLE "HR"."EMPLOYEES" ADD CONSTRAINT "EMP_MANAGER_FK" FOREIGN KEY ("MANAGER_ID")
+	  REFERENCES "HR"."EMPLOYEES" ENABLE;
+-- new object type path: SCHEMA_EXPORT/PROCEDURE/PROCEDURE
+CREATE EDITIONABLE PROCEDURE "HR"."ADD_JOB_HISTORY"
+  (  p_emp_id          job_history.employee_id%type
+   , p_start_date      job_history.start_date%type
+  )
+IS
+BEGIN
+  INSERT INTO job_history (employee_id, start_date)
+    VALUES(p_emp_id, p_start_date);
+END add_job_history;
+/
+GRANT SELECT ON "HR"."EMPLOYEES" TO "SCOTT";
+`
+
+func TestProcessSQLFile(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SetSchemaMode()
+	mockAccessor := new(mocks.MockExpressionVerificationAccessor)
+	mockAccessor.On("VerifyExpressions", context.Background(), mock.Anything).Return(internal.VerifyExpressionsOutput{})
+	err := common.ProcessDbDump(conv, internal.NewReader(bufio.NewReader(strings.NewReader(testSQLFile)), nil), DbDumpImpl{}, &expressions_api.MockDDLVerifier{}, mockAccessor)
+	assert.Nil(t, err)
+	assert.Zero(t, conv.StatementErrors())
+	assert.Zero(t, conv.Unexpecteds(), conv.Stats.Unexpected)
+	assert.Equal(t, int64(2), conv.Stats.Statement["CREATE TABLE"].Schema)
+	assert.Equal(t, int64(6), conv.Stats.Statement["ALTER TABLE"].Schema)
+	assert.Equal(t, int64(1), conv.Stats.Statement["CREATE PROCEDURE"].Skip)
+	assert.Equal(t, int64(1), conv.Stats.Statement["GRANT"].Skip)
+
+	depts, ok := internal.GetSrcTableByName(conv.SrcSchema, "DEPARTMENTS")
+	assert.True(t, ok)
+	emps, ok := internal.GetSrcTableByName(conv.SrcSchema, "EMPLOYEES")
+	assert.True(t, ok)
+	assert.Equal(t, "HR", emps.Schema)
+	col := func(tbl *schema.Table, name string) schema.Column {
+		return tbl.ColDefs[tbl.ColNameIdMap[name]]
+	}
+	for _, tc := range []struct {
+		col      schema.Column
+		expected schema.Type
+	}{
+		{col(depts, "DEPARTMENT_ID"), schema.Type{Name: "NUMBER", Mods: []int64{4}}},
+		{col(depts, "DEPARTMENT_NAME"), schema.Type{Name: "VARCHAR2", Mods: []int64{30}}},
+		{col(depts, "BUDGET"), schema.Type{Name: "NUMBER", Mods: []int64{12, 2}}},
+		{col(depts, "SETTINGS"), schema.Type{Name: "JSON"}},
+		{col(depts, "ADDRESS"), schema.Type{Name: "OBJECT"}},
+		{col(emps, "EMPLOYEE_ID"), schema.Type{Name: "NUMBER"}},
+		{col(emps, "GRADE"), schema.Type{Name: "CHAR", Mods: []int64{1}}},
+		{col(emps, "UPDATED"), schema.Type{Name: "TIMESTAMP(6) WITH TIME ZONE"}},
+		{col(emps, "TENURE"), schema.Type{Name: "INTERVAL YEAR(3) TO MONTH"}},
+		{col(emps, "PHONES"), schema.Type{Name: "VARCHAR2", Mods: []int64{25}, ArrayBounds: []int64{-1}}},
+		{col(emps, "BADGE"), schema.Type{Name: "RAW", Mods: []int64{16}}},
+		{col(emps, "MANAGER_ID"), schema.Type{Name: "NUMBER"}},
+	} {
+		assert.Equal(t, tc.expected, tc.col.Type, tc.col.Name)
+	}
+	assert.True(t, col(depts, "DEPARTMENT_NAME").NotNull)
+	assert.True(t, col(depts, "BUDGET").Ignored.Default)
+	assert.True(t, col(depts, "SETTINGS").Ignored.Check)
+	assert.True(t, col(emps, "EMPLOYEE_ID").Ignored.Default)
+	assert.True(t, col(emps, "EMPLOYEE_ID").NotNull)
+	assert.True(t, col(emps, "DEPARTMENT_ID").NotNull)
+	assert.True(t, col(emps, "NOTE").NotNull)
+	assert.False(t, col(emps, "GRADE").NotNull)
+
+	expected := "CREATE TABLE DEPARTMENTS (\n" +
+		"	DEPARTMENT_ID INT64 NOT NULL ,\n" +
+		"	DEPARTMENT_NAME STRING(30) NOT NULL ,\n" +
+		"	BUDGET NUMERIC,\n" +
+		"	SETTINGS JSON,\n" +
+		"	ADDRESS JSON,\n" +
+		") PRIMARY KEY (DEPARTMENT_ID) " +
+		"CREATE TABLE EMPLOYEES (\n" +
+		"	EMPLOYEE_ID NUMERIC NOT NULL ,\n" +
+		"	NAME STRING(50) NOT NULL ,\n" +
+		"	GRADE STRING(1),\n" +
+		"	HIRED DATE,\n" +
+		"	UPDATED TIMESTAMP,\n" +
+		"	TENURE STRING(MAX),\n" +
+		"	PHOTO BYTES(MAX),\n" +
+		"	PHONES ARRAY<STRING(25)>,\n" +
+		"	RATE FLOAT64,\n" +
+		"	BADGE BYTES(MAX),\n" +
+		"	DEPARTMENT_ID INT64 NOT NULL ,\n" +
+		"	MANAGER_ID NUMERIC,\n" +
+		"	NOTE STRING(100) NOT NULL ,\n" +
+		") PRIMARY KEY (EMPLOYEE_ID) " +
+		"CREATE UNIQUE INDEX EMP_BADGE_UK ON EMPLOYEES (BADGE) " +
+		"CREATE INDEX EMP_NAME_IX ON EMPLOYEES (NAME, HIRED DESC) " +
+		"ALTER TABLE EMPLOYEES ADD CONSTRAINT EMP_DEPT_FK FOREIGN KEY (DEPARTMENT_ID) REFERENCES DEPARTMENTS (DEPARTMENT_ID) ON DELETE CASCADE " +
+		"ALTER TABLE EMPLOYEES ADD CONSTRAINT EMP_MANAGER_FK FOREIGN KEY (MANAGER_ID) REFERENCES EMPLOYEES (EMPLOYEE_ID)"
+	c := ddl.Config{Tables: true, ForeignKeys: true}
+	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects()), " "))
+}
+
+func TestProcessSQLFileErrors(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SetSchemaMode()
+	// Data Pump export files are binary.
+	dmp := "\x03\x02\x00\x00\x00EXPORT:V19.00.00\n"
+	err := processSQLFile(conv, internal.NewReader(bufio.NewReader(strings.NewReader(dmp)), nil))
+	assert.NotNil(t, err)
+
+	// Statements that can't be processed are reported.
+	err = processSQLFile(conv, internal.NewReader(bufio.NewReader(strings.NewReader(
+		"CREATE TABLE \"T\" (\"A\" NUMBER);\n"+
+			"CREATE TABLE \"U\" AS SELECT * FROM \"T\";\n"+
+			"ALTER TABLE \"T\" ADD CONSTRAINT \"T_PK\" PRIMARY KEY (\"B\");\n"+
+			"CREATE INDEX \"X\" ON \"V\" (\"A\");\n"+
+			"CREATE TABLE \"T\" (\"A\" NUMBER);")), nil))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), conv.StatementErrors())
+	assert.Equal(t, int64(4), conv.Unexpecteds())
+	assert.Equal(t, 1, len(conv.SrcSchema))
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []token{
+		{tokenWord, "CREATE"}, {tokenWord, "TABLE"}, {tokenIdent, "hr"}, {tokenSymbol, "."}, {tokenIdent, `a "b"`},
+		{tokenSymbol, "("}, {tokenWord, "C"}, {tokenWord, "NUMBER"}, {tokenSymbol, "("}, {tokenSymbol, "*"}, {tokenSymbol, ","},
+		{tokenNumber, "0"}, {tokenSymbol, ")"}, {tokenWord, "DEFAULT"}, {tokenString, "it's"}, {tokenSymbol, ")"},
+	}, tokenize(`create table "hr"."a ""b""" (c number(*,0) default 'it''s')`))
+}
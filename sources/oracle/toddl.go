@@ -20,6 +20,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
 
@@ -166,6 +167,8 @@ func toSpannerTypeInternal(conv *internal.Conv, spType string, srcType schema.Ty
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
 	case "JSON", "OBJECT":
 		return ddl.Type{Name: ddl.JSON}, nil
+	case "SDO_GEOMETRY":
+		return common.ToSpannerSpatialType(spType)
 	default:
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.NoGoodType}
 	}
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// GetUniqueConstraints implements the common.UniqueConstraintSource
+// interface, reading the index implementing each UNIQUE constraint from
+// all_constraints.
+func (isi InfoSchemaImpl) GetUniqueConstraints(tables []common.SchemaAndName) (map[common.SchemaAndName][]string, error) {
+	q := fmt.Sprintf(`SELECT table_name, index_name FROM all_constraints WHERE owner = '%s' AND constraint_type = 'U' AND index_name IS NOT NULL`, isi.DbName)
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get unique constraints: %w", err)
+	}
+	defer rows.Close()
+	included := make(map[string]bool)
+	for _, t := range tables {
+		included[t.Name] = true
+	}
+	constraints := make(map[common.SchemaAndName][]string)
+	for rows.Next() {
+		var tableName, indexName string
+		if err := rows.Scan(&tableName, &indexName); err != nil {
+			return nil, fmt.Errorf("couldn't get unique constraints: %w", err)
+		}
+		if included[tableName] {
+			t := common.SchemaAndName{Schema: isi.DbName, Name: tableName}
+			constraints[t] = append(constraints[t], indexName)
+		}
+	}
+	return constraints, rows.Err()
+}
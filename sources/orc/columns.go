@@ -0,0 +1,473 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// columnReader reads the values of a column of a stripe, see
+// https://orc.apache.org/specification/ORCv1/#column-encodings. Values are
+// of the Go types below, by ORC type:
+//
+//	boolean: bool
+//	tinyint, smallint, int, bigint: int64
+//	float: float32
+//	double: float64
+//	string, varchar, char: string
+//	binary: []byte
+//	decimal: *big.Rat
+//	date: civil.Date
+//	timestamp: civil.DateTime
+//	timestamp with local time zone: time.Time
+//	array: []interface{}
+//	map, struct: map[string]interface{}
+//
+// Values of unions are the values of their types. Null values are nil.
+type columnReader interface {
+	next() (interface{}, error)
+}
+
+// nulls reads the PRESENT stream of a column, which the values of columns
+// with null values have. Columns only have values for the non-null values
+// of their parent columns, and their streams only for their non-null
+// values.
+type nulls struct {
+	present *boolRLE // nil if the column has no null values.
+}
+
+func (n nulls) isNull() (bool, error) {
+	if n.present == nil {
+		return false, nil
+	}
+	present, err := n.present.next()
+	return !present, err
+}
+
+// epoch is the time timestamps are stored relative to.
+var epoch = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newColumnReader returns a reader of the column id of a stripe.
+func newColumnReader(s *stripe, id int) (columnReader, error) {
+	t := s.file.types[id]
+	n := nulls{}
+	if _, ok := s.streams[streamKey{column: id, kind: streamPresent}]; ok {
+		n.present = newBoolRLE(s.stream(id, streamPresent))
+	}
+	ce := s.encoding(id)
+	encoding := ce.kind
+	switch t.kind {
+	case kindBoolean:
+		return &boolColumn{nulls: n, data: newBoolRLE(s.stream(id, streamData))}, nil
+	case kindByte:
+		return &byteColumn{nulls: n, data: &byteRLE{r: s.stream(id, streamData)}}, nil
+	case kindShort, kindInt, kindLong:
+		return &intColumn{nulls: n, data: newIntDecoder(s.stream(id, streamData), encoding, true)}, nil
+	case kindFloat:
+		return &floatColumn{nulls: n, data: s.stream(id, streamData), size: 4}, nil
+	case kindDouble:
+		return &floatColumn{nulls: n, data: s.stream(id, streamData), size: 8}, nil
+	case kindString, kindVarchar, kindChar, kindBinary:
+		isBinary := t.kind == kindBinary
+		if encoding == encodingDictionary || encoding == encodingDictionaryV2 {
+			dict, err := readDictionary(s, id, ce)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't read dictionary of column %d: %w", id, err)
+			}
+			return &dictionaryColumn{nulls: n, data: newIntDecoder(s.stream(id, streamData), encoding, false), dict: dict, binary: isBinary}, nil
+		}
+		return &stringColumn{nulls: n, data: s.stream(id, streamData), length: newIntDecoder(s.stream(id, streamLength), encoding, false), binary: isBinary}, nil
+	case kindDecimal:
+		return &decimalColumn{nulls: n, data: s.stream(id, streamData), scale: newIntDecoder(s.stream(id, streamSecondary), encoding, true)}, nil
+	case kindDate:
+		return &dateColumn{nulls: n, data: newIntDecoder(s.stream(id, streamData), encoding, true)}, nil
+	case kindTimestamp, kindTimestampInstant:
+		c := &timestampColumn{
+			nulls: n,
+			data:  newIntDecoder(s.stream(id, streamData), encoding, true),
+			nanos: newIntDecoder(s.stream(id, streamSecondary), encoding, false),
+			loc:   time.UTC,
+		}
+		if t.kind == kindTimestampInstant {
+			c.instant = true
+		} else if s.timezone != "" {
+			// Timestamps are stored relative to the epoch in the time zone of
+			// the writer.
+			loc, err := time.LoadLocation(s.timezone)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't load time zone of column %d: %w", id, err)
+			}
+			c.loc = loc
+		}
+		return c, nil
+	case kindList, kindMap, kindStruct, kindUnion:
+		var children []columnReader
+		for _, sub := range t.subtypes {
+			child, err := newColumnReader(s, sub)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		switch t.kind {
+		case kindList:
+			if len(children) != 1 {
+				return nil, fmt.Errorf("invalid list column %d", id)
+			}
+			return &listColumn{nulls: n, length: newIntDecoder(s.stream(id, streamLength), encoding, false), elems: children[0]}, nil
+		case kindMap:
+			if len(children) != 2 {
+				return nil, fmt.Errorf("invalid map column %d", id)
+			}
+			return &mapColumn{nulls: n, length: newIntDecoder(s.stream(id, streamLength), encoding, false), keys: children[0], values: children[1]}, nil
+		case kindStruct:
+			return &structColumn{nulls: n, names: t.fieldNames, fields: children}, nil
+		default:
+			return &unionColumn{nulls: n, tags: &byteRLE{r: s.stream(id, streamData)}, branches: children}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %d of column %d", t.kind, id)
+}
+
+type boolColumn struct {
+	nulls
+	data *boolRLE
+}
+
+func (c *boolColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	return c.data.next()
+}
+
+type byteColumn struct {
+	nulls
+	data *byteRLE
+}
+
+func (c *byteColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	b, err := c.data.next()
+	return int64(int8(b)), err
+}
+
+type intColumn struct {
+	nulls
+	data intDecoder
+}
+
+func (c *intColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	return c.data.next()
+}
+
+// floatColumn reads little endian IEEE 754 floats of size bytes.
+type floatColumn struct {
+	nulls
+	data io.Reader
+	size int
+}
+
+func (c *floatColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	var b [8]byte
+	if _, err := io.ReadFull(c.data, b[:c.size]); err != nil {
+		return nil, err
+	}
+	if c.size == 4 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(b[:4])), nil
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), nil
+}
+
+// stringColumn reads directly encoded strings, or binary values: their
+// bytes, and their lengths.
+type stringColumn struct {
+	nulls
+	data   io.Reader
+	length intDecoder
+	binary bool
+}
+
+func (c *stringColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	n, err := c.length.next()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(c.data, b); err != nil {
+		return nil, err
+	}
+	if c.binary {
+		return b, nil
+	}
+	return string(b), nil
+}
+
+// dictionaryColumn reads dictionary encoded strings: their indexes in the
+// dictionary of the column.
+type dictionaryColumn struct {
+	nulls
+	data   intDecoder
+	dict   [][]byte
+	binary bool
+}
+
+func (c *dictionaryColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	i, err := c.data.next()
+	if err != nil {
+		return nil, err
+	}
+	if i < 0 || i >= int64(len(c.dict)) {
+		return nil, fmt.Errorf("invalid dictionary index %d", i)
+	}
+	if c.binary {
+		return c.dict[i], nil
+	}
+	return string(c.dict[i]), nil
+}
+
+// readDictionary reads the dictionary of a column: the bytes of its
+// entries, and their lengths.
+func readDictionary(s *stripe, id int, ce columnEncoding) ([][]byte, error) {
+	data, err := io.ReadAll(s.stream(id, streamDictionaryData))
+	if err != nil {
+		return nil, err
+	}
+	length := newIntDecoder(s.stream(id, streamLength), ce.kind, false)
+	var dict [][]byte
+	for i := int64(0); i < ce.dictionarySize; i++ {
+		n, err := length.next()
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || n > int64(len(data)) {
+			return nil, fmt.Errorf("invalid dictionary entry length %d", n)
+		}
+		dict = append(dict, data[:n])
+		data = data[n:]
+	}
+	return dict, nil
+}
+
+// decimalColumn reads decimals: their unscaled values, and their scales.
+type decimalColumn struct {
+	nulls
+	data  io.ByteReader
+	scale intDecoder
+}
+
+func (c *decimalColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	unscaled, err := readBigVarint(c.data)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := c.scale.next()
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Rat).SetInt(unscaled)
+	if scale > 0 {
+		r.Quo(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(scale), nil)))
+	}
+	return r, nil
+}
+
+// dateColumn reads dates, stored as days since 1970-01-01.
+type dateColumn struct {
+	nulls
+	data intDecoder
+}
+
+func (c *dateColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	days, err := c.data.next()
+	if err != nil {
+		return nil, err
+	}
+	return civil.DateOf(time.Unix(days*24*60*60, 0).UTC()), nil
+}
+
+// timestampColumn reads timestamps: their seconds since the epoch in loc,
+// and their nanoseconds. Timestamps with local time zone are instants, and
+// other timestamps date times in loc.
+type timestampColumn struct {
+	nulls
+	data    intDecoder
+	nanos   intDecoder
+	loc     *time.Location
+	instant bool
+}
+
+func (c *timestampColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	secs, err := c.data.next()
+	if err != nil {
+		return nil, err
+	}
+	n, err := c.nanos.next()
+	if err != nil {
+		return nil, err
+	}
+	// Nanoseconds are stored without their trailing zeros, whose number
+	// less one is in their 3 low bits.
+	nanos := n >> 3
+	if zeros := n & 7; zeros != 0 {
+		for i := int64(0); i <= zeros; i++ {
+			nanos *= 10
+		}
+	}
+	base := epoch
+	if !c.instant {
+		base = time.Date(2015, 1, 1, 0, 0, 0, 0, c.loc)
+	}
+	secs += base.Unix()
+	// Seconds of times before 1970 with a fraction of a second are rounded
+	// up by writers.
+	if secs < 0 && nanos > 999999 {
+		secs--
+	}
+	t := time.Unix(secs, nanos)
+	if c.instant {
+		return t.UTC(), nil
+	}
+	return civil.DateTimeOf(t.In(c.loc)), nil
+}
+
+// listColumn reads lists: their lengths, and their elements.
+type listColumn struct {
+	nulls
+	length intDecoder
+	elems  columnReader
+}
+
+func (c *listColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	n, err := c.length.next()
+	if err != nil {
+		return nil, err
+	}
+	elems := make([]interface{}, n)
+	for i := range elems {
+		if elems[i], err = c.elems.next(); err != nil {
+			return nil, err
+		}
+	}
+	return elems, nil
+}
+
+// mapColumn reads maps: their numbers of entries, and their keys and
+// values. Keys are formatted as strings.
+type mapColumn struct {
+	nulls
+	length intDecoder
+	keys   columnReader
+	values columnReader
+}
+
+func (c *mapColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	n, err := c.length.next()
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	for i := int64(0); i < n; i++ {
+		k, err := c.keys.next()
+		if err != nil {
+			return nil, err
+		}
+		v, err := c.values.next()
+		if err != nil {
+			return nil, err
+		}
+		m[toString(k)] = v
+	}
+	return m, nil
+}
+
+// structColumn reads structs: the values of their fields.
+type structColumn struct {
+	nulls
+	names  []string
+	fields []columnReader
+}
+
+func (c *structColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	for i, f := range c.fields {
+		v, err := f.next()
+		if err != nil {
+			return nil, err
+		}
+		m[c.names[i]] = v
+	}
+	return m, nil
+}
+
+// unionColumn reads unions: the tags of their types, and their values.
+type unionColumn struct {
+	nulls
+	tags     *byteRLE
+	branches []columnReader
+}
+
+func (c *unionColumn) next() (interface{}, error) {
+	if null, err := c.isNull(); err != nil || null {
+		return nil, err
+	}
+	tag, err := c.tags.next()
+	if err != nil {
+		return nil, err
+	}
+	if int(tag) >= len(c.branches) {
+		return nil, fmt.Errorf("invalid union tag %d", tag)
+	}
+	return c.branches[tag].next()
+}
@@ -0,0 +1,351 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ProcessDataRow converts a row, keyed by column name, and writes it out
+// to Spanner.
+func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, row map[string]interface{}) {
+	spVals, badCols, srcStrVals := cvtRow(conv, row, srcSchema, spSchema, colIds)
+	srcTableName := srcSchema.Name
+	spColNames := []string{}
+	srcColNames := []string{}
+	for _, colId := range colIds {
+		srcColNames = append(srcColNames, srcSchema.ColDefs[colId].Name)
+		spColNames = append(spColNames, spSchema.ColDefs[colId].Name)
+	}
+	if len(badCols) > 0 {
+		conv.Unexpected(fmt.Sprintf("Data conversion error for table %s in column(s) %s\n", srcTableName, badCols))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcColNames, srcStrVals)
+		return
+	}
+	if aux, ok := conv.SyntheticPKeys[tableId]; ok {
+		spColNames = append(spColNames, conv.SpSchema[tableId].ColDefs[aux.ColId].Name)
+		spVals = append(spVals, fmt.Sprintf("%d", int64(bits.Reverse64(uint64(aux.Sequence)))))
+		aux.Sequence++
+		conv.SyntheticPKeys[tableId] = aux
+	}
+	conv.WriteRow(srcTableName, spSchema.Name, spColNames, spVals)
+}
+
+// cvtRow converts the values of the columns colIds of row to the types of
+// their Spanner columns. It returns the converted values, the names of the
+// columns whose values couldn't be converted and the values as strings.
+func cvtRow(conv *internal.Conv, row map[string]interface{}, srcSchema schema.Table, spSchema ddl.CreateTable, colIds []string) ([]interface{}, []string, []string) {
+	var srcStrVals []string
+	var spVals []interface{}
+	var badCols []string
+	for _, colId := range colIds {
+		srcColDef := srcSchema.ColDefs[colId]
+		val := row[srcColDef.Name]
+		if val == nil {
+			srcStrVals = append(srcStrVals, "NULL")
+			spVals = append(spVals, nil)
+			continue
+		}
+		spType := spSchema.ColDefs[colId].T
+		var spVal interface{}
+		var err error
+		if arr, ok := val.([]interface{}); ok && spType.IsArray {
+			spVal, err = convArray(conv, arr, spType.Name)
+		} else {
+			spVal, err = convScalar(conv, val, spType.Name)
+		}
+		if err != nil {
+			badCols = append(badCols, srcColDef.Name)
+		}
+		srcStrVals = append(srcStrVals, toString(val))
+		spVals = append(spVals, spVal)
+	}
+	return spVals, badCols, srcStrVals
+}
+
+// maxScale is the maximum number of digits after the decimal point of
+// decimals formatted as strings.
+const maxScale = 1000
+
+// convScalar converts a value read by a columnReader to a value of
+// Spanner type spType.
+func convScalar(conv *internal.Conv, val interface{}, spType string) (interface{}, error) {
+	switch spType {
+	case ddl.Bool:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+	case ddl.Bytes:
+		switch v := val.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+	case ddl.Date:
+		if d, ok := val.(civil.Date); ok {
+			return d, nil
+		}
+	case ddl.Float32:
+		switch v := val.(type) {
+		case float32:
+			return v, nil
+		case float64:
+			return float32(v), nil
+		}
+	case ddl.Float64:
+		switch v := val.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			// Converted through their shortest decimal representation, so
+			// that e.g. 0.1 isn't stored as 0.10000000149011612.
+			return strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+		}
+	case ddl.Int64:
+		switch v := val.(type) {
+		case int64:
+			return v, nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+	case ddl.Numeric:
+		switch v := val.(type) {
+		case *big.Rat:
+			return convNumeric(conv, v), nil
+		case int64:
+			return convNumeric(conv, big.NewRat(v, 1)), nil
+		}
+	case ddl.Timestamp:
+		switch v := val.(type) {
+		case time.Time:
+			return v.UTC(), nil
+		case civil.DateTime:
+			return v.In(time.UTC), nil
+		}
+	case ddl.String:
+		return toString(val), nil
+	case ddl.JSON:
+		return toJSON(val)
+	}
+	return nil, fmt.Errorf("can't convert value %v of type %T to Spanner type %s", val, val, spType)
+}
+
+// convArray converts the elements of a list to a slice of the Spanner type
+// spType. The Spanner client doesn't accept []interface{} for arrays, only
+// slices of specific types. Null elements are null in the slice.
+func convArray(conv *internal.Conv, vals []interface{}, spType string) (interface{}, error) {
+	elems := make([]interface{}, len(vals))
+	for i, val := range vals {
+		if val == nil {
+			continue
+		}
+		elem, err := convScalar(conv, val, spType)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = elem
+	}
+	switch spType {
+	case ddl.Bool:
+		r := []spanner.NullBool{}
+		for _, e := range elems {
+			b, ok := e.(bool)
+			r = append(r, spanner.NullBool{Bool: b, Valid: ok})
+		}
+		return r, nil
+	case ddl.Bytes:
+		r := [][]byte{}
+		for _, e := range elems {
+			b, _ := e.([]byte)
+			r = append(r, b)
+		}
+		return r, nil
+	case ddl.Date:
+		r := []spanner.NullDate{}
+		for _, e := range elems {
+			d, ok := e.(civil.Date)
+			r = append(r, spanner.NullDate{Date: d, Valid: ok})
+		}
+		return r, nil
+	case ddl.Float32:
+		r := []spanner.NullFloat32{}
+		for _, e := range elems {
+			f, ok := e.(float32)
+			r = append(r, spanner.NullFloat32{Float32: f, Valid: ok})
+		}
+		return r, nil
+	case ddl.Float64:
+		r := []spanner.NullFloat64{}
+		for _, e := range elems {
+			f, ok := e.(float64)
+			r = append(r, spanner.NullFloat64{Float64: f, Valid: ok})
+		}
+		return r, nil
+	case ddl.Int64:
+		r := []spanner.NullInt64{}
+		for _, e := range elems {
+			n, ok := e.(int64)
+			r = append(r, spanner.NullInt64{Int64: n, Valid: ok})
+		}
+		return r, nil
+	case ddl.Numeric:
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			r := []spanner.PGNumeric{}
+			for _, e := range elems {
+				n, _ := e.(spanner.PGNumeric)
+				r = append(r, n)
+			}
+			return r, nil
+		}
+		r := []spanner.NullNumeric{}
+		for _, e := range elems {
+			n, ok := e.(*big.Rat)
+			if !ok {
+				r = append(r, spanner.NullNumeric{})
+				continue
+			}
+			r = append(r, spanner.NullNumeric{Numeric: *n, Valid: true})
+		}
+		return r, nil
+	case ddl.String:
+		r := []spanner.NullString{}
+		for _, e := range elems {
+			s, ok := e.(string)
+			r = append(r, spanner.NullString{StringVal: s, Valid: ok})
+		}
+		return r, nil
+	case ddl.Timestamp:
+		r := []spanner.NullTime{}
+		for _, e := range elems {
+			t, ok := e.(time.Time)
+			r = append(r, spanner.NullTime{Time: t, Valid: ok})
+		}
+		return r, nil
+	case ddl.JSON:
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			r := []spanner.PGJsonB{}
+			for _, e := range elems {
+				s, ok := e.(string)
+				r = append(r, spanner.PGJsonB{Value: json.RawMessage(s), Valid: ok})
+			}
+			return r, nil
+		}
+		r := []spanner.NullJSON{}
+		for _, e := range elems {
+			s, ok := e.(string)
+			r = append(r, spanner.NullJSON{Value: json.RawMessage(s), Valid: ok})
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("array type conversion not implemented for type %v", spType)
+}
+
+// convNumeric maps a rational number into a valid Spanner numeric.
+func convNumeric(conv *internal.Conv, r *big.Rat) interface{} {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return spanner.PGNumeric{Numeric: decimalString(r), Valid: true}
+	}
+	return r
+}
+
+// decimalString formats a decimal without trailing zeros. The denominator
+// of decimals is a divisor of a power of 10, which gives the number of
+// digits after the decimal point.
+func decimalString(r *big.Rat) string {
+	digits, p := 0, big.NewInt(1)
+	for new(big.Int).Rem(p, r.Denom()).Sign() != 0 && digits < maxScale {
+		p.Mul(p, big.NewInt(10))
+		digits++
+	}
+	return r.FloatString(digits)
+}
+
+// toString formats a value read by a columnReader as a string.
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case *big.Rat:
+		return decimalString(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case civil.Date, civil.DateTime:
+		return fmt.Sprint(v)
+	}
+	s, err := toJSON(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+	return s
+}
+
+// toJSON formats a value read by a columnReader as JSON. Bytes are base64
+// encoded, and decimals are JSON numbers.
+func toJSON(val interface{}) (string, error) {
+	b, err := json.Marshal(toJSONValue(val))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func toJSONValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for k, e := range v {
+			m[k] = toJSONValue(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = toJSONValue(e)
+		}
+		return a
+	case *big.Rat:
+		return json.Number(decimalString(v))
+	}
+	return val
+}
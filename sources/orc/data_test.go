@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestConvScalar(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		in      interface{}
+		want    interface{}
+	}{
+		{name: "bool", spType: ddl.Bool, in: true, want: true},
+		{name: "bool to int64", spType: ddl.Int64, in: true, want: int64(1)},
+		{name: "float32 to float64", spType: ddl.Float64, in: float32(0.1), want: 0.1},
+		{name: "string to bytes", spType: ddl.Bytes, in: "ab", want: []byte("ab")},
+		{name: "numeric pg", dialect: constants.DIALECT_POSTGRESQL, spType: ddl.Numeric, in: big.NewRat(25, 2), want: spanner.PGNumeric{Numeric: "12.5", Valid: true}},
+		{name: "bigint to numeric", spType: ddl.Numeric, in: int64(3), want: big.NewRat(3, 1)},
+		{name: "numeric to string", spType: ddl.String, in: big.NewRat(1, 8), want: "0.125"},
+		{name: "timestamp", spType: ddl.Timestamp, in: civil.DateTime{Date: civil.Date{Year: 2024, Month: 1, Day: 2}}, want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "date to string", spType: ddl.String, in: civil.Date{Year: 2024, Month: 1, Day: 2}, want: "2024-01-02"},
+		{name: "struct", spType: ddl.JSON, in: map[string]interface{}{"n": big.NewRat(5, 4), "b": []byte{0xca, 0xfe}, "l": []interface{}{int64(1), nil}}, want: `{"b":"yv4=","l":[1,null],"n":1.25}`},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		got, err := convScalar(conv, tc.in, tc.spType)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+	_, err := convScalar(internal.MakeConv(), "abc", ddl.Int64)
+	assert.NotNil(t, err)
+}
+
+func TestConvArray(t *testing.T) {
+	conv := internal.MakeConv()
+	got, err := convArray(conv, []interface{}{int64(1), nil}, ddl.Int64)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullInt64{{Int64: 1, Valid: true}, {}}, got)
+	got, err = convArray(conv, []interface{}{big.NewRat(1, 2)}, ddl.Numeric)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullNumeric{{Numeric: *big.NewRat(1, 2), Valid: true}}, got)
+	got, err = convArray(conv, []interface{}{civil.Date{Year: 2024, Month: 1, Day: 2}}, ddl.Date)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullDate{{Date: civil.Date{Year: 2024, Month: 1, Day: 2}, Valid: true}}, got)
+	_, err = convArray(conv, []interface{}{"abc"}, ddl.Int64)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package orc handles schema and data migrations from ORC files, e.g.
+// exports of Hive tables. Each table is read from a set of files, found
+// under a local directory or GCS path or listed in a manifest (see
+// common.GetFileSets). The schema of a table is the schema of the first
+// file of its set, and the columns of the other files are matched to it by
+// name. Files are read a stripe at a time, and all their rows are read.
+package orc
+
+import (
+	"context"
+	"fmt"
+
+	sp "cloud.google.com/go/spanner"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Extension is the extension of ORC files.
+const Extension = ".orc"
+
+// Source types are named after the ORC types, as in the schemas of Hive
+// tables.
+const (
+	typeBoolean          = "boolean"
+	typeTinyint          = "tinyint"
+	typeSmallint         = "smallint"
+	typeInt              = "int"
+	typeBigint           = "bigint"
+	typeFloat            = "float"
+	typeDouble           = "double"
+	typeString           = "string"
+	typeVarchar          = "varchar"
+	typeChar             = "char"
+	typeBinary           = "binary"
+	typeDecimal          = "decimal"
+	typeDate             = "date"
+	typeTimestamp        = "timestamp"
+	typeTimestampLocalTZ = "timestamp with local time zone"
+	typeStruct           = "struct"
+	typeMap              = "map"
+	typeUnion            = "uniontype"
+)
+
+// InfoSchemaImpl is the ORC specific implementation of InfoSchema.
+type InfoSchemaImpl struct {
+	FileSets []common.FileSet
+}
+
+// GetToDdl implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: files can only be migrated with
+// bulk migrations.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for ORC files")
+}
+
+// StartStreamingMigration is not supported: files can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for ORC files")
+}
+
+// GetTableName returns table name. Tables have no schema.
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	return tableName
+}
+
+// GetTables returns a table for each file set.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	var tables []common.SchemaAndName
+	for _, fs := range isi.FileSets {
+		tables = append(tables, common.SchemaAndName{Name: fs.Table})
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names, read from the
+// schema of the first file of the table. ORC columns have no NOT NULL
+// constraints.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	files, err := isi.getFiles(table.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := openFile(context.Background(), files[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't read schema of table %s from %s: %w", table.Name, files[0], err)
+	}
+	defer f.Close()
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	root := f.types[0]
+	for i, name := range root.fieldNames {
+		colId := internal.GenerateColumnId()
+		colDefs[colId] = schema.Column{
+			Id:   colId,
+			Name: name,
+			Type: toType(f.types, root.subtypes[i]),
+		}
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetRowsFromTable is not used: data is read from files by ProcessData.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
+	return nil, fmt.Errorf("data of ORC files is read by ProcessData")
+}
+
+// GetRowCount returns the number of rows of a table, from the footers of
+// its files.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	files, err := isi.getFiles(table.Name)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, path := range files {
+		f, err := openFile(context.Background(), path)
+		if err != nil {
+			return 0, err
+		}
+		count += f.numRows
+		f.Close()
+	}
+	return count, nil
+}
+
+// GetConstraints returns no constraints: ORC files have no primary keys,
+// so tables get a synthetic primary key.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	return nil, nil, make(map[string][]string), nil
+}
+
+// GetForeignKeys returns no foreign keys: ORC files have none.
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	return nil, nil
+}
+
+// GetIndexes returns no indexes: ORC files have none.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return nil, nil
+}
+
+// ProcessData reads the rows of the files of a table, converts them to
+// Spanner data (based on the source and Spanner schemas) and writes them
+// to Spanner. Columns missing from a file are NULL, and columns of files
+// that aren't in the source schema are skipped.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	files, err := isi.getFiles(srcSchema.Name)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	ctx := context.Background()
+	for _, path := range files {
+		err := readRows(ctx, path, func(row map[string]interface{}) {
+			ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, row)
+		})
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read file %s of table %s : err = %s", path, srcSchema.Name, err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (isi InfoSchemaImpl) getFiles(tableName string) ([]string, error) {
+	for _, fs := range isi.FileSets {
+		if fs.Table == tableName && len(fs.Files) > 0 {
+			return fs.Files, nil
+		}
+	}
+	return nil, fmt.Errorf("no files found for table %s", tableName)
+}
+
+// readRows calls processRow with each row of the ORC file at path, keyed
+// by column name. See columnReader for the types of values.
+func readRows(ctx context.Context, path string, processRow func(row map[string]interface{})) error {
+	f, err := openFile(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for i, si := range f.stripes {
+		s, err := f.readStripe(si)
+		if err != nil {
+			return fmt.Errorf("couldn't read stripe %d: %w", i, err)
+		}
+		rows, err := newColumnReader(s, 0)
+		if err != nil {
+			return fmt.Errorf("couldn't read stripe %d: %w", i, err)
+		}
+		for j := int64(0); j < s.numRows; j++ {
+			v, err := rows.next()
+			if err != nil {
+				return fmt.Errorf("couldn't read row %d of stripe %d: %w", j, i, err)
+			}
+			row, _ := v.(map[string]interface{})
+			processRow(row)
+		}
+	}
+	return nil
+}
+
+// toType maps the ORC type id to a schema.Type. The mods of decimal types
+// are their precision and scale, and those of varchar and char types their
+// maximum length. Arrays are arrays of their elements, and arrays of
+// arrays multi-dimensional arrays.
+func toType(types []orcType, id int) schema.Type {
+	t := types[id]
+	switch t.kind {
+	case kindBoolean:
+		return schema.Type{Name: typeBoolean}
+	case kindByte:
+		return schema.Type{Name: typeTinyint}
+	case kindShort:
+		return schema.Type{Name: typeSmallint}
+	case kindInt:
+		return schema.Type{Name: typeInt}
+	case kindLong:
+		return schema.Type{Name: typeBigint}
+	case kindFloat:
+		return schema.Type{Name: typeFloat}
+	case kindDouble:
+		return schema.Type{Name: typeDouble}
+	case kindString:
+		return schema.Type{Name: typeString}
+	case kindVarchar:
+		return schema.Type{Name: typeVarchar, Mods: []int64{t.maxLength}}
+	case kindChar:
+		return schema.Type{Name: typeChar, Mods: []int64{t.maxLength}}
+	case kindBinary:
+		return schema.Type{Name: typeBinary}
+	case kindDecimal:
+		return schema.Type{Name: typeDecimal, Mods: []int64{t.precision, t.scale}}
+	case kindDate:
+		return schema.Type{Name: typeDate}
+	case kindTimestamp:
+		return schema.Type{Name: typeTimestamp}
+	case kindTimestampInstant:
+		return schema.Type{Name: typeTimestampLocalTZ}
+	case kindStruct:
+		return schema.Type{Name: typeStruct}
+	case kindMap:
+		return schema.Type{Name: typeMap}
+	case kindUnion:
+		return schema.Type{Name: typeUnion}
+	case kindList:
+		if len(t.subtypes) == 1 {
+			elem := toType(types, t.subtypes[0])
+			elem.ArrayBounds = append([]int64{-1}, elem.ArrayBounds...)
+			return elem
+		}
+	}
+	return schema.Type{Name: fmt.Sprintf("unknown type %d", t.kind)}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+// mkInfoSchema writes the files of the orders table to a temporary
+// directory, and returns an InfoSchemaImpl reading them.
+func mkInfoSchema(t *testing.T) InfoSchemaImpl {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "orders", "part-00000.orc"), compressionZlib, testTypes, testStripes(t, 1, 3)...)
+	writeTestFile(t, filepath.Join(dir, "orders", "part-00001.orc"), compressionSnappy, testTypes, testStripes(t, 5)...)
+	fileSets, err := common.GetFileSets(context.Background(), dir, "", Extension)
+	assert.Nil(t, err)
+	return InfoSchemaImpl{FileSets: fileSets}
+}
+
+func processSchema(t *testing.T, isi InfoSchemaImpl) *internal.Conv {
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	return conv
+}
+
+var cols = []string{"id", "name", "price", "ok", "tiny", "f", "d", "day", "ts", "tsi", "data", "tags", "attrs", "nested", "u", "code"}
+
+func TestProcessSchema(t *testing.T) {
+	conv := processSchema(t, mkInfoSchema(t))
+	expectedSchema := map[string]ddl.CreateTable{
+		"orders": {
+			Name:   "orders",
+			ColIds: append(append([]string{}, cols...), "synth_id"),
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":       {Name: "id", T: ddl.Type{Name: ddl.Int64}},
+				"name":     {Name: "name", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"price":    {Name: "price", T: ddl.Type{Name: ddl.Numeric}},
+				"ok":       {Name: "ok", T: ddl.Type{Name: ddl.Bool}},
+				"tiny":     {Name: "tiny", T: ddl.Type{Name: ddl.Int64}},
+				"f":        {Name: "f", T: ddl.Type{Name: ddl.Float32}},
+				"d":        {Name: "d", T: ddl.Type{Name: ddl.Float64}},
+				"day":      {Name: "day", T: ddl.Type{Name: ddl.Date}},
+				"ts":       {Name: "ts", T: ddl.Type{Name: ddl.Timestamp}},
+				"tsi":      {Name: "tsi", T: ddl.Type{Name: ddl.Timestamp}},
+				"data":     {Name: "data", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+				"tags":     {Name: "tags", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+				"attrs":    {Name: "attrs", T: ddl.Type{Name: ddl.JSON}},
+				"nested":   {Name: "nested", T: ddl.Type{Name: ddl.JSON}},
+				"u":        {Name: "u", T: ddl.Type{Name: ddl.JSON}},
+				"code":     {Name: "code", T: ddl.Type{Name: ddl.String, Len: 8}},
+				"synth_id": {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
+
+func TestGetRowCount(t *testing.T) {
+	isi := mkInfoSchema(t)
+	count, err := isi.GetRowCount(common.SchemaAndName{Name: "orders"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), count)
+	_, err = isi.GetRowCount(common.SchemaAndName{Name: "missing"})
+	assert.NotNil(t, err)
+}
+
+// processTableData migrates the data of the Spanner table spTableName and
+// returns the rows written, without their synthetic primary keys.
+func processTableData(t *testing.T, conv *internal.Conv, isi InfoSchemaImpl, spTableName string) []spannerData {
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols[:len(cols)-1], vals: vals[:len(vals)-1]})
+		})
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, spTableName)
+	assert.Nil(t, err)
+	colIds := conv.SpSchema[tableId].ColIds
+	err = isi.ProcessData(conv, tableId, conv.SrcSchema[tableId], colIds[:len(colIds)-1], conv.SpSchema[tableId], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	return rows
+}
+
+func TestProcessData(t *testing.T) {
+	isi := mkInfoSchema(t)
+	conv := processSchema(t, isi)
+	rows := processTableData(t, conv, isi, "orders")
+	var want []spannerData
+	for _, id := range []int64{1, 3, 5} {
+		want = append(want, spannerData{
+			table: "orders",
+			cols:  cols,
+			vals: []interface{}{
+				id, "apple", big.NewRat(25, 2), true, int64(-1), float32(1.5), 2.25, day,
+				time.Date(2024, 1, 2, 3, 4, 5, 500000000, time.UTC), tsi, []byte{1, 2, 3},
+				[]spanner.NullString{{StringVal: "a", Valid: true}, {StringVal: "b", Valid: true}},
+				`{"k":7}`, `{"x":3}`, `"s"`, "AB",
+			},
+		}, spannerData{
+			table: "orders",
+			cols:  cols,
+			vals: []interface{}{
+				id + 1, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				[]spanner.NullString{}, nil, `{"x":null}`, `5`, "AB",
+			},
+		})
+	}
+	assert.Equal(t, want, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+}
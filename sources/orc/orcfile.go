@@ -0,0 +1,523 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+)
+
+// The structure of ORC files, see
+// https://orc.apache.org/specification/ORCv1/#file-tail. The metadata of
+// files is protobuf encoded, and decoded here field by field, with the
+// field numbers of orc_proto.proto.
+
+type compressionKind uint64
+
+const (
+	compressionNone   compressionKind = 0
+	compressionZlib   compressionKind = 1
+	compressionSnappy compressionKind = 2
+	compressionLzo    compressionKind = 3
+	compressionLz4    compressionKind = 4
+	compressionZstd   compressionKind = 5
+)
+
+// typeKind is the kind of an ORC type.
+type typeKind uint64
+
+const (
+	kindBoolean          typeKind = 0
+	kindByte             typeKind = 1
+	kindShort            typeKind = 2
+	kindInt              typeKind = 3
+	kindLong             typeKind = 4
+	kindFloat            typeKind = 5
+	kindDouble           typeKind = 6
+	kindString           typeKind = 7
+	kindBinary           typeKind = 8
+	kindTimestamp        typeKind = 9
+	kindList             typeKind = 10
+	kindMap              typeKind = 11
+	kindStruct           typeKind = 12
+	kindUnion            typeKind = 13
+	kindDecimal          typeKind = 14
+	kindDate             typeKind = 15
+	kindVarchar          typeKind = 16
+	kindChar             typeKind = 17
+	kindTimestampInstant typeKind = 18
+)
+
+type streamKind uint64
+
+const (
+	streamPresent        streamKind = 0
+	streamData           streamKind = 1
+	streamLength         streamKind = 2
+	streamDictionaryData streamKind = 3
+	streamSecondary      streamKind = 5
+)
+
+type encodingKind uint64
+
+const (
+	encodingDirect       encodingKind = 0
+	encodingDictionary   encodingKind = 1
+	encodingDirectV2     encodingKind = 2
+	encodingDictionaryV2 encodingKind = 3
+)
+
+// orcType is a type of the schema of an ORC file. Types are numbered in
+// pre-order, and are also the ids of the columns of the file: the root
+// struct type 0 is the type of the rows.
+type orcType struct {
+	kind       typeKind
+	subtypes   []int    // Ids of the types of fields, elements, keys and values.
+	fieldNames []string // Names of the fields of structs.
+	maxLength  int64    // Of varchar and char types.
+	precision  int64    // Of decimal types.
+	scale      int64    // Of decimal types.
+}
+
+// stripeInfo is the location of a stripe in a file.
+type stripeInfo struct {
+	offset       int64
+	indexLength  int64
+	dataLength   int64
+	footerLength int64
+	numRows      int64
+}
+
+// tailSize is the size of the end of files read to read their postscript
+// and footer, which are usually smaller.
+const tailSize = 16 * 1024
+
+// orcFile is an open ORC file. Its stripes are read one at a time.
+type orcFile struct {
+	r           file_reader.ReaderAtSeekCloser
+	compression compressionKind
+	types       []orcType
+	stripes     []stripeInfo
+	numRows     int64
+	zstd        *zstd.Decoder
+}
+
+// openFile opens the local or GCS ORC file at path, and reads its footer.
+func openFile(ctx context.Context, path string) (*orcFile, error) {
+	r, err := file_reader.OpenReaderAt(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	f := &orcFile{r: r}
+	if err := f.readTail(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("couldn't read ORC file %s: %w", path, err)
+	}
+	return f, nil
+}
+
+func (f *orcFile) Close() error {
+	if f.zstd != nil {
+		f.zstd.Close()
+	}
+	return f.r.Close()
+}
+
+// readTail reads the postscript and the footer of the file. The last byte
+// of files is the length of their postscript, which precedes it, and which
+// has the length of the footer preceding it.
+func (f *orcFile) readTail() error {
+	size, err := f.r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	tail := make([]byte, min(size, tailSize))
+	if _, err := f.r.ReadAt(tail, size-int64(len(tail))); err != nil && err != io.EOF {
+		return err
+	}
+	if len(tail) == 0 {
+		return fmt.Errorf("empty file")
+	}
+	psLen := int(tail[len(tail)-1])
+	if psLen+1 > len(tail) {
+		return fmt.Errorf("invalid postscript length %d", psLen)
+	}
+	ps := tail[len(tail)-1-psLen : len(tail)-1]
+	var footerLen int64
+	var magic string
+	err = parseMessage(ps, func(num protowire.Number, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			footerLen = int64(v)
+		case 2:
+			f.compression = compressionKind(v)
+		case 8000:
+			magic = string(b)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("invalid postscript: %w", err)
+	}
+	if magic != "ORC" {
+		return fmt.Errorf("not an ORC file")
+	}
+	switch f.compression {
+	case compressionNone, compressionZlib, compressionSnappy, compressionLz4:
+	case compressionZstd:
+		if f.zstd, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported compression %d", f.compression)
+	}
+	var footer []byte
+	if end := len(tail) - 1 - psLen; int64(end) >= footerLen {
+		footer = tail[end-int(footerLen) : end]
+	} else {
+		footer = make([]byte, footerLen)
+		if _, err := f.r.ReadAt(footer, size-1-int64(psLen)-footerLen); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	if footer, err = io.ReadAll(f.stream(footer)); err != nil {
+		return err
+	}
+	if err := f.parseFooter(footer); err != nil {
+		return err
+	}
+	return f.checkTypes()
+}
+
+// checkTypes checks that the types of the file are a tree, whose root is
+// the struct type of the rows.
+func (f *orcFile) checkTypes() error {
+	if len(f.types) == 0 || f.types[0].kind != kindStruct {
+		return fmt.Errorf("rows aren't structs")
+	}
+	for id, t := range f.types {
+		for _, sub := range t.subtypes {
+			if sub <= id || sub >= len(f.types) {
+				return fmt.Errorf("invalid subtype %d of type %d", sub, id)
+			}
+		}
+		if t.kind == kindStruct && len(t.fieldNames) != len(t.subtypes) {
+			return fmt.Errorf("invalid fields of type %d", id)
+		}
+	}
+	return nil
+}
+
+func (f *orcFile) parseFooter(footer []byte) error {
+	return parseMessage(footer, func(num protowire.Number, v uint64, b []byte) error {
+		switch num {
+		case 3:
+			var si stripeInfo
+			err := parseMessage(b, func(num protowire.Number, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					si.offset = int64(v)
+				case 2:
+					si.indexLength = int64(v)
+				case 3:
+					si.dataLength = int64(v)
+				case 4:
+					si.footerLength = int64(v)
+				case 5:
+					si.numRows = int64(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("invalid stripe information: %w", err)
+			}
+			f.stripes = append(f.stripes, si)
+		case 4:
+			var t orcType
+			err := parseMessage(b, func(num protowire.Number, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					t.kind = typeKind(v)
+				case 2:
+					ids, err := parseUint32s(v, b)
+					for _, id := range ids {
+						t.subtypes = append(t.subtypes, int(id))
+					}
+					return err
+				case 3:
+					t.fieldNames = append(t.fieldNames, string(b))
+				case 4:
+					t.maxLength = int64(v)
+				case 5:
+					t.precision = int64(v)
+				case 6:
+					t.scale = int64(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("invalid type: %w", err)
+			}
+			f.types = append(f.types, t)
+		case 6:
+			f.numRows = int64(v)
+		}
+		return nil
+	})
+}
+
+// stripe is a stripe of a file, read in memory.
+type stripe struct {
+	file      *orcFile
+	numRows   int64
+	streams   map[streamKey][]byte // Compressed data streams.
+	encodings []columnEncoding     // Encodings of the columns.
+	timezone  string               // Time zone of the writer.
+}
+
+type columnEncoding struct {
+	kind           encodingKind
+	dictionarySize int64
+}
+
+type streamKey struct {
+	column int
+	kind   streamKind
+}
+
+// readStripe reads the data streams and footer of a stripe. Index streams,
+// which precede the data streams, aren't read.
+func (f *orcFile) readStripe(si stripeInfo) (*stripe, error) {
+	buf := make([]byte, si.dataLength+si.footerLength)
+	dataOffset := si.offset + si.indexLength
+	if _, err := f.r.ReadAt(buf, dataOffset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	footer, err := io.ReadAll(f.stream(buf[si.dataLength:]))
+	if err != nil {
+		return nil, err
+	}
+	s := &stripe{file: f, numRows: si.numRows, streams: make(map[streamKey][]byte)}
+	offset := si.offset
+	err = parseMessage(footer, func(num protowire.Number, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			var key streamKey
+			var length int64
+			err := parseMessage(b, func(num protowire.Number, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					key.kind = streamKind(v)
+				case 2:
+					key.column = int(v)
+				case 3:
+					length = int64(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			// Streams are stored in the order of the footer.
+			if offset >= dataOffset && offset+length <= dataOffset+si.dataLength {
+				s.streams[key] = buf[offset-dataOffset : offset-dataOffset+length]
+			}
+			offset += length
+		case 2:
+			var encoding columnEncoding
+			err := parseMessage(b, func(num protowire.Number, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					encoding.kind = encodingKind(v)
+				case 2:
+					encoding.dictionarySize = int64(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			s.encodings = append(s.encodings, encoding)
+		case 3:
+			s.timezone = string(b)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid stripe footer: %w", err)
+	}
+	return s, nil
+}
+
+// stream returns a reader of a stream of a column, which is empty if the
+// column has no such stream.
+func (s *stripe) stream(column int, kind streamKind) *chunkReader {
+	return s.file.stream(s.streams[streamKey{column: column, kind: kind}])
+}
+
+func (s *stripe) encoding(column int) columnEncoding {
+	if column < len(s.encodings) {
+		return s.encodings[column]
+	}
+	return columnEncoding{kind: encodingDirect}
+}
+
+// stream returns a reader of the decompressed data of a stream.
+func (f *orcFile) stream(data []byte) *chunkReader {
+	r := &chunkReader{file: f, src: data}
+	if f.compression == compressionNone {
+		r.buf, r.src = data, nil
+	}
+	return r
+}
+
+// chunkReader reads a compressed stream: a sequence of chunks, each with a
+// 3 byte header with their length and whether they are compressed.
+// Chunks are decompressed as they are read.
+type chunkReader struct {
+	file *orcFile
+	src  []byte // Chunks left.
+	buf  []byte // Data left of the current chunk.
+}
+
+func (r *chunkReader) ReadByte() (byte, error) {
+	for len(r.buf) == 0 {
+		if err := r.nextChunk(); err != nil {
+			return 0, err
+		}
+	}
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b, nil
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if err := r.nextChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *chunkReader) nextChunk() error {
+	if len(r.src) == 0 {
+		return io.EOF
+	}
+	if len(r.src) < 3 {
+		return io.ErrUnexpectedEOF
+	}
+	h := int(r.src[0]) | int(r.src[1])<<8 | int(r.src[2])<<16
+	length := h >> 1
+	if 3+length > len(r.src) {
+		return io.ErrUnexpectedEOF
+	}
+	chunk := r.src[3 : 3+length]
+	r.src = r.src[3+length:]
+	if h&1 == 1 {
+		// Chunks that wouldn't be smaller compressed are stored as is.
+		r.buf = chunk
+		return nil
+	}
+	var err error
+	switch r.file.compression {
+	case compressionZlib:
+		r.buf, err = io.ReadAll(flate.NewReader(bytes.NewReader(chunk)))
+	case compressionSnappy:
+		r.buf, err = snappy.Decode(nil, chunk)
+	case compressionLz4:
+		r.buf, err = lz4Decode(chunk)
+	case compressionZstd:
+		r.buf, err = r.file.zstd.DecodeAll(chunk, nil)
+	default:
+		err = fmt.Errorf("unsupported compression %d", r.file.compression)
+	}
+	return err
+}
+
+// lz4Decode decompresses an LZ4 block, whose decompressed size isn't
+// stored, into buffers of growing size.
+func lz4Decode(chunk []byte) ([]byte, error) {
+	for size := 4 * len(chunk); ; size *= 2 {
+		buf := make([]byte, size)
+		n, err := lz4.UncompressBlock(chunk, buf)
+		if err == nil {
+			return buf[:n], nil
+		}
+		if size > 64*len(chunk)+64*1024 {
+			return nil, err
+		}
+	}
+}
+
+// parseMessage calls field with the number and value of each field of a
+// protobuf message: v for varint fields, and b for length-delimited ones.
+func parseMessage(msg []byte, field func(num protowire.Number, v uint64, b []byte) error) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+		var v uint64
+		var b []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(msg)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(msg)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, msg)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+		if err := field(num, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseUint32s returns the values of a repeated uint32 field, which are
+// packed in b, or in v if they aren't packed.
+func parseUint32s(v uint64, b []byte) ([]uint64, error) {
+	if b == nil {
+		return []uint64{v}, nil
+	}
+	var vals []uint64
+	for len(b) > 0 {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		vals = append(vals, v)
+		b = b[n:]
+	}
+	return vals, nil
+}
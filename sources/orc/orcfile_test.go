@@ -0,0 +1,407 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// testStream is a stream of a stripe of a test file.
+type testStream struct {
+	column int
+	kind   streamKind
+	data   []byte // Uncompressed.
+}
+
+// testStripe is a stripe of a test file. Columns are encoded with the
+// DIRECT encoding unless specified otherwise.
+type testStripe struct {
+	numRows   int64
+	timezone  string
+	encodings map[int]columnEncoding
+	streams   []testStream
+}
+
+// writeTestFile writes an ORC file with types and stripes. Each stripe has
+// a row index stream, which is skipped by readers.
+func writeTestFile(t *testing.T, path string, compression compressionKind, types []orcType, stripes ...testStripe) {
+	file := []byte("ORC")
+	var footer []byte
+	var numRows int64
+	for _, s := range stripes {
+		offset := int64(len(file))
+		index := compress(t, compression, []byte{0xde, 0xad})
+		file = append(file, index...)
+		var sf []byte
+		sf = protowire.AppendTag(sf, 1, protowire.BytesType)
+		sf = protowire.AppendBytes(sf, streamInfo(6, 0, len(index)))
+		var dataLength int
+		for _, st := range s.streams {
+			data := compress(t, compression, st.data)
+			file = append(file, data...)
+			dataLength += len(data)
+			sf = protowire.AppendTag(sf, 1, protowire.BytesType)
+			sf = protowire.AppendBytes(sf, streamInfo(st.kind, st.column, len(data)))
+		}
+		for i := range types {
+			var ce []byte
+			ce = protowire.AppendTag(ce, 1, protowire.VarintType)
+			ce = protowire.AppendVarint(ce, uint64(s.encodings[i].kind))
+			ce = protowire.AppendTag(ce, 2, protowire.VarintType)
+			ce = protowire.AppendVarint(ce, uint64(s.encodings[i].dictionarySize))
+			sf = protowire.AppendTag(sf, 2, protowire.BytesType)
+			sf = protowire.AppendBytes(sf, ce)
+		}
+		sf = protowire.AppendTag(sf, 3, protowire.BytesType)
+		sf = protowire.AppendString(sf, s.timezone)
+		sf = compress(t, compression, sf)
+		file = append(file, sf...)
+		var si []byte
+		for i, v := range []int64{offset, int64(len(index)), int64(dataLength), int64(len(sf)), s.numRows} {
+			si = protowire.AppendTag(si, protowire.Number(i+1), protowire.VarintType)
+			si = protowire.AppendVarint(si, uint64(v))
+		}
+		footer = protowire.AppendTag(footer, 3, protowire.BytesType)
+		footer = protowire.AppendBytes(footer, si)
+		numRows += s.numRows
+	}
+	for _, ty := range types {
+		var tb []byte
+		tb = protowire.AppendTag(tb, 1, protowire.VarintType)
+		tb = protowire.AppendVarint(tb, uint64(ty.kind))
+		var subtypes []byte
+		for _, sub := range ty.subtypes {
+			subtypes = protowire.AppendVarint(subtypes, uint64(sub))
+		}
+		tb = protowire.AppendTag(tb, 2, protowire.BytesType)
+		tb = protowire.AppendBytes(tb, subtypes)
+		for _, name := range ty.fieldNames {
+			tb = protowire.AppendTag(tb, 3, protowire.BytesType)
+			tb = protowire.AppendString(tb, name)
+		}
+		for i, v := range []int64{ty.maxLength, ty.precision, ty.scale} {
+			tb = protowire.AppendTag(tb, protowire.Number(i+4), protowire.VarintType)
+			tb = protowire.AppendVarint(tb, uint64(v))
+		}
+		footer = protowire.AppendTag(footer, 4, protowire.BytesType)
+		footer = protowire.AppendBytes(footer, tb)
+	}
+	footer = protowire.AppendTag(footer, 6, protowire.VarintType)
+	footer = protowire.AppendVarint(footer, uint64(numRows))
+	footer = compress(t, compression, footer)
+	file = append(file, footer...)
+	var ps []byte
+	ps = protowire.AppendTag(ps, 1, protowire.VarintType)
+	ps = protowire.AppendVarint(ps, uint64(len(footer)))
+	ps = protowire.AppendTag(ps, 2, protowire.VarintType)
+	ps = protowire.AppendVarint(ps, uint64(compression))
+	ps = protowire.AppendTag(ps, 8000, protowire.BytesType)
+	ps = protowire.AppendString(ps, "ORC")
+	file = append(file, ps...)
+	file = append(file, byte(len(ps)))
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.Nil(t, os.WriteFile(path, file, 0644))
+}
+
+func streamInfo(kind streamKind, column, length int) []byte {
+	var b []byte
+	for i, v := range []int{int(kind), column, length} {
+		b = protowire.AppendTag(b, protowire.Number(i+1), protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(v))
+	}
+	return b
+}
+
+// compress compresses data in a single chunk.
+func compress(t *testing.T, compression compressionKind, data []byte) []byte {
+	var chunk []byte
+	switch compression {
+	case compressionNone:
+		return data
+	case compressionZlib:
+		var buf bytes.Buffer
+		w, err := flate.NewWriter(&buf, flate.BestCompression)
+		assert.Nil(t, err)
+		_, err = w.Write(data)
+		assert.Nil(t, err)
+		assert.Nil(t, w.Close())
+		chunk = buf.Bytes()
+	case compressionSnappy:
+		chunk = snappy.Encode(nil, data)
+	case compressionLz4:
+		chunk = make([]byte, lz4.CompressBlockBound(len(data)))
+		n, err := lz4.CompressBlock(data, chunk, nil)
+		assert.Nil(t, err)
+		chunk = chunk[:n]
+		if n == 0 {
+			// Incompressible data is stored as is.
+			return append(chunkHeader(len(data), true), data...)
+		}
+	case compressionZstd:
+		enc, err := zstd.NewWriter(nil)
+		assert.Nil(t, err)
+		chunk = enc.EncodeAll(data, nil)
+	}
+	return append(chunkHeader(len(chunk), false), chunk...)
+}
+
+func chunkHeader(length int, original bool) []byte {
+	h := length << 1
+	if original {
+		h |= 1
+	}
+	return []byte{byte(h), byte(h >> 8), byte(h >> 16)}
+}
+
+// byteLiterals byte run length encodes values as runs of literals.
+func byteLiterals(vals ...byte) []byte {
+	var b []byte
+	for len(vals) > 0 {
+		n := min(len(vals), 128)
+		b = append(b, byte(-n))
+		b = append(b, vals[:n]...)
+		vals = vals[n:]
+	}
+	return b
+}
+
+// bools encodes a boolean stream.
+func bools(vals ...bool) []byte {
+	var packed []byte
+	for i, v := range vals {
+		if i%8 == 0 {
+			packed = append(packed, 0)
+		}
+		if v {
+			packed[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return byteLiterals(packed...)
+}
+
+// ints encodes integers with version 1 run length encoding, as runs of
+// literals.
+func ints(signed bool, vals ...int64) []byte {
+	var b []byte
+	for len(vals) > 0 {
+		n := min(len(vals), 128)
+		b = append(b, byte(-n))
+		for _, v := range vals[:n] {
+			if signed {
+				b = binary.AppendVarint(b, v)
+			} else {
+				b = binary.AppendUvarint(b, uint64(v))
+			}
+		}
+		vals = vals[n:]
+	}
+	return b
+}
+
+// The types of the test file, in pre-order.
+var testTypes = []orcType{
+	{kind: kindStruct, subtypes: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 14, 17, 19, 22}, fieldNames: []string{
+		"id", "name", "price", "ok", "tiny", "f", "d", "day", "ts", "tsi", "data", "tags", "attrs", "nested", "u", "code",
+	}},
+	{kind: kindLong},
+	{kind: kindString},
+	{kind: kindDecimal, precision: 10, scale: 2},
+	{kind: kindBoolean},
+	{kind: kindByte},
+	{kind: kindFloat},
+	{kind: kindDouble},
+	{kind: kindDate},
+	{kind: kindTimestamp},
+	{kind: kindTimestampInstant},
+	{kind: kindBinary},
+	{kind: kindList, subtypes: []int{13}},
+	{kind: kindString},
+	{kind: kindMap, subtypes: []int{15, 16}},
+	{kind: kindString},
+	{kind: kindInt},
+	{kind: kindStruct, subtypes: []int{18}, fieldNames: []string{"x"}},
+	{kind: kindInt},
+	{kind: kindUnion, subtypes: []int{20, 21}},
+	{kind: kindInt},
+	{kind: kindString},
+	{kind: kindVarchar, maxLength: 8},
+}
+
+var (
+	day = civil.Date{Year: 2024, Month: 1, Day: 2}
+	// ts is a timestamp in the time zone of the writer.
+	ts = civil.DateTime{Date: day, Time: civil.Time{Hour: 3, Minute: 4, Second: 5, Nanosecond: 500000000}}
+	// tsi is a timestamp with local time zone.
+	tsi = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+// testStripes returns the stripes of the test file, each with a row with
+// values in all columns, and a row with null values in most columns.
+func testStripes(t *testing.T, ids ...int64) []testStripe {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	tsSecs := ts.In(ny).Unix() - time.Date(2015, 1, 1, 0, 0, 0, 0, ny).Unix()
+	tsiSecs := tsi.Unix() - epoch.Unix()
+	f := make([]byte, 4)
+	binary.LittleEndian.PutUint32(f, math.Float32bits(1.5))
+	d := make([]byte, 8)
+	binary.LittleEndian.PutUint64(d, math.Float64bits(2.25))
+	present := bools(true, false)
+	var stripes []testStripe
+	for _, id := range ids {
+		stripes = append(stripes, testStripe{
+			numRows:   2,
+			timezone:  "America/New_York",
+			encodings: map[int]columnEncoding{22: {kind: encodingDictionary, dictionarySize: 1}},
+			streams: []testStream{
+				{column: 1, kind: streamData, data: ints(true, id, id+1)},
+				{column: 2, kind: streamPresent, data: present},
+				{column: 2, kind: streamData, data: []byte("apple")},
+				{column: 2, kind: streamLength, data: ints(false, 5)},
+				{column: 3, kind: streamPresent, data: present},
+				{column: 3, kind: streamData, data: []byte{0xc4, 0x13}},
+				{column: 3, kind: streamSecondary, data: ints(true, 2)},
+				{column: 4, kind: streamPresent, data: present},
+				{column: 4, kind: streamData, data: bools(true)},
+				{column: 5, kind: streamPresent, data: present},
+				{column: 5, kind: streamData, data: byteLiterals(0xff)},
+				{column: 6, kind: streamPresent, data: present},
+				{column: 6, kind: streamData, data: f},
+				{column: 7, kind: streamPresent, data: present},
+				{column: 7, kind: streamData, data: d},
+				{column: 8, kind: streamPresent, data: present},
+				{column: 8, kind: streamData, data: ints(true, 19724)},
+				{column: 9, kind: streamPresent, data: present},
+				{column: 9, kind: streamData, data: ints(true, tsSecs)},
+				// 5 with 8 trailing zeros.
+				{column: 9, kind: streamSecondary, data: ints(false, 5<<3|7)},
+				{column: 10, kind: streamPresent, data: present},
+				{column: 10, kind: streamData, data: ints(true, tsiSecs)},
+				{column: 10, kind: streamSecondary, data: ints(false, 0)},
+				{column: 11, kind: streamPresent, data: present},
+				{column: 11, kind: streamData, data: []byte{1, 2, 3}},
+				{column: 11, kind: streamLength, data: ints(false, 3)},
+				{column: 12, kind: streamLength, data: ints(false, 2, 0)},
+				{column: 13, kind: streamData, data: []byte("ab")},
+				{column: 13, kind: streamLength, data: ints(false, 1, 1)},
+				{column: 14, kind: streamPresent, data: present},
+				{column: 14, kind: streamLength, data: ints(false, 1)},
+				{column: 15, kind: streamData, data: []byte("k")},
+				{column: 15, kind: streamLength, data: ints(false, 1)},
+				{column: 16, kind: streamData, data: ints(true, 7)},
+				{column: 18, kind: streamPresent, data: present},
+				{column: 18, kind: streamData, data: ints(true, 3)},
+				{column: 19, kind: streamData, data: byteLiterals(1, 0)},
+				{column: 20, kind: streamData, data: ints(true, 5)},
+				{column: 21, kind: streamData, data: []byte("s")},
+				{column: 21, kind: streamLength, data: ints(false, 1)},
+				{column: 22, kind: streamData, data: ints(false, 0, 0)},
+				{column: 22, kind: streamDictionaryData, data: []byte("AB")},
+				{column: 22, kind: streamLength, data: ints(false, 2)},
+			},
+		})
+	}
+	return stripes
+}
+
+// testRows returns the rows of the stripes of testStripes.
+func testRows(ids ...int64) []map[string]interface{} {
+	var rows []map[string]interface{}
+	for _, id := range ids {
+		rows = append(rows, map[string]interface{}{
+			"id": id, "name": "apple", "price": big.NewRat(25, 2), "ok": true, "tiny": int64(-1),
+			"f": float32(1.5), "d": 2.25, "day": day, "ts": ts, "tsi": tsi, "data": []byte{1, 2, 3},
+			"tags": []interface{}{"a", "b"}, "attrs": map[string]interface{}{"k": int64(7)},
+			"nested": map[string]interface{}{"x": int64(3)}, "u": "s", "code": "AB",
+		}, map[string]interface{}{
+			"id": id + 1, "name": nil, "price": nil, "ok": nil, "tiny": nil,
+			"f": nil, "d": nil, "day": nil, "ts": nil, "tsi": nil, "data": nil,
+			"tags": []interface{}{}, "attrs": nil,
+			"nested": map[string]interface{}{"x": nil}, "u": int64(5), "code": "AB",
+		})
+	}
+	return rows
+}
+
+func readTestRows(t *testing.T, path string) []map[string]interface{} {
+	var rows []map[string]interface{}
+	err := readRows(context.Background(), path, func(row map[string]interface{}) {
+		rows = append(rows, row)
+	})
+	assert.Nil(t, err)
+	return rows
+}
+
+func TestReadRows(t *testing.T) {
+	for _, compression := range []compressionKind{compressionNone, compressionZlib, compressionSnappy, compressionLz4, compressionZstd} {
+		path := filepath.Join(t.TempDir(), "test.orc")
+		writeTestFile(t, path, compression, testTypes, testStripes(t, 1, 3)...)
+		assert.Equal(t, testRows(1, 3), readTestRows(t, path), compression)
+	}
+}
+
+func TestReadRowsV2(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.orc")
+	types := []orcType{{kind: kindStruct, subtypes: []int{1}, fieldNames: []string{"n"}}, {kind: kindLong}}
+	writeTestFile(t, path, compressionZlib, types, testStripe{
+		numRows:   4,
+		encodings: map[int]columnEncoding{1: {kind: encodingDirectV2}},
+		streams: []testStream{
+			// A delta run of 10, 8, 6, 4.
+			{column: 1, kind: streamData, data: []byte{0xc0, 0x03, 0x14, 0x03}},
+		},
+	})
+	var vals []interface{}
+	for _, row := range readTestRows(t, path) {
+		vals = append(vals, row["n"])
+	}
+	assert.Equal(t, []interface{}{int64(10), int64(8), int64(6), int64(4)}, vals)
+}
+
+func TestOpenFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.orc")
+	writeTestFile(t, path, compressionSnappy, testTypes, testStripes(t, 1, 3)...)
+	f, err := openFile(context.Background(), path)
+	assert.Nil(t, err)
+	assert.Equal(t, testTypes, f.types)
+	assert.Equal(t, int64(4), f.numRows)
+	assert.Equal(t, 2, len(f.stripes))
+	assert.Nil(t, f.Close())
+
+	// Not ORC files.
+	for _, data := range [][]byte{{}, []byte("not an orc file")} {
+		path := filepath.Join(dir, "invalid.orc")
+		assert.Nil(t, os.WriteFile(path, data, 0644))
+		_, err := openFile(context.Background(), path)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,443 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"encoding/binary"
+	"io"
+	"math/big"
+)
+
+// The decoders of the run length encodings of ORC streams, see
+// https://orc.apache.org/specification/ORCv1/#run-length-encoding.
+
+// byteRLE decodes byte run length encoded streams.
+type byteRLE struct {
+	r       io.ByteReader
+	literal bool // Whether the current run is a run of literals.
+	n       int  // Values left in the current run.
+	val     byte // Value of the current run of repeated values.
+}
+
+func (d *byteRLE) next() (byte, error) {
+	if d.n == 0 {
+		h, err := d.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if int8(h) >= 0 {
+			d.literal, d.n = false, int(h)+3
+			if d.val, err = d.r.ReadByte(); err != nil {
+				return 0, err
+			}
+		} else {
+			d.literal, d.n = true, -int(int8(h))
+		}
+	}
+	d.n--
+	if d.literal {
+		return d.r.ReadByte()
+	}
+	return d.val, nil
+}
+
+// boolRLE decodes boolean streams: byte run length encoded bytes of 8
+// values each, from their most significant bit.
+type boolRLE struct {
+	bytes byteRLE
+	b     byte
+	n     int // Values left in b.
+}
+
+func newBoolRLE(r io.ByteReader) *boolRLE {
+	return &boolRLE{bytes: byteRLE{r: r}}
+}
+
+func (d *boolRLE) next() (bool, error) {
+	if d.n == 0 {
+		b, err := d.bytes.next()
+		if err != nil {
+			return false, err
+		}
+		d.b, d.n = b, 8
+	}
+	d.n--
+	return d.b&(1<<d.n) != 0, nil
+}
+
+// intDecoder decodes integer streams. Unsigned values are returned as
+// int64 values with the same bits.
+type intDecoder interface {
+	next() (int64, error)
+}
+
+func newIntDecoder(r io.ByteReader, encoding encodingKind, signed bool) intDecoder {
+	if encoding == encodingDirectV2 || encoding == encodingDictionaryV2 {
+		return &intRLEv2{r: r, signed: signed}
+	}
+	return &intRLEv1{r: r, signed: signed}
+}
+
+// intRLEv1 decodes version 1 run length encoded integers, used by the
+// DIRECT and DICTIONARY encodings.
+type intRLEv1 struct {
+	r       io.ByteReader
+	signed  bool
+	literal bool // Whether the current run is a run of literals.
+	n       int  // Values left in the current run.
+	val     int64
+	delta   int64
+}
+
+func (d *intRLEv1) next() (int64, error) {
+	if d.n == 0 {
+		h, err := d.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if int8(h) >= 0 {
+			d.literal, d.n = false, int(h)+3
+			delta, err := d.r.ReadByte()
+			if err != nil {
+				return 0, err
+			}
+			d.delta = int64(int8(delta))
+			if d.val, err = readVarint(d.r, d.signed); err != nil {
+				return 0, err
+			}
+		} else {
+			d.literal, d.n = true, -int(int8(h))
+		}
+	}
+	d.n--
+	if d.literal {
+		return readVarint(d.r, d.signed)
+	}
+	v := d.val
+	d.val += d.delta
+	return v, nil
+}
+
+// intRLEv2 decodes version 2 run length encoded integers, used by the
+// DIRECT_V2 and DICTIONARY_V2 encodings. Runs are decoded as a whole.
+type intRLEv2 struct {
+	r      io.ByteReader
+	signed bool
+	vals   []int64 // Values of the current run.
+	pos    int
+}
+
+func (d *intRLEv2) next() (int64, error) {
+	if d.pos == len(d.vals) {
+		if err := d.readRun(); err != nil {
+			return 0, err
+		}
+	}
+	v := d.vals[d.pos]
+	d.pos++
+	return v, nil
+}
+
+func (d *intRLEv2) readRun() error {
+	h, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	d.vals, d.pos = d.vals[:0], 0
+	switch h >> 6 {
+	case 0:
+		return d.readShortRepeat(h)
+	case 1:
+		return d.readDirect(h)
+	case 2:
+		return d.readPatchedBase(h)
+	default:
+		return d.readDelta(h)
+	}
+}
+
+// readShortRepeat reads a run of 3 to 10 repeated values.
+func (d *intRLEv2) readShortRepeat(h byte) error {
+	width := int(h>>3&7) + 1
+	count := int(h&7) + 3
+	u, err := readBigEndian(d.r, width)
+	if err != nil {
+		return err
+	}
+	v := int64(u)
+	if d.signed {
+		v = unzigzag(u)
+	}
+	for i := 0; i < count; i++ {
+		d.vals = append(d.vals, v)
+	}
+	return nil
+}
+
+// readDirect reads a run of bit packed values.
+func (d *intRLEv2) readDirect(h byte) error {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	width := decodeWidth(h >> 1 & 0x1f)
+	vals, err := readPacked(d.r, runLength(h, b), width)
+	if err != nil {
+		return err
+	}
+	for _, u := range vals {
+		v := int64(u)
+		if d.signed {
+			v = unzigzag(u)
+		}
+		d.vals = append(d.vals, v)
+	}
+	return nil
+}
+
+// readPatchedBase reads a run of bit packed offsets from a base value,
+// where the high bits of the largest offsets are in a patch list.
+func (d *intRLEv2) readPatchedBase(h byte) error {
+	var hdr [3]byte
+	for i := range hdr {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		hdr[i] = b
+	}
+	width := decodeWidth(h >> 1 & 0x1f)
+	n := runLength(h, hdr[0])
+	baseWidth := int(hdr[1]>>5&7) + 1
+	patchWidth := decodeWidth(hdr[1] & 0x1f)
+	gapWidth := int(hdr[2]>>5&7) + 1
+	patchLen := int(hdr[2] & 0x1f)
+	// The base value is in sign-magnitude representation.
+	u, err := readBigEndian(d.r, baseWidth)
+	if err != nil {
+		return err
+	}
+	signBit := uint64(1) << (baseWidth*8 - 1)
+	base := int64(u &^ signBit)
+	if u&signBit != 0 {
+		base = -base
+	}
+	vals, err := readPacked(d.r, n, width)
+	if err != nil {
+		return err
+	}
+	patches, err := readPacked(d.r, patchLen, closestFixedBits(gapWidth+patchWidth))
+	if err != nil {
+		return err
+	}
+	// Patches are at gaps from the previous patch. Gaps larger than 255 are
+	// split in gaps of 255 without patches.
+	i := 0
+	for _, p := range patches {
+		gap := int(p >> patchWidth)
+		patch := p & (1<<patchWidth - 1)
+		i += gap
+		if patch == 0 {
+			continue
+		}
+		if i < len(vals) {
+			vals[i] |= patch << width
+		}
+	}
+	for _, u := range vals {
+		d.vals = append(d.vals, base+int64(u))
+	}
+	return nil
+}
+
+// readDelta reads a run of values from a base value and deltas: either a
+// fixed delta, or the bit packed absolute values of deltas with the sign
+// of the first delta.
+func (d *intRLEv2) readDelta(h byte) error {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	width := 0
+	if code := h >> 1 & 0x1f; code != 0 {
+		width = decodeWidth(code)
+	}
+	n := runLength(h, b)
+	base, err := readVarint(d.r, d.signed)
+	if err != nil {
+		return err
+	}
+	deltaBase, err := readVarint(d.r, true)
+	if err != nil {
+		return err
+	}
+	d.vals = append(d.vals, base)
+	if n == 1 {
+		return nil
+	}
+	d.vals = append(d.vals, base+deltaBase)
+	if width == 0 {
+		for i := 2; i < n; i++ {
+			d.vals = append(d.vals, d.vals[i-1]+deltaBase)
+		}
+		return nil
+	}
+	deltas, err := readPacked(d.r, n-2, width)
+	if err != nil {
+		return err
+	}
+	for i, delta := range deltas {
+		prev := d.vals[i+1]
+		if deltaBase < 0 {
+			d.vals = append(d.vals, prev-int64(delta))
+		} else {
+			d.vals = append(d.vals, prev+int64(delta))
+		}
+	}
+	return nil
+}
+
+// runLength returns the length of a run, from its 9 bit field in the first
+// two bytes of its header.
+func runLength(h, b byte) int {
+	return (int(h&1)<<8 | int(b)) + 1
+}
+
+// decodeWidth returns the bit width encoded by a 5 bit code.
+func decodeWidth(code byte) int {
+	switch {
+	case code <= 23:
+		return int(code) + 1
+	case code == 24:
+		return 26
+	case code == 25:
+		return 28
+	case code == 26:
+		return 30
+	case code == 27:
+		return 32
+	case code == 28:
+		return 40
+	case code == 29:
+		return 48
+	case code == 30:
+		return 56
+	}
+	return 64
+}
+
+// closestFixedBits returns the smallest bit width with a 5 bit code that
+// is at least n.
+func closestFixedBits(n int) int {
+	switch {
+	case n == 0:
+		return 1
+	case n <= 24:
+		return n
+	case n <= 26:
+		return 26
+	case n <= 28:
+		return 28
+	case n <= 30:
+		return 30
+	case n <= 32:
+		return 32
+	case n <= 40:
+		return 40
+	case n <= 48:
+		return 48
+	case n <= 56:
+		return 56
+	}
+	return 64
+}
+
+// readPacked reads n values of width bits, packed from the most
+// significant bit of the first byte. The last byte is padded.
+func readPacked(r io.ByteReader, n, width int) ([]uint64, error) {
+	vals := make([]uint64, n)
+	var cur uint64
+	left := 0 // Bits left in cur.
+	for i := range vals {
+		var v uint64
+		for need := width; need > 0; {
+			if left == 0 {
+				b, err := r.ReadByte()
+				if err != nil {
+					return nil, err
+				}
+				cur, left = uint64(b), 8
+			}
+			take := min(need, left)
+			v = v<<take | cur>>(left-take)&(1<<take-1)
+			left -= take
+			need -= take
+		}
+		vals[i] = v
+	}
+	return vals, nil
+}
+
+// readBigEndian reads an unsigned integer of n bytes, most significant
+// byte first.
+func readBigEndian(r io.ByteReader, n int) (uint64, error) {
+	var u uint64
+	for i := 0; i < n; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		u = u<<8 | uint64(b)
+	}
+	return u, nil
+}
+
+// readVarint reads a base 128 varint, zigzag encoded if signed.
+func readVarint(r io.ByteReader, signed bool) (int64, error) {
+	u, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if signed {
+		return unzigzag(u), nil
+	}
+	return int64(u), nil
+}
+
+// readBigVarint reads a zigzag encoded base 128 varint of any size, as
+// used for the unscaled values of decimals.
+func readBigVarint(r io.ByteReader) (*big.Int, error) {
+	n := new(big.Int)
+	for shift := uint(0); ; shift += 7 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		n.Or(n, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), shift))
+		if b < 0x80 {
+			break
+		}
+	}
+	neg := n.Bit(0) == 1
+	n.Rsh(n, 1)
+	if neg {
+		n.Neg(n).Sub(n, big.NewInt(1))
+	}
+	return n, nil
+}
+
+func unzigzag(u uint64) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
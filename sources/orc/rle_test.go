@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The examples are those of the ORC specification.
+
+func TestByteRLE(t *testing.T) {
+	d := byteRLE{r: bytes.NewReader([]byte{0x61, 0x00, 0xfe, 0x44, 0x45})}
+	for i := 0; i < 100; i++ {
+		b, err := d.next()
+		assert.Nil(t, err)
+		assert.Equal(t, byte(0), b)
+	}
+	for _, want := range []byte{0x44, 0x45} {
+		b, err := d.next()
+		assert.Nil(t, err)
+		assert.Equal(t, want, b)
+	}
+	_, err := d.next()
+	assert.NotNil(t, err)
+}
+
+func TestBoolRLE(t *testing.T) {
+	d := newBoolRLE(bytes.NewReader([]byte{0xff, 0x80}))
+	for i, want := range []bool{true, false, false, false, false, false, false, false} {
+		b, err := d.next()
+		assert.Nil(t, err)
+		assert.Equal(t, want, b, i)
+	}
+}
+
+func readInts(t *testing.T, d intDecoder, n int) []int64 {
+	var vals []int64
+	for i := 0; i < n; i++ {
+		v, err := d.next()
+		assert.Nil(t, err)
+		vals = append(vals, v)
+	}
+	_, err := d.next()
+	assert.NotNil(t, err)
+	return vals
+}
+
+func TestIntRLEv1(t *testing.T) {
+	d := newIntDecoder(bytes.NewReader([]byte{0x61, 0x00, 0x07, 0x61, 0xff, 0x64, 0xfb, 0x02, 0x03, 0x04, 0x07, 0x0b}), encodingDirect, false)
+	vals := readInts(t, d, 205)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, int64(7), vals[i])
+		assert.Equal(t, int64(100-i), vals[100+i])
+	}
+	assert.Equal(t, []int64{2, 3, 4, 7, 11}, vals[200:])
+
+	// Signed values are zigzag encoded.
+	d = newIntDecoder(bytes.NewReader([]byte{0xfe, 0x03, 0x04}), encodingDirect, true)
+	assert.Equal(t, []int64{-2, 2}, readInts(t, d, 2))
+}
+
+func TestIntRLEv2(t *testing.T) {
+	testCases := []struct {
+		name   string
+		signed bool
+		data   []byte
+		want   []int64
+	}{
+		{
+			name: "short repeat",
+			data: []byte{0x0a, 0x27, 0x10},
+			want: []int64{10000, 10000, 10000, 10000, 10000},
+		},
+		{
+			name:   "short repeat signed",
+			signed: true,
+			data:   []byte{0x0a, 0x27, 0x0f},
+			want:   []int64{-5000, -5000, -5000, -5000, -5000},
+		},
+		{
+			name: "direct",
+			data: []byte{0x5e, 0x03, 0x5c, 0xa1, 0xab, 0x1e, 0xde, 0xad, 0xbe, 0xef},
+			want: []int64{23713, 43806, 57005, 48879},
+		},
+		{
+			name: "patched base",
+			data: []byte{
+				0x8e, 0x13, 0x2b, 0x21, 0x07, 0xd0, 0x1e, 0x00, 0x14, 0x70, 0x28, 0x32, 0x3c, 0x46, 0x50, 0x5a,
+				0x64, 0x6e, 0x78, 0x82, 0x8c, 0x96, 0xa0, 0xaa, 0xb4, 0xbe, 0xfc, 0xe8,
+			},
+			want: []int64{
+				2030, 2000, 2020, 1000000, 2040, 2050, 2060, 2070, 2080, 2090,
+				2100, 2110, 2120, 2130, 2140, 2150, 2160, 2170, 2180, 2190,
+			},
+		},
+		{
+			name: "delta",
+			data: []byte{0xc6, 0x09, 0x02, 0x02, 0x22, 0x42, 0x42, 0x46},
+			want: []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29},
+		},
+		{
+			name:   "fixed delta",
+			signed: true,
+			data:   []byte{0xc0, 0x03, 0x14, 0x03},
+			want:   []int64{10, 8, 6, 4},
+		},
+	}
+	for _, tc := range testCases {
+		d := newIntDecoder(bytes.NewReader(tc.data), encodingDirectV2, tc.signed)
+		assert.Equal(t, tc.want, readInts(t, d, len(tc.want)), tc.name)
+	}
+}
+
+func TestReadBigVarint(t *testing.T) {
+	testCases := []struct {
+		data []byte
+		want string
+	}{
+		{data: []byte{0x00}, want: "0"},
+		{data: []byte{0x01}, want: "-1"},
+		{data: []byte{0xc4, 0x13}, want: "1250"},
+		{data: []byte{0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, want: "9223372036854775807"},
+		{data: []byte{0x81, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, want: "-75557863725914323419137"},
+	}
+	for _, tc := range testCases {
+		n, err := readBigVarint(bytes.NewReader(tc.data))
+		assert.Nil(t, err)
+		want, _ := new(big.Int).SetString(tc.want, 10)
+		assert.Equal(t, want, n, tc.want)
+	}
+}
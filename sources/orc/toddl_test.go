@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpannerType(t *testing.T) {
+	array := []int64{-1}
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		isPk    bool
+		srcType schema.Type
+		want    ddl.Type
+		issues  []internal.SchemaIssue
+	}{
+		{name: "boolean", srcType: schema.Type{Name: typeBoolean}, want: ddl.Type{Name: ddl.Bool}},
+		{name: "tinyint", srcType: schema.Type{Name: typeTinyint}, want: ddl.Type{Name: ddl.Int64}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "int", srcType: schema.Type{Name: typeInt}, want: ddl.Type{Name: ddl.Int64}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "bigint", srcType: schema.Type{Name: typeBigint}, want: ddl.Type{Name: ddl.Int64}},
+		{name: "bigint to string", spType: ddl.String, srcType: schema.Type{Name: typeBigint}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "float", srcType: schema.Type{Name: typeFloat}, want: ddl.Type{Name: ddl.Float32}},
+		{name: "double", srcType: schema.Type{Name: typeDouble}, want: ddl.Type{Name: ddl.Float64}},
+		{name: "decimal", srcType: schema.Type{Name: typeDecimal, Mods: []int64{10, 2}}, want: ddl.Type{Name: ddl.Numeric}},
+		{name: "decimal pg", dialect: constants.DIALECT_POSTGRESQL, srcType: schema.Type{Name: typeDecimal, Mods: []int64{10, 2}}, want: ddl.Type{Name: ddl.Numeric, Precision: 10, Scale: 2}},
+		{name: "large decimal", srcType: schema.Type{Name: typeDecimal, Mods: []int64{38, 18}}, want: ddl.Type{Name: ddl.Numeric}, issues: []internal.SchemaIssue{internal.Decimal}},
+		{name: "string", srcType: schema.Type{Name: typeString}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{name: "varchar", srcType: schema.Type{Name: typeVarchar, Mods: []int64{20}}, want: ddl.Type{Name: ddl.String, Len: 20}},
+		{name: "char to bytes", spType: ddl.Bytes, srcType: schema.Type{Name: typeChar, Mods: []int64{2}}, want: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+		{name: "binary", srcType: schema.Type{Name: typeBinary}, want: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+		{name: "date", srcType: schema.Type{Name: typeDate}, want: ddl.Type{Name: ddl.Date}},
+		{name: "timestamp", srcType: schema.Type{Name: typeTimestamp}, want: ddl.Type{Name: ddl.Timestamp}, issues: []internal.SchemaIssue{internal.Datetime}},
+		{name: "timestamp with local time zone", srcType: schema.Type{Name: typeTimestampLocalTZ}, want: ddl.Type{Name: ddl.Timestamp}},
+		{name: "struct", srcType: schema.Type{Name: typeStruct}, want: ddl.Type{Name: ddl.JSON}},
+		{name: "uniontype to string", spType: ddl.String, srcType: schema.Type{Name: typeUnion}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{name: "array of bigints", srcType: schema.Type{Name: typeBigint, ArrayBounds: array}, want: ddl.Type{Name: ddl.Int64, IsArray: true}},
+		{name: "array of maps", srcType: schema.Type{Name: typeMap, ArrayBounds: array}, want: ddl.Type{Name: ddl.JSON}},
+		{name: "array of arrays", srcType: schema.Type{Name: typeBigint, ArrayBounds: []int64{-1, -1}}, want: ddl.Type{Name: ddl.JSON}, issues: []internal.SchemaIssue{internal.MultiDimensionalArray}},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		ty, issues := ToDdlImpl{}.ToSpannerType(conv, tc.spType, tc.srcType, tc.isPk)
+		assert.Equal(t, tc.want, ty, tc.name)
+		assert.Equal(t, tc.issues, issues, tc.name)
+	}
+}
+
+func TestToType(t *testing.T) {
+	types := []orcType{
+		{kind: kindStruct, subtypes: []int{1, 3}, fieldNames: []string{"matrix", "amount"}},
+		{kind: kindList, subtypes: []int{2}},
+		{kind: kindList, subtypes: []int{4}},
+		{kind: kindDecimal, precision: 12, scale: 2},
+		{kind: kindDouble},
+	}
+	assert.Equal(t, schema.Type{Name: typeStruct}, toType(types, 0))
+	assert.Equal(t, schema.Type{Name: typeDouble, ArrayBounds: []int64{-1, -1}}, toType(types, 1))
+	assert.Equal(t, schema.Type{Name: typeDecimal, Mods: []int64{12, 2}}, toType(types, 3))
+}
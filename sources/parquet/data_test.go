@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	pqschema "github.com/apache/arrow/go/v12/parquet/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestColumnValue(t *testing.T) {
+	mem := memory.DefaultAllocator
+	bin := array.NewBinaryBuilder(mem, arrow.BinaryTypes.Binary)
+	bin.Append([]byte(`{"a": 1}`))
+	bin.AppendNull()
+	binArr := bin.NewArray()
+	defer binArr.Release()
+	assert.Equal(t, json.RawMessage(`{"a": 1}`), columnValue(binArr, 0, pqschema.JSONLogicalType{}))
+	assert.Equal(t, []byte(`{"a": 1}`), columnValue(binArr, 0, nil))
+	assert.Nil(t, columnValue(binArr, 1, pqschema.JSONLogicalType{}))
+
+	fixed := array.NewFixedSizeBinaryBuilder(mem, &arrow.FixedSizeBinaryType{ByteWidth: 16})
+	fixed.Append([]byte{0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78, 0x12, 0x34, 0x56, 0x78})
+	fixedArr := fixed.NewArray()
+	defer fixedArr.Release()
+	assert.Equal(t, "12345678-1234-5678-1234-567812345678", columnValue(fixedArr, 0, pqschema.UUIDLogicalType{}))
+
+	ts := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"})
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ts.Append(arrow.Timestamp(at.UnixMilli()))
+	tsArr := ts.NewArray()
+	defer tsArr.Release()
+	assert.Equal(t, at, columnValue(tsArr, 0, pqschema.NewTimestampLogicalType(true, pqschema.TimeUnitMillis)))
+	assert.Equal(t, civil.DateTimeOf(at), columnValue(tsArr, 0, pqschema.NewTimestampLogicalType(false, pqschema.TimeUnitMillis)))
+}
+
+func TestValue_Map(t *testing.T) {
+	b := array.NewMapBuilder(memory.DefaultAllocator, arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32, false)
+	defer b.Release()
+	b.Append(true)
+	b.KeyBuilder().(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	b.ItemBuilder().(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	arr := b.NewArray()
+	defer arr.Release()
+	assert.Equal(t, map[string]interface{}{"a": int64(1), "b": int64(2)}, value(arr, 0))
+}
+
+func TestConvScalar(t *testing.T) {
+	local := civil.DateTime{Date: civil.Date{Year: 2024, Month: 1, Day: 2}, Time: civil.Time{Hour: 3}}
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		in      interface{}
+		want    interface{}
+	}{
+		{name: "bool", spType: ddl.Bool, in: true, want: true},
+		{name: "bytes", spType: ddl.Bytes, in: []byte{0xca, 0xfe}, want: []byte{0xca, 0xfe}},
+		{name: "float32", spType: ddl.Float32, in: float32(0.1), want: float32(0.1)},
+		{name: "float32 to float64", spType: ddl.Float64, in: float32(0.1), want: 0.1},
+		{name: "uint64", spType: ddl.Numeric, in: uint64(18446744073709551615), want: new(big.Rat).SetInt(new(big.Int).SetUint64(18446744073709551615))},
+		{name: "uint64 to int64", spType: ddl.Int64, in: uint64(42), want: int64(42)},
+		{name: "numeric pg", dialect: constants.DIALECT_POSTGRESQL, spType: ddl.Numeric, in: big.NewRat(25, 2), want: spanner.PGNumeric{Numeric: "12.5", Valid: true}},
+		{name: "datetime", spType: ddl.Timestamp, in: local, want: time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{name: "time to string", spType: ddl.String, in: civil.Time{Hour: 3, Minute: 4, Second: 5}, want: "03:04:05"},
+		{name: "uint64 to string", spType: ddl.String, in: uint64(18446744073709551615), want: "18446744073709551615"},
+		{name: "json", spType: ddl.JSON, in: json.RawMessage(`{"a": [1, 2]}`), want: `{"a": [1, 2]}`},
+		{name: "struct", spType: ddl.JSON, in: map[string]interface{}{"n": big.NewRat(5, 4), "b": []byte{0xca, 0xfe}, "l": []interface{}{int64(1), nil}}, want: `{"b":"yv4=","l":[1,null],"n":1.25}`},
+		{name: "list of lists", spType: ddl.JSON, in: []interface{}{[]interface{}{int64(1)}, []interface{}{}}, want: `[[1],[]]`},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		got, err := convScalar(conv, tc.in, tc.spType)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+	_, err := convScalar(internal.MakeConv(), uint64(18446744073709551615), ddl.Int64)
+	assert.NotNil(t, err)
+}
+
+func TestConvArray(t *testing.T) {
+	conv := internal.MakeConv()
+	got, err := convArray(conv, []interface{}{float32(1.5), nil}, ddl.Float32)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullFloat32{{Float32: 1.5, Valid: true}, {}}, got)
+	got, err = convArray(conv, []interface{}{big.NewRat(1, 2)}, ddl.Numeric)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullNumeric{{Numeric: *big.NewRat(1, 2), Valid: true}}, got)
+	got, err = convArray(conv, []interface{}{civil.Date{Year: 2024, Month: 1, Day: 2}}, ddl.Date)
+	assert.Nil(t, err)
+	assert.Equal(t, []spanner.NullDate{{Date: civil.Date{Year: 2024, Month: 1, Day: 2}, Valid: true}}, got)
+	_, err = convArray(conv, []interface{}{"abc"}, ddl.Int64)
+	assert.NotNil(t, err)
+}
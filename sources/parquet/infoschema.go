@@ -0,0 +1,366 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parquet handles schema and data migrations from Parquet files.
+// Each table is read from a set of files, found under a local directory or
+// GCS path or listed in a manifest (see common.GetFileSets). The schema of
+// a table is the schema of the first file of its set, and the columns of
+// the other files are matched to it by name.
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	pqschema "github.com/apache/arrow/go/v12/parquet/schema"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Extension is the extension of Parquet files.
+const Extension = ".parquet"
+
+// Source types are named after the Parquet logical types, or physical types
+// for columns without a logical type.
+const (
+	typeBoolean      = "BOOLEAN"
+	typeInt8         = "INT8"
+	typeInt16        = "INT16"
+	typeInt32        = "INT32"
+	typeInt64        = "INT64"
+	typeUint8        = "UINT8"
+	typeUint16       = "UINT16"
+	typeUint32       = "UINT32"
+	typeUint64       = "UINT64"
+	typeFloat        = "FLOAT"
+	typeDouble       = "DOUBLE"
+	typeDecimal      = "DECIMAL"
+	typeString       = "STRING"
+	typeBinary       = "BINARY"
+	typeUUID         = "UUID"
+	typeJSON         = "JSON"
+	typeDate         = "DATE"
+	typeTime         = "TIME"
+	typeTimestamp    = "TIMESTAMP"
+	typeTimestampNTZ = "TIMESTAMP_NTZ" // Timestamps not adjusted to UTC.
+	typeStruct       = "STRUCT"
+	typeMap          = "MAP"
+)
+
+// batchSize is the number of rows read from files at a time.
+const batchSize = 1024
+
+// InfoSchemaImpl is the Parquet specific implementation of InfoSchema.
+type InfoSchemaImpl struct {
+	FileSets []common.FileSet
+}
+
+// GetToDdl implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: files can only be migrated with
+// bulk migrations.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for Parquet files")
+}
+
+// StartStreamingMigration is not supported: files can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for Parquet files")
+}
+
+// GetTableName returns table name. Tables have no schema.
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	return tableName
+}
+
+// GetTables returns a table for each file set.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	var tables []common.SchemaAndName
+	for _, fs := range isi.FileSets {
+		tables = append(tables, common.SchemaAndName{Name: fs.Table})
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names, read from the
+// schema of the first file of the table. Required fields are NOT NULL.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	files, err := isi.getFiles(table.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields, err := readFields(context.Background(), files[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't read schema of table %s from %s: %w", table.Name, files[0], err)
+	}
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	for _, f := range fields {
+		colId := internal.GenerateColumnId()
+		colDefs[colId] = schema.Column{
+			Id:      colId,
+			Name:    f.Name,
+			Type:    f.Type,
+			NotNull: !f.Nullable,
+		}
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetRowsFromTable is not used: data is read from files by ProcessData.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
+	return nil, fmt.Errorf("data of Parquet files is read by ProcessData")
+}
+
+// GetRowCount returns the number of rows of a table, from the metadata of
+// its files.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	files, err := isi.getFiles(table.Name)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, path := range files {
+		pf, err := openFile(context.Background(), path)
+		if err != nil {
+			return 0, err
+		}
+		count += pf.NumRows()
+		pf.Close()
+	}
+	return count, nil
+}
+
+// GetConstraints returns no constraints: Parquet files have no primary
+// keys, so tables get a synthetic primary key.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	return nil, nil, make(map[string][]string), nil
+}
+
+// GetForeignKeys returns no foreign keys: Parquet files have none.
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	return nil, nil
+}
+
+// GetIndexes returns no indexes: Parquet files have none.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return nil, nil
+}
+
+// ProcessData reads the rows of the files of a table, converts them to
+// Spanner data (based on the source and Spanner schemas) and writes them
+// to Spanner. Columns missing from a file are NULL, and columns of files
+// that aren't in the source schema are skipped.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	files, err := isi.getFiles(srcSchema.Name)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	ctx := context.Background()
+	for _, path := range files {
+		err := readRows(ctx, path, func(row map[string]interface{}) {
+			ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, row)
+		})
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read file %s of table %s : err = %s", path, srcSchema.Name, err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (isi InfoSchemaImpl) getFiles(tableName string) ([]string, error) {
+	for _, fs := range isi.FileSets {
+		if fs.Table == tableName && len(fs.Files) > 0 {
+			return fs.Files, nil
+		}
+	}
+	return nil, fmt.Errorf("no files found for table %s", tableName)
+}
+
+// field is a top-level field of the schema of a Parquet file.
+type field struct {
+	Name     string
+	Type     schema.Type
+	Nullable bool
+}
+
+// readFields returns the top-level fields of the Parquet file at path.
+func readFields(ctx context.Context, path string) ([]field, error) {
+	pf, err := openFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer pf.Close()
+	sc, err := pqarrow.FromParquet(pf.MetaData().Schema, nil, pf.MetaData().KeyValueMetadata())
+	if err != nil {
+		return nil, err
+	}
+	root := pf.MetaData().Schema.Root()
+	var fields []field
+	for i, f := range sc.Fields() {
+		fields = append(fields, field{Name: f.Name, Type: toType(f.Type, root.Field(i).LogicalType()), Nullable: f.Nullable})
+	}
+	return fields, nil
+}
+
+// readRows calls processRow with each row of the Parquet file at path,
+// keyed by column name. See value for the types of values.
+func readRows(ctx context.Context, path string, processRow func(row map[string]interface{})) error {
+	pf, err := openFile(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer pf.Close()
+	if pf.MetaData().Schema.NumColumns() == 0 {
+		return nil
+	}
+	mem := memory.DefaultAllocator
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: batchSize}, mem)
+	if err != nil {
+		return err
+	}
+	root := pf.MetaData().Schema.Root()
+	var logicalTypes []pqschema.LogicalType
+	for i := 0; i < root.NumFields(); i++ {
+		logicalTypes = append(logicalTypes, root.Field(i).LogicalType())
+	}
+	rr, err := fr.GetRecordReader(ctx, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer rr.Release()
+	for rr.Next() {
+		rec := rr.Record()
+		fields := rec.Schema().Fields()
+		for i := 0; i < int(rec.NumRows()); i++ {
+			row := make(map[string]interface{})
+			for j, f := range fields {
+				row[f.Name] = columnValue(rec.Column(j), i, logicalTypes[j])
+			}
+			processRow(row)
+		}
+	}
+	// The record reader reports the end of the file as an error.
+	if err := rr.Err(); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// openFile opens the local or GCS Parquet file at path.
+func openFile(ctx context.Context, path string) (*file.Reader, error) {
+	r, err := file_reader.OpenReaderAt(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	pf, err := file.NewParquetReader(r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return pf, nil
+}
+
+// toType maps the Arrow type of a field of a Parquet file, and the logical
+// type of its Parquet column, to a schema.Type. The mods of DECIMAL types
+// are their precision and scale, and those of fixed length BINARY types
+// their length. Lists are arrays of their elements, and lists of lists
+// multi-dimensional arrays.
+func toType(dt arrow.DataType, logicalType pqschema.LogicalType) schema.Type {
+	switch t := dt.(type) {
+	case *arrow.BooleanType:
+		return schema.Type{Name: typeBoolean}
+	case *arrow.Int8Type:
+		return schema.Type{Name: typeInt8}
+	case *arrow.Int16Type:
+		return schema.Type{Name: typeInt16}
+	case *arrow.Int32Type:
+		return schema.Type{Name: typeInt32}
+	case *arrow.Int64Type:
+		return schema.Type{Name: typeInt64}
+	case *arrow.Uint8Type:
+		return schema.Type{Name: typeUint8}
+	case *arrow.Uint16Type:
+		return schema.Type{Name: typeUint16}
+	case *arrow.Uint32Type:
+		return schema.Type{Name: typeUint32}
+	case *arrow.Uint64Type:
+		return schema.Type{Name: typeUint64}
+	case *arrow.Float32Type:
+		return schema.Type{Name: typeFloat}
+	case *arrow.Float64Type:
+		return schema.Type{Name: typeDouble}
+	case *arrow.Decimal128Type:
+		return schema.Type{Name: typeDecimal, Mods: []int64{int64(t.Precision), int64(t.Scale)}}
+	case *arrow.Decimal256Type:
+		return schema.Type{Name: typeDecimal, Mods: []int64{int64(t.Precision), int64(t.Scale)}}
+	case *arrow.StringType, *arrow.LargeStringType:
+		return schema.Type{Name: typeString}
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		// JSON and ENUM columns are read as binary values.
+		switch logicalType.(type) {
+		case pqschema.JSONLogicalType:
+			return schema.Type{Name: typeJSON}
+		case pqschema.EnumLogicalType:
+			return schema.Type{Name: typeString}
+		}
+		return schema.Type{Name: typeBinary}
+	case *arrow.FixedSizeBinaryType:
+		if _, ok := logicalType.(pqschema.UUIDLogicalType); ok {
+			return schema.Type{Name: typeUUID}
+		}
+		return schema.Type{Name: typeBinary, Mods: []int64{int64(t.ByteWidth)}}
+	case *arrow.Date32Type, *arrow.Date64Type:
+		return schema.Type{Name: typeDate}
+	case *arrow.Time32Type, *arrow.Time64Type:
+		return schema.Type{Name: typeTime}
+	case *arrow.TimestampType:
+		// Arrow reads all timestamps as UTC, so timestamps not adjusted to
+		// UTC are told apart by their logical type.
+		if lt, ok := logicalType.(*pqschema.TimestampLogicalType); ok && !lt.IsAdjustedToUTC() {
+			return schema.Type{Name: typeTimestampNTZ}
+		}
+		return schema.Type{Name: typeTimestamp}
+	case *arrow.MapType:
+		return schema.Type{Name: typeMap}
+	case *arrow.StructType:
+		return schema.Type{Name: typeStruct}
+	case *arrow.ListType:
+		elem := toType(t.Elem(), nil)
+		elem.ArrayBounds = append([]int64{-1}, elem.ArrayBounds...)
+		return elem
+	case *arrow.LargeListType:
+		elem := toType(t.Elem(), nil)
+		elem.ArrayBounds = append([]int64{-1}, elem.ArrayBounds...)
+		return elem
+	}
+	return schema.Type{Name: dt.Name()}
+}
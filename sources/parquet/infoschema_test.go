@@ -0,0 +1,264 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/decimal128"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	pqschema "github.com/apache/arrow/go/v12/parquet/schema"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+var created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+var ordersSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "amount", Type: &arrow.Decimal128Type{Precision: 12, Scale: 2}, Nullable: true},
+	{Name: "created", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, Nullable: true},
+	{Name: "score", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+	{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+	{Name: "shipping", Type: arrow.StructOf(arrow.Field{Name: "city", Type: arrow.BinaryTypes.String, Nullable: true}), Nullable: true},
+}, nil)
+
+// writeOrdersFile writes a Parquet file with schema ordersSchema and a row
+// for each id. Rows with odd ids have values, the others nulls.
+func writeOrdersFile(t *testing.T, path string, ids ...int64) {
+	b := array.NewRecordBuilder(memory.DefaultAllocator, ordersSchema)
+	defer b.Release()
+	for _, id := range ids {
+		b.Field(0).(*array.Int64Builder).Append(id)
+		if id%2 == 0 {
+			for i := 1; i < len(ordersSchema.Fields()); i++ {
+				b.Field(i).AppendNull()
+			}
+			continue
+		}
+		b.Field(1).(*array.Decimal128Builder).Append(decimal128.FromI64(1250))
+		b.Field(2).(*array.TimestampBuilder).Append(arrow.Timestamp(created.UnixMicro()))
+		b.Field(3).(*array.Float32Builder).Append(0.1)
+		tags := b.Field(4).(*array.ListBuilder)
+		tags.Append(true)
+		tags.ValueBuilder().(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+		shipping := b.Field(5).(*array.StructBuilder)
+		shipping.Append(true)
+		shipping.FieldBuilder(0).(*array.StringBuilder).Append("Paris")
+	}
+	writeFile(t, path, b.NewRecord())
+}
+
+func writeCustomersFile(t *testing.T, path string) {
+	sc := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "photo", Type: &arrow.FixedSizeBinaryType{ByteWidth: 4}, Nullable: true},
+		{Name: "visits", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		{Name: "birthday", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+	}, nil)
+	b := array.NewRecordBuilder(memory.DefaultAllocator, sc)
+	defer b.Release()
+	b.Field(0).(*array.Int32Builder).Append(1)
+	b.Field(1).(*array.StringBuilder).Append("Ann")
+	b.Field(2).(*array.FixedSizeBinaryBuilder).Append([]byte{1, 2, 3, 4})
+	b.Field(3).(*array.Uint64Builder).Append(18446744073709551615)
+	b.Field(4).(*array.Date32Builder).Append(arrow.Date32FromTime(created))
+	writeFile(t, path, b.NewRecord())
+}
+
+func writeFile(t *testing.T, path string, rec arrow.Record) {
+	defer rec.Release()
+	tbl := array.NewTableFromRecords(rec.Schema(), []arrow.Record{rec})
+	defer tbl.Release()
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+	f, err := os.Create(path)
+	assert.Nil(t, err)
+	defer f.Close()
+	assert.Nil(t, pqarrow.WriteTable(tbl, f, 1024, nil, pqarrow.DefaultWriterProps()))
+}
+
+// mkInfoSchema writes the files of the customers and orders tables to a
+// temporary directory, and returns an InfoSchemaImpl reading them.
+func mkInfoSchema(t *testing.T) InfoSchemaImpl {
+	dir := t.TempDir()
+	writeCustomersFile(t, filepath.Join(dir, "customers.parquet"))
+	writeOrdersFile(t, filepath.Join(dir, "orders", "part-00000.parquet"), 1, 2)
+	writeOrdersFile(t, filepath.Join(dir, "orders", "part-00001.parquet"), 3)
+	fileSets, err := common.GetFileSets(context.Background(), dir, "", Extension)
+	assert.Nil(t, err)
+	return InfoSchemaImpl{FileSets: fileSets}
+}
+
+func processSchema(t *testing.T, isi InfoSchemaImpl) *internal.Conv {
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	return conv
+}
+
+func TestProcessSchema(t *testing.T) {
+	conv := processSchema(t, mkInfoSchema(t))
+	expectedSchema := map[string]ddl.CreateTable{
+		"customers": {
+			Name:   "customers",
+			ColIds: []string{"id", "name", "photo", "visits", "birthday", "synth_id"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":       {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"name":     {Name: "name", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"photo":    {Name: "photo", T: ddl.Type{Name: ddl.Bytes, Len: 4}},
+				"visits":   {Name: "visits", T: ddl.Type{Name: ddl.Numeric}},
+				"birthday": {Name: "birthday", T: ddl.Type{Name: ddl.Date}},
+				"synth_id": {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
+		},
+		"orders": {
+			Name:   "orders",
+			ColIds: []string{"id", "amount", "created", "score", "tags", "shipping", "synth_id"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":       {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"amount":   {Name: "amount", T: ddl.Type{Name: ddl.Numeric}},
+				"created":  {Name: "created", T: ddl.Type{Name: ddl.Timestamp}},
+				"score":    {Name: "score", T: ddl.Type{Name: ddl.Float32}},
+				"tags":     {Name: "tags", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+				"shipping": {Name: "shipping", T: ddl.Type{Name: ddl.JSON}},
+				"synth_id": {Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "synth_id", Order: 1}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
+
+func TestToType(t *testing.T) {
+	testCases := []struct {
+		name        string
+		dt          arrow.DataType
+		logicalType pqschema.LogicalType
+		want        schema.Type
+	}{
+		{name: "int32", dt: arrow.PrimitiveTypes.Int32, want: schema.Type{Name: typeInt32}},
+		{name: "decimal", dt: &arrow.Decimal128Type{Precision: 12, Scale: 2}, want: schema.Type{Name: typeDecimal, Mods: []int64{12, 2}}},
+		{name: "binary", dt: arrow.BinaryTypes.Binary, want: schema.Type{Name: typeBinary}},
+		{name: "json", dt: arrow.BinaryTypes.Binary, logicalType: pqschema.JSONLogicalType{}, want: schema.Type{Name: typeJSON}},
+		{name: "enum", dt: arrow.BinaryTypes.Binary, logicalType: pqschema.EnumLogicalType{}, want: schema.Type{Name: typeString}},
+		{name: "fixed length binary", dt: &arrow.FixedSizeBinaryType{ByteWidth: 4}, want: schema.Type{Name: typeBinary, Mods: []int64{4}}},
+		{name: "uuid", dt: &arrow.FixedSizeBinaryType{ByteWidth: 16}, logicalType: pqschema.UUIDLogicalType{}, want: schema.Type{Name: typeUUID}},
+		{name: "timestamp", dt: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, logicalType: pqschema.NewTimestampLogicalType(true, pqschema.TimeUnitMicros), want: schema.Type{Name: typeTimestamp}},
+		{name: "timestamp ntz", dt: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, logicalType: pqschema.NewTimestampLogicalType(false, pqschema.TimeUnitMicros), want: schema.Type{Name: typeTimestampNTZ}},
+		{name: "time", dt: arrow.FixedWidthTypes.Time64us, want: schema.Type{Name: typeTime}},
+		{name: "map", dt: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int64), want: schema.Type{Name: typeMap}},
+		{name: "list", dt: arrow.ListOf(arrow.PrimitiveTypes.Int64), want: schema.Type{Name: typeInt64, ArrayBounds: []int64{-1}}},
+		{name: "list of lists", dt: arrow.ListOf(arrow.ListOf(arrow.PrimitiveTypes.Int64)), want: schema.Type{Name: typeInt64, ArrayBounds: []int64{-1, -1}}},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, toType(tc.dt, tc.logicalType), tc.name)
+	}
+}
+
+func TestGetRowCount(t *testing.T) {
+	isi := mkInfoSchema(t)
+	count, err := isi.GetRowCount(common.SchemaAndName{Name: "orders"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+	_, err = isi.GetRowCount(common.SchemaAndName{Name: "missing"})
+	assert.NotNil(t, err)
+}
+
+// processTableData migrates the data of the Spanner table spTableName and
+// returns the rows written, without their synthetic primary keys.
+func processTableData(t *testing.T, conv *internal.Conv, isi InfoSchemaImpl, spTableName string) []spannerData {
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols[:len(cols)-1], vals: vals[:len(vals)-1]})
+		})
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, spTableName)
+	assert.Nil(t, err)
+	colIds := conv.SpSchema[tableId].ColIds
+	err = isi.ProcessData(conv, tableId, conv.SrcSchema[tableId], colIds[:len(colIds)-1], conv.SpSchema[tableId], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	return rows
+}
+
+func TestProcessData(t *testing.T) {
+	isi := mkInfoSchema(t)
+	conv := processSchema(t, isi)
+	rows := processTableData(t, conv, isi, "orders")
+	cols := []string{"id", "amount", "created", "score", "tags", "shipping"}
+	full := func(id int64) spannerData {
+		return spannerData{
+			table: "orders",
+			cols:  cols,
+			vals: []interface{}{
+				id, big.NewRat(25, 2), created, float32(0.1),
+				[]spanner.NullString{{StringVal: "a", Valid: true}, {StringVal: "b", Valid: true}},
+				`{"city":"Paris"}`,
+			},
+		}
+	}
+	assert.Equal(t, []spannerData{
+		full(1),
+		{table: "orders", cols: cols, vals: []interface{}{int64(2), nil, nil, nil, nil, nil}},
+		full(3),
+	}, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+
+	rows = processTableData(t, conv, isi, "customers")
+	assert.Equal(t, []spannerData{
+		{
+			table: "customers",
+			cols:  []string{"id", "name", "photo", "visits", "birthday"},
+			vals: []interface{}{
+				int64(1), "Ann", []byte{1, 2, 3, 4}, new(big.Rat).SetInt(new(big.Int).SetUint64(18446744073709551615)),
+				civil.Date{Year: 2024, Month: 1, Day: 2},
+			},
+		},
+	}, rows)
+}
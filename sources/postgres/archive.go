@@ -0,0 +1,556 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+// This file reads pg_dump archives in the custom (-Fc) and directory (-Fd)
+// formats, and translates them into the plain-text SQL that pg_restore
+// would print for them, which processPgDump understands.
+//
+// An archive starts with a header and a table of contents (TOC), whose
+// entries hold the SQL definitions of the dumped objects and, for table
+// data, the COPY statement that loads it. Custom archives then hold the
+// data of each table in a block, while directory archives hold it in a
+// file per table next to the toc.dat file. See pg_backup_archiver.c,
+// pg_backup_custom.c and pg_backup_directory.c in the PostgreSQL sources.
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+var archiveMagic = []byte("PGDMP")
+
+// Formats of archives.
+const (
+	archCustom    = 1
+	archDirectory = 5
+)
+
+// Compression algorithms of archive data.
+const (
+	compressionNone = 0
+	compressionGzip = 1
+	compressionLZ4  = 2
+	compressionZstd = 3
+)
+
+// Sections of TOC entries.
+const (
+	sectionNone     = 1
+	sectionPreData  = 2
+	sectionData     = 3
+	sectionPostData = 4
+)
+
+// Types of the data blocks of custom archives.
+const (
+	blockData  = 1
+	blockBlobs = 3
+)
+
+// offsetNoData marks TOC entries of custom archives without a data block.
+const offsetNoData = 3
+
+// Archive versions whose format changed. Versions older than 1.12, written
+// by pg_dump before PostgreSQL 9.0, aren't supported.
+var (
+	archiveVersion1_12 = archiveVersion(1, 12)
+	archiveVersion1_14 = archiveVersion(1, 14) // Adds table access methods.
+	archiveVersion1_15 = archiveVersion(1, 15) // Adds compression algorithms.
+	archiveVersion1_16 = archiveVersion(1, 16) // Adds relation kinds.
+)
+
+func archiveVersion(major, minor byte) int {
+	return int(major)<<16 | int(minor)<<8
+}
+
+// IsArchive reports whether a dump starting with prefix is a pg_dump
+// archive rather than a plain-text dump.
+func IsArchive(prefix []byte) bool {
+	return bytes.HasPrefix(prefix, archiveMagic)
+}
+
+// NewDumpReader returns a reader of the plain-text dump read from r,
+// translating it if r is a custom format archive.
+func NewDumpReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	prefix, err := br.Peek(len(archiveMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if IsArchive(prefix) {
+		return NewArchiveReader(br)
+	}
+	return br, nil
+}
+
+// NewArchiveReader returns a reader of the plain-text dump equivalent to
+// the custom format archive read from r. The data blocks of the archive
+// are translated as they are read, so r needn't be seekable.
+func NewArchiveReader(r io.Reader) (io.Reader, error) {
+	a := &archive{r: bufio.NewReader(r)}
+	if err := a.readHeader(); err != nil {
+		return nil, err
+	}
+	if a.format != archCustom {
+		return nil, fmt.Errorf("pg_dump archive format %d is not supported, use the custom (-Fc) or directory (-Fd) format", a.format)
+	}
+	if err := a.readToc(); err != nil {
+		return nil, err
+	}
+	return a.translate(a.writeCustomData), nil
+}
+
+// NewDirectoryArchiveReader returns a reader of the plain-text dump
+// equivalent to the directory format archive in dir.
+func NewDirectoryArchiveReader(dir string) (io.Reader, error) {
+	f, err := os.Open(filepath.Join(dir, "toc.dat"))
+	if err != nil {
+		return nil, fmt.Errorf("can't open pg_dump directory archive: %v", err)
+	}
+	defer f.Close()
+	a := &archive{r: bufio.NewReader(f), dir: dir}
+	if err := a.readHeader(); err != nil {
+		return nil, err
+	}
+	if a.format != archDirectory {
+		return nil, fmt.Errorf("%s is not the TOC of a pg_dump directory archive", f.Name())
+	}
+	if err := a.readToc(); err != nil {
+		return nil, err
+	}
+	a.r = nil
+	return a.translate(a.writeDirectoryData), nil
+}
+
+type archive struct {
+	r           *bufio.Reader
+	dir         string // Directory of directory archives.
+	version     int
+	intSize     int
+	offSize     int
+	format      byte
+	compression byte
+	entries     []tocEntry
+}
+
+type tocEntry struct {
+	dumpId   int
+	desc     string
+	section  int
+	defn     string
+	copyStmt string
+	hasData  bool
+	filename string // Data file of directory archives.
+}
+
+func (a *archive) readHeader() error {
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(a.r, magic); err != nil || !IsArchive(magic) {
+		return fmt.Errorf("not a pg_dump archive")
+	}
+	b := make([]byte, 6)
+	if _, err := io.ReadFull(a.r, b); err != nil {
+		return fmt.Errorf("can't read pg_dump archive header: %v", err)
+	}
+	a.version = archiveVersion(b[0], b[1]) | int(b[2])
+	if a.version < archiveVersion1_12 || a.version >= archiveVersion(1, 17) {
+		return fmt.Errorf("pg_dump archive version %d.%d is not supported", b[0], b[1])
+	}
+	a.intSize, a.offSize, a.format = int(b[3]), int(b[4]), b[5]
+	if a.intSize < 1 || a.intSize > 8 || a.offSize < 1 || a.offSize > 8 {
+		return fmt.Errorf("invalid pg_dump archive header")
+	}
+	if a.version >= archiveVersion1_15 {
+		c, err := a.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		a.compression = c
+	} else {
+		// Older archives record a zlib compression level instead.
+		level, err := a.readInt()
+		if err != nil {
+			return err
+		}
+		if level != 0 {
+			a.compression = compressionGzip
+		}
+	}
+	if a.compression > compressionZstd {
+		return fmt.Errorf("pg_dump archive compression %d is not supported", a.compression)
+	}
+	// Skip the creation time, then the database name and the server and
+	// pg_dump versions.
+	for i := 0; i < 7; i++ {
+		if _, err := a.readInt(); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if _, _, err := a.readStr(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *archive) readToc() error {
+	n, err := a.readInt()
+	if err != nil {
+		return fmt.Errorf("can't read pg_dump archive TOC: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		e, err := a.readTocEntry()
+		if err != nil {
+			return fmt.Errorf("can't read pg_dump archive TOC: %v", err)
+		}
+		a.entries = append(a.entries, e)
+	}
+	return nil
+}
+
+func (a *archive) readTocEntry() (tocEntry, error) {
+	var e tocEntry
+	var err error
+	// skip reads the next string of the entry, which isn't needed.
+	skip := func() {
+		if err == nil {
+			_, _, err = a.readStr()
+		}
+	}
+	str := func(s *string) {
+		if err == nil {
+			*s, _, err = a.readStr()
+		}
+	}
+	integer := func(i *int) {
+		if err == nil {
+			*i, err = a.readInt()
+		}
+	}
+	var hadDumper, relkind int
+	integer(&e.dumpId)
+	integer(&hadDumper)
+	skip() // Table OID.
+	skip() // OID.
+	skip() // Tag.
+	str(&e.desc)
+	integer(&e.section)
+	str(&e.defn)
+	skip() // Drop statement.
+	str(&e.copyStmt)
+	skip() // Namespace.
+	skip() // Tablespace.
+	if a.version >= archiveVersion1_14 {
+		skip() // Table access method.
+	}
+	if a.version >= archiveVersion1_16 {
+		integer(&relkind)
+	}
+	skip() // Owner.
+	skip() // With OIDs.
+	if err != nil {
+		return e, err
+	}
+	// Dependencies are a list of strings ending with a null one.
+	for {
+		_, ok, err := a.readStr()
+		if err != nil {
+			return e, err
+		}
+		if !ok {
+			break
+		}
+	}
+	if a.format == archCustom {
+		flag, err := a.readOffset()
+		if err != nil {
+			return e, err
+		}
+		e.hasData = hadDumper != 0 && flag != offsetNoData
+	} else {
+		str(&e.filename)
+		e.hasData = hadDumper != 0 && e.filename != ""
+	}
+	return e, err
+}
+
+// readInt reads an integer, which is stored as a sign byte followed by
+// the intSize bytes of its absolute value, least significant first.
+func (a *archive) readInt() (int, error) {
+	b := make([]byte, 1+a.intSize)
+	if _, err := io.ReadFull(a.r, b); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	var v int
+	for i := a.intSize; i > 0; i-- {
+		v = v<<8 | int(b[i])
+	}
+	if b[0] != 0 {
+		v = -v
+	}
+	return v, nil
+}
+
+// readStr reads a string, which is stored as its length followed by its
+// bytes. The length of null strings is -1, for which ok is false.
+func (a *archive) readStr() (s string, ok bool, err error) {
+	n, err := a.readInt()
+	if err != nil || n < 0 {
+		return "", false, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(a.r, b); err != nil {
+		return "", false, unexpectedEOF(err)
+	}
+	return string(b), true, nil
+}
+
+// readOffset reads the data offset of a TOC entry of a custom archive and
+// returns its flag.
+func (a *archive) readOffset() (byte, error) {
+	b := make([]byte, 1+a.offSize)
+	if _, err := io.ReadFull(a.r, b); err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	return b[0], nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// translate returns a reader of the plain-text dump of the archive. The
+// definitions of the objects are written before and after the data like
+// pg_restore does, and writeData writes the data of the tables.
+func (a *archive) translate(writeData func(w io.Writer) error) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		w := bufio.NewWriter(pw)
+		err := a.writeDefinitions(w, sectionNone, sectionPreData)
+		if err == nil {
+			err = writeData(w)
+		}
+		if err == nil {
+			err = a.writeDefinitions(w, sectionData, sectionPostData)
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+func (a *archive) writeDefinitions(w io.Writer, sections ...int) error {
+	for _, e := range a.entries {
+		for _, section := range sections {
+			if e.section == section && e.defn != "" {
+				if _, err := io.WriteString(w, e.defn+"\n"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeTableData writes the data of the TOC entry e read from r, following
+// its COPY statement if it has one; the data of dumps made with --inserts
+// are INSERT statements instead.
+func writeTableData(w io.Writer, e tocEntry, r io.Reader) error {
+	if _, err := io.WriteString(w, e.copyStmt); err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return fmt.Errorf("can't read data of pg_dump archive entry %d: %v", e.dumpId, err)
+	}
+	if e.copyStmt != "" {
+		if _, err := io.WriteString(w, "\\.\n\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCustomData writes the data blocks of a custom archive, which follow
+// its TOC.
+func (a *archive) writeCustomData(w io.Writer) error {
+	entries := make(map[int]tocEntry)
+	for _, e := range a.entries {
+		entries[e.dumpId] = e
+	}
+	for {
+		blockType, err := a.r.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		dumpId, err := a.readInt()
+		if err != nil {
+			return err
+		}
+		switch blockType {
+		case blockData:
+			e, ok := entries[dumpId]
+			if !ok {
+				return fmt.Errorf("pg_dump archive has data for unknown entry %d", dumpId)
+			}
+			if err := a.copyBlock(func(r io.Reader) error { return writeTableData(w, e, r) }); err != nil {
+				return err
+			}
+		case blockBlobs:
+			// Large objects aren't migrated. Each has an OID and data, and
+			// they end with OID 0.
+			for {
+				oid, err := a.readInt()
+				if err != nil {
+					return err
+				}
+				if oid == 0 {
+					break
+				}
+				if err := a.copyBlock(func(r io.Reader) error {
+					_, err := io.Copy(io.Discard, r)
+					return err
+				}); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("pg_dump archive has unknown block type %d", blockType)
+		}
+	}
+}
+
+// copyBlock calls process with a reader of the decompressed data of the
+// next data block of a custom archive, and then skips what is left of the
+// block.
+func (a *archive) copyBlock(process func(r io.Reader) error) error {
+	cr := &chunkReader{a: a}
+	var r io.Reader = cr
+	switch a.compression {
+	case compressionGzip:
+		// Custom archives use the zlib format rather than the gzip one.
+		zr, err := zlib.NewReader(cr)
+		if err != nil {
+			return fmt.Errorf("can't decompress pg_dump archive data: %v", err)
+		}
+		r = zr
+	case compressionLZ4:
+		r = lz4.NewReader(cr)
+	case compressionZstd:
+		zr, err := zstd.NewReader(cr, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return fmt.Errorf("can't decompress pg_dump archive data: %v", err)
+		}
+		r = zr
+	}
+	if err := process(r); err != nil {
+		return err
+	}
+	_, err := io.Copy(io.Discard, cr)
+	return err
+}
+
+// chunkReader reads the data of a block of a custom archive, which is
+// stored in chunks that each start with their length. An empty chunk ends
+// the block.
+type chunkReader struct {
+	a         *archive
+	remaining int
+	done      bool
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for c.remaining == 0 {
+		if c.done {
+			return 0, io.EOF
+		}
+		n, err := c.a.readInt()
+		if err != nil {
+			return 0, err
+		}
+		if n < 0 {
+			return 0, fmt.Errorf("invalid pg_dump archive data chunk length %d", n)
+		}
+		c.remaining = n
+		c.done = n == 0
+	}
+	if len(p) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.a.r.Read(p)
+	c.remaining -= n
+	return n, unexpectedEOF(err)
+}
+
+// writeDirectoryData writes the data files of a directory archive, in the
+// order of its TOC. Compressed files have the extension of their
+// compression.
+func (a *archive) writeDirectoryData(w io.Writer) error {
+	extension := map[byte]string{compressionNone: "", compressionGzip: ".gz", compressionLZ4: ".lz4", compressionZstd: ".zst"}[a.compression]
+	for _, e := range a.entries {
+		// Large objects aren't migrated.
+		if !e.hasData || e.desc == "BLOBS" {
+			continue
+		}
+		if err := a.writeDirectoryFile(w, e, filepath.Join(a.dir, e.filename+extension)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *archive) writeDirectoryFile(w io.Writer, e tocEntry, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("can't open data file of pg_dump archive entry %d: %v", e.dumpId, err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	switch a.compression {
+	case compressionGzip:
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("can't decompress %s: %v", path, err)
+		}
+		r = gr
+	case compressionLZ4:
+		r = lz4.NewReader(f)
+	case compressionZstd:
+		zr, err := zstd.NewReader(f, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return fmt.Errorf("can't decompress %s: %v", path, err)
+		}
+		r = zr
+	}
+	return writeTableData(w, e, r)
+}
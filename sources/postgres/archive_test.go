@@ -0,0 +1,295 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTocEntry struct {
+	dumpId   int
+	desc     string
+	section  int
+	defn     string
+	copyStmt string
+	data     string
+}
+
+var testToc = []testTocEntry{
+	{dumpId: 1, desc: "ENCODING", section: sectionPreData, defn: "SET client_encoding = 'UTF8';\n"},
+	{dumpId: 2, desc: "TABLE", section: sectionPreData, defn: "CREATE TABLE public.cart (\n    productid text NOT NULL,\n    userid text NOT NULL,\n    quantity bigint\n);\n"},
+	{dumpId: 3, desc: "TABLE DATA", section: sectionData, copyStmt: "COPY public.cart (productid, userid, quantity) FROM stdin;\n", data: "901e-a6cfc2b502dc\tabc-123\t1\n9dd8-ce1e1e0d7ff4\txyz-789\t\\N\n"},
+	{dumpId: 4, desc: "SEQUENCE SET", section: sectionData, defn: "SELECT pg_catalog.setval('public.seq', 1, false);\n"},
+	{dumpId: 5, desc: "CONSTRAINT", section: sectionPostData, defn: "ALTER TABLE ONLY public.cart\n    ADD CONSTRAINT cart_pkey PRIMARY KEY (productid, userid);\n"},
+}
+
+const testArchiveDump = "SET client_encoding = 'UTF8';\n\n" +
+	"CREATE TABLE public.cart (\n    productid text NOT NULL,\n    userid text NOT NULL,\n    quantity bigint\n);\n\n" +
+	"COPY public.cart (productid, userid, quantity) FROM stdin;\n901e-a6cfc2b502dc\tabc-123\t1\n9dd8-ce1e1e0d7ff4\txyz-789\t\\N\n\\.\n\n" +
+	"SELECT pg_catalog.setval('public.seq', 1, false);\n\n" +
+	"ALTER TABLE ONLY public.cart\n    ADD CONSTRAINT cart_pkey PRIMARY KEY (productid, userid);\n\n"
+
+// archiveWriter writes pg_dump archives, like pg_backup_archiver.c does.
+type archiveWriter struct {
+	bytes.Buffer
+	minor       byte
+	compression byte
+}
+
+func (w *archiveWriter) writeInt(v int) {
+	if v < 0 {
+		w.WriteByte(1)
+		v = -v
+	} else {
+		w.WriteByte(0)
+	}
+	for i := 0; i < 4; i++ {
+		w.WriteByte(byte(v >> (8 * i)))
+	}
+}
+
+func (w *archiveWriter) writeStr(s string) {
+	w.writeInt(len(s))
+	w.WriteString(s)
+}
+
+func (w *archiveWriter) writeHeader(format byte) {
+	w.Write(archiveMagic)
+	w.Write([]byte{1, w.minor, 0, 4, 8, format})
+	if archiveVersion(1, w.minor) >= archiveVersion1_15 {
+		w.WriteByte(w.compression)
+	} else if w.compression == compressionGzip {
+		w.writeInt(-1)
+	} else {
+		w.writeInt(0)
+	}
+	for _, v := range []int{0, 30, 12, 1, 5, 125, 0} {
+		w.writeInt(v)
+	}
+	for _, s := range []string{"cart", "16.4", "16.4"} {
+		w.writeStr(s)
+	}
+}
+
+func (w *archiveWriter) writeToc(format byte) {
+	w.writeInt(len(testToc))
+	for _, e := range testToc {
+		w.writeInt(e.dumpId)
+		hadDumper := 0
+		if e.data != "" {
+			hadDumper = 1
+		}
+		w.writeInt(hadDumper)
+		for _, s := range []string{"0", "0", "cart", e.desc} {
+			w.writeStr(s)
+		}
+		w.writeInt(e.section)
+		for _, s := range []string{e.defn, "", e.copyStmt, "public", ""} {
+			w.writeStr(s)
+		}
+		if archiveVersion(1, w.minor) >= archiveVersion1_14 {
+			w.writeStr("heap")
+		}
+		if archiveVersion(1, w.minor) >= archiveVersion1_16 {
+			w.writeInt('r')
+		}
+		w.writeStr("postgres")
+		w.writeStr("false")
+		if e.dumpId > 1 {
+			w.writeStr("1")
+		}
+		w.writeInt(-1)
+		if format == archCustom {
+			flag := byte(offsetNoData)
+			if e.data != "" {
+				flag = 2
+			}
+			w.WriteByte(flag)
+			w.Write(make([]byte, 8))
+		} else if e.data != "" {
+			w.writeStr(filename(e))
+		} else {
+			w.writeInt(-1)
+		}
+	}
+}
+
+func filename(e testTocEntry) string {
+	return string(rune('0'+e.dumpId)) + ".dat"
+}
+
+// compress compresses data like pg_dump compresses the data of custom
+// archives, or of directory archives if files is set.
+func (w *archiveWriter) compress(t *testing.T, data string, files bool) []byte {
+	var b bytes.Buffer
+	var c io.WriteCloser
+	switch w.compression {
+	case compressionNone:
+		return []byte(data)
+	case compressionGzip:
+		if files {
+			c = gzip.NewWriter(&b)
+		} else {
+			c = zlib.NewWriter(&b)
+		}
+	case compressionLZ4:
+		c = lz4.NewWriter(&b)
+	case compressionZstd:
+		var err error
+		c, err = zstd.NewWriter(&b)
+		assert.Nil(t, err)
+	}
+	_, err := c.Write([]byte(data))
+	assert.Nil(t, err)
+	assert.Nil(t, c.Close())
+	return b.Bytes()
+}
+
+// writeChunks writes data as chunks of at most 16 bytes.
+func (w *archiveWriter) writeChunks(data []byte) {
+	for len(data) > 0 {
+		n := min(16, len(data))
+		w.writeInt(n)
+		w.Write(data[:n])
+		data = data[n:]
+	}
+	w.writeInt(0)
+}
+
+func customArchive(t *testing.T, minor, compression byte) []byte {
+	w := &archiveWriter{minor: minor, compression: compression}
+	w.writeHeader(archCustom)
+	w.writeToc(archCustom)
+	// Large objects are skipped.
+	w.WriteByte(blockBlobs)
+	w.writeInt(6)
+	w.writeInt(16384)
+	w.writeChunks(w.compress(t, "blob", false))
+	w.writeInt(0)
+	for _, e := range testToc {
+		if e.data != "" {
+			w.WriteByte(blockData)
+			w.writeInt(e.dumpId)
+			w.writeChunks(w.compress(t, e.data, false))
+		}
+	}
+	return w.Bytes()
+}
+
+func directoryArchive(t *testing.T, minor, compression byte) string {
+	dir := t.TempDir()
+	w := &archiveWriter{minor: minor, compression: compression}
+	w.writeHeader(archDirectory)
+	w.writeToc(archDirectory)
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "toc.dat"), w.Bytes(), 0644))
+	extension := map[byte]string{compressionNone: "", compressionGzip: ".gz", compressionLZ4: ".lz4", compressionZstd: ".zst"}[compression]
+	for _, e := range testToc {
+		if e.data != "" {
+			assert.Nil(t, os.WriteFile(filepath.Join(dir, filename(e)+extension), w.compress(t, e.data, true), 0644))
+		}
+	}
+	return dir
+}
+
+func TestNewArchiveReader(t *testing.T) {
+	testCases := []struct {
+		name        string
+		minor       byte
+		compression byte
+	}{
+		{name: "pg 12", minor: 14},
+		{name: "pg 12 compressed", minor: 14, compression: compressionGzip},
+		{name: "pg 16 gzip", minor: 15, compression: compressionGzip},
+		{name: "pg 16 lz4", minor: 15, compression: compressionLZ4},
+		{name: "pg 16 zstd", minor: 15, compression: compressionZstd},
+		{name: "pg 17", minor: 16, compression: compressionZstd},
+	}
+	for _, tc := range testCases {
+		archive := customArchive(t, tc.minor, tc.compression)
+		assert.True(t, IsArchive(archive), tc.name)
+		r, err := NewArchiveReader(bytes.NewReader(archive))
+		assert.Nil(t, err, tc.name)
+		dump, err := io.ReadAll(r)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, testArchiveDump, string(dump), tc.name)
+	}
+}
+
+func TestNewDirectoryArchiveReader(t *testing.T) {
+	for _, compression := range []byte{compressionNone, compressionGzip, compressionLZ4, compressionZstd} {
+		r, err := NewDirectoryArchiveReader(directoryArchive(t, 15, compression))
+		assert.Nil(t, err)
+		dump, err := io.ReadAll(r)
+		assert.Nil(t, err)
+		assert.Equal(t, testArchiveDump, string(dump), compression)
+	}
+
+	// Data files are required.
+	dir := directoryArchive(t, 16, compressionNone)
+	assert.Nil(t, os.Remove(filepath.Join(dir, "3.dat")))
+	r, err := NewDirectoryArchiveReader(dir)
+	assert.Nil(t, err)
+	_, err = io.ReadAll(r)
+	assert.NotNil(t, err)
+
+	_, err = NewDirectoryArchiveReader(t.TempDir())
+	assert.NotNil(t, err)
+}
+
+func TestNewArchiveReaderErrors(t *testing.T) {
+	_, err := NewArchiveReader(bytes.NewReader([]byte("--\n-- PostgreSQL database dump\n")))
+	assert.NotNil(t, err)
+
+	old := customArchive(t, 14, compressionNone)
+	old[6] = 11
+	_, err = NewArchiveReader(bytes.NewReader(old))
+	assert.NotNil(t, err, "unsupported version")
+
+	tar := customArchive(t, 14, compressionNone)
+	tar[10] = 3
+	_, err = NewArchiveReader(bytes.NewReader(tar))
+	assert.NotNil(t, err, "tar format")
+
+	archive := customArchive(t, 15, compressionGzip)
+	_, err = NewArchiveReader(bytes.NewReader(archive[:200]))
+	assert.NotNil(t, err, "truncated TOC")
+	r, err := NewArchiveReader(bytes.NewReader(archive[:len(archive)-10]))
+	assert.Nil(t, err)
+	_, err = io.ReadAll(r)
+	assert.NotNil(t, err, "truncated data")
+}
+
+func TestProcessPgDumpArchive(t *testing.T) {
+	r, err := NewArchiveReader(bytes.NewReader(customArchive(t, 15, compressionGzip)))
+	assert.Nil(t, err)
+	dump, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	conv, rows := runProcessPgDump(string(dump))
+	noIssues(conv, t, "archive")
+	assert.Equal(t, []spannerData{
+		{table: "cart", cols: []string{"productid", "userid", "quantity"}, vals: []interface{}{"901e-a6cfc2b502dc", "abc-123", int64(1)}},
+		{table: "cart", cols: []string{"productid", "userid"}, vals: []interface{}{"9dd8-ce1e1e0d7ff4", "xyz-789"}},
+	}, rows)
+}
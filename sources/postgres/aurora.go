@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Aurora PostgreSQL clusters are migrated as PostgreSQL sources. Their data
+// is read from the reader endpoint of the cluster when it's known, so that
+// the bulk reads don't load the writer instance.
+
+// auroraSchemas are the schemas of the extensions of Aurora PostgreSQL,
+// e.g. the plans of apg_plan_mgmt, whose tables aren't user tables.
+var auroraSchemas = []string{"apg_plan_mgmt", "aws_commons", "aws_lambda", "aws_ml", "aws_s3"}
+
+// GetAuroraVersion returns the Aurora version, e.g. 16.1.1, of the server
+// of db, or "" if it isn't an Aurora PostgreSQL server.
+func GetAuroraVersion(db *sql.DB) (string, error) {
+	var aurora bool
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_proc WHERE proname = 'aurora_version');").Scan(&aurora); err != nil {
+		return "", fmt.Errorf("couldn't check whether the source is Aurora: %w", err)
+	}
+	if !aurora {
+		return "", nil
+	}
+	var version string
+	if err := db.QueryRow("SELECT aurora_version();").Scan(&version); err != nil {
+		return "", fmt.Errorf("couldn't get Aurora version: %w", err)
+	}
+	return version, nil
+}
+
+// dataDb returns the database to read data from: the reader endpoint of
+// Aurora clusters if it's known.
+func (isi InfoSchemaImpl) dataDb() *sql.DB {
+	if isi.ReaderDb != nil {
+		return isi.ReaderDb
+	}
+	return isi.Db
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+func TestGetAuroraVersion(t *testing.T) {
+	db := mkMockDB(t, []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM pg_proc WHERE proname = 'aurora_version');"),
+			cols:  []string{"exists"},
+			rows:  [][]driver.Value{{true}},
+		},
+		{
+			query: regexp.QuoteMeta("SELECT aurora_version();"),
+			cols:  []string{"aurora_version"},
+			rows:  [][]driver.Value{{"16.1.1"}},
+		},
+	})
+	version, err := GetAuroraVersion(db)
+	assert.Nil(t, err)
+	assert.Equal(t, "16.1.1", version)
+
+	db = mkMockDB(t, []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM pg_proc WHERE proname = 'aurora_version');"),
+			cols:  []string{"exists"},
+			rows:  [][]driver.Value{{false}},
+		},
+	})
+	version, err = GetAuroraVersion(db)
+	assert.Nil(t, err)
+	assert.Equal(t, "", version)
+}
+
+func TestGetTables_Aurora(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT table_schema, table_name FROM information_schema.tables where table_type = 'BASE TABLE'",
+			cols:  []string{"table_schema", "table_name"},
+			rows:  [][]driver.Value{{"public", "orders"}, {"apg_plan_mgmt", "plans"}},
+		},
+	}
+	isi := InfoSchemaImpl{Db: mkMockDB(t, ms), IsSchemaUnique: newFalsePtr(), AuroraVersion: "16.1.1"}
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "public", Name: "orders"}}, tables)
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func chunksConv(lineType schema.Type) *internal.Conv {
+	return buildConv(
+		ddl.CreateTable{
+			Name:   "order_lines",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "order_id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "line", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+				"c3": {Name: "amount", Id: "c3", T: ddl.Type{Name: ddl.Int64}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		},
+		schema.Table{
+			Name:   "order_lines",
+			Id:     "t1",
+			Schema: "public",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "order_id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+				"c2": {Name: "line", Id: "c2", Type: lineType},
+				"c3": {Name: "amount", Id: "c3", Type: schema.Type{Name: "bigint"}},
+			},
+			ColNameIdMap: map[string]string{"order_id": "c1", "line": "c2", "amount": "c3"},
+			PrimaryKeys:  []schema.Key{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		})
+}
+
+func TestProcessDataChunks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	cols := []string{"order_id", "line", "amount"}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "public"."order_lines" ORDER BY "order_id", "line" LIMIT 2;`)).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(1), int64(1), int64(10)).AddRow(int64(1), int64(2), int64(20)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "public"."order_lines" WHERE ("order_id", "line") > ($1, $2) ORDER BY "order_id", "line" LIMIT 2;`)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(2), int64(1), int64(30)))
+	conv := chunksConv(schema.Type{Name: "integer"})
+	conv.SetDataMode()
+	var amounts []int64
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			amounts = append(amounts, vals[2].(int64))
+		})
+	sourceProfile := profiles.SourceProfile{Ty: profiles.SourceProfileTypeConnection, Conn: profiles.SourceProfileConnection{ChunkSize: 2}}
+	isi := InfoSchemaImpl{Db: db, SourceProfile: sourceProfile}
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, isi, internal.AdditionalDataAttributes{})
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []int64{10, 20, 30}, amounts)
+}
+
+func TestChunkKeyOf(t *testing.T) {
+	assert.Equal(t, []string{"order_id", "line"}, chunkKeyOf(chunksConv(schema.Type{Name: "integer"}).SrcSchema["t1"]))
+	// Tables whose keys can't be compared exactly are read with a single
+	// query.
+	assert.Nil(t, chunkKeyOf(chunksConv(schema.Type{Name: "double precision"}).SrcSchema["t1"]))
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// GetComments implements the common.CommentSource interface, reading the
+// comments set with COMMENT ON TABLE and COMMENT ON COLUMN from
+// pg_description. Table comments have objsubid 0, column comments have the
+// number of the column.
+func (isi InfoSchemaImpl) GetComments(tables []common.SchemaAndName) (map[common.SchemaAndName]common.TableComments, error) {
+	included := make(map[common.SchemaAndName]bool)
+	for _, t := range tables {
+		included[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] = true
+	}
+	q := `SELECT n.nspname, c.relname, COALESCE(a.attname, ''), d.description
+		FROM pg_description d
+		JOIN pg_class c ON c.oid = d.objoid AND d.classoid = 'pg_class'::regclass
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.objsubid AND d.objsubid > 0
+		WHERE n.nspname NOT IN ('information_schema', 'pg_catalog');`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get comments: %w", err)
+	}
+	defer rows.Close()
+	comments := make(map[common.SchemaAndName]common.TableComments)
+	for rows.Next() {
+		var schemaName, tableName, colName, comment string
+		if err := rows.Scan(&schemaName, &tableName, &colName, &comment); err != nil {
+			return nil, fmt.Errorf("couldn't get comments: %w", err)
+		}
+		t := common.SchemaAndName{Schema: schemaName, Name: tableName}
+		if !included[t] {
+			continue
+		}
+		c := comments[t]
+		if colName == "" {
+			c.Comment = comment
+		} else {
+			if c.Columns == nil {
+				c.Columns = make(map[string]string)
+			}
+			c.Columns[colName] = comment
+		}
+		comments[t] = c
+	}
+	return comments, rows.Err()
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+func TestGetComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM pg_description d`)).
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname", "attname", "description"}).
+			AddRow("public", "orders", "", "Orders placed by customers").
+			AddRow("public", "orders", "id", "Order number").
+			AddRow("sales", "users", "email", "Login of the user").
+			AddRow("public", "orders_view", "", "Not a table"))
+	isi := InfoSchemaImpl{Db: db}
+	tables := []common.SchemaAndName{{Schema: "public", Name: "orders"}, {Schema: "sales", Name: "users"}}
+	comments, err := isi.GetComments(tables)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, map[common.SchemaAndName]common.TableComments{
+		{Schema: "public", Name: "orders"}: {Comment: "Orders placed by customers", Columns: map[string]string{"id": "Order number"}},
+		{Schema: "sales", Name: "users"}:   {Columns: map[string]string{"email": "Login of the user"}},
+	}, comments)
+}
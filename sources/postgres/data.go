@@ -28,7 +28,9 @@ import (
 	"cloud.google.com/go/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 )
 
 // ProcessDataRow converts a row of data and writes it out to Spanner.
@@ -116,6 +118,12 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, l
 	// strconv.ParseFloat and strconv.ParseInt) return "invalid syntax"
 	// errors if whitespace were to appear at the start or end of a string.
 	// We do not expect pg_dump to generate such output.
+	if isSpatialType(srcTypeName) {
+		return common.ConvSpatial(spannerType, val)
+	}
+	if toExtensionType(srcTypeName) == "hstore" && spannerType.Name == ddl.JSON {
+		return convHstore(val)
+	}
 	switch spannerType.Name {
 	case ddl.Bool:
 		return convBool(val)
@@ -137,11 +145,21 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, l
 		return convTimestamp(srcTypeName, location, val)
 	case ddl.JSON:
 		return val, nil
+	case ddl.UUID:
+		return convUUID(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
 }
 
+// isSpatialType returns true for PostGIS types, whose values are read as
+// hex-encoded EWKB. pg_dump qualifies them with the schema of PostGIS e.g.
+// public.geometry.
+func isSpatialType(srcTypeName string) bool {
+	name := srcTypeName[strings.LastIndex(srcTypeName, ".")+1:]
+	return name == "geometry" || name == "geography"
+}
+
 func convBool(val string) (bool, error) {
 	b, err := strconv.ParseBool(val)
 	if err != nil {
@@ -150,6 +168,16 @@ func convBool(val string) (bool, error) {
 	return b, err
 }
 
+// convUUID converts a UUID to its canonical textual form, which is how
+// Spanner accepts UUID values.
+func convUUID(val string) (string, error) {
+	u, err := uuid.Parse(val)
+	if err != nil {
+		return "", fmt.Errorf("can't convert to uuid: %w", err)
+	}
+	return u.String(), nil
+}
+
 func convBytes(val string) ([]byte, error) {
 	if val[0:2] != `\x` {
 		return []byte{}, fmt.Errorf("can't convert to bytes: doesn't start with \\x prefix")
@@ -255,6 +283,10 @@ func convTimestamp(srcTypeName string, location *time.Location, val string) (t t
 // NULL, 2}", but it does not handle "NULL" (it returns error).
 func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location, v string) (interface{}, error) {
 	v = strings.TrimSpace(v)
+	// pgvector values have the format [v1,v2,...].
+	if srcTypeName == "vector" && len(v) >= 2 && v[0] == '[' && v[len(v)-1] == ']' {
+		v = "{" + v[1:len(v)-1] + "}"
+	}
 	// Handle empty array. Note that we use an empty NullString array
 	// for all Spanner array types since this will be converted to the
 	// appropriate type by the Spanner client.
@@ -264,7 +296,10 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 	if v[0] != '{' || v[len(v)-1] != '}' {
 		return []interface{}{}, fmt.Errorf("unrecognized data format for array: expected {v1, v2, ...}")
 	}
-	a := strings.Split(v[1:len(v)-1], ",")
+	a, err := parseArrayElements(v[1 : len(v)-1])
+	if err != nil {
+		return []interface{}{}, err
+	}
 
 	// The Spanner client for go does not accept []interface{} for arrays.
 	// Instead it only accepts slices of a specific type e.g. []int64, []string.
@@ -272,15 +307,12 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 	switch spannerType.Name {
 	case ddl.Bool:
 		var r []spanner.NullBool
-		for _, s := range a {
-			if s == "NULL" {
+		for _, e := range a {
+			if e == nil {
 				r = append(r, spanner.NullBool{Valid: false})
 				continue
 			}
-			s, err := processQuote(s)
-			if err != nil {
-				return []spanner.NullBool{}, err
-			}
+			s := *e
 			b, err := convBool(s)
 			if err != nil {
 				return []spanner.NullBool{}, err
@@ -290,15 +322,12 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 		return r, nil
 	case ddl.Bytes:
 		var r [][]byte
-		for _, s := range a {
-			if s == "NULL" {
+		for _, e := range a {
+			if e == nil {
 				r = append(r, nil)
 				continue
 			}
-			s, err := processQuote(s)
-			if err != nil {
-				return [][]byte{}, err
-			}
+			s := *e
 			b, err := convBytes(s)
 			if err != nil {
 				return [][]byte{}, err
@@ -308,15 +337,12 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 		return r, nil
 	case ddl.Date:
 		var r []spanner.NullDate
-		for _, s := range a {
-			if s == "NULL" {
+		for _, e := range a {
+			if e == nil {
 				r = append(r, spanner.NullDate{Valid: false})
 				continue
 			}
-			s, err := processQuote(s)
-			if err != nil {
-				return []spanner.NullDate{}, err
-			}
+			s := *e
 			date, err := convDate(s)
 			if err != nil {
 				return []spanner.NullDate{}, err
@@ -326,15 +352,12 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 		return r, nil
 	case ddl.Float32:
 		var r []spanner.NullFloat32
-		for _, s := range a {
-			if s == "NULL" {
+		for _, e := range a {
+			if e == nil {
 				r = append(r, spanner.NullFloat32{Valid: false})
 				continue
 			}
-			s, err := processQuote(s)
-			if err != nil {
-				return []spanner.NullFloat32{}, err
-			}
+			s := *e
 			f, err := convFloat32(s)
 			if err != nil {
 				return []spanner.NullFloat32{}, err
@@ -344,15 +367,12 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 		return r, nil
 	case ddl.Float64:
 		var r []spanner.NullFloat64
-		for _, s := range a {
-			if s == "NULL" {
+		for _, e := range a {
+			if e == nil {
 				r = append(r, spanner.NullFloat64{Valid: false})
 				continue
 			}
-			s, err := processQuote(s)
-			if err != nil {
-				return []spanner.NullFloat64{}, err
-			}
+			s := *e
 			f, err := convFloat64(s)
 			if err != nil {
 				return []spanner.NullFloat64{}, err
@@ -362,15 +382,12 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 		return r, nil
 	case ddl.Int64:
 		var r []spanner.NullInt64
-		for _, s := range a {
-			if s == "NULL" {
+		for _, e := range a {
+			if e == nil {
 				r = append(r, spanner.NullInt64{Valid: false})
 				continue
 			}
-			s, err := processQuote(s)
-			if err != nil {
-				return []spanner.NullInt64{}, err
-			}
+			s := *e
 			i, err := convInt64(s)
 			if err != nil {
 				return r, err
@@ -380,29 +397,23 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 		return r, nil
 	case ddl.String:
 		var r []spanner.NullString
-		for _, s := range a {
-			if s == "NULL" {
+		for _, e := range a {
+			if e == nil {
 				r = append(r, spanner.NullString{Valid: false})
 				continue
 			}
-			s, err := processQuote(s)
-			if err != nil {
-				return []spanner.NullString{}, err
-			}
+			s := *e
 			r = append(r, spanner.NullString{StringVal: s, Valid: true})
 		}
 		return r, nil
 	case ddl.Timestamp:
 		var r []spanner.NullTime
-		for _, s := range a {
-			if s == "NULL" {
+		for _, e := range a {
+			if e == nil {
 				r = append(r, spanner.NullTime{Valid: false})
 				continue
 			}
-			s, err := processQuote(s)
-			if err != nil {
-				return []spanner.NullTime{}, err
-			}
+			s := *e
 			t, err := convTimestamp(srcTypeName, location, s)
 			if err != nil {
 				return []spanner.NullTime{}, err
@@ -410,21 +421,115 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 			r = append(r, spanner.NullTime{Time: t, Valid: true})
 		}
 		return r, nil
+	case ddl.UUID:
+		var r []spanner.NullString
+		for _, e := range a {
+			if e == nil {
+				r = append(r, spanner.NullString{Valid: false})
+				continue
+			}
+			u, err := convUUID(*e)
+			if err != nil {
+				return []spanner.NullString{}, err
+			}
+			r = append(r, spanner.NullString{StringVal: u, Valid: true})
+		}
+		return r, nil
 	}
 	return []interface{}{}, fmt.Errorf("array type conversion not implemented for type %v", reflect.TypeOf(spannerType))
 }
 
-// processQuote returns the unquoted version of s.
-// Note: The element values of PostgreSQL arrays may have double
-// quotes around them.  The array output routine will put double
-// quotes around element values if they are empty strings, contain
-// curly braces, delimiter characters, double quotes, backslashes, or
-// white space, or match the word NULL. Double quotes and backslashes
-// embedded in element values will be backslash-escaped.  See section
-// 8.14.6.of www.postgresql.org/docs/9.1/arrays.html.
-func processQuote(s string) (string, error) {
-	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
-		return strconv.Unquote(s)
+// parseArrayElements splits the elements of a one-dimensional PostgreSQL
+// array, given without its enclosing braces, and returns nil for NULL
+// elements. The array output routine puts double quotes around element
+// values if they are empty strings, contain curly braces, delimiter
+// characters, double quotes, backslashes, or white space, or match the word
+// NULL. Double quotes and backslashes embedded in element values are
+// backslash-escaped, and whitespace around elements is ignored. See section
+// 8.15.6 of www.postgresql.org/docs/current/arrays.html.
+func parseArrayElements(s string) ([]*string, error) {
+	var elems []*string
+	i := 0
+	skipSpace := func() {
+		for i < len(s) && isArraySpace(s[i]) {
+			i++
+		}
+	}
+	for {
+		skipSpace()
+		if i == len(s) {
+			if len(elems) == 0 {
+				return elems, nil
+			}
+			return nil, fmt.Errorf("unrecognized data format for array: missing element after delimiter")
+		}
+		var b strings.Builder
+		if s[i] == '"' {
+			i++
+			for {
+				if i == len(s) {
+					return nil, fmt.Errorf("unrecognized data format for array: unterminated quoted element")
+				}
+				c := s[i]
+				i++
+				if c == '"' {
+					break
+				}
+				if c == '\\' {
+					if i == len(s) {
+						return nil, fmt.Errorf("unrecognized data format for array: unterminated quoted element")
+					}
+					c = s[i]
+					i++
+				}
+				b.WriteByte(c)
+			}
+			skipSpace()
+			elem := b.String()
+			elems = append(elems, &elem)
+		} else {
+			// Trailing whitespace of unquoted elements is dropped unless
+			// it is escaped.
+			escaped, keep := false, 0
+			for i < len(s) && s[i] != ',' {
+				c := s[i]
+				i++
+				switch {
+				case c == '"' || c == '{' || c == '}':
+					return nil, fmt.Errorf("unrecognized data format for array: unexpected %q in element", c)
+				case c == '\\':
+					if i == len(s) {
+						return nil, fmt.Errorf("unrecognized data format for array: incomplete escape sequence")
+					}
+					b.WriteByte(s[i])
+					i++
+					escaped, keep = true, b.Len()
+				default:
+					b.WriteByte(c)
+					if !isArraySpace(c) {
+						keep = b.Len()
+					}
+				}
+			}
+			elem := b.String()[:keep]
+			if !escaped && strings.EqualFold(elem, "NULL") {
+				elems = append(elems, nil)
+			} else {
+				elems = append(elems, &elem)
+			}
+		}
+		if i == len(s) {
+			return elems, nil
+		}
+		if s[i] != ',' {
+			return nil, fmt.Errorf("unrecognized data format for array: expected delimiter after element")
+		}
+		i++
 	}
-	return s, nil
+}
+
+// isArraySpace reports whether c is whitespace that PostgreSQL ignores around
+// array elements.
+func isArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
 }
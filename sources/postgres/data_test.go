@@ -83,6 +83,12 @@ func TestConvertData(t *testing.T) {
 		{"string", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "", "eh", "eh"},
 		{"timestamptz", ddl.Type{Name: ddl.Timestamp}, "timestamptz", "2019-10-29 05:30:00+10", getTime(t, "2019-10-29T05:30:00+10:00")},
 		{"timestamp", ddl.Type{Name: ddl.Timestamp}, "timestamp", "2019-10-29 05:30:00", getTime(t, "2019-10-29T05:30:00Z")},
+		{"uuid", ddl.Type{Name: ddl.UUID}, "uuid", "{123E4567-E89B-12D3-A456-426614174000}", "123e4567-e89b-12d3-a456-426614174000"},
+		{"geometry string", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "public.geometry", "0101000020E6100000000000000000F03F0000000000000040", "POINT(1 2)"},
+		{"geography json", ddl.Type{Name: ddl.JSON}, "geography", "0101000020E6100000000000000000F03F0000000000000040", `{"type":"Point","coordinates":[1,2]}`},
+		{"hstore json", ddl.Type{Name: ddl.JSON}, "public.hstore", `"a"=>"1", "b"=>NULL`, `{"a":"1","b":null}`},
+		{"hstore string", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "hstore", `"a"=>"1"`, `"a"=>"1"`},
+		{"inet", ddl.Type{Name: ddl.String, Len: inetLength}, "inet", "192.168.0.1/24", "192.168.0.1/24"},
 
 		// Add cases for each array type, since each is a separate code path.
 		// Note: the PostgreSQL array output routine puts double quotes around
@@ -100,6 +106,10 @@ func TestConvertData(t *testing.T) {
 			spanner.NullFloat32{Valid: false},
 			spanner.NullFloat32{Float32: 2.2, Valid: true},
 			spanner.NullFloat32{Float32: 3.3, Valid: true}}},
+		{"vector", ddl.Type{Name: ddl.Float32, IsArray: true, VectorLength: 3}, "vector", "[1.1,2.2,3.3]", []spanner.NullFloat32{
+			spanner.NullFloat32{Float32: 1.1, Valid: true},
+			spanner.NullFloat32{Float32: 2.2, Valid: true},
+			spanner.NullFloat32{Float32: 3.3, Valid: true}}},
 		{"float64 array", ddl.Type{Name: ddl.Float64, IsArray: true}, "", "{1.1,NULL,2.2,3.3}", []spanner.NullFloat64{
 			spanner.NullFloat64{Float64: 1.1, Valid: true},
 			spanner.NullFloat64{Valid: false},
@@ -115,6 +125,20 @@ func TestConvertData(t *testing.T) {
 			spanner.NullString{Valid: false},
 			spanner.NullString{StringVal: "3", Valid: true},
 			spanner.NullString{StringVal: "NULL", Valid: true}}},
+		{"string array with quoted delimiters", ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, "", `{"hello, world",foo,"{a}"}`, []spanner.NullString{
+			spanner.NullString{StringVal: "hello, world", Valid: true},
+			spanner.NullString{StringVal: "foo", Valid: true},
+			spanner.NullString{StringVal: "{a}", Valid: true}}},
+		{"string array with escapes", ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, "", `{"say \"hi\"","back\\slash",a\,b}`, []spanner.NullString{
+			spanner.NullString{StringVal: `say "hi"`, Valid: true},
+			spanner.NullString{StringVal: `back\slash`, Valid: true},
+			spanner.NullString{StringVal: "a,b", Valid: true}}},
+		{"string array with NULL elements", ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, "", `{NULL, null ,"null",\NULL,""}`, []spanner.NullString{
+			spanner.NullString{Valid: false},
+			spanner.NullString{Valid: false},
+			spanner.NullString{StringVal: "null", Valid: true},
+			spanner.NullString{StringVal: "NULL", Valid: true},
+			spanner.NullString{StringVal: "", Valid: true}}},
 		{"timestamp array", ddl.Type{Name: ddl.Timestamp, IsArray: true}, "timestamptz", `{"2019-10-29 05:30:00+10",NULL}`, []spanner.NullTime{
 			spanner.NullTime{Time: getTime(t, "2019-10-29T05:30:00+10:00"), Valid: true},
 			spanner.NullTime{Valid: false}}},
@@ -332,3 +356,35 @@ func getDate(s string) civil.Date {
 	d, _ := civil.ParseDate(s)
 	return d
 }
+
+func TestParseArrayElements(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name     string
+		in       string
+		expected []*string
+		wantErr  bool
+	}{
+		{"empty", "", nil, false},
+		{"unquoted", "a, b ,c", []*string{str("a"), str("b"), str("c")}, false},
+		{"quoted delimiter", `"a,b",c`, []*string{str("a,b"), str("c")}, false},
+		{"escaped quote", `"a\"b"`, []*string{str(`a"b`)}, false},
+		{"escaped backslash", `"a\\b"`, []*string{str(`a\b`)}, false},
+		{"escaped trailing space", `a\ `, []*string{str("a ")}, false},
+		{"null", "NULL,1", []*string{nil, str("1")}, false},
+		{"quoted null", `"NULL"`, []*string{str("NULL")}, false},
+		{"unterminated quote", `"a,b`, nil, true},
+		{"text after quote", `"a"b`, nil, true},
+		{"missing element", "a,", nil, true},
+		{"nested array", "{1},{2}", nil, true},
+	}
+	for _, tc := range tests {
+		got, err := parseArrayElements(tc.in)
+		if tc.wantErr {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expected, got, tc.name)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Types of popular PostgreSQL extensions are mapped as follows: hstore to
+// JSON objects of strings, citext and ltree to STRING. The network address
+// types inet and cidr are mapped to STRING, with a CHECK constraint
+// validating the addresses.
+
+// Length of the longest inet value e.g.
+// ffff:ffff:ffff:ffff:ffff:ffff:255.255.255.255/128.
+const inetLength = 49
+
+// Patterns of the text form of inet and cidr values: IPv4 and IPv6
+// addresses with an optional prefix length.
+const (
+	ipv4Pattern = `(25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])(\.(25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}(/([0-9]|[12][0-9]|3[0-2]))?`
+	ipv6Pattern = `[0-9a-fA-F:]*:[0-9a-fA-F:.]*(/([0-9]|[1-9][0-9]|1[01][0-9]|12[0-8]))?`
+)
+
+// toExtensionType returns the name of the extension type srcTypeName, or
+// an empty string for other types. pg_dump qualifies extension types with
+// the schema of the extension e.g. public.hstore.
+func toExtensionType(srcTypeName string) string {
+	name := srcTypeName[strings.LastIndex(srcTypeName, ".")+1:]
+	switch name {
+	case "hstore", "citext", "ltree":
+		return name
+	}
+	return ""
+}
+
+// toSpannerExtensionType maps an extension type to a Spanner type.
+func toSpannerExtensionType(extensionType, spType string) (ddl.Type, []internal.SchemaIssue) {
+	switch extensionType {
+	case "hstore":
+		if spType == ddl.String {
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		}
+		return ddl.Type{Name: ddl.JSON}, nil
+	case "citext":
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.CaseInsensitiveText}
+	default:
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+	}
+}
+
+// GetColumnCheckExpr implements the common.ColumnCheckProvider interface.
+// inet and cidr columns mapped to STRING are checked to hold IPv4 or IPv6
+// addresses.
+func (tdi ToDdlImpl) GetColumnCheckExpr(conv *internal.Conv, srcType schema.Type, spCol ddl.ColumnDef) string {
+	if (srcType.Name != "inet" && srcType.Name != "cidr") || len(srcType.ArrayBounds) > 0 || spCol.T.Name != ddl.String || spCol.T.IsArray {
+		return ""
+	}
+	pattern := fmt.Sprintf("^(%s|%s)$", ipv4Pattern, ipv6Pattern)
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Sprintf(`("%s" ~ '%s')`, spCol.Name, pattern)
+	}
+	return fmt.Sprintf("REGEXP_CONTAINS(`%s`, r'%s')", spCol.Name, pattern)
+}
+
+// convHstore converts the text form of an hstore value e.g.
+// "a"=>"1", "b"=>NULL to a JSON object e.g. {"a":"1","b":null}.
+func convHstore(val string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i := skipHstoreSpaces(val, 0); i < len(val); {
+		key, quoted, next, err := readHstoreToken(val, i)
+		if err != nil {
+			return "", err
+		}
+		if !quoted && strings.EqualFold(key, "NULL") {
+			return "", fmt.Errorf("hstore key can't be NULL: %s", val)
+		}
+		i = skipHstoreSpaces(val, next)
+		if !strings.HasPrefix(val[i:], "=>") {
+			return "", fmt.Errorf("expected => after hstore key %q: %s", key, val)
+		}
+		value, quoted, next, err := readHstoreToken(val, skipHstoreSpaces(val, i+2))
+		if err != nil {
+			return "", err
+		}
+		k, _ := json.Marshal(key)
+		v, _ := json.Marshal(value)
+		if !quoted && strings.EqualFold(value, "NULL") {
+			v = []byte("null")
+		}
+		if sb.Len() > 1 {
+			sb.WriteString(",")
+		}
+		sb.Write(k)
+		sb.WriteString(":")
+		sb.Write(v)
+		i = skipHstoreSpaces(val, next)
+		if i < len(val) {
+			if val[i] != ',' {
+				return "", fmt.Errorf("expected , after hstore value %q: %s", value, val)
+			}
+			i = skipHstoreSpaces(val, i+1)
+		}
+	}
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+// readHstoreToken reads the key or value of an hstore value starting at i,
+// and returns it along with whether it was quoted and the index following
+// it. Quoted tokens can contain backslash escaped characters.
+func readHstoreToken(val string, i int) (string, bool, int, error) {
+	if i >= len(val) {
+		return "", false, i, fmt.Errorf("unexpected end of hstore value: %s", val)
+	}
+	var sb strings.Builder
+	if val[i] != '"' {
+		j := i
+		for j < len(val) && !strings.ContainsRune(" \t\n,=>", rune(val[j])) {
+			j++
+		}
+		if j == i {
+			return "", false, i, fmt.Errorf("unexpected %q in hstore value: %s", val[i], val)
+		}
+		return val[i:j], false, j, nil
+	}
+	for j := i + 1; j < len(val); j++ {
+		switch val[j] {
+		case '\\':
+			j++
+			if j < len(val) {
+				sb.WriteByte(val[j])
+			}
+		case '"':
+			return sb.String(), true, j + 1, nil
+		default:
+			sb.WriteByte(val[j])
+		}
+	}
+	return "", false, i, fmt.Errorf("unterminated string in hstore value: %s", val)
+}
+
+func skipHstoreSpaces(val string, i int) int {
+	for i < len(val) && strings.ContainsRune(" \t\n", rune(val[i])) {
+		i++
+	}
+	return i
+}
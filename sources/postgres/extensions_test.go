@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func TestToSpannerTypeExtensions(t *testing.T) {
+	testCases := []struct {
+		srcType  string
+		spType   string
+		expected ddl.Type
+		issues   []internal.SchemaIssue
+	}{
+		{"hstore", "", ddl.Type{Name: ddl.JSON}, nil},
+		{"public.hstore", ddl.String, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil},
+		{"public.citext", "", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.CaseInsensitiveText}},
+		{"ltree", "", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil},
+		{"inet", "", ddl.Type{Name: ddl.String, Len: inetLength}, nil},
+		{"cidr", "", ddl.Type{Name: ddl.String, Len: inetLength}, nil},
+	}
+	for _, tc := range testCases {
+		ty, issues := toSpannerTypeInternal(schema.Type{Name: tc.srcType}, tc.spType)
+		assert.Equal(t, tc.expected, ty, tc.srcType)
+		assert.Equal(t, tc.issues, issues, tc.srcType)
+	}
+}
+
+func TestGetColumnCheckExpr(t *testing.T) {
+	conv := internal.MakeConv()
+	spCol := ddl.ColumnDef{Name: "addr", T: ddl.Type{Name: ddl.String, Len: inetLength}}
+	expr := ToDdlImpl{}.GetColumnCheckExpr(conv, schema.Type{Name: "inet"}, spCol)
+	assert.Regexp(t, "^REGEXP_CONTAINS\\(`addr`, r'", expr)
+
+	// The pattern of the CHECK constraint accepts the text form of addresses only.
+	pattern := regexp.MustCompile(regexp.MustCompile(`r'(.*)'\)$`).FindStringSubmatch(expr)[1])
+	for _, addr := range []string{"192.168.0.1", "10.0.0.0/8", "::1", "2001:db8::/32", "::ffff:1.2.3.4/128"} {
+		assert.True(t, pattern.MatchString(addr), addr)
+	}
+	for _, addr := range []string{"256.0.0.1", "10.0.0.0/33", "localhost", "2001:db8::/129", ""} {
+		assert.False(t, pattern.MatchString(addr), addr)
+	}
+
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	assert.Regexp(t, `^\("addr" ~ '\^`, ToDdlImpl{}.GetColumnCheckExpr(conv, schema.Type{Name: "cidr"}, spCol))
+	assert.Equal(t, "", ToDdlImpl{}.GetColumnCheckExpr(conv, schema.Type{Name: "inet", ArrayBounds: []int64{-1}}, spCol))
+	assert.Equal(t, "", ToDdlImpl{}.GetColumnCheckExpr(conv, schema.Type{Name: "text"}, spCol))
+}
+
+func TestConvHstore(t *testing.T) {
+	testCases := []struct {
+		val      string
+		expected string
+	}{
+		{``, `{}`},
+		{`"a"=>"1"`, `{"a":"1"}`},
+		{`"a"=>"1", "b"=>NULL, "c"=>"NULL"`, `{"a":"1","b":null,"c":"NULL"}`},
+		{`"say \"hi\""=>"C:\\dir"`, `{"say \"hi\"":"C:\\dir"}`},
+		{`a => b , key=>value`, `{"a":"b","key":"value"}`},
+	}
+	for _, tc := range testCases {
+		s, err := convHstore(tc.val)
+		assert.Nil(t, err, tc.val)
+		assert.Equal(t, tc.expected, s, tc.val)
+	}
+	for _, val := range []string{`"a"`, `"a"=>`, `"a"=>"1" "b"=>"2"`, `"a=>"1"`, `NULL=>"1"`} {
+		_, err := convHstore(val)
+		assert.NotNil(t, err, val)
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// PostgreSQL has two kinds of generated columns: identity columns, which
+// take their values from a sequence owned by the column, and columns
+// generated from an expression over the other columns of the row. The
+// former are mapped to columns using a Spanner sequence, and the latter to
+// stored generated columns.
+
+// identityOptions are the sequence options of an identity column, as
+// strings. Options which aren't set are empty.
+type identityOptions struct {
+	start, increment, min, max, cache string
+	cycle                             bool
+}
+
+// Maximum values of the integer types of identity columns.
+var identityTypeMax = map[string]int64{
+	"int2": 32767, "smallint": 32767,
+	"int4": 2147483647, "integer": 2147483647,
+	"int8": 9223372036854775807, "bigint": 9223372036854775807,
+}
+
+// identitySequence returns the source sequence of an identity column of
+// type colType. Options set to their PostgreSQL default for the type and
+// direction of the sequence are left out, so that only options chosen by
+// the user are reported as unsupported.
+func identitySequence(name, colType string, opts identityOptions) ddl.Sequence {
+	seq := ddl.Sequence{
+		Id:           internal.GenerateSequenceId(),
+		Name:         name,
+		SequenceKind: "BIT REVERSED SEQUENCE",
+		Cycle:        opts.cycle,
+	}
+	if opts.increment != "1" {
+		seq.Increment = opts.increment
+	}
+	if opts.cache != "1" {
+		seq.CacheSize = opts.cache
+	}
+	typeMax, ok := identityTypeMax[colType]
+	if !ok {
+		typeMax = identityTypeMax["bigint"]
+	}
+	defaultStart, defaultMin, defaultMax := "1", "1", strconv.FormatInt(typeMax, 10)
+	if strings.HasPrefix(opts.increment, "-") {
+		defaultStart, defaultMin, defaultMax = "-1", strconv.FormatInt(-typeMax-1, 10), "-1"
+	}
+	if opts.start != defaultStart {
+		seq.StartWithCounter = opts.start
+	}
+	if opts.min != defaultMin {
+		seq.MinValue = opts.min
+	}
+	if opts.max != defaultMax {
+		seq.MaxValue = opts.max
+	}
+	return seq
+}
+
+// addIdentitySequence records that column colId of table tableId takes its
+// values from seq, and returns the auto generation of the column.
+func addIdentitySequence(conv *internal.Conv, seq ddl.Sequence, tableId, colId string) ddl.AutoGenCol {
+	conv.ConvLock.Lock()
+	defer conv.ConvLock.Unlock()
+	seq.ColumnsUsingSeq = map[string][]string{tableId: {colId}}
+	conv.SrcSequences[seq.Id] = seq
+	return ddl.AutoGenCol{Name: seq.Name, GenerationType: constants.SEQUENCE}
+}
+
+// toGeneratedColumn returns the generated column of a column generated
+// from expr. PostgreSQL generated columns are always stored.
+func toGeneratedColumn(expr string) ddl.GeneratedColumn {
+	if expr == "" {
+		return ddl.GeneratedColumn{}
+	}
+	return ddl.GeneratedColumn{
+		IsPresent: true,
+		Value:     ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: expr},
+		Type:      ddl.GeneratedStored,
+	}
+}
+
+// ToSpannerGeneratedExpression implements the common.GeneratedColumnTranslator
+// interface. Expressions are kept as they are for the PostgreSQL dialect,
+// and translated to GoogleSQL otherwise.
+func (tdi ToDdlImpl) ToSpannerGeneratedExpression(conv *internal.Conv, expr string) (string, bool) {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return expr, true
+	}
+	return toSpannerGeneratedExpression(expr)
+}
+
+// toSpannerGeneratedExpression translates a PostgreSQL generated column
+// expression e.g. (price * (quantity)::numeric) to GoogleSQL. It returns
+// false when the expression uses constructs without an equivalent.
+func toSpannerGeneratedExpression(expr string) (string, bool) {
+	tree, err := pg_query.Parse("SELECT " + expr)
+	if err != nil || len(tree.Stmts) != 1 {
+		return "", false
+	}
+	sel := tree.Stmts[0].Stmt.GetSelectStmt()
+	if sel == nil || len(sel.TargetList) != 1 || sel.TargetList[0].GetResTarget() == nil {
+		return "", false
+	}
+	return translateGeneratedExpr(sel.TargetList[0].GetResTarget().Val)
+}
+
+// Operators with the same semantics in PostgreSQL and GoogleSQL. Division
+// isn't included as it truncates integers in PostgreSQL only.
+var generatedExprOperators = map[string]string{
+	"+": "+", "-": "-", "*": "*",
+	"=": "=", "<>": "<>", "!=": "!=", "<": "<", "<=": "<=", ">": ">", ">=": ">=",
+	"~~": "LIKE", "!~~": "NOT LIKE",
+}
+
+// generatedExprFunctions maps the PostgreSQL functions supported in
+// generated column expressions to their GoogleSQL name. concat isn't
+// included as, unlike GoogleSQL CONCAT, it ignores NULL arguments.
+var generatedExprFunctions = map[string]string{
+	"abs":         "ABS",
+	"btrim":       "TRIM",
+	"ceil":        "CEIL",
+	"ceiling":     "CEILING",
+	"char_length": "CHAR_LENGTH",
+	"exp":         "EXP",
+	"floor":       "FLOOR",
+	"length":      "CHAR_LENGTH",
+	"ln":          "LN",
+	"lower":       "LOWER",
+	"lpad":        "LPAD",
+	"ltrim":       "LTRIM",
+	"mod":         "MOD",
+	"power":       "POWER",
+	"repeat":      "REPEAT",
+	"replace":     "REPLACE",
+	"reverse":     "REVERSE",
+	"round":       "ROUND",
+	"rpad":        "RPAD",
+	"rtrim":       "RTRIM",
+	"sign":        "SIGN",
+	"sqrt":        "SQRT",
+	"starts_with": "STARTS_WITH",
+	"strpos":      "STRPOS",
+	"substr":      "SUBSTR",
+	"substring":   "SUBSTR",
+	"trunc":       "TRUNC",
+	"upper":       "UPPER",
+}
+
+// generatedExprTypes maps the types of casts to their GoogleSQL name.
+var generatedExprTypes = map[string]string{
+	"bool": "BOOL", "boolean": "BOOL",
+	"int2": "INT64", "int4": "INT64", "int8": "INT64", "smallint": "INT64", "integer": "INT64", "bigint": "INT64",
+	"float4": "FLOAT64", "float8": "FLOAT64", "real": "FLOAT64",
+	"numeric": "NUMERIC", "decimal": "NUMERIC",
+	"text": "STRING", "varchar": "STRING", "bpchar": "STRING",
+	"bytea": "BYTES",
+	"date":  "DATE", "timestamptz": "TIMESTAMP",
+}
+
+// translateGeneratedExpr translates the parse tree of an expression to
+// GoogleSQL. Binary operations are parenthesized to keep their precedence.
+func translateGeneratedExpr(n *pg_query.Node) (string, bool) {
+	switch e := n.GetNode().(type) {
+	case *pg_query.Node_ColumnRef:
+		if len(e.ColumnRef.Fields) != 1 || e.ColumnRef.Fields[0].GetString_() == nil {
+			return "", false
+		}
+		return "`" + e.ColumnRef.Fields[0].GetString_().Sval + "`", true
+	case *pg_query.Node_AConst:
+		c := e.AConst
+		switch {
+		case c.Isnull:
+			return "NULL", true
+		case c.GetIval() != nil:
+			return strconv.FormatInt(int64(c.GetIval().Ival), 10), true
+		case c.GetFval() != nil:
+			return c.GetFval().Fval, true
+		case c.GetBoolval() != nil:
+			return strings.ToUpper(strconv.FormatBool(c.GetBoolval().Boolval)), true
+		case c.GetSval() != nil:
+			return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(c.GetSval().Sval) + "'", true
+		}
+	case *pg_query.Node_AExpr:
+		return translateGeneratedAExpr(e.AExpr)
+	case *pg_query.Node_BoolExpr:
+		args, ok := translateGeneratedExprs(e.BoolExpr.Args)
+		if !ok {
+			return "", false
+		}
+		switch e.BoolExpr.Boolop {
+		case pg_query.BoolExprType_AND_EXPR:
+			return "(" + strings.Join(args, " AND ") + ")", true
+		case pg_query.BoolExprType_OR_EXPR:
+			return "(" + strings.Join(args, " OR ") + ")", true
+		case pg_query.BoolExprType_NOT_EXPR:
+			return "(NOT " + args[0] + ")", true
+		}
+	case *pg_query.Node_NullTest:
+		arg, ok := translateGeneratedExpr(e.NullTest.Arg)
+		if !ok {
+			return "", false
+		}
+		if e.NullTest.Nulltesttype == pg_query.NullTestType_IS_NOT_NULL {
+			return "(" + arg + " IS NOT NULL)", true
+		}
+		return "(" + arg + " IS NULL)", true
+	case *pg_query.Node_CoalesceExpr:
+		args, ok := translateGeneratedExprs(e.CoalesceExpr.Args)
+		if !ok {
+			return "", false
+		}
+		return "COALESCE(" + strings.Join(args, ", ") + ")", true
+	case *pg_query.Node_MinMaxExpr:
+		args, ok := translateGeneratedExprs(e.MinMaxExpr.Args)
+		if !ok {
+			return "", false
+		}
+		fn := "GREATEST"
+		if e.MinMaxExpr.Op == pg_query.MinMaxOp_IS_LEAST {
+			fn = "LEAST"
+		}
+		return fn + "(" + strings.Join(args, ", ") + ")", true
+	case *pg_query.Node_CaseExpr:
+		return translateGeneratedCaseExpr(e.CaseExpr)
+	case *pg_query.Node_TypeCast:
+		arg, ok := translateGeneratedExpr(e.TypeCast.Arg)
+		names := e.TypeCast.TypeName.GetNames()
+		if !ok || len(names) == 0 || len(e.TypeCast.TypeName.ArrayBounds) > 0 {
+			return "", false
+		}
+		ty, found := generatedExprTypes[names[len(names)-1].GetString_().GetSval()]
+		if !found {
+			return "", false
+		}
+		if ty == "STRING" && e.TypeCast.Arg.GetAConst().GetSval() != nil {
+			// String literals are typed text in PostgreSQL expressions.
+			return arg, true
+		}
+		return fmt.Sprintf("CAST(%s AS %s)", arg, ty), true
+	case *pg_query.Node_FuncCall:
+		f := e.FuncCall
+		names := f.Funcname
+		if len(names) == 0 || f.AggStar || f.AggDistinct || f.Over != nil || len(f.AggOrder) > 0 || f.AggFilter != nil {
+			return "", false
+		}
+		fn := generatedExprFunctions[names[len(names)-1].GetString_().GetSval()]
+		if fn == "" {
+			return "", false
+		}
+		args, ok := translateGeneratedExprs(f.Args)
+		if !ok {
+			return "", false
+		}
+		return fn + "(" + strings.Join(args, ", ") + ")", true
+	}
+	return "", false
+}
+
+// translateGeneratedAExpr translates operations, including IN lists and
+// NULLIF, to GoogleSQL.
+func translateGeneratedAExpr(a *pg_query.A_Expr) (string, bool) {
+	if len(a.Name) != 1 {
+		return "", false
+	}
+	op := a.Name[0].GetString_().GetSval()
+	var lexpr string
+	if a.Lexpr != nil {
+		var ok bool
+		if lexpr, ok = translateGeneratedExpr(a.Lexpr); !ok {
+			return "", false
+		}
+	}
+	switch a.Kind {
+	case pg_query.A_Expr_Kind_AEXPR_OP:
+		rexpr, ok := translateGeneratedExpr(a.Rexpr)
+		if !ok {
+			return "", false
+		}
+		switch {
+		case op == "||":
+			return "CONCAT(" + lexpr + ", " + rexpr + ")", true
+		case op == "%":
+			return "MOD(" + lexpr + ", " + rexpr + ")", true
+		case a.Lexpr == nil && (op == "-" || op == "+"):
+			return "(" + op + rexpr + ")", true
+		case a.Lexpr != nil && generatedExprOperators[op] != "":
+			return "(" + lexpr + " " + generatedExprOperators[op] + " " + rexpr + ")", true
+		}
+	case pg_query.A_Expr_Kind_AEXPR_IN:
+		items, ok := translateGeneratedExprs(a.Rexpr.GetList().GetItems())
+		if !ok || len(items) == 0 {
+			return "", false
+		}
+		in := " IN ("
+		if op == "<>" {
+			in = " NOT IN ("
+		}
+		return "(" + lexpr + in + strings.Join(items, ", ") + "))", true
+	case pg_query.A_Expr_Kind_AEXPR_NULLIF:
+		rexpr, ok := translateGeneratedExpr(a.Rexpr)
+		if !ok {
+			return "", false
+		}
+		return "NULLIF(" + lexpr + ", " + rexpr + ")", true
+	}
+	return "", false
+}
+
+// translateGeneratedCaseExpr translates a CASE expression to GoogleSQL.
+func translateGeneratedCaseExpr(c *pg_query.CaseExpr) (string, bool) {
+	var sb strings.Builder
+	sb.WriteString("CASE")
+	if c.Arg != nil {
+		arg, ok := translateGeneratedExpr(c.Arg)
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(" " + arg)
+	}
+	for _, w := range c.Args {
+		when := w.GetCaseWhen()
+		if when == nil {
+			return "", false
+		}
+		cond, ok := translateGeneratedExpr(when.Expr)
+		if !ok {
+			return "", false
+		}
+		result, ok := translateGeneratedExpr(when.Result)
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(" WHEN " + cond + " THEN " + result)
+	}
+	if c.Defresult != nil {
+		result, ok := translateGeneratedExpr(c.Defresult)
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(" ELSE " + result)
+	}
+	sb.WriteString(" END")
+	return sb.String(), true
+}
+
+// translateGeneratedExprs translates a list of expressions to GoogleSQL.
+func translateGeneratedExprs(l []*pg_query.Node) ([]string, bool) {
+	var exprs []string
+	for _, n := range l {
+		s, ok := translateGeneratedExpr(n)
+		if !ok {
+			return nil, false
+		}
+		exprs = append(exprs, s)
+	}
+	return exprs, true
+}
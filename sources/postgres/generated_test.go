@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+)
+
+func TestToSpannerGeneratedExpression(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{"(price * (qty)::numeric)", "(`price` * CAST(`qty` AS NUMERIC))"},
+		{"((first_name || ' '::text) || last_name)", "CONCAT(CONCAT(`first_name`, ' '), `last_name`)"},
+		{"upper(\"left\"(code, 3))", ""},
+		{"lower(btrim(email))", "LOWER(TRIM(`email`))"},
+		{"(a % 2)", "MOD(`a`, 2)"},
+		{"CASE WHEN (qty > 10) THEN 'bulk'::text ELSE 'retail'::text END", "CASE WHEN (`qty` > 10) THEN 'bulk' ELSE 'retail' END"},
+		{"((note IS NOT NULL) AND (NOT archived))", "((`note` IS NOT NULL) AND (NOT `archived`))"},
+		{"COALESCE(nickname, 'it''s'::character varying)", "COALESCE(`nickname`, 'it\\'s')"},
+		{"(status = ANY (ARRAY['a'::text, 'b'::text]))", ""},
+		{"(status IN ('a', 'b'))", "(`status` IN ('a', 'b'))"},
+		{"(- balance)", "(-`balance`)"},
+		{"(price / 100.0)", ""},
+		{"to_char(created, 'YYYY'::text)", ""},
+		{"concat(a, b)", ""},
+		{"(doc ->> 'name'::text)", ""},
+	}
+	for _, tc := range testCases {
+		s, ok := toSpannerGeneratedExpression(tc.expr)
+		assert.Equal(t, tc.expected != "", ok, tc.expr)
+		assert.Equal(t, tc.expected, s, tc.expr)
+	}
+
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	s, ok := ToDdlImpl{}.ToSpannerGeneratedExpression(conv, "(a / 2)")
+	assert.True(t, ok)
+	assert.Equal(t, "(a / 2)", s)
+}
@@ -31,11 +31,14 @@ import (
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/streaming"
+	"github.com/google/uuid"
+	pg_query "github.com/pganalyze/pg_query_go/v6"
 )
 
 // InfoSchemaImpl postgres specific implementation for InfoSchema.
@@ -45,6 +48,12 @@ type InfoSchemaImpl struct {
 	SourceProfile      profiles.SourceProfile
 	TargetProfile      profiles.TargetProfile
 	IsSchemaUnique     *bool
+	// AuroraVersion is the version of Aurora PostgreSQL servers, see aurora.go.
+	AuroraVersion string
+	// ReaderDb is the reader endpoint of Aurora clusters, if it's known.
+	ReaderDb *sql.DB
+	// Metadata is the metadata of tables fetched in bulk, if it has been.
+	Metadata *common.BulkMetadata
 }
 
 func (isi InfoSchemaImpl) populateSchemaIsUnique(schemaAndNames []common.SchemaAndName) {
@@ -125,22 +134,28 @@ func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
 
 // GetRowsFromTable returns a sql Rows object for a table.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
+	q := fmt.Sprintf(`SELECT * FROM %s;`, quotedTableName(conv.SrcSchema[tableId]))
+	rows, err := isi.dataDb().Query(q)
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// quotedTableName returns the name of table, qualified by its schema and
+// quoted.
+func quotedTableName(table schema.Table) string {
 	// PostgreSQL schema and name can be arbitrary strings.
 	// Ideally we would pass schema/name as a query parameter,
 	// but PostgreSQL doesn't support this. So we quote it instead.
-	isSchemaNamePrefixed := strings.HasPrefix(conv.SrcSchema[tableId].Name, conv.SrcSchema[tableId].Schema+".")
+	isSchemaNamePrefixed := strings.HasPrefix(table.Name, table.Schema+".")
 	var tableName string
 	if isSchemaNamePrefixed {
-		tableName = strings.TrimPrefix(conv.SrcSchema[tableId].Name, conv.SrcSchema[tableId].Schema+".")
+		tableName = strings.TrimPrefix(table.Name, table.Schema+".")
 	} else {
-		tableName = conv.SrcSchema[tableId].Name
+		tableName = table.Name
 	}
-	q := fmt.Sprintf(`SELECT * FROM "%s"."%s";`, conv.SrcSchema[tableId].Schema, tableName)
-	rows, err := isi.Db.Query(q)
-	if err != nil {
-		return nil, err
-	}
-	return rows, err
+	return fmt.Sprintf(`"%s"."%s"`, table.Schema, tableName)
 }
 
 // ProcessDataRows performs data conversion for source database
@@ -163,6 +178,19 @@ func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string)
 // we can generate more targeted error messages: hence we pass
 // *interface{} parameters to row.Scan.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	readers := isi.SourceProfile.ParallelReaders()
+	chunkKey := chunkKeyOf(conv.SrcSchema[tableId])
+	if partitionReaders := common.PartitionReadersOf(conv.SrcSchema[tableId], readers); partitionReaders > 0 {
+		return isi.processPartitionsData(conv, tableId, srcSchema, colIds, spSchema, partitionReaders, chunkKey)
+	}
+	if readers > 1 {
+		if key, ranges := isi.getKeyRanges(conv.SrcSchema[tableId], readers); len(ranges) > 1 {
+			return isi.processKeyRangesData(conv, tableId, srcSchema, colIds, spSchema, key, ranges, readers, chunkKey)
+		}
+	}
+	if chunkKey != nil {
+		return isi.processChunksData(conv, tableId, srcSchema, colIds, spSchema, chunkKey)
+	}
 	srcTableName := conv.SrcSchema[tableId].Name
 	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
 	if err != nil {
@@ -182,19 +210,30 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
 			continue
 		}
-		newValues, err1 := common.PrepareValues(conv, tableId, colNameIdMap, colIds, srcCols, v)
-		cvtCols, cvtVals, err2 := convertSQLRow(conv, tableId, colIds, srcSchema, spSchema, newValues)
-		if err1 != nil || err2 != nil {
-			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
-			conv.StatsAddBadRow(srcTableName, conv.DataMode())
-			conv.CollectBadRow(srcTableName, srcCols, valsToStrings(v))
-			continue
-		}
-		conv.WriteRow(srcTableName, conv.SpSchema[tableId].Name, cvtCols, cvtVals)
+		processRowValues(conv, tableId, srcSchema, colIds, spSchema, colNameIdMap, srcCols, v)
 	}
 	return nil
 }
 
+// processRowValues converts the values v of the columns srcCols of a row
+// of a table, and writes them.
+func processRowValues(conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, colNameIdMap map[string]string, srcCols []string, v []interface{}) {
+	srcTableName := conv.SrcSchema[tableId].Name
+	newValues, err1 := common.PrepareValues(conv, tableId, colNameIdMap, colIds, srcCols, v)
+	cvtCols, cvtVals, err2 := convertSQLRow(conv, tableId, colIds, srcSchema, spSchema, newValues)
+	if err1 != nil || err2 != nil {
+		err := err1
+		if err == nil {
+			err = err2
+		}
+		conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcCols, valsToStrings(v))
+		return
+	}
+	conv.WriteRow(srcTableName, conv.SpSchema[tableId].Name, cvtCols, cvtVals)
+}
+
 // ConvertSQLRow performs data conversion for a single row of data
 // returned from a 'SELECT *' query. ConvertSQLRow assumes that
 // srcCols, spCols and srcVals all have the same length. Note that
@@ -241,7 +280,7 @@ func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error)
 	// Ideally we would pass schema/name as a query parameter,
 	// but PostgreSQL doesn't support this. So we quote it instead.
 	q := fmt.Sprintf(`SELECT COUNT(*) FROM "%s"."%s";`, table.Schema, table.Name)
-	rows, err := isi.Db.Query(q)
+	rows, err := isi.dataDb().Query(q)
 	if err != nil {
 		return 0, err
 	}
@@ -254,6 +293,40 @@ func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error)
 	return 0, nil //Check if 0 is ok to return
 }
 
+// GetMaxColumnLengths implements the common.ColumnLengthSampler interface.
+func (isi InfoSchemaImpl) GetMaxColumnLengths(table common.SchemaAndName, cols map[string]bool, sampleSize int64) (map[string]int64, error) {
+	var names, quoted, maxLengths []string
+	for col := range cols {
+		names = append(names, col)
+	}
+	sort.Strings(names)
+	for _, col := range names {
+		c := `"` + col + `"`
+		quoted = append(quoted, c)
+		if cols[col] {
+			maxLengths = append(maxLengths, fmt.Sprintf("MAX(octet_length(%s))", c))
+		} else {
+			maxLengths = append(maxLengths, fmt.Sprintf("MAX(char_length(%s))", c))
+		}
+	}
+	q := fmt.Sprintf(`SELECT %s FROM (SELECT %s FROM "%s"."%s" LIMIT %d) AS sample;`, strings.Join(maxLengths, ", "), strings.Join(quoted, ", "), table.Schema, table.Name, sampleSize)
+	values := make([]sql.NullInt64, len(names))
+	dest := make([]interface{}, len(names))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := isi.Db.QueryRow(q).Scan(dest...); err != nil {
+		return nil, err
+	}
+	lengths := make(map[string]int64)
+	for i, v := range values {
+		if v.Valid {
+			lengths[names[i]] = v.Int64
+		}
+	}
+	return lengths, nil
+}
+
 // GetTables return list of tables in the selected database.
 // TODO: All of the queries to get tables and table data should be in
 // a single transaction to ensure we obtain a consistent snapshot of
@@ -265,6 +338,12 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	for _, s := range []string{"information_schema", "postgres", "pg_catalog", "pg_temp_1", "pg_toast", "pg_toast_temp_1"} {
 		ignored[s] = true
 	}
+	if isi.AuroraVersion != "" {
+		for _, s := range auroraSchemas {
+			ignored[s] = true
+		}
+	}
+	partitions := isi.getPartitionTables()
 	q := "SELECT table_schema, table_name FROM information_schema.tables where table_type = 'BASE TABLE'"
 	rows, err := isi.Db.Query(q)
 	if err != nil {
@@ -275,33 +354,254 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	var tables []common.SchemaAndName
 	for rows.Next() {
 		rows.Scan(&tableSchema, &tableName)
-		if !ignored[tableSchema] {
+		// Partitions are migrated with their partitioned table.
+		if !ignored[tableSchema] && isi.isSchemaSelected(tableSchema) && !partitions[common.SchemaAndName{Schema: tableSchema, Name: tableName}] {
 			tables = append(tables, common.SchemaAndName{Schema: tableSchema, Name: tableName})
 		}
 	}
+	// Materialized views are migrated as tables, unless they're migrated as
+	// views. They aren't in information_schema.tables.
+	if isi.SourceProfile.MaterializedViews() == profiles.MaterializedViewsTable {
+		matviews, err := isi.getMaterializedViews()
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("couldn't get materialized views, they won't be migrated: %v", err))
+		}
+		for _, mv := range matviews {
+			if !ignored[mv.schema] && isi.isSchemaSelected(mv.schema) {
+				tables = append(tables, common.SchemaAndName{Schema: mv.schema, Name: mv.name})
+			}
+		}
+	}
 	isi.populateSchemaIsUnique(tables)
 	return tables, nil
 }
 
+// isSchemaSelected reports whether the objects of schema are migrated, i.e.
+// whether no schemas were selected in the source profile or schema is one
+// of them.
+func (isi InfoSchemaImpl) isSchemaSelected(schema string) bool {
+	schemas := isi.SourceProfile.Schemas()
+	if len(schemas) == 0 {
+		return true
+	}
+	for _, s := range schemas {
+		if s == schema {
+			return true
+		}
+	}
+	return false
+}
+
+// materializedView is a materialized view, as listed by pg_matviews.
+type materializedView struct {
+	schema, name, query string
+}
+
+// getMaterializedViews returns the materialized views of the database.
+func (isi InfoSchemaImpl) getMaterializedViews() ([]materializedView, error) {
+	q := `SELECT schemaname, matviewname, definition FROM pg_matviews
+		WHERE schemaname NOT IN ('information_schema', 'pg_catalog') ORDER BY schemaname, matviewname;`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var matviews []materializedView
+	for rows.Next() {
+		var mv materializedView
+		if err := rows.Scan(&mv.schema, &mv.name, &mv.query); err != nil {
+			return nil, err
+		}
+		matviews = append(matviews, mv)
+	}
+	return matviews, rows.Err()
+}
+
+// GetMaterializedViews implements the common.MaterializedViewSource
+// interface.
+func (isi InfoSchemaImpl) GetMaterializedViews(tables []common.SchemaAndName) (map[common.SchemaAndName]string, error) {
+	if isi.SourceProfile.MaterializedViews() != profiles.MaterializedViewsTable {
+		return nil, nil
+	}
+	matviews, err := isi.getMaterializedViews()
+	if err != nil {
+		return nil, err
+	}
+	included := make(map[common.SchemaAndName]bool)
+	for _, t := range tables {
+		included[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] = true
+	}
+	queries := make(map[common.SchemaAndName]string)
+	for _, mv := range matviews {
+		if t := (common.SchemaAndName{Schema: mv.schema, Name: mv.name}); included[t] {
+			queries[t] = mv.query
+		}
+	}
+	return queries, nil
+}
+
+// GetViews implements the common.ViewSource interface. Materialized views
+// are included when they're migrated as views.
+func (isi InfoSchemaImpl) GetViews() ([]schema.View, error) {
+	relkinds := "'v'"
+	if isi.SourceProfile.MaterializedViews() == profiles.MaterializedViewsView {
+		relkinds = "'v', 'm'"
+	}
+	q := fmt.Sprintf(`SELECT n.nspname, c.relname, pg_get_viewdef(c.oid), c.relkind = 'm'
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN (%s) AND n.nspname NOT IN ('information_schema', 'pg_catalog');`, relkinds)
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get views: %w", err)
+	}
+	defer rows.Close()
+	var views []schema.View
+	for rows.Next() {
+		var view schema.View
+		if err := rows.Scan(&view.Schema, &view.Name, &view.Query, &view.Materialized); err != nil {
+			return nil, fmt.Errorf("couldn't get views: %w", err)
+		}
+		if isi.isSchemaSelected(view.Schema) {
+			views = append(views, view)
+		}
+	}
+	return views, rows.Err()
+}
+
+// GetRoutines implements the common.RoutineSource interface. Functions
+// written in C, such as those of extensions, aren't included.
+func (isi InfoSchemaImpl) GetRoutines() ([]schema.Routine, error) {
+	q := `SELECT routine_schema, routine_name, routine_type, routine_definition
+		FROM information_schema.routines
+		WHERE routine_schema NOT IN ('information_schema', 'pg_catalog') AND external_language NOT IN ('C', 'INTERNAL')
+		ORDER BY routine_schema, routine_name;`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get routines: %w", err)
+	}
+	defer rows.Close()
+	var routines []schema.Routine
+	for rows.Next() {
+		var routine schema.Routine
+		var body sql.NullString
+		if err := rows.Scan(&routine.Schema, &routine.Name, &routine.Kind, &body); err != nil {
+			return nil, fmt.Errorf("couldn't get routines: %w", err)
+		}
+		routine.Body = body.String
+		if isi.isSchemaSelected(routine.Schema) {
+			routines = append(routines, routine)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't get routines: %w", err)
+	}
+
+	// Triggers are listed once per event.
+	q = `SELECT trigger_schema, trigger_name, event_object_table, action_timing, event_manipulation, action_statement
+		FROM information_schema.triggers
+		WHERE trigger_schema NOT IN ('information_schema', 'pg_catalog')
+		ORDER BY trigger_schema, event_object_table, trigger_name, event_manipulation;`
+	triggerRows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get triggers: %w", err)
+	}
+	defer triggerRows.Close()
+	for triggerRows.Next() {
+		trigger := schema.Routine{Kind: schema.RoutineTrigger}
+		var event string
+		if err := triggerRows.Scan(&trigger.Schema, &trigger.Name, &trigger.Table, &trigger.Timing, &event, &trigger.Body); err != nil {
+			return nil, fmt.Errorf("couldn't get triggers: %w", err)
+		}
+		if !isi.isSchemaSelected(trigger.Schema) {
+			continue
+		}
+		if n := len(routines); n > 0 {
+			last := &routines[n-1]
+			if last.Kind == schema.RoutineTrigger && last.Schema == trigger.Schema && last.Table == trigger.Table && last.Name == trigger.Name {
+				last.Events = append(last.Events, event)
+				continue
+			}
+		}
+		trigger.Events = []string{event}
+		routines = append(routines, trigger)
+	}
+	return routines, triggerRows.Err()
+}
+
 // GetColumns returns a list of Column objects and names
 func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
-	q := `SELECT c.column_name, c.data_type, e.data_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale
+	// For pgvector columns we return the formatted type e.g. vector(3), since
+	// the vector length isn't available in information_schema. For columns of
+	// enum types we return their values e.g. enum('small','large'), and for
+	// PostGIS and extension columns their type e.g. geometry or hstore. Identity columns
+	// are returned with the name and options of their sequence.
+	q := `SELECT c.column_name,
+                CASE WHEN c.data_type = 'USER-DEFINED' AND c.udt_name = 'vector'
+                  THEN (SELECT format_type(a.atttypid, a.atttypmod) FROM pg_attribute a
+                        WHERE a.attrelid = (quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass AND a.attname = c.column_name)
+                WHEN c.data_type = 'USER-DEFINED' AND c.udt_name IN ('geometry', 'geography', 'hstore', 'citext', 'ltree')
+                  THEN c.udt_name
+                WHEN c.data_type = 'USER-DEFINED' AND EXISTS (SELECT 1 FROM pg_enum en WHERE en.enumtypid = (quote_ident(c.udt_schema) || '.' || quote_ident(c.udt_name))::regtype)
+                  THEN (SELECT 'enum(' || string_agg(quote_literal(en.enumlabel), ',' ORDER BY en.enumsortorder) || ')' FROM pg_enum en
+                        WHERE en.enumtypid = (quote_ident(c.udt_schema) || '.' || quote_ident(c.udt_name))::regtype)
+                  ELSE c.data_type END,
+                e.data_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale,
+                c.identity_generation, pg_get_serial_sequence(quote_ident(c.table_schema) || '.' || quote_ident(c.table_name), c.column_name),
+                c.identity_start, c.identity_increment, c.identity_minimum, c.identity_maximum, c.identity_cycle, c.generation_expression
               FROM information_schema.COLUMNS c LEFT JOIN information_schema.element_types e
                  ON ((c.table_catalog, c.table_schema, c.table_name, 'TABLE', c.dtd_identifier)
                      = (e.object_catalog, e.object_schema, e.object_name, e.object_type, e.collection_type_identifier))
               where table_schema = $1 and table_name = $2 ORDER BY c.ordinal_position;`
-	cols, err := isi.Db.Query(q, table.Schema, table.Name)
+	cols, err := isi.Metadata.Query(isi.Db, common.MetadataColumns, table, q, table.Schema, table.Name)
 	if err != nil {
 		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
 	}
 	defer cols.Close()
+	colDefs, colIds := isi.toColumns(conv, table, cols, constraints)
+	if len(colIds) > 0 || isi.SourceProfile.MaterializedViews() != profiles.MaterializedViewsTable {
+		return colDefs, colIds, nil
+	}
+
+	// The columns of materialized views aren't in information_schema: they
+	// are read from pg_attribute, with the same columns as the query above.
+	q = `SELECT a.attname,
+                CASE WHEN t.typcategory = 'A' THEN 'ARRAY'
+                WHEN t.typname = 'vector' THEN format_type(a.atttypid, a.atttypmod)
+                WHEN t.typtype = 'e'
+                  THEN (SELECT 'enum(' || string_agg(quote_literal(en.enumlabel), ',' ORDER BY en.enumsortorder) || ')' FROM pg_enum en
+                        WHERE en.enumtypid = a.atttypid)
+                  ELSE format_type(a.atttypid, NULL) END,
+                format_type(t.typelem, NULL), CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END, NULL,
+                information_schema._pg_char_max_length(a.atttypid, a.atttypmod),
+                information_schema._pg_numeric_precision(a.atttypid, a.atttypmod),
+                information_schema._pg_numeric_scale(a.atttypid, a.atttypmod),
+                NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL
+              FROM pg_attribute a
+                JOIN pg_class c ON c.oid = a.attrelid
+                JOIN pg_type t ON t.oid = a.atttypid
+              WHERE a.attrelid = (quote_ident($1) || '.' || quote_ident($2))::regclass AND c.relkind = 'm'
+                AND a.attnum > 0 AND NOT a.attisdropped ORDER BY a.attnum;`
+	matviewCols, err := isi.Db.Query(q, table.Schema, table.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
+	}
+	defer matviewCols.Close()
+	colDefs, colIds = isi.toColumns(conv, table, matviewCols, constraints)
+	return colDefs, colIds, nil
+}
+
+// toColumns returns the columns of table read by GetColumns.
+func (isi InfoSchemaImpl) toColumns(conv *internal.Conv, table common.SchemaAndName, cols common.MetadataRows, constraints map[string][]string) (map[string]schema.Column, []string) {
 	colDefs := make(map[string]schema.Column)
 	var colIds []string
 	var colName, dataType, isNullable string
-	var colDefault, elementDataType sql.NullString
+	var colDefault, elementDataType, identityGeneration, identitySeq, generationExpr sql.NullString
+	var identityStart, identityIncrement, identityMin, identityMax, identityCycle sql.NullString
 	var charMaxLen, numericPrecision, numericScale sql.NullInt64
 	for cols.Next() {
-		err := cols.Scan(&colName, &dataType, &elementDataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale)
+		err := cols.Scan(&colName, &dataType, &elementDataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale,
+			&identityGeneration, &identitySeq, &identityStart, &identityIncrement, &identityMin, &identityMax, &identityCycle, &generationExpr)
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
@@ -320,17 +620,49 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 		}
 		ignored.Default = colDefault.Valid
 		colId := internal.GenerateColumnId()
+		var autoGen ddl.AutoGenCol
+		if identityGeneration.Valid {
+			seqName := fmt.Sprintf("%s_%s_seq", table.Name, colName)
+			if identitySeq.Valid {
+				// The sequence name is qualified by its schema e.g. public.t_id_seq.
+				seqName = trimQuote(identitySeq.String[strings.LastIndex(identitySeq.String, ".")+1:])
+			}
+			seq := identitySequence(seqName, dataType, identityOptions{
+				start:     identityStart.String,
+				increment: identityIncrement.String,
+				min:       identityMin.String,
+				max:       identityMax.String,
+				cycle:     identityCycle.String == "YES",
+			})
+			autoGen = addIdentitySequence(conv, seq, table.Id, colId)
+		}
+		var defaultVal ddl.DefaultValue
+		if seqName, ok := ddl.DefaultSequenceName(colDefault.String); ok && !identityGeneration.Valid {
+			// Serial columns, and columns defaulting to the next value of a
+			// sequence, take their values from a Spanner sequence instead.
+			autoGen = addIdentitySequence(conv, identitySequence(seqName, dataType, identityOptions{}), table.Id, colId)
+			ignored.Default = false
+		} else if colDefault.Valid {
+			defaultVal = ddl.DefaultValue{
+				IsPresent: true,
+				Value:     ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: colDefault.String},
+			}
+		}
 		c := schema.Column{
-			Id:      colId,
-			Name:    colName,
-			Type:    toType(dataType, elementDataType, charMaxLen, numericPrecision, numericScale),
-			NotNull: common.ToNotNull(conv, isNullable),
-			Ignored: ignored,
+			Id:              colId,
+			Name:            colName,
+			Type:            toType(dataType, elementDataType, charMaxLen, numericPrecision, numericScale),
+			NotNull:         common.ToNotNull(conv, isNullable),
+			Ignored:         ignored,
+			EnumValues:      common.ParseEnumValues(dataType),
+			AutoGen:         autoGen,
+			DefaultValue:    defaultVal,
+			GeneratedColumn: toGeneratedColumn(generationExpr.String),
 		}
 		colDefs[colId] = c
 		colIds = append(colIds, colId)
 	}
-	return colDefs, colIds, nil
+	return colDefs, colIds
 }
 
 // GetConstraints returns a list of primary keys and by-column map of
@@ -343,7 +675,7 @@ func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.Schem
                 INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
                   ON t.CONSTRAINT_NAME = k.CONSTRAINT_NAME AND t.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
               WHERE k.TABLE_SCHEMA = $1 AND k.TABLE_NAME = $2 ORDER BY k.ordinal_position;`
-	rows, err := isi.Db.Query(q, table.Schema, table.Name)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataConstraints, table, q, table.Schema, table.Name)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -395,7 +727,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 			rc.constraint_schema = $1
 			AND kcu.table_name = $2;`
 
-	rows, err := isi.Db.Query(q, table.Schema, table.Name)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataForeignKeys, table, q, table.Schema, table.Name)
 
 	if err != nil {
 		return nil, err
@@ -448,10 +780,13 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
 	q := `SELECT
 			irel.relname AS index_name,
-			a.attname AS column_name,
-			1 + Array_position(i.indkey, a.attnum) AS column_position,
+			COALESCE(a.attname, pg_get_indexdef(i.indexrelid, c.ordinality::int, true)) AS column_name,
+			c.ordinality AS column_position,
 			i.indisunique AS is_unique,
-			CASE o.OPTION & 1 WHEN 1 THEN 'DESC' ELSE 'ASC' END AS order
+			CASE o.OPTION & 1 WHEN 1 THEN 'DESC' ELSE 'ASC' END AS order,
+			am.amname AS index_method,
+			opc.opcname AS opclass,
+			pg_get_expr(i.indpred, i.indrelid) AS predicate
 		FROM pg_index AS i
 		JOIN pg_class AS trel
 		ON trel.oid = i.indrelid
@@ -459,10 +794,14 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		ON trel.relnamespace = tnsp.oid
 		JOIN pg_class AS irel
 		ON irel.oid = i.indexrelid
+		JOIN pg_am AS am
+		ON am.oid = irel.relam
+		LEFT JOIN pg_opclass AS opc
+		ON opc.oid = i.indclass[c.ordinality::int - 1]
 		CROSS JOIN LATERAL UNNEST (i.indkey) WITH ordinality AS c (colnum, ordinality)
 		LEFT JOIN LATERAL UNNEST (i.indoption) WITH ordinality AS o (OPTION, ordinality)
 		ON c.ordinality = o.ordinality
-		JOIN pg_attribute AS a
+		LEFT JOIN pg_attribute AS a
 		ON trel.oid = a.attrelid
 			AND a.attnum = c.colnum
 		WHERE tnsp.nspname= $1
@@ -471,45 +810,223 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		GROUP BY tnsp.nspname,
            		trel.relname,
            		irel.relname,
+           		i.indexrelid,
            		a.attname,
-           		array_position(i.indkey, a.attnum),
-           		o.OPTION,i.indisunique
-		ORDER BY irel.relname, array_position(i.indkey, a.attnum);`
-	rows, err := isi.Db.Query(q, table.Schema, table.Name)
+           		c.ordinality,
+           		o.OPTION,i.indisunique,
+           		am.amname,
+           		opc.opcname,
+           		pg_get_expr(i.indpred, i.indrelid)
+		ORDER BY irel.relname, c.ordinality;`
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataIndexes, table, q, table.Schema, table.Name)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var name, column, sequence, isUnique, collation string
+	var name, column, sequence, isUnique, collation, indexMethod string
+	var opclass, predicate sql.NullString
 	indexMap := make(map[string]schema.Index)
+	predicates := make(map[string]string)
 	var indexNames []string
 	var indexes []schema.Index
 	for rows.Next() {
-		if err := rows.Scan(&name, &column, &sequence, &isUnique, &collation); err != nil {
+		if err := rows.Scan(&name, &column, &sequence, &isUnique, &collation, &indexMethod, &opclass, &predicate); err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
 		}
 		if _, found := indexMap[name]; !found {
 			indexNames = append(indexNames, name)
 			indexMap[name] = schema.Index{
-				Id:     internal.GenerateIndexesId(),
-				Name:   name,
-				Unique: (isUnique == "true")}
+				Id:             internal.GenerateIndexesId(),
+				Name:           name,
+				Unique:         (isUnique == "true"),
+				FullText:       isFullTextOpclass(indexMethod, opclass.String),
+				Spatial:        isSpatialOpclass(opclass.String),
+				VectorDistance: toVectorDistance(indexMethod, opclass.String)}
+			predicates[name] = predicate.String
 		}
 		index := indexMap[name]
-		index.Keys = append(index.Keys, schema.Key{
-			ColId: colNameIdMap[column],
-			Desc:  (collation == "DESC")})
+		// Any tsvector key makes a GIN index full-text, not only the first.
+		index.FullText = index.FullText || isFullTextOpclass(indexMethod, opclass.String)
+		if colId, ok := colNameIdMap[column]; ok {
+			index.Keys = append(index.Keys, schema.Key{
+				ColId: colId,
+				Desc:  (collation == "DESC")})
+		} else if cols, config := getTsvectorIndexColumns(column); index.FullText && len(cols) > 0 {
+			// Expression keys, as returned by pg_get_indexdef, are only
+			// kept for the to_tsvector documents of full-text indexes.
+			for _, col := range cols {
+				index.Keys = append(index.Keys, schema.Key{ColId: colNameIdMap[col]})
+			}
+			if index.FullTextConfig == "" {
+				index.FullTextConfig = config
+			}
+		}
 		indexMap[name] = index
 	}
 	for _, k := range indexNames {
-		indexes = append(indexes, indexMap[k])
+		index := indexMap[k]
+		if len(index.Keys) == 0 {
+			// Indexes on expressions only.
+			continue
+		}
+		if predicates[k] != "" {
+			index.NullFiltered = isNullFilteredIndexPredicate(predicates[k], index.Keys, colNameIdMap)
+		}
+		indexes = append(indexes, index)
 	}
 	return indexes, nil
 }
 
+// WithBulkMetadata implements the common.BulkMetadataSource interface. The
+// metadata of the tables of the database is fetched with the queries of
+// that of a table, without their filter on the table. Those of system
+// tables are left out.
+func (isi InfoSchemaImpl) WithBulkMetadata(tables []common.SchemaAndName) (common.InfoSchema, error) {
+	queries := map[string]string{
+		common.MetadataColumns: `SELECT c.table_schema, c.table_name, c.column_name,
+                CASE WHEN c.data_type = 'USER-DEFINED' AND c.udt_name = 'vector'
+                  THEN (SELECT format_type(a.atttypid, a.atttypmod) FROM pg_attribute a
+                        WHERE a.attrelid = (quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass AND a.attname = c.column_name)
+                WHEN c.data_type = 'USER-DEFINED' AND c.udt_name IN ('geometry', 'geography', 'hstore', 'citext', 'ltree')
+                  THEN c.udt_name
+                WHEN c.data_type = 'USER-DEFINED' AND EXISTS (SELECT 1 FROM pg_enum en WHERE en.enumtypid = (quote_ident(c.udt_schema) || '.' || quote_ident(c.udt_name))::regtype)
+                  THEN (SELECT 'enum(' || string_agg(quote_literal(en.enumlabel), ',' ORDER BY en.enumsortorder) || ')' FROM pg_enum en
+                        WHERE en.enumtypid = (quote_ident(c.udt_schema) || '.' || quote_ident(c.udt_name))::regtype)
+                  ELSE c.data_type END,
+                e.data_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale,
+                c.identity_generation, pg_get_serial_sequence(quote_ident(c.table_schema) || '.' || quote_ident(c.table_name), c.column_name),
+                c.identity_start, c.identity_increment, c.identity_minimum, c.identity_maximum, c.identity_cycle, c.generation_expression
+              FROM information_schema.COLUMNS c LEFT JOIN information_schema.element_types e
+                 ON ((c.table_catalog, c.table_schema, c.table_name, 'TABLE', c.dtd_identifier)
+                     = (e.object_catalog, e.object_schema, e.object_name, e.object_type, e.collection_type_identifier))
+              WHERE c.table_schema NOT IN ('pg_catalog', 'information_schema') ORDER BY c.table_schema, c.table_name, c.ordinal_position;`,
+		common.MetadataConstraints: `SELECT k.TABLE_SCHEMA, k.TABLE_NAME, k.COLUMN_NAME, t.CONSTRAINT_TYPE
+              FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS t
+                INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
+                  ON t.CONSTRAINT_NAME = k.CONSTRAINT_NAME AND t.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+              WHERE k.TABLE_SCHEMA NOT IN ('pg_catalog', 'information_schema') ORDER BY k.TABLE_SCHEMA, k.TABLE_NAME, k.ordinal_position;`,
+		common.MetadataForeignKeys: `SELECT
+			rc.constraint_schema,
+			kcu.table_name,
+			rc.constraint_schema AS "TABLE_SCHEMA",
+			ccu.table_name AS "REFERENCED_TABLE_NAME",
+			kcu.column_name AS "COLUMN_NAME",
+			ccu.column_name AS "REF_COLUMN_NAME",
+			rc.constraint_name AS "CONSTRAINT_NAME",
+			rc.delete_rule AS "ON_DELETE",
+			rc.update_rule AS "ON_UPDATE"
+		FROM
+			INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc
+		INNER JOIN
+			INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+			ON rc.constraint_name = kcu.constraint_name
+			AND rc.constraint_schema = kcu.constraint_schema
+		INNER JOIN
+			INFORMATION_SCHEMA.CONSTRAINT_COLUMN_USAGE ccu
+			ON rc.constraint_name = ccu.constraint_name
+			AND rc.constraint_schema = ccu.constraint_schema
+		WHERE
+			rc.constraint_schema NOT IN ('pg_catalog', 'information_schema');`,
+		common.MetadataIndexes: `SELECT
+			tnsp.nspname,
+			trel.relname,
+			irel.relname AS index_name,
+			COALESCE(a.attname, pg_get_indexdef(i.indexrelid, c.ordinality::int, true)) AS column_name,
+			c.ordinality AS column_position,
+			i.indisunique AS is_unique,
+			CASE o.OPTION & 1 WHEN 1 THEN 'DESC' ELSE 'ASC' END AS order,
+			am.amname AS index_method,
+			opc.opcname AS opclass,
+			pg_get_expr(i.indpred, i.indrelid) AS predicate
+		FROM pg_index AS i
+		JOIN pg_class AS trel
+		ON trel.oid = i.indrelid
+		JOIN pg_namespace AS tnsp
+		ON trel.relnamespace = tnsp.oid
+		JOIN pg_class AS irel
+		ON irel.oid = i.indexrelid
+		JOIN pg_am AS am
+		ON am.oid = irel.relam
+		LEFT JOIN pg_opclass AS opc
+		ON opc.oid = i.indclass[c.ordinality::int - 1]
+		CROSS JOIN LATERAL UNNEST (i.indkey) WITH ordinality AS c (colnum, ordinality)
+		LEFT JOIN LATERAL UNNEST (i.indoption) WITH ordinality AS o (OPTION, ordinality)
+		ON c.ordinality = o.ordinality
+		LEFT JOIN pg_attribute AS a
+		ON trel.oid = a.attrelid
+			AND a.attnum = c.colnum
+		WHERE tnsp.nspname NOT IN ('pg_catalog', 'information_schema')
+			AND i.indisprimary = false
+		GROUP BY tnsp.nspname,
+           		trel.relname,
+           		irel.relname,
+           		i.indexrelid,
+           		a.attname,
+           		c.ordinality,
+           		o.OPTION,i.indisunique,
+           		am.amname,
+           		opc.opcname,
+           		pg_get_expr(i.indpred, i.indrelid)
+		ORDER BY tnsp.nspname, trel.relname, irel.relname, c.ordinality;`,
+	}
+	metadata := &common.BulkMetadata{}
+	for kind, q := range queries {
+		if err := metadata.Fetch(isi.Db, kind, q); err != nil {
+			return isi, err
+		}
+	}
+	isi.Metadata = metadata
+	return isi, nil
+}
+
+// isSpatialOpclass returns true for the operator classes of PostGIS
+// indexes e.g. gist_geometry_ops_2d or brin_geography_inclusion_ops.
+func isSpatialOpclass(opclass string) bool {
+	return strings.Contains(opclass, "geometry") || strings.Contains(opclass, "geography")
+}
+
+// isNullFilteredIndexPredicate parses a partial index predicate, as returned
+// by pg_get_expr, and checks if the index maps to a NULL_FILTERED index.
+func isNullFilteredIndexPredicate(predicate string, keys []schema.Key, colNameIdMap map[string]string) bool {
+	tree, err := pg_query.Parse("SELECT 1 WHERE " + predicate)
+	if err != nil || len(tree.GetStmts()) != 1 {
+		return false
+	}
+	sel, ok := tree.GetStmts()[0].GetStmt().GetNode().(*pg_query.Node_SelectStmt)
+	if !ok || sel.SelectStmt.GetWhereClause() == nil {
+		return false
+	}
+	return isNullFilteredPredicate(sel.SelectStmt.GetWhereClause(), keys, colNameIdMap)
+}
+
+// getTsvectorIndexColumns parses an index key expression, as returned by
+// pg_get_indexdef, and returns the columns of its to_tsvector documents along
+// with their text search configuration.
+func getTsvectorIndexColumns(expr string) ([]string, string) {
+	tree, err := pg_query.Parse("SELECT " + expr)
+	if err != nil || len(tree.GetStmts()) != 1 {
+		return nil, ""
+	}
+	sel, ok := tree.GetStmts()[0].GetStmt().GetNode().(*pg_query.Node_SelectStmt)
+	if !ok || len(sel.SelectStmt.GetTargetList()) != 1 {
+		return nil, ""
+	}
+	return getTsvectorColumns(sel.SelectStmt.GetTargetList()[0].GetResTarget().GetVal())
+}
+
 func toType(dataType string, elementDataType sql.NullString, charLen sql.NullInt64, numericPrecision, numericScale sql.NullInt64) schema.Type {
 	switch {
+	case strings.HasPrefix(dataType, "vector"):
+		// pgvector types are reported as vector(n) by GetColumns.
+		var vectorLen int64
+		if _, err := fmt.Sscanf(dataType, "vector(%d)", &vectorLen); err == nil {
+			return schema.Type{Name: "vector", Mods: []int64{vectorLen}}
+		}
+		return schema.Type{Name: "vector"}
+	case strings.HasPrefix(dataType, "enum("):
+		// Values of enum types are reported as enum('a','b') by GetColumns.
+		return schema.Type{Name: "enum"}
 	case dataType == "ARRAY" && elementDataType.Valid:
 		return schema.Type{Name: elementDataType.String, ArrayBounds: []int64{-1}}
 		// TODO: handle error cases.
@@ -550,6 +1067,23 @@ func cvtSQLArray(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef, v
 //	string
 //	time.Time
 func cvtSQLScalar(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef, val interface{}) (interface{}, error) {
+	if isSpatialType(srcCd.Type.Name) {
+		// PostGIS values are read as hex-encoded EWKB.
+		switch v := val.(type) {
+		case []byte:
+			return common.ConvSpatial(spCd.T, string(v))
+		case string:
+			return common.ConvSpatial(spCd.T, v)
+		}
+	}
+	if toExtensionType(srcCd.Type.Name) == "hstore" && spCd.T.Name == ddl.JSON {
+		switch v := val.(type) {
+		case []byte:
+			return convHstore(string(v))
+		case string:
+			return convHstore(v)
+		}
+	}
 	switch spCd.T.Name {
 	case ddl.Bool:
 		switch v := val.(type) {
@@ -651,6 +1185,15 @@ func cvtSQLScalar(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef,
 		case []uint8:
 			return string(v), nil
 		}
+	case ddl.UUID:
+		switch v := val.(type) {
+		case string:
+			return convUUID(v)
+		case []byte:
+			return convUUID(string(v))
+		case [16]byte:
+			return uuid.UUID(v).String(), nil
+		}
 	}
 	return nil, fmt.Errorf("can't convert value of type %s to Spanner type %s", reflect.TypeOf(val), reflect.TypeOf(spCd.T))
 }
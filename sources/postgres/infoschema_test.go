@@ -19,6 +19,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"math/big"
+	"regexp"
 	"testing"
 	"time"
 
@@ -80,17 +81,17 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "user"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"},
 			rows: [][]driver.Value{
-				{"user_id", "text", nil, "NO", nil, nil, nil, nil},
-				{"name", "text", nil, "NO", nil, nil, nil, nil},
-				{"ref", "bigint", nil, "YES", nil, nil, nil, nil}},
+				{"user_id", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"name", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"ref", "bigint", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "user"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_method", "opclass", "predicate"},
 		},
 
 		{
@@ -112,22 +113,22 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "cart"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"},
 			rows: [][]driver.Value{
-				{"productid", "text", nil, "NO", nil, nil, nil, nil},
-				{"userid", "text", nil, "NO", nil, nil, nil, nil},
-				{"quantity", "bigint", nil, "YES", nil, nil, 64, 0}},
+				{"productid", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"userid", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"quantity", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "cart"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
-			rows: [][]driver.Value{{"index1", "userid", 1, "false", "ASC"},
-				{"index2", "userid", 1, "true", "ASC"},
-				{"index2", "productid", 2, "true", "DESC"},
-				{"index3", "productid", 1, "true", "DESC"},
-				{"index3", "userid", 2, "true", "ASC"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_method", "opclass", "predicate"},
+			rows: [][]driver.Value{{"index1", "userid", 1, "false", "ASC", "btree", "text_ops", "(userid IS NOT NULL)"},
+				{"index2", "userid", 1, "true", "ASC", "btree", "text_ops", nil},
+				{"index2", "productid", 2, "true", "DESC", "btree", "text_ops", nil},
+				{"index3", "productid", 1, "true", "DESC", "btree", "text_ops", "(productid IS NOT NULL)"},
+				{"index3", "userid", 2, "true", "ASC", "btree", "text_ops", "(productid IS NOT NULL)"},
 			},
 		},
 		{
@@ -145,16 +146,16 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "product"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"},
 			rows: [][]driver.Value{
-				{"product_id", "text", nil, "NO", nil, nil, nil, nil},
-				{"product_name", "text", nil, "NO", nil, nil, nil, nil}},
+				{"product_id", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"product_name", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "product"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_method", "opclass", "predicate"},
 		},
 
 		{
@@ -173,35 +174,35 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"},
 			rows: [][]driver.Value{
-				{"id", "bigint", nil, "NO", nil, nil, 64, 0},
-				{"aint", "ARRAY", "integer", "YES", nil, nil, nil, nil},
-				{"atext", "ARRAY", "text", "YES", nil, nil, nil, nil},
-				{"b", "boolean", nil, "YES", nil, nil, nil, nil},
-				{"bs", "bigint", nil, "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0},
-				{"by", "bytea", nil, "YES", nil, nil, nil, nil},
-				{"c", "character", nil, "YES", nil, 1, nil, nil},
-				{"c_8", "character", nil, "YES", nil, 8, nil, nil},
-				{"d", "date", nil, "YES", nil, nil, nil, nil},
-				{"f8", "double precision", nil, "YES", nil, nil, 53, nil},
-				{"f4", "real", nil, "YES", nil, nil, 24, nil},
-				{"i8", "bigint", nil, "YES", nil, nil, 64, 0},
-				{"i4", "integer", nil, "YES", nil, nil, 32, 0},
-				{"i2", "smallint", nil, "YES", nil, nil, 16, 0},
-				{"num", "numeric", nil, "YES", nil, nil, nil, nil},
-				{"s", "integer", nil, "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0},
-				{"ts", "timestamp without time zone", nil, "YES", nil, nil, nil, nil},
-				{"tz", "timestamp with time zone", nil, "YES", nil, nil, nil, nil},
-				{"txt", "text", nil, "NO", nil, nil, nil, nil},
-				{"vc", "character varying", nil, "YES", nil, nil, nil, nil},
-				{"vc6", "character varying", nil, "YES", nil, 6, nil, nil}},
+				{"id", "bigint", nil, "NO", nil, nil, 64, 0, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"aint", "ARRAY", "integer", "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"atext", "ARRAY", "text", "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"b", "boolean", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"bs", "bigint", nil, "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"by", "bytea", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"c", "character", nil, "YES", nil, 1, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"c_8", "character", nil, "YES", nil, 8, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"d", "date", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"f8", "double precision", nil, "YES", nil, nil, 53, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"f4", "real", nil, "YES", nil, nil, 24, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"i8", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"i4", "integer", nil, "YES", nil, nil, 32, 0, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"i2", "smallint", nil, "YES", nil, nil, 16, 0, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"num", "numeric", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"s", "integer", nil, "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"ts", "timestamp without time zone", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"tz", "timestamp with time zone", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"txt", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"vc", "character varying", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"vc6", "character varying", nil, "YES", nil, 6, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_method", "opclass", "predicate"},
 		},
 
 		{
@@ -219,17 +220,17 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test_ref"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"},
 			rows: [][]driver.Value{
-				{"ref_id", "bigint", nil, "NO", nil, nil, 64, 0},
-				{"ref_txt", "text", nil, "NO", nil, nil, nil, nil},
-				{"abc", "text", nil, "NO", nil, nil, nil, nil}},
+				{"ref_id", "bigint", nil, "NO", nil, nil, 64, 0, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"ref_txt", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"abc", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "test_ref"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_method", "opclass", "predicate"},
 		},
 	}
 	db := mkMockDB(t, ms)
@@ -246,7 +247,7 @@ func TestProcessSchema(t *testing.T) {
 		ExpressionVerificationAccessor: mockAccessor,
 		DdlV:                           &expressions_api.MockDDLVerifier{},
 	}
-	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr(), "", nil, nil}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
 	assert.Nil(t, err)
 	expectedSchema := map[string]ddl.CreateTable{
 		"user": ddl.CreateTable{
@@ -270,7 +271,7 @@ func TestProcessSchema(t *testing.T) {
 			PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "productid", Order: 1}, ddl.IndexKey{ColId: "userid", Order: 2}},
 			ForeignKeys: []ddl.Foreignkey{ddl.Foreignkey{Name: "fk_test2", ColIds: []string{"productid"}, ReferTableId: "product", ReferColumnIds: []string{"product_id"}, OnDelete: constants.FK_NO_ACTION, OnUpdate: constants.FK_NO_ACTION},
 				ddl.Foreignkey{Name: "fk_test3", ColIds: []string{"userid"}, ReferTableId: "user", ReferColumnIds: []string{"user_id"}, OnDelete: constants.FK_NO_ACTION, OnUpdate: constants.FK_NO_ACTION}},
-			Indexes: []ddl.CreateIndex{ddl.CreateIndex{Name: "index1", TableId: "cart", Unique: false, Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "userid", Desc: false, Order: 1}}},
+			Indexes: []ddl.CreateIndex{ddl.CreateIndex{Name: "index1", TableId: "cart", Unique: false, Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "userid", Desc: false, Order: 1}}, NullFiltered: true},
 				ddl.CreateIndex{Name: "index2", TableId: "cart", Unique: true, Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "userid", Desc: false, Order: 1}, ddl.IndexKey{ColId: "productid", Desc: true, Order: 2}}},
 				ddl.CreateIndex{Name: "index3", TableId: "cart", Unique: true, Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "productid", Desc: true, Order: 1}, ddl.IndexKey{ColId: "userid", Desc: false, Order: 2}}}}},
 		"product": ddl.CreateTable{
@@ -286,10 +287,10 @@ func TestProcessSchema(t *testing.T) {
 			ColIds: []string{"id", "aint", "atext", "b", "bs", "by", "c", "c_8", "d", "f8", "f4", "i8", "i4", "i2", "num", "s", "ts", "tz", "txt", "vc", "vc6"},
 			ColDefs: map[string]ddl.ColumnDef{
 				"id":    ddl.ColumnDef{Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
-				"aint":  ddl.ColumnDef{Name: "aint", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false}},
-				"atext": ddl.ColumnDef{Name: "atext", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false}},
+				"aint":  ddl.ColumnDef{Name: "aint", T: ddl.Type{Name: ddl.Int64, IsArray: true}},
+				"atext": ddl.ColumnDef{Name: "atext", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
 				"b":     ddl.ColumnDef{Name: "b", T: ddl.Type{Name: ddl.Bool}},
-				"bs":    ddl.ColumnDef{Name: "bs", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"bs":    ddl.ColumnDef{Name: "bs", T: ddl.Type{Name: ddl.Int64}, NotNull: true, AutoGen: ddl.AutoGenCol{Name: "test11_bs_seq", GenerationType: constants.SEQUENCE}},
 				"by":    ddl.ColumnDef{Name: "by", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
 				"c":     ddl.ColumnDef{Name: "c", T: ddl.Type{Name: ddl.String, Len: int64(1)}},
 				"c_8":   ddl.ColumnDef{Name: "c_8", T: ddl.Type{Name: ddl.String, Len: int64(8)}},
@@ -300,7 +301,7 @@ func TestProcessSchema(t *testing.T) {
 				"i4":    ddl.ColumnDef{Name: "i4", T: ddl.Type{Name: ddl.Int64}},
 				"i2":    ddl.ColumnDef{Name: "i2", T: ddl.Type{Name: ddl.Int64}},
 				"num":   ddl.ColumnDef{Name: "num", T: ddl.Type{Name: ddl.Numeric}},
-				"s":     ddl.ColumnDef{Name: "s", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"s":     ddl.ColumnDef{Name: "s", T: ddl.Type{Name: ddl.Int64}, NotNull: true, AutoGen: ddl.AutoGenCol{Name: "test11_s_seq", GenerationType: constants.SEQUENCE}},
 				"ts":    ddl.ColumnDef{Name: "ts", T: ddl.Type{Name: ddl.Timestamp}},
 				"tz":    ddl.ColumnDef{Name: "tz", T: ddl.Type{Name: ddl.Timestamp}},
 				"txt":   ddl.ColumnDef{Name: "txt", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
@@ -325,10 +326,10 @@ func TestProcessSchema(t *testing.T) {
 	assert.Equal(t, len(conv.SchemaIssues[cartTableId].ColumnLevelIssues), 0)
 	expectedIssues := map[string][]internal.SchemaIssue{
 		"aint":  []internal.SchemaIssue{internal.Widened, internal.ArrayTypeNotSupported},
-		"bs":    []internal.SchemaIssue{internal.DefaultValue},
+		"bs":    []internal.SchemaIssue{internal.SequenceCreated},
 		"i4":    []internal.SchemaIssue{internal.Widened},
 		"i2":    []internal.SchemaIssue{internal.Widened},
-		"s":     []internal.SchemaIssue{internal.Widened, internal.DefaultValue},
+		"s":     []internal.SchemaIssue{internal.Widened, internal.SequenceCreated},
 		"ts":    []internal.SchemaIssue{internal.Timestamp},
 		"atext": []internal.SchemaIssue{internal.ArrayTypeNotSupported},
 	}
@@ -381,7 +382,7 @@ func TestProcessData(t *testing.T) {
 			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
 		})
 	commonInfoSchema := common.InfoSchemaImpl{}
-	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, internal.AdditionalDataAttributes{})
+	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr(), "", nil, nil}, internal.AdditionalDataAttributes{})
 
 	assert.Equal(t,
 		[]spannerData{
@@ -508,17 +509,17 @@ func TestConvertSqlRow_MultiCol(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"},
 			rows: [][]driver.Value{
-				{"a", "text", nil, "NO", nil, nil, nil, nil},
-				{"b", "double precision", nil, "YES", nil, nil, 53, nil},
-				{"c", "bigint", nil, "YES", nil, nil, 64, 0}},
+				{"a", "text", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"b", "double precision", nil, "YES", nil, nil, 53, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+				{"c", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil, nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_method", "opclass", "predicate"},
 		},
 		{
 			query: `SELECT [*] FROM "public"."test"`, // query is a regexp!
@@ -542,7 +543,7 @@ func TestConvertSqlRow_MultiCol(t *testing.T) {
 		ExpressionVerificationAccessor: mockAccessor,
 		DdlV:                           &expressions_api.MockDDLVerifier{},
 	}
-	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr(), "", nil, nil}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
 	assert.Nil(t, err)
 	conv.SetDataMode()
 	var rows []spannerData
@@ -551,7 +552,7 @@ func TestConvertSqlRow_MultiCol(t *testing.T) {
 			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
 		})
 	commonInfoSchema := common.InfoSchemaImpl{}
-	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, internal.AdditionalDataAttributes{})
+	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr(), "", nil, nil}, internal.AdditionalDataAttributes{})
 	assert.Equal(t, []spannerData{
 		{table: "test", cols: []string{"a", "b", "synth_id"}, vals: []interface{}{"cat", float64(42.3), "0"}},
 		{table: "test", cols: []string{"a", "c", "synth_id"}, vals: []interface{}{"dog", int64(22), "-9223372036854775808"}}},
@@ -559,6 +560,41 @@ func TestConvertSqlRow_MultiCol(t *testing.T) {
 	assert.Equal(t, int64(0), conv.Unexpecteds())
 }
 
+func TestGetIndexes_ExpressionKeys(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM pg_index (.+)",
+			args:  []driver.Value{"public", "posts"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_method", "opclass", "predicate"},
+			rows: [][]driver.Value{
+				{"posts_fts", "to_tsvector('english'::regconfig, (title || ' '::text) || COALESCE(body, ''::text))", 1, "false", "ASC", "gin", "tsvector_ops", nil},
+				{"posts_lower", "lower(title)", 1, "false", "ASC", "btree", "text_ops", nil},
+				{"posts_title_lower", "title", 1, "false", "ASC", "btree", "text_ops", nil},
+				{"posts_title_lower", "lower(body)", 2, "false", "ASC", "btree", "text_ops", nil},
+				{"posts_attrs", "attrs", 1, "false", "ASC", "gin", "jsonb_ops", nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	isi := InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr(), "", nil, nil}
+	colNameIdMap := map[string]string{"id": "c1", "title": "c2", "body": "c3", "attrs": "c4"}
+	indexes, err := isi.GetIndexes(conv, common.SchemaAndName{Schema: "public", Name: "posts"}, colNameIdMap)
+	assert.Nil(t, err)
+	// Indexes on expressions only are left out, and expression keys of other
+	// indexes are skipped.
+	assert.Equal(t, 3, len(indexes))
+	assert.Equal(t, "posts_fts", indexes[0].Name)
+	assert.True(t, indexes[0].FullText)
+	assert.Equal(t, "english", indexes[0].FullTextConfig)
+	assert.Equal(t, []schema.Key{{ColId: "c2"}, {ColId: "c3"}}, indexes[0].Keys)
+	assert.Equal(t, "posts_title_lower", indexes[1].Name)
+	assert.Equal(t, []schema.Key{{ColId: "c2"}}, indexes[1].Keys)
+	// GIN indexes of jsonb columns aren't full-text indexes.
+	assert.Equal(t, "posts_attrs", indexes[2].Name)
+	assert.False(t, indexes[2].FullText)
+}
+
 func TestSetRowStats(t *testing.T) {
 	ms := []mockSpec{
 		{
@@ -579,12 +615,27 @@ func TestSetRowStats(t *testing.T) {
 	conv := internal.MakeConv()
 	conv.SetDataMode()
 	commonInfoSchema := common.InfoSchemaImpl{}
-	commonInfoSchema.SetRowStats(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()})
+	commonInfoSchema.SetRowStats(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr(), "", nil, nil})
 	assert.Equal(t, int64(5), conv.Stats.Rows["test1"])
 	assert.Equal(t, int64(142), conv.Stats.Rows["test2"])
 	assert.Equal(t, int64(0), conv.Unexpecteds())
 }
 
+func TestGetMaxColumnLengths(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta(`SELECT MAX(octet_length("data")), MAX(char_length("notes")), MAX(char_length("title")) FROM (SELECT "data", "notes", "title" FROM "public"."t" LIMIT 100) AS sample;`),
+			cols:  []string{"data", "notes", "title"},
+			rows:  [][]driver.Value{{12, 40, nil}},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr(), "", nil, nil}
+	lengths, err := isi.GetMaxColumnLengths(common.SchemaAndName{Schema: "public", Name: "t"}, map[string]bool{"title": false, "data": true, "notes": false}, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"data": 12, "notes": 40}, lengths)
+}
+
 func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
 	db, mock, err := sqlmock.New()
 	assert.Nil(t, err)
@@ -607,3 +658,159 @@ func newFalsePtr() *bool {
 	temp := false
 	return &temp
 }
+
+func TestGetViews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT n.nspname, c.relname, pg_get_viewdef(c.oid)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname", "pg_get_viewdef", "materialized"}).
+			AddRow("public", "active_users", " SELECT users.id\n   FROM users\n  WHERE users.active;", false))
+	isi := InfoSchemaImpl{Db: db}
+	views, err := isi.GetViews()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []schema.View{{Name: "active_users", Schema: "public", Query: " SELECT users.id\n   FROM users\n  WHERE users.active;"}}, views)
+}
+
+func TestMaterializedViews(t *testing.T) {
+	matviews := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"schemaname", "matviewname", "definition"}).
+			AddRow("public", "order_totals", " SELECT orders.customer_id,\n    sum(orders.amount) AS total\n   FROM orders\n  GROUP BY orders.customer_id;")
+	}
+
+	// Materialized views are migrated as tables by default.
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("table_type = 'BASE TABLE'")).
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name"}).AddRow("public", "orders"))
+	mock.ExpectQuery("FROM pg_matviews").WillReturnRows(matviews())
+	mock.ExpectQuery("FROM pg_matviews").WillReturnRows(matviews())
+	isi := InfoSchemaImpl{Db: db, IsSchemaUnique: newFalsePtr()}
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "public", Name: "orders"}, {Schema: "public", Name: "order_totals"}}, tables)
+	queries, err := isi.GetMaterializedViews(tables)
+	assert.Nil(t, err)
+	assert.Equal(t, map[common.SchemaAndName]string{
+		{Schema: "public", Name: "order_totals"}: " SELECT orders.customer_id,\n    sum(orders.amount) AS total\n   FROM orders\n  GROUP BY orders.customer_id;",
+	}, queries)
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	// Their columns aren't in information_schema.
+	db, mock, err = sqlmock.New()
+	assert.Nil(t, err)
+	columns := []string{"column_name", "data_type", "element_data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale",
+		"identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"}
+	mock.ExpectQuery("FROM information_schema.COLUMNS").WithArgs("public", "order_totals").WillReturnRows(sqlmock.NewRows(columns))
+	mock.ExpectQuery("FROM pg_attribute").WithArgs("public", "order_totals").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("customer_id", "integer", nil, "YES", nil, nil, 32, 0, nil, nil, nil, nil, nil, nil, nil, nil).
+		AddRow("total", "numeric", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
+	conv := internal.MakeConv()
+	colDefs, colIds, err := InfoSchemaImpl{Db: db}.GetColumns(conv, common.SchemaAndName{Schema: "public", Name: "order_totals"}, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(colIds))
+	assert.Equal(t, "customer_id", colDefs[colIds[0]].Name)
+	assert.Equal(t, schema.Type{Name: "integer", Mods: []int64{32}}, colDefs[colIds[0]].Type)
+	assert.Equal(t, "numeric", colDefs[colIds[1]].Type.Name)
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	// With materializedViews=view, they're migrated as views.
+	db, mock, err = sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("c.relkind IN ('v', 'm')")).
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname", "pg_get_viewdef", "materialized"}).
+			AddRow("public", "order_totals", " SELECT orders.customer_id FROM orders;", true))
+	sourceProfile := profiles.SourceProfile{Ty: profiles.SourceProfileTypeConnection, Conn: profiles.SourceProfileConnection{MaterializedViews: profiles.MaterializedViewsView}}
+	isi = InfoSchemaImpl{Db: db, SourceProfile: sourceProfile}
+	views, err := isi.GetViews()
+	assert.Nil(t, err)
+	assert.Equal(t, []schema.View{{Name: "order_totals", Schema: "public", Query: " SELECT orders.customer_id FROM orders;", Materialized: true}}, views)
+	queries, err = isi.GetMaterializedViews([]common.SchemaAndName{{Schema: "public", Name: "orders"}})
+	assert.Nil(t, err)
+	assert.Empty(t, queries)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTablesSelectedSchemas(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("table_type = 'BASE TABLE'")).
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name"}).AddRow("public", "orders").AddRow("sales", "orders").AddRow("hr", "employees"))
+	mock.ExpectQuery("FROM pg_class").
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname", "pg_get_viewdef", "materialized"}).AddRow("public", "recent_orders", "SELECT 1", false).AddRow("hr", "managers", "SELECT 1", false))
+	sourceProfile := profiles.SourceProfile{Conn: profiles.SourceProfileConnection{MaterializedViews: profiles.MaterializedViewsView, Schemas: []string{"sales", "hr"}}}
+	isi := InfoSchemaImpl{Db: db, IsSchemaUnique: newFalsePtr(), SourceProfile: sourceProfile}
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "sales", Name: "orders"}, {Schema: "hr", Name: "employees"}}, tables)
+	assert.Equal(t, "sales.orders", isi.GetTableName("sales", "orders"))
+	views, err := isi.GetViews()
+	assert.Nil(t, err)
+	assert.Equal(t, []schema.View{{Schema: "hr", Name: "managers", Query: "SELECT 1"}}, views)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRoutines(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM information_schema.routines`)).
+		WillReturnRows(sqlmock.NewRows([]string{"routine_schema", "routine_name", "routine_type", "routine_definition"}).
+			AddRow("public", "audit", "FUNCTION", "BEGIN INSERT INTO audit (id) VALUES (NEW.id); RETURN NEW; END"))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM information_schema.triggers`)).
+		WillReturnRows(sqlmock.NewRows([]string{"trigger_schema", "trigger_name", "event_object_table", "action_timing", "event_manipulation", "action_statement"}).
+			AddRow("public", "audit_orders", "orders", "AFTER", "INSERT", "EXECUTE FUNCTION audit()").
+			AddRow("public", "audit_orders", "orders", "AFTER", "UPDATE", "EXECUTE FUNCTION audit()").
+			AddRow("public", "audit_users", "users", "AFTER", "DELETE", "EXECUTE FUNCTION audit()"))
+	isi := InfoSchemaImpl{Db: db}
+	routines, err := isi.GetRoutines()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []schema.Routine{
+		{Name: "audit", Schema: "public", Kind: schema.RoutineFunction, Body: "BEGIN INSERT INTO audit (id) VALUES (NEW.id); RETURN NEW; END"},
+		{Name: "audit_orders", Schema: "public", Kind: schema.RoutineTrigger, Table: "orders", Timing: "AFTER", Events: []string{"INSERT", "UPDATE"}, Body: "EXECUTE FUNCTION audit()"},
+		{Name: "audit_users", Schema: "public", Kind: schema.RoutineTrigger, Table: "users", Timing: "AFTER", Events: []string{"DELETE"}, Body: "EXECUTE FUNCTION audit()"},
+	}, routines)
+}
+
+func TestGetColumnsEnum(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM information_schema.COLUMNS c`)).WithArgs("public", "shirts").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"}).
+			AddRow("size", "enum('small','it''s large')", nil, "NO", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+	colDefs, colIds, err := isi.GetColumns(conv, common.SchemaAndName{Schema: "public", Name: "shirts"}, nil, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	col := colDefs[colIds[0]]
+	assert.Equal(t, schema.Type{Name: "enum"}, col.Type)
+	assert.Equal(t, []string{"small", "it's large"}, col.EnumValues)
+
+	spType, issues := toSpannerTypeInternal(col.Type, "")
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, spType)
+	assert.Nil(t, issues)
+}
+
+func TestGetColumnsIdentityAndGenerated(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM information_schema.COLUMNS c`)).WithArgs("public", "orders").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "identity_generation", "identity_sequence", "identity_start", "identity_increment", "identity_minimum", "identity_maximum", "identity_cycle", "generation_expression"}).
+			AddRow("id", "integer", nil, "NO", nil, nil, 32, 0, "ALWAYS", `public."Orders_id_seq"`, "1", "1", "1", "2147483647", "NO", nil).
+			AddRow("total", "numeric", nil, "YES", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "(price * (qty)::numeric)"))
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+	colDefs, colIds, err := isi.GetColumns(conv, common.SchemaAndName{Schema: "public", Name: "orders", Id: "t1"}, nil, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, ddl.AutoGenCol{Name: "Orders_id_seq", GenerationType: constants.SEQUENCE}, colDefs[colIds[0]].AutoGen)
+	assert.Equal(t, 1, len(conv.SrcSequences))
+	for _, seq := range conv.SrcSequences {
+		// Options at their default for an integer column are left out.
+		assert.Equal(t, ddl.Sequence{Id: seq.Id, Name: "Orders_id_seq", SequenceKind: "BIT REVERSED SEQUENCE", ColumnsUsingSeq: map[string][]string{"t1": {colIds[0]}}}, seq)
+	}
+	generated := colDefs[colIds[1]].GeneratedColumn
+	assert.Equal(t, ddl.GeneratedStored, generated.Type)
+	assert.Equal(t, "(price * (qty)::numeric)", generated.Value.Statement)
+}
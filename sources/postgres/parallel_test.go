@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func parallelReadsConv(keyType schema.Type, partitioning *schema.Partitioning) *internal.Conv {
+	return buildConv(
+		ddl.CreateTable{
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c2": {Name: "amount", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+		},
+		schema.Table{
+			Name:   "orders",
+			Id:     "t1",
+			Schema: "public",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: keyType},
+				"c2": {Name: "amount", Id: "c2", Type: schema.Type{Name: "bigint"}},
+			},
+			ColNameIdMap: map[string]string{"id": "c1", "amount": "c2"},
+			PrimaryKeys:  []schema.Key{{ColId: "c1"}},
+			Partitioning: partitioning,
+		})
+}
+
+func processParallelReads(db *InfoSchemaImpl, conv *internal.Conv) []int64 {
+	conv.SetDataMode()
+	var amounts []int64
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			amounts = append(amounts, vals[1].(int64))
+		})
+	db.SourceProfile = profiles.SourceProfile{Ty: profiles.SourceProfileTypeConnection, Conn: profiles.SourceProfileConnection{ParallelReaders: 2}}
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, *db, internal.AdditionalDataAttributes{})
+	sort.Slice(amounts, func(i, j int) bool { return amounts[i] < amounts[j] })
+	return amounts
+}
+
+func TestProcessDataKeyRanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "public"."orders" WHERE "id" < $1 ORDER BY "id" LIMIT 10000;`)).WithArgs("80000000-0000-0000-0000-000000000000").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow("123e4567-e89b-12d3-a456-426614174000", 10))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "public"."orders" WHERE "id" >= $1 ORDER BY "id" LIMIT 10000;`)).WithArgs("80000000-0000-0000-0000-000000000000").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow("a23e4567-e89b-12d3-a456-426614174000", 20))
+	amounts := processParallelReads(&InfoSchemaImpl{Db: db}, parallelReadsConv(schema.Type{Name: "uuid"}, nil))
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []int64{10, 20}, amounts)
+
+	// Integer keys are split by their minimum and maximum values.
+	db, mock, err = sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT MIN("id"), MAX("id") FROM "public"."orders";`)).
+		WillReturnRows(sqlmock.NewRows([]string{"min", "max"}).AddRow(1, 4))
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "public"."orders" WHERE "id" < $1 ORDER BY "id" LIMIT 10000;`)).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow(1, 10).AddRow(2, 20))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "public"."orders" WHERE "id" >= $1 ORDER BY "id" LIMIT 10000;`)).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow(3, 30))
+	amounts = processParallelReads(&InfoSchemaImpl{Db: db}, parallelReadsConv(schema.Type{Name: "integer"}, nil))
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []int64{10, 20, 30}, amounts)
+}
+
+func TestProcessDataPartitions(t *testing.T) {
+	// Partitions are read in parallel with parallelReaders, whatever the
+	// mapping of the table.
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "public"."orders_2024" ORDER BY "id" LIMIT 10000;`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow("1", 10))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "public"."orders_2025" ORDER BY "id" LIMIT 10000;`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow("2", 20))
+	partitioning := &schema.Partitioning{
+		Method:     "RANGE",
+		ColIds:     []string{"c1"},
+		Partitions: []schema.Partition{{Name: "orders_2024", Schema: "public", Table: "orders_2024"}, {Name: "orders_2025", Schema: "public", Table: "orders_2025"}},
+		Mapping:    schema.PartitionMappingSingleTable,
+	}
+	amounts := processParallelReads(&InfoSchemaImpl{Db: db}, parallelReadsConv(schema.Type{Name: "text"}, partitioning))
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []int64{10, 20}, amounts)
+}
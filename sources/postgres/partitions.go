@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Partitions of PostgreSQL partitioned tables are tables, listed in
+// information_schema.tables along with their partitioned table, whose rows
+// include theirs. They are migrated with their partitioned table.
+
+// partitionStrategies maps pg_partitioned_table.partstrat to partitioning
+// methods.
+var partitionStrategies = map[string]string{"r": "RANGE", "l": "LIST", "h": "HASH"}
+
+// getPartitionTables returns the partitions of partitioned tables. Servers
+// before PostgreSQL 10 have no pg_partitioned_table, and no partitioned
+// tables.
+func (isi InfoSchemaImpl) getPartitionTables() map[common.SchemaAndName]bool {
+	q := `SELECT n.nspname, c.relname
+		FROM pg_inherits i
+		JOIN pg_partitioned_table pt ON pt.partrelid = i.inhparent
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace;`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	partitions := make(map[common.SchemaAndName]bool)
+	for rows.Next() {
+		var t common.SchemaAndName
+		if err := rows.Scan(&t.Schema, &t.Name); err != nil {
+			return nil
+		}
+		partitions[t] = true
+	}
+	return partitions
+}
+
+// GetPartitionedTables implements the common.PartitionedTableSource
+// interface. Partitions which are themselves partitioned are read along
+// with their partitions.
+func (isi InfoSchemaImpl) GetPartitionedTables(tables []common.SchemaAndName) (map[common.SchemaAndName]common.TablePartitioning, error) {
+	included := make(map[common.SchemaAndName]bool)
+	for _, t := range tables {
+		included[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] = true
+	}
+	partitionings := make(map[common.SchemaAndName]common.TablePartitioning)
+
+	q := `SELECT n.nspname, c.relname, pt.partstrat, pg_get_partkeydef(c.oid)
+		FROM pg_partitioned_table pt
+		JOIN pg_class c ON c.oid = pt.partrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace;`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get partitioned tables: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t common.SchemaAndName
+		var strategy, keyDef string
+		if err := rows.Scan(&t.Schema, &t.Name, &strategy, &keyDef); err != nil {
+			return nil, fmt.Errorf("couldn't get partitioned tables: %w", err)
+		}
+		if !included[t] {
+			continue
+		}
+		method := partitionStrategies[strategy]
+		// Partition key definitions look like RANGE (created_at).
+		expression := strings.TrimSpace(strings.TrimPrefix(keyDef, method))
+		expression = strings.TrimSuffix(strings.TrimPrefix(expression, "("), ")")
+		partitionings[t] = common.TablePartitioning{Method: method, Expression: expression, Mapping: isi.SourceProfile.PartitionMapping()}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't get partitioned tables: %w", err)
+	}
+
+	// Columns of the partition key, expressions being numbered 0.
+	q = `SELECT n.nspname, c.relname, a.attname
+		FROM pg_partitioned_table pt
+		JOIN pg_class c ON c.oid = pt.partrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		CROSS JOIN LATERAL unnest(pt.partattrs::int2[]) WITH ORDINALITY AS k(attnum, ord)
+		JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = k.attnum
+		ORDER BY n.nspname, c.relname, k.ord;`
+	keyRows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get partition keys: %w", err)
+	}
+	defer keyRows.Close()
+	for keyRows.Next() {
+		var t common.SchemaAndName
+		var col string
+		if err := keyRows.Scan(&t.Schema, &t.Name, &col); err != nil {
+			return nil, fmt.Errorf("couldn't get partition keys: %w", err)
+		}
+		if p, ok := partitionings[t]; ok {
+			p.Columns = append(p.Columns, col)
+			partitionings[t] = p
+		}
+	}
+	if err := keyRows.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't get partition keys: %w", err)
+	}
+
+	q = `SELECT pn.nspname, p.relname, n.nspname, c.relname, pg_get_expr(c.relpartbound, c.oid)
+		FROM pg_inherits i
+		JOIN pg_partitioned_table pt ON pt.partrelid = i.inhparent
+		JOIN pg_class p ON p.oid = i.inhparent
+		JOIN pg_namespace pn ON pn.oid = p.relnamespace
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		ORDER BY pn.nspname, p.relname, c.relname;`
+	partitionRows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get partitions: %w", err)
+	}
+	defer partitionRows.Close()
+	for partitionRows.Next() {
+		var t common.SchemaAndName
+		var partition schema.Partition
+		var bound sql.NullString
+		if err := partitionRows.Scan(&t.Schema, &t.Name, &partition.Schema, &partition.Table, &bound); err != nil {
+			return nil, fmt.Errorf("couldn't get partitions: %w", err)
+		}
+		if p, ok := partitionings[t]; ok {
+			partition.Name = partition.Table
+			partition.Bound = bound.String
+			p.Partitions = append(p.Partitions, partition)
+			partitionings[t] = p
+		}
+	}
+	if err := partitionRows.Err(); err != nil {
+		return nil, fmt.Errorf("couldn't get partitions: %w", err)
+	}
+	return partitionings, nil
+}
+
+// processPartitionsData performs data conversion for a partitioned table,
+// whose partitions are read by readers readers at a time, in chunks by
+// chunkKey if not nil.
+func (isi InfoSchemaImpl) processPartitionsData(conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, readers int, chunkKey []string) error {
+	srcTable := conv.SrcSchema[tableId]
+	colNameIdMap := internal.GetSrcColNameIdMap(srcTable)
+	read := func(p schema.Partition, emit func(parallelRow)) error {
+		if chunkKey != nil {
+			return isi.readChunks(fmt.Sprintf(`"%s"."%s"`, p.Schema, p.Table), chunkKey, "", nil, emit)
+		}
+		return isi.readRows(emit, fmt.Sprintf(`SELECT * FROM "%s"."%s";`, p.Schema, p.Table))
+	}
+	process := func(row parallelRow) {
+		processParallelRow(conv, tableId, srcSchema, colIds, spSchema, colNameIdMap, row)
+	}
+	if err := common.ReadPartitions(srcTable.Partitioning.Partitions, readers, read, process); err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTable.Name, err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+func TestGetTablesExcludesPartitions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM pg_inherits i`)).
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname"}).
+			AddRow("public", "orders_2024").
+			AddRow("public", "orders_2025"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT table_schema, table_name FROM information_schema.tables`)).
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name"}).
+			AddRow("public", "orders").
+			AddRow("public", "orders_2024").
+			AddRow("public", "orders_2025").
+			AddRow("public", "customers"))
+	isSchemaUnique := false
+	isi := InfoSchemaImpl{Db: db, IsSchemaUnique: &isSchemaUnique}
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []common.SchemaAndName{{Schema: "public", Name: "orders"}, {Schema: "public", Name: "customers"}}, tables)
+}
+
+func TestGetPartitionedTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT n.nspname, c.relname, pt.partstrat, pg_get_partkeydef(c.oid)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname", "partstrat", "pg_get_partkeydef"}).
+			AddRow("public", "orders", "r", "RANGE (created_at)").
+			AddRow("public", "events", "h", "HASH (id)").
+			AddRow("archive", "orders", "l", "LIST (region)"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT n.nspname, c.relname, a.attname`)).
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname", "attname"}).
+			AddRow("archive", "orders", "region").
+			AddRow("public", "events", "id").
+			AddRow("public", "orders", "created_at"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT pn.nspname, p.relname, n.nspname, c.relname, pg_get_expr(c.relpartbound, c.oid)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname", "nspname", "relname", "pg_get_expr"}).
+			AddRow("archive", "orders", "archive", "orders_eu", "FOR VALUES IN ('eu')").
+			AddRow("public", "events", "public", "events_0", "FOR VALUES WITH (modulus 2, remainder 0)").
+			AddRow("public", "events", "public", "events_1", "FOR VALUES WITH (modulus 2, remainder 1)").
+			AddRow("public", "orders", "public", "orders_2024", "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')").
+			AddRow("public", "orders", "public", "orders_default", "DEFAULT"))
+	sourceProfile := profiles.SourceProfile{Conn: profiles.SourceProfileConnection{PartitionMapping: schema.PartitionMappingParallelExport}}
+	isi := InfoSchemaImpl{Db: db, SourceProfile: sourceProfile}
+	partitionings, err := isi.GetPartitionedTables([]common.SchemaAndName{{Schema: "public", Name: "orders"}, {Schema: "public", Name: "events"}})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, map[common.SchemaAndName]common.TablePartitioning{
+		{Schema: "public", Name: "orders"}: {
+			Method:     "RANGE",
+			Expression: "created_at",
+			Columns:    []string{"created_at"},
+			Partitions: []schema.Partition{
+				{Name: "orders_2024", Bound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')", Schema: "public", Table: "orders_2024"},
+				{Name: "orders_default", Bound: "DEFAULT", Schema: "public", Table: "orders_default"},
+			},
+			Mapping: schema.PartitionMappingParallelExport,
+		},
+		{Schema: "public", Name: "events"}: {
+			Method:     "HASH",
+			Expression: "id",
+			Columns:    []string{"id"},
+			Partitions: []schema.Partition{
+				{Name: "events_0", Bound: "FOR VALUES WITH (modulus 2, remainder 0)", Schema: "public", Table: "events_0"},
+				{Name: "events_1", Bound: "FOR VALUES WITH (modulus 2, remainder 1)", Schema: "public", Table: "events_1"},
+			},
+			Mapping: schema.PartitionMappingParallelExport,
+		},
+	}, partitionings)
+}
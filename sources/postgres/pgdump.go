@@ -255,11 +255,17 @@ func processIndexStmt(conv *internal.Conv, n *pg_query.IndexStmt) {
 	}
 	if tbl, ok := internal.GetSrcTableByName(conv.SrcSchema, tableName); ok {
 		ctable := conv.SrcSchema[tbl.Id]
+		fullText := isFullTextIndex(n, ctable)
+		keys := toIndexKeys(conv, n.Idxname, n.IndexParams, ctable.ColNameIdMap, fullText)
 		ctable.Indexes = append(ctable.Indexes, schema.Index{
-			Id:     internal.GenerateIndexesId(),
-			Name:   n.Idxname,
-			Unique: n.Unique,
-			Keys:   toIndexKeys(conv, n.Idxname, n.IndexParams, ctable.ColNameIdMap),
+			Id:             internal.GenerateIndexesId(),
+			Name:           n.Idxname,
+			Unique:         n.Unique,
+			Keys:           keys,
+			FullText:       fullText,
+			FullTextConfig: getIndexTsvectorConfig(n.IndexParams),
+			VectorDistance: toVectorDistance(n.AccessMethod, getIndexOpclass(n.IndexParams)),
+			NullFiltered:   n.WhereClause != nil && isNullFilteredPredicate(n.WhereClause, keys, ctable.ColNameIdMap),
 		})
 		conv.SrcSchema[tbl.Id] = ctable
 	} else {
@@ -290,6 +296,10 @@ func processAlterTableStmt(conv *internal.Conv, n *pg_query.AlterTableStmt) {
 					c := constraint{ct: pg_query.ConstrType_CONSTR_NOTNULL, cols: []string{a.Name}}
 					updateSchema(conv, tbl.Id, []constraint{c}, "ALTER TABLE")
 					conv.SchemaStatement(strings.Join([]string{printNodeType(n), printNodeType(t)}, "."))
+				case a.Subtype == pg_query.AlterTableType_AT_AddIdentity && a.Name != "" && a.Def != nil:
+					// pg_dump adds identities to columns after creating tables.
+					updateSchema(conv, tbl.Id, analyzeColDefConstraints(conv, printNodeType(n), tableName, []*pg_query.Node{a.Def}, a.Name), "ALTER TABLE")
+					conv.SchemaStatement(strings.Join([]string{printNodeType(n), printNodeType(t)}, "."))
 				case a.Subtype == pg_query.AlterTableType_AT_AddConstraint && a.Def != nil:
 					switch at := a.Def.GetNode().(type) {
 					case *pg_query.Node_Constraint:
@@ -589,6 +599,9 @@ type constraint struct {
 	referTable string
 	onDelete   string
 	onUpdate   string
+	/* Fields used for IDENTITY and GENERATED column constraints: */
+	options []*pg_query.Node // Sequence options e.g. START WITH.
+	expr    string           // Expression of the generated column.
 }
 
 // extractConstraints traverses a list of nodes (expecting them to be
@@ -601,7 +614,19 @@ func extractConstraints(conv *internal.Conv, stmtType, table string, l []*pg_que
 			var cols, referCols []string
 			var referTable, onDelete, onUpdate string
 			var conName string
+			var options []*pg_query.Node
+			var expr string
 			switch c.Contype {
+			case pg_query.ConstrType_CONSTR_IDENTITY:
+				options = c.Options
+			case pg_query.ConstrType_CONSTR_GENERATED:
+				e, err := deparseExpr(c.RawExpr)
+				if err != nil {
+					conv.Unexpected(fmt.Sprintf("Processing %v statement: error processing constraints: %s", printNodeType(d), err.Error()))
+					conv.ErrorInStatement(printNodeType(d))
+					continue
+				}
+				expr = e
 			case pg_query.ConstrType_CONSTR_FOREIGN:
 				t, err := getTableName(conv, c.Pktable)
 				if err != nil {
@@ -681,7 +706,7 @@ func extractConstraints(conv *internal.Conv, stmtType, table string, l []*pg_que
 					cols = append(cols, k)
 				}
 			}
-			cs = append(cs, constraint{ct: c.Contype, cols: cols, name: conName, referCols: referCols, referTable: referTable, onDelete: onDelete, onUpdate: onUpdate})
+			cs = append(cs, constraint{ct: c.Contype, cols: cols, name: conName, referCols: referCols, referTable: referTable, onDelete: onDelete, onUpdate: onUpdate, options: options, expr: expr})
 		default:
 			conv.Unexpected(fmt.Sprintf("Processing %v statement: found %s node while processing constraints\n", stmtType, printNodeType(d)))
 		}
@@ -733,8 +758,25 @@ func updateSchema(conv *internal.Conv, tableId string, cs []constraint, stmtType
 			// appear in toddl.go. This file should focus on generic transformation from source
 			// database schemas into schema.go.
 			ct := conv.SrcSchema[tableId]
-			ct.Indexes = append(ct.Indexes, schema.Index{Name: c.name, Unique: true, Keys: toSchemaKeys(conv, tableId, c.cols, colNameIdMap)})
+			ct.Indexes = append(ct.Indexes, schema.Index{Name: c.name, Id: internal.GenerateIndexesId(), Unique: true, Constraint: true, Keys: toSchemaKeys(conv, tableId, c.cols, colNameIdMap)})
 			conv.SrcSchema[tableId] = ct
+		case pg_query.ConstrType_CONSTR_IDENTITY:
+			ct := conv.SrcSchema[tableId]
+			for _, cn := range c.cols {
+				cid := colNameIdMap[cn]
+				cd := ct.ColDefs[cid]
+				seq := identitySequence(getIdentitySequenceName(ct.Name, cn, c.options), cd.Type.Name, getIdentityOptions(c.options))
+				cd.AutoGen = addIdentitySequence(conv, seq, tableId, cid)
+				ct.ColDefs[cid] = cd
+			}
+		case pg_query.ConstrType_CONSTR_GENERATED:
+			ct := conv.SrcSchema[tableId]
+			for _, cn := range c.cols {
+				cid := colNameIdMap[cn]
+				cd := ct.ColDefs[cid]
+				cd.GeneratedColumn = toGeneratedColumn(c.expr)
+				ct.ColDefs[cid] = cd
+			}
 		default:
 			ct := conv.SrcSchema[tableId]
 			updateCols(c.ct, c.cols, ct.ColDefs, colNameIdMap)
@@ -772,11 +814,19 @@ func toSchemaKeys(conv *internal.Conv, tableId string, colNames []string, colNam
 }
 
 // toIndexKeys converts a list of PostgreSQL index keys to schema index keys.
-func toIndexKeys(conv *internal.Conv, idxName string, s []*pg_query.Node, colNameIdMap map[string]string) (l []schema.Key) {
+// Keys of full-text indexes can be to_tsvector expressions, which are
+// replaced by the columns they are computed from.
+func toIndexKeys(conv *internal.Conv, idxName string, s []*pg_query.Node, colNameIdMap map[string]string, fullText bool) (l []schema.Key) {
 	for _, k := range s {
 		switch e := k.GetNode().(type) {
 		case *pg_query.Node_IndexElem:
 			if e.IndexElem.Name == "" {
+				if cols, _ := getTsvectorColumns(e.IndexElem.Expr); fullText && len(cols) > 0 {
+					for _, col := range cols {
+						l = append(l, schema.Key{ColId: colNameIdMap[col]})
+					}
+					continue
+				}
 				conv.Unexpected(fmt.Sprintf("Failed to process index %s: empty index column name", idxName))
 				continue
 			}
@@ -790,8 +840,253 @@ func toIndexKeys(conv *internal.Conv, idxName string, s []*pg_query.Node, colNam
 	return
 }
 
+// isFullTextOpclass returns true if an index key with the operator class
+// opclass, of an index using the access method indexMethod, indexes a
+// tsvector, i.e. documents of a full-text search. GIN indexes with other
+// operator classes, e.g. on jsonb or array columns, aren't full-text indexes.
+func isFullTextOpclass(indexMethod, opclass string) bool {
+	return indexMethod == "gin" && opclass == "tsvector_ops"
+}
+
+// isFullTextIndex returns true if n is a GIN index of a tsvector: a key with
+// the tsvector_ops operator class, a to_tsvector expression or a tsvector
+// column of table.
+func isFullTextIndex(n *pg_query.IndexStmt, table schema.Table) bool {
+	if n.AccessMethod != "gin" {
+		return false
+	}
+	for _, k := range n.IndexParams {
+		e, ok := k.GetNode().(*pg_query.Node_IndexElem)
+		if !ok {
+			continue
+		}
+		if len(e.IndexElem.Opclass) > 0 && isFullTextOpclass(n.AccessMethod, e.IndexElem.Opclass[len(e.IndexElem.Opclass)-1].GetString_().GetSval()) {
+			return true
+		}
+		if cols, _ := getTsvectorColumns(e.IndexElem.Expr); len(cols) > 0 {
+			return true
+		}
+		if colId, ok := table.ColNameIdMap[e.IndexElem.Name]; ok && e.IndexElem.Name != "" && table.ColDefs[colId].Type.Name == "tsvector" {
+			return true
+		}
+	}
+	return false
+}
+
+// getIndexOpclass returns the operator class of the first index column, if any.
+func getIndexOpclass(s []*pg_query.Node) string {
+	if len(s) == 0 {
+		return ""
+	}
+	e, ok := s[0].GetNode().(*pg_query.Node_IndexElem)
+	if !ok || len(e.IndexElem.Opclass) == 0 {
+		return ""
+	}
+	return e.IndexElem.Opclass[len(e.IndexElem.Opclass)-1].GetString_().GetSval()
+}
+
+// isNullFilteredPredicate returns true if the predicate of a partial index
+// only excludes rows with NULL key values i.e. it is an AND of col IS NOT NULL
+// tests covering exactly the key columns of the index. Such indexes map to
+// Spanner NULL_FILTERED indexes.
+func isNullFilteredPredicate(where *pg_query.Node, keys []schema.Key, colNameIdMap map[string]string) bool {
+	cols, ok := getNotNullColumns(where)
+	if !ok {
+		return false
+	}
+	tested := make(map[string]bool)
+	for _, col := range cols {
+		colId, found := colNameIdMap[col]
+		if !found {
+			return false
+		}
+		tested[colId] = true
+	}
+	keyColIds := make(map[string]bool)
+	for _, k := range keys {
+		if !tested[k.ColId] {
+			return false
+		}
+		keyColIds[k.ColId] = true
+	}
+	return len(tested) == len(keyColIds)
+}
+
+// getNotNullColumns returns the columns of a predicate made up only of
+// col IS NOT NULL tests joined by AND. It returns false for any other
+// predicate.
+func getNotNullColumns(n *pg_query.Node) ([]string, bool) {
+	switch e := n.GetNode().(type) {
+	case *pg_query.Node_NullTest:
+		if e.NullTest.Nulltesttype != pg_query.NullTestType_IS_NOT_NULL {
+			return nil, false
+		}
+		cr, ok := e.NullTest.Arg.GetNode().(*pg_query.Node_ColumnRef)
+		if !ok || len(cr.ColumnRef.Fields) == 0 {
+			return nil, false
+		}
+		col := cr.ColumnRef.Fields[len(cr.ColumnRef.Fields)-1].GetString_().GetSval()
+		if col == "" {
+			return nil, false
+		}
+		return []string{col}, true
+	case *pg_query.Node_BoolExpr:
+		if e.BoolExpr.Boolop != pg_query.BoolExprType_AND_EXPR {
+			return nil, false
+		}
+		var cols []string
+		for _, arg := range e.BoolExpr.Args {
+			c, ok := getNotNullColumns(arg)
+			if !ok {
+				return nil, false
+			}
+			cols = append(cols, c...)
+		}
+		return cols, true
+	}
+	return nil, false
+}
+
+// getIndexTsvectorConfig returns the text search configuration of the first
+// to_tsvector expression key of an index, if any.
+func getIndexTsvectorConfig(s []*pg_query.Node) string {
+	for _, k := range s {
+		if e, ok := k.GetNode().(*pg_query.Node_IndexElem); ok && e.IndexElem.Expr != nil {
+			if _, config := getTsvectorColumns(e.IndexElem.Expr); config != "" {
+				return config
+			}
+		}
+	}
+	return ""
+}
+
+// getTsvectorColumns returns the columns of the documents of to_tsvector
+// calls in an expression, such as to_tsvector('english', title || ' ' || body),
+// along with the text search configuration passed to to_tsvector, if any.
+func getTsvectorColumns(n *pg_query.Node) (cols []string, config string) {
+	var walk func(n *pg_query.Node, inDoc bool)
+	walk = func(n *pg_query.Node, inDoc bool) {
+		switch e := n.GetNode().(type) {
+		case *pg_query.Node_FuncCall:
+			args := e.FuncCall.Args
+			if !inDoc && len(e.FuncCall.Funcname) > 0 && e.FuncCall.Funcname[len(e.FuncCall.Funcname)-1].GetString_().GetSval() == "to_tsvector" {
+				if len(args) == 2 {
+					if c := getConstString(args[0]); c != "" && config == "" {
+						config = c
+					}
+					args = args[1:]
+				}
+				inDoc = true
+			}
+			for _, arg := range args {
+				walk(arg, inDoc)
+			}
+		case *pg_query.Node_AExpr:
+			walk(e.AExpr.Lexpr, inDoc)
+			walk(e.AExpr.Rexpr, inDoc)
+		case *pg_query.Node_CoalesceExpr:
+			for _, arg := range e.CoalesceExpr.Args {
+				walk(arg, inDoc)
+			}
+		case *pg_query.Node_TypeCast:
+			walk(e.TypeCast.Arg, inDoc)
+		case *pg_query.Node_ColumnRef:
+			if !inDoc || len(e.ColumnRef.Fields) == 0 {
+				return
+			}
+			if col := e.ColumnRef.Fields[len(e.ColumnRef.Fields)-1].GetString_().GetSval(); col != "" {
+				for _, c := range cols {
+					if c == col {
+						return
+					}
+				}
+				cols = append(cols, col)
+			}
+		}
+	}
+	walk(n, false)
+	return cols, config
+}
+
+// getConstString returns the value of a string constant, possibly cast to
+// another type e.g. 'english'::regconfig.
+func getConstString(n *pg_query.Node) string {
+	switch e := n.GetNode().(type) {
+	case *pg_query.Node_TypeCast:
+		return getConstString(e.TypeCast.Arg)
+	case *pg_query.Node_AConst:
+		if s, ok := e.AConst.Val.(*pg_query.A_Const_Sval); ok {
+			return s.Sval.Sval
+		}
+	}
+	return ""
+}
+
 // toForeignKeys converts a string list of PostgreSQL foreign keys to schema
 // foreign keys.
+// getIdentitySequenceName returns the name of the sequence of an identity
+// column, which is <table>_<column>_seq unless set with SEQUENCE NAME.
+func getIdentitySequenceName(table, col string, options []*pg_query.Node) string {
+	for _, o := range options {
+		if d := o.GetDefElem(); d != nil && d.Defname == "sequence_name" {
+			if names := d.Arg.GetList().GetItems(); len(names) > 0 {
+				if name, err := getString(names[len(names)-1]); err == nil {
+					return name
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("%s_%s_seq", table[strings.LastIndex(table, ".")+1:], col)
+}
+
+// getIdentityOptions returns the sequence options of an identity column.
+// Options reset with e.g. NO MINVALUE are left empty.
+func getIdentityOptions(options []*pg_query.Node) (opts identityOptions) {
+	for _, o := range options {
+		d := o.GetDefElem()
+		if d == nil {
+			continue
+		}
+		var val string
+		switch arg := d.Arg.GetNode().(type) {
+		case *pg_query.Node_Integer:
+			val = strconv.FormatInt(int64(arg.Integer.Ival), 10)
+		case *pg_query.Node_Float:
+			val = arg.Float.Fval
+		}
+		switch d.Defname {
+		case "start":
+			opts.start = val
+		case "increment":
+			opts.increment = val
+		case "minvalue":
+			opts.min = val
+		case "maxvalue":
+			opts.max = val
+		case "cache":
+			opts.cache = val
+		case "cycle":
+			opts.cycle = d.Arg.GetBoolean().GetBoolval()
+		}
+	}
+	return opts
+}
+
+// deparseExpr returns the SQL text of an expression e.g. of a generated
+// column.
+func deparseExpr(n *pg_query.Node) (string, error) {
+	sel := &pg_query.SelectStmt{
+		TargetList:  []*pg_query.Node{pg_query.MakeResTargetNodeWithVal(n, 0)},
+		LimitOption: pg_query.LimitOption_LIMIT_OPTION_DEFAULT,
+		Op:          pg_query.SetOperation_SETOP_NONE,
+	}
+	s, err := pg_query.Deparse(&pg_query.ParseResult{Stmts: []*pg_query.RawStmt{{Stmt: &pg_query.Node{Node: &pg_query.Node_SelectStmt{SelectStmt: sel}}}}})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(s, "SELECT "), nil
+}
+
 func toForeignKeys(fk constraint) (fkey schema.ForeignKey) {
 	fkey = schema.ForeignKey{
 		Id:               internal.GenerateForeignkeyId(),
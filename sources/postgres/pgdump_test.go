@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/bits"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/mocks"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	pg_query "github.com/pganalyze/pg_query_go/v6"
@@ -93,9 +95,9 @@ func TestProcessPgDump(t *testing.T) {
 	}{
 		{"text", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}},
 		{"text NOT NULL", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true}},
-		{"text array[4]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false}}},
-		{"text[4]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false}}},
-		{"text[]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false}}},
+		{"text array[4]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}}},
+		{"text[4]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}}},
+		{"text[]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}}},
 		{"text[][]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}}, // Unrecognized array type mapped to string.
 	}
 	for _, tc := range singleColTests {
@@ -329,6 +331,48 @@ func TestProcessPgDump(t *testing.T) {
 					PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "synth_id", Order: 1}},
 					Indexes:     []ddl.CreateIndex{ddl.CreateIndex{Name: "custom_index", TableId: "test", Unique: false, Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "b", Desc: false, Order: 1}, ddl.IndexKey{ColId: "c", Desc: false, Order: 2}}}}}},
 		},
+		{
+			name: "Create partial index statement",
+			input: "CREATE TABLE test (" +
+				"a smallint," +
+				"b text," +
+				"c text" +
+				");\n" +
+				"CREATE INDEX null_filtered_index ON test (b, c) WHERE b IS NOT NULL AND c IS NOT NULL;\n" +
+				"CREATE INDEX partial_index ON test (b) WHERE c IS NOT NULL;\n",
+			expectedSchema: map[string]ddl.CreateTable{
+				"test": ddl.CreateTable{
+					Name:   "test",
+					ColIds: []string{"a", "b", "c", "synth_id"},
+					ColDefs: map[string]ddl.ColumnDef{
+						"a":        ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.Int64}},
+						"b":        ddl.ColumnDef{Name: "b", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+						"c":        ddl.ColumnDef{Name: "c", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+						"synth_id": ddl.ColumnDef{Name: "synth_id", T: ddl.Type{Name: ddl.String, Len: 50}},
+					},
+					PrimaryKeys: []ddl.IndexKey{ddl.IndexKey{ColId: "synth_id", Order: 1}},
+					Indexes: []ddl.CreateIndex{
+						ddl.CreateIndex{Name: "null_filtered_index", TableId: "test", Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "b", Order: 1}, ddl.IndexKey{ColId: "c", Order: 2}}, NullFiltered: true},
+						ddl.CreateIndex{Name: "partial_index", TableId: "test", Keys: []ddl.IndexKey{ddl.IndexKey{ColId: "b", Order: 1}}}}}},
+		},
+		{
+			name: "Create vector index statement",
+			input: "CREATE TABLE test (" +
+				"a bigint PRIMARY KEY," +
+				"b vector(3)" +
+				");\n" +
+				"CREATE INDEX vector_index ON test USING hnsw (b vector_l2_ops);\n",
+			expectedSchema: map[string]ddl.CreateTable{
+				"test": ddl.CreateTable{
+					Name:   "test",
+					ColIds: []string{"a", "b"},
+					ColDefs: map[string]ddl.ColumnDef{
+						"a": ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+						"b": ddl.ColumnDef{Name: "b", T: ddl.Type{Name: ddl.Float32, IsArray: true, VectorLength: 3}},
+					},
+					PrimaryKeys:   []ddl.IndexKey{ddl.IndexKey{ColId: "a", Order: 1}},
+					VectorIndexes: []ddl.VectorIndex{ddl.VectorIndex{Name: "vector_index", TableId: "test", ColId: "b", DistanceType: ddl.VectorDistanceEuclidean}}}},
+		},
 		{
 			name: "Create index statement with order",
 			input: "CREATE TABLE test (" +
@@ -739,7 +783,7 @@ COPY test (id, a, b, c, d, e, f, g) FROM stdin;
 					table: "test", cols: []string{"int8", "float8", "bool", "timestamp", "date", "bytea", "arr", "float4", "synth_id"},
 					vals: []interface{}{int64(7), float64(42.1), true, getTime(t, "2019-10-29T05:30:00Z"),
 						getDate("2019-10-29"), []byte{0x0, 0x1, 0xbe, 0xef},
-						"{42,6}", float32(3.14),
+						[]spanner.NullInt64{{Int64: 42, Valid: true}, {Int64: 6, Valid: true}}, float32(3.14),
 						fmt.Sprintf("%d", bitReverse(0))}},
 				spannerData{table: "test", cols: []string{"int8", "synth_id"}, vals: []interface{}{int64(7), fmt.Sprintf("%d", bitReverse(1))}},
 				spannerData{table: "test", cols: []string{"float8", "synth_id"}, vals: []interface{}{float64(42.1), fmt.Sprintf("%d", bitReverse(2))}},
@@ -748,9 +792,9 @@ COPY test (id, a, b, c, d, e, f, g) FROM stdin;
 				spannerData{table: "test", cols: []string{"date", "synth_id"}, vals: []interface{}{getDate("2019-10-29"), fmt.Sprintf("%d", bitReverse(5))}},
 				spannerData{table: "test", cols: []string{"bytea", "synth_id"}, vals: []interface{}{[]byte{0x0, 0x1, 0xbe, 0xef}, fmt.Sprintf("%d", bitReverse(6))}},
 				spannerData{table: "test", cols: []string{"arr", "synth_id"},
-					vals: []interface{}{"{42,6}", fmt.Sprintf("%d", bitReverse(7))}},
+					vals: []interface{}{[]spanner.NullInt64{{Int64: 42, Valid: true}, {Int64: 6, Valid: true}}, fmt.Sprintf("%d", bitReverse(7))}},
 				spannerData{table: "test", cols: []string{"arr", "synth_id"},
-					vals: []interface{}{"{42, 6}", fmt.Sprintf("%d", bitReverse(8))}},
+					vals: []interface{}{[]spanner.NullInt64{{Int64: 42, Valid: true}, {Int64: 6, Valid: true}}, fmt.Sprintf("%d", bitReverse(8))}},
 				spannerData{table: "test", cols: []string{"float4", "synth_id"}, vals: []interface{}{float32(3.14), fmt.Sprintf("%d", bitReverse(9))}},
 			},
 		},
@@ -782,8 +826,8 @@ func TestProcessPgDumpPGTarget(t *testing.T) {
 		{"float4", ddl.Type{Name: ddl.Float32}},
 		{"integer", ddl.Type{Name: ddl.Int64}},
 		{"numeric", ddl.Type{Name: ddl.Numeric}},
-		{"numeric(4)", ddl.Type{Name: ddl.Numeric}},
-		{"numeric(6, 4)", ddl.Type{Name: ddl.Numeric}},
+		{"numeric(4)", ddl.Type{Name: ddl.Numeric, Precision: 4}},
+		{"numeric(6, 4)", ddl.Type{Name: ddl.Numeric, Precision: 6, Scale: 4}},
 		{"real", ddl.Type{Name: ddl.Float32}},
 		{"smallint", ddl.Type{Name: ddl.Int64}},
 		{"text", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
@@ -803,16 +847,16 @@ func TestProcessPgDumpPGTarget(t *testing.T) {
 		columnId, _ := internal.GetColIdFromSrcName(conv.SrcSchema[tableId].ColDefs, "a")
 		assert.Equal(t, conv.SpSchema[tableId].ColDefs[columnId].T, tc.expected, "Scalar type: "+tc.ty)
 	}
-	// Next test array types and not null. For PG Spanner, all array types mapped to string.
+	// Next test array types and not null. Multi-dimensional arrays are mapped to string.
 	singleColTests := []struct {
 		ty       string
 		expected ddl.ColumnDef
 	}{
 		{"text", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}},
 		{"text NOT NULL", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true}},
-		{"text array[4]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}},
-		{"text[4]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}},
-		{"text[]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}},
+		{"text array[4]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}}},
+		{"text[4]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}}},
+		{"text[]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}}},
 		{"text[][]", ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}},
 	}
 	for _, tc := range singleColTests {
@@ -1419,7 +1463,7 @@ COPY test (id, a, b, c, d, e) FROM stdin;
 					table: "test", cols: []string{"int8", "float8", "bool", "timestamp", "date", "bytea", "arr", "float4", "synth_id"},
 					vals: []interface{}{int64(7), float64(42.1), true, getTime(t, "2019-10-29T05:30:00Z"),
 						getDate("2019-10-29"), []byte{0x0, 0x1, 0xbe, 0xef},
-						"{42,6}", float32(3.14),
+						[]spanner.NullInt64{{Int64: 42, Valid: true}, {Int64: 6, Valid: true}}, float32(3.14),
 						fmt.Sprintf("%d", bitReverse(0))}},
 				spannerData{table: "test", cols: []string{"int8", "synth_id"}, vals: []interface{}{int64(7), fmt.Sprintf("%d", bitReverse(1))}},
 				spannerData{table: "test", cols: []string{"float8", "synth_id"}, vals: []interface{}{float64(42.1), fmt.Sprintf("%d", bitReverse(2))}},
@@ -1427,8 +1471,8 @@ COPY test (id, a, b, c, d, e) FROM stdin;
 				spannerData{table: "test", cols: []string{"timestamp", "synth_id"}, vals: []interface{}{getTime(t, "2019-10-29T05:30:00Z"), fmt.Sprintf("%d", bitReverse(4))}},
 				spannerData{table: "test", cols: []string{"date", "synth_id"}, vals: []interface{}{getDate("2019-10-29"), fmt.Sprintf("%d", bitReverse(5))}},
 				spannerData{table: "test", cols: []string{"bytea", "synth_id"}, vals: []interface{}{[]byte{0x0, 0x1, 0xbe, 0xef}, fmt.Sprintf("%d", bitReverse(6))}},
-				spannerData{table: "test", cols: []string{"arr", "synth_id"}, vals: []interface{}{"{42,6}", fmt.Sprintf("%d", bitReverse(7))}},
-				spannerData{table: "test", cols: []string{"arr", "synth_id"}, vals: []interface{}{"{42, 6}", fmt.Sprintf("%d", bitReverse(8))}},
+				spannerData{table: "test", cols: []string{"arr", "synth_id"}, vals: []interface{}{[]spanner.NullInt64{{Int64: 42, Valid: true}, {Int64: 6, Valid: true}}, fmt.Sprintf("%d", bitReverse(7))}},
+				spannerData{table: "test", cols: []string{"arr", "synth_id"}, vals: []interface{}{[]spanner.NullInt64{{Int64: 42, Valid: true}, {Int64: 6, Valid: true}}, fmt.Sprintf("%d", bitReverse(8))}},
 				spannerData{table: "test", cols: []string{"float4", "synth_id"}, vals: []interface{}{float32(3.14), fmt.Sprintf("%d", bitReverse(9))}},
 			},
 		},
@@ -1452,7 +1496,7 @@ func TestProcessPgDump_GetDDL(t *testing.T) {
 			"	quantity INT64,\n" +
 			") PRIMARY KEY (productid, userid)"
 	c := ddl.Config{Tables: true}
-	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences), " "))
+	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects()), " "))
 }
 
 func TestProcessPgDump_GetPGDDL(t *testing.T) {
@@ -1466,7 +1510,7 @@ func TestProcessPgDump_GetPGDDL(t *testing.T) {
 			"	PRIMARY KEY (productid, userid)\n" +
 			")"
 	c := ddl.Config{Tables: true, SpDialect: conv.SpDialect}
-	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences), " "))
+	assert.Equal(t, expected, strings.Join(ddl.GetDDL(c, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects()), " "))
 }
 
 func TestProcessPgDump_Rows(t *testing.T) {
@@ -1533,6 +1577,40 @@ func TestProcessPgDump_AddPrimaryKeys(t *testing.T) {
 	}
 }
 
+func TestProcessPgDump_TsvectorIndex(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TABLE posts (id bigint PRIMARY KEY, title text, body text);\n" +
+		"CREATE INDEX posts_fts ON posts USING gin (to_tsvector('english'::regconfig, title || ' ' || COALESCE(body, '')));\n" +
+		"CREATE INDEX posts_lower ON posts (lower(title));\n")
+	tbl, _ := internal.GetSrcTableByName(conv.SrcSchema, "posts")
+	colNameIdMap := internal.GetSrcColNameIdMap(*tbl)
+	assert.Equal(t, "english", tbl.Indexes[0].FullTextConfig)
+	assert.Equal(t, []schema.Key{{ColId: colNameIdMap["title"], Order: 1}, {ColId: colNameIdMap["body"], Order: 2}}, tbl.Indexes[0].Keys)
+	// Expression keys of other indexes are still not supported.
+	assert.Equal(t, int64(1), conv.Stats.Unexpected["Failed to process index posts_lower: empty index column name"])
+
+	spTable := conv.SpSchema[tbl.Id]
+	assert.Equal(t, 1, len(spTable.SearchIndexes))
+	var tokenize []string
+	for _, k := range spTable.SearchIndexes[0].Keys {
+		tokenize = append(tokenize, spTable.ColDefs[k.ColId].GeneratedColumn.Value.Statement)
+	}
+	assert.Equal(t, []string{"TOKENIZE_FULLTEXT(title, language_tag=>'en')", "TOKENIZE_FULLTEXT(body, language_tag=>'en')"}, tokenize)
+}
+
+func TestProcessPgDump_GinIndexes(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TABLE docs (id bigint PRIMARY KEY, attrs jsonb, tags text[], doc tsvector);\n" +
+		"CREATE INDEX docs_attrs ON docs USING gin (attrs);\n" +
+		"CREATE INDEX docs_attrs_path ON docs USING gin (attrs jsonb_path_ops);\n" +
+		"CREATE INDEX docs_doc ON docs USING gin (doc);\n")
+	tbl, _ := internal.GetSrcTableByName(conv.SrcSchema, "docs")
+	fullText := make(map[string]bool)
+	for _, index := range tbl.Indexes {
+		fullText[index.Name] = index.FullText
+	}
+	// Only GIN indexes of tsvectors are full-text indexes.
+	assert.Equal(t, map[string]bool{"docs_attrs": false, "docs_attrs_path": false, "docs_doc": true}, fullText)
+}
+
 func TestProcessPgDump_WithUnparsableContent(t *testing.T) {
 	s := "This is unparsable content"
 	conv := internal.MakeConv()
@@ -1554,6 +1632,74 @@ func TestProcessPgDump_WithUnparsableContent(t *testing.T) {
 	}
 }
 
+func TestProcessPgDump_IdentityAndGeneratedColumns(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TABLE public.orders (\n" +
+		"    id integer NOT NULL,\n" +
+		"    price numeric NOT NULL,\n" +
+		"    qty integer NOT NULL,\n" +
+		"    total numeric GENERATED ALWAYS AS ((price * (qty)::numeric)) STORED,\n" +
+		"    label text GENERATED ALWAYS AS (to_char(price, '999'::text)) STORED\n" +
+		");\n" +
+		"ALTER TABLE public.orders ALTER COLUMN id ADD GENERATED ALWAYS AS IDENTITY (\n" +
+		"    SEQUENCE NAME public.orders_id_seq\n" +
+		"    START WITH 100\n" +
+		"    INCREMENT BY 1\n" +
+		"    NO MINVALUE\n" +
+		"    NO MAXVALUE\n" +
+		"    CACHE 1\n" +
+		");\n" +
+		"CREATE TABLE public.items (id bigint GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 2 CYCLE) PRIMARY KEY);\n" +
+		"ALTER TABLE ONLY public.orders ADD CONSTRAINT orders_pkey PRIMARY KEY (id);\n")
+	tbl, _ := internal.GetSrcTableByName(conv.SrcSchema, "orders")
+	colNameIdMap := internal.GetSrcColNameIdMap(*tbl)
+	id := tbl.ColDefs[colNameIdMap["id"]]
+	assert.Equal(t, ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.SEQUENCE}, id.AutoGen)
+	assert.Equal(t, "price * qty::numeric", tbl.ColDefs[colNameIdMap["total"]].GeneratedColumn.Value.Statement)
+
+	var seqs []ddl.Sequence
+	for _, seq := range conv.SrcSequences {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i].Name < seqs[j].Name })
+	assert.Equal(t, 2, len(seqs))
+	assert.Equal(t, "items_id_seq", seqs[0].Name)
+	assert.Equal(t, "2", seqs[0].Increment)
+	assert.True(t, seqs[0].Cycle)
+	assert.Equal(t, "orders_id_seq", seqs[1].Name)
+	assert.Equal(t, "100", seqs[1].StartWithCounter)
+	assert.Equal(t, "", seqs[1].Increment+seqs[1].MinValue+seqs[1].MaxValue+seqs[1].CacheSize)
+
+	spTable := conv.SpSchema[tbl.Id]
+	assert.Equal(t, ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.SEQUENCE}, spTable.ColDefs[colNameIdMap["id"]].AutoGen)
+	assert.Equal(t, ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{ExpressionId: tbl.ColDefs[colNameIdMap["total"]].GeneratedColumn.Value.ExpressionId, Statement: "(`price` * CAST(`qty` AS NUMERIC))"}, Type: ddl.GeneratedStored}, spTable.ColDefs[colNameIdMap["total"]].GeneratedColumn)
+	assert.False(t, spTable.ColDefs[colNameIdMap["label"]].GeneratedColumn.IsPresent)
+	assert.Contains(t, conv.SchemaIssues[tbl.Id].ColumnLevelIssues[colNameIdMap["label"]], internal.GeneratedColumnMaterialized)
+	assert.Contains(t, conv.SchemaIssues[tbl.Id].ColumnLevelIssues[colNameIdMap["id"]], internal.SequenceCreated)
+}
+
+func TestProcessPgDump_ExtensionTypes(t *testing.T) {
+	conv, rows := runProcessPgDump("CREATE TABLE public.hosts (\n" +
+		"    id bigint PRIMARY KEY,\n" +
+		"    name public.citext,\n" +
+		"    addr inet,\n" +
+		"    path public.ltree,\n" +
+		"    attrs public.hstore\n" +
+		");\n" +
+		"COPY public.hosts (id, name, addr, path, attrs) FROM stdin;\n" +
+		"1\tWeb\t10.0.0.1/8\ttop.web\t\"role\"=>\"frontend\", \"zone\"=>NULL\n" +
+		"\\.\n")
+	tbl, _ := internal.GetSrcTableByName(conv.SrcSchema, "hosts")
+	colNameIdMap := internal.GetSrcColNameIdMap(*tbl)
+	spTable := conv.SpSchema[tbl.Id]
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, spTable.ColDefs[colNameIdMap["name"]].T)
+	assert.Equal(t, ddl.Type{Name: ddl.JSON}, spTable.ColDefs[colNameIdMap["attrs"]].T)
+	assert.Contains(t, conv.SchemaIssues[tbl.Id].ColumnLevelIssues[colNameIdMap["name"]], internal.CaseInsensitiveText)
+	assert.Equal(t, 1, len(spTable.CheckConstraints))
+	assert.Equal(t, "chk_hosts_addr", spTable.CheckConstraints[0].Name)
+	assert.Equal(t, []spannerData{{table: "hosts", cols: []string{"id", "name", "addr", "path", "attrs"},
+		vals: []interface{}{int64(1), "Web", "10.0.0.1/8", "top.web", `{"role":"frontend","zone":null}`}}}, rows)
+}
+
 func runProcessPgDump(s string) (*internal.Conv, []spannerData) {
 	conv := internal.MakeConv()
 	conv.SetLocation(time.UTC)
@@ -16,6 +16,8 @@
 package postgres
 
 import (
+	"fmt"
+
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
@@ -31,16 +33,30 @@ type ToDdlImpl struct {
 // mods) into a Spanner type. This is the core source-to-Spanner type
 // mapping.  toSpannerType returns the Spanner type and a list of type
 // conversion issues encountered.
+//
+// uuid columns map to UUID by default, and to STRING(36) when spType is
+// STRING or with the uuidAsString param of the target profile.
 func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType schema.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
 	ty, issues := toSpannerTypeInternal(srcType, spType)
+	ty, numericIssues := common.ToSpannerNumeric(ty, srcType, conv.SpDialect)
+	issues = append(issues, numericIssues...)
 	if len(srcType.ArrayBounds) > 1 {
 		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
 		issues = append(issues, internal.MultiDimensionalArray)
 	} else if len(srcType.ArrayBounds) == 1 {
-		// This check has been added because we don't support Array<primitive type> to string conversions
-		// and Array datatype is currently not supported in datastream.
-		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
-		issues = append(issues, internal.ArrayTypeNotSupported)
+		// Single dimensional arrays map to arrays of the element type. Arrays
+		// of arrays (e.g. vector[]) aren't supported by Spanner, and arrays
+		// aren't supported by Datastream, so they map to strings for minimal
+		// downtime migrations.
+		if conv.Audit.StreamingStats.Streaming {
+			ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+			issues = append(issues, internal.ArrayTypeNotSupported)
+		} else if ty.IsArray {
+			ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+			issues = append(issues, internal.MultiDimensionalArray)
+		} else {
+			ty.IsArray = true
+		}
 	}
 	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
 		var pg_issues []internal.SchemaIssue
@@ -50,8 +66,25 @@ func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType s
 	return ty, issues
 }
 
+// GetColumnAutoGen maps identity columns to columns using the Spanner
+// sequence of their identity sequence.
 func (tdi ToDdlImpl) GetColumnAutoGen(conv *internal.Conv, autoGenCol ddl.AutoGenCol, colId string, tableId string) (*ddl.AutoGenCol, error) {
-	return nil, nil
+	if autoGenCol.GenerationType != constants.SEQUENCE {
+		return nil, nil
+	}
+	for seqId, seq := range conv.SrcSequences {
+		if seq.Name != autoGenCol.Name {
+			continue
+		}
+		spSequence := conv.SpSequences[seqId]
+		if spSequence.ColumnsUsingSeq == nil {
+			spSequence.ColumnsUsingSeq = make(map[string][]string)
+		}
+		spSequence.ColumnsUsingSeq[tableId] = append(spSequence.ColumnsUsingSeq[tableId], colId)
+		conv.SpSequences[seqId] = spSequence
+		return &ddl.AutoGenCol{Name: spSequence.Name, GenerationType: constants.SEQUENCE}, nil
+	}
+	return &ddl.AutoGenCol{}, fmt.Errorf("sequence corresponding to column auto generation not found")
 }
 
 // toSpannerTypeInternal defines the mapping of source types into Spanner
@@ -150,8 +183,6 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 		case ddl.String:
 			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
 		default:
-			// TODO: check mod[0] and mod[1] and generate a warning
-			// if this numeric won't fit in Spanner's NUMERIC.
 			return ddl.Type{Name: ddl.Numeric}, nil
 		}
 	case "serial":
@@ -190,6 +221,32 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 		default:
 			return ddl.Type{Name: ddl.JSON}, nil
 		}
+	case "vector":
+		// pgvector columns map to FLOAT32 arrays, with the vector length
+		// set so that they can be used in vector indexes.
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		default:
+			if len(srcType.Mods) > 0 {
+				return ddl.Type{Name: ddl.Float32, IsArray: true, VectorLength: srcType.Mods[0]}, nil
+			}
+			return ddl.Type{Name: ddl.Float32, IsArray: true}, nil
+		}
+	case "enum":
+		// Columns of enum types, whose values are kept in Column.EnumValues.
+		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+	case "inet", "cidr":
+		// Addresses are stored in their text form, and validated by the
+		// CHECK constraint of GetColumnCheckExpr.
+		return ddl.Type{Name: ddl.String, Len: inetLength}, nil
+	case "uuid":
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: 36}, nil
+		case ddl.UUID, "":
+			return ddl.Type{Name: ddl.UUID}, nil
+		}
 	case "varchar", "character varying":
 		switch spType {
 		case ddl.Bytes:
@@ -204,5 +261,28 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
 		}
 	}
+	if isSpatialType(srcType.Name) {
+		return common.ToSpannerSpatialType(spType)
+	}
+	if extensionType := toExtensionType(srcType.Name); extensionType != "" {
+		return toSpannerExtensionType(extensionType, spType)
+	}
 	return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.NoGoodType}
 }
+
+// toVectorDistance maps pgvector index operator classes to the distance
+// type of Spanner vector indexes. It returns an empty string for indexes which
+// aren't pgvector indexes.
+func toVectorDistance(accessMethod, opclass string) string {
+	if accessMethod != "hnsw" && accessMethod != "ivfflat" {
+		return ""
+	}
+	switch opclass {
+	case "vector_l2_ops":
+		return ddl.VectorDistanceEuclidean
+	case "vector_ip_ops":
+		return ddl.VectorDistanceDotProduct
+	default:
+		return ddl.VectorDistanceCosine
+	}
+}
@@ -121,6 +121,33 @@ func TestToSpannerTypeInternal(t *testing.T) {
 	}
 }
 
+func TestToSpannerTypeUUID(t *testing.T) {
+	// uuid columns map to UUID unless another type is requested.
+	ty, issues := ToDdlImpl{}.ToSpannerType(internal.MakeConv(), "", schema.Type{Name: "uuid"}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, ty)
+	assert.Nil(t, issues)
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "uuid"}, ddl.UUID)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, ty)
+	ty, _ = toSpannerTypeInternal(schema.Type{Name: "uuid"}, ddl.String)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 36}, ty)
+	// Other types aren't supported.
+	ty, issues = toSpannerTypeInternal(schema.Type{Name: "uuid"}, ddl.Bool)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.NoGoodType}, issues)
+}
+
+func TestToSpannerTypeArray(t *testing.T) {
+	conv := internal.MakeConv()
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "text", ArrayBounds: []int64{-1}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, ty)
+	assert.Nil(t, issues)
+	// Datastream doesn't support arrays.
+	conv.Audit.StreamingStats.Streaming = true
+	ty, issues = ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "text", ArrayBounds: []int64{-1}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.ArrayTypeNotSupported}, issues)
+}
+
 // This is just a very basic smoke-test for toSpannerType.
 // The real testing of toSpannerType happens in process_test.go
 // via the public API ProcessPgDump (see TestProcessPgDump).
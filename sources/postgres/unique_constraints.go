@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// GetUniqueConstraints implements the common.UniqueConstraintSource
+// interface, reading the index implementing each UNIQUE constraint from
+// pg_constraint.conindid.
+func (isi InfoSchemaImpl) GetUniqueConstraints(tables []common.SchemaAndName) (map[common.SchemaAndName][]string, error) {
+	q := `SELECT n.nspname, c.relname, i.relname
+		FROM pg_constraint con
+		JOIN pg_class c ON c.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_class i ON i.oid = con.conindid
+		WHERE con.contype = 'u';`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get unique constraints: %w", err)
+	}
+	defer rows.Close()
+	included := make(map[common.SchemaAndName]bool)
+	for _, t := range tables {
+		included[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] = true
+	}
+	constraints := make(map[common.SchemaAndName][]string)
+	for rows.Next() {
+		var schemaName, tableName, indexName string
+		if err := rows.Scan(&schemaName, &tableName, &indexName); err != nil {
+			return nil, fmt.Errorf("couldn't get unique constraints: %w", err)
+		}
+		if t := (common.SchemaAndName{Schema: schemaName, Name: tableName}); included[t] {
+			constraints[t] = append(constraints[t], indexName)
+		}
+	}
+	return constraints, rows.Err()
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+func TestGetUniqueConstraints(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM pg_constraint con`)).
+		WillReturnRows(sqlmock.NewRows([]string{"nspname", "relname", "relname"}).
+			AddRow("public", "users", "users_email_key").
+			AddRow("sales", "users", "users_login_key").
+			AddRow("public", "logs", "logs_seq_key"))
+	isi := InfoSchemaImpl{Db: db}
+	tables := []common.SchemaAndName{{Schema: "public", Name: "users"}, {Schema: "sales", Name: "users"}}
+	constraints, err := isi.GetUniqueConstraints(tables)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, map[common.SchemaAndName][]string{
+		{Schema: "public", Name: "users"}: {"users_email_key"},
+		{Schema: "sales", Name: "users"}:  {"users_login_key"},
+	}, constraints)
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redshift handles schema and data migrations from Amazon Redshift.
+//
+// Redshift speaks the PostgreSQL protocol and keeps PostgreSQL's catalog,
+// so InfoSchemaImpl builds on the PostgreSQL implementation. It differs
+// where Redshift does: Redshift specific types (SUPER, VARBYTE), IDENTITY
+// columns, distribution and sort keys, which are reported as interleaving
+// and primary key suggestions, and the absence of indexes. Data is either
+// queried directly or, for larger tables, unloaded to S3 with UNLOAD and
+// read from there.
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// identityDefault prefixes the defaults Redshift reports for IDENTITY
+// columns e.g. "identity"(100213, 0, '1,1'::text).
+const identityDefault = `"identity"(`
+
+// InfoSchemaImpl is Redshift specific implementation for InfoSchema.
+type InfoSchemaImpl struct {
+	postgres.InfoSchemaImpl
+	Unload *UnloadConfig // Unload data to S3 rather than querying it, if set.
+	S3     s3iface.S3API // Client used to read unloaded data.
+}
+
+// GetToDdl implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: Redshift has no change data
+// capture that Datastream can read, so it can only be migrated with bulk
+// migrations.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for Redshift")
+}
+
+// StartStreamingMigration is not supported: Redshift can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for Redshift")
+}
+
+// ProcessData performs data conversion for source database
+// 'db'. For each table, we extract data either using a "SELECT *" query,
+// as for PostgreSQL, or by unloading it to S3, convert the data to Spanner
+// data (based on the source and Spanner schemas), and write it to
+// Spanner.  If we can't get/process data for a table, we skip that table
+// and process the remaining tables.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	if isi.Unload != nil {
+		return isi.processUnloadedData(context.Background(), conv, tableId, srcSchema, colIds)
+	}
+	return isi.InfoSchemaImpl.ProcessData(conv, tableId, srcSchema, colIds, spSchema, additionalAttributes)
+}
+
+// GetTables return list of tables in the selected database. System
+// schemas, including the ones Redshift uses internally, are skipped.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	q := `SELECT table_schema, table_name FROM information_schema.tables
+              WHERE table_type = 'BASE TABLE'
+              AND table_schema NOT IN ('information_schema', 'pg_catalog', 'pg_internal', 'pg_automv', 'pg_auto_copy', 'pg_mv', 'pg_s3')
+              ORDER BY table_schema, table_name;`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tables: %w", err)
+	}
+	defer rows.Close()
+	var tableSchema, tableName string
+	var tables []common.SchemaAndName
+	schemas := make(map[string]bool)
+	for rows.Next() {
+		rows.Scan(&tableSchema, &tableName)
+		tables = append(tables, common.SchemaAndName{Schema: tableSchema, Name: tableName})
+		schemas[tableSchema] = true
+	}
+	*isi.IsSchemaUnique = len(schemas) == 1
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names. Columns are read
+// from svv_columns, which lists SUPER and VARBYTE columns with their
+// Redshift type names, and pg_attribute, which tells whether they are part
+// of the distribution and sort keys of the table.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	q := `SELECT c.column_name, c.data_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale,
+                a.attisdistkey, a.attsortkeyord
+              FROM svv_columns c
+                JOIN pg_namespace n ON n.nspname = c.table_schema
+                JOIN pg_class t ON t.relnamespace = n.oid AND t.relname = c.table_name
+                JOIN pg_attribute a ON a.attrelid = t.oid AND a.attname = c.column_name
+              WHERE c.table_schema = $1 AND c.table_name = $2
+              ORDER BY c.ordinal_position;`
+	cols, err := isi.Db.Query(q, table.Schema, table.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
+	}
+	defer cols.Close()
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	var colName, dataType, isNullable string
+	var colDefault sql.NullString
+	var charMaxLen, numericPrecision, numericScale sql.NullInt64
+	var distKey bool
+	var sortKeyOrder int
+	for cols.Next() {
+		err := cols.Scan(&colName, &dataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &distKey, &sortKeyOrder)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		ignored := schema.Ignored{}
+		for _, c := range constraints[colName] {
+			if c == "CHECK" {
+				ignored.Check = true
+			}
+		}
+		if strings.HasPrefix(colDefault.String, identityDefault) {
+			ignored.AutoIncrement = true
+		} else {
+			ignored.Default = colDefault.Valid
+		}
+		// Columns of interleaved sort keys have negative positions.
+		if sortKeyOrder < 0 {
+			sortKeyOrder = -sortKeyOrder
+		}
+		colId := internal.GenerateColumnId()
+		c := schema.Column{
+			Id:           colId,
+			Name:         colName,
+			Type:         toType(dataType, charMaxLen, numericPrecision, numericScale),
+			NotNull:      common.ToNotNull(conv, isNullable),
+			Ignored:      ignored,
+			DistKey:      distKey,
+			SortKeyOrder: sortKeyOrder,
+		}
+		colDefs[colId] = c
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetIndexes return a list of all indexes for the specified table.
+// Redshift tables have no indexes: their distribution and sort keys are
+// reported by GetColumns instead.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return nil, nil
+}
+
+// toType maps a column of svv_columns to a schema.Type. The mods of numeric
+// types are their precision and scale, and those of character and binary
+// types their length.
+func toType(dataType string, charLen, numericPrecision, numericScale sql.NullInt64) schema.Type {
+	switch {
+	case charLen.Valid:
+		return schema.Type{Name: dataType, Mods: []int64{charLen.Int64}}
+	case dataType == "numeric" && numericPrecision.Valid && numericScale.Valid:
+		return schema.Type{Name: dataType, Mods: []int64{numericPrecision.Int64, numericScale.Int64}}
+	default:
+		return schema.Type{Name: dataType}
+	}
+}
+
+// quoteIdentifier quotes a schema, table or column name for use in
+// Redshift queries.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
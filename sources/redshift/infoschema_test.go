@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redshift
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type mockSpec struct {
+	query string
+	args  []driver.Value   // Query args.
+	cols  []string         // Columns names for returned rows.
+	rows  [][]driver.Value // Set of rows returned.
+}
+
+var (
+	constraintCols = []string{"column_name", "constraint_type"}
+	fkCols         = []string{"TABLE_SCHEMA", "REFERENCED_TABLE_NAME", "COLUMN_NAME", "REF_COLUMN_NAME", "CONSTRAINT_NAME", "ON_DELETE", "ON_UPDATE"}
+	columnCols     = []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "attisdistkey", "attsortkeyord"}
+)
+
+func TestProcessSchema(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT table_schema, table_name FROM information_schema.tables (.+)",
+			cols:  []string{"table_schema", "table_name"},
+			rows:  [][]driver.Value{{"public", "customers"}, {"public", "orders"}},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS (.+)",
+			args:  []driver.Value{"public", "customers"},
+			cols:  constraintCols,
+			rows:  [][]driver.Value{{"id", "PRIMARY KEY"}},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS (.+)",
+			args:  []driver.Value{"public", "customers"},
+			cols:  fkCols,
+		},
+		{
+			query: "SELECT (.+) FROM svv_columns (.+)",
+			args:  []driver.Value{"public", "customers"},
+			cols:  columnCols,
+			rows: [][]driver.Value{
+				{"id", "bigint", "NO", `"identity"(100213, 0, '1,1'::text)`, nil, 64, 0, true, 0},
+				{"name", "character varying", "YES", nil, 100, nil, nil, false, 0},
+				{"profile", "super", "YES", nil, nil, nil, nil, false, 0},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS (.+)",
+			args:  []driver.Value{"public", "orders"},
+			cols:  constraintCols,
+			rows:  [][]driver.Value{{"id", "PRIMARY KEY"}, {"customer_id", "FOREIGN KEY"}},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS (.+)",
+			args:  []driver.Value{"public", "orders"},
+			cols:  fkCols,
+			rows:  [][]driver.Value{{"public", "customers", "customer_id", "id", "orders_customer_fk", "NO ACTION", "NO ACTION"}},
+		},
+		{
+			query: "SELECT (.+) FROM svv_columns (.+)",
+			args:  []driver.Value{"public", "orders"},
+			cols:  columnCols,
+			rows: [][]driver.Value{
+				{"id", "bigint", "NO", nil, nil, 64, 0, false, 0},
+				{"customer_id", "bigint", "NO", nil, nil, 64, 0, true, 0},
+				{"created", "timestamp without time zone", "NO", "getdate()", nil, nil, nil, false, 1},
+				{"amount", "numeric", "YES", nil, nil, 12, 2, false, 0},
+				{"receipt", "binary varying", "YES", nil, 64, nil, nil, false, 0},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	isi := mkInfoSchema(db)
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	expectedSchema := map[string]ddl.CreateTable{
+		"customers": {
+			Name:   "customers",
+			ColIds: []string{"id", "name", "profile"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":      {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"name":    {Name: "name", T: ddl.Type{Name: ddl.String, Len: 100}},
+				"profile": {Name: "profile", T: ddl.Type{Name: ddl.JSON}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}},
+		},
+		"orders": {
+			Name:   "orders",
+			ColIds: []string{"id", "customer_id", "created", "amount", "receipt"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":          {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"customer_id": {Name: "customer_id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"created":     {Name: "created", T: ddl.Type{Name: ddl.Timestamp}, NotNull: true},
+				"amount":      {Name: "amount", T: ddl.Type{Name: ddl.Numeric}},
+				"receipt":     {Name: "receipt", T: ddl.Type{Name: ddl.Bytes, Len: 64}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "orders_customer_fk", ColIds: []string{"customer_id"}, ReferTableId: "customers", ReferColumnIds: []string{"id"}, OnDelete: constants.FK_NO_ACTION, OnUpdate: constants.FK_NO_ACTION}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+
+	// Distribution and sort keys are reported as suggestions.
+	customersTableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "customers")
+	idColId, _ := internal.GetColIdFromSpName(conv.SpSchema[customersTableId].ColDefs, "id")
+	assert.Equal(t, []internal.SchemaIssue{internal.AutoIncrement, internal.DistributionKey}, conv.SchemaIssues[customersTableId].ColumnLevelIssues[idColId])
+	ordersTableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "orders")
+	customerIdColId, _ := internal.GetColIdFromSpName(conv.SpSchema[ordersTableId].ColDefs, "customer_id")
+	assert.Contains(t, conv.SchemaIssues[ordersTableId].ColumnLevelIssues[customerIdColId], internal.DistributionKey)
+	createdColId, _ := internal.GetColIdFromSpName(conv.SpSchema[ordersTableId].ColDefs, "created")
+	assert.Equal(t, []internal.SchemaIssue{internal.Timestamp, internal.DefaultValue, internal.SortKey}, conv.SchemaIssues[ordersTableId].ColumnLevelIssues[createdColId])
+}
+
+func TestGetColumns_InterleavedSortKey(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM svv_columns (.+)",
+			args:  []driver.Value{"sales", "events"},
+			cols:  columnCols,
+			rows: [][]driver.Value{
+				{"day", "date", "NO", nil, nil, nil, nil, false, -1},
+				{"kind", "character", "NO", nil, 4, nil, nil, false, -2},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := mkInfoSchema(db)
+	colDefs, colIds, err := isi.GetColumns(internal.MakeConv(), common.SchemaAndName{Schema: "sales", Name: "events"}, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(colIds))
+	assert.Equal(t, 1, colDefs[colIds[0]].SortKeyOrder)
+	assert.Equal(t, 2, colDefs[colIds[1]].SortKeyOrder)
+	assert.Equal(t, schema.Type{Name: "character", Mods: []int64{4}}, colDefs[colIds[1]].Type)
+}
+
+func TestGetTables(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT table_schema, table_name FROM information_schema.tables"),
+			cols:  []string{"table_schema", "table_name"},
+			rows:  [][]driver.Value{{"public", "customers"}, {"sales", "orders"}},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := mkInfoSchema(db)
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "public", Name: "customers"}, {Schema: "sales", Name: "orders"}}, tables)
+	assert.Equal(t, "customers", isi.GetTableName("public", "customers"))
+	assert.Equal(t, "sales.orders", isi.GetTableName("sales", "orders"))
+}
+
+func mkInfoSchema(db *sql.DB) InfoSchemaImpl {
+	isSchemaUnique := false
+	return InfoSchemaImpl{InfoSchemaImpl: postgres.InfoSchemaImpl{Db: db, IsSchemaUnique: &isSchemaUnique}}
+}
+
+func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	for _, m := range ms {
+		rows := sqlmock.NewRows(m.cols)
+		for _, r := range m.rows {
+			rows.AddRow(r...)
+		}
+		if len(m.args) > 0 {
+			mock.ExpectQuery(m.query).WithArgs(m.args...).WillReturnRows(rows)
+		} else {
+			mock.ExpectQuery(m.query).WillReturnRows(rows)
+		}
+	}
+	return db
+}
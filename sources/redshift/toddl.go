@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redshift
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// ToDdlImpl Redshift specific implementation for ToDdl. Types shared with
+// PostgreSQL are mapped as they are for PostgreSQL.
+type ToDdlImpl struct {
+	postgres.ToDdlImpl
+}
+
+// ToSpannerType maps a scalar source schema type (defined by id and
+// mods) into a Spanner type. This is the core source-to-Spanner type
+// mapping.  toSpannerType returns the Spanner type and a list of type
+// conversion issues encountered.
+func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType schema.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
+	var ty ddl.Type
+	switch srcType.Name {
+	case "super":
+		// SUPER values are semi-structured values, which are unloaded and
+		// queried as JSON.
+		switch spType {
+		case ddl.String:
+			ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+		default:
+			ty = ddl.Type{Name: ddl.JSON}
+		}
+	case "binary varying", "varbyte":
+		switch spType {
+		case ddl.String:
+			ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+		default:
+			if len(srcType.Mods) > 0 && srcType.Mods[0] > 0 && srcType.Mods[0] <= ddl.BytesMaxLength {
+				ty = ddl.Type{Name: ddl.Bytes, Len: srcType.Mods[0]}
+			} else {
+				ty = ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}
+			}
+		}
+	default:
+		return tdi.ToDdlImpl.ToSpannerType(conv, spType, srcType, isPk)
+	}
+	var issues []internal.SchemaIssue
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		ty, issues = common.ToPGDialectType(ty, isPk)
+	}
+	return ty, issues
+}
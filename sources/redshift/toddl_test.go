@@ -0,0 +1,50 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redshift
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpannerType(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  string
+		spType   string
+		srcType  schema.Type
+		expected ddl.Type
+	}{
+		{"super", constants.DIALECT_GOOGLESQL, "", schema.Type{Name: "super"}, ddl.Type{Name: ddl.JSON}},
+		{"super as string", constants.DIALECT_GOOGLESQL, ddl.String, schema.Type{Name: "super"}, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{"super pg", constants.DIALECT_POSTGRESQL, "", schema.Type{Name: "super"}, ddl.Type{Name: ddl.JSON}},
+		{"varbyte", constants.DIALECT_GOOGLESQL, "", schema.Type{Name: "binary varying", Mods: []int64{64}}, ddl.Type{Name: ddl.Bytes, Len: 64}},
+		{"varbyte without length", constants.DIALECT_GOOGLESQL, "", schema.Type{Name: "varbyte"}, ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+		{"varbyte as string", constants.DIALECT_GOOGLESQL, ddl.String, schema.Type{Name: "varbyte", Mods: []int64{64}}, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{"bigint", constants.DIALECT_GOOGLESQL, "", schema.Type{Name: "bigint"}, ddl.Type{Name: ddl.Int64}},
+		{"varchar", constants.DIALECT_GOOGLESQL, "", schema.Type{Name: "character varying", Mods: []int64{256}}, ddl.Type{Name: ddl.String, Len: 256}},
+	}
+	for _, tc := range tests {
+		conv := internal.MakeConv()
+		conv.SpDialect = tc.dialect
+		ty, _ := ToDdlImpl{}.ToSpannerType(conv, tc.spType, tc.srcType, false)
+		assert.Equal(t, tc.expected, ty, tc.name)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redshift
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
+)
+
+// csvNull is the text NULL values are unloaded as. It is also how
+// PostgreSQL represents NULL values in COPY blocks, so that unloaded rows
+// are converted like the rows of pg_dump files.
+const csvNull = `\N`
+
+// UnloadConfig configures the unloading of the data of tables to S3 with
+// UNLOAD, which is faster than querying it for large tables. Redshift writes
+// the files with IamRole, which must be allowed to write to Uri.
+type UnloadConfig struct {
+	Uri     string // S3 path the data is unloaded under, e.g. s3://bucket/path.
+	IamRole string // ARN of the IAM role Redshift unloads data with.
+}
+
+// tableUri returns the S3 path the data of table tableName of schema
+// schemaName is unloaded under.
+func (uc UnloadConfig) tableUri(schemaName, tableName string) string {
+	return fmt.Sprintf("%s/%s/%s/", strings.TrimSuffix(uc.Uri, "/"), url.PathEscape(schemaName), url.PathEscape(tableName))
+}
+
+// manifest is the manifest file UNLOAD writes with the MANIFEST option,
+// listing the files it unloaded.
+type manifest struct {
+	Entries []struct {
+		Url string `json:"url"`
+	} `json:"entries"`
+}
+
+// processUnloadedData unloads the data of a table to S3 as CSV files, then
+// reads the unloaded files back and converts their rows as the rows of
+// pg_dump files are converted.
+func (isi InfoSchemaImpl) processUnloadedData(ctx context.Context, conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string) error {
+	srcTableName := conv.SrcSchema[tableId].Name
+	files, err := isi.unloadTable(ctx, conv.SrcSchema[tableId])
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't unload data for table %s : err = %s", srcTableName, err))
+		return err
+	}
+	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
+	for _, file := range files {
+		err := isi.readS3Object(ctx, file, func(r io.Reader) error {
+			return readCSV(r, func(srcCols, values []string) {
+				newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, colIds, srcCols, values)
+				if err != nil {
+					conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+					conv.StatsAddBadRow(srcTableName, conv.DataMode())
+					conv.CollectBadRow(srcTableName, srcCols, values)
+					return
+				}
+				postgres.ProcessDataRow(conv, tableId, colIds, toPGValues(srcSchema, colIds, newValues))
+			})
+		})
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read unloaded data file %s for table %s : err = %s", file, srcTableName, err))
+			return err
+		}
+	}
+	return nil
+}
+
+// unloadTable unloads the data of table tbl under its S3 path and returns
+// the S3 paths of the unloaded files, read from the manifest of the unload.
+func (isi InfoSchemaImpl) unloadTable(ctx context.Context, tbl schema.Table) ([]string, error) {
+	tableName := strings.TrimPrefix(tbl.Name, tbl.Schema+".")
+	uri := isi.Unload.tableUri(tbl.Schema, tableName)
+	if _, err := isi.Db.ExecContext(ctx, unloadQuery(tbl, tableName, uri, isi.Unload.IamRole)); err != nil {
+		return nil, err
+	}
+	var m manifest
+	err := isi.readS3Object(ctx, uri+"manifest", func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&m)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read unload manifest: %w", err)
+	}
+	var files []string
+	for _, e := range m.Entries {
+		files = append(files, e.Url)
+	}
+	return files, nil
+}
+
+// unloadQuery returns the UNLOAD command unloading the columns of table tbl,
+// named tableName in its schema, to CSV files under uri. Files have a
+// header row, which maps their columns to the columns of the table.
+func unloadQuery(tbl schema.Table, tableName, uri, iamRole string) string {
+	var cols []string
+	for _, colId := range tbl.ColIds {
+		cols = append(cols, quoteIdentifier(tbl.ColDefs[colId].Name))
+	}
+	selectQuery := fmt.Sprintf("SELECT %s FROM %s.%s", strings.Join(cols, ", "), quoteIdentifier(tbl.Schema), quoteIdentifier(tableName))
+	return fmt.Sprintf(`UNLOAD (%s) TO %s IAM_ROLE %s FORMAT AS CSV HEADER NULL AS '\\N' MANIFEST ALLOWOVERWRITE;`,
+		quoteLiteral(selectQuery), quoteLiteral(uri), quoteLiteral(iamRole))
+}
+
+// quoteLiteral quotes a string for use as a string literal in Redshift
+// commands, in which backslashes are escape characters.
+func quoteLiteral(s string) string {
+	return `'` + strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(s) + `'`
+}
+
+// readS3Object calls read with the content of the S3 object at path.
+func (isi InfoSchemaImpl) readS3Object(ctx context.Context, path string, read func(r io.Reader) error) error {
+	u, err := url.Parse(path)
+	if err != nil || u.Scheme != constants.S3_SCHEME {
+		return fmt.Errorf("invalid S3 path %s", path)
+	}
+	out, err := isi.S3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(u.Host),
+		Key:    aws.String(strings.TrimPrefix(u.Path, "/")),
+	})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+	return read(out.Body)
+}
+
+// readCSV calls processRow with the column names of the header row of an
+// unloaded CSV file and the values of each of its other rows.
+func readCSV(r io.Reader, processRow func(srcCols, values []string)) error {
+	reader := csv.NewReader(r)
+	srcCols, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		processRow(srcCols, values)
+	}
+}
+
+// toPGValues converts unloaded values, the values of columns colIds of a
+// row, to the text form of PostgreSQL values. Only VARBYTE values differ:
+// they are unloaded as hex strings without the \x prefix of bytea values.
+func toPGValues(srcSchema schema.Table, colIds []string, values []string) []string {
+	for i, colId := range colIds {
+		switch srcSchema.ColDefs[colId].Type.Name {
+		case "binary varying", "varbyte":
+			if values[i] != csvNull {
+				values[i] = `\x` + values[i]
+			}
+		}
+	}
+	return values
+}
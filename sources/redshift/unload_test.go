@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redshift
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// fakeS3 serves objects from memory, keyed by their S3 path.
+type fakeS3 struct {
+	s3iface.S3API
+	objects map[string]string
+}
+
+func (f fakeS3) GetObjectWithContext(ctx aws.Context, in *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	content, ok := f.objects[fmt.Sprintf("s3://%s/%s", *in.Bucket, *in.Key)]
+	if !ok {
+		return nil, fmt.Errorf("NoSuchKey: %s", *in.Key)
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(content))}, nil
+}
+
+func TestUnloadQuery(t *testing.T) {
+	tbl := schema.Table{
+		Name:    "sales.o'rders",
+		Schema:  "sales",
+		ColIds:  []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{"c1": {Name: "id"}, "c2": {Name: `no"te`}},
+	}
+	q := unloadQuery(tbl, "o'rders", "s3://bucket/unload/sales/o%27rders/", "arn:aws:iam::123456789012:role/Unload")
+	assert.Equal(t, `UNLOAD ('SELECT "id", "no""te" FROM "sales"."o''rders"') TO 's3://bucket/unload/sales/o%27rders/' IAM_ROLE 'arn:aws:iam::123456789012:role/Unload' FORMAT AS CSV HEADER NULL AS '\\N' MANIFEST ALLOWOVERWRITE;`, q)
+}
+
+func TestProcessData_Unload(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectExec(regexp.QuoteMeta(`UNLOAD ('SELECT "id", "name", "receipt" FROM "public"."orders"') TO 's3://bucket/unload/public/orders/'`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s3 := fakeS3{objects: map[string]string{
+		"s3://bucket/unload/public/orders/manifest":     `{"entries": [{"url": "s3://bucket/unload/public/orders/0000_part_00"}, {"url": "s3://bucket/unload/public/orders/0001_part_00"}]}`,
+		"s3://bucket/unload/public/orders/0000_part_00": "id,name,receipt\n1,\"Smith, J\",0a0b\n",
+		"s3://bucket/unload/public/orders/0001_part_00": "id,name,receipt\n2,\\N,\\N\nx,y,z\n",
+	}}
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"c3": {Name: "receipt", Id: "c3", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+		schema.Table{
+			Name:   "orders",
+			Id:     "t1",
+			Schema: "public",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+				"c2": {Name: "name", Id: "c2", Type: schema.Type{Name: "character varying"}},
+				"c3": {Name: "receipt", Id: "c3", Type: schema.Type{Name: "binary varying"}},
+			},
+		})
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	isi := InfoSchemaImpl{
+		InfoSchemaImpl: postgres.InfoSchemaImpl{Db: db},
+		Unload:         &UnloadConfig{Uri: "s3://bucket/unload/", IamRole: "arn:aws:iam::123456789012:role/Unload"},
+		S3:             s3,
+	}
+	err = isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], []string{"c1", "c2", "c3"}, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	assert.Equal(t, []spannerData{
+		{table: "orders", cols: []string{"id", "name", "receipt"}, vals: []interface{}{int64(1), "Smith, J", []byte{0x0a, 0x0b}}},
+		{table: "orders", cols: []string{"id"}, vals: []interface{}{int64(2)}},
+	}, rows)
+	assert.Equal(t, int64(1), conv.BadRows())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestProcessData_UnloadError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectExec("UNLOAD (.+)").WillReturnError(fmt.Errorf("permission denied"))
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:        "orders",
+			Id:          "t1",
+			ColIds:      []string{"c1"},
+			ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}}},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+		schema.Table{
+			Name:    "orders",
+			Id:      "t1",
+			Schema:  "public",
+			ColIds:  []string{"c1"},
+			ColDefs: map[string]schema.Column{"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}}},
+		})
+	isi := InfoSchemaImpl{
+		InfoSchemaImpl: postgres.InfoSchemaImpl{Db: db},
+		Unload:         &UnloadConfig{Uri: "s3://bucket/unload", IamRole: "arn:aws:iam::123456789012:role/Unload"},
+		S3:             fakeS3{},
+	}
+	err = isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], []string{"c1"}, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+}
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+func buildConv(spTable ddl.CreateTable, srcTable schema.Table) *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpSchema[spTable.Id] = spTable
+	conv.SrcSchema[srcTable.Id] = srcTable
+	conv.ToSource[spTable.Name] = internal.NameAndCols{Name: srcTable.Name, Cols: make(map[string]string)}
+	conv.ToSpanner[srcTable.Name] = internal.NameAndCols{Name: spTable.Name, Cols: make(map[string]string)}
+	for _, colId := range spTable.ColIds {
+		conv.ToSource[spTable.Name].Cols[spTable.ColDefs[colId].Name] = srcTable.ColDefs[colId].Name
+		conv.ToSpanner[srcTable.Name].Cols[srcTable.ColDefs[colId].Name] = spTable.ColDefs[colId].Name
+	}
+	return conv
+}
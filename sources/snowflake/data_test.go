@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+type spannerData struct {
+	table string
+	cols  []string
+	vals  []interface{}
+}
+
+func TestConvertData(t *testing.T) {
+	singleColTests := []struct {
+		name string
+		ty   ddl.Type
+		in   string      // Input value for conversion.
+		e    interface{} // Expected result.
+	}{
+		{"bool", ddl.Type{Name: ddl.Bool}, "true", true},
+		{"bytes", ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, "89504E47", []byte{0x89, 0x50, 0x4e, 0x47}},
+		{"date", ddl.Type{Name: ddl.Date}, "2019-10-29", civil.Date{Year: 2019, Month: 10, Day: 29}},
+		{"date with time", ddl.Type{Name: ddl.Date}, "2019-10-29T00:00:00Z", civil.Date{Year: 2019, Month: 10, Day: 29}},
+		{"float64", ddl.Type{Name: ddl.Float64}, "42.6", float64(42.6)},
+		{"float64 inf", ddl.Type{Name: ddl.Float64}, "inf", math.Inf(1)},
+		{"int64", ddl.Type{Name: ddl.Int64}, "42", int64(42)},
+		{"numeric", ddl.Type{Name: ddl.Numeric}, "234.50", big.NewRat(469, 2)},
+		{"string", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "eh", "eh"},
+		{"json", ddl.Type{Name: ddl.JSON}, `{"a": [1, 2]}`, `{"a": [1, 2]}`},
+		{"timestamp", ddl.Type{Name: ddl.Timestamp}, "2019-10-29T05:30:00.123456789+01:00", time.Date(2019, 10, 29, 5, 30, 0, 123456789, time.FixedZone("", 3600))},
+		{"timestamp utc", ddl.Type{Name: ddl.Timestamp}, "2019-10-29T05:30:00Z", time.Date(2019, 10, 29, 5, 30, 0, 0, time.UTC)},
+		{"timestamp ntz", ddl.Type{Name: ddl.Timestamp}, "2019-10-29T05:30:00.000000000", time.Date(2019, 10, 29, 5, 30, 0, 0, time.UTC)},
+	}
+	tableName := "testtable"
+	tableId := "t1"
+	for _, tc := range singleColTests {
+		col := "a"
+		colId := "c1"
+		conv := buildConv(
+			ddl.CreateTable{
+				Name:        tableName,
+				Id:          tableId,
+				ColIds:      []string{colId},
+				ColDefs:     map[string]ddl.ColumnDef{colId: {Name: col, Id: colId, T: tc.ty}},
+				PrimaryKeys: []ddl.IndexKey{}},
+			schema.Table{
+				Name:    tableName,
+				Id:      tableId,
+				ColIds:  []string{colId},
+				ColDefs: map[string]schema.Column{colId: {Name: col, Id: colId}}})
+		at, ac, av, err := ConvertData(conv, tableId, []string{colId}, conv.SrcSchema[tableId], conv.SpSchema[tableId], []string{tc.in})
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tableName, at, tc.name)
+		assert.Equal(t, []string{col}, ac, tc.name)
+		if ts, ok := tc.e.(time.Time); ok {
+			assert.True(t, ts.Equal(av[0].(time.Time)), tc.name)
+			continue
+		}
+		assert.Equal(t, []interface{}{tc.e}, av, tc.name)
+	}
+}
+
+func TestConvertError(t *testing.T) {
+	errorTests := []struct {
+		name string
+		ty   ddl.Type
+		in   string // Input value for conversion.
+	}{
+		{"bool", ddl.Type{Name: ddl.Bool}, "yes please"},
+		{"bytes", ddl.Type{Name: ddl.Bytes}, "not hex"},
+		{"int64", ddl.Type{Name: ddl.Int64}, "4.2"},
+		{"float64", ddl.Type{Name: ddl.Float64}, "forty-two"},
+		{"numeric", ddl.Type{Name: ddl.Numeric}, "a lot"},
+		{"date", ddl.Type{Name: ddl.Date}, "tomorrow"},
+		{"timestamp", ddl.Type{Name: ddl.Timestamp}, "29/10/2019"},
+	}
+	for _, tc := range errorTests {
+		_, err := convScalar(internal.MakeConv(), tc.ty, tc.in)
+		assert.NotNil(t, err, tc.name)
+	}
+}
+
+func TestConvertNumericPostgreSQL(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	v, err := convScalar(conv, ddl.Type{Name: ddl.Numeric}, "234.5")
+	assert.Nil(t, err)
+	assert.Equal(t, spanner.PGNumeric{Numeric: "234.5", Valid: true}, v)
+}
+
+func buildConv(spTable ddl.CreateTable, srcTable schema.Table) *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpSchema[spTable.Id] = spTable
+	conv.SrcSchema[srcTable.Id] = srcTable
+	return conv
+}
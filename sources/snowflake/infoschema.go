@@ -0,0 +1,420 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snowflake handles schema and data migrations from Snowflake
+// databases. Schemas are read from INFORMATION_SCHEMA, and data is either
+// queried directly or, for larger databases, unloaded to GCS in CSV or
+// Parquet files with COPY INTO and read from there.
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sp "cloud.google.com/go/spanner"
+	_ "github.com/snowflakedb/gosnowflake" // The driver should be used via the database/sql package.
+
+	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
+	storageaccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// DriverName is the name of the database/sql driver for Snowflake.
+const DriverName = "snowflake"
+
+// defaultSchema is the schema Snowflake creates in every database. Tables of
+// other schemas are named after their schema, unless a single schema is
+// migrated.
+const defaultSchema = "PUBLIC"
+
+type InfoSchemaImpl struct {
+	Db      *sql.DB
+	Schemas []string      // Schemas to migrate, all the schemas of the database if empty.
+	Unload  *UnloadConfig // Unload data to GCS rather than querying it, if set.
+
+	// Storage accessor and client used to read unloaded data, created on
+	// first use if nil.
+	StorageAccessor storageaccessor.StorageAccessor
+	StorageClient   storageclient.StorageClient
+}
+
+// GetToDdl function below implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: Snowflake can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for Snowflake")
+}
+
+// StartStreamingMigration is not supported: Snowflake can only be migrated
+// with bulk migrations.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for Snowflake")
+}
+
+// GetTableName returns table name, prefixed with its schema name unless
+// the table is in the PUBLIC schema or a single schema is migrated.
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	if isi.isQualified(schema) {
+		return fmt.Sprintf("%s.%s", schema, tableName)
+	}
+	return tableName
+}
+
+func (isi InfoSchemaImpl) isQualified(schema string) bool {
+	return schema != defaultSchema && len(isi.Schemas) != 1
+}
+
+// sourceTableName returns the name of table tbl in its Snowflake schema.
+func (isi InfoSchemaImpl) sourceTableName(tbl schema.Table) string {
+	if isi.isQualified(tbl.Schema) {
+		return strings.TrimPrefix(tbl.Name, tbl.Schema+".")
+	}
+	return tbl.Name
+}
+
+// ProcessData performs data conversion for source database
+// 'db'. For each table, we extract data either using a "SELECT" query or
+// by unloading it to GCS, convert the data to Spanner data (based on the
+// source and Spanner schemas), and write it to Spanner.  If we can't
+// get/process data for a table, we skip that table and process the
+// remaining tables.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	if isi.Unload != nil {
+		return isi.processUnloadedData(context.Background(), conv, tableId, srcSchema, commonColIds, spSchema)
+	}
+	srcTableName := conv.SrcSchema[tableId].Name
+	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTableName, err))
+		return err
+	}
+	rows := rowsInterface.(*sql.Rows)
+	defer rows.Close()
+	srcCols, _ := rows.Columns()
+	v, scanArgs := buildVals(len(srcCols))
+	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
+	for rows.Next() {
+		err := rows.Scan(scanArgs...)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
+			// Scan failed, so we don't have any data to add to bad rows.
+			conv.StatsAddBadRow(srcTableName, conv.DataMode())
+			continue
+		}
+		processValues(conv, tableId, colNameIdMap, commonColIds, srcSchema, spSchema, srcCols, valsToStrings(v))
+	}
+	return nil
+}
+
+// processValues converts values, the values of columns srcCols of a row,
+// and writes them out to Spanner.
+func processValues(conv *internal.Conv, tableId string, colNameIdMap map[string]string, commonColIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, srcCols, values []string) {
+	newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+		conv.StatsAddBadRow(srcSchema.Name, conv.DataMode())
+		conv.CollectBadRow(srcSchema.Name, srcCols, values)
+		return
+	}
+	ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+}
+
+// GetRowsFromTable returns a sql Rows object for a table.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
+	rows, err := isi.Db.Query(isi.selectQuery(conv.SrcSchema[tableId]))
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// selectQuery returns the query selecting the columns of table tbl, both
+// to query and to unload its data.
+func (isi InfoSchemaImpl) selectQuery(tbl schema.Table) string {
+	var cols []string
+	for _, colId := range tbl.ColIds {
+		cols = append(cols, quoteIdentifier(tbl.ColDefs[colId].Name))
+	}
+	return fmt.Sprintf("SELECT %s FROM %s.%s", strings.Join(cols, ", "), quoteIdentifier(tbl.Schema), quoteIdentifier(isi.sourceTableName(tbl)))
+}
+
+// buildVals contructs interface{} value containers to scan row
+// results into.  Returns both the underlying containers (as a slice)
+// as well as an interface{} of pointers to containers to pass to
+// rows.Scan.
+func buildVals(n int) (v []interface{}, iv []interface{}) {
+	v = make([]interface{}, n)
+	for i := range v {
+		iv = append(iv, &v[i])
+	}
+	return v, iv
+}
+
+// GetRowCount with number of rows in each table. Snowflake keeps the
+// number of rows of tables in INFORMATION_SCHEMA, so tables aren't scanned.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	q := `SELECT ROW_COUNT FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?;`
+	rows, err := isi.Db.Query(q, table.Schema, table.Name)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var count sql.NullInt64
+	if rows.Next() {
+		err := rows.Scan(&count)
+		return count.Int64, err
+	}
+	return 0, nil
+}
+
+// GetTables return list of tables in the selected schemas, or in all the
+// schemas of the database if none were selected. Views, external and
+// temporary tables are skipped.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	q := `SELECT TABLE_SCHEMA, TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_TYPE = 'BASE TABLE' AND TABLE_SCHEMA <> 'INFORMATION_SCHEMA'`
+	var args []interface{}
+	if len(isi.Schemas) > 0 {
+		q += fmt.Sprintf(" AND TABLE_SCHEMA IN (%s)", strings.TrimSuffix(strings.Repeat("?, ", len(isi.Schemas)), ", "))
+		for _, s := range isi.Schemas {
+			args = append(args, s)
+		}
+	}
+	q += ` ORDER BY TABLE_SCHEMA, TABLE_NAME;`
+	rows, err := isi.Db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tables: %w", err)
+	}
+	defer rows.Close()
+	var tableSchema, tableName string
+	var tables []common.SchemaAndName
+	for rows.Next() {
+		rows.Scan(&tableSchema, &tableName)
+		tables = append(tables, common.SchemaAndName{Schema: tableSchema, Name: tableName})
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	q := `SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE, IS_IDENTITY
+		FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION;`
+	cols, err := isi.Db.Query(q, table.Schema, table.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
+	}
+	defer cols.Close()
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	var colName, dataType, isNullable, isIdentity string
+	var colDefault sql.NullString
+	var charMaxLen, numericPrecision, numericScale sql.NullInt64
+	for cols.Next() {
+		err := cols.Scan(&colName, &dataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &isIdentity)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		ignored := schema.Ignored{}
+		ignored.Default = colDefault.Valid
+		ignored.AutoIncrement = isIdentity == "YES"
+		colId := internal.GenerateColumnId()
+		c := schema.Column{
+			Id:      colId,
+			Name:    colName,
+			Type:    toType(dataType, charMaxLen, numericPrecision, numericScale),
+			NotNull: strings.ToUpper(isNullable) == "NO",
+			Ignored: ignored,
+		}
+		colDefs[colId] = c
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetConstraints returns a list of primary keys and by-column map of
+// other constraints.  Note: we need to preserve ordinal order of
+// columns in primary key constraints.
+// Snowflake has no check constraints, and doesn't enforce unique
+// constraints, so they aren't migrated. Primary keys aren't enforced
+// either, except for hybrid tables, so tables with duplicate keys won't
+// be fully migrated.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	q := fmt.Sprintf("SHOW PRIMARY KEYS IN TABLE %s.%s;", quoteIdentifier(table.Schema), quoteIdentifier(table.Name))
+	rows, err := isi.queryRows(q)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sortByKeySequence(rows)
+	var primaryKeys []string
+	for _, row := range rows {
+		primaryKeys = append(primaryKeys, row["column_name"])
+	}
+	return primaryKeys, nil, make(map[string][]string), nil
+}
+
+// GetForeignKeys returns a list of all the foreign key constraints.
+// Foreign keys referencing tables of other databases are skipped.
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	q := fmt.Sprintf("SHOW IMPORTED KEYS IN TABLE %s.%s;", quoteIdentifier(table.Schema), quoteIdentifier(table.Name))
+	rows, err := isi.queryRows(q)
+	if err != nil {
+		return nil, err
+	}
+	sortByKeySequence(rows)
+	fKeys := make(map[string]common.FkConstraint)
+	var keyNames []string
+	for _, row := range rows {
+		fKeyName := row["fk_name"]
+		if row["pk_database_name"] != row["fk_database_name"] {
+			conv.Unexpected(fmt.Sprintf("Skipping foreign key %s of table %s.%s: it references a table of database %s", fKeyName, table.Schema, table.Name, row["pk_database_name"]))
+			continue
+		}
+		fk, found := fKeys[fKeyName]
+		if !found {
+			fk = common.FkConstraint{
+				Name:     fKeyName,
+				Table:    isi.GetTableName(row["pk_schema_name"], row["pk_table_name"]),
+				OnDelete: row["delete_rule"],
+				OnUpdate: row["update_rule"],
+			}
+			keyNames = append(keyNames, fKeyName)
+		}
+		fk.Cols = append(fk.Cols, row["fk_column_name"])
+		fk.Refcols = append(fk.Refcols, row["pk_column_name"])
+		fKeys[fKeyName] = fk
+	}
+	sort.Strings(keyNames)
+	for _, k := range keyNames {
+		foreignKeys = append(foreignKeys,
+			schema.ForeignKey{
+				Id:               internal.GenerateForeignkeyId(),
+				Name:             fKeys[k].Name,
+				ColumnNames:      fKeys[k].Cols,
+				ReferTableName:   fKeys[k].Table,
+				ReferColumnNames: fKeys[k].Refcols,
+				OnDelete:         fKeys[k].OnDelete,
+				OnUpdate:         fKeys[k].OnUpdate,
+			})
+	}
+	return foreignKeys, nil
+}
+
+// GetIndexes return a list of all indexes for the specified table.
+// Snowflake standard tables have no indexes.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return nil, nil
+}
+
+// queryRows runs q and returns its rows as maps from lower case column
+// names to values. It is used for SHOW and COPY INTO commands, whose
+// output gains new columns over time, so columns are looked up by name.
+func (isi InfoSchemaImpl) queryRows(q string) ([]map[string]string, error) {
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var result []map[string]string
+	for rows.Next() {
+		vals := make([]sql.NullString, len(cols))
+		scanArgs := make([]interface{}, len(cols))
+		for i := range vals {
+			scanArgs[i] = &vals[i]
+		}
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]string)
+		for i, col := range cols {
+			row[strings.ToLower(col)] = vals[i].String
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// sortByKeySequence sorts the rows of SHOW PRIMARY KEYS and SHOW IMPORTED
+// KEYS by the position of the columns in their keys.
+func sortByKeySequence(rows []map[string]string) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		ki, _ := strconv.Atoi(rows[i]["key_sequence"])
+		kj, _ := strconv.Atoi(rows[j]["key_sequence"])
+		return ki < kj
+	})
+}
+
+// toType maps a column of INFORMATION_SCHEMA.COLUMNS to a schema.Type with
+// a lower case type name. The mods of NUMBER types are their precision and
+// scale, and those of TEXT and BINARY types their length.
+func toType(dataType string, charLen, precision, scale sql.NullInt64) schema.Type {
+	ty := schema.Type{Name: strings.ToLower(dataType)}
+	switch ty.Name {
+	case "number":
+		if precision.Valid && scale.Valid {
+			ty.Mods = []int64{precision.Int64, scale.Int64}
+		}
+	case "text", "binary":
+		if charLen.Valid {
+			ty.Mods = []int64{charLen.Int64}
+		}
+	}
+	return ty
+}
+
+// quoteIdentifier quotes a schema, table or column name for use in
+// Snowflake queries.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// valsToStrings converts the values returned by the Snowflake driver to
+// the text form they are unloaded in, see unloadFileFormat.
+func valsToStrings(vals []interface{}) []string {
+	toString := func(val interface{}) string {
+		if val == nil {
+			return "NULL"
+		}
+		switch v := val.(type) {
+		case []byte:
+			return hex.EncodeToString(v)
+		case time.Time:
+			return v.Format(time.RFC3339Nano)
+		}
+		return fmt.Sprintf("%v", val)
+	}
+	var s []string
+	for _, v := range vals {
+		s = append(s, toString(v))
+	}
+	return s
+}
@@ -0,0 +1,313 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type mockSpec struct {
+	query string
+	args  []driver.Value   // Query args.
+	cols  []string         // Columns names for returned rows.
+	rows  [][]driver.Value // Set of rows returned.
+}
+
+var (
+	columnsCols     = []string{"COLUMN_NAME", "DATA_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "CHARACTER_MAXIMUM_LENGTH", "NUMERIC_PRECISION", "NUMERIC_SCALE", "IS_IDENTITY"}
+	primaryKeysCols = []string{"created_on", "database_name", "schema_name", "table_name", "column_name", "key_sequence", "constraint_name"}
+	importedKeyCols = []string{"pk_database_name", "pk_schema_name", "pk_table_name", "pk_column_name", "fk_database_name", "fk_schema_name", "fk_table_name", "fk_column_name", "key_sequence", "update_rule", "delete_rule", "fk_name"}
+)
+
+func TestProcessSchema(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT TABLE_SCHEMA, TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_TYPE = 'BASE TABLE' AND TABLE_SCHEMA <> 'INFORMATION_SCHEMA' ORDER BY TABLE_SCHEMA, TABLE_NAME;"),
+			cols:  []string{"TABLE_SCHEMA", "TABLE_NAME"},
+			rows:  [][]driver.Value{{"PUBLIC", "CUSTOMERS"}, {"SALES", "ORDERS"}},
+		},
+		{
+			query: regexp.QuoteMeta(`SHOW PRIMARY KEYS IN TABLE "PUBLIC"."CUSTOMERS";`),
+			cols:  primaryKeysCols,
+			rows:  [][]driver.Value{{time.Now(), "SHOP", "PUBLIC", "CUSTOMERS", "ID", "1", "PK_CUSTOMERS"}},
+		},
+		{
+			query: regexp.QuoteMeta(`SHOW IMPORTED KEYS IN TABLE "PUBLIC"."CUSTOMERS";`),
+			cols:  importedKeyCols,
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.COLUMNS (.+)",
+			args:  []driver.Value{"PUBLIC", "CUSTOMERS"},
+			cols:  columnsCols,
+			rows: [][]driver.Value{
+				{"ID", "NUMBER", "NO", nil, nil, 18, 0, "YES"},
+				{"NAME", "TEXT", "NO", nil, 100, nil, nil, "NO"},
+				{"NOTES", "TEXT", "YES", nil, 16777216, nil, nil, "NO"},
+				{"PROFILE", "VARIANT", "YES", nil, nil, nil, nil, "NO"},
+				{"TAGS", "ARRAY", "YES", nil, nil, nil, nil, "NO"},
+				{"ACTIVE", "BOOLEAN", "YES", "TRUE", nil, nil, nil, "NO"},
+				{"PHOTO", "BINARY", "YES", nil, 8388608, nil, nil, "NO"},
+				{"CREATED", "TIMESTAMP_NTZ", "YES", nil, nil, nil, nil, "NO"},
+				{"LOCATION", "GEOGRAPHY", "YES", nil, nil, nil, nil, "NO"},
+			},
+		},
+		{
+			query: regexp.QuoteMeta(`SHOW PRIMARY KEYS IN TABLE "SALES"."ORDERS";`),
+			cols:  primaryKeysCols,
+			rows: [][]driver.Value{
+				{time.Now(), "SHOP", "SALES", "ORDERS", "LINE", "2", "PK_ORDERS"},
+				{time.Now(), "SHOP", "SALES", "ORDERS", "ID", "1", "PK_ORDERS"},
+			},
+		},
+		{
+			query: regexp.QuoteMeta(`SHOW IMPORTED KEYS IN TABLE "SALES"."ORDERS";`),
+			cols:  importedKeyCols,
+			rows: [][]driver.Value{
+				{"SHOP", "PUBLIC", "CUSTOMERS", "ID", "SHOP", "SALES", "ORDERS", "CUSTOMER_ID", "1", "NO ACTION", "CASCADE", "FK_CUSTOMER"},
+				{"CRM", "PUBLIC", "ACCOUNTS", "ID", "SHOP", "SALES", "ORDERS", "CUSTOMER_ID", "1", "NO ACTION", "NO ACTION", "FK_ACCOUNT"},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.COLUMNS (.+)",
+			args:  []driver.Value{"SALES", "ORDERS"},
+			cols:  columnsCols,
+			rows: [][]driver.Value{
+				{"ID", "NUMBER", "NO", nil, nil, 38, 0, "NO"},
+				{"LINE", "NUMBER", "NO", nil, nil, 4, 0, "NO"},
+				{"CUSTOMER_ID", "NUMBER", "YES", nil, nil, 18, 0, "NO"},
+				{"AMOUNT", "NUMBER", "YES", nil, nil, 12, 2, "NO"},
+				{"RATE", "FLOAT", "YES", nil, nil, nil, nil, "NO"},
+				{"ORDERED", "DATE", "YES", nil, nil, nil, nil, "NO"},
+				{"SHIPPED", "TIMESTAMP_TZ", "YES", nil, nil, nil, nil, "NO"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	isi := InfoSchemaImpl{Db: db}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	expectedSchema := map[string]ddl.CreateTable{
+		"CUSTOMERS": {
+			Name:   "CUSTOMERS",
+			ColIds: []string{"ID", "NAME", "NOTES", "PROFILE", "TAGS", "ACTIVE", "PHOTO", "CREATED", "LOCATION"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"ID":       {Name: "ID", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"NAME":     {Name: "NAME", T: ddl.Type{Name: ddl.String, Len: 100}, NotNull: true},
+				"NOTES":    {Name: "NOTES", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"PROFILE":  {Name: "PROFILE", T: ddl.Type{Name: ddl.JSON}},
+				"TAGS":     {Name: "TAGS", T: ddl.Type{Name: ddl.JSON}},
+				"ACTIVE":   {Name: "ACTIVE", T: ddl.Type{Name: ddl.Bool}},
+				"PHOTO":    {Name: "PHOTO", T: ddl.Type{Name: ddl.Bytes, Len: 8388608}},
+				"CREATED":  {Name: "CREATED", T: ddl.Type{Name: ddl.Timestamp}},
+				"LOCATION": {Name: "LOCATION", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "ID", Order: 1}},
+		},
+		"SALES_ORDERS": {
+			Name:   "SALES_ORDERS",
+			ColIds: []string{"ID", "LINE", "CUSTOMER_ID", "AMOUNT", "RATE", "ORDERED", "SHIPPED"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"ID":          {Name: "ID", T: ddl.Type{Name: ddl.Numeric}, NotNull: true},
+				"LINE":        {Name: "LINE", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"CUSTOMER_ID": {Name: "CUSTOMER_ID", T: ddl.Type{Name: ddl.Int64}},
+				"AMOUNT":      {Name: "AMOUNT", T: ddl.Type{Name: ddl.Numeric}},
+				"RATE":        {Name: "RATE", T: ddl.Type{Name: ddl.Float64}},
+				"ORDERED":     {Name: "ORDERED", T: ddl.Type{Name: ddl.Date}},
+				"SHIPPED":     {Name: "SHIPPED", T: ddl.Type{Name: ddl.Timestamp}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "ID", Order: 1}, {ColId: "LINE", Order: 2}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "FK_CUSTOMER", ColIds: []string{"CUSTOMER_ID"}, ReferTableId: "CUSTOMERS", ReferColumnIds: []string{"ID"}, OnDelete: "CASCADE", OnUpdate: "NO ACTION"}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, stripSchemaComments(conv.SpSchema))
+
+	customersTableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "CUSTOMERS")
+	assert.Nil(t, err)
+	idColId, err := internal.GetColIdFromSpName(conv.SpSchema[customersTableId].ColDefs, "ID")
+	assert.Nil(t, err)
+	assert.Contains(t, conv.SchemaIssues[customersTableId].ColumnLevelIssues[idColId], internal.AutoIncrement)
+	ordersTableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "SALES_ORDERS")
+	assert.Nil(t, err)
+	assert.Equal(t, "SALES.ORDERS", conv.SrcSchema[ordersTableId].Name)
+	assert.Equal(t, "SALES", conv.SrcSchema[ordersTableId].Schema)
+	// The foreign key referencing a table of another database is skipped.
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+}
+
+func TestGetTables_Schemas(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("AND TABLE_SCHEMA IN (?, ?) ORDER BY TABLE_SCHEMA, TABLE_NAME;"),
+			args:  []driver.Value{"SALES", "HR"},
+			cols:  []string{"TABLE_SCHEMA", "TABLE_NAME"},
+			rows:  [][]driver.Value{{"HR", "EMPLOYEES"}, {"SALES", "ORDERS"}},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db, Schemas: []string{"SALES", "HR"}}
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "HR", Name: "EMPLOYEES"}, {Schema: "SALES", Name: "ORDERS"}}, tables)
+}
+
+func TestGetTableName(t *testing.T) {
+	assert.Equal(t, "ORDERS", InfoSchemaImpl{}.GetTableName("PUBLIC", "ORDERS"))
+	assert.Equal(t, "SALES.ORDERS", InfoSchemaImpl{}.GetTableName("SALES", "ORDERS"))
+	assert.Equal(t, "SALES.ORDERS", InfoSchemaImpl{Schemas: []string{"SALES", "HR"}}.GetTableName("SALES", "ORDERS"))
+	// Table names are unique when a single schema is migrated.
+	assert.Equal(t, "ORDERS", InfoSchemaImpl{Schemas: []string{"SALES"}}.GetTableName("SALES", "ORDERS"))
+}
+
+func TestGetRowCount(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT ROW_COUNT FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?;"),
+			args:  []driver.Value{"SALES", "ORDERS"},
+			cols:  []string{"ROW_COUNT"},
+			rows:  [][]driver.Value{{int64(42)}},
+		},
+	}
+	db := mkMockDB(t, ms)
+	count, err := InfoSchemaImpl{Db: db}.GetRowCount(common.SchemaAndName{Schema: "SALES", Name: "ORDERS"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), count)
+}
+
+func TestProcessData(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta(`SELECT "ID", "AMOUNT", "ORDERED", "SHIPPED", "DATA", "DETAILS" FROM "SALES"."ORDERS"`),
+			cols:  []string{"ID", "AMOUNT", "ORDERED", "SHIPPED", "DATA", "DETAILS"},
+			rows: [][]driver.Value{
+				{"1", "12.50", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 4, 5, 6, 0, time.UTC), []byte{0xca, 0xfe}, `{"a": 1}`},
+				{"2", nil, nil, nil, nil, nil},
+				{"x", nil, nil, nil, nil, nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := buildConv(ordersSpTable(), ordersSrcTable())
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	isi := InfoSchemaImpl{Db: db}
+	err := isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	assert.Equal(t, []spannerData{
+		{
+			table: "SALES_ORDERS",
+			cols:  []string{"ID", "AMOUNT", "ORDERED", "SHIPPED", "DATA", "DETAILS"},
+			vals:  []interface{}{int64(1), big.NewRat(25, 2), civil.Date{Year: 2024, Month: 1, Day: 2}, time.Date(2024, 1, 3, 4, 5, 6, 0, time.UTC), []byte{0xca, 0xfe}, `{"a": 1}`},
+		},
+		{table: "SALES_ORDERS", cols: []string{"ID"}, vals: []interface{}{int64(2)}},
+	}, rows)
+	assert.Equal(t, int64(1), conv.BadRows())
+}
+
+// ordersSpTable and ordersSrcTable return the schemas of table ORDERS of
+// schema SALES, migrated along with tables of other schemas.
+func ordersSpTable() ddl.CreateTable {
+	return ddl.CreateTable{
+		Name:   "SALES_ORDERS",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3", "c4", "c5", "c6"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "ID", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "AMOUNT", Id: "c2", T: ddl.Type{Name: ddl.Numeric}},
+			"c3": {Name: "ORDERED", Id: "c3", T: ddl.Type{Name: ddl.Date}},
+			"c4": {Name: "SHIPPED", Id: "c4", T: ddl.Type{Name: ddl.Timestamp}},
+			"c5": {Name: "DATA", Id: "c5", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			"c6": {Name: "DETAILS", Id: "c6", T: ddl.Type{Name: ddl.JSON}},
+		},
+		PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+	}
+}
+
+func ordersSrcTable() schema.Table {
+	return schema.Table{
+		Name:   "SALES.ORDERS",
+		Schema: "SALES",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3", "c4", "c5", "c6"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "ID", Id: "c1", Type: schema.Type{Name: "number", Mods: []int64{18, 0}}},
+			"c2": {Name: "AMOUNT", Id: "c2", Type: schema.Type{Name: "number", Mods: []int64{12, 2}}},
+			"c3": {Name: "ORDERED", Id: "c3", Type: schema.Type{Name: "date"}},
+			"c4": {Name: "SHIPPED", Id: "c4", Type: schema.Type{Name: "timestamp_tz"}},
+			"c5": {Name: "DATA", Id: "c5", Type: schema.Type{Name: "binary"}},
+			"c6": {Name: "DETAILS", Id: "c6", Type: schema.Type{Name: "variant"}},
+		},
+	}
+}
+
+func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	for _, m := range ms {
+		rows := sqlmock.NewRows(m.cols)
+		for _, r := range m.rows {
+			rows.AddRow(r...)
+		}
+		if len(m.args) > 0 {
+			mock.ExpectQuery(m.query).WithArgs(m.args...).WillReturnRows(rows)
+		} else {
+			mock.ExpectQuery(m.query).WillReturnRows(rows)
+		}
+	}
+	return db
+}
+
+// stripSchemaComments returns a schema with all comments removed.
+// We mostly ignore schema comments in testing since schema comments
+// are often changed and are not a core part of conversion functionality.
+func stripSchemaComments(spSchema map[string]ddl.CreateTable) map[string]ddl.CreateTable {
+	for t, ct := range spSchema {
+		for c, cd := range ct.ColDefs {
+			cd.Comment = ""
+			ct.ColDefs[c] = cd
+		}
+		ct.Comment = ""
+		spSchema[t] = ct
+	}
+	return spSchema
+}
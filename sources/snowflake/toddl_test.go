@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpannerType(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		isPk    bool
+		srcType schema.Type
+		want    ddl.Type
+		issues  []internal.SchemaIssue
+	}{
+		{name: "small integer", srcType: schema.Type{Name: "number", Mods: []int64{9, 0}}, want: ddl.Type{Name: ddl.Int64}},
+		{name: "int64 precision", srcType: schema.Type{Name: "number", Mods: []int64{18, 0}}, want: ddl.Type{Name: ddl.Int64}},
+		{name: "integer", srcType: schema.Type{Name: "number", Mods: []int64{38, 0}}, want: ddl.Type{Name: ddl.Numeric}, issues: []internal.SchemaIssue{internal.Numeric}},
+		{name: "integer to int64", spType: ddl.Int64, srcType: schema.Type{Name: "number", Mods: []int64{38, 0}}, want: ddl.Type{Name: ddl.Int64}},
+		{name: "decimal", srcType: schema.Type{Name: "number", Mods: []int64{12, 2}}, want: ddl.Type{Name: ddl.Numeric}},
+		{name: "decimal pg", dialect: constants.DIALECT_POSTGRESQL, srcType: schema.Type{Name: "number", Mods: []int64{12, 2}}, want: ddl.Type{Name: ddl.Numeric, Precision: 12, Scale: 2}},
+		{name: "decimal pg pk", dialect: constants.DIALECT_POSTGRESQL, isPk: true, srcType: schema.Type{Name: "number", Mods: []int64{12, 2}}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.NumericPKNotSupported}},
+		{name: "decimal to string", spType: ddl.String, srcType: schema.Type{Name: "number", Mods: []int64{12, 2}}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "float", srcType: schema.Type{Name: "float"}, want: ddl.Type{Name: ddl.Float64}},
+		{name: "varchar", srcType: schema.Type{Name: "text", Mods: []int64{100}}, want: ddl.Type{Name: ddl.String, Len: 100}},
+		{name: "varchar max", srcType: schema.Type{Name: "text", Mods: []int64{16777216}}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{name: "binary", srcType: schema.Type{Name: "binary", Mods: []int64{16}}, want: ddl.Type{Name: ddl.Bytes, Len: 16}},
+		{name: "boolean", srcType: schema.Type{Name: "boolean"}, want: ddl.Type{Name: ddl.Bool}},
+		{name: "date", srcType: schema.Type{Name: "date"}, want: ddl.Type{Name: ddl.Date}},
+		{name: "time", srcType: schema.Type{Name: "time"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Time}},
+		{name: "timestamp_ntz", srcType: schema.Type{Name: "timestamp_ntz"}, want: ddl.Type{Name: ddl.Timestamp}, issues: []internal.SchemaIssue{internal.Timestamp}},
+		{name: "timestamp_ltz", srcType: schema.Type{Name: "timestamp_ltz"}, want: ddl.Type{Name: ddl.Timestamp}},
+		{name: "timestamp_tz", srcType: schema.Type{Name: "timestamp_tz"}, want: ddl.Type{Name: ddl.Timestamp}},
+		{name: "variant", srcType: schema.Type{Name: "variant"}, want: ddl.Type{Name: ddl.JSON}},
+		{name: "object", srcType: schema.Type{Name: "object"}, want: ddl.Type{Name: ddl.JSON}},
+		{name: "array", srcType: schema.Type{Name: "array"}, want: ddl.Type{Name: ddl.JSON}},
+		{name: "variant to string", spType: ddl.String, srcType: schema.Type{Name: "variant"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		{name: "geography", srcType: schema.Type{Name: "geography"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.NoGoodType}},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		ty, issues := ToDdlImpl{}.ToSpannerType(conv, tc.spType, tc.srcType, tc.isPk)
+		assert.Equal(t, tc.want, ty, tc.name)
+		assert.Equal(t, tc.issues, issues, tc.name)
+	}
+}
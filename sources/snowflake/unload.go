@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+
+	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
+	storageaccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Formats of the files data is unloaded to.
+const (
+	UnloadFormatCSV     string = "csv"
+	UnloadFormatParquet string = "parquet"
+)
+
+// snowflakeGCSPrefix is the prefix of GCS locations in Snowflake.
+const snowflakeGCSPrefix = "gcs://"
+
+// csvNull is the text unloaded for NULL values in CSV files. CSV files
+// don't tell quoted values apart, so strings with this text are read as
+// NULL values.
+const csvNull = `\N`
+
+// unloadFileFormats are the FILE_FORMAT options of COPY INTO for each
+// unload format. Values are unloaded in CSV files in the formats convScalar
+// parses. Parquet files keep the types of values, see arrowValueToString.
+var unloadFileFormats = map[string]string{
+	UnloadFormatCSV: `TYPE = CSV COMPRESSION = NONE FIELD_OPTIONALLY_ENCLOSED_BY = '"' NULL_IF = ('\\N') EMPTY_FIELD_AS_NULL = FALSE ` +
+		`BINARY_FORMAT = HEX DATE_FORMAT = 'YYYY-MM-DD' TIMESTAMP_FORMAT = 'YYYY-MM-DD"T"HH24:MI:SS.FF9TZH:TZM'`,
+	UnloadFormatParquet: `TYPE = PARQUET`,
+}
+
+// storageIntegrationRegexp matches the unquoted identifiers storage
+// integrations are named with. The name of the storage integration can't be
+// quoted since unquoted names are stored in upper case.
+var storageIntegrationRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// UnloadConfig configures the unloading of the data of tables to GCS, which
+// is faster than querying it for large tables. Snowflake writes the files
+// through a storage integration, which must be allowed to write to Uri.
+type UnloadConfig struct {
+	Uri                string // GCS path the data is unloaded under, e.g. gs://bucket/path.
+	StorageIntegration string // Name of the Snowflake storage integration for the bucket of Uri.
+	Format             string // One of UnloadFormatCSV and UnloadFormatParquet.
+}
+
+// tableUri returns the GCS path the data of table tableName of schema
+// schemaName is unloaded under.
+func (uc UnloadConfig) tableUri(schemaName, tableName string) string {
+	return fmt.Sprintf("%s/%s/%s/", strings.TrimSuffix(uc.Uri, "/"), url.PathEscape(schemaName), url.PathEscape(tableName))
+}
+
+// processUnloadedData unloads the data of a table to GCS, then reads the
+// unloaded files back and converts their rows like ProcessData does the
+// rows it queries.
+func (isi InfoSchemaImpl) processUnloadedData(ctx context.Context, conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable) error {
+	srcTableName := conv.SrcSchema[tableId].Name
+	files, err := isi.unloadTable(conv.SrcSchema[tableId])
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't unload data for table %s : err = %s", srcTableName, err))
+		return err
+	}
+	sa, sc, err := isi.getStorage(ctx)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't read unloaded data for table %s : err = %s", srcTableName, err))
+		return err
+	}
+	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
+	processRow := func(srcCols, values []string) {
+		processValues(conv, tableId, colNameIdMap, commonColIds, srcSchema, spSchema, srcCols, values)
+	}
+	for _, file := range files {
+		content, err := sa.ReadGcsFile(ctx, sc, file)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read unloaded file %s of table %s : err = %s", file, srcTableName, err))
+			return err
+		}
+		if isi.Unload.Format == UnloadFormatParquet {
+			err = readParquet(ctx, content, processRow)
+		} else {
+			err = readCSV(content, processRow)
+		}
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read unloaded file %s of table %s : err = %s", file, srcTableName, err))
+			return err
+		}
+	}
+	return nil
+}
+
+// unloadTable unloads the data of table tbl with COPY INTO and returns the
+// GCS paths of the files written.
+func (isi InfoSchemaImpl) unloadTable(tbl schema.Table) ([]string, error) {
+	fileFormat, ok := unloadFileFormats[isi.Unload.Format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported unload format %s", isi.Unload.Format)
+	}
+	if !storageIntegrationRegexp.MatchString(isi.Unload.StorageIntegration) {
+		return nil, fmt.Errorf("invalid storage integration name %q", isi.Unload.StorageIntegration)
+	}
+	uri := isi.Unload.tableUri(tbl.Schema, isi.sourceTableName(tbl))
+	location := snowflakeGCSPrefix + strings.TrimPrefix(uri, constants.GCS_FILE_PREFIX)
+	q := fmt.Sprintf("COPY INTO '%s' FROM (%s) STORAGE_INTEGRATION = %s FILE_FORMAT = (%s) HEADER = TRUE OVERWRITE = TRUE DETAILED_OUTPUT = TRUE;",
+		strings.ReplaceAll(location, "'", "''"), isi.selectQuery(tbl), isi.Unload.StorageIntegration, fileFormat)
+	rows, err := isi.queryRows(q)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, row := range rows {
+		files = append(files, uri+row["file_name"])
+	}
+	return files, nil
+}
+
+func (isi InfoSchemaImpl) getStorage(ctx context.Context) (storageaccessor.StorageAccessor, storageclient.StorageClient, error) {
+	sa, sc := isi.StorageAccessor, isi.StorageClient
+	if sa == nil {
+		sa = &storageaccessor.StorageAccessorImpl{}
+	}
+	if sc == nil {
+		var err error
+		sc, err = storageclient.NewStorageClientImpl(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return sa, sc, nil
+}
+
+// readCSV calls processRow with the values of each row of an unloaded CSV
+// file, whose first line is the header with the column names.
+func readCSV(content string, processRow func(srcCols, values []string)) error {
+	r := csv.NewReader(strings.NewReader(content))
+	srcCols, err := r.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for {
+		values, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for i := range values {
+			if values[i] == csvNull {
+				values[i] = "NULL"
+			}
+		}
+		processRow(srcCols, values)
+	}
+}
+
+// readParquet calls processRow with the values of each row of an unloaded
+// Parquet file.
+func readParquet(ctx context.Context, content string, processRow func(srcCols, values []string)) error {
+	mem := memory.DefaultAllocator
+	tbl, err := pqarrow.ReadTable(ctx, bytes.NewReader([]byte(content)), parquet.NewReaderProperties(mem), pqarrow.ArrowReadProperties{}, mem)
+	if err != nil {
+		return err
+	}
+	defer tbl.Release()
+	var srcCols []string
+	for _, field := range tbl.Schema().Fields() {
+		srcCols = append(srcCols, field.Name)
+	}
+	tr := array.NewTableReader(tbl, 0)
+	defer tr.Release()
+	for tr.Next() {
+		rec := tr.Record()
+		for i := 0; i < int(rec.NumRows()); i++ {
+			values := make([]string, len(srcCols))
+			for j := range srcCols {
+				values[j] = arrowValueToString(rec.Column(j), i)
+			}
+			processRow(srcCols, values)
+		}
+	}
+	return tr.Err()
+}
+
+// arrowValueToString converts the i-th value of arr, read from a Parquet
+// file, to the text form values are unloaded in CSV files.
+func arrowValueToString(arr arrow.Array, i int) string {
+	if arr.IsNull(i) {
+		return "NULL"
+	}
+	switch a := arr.(type) {
+	case *array.String:
+		return a.Value(i)
+	case *array.Binary:
+		return hex.EncodeToString(a.Value(i))
+	case *array.Boolean:
+		return strconv.FormatBool(a.Value(i))
+	case *array.Int8:
+		return strconv.FormatInt(int64(a.Value(i)), 10)
+	case *array.Int16:
+		return strconv.FormatInt(int64(a.Value(i)), 10)
+	case *array.Int32:
+		return strconv.FormatInt(int64(a.Value(i)), 10)
+	case *array.Int64:
+		return strconv.FormatInt(a.Value(i), 10)
+	case *array.Float32:
+		return strconv.FormatFloat(float64(a.Value(i)), 'g', -1, 32)
+	case *array.Float64:
+		return strconv.FormatFloat(a.Value(i), 'g', -1, 64)
+	case *array.Decimal128:
+		return a.Value(i).ToString(a.DataType().(*arrow.Decimal128Type).Scale)
+	case *array.Date32:
+		return a.Value(i).FormattedString()
+	case *array.Timestamp:
+		unit := a.DataType().(*arrow.TimestampType).Unit
+		return a.Value(i).ToTime(unit).Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(arr.GetOneForMarshal(i))
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflake
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/decimal128"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+
+	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
+	storageaccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/storage"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+)
+
+func TestProcessData_Unload(t *testing.T) {
+	csvFile := "ID,AMOUNT,ORDERED,SHIPPED,DATA,DETAILS\n" +
+		`1,12.50,2024-01-02,2024-01-03T04:05:06.000000000+00:00,CAFE,"{""a"": 1}"` + "\n" +
+		`2,\N,\N,\N,\N,\N` + "\n"
+	testCases := []struct {
+		name   string
+		format string
+		files  map[string]string // Unloaded files by GCS path.
+	}{
+		{
+			name:   "csv",
+			format: UnloadFormatCSV,
+			files: map[string]string{
+				"gs://bucket/unload/SALES/ORDERS/data_0_0_0.csv": csvFile,
+			},
+		},
+		{
+			name:   "parquet",
+			format: UnloadFormatParquet,
+			files: map[string]string{
+				"gs://bucket/unload/SALES/ORDERS/data_0_0_0.snappy.parquet": mkParquetFile(t),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		var unloaded []driver.Value
+		for file := range tc.files {
+			unloaded = append(unloaded, file[len("gs://bucket/unload/SALES/ORDERS/"):])
+		}
+		ms := []mockSpec{
+			{
+				query: regexp.QuoteMeta(fmt.Sprintf(`COPY INTO 'gcs://bucket/unload/SALES/ORDERS/' FROM (SELECT "ID", "AMOUNT", "ORDERED", "SHIPPED", "DATA", "DETAILS" FROM "SALES"."ORDERS") `+
+					`STORAGE_INTEGRATION = GCS_INT FILE_FORMAT = (%s) HEADER = TRUE OVERWRITE = TRUE DETAILED_OUTPUT = TRUE;`, unloadFileFormats[tc.format])),
+				cols: []string{"FILE_NAME", "FILE_SIZE", "ROW_COUNT"},
+				rows: [][]driver.Value{{unloaded[0], "1024", "2"}},
+			},
+		}
+		db := mkMockDB(t, ms)
+		conv := buildConv(ordersSpTable(), ordersSrcTable())
+		var rows []spannerData
+		conv.SetDataMode()
+		conv.SetDataSink(
+			func(table string, cols []string, vals []interface{}) {
+				rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+			})
+		isi := InfoSchemaImpl{
+			Db:     db,
+			Unload: &UnloadConfig{Uri: "gs://bucket/unload/", StorageIntegration: "GCS_INT", Format: tc.format},
+			StorageAccessor: &storageaccessor.StorageAccessorMock{
+				ReadGcsFileMock: func(ctx context.Context, sc storageclient.StorageClient, filePath string) (string, error) {
+					content, ok := tc.files[filePath]
+					if !ok {
+						return "", fmt.Errorf("file %s not found", filePath)
+					}
+					return content, nil
+				},
+			},
+			StorageClient: &storageclient.StorageClientMock{},
+		}
+		err := isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, 2, len(rows), tc.name)
+		if len(rows) != 2 {
+			continue
+		}
+		assert.Equal(t, []string{"ID", "AMOUNT", "ORDERED", "SHIPPED", "DATA", "DETAILS"}, rows[0].cols, tc.name)
+		assert.Equal(t, []interface{}{int64(1), big.NewRat(25, 2), civil.Date{Year: 2024, Month: 1, Day: 2}}, rows[0].vals[:3], tc.name)
+		assert.True(t, time.Date(2024, 1, 3, 4, 5, 6, 0, time.UTC).Equal(rows[0].vals[3].(time.Time)), tc.name)
+		assert.Equal(t, []interface{}{[]byte{0xca, 0xfe}, `{"a": 1}`}, rows[0].vals[4:], tc.name)
+		assert.Equal(t, spannerData{table: "SALES_ORDERS", cols: []string{"ID"}, vals: []interface{}{int64(2)}}, rows[1], tc.name)
+		assert.Equal(t, int64(0), conv.BadRows(), tc.name)
+	}
+}
+
+func TestProcessData_UnloadError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery("COPY INTO (.+)").WillReturnError(fmt.Errorf("storage integration GCS_INT does not exist"))
+	conv := buildConv(ordersSpTable(), ordersSrcTable())
+	isi := InfoSchemaImpl{Db: db, Unload: &UnloadConfig{Uri: "gs://bucket/unload", StorageIntegration: "GCS_INT", Format: UnloadFormatCSV}}
+	err = isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+
+	// Storage integration names are part of the COPY INTO statement, so
+	// names other than identifiers are rejected before querying.
+	db, mock, err = sqlmock.New()
+	assert.Nil(t, err)
+	conv = buildConv(ordersSpTable(), ordersSrcTable())
+	isi = InfoSchemaImpl{Db: db, Unload: &UnloadConfig{Uri: "gs://bucket/unload", StorageIntegration: "GCS_INT FILE_FORMAT = (TYPE = JSON)", Format: UnloadFormatCSV}}
+	err = isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.NotNil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+// mkParquetFile returns a Parquet file with the rows of the CSV file of
+// TestProcessData_Unload, with the types Snowflake unloads them with.
+func mkParquetFile(t *testing.T) string {
+	mem := memory.NewGoAllocator()
+	sc := arrow.NewSchema([]arrow.Field{
+		{Name: "ID", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "AMOUNT", Type: &arrow.Decimal128Type{Precision: 12, Scale: 2}, Nullable: true},
+		{Name: "ORDERED", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+		{Name: "SHIPPED", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, Nullable: true},
+		{Name: "DATA", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "DETAILS", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	b := array.NewRecordBuilder(mem, sc)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	b.Field(1).(*array.Decimal128Builder).AppendValues([]decimal128.Num{decimal128.FromI64(1250), {}}, []bool{true, false})
+	b.Field(2).(*array.Date32Builder).AppendValues([]arrow.Date32{arrow.Date32FromTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)), 0}, []bool{true, false})
+	b.Field(3).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{arrow.Timestamp(time.Date(2024, 1, 3, 4, 5, 6, 0, time.UTC).UnixMicro()), 0}, []bool{true, false})
+	b.Field(4).(*array.BinaryBuilder).AppendValues([][]byte{{0xca, 0xfe}, nil}, []bool{true, false})
+	b.Field(5).(*array.StringBuilder).AppendValues([]string{`{"a": 1}`, ""}, []bool{true, false})
+	rec := b.NewRecord()
+	defer rec.Release()
+	tbl := array.NewTableFromRecords(sc, []arrow.Record{rec})
+	defer tbl.Release()
+	var buf bytes.Buffer
+	err := pqarrow.WriteTable(tbl, &buf, 1024, nil, pqarrow.DefaultWriterProps())
+	assert.Nil(t, err)
+	return buf.String()
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/dataflow/apiv1beta3/dataflowpb"
+	"github.com/googleapis/gax-go/v2"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Classic Dataflow templates exporting a Spanner table to CSV files, and
+// importing CSV files into a Spanner database, in the region of the jobs.
+const (
+	exportTemplatePath = "gs://dataflow-templates-%s/latest/Spanner_to_GCS_Text"
+	importTemplatePath = "gs://dataflow-templates-%s/latest/GCS_Text_to_Cloud_Spanner"
+)
+
+// TemplatesClient launches classic Dataflow templates, see
+// dataflow.TemplatesClient.
+type TemplatesClient interface {
+	LaunchTemplate(ctx context.Context, req *dataflowpb.LaunchTemplateRequest, opts ...gax.CallOption) (*dataflowpb.LaunchTemplateResponse, error)
+}
+
+// JobsClient fetches the state of Dataflow jobs, see
+// dataflow.JobsV1Beta3Client.
+type JobsClient interface {
+	GetJob(ctx context.Context, req *dataflowpb.GetJobRequest, opts ...gax.CallOption) (*dataflowpb.Job, error)
+}
+
+// Database identifies a Spanner database.
+type Database struct {
+	Project  string
+	Instance string
+	Db       string
+}
+
+// DataflowCopy copies the data of a Spanner database to the database it was
+// converted to, e.g. one of the other dialect, with Dataflow. Each table is
+// exported to CSV files in GcsPath by a Spanner_to_GCS_Text job, and the
+// files are then imported into the converted tables by a single
+// GCS_Text_to_Cloud_Spanner job, which loads parent tables before the tables
+// interleaved in them.
+type DataflowCopy struct {
+	Templates TemplatesClient
+	Jobs      JobsClient
+	// WriteGCSFile writes data to the file fileName of the Cloud Storage
+	// directory filePath, see storageaccessor.StorageAccessor.WriteDataToGCS.
+	WriteGCSFile func(ctx context.Context, filePath, fileName, data string) error
+	Project      string // Project the Dataflow jobs run in.
+	Region       string
+	GcsPath      string
+	PollInterval time.Duration
+}
+
+// textImportManifest is the manifest of the GCS_Text_to_Cloud_Spanner
+// template, listing the files and columns of each table.
+type textImportManifest struct {
+	Tables []textImportTable `json:"tables"`
+}
+
+type textImportTable struct {
+	TableName    string             `json:"table_name"`
+	FilePatterns []string           `json:"file_patterns"`
+	Columns      []textImportColumn `json:"columns"`
+}
+
+type textImportColumn struct {
+	ColumnName string `json:"column_name"`
+	TypeName   string `json:"type_name"`
+}
+
+// textTypes are the names of the types the text import template accepts, by
+// dialect and ddl type.
+var textTypes = map[string]map[string]string{
+	constants.DIALECT_GOOGLESQL: {
+		ddl.Bool: "BOOL", ddl.Bytes: "BYTES", ddl.Date: "DATE", ddl.Float32: "FLOAT32", ddl.Float64: "FLOAT64",
+		ddl.Int64: "INT64", ddl.Numeric: "NUMERIC", ddl.JSON: "JSON", ddl.String: "STRING", ddl.Timestamp: "TIMESTAMP",
+	},
+	constants.DIALECT_POSTGRESQL: {
+		ddl.Bool: "boolean", ddl.Bytes: "bytea", ddl.Date: "date", ddl.Float32: "real", ddl.Float64: "double precision",
+		ddl.Int64: "bigint", ddl.Numeric: "numeric", ddl.JSON: "jsonb", ddl.PGJSONB: "jsonb", ddl.String: "character varying",
+		ddl.Timestamp: "timestamp with time zone",
+	},
+}
+
+// CopyData copies the data of the tables of conv from the database src to
+// dst. All tables are exported as of the same timestamp, so writes to src
+// during the copy aren't copied. It returns once the import job is done, or
+// the first job that fails.
+func (dc DataflowCopy) CopyData(ctx context.Context, conv *internal.Conv, src, dst Database) error {
+	gcsPath := strings.TrimSuffix(dc.GcsPath, "/")
+	manifest, err := buildTextImportManifest(conv, gcsPath)
+	if err != nil {
+		return err
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't encode import manifest: %v", err)
+	}
+
+	// Dataflow job names only have lower case letters, digits and hyphens.
+	prefix := fmt.Sprintf("smt-%s-%d", strings.ReplaceAll(strings.ToLower(src.Db), "_", "-"), time.Now().Unix())
+	snapshotTime := time.Now().UTC().Format(time.RFC3339)
+	var exportJobs []string
+	for i, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		srcTable := conv.SrcSchema[tableId]
+		jobId, err := dc.launch(ctx, fmt.Sprintf("%s-export-%d", prefix, i), exportTemplatePath, map[string]string{
+			"spannerProjectId":    src.Project,
+			"spannerInstanceId":   src.Instance,
+			"spannerDatabaseId":   src.Db,
+			"spannerTable":        srcTable.Name,
+			"spannerSnapshotTime": snapshotTime,
+			"textWritePrefix":     fmt.Sprintf("%s/%s/part", gcsPath, srcTable.Name),
+		})
+		if err != nil {
+			return fmt.Errorf("can't export table %s: %v", srcTable.Name, err)
+		}
+		logger.Log.Info(fmt.Sprintf("Exporting table %s with Dataflow job %s", srcTable.Name, jobId))
+		exportJobs = append(exportJobs, jobId)
+	}
+	for _, jobId := range exportJobs {
+		if err := dc.wait(ctx, jobId); err != nil {
+			return err
+		}
+	}
+
+	if err := dc.WriteGCSFile(ctx, gcsPath, "manifest.json", string(manifestJSON)); err != nil {
+		return fmt.Errorf("can't write import manifest: %v", err)
+	}
+	jobId, err := dc.launch(ctx, prefix+"-import", importTemplatePath, map[string]string{
+		"spannerProjectId":  dst.Project,
+		"instanceId":        dst.Instance,
+		"databaseId":        dst.Db,
+		"importManifest":    gcsPath + "/manifest.json",
+		"trailingDelimiter": "false",
+		"handleNewLine":     "true",
+	})
+	if err != nil {
+		return fmt.Errorf("can't import data: %v", err)
+	}
+	logger.Log.Info(fmt.Sprintf("Importing data with Dataflow job %s", jobId))
+	return dc.wait(ctx, jobId)
+}
+
+// buildTextImportManifest returns the manifest importing the files exported
+// for each table of conv. The exported files have the columns of the source
+// table in order, which must all have a converted column the text import
+// template can load.
+func buildTextImportManifest(conv *internal.Conv, gcsPath string) (textImportManifest, error) {
+	manifest := textImportManifest{Tables: []textImportTable{}}
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		srcTable, ok := conv.SrcSchema[tableId]
+		if !ok {
+			return manifest, fmt.Errorf("table %s not found in the source schema", conv.SpSchema[tableId].Name)
+		}
+		spTable := conv.SpSchema[tableId]
+		table := textImportTable{TableName: spTable.Name, FilePatterns: []string{fmt.Sprintf("%s/%s/part*", gcsPath, srcTable.Name)}}
+		for _, colId := range srcTable.ColIds {
+			spCol, ok := spTable.ColDefs[colId]
+			if !ok {
+				return manifest, fmt.Errorf("can't copy table %s with Dataflow: column %s isn't migrated", srcTable.Name, srcTable.ColDefs[colId].Name)
+			}
+			if spCol.GeneratedColumn.IsPresent {
+				return manifest, fmt.Errorf("can't copy table %s with Dataflow: column %s is generated", srcTable.Name, spCol.Name)
+			}
+			typeName, ok := textTypes[conv.SpDialect][spCol.T.Name]
+			if !ok || spCol.T.IsArray {
+				return manifest, fmt.Errorf("can't copy table %s with Dataflow: column %s has type %s, which can't be imported from CSV files", srcTable.Name, spCol.Name, spCol.T.PrintColumnDefType())
+			}
+			table.Columns = append(table.Columns, textImportColumn{ColumnName: spCol.Name, TypeName: typeName})
+		}
+		if len(table.Columns) != len(spTable.ColIds) {
+			return manifest, fmt.Errorf("can't copy table %s with Dataflow: the converted table has columns without a source column", srcTable.Name)
+		}
+		manifest.Tables = append(manifest.Tables, table)
+	}
+	return manifest, nil
+}
+
+// launch launches the classic template templatePath in the region of the
+// copy, and returns the id of the job.
+func (dc DataflowCopy) launch(ctx context.Context, jobName, templatePath string, parameters map[string]string) (string, error) {
+	resp, err := dc.Templates.LaunchTemplate(ctx, &dataflowpb.LaunchTemplateRequest{
+		ProjectId: dc.Project,
+		Location:  dc.Region,
+		Template:  &dataflowpb.LaunchTemplateRequest_GcsPath{GcsPath: fmt.Sprintf(templatePath, dc.Region)},
+		LaunchParameters: &dataflowpb.LaunchTemplateParameters{
+			JobName:    jobName,
+			Parameters: parameters,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error launching dataflow template: %v", err)
+	}
+	return resp.GetJob().GetId(), nil
+}
+
+// wait polls the job jobId until it is done, and returns an error if it ends
+// in any other state.
+func (dc DataflowCopy) wait(ctx context.Context, jobId string) error {
+	for {
+		job, err := dc.Jobs.GetJob(ctx, &dataflowpb.GetJobRequest{ProjectId: dc.Project, Location: dc.Region, JobId: jobId})
+		if err != nil {
+			return fmt.Errorf("can't get state of dataflow job %s: %v", jobId, err)
+		}
+		switch job.CurrentState {
+		case dataflowpb.JobState_JOB_STATE_DONE:
+			return nil
+		case dataflowpb.JobState_JOB_STATE_FAILED, dataflowpb.JobState_JOB_STATE_CANCELLED, dataflowpb.JobState_JOB_STATE_DRAINED, dataflowpb.JobState_JOB_STATE_UPDATED:
+			return fmt.Errorf("dataflow job %s (%s) ended in state %s", job.Name, jobId, job.CurrentState)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dc.PollInterval):
+		}
+	}
+}
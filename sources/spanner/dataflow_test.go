@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/dataflow/apiv1beta3/dataflowpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+type mockTemplatesClient struct {
+	requests []*dataflowpb.LaunchTemplateRequest
+}
+
+func (m *mockTemplatesClient) LaunchTemplate(ctx context.Context, req *dataflowpb.LaunchTemplateRequest, opts ...gax.CallOption) (*dataflowpb.LaunchTemplateResponse, error) {
+	m.requests = append(m.requests, req)
+	return &dataflowpb.LaunchTemplateResponse{Job: &dataflowpb.Job{Id: fmt.Sprintf("job-%d", len(m.requests))}}, nil
+}
+
+// mockJobsClient reports the jobs done, or failed for failedJob, after
+// their first poll.
+type mockJobsClient struct {
+	failedJob string
+	polls     map[string]int
+}
+
+func (m *mockJobsClient) GetJob(ctx context.Context, req *dataflowpb.GetJobRequest, opts ...gax.CallOption) (*dataflowpb.Job, error) {
+	m.polls[req.JobId]++
+	state := dataflowpb.JobState_JOB_STATE_RUNNING
+	if m.polls[req.JobId] > 1 {
+		state = dataflowpb.JobState_JOB_STATE_DONE
+		if req.JobId == m.failedJob {
+			state = dataflowpb.JobState_JOB_STATE_FAILED
+		}
+	}
+	return &dataflowpb.Job{Id: req.JobId, CurrentState: state}, nil
+}
+
+// buildCopyConv returns the conv of a GoogleSQL database converted to a
+// PostgreSQL one, with the column c2 of table t2 of type spType.
+func buildCopyConv(spType ddl.Type) *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "Singers", Id: "t1", ColIds: []string{"c1", "c2"}, ColDefs: map[string]schema.Column{
+			"c1": {Name: "SingerId", Id: "c1"}, "c2": {Name: "Name", Id: "c2"}}},
+		"t2": {Name: "Albums", Id: "t2", ColIds: []string{"c1", "c2"}, ColDefs: map[string]schema.Column{
+			"c1": {Name: "AlbumId", Id: "c1"}, "c2": {Name: "Info", Id: "c2"}}},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {Name: "singers", Id: "t1", ColIds: []string{"c1", "c2"}, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "singerid", Id: "c1", T: ddl.Type{Name: ddl.Int64}}, "c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}}},
+		"t2": {Name: "albums", Id: "t2", ColIds: []string{"c1", "c2"}, ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "albumid", Id: "c1", T: ddl.Type{Name: ddl.Int64}}, "c2": {Name: "info", Id: "c2", T: spType}}},
+	}
+	return conv
+}
+
+func TestCopyData(t *testing.T) {
+	logger.Log = zap.NewNop()
+	templates := &mockTemplatesClient{}
+	files := map[string]string{}
+	dc := DataflowCopy{
+		Templates: templates,
+		Jobs:      &mockJobsClient{polls: map[string]int{}},
+		WriteGCSFile: func(ctx context.Context, filePath, fileName, data string) error {
+			files[filePath+"/"+fileName] = data
+			return nil
+		},
+		Project: "migration-project",
+		Region:  "us-central1",
+		GcsPath: "gs://bucket/copy/",
+	}
+	src := Database{Project: "src-project", Instance: "src-instance", Db: "music_db"}
+	dst := Database{Project: "dst-project", Instance: "dst-instance", Db: "music-pg"}
+	assert.Nil(t, dc.CopyData(context.Background(), buildCopyConv(ddl.Type{Name: ddl.JSON}), src, dst))
+
+	assert.Equal(t, 3, len(templates.requests))
+	for i, table := range []string{"Albums", "Singers"} {
+		req := templates.requests[i]
+		assert.Equal(t, "migration-project", req.ProjectId)
+		assert.Equal(t, "us-central1", req.Location)
+		assert.Equal(t, "gs://dataflow-templates-us-central1/latest/Spanner_to_GCS_Text", req.GetGcsPath())
+		assert.Regexp(t, fmt.Sprintf("^smt-music-db-[0-9]+-export-%d$", i), req.LaunchParameters.JobName)
+		params := req.LaunchParameters.Parameters
+		assert.Equal(t, "src-project", params["spannerProjectId"])
+		assert.Equal(t, "src-instance", params["spannerInstanceId"])
+		assert.Equal(t, "music_db", params["spannerDatabaseId"])
+		assert.Equal(t, table, params["spannerTable"])
+		assert.Equal(t, "gs://bucket/copy/"+table+"/part", params["textWritePrefix"])
+		assert.Equal(t, templates.requests[0].LaunchParameters.Parameters["spannerSnapshotTime"], params["spannerSnapshotTime"])
+	}
+	importReq := templates.requests[2]
+	assert.Equal(t, "gs://dataflow-templates-us-central1/latest/GCS_Text_to_Cloud_Spanner", importReq.GetGcsPath())
+	assert.Equal(t, map[string]string{
+		"spannerProjectId":  "dst-project",
+		"instanceId":        "dst-instance",
+		"databaseId":        "music-pg",
+		"importManifest":    "gs://bucket/copy/manifest.json",
+		"trailingDelimiter": "false",
+		"handleNewLine":     "true",
+	}, importReq.LaunchParameters.Parameters)
+	assert.JSONEq(t, `{"tables": [
+		{"table_name": "albums", "file_patterns": ["gs://bucket/copy/Albums/part*"], "columns": [
+			{"column_name": "albumid", "type_name": "bigint"}, {"column_name": "info", "type_name": "jsonb"}]},
+		{"table_name": "singers", "file_patterns": ["gs://bucket/copy/Singers/part*"], "columns": [
+			{"column_name": "singerid", "type_name": "bigint"}, {"column_name": "name", "type_name": "character varying"}]}
+	]}`, files["gs://bucket/copy/manifest.json"])
+}
+
+func TestCopyDataErrors(t *testing.T) {
+	logger.Log = zap.NewNop()
+	tests := []struct {
+		name      string
+		spType    ddl.Type
+		failedJob string
+		launches  int
+	}{
+		{name: "array column", spType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, launches: 0},
+		{name: "proto column", spType: ddl.Type{Name: ddl.Proto}, launches: 0},
+		{name: "failed export", spType: ddl.Type{Name: ddl.JSON}, failedJob: "job-1", launches: 2},
+		{name: "failed import", spType: ddl.Type{Name: ddl.JSON}, failedJob: "job-3", launches: 3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			templates := &mockTemplatesClient{}
+			dc := DataflowCopy{
+				Templates:    templates,
+				Jobs:         &mockJobsClient{failedJob: tc.failedJob, polls: map[string]int{}},
+				WriteGCSFile: func(ctx context.Context, filePath, fileName, data string) error { return nil },
+				GcsPath:      "gs://bucket/copy",
+			}
+			err := dc.CopyData(context.Background(), buildCopyConv(tc.spType), Database{Db: "src"}, Database{Db: "dst"})
+			assert.NotNil(t, err)
+			assert.Equal(t, tc.launches, len(templates.requests))
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
@@ -88,12 +89,15 @@ func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string)
 	return nil, nil
 }
 
+// StartChangeDataCapture is not supported: the data of Spanner databases is
+// copied as of a timestamp by the Dataflow jobs of DataflowCopy.
 func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
-	return nil, nil
+	return nil, fmt.Errorf("minimal downtime migrations are not supported for Spanner databases")
 }
 
+// StartStreamingMigration is not supported, see StartChangeDataCapture.
 func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *spanner.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
-	return internal.DataflowOutput{}, nil
+	return internal.DataflowOutput{}, fmt.Errorf("minimal downtime migrations are not supported for Spanner databases")
 }
 
 // GetTableName returns table name.
@@ -499,8 +503,66 @@ func (isi InfoSchemaImpl) GetInterleaveTables(spSchema ddl.Schema) (map[string]d
 	return parentTables, nil
 }
 
+// GetParentTableName implements the common.ChildTableSource interface, to
+// keep interleaved tables interleaved when Spanner is the source.
+func (isi InfoSchemaImpl) GetParentTableName(tableName string) string {
+	parentTableName, _, _ := isi.getInterleaving(tableName)
+	return parentTableName
+}
+
+// GetInterleaving implements the common.InterleavedTableSource interface.
+func (isi InfoSchemaImpl) GetInterleaving(tableName string) (string, string) {
+	_, onDelete, interleaveType := isi.getInterleaving(tableName)
+	return onDelete, interleaveType
+}
+
+// getInterleaving returns the parent table, ON DELETE action and interleave
+// type of table tableName, which are empty if it isn't interleaved.
+func (isi InfoSchemaImpl) getInterleaving(tableName string) (string, string, string) {
+	q := `SELECT parent_table_name, on_delete_action, interleave_type FROM information_schema.tables
+	WHERE table_schema = '' AND table_name = @p1`
+	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+		q = `SELECT parent_table_name, on_delete_action, interleave_type FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_name = $1`
+	}
+	stmt := spanner.Statement{
+		SQL: q,
+		Params: map[string]interface{}{
+			"p1": tableName,
+		},
+	}
+	iter := isi.query(stmt)
+	defer iter.Stop()
+	row, err := iter.Next()
+	if err == iterator.Done {
+		return "", "", ""
+	}
+	var parentTableName, onDelete, interleaveType spanner.NullString
+	if err == nil {
+		err = row.Columns(&parentTableName, &onDelete, &interleaveType)
+	}
+	if err != nil {
+		logger.Log.Warn(fmt.Sprintf("can't read how table %s is interleaved: %v", tableName, err))
+		return "", "", ""
+	}
+	return parentTableName.StringVal, onDelete.StringVal, interleaveType.StringVal
+}
+
+// query runs stmt with the client of isi.
+func (isi InfoSchemaImpl) query(stmt spanner.Statement) spannerclient.RowIterator {
+	if isi.SpannerClient != nil {
+		return isi.SpannerClient.Single().Query(isi.Ctx, stmt)
+	}
+	return isi.Client.Single().Query(isi.Ctx, stmt)
+}
+
 func toType(dataType string) schema.Type {
 	switch {
+	case strings.HasSuffix(dataType, "[]"):
+		// Arrays of PostgreSQL databases, e.g. character varying(10)[].
+		schemaType := toType(strings.TrimSuffix(dataType, "[]"))
+		schemaType.ArrayBounds = []int64{-1}
+		return schemaType
 	case strings.Contains(dataType, "ARRAY"):
 		typeLenStr := dataType[(strings.Index(dataType, "<") + 1):(len(dataType) - 1)]
 		schemaType := toType(typeLenStr)
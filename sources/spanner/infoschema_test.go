@@ -47,6 +47,10 @@ func TestToType(t *testing.T) {
 		{"float32_arr", "ARRAY<FLOAT32>", schema.Type{Name: "FLOAT32", ArrayBounds: []int64{-1}}},
 		{"float64_arr", "ARRAY<FLOAT64>", schema.Type{Name: "FLOAT64", ArrayBounds: []int64{-1}}},
 		{"numeric_arr", "ARRAY<NUMERIC>", schema.Type{Name: "NUMERIC", ArrayBounds: []int64{-1}}},
+		// PostgreSQL types.
+		{"pg_varchar", "character varying(100)", schema.Type{Name: "character varying", Mods: []int64{100}}},
+		{"pg_varchar_arr", "character varying(100)[]", schema.Type{Name: "character varying", Mods: []int64{100}, ArrayBounds: []int64{-1}}},
+		{"pg_bigint_arr", "bigint[]", schema.Type{Name: "bigint", ArrayBounds: []int64{-1}}},
 	}
 	for _, tc := range testCases {
 		ty := toType(tc.dataType)
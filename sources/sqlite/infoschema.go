@@ -0,0 +1,414 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	sp "cloud.google.com/go/spanner"
+	_ "github.com/mattn/go-sqlite3" // The driver should be used via the database/sql package.
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// DriverName is the name of the database/sql driver for SQLite.
+const DriverName = "sqlite3"
+
+// mainSchema is the schema of the tables of the database file, as opposed to
+// the temp schema and attached databases.
+const mainSchema = "main"
+
+// declaredTypeRegexp splits declared types such as VARCHAR(255) or
+// DECIMAL(10, 2) into a type name and its mods.
+var declaredTypeRegexp = regexp.MustCompile(`^([^(]*?)\s*(?:\(\s*([+-]?\d+)\s*(?:,\s*([+-]?\d+)\s*)?\))?$`)
+
+type InfoSchemaImpl struct {
+	Db             *sql.DB
+	TypeAffinities map[string]string // Overrides of the affinity of declared types, see GetAffinity.
+}
+
+// GetToDdl function below implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{TypeAffinities: isi.TypeAffinities}
+}
+
+// We leave the 2 functions below empty to be able to pass this as an infoSchema interface. We don't need these for now.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, nil
+}
+
+// GetTableName returns table name.
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	return tableName
+}
+
+// ProcessData performs data conversion for source database
+// 'db'. For each table, we extract data using a "SELECT" query,
+// convert the data to Spanner data (based on the source and Spanner
+// schemas), and write it to Spanner.  If we can't get/process data
+// for a table, we skip that table and process the remaining tables.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	srcTableName := conv.SrcSchema[tableId].Name
+	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTableName, err))
+		return err
+	}
+	rows := rowsInterface.(*sql.Rows)
+	defer rows.Close()
+	srcCols, _ := rows.Columns()
+	v, scanArgs := buildVals(len(srcCols))
+	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
+	for rows.Next() {
+		err := rows.Scan(scanArgs...)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
+			// Scan failed, so we don't have any data to add to bad rows.
+			conv.StatsAddBadRow(srcTableName, conv.DataMode())
+			continue
+		}
+		values := valsToStrings(v)
+		newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+			conv.StatsAddBadRow(srcTableName, conv.DataMode())
+			conv.CollectBadRow(srcTableName, srcCols, values)
+			continue
+		}
+		ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+	}
+	return nil
+}
+
+// GetRowsFromTable returns a sql Rows object for a table.
+//
+// The SQLite driver converts the values of columns declared with some types,
+// e.g. DATETIME or BOOLEAN, to go types. Each column is selected as the
+// expression +column, for which SQLite returns the stored value as is but
+// no declared type, so that values are always converted by ProcessData,
+// based on their Spanner type.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
+	tbl := conv.SrcSchema[tableId]
+	var selects []string
+	for _, colId := range tbl.ColIds {
+		cn := quoteIdentifier(tbl.ColDefs[colId].Name)
+		selects = append(selects, fmt.Sprintf("+%s AS %s", cn, cn))
+	}
+	q := fmt.Sprintf("SELECT %s FROM %s;", strings.Join(selects, ", "), quoteIdentifier(tbl.Name))
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// buildVals contructs interface{} value containers to scan row
+// results into.  Returns both the underlying containers (as a slice)
+// as well as an interface{} of pointers to containers to pass to
+// rows.Scan.
+func buildVals(n int) (v []interface{}, iv []interface{}) {
+	v = make([]interface{}, n)
+	for i := range v {
+		iv = append(iv, &v[i])
+	}
+	return v, iv
+}
+
+// GetRowCount with number of rows in each table.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	q := fmt.Sprintf("SELECT COUNT(*) FROM %s;", quoteIdentifier(table.Name))
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var count int64
+	if rows.Next() {
+		err := rows.Scan(&count)
+		return count, err
+	}
+	return 0, nil
+}
+
+// GetTables return list of tables in the database file. Virtual tables, e.g.
+// full-text search tables, and their shadow tables are skipped, as are the
+// internal sqlite_ tables.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	q := `SELECT name FROM pragma_table_list WHERE schema = 'main' AND type = 'table' AND name NOT LIKE 'sqlite\_%' ESCAPE '\' ORDER BY name;`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tables: %w", err)
+	}
+	defer rows.Close()
+	var tableName string
+	var tables []common.SchemaAndName
+	for rows.Next() {
+		rows.Scan(&tableName)
+		tables = append(tables, common.SchemaAndName{Schema: mainSchema, Name: tableName})
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	q := `SELECT name, type, "notnull", dflt_value FROM pragma_table_info(?) ORDER BY cid;`
+	cols, err := isi.Db.Query(q, table.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get schema for table %s: %s", table.Name, err)
+	}
+	defer cols.Close()
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	var colName, declType string
+	var notNull bool
+	var colDefault sql.NullString
+	for cols.Next() {
+		err := cols.Scan(&colName, &declType, &notNull, &colDefault)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		ignored := schema.Ignored{}
+		ignored.Default = colDefault.Valid
+		// An INTEGER PRIMARY KEY column is an alias for the rowid, which
+		// SQLite assigns on insert.
+		ignored.AutoIncrement = len(primaryKeys) == 1 && primaryKeys[0] == colName && strings.EqualFold(declType, "integer")
+		colId := internal.GenerateColumnId()
+		c := schema.Column{
+			Id:      colId,
+			Name:    colName,
+			Type:    toType(declType),
+			NotNull: notNull,
+			Ignored: ignored,
+		}
+		colDefs[colId] = c
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetConstraints returns a list of primary keys and by-column map of
+// other constraints.  Note: we need to preserve ordinal order of
+// columns in primary key constraints.
+// Unique constraints are handled in GetIndexes, since SQLite implements
+// them as indexes, and foreign key constraints in GetForeignKeys. SQLite
+// doesn't record check constraints other than in the CREATE TABLE
+// statement, so they aren't migrated.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	primaryKeys, err := isi.getPrimaryKeys(table.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return primaryKeys, nil, make(map[string][]string), nil
+}
+
+func (isi InfoSchemaImpl) getPrimaryKeys(tableName string) ([]string, error) {
+	q := `SELECT name FROM pragma_table_info(?) WHERE pk > 0 ORDER BY pk;`
+	rows, err := isi.Db.Query(q, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var primaryKeys []string
+	var col string
+	for rows.Next() {
+		if err := rows.Scan(&col); err != nil {
+			return nil, err
+		}
+		primaryKeys = append(primaryKeys, col)
+	}
+	return primaryKeys, nil
+}
+
+// GetForeignKeys returns a list of all the foreign key constraints.
+// SQLite doesn't record the names of foreign keys, so they are left for
+// Spanner to name. Foreign keys that don't list the referenced columns
+// reference the primary key of the referenced table.
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	q := `SELECT id, "table", "from", "to", on_update, on_delete FROM pragma_foreign_key_list(?) ORDER BY id, seq;`
+	rows, err := isi.Db.Query(q, table.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var id int
+	var refTable, col, onUpdate, onDelete string
+	var refCol sql.NullString
+	fKeys := make(map[int]common.FkConstraint)
+	var ids []int
+	for rows.Next() {
+		err := rows.Scan(&id, &refTable, &col, &refCol, &onUpdate, &onDelete)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		fk, found := fKeys[id]
+		if !found {
+			fk = common.FkConstraint{Table: refTable, OnDelete: onDelete, OnUpdate: onUpdate}
+			ids = append(ids, id)
+		}
+		fk.Cols = append(fk.Cols, col)
+		if refCol.Valid {
+			fk.Refcols = append(fk.Refcols, refCol.String)
+		}
+		fKeys[id] = fk
+	}
+	for _, id := range ids {
+		fk := fKeys[id]
+		if len(fk.Refcols) == 0 {
+			fk.Refcols, err = isi.getPrimaryKeys(fk.Table)
+			if err != nil {
+				return nil, err
+			}
+		}
+		foreignKeys = append(foreignKeys,
+			schema.ForeignKey{
+				Id:               internal.GenerateForeignkeyId(),
+				ColumnNames:      fk.Cols,
+				ReferTableName:   fk.Table,
+				ReferColumnNames: fk.Refcols,
+				OnDelete:         fk.OnDelete,
+				OnUpdate:         fk.OnUpdate,
+			})
+	}
+	return foreignKeys, nil
+}
+
+// GetIndexes return a list of all indexes for the specified table,
+// including the indexes implementing unique constraints. Indexes on
+// expressions, and partial unique indexes, which would make Spanner reject
+// rows that SQLite accepts, are skipped.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	q := `SELECT il.name, il.origin, il."unique", il.partial, ii.name, ii."desc"
+		FROM pragma_index_list(?) AS il
+		JOIN pragma_index_xinfo(il.name) AS ii
+		WHERE il.origin <> 'pk' AND ii.key = 1
+		ORDER BY il.name, ii.seqno;`
+	rows, err := isi.Db.Query(q, table.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name, origin string
+	var unique, partial, desc bool
+	var column sql.NullString
+	indexMap := make(map[string]schema.Index)
+	skipped := make(map[string]bool)
+	constraintCols := make(map[string][]string)
+	var indexNames []string
+	for rows.Next() {
+		if err := rows.Scan(&name, &origin, &unique, &partial, &column, &desc); err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		if skipped[name] {
+			continue
+		}
+		if !column.Valid || (unique && partial) {
+			conv.Unexpected(fmt.Sprintf("Skipping index %s of table %s: indexes on expressions and partial unique indexes are not supported", name, table.Name))
+			skipped[name] = true
+			continue
+		}
+		if _, found := indexMap[name]; !found {
+			indexNames = append(indexNames, name)
+			indexMap[name] = schema.Index{
+				Id:     internal.GenerateIndexesId(),
+				Name:   name,
+				Unique: unique}
+		}
+		index := indexMap[name]
+		index.Keys = append(index.Keys, schema.Key{
+			ColId: colNameIdMap[column.String],
+			Desc:  desc})
+		indexMap[name] = index
+		if origin == "u" {
+			constraintCols[name] = append(constraintCols[name], column.String)
+		}
+	}
+	var indexes []schema.Index
+	for _, k := range indexNames {
+		if skipped[k] {
+			continue
+		}
+		index := indexMap[k]
+		// The indexes of unique constraints are named sqlite_autoindex_<table>_<n>,
+		// name them after the table and columns instead.
+		if cols, ok := constraintCols[k]; ok {
+			index.Name = fmt.Sprintf("%s_%s_key", table.Name, strings.Join(cols, "_"))
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// toType splits declared type declType into a lower case type name and
+// its mods. Declared types that can't be parsed are kept whole, as SQLite
+// only uses them to determine the affinity of the column.
+func toType(declType string) schema.Type {
+	declType = strings.ToLower(strings.TrimSpace(declType))
+	m := declaredTypeRegexp.FindStringSubmatch(declType)
+	if m == nil {
+		return schema.Type{Name: declType}
+	}
+	ty := schema.Type{Name: m[1]}
+	for _, mod := range m[2:] {
+		if mod == "" {
+			break
+		}
+		i, err := strconv.ParseInt(mod, 10, 64)
+		if err != nil {
+			return schema.Type{Name: declType}
+		}
+		ty.Mods = append(ty.Mods, i)
+	}
+	return ty
+}
+
+// quoteIdentifier quotes a table or column name for use in SQLite queries.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func valsToStrings(vals []interface{}) []string {
+	toString := func(val interface{}) string {
+		if val == nil {
+			return "NULL"
+		}
+		switch v := val.(type) {
+		case []byte:
+			return string(v)
+		case float64:
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		return fmt.Sprintf("%v", val)
+	}
+	var s []string
+	for _, v := range vals {
+		s = append(s, toString(v))
+	}
+	return s
+}
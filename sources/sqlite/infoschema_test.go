@@ -0,0 +1,271 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type mockSpec struct {
+	query string
+	args  []driver.Value   // Query args.
+	cols  []string         // Columns names for returned rows.
+	rows  [][]driver.Value // Set of rows returned.
+}
+
+func TestProcessSchema(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT name FROM pragma_table_list (.+)",
+			cols:  []string{"name"},
+			rows:  [][]driver.Value{{"albums"}, {"singers"}},
+		},
+		{
+			query: regexp.QuoteMeta("FROM pragma_table_info(?) WHERE pk > 0"),
+			args:  []driver.Value{"albums"},
+			cols:  []string{"name"},
+			rows:  [][]driver.Value{{"singer_id"}, {"album_id"}},
+		},
+		{
+			query: "SELECT (.+) FROM pragma_foreign_key_list(.+)",
+			args:  []driver.Value{"albums"},
+			cols:  []string{"id", "table", "from", "to", "on_update", "on_delete"},
+			rows:  [][]driver.Value{{0, "singers", "singer_id", nil, "NO ACTION", "CASCADE"}},
+		},
+		// The foreign key doesn't list the referenced columns, so the primary
+		// key of the referenced table is fetched.
+		{
+			query: regexp.QuoteMeta("FROM pragma_table_info(?) WHERE pk > 0"),
+			args:  []driver.Value{"singers"},
+			cols:  []string{"name"},
+			rows:  [][]driver.Value{{"id"}},
+		},
+		{
+			query: regexp.QuoteMeta("FROM pragma_table_info(?) ORDER BY cid"),
+			args:  []driver.Value{"albums"},
+			cols:  []string{"name", "type", "notnull", "dflt_value"},
+			rows: [][]driver.Value{
+				{"singer_id", "INTEGER", true, nil},
+				{"album_id", "INT", true, nil},
+				{"title", "TEXT", false, nil},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM pragma_index_list(.+)",
+			args:  []driver.Value{"albums"},
+			cols:  []string{"name", "origin", "unique", "partial", "name", "desc"},
+			rows: [][]driver.Value{
+				{"albums_by_title", "c", false, false, "title", true},
+				{"albums_by_title", "c", false, false, "album_id", false},
+				{"expr_idx", "c", false, false, nil, false},
+				{"sqlite_autoindex_albums_1", "u", true, false, "title", false},
+			},
+		},
+		{
+			query: regexp.QuoteMeta("FROM pragma_table_info(?) WHERE pk > 0"),
+			args:  []driver.Value{"singers"},
+			cols:  []string{"name"},
+			rows:  [][]driver.Value{{"id"}},
+		},
+		{
+			query: "SELECT (.+) FROM pragma_foreign_key_list(.+)",
+			args:  []driver.Value{"singers"},
+			cols:  []string{"id", "table", "from", "to", "on_update", "on_delete"},
+		},
+		{
+			query: regexp.QuoteMeta("FROM pragma_table_info(?) ORDER BY cid"),
+			args:  []driver.Value{"singers"},
+			cols:  []string{"name", "type", "notnull", "dflt_value"},
+			rows: [][]driver.Value{
+				{"id", "INTEGER", true, nil},
+				{"name", "VARCHAR(100)", true, nil},
+				{"born", "DATETIME", false, nil},
+				{"updated", "TIMESTAMP", false, "CURRENT_TIMESTAMP"},
+				{"active", "BOOLEAN", false, "1"},
+				{"score", "DECIMAL(10, 2)", false, nil},
+				{"weight", "DOUBLE PRECISION", false, nil},
+				{"plays", "UNSIGNED BIG INT", false, nil},
+				{"pic", "BLOB", false, nil},
+				{"meta", "JSON", false, nil},
+				{"misc", "", false, nil},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM pragma_index_list(.+)",
+			args:  []driver.Value{"singers"},
+			cols:  []string{"name", "origin", "unique", "partial", "name", "desc"},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	isi := InfoSchemaImpl{Db: db, TypeAffinities: map[string]string{"datetime": TextAffinity}}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	expectedSchema := map[string]ddl.CreateTable{
+		"albums": {
+			Name:   "albums",
+			ColIds: []string{"singer_id", "album_id", "title"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"singer_id": {Name: "singer_id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"album_id":  {Name: "album_id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"title":     {Name: "title", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "singer_id", Order: 1}, {ColId: "album_id", Order: 2}},
+			ForeignKeys: []ddl.Foreignkey{{ColIds: []string{"singer_id"}, ReferTableId: "singers", ReferColumnIds: []string{"id"}, OnDelete: "CASCADE", OnUpdate: "NO ACTION"}},
+			Indexes: []ddl.CreateIndex{
+				{Name: "albums_by_title", TableId: "albums", Keys: []ddl.IndexKey{{ColId: "title", Desc: true, Order: 1}, {ColId: "album_id", Order: 2}}},
+				{Name: "albums_title_key", TableId: "albums", Unique: true, Keys: []ddl.IndexKey{{ColId: "title", Order: 1}}},
+			},
+		},
+		"singers": {
+			Name:   "singers",
+			ColIds: []string{"id", "name", "born", "updated", "active", "score", "weight", "plays", "pic", "meta", "misc"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":      {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"name":    {Name: "name", T: ddl.Type{Name: ddl.String, Len: 100}, NotNull: true},
+				"born":    {Name: "born", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+				"updated": {Name: "updated", T: ddl.Type{Name: ddl.Timestamp}},
+				"active":  {Name: "active", T: ddl.Type{Name: ddl.Bool}},
+				"score":   {Name: "score", T: ddl.Type{Name: ddl.Numeric}},
+				"weight":  {Name: "weight", T: ddl.Type{Name: ddl.Float64}},
+				"plays":   {Name: "plays", T: ddl.Type{Name: ddl.Int64}},
+				"pic":     {Name: "pic", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+				"meta":    {Name: "meta", T: ddl.Type{Name: ddl.JSON}},
+				"misc":    {Name: "misc", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, stripSchemaComments(conv.SpSchema))
+
+	singersTableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "singers")
+	assert.Nil(t, err)
+	idColId, err := internal.GetColIdFromSpName(conv.SpSchema[singersTableId].ColDefs, "id")
+	assert.Nil(t, err)
+	assert.Contains(t, conv.SchemaIssues[singersTableId].ColumnLevelIssues[idColId], internal.AutoIncrement)
+	assert.Equal(t, schema.Type{Name: "decimal", Mods: []int64{10, 2}}, conv.SrcSchema[singersTableId].ColDefs[conv.SpSchema[singersTableId].ColIds[5]].Type)
+	// The expression index is skipped.
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+}
+
+func TestProcessData(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta(`SELECT +"id" AS "id", +"born" AS "born", +"score" AS "score", +"pic" AS "pic" FROM "te""st";`),
+			cols:  []string{"id", "born", "score", "pic"},
+			rows: [][]driver.Value{
+				{int64(1), "2024-01-02 03:04:05", 1.25, []byte{1, 2}},
+				{int64(2), int64(1700000000), int64(3), nil},
+				{"x", nil, nil, nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   "te_st",
+			ColIds: []string{"c1", "c2", "c3", "c4"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "born", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+				"c3": {Name: "score", Id: "c3", T: ddl.Type{Name: ddl.Numeric}},
+				"c4": {Name: "pic", Id: "c4", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			Id:          "t1",
+		},
+		schema.Table{
+			Name:   `te"st`,
+			Schema: mainSchema,
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "integer"}},
+				"c2": {Name: "born", Id: "c2", Type: schema.Type{Name: "datetime"}},
+				"c3": {Name: "score", Id: "c3", Type: schema.Type{Name: "decimal"}},
+				"c4": {Name: "pic", Id: "c4", Type: schema.Type{Name: "blob"}},
+			},
+		})
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	isi := InfoSchemaImpl{Db: db}
+	err := isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	assert.Equal(t, []spannerData{
+		{table: "te_st", cols: []string{"id", "born", "score", "pic"}, vals: []interface{}{int64(1), time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), big.NewRat(5, 4), []byte{1, 2}}},
+		{table: "te_st", cols: []string{"id", "born", "score"}, vals: []interface{}{int64(2), time.Unix(1700000000, 0).UTC(), big.NewRat(3, 1)}},
+	}, rows)
+	// Text stored in an INTEGER column can't be converted.
+	assert.Equal(t, int64(1), conv.BadRows())
+}
+
+func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	for _, m := range ms {
+		rows := sqlmock.NewRows(m.cols)
+		for _, r := range m.rows {
+			rows.AddRow(r...)
+		}
+		if len(m.args) > 0 {
+			mock.ExpectQuery(m.query).WithArgs(m.args...).WillReturnRows(rows)
+		} else {
+			mock.ExpectQuery(m.query).WillReturnRows(rows)
+		}
+	}
+	return db
+}
+
+// stripSchemaComments returns a schema with all comments removed.
+// We mostly ignore schema comments in testing since schema comments
+// are often changed and are not a core part of conversion functionality.
+func stripSchemaComments(spSchema map[string]ddl.CreateTable) map[string]ddl.CreateTable {
+	for t, ct := range spSchema {
+		for c, cd := range ct.ColDefs {
+			cd.Comment = ""
+			ct.ColDefs[c] = cd
+		}
+		ct.Comment = ""
+		spSchema[t] = ct
+	}
+	return spSchema
+}
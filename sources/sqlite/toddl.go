@@ -0,0 +1,211 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlite handles schema and data migrations from SQLite database files.
+package sqlite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// SQLite columns have no strict type: values of any type can be stored in
+// any column, and the declared type of a column only gives it an affinity,
+// the type its values are preferably stored as. See
+// https://www.sqlite.org/datatype3.html#type_affinity.
+const (
+	IntegerAffinity string = "integer"
+	TextAffinity    string = "text"
+	BlobAffinity    string = "blob"
+	RealAffinity    string = "real"
+	NumericAffinity string = "numeric"
+
+	// The affinities below aren't SQLite ones: SQLite stores booleans, dates
+	// and JSON as integers or text, but applications declare their columns
+	// with these types to map them to the Spanner type of the same kind.
+	BooleanAffinity   string = "boolean"
+	DateAffinity      string = "date"
+	TimestampAffinity string = "timestamp"
+	JSONAffinity      string = "json"
+)
+
+var affinities = []string{IntegerAffinity, TextAffinity, BlobAffinity, RealAffinity, NumericAffinity, BooleanAffinity, DateAffinity, TimestampAffinity, JSONAffinity}
+
+// defaultTypeAffinities maps declared types to the affinities that take
+// precedence over the SQLite rules.
+var defaultTypeAffinities = map[string]string{
+	"boolean":   BooleanAffinity,
+	"bool":      BooleanAffinity,
+	"date":      DateAffinity,
+	"datetime":  TimestampAffinity,
+	"timestamp": TimestampAffinity,
+	"json":      JSONAffinity,
+}
+
+// ValidateTypeAffinities checks that typeAffinities, e.g. from the source
+// profile, only maps declared types to known affinities.
+func ValidateTypeAffinities(typeAffinities map[string]string) error {
+	var declTypes []string
+	for declType := range typeAffinities {
+		declTypes = append(declTypes, declType)
+	}
+	sort.Strings(declTypes)
+	for _, declType := range declTypes {
+		if !internal.Contains(affinities, typeAffinities[declType]) {
+			return fmt.Errorf("invalid affinity %s for type %s, expected one of %s", typeAffinities[declType], declType, strings.Join(affinities, ", "))
+		}
+	}
+	return nil
+}
+
+// GetAffinity returns the affinity of columns declared with type declType:
+// the one given by typeAffinities or defaultTypeAffinities if any, otherwise
+// the one given by the SQLite rules. Like SQLite, declared types are case
+// insensitive.
+func GetAffinity(declType string, typeAffinities map[string]string) string {
+	declType = strings.ToLower(declType)
+	if affinity, ok := typeAffinities[declType]; ok {
+		return affinity
+	}
+	if affinity, ok := defaultTypeAffinities[declType]; ok {
+		return affinity
+	}
+	switch {
+	case strings.Contains(declType, "int"):
+		return IntegerAffinity
+	case strings.Contains(declType, "char"), strings.Contains(declType, "clob"), strings.Contains(declType, "text"):
+		return TextAffinity
+	case declType == "", strings.Contains(declType, "blob"):
+		return BlobAffinity
+	case strings.Contains(declType, "real"), strings.Contains(declType, "floa"), strings.Contains(declType, "doub"):
+		return RealAffinity
+	default:
+		return NumericAffinity
+	}
+}
+
+// ToDdlImpl SQLite specific implementation for ToDdl.
+type ToDdlImpl struct {
+	TypeAffinities map[string]string // Overrides of the affinity of declared types.
+}
+
+// ToSpannerType maps a scalar source schema type (defined by id and
+// mods) into a Spanner type. This is the core source-to-Spanner type
+// mapping.  toSpannerType returns the Spanner type and a list of type
+// conversion issues encountered.
+func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType schema.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
+	ty, issues := toSpannerTypeInternal(GetAffinity(srcType.Name, tdi.TypeAffinities), srcType, spType)
+	ty, numericIssues := common.ToSpannerNumeric(ty, srcType, conv.SpDialect)
+	issues = append(issues, numericIssues...)
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		var pg_issues []internal.SchemaIssue
+		ty, pg_issues = common.ToPGDialectType(ty, isPk)
+		issues = append(issues, pg_issues...)
+	}
+	return ty, issues
+}
+
+func (tdi ToDdlImpl) GetColumnAutoGen(conv *internal.Conv, autoGenCol ddl.AutoGenCol, colId string, tableId string) (*ddl.AutoGenCol, error) {
+	return nil, nil
+}
+
+// toSpannerTypeInternal defines the mapping of SQLite affinities into
+// Spanner types. Each affinity has a default Spanner type, as well as other
+// potential Spanner types it could map to. If the target Spanner type name
+// spType is specified and is a potential mapping for this affinity, then it
+// will be used to build the returned ddl.Type. If not, the default Spanner
+// type for this affinity will be used.
+func toSpannerTypeInternal(affinity string, srcType schema.Type, spType string) (ddl.Type, []internal.SchemaIssue) {
+	switch affinity {
+	case IntegerAffinity:
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+		default:
+			return ddl.Type{Name: ddl.Int64}, nil
+		}
+	case RealAffinity:
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+		default:
+			return ddl.Type{Name: ddl.Float64}, nil
+		}
+	case NumericAffinity:
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+		case ddl.Float64:
+			return ddl.Type{Name: ddl.Float64}, nil
+		default:
+			return ddl.Type{Name: ddl.Numeric}, nil
+		}
+	case BooleanAffinity:
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+		case ddl.Int64:
+			return ddl.Type{Name: ddl.Int64}, []internal.SchemaIssue{internal.Widened}
+		default:
+			return ddl.Type{Name: ddl.Bool}, nil
+		}
+	case DateAffinity:
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+		default:
+			return ddl.Type{Name: ddl.Date}, nil
+		}
+	case TimestampAffinity:
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+		default:
+			return ddl.Type{Name: ddl.Timestamp}, nil
+		}
+	case JSONAffinity:
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		default:
+			return ddl.Type{Name: ddl.JSON}, nil
+		}
+	case BlobAffinity:
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		default:
+			return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil
+		}
+	default: // TextAffinity
+		switch spType {
+		case ddl.Bytes:
+			return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil
+		default:
+			// SQLite doesn't enforce declared lengths, e.g. VARCHAR(255),
+			// but they document the expected length of the values.
+			if len(srcType.Mods) > 0 && srcType.Mods[0] > 0 && srcType.Mods[0] <= ddl.StringMaxLength {
+				return ddl.Type{Name: ddl.String, Len: srcType.Mods[0]}, nil
+			}
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		}
+	}
+}
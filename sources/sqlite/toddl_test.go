@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAffinity(t *testing.T) {
+	overrides := map[string]string{"money": RealAffinity, "datetime": TextAffinity}
+	tests := []struct {
+		declType string
+		expected string
+	}{
+		{"INTEGER", IntegerAffinity},
+		{"bigint", IntegerAffinity},
+		{"POINT", IntegerAffinity}, // Contains "int", as in SQLite.
+		{"varchar", TextAffinity},
+		{"nchar", TextAffinity},
+		{"clob", TextAffinity},
+		{"", BlobAffinity},
+		{"blob", BlobAffinity},
+		{"real", RealAffinity},
+		{"double precision", RealAffinity},
+		{"float", RealAffinity},
+		{"decimal", NumericAffinity},
+		{"numeric", NumericAffinity},
+		{"BOOLEAN", BooleanAffinity},
+		{"date", DateAffinity},
+		{"timestamp", TimestampAffinity},
+		{"json", JSONAffinity},
+		{"money", RealAffinity},
+		{"DATETIME", TextAffinity},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, GetAffinity(tc.declType, overrides), tc.declType)
+	}
+}
+
+func TestValidateTypeAffinities(t *testing.T) {
+	assert.Nil(t, ValidateTypeAffinities(nil))
+	assert.Nil(t, ValidateTypeAffinities(map[string]string{"money": RealAffinity, "uuid": BlobAffinity}))
+	assert.NotNil(t, ValidateTypeAffinities(map[string]string{"money": "float"}))
+}
+
+func TestToSpannerType(t *testing.T) {
+	tests := []struct {
+		name     string
+		srcType  schema.Type
+		spType   string
+		expected ddl.Type
+		issues   []internal.SchemaIssue
+	}{
+		{"integer", schema.Type{Name: "integer"}, "", ddl.Type{Name: ddl.Int64}, nil},
+		{"integer to string", schema.Type{Name: "integer"}, ddl.String, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}},
+		{"real", schema.Type{Name: "real"}, "", ddl.Type{Name: ddl.Float64}, nil},
+		{"numeric", schema.Type{Name: "decimal", Mods: []int64{10, 2}}, "", ddl.Type{Name: ddl.Numeric}, nil},
+		{"numeric to float64", schema.Type{Name: "decimal"}, ddl.Float64, ddl.Type{Name: ddl.Float64}, nil},
+		{"boolean", schema.Type{Name: "boolean"}, "", ddl.Type{Name: ddl.Bool}, nil},
+		{"boolean to int64", schema.Type{Name: "boolean"}, ddl.Int64, ddl.Type{Name: ddl.Int64}, []internal.SchemaIssue{internal.Widened}},
+		{"date", schema.Type{Name: "date"}, "", ddl.Type{Name: ddl.Date}, nil},
+		{"timestamp", schema.Type{Name: "datetime"}, "", ddl.Type{Name: ddl.Timestamp}, nil},
+		{"json", schema.Type{Name: "json"}, "", ddl.Type{Name: ddl.JSON}, nil},
+		{"blob", schema.Type{Name: "blob"}, "", ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil},
+		{"no type", schema.Type{Name: ""}, "", ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil},
+		{"text", schema.Type{Name: "text"}, "", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil},
+		{"varchar", schema.Type{Name: "varchar", Mods: []int64{255}}, "", ddl.Type{Name: ddl.String, Len: 255}, nil},
+		{"varchar too long", schema.Type{Name: "varchar", Mods: []int64{ddl.StringMaxLength + 1}}, "", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil},
+		{"text to bytes", schema.Type{Name: "text"}, ddl.Bytes, ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil},
+	}
+	conv := internal.MakeConv()
+	for _, tc := range tests {
+		ty, issues := ToDdlImpl{}.ToSpannerType(conv, tc.spType, tc.srcType, false)
+		assert.Equal(t, tc.expected, ty, tc.name)
+		assert.Equal(t, tc.issues, issues, tc.name)
+	}
+
+	// Affinity overrides take precedence over the defaults.
+	ty, _ := ToDdlImpl{TypeAffinities: map[string]string{"datetime": TextAffinity}}.ToSpannerType(conv, "", schema.Type{Name: "DATETIME"}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	ty, _ = ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "decimal", Mods: []int64{10, 2}}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.Numeric, Precision: 10, Scale: 2}, ty)
+	ty, issues := ToDdlImpl{}.ToSpannerType(conv, "", schema.Type{Name: "decimal"}, true)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.NumericPKNotSupported}, issues)
+}
@@ -0,0 +1,695 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	sp "cloud.google.com/go/spanner"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// SQL Server packages are zip archives written by SqlPackage, SSMS or the
+// Azure portal. model.xml holds the schema of the database, as elements of
+// the DacFx schema model. BACPAC files (exports) also hold the rows of
+// each table, in BCP native format files in Data/<schema>.<table>/, while
+// DACPAC files (extracts) have no data.
+
+// packageTable is a table of the schema model of a package.
+type packageTable struct {
+	schema      string
+	name        string
+	columns     []packageColumn
+	computed    []string // Computed columns, which aren't migrated.
+	primaryKey  []string
+	indexes     []packageIndex
+	foreignKeys []packageForeignKey
+}
+
+type packageColumn struct {
+	name       string
+	typ        schema.Type
+	scale      int64  // Fractional second digits of time types.
+	collation  string // Collation of char types.
+	notNull    bool
+	hasDefault bool
+	hasCheck   bool
+}
+
+type packageIndex struct {
+	name   string
+	unique bool
+	keys   []schema.Key // ColIds are column names.
+	stored []string
+}
+
+type packageForeignKey struct {
+	name      string
+	cols      []string
+	refSchema string
+	refTable  string
+	refCols   []string
+	onDelete  string
+	onUpdate  string
+}
+
+func (t *packageTable) column(name string) *packageColumn {
+	for i := range t.columns {
+		if t.columns[i].name == name {
+			return &t.columns[i]
+		}
+	}
+	return nil
+}
+
+// Elements of model.xml. Elements have properties, and relationships to
+// other elements: either elements nested in the relationship, or
+// references to elements by name e.g. [dbo].[Orders].[Id]. Built-in
+// types are referenced by name too, e.g. [nvarchar].
+type modelElement struct {
+	Type          string              `xml:"Type,attr"`
+	Name          string              `xml:"Name,attr"`
+	Properties    []modelProperty     `xml:"Property"`
+	Relationships []modelRelationship `xml:"Relationship"`
+}
+
+type modelProperty struct {
+	Name  string `xml:"Name,attr"`
+	Value string `xml:"Value,attr"`
+}
+
+type modelRelationship struct {
+	Name    string `xml:"Name,attr"`
+	Entries []struct {
+		Elements   []modelElement `xml:"Element"`
+		References []struct {
+			Name string `xml:"Name,attr"`
+		} `xml:"References"`
+	} `xml:"Entry"`
+}
+
+func (e modelElement) property(name string) string {
+	for _, p := range e.Properties {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// elements returns the elements nested in a relationship.
+func (e modelElement) elements(relationship string) []modelElement {
+	var elements []modelElement
+	for _, r := range e.Relationships {
+		if r.Name == relationship {
+			for _, entry := range r.Entries {
+				elements = append(elements, entry.Elements...)
+			}
+		}
+	}
+	return elements
+}
+
+// references returns the names of the elements referenced by a
+// relationship.
+func (e modelElement) references(relationship string) []string {
+	var names []string
+	for _, r := range e.Relationships {
+		if r.Name == relationship {
+			for _, entry := range r.Entries {
+				for _, ref := range entry.References {
+					names = append(names, ref.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// splitName splits a name of the model, e.g. [dbo].[Orders].[Id], into
+// its parts.
+func splitName(name string) []string {
+	var parts []string
+	for len(name) > 0 {
+		if name[0] == '.' {
+			name = name[1:]
+			continue
+		}
+		if name[0] != '[' {
+			i := strings.IndexByte(name, '.')
+			if i < 0 {
+				i = len(name)
+			}
+			parts = append(parts, name[:i])
+			name = name[i:]
+			continue
+		}
+		var part strings.Builder
+		i := 1
+		for ; i < len(name); i++ {
+			if name[i] == ']' {
+				if i+1 < len(name) && name[i+1] == ']' {
+					part.WriteByte(']')
+					i++
+					continue
+				}
+				break
+			}
+			part.WriteByte(name[i])
+		}
+		parts = append(parts, part.String())
+		name = name[min(i+1, len(name)):]
+	}
+	return parts
+}
+
+// columnName returns the name of the column referenced by ref, e.g. Id
+// for [dbo].[Orders].[Id].
+func columnName(ref string) string {
+	parts := splitName(ref)
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[len(parts)-1]
+}
+
+// tableKey returns the schema and name of the table named, or referenced
+// by, ref.
+func tableKey(ref string) (string, string) {
+	parts := splitName(ref)
+	if len(parts) < 2 {
+		return "", ref
+	}
+	return parts[0], parts[1]
+}
+
+// parseModel reads the tables of the model.xml of a package, in the order
+// in which they are listed.
+func parseModel(r io.Reader) ([]*packageTable, error) {
+	var model struct {
+		Elements []modelElement `xml:"Model>Element"`
+	}
+	if err := xml.NewDecoder(r).Decode(&model); err != nil {
+		return nil, fmt.Errorf("can't parse model.xml: %w", err)
+	}
+	collation := "SQL_Latin1_General_CP1_CI_AS"
+	aliases := make(map[string]modelElement)
+	for _, e := range model.Elements {
+		switch e.Type {
+		case "SqlDatabaseOptions":
+			if c := e.property("Collation"); c != "" {
+				collation = c
+			}
+		case "SqlUserDefinedDataType":
+			aliases[e.Name] = e
+		}
+	}
+	var tables []*packageTable
+	byName := make(map[string]*packageTable)
+	for _, e := range model.Elements {
+		if e.Type != "SqlTable" {
+			continue
+		}
+		s, n := tableKey(e.Name)
+		t := &packageTable{schema: s, name: n}
+		for _, c := range e.elements("Columns") {
+			name := columnName(c.Name)
+			if c.Type == "SqlComputedColumn" {
+				t.computed = append(t.computed, name)
+				continue
+			}
+			col, err := parseColumn(c, aliases, collation)
+			if err != nil {
+				return nil, fmt.Errorf("can't get type of column %s of table %s.%s: %w", name, s, n, err)
+			}
+			t.columns = append(t.columns, col)
+		}
+		tables = append(tables, t)
+		byName[s+"."+n] = t
+	}
+	definingTable := func(e modelElement) *packageTable {
+		refs := e.references("DefiningTable")
+		if len(refs) == 0 {
+			refs = e.references("IndexedObject")
+		}
+		if len(refs) == 0 {
+			return nil
+		}
+		s, n := tableKey(refs[0])
+		return byName[s+"."+n]
+	}
+	for _, e := range model.Elements {
+		t := definingTable(e)
+		if t == nil {
+			continue
+		}
+		switch e.Type {
+		case "SqlPrimaryKeyConstraint":
+			for _, k := range indexKeys(e) {
+				t.primaryKey = append(t.primaryKey, k.ColId)
+				if c := t.column(k.ColId); c != nil {
+					c.notNull = true
+				}
+			}
+		case "SqlUniqueConstraint":
+			keys := indexKeys(e)
+			t.indexes = append(t.indexes, packageIndex{name: constraintName(e, "UQ", t, keys), unique: true, keys: keys})
+		case "SqlIndex":
+			keys := indexKeys(e)
+			var stored []string
+			for _, ref := range e.references("IncludedColumns") {
+				stored = append(stored, columnName(ref))
+			}
+			t.indexes = append(t.indexes, packageIndex{name: constraintName(e, "IX", t, keys), unique: e.property("IsUnique") == "True", keys: keys, stored: stored})
+		case "SqlForeignKeyConstraint":
+			fk := packageForeignKey{onDelete: foreignKeyAction(e.property("DeleteAction")), onUpdate: foreignKeyAction(e.property("UpdateAction"))}
+			for _, ref := range e.references("Columns") {
+				fk.cols = append(fk.cols, columnName(ref))
+			}
+			for _, ref := range e.references("ForeignColumns") {
+				fk.refCols = append(fk.refCols, columnName(ref))
+			}
+			if refs := e.references("ForeignTable"); len(refs) > 0 {
+				fk.refSchema, fk.refTable = tableKey(refs[0])
+			}
+			var keys []schema.Key
+			for _, c := range fk.cols {
+				keys = append(keys, schema.Key{ColId: c})
+			}
+			fk.name = constraintName(e, "FK", t, keys)
+			t.foreignKeys = append(t.foreignKeys, fk)
+		case "SqlDefaultConstraint":
+			for _, ref := range e.references("ForColumn") {
+				if c := t.column(columnName(ref)); c != nil {
+					c.hasDefault = true
+				}
+			}
+		case "SqlCheckConstraint":
+			for _, ref := range e.references("CheckExpressionDependencies") {
+				if c := t.column(columnName(ref)); c != nil && len(splitName(ref)) == 3 {
+					c.hasCheck = true
+				}
+			}
+		}
+	}
+	return tables, nil
+}
+
+// parseColumn returns the column defined by element c. Its type is given
+// by its type specifier, or by the alias type that it references.
+func parseColumn(c modelElement, aliases map[string]modelElement, collation string) (packageColumn, error) {
+	col := packageColumn{name: columnName(c.Name), notNull: c.property("IsNullable") == "False", collation: collation}
+	specs := c.elements("TypeSpecifier")
+	if len(specs) == 0 {
+		return col, fmt.Errorf("column has no type")
+	}
+	spec := specs[0]
+	refs := spec.references("Type")
+	if len(refs) == 0 {
+		return col, fmt.Errorf("column has no type")
+	}
+	if alias, ok := aliases[refs[0]]; ok {
+		// Alias types, e.g. CREATE TYPE Phone FROM varchar(20), specify
+		// their base type themselves.
+		spec = alias
+		if refs = alias.references("Type"); len(refs) == 0 {
+			return col, fmt.Errorf("alias type %s has no base type", alias.Name)
+		}
+	}
+	name := strings.ToLower(columnName(refs[0]))
+	col.typ = schema.Type{Name: name}
+	intProperty := func(p string, def int64) int64 {
+		if v, err := strconv.ParseInt(spec.property(p), 10, 64); err == nil {
+			return v
+		}
+		return def
+	}
+	switch name {
+	case "char", "varchar", "nchar", "nvarchar", "binary", "varbinary":
+		if spec.property("IsMax") == "True" {
+			// information_schema reports the length of max types as -1.
+			col.typ.Mods = []int64{-1}
+		} else {
+			col.typ.Mods = []int64{intProperty("Length", 1)}
+		}
+	case "decimal", "numeric":
+		p, s := intProperty("Precision", 18), intProperty("Scale", 0)
+		col.typ.Mods = []int64{p}
+		if s != 0 {
+			col.typ.Mods = append(col.typ.Mods, s)
+		}
+	case "time", "datetime2", "datetimeoffset":
+		col.scale = intProperty("Scale", 7)
+	}
+	if c := c.property("Collation"); c != "" {
+		col.collation = c
+	}
+	return col, nil
+}
+
+// indexKeys returns the keys of a constraint or index element, with
+// column names as ColIds.
+func indexKeys(e modelElement) []schema.Key {
+	var keys []schema.Key
+	for _, spec := range e.elements("ColumnSpecifications") {
+		refs := spec.references("Column")
+		if len(refs) == 0 {
+			continue
+		}
+		keys = append(keys, schema.Key{ColId: columnName(refs[0]), Desc: spec.property("IsAscending") == "False"})
+	}
+	return keys
+}
+
+// constraintName returns the name of a constraint or index. Constraints
+// defined without a name are named after their table and columns, as SQL
+// Server names them when they are created.
+func constraintName(e modelElement, prefix string, t *packageTable, keys []schema.Key) string {
+	if e.Name != "" {
+		return columnName(e.Name)
+	}
+	name := prefix + "_" + t.name
+	for _, k := range keys {
+		name += "_" + k.ColId
+	}
+	return name
+}
+
+// foreignKeyAction returns the referential action of the DeleteAction or
+// UpdateAction property of a foreign key.
+func foreignKeyAction(v string) string {
+	switch v {
+	case "1", "Cascade":
+		return constants.FK_CASCADE
+	case "2", "SetNull":
+		return constants.FK_SET_NULL
+	case "3", "SetDefault":
+		return constants.FK_SET_DEFAULT
+	}
+	return constants.FK_NO_ACTION
+}
+
+// PackageInfoSchemaImpl is the implementation of InfoSchema for SQL Server
+// packages: BACPAC and DACPAC files.
+type PackageInfoSchemaImpl struct {
+	Path   string // Local or GCS path of the package.
+	tables []*packageTable
+}
+
+// NewPackageInfoSchema reads the schema model of the local or GCS package
+// at path.
+func NewPackageInfoSchema(ctx context.Context, path string) (PackageInfoSchemaImpl, error) {
+	isi := PackageInfoSchemaImpl{Path: path}
+	pkg, err := openPackage(ctx, path)
+	if err != nil {
+		return isi, err
+	}
+	defer pkg.Close()
+	f, ok := pkg.files["model.xml"]
+	if !ok {
+		return isi, fmt.Errorf("%s isn't a BACPAC or DACPAC file: it has no model.xml", path)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return isi, err
+	}
+	defer r.Close()
+	if isi.tables, err = parseModel(r); err != nil {
+		return isi, fmt.Errorf("couldn't read package %s: %w", path, err)
+	}
+	return isi, nil
+}
+
+// sqlPackage is an open package.
+type sqlPackage struct {
+	r     file_reader.ReaderAtSeekCloser
+	files map[string]*zip.File
+}
+
+func openPackage(ctx context.Context, path string) (*sqlPackage, error) {
+	r, err := file_reader.OpenReaderAt(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	size, err := r.Seek(0, io.SeekEnd)
+	if err == nil {
+		var z *zip.Reader
+		if z, err = zip.NewReader(r, size); err == nil {
+			pkg := &sqlPackage{r: r, files: make(map[string]*zip.File)}
+			for _, f := range z.File {
+				pkg.files[f.Name] = f
+			}
+			return pkg, nil
+		}
+	}
+	r.Close()
+	return nil, fmt.Errorf("couldn't read package %s: %w", path, err)
+}
+
+func (pkg *sqlPackage) Close() error {
+	return pkg.r.Close()
+}
+
+// dataFiles returns the BCP files holding the rows of a table, in order.
+func (pkg *sqlPackage) dataFiles(t *packageTable) []*zip.File {
+	dir := "Data/" + t.schema + "." + t.name + "/"
+	var files []*zip.File
+	for name, f := range pkg.files {
+		if strings.HasPrefix(name, dir) && !strings.Contains(name[len(dir):], "/") && strings.EqualFold(path.Ext(name), ".bcp") {
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files
+}
+
+func (isi PackageInfoSchemaImpl) table(table common.SchemaAndName) (*packageTable, error) {
+	for _, t := range isi.tables {
+		if t.schema == table.Schema && t.name == table.Name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("table %s.%s not found in %s", table.Schema, table.Name, isi.Path)
+}
+
+// GetToDdl function below implement the common.InfoSchema interface.
+func (isi PackageInfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: packages can only be migrated
+// with bulk migrations.
+func (isi PackageInfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for SQL Server packages")
+}
+
+// StartStreamingMigration is not supported: packages can only be migrated
+// with bulk migrations.
+func (isi PackageInfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for SQL Server packages")
+}
+
+// GetTableName returns table name.
+func (isi PackageInfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	return InfoSchemaImpl{}.GetTableName(schema, tableName)
+}
+
+// GetTables returns the tables of the package.
+func (isi PackageInfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	var tables []common.SchemaAndName
+	for _, t := range isi.tables {
+		tables = append(tables, common.SchemaAndName{Schema: t.schema, Name: t.name})
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names. Computed columns
+// are skipped: their values aren't exported.
+func (isi PackageInfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	t, err := isi.table(table)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range t.computed {
+		conv.Unexpected(fmt.Sprintf("Skipped computed column %s of table %s.%s", name, t.schema, t.name))
+	}
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	for _, c := range t.columns {
+		colId := internal.GenerateColumnId()
+		colDefs[colId] = schema.Column{
+			Id:      colId,
+			Name:    c.name,
+			Type:    c.typ,
+			NotNull: c.notNull,
+			Ignored: schema.Ignored{Check: c.hasCheck, Default: c.hasDefault},
+		}
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetConstraints returns the primary key of a table. Columns with check
+// constraints are flagged by GetColumns.
+func (isi PackageInfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	t, err := isi.table(table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return t.primaryKey, nil, make(map[string][]string), nil
+}
+
+// GetForeignKeys returns the foreign keys of a table.
+func (isi PackageInfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) ([]schema.ForeignKey, error) {
+	t, err := isi.table(table)
+	if err != nil {
+		return nil, err
+	}
+	var foreignKeys []schema.ForeignKey
+	for _, fk := range t.foreignKeys {
+		foreignKeys = append(foreignKeys, schema.ForeignKey{
+			Id:               internal.GenerateForeignkeyId(),
+			Name:             fk.name,
+			ColumnNames:      fk.cols,
+			ReferTableName:   isi.GetTableName(fk.refSchema, fk.refTable),
+			ReferColumnNames: fk.refCols,
+			OnDelete:         fk.onDelete,
+			OnUpdate:         fk.onUpdate,
+		})
+	}
+	return foreignKeys, nil
+}
+
+// GetIndexes returns the indexes of a table, and its unique constraints
+// as unique indexes.
+func (isi PackageInfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	t, err := isi.table(table)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []schema.Index
+	for _, idx := range t.indexes {
+		index := schema.Index{Id: internal.GenerateIndexesId(), Name: idx.name, Unique: idx.unique}
+		for _, k := range idx.keys {
+			index.Keys = append(index.Keys, schema.Key{ColId: colNameIdMap[k.ColId], Desc: k.Desc})
+		}
+		for _, c := range idx.stored {
+			index.StoredColumnIds = append(index.StoredColumnIds, colNameIdMap[c])
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// GetRowsFromTable is not used: data is read from packages by
+// ProcessData.
+func (isi PackageInfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
+	return nil, fmt.Errorf("data of SQL Server packages is read by ProcessData")
+}
+
+// GetRowCount returns the number of rows of a table.
+func (isi PackageInfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	t, err := isi.table(table)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	err = isi.readRows(t, func(vals []string, err error) {
+		count++
+	})
+	return count, err
+}
+
+// ProcessData reads the rows of a table from its BCP files, converts them
+// to Spanner data (based on the source and Spanner schemas) and writes
+// them to Spanner.
+func (isi PackageInfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	if strings.EqualFold(path.Ext(isi.Path), ".dacpac") {
+		return fmt.Errorf("DACPAC files have no data: export the database to a BACPAC file, e.g. with SqlPackage /Action:Export, to migrate its data")
+	}
+	t, err := isi.table(common.SchemaAndName{Schema: srcSchema.Schema, Name: strings.TrimPrefix(srcSchema.Name, srcSchema.Schema+".")})
+	if err != nil {
+		return err
+	}
+	var srcCols []string
+	for _, c := range t.columns {
+		srcCols = append(srcCols, c.name)
+	}
+	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
+	err = isi.readRows(t, func(values []string, err error) {
+		if err == nil {
+			var newValues []string
+			if newValues, err = common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values); err == nil {
+				ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+				return
+			}
+		}
+		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+		conv.StatsAddBadRow(srcSchema.Name, conv.DataMode())
+		conv.CollectBadRow(srcSchema.Name, srcCols, values)
+	})
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't read data of table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	return nil
+}
+
+// readRows calls processRow with the values of each row of a table, or
+// with an error if the values of a row can't be converted to the strings
+// that ProcessDataRow converts. The values of a row are in the order of
+// the columns of the table.
+func (isi PackageInfoSchemaImpl) readRows(t *packageTable, processRow func(values []string, err error)) error {
+	pkg, err := openPackage(context.Background(), isi.Path)
+	if err != nil {
+		return err
+	}
+	defer pkg.Close()
+	for _, f := range pkg.dataFiles(t) {
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		br := newBCPReader(bufio.NewReader(rc), t.columns)
+		for {
+			values, valueErr, err := br.readRow()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				rc.Close()
+				return fmt.Errorf("can't read %s: %w", f.Name, err)
+			}
+			processRow(values, valueErr)
+		}
+		rc.Close()
+	}
+	return nil
+}
@@ -0,0 +1,648 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/binary"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+// testModel is in the style of the model.xml files of packages.
+const testModel = `<?xml version="1.0" encoding="utf-8"?>
+<DataSchemaModel FileFormatVersion="1.2" SchemaVersion="2.9" DspName="Microsoft.Data.Tools.Schema.Sql.SqlAzureV12DatabaseSchemaProvider" CollationLcid="1033" CollationCaseSensitive="False" xmlns="http://schemas.microsoft.com/sqlserver/dac/Serialization/2012/02">
+  <Model>
+    <Element Type="SqlDatabaseOptions">
+      <Property Name="Collation" Value="SQL_Latin1_General_CP1_CI_AS" />
+    </Element>
+    <Element Type="SqlUserDefinedDataType" Name="[dbo].[RegionCode]">
+      <Property Name="Length" Value="2" />
+      <Relationship Name="Type">
+        <Entry>
+          <References ExternalSource="BuiltIns" Name="[char]" />
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlTable" Name="[dbo].[Customers]">
+      <Relationship Name="Columns">
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[dbo].[Customers].[Id]">
+            <Property Name="IsNullable" Value="False" />
+            <Property Name="IsIdentity" Value="True" />
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[int]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[dbo].[Customers].[Name]">
+            <Property Name="IsNullable" Value="False" />
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Property Name="Length" Value="50" />
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[nvarchar]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[dbo].[Customers].[Notes]">
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Property Name="IsMax" Value="True" />
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[varchar]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[dbo].[Customers].[Balance]">
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[money]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[dbo].[Customers].[Joined]">
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Property Name="Scale" Value="3" />
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[datetime2]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[dbo].[Customers].[Region]">
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References Name="[dbo].[RegionCode]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlComputedColumn" Name="[dbo].[Customers].[Label]">
+            <Property Name="ExpressionScript">
+              <Value><![CDATA[([Name]+[Region])]]></Value>
+            </Property>
+          </Element>
+        </Entry>
+      </Relationship>
+      <Relationship Name="Schema">
+        <Entry>
+          <References ExternalSource="BuiltIns" Name="[dbo]" />
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlPrimaryKeyConstraint" Name="[dbo].[PK_Customers]">
+      <Relationship Name="ColumnSpecifications">
+        <Entry>
+          <Element Type="SqlIndexedColumnSpecification">
+            <Relationship Name="Column">
+              <Entry>
+                <References Name="[dbo].[Customers].[Id]" />
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+      </Relationship>
+      <Relationship Name="DefiningTable">
+        <Entry>
+          <References Name="[dbo].[Customers]" />
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlUniqueConstraint" Name="[dbo].[UQ_Customers_Name]">
+      <Relationship Name="ColumnSpecifications">
+        <Entry>
+          <Element Type="SqlIndexedColumnSpecification">
+            <Relationship Name="Column">
+              <Entry>
+                <References Name="[dbo].[Customers].[Name]" />
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+      </Relationship>
+      <Relationship Name="DefiningTable">
+        <Entry>
+          <References Name="[dbo].[Customers]" />
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlDefaultConstraint" Name="[dbo].[DF_Customers_Balance]">
+      <Property Name="DefaultExpressionScript">
+        <Value><![CDATA[((0))]]></Value>
+      </Property>
+      <Relationship Name="DefiningTable">
+        <Entry>
+          <References Name="[dbo].[Customers]" />
+        </Entry>
+      </Relationship>
+      <Relationship Name="ForColumn">
+        <Entry>
+          <References Name="[dbo].[Customers].[Balance]" />
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlCheckConstraint" Name="[dbo].[CK_Customers_Balance]">
+      <Property Name="CheckExpressionScript">
+        <Value><![CDATA[([Balance]>=(0))]]></Value>
+      </Property>
+      <Relationship Name="CheckExpressionDependencies">
+        <Entry>
+          <References Name="[dbo].[Customers].[Balance]" />
+        </Entry>
+      </Relationship>
+      <Relationship Name="DefiningTable">
+        <Entry>
+          <References Name="[dbo].[Customers]" />
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlTable" Name="[sales].[Orders]">
+      <Relationship Name="Columns">
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[sales].[Orders].[Id]">
+            <Property Name="IsNullable" Value="False" />
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[bigint]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[sales].[Orders].[CustomerId]">
+            <Property Name="IsNullable" Value="False" />
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[int]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[sales].[Orders].[Amount]">
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Property Name="Precision" Value="10" />
+                  <Property Name="Scale" Value="2" />
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[decimal]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[sales].[Orders].[Ref]">
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[uniqueidentifier]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+        <Entry>
+          <Element Type="SqlSimpleColumn" Name="[sales].[Orders].[Placed]">
+            <Relationship Name="TypeSpecifier">
+              <Entry>
+                <Element Type="SqlTypeSpecifier">
+                  <Property Name="Scale" Value="0" />
+                  <Relationship Name="Type">
+                    <Entry>
+                      <References ExternalSource="BuiltIns" Name="[datetimeoffset]" />
+                    </Entry>
+                  </Relationship>
+                </Element>
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlPrimaryKeyConstraint">
+      <Relationship Name="ColumnSpecifications">
+        <Entry>
+          <Element Type="SqlIndexedColumnSpecification">
+            <Relationship Name="Column">
+              <Entry>
+                <References Name="[sales].[Orders].[Id]" />
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+      </Relationship>
+      <Relationship Name="DefiningTable">
+        <Entry>
+          <References Name="[sales].[Orders]" />
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlForeignKeyConstraint" Name="[sales].[FK_Orders_Customers]">
+      <Property Name="DeleteAction" Value="1" />
+      <Relationship Name="Columns">
+        <Entry>
+          <References Name="[sales].[Orders].[CustomerId]" />
+        </Entry>
+      </Relationship>
+      <Relationship Name="DefiningTable">
+        <Entry>
+          <References Name="[sales].[Orders]" />
+        </Entry>
+      </Relationship>
+      <Relationship Name="ForeignColumns">
+        <Entry>
+          <References Name="[dbo].[Customers].[Id]" />
+        </Entry>
+      </Relationship>
+      <Relationship Name="ForeignTable">
+        <Entry>
+          <References Name="[dbo].[Customers]" />
+        </Entry>
+      </Relationship>
+    </Element>
+    <Element Type="SqlIndex" Name="[sales].[Orders].[IX_Orders_Placed]">
+      <Relationship Name="ColumnSpecifications">
+        <Entry>
+          <Element Type="SqlIndexedColumnSpecification">
+            <Property Name="IsAscending" Value="False" />
+            <Relationship Name="Column">
+              <Entry>
+                <References Name="[sales].[Orders].[Placed]" />
+              </Entry>
+            </Relationship>
+          </Element>
+        </Entry>
+      </Relationship>
+      <Relationship Name="IncludedColumns">
+        <Entry>
+          <References Name="[sales].[Orders].[Amount]" />
+        </Entry>
+      </Relationship>
+      <Relationship Name="IndexedObject">
+        <Entry>
+          <References Name="[sales].[Orders]" />
+        </Entry>
+      </Relationship>
+    </Element>
+  </Model>
+</DataSchemaModel>`
+
+// bcpWriter writes values in BCP native format.
+type bcpWriter struct {
+	b []byte
+}
+
+func (w *bcpWriter) fixed(b ...byte) *bcpWriter {
+	w.b = append(w.b, b...)
+	return w
+}
+
+func (w *bcpWriter) prefixed(size int, b []byte) *bcpWriter {
+	n := uint64(len(b))
+	if b == nil {
+		n = ^uint64(0)
+	}
+	for i := 0; i < size; i++ {
+		w.b = append(w.b, byte(n>>(8*i)))
+	}
+	w.b = append(w.b, b...)
+	return w
+}
+
+func (w *bcpWriter) int32(v int32) *bcpWriter {
+	return w.fixed(binary.LittleEndian.AppendUint32(nil, uint32(v))...)
+}
+
+func (w *bcpWriter) int64(v int64) *bcpWriter {
+	return w.fixed(binary.LittleEndian.AppendUint64(nil, uint64(v))...)
+}
+
+func utf16le(s string) []byte {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = binary.LittleEndian.AppendUint16(b, u)
+	}
+	return b
+}
+
+// money returns the storage of a money value of v/10000.
+func money(v int64) []byte {
+	return append(binary.LittleEndian.AppendUint32(nil, uint32(v>>32)), binary.LittleEndian.AppendUint32(nil, uint32(v))...)
+}
+
+// datetime2 returns the storage of a datetime2(3) or datetimeoffset(0)
+// value, without its offset.
+func datetime2(t time.Time, scale int) []byte {
+	units := int64(t.Sub(t.Truncate(24*time.Hour)) / time.Nanosecond / 100)
+	for i := scale; i < 7; i++ {
+		units /= 10
+	}
+	size := 3
+	if scale > 2 {
+		size = 4
+	}
+	var b []byte
+	for i := 0; i < size; i++ {
+		b = append(b, byte(units>>(8*i)))
+	}
+	days := int((t.Unix() - time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC).Unix()) / 86400)
+	return append(b, byte(days), byte(days>>8), byte(days>>16))
+}
+
+// writeTestPackage writes a package with the test model, and with files
+// of data unless it is a DACPAC file.
+func writeTestPackage(t *testing.T, name string) string {
+	p := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(p)
+	assert.Nil(t, err)
+	defer f.Close()
+	z := zip.NewWriter(f)
+	files := map[string][]byte{"model.xml": []byte(testModel), "Origin.xml": []byte("<DacOrigin/>")}
+	if strings.HasSuffix(name, ".bacpac") {
+		joined := time.Date(2024, 3, 1, 10, 30, 0, 250000000, time.UTC)
+		files["Data/dbo.Customers/TableData-000-00000.BCP"] = (&bcpWriter{}).
+			int32(1).prefixed(2, utf16le("Zoë")).prefixed(8, []byte("caf\xe9")).prefixed(1, money(12345678)).prefixed(1, datetime2(joined, 3)).prefixed(2, []byte("EU")).
+			int32(2).prefixed(2, utf16le("Bob")).prefixed(8, nil).prefixed(1, nil).prefixed(1, nil).prefixed(2, nil).b
+		ref := []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+		placed := append(datetime2(time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC), 0), binary.LittleEndian.AppendUint16(nil, uint16(60))...)
+		files["Data/sales.Orders/TableData-000-00000.BCP"] = (&bcpWriter{}).
+			int64(10).int32(1).prefixed(1, []byte{10, 2, 1, 0x39, 0x30, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}).prefixed(1, ref).prefixed(1, placed).b
+		files["Data/sales.Orders/TableData-001-00000.BCP"] = (&bcpWriter{}).
+			int64(11).int32(2).prefixed(1, []byte{10, 2, 0, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}).prefixed(1, nil).prefixed(1, nil).b
+	}
+	for name, content := range files {
+		w, err := z.Create(name)
+		assert.Nil(t, err)
+		_, err = w.Write(content)
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, z.Close())
+	return p
+}
+
+func mkPackageInfoSchema(t *testing.T, name string) PackageInfoSchemaImpl {
+	isi, err := NewPackageInfoSchema(context.Background(), writeTestPackage(t, name))
+	assert.Nil(t, err)
+	return isi
+}
+
+func TestPackageGetTables(t *testing.T) {
+	isi := mkPackageInfoSchema(t, "sales.bacpac")
+	tables, err := isi.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "dbo", Name: "Customers"}, {Schema: "sales", Name: "Orders"}}, tables)
+	assert.Equal(t, "Customers", isi.GetTableName("dbo", "Customers"))
+	assert.Equal(t, "sales.Orders", isi.GetTableName("sales", "Orders"))
+}
+
+func TestPackageGetColumns(t *testing.T) {
+	isi := mkPackageInfoSchema(t, "sales.bacpac")
+	conv := internal.MakeConv()
+	colDefs, colIds, err := isi.GetColumns(conv, common.SchemaAndName{Schema: "dbo", Name: "Customers"}, nil, nil)
+	assert.Nil(t, err)
+	var cols []schema.Column
+	for _, id := range colIds {
+		c := colDefs[id]
+		c.Id = ""
+		cols = append(cols, c)
+	}
+	assert.Equal(t, []schema.Column{
+		{Name: "Id", Type: schema.Type{Name: "int"}, NotNull: true},
+		{Name: "Name", Type: schema.Type{Name: "nvarchar", Mods: []int64{50}}, NotNull: true},
+		{Name: "Notes", Type: schema.Type{Name: "varchar", Mods: []int64{-1}}},
+		{Name: "Balance", Type: schema.Type{Name: "money"}, Ignored: schema.Ignored{Check: true, Default: true}},
+		{Name: "Joined", Type: schema.Type{Name: "datetime2"}},
+		{Name: "Region", Type: schema.Type{Name: "char", Mods: []int64{2}}},
+	}, cols)
+	// The computed column is skipped.
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+
+	colDefs, colIds, err = isi.GetColumns(conv, common.SchemaAndName{Schema: "sales", Name: "Orders"}, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, schema.Type{Name: "decimal", Mods: []int64{10, 2}}, colDefs[colIds[2]].Type)
+	_, _, err = isi.GetColumns(conv, common.SchemaAndName{Schema: "dbo", Name: "Missing"}, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestPackageProcessSchema(t *testing.T) {
+	isi := mkPackageInfoSchema(t, "sales.dacpac")
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{DdlV: &expressions_api.MockDDLVerifier{}}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+
+	customers, ok := internal.GetSrcTableByName(conv.SrcSchema, "Customers")
+	assert.True(t, ok)
+	orders, ok := internal.GetSrcTableByName(conv.SrcSchema, "sales.Orders")
+	assert.True(t, ok)
+	assert.Equal(t, []schema.Key{{ColId: customers.ColNameIdMap["Id"], Order: 1}}, customers.PrimaryKeys)
+	assert.Equal(t, []schema.Key{{ColId: orders.ColNameIdMap["Id"], Order: 1}}, orders.PrimaryKeys)
+	assert.Equal(t, 1, len(customers.Indexes))
+	assert.Equal(t, "UQ_Customers_Name", customers.Indexes[0].Name)
+	assert.True(t, customers.Indexes[0].Unique)
+	assert.Equal(t, 1, len(orders.Indexes))
+	assert.Equal(t, schema.Index{
+		Id:              orders.Indexes[0].Id,
+		Name:            "IX_Orders_Placed",
+		Keys:            []schema.Key{{ColId: orders.ColNameIdMap["Placed"], Desc: true, Order: 1}},
+		StoredColumnIds: []string{orders.ColNameIdMap["Amount"]},
+	}, orders.Indexes[0])
+	assert.Equal(t, 1, len(orders.ForeignKeys))
+	fk := orders.ForeignKeys[0]
+	assert.Equal(t, "FK_Orders_Customers", fk.Name)
+	assert.Equal(t, customers.Id, fk.ReferTableId)
+	assert.Equal(t, []string{orders.ColNameIdMap["CustomerId"]}, fk.ColIds)
+	assert.Equal(t, []string{customers.ColNameIdMap["Id"]}, fk.ReferColumnIds)
+	assert.Equal(t, constants.FK_CASCADE, fk.OnDelete)
+	assert.Equal(t, constants.FK_NO_ACTION, fk.OnUpdate)
+
+	spOrders := conv.SpSchema[orders.Id]
+	assert.Equal(t, ddl.Type{Name: ddl.Numeric}, spOrders.ColDefs[orders.ColNameIdMap["Amount"]].T)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, spOrders.ColDefs[orders.ColNameIdMap["Ref"]].T)
+	assert.Equal(t, ddl.Type{Name: ddl.Timestamp}, spOrders.ColDefs[orders.ColNameIdMap["Placed"]].T)
+}
+
+func TestPackageGetRowCount(t *testing.T) {
+	isi := mkPackageInfoSchema(t, "sales.bacpac")
+	count, err := isi.GetRowCount(common.SchemaAndName{Schema: "sales", Name: "Orders"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	isi = mkPackageInfoSchema(t, "sales.dacpac")
+	count, err = isi.GetRowCount(common.SchemaAndName{Schema: "sales", Name: "Orders"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+// processPackageData migrates the data of the source table srcTableName
+// and returns the rows written.
+func processPackageData(t *testing.T, isi PackageInfoSchemaImpl, srcTableName string) ([]spannerData, *internal.Conv, error) {
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{DdlV: &expressions_api.MockDDLVerifier{}}
+	err := processSchema.ProcessSchema(conv, isi, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	var rows []spannerData
+	conv.SetDataMode()
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			// Compare times in UTC, whatever their offsets.
+			for i, v := range vals {
+				if ts, ok := v.(time.Time); ok {
+					vals[i] = ts.UTC()
+				}
+			}
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	src, ok := internal.GetSrcTableByName(conv.SrcSchema, srcTableName)
+	assert.True(t, ok)
+	err = isi.ProcessData(conv, src.Id, *src, conv.SpSchema[src.Id].ColIds, conv.SpSchema[src.Id], internal.AdditionalDataAttributes{})
+	return rows, conv, err
+}
+
+func TestPackageProcessData(t *testing.T) {
+	isi := mkPackageInfoSchema(t, "sales.bacpac")
+	rows, conv, err := processPackageData(t, isi, "Customers")
+	assert.Nil(t, err)
+	assert.Equal(t, []spannerData{
+		{
+			table: "Customers",
+			cols:  []string{"Id", "Name", "Notes", "Balance", "Joined", "Region"},
+			vals:  []interface{}{int64(1), "Zoë", "café", big.NewRat(12345678, 10000), time.Date(2024, 3, 1, 10, 30, 0, 250000000, time.UTC), "EU"},
+		},
+		{
+			table: "Customers",
+			cols:  []string{"Id", "Name"},
+			vals:  []interface{}{int64(2), "Bob"},
+		},
+	}, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+
+	rows, conv, err = processPackageData(t, isi, "sales.Orders")
+	assert.Nil(t, err)
+	assert.Equal(t, []spannerData{
+		{
+			table: "sales_Orders",
+			cols:  []string{"Id", "CustomerId", "Amount", "Ref", "Placed"},
+			vals:  []interface{}{int64(10), int64(1), big.NewRat(12345, 100), "00112233-4455-6677-8899-AABBCCDDEEFF", time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC)},
+		},
+		{
+			table: "sales_Orders",
+			cols:  []string{"Id", "CustomerId", "Amount"},
+			vals:  []interface{}{int64(11), int64(2), big.NewRat(-5, 100)},
+		},
+	}, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+
+	_, _, err = processPackageData(t, mkPackageInfoSchema(t, "sales.dacpac"), "Customers")
+	assert.NotNil(t, err)
+}
+
+func TestNewPackageInfoSchemaErrors(t *testing.T) {
+	_, err := NewPackageInfoSchema(context.Background(), filepath.Join(t.TempDir(), "missing.bacpac"))
+	assert.NotNil(t, err)
+	p := filepath.Join(t.TempDir(), "not-a-zip.bacpac")
+	assert.Nil(t, os.WriteFile(p, []byte("BACPAC"), 0644))
+	_, err = NewPackageInfoSchema(context.Background(), p)
+	assert.NotNil(t, err)
+}
+
+func TestSplitName(t *testing.T) {
+	assert.Equal(t, []string{"dbo", "Orders", "Id"}, splitName("[dbo].[Orders].[Id]"))
+	assert.Equal(t, []string{"dbo", "a.b]c"}, splitName("[dbo].[a.b]]c]"))
+	assert.Equal(t, []string{"int"}, splitName("[int]"))
+	assert.Equal(t, []string{"dbo", "T"}, splitName("dbo.T"))
+}
@@ -0,0 +1,308 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// BCP native format files hold the values of the columns of each row in
+// their binary storage format, with no row or column separators. Values
+// of variable length, and nullable values of fixed length, are prefixed
+// by their length: NULL values have a length of -1. The length prefix is
+// 1 byte long for nullable fixed-length types, decimals and the date and
+// time types introduced in SQL Server 2008, 2 bytes long for char and
+// binary types, and 8 bytes long for max types and other large values.
+
+// fixedSizes are the sizes of the types of fixed size.
+var fixedSizes = map[string]int{
+	"bit":              1,
+	"tinyint":          1,
+	"smallint":         2,
+	"int":              4,
+	"bigint":           8,
+	"real":             4,
+	"float":            8,
+	"smallmoney":       4,
+	"money":            8,
+	"smalldatetime":    4,
+	"datetime":         8,
+	"uniqueidentifier": 16,
+	"timestamp":        8,
+	"rowversion":       8,
+}
+
+// bcpReader reads the rows of a BCP native format file.
+type bcpReader struct {
+	r    *bufio.Reader
+	cols []packageColumn
+	buf  []byte
+}
+
+func newBCPReader(r *bufio.Reader, cols []packageColumn) *bcpReader {
+	return &bcpReader{r: r, cols: cols}
+}
+
+// readRow returns the values of the next row in the formats that the
+// select query of GetRowsFromTable returns them in, with "NULL" for NULL
+// values, or io.EOF at the end of the file. Values that can't be
+// converted to strings are reported by valueErr: the rest of the row is
+// still read.
+func (br *bcpReader) readRow() (values []string, valueErr error, err error) {
+	if _, err := br.r.Peek(1); err == io.EOF {
+		return nil, nil, io.EOF
+	}
+	values = make([]string, len(br.cols))
+	for i, c := range br.cols {
+		b, null, err := br.readValue(c)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if null {
+			values[i] = "NULL"
+			continue
+		}
+		if values[i], err = formatValue(c, b); err != nil && valueErr == nil {
+			valueErr = fmt.Errorf("can't read value of column %s: %w", c.name, err)
+		}
+	}
+	return values, valueErr, nil
+}
+
+// readValue returns the bytes of the next value of column c.
+func (br *bcpReader) readValue(c packageColumn) ([]byte, bool, error) {
+	name := c.typ.Name
+	prefix := 8
+	if size, ok := fixedSizes[name]; ok {
+		if !c.notNull {
+			prefix = 1
+		} else {
+			b, err := br.read(size)
+			return b, false, err
+		}
+	}
+	switch name {
+	case "decimal", "numeric", "date", "time", "datetime2", "datetimeoffset":
+		prefix = 1
+	case "char", "varchar", "nchar", "nvarchar", "binary", "varbinary":
+		if len(c.typ.Mods) == 0 || c.typ.Mods[0] >= 0 {
+			prefix = 2
+		}
+	}
+	b, err := br.read(prefix)
+	if err != nil {
+		return nil, false, err
+	}
+	var n uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		n = n<<8 | uint64(b[i])
+	}
+	if n == math.MaxUint64>>(64-8*prefix) {
+		return nil, true, nil
+	}
+	if n > math.MaxInt32 {
+		return nil, false, fmt.Errorf("value of column %s is too long: %d bytes", c.name, n)
+	}
+	b, err = br.read(int(n))
+	return b, false, err
+}
+
+func (br *bcpReader) read(n int) ([]byte, error) {
+	if cap(br.buf) < n {
+		br.buf = make([]byte, n)
+	}
+	b := br.buf[:n]
+	_, err := io.ReadFull(br.r, b)
+	return b, err
+}
+
+// formatValue returns the value b of column c as a string.
+func formatValue(c packageColumn, b []byte) (string, error) {
+	name := c.typ.Name
+	if size, ok := fixedSizes[name]; ok && len(b) != size {
+		return "", fmt.Errorf("%s value has %d bytes", name, len(b))
+	}
+	switch name {
+	case "bit":
+		return strconv.FormatBool(b[0] != 0), nil
+	case "tinyint":
+		return strconv.Itoa(int(b[0])), nil
+	case "smallint":
+		return strconv.Itoa(int(int16(binary.LittleEndian.Uint16(b)))), nil
+	case "int":
+		return strconv.Itoa(int(int32(binary.LittleEndian.Uint32(b)))), nil
+	case "bigint":
+		return strconv.FormatInt(int64(binary.LittleEndian.Uint64(b)), 10), nil
+	case "real":
+		return strconv.FormatFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), 'g', -1, 32), nil
+	case "float":
+		return strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64(b)), 'g', -1, 64), nil
+	case "smallmoney":
+		return formatScaled(big.NewInt(int64(int32(binary.LittleEndian.Uint32(b)))), 4), nil
+	case "money":
+		// The high 4 bytes come first.
+		v := int64(binary.LittleEndian.Uint32(b))<<32 | int64(binary.LittleEndian.Uint32(b[4:]))
+		return formatScaled(big.NewInt(v), 4), nil
+	case "decimal", "numeric":
+		// Precision, scale, sign (1 for positive values) and magnitude.
+		if len(b) < 3 {
+			return "", fmt.Errorf("%s value has %d bytes", name, len(b))
+		}
+		v := new(big.Int).SetBytes(reverse(b[3:]))
+		if b[2] == 0 {
+			v.Neg(v)
+		}
+		return formatScaled(v, int(b[1])), nil
+	case "smalldatetime":
+		days, minutes := binary.LittleEndian.Uint16(b), binary.LittleEndian.Uint16(b[2:])
+		t := time.Date(1900, 1, 1+int(days), 0, int(minutes), 0, 0, time.UTC)
+		return t.Format("2006-01-02T15:04:05"), nil
+	case "datetime":
+		// Days since 1900 and 1/300ths of a second since midnight.
+		days, ticks := int32(binary.LittleEndian.Uint32(b)), binary.LittleEndian.Uint32(b[4:])
+		t := time.Date(1900, 1, 1+int(days), 0, 0, 0, 0, time.UTC).Add(time.Duration(ticks) * time.Second / 300).Round(time.Millisecond)
+		return t.Format("2006-01-02T15:04:05.000"), nil
+	case "date":
+		if len(b) != 3 {
+			return "", fmt.Errorf("date value has %d bytes", len(b))
+		}
+		return dateOf(b).Format("2006-01-02"), nil
+	case "time":
+		d, err := timeOfDay(b, c.scale)
+		if err != nil {
+			return "", err
+		}
+		return time.Time{}.Add(d).Format("15:04:05" + fraction(c.scale)), nil
+	case "datetime2", "datetimeoffset":
+		n := len(b) - 3
+		if name == "datetimeoffset" {
+			n -= 2
+		}
+		if n < 0 {
+			return "", fmt.Errorf("%s value has %d bytes", name, len(b))
+		}
+		d, err := timeOfDay(b[:n], c.scale)
+		if err != nil {
+			return "", err
+		}
+		t := dateOf(b[n : n+3]).Add(d)
+		if name == "datetime2" {
+			return t.Format("2006-01-02T15:04:05" + fraction(c.scale)), nil
+		}
+		// The time is in UTC, followed by the offset in minutes.
+		offset := int(int16(binary.LittleEndian.Uint16(b[n+3:])))
+		return t.In(time.FixedZone("", offset*60)).Format("2006-01-02T15:04:05" + fraction(c.scale) + "Z07:00"), nil
+	case "uniqueidentifier":
+		return fmt.Sprintf("%08X-%04X-%04X-%X-%X", binary.LittleEndian.Uint32(b), binary.LittleEndian.Uint16(b[4:]), binary.LittleEndian.Uint16(b[6:]), b[8:10], b[10:]), nil
+	case "timestamp", "rowversion":
+		return strconv.FormatInt(int64(binary.BigEndian.Uint64(b)), 10), nil
+	case "char", "varchar", "text":
+		if strings.Contains(strings.ToUpper(c.collation), "UTF8") {
+			return string(b), nil
+		}
+		// Latin1_General collations, the default ones, use the Windows-1252
+		// code page.
+		return charmap.Windows1252.NewDecoder().String(string(b))
+	case "nchar", "nvarchar", "ntext", "xml":
+		return decodeUTF16(b)
+	case "binary", "varbinary", "image":
+		return string(b), nil
+	}
+	return "", fmt.Errorf("values of %s columns can't be read from BACPAC files", name)
+}
+
+// formatScaled returns the decimal string of v/10^scale.
+func formatScaled(v *big.Int, scale int) string {
+	if scale == 0 {
+		return v.String()
+	}
+	s := new(big.Int).Abs(v).String()
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+	s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	if v.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// dateOf returns the date of 3 bytes holding the number of days since
+// 0001-01-01.
+func dateOf(b []byte) time.Time {
+	days := int(b[0]) | int(b[1])<<8 | int(b[2])<<16
+	return time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, days)
+}
+
+// timeOfDay returns the time of day of 3 to 5 bytes holding the number of
+// 10^-scale second units since midnight.
+func timeOfDay(b []byte, scale int64) (time.Duration, error) {
+	if len(b) < 3 || len(b) > 5 || scale < 0 || scale > 7 {
+		return 0, fmt.Errorf("invalid time of %d bytes with scale %d", len(b), scale)
+	}
+	var units int64
+	for i := len(b) - 1; i >= 0; i-- {
+		units = units<<8 | int64(b[i])
+	}
+	for i := scale; i < 7; i++ {
+		units *= 10
+	}
+	return time.Duration(units) * 100 * time.Nanosecond, nil
+}
+
+// fraction returns the layout of the fractional seconds of a time type.
+func fraction(scale int64) string {
+	if scale <= 0 {
+		return ""
+	}
+	return "." + strings.Repeat("0", int(scale))
+}
+
+func decodeUTF16(b []byte) (string, error) {
+	if len(b)%2 != 0 {
+		return "", errors.New("UTF-16 string has an odd number of bytes")
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	if len(u) > 0 && u[0] == 0xFEFF {
+		u = u[1:]
+	}
+	return string(utf16.Decode(u)), nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, c := range b {
+		r[len(b)-1-i] = c
+	}
+	return r
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatValue(t *testing.T) {
+	col := func(name string, mods ...int64) packageColumn {
+		return packageColumn{name: "c", typ: schema.Type{Name: name, Mods: mods}, scale: 7, collation: "SQL_Latin1_General_CP1_CI_AS"}
+	}
+	for _, tc := range []struct {
+		col      packageColumn
+		b        []byte
+		expected string
+	}{
+		{col("bit"), []byte{1}, "true"},
+		{col("tinyint"), []byte{255}, "255"},
+		{col("smallint"), []byte{0xfe, 0xff}, "-2"},
+		{col("int"), []byte{0x2a, 0, 0, 0}, "42"},
+		{col("bigint"), []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "-1"},
+		{col("real"), []byte{0, 0, 0xc0, 0x3f}, "1.5"},
+		{col("float"), []byte{0, 0, 0, 0, 0, 0, 0xf8, 0x3f}, "1.5"},
+		{col("smallmoney"), []byte{0xf6, 0xff, 0xff, 0xff}, "-0.0010"},
+		{col("money"), money(-12345678), "-1234.5678"},
+		{col("decimal", 5, 3), []byte{5, 3, 1, 0x39, 0x30, 0, 0}, "12.345"},
+		{col("numeric", 5), []byte{5, 0, 0, 7, 0, 0, 0}, "-7"},
+		{col("smalldatetime"), []byte{1, 0, 61, 0}, "1900-01-02T01:01:00"},
+		{col("datetime"), append(int32le(45000), int32le(300*3600+150)...), "2023-03-17T01:00:00.500"},
+		{col("date"), datetime2(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 0)[3:], "2024-02-29"},
+		{packageColumn{typ: schema.Type{Name: "time"}, scale: 2}, []byte{0x15, 0x43, 0x06}, "01:08:23.89"},
+		{packageColumn{typ: schema.Type{Name: "datetime2"}, scale: 3}, datetime2(time.Date(2024, 3, 1, 10, 30, 0, 250000000, time.UTC), 3), "2024-03-01T10:30:00.250"},
+		{packageColumn{typ: schema.Type{Name: "datetimeoffset"}, scale: 0}, append(datetime2(time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC), 0), 0x88, 0xff), "2024-03-01T21:00:00-02:00"},
+		{col("uniqueidentifier"), []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}, "00112233-4455-6677-8899-AABBCCDDEEFF"},
+		{col("timestamp"), []byte{0, 0, 0, 0, 0, 0, 0x07, 0xd1}, "2001"},
+		{col("varchar", 10), []byte("na\xefve"), "naïve"},
+		{packageColumn{typ: schema.Type{Name: "varchar"}, collation: "Latin1_General_100_CI_AS_SC_UTF8"}, []byte("naïve"), "naïve"},
+		{col("nvarchar", -1), utf16le("日本"), "日本"},
+		{col("xml"), append([]byte{0xff, 0xfe}, utf16le("<a/>")...), "<a/>"},
+		{col("varbinary", 4), []byte{0, 1, 2}, "\x00\x01\x02"},
+	} {
+		s, err := formatValue(tc.col, tc.b)
+		assert.Nil(t, err, tc.col.typ.Name)
+		assert.Equal(t, tc.expected, s, tc.col.typ.Name)
+	}
+	for _, tc := range []struct {
+		col packageColumn
+		b   []byte
+	}{
+		{col("int"), []byte{1, 2}},
+		{col("geography"), []byte{0xe6, 0x10}},
+		{col("hierarchyid"), []byte{0x58}},
+		{col("nvarchar", 5), []byte{0x41}},
+		{packageColumn{typ: schema.Type{Name: "time"}, scale: 7}, []byte{1, 2}},
+	} {
+		_, err := formatValue(tc.col, tc.b)
+		assert.NotNil(t, err, tc.col.typ.Name)
+	}
+}
+
+func int32le(v int32) []byte {
+	return (&bcpWriter{}).int32(v).b
+}
+
+func TestReadRow(t *testing.T) {
+	cols := []packageColumn{
+		{name: "id", typ: schema.Type{Name: "int"}, notNull: true},
+		{name: "n", typ: schema.Type{Name: "int"}},
+		{name: "s", typ: schema.Type{Name: "nvarchar", Mods: []int64{10}}},
+		{name: "m", typ: schema.Type{Name: "varbinary", Mods: []int64{-1}}},
+		{name: "g", typ: schema.Type{Name: "geometry"}},
+	}
+	data := (&bcpWriter{}).
+		int32(1).prefixed(1, int32le(7)).prefixed(2, utf16le("ab")).prefixed(8, []byte{}).prefixed(8, nil).
+		int32(2).prefixed(1, nil).prefixed(2, nil).prefixed(8, nil).prefixed(8, []byte{1, 2}).
+		int32(3).b
+	br := newBCPReader(bufio.NewReader(bytes.NewReader(data)), cols)
+
+	values, valueErr, err := br.readRow()
+	assert.Nil(t, err)
+	assert.Nil(t, valueErr)
+	assert.Equal(t, []string{"1", "7", "ab", "", "NULL"}, values)
+
+	// The geometry value can't be read, but the row is read entirely.
+	values, valueErr, err = br.readRow()
+	assert.Nil(t, err)
+	assert.NotNil(t, valueErr)
+	assert.Equal(t, []string{"2", "NULL", "NULL", "NULL", ""}, values)
+
+	// The last row is truncated.
+	_, _, err = br.readRow()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	br = newBCPReader(bufio.NewReader(bytes.NewReader(nil)), cols)
+	_, _, err = br.readRow()
+	assert.Equal(t, io.EOF, err)
+}
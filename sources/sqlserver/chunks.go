@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// chunkKeyTypes are the types of the key columns whose tables are read in
+// chunks. Values of other types are either rounded, or converted by
+// getSelectQuery e.g. to text, so that their rows would be sorted by the
+// converted values but compared by the original ones.
+var chunkKeyTypes = map[string]bool{
+	"tinyint": true, "smallint": true, "int": true, "bigint": true,
+	"char": true, "varchar": true, "nchar": true, "nvarchar": true,
+	"binary": true, "varbinary": true,
+}
+
+// chunkRow is a row of a chunk of the rows of a table.
+type chunkRow struct {
+	cols   []string
+	values []interface{}
+	err    error
+}
+
+// chunkKeyOf returns the columns of the key the rows of table are read in
+// chunks by, or nil when they're read with a single query.
+func chunkKeyOf(table schema.Table) []string {
+	return common.ChunkKey(table, func(col schema.Column) bool {
+		return !chunkKeyTypes[col.Type.Name]
+	})
+}
+
+// readChunks reads the rows of the table tableId in chunks in the order of
+// its key columns key, passing them to emit.
+func (isi InfoSchemaImpl) readChunks(conv *internal.Conv, tableId string, key []string, emit func(chunkRow)) error {
+	tbl := conv.SrcSchema[tableId]
+	//To get only the table name by removing the schema name prefix
+	tblName := strings.Replace(tbl.Name, tbl.Schema+".", "", 1)
+	colNameIdMap := internal.GetSrcColNameIdMap(tbl)
+	var quoted, types []string
+	for _, col := range key {
+		quoted = append(quoted, "["+col+"]")
+		types = append(types, keyParamType(tbl.ColDefs[colNameIdMap[col]].Type))
+	}
+	// The values of the key are cast to the types of its columns: string
+	// values are bound as nvarchar, which varchar columns would otherwise
+	// be converted to, and compared with another collation than theirs,
+	// without seeking their index. Casts take the collation of the column
+	// they are compared to.
+	placeholder := func(i, col int) string { return fmt.Sprintf("CAST(@p%d AS %s)", i, types[col]) }
+	read := func(after []interface{}, limit int) ([]chunkRow, error) {
+		q := fmt.Sprintf("SELECT TOP (%d) %s FROM [%s].[%s].[%s]", limit, getSelectColumns(tbl.ColIds, tbl.ColDefs), isi.DbName, tbl.Schema, tblName)
+		var args []interface{}
+		if after != nil {
+			var filter string
+			filter, args = common.KeysetFilter(quoted, after, placeholder, nil)
+			q += " WHERE " + filter
+		}
+		q += " ORDER BY " + strings.Join(quoted, ", ")
+		rows, err := isi.Db.Query(q, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		srcCols, _ := rows.Columns()
+		var chunk []chunkRow
+		for rows.Next() {
+			v, scanArgs := buildVals(len(srcCols))
+			if err := rows.Scan(scanArgs...); err != nil {
+				chunk = append(chunk, chunkRow{err: err})
+				continue
+			}
+			chunk = append(chunk, chunkRow{cols: srcCols, values: v})
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return chunk, nil
+	}
+	rowKey := func(row chunkRow) []interface{} { return keyOf(row, key) }
+	return common.ReadChunks(isi.SourceProfile.ChunkSize(), read, rowKey, emit)
+}
+
+// keyParamType returns the type of the values of a key column of type ty
+// in the queries of chunks, e.g. varchar(20).
+func keyParamType(ty schema.Type) string {
+	switch ty.Name {
+	case "char", "varchar", "nchar", "nvarchar", "binary", "varbinary":
+		switch {
+		case len(ty.Mods) == 0:
+		case ty.Mods[0] < 0:
+			return ty.Name + "(max)"
+		default:
+			return fmt.Sprintf("%s(%d)", ty.Name, ty.Mods[0])
+		}
+	}
+	return ty.Name
+}
+
+// keyOf returns the values of the key columns key of row, or nil if they
+// couldn't be read.
+func keyOf(row chunkRow, key []string) []interface{} {
+	if row.err != nil {
+		return nil
+	}
+	var values []interface{}
+	for _, col := range key {
+		i := slices.Index(row.cols, col)
+		if i < 0 {
+			return nil
+		}
+		values = append(values, row.values[i])
+	}
+	return values
+}
+
+// processChunksData performs data conversion for a table whose rows are
+// read in chunks in the order of its key columns key.
+func (isi InfoSchemaImpl) processChunksData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, key []string) error {
+	srcTable := conv.SrcSchema[tableId]
+	colNameIdMap := internal.GetSrcColNameIdMap(srcTable)
+	process := func(row chunkRow) {
+		if row.err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", row.err))
+			// Scan failed, so we don't have any data to add to bad rows.
+			conv.StatsAddBadRow(srcTable.Name, conv.DataMode())
+			return
+		}
+		processRowValues(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, row.cols, row.values)
+	}
+	if err := isi.readChunks(conv, tableId, key, process); err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTable.Name, err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func chunksConv(lineType schema.Type) *internal.Conv {
+	return buildConv(
+		ddl.CreateTable{
+			Name:   "order_lines",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "order_id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "line", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+				"c3": {Name: "amount", Id: "c3", T: ddl.Type{Name: ddl.Int64}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		},
+		schema.Table{
+			Name:   "order_lines",
+			Id:     "t1",
+			Schema: "dbo",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "order_id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+				"c2": {Name: "line", Id: "c2", Type: lineType},
+				"c3": {Name: "amount", Id: "c3", Type: schema.Type{Name: "bigint"}},
+			},
+			ColNameIdMap: map[string]string{"order_id": "c1", "line": "c2", "amount": "c3"},
+			PrimaryKeys:  []schema.Key{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+		})
+}
+
+func TestProcessDataChunks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	cols := []string{"order_id", "line", "amount"}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT TOP (2) [order_id], [line], [amount] FROM [test].[dbo].[order_lines] ORDER BY [order_id], [line]")).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(1), "1", int64(10)).AddRow(int64(1), "2", int64(20)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT TOP (2) [order_id], [line], [amount] FROM [test].[dbo].[order_lines] WHERE (([order_id] > CAST(@p1 AS bigint)) OR ([order_id] = CAST(@p2 AS bigint) AND [line] > CAST(@p3 AS varchar(20)))) ORDER BY [order_id], [line]")).
+		WithArgs(int64(1), int64(1), "2").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(2), "1", int64(30)))
+	// Key values are cast to the types of the key columns, e.g. so that
+	// string values aren't compared as nvarchar to varchar columns.
+	conv := chunksConv(schema.Type{Name: "varchar", Mods: []int64{20}})
+	conv.SetDataMode()
+	var rows []spannerData
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	sourceProfile := profiles.SourceProfile{Ty: profiles.SourceProfileTypeConnection, Conn: profiles.SourceProfileConnection{ChunkSize: 2}}
+	isi := InfoSchemaImpl{DbName: "test", Db: db, SourceProfile: sourceProfile}
+	err = isi.ProcessData(conv, "t1", conv.SrcSchema["t1"], conv.SpSchema["t1"].ColIds, conv.SpSchema["t1"], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	cols = []string{"order_id", "line", "amount"}
+	assert.Equal(t, []spannerData{
+		{table: "order_lines", cols: cols, vals: []interface{}{int64(1), int64(1), int64(10)}},
+		{table: "order_lines", cols: cols, vals: []interface{}{int64(1), int64(2), int64(20)}},
+		{table: "order_lines", cols: cols, vals: []interface{}{int64(2), int64(1), int64(30)}},
+	}, rows)
+}
+
+func TestChunkKeyOf(t *testing.T) {
+	assert.Equal(t, []string{"order_id", "line"}, chunkKeyOf(chunksConv(schema.Type{Name: "int"}).SrcSchema["t1"]))
+	// Tables whose keys are converted by the queries of their rows, or
+	// can't be compared exactly, are read with a single query.
+	assert.Nil(t, chunkKeyOf(chunksConv(schema.Type{Name: uuidType}).SrcSchema["t1"]))
+	assert.Nil(t, chunkKeyOf(chunksConv(schema.Type{Name: "float"}).SrcSchema["t1"]))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
 
@@ -98,6 +99,9 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, t
 	// Note that many of the underlying conversions functions we use (like
 	// strconv.ParseFloat and strconv.ParseInt) return "invalid syntax"
 	// errors if whitespace were to appear at the start or end of a string.
+	if srcTypeName == geometryType || srcTypeName == geographyType {
+		return common.ConvSpatial(spannerType, val)
+	}
 	switch spannerType.Name {
 	case ddl.Bool:
 		return convBool(val)
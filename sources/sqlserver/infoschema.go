@@ -18,12 +18,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	sp "cloud.google.com/go/spanner"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
@@ -44,8 +46,11 @@ const (
 )
 
 type InfoSchemaImpl struct {
-	DbName string
-	Db     *sql.DB
+	DbName        string
+	Db            *sql.DB
+	SourceProfile profiles.SourceProfile
+	// Metadata is the metadata of tables fetched in bulk, if it has been.
+	Metadata *common.BulkMetadata
 }
 
 // GetToDdl function below implement the common.InfoSchema interface.
@@ -85,6 +90,9 @@ func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
 // we can generate more targeted error messages: hence we pass
 // *interface{} parameters to row.Scan.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	if key := chunkKeyOf(conv.SrcSchema[tableId]); key != nil {
+		return isi.processChunksData(conv, tableId, srcSchema, commonColIds, spSchema, key)
+	}
 	srcTableName := conv.SrcSchema[tableId].Name
 	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
 	if err != nil {
@@ -105,19 +113,26 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 			conv.StatsAddBadRow(srcTableName, conv.DataMode())
 			continue
 		}
-		values := valsToStrings(v)
-		newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
-		if err != nil {
-			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
-			conv.StatsAddBadRow(srcTableName, conv.DataMode())
-			conv.CollectBadRow(srcTableName, srcCols, values)
-			continue
-		}
-		ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+		processRowValues(conv, tableId, srcSchema, commonColIds, spSchema, colNameIdMap, srcCols, v)
 	}
 	return nil
 }
 
+// processRowValues converts the values v of the columns srcCols of a row
+// of a table, and writes them.
+func processRowValues(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, colNameIdMap map[string]string, srcCols []string, v []interface{}) {
+	srcTableName := conv.SrcSchema[tableId].Name
+	values := valsToStrings(v)
+	newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcCols, values)
+		return
+	}
+	ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+}
+
 // GetRowsFromTable returns a sql Rows object for a table.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
 	tbl := conv.SrcSchema[tableId]
@@ -133,6 +148,12 @@ func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string)
 }
 
 func getSelectQuery(srcDb string, schemaName string, tableName string, colIds []string, colDefs map[string]schema.Column) string {
+	return fmt.Sprintf("SELECT %s FROM [%s].[%s].[%s]", getSelectColumns(colIds, colDefs), srcDb, schemaName, tableName)
+}
+
+// getSelectColumns returns the list of the columns colIds selected by
+// getSelectQuery, converted as needed.
+func getSelectColumns(colIds []string, colDefs map[string]schema.Column) string {
 	var selects = make([]string, len(colIds))
 
 	for i, colId := range colIds {
@@ -159,7 +180,7 @@ func getSelectQuery(srcDb string, schemaName string, tableName string, colIds []
 		selects[i] = s
 	}
 
-	return fmt.Sprintf("SELECT %s FROM [%s].[%s].[%s]", strings.Join(selects, ", "), srcDb, schemaName, tableName)
+	return strings.Join(selects, ", ")
 }
 
 // buildVals contructs interface{} value containers to scan row
@@ -213,7 +234,118 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 		rows.Scan(&tableSchema, &tableName)
 		tables = append(tables, common.SchemaAndName{Schema: tableSchema, Name: tableName})
 	}
-	return tables, nil
+	if isi.SourceProfile.Conn.SqlServer.TemporalHistory {
+		return tables, nil
+	}
+	return isi.withoutHistoryTables(tables), nil
+}
+
+// createViewRegex matches the CREATE VIEW statement header of view
+// definitions, which precedes their query.
+var createViewRegex = regexp.MustCompile(`(?is)^\s*CREATE\s+(OR\s+ALTER\s+)?VIEW\s+.+?\s+AS\s+`)
+
+// GetViews implements the common.ViewSource interface.
+func (isi InfoSchemaImpl) GetViews() ([]schema.View, error) {
+	q := `
+	SELECT
+		SCH.name AS view_schema,
+		VW.name AS view_name,
+		MOD.definition
+	FROM sys.views AS VW
+	INNER JOIN sys.schemas AS SCH
+	ON SCH.schema_id = VW.schema_id
+	INNER JOIN sys.sql_modules AS MOD
+	ON MOD.object_id = VW.object_id
+	WHERE VW.is_ms_shipped = 0
+	`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get views: %w", err)
+	}
+	defer rows.Close()
+	var views []schema.View
+	for rows.Next() {
+		var view schema.View
+		var definition sql.NullString
+		if err := rows.Scan(&view.Schema, &view.Name, &definition); err != nil {
+			return nil, fmt.Errorf("couldn't get views: %w", err)
+		}
+		// The definitions of encrypted views are NULL.
+		view.Query = createViewRegex.ReplaceAllString(definition.String, "")
+		views = append(views, view)
+	}
+	return views, rows.Err()
+}
+
+// routineKinds maps sys.objects.type to the kinds of routines.
+var routineKinds = map[string]string{
+	"P":  schema.RoutineProcedure,
+	"FN": schema.RoutineFunction,
+	"IF": schema.RoutineFunction,
+	"TF": schema.RoutineFunction,
+	"TR": schema.RoutineTrigger,
+}
+
+// GetRoutines implements the common.RoutineSource interface.
+func (isi InfoSchemaImpl) GetRoutines() ([]schema.Routine, error) {
+	q := `
+	SELECT
+		SCH.name AS routine_schema,
+		OBJ.name AS routine_name,
+		RTRIM(OBJ.type) AS routine_type,
+		MOD.definition,
+		PARENT.name AS table_name,
+		OBJECTPROPERTY(OBJ.object_id, 'ExecIsInsteadOfTrigger') AS is_instead_of,
+		OBJECTPROPERTY(OBJ.object_id, 'ExecIsInsertTrigger') AS is_insert,
+		OBJECTPROPERTY(OBJ.object_id, 'ExecIsUpdateTrigger') AS is_update,
+		OBJECTPROPERTY(OBJ.object_id, 'ExecIsDeleteTrigger') AS is_delete
+	FROM sys.objects AS OBJ
+	INNER JOIN sys.schemas AS SCH
+	ON SCH.schema_id = OBJ.schema_id
+	INNER JOIN sys.sql_modules AS MOD
+	ON MOD.object_id = OBJ.object_id
+	LEFT JOIN sys.objects AS PARENT
+	ON PARENT.object_id = OBJ.parent_object_id
+	WHERE OBJ.type IN ('P', 'FN', 'IF', 'TF', 'TR') AND OBJ.is_ms_shipped = 0
+	ORDER BY SCH.name, OBJ.name
+	`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get routines: %w", err)
+	}
+	defer rows.Close()
+	var routines []schema.Routine
+	for rows.Next() {
+		var routine schema.Routine
+		var objType string
+		var body, table sql.NullString
+		var isInsteadOf, isInsert, isUpdate, isDelete sql.NullInt64
+		if err := rows.Scan(&routine.Schema, &routine.Name, &objType, &body, &table, &isInsteadOf, &isInsert, &isUpdate, &isDelete); err != nil {
+			return nil, fmt.Errorf("couldn't get routines: %w", err)
+		}
+		routine.Kind = routineKinds[objType]
+		// The definitions of encrypted routines are NULL.
+		routine.Body = body.String
+		if routine.Kind == schema.RoutineTrigger {
+			// Triggers on the database, rather than a table, have no parent.
+			routine.Table = table.String
+			routine.Timing = "AFTER"
+			if isInsteadOf.Int64 == 1 {
+				routine.Timing = "INSTEAD OF"
+			}
+			if isInsert.Int64 == 1 {
+				routine.Events = append(routine.Events, "INSERT")
+			}
+			if isUpdate.Int64 == 1 {
+				routine.Events = append(routine.Events, "UPDATE")
+			}
+			if isDelete.Int64 == 1 {
+				routine.Events = append(routine.Events, "DELETE")
+			}
+		}
+		routines = append(routines, routine)
+	}
+	return routines, rows.Err()
 }
 
 // GetColumns returns a list of Column objects and names
@@ -231,7 +363,7 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 		WHERE table_schema = @p1 and table_name = @p2 
 		ORDER BY ordinal_position;
 	`
-	cols, err := isi.Db.Query(q, table.Schema, table.Name)
+	cols, err := isi.Metadata.Query(isi.Db, common.MetadataColumns, table, q, table.Schema, table.Name)
 	if err != nil {
 		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
 	}
@@ -290,7 +422,7 @@ func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.Schem
 			ON t.CONSTRAINT_NAME = k.CONSTRAINT_NAME AND t.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
 		WHERE k.TABLE_SCHEMA = @p1 AND k.TABLE_NAME = @p2 ORDER BY k.ordinal_position;
 	`
-	rows, err := isi.Db.Query(q, table.Schema, table.Name)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataConstraints, table, q, table.Schema, table.Name)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -333,7 +465,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 	WHERE FK.parent_object_id = OBJECT_ID(@p1);
 	`
 
-	rows, err := isi.Db.Query(q, fmt.Sprintf("%s.%s", table.Schema, table.Name))
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataForeignKeys, table, q, fmt.Sprintf("%s.%s", table.Schema, table.Name))
 	if err != nil {
 		return nil, err
 	}
@@ -394,9 +526,10 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 			AND TAB.name=@p1
 			AND TAB.schema_id = SCHEMA_ID(@p2)
 			AND IX.type != 5 								 -- type=5 for clustered columnstore indexes
+			AND IX.type != 4 								 -- type=4 for spatial indexes
 			ORDER BY IX.name ;
 	`
-	rows, err := isi.Db.Query(q2, table.Name, table.Schema)
+	rows, err := isi.Metadata.Query(isi.Db, common.MetadataIndexes, table, q2, table.Name, table.Schema)
 	if err != nil {
 		return nil, err
 	}
@@ -434,6 +567,81 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 	return indexes, nil
 }
 
+// WithBulkMetadata implements the common.BulkMetadataSource interface. The
+// metadata of the tables of the database is fetched with the queries of
+// that of a table, without their filter on the table.
+func (isi InfoSchemaImpl) WithBulkMetadata(tables []common.SchemaAndName) (common.InfoSchema, error) {
+	queries := map[string]string{
+		common.MetadataColumns: `
+		SELECT
+			table_schema,
+			table_name,
+			column_name,
+			data_type,
+			is_nullable,
+			column_default,
+			character_maximum_length,
+			numeric_precision,
+			numeric_scale
+		FROM information_schema.COLUMNS
+		ORDER BY table_schema, table_name, ordinal_position;
+	`,
+		common.MetadataConstraints: `
+		SELECT
+			k.TABLE_SCHEMA,
+			k.TABLE_NAME,
+			k.COLUMN_NAME,
+			t.CONSTRAINT_TYPE
+		FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS t
+		INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS k
+			ON t.CONSTRAINT_NAME = k.CONSTRAINT_NAME AND t.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+		ORDER BY k.TABLE_SCHEMA, k.TABLE_NAME, k.ordinal_position;
+	`,
+		common.MetadataForeignKeys: `
+		SELECT
+			OBJECT_SCHEMA_NAME (FK.parent_object_id),
+			OBJECT_NAME (FK.parent_object_id),
+			OBJECT_SCHEMA_NAME (FK.referenced_object_id) AS [schema_name],
+			OBJECT_NAME (FK.referenced_object_id) AS [referenced_table],
+			COL_NAME(FKC.parent_object_id, FKC.parent_column_id) AS [column],
+			COL_NAME(FKC.referenced_object_id, FKC.referenced_column_id) AS [referenced_column],
+			FK.name AS [foreign_key_name]
+		FROM sys.foreign_keys AS FK
+		INNER JOIN sys.foreign_key_columns AS FKC
+		ON FK.object_id = FKC.constraint_object_id;
+	`,
+		common.MetadataIndexes: `
+		SELECT
+			SCHEMA_NAME(TAB.schema_id),
+			TAB.name,
+			IX.name,
+			COL_NAME(IX.object_id, IXC.column_id) as [Column Name],
+			IX.is_unique,
+			IXC.is_descending_key,
+			IXC.is_included_column
+		FROM sys.indexes IX
+		INNER JOIN sys.index_columns IXC
+			ON  IX.object_id = IXC.object_id AND IX.index_id = IXC.index_id
+		INNER JOIN sys.tables TAB
+			ON IX.object_id = TAB.object_id
+		WHERE
+			IX.is_primary_key = 0
+			AND IX.is_unique_constraint = 0
+			AND TAB.is_ms_shipped = 0
+			AND IX.type != 5 								 -- type=5 for clustered columnstore indexes
+			ORDER BY SCHEMA_NAME(TAB.schema_id), TAB.name, IX.name ;
+	`,
+	}
+	metadata := &common.BulkMetadata{}
+	for kind, q := range queries {
+		if err := metadata.Fetch(isi.Db, kind, q); err != nil {
+			return isi, err
+		}
+	}
+	isi.Metadata = metadata
+	return isi, nil
+}
+
 func toType(dataType string, charLen sql.NullInt64, numericPrecision, numericScale sql.NullInt64) schema.Type {
 	switch {
 	case charLen.Valid:
@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/mocks"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/stretchr/testify/assert"
@@ -265,7 +266,7 @@ func TestProcessSchema(t *testing.T) {
 		ExpressionVerificationAccessor: mockAccessor,
 		DdlV:                           &expressions_api.MockDDLVerifier{},
 	}
-	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{"test", db}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{DbName: "test", Db: db}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
 	assert.Nil(t, err)
 	expectedSchema := map[string]ddl.CreateTable{
 		"user": {
@@ -366,7 +367,7 @@ func TestProcessSchema(t *testing.T) {
 	testTableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "test")
 	assert.Equal(t, nil, err)
 	assert.Equal(t, len(conv.SchemaIssues[cartTableId].ColumnLevelIssues), 0)
-	assert.Equal(t, len(conv.SchemaIssues[testTableId].ColumnLevelIssues), 15)
+	assert.Equal(t, len(conv.SchemaIssues[testTableId].ColumnLevelIssues), 16)
 	assert.Equal(t, int64(0), conv.Unexpecteds())
 
 }
@@ -403,3 +404,40 @@ func stripSchemaComments(spSchema map[string]ddl.CreateTable) map[string]ddl.Cre
 	}
 	return spSchema
 }
+
+func TestGetViews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery("FROM sys.views AS VW").
+		WillReturnRows(sqlmock.NewRows([]string{"view_schema", "view_name", "definition"}).
+			AddRow("dbo", "active_users", "CREATE VIEW [dbo].[active_users]\nAS\nSELECT id FROM dbo.users WHERE active = 1").
+			AddRow("dbo", "secret", nil))
+	isi := InfoSchemaImpl{Db: db}
+	views, err := isi.GetViews()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []schema.View{
+		{Name: "active_users", Schema: "dbo", Query: "SELECT id FROM dbo.users WHERE active = 1"},
+		{Name: "secret", Schema: "dbo"},
+	}, views)
+}
+
+func TestGetRoutines(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery("FROM sys.objects AS OBJ").
+		WillReturnRows(sqlmock.NewRows([]string{"routine_schema", "routine_name", "routine_type", "definition", "table_name", "is_instead_of", "is_insert", "is_update", "is_delete"}).
+			AddRow("dbo", "archive_orders", "P", "CREATE PROCEDURE archive_orders AS DELETE FROM orders", nil, nil, nil, nil, nil).
+			AddRow("dbo", "audit_orders", "TR", "CREATE TRIGGER audit_orders ON orders AFTER INSERT, DELETE AS INSERT INTO audit SELECT id FROM inserted", "orders", 0, 1, 0, 1).
+			AddRow("dbo", "discount", "FN", nil, nil, nil, nil, nil, nil))
+	isi := InfoSchemaImpl{Db: db}
+	routines, err := isi.GetRoutines()
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []schema.Routine{
+		{Name: "archive_orders", Schema: "dbo", Kind: schema.RoutineProcedure, Body: "CREATE PROCEDURE archive_orders AS DELETE FROM orders"},
+		{Name: "audit_orders", Schema: "dbo", Kind: schema.RoutineTrigger, Table: "orders", Timing: "AFTER", Events: []string{"INSERT", "DELETE"},
+			Body: "CREATE TRIGGER audit_orders ON orders AFTER INSERT, DELETE AS INSERT INTO audit SELECT id FROM inserted"},
+		{Name: "discount", Schema: "dbo", Kind: schema.RoutineFunction},
+	}, routines)
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+// System-versioned temporal tables keep the previous versions of their rows
+// in a history table, with the period of validity of each version in two
+// period columns, which may be hidden. The current tables are migrated as
+// regular tables, and their history tables only if the source profile says
+// so, since Spanner doesn't version rows.
+
+// systemVersionedTable is a system-versioned temporal table and its history
+// table.
+type systemVersionedTable struct {
+	table       common.SchemaAndName
+	history     common.SchemaAndName
+	periodStart string
+	periodEnd   string
+}
+
+// getSystemVersionedTables returns the system-versioned temporal tables of
+// the database. Versions of SQL Server without temporal tables, before
+// 2016, return an error.
+func (isi InfoSchemaImpl) getSystemVersionedTables() ([]systemVersionedTable, error) {
+	q := `
+	SELECT
+		SCH.name,
+		TBL.name,
+		HSCH.name,
+		HTBL.name,
+		COL_NAME(PER.object_id, PER.start_column_id),
+		COL_NAME(PER.object_id, PER.end_column_id)
+	FROM sys.tables AS TBL
+	INNER JOIN sys.schemas AS SCH
+		ON SCH.schema_id = TBL.schema_id
+	INNER JOIN sys.tables AS HTBL
+		ON HTBL.object_id = TBL.history_table_id
+	INNER JOIN sys.schemas AS HSCH
+		ON HSCH.schema_id = HTBL.schema_id
+	INNER JOIN sys.periods AS PER
+		ON PER.object_id = TBL.object_id
+	WHERE TBL.temporal_type = 2
+	`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get system-versioned tables: %w", err)
+	}
+	defer rows.Close()
+	var tables []systemVersionedTable
+	for rows.Next() {
+		var t systemVersionedTable
+		if err := rows.Scan(&t.table.Schema, &t.table.Name, &t.history.Schema, &t.history.Name, &t.periodStart, &t.periodEnd); err != nil {
+			return nil, fmt.Errorf("couldn't scan system-versioned table: %w", err)
+		}
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+// withoutHistoryTables returns tables without the history tables of
+// system-versioned tables.
+func (isi InfoSchemaImpl) withoutHistoryTables(tables []common.SchemaAndName) []common.SchemaAndName {
+	versioned, err := isi.getSystemVersionedTables()
+	if err != nil {
+		logger.Log.Debug(fmt.Sprintf("assuming there are no system-versioned tables: %v", err))
+		return tables
+	}
+	history := make(map[common.SchemaAndName]bool)
+	for _, t := range versioned {
+		history[t.history] = true
+	}
+	var filtered []common.SchemaAndName
+	for _, t := range tables {
+		if !history[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// GetTemporalTables implements the common.TemporalTableSource interface.
+func (isi InfoSchemaImpl) GetTemporalTables(tables []common.SchemaAndName) (map[common.SchemaAndName]common.TemporalTable, error) {
+	versioned, err := isi.getSystemVersionedTables()
+	if err != nil {
+		return nil, err
+	}
+	included := make(map[common.SchemaAndName]bool)
+	for _, t := range tables {
+		included[common.SchemaAndName{Schema: t.Schema, Name: t.Name}] = true
+	}
+	temporalTables := make(map[common.SchemaAndName]common.TemporalTable)
+	for _, t := range versioned {
+		if included[t.table] {
+			temporalTables[t.table] = common.TemporalTable{
+				HistoryTable: isi.GetTableName(t.history.Schema, t.history.Name),
+				PeriodStart:  t.periodStart,
+				PeriodEnd:    t.periodEnd,
+			}
+		}
+		// History tables have the period columns of their table.
+		if included[t.history] {
+			temporalTables[t.history] = common.TemporalTable{
+				CurrentTable: isi.GetTableName(t.table.Schema, t.table.Name),
+				PeriodStart:  t.periodStart,
+				PeriodEnd:    t.periodEnd,
+			}
+		}
+	}
+	return temporalTables, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+)
+
+func mockSystemVersionedTables(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("WHERE TBL.temporal_type = 2").
+		WillReturnRows(sqlmock.NewRows([]string{"schema", "table", "history_schema", "history_table", "start", "end"}).
+			AddRow("dbo", "employee", "history", "employee_history", "valid_from", "valid_to"))
+}
+
+func TestGetTablesWithTemporalTables(t *testing.T) {
+	tablesQuery := "WHERE TBL.type = 'U'"
+	tables := sqlmock.NewRows([]string{"table_schema", "table_name"}).
+		AddRow("dbo", "employee").
+		AddRow("history", "employee_history").
+		AddRow("dbo", "dept")
+
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(tablesQuery).WillReturnRows(tables)
+	mockSystemVersionedTables(mock)
+	got, err := InfoSchemaImpl{Db: db}.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "dbo", Name: "employee"}, {Schema: "dbo", Name: "dept"}}, got)
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	// History tables are migrated with temporalHistory=true.
+	db, mock, err = sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(tablesQuery).WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name"}).
+		AddRow("dbo", "employee").
+		AddRow("history", "employee_history"))
+	sourceProfile := profiles.SourceProfile{Conn: profiles.SourceProfileConnection{SqlServer: profiles.SourceProfileConnectionSqlServer{TemporalHistory: true}}}
+	got, err = InfoSchemaImpl{Db: db, SourceProfile: sourceProfile}.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "dbo", Name: "employee"}, {Schema: "history", Name: "employee_history"}}, got)
+
+	// Versions of SQL Server without temporal tables.
+	db, mock, err = sqlmock.New()
+	assert.Nil(t, err)
+	mock.ExpectQuery(tablesQuery).WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name"}).AddRow("dbo", "dept"))
+	mock.ExpectQuery("WHERE TBL.temporal_type = 2").WillReturnError(fmt.Errorf("Invalid column name 'temporal_type'"))
+	got, err = InfoSchemaImpl{Db: db}.GetTables()
+	assert.Nil(t, err)
+	assert.Equal(t, []common.SchemaAndName{{Schema: "dbo", Name: "dept"}}, got)
+}
+
+func TestGetTemporalTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mockSystemVersionedTables(mock)
+	got, err := InfoSchemaImpl{Db: db}.GetTemporalTables([]common.SchemaAndName{
+		{Schema: "dbo", Name: "employee"},
+		{Schema: "history", Name: "employee_history"},
+		{Schema: "dbo", Name: "dept"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[common.SchemaAndName]common.TemporalTable{
+		{Schema: "dbo", Name: "employee"}:             {HistoryTable: "history.employee_history", PeriodStart: "valid_from", PeriodEnd: "valid_to"},
+		{Schema: "history", Name: "employee_history"}: {CurrentTable: "employee", PeriodStart: "valid_from", PeriodEnd: "valid_to"},
+	}, got)
+}
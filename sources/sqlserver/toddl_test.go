@@ -227,7 +227,7 @@ func TestToSpannerType(t *testing.T) {
 			"c1":  {internal.Widened},
 			"c3":  {internal.Widened},
 			"c10": {internal.Timestamp},
-			"c13": {internal.NoGoodType},
+			"c13": {internal.Spatial},
 		},
 	}
 	assert.Equal(t, expectedIssues, conv.SchemaIssues[tableId])
@@ -341,7 +341,7 @@ func TestToSpannerPostgreSQLDialectType(t *testing.T) {
 			"c1":  {internal.Widened},
 			"c3":  {internal.Widened},
 			"c10": {internal.Timestamp},
-			"c13": {internal.NoGoodType},
+			"c13": {internal.Spatial},
 		},
 	}
 	assert.Equal(t, expectedIssues, conv.SchemaIssues[tableId])
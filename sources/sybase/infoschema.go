@@ -0,0 +1,461 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sybase handles schema and data migrations from SAP ASE (Sybase
+// Adaptive Server Enterprise).
+//
+// ASE has no information_schema: the schema is read from the system tables
+// of the database (sysobjects, syscolumns, systypes, sysindexes and
+// sysreferences). ASE and SQL Server share their Transact-SQL heritage, so
+// types they have in common are mapped, and their data converted, as they
+// are for SQL Server. Data is read with a FreeTDS compatible driver, which
+// must be registered under DriverName.
+package sybase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	sp "cloud.google.com/go/spanner"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/sqlserver"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// DriverName is the database/sql driver name ASE databases are opened
+// with.
+const DriverName = "tds"
+
+// Bits of syscolumns.status and sysindexes.status.
+const (
+	colNullable   = 0x08
+	colIdentity   = 0x80
+	indexUnique   = 0x02
+	indexPrimary  = 0x800
+	maxForeignKey = 16 // Maximum number of columns of a foreign key.
+)
+
+// textSize is the maximum size of text, unitext and image values returned
+// by the server, which truncates them to 32KB by default.
+const textSize = 2147483647
+
+// InfoSchemaImpl is Sybase ASE specific implementation for InfoSchema.
+type InfoSchemaImpl struct {
+	DbName string
+	Db     *sql.DB
+}
+
+// GetToDdl implement the common.InfoSchema interface.
+func (isi InfoSchemaImpl) GetToDdl() common.ToDdl {
+	return ToDdlImpl{}
+}
+
+// StartChangeDataCapture is not supported: ASE has no change data capture
+// that Datastream can read, so it can only be migrated with bulk migrations.
+func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("streaming migrations are not supported for Sybase ASE")
+}
+
+// StartStreamingMigration is not supported: ASE can only be migrated with
+// bulk migrations.
+func (isi InfoSchemaImpl) StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamingInfo map[string]interface{}) (internal.DataflowOutput, error) {
+	return internal.DataflowOutput{}, fmt.Errorf("streaming migrations are not supported for Sybase ASE")
+}
+
+// GetTableName returns table name.
+func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
+	if schema == "dbo" { // Drop 'dbo' prefix.
+		return tableName
+	}
+	return fmt.Sprintf("%s.%s", schema, tableName)
+}
+
+// ProcessData performs data conversion for source database
+// 'db'. For each table, we extract data using a "SELECT *" query,
+// convert the data to Spanner data (based on the source and Spanner
+// schemas), and write it to Spanner.  If we can't get/process data
+// for a table, we skip that table and process the remaining tables.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	srcTableName := conv.SrcSchema[tableId].Name
+	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTableName, err))
+		return err
+	}
+	rows := rowsInterface.(*sql.Rows)
+	defer rows.Close()
+	srcCols, _ := rows.Columns()
+	v, scanArgs := buildVals(len(srcCols))
+	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
+	for rows.Next() {
+		err := rows.Scan(scanArgs...)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't process sql data row: %s", err))
+			// Scan failed, so we don't have any data to add to bad rows.
+			conv.StatsAddBadRow(srcTableName, conv.DataMode())
+			continue
+		}
+		values := valsToStrings(v)
+		newValues, err := common.PrepareValues(conv, tableId, colNameIdMap, commonColIds, srcCols, values)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+			conv.StatsAddBadRow(srcTableName, conv.DataMode())
+			conv.CollectBadRow(srcTableName, srcCols, values)
+			continue
+		}
+		sqlserver.ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, newValues)
+	}
+	return nil
+}
+
+// GetRowsFromTable returns a sql Rows object for a table.
+func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
+	tbl := conv.SrcSchema[tableId]
+	tblName := strings.TrimPrefix(tbl.Name, tbl.Schema+".")
+	q := getSelectQuery(tbl.Schema, tblName, tbl.ColIds, tbl.ColDefs)
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// getSelectQuery returns the query the rows of a table are read with.
+// Values whose driver representation isn't a valid input of the SQL Server
+// data conversion are converted to strings by the server: datetime types
+// to ISO 8601, and exact numerics to their decimal representation. Money
+// values are converted to numeric first, since converting them to strings
+// rounds them to 2 decimal places.
+func getSelectQuery(schemaName string, tableName string, colIds []string, colDefs map[string]schema.Column) string {
+	var selects = make([]string, len(colIds))
+	for i, colId := range colIds {
+		cn := colDefs[colId].Name
+		var s string
+		switch colDefs[colId].Type.Name {
+		case "datetime", "smalldatetime", "bigdatetime":
+			s = fmt.Sprintf("str_replace(convert(varchar(26), [%s], 140), ' ', 'T') AS [%s]", cn, cn)
+		case "date":
+			s = fmt.Sprintf("convert(char(10), [%s], 23) AS [%s]", cn, cn)
+		case "time", "bigtime":
+			s = fmt.Sprintf("substring(convert(varchar(26), [%s], 140), 12, 15) AS [%s]", cn, cn)
+		case "money", "smallmoney":
+			s = fmt.Sprintf("convert(varchar(40), convert(numeric(19,4), [%s])) AS [%s]", cn, cn)
+		case "numeric", "decimal":
+			s = fmt.Sprintf("convert(varchar(40), [%s]) AS [%s]", cn, cn)
+		default:
+			s = fmt.Sprintf("[%s]", cn)
+		}
+		selects[i] = s
+	}
+	return fmt.Sprintf("SET TEXTSIZE %d SELECT %s FROM [%s].[%s]", textSize, strings.Join(selects, ", "), schemaName, tableName)
+}
+
+// buildVals contructs interface{} value containers to scan row
+// results into.  Returns both the underlying containers (as a slice)
+// as well as an interface{} of pointers to containers to pass to
+// rows.Scan.
+func buildVals(n int) (v []interface{}, iv []interface{}) {
+	v = make([]interface{}, n)
+	for i := range v {
+		iv = append(iv, &v[i])
+	}
+	return v, iv
+}
+
+// GetRowCount with number of rows in each table.
+func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
+	q := fmt.Sprintf(`SELECT COUNT(1) FROM [%s].[%s]`, table.Schema, table.Name)
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var count int64
+	if rows.Next() {
+		err := rows.Scan(&count)
+		return count, err
+	}
+	return 0, nil
+}
+
+// GetTables return list of user tables in the selected database.
+func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
+	q := `SELECT user_name(o.uid), o.name FROM sysobjects o WHERE o.type = 'U' ORDER BY 1, 2`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tables: %w", err)
+	}
+	defer rows.Close()
+	var tableSchema, tableName string
+	var tables []common.SchemaAndName
+	for rows.Next() {
+		rows.Scan(&tableSchema, &tableName)
+		tables = append(tables, common.SchemaAndName{Schema: tableSchema, Name: tableName})
+	}
+	return tables, nil
+}
+
+// GetColumns returns a list of Column objects and names. The type of user
+// defined types (whose usertype is 100 or more) is the system type they are
+// defined with.
+func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
+	q := `SELECT c.name, t.name, b.name, t.usertype, c.length, c.prec, c.scale, c.status, c.cdefault
+              FROM syscolumns c
+                JOIN systypes t ON t.usertype = c.usertype
+                JOIN systypes b ON b.usertype = (SELECT MIN(u.usertype) FROM systypes u WHERE u.type = t.type)
+              WHERE c.id = object_id(?)
+              ORDER BY c.colid`
+	cols, err := isi.Db.Query(q, fmt.Sprintf("%s.%s", table.Schema, table.Name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get schema for table %s.%s: %s", table.Schema, table.Name, err)
+	}
+	defer cols.Close()
+	colDefs := make(map[string]schema.Column)
+	var colIds []string
+	var colName, typeName, baseTypeName string
+	var userType, length, status, colDefault int64
+	var prec, scale sql.NullInt64
+	for cols.Next() {
+		err := cols.Scan(&colName, &typeName, &baseTypeName, &userType, &length, &prec, &scale, &status, &colDefault)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		if userType >= 100 {
+			typeName = baseTypeName
+		}
+		ignored := schema.Ignored{}
+		for _, c := range constraints[colName] {
+			if c == "CHECK" {
+				ignored.Check = true
+			}
+		}
+		ignored.Default = colDefault != 0
+		ignored.AutoIncrement = status&colIdentity != 0
+		colId := internal.GenerateColumnId()
+		c := schema.Column{
+			Id:      colId,
+			Name:    colName,
+			Type:    toType(typeName, length, prec, scale),
+			NotNull: status&colNullable == 0,
+			Ignored: ignored,
+		}
+		colDefs[colId] = c
+		colIds = append(colIds, colId)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetConstraints returns a list of primary keys and by-column map of
+// other constraints. The primary key of a table is the index created for
+// its PRIMARY KEY constraint.
+func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) ([]string, []schema.CheckConstraint, map[string][]string, error) {
+	indexCols, err := isi.getIndexColumns(conv, table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var primaryKeys []string
+	for _, ic := range indexCols {
+		if ic.status&indexPrimary != 0 {
+			primaryKeys = append(primaryKeys, ic.column)
+		}
+	}
+	return primaryKeys, nil, map[string][]string{}, nil
+}
+
+// GetForeignKeys returns a list of all the foreign key constraints. The
+// columns of a foreign key are stored in the fokeyN and refkeyN columns of
+// sysreferences. Foreign keys referencing tables of other databases are
+// skipped. ASE has no referential actions: rows referenced by other rows
+// can't be deleted or updated, as with NO ACTION.
+func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
+	var keyCols []string
+	for i := 1; i <= maxForeignKey; i++ {
+		keyCols = append(keyCols, fmt.Sprintf("col_name(r.tableid, r.fokey%d), col_name(r.reftabid, r.refkey%d)", i, i))
+	}
+	q := fmt.Sprintf(`SELECT object_name(r.constrid), user_name(o.uid), o.name, r.keycnt, %s
+              FROM sysreferences r
+                JOIN sysobjects o ON o.id = r.reftabid
+              WHERE r.tableid = object_id(?) AND r.pmrydbname IS NULL
+              ORDER BY 1`, strings.Join(keyCols, ", "))
+	rows, err := isi.Db.Query(q, fmt.Sprintf("%s.%s", table.Schema, table.Name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var name string
+	var refTable common.SchemaAndName
+	var keyCnt int
+	keys := make([]sql.NullString, 2*maxForeignKey)
+	scanArgs := []interface{}{&name, &refTable.Schema, &refTable.Name, &keyCnt}
+	for i := range keys {
+		scanArgs = append(scanArgs, &keys[i])
+	}
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		fk := schema.ForeignKey{
+			Id:             internal.GenerateForeignkeyId(),
+			Name:           name,
+			ReferTableName: isi.GetTableName(refTable.Schema, refTable.Name),
+			OnDelete:       constants.FK_NO_ACTION,
+			OnUpdate:       constants.FK_NO_ACTION,
+		}
+		for i := 0; i < keyCnt && i < maxForeignKey; i++ {
+			fk.ColumnNames = append(fk.ColumnNames, keys[2*i].String)
+			fk.ReferColumnNames = append(fk.ReferColumnNames, keys[2*i+1].String)
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+	return foreignKeys, nil
+}
+
+// GetIndexes return a list of all indexes for the specified table, but for
+// the index of its primary key.
+func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	indexCols, err := isi.getIndexColumns(conv, table)
+	if err != nil {
+		return nil, err
+	}
+	indexMap := make(map[string]schema.Index)
+	var indexNames []string
+	for _, ic := range indexCols {
+		if ic.status&indexPrimary != 0 {
+			continue
+		}
+		if _, found := indexMap[ic.name]; !found {
+			indexNames = append(indexNames, ic.name)
+			indexMap[ic.name] = schema.Index{
+				Id:     internal.GenerateIndexesId(),
+				Name:   ic.name,
+				Unique: ic.status&indexUnique != 0}
+		}
+		index := indexMap[ic.name]
+		index.Keys = append(index.Keys, schema.Key{ColId: colNameIdMap[ic.column], Desc: ic.order == "DESC"})
+		indexMap[ic.name] = index
+	}
+	sort.Strings(indexNames)
+	var indexes []schema.Index
+	for _, k := range indexNames {
+		indexes = append(indexes, indexMap[k])
+	}
+	return indexes, nil
+}
+
+// indexColumn is a key column of an index.
+type indexColumn struct {
+	name   string
+	column string
+	order  string // ASC or DESC.
+	status int64  // sysindexes.status of the index.
+}
+
+// getIndexColumns returns the key columns of the indexes of a table, in
+// index and key order. sysindexes rows with indid 0 (the table itself) and
+// 255 (text and image data) aren't indexes. Key columns are numbered from
+// 1 to keycnt, which also counts the row id of nonclustered indexes, for
+// which index_col returns NULL.
+func (isi InfoSchemaImpl) getIndexColumns(conv *internal.Conv, table common.SchemaAndName) ([]indexColumn, error) {
+	q := `SELECT i.name, index_col(o.name, i.indid, k.colid, o.uid), index_colorder(o.name, i.indid, k.colid, o.uid), i.status
+              FROM sysindexes i
+                JOIN sysobjects o ON o.id = i.id
+                JOIN syscolumns k ON k.id = i.id AND k.colid <= i.keycnt
+              WHERE i.id = object_id(?) AND i.indid > 0 AND i.indid < 255
+                AND index_col(o.name, i.indid, k.colid, o.uid) IS NOT NULL
+              ORDER BY i.indid, k.colid`
+	rows, err := isi.Db.Query(q, fmt.Sprintf("%s.%s", table.Schema, table.Name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var indexCols []indexColumn
+	for rows.Next() {
+		var ic indexColumn
+		if err := rows.Scan(&ic.name, &ic.column, &ic.order, &ic.status); err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		indexCols = append(indexCols, ic)
+	}
+	return indexCols, nil
+}
+
+// toType maps a column of syscolumns to a schema.Type. Nullable columns of
+// fixed length types have types of their own (e.g. intn), from which the
+// declared type is derived using the length of the column. The mods of
+// exact numerics are their precision and scale, and those of character and
+// binary types their length.
+func toType(typeName string, length int64, prec, scale sql.NullInt64) schema.Type {
+	switch typeName {
+	case "intn":
+		typeName = map[int64]string{1: "tinyint", 2: "smallint", 4: "int", 8: "bigint"}[length]
+	case "uintn":
+		typeName = map[int64]string{1: "unsigned tinyint", 2: "unsigned smallint", 4: "unsigned int", 8: "unsigned bigint"}[length]
+	case "floatn":
+		typeName = map[int64]string{4: "real", 8: "float"}[length]
+	case "moneyn":
+		typeName = map[int64]string{4: "smallmoney", 8: "money"}[length]
+	case "datetimn":
+		typeName = map[int64]string{4: "smalldatetime", 8: "datetime"}[length]
+	case "daten":
+		typeName = "date"
+	case "timen":
+		typeName = "time"
+	case "decimaln":
+		typeName = "decimal"
+	case "numericn":
+		typeName = "numeric"
+	}
+	switch typeName {
+	case "numeric", "decimal":
+		if prec.Valid && scale.Valid {
+			return schema.Type{Name: typeName, Mods: []int64{prec.Int64, scale.Int64}}
+		}
+	case "unichar", "univarchar":
+		// Lengths of unichar columns are in bytes, of 2 per character.
+		return schema.Type{Name: typeName, Mods: []int64{length / 2}}
+	case "char", "varchar", "nchar", "nvarchar", "binary", "varbinary", "sysname", "longsysname":
+		return schema.Type{Name: typeName, Mods: []int64{length}}
+	}
+	return schema.Type{Name: typeName}
+}
+
+func valsToStrings(vals []interface{}) []string {
+	toString := func(val interface{}) string {
+		if val == nil {
+			return "NULL"
+		}
+		switch v := val.(type) {
+		case []uint8:
+			val = string([]byte(v))
+		case *interface{}:
+			val = *v
+		}
+		return fmt.Sprintf("%v", val)
+	}
+	var s []string
+	for _, v := range vals {
+		s = append(s, toString(v))
+	}
+	return s
+}
@@ -0,0 +1,315 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sybase
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type mockSpec struct {
+	query string
+	args  []driver.Value   // Query args.
+	cols  []string         // Columns names for returned rows.
+	rows  [][]driver.Value // Set of rows returned.
+}
+
+var (
+	indexCols  = []string{"name", "column", "order", "status"}
+	columnCols = []string{"name", "type", "base_type", "usertype", "length", "prec", "scale", "status", "cdefault"}
+)
+
+// fkCols returns the columns of the sysreferences query.
+func fkCols() []string {
+	cols := []string{"name", "ref_schema", "ref_table", "keycnt"}
+	for i := 0; i < 2*maxForeignKey; i++ {
+		cols = append(cols, "key")
+	}
+	return cols
+}
+
+// fkRow returns a row of the sysreferences query for a foreign key of
+// cols, referencing refCols.
+func fkRow(name, refSchema, refTable string, cols, refCols []string) []driver.Value {
+	row := []driver.Value{name, refSchema, refTable, int64(len(cols))}
+	for i := 0; i < maxForeignKey; i++ {
+		if i < len(cols) {
+			row = append(row, cols[i], refCols[i])
+		} else {
+			row = append(row, nil, nil)
+		}
+	}
+	return row
+}
+
+func TestProcessSchema(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SELECT user_name(o.uid), o.name FROM sysobjects o WHERE o.type = 'U'"),
+			cols:  []string{"schema", "name"},
+			rows:  [][]driver.Value{{"dbo", "customers"}, {"sales", "orders"}},
+		},
+		{
+			query: "SELECT (.+) FROM sysindexes (.+)",
+			args:  []driver.Value{"dbo.customers"},
+			cols:  indexCols,
+			rows:  [][]driver.Value{{"customers_pk", "id", "ASC", int64(indexPrimary | indexUnique)}},
+		},
+		{
+			query: "SELECT (.+) FROM sysreferences (.+)",
+			args:  []driver.Value{"dbo.customers"},
+			cols:  fkCols(),
+		},
+		{
+			query: "SELECT (.+) FROM syscolumns (.+)",
+			args:  []driver.Value{"dbo.customers"},
+			cols:  columnCols,
+			rows: [][]driver.Value{
+				{"id", "numeric", "numeric", int64(10), int64(5), int64(10), int64(0), int64(colIdentity), int64(0)},
+				{"name", "univarchar", "univarchar", int64(35), int64(200), nil, nil, int64(colNullable), int64(0)},
+				{"notes", "text", "text", int64(19), int64(16), nil, nil, int64(colNullable), int64(0)},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM sysindexes (.+)",
+			args:  []driver.Value{"dbo.customers"},
+			cols:  indexCols,
+			rows:  [][]driver.Value{{"customers_pk", "id", "ASC", int64(indexPrimary | indexUnique)}},
+		},
+		{
+			query: "SELECT (.+) FROM sysindexes (.+)",
+			args:  []driver.Value{"sales.orders"},
+			cols:  indexCols,
+			rows: [][]driver.Value{
+				{"orders_pk", "id", "ASC", int64(indexPrimary | indexUnique)},
+				{"orders_created", "created", "DESC", int64(0)},
+				{"orders_created", "customer_id", "ASC", int64(0)},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM sysreferences (.+)",
+			args:  []driver.Value{"sales.orders"},
+			cols:  fkCols(),
+			rows:  [][]driver.Value{fkRow("orders_customers_fk", "dbo", "customers", []string{"customer_id"}, []string{"id"})},
+		},
+		{
+			query: "SELECT (.+) FROM syscolumns (.+)",
+			args:  []driver.Value{"sales.orders"},
+			cols:  columnCols,
+			rows: [][]driver.Value{
+				{"id", "int", "int", int64(7), int64(4), nil, nil, int64(0), int64(0)},
+				{"customer_id", "numericn", "numericn", int64(28), int64(5), int64(10), int64(0), int64(colNullable), int64(0)},
+				{"amount", "moneyn", "moneyn", int64(17), int64(8), nil, nil, int64(colNullable), int64(0)},
+				{"created", "datetime", "datetime", int64(12), int64(8), nil, nil, int64(0), int64(42)},
+				// status is of a user defined type, based on varchar(10).
+				{"status", "status_t", "varchar", int64(100), int64(10), nil, nil, int64(colNullable), int64(0)},
+				{"receipt", "image", "image", int64(20), int64(16), nil, nil, int64(colNullable), int64(0)},
+				{"version", "timestamp", "varbinary", int64(80), int64(8), nil, nil, int64(colNullable), int64(0)},
+			},
+		},
+		{
+			query: "SELECT (.+) FROM sysindexes (.+)",
+			args:  []driver.Value{"sales.orders"},
+			cols:  indexCols,
+			rows: [][]driver.Value{
+				{"orders_pk", "id", "ASC", int64(indexPrimary | indexUnique)},
+				{"orders_created", "created", "DESC", int64(0)},
+				{"orders_created", "customer_id", "ASC", int64(0)},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	conv := internal.MakeConv()
+	processSchema := common.ProcessSchemaImpl{}
+	schemaToSpanner := common.SchemaToSpannerImpl{
+		DdlV: &expressions_api.MockDDLVerifier{},
+	}
+	err := processSchema.ProcessSchema(conv, InfoSchemaImpl{"test", db}, 1, internal.AdditionalSchemaAttributes{}, &schemaToSpanner, &common.UtilsOrderImpl{}, &common.InfoSchemaImpl{})
+	assert.Nil(t, err)
+	expectedSchema := map[string]ddl.CreateTable{
+		"customers": {
+			Name:   "customers",
+			ColIds: []string{"id", "name", "notes"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":    {Name: "id", T: ddl.Type{Name: ddl.Numeric}, NotNull: true},
+				"name":  {Name: "name", T: ddl.Type{Name: ddl.String, Len: 100}},
+				"notes": {Name: "notes", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}},
+		},
+		"sales_orders": {
+			Name:   "sales_orders",
+			ColIds: []string{"id", "customer_id", "amount", "created", "status", "receipt", "version"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"id":          {Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"customer_id": {Name: "customer_id", T: ddl.Type{Name: ddl.Numeric}},
+				"amount":      {Name: "amount", T: ddl.Type{Name: ddl.Numeric}},
+				"created":     {Name: "created", T: ddl.Type{Name: ddl.Timestamp}, NotNull: true},
+				"status":      {Name: "status", T: ddl.Type{Name: ddl.String, Len: 10}},
+				"receipt":     {Name: "receipt", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+				"version":     {Name: "version", T: ddl.Type{Name: ddl.Bytes, Len: 8}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "id", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "orders_customers_fk", ColIds: []string{"customer_id"}, ReferTableId: "customers", ReferColumnIds: []string{"id"}, OnDelete: constants.FK_NO_ACTION, OnUpdate: constants.FK_NO_ACTION}},
+			Indexes:     []ddl.CreateIndex{{Name: "orders_created", TableId: "sales_orders", Keys: []ddl.IndexKey{{ColId: "created", Desc: true, Order: 1}, {ColId: "customer_id", Order: 2}}}},
+		},
+	}
+	internal.AssertSpSchema(conv, t, expectedSchema, conv.SpSchema)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+
+	customersTableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "customers")
+	idColId, _ := internal.GetColIdFromSpName(conv.SpSchema[customersTableId].ColDefs, "id")
+	assert.Contains(t, conv.SchemaIssues[customersTableId].ColumnLevelIssues[idColId], internal.AutoIncrement)
+	notesColId, _ := internal.GetColIdFromSpName(conv.SpSchema[customersTableId].ColDefs, "notes")
+	assert.Equal(t, []internal.SchemaIssue{internal.LargeObject}, conv.SchemaIssues[customersTableId].ColumnLevelIssues[notesColId])
+	ordersTableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, "sales_orders")
+	amountColId, _ := internal.GetColIdFromSpName(conv.SpSchema[ordersTableId].ColDefs, "amount")
+	assert.Equal(t, []internal.SchemaIssue{internal.Money}, conv.SchemaIssues[ordersTableId].ColumnLevelIssues[amountColId])
+	createdColId, _ := internal.GetColIdFromSpName(conv.SpSchema[ordersTableId].ColDefs, "created")
+	assert.Contains(t, conv.SchemaIssues[ordersTableId].ColumnLevelIssues[createdColId], internal.DefaultValue)
+}
+
+func TestToType(t *testing.T) {
+	testCases := []struct {
+		typeName string
+		length   int64
+		prec     sql.NullInt64
+		scale    sql.NullInt64
+		want     schema.Type
+	}{
+		{typeName: "intn", length: 2, want: schema.Type{Name: "smallint"}},
+		{typeName: "uintn", length: 8, want: schema.Type{Name: "unsigned bigint"}},
+		{typeName: "floatn", length: 4, want: schema.Type{Name: "real"}},
+		{typeName: "datetimn", length: 4, want: schema.Type{Name: "smalldatetime"}},
+		{typeName: "moneyn", length: 4, want: schema.Type{Name: "smallmoney"}},
+		{typeName: "daten", length: 4, want: schema.Type{Name: "date"}},
+		{typeName: "decimaln", length: 9, prec: sql.NullInt64{Int64: 18, Valid: true}, scale: sql.NullInt64{Int64: 4, Valid: true}, want: schema.Type{Name: "decimal", Mods: []int64{18, 4}}},
+		{typeName: "unichar", length: 20, want: schema.Type{Name: "unichar", Mods: []int64{10}}},
+		{typeName: "varbinary", length: 255, want: schema.Type{Name: "varbinary", Mods: []int64{255}}},
+		{typeName: "bit", length: 1, want: schema.Type{Name: "bit"}},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, toType(tc.typeName, tc.length, tc.prec, tc.scale), tc.typeName)
+	}
+}
+
+func TestGetSelectQuery(t *testing.T) {
+	colDefs := map[string]schema.Column{
+		"c1": {Name: "id", Type: schema.Type{Name: "int"}},
+		"c2": {Name: "created", Type: schema.Type{Name: "datetime"}},
+		"c3": {Name: "day", Type: schema.Type{Name: "date"}},
+		"c4": {Name: "at", Type: schema.Type{Name: "bigtime"}},
+		"c5": {Name: "amount", Type: schema.Type{Name: "money"}},
+		"c6": {Name: "price", Type: schema.Type{Name: "numeric", Mods: []int64{10, 2}}},
+	}
+	q := getSelectQuery("dbo", "orders", []string{"c1", "c2", "c3", "c4", "c5", "c6"}, colDefs)
+	assert.Equal(t, "SET TEXTSIZE 2147483647 SELECT [id], "+
+		"str_replace(convert(varchar(26), [created], 140), ' ', 'T') AS [created], "+
+		"convert(char(10), [day], 23) AS [day], "+
+		"substring(convert(varchar(26), [at], 140), 12, 15) AS [at], "+
+		"convert(varchar(40), convert(numeric(19,4), [amount])) AS [amount], "+
+		"convert(varchar(40), [price]) AS [price] "+
+		"FROM [dbo].[orders]", q)
+}
+
+func TestProcessData(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SetDataMode()
+	tableId := "t1"
+	conv.SrcSchema[tableId] = schema.Table{
+		Id:     tableId,
+		Name:   "orders",
+		Schema: "dbo",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "int"}},
+			"c2": {Id: "c2", Name: "created", Type: schema.Type{Name: "datetime"}},
+			"c3": {Id: "c3", Name: "amount", Type: schema.Type{Name: "money"}},
+			"c4": {Id: "c4", Name: "receipt", Type: schema.Type{Name: "image"}},
+		},
+	}
+	conv.SpSchema[tableId] = ddl.CreateTable{
+		Id:     tableId,
+		Name:   "orders",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Id: "c1", Name: "id", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Id: "c2", Name: "created", T: ddl.Type{Name: ddl.Timestamp}},
+			"c3": {Id: "c3", Name: "amount", T: ddl.Type{Name: ddl.Numeric}},
+			"c4": {Id: "c4", Name: "receipt", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+		},
+	}
+	ms := []mockSpec{
+		{
+			query: regexp.QuoteMeta("SET TEXTSIZE 2147483647 SELECT [id], ") + "(.+)" + regexp.QuoteMeta("FROM [dbo].[orders]"),
+			cols:  []string{"id", "created", "amount", "receipt"},
+			rows: [][]driver.Value{
+				{int64(1), "2024-01-02T03:04:05.123456", "12.5000", []byte{0xca, 0xfe}},
+				{int64(2), nil, nil, nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	var rows [][]interface{}
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, vals)
+		})
+	isi := InfoSchemaImpl{"test", db}
+	err := isi.ProcessData(conv, tableId, conv.SrcSchema[tableId], conv.SrcSchema[tableId].ColIds, conv.SpSchema[tableId], internal.AdditionalDataAttributes{})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{
+		{int64(1), time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), big.NewRat(25, 2), []byte{0xca, 0xfe}},
+		{int64(2)},
+	}, rows)
+	assert.Equal(t, int64(0), conv.BadRows())
+}
+
+func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	for _, m := range ms {
+		rows := sqlmock.NewRows(m.cols)
+		for _, r := range m.rows {
+			rows.AddRow(r...)
+		}
+		if len(m.args) > 0 {
+			mock.ExpectQuery(m.query).WithArgs(m.args...).WillReturnRows(rows)
+		} else {
+			mock.ExpectQuery(m.query).WillReturnRows(rows)
+		}
+	}
+	return db
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sybase
+
+import (
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/sqlserver"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// timestampLength is the length of ASE timestamp values, which are row
+// versions stored as varbinary(8) rather than points in time.
+const timestampLength = 8
+
+// ToDdlImpl Sybase ASE specific implementation for ToDdl. Types shared with
+// SQL Server are mapped as they are for SQL Server.
+type ToDdlImpl struct {
+	sqlserver.ToDdlImpl
+}
+
+// ToSpannerType maps a scalar source schema type (defined by id and
+// mods) into a Spanner type. This is the core source-to-Spanner type
+// mapping.  toSpannerType returns the Spanner type and a list of type
+// conversion issues encountered.
+func (tdi ToDdlImpl) ToSpannerType(conv *internal.Conv, spType string, srcType schema.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
+	var ty ddl.Type
+	var issues []internal.SchemaIssue
+	switch srcType.Name {
+	case "unichar", "univarchar", "sysname", "longsysname":
+		return tdi.ToDdlImpl.ToSpannerType(conv, spType, schema.Type{Name: "nvarchar", Mods: srcType.Mods}, isPk)
+	case "unsigned tinyint", "unsigned smallint", "unsigned int":
+		return tdi.ToDdlImpl.ToSpannerType(conv, spType, schema.Type{Name: "int"}, isPk)
+	case "unsigned bigint":
+		// Unsigned bigint values can exceed the range of INT64.
+		switch spType {
+		case ddl.String:
+			ty, issues = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+		default:
+			ty = ddl.Type{Name: ddl.Numeric}
+		}
+	case "double precision":
+		return tdi.ToDdlImpl.ToSpannerType(conv, spType, schema.Type{Name: "float"}, isPk)
+	case "money", "smallmoney":
+		switch spType {
+		case ddl.String:
+			ty, issues = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Widened}
+		default:
+			ty, issues = ddl.Type{Name: ddl.Numeric}, []internal.SchemaIssue{internal.Money}
+		}
+	case "text", "unitext":
+		switch spType {
+		case ddl.Bytes:
+			ty = ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}
+		default:
+			ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+		}
+		issues = []internal.SchemaIssue{internal.LargeObject}
+	case "image":
+		switch spType {
+		case ddl.String:
+			ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+		default:
+			ty = ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}
+		}
+		issues = []internal.SchemaIssue{internal.LargeObject}
+	case "timestamp":
+		switch spType {
+		case ddl.String:
+			ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
+		default:
+			ty = ddl.Type{Name: ddl.Bytes, Len: timestampLength}
+		}
+	case "bigdatetime":
+		return tdi.ToDdlImpl.ToSpannerType(conv, spType, schema.Type{Name: "datetime2"}, isPk)
+	case "bigtime":
+		return tdi.ToDdlImpl.ToSpannerType(conv, spType, schema.Type{Name: "time"}, isPk)
+	default:
+		return tdi.ToDdlImpl.ToSpannerType(conv, spType, srcType, isPk)
+	}
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		var pgIssues []internal.SchemaIssue
+		ty, pgIssues = common.ToPGDialectType(ty, isPk)
+		issues = append(issues, pgIssues...)
+	}
+	return ty, issues
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sybase
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSpannerType(t *testing.T) {
+	testCases := []struct {
+		name    string
+		dialect string
+		spType  string
+		isPk    bool
+		srcType schema.Type
+		want    ddl.Type
+		issues  []internal.SchemaIssue
+	}{
+		{name: "int", srcType: schema.Type{Name: "int"}, want: ddl.Type{Name: ddl.Int64}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "unsigned int", srcType: schema.Type{Name: "unsigned int"}, want: ddl.Type{Name: ddl.Int64}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "unsigned bigint", srcType: schema.Type{Name: "unsigned bigint"}, want: ddl.Type{Name: ddl.Numeric}},
+		{name: "unsigned bigint to string", spType: ddl.String, srcType: schema.Type{Name: "unsigned bigint"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "double precision", srcType: schema.Type{Name: "double precision"}, want: ddl.Type{Name: ddl.Float64}},
+		{name: "univarchar", srcType: schema.Type{Name: "univarchar", Mods: []int64{100}}, want: ddl.Type{Name: ddl.String, Len: 100}},
+		{name: "sysname", srcType: schema.Type{Name: "sysname", Mods: []int64{30}}, want: ddl.Type{Name: ddl.String, Len: 30}},
+		{name: "money", srcType: schema.Type{Name: "money"}, want: ddl.Type{Name: ddl.Numeric}, issues: []internal.SchemaIssue{internal.Money}},
+		{name: "smallmoney to string", spType: ddl.String, srcType: schema.Type{Name: "smallmoney"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Widened}},
+		{name: "text", srcType: schema.Type{Name: "text"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.LargeObject}},
+		{name: "unitext", srcType: schema.Type{Name: "unitext"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.LargeObject}},
+		{name: "image", srcType: schema.Type{Name: "image"}, want: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.LargeObject}},
+		{name: "timestamp", srcType: schema.Type{Name: "timestamp"}, want: ddl.Type{Name: ddl.Bytes, Len: 8}},
+		{name: "datetime", srcType: schema.Type{Name: "datetime"}, want: ddl.Type{Name: ddl.Timestamp}, issues: []internal.SchemaIssue{internal.Timestamp}},
+		{name: "bigdatetime", srcType: schema.Type{Name: "bigdatetime"}, want: ddl.Type{Name: ddl.Timestamp}, issues: []internal.SchemaIssue{internal.Timestamp}},
+		{name: "bigtime", srcType: schema.Type{Name: "bigtime"}, want: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.Time}},
+		{name: "date", srcType: schema.Type{Name: "date"}, want: ddl.Type{Name: ddl.Date}},
+		{name: "bit", srcType: schema.Type{Name: "bit"}, want: ddl.Type{Name: ddl.Bool}},
+		{name: "money pg", dialect: constants.DIALECT_POSTGRESQL, srcType: schema.Type{Name: "money"}, want: ddl.Type{Name: ddl.Numeric}, issues: []internal.SchemaIssue{internal.Money}},
+		{name: "image pg", dialect: constants.DIALECT_POSTGRESQL, srcType: schema.Type{Name: "image"}, want: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, issues: []internal.SchemaIssue{internal.LargeObject}},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		if tc.dialect != "" {
+			conv.SpDialect = tc.dialect
+		}
+		ty, issues := ToDdlImpl{}.ToSpannerType(conv, tc.spType, tc.srcType, tc.isPk)
+		assert.Equal(t, tc.want, ty, tc.name)
+		assert.Equal(t, tc.issues, issues, tc.name)
+	}
+}
@@ -23,6 +23,7 @@ package ddl
 import (
 	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -53,6 +54,16 @@ const (
 	Numeric string = "NUMERIC"
 	// Json represent JSON type.
 	JSON string = "JSON"
+	// UUID represent UUID type.
+	UUID string = "UUID"
+	// TokenList represent TOKENLIST type, used by full-text search.
+	TokenList string = "TOKENLIST"
+	// Proto represents a PROTO type, declared using the fully qualified
+	// name of the proto message.
+	Proto string = "PROTO"
+	// Enum represents an ENUM type, declared using the fully qualified
+	// name of the proto enum.
+	Enum string = "ENUM"
 	// MaxLength is a sentinel for Type's Len field, representing the MAX value.
 	MaxLength = math.MaxInt64
 	// StringMaxLength represents maximum allowed STRING length.
@@ -60,6 +71,14 @@ const (
 	// BytesMaxLength represents maximum allowed BYTES length.
 	BytesMaxLength        = 10485760
 	MaxNonKeyColumnLength = 1677721600
+	// NumericMaxIntegerDigits and NumericMaxScale are the digits NUMERIC
+	// can store before and after the decimal point, i.e. NUMERIC(38,9).
+	NumericMaxIntegerDigits = 29
+	NumericMaxScale         = 9
+	// PGNumericMaxIntegerDigits and PGNumericMaxScale are the digits numeric
+	// can store before and after the decimal point in PG.
+	PGNumericMaxIntegerDigits = 147455
+	PGNumericMaxScale         = 16383
 
 	// Types specific to Spanner with postgresql dialect, when they differ from
 	// Spanner with google_standard_sql.
@@ -77,6 +96,11 @@ const (
 	PGTimestamptz string = "TIMESTAMPTZ"
 	// Jsonb represents the PG.JSONB type
 	PGJSONB string = "JSONB"
+	// PGTokenList represents SPANNER.TOKENLIST, which is TOKENLIST type in PG.
+	PGTokenList string = "SPANNER.TOKENLIST"
+	// PGCommitTimestamp represents SPANNER.COMMIT_TIMESTAMP, which is the PG
+	// equivalent of a TIMESTAMP column with allow_commit_timestamp set.
+	PGCommitTimestamp string = "SPANNER.COMMIT_TIMESTAMP"
 	// PGMaxLength represents sentinel for Type's Len field in PG.
 	PGMaxLength = 2621440
 )
@@ -89,6 +113,7 @@ var STANDARD_TYPE_TO_PGSQL_TYPEMAP = map[string]string{
 	String:    PGVarchar,
 	Timestamp: PGTimestamptz,
 	JSON:      PGJSONB,
+	TokenList: PGTokenList,
 }
 
 var PGSQL_TO_STANDARD_TYPE_TYPEMAP = map[string]string{
@@ -99,6 +124,7 @@ var PGSQL_TO_STANDARD_TYPE_TYPEMAP = map[string]string{
 	PGVarchar:     String,
 	PGTimestamptz: Timestamp,
 	PGJSONB:       JSON,
+	PGTokenList:   TokenList,
 }
 
 // PGDialect keyword list
@@ -112,6 +138,15 @@ var PGSQL_RESERVED_KEYWORD_LIST = []string{"ALL", "ANALYSE", "ANALYZE", "AND", "
 	"TABLE", "TABLESAMPLE", "THEN", "TIME", "TIMESTAMP", "TO", "TRAILING", "TREAT", "TRIM", "TRUE", "UNION", "UNIQUE", "USER", "USING", "VALUES", "VARCHAR", "VARIADIC", "VERBOSE", "WHEN", "WHERE", "WINDOW", "WITH",
 	"XMLATTRIBUTES", "XMLCONCAT", "XMLELEMENT", "XMLEXISTS", "XMLFOREST", "XMLNAMESPACES", "XMLPARSE", "XMLPI", "XMLROOT", "XMLSERIALIZE", "XMLTABLE"}
 
+// GoogleSQL dialect keyword list. Identifiers matching these keywords must be
+// quoted using backticks.
+var GOOGLESQL_RESERVED_KEYWORD_LIST = []string{"ALL", "AND", "ANY", "ARRAY", "AS", "ASC", "ASSERT_ROWS_MODIFIED", "AT", "BETWEEN", "BY", "CASE", "CAST", "COLLATE",
+	"CONTAINS", "CREATE", "CROSS", "CUBE", "CURRENT", "DEFAULT", "DEFINE", "DESC", "DISTINCT", "ELSE", "END", "ENUM", "ESCAPE", "EXCEPT", "EXCLUDE", "EXISTS",
+	"EXTRACT", "FALSE", "FETCH", "FOLLOWING", "FOR", "FROM", "FULL", "GROUP", "GROUPING", "GROUPS", "HASH", "HAVING", "IF", "IGNORE", "IN", "INNER", "INTERSECT",
+	"INTERVAL", "INTO", "IS", "JOIN", "LATERAL", "LEFT", "LIKE", "LIMIT", "LOOKUP", "MERGE", "NATURAL", "NEW", "NO", "NOT", "NULL", "NULLS", "OF", "ON", "OR",
+	"ORDER", "OUTER", "OVER", "PARTITION", "PRECEDING", "PROTO", "RANGE", "RECURSIVE", "RESPECT", "RIGHT", "ROLLUP", "ROWS", "SELECT", "SET", "SOME", "STRUCT",
+	"TABLESAMPLE", "THEN", "TO", "TREAT", "TRUE", "UNBOUNDED", "UNION", "UNNEST", "USING", "WHEN", "WHERE", "WINDOW", "WITH", "WITHIN"}
+
 // Type represents the type of a column.
 //
 //	type:
@@ -125,11 +160,25 @@ type Type struct {
 	// IsArray represents if Type is an array_type or not
 	// When false, column has type T; when true, it is an array of type T.
 	IsArray bool
+	// ProtoName is the fully qualified name of the proto message or enum,
+	// set for PROTO and ENUM types.
+	ProtoName string
+	// VectorLength encodes the vector_length option of FLOAT32 and FLOAT64
+	// arrays, required for columns used in vector indexes. Zero means unset.
+	VectorLength int64
+	// Precision and Scale encode numeric(precision, scale) in PG. GoogleSQL
+	// NUMERIC has a fixed precision and scale, so they are not printed for
+	// it. Zero Precision means unset.
+	Precision int64
+	Scale     int64
 }
 
 // PrintColumnDefType unparses the type encoded in a ColumnDef.
 func (ty Type) PrintColumnDefType() string {
 	str := ty.Name
+	if ty.Name == Proto || ty.Name == Enum {
+		str = ty.ProtoName
+	}
 	if ty.Name == String || ty.Name == Bytes {
 		str += "("
 		if ty.Len == MaxLength {
@@ -141,6 +190,9 @@ func (ty Type) PrintColumnDefType() string {
 	}
 	if ty.IsArray {
 		str = "ARRAY<" + str + ">"
+		if ty.VectorLength > 0 {
+			str += fmt.Sprintf("(vector_length=>%d)", ty.VectorLength)
+		}
 	}
 	return str
 }
@@ -155,15 +207,12 @@ func GetPGType(spType Type) string {
 
 func (ty Type) PGPrintColumnDefType() string {
 	str := GetPGType(ty)
-	// PG doesn't support array types, and we don't expect to receive a type
-	// with IsArray set to true. In the unlikely event, set to string type.
-	if ty.IsArray {
-		str = PGVarchar
-		ty.Len = PGMaxLength
+	if ty.IsArray && ty.VectorLength > 0 {
+		return fmt.Sprintf("%s[] VECTOR LENGTH %d", strings.ToLower(str), ty.VectorLength)
 	}
 	// PG doesn't support variable length Bytea and thus doesn't support
 	// setting length (or max length) for the Bytes.
-	if ty.Name == String || ty.IsArray {
+	if ty.Name == String {
 		str += "("
 		if ty.Len == MaxLength || ty.Len == PGMaxLength {
 			str += fmt.Sprintf("%v", PGMaxLength)
@@ -172,22 +221,162 @@ func (ty Type) PGPrintColumnDefType() string {
 		}
 		str += ")"
 	}
+	if ty.Name == Numeric && ty.Precision > 0 {
+		str += fmt.Sprintf("(%d,%d)", ty.Precision, ty.Scale)
+	}
+	if ty.IsArray {
+		str += "[]"
+	}
 	return str
 }
 
 // ColumnDef encodes the following DDL definition:
 //
 //	column_def:
-//	  column_name type [NOT NULL] [options_def]
+//	  column_name type [NOT NULL] [{ DEFAULT ( expression ) | AS ( expression ) STORED }] [HIDDEN] [options_def]
+//
+// Hidden columns are not returned by SELECT *, e.g. the synthetic columns
+// added by the tool such as the shard id column.
 type ColumnDef struct {
-	Name         string
-	T            Type
-	NotNull      bool
-	Comment      string
-	Id           string
-	AutoGen      AutoGenCol
-	DefaultValue DefaultValue
-	Opts         map[string]string
+	Name            string
+	T               Type
+	NotNull         bool
+	Comment         string
+	Id              string
+	AutoGen         AutoGenCol
+	DefaultValue    DefaultValue
+	GeneratedColumn GeneratedColumn
+	Hidden          bool
+	Opts            map[string]string
+}
+
+// AllowCommitTimestampOpt is the column option that allows a TIMESTAMP
+// column to store the commit timestamp of the writing transaction.
+const AllowCommitTimestampOpt = "allow_commit_timestamp"
+
+// LocalityGroupOpt is the column option that places a column in a locality
+// group other than the locality group of its table.
+const LocalityGroupOpt = "locality_group"
+
+// CassandraTypeOpt is the column option recording the Cassandra type of
+// columns migrated from Cassandra, used by the Cassandra adapter.
+const CassandraTypeOpt = "cassandra_type"
+
+// ColumnOption describes a column option that can be set in ColumnDef.Opts.
+// PostgreSQL has no column OPTIONS clause, so the options it supports are
+// printed with dedicated syntax instead, e.g. allow_commit_timestamp as the
+// SPANNER.COMMIT_TIMESTAMP type.
+type ColumnOption struct {
+	Key        string
+	Bool       bool // If true, the value is true or false and printed unquoted; otherwise it is printed as a string.
+	GoogleSQL  bool // If true, the option is supported by the GoogleSQL dialect.
+	PostgreSQL bool // If true, the option is supported by the PostgreSQL dialect.
+}
+
+// ColumnOptions lists the column options that can be set in ColumnDef.Opts,
+// in the order they are printed. Other keys are not printed.
+var ColumnOptions = []ColumnOption{
+	{Key: CassandraTypeOpt, GoogleSQL: true},
+	{Key: AllowCommitTimestampOpt, Bool: true, GoogleSQL: true, PostgreSQL: true},
+	{Key: LocalityGroupOpt, GoogleSQL: true, PostgreSQL: true},
+}
+
+// ValidateColumnOption checks that the column option key is supported by
+// the dialect and that value is a valid value for it.
+func ValidateColumnOption(key, value, dialect string) error {
+	for _, o := range ColumnOptions {
+		if o.Key != key {
+			continue
+		}
+		if (dialect == constants.DIALECT_POSTGRESQL && !o.PostgreSQL) || (dialect != constants.DIALECT_POSTGRESQL && !o.GoogleSQL) {
+			return fmt.Errorf("column option %s is not supported by the %s dialect", key, dialect)
+		}
+		if o.Bool && value != "true" && value != "false" {
+			return fmt.Errorf("value of column option %s must be true or false, found %q", key, value)
+		}
+		if !o.Bool && (value == "" || strings.ContainsAny(value, "'\\\n")) {
+			return fmt.Errorf("invalid value %q of column option %s", value, key)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown column option %s", key)
+}
+
+// SetOpt sets the column option key to value after validating it for the
+// column and the dialect. An empty value removes the option.
+func (cd *ColumnDef) SetOpt(key, value, dialect string) error {
+	if value == "" {
+		delete(cd.Opts, key)
+		return nil
+	}
+	if err := ValidateColumnOption(key, value, dialect); err != nil {
+		return err
+	}
+	if key == AllowCommitTimestampOpt && (cd.T.Name != Timestamp || cd.T.IsArray) {
+		return fmt.Errorf("%s can only be set on %s columns", AllowCommitTimestampOpt, Timestamp)
+	}
+	if cd.Opts == nil {
+		cd.Opts = make(map[string]string)
+	}
+	cd.Opts[key] = value
+	return nil
+}
+
+// printOptions returns the options of the column supported by the GoogleSQL
+// dialect, in the order of ColumnOptions.
+func (cd ColumnDef) printOptions() []string {
+	var opts []string
+	for _, o := range ColumnOptions {
+		value, ok := cd.Opts[o.Key]
+		if !ok || value == "" || !o.GoogleSQL {
+			continue
+		}
+		switch {
+		case o.Key == AllowCommitTimestampOpt:
+			if cd.AllowsCommitTimestamp() {
+				opts = append(opts, fmt.Sprintf("%s = true", o.Key))
+			}
+		case o.Bool:
+			opts = append(opts, fmt.Sprintf("%s = %s", o.Key, value))
+		default:
+			opts = append(opts, fmt.Sprintf("%s = '%s'", o.Key, value))
+		}
+	}
+	return opts
+}
+
+// PrintOptionChanges returns the options of the column supported by the
+// GoogleSQL dialect which differ from those of old, in the order of
+// ColumnOptions, as set by ALTER COLUMN ... SET OPTIONS. Options of old which
+// the column doesn't have are set to null.
+func (cd ColumnDef) PrintOptionChanges(old ColumnDef) []string {
+	printed := func(col ColumnDef) map[string]string {
+		m := make(map[string]string)
+		for _, opt := range col.printOptions() {
+			key, _, _ := strings.Cut(opt, " = ")
+			m[key] = opt
+		}
+		return m
+	}
+	oldOpts, newOpts := printed(old), printed(cd)
+	var changes []string
+	for _, o := range ColumnOptions {
+		newOpt, inNew := newOpts[o.Key]
+		oldOpt, inOld := oldOpts[o.Key]
+		switch {
+		case inNew && newOpt != oldOpt:
+			changes = append(changes, newOpt)
+		case inOld && !inNew:
+			changes = append(changes, o.Key+" = null")
+		}
+	}
+	return changes
+}
+
+// AllowsCommitTimestamp returns true if the column is a TIMESTAMP column with
+// the allow_commit_timestamp option set.
+func (cd ColumnDef) AllowsCommitTimestamp() bool {
+	return cd.T.Name == Timestamp && !cd.T.IsArray && cd.Opts[AllowCommitTimestampOpt] == "true"
 }
 
 // Config controls how AST nodes are printed (aka unparsed).
@@ -198,6 +387,110 @@ type Config struct {
 	ForeignKeys bool // If true, print foreign key constraints.
 	SpDialect   string
 	Source      string // SourceDB information for determining case-sensitivity handling for PGSQL
+	// If true along with ProtectIds, GoogleSQL names are only quoted when
+	// they are reserved keywords or not otherwise legal identifiers.
+	QuoteReservedOnly bool
+	// If true, tables, indexes and sequences are created with IF NOT EXISTS,
+	// so the DDL can be re-applied to a partially created database.
+	IfNotExists bool
+	// If true, PostgreSQL table and column comments are printed as COMMENT ON
+	// statements after the table, so that they are kept in the database.
+	CommentStatements bool
+	// If true, check constraints are printed as ALTER TABLE ADD CONSTRAINT
+	// statements after the tables instead of inline, so that each of them
+	// can be debugged and re-applied on its own.
+	CheckConstraintsAlterTable bool
+	Format                     Format // Layout of CREATE TABLE statements.
+}
+
+// Format controls the layout of CREATE TABLE statements, so that the printed
+// DDL can match the SQL style guide of a team. The zero value prints one
+// column per line, indented by a tab, with aligned trailing comments.
+type Format struct {
+	IndentWidth        int  // Number of spaces to indent table elements by. A tab is used when 0.
+	MaxLineLength      int  // Maximum length of the lines of compact column lists. No limit when 0.
+	Compact            bool // If true, columns are printed several per line instead of one per line.
+	NoCommentAlignment bool // If true, trailing column comments are not aligned with each other.
+}
+
+func (f Format) indent() string {
+	if f.IndentWidth > 0 {
+		return strings.Repeat(" ", f.IndentWidth)
+	}
+	return "\t"
+}
+
+// formatColumns lays out the column definitions of a CREATE TABLE statement,
+// followed by their comments if printComments is set.
+func (f Format) formatColumns(defs, comments []string, printComments bool) string {
+	indent := f.indent()
+	var b strings.Builder
+	if f.Compact {
+		var line string
+		for i, def := range defs {
+			def += ","
+			if line != "" && f.MaxLineLength > 0 && len(indent)+len(line)+1+len(def) > f.MaxLineLength {
+				b.WriteString(indent + line + "\n")
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += def
+			// A comment runs to the end of the line, so it also ends the line.
+			if printComments && len(comments[i]) > 0 {
+				b.WriteString(indent + line + " -- " + lineComment(comments[i]) + "\n")
+				line = ""
+			}
+		}
+		if line != "" {
+			b.WriteString(indent + line + "\n")
+		}
+		return b.String()
+	}
+	n := 0
+	if !f.NoCommentAlignment {
+		n = maxStringLength(defs)
+	}
+	for i, def := range defs {
+		b.WriteString(indent + def + ",")
+		if printComments && len(comments[i]) > 0 {
+			if n > len(def) {
+				b.WriteString(strings.Repeat(" ", n-len(def)))
+			}
+			b.WriteString(" -- " + lineComment(comments[i]))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// lineComment returns comment on a single line, so that it can be printed
+// as a -- comment. Comments carried over from the source may span lines.
+func lineComment(comment string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(comment)
+}
+
+// ifNotExists returns the IF NOT EXISTS clause of CREATE statements, if
+// required by the config.
+func (c Config) ifNotExists() string {
+	if c.IfNotExists {
+		return "IF NOT EXISTS "
+	}
+	return ""
+}
+
+// googleSQLIdentifierRegexp matches the identifiers that GoogleSQL accepts
+// without quoting, unless they are reserved keywords.
+var googleSQLIdentifierRegexp = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+func isIdentifierReservedInGoogleSQL(identifier string) bool {
+	for _, keyword := range GOOGLESQL_RESERVED_KEYWORD_LIST {
+		if strings.EqualFold(keyword, identifier) {
+			return true
+		}
+	}
+	return false
 }
 
 func isIdentifierReservedInPG(identifier string) bool {
@@ -218,7 +511,23 @@ func isSourceCaseSensitive(source string) bool {
 	}
 }
 
+// Quote returns the identifier s, quoted if required by the config.
+func (c Config) Quote(s string) string {
+	return c.quote(s)
+}
+
 func (c Config) quote(s string) string {
+	// Names of tables and indexes in named schemas are qualified by the
+	// schema, e.g. sales.orders, whose parts are quoted separately.
+	if schema, name, ok := strings.Cut(s, "."); ok {
+		return c.quoteIdentifier(schema) + "." + c.quoteIdentifier(name)
+	}
+	return c.quoteIdentifier(s)
+}
+
+// quoteIdentifier quotes s as a single identifier, even if it contains dots,
+// e.g. the full names of proto types.
+func (c Config) quoteIdentifier(s string) string {
 	if c.ProtectIds {
 		if c.SpDialect == constants.DIALECT_POSTGRESQL {
 			if isIdentifierReservedInPG(s) || isSourceCaseSensitive(c.Source) {
@@ -227,39 +536,170 @@ func (c Config) quote(s string) string {
 				return s
 			}
 		} else {
+			if c.QuoteReservedOnly && googleSQLIdentifierRegexp.MatchString(s) && !isIdentifierReservedInGoogleSQL(s) {
+				return s
+			}
 			return "`" + s + "`"
 		}
 	}
 	return s
 }
 
+// maxIdentifierLength is the maximum length of Spanner names.
+const maxIdentifierLength = 128
+
+// spannerNameRegexp matches legal Spanner names.
+var spannerNameRegexp = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9_]*$")
+
+// ValidateIdentifier checks that name follows the Spanner naming rules: it
+// must start with a letter, contain only letters, digits and underscores and
+// be at most 128 characters long.
+func ValidateIdentifier(name string) error {
+	if name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if len(name) > maxIdentifierLength {
+		return fmt.Errorf("name %s is longer than %d characters", name, maxIdentifierLength)
+	}
+	if !spannerNameRegexp.MatchString(name) {
+		return fmt.Errorf("name %s must start with a letter and contain only letters, digits and underscores", name)
+	}
+	return nil
+}
+
+// NamedSchema returns the named schema of the name of a table or index,
+// e.g. sales for sales.orders, and "" for names in the default schema.
+func NamedSchema(name string) string {
+	schema, _, _ := strings.Cut(name, ".")
+	if schema == name {
+		return ""
+	}
+	return schema
+}
+
+// GetNamedSchemas returns the sorted named schemas of the tables and indexes
+// of tableSchema.
+func GetNamedSchemas(tableSchema Schema) []string {
+	found := make(map[string]bool)
+	for _, ct := range tableSchema {
+		if s := NamedSchema(ct.Name); s != "" {
+			found[s] = true
+		}
+		for _, index := range ct.Indexes {
+			if s := NamedSchema(index.Name); s != "" {
+				found[s] = true
+			}
+		}
+	}
+	var schemas []string
+	for s := range found {
+		schemas = append(schemas, s)
+	}
+	sort.Strings(schemas)
+	return schemas
+}
+
+// ValidateIdentifiers checks the names of the tables, columns, indexes,
+// constraints, sequences and other schema objects against the Spanner naming
+// rules and returns a description of each violation.
+func ValidateIdentifiers(tableSchema Schema, sequenceSchema map[string]Sequence, objects SchemaObjects) []string {
+	var violations []string
+	check := func(kind, name string) {
+		if err := ValidateIdentifier(name); err != nil {
+			violations = append(violations, fmt.Sprintf("invalid %s name: %v", kind, err))
+		}
+	}
+	// Tables and indexes can be in named schemas.
+	checkQualified := func(kind, name string) {
+		if schema := NamedSchema(name); schema != "" {
+			check("schema", schema)
+			name = strings.TrimPrefix(name, schema+".")
+		}
+		check(kind, name)
+	}
+	for _, tableId := range GetSortedTableIdsBySpName(tableSchema) {
+		ct := tableSchema[tableId]
+		checkQualified("table", ct.Name)
+		for _, colId := range ct.ColIds {
+			check("column", ct.ColDefs[colId].Name)
+		}
+		for _, index := range ct.Indexes {
+			checkQualified("index", index.Name)
+		}
+		for _, fk := range ct.ForeignKeys {
+			if fk.Name != "" {
+				check("foreign key", fk.Name)
+			}
+		}
+		for _, ck := range ct.CheckConstraints {
+			if ck.Name != "" {
+				check("check constraint", ck.Name)
+			}
+		}
+	}
+	var seqNames []string
+	for _, seq := range sequenceSchema {
+		seqNames = append(seqNames, seq.Name)
+	}
+	sort.Strings(seqNames)
+	for _, name := range seqNames {
+		check("sequence", name)
+	}
+	for _, viewId := range GetSortedViewIds(objects.Views) {
+		check("view", objects.Views[viewId].Name)
+	}
+	for _, csId := range GetSortedChangeStreamIds(objects.ChangeStreams) {
+		check("change stream", objects.ChangeStreams[csId].Name)
+	}
+	for _, mId := range GetSortedModelIds(objects.Models) {
+		check("model", objects.Models[mId].Name)
+	}
+	for _, pgId := range GetSortedPropertyGraphIds(objects.PropertyGraphs) {
+		check("property graph", objects.PropertyGraphs[pgId].Name)
+	}
+	return violations
+}
+
 // PrintColumnDef unparses ColumnDef and returns it as well as any ColumnDef
 // comment. These are returned as separate strings to support formatting
 // needs of PrintCreateTable.
 func (cd ColumnDef) PrintColumnDef(c Config) (string, string) {
 	var s string
 	if c.SpDialect == constants.DIALECT_POSTGRESQL {
-		s = fmt.Sprintf("%s %s", c.quote(cd.Name), cd.T.PGPrintColumnDefType())
+		if cd.AllowsCommitTimestamp() {
+			s = fmt.Sprintf("%s %s", c.quote(cd.Name), PGCommitTimestamp)
+		} else {
+			s = fmt.Sprintf("%s %s", c.quote(cd.Name), cd.T.PGPrintColumnDefType())
+		}
 		if cd.NotNull {
 			s += " NOT NULL "
 		}
-		s += cd.DefaultValue.PGPrintDefaultValue(cd.T)
-		s += cd.AutoGen.PGPrintAutoGenCol()
+		if cd.GeneratedColumn.IsPresent {
+			s += cd.GeneratedColumn.PGPrintGeneratedColumn()
+		} else {
+			s += cd.DefaultValue.PGPrintDefaultValue(cd.T)
+			s += cd.AutoGen.PGPrintAutoGenCol()
+		}
 	} else {
 		s = fmt.Sprintf("%s %s", c.quote(cd.Name), cd.T.PrintColumnDefType())
 		if cd.NotNull {
 			s += " NOT NULL "
 		}
-		s += cd.DefaultValue.PrintDefaultValue(cd.T)
-		s += cd.AutoGen.PrintAutoGenCol()
+		if cd.GeneratedColumn.IsPresent {
+			s += cd.GeneratedColumn.PrintGeneratedColumn()
+		} else {
+			s += cd.DefaultValue.PrintDefaultValue(cd.T)
+			s += cd.AutoGen.PrintAutoGenCol()
+		}
 	}
-	var  opts []string
-	if cd.Opts != nil {
-		if opt, ok := cd.Opts["cassandra_type"]; ok && opt != "" {
-			opts = append(opts, fmt.Sprintf("cassandra_type = '%s'", opt))
+	if cd.Hidden {
+		s += " HIDDEN"
+	}
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		if lg := cd.Opts[LocalityGroupOpt]; lg != "" {
+			s += " LOCALITY GROUP " + c.quote(lg)
 		}
-	}	
-	if len(opts) > 0 {
+	} else if opts := cd.printOptions(); len(opts) > 0 {
 		s += " OPTIONS (" + strings.Join(opts, ", ") + ")"
 	}
 	return s, cd.Comment
@@ -298,6 +738,9 @@ func (idx IndexKey) PrintPkOrIndexKey(ct CreateTable, c Config) string {
 //
 //	   [ CONSTRAINT constraint_name ]
 //		  FOREIGN KEY ( column_name [, ... ] ) REFERENCES ref_table ( ref_column [, ... ] ) }
+//		  [ ON DELETE { CASCADE | NO ACTION } ] [ ON UPDATE action ] [ NOT ENFORCED ]
+//
+// ON UPDATE is only part of the PostgreSQL dialect.
 type Foreignkey struct {
 	Name           string
 	ColIds         []string
@@ -306,6 +749,9 @@ type Foreignkey struct {
 	Id             string
 	OnDelete       string
 	OnUpdate       string
+	// NotEnforced marks an informational foreign key, which Spanner does not
+	// validate on writes.
+	NotEnforced bool
 }
 
 // InterleavedParent encodes the following DDL definition:
@@ -317,6 +763,16 @@ type InterleavedParent struct {
 	InterleaveType string
 }
 
+// onUpdateClause returns the ON UPDATE clause of the foreign key. GoogleSQL
+// foreign keys have no ON UPDATE clause, and NO ACTION is the default of
+// PostgreSQL ones, so it is only printed for other PostgreSQL actions.
+func (k Foreignkey) onUpdateClause(c Config) string {
+	if c.SpDialect != constants.DIALECT_POSTGRESQL || k.OnUpdate == "" || k.OnUpdate == constants.FK_NO_ACTION {
+		return ""
+	}
+	return fmt.Sprintf(" ON UPDATE %s", k.OnUpdate)
+}
+
 // PrintForeignKey unparses the foreign keys.
 func (k Foreignkey) PrintForeignKey(c Config) string {
 	var cols, referCols []string
@@ -332,6 +788,10 @@ func (k Foreignkey) PrintForeignKey(c Config) string {
 	if k.OnDelete != "" {
 		s = s + fmt.Sprintf(" ON DELETE %s", k.OnDelete)
 	}
+	s = s + k.onUpdateClause(c)
+	if k.NotEnforced {
+		s = s + " NOT ENFORCED"
+	}
 	return s
 }
 
@@ -346,8 +806,13 @@ type CreateTable struct {
 	PrimaryKeys      []IndexKey
 	ForeignKeys      []Foreignkey
 	Indexes          []CreateIndex
+	SearchIndexes    []SearchIndex
+	VectorIndexes    []VectorIndex
 	ParentTable      InterleavedParent // if not empty, this table will be interleaved
 	CheckConstraints []CheckConstraint
+	Synonym          string // if not empty, the table is also addressable by this name
+	LocalityGroup    string // if not empty, name of the locality group storing the table
+	PlacementKey     string // if not empty, id of the column choosing the placement of each row
 	Comment          string
 	Id               string
 }
@@ -359,19 +824,17 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 	var keys []string
 	for _, colId := range ct.ColIds {
 		s, c := ct.ColDefs[colId].PrintColumnDef(config)
-		s = "\t" + s + ","
+		if colId == ct.PlacementKey {
+			s = strings.TrimSpace(s) + " PLACEMENT KEY"
+		}
 		col = append(col, s)
 		colComment = append(colComment, c)
 	}
 
-	n := maxStringLength(col)
-	var cols string
-	for i, c := range col {
-		cols += c
-		if config.Comments && len(colComment[i]) > 0 {
-			cols += strings.Repeat(" ", n-len(c)) + " -- " + colComment[i]
-		}
-		cols += "\n"
+	indent := config.Format.indent()
+	cols := config.Format.formatColumns(col, colComment, config.Comments)
+	if ct.Synonym != "" {
+		cols += indent + "SYNONYM(" + config.quote(ct.Synonym) + "),\n"
 	}
 
 	orderedPks := []IndexKey{}
@@ -385,7 +848,7 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 	}
 	var tableComment string
 	if config.Comments && len(ct.Comment) > 0 {
-		tableComment = "--\n-- " + ct.Comment + "\n--\n"
+		tableComment = "--\n-- " + lineComment(ct.Comment) + "\n--\n"
 	}
 
 	var interleave string
@@ -415,25 +878,43 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 		}
 	}
 
+	if ct.LocalityGroup != "" {
+		if config.SpDialect == constants.DIALECT_POSTGRESQL {
+			interleave += " LOCALITY GROUP " + config.quote(ct.LocalityGroup)
+		} else {
+			interleave += fmt.Sprintf(",\nOPTIONS (%s = '%s')", LocalityGroupOpt, ct.LocalityGroup)
+		}
+	}
+
 	var checkString string
-	if len(ct.CheckConstraints) > 0 {
-		checkString = FormatCheckConstraints(ct.CheckConstraints, config.SpDialect)
+	if len(ct.CheckConstraints) > 0 && !config.CheckConstraintsAlterTable {
+		checkString = formatCheckConstraints(ct.CheckConstraints, config.SpDialect, indent)
 	} else {
 		checkString = ""
 	}
 
 	if len(keys) == 0 {
-		return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s) %s", tableComment, config.quote(ct.Name), cols, checkString, interleave)
+		return fmt.Sprintf("%sCREATE TABLE %s%s (\n%s%s) %s", tableComment, config.ifNotExists(), config.quote(ct.Name), cols, checkString, interleave)
 	}
 	if config.SpDialect == constants.DIALECT_POSTGRESQL {
-		return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s\tPRIMARY KEY (%s)\n)%s", tableComment, config.quote(ct.Name), cols, checkString, strings.Join(keys, ", "), interleave)
+		return fmt.Sprintf("%sCREATE TABLE %s%s (\n%s%s%sPRIMARY KEY (%s)\n)%s", tableComment, config.ifNotExists(), config.quote(ct.Name), cols, checkString, indent, strings.Join(keys, ", "), interleave)
 	}
-	return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s) PRIMARY KEY (%s)%s", tableComment, config.quote(ct.Name), cols, checkString, strings.Join(keys, ", "), interleave)
+	return fmt.Sprintf("%sCREATE TABLE %s%s (\n%s%s) PRIMARY KEY (%s)%s", tableComment, config.ifNotExists(), config.quote(ct.Name), cols, checkString, strings.Join(keys, ", "), interleave)
+}
+
+// PrintAddSynonym unparses an ALTER TABLE statement that adds the table's
+// synonym, for tables that already exist in the database.
+func (ct CreateTable) PrintAddSynonym(config Config) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD SYNONYM %s", config.quote(ct.Name), config.quote(ct.Synonym))
 }
 
 // CreateIndex encodes the following DDL definition:
 //
 //	create index: CREATE [UNIQUE] [NULL_FILTERED] INDEX index_name ON table_name ( key_part [, ...] ) [ storing_clause ] [ , interleave_clause ]
+//	interleave_clause: INTERLEAVE IN table_name
+//
+// PostgreSQL dialect has no NULL_FILTERED keyword, so null-filtered indexes
+// are printed with a WHERE key_part IS NOT NULL [AND ...] clause instead.
 type CreateIndex struct {
 	Name            string
 	TableId         string `json:"TableId"`
@@ -441,13 +922,60 @@ type CreateIndex struct {
 	Keys            []IndexKey
 	Id              string
 	StoredColumnIds []string
-	// We have no requirements for null-filtered option and
-	// interleaving clauses yet, so we omit them for now.
+	// NullFiltered excludes rows with a NULL value in any key column from
+	// the index.
+	NullFiltered bool
+	// Interleave is the id of the ancestor table the index is interleaved
+	// in, or empty if the index isn't interleaved. See ValidateInterleave.
+	Interleave string
+}
+
+// ValidateInterleave checks that an index can be interleaved in the table
+// with id ci.Interleave: the table must be an ancestor of the indexed table,
+// and the index keys must start with its primary key columns, in order.
+func (ci CreateIndex) ValidateInterleave(s Schema) error {
+	parent, ok := s[ci.Interleave]
+	if !ok {
+		return fmt.Errorf("table %s to interleave index %s in doesn't exist", ci.Interleave, ci.Name)
+	}
+	ct := s[ci.TableId]
+	isAncestor := false
+	for id, seen := ct.ParentTable.Id, map[string]bool{}; id != "" && !seen[id]; id = s[id].ParentTable.Id {
+		if id == ci.Interleave {
+			isAncestor = true
+			break
+		}
+		seen[id] = true
+	}
+	if !isAncestor {
+		return fmt.Errorf("can't interleave index %s in %s: %s is not an ancestor of table %s", ci.Name, parent.Name, parent.Name, ct.Name)
+	}
+	keys := orderedIndexKeys(ci.Keys)
+	pks := orderedIndexKeys(parent.PrimaryKeys)
+	if len(keys) < len(pks) {
+		return fmt.Errorf("can't interleave index %s in %s: index keys must start with the primary key of %s", ci.Name, parent.Name, parent.Name)
+	}
+	for i, pk := range pks {
+		pkCol, keyCol := parent.ColDefs[pk.ColId], ct.ColDefs[keys[i].ColId]
+		if pkCol.Name != keyCol.Name || pkCol.T != keyCol.T {
+			return fmt.Errorf("can't interleave index %s in %s: index keys must start with the primary key of %s", ci.Name, parent.Name, parent.Name)
+		}
+	}
+	return nil
+}
+
+func orderedIndexKeys(keys []IndexKey) []IndexKey {
+	orderedKeys := []IndexKey{}
+	orderedKeys = append(orderedKeys, keys...)
+	sort.Slice(orderedKeys, func(i, j int) bool {
+		return orderedKeys[i].Order < orderedKeys[j].Order
+	})
+	return orderedKeys
 }
 
 type AutoGenCol struct {
 	Name string
-	// Type of autogenerated column, example, pre-defined(uuid) or user-defined(sequence)
+	// Type of autogenerated column, example, pre-defined(uuid), user-defined(sequence) or identity
 	GenerationType string
 }
 
@@ -462,6 +990,52 @@ type Expression struct {
 	Statement    string
 }
 
+// GeneratedColumn encodes the following DDL definition:
+//
+//	AS ( expression ) [ STORED ]
+//
+// A generated column is computed from other columns of the same row and
+// cannot be written to directly. Type is either STORED (the default) or
+// VIRTUAL, in which case the STORED keyword is omitted.
+type GeneratedColumn struct {
+	IsPresent bool
+	Value     Expression
+	Type      string
+}
+
+const (
+	// GeneratedStored denotes a generated column whose value is persisted.
+	GeneratedStored string = "STORED"
+	// GeneratedVirtual denotes a generated column computed on read.
+	GeneratedVirtual string = "VIRTUAL"
+)
+
+// PrintGeneratedColumn unparses a generated column clause for the GoogleSQL dialect.
+func (gc GeneratedColumn) PrintGeneratedColumn() string {
+	if !gc.IsPresent {
+		return ""
+	}
+	s := " AS (" + gc.Value.Statement + ")"
+	if gc.Type != GeneratedVirtual {
+		s += " " + GeneratedStored
+	}
+	return s
+}
+
+// PGPrintGeneratedColumn unparses a generated column clause for the PostgreSQL dialect.
+func (gc GeneratedColumn) PGPrintGeneratedColumn() string {
+	if !gc.IsPresent {
+		return ""
+	}
+	s := " GENERATED ALWAYS AS (" + gc.Value.Statement + ")"
+	if gc.Type == GeneratedVirtual {
+		s += " " + GeneratedVirtual
+	} else {
+		s += " " + GeneratedStored
+	}
+	return s
+}
+
 func (dv DefaultValue) PrintDefaultValue(ty Type) string {
 	if !dv.IsPresent {
 		return ""
@@ -497,6 +1071,9 @@ func (agc AutoGenCol) PrintAutoGenCol() string {
 	if agc.GenerationType == constants.SEQUENCE {
 		return fmt.Sprintf(" DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE %s))", agc.Name)
 	}
+	if agc.GenerationType == constants.IDENTITY {
+		return " GENERATED BY DEFAULT AS IDENTITY (BIT_REVERSED_POSITIVE)"
+	}
 	return ""
 }
 
@@ -507,26 +1084,38 @@ func (agc AutoGenCol) PGPrintAutoGenCol() string {
 	if agc.GenerationType == constants.SEQUENCE {
 		return fmt.Sprintf(" DEFAULT NEXTVAL('%s')", agc.Name)
 	}
+	if agc.GenerationType == constants.IDENTITY {
+		return " GENERATED BY DEFAULT AS IDENTITY (BIT_REVERSED_POSITIVE)"
+	}
 	return ""
 }
 
-// PrintCreateIndex unparses a CREATE INDEX statement.
-func (ci CreateIndex) PrintCreateIndex(ct CreateTable, c Config) string {
+// PrintCreateIndex unparses a CREATE INDEX statement. The interleave clause
+// is omitted if the index can no longer be interleaved e.g. because the
+// primary key of the parent table was changed.
+func (ci CreateIndex) PrintCreateIndex(spSchema Schema, ct CreateTable, c Config) string {
 	var keys []string
 
-	orderedKeys := []IndexKey{}
-	orderedKeys = append(orderedKeys, ci.Keys...)
-	sort.Slice(orderedKeys, func(i, j int) bool {
-		return orderedKeys[i].Order < orderedKeys[j].Order
-	})
+	orderedKeys := orderedIndexKeys(ci.Keys)
 
 	for _, p := range orderedKeys {
 		keys = append(keys, p.PrintPkOrIndexKey(ct, c))
 	}
-	var unique, stored, storingClause string
+	var unique, nullFiltered, stored, storingClause, interleaveClause, whereClause string
 	if ci.Unique {
 		unique = "UNIQUE "
 	}
+	if ci.NullFiltered {
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			var notNulls []string
+			for _, p := range orderedKeys {
+				notNulls = append(notNulls, fmt.Sprintf("%s IS NOT NULL", c.quote(ct.ColDefs[p.ColId].Name)))
+			}
+			whereClause = " WHERE " + strings.Join(notNulls, " AND ")
+		} else {
+			nullFiltered = "NULL_FILTERED "
+		}
+	}
 	if c.SpDialect == constants.DIALECT_POSTGRESQL {
 		stored = "INCLUDE"
 	} else {
@@ -541,7 +1130,180 @@ func (ci CreateIndex) PrintCreateIndex(ct CreateTable, c Config) string {
 		}
 		storingClause = fmt.Sprintf(" %s (%s)", stored, strings.Join(storedColumns, ", "))
 	}
-	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)%s", unique, c.quote(ci.Name), c.quote(ct.Name), strings.Join(keys, ", "), storingClause)
+	if ci.Interleave != "" && ci.ValidateInterleave(spSchema) == nil {
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			interleaveClause = fmt.Sprintf(" INTERLEAVE IN %s", c.quote(spSchema[ci.Interleave].Name))
+		} else {
+			interleaveClause = fmt.Sprintf(", INTERLEAVE IN %s", c.quote(spSchema[ci.Interleave].Name))
+		}
+	}
+	return fmt.Sprintf("CREATE %s%sINDEX %s%s ON %s (%s)%s%s%s", unique, nullFiltered, c.ifNotExists(), c.quote(ci.Name), c.quote(ct.Name), strings.Join(keys, ", "), storingClause, interleaveClause, whereClause)
+}
+
+// SearchIndex encodes the following DDL definition:
+//
+//	create search index: CREATE SEARCH INDEX index_name ON table_name ( tokenlist_column [, ...] )
+//	  [ storing_clause ] [ PARTITION BY column_name [, ...] ] [ ORDER BY column_name [ DESC ] [, ...] ]
+//	  [ OPTIONS ( search_index_option [, ...] ) ]
+type SearchIndex struct {
+	Name                      string
+	TableId                   string
+	Id                        string
+	Keys                      []IndexKey // TOKENLIST columns being indexed.
+	StoredColumnIds           []string
+	PartitionBy               []string
+	OrderBy                   []IndexKey
+	SortOrderSharding         bool
+	DisableAutomaticUidColumn bool
+}
+
+// PrintSearchIndex unparses a CREATE SEARCH INDEX statement.
+func (si SearchIndex) PrintSearchIndex(ct CreateTable, c Config) string {
+	var keys []string
+	for _, k := range si.Keys {
+		keys = append(keys, c.quote(ct.ColDefs[k.ColId].Name))
+	}
+	s := fmt.Sprintf("CREATE SEARCH INDEX %s ON %s (%s)", c.quote(si.Name), c.quote(ct.Name), strings.Join(keys, ", "))
+	if len(si.StoredColumnIds) > 0 {
+		var stored []string
+		for _, colId := range si.StoredColumnIds {
+			stored = append(stored, c.quote(ct.ColDefs[colId].Name))
+		}
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			s += fmt.Sprintf(" INCLUDE (%s)", strings.Join(stored, ", "))
+		} else {
+			s += fmt.Sprintf(" STORING (%s)", strings.Join(stored, ", "))
+		}
+	}
+	if len(si.PartitionBy) > 0 {
+		var partitions []string
+		for _, colId := range si.PartitionBy {
+			partitions = append(partitions, c.quote(ct.ColDefs[colId].Name))
+		}
+		s += " PARTITION BY " + strings.Join(partitions, ", ")
+	}
+	if len(si.OrderBy) > 0 {
+		var orderBy []string
+		for _, k := range si.OrderBy {
+			orderBy = append(orderBy, k.PrintPkOrIndexKey(ct, c))
+		}
+		s += " ORDER BY " + strings.Join(orderBy, ", ")
+	}
+	var options []string
+	if si.SortOrderSharding {
+		options = append(options, "sort_order_sharding = true")
+	}
+	if si.DisableAutomaticUidColumn {
+		options = append(options, "disable_automatic_uid_column = true")
+	}
+	if len(options) > 0 {
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			s += " WITH (" + strings.Join(options, ", ") + ")"
+		} else {
+			s += " OPTIONS (" + strings.Join(options, ", ") + ")"
+		}
+	}
+	return s
+}
+
+// Distance types supported by vector indexes.
+const (
+	VectorDistanceCosine     = "COSINE"
+	VectorDistanceEuclidean  = "EUCLIDEAN"
+	VectorDistanceDotProduct = "DOT_PRODUCT"
+)
+
+// pgVectorDistanceFunctions maps vector index distance types to the
+// distance functions used by the PG dialect.
+var pgVectorDistanceFunctions = map[string]string{
+	VectorDistanceCosine:     "spanner.cosine",
+	VectorDistanceEuclidean:  "spanner.euclidean",
+	VectorDistanceDotProduct: "spanner.dot_product",
+}
+
+// VectorIndex encodes the following DDL definition:
+//
+//	create vector index: CREATE VECTOR INDEX index_name ON table_name ( column_name )
+//	  [ storing_clause ] [ WHERE column_name IS NOT NULL ] OPTIONS ( vector_index_option [, ...] )
+//
+// For the PG dialect, the equivalent CREATE INDEX ... USING ScaNN statement is printed.
+type VectorIndex struct {
+	Name            string
+	TableId         string
+	Id              string
+	ColId           string // The embedding column, an array with VectorLength set.
+	StoredColumnIds []string
+	DistanceType    string // One of COSINE, EUCLIDEAN or DOT_PRODUCT.
+	TreeDepth       int64  // Zero means unset.
+	NumLeaves       int64  // Zero means unset.
+	NumBranches     int64  // Zero means unset.
+}
+
+// PrintVectorIndex unparses a CREATE VECTOR INDEX statement. Spanner requires
+// vector indexes over nullable columns to filter out NULL values, so a WHERE
+// clause is printed unless the column is NOT NULL.
+func (vi VectorIndex) PrintVectorIndex(ct CreateTable, c Config) string {
+	col := c.quote(ct.ColDefs[vi.ColId].Name)
+	var stored []string
+	for _, colId := range vi.StoredColumnIds {
+		stored = append(stored, c.quote(ct.ColDefs[colId].Name))
+	}
+	var options []string
+	if vi.DistanceType != "" && c.SpDialect != constants.DIALECT_POSTGRESQL {
+		options = append(options, fmt.Sprintf("distance_type = '%s'", vi.DistanceType))
+	}
+	if vi.TreeDepth > 0 {
+		options = append(options, fmt.Sprintf("tree_depth = %d", vi.TreeDepth))
+	}
+	if vi.NumLeaves > 0 {
+		options = append(options, fmt.Sprintf("num_leaves = %d", vi.NumLeaves))
+	}
+	if vi.NumBranches > 0 {
+		options = append(options, fmt.Sprintf("num_branches = %d", vi.NumBranches))
+	}
+	var where string
+	if !ct.ColDefs[vi.ColId].NotNull {
+		where = fmt.Sprintf(" WHERE %s IS NOT NULL", col)
+	}
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		s := fmt.Sprintf("CREATE INDEX %s ON %s USING ScaNN (%s %s)", c.quote(vi.Name), c.quote(ct.Name), col, pgVectorDistanceFunctions[vi.DistanceType])
+		if len(stored) > 0 {
+			s += fmt.Sprintf(" INCLUDE (%s)", strings.Join(stored, ", "))
+		}
+		if len(options) > 0 {
+			s += " WITH (" + strings.Join(options, ", ") + ")"
+		}
+		return s + where
+	}
+	s := fmt.Sprintf("CREATE VECTOR INDEX %s ON %s (%s)", c.quote(vi.Name), c.quote(ct.Name), col)
+	if len(stored) > 0 {
+		s += fmt.Sprintf(" STORING (%s)", strings.Join(stored, ", "))
+	}
+	s += where
+	if len(options) > 0 {
+		s += " OPTIONS (" + strings.Join(options, ", ") + ")"
+	}
+	return s
+}
+
+// TokenizeFullText returns the TOKENIZE_FULLTEXT expression used to generate
+// a TOKENLIST column from colName. Tokenization options such as language_tag
+// or content_type are passed as named arguments and printed in sorted order.
+func TokenizeFullText(colName string, options map[string]string, dialect string) string {
+	fn := "TOKENIZE_FULLTEXT"
+	if dialect == constants.DIALECT_POSTGRESQL {
+		fn = "spanner.tokenize_fulltext"
+	}
+	args := []string{colName}
+	var names []string
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, fmt.Sprintf("%s=>'%s'", name, options[name]))
+	}
+	return fmt.Sprintf("%s(%s)", fn, strings.Join(args, ", "))
 }
 
 // Checks if the colId is part of the primary of a table
@@ -571,18 +1333,36 @@ func (k Foreignkey) PrintForeignKeyAlterTable(spannerSchema Schema, c Config, ta
 	if k.OnDelete != "" {
 		s = s + fmt.Sprintf(" ON DELETE %s", k.OnDelete)
 	}
+	s = s + k.onUpdateClause(c)
+	if k.NotEnforced {
+		s = s + " NOT ENFORCED"
+	}
 	return s
 }
 
+// PrintCheckConstraintAlterTable unparses an ALTER TABLE statement adding
+// the check constraint to table ct.
+func (ck CheckConstraint) PrintCheckConstraintAlterTable(ct CreateTable, c Config) string {
+	var s string
+	if ck.Name != "" {
+		s = fmt.Sprintf("CONSTRAINT %s ", c.quote(ck.Name))
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD %sCHECK %s", c.quote(ct.Name), s, ck.Expr)
+}
+
 // FormatCheckConstraints formats the check constraints in SQL syntax.
 func FormatCheckConstraints(cks []CheckConstraint, dailect string) string {
+	return formatCheckConstraints(cks, dailect, "\t")
+}
+
+func formatCheckConstraints(cks []CheckConstraint, dailect, indent string) string {
 	var builder strings.Builder
 
 	for _, col := range cks {
 		if col.Name != "" {
-			builder.WriteString(fmt.Sprintf("\tCONSTRAINT %s CHECK %s,\n", col.Name, col.Expr))
+			builder.WriteString(fmt.Sprintf("%sCONSTRAINT %s CHECK %s,\n", indent, col.Name, col.Expr))
 		} else {
-			builder.WriteString(fmt.Sprintf("\tCHECK %s,\n", col.Expr))
+			builder.WriteString(fmt.Sprintf("%sCHECK %s,\n", indent, col.Expr))
 		}
 	}
 
@@ -599,6 +1379,26 @@ func FormatCheckConstraints(cks []CheckConstraint, dailect string) string {
 	return ""
 }
 
+// PrintCommentStatements unparses the COMMENT ON statements setting the
+// comments of the table and its columns, in column order. Comment statements
+// are only printed for PostgreSQL, when Config.CommentStatements is set.
+func (ct CreateTable) PrintCommentStatements(c Config) []string {
+	if !c.CommentStatements || c.SpDialect != constants.DIALECT_POSTGRESQL {
+		return nil
+	}
+	quoteComment := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+	var stmts []string
+	if ct.Comment != "" {
+		stmts = append(stmts, fmt.Sprintf("COMMENT ON TABLE %s IS %s", c.quote(ct.Name), quoteComment(ct.Comment)))
+	}
+	for _, colId := range ct.ColIds {
+		if cd := ct.ColDefs[colId]; cd.Comment != "" {
+			stmts = append(stmts, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", c.quote(ct.Name), c.quote(cd.Name), quoteComment(cd.Comment)))
+		}
+	}
+	return stmts
+}
+
 // Schema stores a map of table names and Tables.
 type Schema map[string]CreateTable
 
@@ -613,19 +1413,17 @@ func NewSchema() Schema {
 // TODO: Move this method to mapping.go and preserve the table names in sorted
 // order in conv so that we don't need to order the table names multiple times.
 func GetSortedTableIdsBySpName(s Schema) []string {
-	var tableNames, sortedTableNames, sortedTableIds []string
-	tableNameIdMap := map[string]string{}
-	for _, t := range s {
-		tableNames = append(tableNames, t.Name)
-		tableNameIdMap[t.Name] = t.Id
-	}
-	logger.Log.Debug(fmt.Sprintf("getting sorted table ids by table name: %s", tableNames))
-	sort.Strings(tableNames)
-	tableQueue := tableNames
+	var tableIds, sortedTableIds []string
+	for id := range s {
+		tableIds = append(tableIds, id)
+	}
+	sortIdsByName(tableIds, func(id string) string { return s[id].Name })
+	logger.Log.Debug(fmt.Sprintf("getting sorted table ids by table name: %s", tableIds))
+	tableQueue := tableIds
 	tableAdded := make(map[string]bool)
 	for len(tableQueue) > 0 {
-		tableName := tableQueue[0]
-		table := s[tableNameIdMap[tableName]]
+		tableId := tableQueue[0]
+		table := s[tableId]
 		tableQueue = tableQueue[1:]
 		parentTableExists := false
 		if table.ParentTable.Id != "" {
@@ -635,9 +1433,9 @@ func GetSortedTableIdsBySpName(s Schema) []string {
 		// Add table t if either:
 		// a) t is not interleaved in another table, or
 		// b) t is interleaved in another table and that table has already been added to the list.
-		if table.ParentTable.Id == "" || tableAdded[s[table.ParentTable.Id].Name] || !parentTableExists {
-			sortedTableNames = append(sortedTableNames, tableName)
-			tableAdded[tableName] = true
+		if table.ParentTable.Id == "" || tableAdded[table.ParentTable.Id] || !parentTableExists {
+			sortedTableIds = append(sortedTableIds, tableId)
+			tableAdded[tableId] = true
 		} else {
 			// We can't add table t now because its parent hasn't been added.
 			// Add it at end of tables and we'll try again later.
@@ -645,23 +1443,44 @@ func GetSortedTableIdsBySpName(s Schema) []string {
 			// but we will always make progress because interleaved tables can't
 			// have cycles. In principle this could be O(n^2), but in practice chains
 			// of interleaved tables are small.
-			tableQueue = append(tableQueue, tableName)
+			tableQueue = append(tableQueue, tableId)
 		}
 	}
-	for _, tableName := range sortedTableNames {
-		sortedTableIds = append(sortedTableIds, tableNameIdMap[tableName])
-	}
 	return sortedTableIds
 }
 
 // GetDDL returns the string representation of Spanner schema represented by Schema struct.
-// Tables are printed in alphabetical order with one exception: interleaved
-// tables are potentially out of order since they must appear after the
-// definition of their parent table.
-func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence) []string {
+// The statements are printed in a deterministic order, so that generated
+// files diff cleanly between runs: database options, the proto bundle,
+// sequences, locality groups, placements, then each table followed by its
+// indexes, search indexes and vector indexes, then property graphs, models,
+// views, change streams, check constraints if Config.CheckConstraintsAlterTable
+// is set, and finally foreign keys. Objects of the same kind
+// are printed in alphabetical order of their names, see the GetSorted*
+// functions, with one exception: interleaved tables are potentially out of
+// order since they must appear after the definition of their parent table.
+// Search and vector indexes keep their table order, followed by the COMMENT
+// ON statements of the table if enabled. Property graphs and models are only
+// printed for GoogleSQL.
+func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence, objects SchemaObjects) []string {
 	var ddl []string
 
-	for _, seq := range sequenceSchema {
+	// Database options come first, as the default sequence kind applies to
+	// the sequences and identity columns created afterwards.
+	if c.Tables {
+		ddl = append(ddl, objects.DatabaseOptions.PrintDatabaseOptions(c)...)
+		for _, schema := range GetNamedSchemas(tableSchema) {
+			ddl = append(ddl, "CREATE SCHEMA "+c.ifNotExists()+c.quote(schema))
+		}
+	}
+
+	// The proto bundle must be created before the tables using its types.
+	if protoNames := GetProtoBundle(tableSchema); c.Tables && len(protoNames) > 0 && c.SpDialect != constants.DIALECT_POSTGRESQL {
+		ddl = append(ddl, PrintProtoBundle(protoNames, c))
+	}
+
+	for _, seqId := range GetSortedSequenceIds(sequenceSchema) {
+		seq := sequenceSchema[seqId]
 		if c.SpDialect == constants.DIALECT_POSTGRESQL {
 			ddl = append(ddl, seq.PGPrintSequence(c))
 		} else {
@@ -672,10 +1491,46 @@ func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence) []
 	tableIds := GetSortedTableIdsBySpName(tableSchema)
 
 	if c.Tables {
+		// Locality groups and placements must exist before the tables using them.
+		for _, lgId := range GetSortedLocalityGroupIds(objects.LocalityGroups) {
+			ddl = append(ddl, objects.LocalityGroups[lgId].PrintLocalityGroup(c))
+		}
+		for _, pId := range GetSortedPlacementIds(objects.Placements) {
+			ddl = append(ddl, objects.Placements[pId].PrintPlacement(c))
+		}
 		for _, tableId := range tableIds {
 			ddl = append(ddl, tableSchema[tableId].PrintCreateTable(tableSchema, c))
-			for _, index := range tableSchema[tableId].Indexes {
-				ddl = append(ddl, index.PrintCreateIndex(tableSchema[tableId], c))
+			for _, index := range GetSortedIndexes(tableSchema[tableId]) {
+				ddl = append(ddl, index.PrintCreateIndex(tableSchema, tableSchema[tableId], c))
+			}
+			for _, index := range tableSchema[tableId].SearchIndexes {
+				ddl = append(ddl, index.PrintSearchIndex(tableSchema[tableId], c))
+			}
+			for _, index := range tableSchema[tableId].VectorIndexes {
+				ddl = append(ddl, index.PrintVectorIndex(tableSchema[tableId], c))
+			}
+			ddl = append(ddl, tableSchema[tableId].PrintCommentStatements(c)...)
+		}
+		// Property graphs and models must exist before the views using them.
+		if c.SpDialect != constants.DIALECT_POSTGRESQL {
+			for _, pgId := range GetSortedPropertyGraphIds(objects.PropertyGraphs) {
+				ddl = append(ddl, objects.PropertyGraphs[pgId].PrintPropertyGraph(tableSchema, c))
+			}
+			for _, mId := range GetSortedModelIds(objects.Models) {
+				ddl = append(ddl, objects.Models[mId].PrintCreateModel(c))
+			}
+		}
+		for _, viewId := range GetSortedViewIds(objects.Views) {
+			ddl = append(ddl, objects.Views[viewId].PrintCreateView(c))
+		}
+		for _, csId := range GetSortedChangeStreamIds(objects.ChangeStreams) {
+			ddl = append(ddl, objects.ChangeStreams[csId].PrintChangeStream(tableSchema, c))
+		}
+		if c.CheckConstraintsAlterTable {
+			for _, t := range tableIds {
+				for _, ck := range tableSchema[t].CheckConstraints {
+					ddl = append(ddl, ck.PrintCheckConstraintAlterTable(tableSchema[t], c))
+				}
 			}
 		}
 	}
@@ -687,7 +1542,7 @@ func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence) []
 	// of circular foreign keys definitions. We opt for simplicity.
 	if c.ForeignKeys {
 		for _, t := range tableIds {
-			for _, fk := range tableSchema[t].ForeignKeys {
+			for _, fk := range GetSortedForeignKeys(tableSchema[t]) {
 				ddl = append(ddl, fk.PrintForeignKeyAlterTable(tableSchema, c, t))
 			}
 		}
@@ -696,6 +1551,617 @@ func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence) []
 	return ddl
 }
 
+// GetDropDDL returns the statements that drop the Spanner schema represented
+// by Schema struct, e.g. to clean up after a failed migration. Statements are
+// ordered so that objects are dropped before the objects they depend on:
+// foreign keys first, then change streams, views, property graphs, models and
+// indexes, then tables (interleaved tables before their parents, and tables
+// before the tables their foreign keys reference), then locality groups and
+// placements and finally sequences and the proto bundle.
+// Unnamed foreign keys can't be dropped on their own and are dropped along
+// with their table.
+func GetDropDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence, objects SchemaObjects) []string {
+	var ddl []string
+	tableIds := GetSortedTableIdsBySpName(tableSchema)
+
+	if c.ForeignKeys {
+		for _, t := range tableIds {
+			for _, fk := range GetSortedForeignKeys(tableSchema[t]) {
+				if fk.Name != "" {
+					ddl = append(ddl, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", c.quote(tableSchema[t].Name), c.quote(fk.Name)))
+				}
+			}
+		}
+	}
+	if c.Tables {
+		for _, csId := range GetSortedChangeStreamIds(objects.ChangeStreams) {
+			ddl = append(ddl, fmt.Sprintf("DROP CHANGE STREAM %s", c.quote(objects.ChangeStreams[csId].Name)))
+		}
+		for _, viewId := range GetSortedViewIds(objects.Views) {
+			ddl = append(ddl, fmt.Sprintf("DROP VIEW %s", c.quote(objects.Views[viewId].Name)))
+		}
+		if c.SpDialect != constants.DIALECT_POSTGRESQL {
+			for _, pgId := range GetSortedPropertyGraphIds(objects.PropertyGraphs) {
+				ddl = append(ddl, fmt.Sprintf("DROP PROPERTY GRAPH %s", c.quote(objects.PropertyGraphs[pgId].Name)))
+			}
+			for _, mId := range GetSortedModelIds(objects.Models) {
+				ddl = append(ddl, fmt.Sprintf("DROP MODEL %s", c.quote(objects.Models[mId].Name)))
+			}
+		}
+		for _, tableId := range tableIds {
+			for _, index := range GetSortedIndexes(tableSchema[tableId]) {
+				ddl = append(ddl, fmt.Sprintf("DROP INDEX %s", c.quote(index.Name)))
+			}
+			for _, index := range tableSchema[tableId].SearchIndexes {
+				ddl = append(ddl, fmt.Sprintf("DROP SEARCH INDEX %s", c.quote(index.Name)))
+			}
+			for _, index := range tableSchema[tableId].VectorIndexes {
+				// PostgreSQL vector indexes are created as ScaNN indexes.
+				if c.SpDialect == constants.DIALECT_POSTGRESQL {
+					ddl = append(ddl, fmt.Sprintf("DROP INDEX %s", c.quote(index.Name)))
+				} else {
+					ddl = append(ddl, fmt.Sprintf("DROP VECTOR INDEX %s", c.quote(index.Name)))
+				}
+			}
+		}
+		// Tables are dropped before the tables they depend on. Those whose
+		// foreign keys form a cycle are dropped in reverse order of name.
+		dropOrder, err := NewDependencyGraph(tableSchema).TopologicalOrder()
+		if err != nil {
+			dropOrder = tableIds
+		}
+		for i := len(dropOrder) - 1; i >= 0; i-- {
+			ddl = append(ddl, fmt.Sprintf("DROP TABLE %s", c.quote(tableSchema[dropOrder[i]].Name)))
+		}
+		for _, lgId := range GetSortedLocalityGroupIds(objects.LocalityGroups) {
+			ddl = append(ddl, fmt.Sprintf("DROP LOCALITY GROUP %s", c.quote(objects.LocalityGroups[lgId].Name)))
+		}
+		for _, pId := range GetSortedPlacementIds(objects.Placements) {
+			ddl = append(ddl, fmt.Sprintf("DROP PLACEMENT %s", c.quote(objects.Placements[pId].Name)))
+		}
+	}
+
+	for _, seqId := range GetSortedSequenceIds(sequenceSchema) {
+		ddl = append(ddl, fmt.Sprintf("DROP SEQUENCE %s", c.quote(sequenceSchema[seqId].Name)))
+	}
+
+	if c.Tables && len(GetProtoBundle(tableSchema)) > 0 && c.SpDialect != constants.DIALECT_POSTGRESQL {
+		ddl = append(ddl, "DROP PROTO BUNDLE")
+	}
+	return ddl
+}
+
+// CreateView encodes the following DDL definition:
+//
+//	create_view: CREATE VIEW view_name SQL SECURITY { INVOKER | DEFINER } AS query
+type CreateView struct {
+	Name         string
+	Id           string
+	SecurityType string // INVOKER or DEFINER. Defaults to INVOKER when empty.
+	Query        string
+	Comment      string
+}
+
+// PrintCreateView unparses a CREATE VIEW statement.
+func (cv CreateView) PrintCreateView(c Config) string {
+	securityType := cv.SecurityType
+	if securityType == "" {
+		securityType = "INVOKER"
+	}
+	var viewComment string
+	if c.Comments && len(cv.Comment) > 0 {
+		viewComment = "--\n-- " + cv.Comment + "\n--\n"
+	}
+	return fmt.Sprintf("%sCREATE VIEW %s SQL SECURITY %s AS %s", viewComment, c.quote(cv.Name), securityType, cv.Query)
+}
+
+// ChangeStream encodes the following DDL definition:
+//
+//	create_change_stream: CREATE CHANGE STREAM change_stream_name
+//	  [ FOR { table_columns [, ... ] | ALL } ]
+//	  [ OPTIONS ( change_stream_option [, ... ] ) ]
+//	table_columns: table_name [ ( [ column_name, ... ] ) ]
+type ChangeStream struct {
+	Id               string
+	Name             string
+	WatchAll         bool                // If true, the change stream watches all tables and columns.
+	WatchedTables    []ChangeStreamTable // Ignored when WatchAll is set.
+	ValueCaptureType string              // e.g. OLD_AND_NEW_VALUES, NEW_ROW, NEW_VALUES.
+	RetentionPeriod  string              // e.g. 7d or 36h.
+}
+
+// ChangeStreamValueCaptureTypes lists the supported values of the
+// value_capture_type change stream option.
+var ChangeStreamValueCaptureTypes = []string{"OLD_AND_NEW_VALUES", "NEW_ROW", "NEW_VALUES", "NEW_ROW_AND_OLD_VALUES"}
+
+// ChangeStreamTable is a table watched by a change stream. When ColIds is
+// empty, all columns of the table are watched.
+type ChangeStreamTable struct {
+	TableId string
+	ColIds  []string
+}
+
+// PrintChangeStream unparses a CREATE CHANGE STREAM statement.
+func (cs ChangeStream) PrintChangeStream(spSchema Schema, c Config) string {
+	s := fmt.Sprintf("CREATE CHANGE STREAM %s", c.quote(cs.Name))
+	if cs.WatchAll {
+		s += " FOR ALL"
+	} else if len(cs.WatchedTables) > 0 {
+		var tables []string
+		for _, wt := range cs.WatchedTables {
+			table := spSchema[wt.TableId]
+			t := c.quote(table.Name)
+			if len(wt.ColIds) > 0 {
+				var cols []string
+				for _, colId := range wt.ColIds {
+					cols = append(cols, c.quote(table.ColDefs[colId].Name))
+				}
+				t += "(" + strings.Join(cols, ", ") + ")"
+			}
+			tables = append(tables, t)
+		}
+		s += " FOR " + strings.Join(tables, ", ")
+	}
+	var options []string
+	if cs.RetentionPeriod != "" {
+		options = append(options, fmt.Sprintf("retention_period = '%s'", cs.RetentionPeriod))
+	}
+	if cs.ValueCaptureType != "" {
+		options = append(options, fmt.Sprintf("value_capture_type = '%s'", cs.ValueCaptureType))
+	}
+	if len(options) > 0 {
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			s += " WITH (" + strings.Join(options, ", ") + ")"
+		} else {
+			s += " OPTIONS (" + strings.Join(options, ", ") + ")"
+		}
+	}
+	return s
+}
+
+// LocalityGroup encodes the following DDL definition:
+//
+//	create_locality_group: CREATE LOCALITY GROUP locality_group_name
+//	  [ OPTIONS ( storage = '{ ssd | hdd }' [, ssd_to_hdd_spill_timespan = 'duration' ] ) ]
+//
+// Tables and columns are placed in a locality group by name, see
+// CreateTable.LocalityGroup and LocalityGroupOpt.
+type LocalityGroup struct {
+	Id                    string
+	Name                  string
+	Storage               string // ssd or hdd.
+	SsdToHddSpillTimespan string // e.g. 10d; data older than this is moved to HDD.
+}
+
+// LocalityGroupStorageTypes lists the supported values of the storage
+// locality group option.
+var LocalityGroupStorageTypes = []string{"ssd", "hdd"}
+
+// PrintLocalityGroup unparses a CREATE LOCALITY GROUP statement.
+func (lg LocalityGroup) PrintLocalityGroup(c Config) string {
+	s := "CREATE LOCALITY GROUP " + c.quote(lg.Name)
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		if lg.Storage != "" {
+			s += fmt.Sprintf(" STORAGE '%s'", lg.Storage)
+		}
+		if lg.SsdToHddSpillTimespan != "" {
+			s += fmt.Sprintf(" SSD_TO_HDD_SPILL_TIMESPAN '%s'", lg.SsdToHddSpillTimespan)
+		}
+		return s
+	}
+	var options []string
+	if lg.Storage != "" {
+		options = append(options, fmt.Sprintf("storage = '%s'", lg.Storage))
+	}
+	if lg.SsdToHddSpillTimespan != "" {
+		options = append(options, fmt.Sprintf("ssd_to_hdd_spill_timespan = '%s'", lg.SsdToHddSpillTimespan))
+	}
+	if len(options) > 0 {
+		s += " OPTIONS (" + strings.Join(options, ", ") + ")"
+	}
+	return s
+}
+
+// Placement encodes the following DDL definition:
+//
+//	create_placement: CREATE PLACEMENT placement_name
+//	  [ OPTIONS ( instance_partition = 'partition_id' [, default_leader = 'region' ] ) ]
+//
+// Rows of tables with a placement key are stored in the instance partition of
+// the placement named by the value of their placement key column.
+type Placement struct {
+	Id                string
+	Name              string
+	InstancePartition string
+	DefaultLeader     string
+}
+
+// PrintPlacement unparses a CREATE PLACEMENT statement.
+func (p Placement) PrintPlacement(c Config) string {
+	s := "CREATE PLACEMENT " + c.quote(p.Name)
+	var options []string
+	if p.InstancePartition != "" {
+		options = append(options, fmt.Sprintf("instance_partition = '%s'", p.InstancePartition))
+	}
+	if p.DefaultLeader != "" {
+		options = append(options, fmt.Sprintf("default_leader = '%s'", p.DefaultLeader))
+	}
+	if len(options) > 0 {
+		if c.SpDialect == constants.DIALECT_POSTGRESQL {
+			s += " WITH (" + strings.Join(options, ", ") + ")"
+		} else {
+			s += " OPTIONS (" + strings.Join(options, ", ") + ")"
+		}
+	}
+	return s
+}
+
+// GetSortedPlacementIds returns the placement ids ordered by placement name.
+func GetSortedPlacementIds(placements map[string]Placement) []string {
+	var ids []string
+	for id := range placements {
+		ids = append(ids, id)
+	}
+	sortIdsByName(ids, func(id string) string { return placements[id].Name })
+	return ids
+}
+
+// Model encodes the following DDL definition:
+//
+//	create_model: CREATE MODEL model_name
+//	  [ INPUT ( column_list ) OUTPUT ( column_list ) ]
+//	  REMOTE
+//	  [ OPTIONS ( model_option [, ... ] ) ]
+//	column_list: column_name data_type [ OPTIONS ( required = { true | false } ) ] [, ... ]
+//	model_option: { endpoint = 'endpoint' | endpoints = [ 'endpoint' [, ... ] ] | default_batch_size = int64 }
+//
+// Models are remote Vertex AI models used in queries through ML.PREDICT.
+// They are only supported by GoogleSQL databases.
+type Model struct {
+	Id   string
+	Name string
+	// Inputs and Outputs are either both set or both empty, in which case
+	// Spanner reads the columns from the endpoint.
+	Inputs           []ModelColumn
+	Outputs          []ModelColumn
+	Endpoint         string   // e.g. //aiplatform.googleapis.com/projects/p/locations/us-central1/endpoints/e
+	Endpoints        []string // Endpoints requests are load balanced across, instead of Endpoint.
+	DefaultBatchSize int64    // Omitted when 0.
+}
+
+// ModelColumn is an input or output column of a model.
+type ModelColumn struct {
+	Name     string
+	T        Type
+	Optional bool // Printed as OPTIONS (required = false).
+}
+
+// PrintCreateModel unparses a CREATE MODEL statement.
+func (m Model) PrintCreateModel(c Config) string {
+	s := fmt.Sprintf("CREATE MODEL %s%s", c.ifNotExists(), c.quote(m.Name))
+	if len(m.Inputs) > 0 || len(m.Outputs) > 0 {
+		s += fmt.Sprintf(" INPUT (%s) OUTPUT (%s)", printModelColumns(m.Inputs, c), printModelColumns(m.Outputs, c))
+	}
+	s += " REMOTE"
+	var options []string
+	if m.Endpoint != "" {
+		options = append(options, fmt.Sprintf("endpoint = '%s'", m.Endpoint))
+	}
+	if len(m.Endpoints) > 0 {
+		var endpoints []string
+		for _, e := range m.Endpoints {
+			endpoints = append(endpoints, "'"+e+"'")
+		}
+		options = append(options, fmt.Sprintf("endpoints = [%s]", strings.Join(endpoints, ", ")))
+	}
+	if m.DefaultBatchSize > 0 {
+		options = append(options, fmt.Sprintf("default_batch_size = %d", m.DefaultBatchSize))
+	}
+	if len(options) > 0 {
+		s += " OPTIONS (" + strings.Join(options, ", ") + ")"
+	}
+	return s
+}
+
+func printModelColumns(cols []ModelColumn, c Config) string {
+	var s []string
+	for _, col := range cols {
+		def := fmt.Sprintf("%s %s", c.quote(col.Name), col.T.PrintColumnDefType())
+		if col.Optional {
+			def += " OPTIONS (required = false)"
+		}
+		s = append(s, def)
+	}
+	return strings.Join(s, ", ")
+}
+
+// GetSortedModelIds returns the model ids ordered by model name.
+func GetSortedModelIds(models map[string]Model) []string {
+	var ids []string
+	for id := range models {
+		ids = append(ids, id)
+	}
+	sortIdsByName(ids, func(id string) string { return models[id].Name })
+	return ids
+}
+
+// PropertyGraph encodes the following DDL definition:
+//
+//	create_property_graph: CREATE PROPERTY GRAPH graph_name
+//	  NODE TABLES ( element_table [, ... ] )
+//	  [ EDGE TABLES ( element_table [, ... ] ) ]
+//	element_table: table_name [ AS element_name ] [ KEY ( column [, ... ] ) ]
+//	  [ SOURCE KEY ( column [, ... ] ) REFERENCES node_element ( column [, ... ] )
+//	    DESTINATION KEY ( column [, ... ] ) REFERENCES node_element ( column [, ... ] ) ]
+//	  [ label_and_properties ... ]
+//	label_and_properties: { DEFAULT LABEL | LABEL label_name }
+//	  [ PROPERTIES ALL COLUMNS | PROPERTIES ( column [, ... ] ) | NO PROPERTIES ]
+//
+// Property graphs are only supported by GoogleSQL databases.
+type PropertyGraph struct {
+	Id         string
+	Name       string
+	NodeTables []GraphElementTable
+	EdgeTables []GraphElementTable
+}
+
+// GraphElementTable is a node or edge table of a property graph.
+type GraphElementTable struct {
+	TableId   string
+	Alias     string   // Name of the element, if different from the table name.
+	KeyColIds []string // Defaults to the primary key of the table when empty.
+	// Source and Destination are set for edge tables only.
+	Source      GraphNodeReference
+	Destination GraphNodeReference
+	// When empty, the element has a default label exposing all columns.
+	Labels []GraphLabel
+}
+
+// GraphNodeReference references the node an edge starts or ends at: ColIds
+// of the edge table reference NodeColIds of the node table NodeTableId.
+type GraphNodeReference struct {
+	ColIds      []string
+	NodeTableId string
+	NodeColIds  []string
+}
+
+// GraphLabel is a label of a graph element along with the columns exposed
+// as its properties.
+type GraphLabel struct {
+	Name           string   // The default label when empty.
+	PropertyColIds []string // All columns when empty, unless NoProperties is set.
+	NoProperties   bool
+}
+
+// elementName returns the name of the element in the graph.
+func (e GraphElementTable) elementName(spSchema Schema) string {
+	if e.Alias != "" {
+		return e.Alias
+	}
+	return spSchema[e.TableId].Name
+}
+
+// PrintPropertyGraph unparses a CREATE PROPERTY GRAPH statement.
+func (pg PropertyGraph) PrintPropertyGraph(spSchema Schema, c Config) string {
+	s := fmt.Sprintf("CREATE PROPERTY GRAPH %s%s\n\tNODE TABLES (\n\t\t%s\n\t)", c.ifNotExists(), c.quote(pg.Name), pg.printElements(pg.NodeTables, spSchema, c))
+	if len(pg.EdgeTables) > 0 {
+		s += fmt.Sprintf("\n\tEDGE TABLES (\n\t\t%s\n\t)", pg.printElements(pg.EdgeTables, spSchema, c))
+	}
+	return s
+}
+
+func (pg PropertyGraph) printElements(elements []GraphElementTable, spSchema Schema, c Config) string {
+	quoteCols := func(table CreateTable, colIds []string) string {
+		var cols []string
+		for _, colId := range colIds {
+			cols = append(cols, c.quote(table.ColDefs[colId].Name))
+		}
+		return "(" + strings.Join(cols, ", ") + ")"
+	}
+	nodeName := func(tableId string) string {
+		for _, n := range pg.NodeTables {
+			if n.TableId == tableId {
+				return n.elementName(spSchema)
+			}
+		}
+		return spSchema[tableId].Name
+	}
+	var printed []string
+	for _, e := range elements {
+		table := spSchema[e.TableId]
+		s := c.quote(table.Name)
+		if e.Alias != "" {
+			s += " AS " + c.quote(e.Alias)
+		}
+		if len(e.KeyColIds) > 0 {
+			s += " KEY " + quoteCols(table, e.KeyColIds)
+		}
+		if e.Source.NodeTableId != "" {
+			s += fmt.Sprintf(" SOURCE KEY %s REFERENCES %s %s", quoteCols(table, e.Source.ColIds), c.quote(nodeName(e.Source.NodeTableId)), quoteCols(spSchema[e.Source.NodeTableId], e.Source.NodeColIds))
+			s += fmt.Sprintf(" DESTINATION KEY %s REFERENCES %s %s", quoteCols(table, e.Destination.ColIds), c.quote(nodeName(e.Destination.NodeTableId)), quoteCols(spSchema[e.Destination.NodeTableId], e.Destination.NodeColIds))
+		}
+		for _, l := range e.Labels {
+			if l.Name == "" {
+				s += " DEFAULT LABEL"
+			} else {
+				s += " LABEL " + c.quote(l.Name)
+			}
+			switch {
+			case l.NoProperties:
+				s += " NO PROPERTIES"
+			case len(l.PropertyColIds) > 0:
+				s += " PROPERTIES " + quoteCols(table, l.PropertyColIds)
+			default:
+				s += " PROPERTIES ALL COLUMNS"
+			}
+		}
+		printed = append(printed, s)
+	}
+	return strings.Join(printed, ",\n\t\t")
+}
+
+// GetSortedPropertyGraphIds returns the property graph ids ordered by graph name.
+func GetSortedPropertyGraphIds(graphs map[string]PropertyGraph) []string {
+	var ids []string
+	for id := range graphs {
+		ids = append(ids, id)
+	}
+	sortIdsByName(ids, func(id string) string { return graphs[id].Name })
+	return ids
+}
+
+// GetSortedLocalityGroupIds returns the locality group ids ordered by
+// locality group name.
+func GetSortedLocalityGroupIds(localityGroups map[string]LocalityGroup) []string {
+	var ids []string
+	for id := range localityGroups {
+		ids = append(ids, id)
+	}
+	sortIdsByName(ids, func(id string) string { return localityGroups[id].Name })
+	return ids
+}
+
+// SchemaObjects holds the schema objects, other than tables and sequences,
+// that GetDDL prints.
+type SchemaObjects struct {
+	Views           map[string]CreateView    // Maps view id to view definition.
+	ChangeStreams   map[string]ChangeStream  // Maps change stream id to change stream definition.
+	LocalityGroups  map[string]LocalityGroup // Maps locality group id to locality group definition.
+	Placements      map[string]Placement     // Maps placement id to placement definition.
+	Models          map[string]Model         // Maps model id to model definition.
+	PropertyGraphs  map[string]PropertyGraph // Maps property graph id to property graph definition.
+	DatabaseOptions DatabaseOptions          // Options of the database the schema is created in.
+}
+
+// DatabaseOptions encodes the following DDL definition:
+//
+//	alter_database: ALTER DATABASE database_id SET OPTIONS ( options_def )
+//
+//	options_def:
+//	  { version_retention_period = { 'duration' | null } |
+//	    default_leader = { 'region' | null } |
+//	    default_sequence_kind = { 'bit_reversed_positive' | null } } [, ... ]
+type DatabaseOptions struct {
+	DatabaseName           string
+	VersionRetentionPeriod string // e.g. 7d or 36h.
+	DefaultLeader          string // e.g. us-central1.
+	DefaultSequenceKind    string // e.g. bit_reversed_positive.
+}
+
+// PrintDatabaseOptions unparses the ALTER DATABASE statements that set the
+// database options. PostgreSQL sets each option in a separate statement.
+// Nothing is printed if no option is set.
+func (opts DatabaseOptions) PrintDatabaseOptions(c Config) []string {
+	var names, values []string
+	for _, o := range []struct{ name, value string }{
+		{"default_leader", opts.DefaultLeader},
+		{"default_sequence_kind", opts.DefaultSequenceKind},
+		{"version_retention_period", opts.VersionRetentionPeriod},
+	} {
+		if o.value != "" {
+			names = append(names, o.name)
+			values = append(values, "'"+o.value+"'")
+		}
+	}
+	if opts.DatabaseName == "" || len(names) == 0 {
+		return nil
+	}
+	var stmts []string
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		for i := range names {
+			stmts = append(stmts, fmt.Sprintf("ALTER DATABASE \"%s\" SET spanner.%s = %s", opts.DatabaseName, names[i], values[i]))
+		}
+		return stmts
+	}
+	var options []string
+	for i := range names {
+		options = append(options, fmt.Sprintf("%s = %s", names[i], values[i]))
+	}
+	return []string{fmt.Sprintf("ALTER DATABASE `%s` SET OPTIONS (%s)", opts.DatabaseName, strings.Join(options, ", "))}
+}
+
+// GetSortedChangeStreamIds returns the change stream ids ordered by change stream name.
+func GetSortedChangeStreamIds(changeStreams map[string]ChangeStream) []string {
+	var ids []string
+	for id := range changeStreams {
+		ids = append(ids, id)
+	}
+	sortIdsByName(ids, func(id string) string { return changeStreams[id].Name })
+	return ids
+}
+
+// sortIdsByName sorts object ids by object name, and by id for objects with
+// the same name, so that the order doesn't depend on map iteration order.
+func sortIdsByName(ids []string, name func(id string) string) {
+	sort.Slice(ids, func(i, j int) bool {
+		if ni, nj := name(ids[i]), name(ids[j]); ni != nj {
+			return ni < nj
+		}
+		return ids[i] < ids[j]
+	})
+}
+
+// GetSortedSequenceIds returns the sequence ids ordered by sequence name.
+func GetSortedSequenceIds(sequences map[string]Sequence) []string {
+	var ids []string
+	for id := range sequences {
+		ids = append(ids, id)
+	}
+	sortIdsByName(ids, func(id string) string { return sequences[id].Name })
+	return ids
+}
+
+// GetSortedIndexes returns the indexes of the table ordered by index name.
+func GetSortedIndexes(ct CreateTable) []CreateIndex {
+	indexes := append([]CreateIndex{}, ct.Indexes...)
+	sort.SliceStable(indexes, func(i, j int) bool { return indexes[i].Name < indexes[j].Name })
+	return indexes
+}
+
+// GetSortedForeignKeys returns the foreign keys of the table ordered by
+// foreign key name. Unnamed foreign keys come first, in their table order.
+func GetSortedForeignKeys(ct CreateTable) []Foreignkey {
+	fks := append([]Foreignkey{}, ct.ForeignKeys...)
+	sort.SliceStable(fks, func(i, j int) bool { return fks[i].Name < fks[j].Name })
+	return fks
+}
+
+// GetSortedViewIds returns the view ids ordered by view name.
+func GetSortedViewIds(views map[string]CreateView) []string {
+	var viewIds []string
+	for id := range views {
+		viewIds = append(viewIds, id)
+	}
+	sortIdsByName(viewIds, func(id string) string { return views[id].Name })
+	return viewIds
+}
+
+// GetProtoBundle returns the sorted, distinct names of the proto messages and
+// enums used by the PROTO and ENUM columns of the schema.
+func GetProtoBundle(s Schema) []string {
+	seen := make(map[string]bool)
+	var protoNames []string
+	for _, ct := range s {
+		for _, cd := range ct.ColDefs {
+			if (cd.T.Name == Proto || cd.T.Name == Enum) && cd.T.ProtoName != "" && !seen[cd.T.ProtoName] {
+				seen[cd.T.ProtoName] = true
+				protoNames = append(protoNames, cd.T.ProtoName)
+			}
+		}
+	}
+	sort.Strings(protoNames)
+	return protoNames
+}
+
+// PrintProtoBundle unparses a CREATE PROTO BUNDLE statement.
+func PrintProtoBundle(protoNames []string, c Config) string {
+	var names []string
+	for _, name := range protoNames {
+		names = append(names, c.quoteIdentifier(name))
+	}
+	return fmt.Sprintf("CREATE PROTO BUNDLE (%s)", strings.Join(names, ", "))
+}
+
 // CheckInterleaved checks if schema contains interleaved tables.
 func (s Schema) CheckInterleaved() bool {
 	for _, table := range s {
@@ -724,6 +2190,13 @@ type Sequence struct {
 	SkipRangeMax     string
 	StartWithCounter string
 	ColumnsUsingSeq  map[string][]string
+	// Options of source sequences that Spanner sequences don't support
+	// directly; see internal.ToSpannerSequence for how they are mapped.
+	MinValue  string
+	MaxValue  string
+	Increment string
+	CacheSize string
+	Cycle     bool
 }
 
 func (seq Sequence) PrintSequence(c Config) string {
@@ -743,7 +2216,7 @@ func (seq Sequence) PrintSequence(c Config) string {
 		options = append(options, fmt.Sprintf("start_with_counter = %s", seq.StartWithCounter))
 	}
 
-	seqDDL := fmt.Sprintf("CREATE SEQUENCE %s", c.quote(seq.Name))
+	seqDDL := fmt.Sprintf("CREATE SEQUENCE %s%s", c.ifNotExists(), c.quote(seq.Name))
 	if len(options) > 0 {
 		seqDDL += " OPTIONS (" + strings.Join(options, ", ") + ") "
 	}
@@ -765,7 +2238,7 @@ func (seq Sequence) PGPrintSequence(c Config) string {
 		options = append(options, fmt.Sprintf("START COUNTER WITH %s", seq.StartWithCounter))
 	}
 
-	seqDDL := fmt.Sprintf("CREATE SEQUENCE %s", c.quote(seq.Name))
+	seqDDL := fmt.Sprintf("CREATE SEQUENCE %s%s", c.ifNotExists(), c.quote(seq.Name))
 	if len(options) > 0 {
 		seqDDL += strings.Join(options, " ")
 	}
@@ -15,6 +15,7 @@
 package ddl
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
@@ -98,6 +99,79 @@ func TestPrintColumnDef(t *testing.T) {
 			},
 			expected: "col1 INT64 OPTIONS (cassandra_type = 'bigint')",
 		},
+		{
+			in: ColumnDef{
+				Name:    "last_modified",
+				T:       Type{Name: Timestamp},
+				NotNull: true,
+				Opts:    map[string]string{AllowCommitTimestampOpt: "true"},
+			},
+			expected: "last_modified TIMESTAMP NOT NULL  OPTIONS (allow_commit_timestamp = true)",
+		},
+		{
+			in: ColumnDef{
+				Name: "col1",
+				T:    Type{Name: Int64},
+				Opts: map[string]string{AllowCommitTimestampOpt: "true"},
+			},
+			expected: "col1 INT64",
+		},
+		{
+			in: ColumnDef{
+				Name: "col1",
+				T:    Type{Name: Timestamp},
+				Opts: map[string]string{LocalityGroupOpt: "archive", AllowCommitTimestampOpt: "true", CassandraTypeOpt: "timestamp", "unknown": "x"},
+			},
+			expected: "col1 TIMESTAMP OPTIONS (cassandra_type = 'timestamp', allow_commit_timestamp = true, locality_group = 'archive')",
+		},
+		{
+			in: ColumnDef{
+				Name:   "shard_id",
+				T:      Type{Name: String, Len: 50},
+				Hidden: true,
+				Opts:   map[string]string{LocalityGroupOpt: "archive"},
+			},
+			expected: "shard_id STRING(50) HIDDEN OPTIONS (locality_group = 'archive')",
+		},
+		{
+			in: ColumnDef{
+				Name: "col1",
+				T:    Type{Name: Int64},
+				GeneratedColumn: GeneratedColumn{
+					IsPresent: true,
+					Value:     Expression{Statement: "col2 + 1"},
+					Type:      GeneratedStored,
+				},
+			},
+			expected: "col1 INT64 AS (col2 + 1) STORED",
+		},
+		{
+			in: ColumnDef{
+				Name: "col1",
+				T:    Type{Name: Int64},
+				GeneratedColumn: GeneratedColumn{
+					IsPresent: true,
+					Value:     Expression{Statement: "col2 + 1"},
+					Type:      GeneratedVirtual,
+				},
+			},
+			expected: "col1 INT64 AS (col2 + 1)",
+		},
+		{
+			in: ColumnDef{
+				Name: "col1",
+				T:    Type{Name: Int64},
+				DefaultValue: DefaultValue{
+					IsPresent: true,
+					Value:     Expression{Statement: "0"},
+				},
+				GeneratedColumn: GeneratedColumn{
+					IsPresent: true,
+					Value:     Expression{Statement: "col2 + 1"},
+				},
+			},
+			expected: "col1 INT64 AS (col2 + 1) STORED",
+		},
 	}
 	for _, tc := range tests {
 		s, _ := tc.in.PrintColumnDef(Config{ProtectIds: tc.protectIds})
@@ -112,10 +186,15 @@ func TestPrintColumnDefPG(t *testing.T) {
 		expected   string
 	}{
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}}, expected: "col1 INT8"},
-		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}}, expected: "col1 VARCHAR(2621440)"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}}, expected: "col1 INT8[]"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: String, Len: 10, IsArray: true}}, expected: "col1 VARCHAR(10)[]"},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}, NotNull: true}, expected: "col1 INT8 NOT NULL "},
-		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}, NotNull: true}, expected: "col1 VARCHAR(2621440) NOT NULL "},
+		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}, NotNull: true}, expected: "col1 INT8[] NOT NULL "},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}}, protectIds: true, expected: "col1 INT8"},
+		{in: ColumnDef{Name: "last_modified", T: Type{Name: Timestamp}, Opts: map[string]string{AllowCommitTimestampOpt: "true"}}, expected: "last_modified SPANNER.COMMIT_TIMESTAMP"},
+		{in: ColumnDef{Name: "shard_id", T: Type{Name: String, Len: 50}, Hidden: true}, expected: "shard_id VARCHAR(50) HIDDEN"},
+		{in: ColumnDef{Name: "amount", T: Type{Name: Numeric, Precision: 10, Scale: 2}}, expected: "amount NUMERIC(10,2)"},
+		{in: ColumnDef{Name: "amounts", T: Type{Name: Numeric, Precision: 10, IsArray: true}}, expected: "amounts NUMERIC(10,0)[]"},
 		{
 			in: ColumnDef{
 				Name: "col1",
@@ -127,6 +206,18 @@ func TestPrintColumnDefPG(t *testing.T) {
 			},
 			expected: "col1 INT8 DEFAULT ((`col2` + 1))",
 		},
+		{
+			in: ColumnDef{
+				Name: "col1",
+				T:    Type{Name: Int64},
+				GeneratedColumn: GeneratedColumn{
+					IsPresent: true,
+					Value:     Expression{Statement: "col2 + 1"},
+					Type:      GeneratedStored,
+				},
+			},
+			expected: "col1 INT8 GENERATED ALWAYS AS (col2 + 1) STORED",
+		},
 	}
 	for _, tc := range tests {
 		s, _ := tc.in.PrintColumnDef(Config{ProtectIds: tc.protectIds, SpDialect: constants.DIALECT_POSTGRESQL})
@@ -348,6 +439,346 @@ func TestPrintCreateTable(t *testing.T) {
 	}
 }
 
+func TestPrintCreateTableSynonym(t *testing.T) {
+	ct := CreateTable{
+		Name:        "singers",
+		Id:          "t1",
+		ColIds:      []string{"c1"},
+		ColDefs:     map[string]ColumnDef{"c1": {Name: "singer_id", Id: "c1", T: Type{Name: Int64}, NotNull: true}},
+		PrimaryKeys: []IndexKey{{ColId: "c1"}},
+		Synonym:     "Artists",
+	}
+	s := Schema{"t1": ct}
+	assert.Equal(t, "CREATE TABLE singers (\n\tsinger_id INT64 NOT NULL ,\n\tSYNONYM(Artists),\n) PRIMARY KEY (singer_id)", ct.PrintCreateTable(s, Config{}))
+	assert.Equal(t, "CREATE TABLE singers (\n\tsinger_id INT8 NOT NULL ,\n\tSYNONYM(Artists),\n\tPRIMARY KEY (singer_id)\n)", ct.PrintCreateTable(s, Config{SpDialect: constants.DIALECT_POSTGRESQL}))
+	assert.Equal(t, "ALTER TABLE `singers` ADD SYNONYM `Artists`", ct.PrintAddSynonym(Config{ProtectIds: true}))
+}
+
+func TestPrintLocalityGroup(t *testing.T) {
+	hdd := LocalityGroup{Id: "lg1", Name: "archive", Storage: "hdd"}
+	tiered := LocalityGroup{Id: "lg2", Name: "recent", Storage: "ssd", SsdToHddSpillTimespan: "30d"}
+	assert.Equal(t, "CREATE LOCALITY GROUP archive OPTIONS (storage = 'hdd')", hdd.PrintLocalityGroup(Config{}))
+	assert.Equal(t, "CREATE LOCALITY GROUP recent OPTIONS (storage = 'ssd', ssd_to_hdd_spill_timespan = '30d')", tiered.PrintLocalityGroup(Config{}))
+	assert.Equal(t, "CREATE LOCALITY GROUP recent STORAGE 'ssd' SSD_TO_HDD_SPILL_TIMESPAN '30d'", tiered.PrintLocalityGroup(Config{SpDialect: constants.DIALECT_POSTGRESQL}))
+	assert.Equal(t, "CREATE LOCALITY GROUP archive", LocalityGroup{Name: "archive"}.PrintLocalityGroup(Config{}))
+
+	ct := CreateTable{
+		Name:   "orders",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+			"c2": {Name: "receipt", Id: "c2", T: Type{Name: Bytes, Len: MaxLength}, Opts: map[string]string{LocalityGroupOpt: "archive"}},
+		},
+		PrimaryKeys:   []IndexKey{{ColId: "c1"}},
+		LocalityGroup: "recent",
+	}
+	s := Schema{"t1": ct}
+	assert.Equal(t, "CREATE TABLE orders (\n\tid INT64 NOT NULL ,\n\treceipt BYTES(MAX) OPTIONS (locality_group = 'archive'),\n) PRIMARY KEY (id),\nOPTIONS (locality_group = 'recent')", ct.PrintCreateTable(s, Config{}))
+	assert.Equal(t, "CREATE TABLE orders (\n\tid INT8 NOT NULL ,\n\treceipt BYTEA LOCALITY GROUP archive,\n\tPRIMARY KEY (id)\n) LOCALITY GROUP recent", ct.PrintCreateTable(s, Config{SpDialect: constants.DIALECT_POSTGRESQL}))
+
+	objects := SchemaObjects{LocalityGroups: map[string]LocalityGroup{"lg1": hdd, "lg2": tiered}}
+	ddl := GetDDL(Config{Tables: true}, s, map[string]Sequence{}, objects)
+	assert.Equal(t, []string{hdd.PrintLocalityGroup(Config{}), tiered.PrintLocalityGroup(Config{}), ct.PrintCreateTable(s, Config{Tables: true})}, ddl)
+	assert.Equal(t, []string{"DROP TABLE orders", "DROP LOCALITY GROUP archive", "DROP LOCALITY GROUP recent"}, GetDropDDL(Config{Tables: true}, s, map[string]Sequence{}, objects))
+}
+
+func TestPrintPlacement(t *testing.T) {
+	europe := Placement{Id: "p1", Name: "europe", InstancePartition: "europe-partition", DefaultLeader: "europe-west1"}
+	asia := Placement{Id: "p2", Name: "asia", InstancePartition: "asia-partition"}
+	assert.Equal(t, "CREATE PLACEMENT europe OPTIONS (instance_partition = 'europe-partition', default_leader = 'europe-west1')", europe.PrintPlacement(Config{}))
+	assert.Equal(t, "CREATE PLACEMENT asia WITH (instance_partition = 'asia-partition')", asia.PrintPlacement(Config{SpDialect: constants.DIALECT_POSTGRESQL}))
+
+	ct := CreateTable{
+		Name:   "customers",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+			"c2": {Name: "region", Id: "c2", T: Type{Name: String, Len: MaxLength}, NotNull: true},
+		},
+		PrimaryKeys:  []IndexKey{{ColId: "c1"}},
+		PlacementKey: "c2",
+	}
+	s := Schema{"t1": ct}
+	assert.Equal(t, "CREATE TABLE customers (\n\tid INT64 NOT NULL ,\n\tregion STRING(MAX) NOT NULL PLACEMENT KEY,\n) PRIMARY KEY (id)", ct.PrintCreateTable(s, Config{}))
+
+	objects := SchemaObjects{Placements: map[string]Placement{"p1": europe, "p2": asia}}
+	ddl := GetDDL(Config{Tables: true}, s, map[string]Sequence{}, objects)
+	assert.Equal(t, []string{asia.PrintPlacement(Config{}), europe.PrintPlacement(Config{}), ct.PrintCreateTable(s, Config{Tables: true})}, ddl)
+	assert.Equal(t, []string{"DROP TABLE customers", "DROP PLACEMENT asia", "DROP PLACEMENT europe"}, GetDropDDL(Config{Tables: true}, s, map[string]Sequence{}, objects))
+}
+
+func TestPrintCreateModel(t *testing.T) {
+	sentiment := Model{
+		Id:               "ml1",
+		Name:             "sentiment",
+		Inputs:           []ModelColumn{{Name: "text", T: Type{Name: String, Len: MaxLength}}},
+		Outputs:          []ModelColumn{{Name: "score", T: Type{Name: Float64}}, {Name: "label", T: Type{Name: String, Len: MaxLength}, Optional: true}},
+		Endpoint:         "//aiplatform.googleapis.com/projects/p/locations/us-central1/endpoints/e",
+		DefaultBatchSize: 8,
+	}
+	embeddings := Model{Id: "ml2", Name: "embeddings", Endpoints: []string{"//aiplatform.googleapis.com/a", "//aiplatform.googleapis.com/b"}}
+	assert.Equal(t, "CREATE MODEL sentiment INPUT (text STRING(MAX)) OUTPUT (score FLOAT64, label STRING(MAX) OPTIONS (required = false)) REMOTE OPTIONS (endpoint = '//aiplatform.googleapis.com/projects/p/locations/us-central1/endpoints/e', default_batch_size = 8)", sentiment.PrintCreateModel(Config{}))
+	assert.Equal(t, "CREATE MODEL IF NOT EXISTS `embeddings` REMOTE OPTIONS (endpoints = ['//aiplatform.googleapis.com/a', '//aiplatform.googleapis.com/b'])", embeddings.PrintCreateModel(Config{ProtectIds: true, IfNotExists: true}))
+
+	view := CreateView{Id: "v1", Name: "scores", Query: "SELECT * FROM ML.PREDICT(MODEL sentiment, (SELECT 'x' AS text))"}
+	objects := SchemaObjects{Models: map[string]Model{"ml1": sentiment, "ml2": embeddings}, Views: map[string]CreateView{"v1": view}}
+	assert.Equal(t, []string{embeddings.PrintCreateModel(Config{}), sentiment.PrintCreateModel(Config{}), view.PrintCreateView(Config{})}, GetDDL(Config{Tables: true}, Schema{}, map[string]Sequence{}, objects))
+	assert.Equal(t, []string{"DROP VIEW scores", "DROP MODEL embeddings", "DROP MODEL sentiment"}, GetDropDDL(Config{Tables: true}, Schema{}, map[string]Sequence{}, objects))
+	pg := Config{Tables: true, SpDialect: constants.DIALECT_POSTGRESQL}
+	assert.Equal(t, []string{view.PrintCreateView(pg)}, GetDDL(pg, Schema{}, map[string]Sequence{}, objects))
+}
+
+func TestPrintPropertyGraph(t *testing.T) {
+	s := Schema{
+		"t1": {Name: "Person", Id: "t1", ColIds: []string{"c1", "c2"}, ColDefs: map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}}, "c2": {Name: "name", Id: "c2", T: Type{Name: String, Len: MaxLength}}}, PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}}},
+		"t2": {Name: "Knows", Id: "t2", ColIds: []string{"c3", "c4"}, ColDefs: map[string]ColumnDef{"c3": {Name: "id", Id: "c3", T: Type{Name: Int64}}, "c4": {Name: "other_id", Id: "c4", T: Type{Name: Int64}}}, PrimaryKeys: []IndexKey{{ColId: "c3", Order: 1}, {ColId: "c4", Order: 2}}},
+	}
+	pg := PropertyGraph{
+		Id:         "gr1",
+		Name:       "FriendGraph",
+		NodeTables: []GraphElementTable{{TableId: "t1", Alias: "People", Labels: []GraphLabel{{Name: "Person", PropertyColIds: []string{"c2"}}, {}}}},
+		EdgeTables: []GraphElementTable{{
+			TableId:     "t2",
+			KeyColIds:   []string{"c3", "c4"},
+			Source:      GraphNodeReference{ColIds: []string{"c3"}, NodeTableId: "t1", NodeColIds: []string{"c1"}},
+			Destination: GraphNodeReference{ColIds: []string{"c4"}, NodeTableId: "t1", NodeColIds: []string{"c1"}},
+			Labels:      []GraphLabel{{Name: "Knows", NoProperties: true}},
+		}},
+	}
+	expected := "CREATE PROPERTY GRAPH FriendGraph\n" +
+		"\tNODE TABLES (\n" +
+		"\t\tPerson AS People LABEL Person PROPERTIES (name) DEFAULT LABEL PROPERTIES ALL COLUMNS\n" +
+		"\t)\n" +
+		"\tEDGE TABLES (\n" +
+		"\t\tKnows KEY (id, other_id) SOURCE KEY (id) REFERENCES People (id) DESTINATION KEY (other_id) REFERENCES People (id) LABEL Knows NO PROPERTIES\n" +
+		"\t)"
+	assert.Equal(t, expected, pg.PrintPropertyGraph(s, Config{}))
+
+	objects := SchemaObjects{PropertyGraphs: map[string]PropertyGraph{"gr1": pg}}
+	ddl := GetDDL(Config{Tables: true}, s, map[string]Sequence{}, objects)
+	assert.Equal(t, expected, ddl[len(ddl)-1])
+	assert.Equal(t, []string{"DROP PROPERTY GRAPH FriendGraph", "DROP TABLE Person", "DROP TABLE Knows"}, GetDropDDL(Config{Tables: true}, s, map[string]Sequence{}, objects))
+	assert.Equal(t, 2, len(GetDDL(Config{Tables: true, SpDialect: constants.DIALECT_POSTGRESQL}, s, map[string]Sequence{}, objects)))
+}
+
+func TestQuoteReservedOnly(t *testing.T) {
+	c := Config{ProtectIds: true, QuoteReservedOnly: true}
+	assert.Equal(t, "singers", c.Quote("singers"))
+	assert.Equal(t, "_tmp", c.Quote("_tmp"))
+	assert.Equal(t, "`order`", c.Quote("order"))
+	assert.Equal(t, "`Group`", c.Quote("Group"))
+	assert.Equal(t, "`2fa`", c.Quote("2fa"))
+	assert.Equal(t, "`singers`", Config{ProtectIds: true}.Quote("singers"))
+	assert.Equal(t, "order", Config{QuoteReservedOnly: true}.Quote("order"))
+
+	ct := CreateTable{
+		Name:        "orders",
+		Id:          "t1",
+		ColIds:      []string{"c1", "c2"},
+		ColDefs:     map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}}, "c2": {Name: "from", Id: "c2", T: Type{Name: Date}}},
+		PrimaryKeys: []IndexKey{{ColId: "c1"}},
+	}
+	assert.Equal(t, "CREATE TABLE orders (\n\tid INT64,\n\t`from` DATE,\n) PRIMARY KEY (id)", ct.PrintCreateTable(Schema{"t1": ct}, c))
+}
+
+func TestValidateIdentifiers(t *testing.T) {
+	assert.Nil(t, ValidateIdentifier("singers_2"))
+	assert.NotNil(t, ValidateIdentifier(""))
+	assert.NotNil(t, ValidateIdentifier("_singers"))
+	assert.NotNil(t, ValidateIdentifier("singer-name"))
+	assert.NotNil(t, ValidateIdentifier(strings.Repeat("a", 129)))
+
+	s := Schema{
+		"t1": {
+			Name:             "orders",
+			Id:               "t1",
+			ColIds:           []string{"c1", "c2"},
+			ColDefs:          map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}}, "c2": {Name: "order date", Id: "c2", T: Type{Name: Date}}},
+			Indexes:          []CreateIndex{{Name: "1st_index", TableId: "t1", Keys: []IndexKey{{ColId: "c2"}}}},
+			CheckConstraints: []CheckConstraint{{Name: "id_check", Expr: "(id > 0)"}},
+		},
+	}
+	sequences := map[string]Sequence{"s1": {Id: "s1", Name: "seq$1"}}
+	assert.Equal(t, []string{
+		"invalid column name: name order date must start with a letter and contain only letters, digits and underscores",
+		"invalid index name: name 1st_index must start with a letter and contain only letters, digits and underscores",
+		"invalid sequence name: name seq$1 must start with a letter and contain only letters, digits and underscores",
+	}, ValidateIdentifiers(s, sequences, SchemaObjects{}))
+}
+
+func TestGetDDLNamedSchemas(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:        "sales.orders",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}}, "c2": {Name: "total", Id: "c2", T: Type{Name: Numeric}}},
+			PrimaryKeys: []IndexKey{{ColId: "c1"}},
+			Indexes:     []CreateIndex{{Name: "sales.orders_by_total", TableId: "t1", Keys: []IndexKey{{ColId: "c2"}}}},
+		},
+		"t2": {
+			Name:        "customers",
+			Id:          "t2",
+			ColIds:      []string{"c1"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}}},
+			PrimaryKeys: []IndexKey{{ColId: "c1"}},
+		},
+	}
+	assert.Equal(t, "", NamedSchema("customers"))
+	assert.Equal(t, "sales", NamedSchema("sales.orders"))
+	assert.Equal(t, []string{"sales"}, GetNamedSchemas(s))
+	assert.Equal(t, []string{
+		"CREATE SCHEMA `sales`",
+		"CREATE TABLE `customers` (\n\t`id` INT64,\n) PRIMARY KEY (`id`)",
+		"CREATE TABLE `sales`.`orders` (\n\t`id` INT64,\n\t`total` NUMERIC,\n) PRIMARY KEY (`id`)",
+		"CREATE INDEX `sales`.`orders_by_total` ON `sales`.`orders` (`total`)",
+	}, GetDDL(Config{Tables: true, ProtectIds: true}, s, map[string]Sequence{}, SchemaObjects{}))
+	assert.Equal(t, "CREATE SCHEMA IF NOT EXISTS sales", GetDDL(Config{Tables: true, IfNotExists: true}, s, map[string]Sequence{}, SchemaObjects{})[0])
+	assert.Equal(t, `"sales"."orders"`, Config{ProtectIds: true, SpDialect: constants.DIALECT_POSTGRESQL, Source: "postgres"}.Quote("sales.orders"))
+
+	s["t1"] = CreateTable{Name: "sales.1st_orders", Id: "t1", Indexes: []CreateIndex{{Name: "2nd.orders_idx"}}}
+	delete(s, "t2")
+	assert.Equal(t, []string{
+		"invalid table name: name 1st_orders must start with a letter and contain only letters, digits and underscores",
+		"invalid schema name: name 2nd must start with a letter and contain only letters, digits and underscores",
+	}, ValidateIdentifiers(s, nil, SchemaObjects{}))
+}
+
+func TestPrintIfNotExists(t *testing.T) {
+	ct := CreateTable{
+		Name:        "singers",
+		Id:          "t1",
+		ColIds:      []string{"c1", "c2"},
+		ColDefs:     map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true}, "c2": {Name: "name", Id: "c2", T: Type{Name: String, Len: 50}}},
+		PrimaryKeys: []IndexKey{{ColId: "c1"}},
+	}
+	s := Schema{"t1": ct}
+	index := CreateIndex{Name: "singers_by_name", TableId: "t1", Unique: true, Keys: []IndexKey{{ColId: "c2"}}}
+	seq := Sequence{Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE"}
+
+	c := Config{IfNotExists: true}
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS singers (\n\tid INT64 NOT NULL ,\n\tname STRING(50),\n) PRIMARY KEY (id)", ct.PrintCreateTable(s, c))
+	assert.Equal(t, "CREATE UNIQUE INDEX IF NOT EXISTS singers_by_name ON singers (name)", index.PrintCreateIndex(s, ct, c))
+	assert.Equal(t, "CREATE SEQUENCE IF NOT EXISTS seq OPTIONS (sequence_kind='bit_reversed_positive') ", seq.PrintSequence(c))
+
+	pg := Config{IfNotExists: true, SpDialect: constants.DIALECT_POSTGRESQL}
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS singers (\n\tid INT8 NOT NULL ,\n\tname VARCHAR(50),\n\tPRIMARY KEY (id)\n)", ct.PrintCreateTable(s, pg))
+	assert.Equal(t, "CREATE SEQUENCE IF NOT EXISTS seq BIT_REVERSED_POSITIVE", seq.PGPrintSequence(pg))
+	assert.Equal(t, "CREATE UNIQUE INDEX singers_by_name ON singers (name)", index.PrintCreateIndex(s, ct, Config{}))
+}
+
+func TestPrintCheckConstraintsAlterTable(t *testing.T) {
+	ct := CreateTable{
+		Name:        "singers",
+		Id:          "t1",
+		ColIds:      []string{"c1", "c2"},
+		ColDefs:     map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true}, "c2": {Name: "age", Id: "c2", T: Type{Name: Int64}}},
+		PrimaryKeys: []IndexKey{{ColId: "c1"}},
+		CheckConstraints: []CheckConstraint{
+			{Id: "ck1", Name: "age_check", Expr: "(age > 0)"},
+			{Id: "ck2", Expr: "(id > 0)"},
+		},
+	}
+	s := Schema{"t1": ct}
+
+	c := Config{Tables: true, ProtectIds: true, CheckConstraintsAlterTable: true}
+	assert.Equal(t, []string{
+		"CREATE TABLE `singers` (\n\t`id` INT64 NOT NULL ,\n\t`age` INT64,\n) PRIMARY KEY (`id`)",
+		"ALTER TABLE `singers` ADD CONSTRAINT `age_check` CHECK (age > 0)",
+		"ALTER TABLE `singers` ADD CHECK (id > 0)",
+	}, GetDDL(c, s, nil, SchemaObjects{}))
+
+	pg := Config{Tables: true, SpDialect: constants.DIALECT_POSTGRESQL, CheckConstraintsAlterTable: true}
+	assert.Equal(t, []string{
+		"CREATE TABLE singers (\n\tid INT8 NOT NULL ,\n\tage INT8,\n\tPRIMARY KEY (id)\n)",
+		"ALTER TABLE singers ADD CONSTRAINT age_check CHECK (age > 0)",
+		"ALTER TABLE singers ADD CHECK (id > 0)",
+	}, GetDDL(pg, s, nil, SchemaObjects{}))
+
+	// Check constraints are only printed along with the tables.
+	assert.Empty(t, GetDDL(Config{ForeignKeys: true, CheckConstraintsAlterTable: true}, s, nil, SchemaObjects{}))
+}
+
+func TestPrintCreateTableFormat(t *testing.T) {
+	ct := CreateTable{
+		Name:   "singers",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+			"c2": {Name: "first_name", Id: "c2", T: Type{Name: String, Len: 50}, Comment: "From: first_name varchar(50)"},
+			"c3": {Name: "last_name", Id: "c3", T: Type{Name: String, Len: 50}},
+			"c4": {Name: "age", Id: "c4", T: Type{Name: Int64}, Comment: "From: age int"},
+		},
+		PrimaryKeys:      []IndexKey{{ColId: "c1"}},
+		CheckConstraints: []CheckConstraint{{Id: "ck1", Name: "age_check", Expr: "(age > 0)"}},
+	}
+	s := Schema{"t1": ct}
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{
+			name:   "default",
+			config: Config{Comments: true},
+			expected: "CREATE TABLE singers (\n" +
+				"\tid INT64 NOT NULL ,\n" +
+				"\tfirst_name STRING(50), -- From: first_name varchar(50)\n" +
+				"\tlast_name STRING(50),\n" +
+				"\tage INT64,             -- From: age int\n" +
+				"\tCONSTRAINT age_check CHECK (age > 0),\n" +
+				") PRIMARY KEY (id)",
+		},
+		{
+			name:   "space indent without comment alignment",
+			config: Config{Comments: true, Format: Format{IndentWidth: 2, NoCommentAlignment: true}},
+			expected: "CREATE TABLE singers (\n" +
+				"  id INT64 NOT NULL ,\n" +
+				"  first_name STRING(50), -- From: first_name varchar(50)\n" +
+				"  last_name STRING(50),\n" +
+				"  age INT64, -- From: age int\n" +
+				"  CONSTRAINT age_check CHECK (age > 0),\n" +
+				") PRIMARY KEY (id)",
+		},
+		{
+			name:   "compact",
+			config: Config{Format: Format{IndentWidth: 4, Compact: true, MaxLineLength: 50}},
+			expected: "CREATE TABLE singers (\n" +
+				"    id INT64 NOT NULL , first_name STRING(50),\n" +
+				"    last_name STRING(50), age INT64,\n" +
+				"    CONSTRAINT age_check CHECK (age > 0),\n" +
+				") PRIMARY KEY (id)",
+		},
+		{
+			name:   "compact with comments",
+			config: Config{Comments: true, Format: Format{Compact: true}},
+			expected: "CREATE TABLE singers (\n" +
+				"\tid INT64 NOT NULL , first_name STRING(50), -- From: first_name varchar(50)\n" +
+				"\tlast_name STRING(50), age INT64, -- From: age int\n" +
+				"\tCONSTRAINT age_check CHECK (age > 0),\n" +
+				") PRIMARY KEY (id)",
+		},
+		{
+			name:   "PG indent",
+			config: Config{SpDialect: constants.DIALECT_POSTGRESQL, Format: Format{IndentWidth: 2}},
+			expected: "CREATE TABLE singers (\n" +
+				"  id INT8 NOT NULL ,\n" +
+				"  first_name VARCHAR(50),\n" +
+				"  last_name VARCHAR(50),\n" +
+				"  age INT8,\n" +
+				"  CONSTRAINT age_check CHECK (age > 0),\n" +
+				"  PRIMARY KEY (id)\n" +
+				")",
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, ct.PrintCreateTable(s, tc.config), tc.name)
+	}
+}
+
 func TestPrintCreateTablePG(t *testing.T) {
 	s := Schema{
 		"t1": CreateTable{
@@ -525,6 +956,8 @@ func TestPrintCreateIndex(t *testing.T) {
 			[]IndexKey{{ColId: "c1", Desc: true}, {ColId: "c2"}},
 			"i1",
 			nil,
+			false,
+			"",
 		},
 		{
 			"myindex2",
@@ -533,6 +966,18 @@ func TestPrintCreateIndex(t *testing.T) {
 			[]IndexKey{{ColId: "c1", Desc: true}, {ColId: "c2"}},
 			"i2",
 			nil,
+			false,
+			"",
+		},
+		{
+			"myindex3",
+			"t1",
+			/*Unique =*/ false,
+			[]IndexKey{{ColId: "c1", Desc: true}, {ColId: "c2"}},
+			"i3",
+			nil,
+			/*NullFiltered =*/ true,
+			"",
 		},
 	}
 	tests := []struct {
@@ -547,12 +992,51 @@ func TestPrintCreateIndex(t *testing.T) {
 		{"unique key", true, "", ci[1], "CREATE UNIQUE INDEX `myindex2` ON `mytable` (`col1` DESC, `col2`)"},
 		{"quote non unique PG", true, constants.DIALECT_POSTGRESQL, ci[0], "CREATE INDEX myindex ON mytable (col1 DESC, col2)"},
 		{"unique key PG", true, constants.DIALECT_POSTGRESQL, ci[1], "CREATE UNIQUE INDEX myindex2 ON mytable (col1 DESC, col2)"},
+		{"null filtered", false, "", ci[2], "CREATE NULL_FILTERED INDEX myindex3 ON mytable (col1 DESC, col2)"},
+		{"null filtered PG", false, constants.DIALECT_POSTGRESQL, ci[2], "CREATE INDEX myindex3 ON mytable (col1 DESC, col2) WHERE col1 IS NOT NULL AND col2 IS NOT NULL"},
 	}
 	for _, tc := range tests {
-		assert.Equal(t, tc.expected, tc.index.PrintCreateIndex(ct, Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}))
+		assert.Equal(t, tc.expected, tc.index.PrintCreateIndex(Schema{"t1": ct}, ct, Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}))
 	}
 }
 
+func TestPrintInterleavedIndex(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:        "singers",
+			Id:          "t1",
+			ColIds:      []string{"c1"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "singer_id", Id: "c1", T: Type{Name: Int64}}},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}},
+		},
+		"t2": {
+			Name:   "albums",
+			Id:     "t2",
+			ColIds: []string{"c2", "c3", "c4"},
+			ColDefs: map[string]ColumnDef{
+				"c2": {Name: "singer_id", Id: "c2", T: Type{Name: Int64}},
+				"c3": {Name: "album_id", Id: "c3", T: Type{Name: Int64}},
+				"c4": {Name: "title", Id: "c4", T: Type{Name: String, Len: MaxLength}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c2", Order: 1}, {ColId: "c3", Order: 2}},
+			ParentTable: InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE},
+		},
+	}
+	ci := CreateIndex{Name: "albums_by_title", TableId: "t2", Keys: []IndexKey{{ColId: "c2", Order: 1}, {ColId: "c4", Order: 2}}, Interleave: "t1"}
+	assert.Nil(t, ci.ValidateInterleave(s))
+	assert.Equal(t, "CREATE INDEX albums_by_title ON albums (singer_id, title), INTERLEAVE IN singers", ci.PrintCreateIndex(s, s["t2"], Config{}))
+	assert.Equal(t, "CREATE INDEX albums_by_title ON albums (singer_id, title) INTERLEAVE IN singers", ci.PrintCreateIndex(s, s["t2"], Config{SpDialect: constants.DIALECT_POSTGRESQL}))
+
+	wrongKeys := CreateIndex{Name: "albums_by_title", TableId: "t2", Keys: []IndexKey{{ColId: "c4", Order: 1}}, Interleave: "t1"}
+	assert.NotNil(t, wrongKeys.ValidateInterleave(s))
+	assert.Equal(t, "CREATE INDEX albums_by_title ON albums (title)", wrongKeys.PrintCreateIndex(s, s["t2"], Config{}))
+
+	notAncestor := CreateIndex{Name: "singers_by_id", TableId: "t1", Keys: []IndexKey{{ColId: "c1", Order: 1}}, Interleave: "t2"}
+	assert.NotNil(t, notAncestor.ValidateInterleave(s))
+	missing := CreateIndex{Name: "albums_by_title", TableId: "t2", Keys: ci.Keys, Interleave: "t3"}
+	assert.NotNil(t, missing.ValidateInterleave(s))
+}
+
 func TestPrintForeignKey(t *testing.T) {
 	fk := []Foreignkey{
 		{
@@ -563,6 +1047,7 @@ func TestPrintForeignKey(t *testing.T) {
 			"1",
 			constants.FK_NO_ACTION,
 			constants.FK_NO_ACTION,
+			false,
 		},
 		{
 			"",
@@ -572,6 +1057,7 @@ func TestPrintForeignKey(t *testing.T) {
 			"1",
 			constants.FK_CASCADE,
 			constants.FK_NO_ACTION,
+			false,
 		},
 		{
 			"fk_test",
@@ -581,6 +1067,7 @@ func TestPrintForeignKey(t *testing.T) {
 			"1",
 			"",
 			"",
+			false,
 		},
 	}
 	tests := []struct {
@@ -595,6 +1082,9 @@ func TestPrintForeignKey(t *testing.T) {
 		{"no constraint name", false, "", "FOREIGN KEY (c1) REFERENCES ref_table (ref_c1) ON DELETE CASCADE", fk[1]},
 		{"quote PG", true, constants.DIALECT_POSTGRESQL, "CONSTRAINT fk_test FOREIGN KEY (c1, c2) REFERENCES ref_table (ref_c1, ref_c2) ON DELETE NO ACTION", fk[0]},
 		{"foreign key constraints not supported i.e. dont print ON DELETE", false, "", "CONSTRAINT fk_test FOREIGN KEY (c1, c2) REFERENCES ref_table (ref_c1, ref_c2)", fk[2]},
+		{"not enforced", false, "", "FOREIGN KEY (c1) REFERENCES ref_table (ref_c1) NOT ENFORCED", Foreignkey{ColIds: []string{"c1"}, ReferTableId: "ref_table", ReferColumnIds: []string{"ref_c1"}, NotEnforced: true}},
+		{"on update PG", false, constants.DIALECT_POSTGRESQL, "FOREIGN KEY (c1) REFERENCES ref_table (ref_c1) ON UPDATE CASCADE", Foreignkey{ColIds: []string{"c1"}, ReferTableId: "ref_table", ReferColumnIds: []string{"ref_c1"}, OnUpdate: constants.FK_CASCADE}},
+		{"no on update in GoogleSQL", false, "", "FOREIGN KEY (c1) REFERENCES ref_table (ref_c1)", Foreignkey{ColIds: []string{"c1"}, ReferTableId: "ref_table", ReferColumnIds: []string{"ref_c1"}, OnUpdate: constants.FK_CASCADE}},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -623,6 +1113,7 @@ func TestPrintForeignKeyAlterTable(t *testing.T) {
 					"f1",
 					constants.FK_CASCADE,
 					constants.FK_NO_ACTION,
+					false,
 				},
 				{
 					"",
@@ -632,6 +1123,7 @@ func TestPrintForeignKeyAlterTable(t *testing.T) {
 					"f2",
 					constants.FK_NO_ACTION,
 					constants.FK_NO_ACTION,
+					false,
 				},
 				{
 					"fk_test2",
@@ -641,6 +1133,7 @@ func TestPrintForeignKeyAlterTable(t *testing.T) {
 					"f1",
 					"",
 					"",
+					false,
 				},
 			},
 		},
@@ -773,6 +1266,7 @@ func TestPrintAutoGenCol(t *testing.T) {
 		expected string
 	}{
 		{AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}, " DEFAULT (GENERATE_UUID())"},
+		{AutoGenCol{GenerationType: constants.IDENTITY}, " GENERATED BY DEFAULT AS IDENTITY (BIT_REVERSED_POSITIVE)"},
 		{AutoGenCol{GenerationType: "", Name: ""}, ""},
 	}
 	for _, tc := range tests {
@@ -786,6 +1280,7 @@ func TestPGPrintAutoGenCol(t *testing.T) {
 		expected string
 	}{
 		{AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}, " DEFAULT (spanner.generate_uuid())"},
+		{AutoGenCol{GenerationType: constants.IDENTITY}, " GENERATED BY DEFAULT AS IDENTITY (BIT_REVERSED_POSITIVE)"},
 		{AutoGenCol{GenerationType: "", Name: ""}, ""},
 	}
 	for _, tc := range tests {
@@ -1006,7 +1501,7 @@ func TestGetDDL(t *testing.T) {
 			ParentTable: InterleavedParent{Id: "t1", OnDelete: constants.FK_NO_ACTION, InterleaveType: "IN"},
 		},
 	}
-	tablesOnly := GetDDL(Config{Tables: true, ForeignKeys: false}, s, make(map[string]Sequence))
+	tablesOnly := GetDDL(Config{Tables: true, ForeignKeys: false}, s, make(map[string]Sequence), SchemaObjects{})
 	e := []string{
 		"CREATE TABLE table1 (\n" +
 			"	a INT64,\n" +
@@ -1034,14 +1529,14 @@ func TestGetDDL(t *testing.T) {
 	}
 	assert.ElementsMatch(t, e, tablesOnly)
 
-	fksOnly := GetDDL(Config{Tables: false, ForeignKeys: true}, s, make(map[string]Sequence))
+	fksOnly := GetDDL(Config{Tables: false, ForeignKeys: true}, s, make(map[string]Sequence), SchemaObjects{})
 	e2 := []string{
 		"ALTER TABLE table1 ADD CONSTRAINT fk1 FOREIGN KEY (b) REFERENCES table2 (b) ON DELETE CASCADE",
 		"ALTER TABLE table2 ADD CONSTRAINT fk2 FOREIGN KEY (b, c) REFERENCES table3 (b, c) ON DELETE NO ACTION",
 	}
 	assert.ElementsMatch(t, e2, fksOnly)
 
-	tablesAndFks := GetDDL(Config{Tables: true, ForeignKeys: true}, s, make(map[string]Sequence))
+	tablesAndFks := GetDDL(Config{Tables: true, ForeignKeys: true}, s, make(map[string]Sequence), SchemaObjects{})
 	e3 := []string{
 		"CREATE TABLE table1 (\n" +
 			"	a INT64,\n" +
@@ -1083,8 +1578,132 @@ func TestGetDDL(t *testing.T) {
 	e4 := []string{
 		"CREATE SEQUENCE sequence1 OPTIONS (sequence_kind='bit_reversed_positive', skip_range_min = 0, skip_range_max = 5, start_with_counter = 7) ",
 	}
-	sequencesOnly := GetDDL(Config{}, Schema{}, sequences)
+	sequencesOnly := GetDDL(Config{}, Schema{}, sequences, SchemaObjects{})
 	assert.ElementsMatch(t, e4, sequencesOnly)
+
+	views := SchemaObjects{Views: map[string]CreateView{
+		"v2": {Id: "v2", Name: "view2", Query: "SELECT a FROM table2"},
+		"v1": {Id: "v1", Name: "view1", SecurityType: "DEFINER", Query: "SELECT a, b FROM table1"},
+	}}
+	e5 := []string{
+		"CREATE VIEW view1 SQL SECURITY DEFINER AS SELECT a, b FROM table1",
+		"CREATE VIEW view2 SQL SECURITY INVOKER AS SELECT a FROM table2",
+	}
+	viewsOnly := GetDDL(Config{Tables: true}, Schema{}, make(map[string]Sequence), views)
+	assert.Equal(t, e5, viewsOnly)
+	assert.Empty(t, GetDDL(Config{ForeignKeys: true}, Schema{}, make(map[string]Sequence), views))
+}
+
+func TestGetDropDDL(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:        "singers",
+			Id:          "t1",
+			ColIds:      []string{"c1"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "singer_id", Id: "c1", T: Type{Name: Int64}}},
+			PrimaryKeys: []IndexKey{{ColId: "c1"}},
+		},
+		"t2": {
+			Name:   "albums",
+			Id:     "t2",
+			ColIds: []string{"c2", "c3"},
+			ColDefs: map[string]ColumnDef{
+				"c2": {Name: "singer_id", Id: "c2", T: Type{Name: Int64}},
+				"c3": {Name: "album_id", Id: "c3", T: Type{Name: Int64}},
+			},
+			PrimaryKeys:   []IndexKey{{ColId: "c2"}, {ColId: "c3"}},
+			ParentTable:   InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE},
+			Indexes:       []CreateIndex{{Name: "albums_by_id", TableId: "t2", Keys: []IndexKey{{ColId: "c3"}}}},
+			VectorIndexes: []VectorIndex{{Name: "albums_by_embedding", TableId: "t2", ColId: "c3"}},
+		},
+		"t3": {
+			Name:        "awards",
+			Id:          "t3",
+			ColIds:      []string{"c4", "c5"},
+			ColDefs:     map[string]ColumnDef{"c4": {Name: "award_id", Id: "c4", T: Type{Name: Int64}}, "c5": {Name: "singer_id", Id: "c5", T: Type{Name: Int64}}},
+			PrimaryKeys: []IndexKey{{ColId: "c4"}},
+			ForeignKeys: []Foreignkey{
+				{Name: "fk_singer", ColIds: []string{"c5"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}},
+				{ColIds: []string{"c5"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}},
+			},
+		},
+	}
+	sequences := map[string]Sequence{"s1": {Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE"}}
+	objects := SchemaObjects{
+		Views:         map[string]CreateView{"v1": {Id: "v1", Name: "singer_view", Query: "SELECT singer_id FROM singers"}},
+		ChangeStreams: map[string]ChangeStream{"cs1": {Id: "cs1", Name: "all_changes", WatchAll: true}},
+	}
+	expected := []string{
+		"ALTER TABLE awards DROP CONSTRAINT fk_singer",
+		"DROP CHANGE STREAM all_changes",
+		"DROP VIEW singer_view",
+		"DROP INDEX albums_by_id",
+		"DROP VECTOR INDEX albums_by_embedding",
+		// awards is dropped before singers, which its unnamed foreign key
+		// references although it sorts after it.
+		"DROP TABLE awards",
+		"DROP TABLE albums",
+		"DROP TABLE singers",
+		"DROP SEQUENCE seq",
+	}
+	assert.Equal(t, expected, GetDropDDL(Config{Tables: true, ForeignKeys: true}, s, sequences, objects))
+	assert.Equal(t, []string{"ALTER TABLE awards DROP CONSTRAINT fk_singer", "DROP SEQUENCE seq"}, GetDropDDL(Config{ForeignKeys: true}, s, sequences, objects))
+
+	// PostgreSQL vector indexes are dropped as regular indexes.
+	expected[4] = "DROP INDEX albums_by_embedding"
+	assert.Equal(t, expected, GetDropDDL(Config{Tables: true, ForeignKeys: true, SpDialect: constants.DIALECT_POSTGRESQL}, s, sequences, objects))
+}
+
+func TestPrintDatabaseOptions(t *testing.T) {
+	opts := DatabaseOptions{DatabaseName: "music", VersionRetentionPeriod: "7d", DefaultLeader: "us-central1"}
+	assert.Equal(t, []string{"ALTER DATABASE `music` SET OPTIONS (default_leader = 'us-central1', version_retention_period = '7d')"}, opts.PrintDatabaseOptions(Config{}))
+	assert.Equal(t, []string{
+		"ALTER DATABASE \"music\" SET spanner.default_leader = 'us-central1'",
+		"ALTER DATABASE \"music\" SET spanner.version_retention_period = '7d'",
+	}, opts.PrintDatabaseOptions(Config{SpDialect: constants.DIALECT_POSTGRESQL}))
+	assert.Nil(t, DatabaseOptions{DatabaseName: "music"}.PrintDatabaseOptions(Config{}))
+	assert.Nil(t, DatabaseOptions{DefaultSequenceKind: "bit_reversed_positive"}.PrintDatabaseOptions(Config{}))
+
+	ddl := GetDDL(Config{Tables: true}, Schema{}, map[string]Sequence{}, SchemaObjects{DatabaseOptions: opts})
+	assert.Equal(t, opts.PrintDatabaseOptions(Config{}), ddl)
+}
+
+func TestPrintCreateView(t *testing.T) {
+	tests := []struct {
+		name     string
+		view     CreateView
+		config   Config
+		expected string
+	}{
+		{
+			name:     "default security type",
+			view:     CreateView{Name: "v1", Query: "SELECT * FROM t1"},
+			expected: "CREATE VIEW v1 SQL SECURITY INVOKER AS SELECT * FROM t1",
+		},
+		{
+			name:     "protected ids",
+			view:     CreateView{Name: "v1", Query: "SELECT * FROM t1"},
+			config:   Config{ProtectIds: true},
+			expected: "CREATE VIEW `v1` SQL SECURITY INVOKER AS SELECT * FROM t1",
+		},
+		{
+			name:     "with comment",
+			view:     CreateView{Name: "v1", Query: "SELECT * FROM t1", Comment: "Spanner schema for source view v1"},
+			config:   Config{Comments: true},
+			expected: "--\n-- Spanner schema for source view v1\n--\nCREATE VIEW v1 SQL SECURITY INVOKER AS SELECT * FROM t1",
+		},
+		{
+			name:     "pg dialect",
+			view:     CreateView{Name: "v1", Query: "SELECT * FROM t1"},
+			config:   Config{ProtectIds: true, SpDialect: constants.DIALECT_POSTGRESQL},
+			expected: "CREATE VIEW v1 SQL SECURITY INVOKER AS SELECT * FROM t1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.view.PrintCreateView(tc.config))
+		})
+	}
 }
 
 func TestGetPGDDL(t *testing.T) {
@@ -1139,7 +1758,7 @@ func TestGetPGDDL(t *testing.T) {
 			ParentTable: InterleavedParent{Id: "t1", OnDelete: constants.FK_NO_ACTION, InterleaveType: "IN"},
 		},
 	}
-	tablesOnly := GetDDL(Config{Tables: true, ForeignKeys: false, SpDialect: constants.DIALECT_POSTGRESQL}, s, make(map[string]Sequence))
+	tablesOnly := GetDDL(Config{Tables: true, ForeignKeys: false, SpDialect: constants.DIALECT_POSTGRESQL}, s, make(map[string]Sequence), SchemaObjects{})
 	e := []string{
 		"CREATE TABLE table1 (\n" +
 			"	a INT8,\n" +
@@ -1169,14 +1788,14 @@ func TestGetPGDDL(t *testing.T) {
 	}
 	assert.ElementsMatch(t, e, tablesOnly)
 
-	fksOnly := GetDDL(Config{Tables: false, ForeignKeys: true, SpDialect: constants.DIALECT_POSTGRESQL}, s, make(map[string]Sequence))
+	fksOnly := GetDDL(Config{Tables: false, ForeignKeys: true, SpDialect: constants.DIALECT_POSTGRESQL}, s, make(map[string]Sequence), SchemaObjects{})
 	e2 := []string{
 		"ALTER TABLE table1 ADD CONSTRAINT fk1 FOREIGN KEY (b) REFERENCES table2 (b) ON DELETE CASCADE",
 		"ALTER TABLE table2 ADD CONSTRAINT fk2 FOREIGN KEY (b, c) REFERENCES table3 (b, c) ON DELETE NO ACTION",
 	}
 	assert.ElementsMatch(t, e2, fksOnly)
 
-	tablesAndFks := GetDDL(Config{Tables: true, ForeignKeys: true, SpDialect: constants.DIALECT_POSTGRESQL}, s, make(map[string]Sequence))
+	tablesAndFks := GetDDL(Config{Tables: true, ForeignKeys: true, SpDialect: constants.DIALECT_POSTGRESQL}, s, make(map[string]Sequence), SchemaObjects{})
 	e3 := []string{
 		"CREATE TABLE table1 (\n" +
 			"	a INT8,\n" +
@@ -1220,7 +1839,7 @@ func TestGetPGDDL(t *testing.T) {
 	e4 := []string{
 		"CREATE SEQUENCE sequence1 BIT_REVERSED_POSITIVE SKIP RANGE 0 5 START COUNTER WITH 7",
 	}
-	sequencesOnly := GetDDL(Config{SpDialect: constants.DIALECT_POSTGRESQL}, Schema{}, sequences)
+	sequencesOnly := GetDDL(Config{SpDialect: constants.DIALECT_POSTGRESQL}, Schema{}, sequences, SchemaObjects{})
 	assert.ElementsMatch(t, e4, sequencesOnly)
 }
 
@@ -1310,6 +1929,37 @@ func TestGetSortedTableIdsBySpName(t *testing.T) {
 	}
 }
 
+func TestGetDDLDeterministicOrder(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:        "Singers",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "SingerId", Id: "c1", T: Type{Name: Int64}}, "c2": {Name: "Name", Id: "c2", T: Type{Name: String, Len: 10}}},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}},
+			Indexes:     []CreateIndex{{Name: "z_idx", TableId: "t1", Keys: []IndexKey{{ColId: "c2"}}}, {Name: "a_idx", TableId: "t1", Keys: []IndexKey{{ColId: "c2", Desc: true}}}},
+			ForeignKeys: []Foreignkey{{Name: "fk_z", ColIds: []string{"c1"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}}, {Name: "fk_a", ColIds: []string{"c1"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}}},
+		},
+	}
+	sequences := make(map[string]Sequence)
+	for _, id := range []string{"s3", "s1", "s2", "s4"} {
+		sequences[id] = Sequence{Id: id, Name: "Seq" + id, SequenceKind: "BIT REVERSED POSITIVE"}
+	}
+	c := Config{Tables: true, ForeignKeys: true}
+	expected := GetDDL(c, s, sequences, SchemaObjects{})
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected, GetDDL(c, s, sequences, SchemaObjects{}))
+	}
+	assert.Equal(t, []string{"s1", "s2", "s3", "s4"}, GetSortedSequenceIds(sequences))
+	assert.Contains(t, expected[0], "Seqs1")
+	assert.Contains(t, expected[5], "a_idx")
+	assert.Contains(t, expected[6], "z_idx")
+	assert.Contains(t, expected[7], "fk_a")
+	assert.Contains(t, expected[8], "fk_z")
+	// Objects with the same name are ordered by id.
+	assert.Equal(t, []string{"v1", "v2"}, GetSortedViewIds(map[string]CreateView{"v2": {Name: "V"}, "v1": {Name: "V"}}))
+}
+
 func TestFormatCheckConstraints(t *testing.T) {
 	tests := []struct {
 		description string
@@ -1395,3 +2045,329 @@ func TestFormatCheckConstraintsPG(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintChangeStream(t *testing.T) {
+	s := Schema{
+		"t1": CreateTable{
+			Name:   "table1",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "a", Id: "c1", T: Type{Name: Int64}},
+				"c2": {Name: "b", Id: "c2", T: Type{Name: Int64}},
+			},
+		},
+		"t2": CreateTable{
+			Name:   "table2",
+			Id:     "t2",
+			ColIds: []string{"c3"},
+			ColDefs: map[string]ColumnDef{
+				"c3": {Name: "c", Id: "c3", T: Type{Name: Int64}},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		cs       ChangeStream
+		config   Config
+		expected string
+	}{
+		{
+			name:     "no watched tables",
+			cs:       ChangeStream{Name: "cs1"},
+			expected: "CREATE CHANGE STREAM cs1",
+		},
+		{
+			name:     "watch all",
+			cs:       ChangeStream{Name: "cs1", WatchAll: true, WatchedTables: []ChangeStreamTable{{TableId: "t1"}}},
+			expected: "CREATE CHANGE STREAM cs1 FOR ALL",
+		},
+		{
+			name: "watched tables and columns with options",
+			cs: ChangeStream{
+				Name:             "cs1",
+				WatchedTables:    []ChangeStreamTable{{TableId: "t1", ColIds: []string{"c2"}}, {TableId: "t2"}},
+				ValueCaptureType: "NEW_ROW",
+				RetentionPeriod:  "7d",
+			},
+			config:   Config{ProtectIds: true},
+			expected: "CREATE CHANGE STREAM `cs1` FOR `table1`(`b`), `table2` OPTIONS (retention_period = '7d', value_capture_type = 'NEW_ROW')",
+		},
+		{
+			name: "pg dialect",
+			cs: ChangeStream{
+				Name:             "cs1",
+				WatchedTables:    []ChangeStreamTable{{TableId: "t1", ColIds: []string{"c1", "c2"}}},
+				ValueCaptureType: "OLD_AND_NEW_VALUES",
+			},
+			config:   Config{ProtectIds: true, SpDialect: constants.DIALECT_POSTGRESQL},
+			expected: "CREATE CHANGE STREAM cs1 FOR table1(a, b) WITH (value_capture_type = 'OLD_AND_NEW_VALUES')",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.cs.PrintChangeStream(s, tc.config))
+		})
+	}
+
+	objects := SchemaObjects{ChangeStreams: map[string]ChangeStream{
+		"cs2": {Id: "cs2", Name: "stream_b", WatchAll: true},
+		"cs1": {Id: "cs1", Name: "stream_a", WatchedTables: []ChangeStreamTable{{TableId: "t2"}}},
+	}}
+	e := []string{
+		"CREATE TABLE table1 (\n\ta INT64,\n\tb INT64,\n) ",
+		"CREATE TABLE table2 (\n\tc INT64,\n) ",
+		"CREATE CHANGE STREAM stream_a FOR table2",
+		"CREATE CHANGE STREAM stream_b FOR ALL",
+	}
+	assert.Equal(t, e, GetDDL(Config{Tables: true}, s, make(map[string]Sequence), objects))
+}
+
+func TestPrintSearchIndex(t *testing.T) {
+	ct := CreateTable{
+		Name:   "albums",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3", "c4"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}},
+			"c2": {Name: "title", Id: "c2", T: Type{Name: String, Len: MaxLength}},
+			"c3": {Name: "title_Tokens", Id: "c3", T: Type{Name: TokenList},
+				GeneratedColumn: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "TOKENIZE_FULLTEXT(title)"}, Type: GeneratedVirtual}},
+			"c4": {Name: "release", Id: "c4", T: Type{Name: Timestamp}},
+		},
+		PrimaryKeys: []IndexKey{{ColId: "c1"}},
+	}
+	tests := []struct {
+		name     string
+		index    SearchIndex
+		config   Config
+		expected string
+	}{
+		{
+			name:     "simple",
+			index:    SearchIndex{Name: "albums_idx", TableId: "t1", Keys: []IndexKey{{ColId: "c3"}}},
+			expected: "CREATE SEARCH INDEX albums_idx ON albums (title_Tokens)",
+		},
+		{
+			name: "all clauses",
+			index: SearchIndex{
+				Name:                      "albums_idx",
+				TableId:                   "t1",
+				Keys:                      []IndexKey{{ColId: "c3"}},
+				StoredColumnIds:           []string{"c2"},
+				PartitionBy:               []string{"c1"},
+				OrderBy:                   []IndexKey{{ColId: "c4", Desc: true}},
+				SortOrderSharding:         true,
+				DisableAutomaticUidColumn: true,
+			},
+			config:   Config{ProtectIds: true},
+			expected: "CREATE SEARCH INDEX `albums_idx` ON `albums` (`title_Tokens`) STORING (`title`) PARTITION BY `id` ORDER BY `release` DESC OPTIONS (sort_order_sharding = true, disable_automatic_uid_column = true)",
+		},
+		{
+			name: "pg dialect",
+			index: SearchIndex{
+				Name:              "albums_idx",
+				TableId:           "t1",
+				Keys:              []IndexKey{{ColId: "c3"}},
+				StoredColumnIds:   []string{"c2"},
+				SortOrderSharding: true,
+			},
+			config:   Config{SpDialect: constants.DIALECT_POSTGRESQL},
+			expected: "CREATE SEARCH INDEX albums_idx ON albums (title_Tokens) INCLUDE (title) WITH (sort_order_sharding = true)",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.index.PrintSearchIndex(ct, tc.config))
+		})
+	}
+
+	ct.SearchIndexes = []SearchIndex{{Name: "albums_idx", TableId: "t1", Keys: []IndexKey{{ColId: "c3"}}}}
+	e := []string{
+		"CREATE TABLE albums (\n\tid INT64,\n\ttitle STRING(MAX),\n\ttitle_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(title)),\n\trelease TIMESTAMP,\n) PRIMARY KEY (id)",
+		"CREATE SEARCH INDEX albums_idx ON albums (title_Tokens)",
+	}
+	assert.Equal(t, e, GetDDL(Config{Tables: true}, Schema{"t1": ct}, make(map[string]Sequence), SchemaObjects{}))
+}
+
+func TestTokenizeFullText(t *testing.T) {
+	assert.Equal(t, "TOKENIZE_FULLTEXT(title)", TokenizeFullText("title", nil, ""))
+	assert.Equal(t, "TOKENIZE_FULLTEXT(title, content_type=>'text/html', language_tag=>'en-us')",
+		TokenizeFullText("title", map[string]string{"language_tag": "en-us", "content_type": "text/html"}, constants.DIALECT_GOOGLESQL))
+	assert.Equal(t, "spanner.tokenize_fulltext(title, language_tag=>'en-us')",
+		TokenizeFullText("title", map[string]string{"language_tag": "en-us"}, constants.DIALECT_POSTGRESQL))
+	cd := ColumnDef{
+		Name:            "title_tokens",
+		T:               Type{Name: TokenList},
+		GeneratedColumn: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "spanner.tokenize_fulltext(title)"}, Type: GeneratedVirtual},
+	}
+	s, _ := cd.PrintColumnDef(Config{SpDialect: constants.DIALECT_POSTGRESQL})
+	assert.Equal(t, "title_tokens SPANNER.TOKENLIST GENERATED ALWAYS AS (spanner.tokenize_fulltext(title)) VIRTUAL", s)
+}
+
+func TestPrintVectorIndex(t *testing.T) {
+	ct := CreateTable{
+		Name:   "documents",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+			"c2": {Name: "embedding", Id: "c2", T: Type{Name: Float32, IsArray: true, VectorLength: 128}},
+			"c3": {Name: "body", Id: "c3", T: Type{Name: String, Len: MaxLength}},
+		},
+		PrimaryKeys: []IndexKey{{ColId: "c1"}},
+	}
+	tests := []struct {
+		name     string
+		index    VectorIndex
+		config   Config
+		expected string
+	}{
+		{
+			name:     "distance type only",
+			index:    VectorIndex{Name: "doc_idx", TableId: "t1", ColId: "c2", DistanceType: VectorDistanceCosine},
+			expected: "CREATE VECTOR INDEX doc_idx ON documents (embedding) WHERE embedding IS NOT NULL OPTIONS (distance_type = 'COSINE')",
+		},
+		{
+			name:     "no options",
+			index:    VectorIndex{Name: "doc_idx", TableId: "t1", ColId: "c2"},
+			expected: "CREATE VECTOR INDEX doc_idx ON documents (embedding) WHERE embedding IS NOT NULL",
+		},
+		{
+			name: "all options",
+			index: VectorIndex{
+				Name:            "doc_idx",
+				TableId:         "t1",
+				ColId:           "c2",
+				StoredColumnIds: []string{"c3"},
+				DistanceType:    VectorDistanceEuclidean,
+				TreeDepth:       3,
+				NumLeaves:       1000,
+				NumBranches:     100,
+			},
+			config:   Config{ProtectIds: true},
+			expected: "CREATE VECTOR INDEX `doc_idx` ON `documents` (`embedding`) STORING (`body`) WHERE `embedding` IS NOT NULL OPTIONS (distance_type = 'EUCLIDEAN', tree_depth = 3, num_leaves = 1000, num_branches = 100)",
+		},
+		{
+			name: "pg dialect",
+			index: VectorIndex{
+				Name:            "doc_idx",
+				TableId:         "t1",
+				ColId:           "c2",
+				StoredColumnIds: []string{"c3"},
+				DistanceType:    VectorDistanceDotProduct,
+				NumLeaves:       1000,
+			},
+			config:   Config{SpDialect: constants.DIALECT_POSTGRESQL},
+			expected: "CREATE INDEX doc_idx ON documents USING ScaNN (embedding spanner.dot_product) INCLUDE (body) WITH (num_leaves = 1000) WHERE embedding IS NOT NULL",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.index.PrintVectorIndex(ct, tc.config))
+		})
+	}
+
+	assert.Equal(t, "ARRAY<FLOAT32>(vector_length=>128)", ct.ColDefs["c2"].T.PrintColumnDefType())
+	assert.Equal(t, "float4[] VECTOR LENGTH 128", ct.ColDefs["c2"].T.PGPrintColumnDefType())
+
+	ct.VectorIndexes = []VectorIndex{{Name: "doc_idx", TableId: "t1", ColId: "c2", DistanceType: VectorDistanceCosine}}
+	e := []string{
+		"CREATE TABLE documents (\n\tid INT64 NOT NULL ,\n\tembedding ARRAY<FLOAT32>(vector_length=>128),\n\tbody STRING(MAX),\n) PRIMARY KEY (id)",
+		"CREATE VECTOR INDEX doc_idx ON documents (embedding) WHERE embedding IS NOT NULL OPTIONS (distance_type = 'COSINE')",
+	}
+	assert.Equal(t, e, GetDDL(Config{Tables: true}, Schema{"t1": ct}, make(map[string]Sequence), SchemaObjects{}))
+}
+
+func TestPrintProtoBundle(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+				"c2": {Name: "status", Id: "c2", T: Type{Name: Enum, ProtoName: "shop.orders_status"}},
+				"c3": {Name: "details", Id: "c3", T: Type{Name: Proto, ProtoName: "shop.OrderDetails"}},
+				"c4": {Name: "statuses", Id: "c4", T: Type{Name: Enum, ProtoName: "shop.orders_status", IsArray: true}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c1"}},
+		},
+	}
+	assert.Equal(t, []string{"shop.OrderDetails", "shop.orders_status"}, GetProtoBundle(s))
+	assert.Equal(t, "CREATE PROTO BUNDLE (shop.OrderDetails, shop.orders_status)", PrintProtoBundle(GetProtoBundle(s), Config{}))
+	assert.Equal(t, "CREATE PROTO BUNDLE (`shop.OrderDetails`)", PrintProtoBundle([]string{"shop.OrderDetails"}, Config{ProtectIds: true}))
+	assert.Equal(t, "ARRAY<shop.orders_status>", s["t1"].ColDefs["c4"].T.PrintColumnDefType())
+
+	ddl := GetDDL(Config{Tables: true}, s, nil, SchemaObjects{})
+	assert.Equal(t, []string{
+		"CREATE PROTO BUNDLE (shop.OrderDetails, shop.orders_status)",
+		"CREATE TABLE orders (\n\tid INT64 NOT NULL ,\n\tstatus shop.orders_status,\n\tdetails shop.OrderDetails,\n\tstatuses ARRAY<shop.orders_status>,\n) PRIMARY KEY (id)",
+	}, ddl)
+}
+
+func TestValidateColumnOption(t *testing.T) {
+	assert.Nil(t, ValidateColumnOption(AllowCommitTimestampOpt, "true", constants.DIALECT_GOOGLESQL))
+	assert.Nil(t, ValidateColumnOption(LocalityGroupOpt, "archive", constants.DIALECT_POSTGRESQL))
+	assert.NotNil(t, ValidateColumnOption(AllowCommitTimestampOpt, "yes", constants.DIALECT_GOOGLESQL))
+	assert.NotNil(t, ValidateColumnOption(CassandraTypeOpt, "bigint", constants.DIALECT_POSTGRESQL))
+	assert.NotNil(t, ValidateColumnOption(LocalityGroupOpt, "it's", constants.DIALECT_GOOGLESQL))
+	assert.NotNil(t, ValidateColumnOption("unknown", "x", constants.DIALECT_GOOGLESQL))
+}
+
+func TestColumnDefSetOpt(t *testing.T) {
+	cd := ColumnDef{Name: "ts", T: Type{Name: Timestamp}}
+	assert.Nil(t, cd.SetOpt(AllowCommitTimestampOpt, "true", constants.DIALECT_GOOGLESQL))
+	assert.Nil(t, cd.SetOpt(LocalityGroupOpt, "archive", constants.DIALECT_GOOGLESQL))
+	assert.Equal(t, map[string]string{AllowCommitTimestampOpt: "true", LocalityGroupOpt: "archive"}, cd.Opts)
+	assert.Nil(t, cd.SetOpt(LocalityGroupOpt, "", constants.DIALECT_GOOGLESQL))
+	assert.Equal(t, map[string]string{AllowCommitTimestampOpt: "true"}, cd.Opts)
+
+	str := ColumnDef{Name: "s", T: Type{Name: String, Len: MaxLength}}
+	assert.NotNil(t, str.SetOpt(AllowCommitTimestampOpt, "true", constants.DIALECT_GOOGLESQL))
+	assert.Nil(t, str.Opts)
+}
+
+func TestPrintCommentStatements(t *testing.T) {
+	ct := CreateTable{
+		Name:    "orders",
+		Id:      "t1",
+		ColIds:  []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, Comment: "From: id bigint"}, "c2": {Name: "note", Id: "c2", T: Type{Name: String, Len: MaxLength}}, "c3": {Name: "user", Id: "c3", T: Type{Name: String, Len: 10}, Comment: "Customer's name"}},
+		Comment: "Spanner schema for source table orders",
+	}
+	pg := Config{ProtectIds: true, SpDialect: constants.DIALECT_POSTGRESQL, CommentStatements: true}
+	assert.Equal(t, []string{
+		"COMMENT ON TABLE orders IS 'Spanner schema for source table orders'",
+		"COMMENT ON COLUMN orders.id IS 'From: id bigint'",
+		"COMMENT ON COLUMN orders.\"user\" IS 'Customer''s name'",
+	}, ct.PrintCommentStatements(pg))
+	assert.Nil(t, ct.PrintCommentStatements(Config{SpDialect: constants.DIALECT_POSTGRESQL}))
+	assert.Nil(t, ct.PrintCommentStatements(Config{CommentStatements: true}))
+
+	ct.PrimaryKeys = []IndexKey{{ColId: "c1", Order: 1}}
+	pg.Tables = true
+	stmts := GetDDL(pg, Schema{"t1": ct}, nil, SchemaObjects{})
+	assert.Equal(t, 4, len(stmts))
+	assert.Equal(t, "COMMENT ON TABLE orders IS 'Spanner schema for source table orders'", stmts[1])
+
+	parsed, err := ParseDDL(stmts, constants.DIALECT_POSTGRESQL, newTestIdGenerator())
+	assert.Nil(t, err)
+	assert.Empty(t, parsed.Skipped)
+	assert.Equal(t, stmts, GetDDL(pg, parsed.Tables, nil, SchemaObjects{}))
+}
+
+func TestPrintCreateTableMultiLineComments(t *testing.T) {
+	ct := CreateTable{
+		Name:        "orders",
+		Id:          "t1",
+		ColIds:      []string{"c1"},
+		ColDefs:     map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, Comment: "Order number,\nassigned at checkout"}},
+		PrimaryKeys: []IndexKey{{ColId: "c1"}},
+		Comment:     "Orders placed\r\nby customers",
+	}
+	assert.Equal(t, "--\n-- Orders placed by customers\n--\n"+
+		"CREATE TABLE orders (\n"+
+		"\tid INT64, -- Order number, assigned at checkout\n"+
+		") PRIMARY KEY (id)", ct.PrintCreateTable(Schema{"t1": ct}, Config{Comments: true}))
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+const (
+	// MaxDDLBatchStatements is the maximum number of statements sent in a
+	// single UpdateDatabaseDdl request.
+	MaxDDLBatchStatements = 1000
+	// MaxDDLBatchBytes is the maximum total size of the statements sent in a
+	// single UpdateDatabaseDdl request, which keeps requests well below
+	// Spanner's request size limit.
+	MaxDDLBatchBytes = 4 << 20
+)
+
+// SplitDDLBatches splits statements, e.g. the output of GetDDL, into batches
+// of at most maxStatements statements and maxBytes bytes, to be applied by
+// consecutive UpdateDatabaseDdl requests. Statement order is kept within and
+// across batches, so the ordering of GetDDL, e.g. tables before their
+// indexes and foreign keys, holds when the batches are applied in order. A
+// statement larger than maxBytes is put in a batch of its own.
+func SplitDDLBatches(statements []string, maxStatements, maxBytes int) [][]string {
+	var batches [][]string
+	var batch []string
+	size := 0
+	for _, stmt := range statements {
+		if len(batch) > 0 && (len(batch) >= maxStatements || size+len(stmt) > maxBytes) {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+		batch = append(batch, stmt)
+		size += len(stmt)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
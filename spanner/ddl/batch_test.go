@@ -0,0 +1,33 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDDLBatches(t *testing.T) {
+	stmts := []string{"CREATE TABLE a", "CREATE INDEX a_idx", "CREATE TABLE b", "ALTER TABLE b ADD FK"}
+	assert.Nil(t, SplitDDLBatches(nil, 2, 100))
+	assert.Equal(t, [][]string{stmts}, SplitDDLBatches(stmts, MaxDDLBatchStatements, MaxDDLBatchBytes))
+	assert.Equal(t, [][]string{stmts[:2], stmts[2:]}, SplitDDLBatches(stmts, 2, 100))
+	assert.Equal(t, [][]string{stmts[:1], stmts[1:2], stmts[2:3], stmts[3:]}, SplitDDLBatches(stmts, 10, 20))
+
+	large := strings.Repeat("x", 50)
+	assert.Equal(t, [][]string{{"a"}, {large}, {"b"}}, SplitDDLBatches([]string{"a", large, "b"}, 10, 20))
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+)
+
+// Clone returns a deep copy of the schema.
+func (s Schema) Clone() Schema { return deepCopy(s, false) }
+
+// Equal reports whether the schema is structurally equal to other. Nil and
+// empty maps and slices are considered equal.
+func (s Schema) Equal(other Schema) bool { return structurallyEqual(s, other) }
+
+// Hash returns a hash of the schema that is stable across runs. Schemas that
+// are Equal have the same hash.
+func (s Schema) Hash() string { return structuralHash(s) }
+
+// Clone returns a deep copy of the table.
+func (ct CreateTable) Clone() CreateTable { return deepCopy(ct, false) }
+
+// Equal reports whether the table is structurally equal to other. Nil and
+// empty maps and slices are considered equal.
+func (ct CreateTable) Equal(other CreateTable) bool { return structurallyEqual(ct, other) }
+
+// Hash returns a hash of the table that is stable across runs. Tables that
+// are Equal have the same hash.
+func (ct CreateTable) Hash() string { return structuralHash(ct) }
+
+// Clone returns a deep copy of the column.
+func (cd ColumnDef) Clone() ColumnDef { return deepCopy(cd, false) }
+
+// Equal reports whether the column is structurally equal to other. Nil and
+// empty maps and slices are considered equal.
+func (cd ColumnDef) Equal(other ColumnDef) bool { return structurallyEqual(cd, other) }
+
+// Hash returns a hash of the column that is stable across runs. Columns that
+// are Equal have the same hash.
+func (cd ColumnDef) Hash() string { return structuralHash(cd) }
+
+// deepCopy copies v, including the maps, slices and pointers it refers to,
+// so that fields added to the AST are copied without changes here. If
+// normalize is true, empty maps and slices are replaced by nil.
+func deepCopy[T any](v T, normalize bool) T {
+	return copyValue(reflect.ValueOf(v), normalize).Interface().(T)
+}
+
+func copyValue(v reflect.Value, normalize bool) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() || (normalize && v.Len() == 0) {
+			return reflect.Zero(v.Type())
+		}
+		m := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(copyValue(iter.Key(), normalize), copyValue(iter.Value(), normalize))
+		}
+		return m
+	case reflect.Slice:
+		if v.IsNil() || (normalize && v.Len() == 0) {
+			return reflect.Zero(v.Type())
+		}
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s.Index(i).Set(copyValue(v.Index(i), normalize))
+		}
+		return s
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		p := reflect.New(v.Type().Elem())
+		p.Elem().Set(copyValue(v.Elem(), normalize))
+		return p
+	case reflect.Struct:
+		s := reflect.New(v.Type()).Elem()
+		s.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if s.Field(i).CanSet() {
+				s.Field(i).Set(copyValue(v.Field(i), normalize))
+			}
+		}
+		return s
+	}
+	return v
+}
+
+func structurallyEqual[T any](a, b T) bool {
+	return reflect.DeepEqual(deepCopy(a, true), deepCopy(b, true))
+}
+
+// structuralHash hashes the JSON encoding of v, which is stable as maps are
+// encoded with sorted keys.
+func structuralHash[T any](v T) string {
+	// The AST only holds JSON encodable values, so encoding can't fail.
+	b, _ := json.Marshal(deepCopy(v, true))
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func cloneTestSchema() Schema {
+	return Schema{
+		"t1": {
+			Name:        "Singers",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "SingerId", Id: "c1", T: Type{Name: Int64}}, "c2": {Name: "Name", Id: "c2", T: Type{Name: String, Len: 10}, Opts: map[string]string{LocalityGroupOpt: "archive"}}},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}},
+			Indexes:     []CreateIndex{{Name: "idx", TableId: "t1", Keys: []IndexKey{{ColId: "c2"}}}},
+		},
+	}
+}
+
+func TestSchemaClone(t *testing.T) {
+	s := cloneTestSchema()
+	clone := s.Clone()
+	assert.Equal(t, s, clone)
+
+	ct := clone["t1"]
+	ct.ColIds[0] = "changed"
+	ct.ColDefs["c2"].Opts[LocalityGroupOpt] = "changed"
+	ct.PrimaryKeys[0].Desc = true
+	ct.Indexes[0].Keys[0].ColId = "changed"
+	assert.Equal(t, cloneTestSchema(), s)
+
+	cd := s["t1"].ColDefs["c2"].Clone()
+	cd.Opts[LocalityGroupOpt] = "changed"
+	assert.Equal(t, "archive", s["t1"].ColDefs["c2"].Opts[LocalityGroupOpt])
+}
+
+func TestSchemaEqualAndHash(t *testing.T) {
+	a, b := cloneTestSchema(), cloneTestSchema()
+	assert.True(t, a.Equal(b))
+	assert.Equal(t, a.Hash(), b.Hash())
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, a.Hash(), cloneTestSchema().Hash())
+	}
+
+	// Nil and empty maps and slices are equal.
+	ct := b["t1"]
+	ct.ForeignKeys = []Foreignkey{}
+	ct.CheckConstraints = nil
+	b["t1"] = ct
+	assert.True(t, a.Equal(b))
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.True(t, ColumnDef{Name: "c"}.Equal(ColumnDef{Name: "c", Opts: map[string]string{}}))
+
+	ct.ColDefs["c1"] = ColumnDef{Name: "SingerId", Id: "c1", T: Type{Name: Int64}, NotNull: true}
+	assert.False(t, a.Equal(b))
+	assert.NotEqual(t, a.Hash(), b.Hash())
+	assert.False(t, a["t1"].Equal(b["t1"]))
+	assert.NotEqual(t, a["t1"].ColDefs["c1"].Hash(), b["t1"].ColDefs["c1"].Hash())
+}
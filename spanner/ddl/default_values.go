@@ -0,0 +1,271 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+)
+
+// Source default expressions translated by TranslateDefaultExpression, in
+// lower case and without the parentheses of calls without arguments.
+var (
+	timestampDefaults = map[string]bool{
+		"now": true, "current_timestamp": true, "localtimestamp": true, "sysdate": true, "systimestamp": true,
+		"getdate": true, "getutcdate": true, "sysdatetime": true, "utc_timestamp": true,
+		"clock_timestamp": true, "statement_timestamp": true, "transaction_timestamp": true,
+	}
+	dateDefaults = map[string]bool{"current_date": true, "curdate": true}
+	uuidDefaults = map[string]bool{"uuid": true, "gen_random_uuid": true, "uuid_generate_v4": true, "newid": true, "sys_guid": true}
+	boolDefaults = map[string]string{
+		"true": "TRUE", "'true'": "TRUE", "'t'": "TRUE", "b'1'": "TRUE", "'1'": "TRUE", "1": "TRUE",
+		"false": "FALSE", "'false'": "FALSE", "'f'": "FALSE", "b'0'": "FALSE", "'0'": "FALSE", "0": "FALSE",
+	}
+)
+
+var (
+	// Matches calls without arguments, or with a fractional seconds
+	// precision, e.g. NOW() and CURRENT_TIMESTAMP(6).
+	noArgCallRegexp = regexp.MustCompile(`^([a-z_]+)\s*\(\s*[0-9]*\s*\)$`)
+	// Matches the PostgreSQL casts that information_schema appends to
+	// defaults, e.g. 'abc'::character varying.
+	pgCastSuffixRegexp = regexp.MustCompile(`(?i)::\s*[a-z_][a-z0-9_ ]*(\([0-9, ]*\))?(\[\])?$`)
+)
+
+// Kinds of source default expressions, see ClassifyDefaultExpression.
+const (
+	// DefaultKindLiteral is a literal of the type of the column, e.g. 0,
+	// 'abc' or TRUE.
+	DefaultKindLiteral = "literal"
+	// DefaultKindCurrentTime is the current date or time, e.g. NOW() or
+	// CURRENT_TIMESTAMP, of a DATE or TIMESTAMP column.
+	DefaultKindCurrentTime = "current-time"
+	// DefaultKindUUID is a generated UUID, e.g. UUID() or gen_random_uuid(),
+	// of a STRING or UUID column.
+	DefaultKindUUID = "uuid"
+	// DefaultKindSequence is the next value of a sequence, e.g.
+	// nextval('orders_id_seq'::regclass).
+	DefaultKindSequence = "sequence"
+	// DefaultKindExpression is any other expression, which has to be
+	// verified against Spanner.
+	DefaultKindExpression = "expression"
+)
+
+var (
+	// Matches numeric literals, e.g. -1, 2.5 or 1e10.
+	numericLiteralRegexp = regexp.MustCompile(`^[-+]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?$`)
+	// Matches PostgreSQL nextval calls, e.g. nextval('orders_id_seq'::regclass).
+	nextvalRegexp = regexp.MustCompile(`(?i)^nextval\s*\(\s*'([^']+)'\s*(::\s*regclass)?\s*\)$`)
+)
+
+// ClassifyDefaultExpression returns the kind of the source default
+// expression expr of a column of Spanner type ty. Literals, current date and
+// time functions and UUID generators are only classified as such when they
+// suit ty, so that their translation by TranslateDefaultExpression is a
+// valid default of the column.
+func ClassifyDefaultExpression(expr string, ty Type) string {
+	e, name := normalizeDefaultExpression(expr)
+	if _, ok := DefaultSequenceName(expr); ok {
+		return DefaultKindSequence
+	}
+	if ty.IsArray {
+		return DefaultKindExpression
+	}
+	switch {
+	case timestampDefaults[name] || dateDefaults[name]:
+		if ty.Name == Timestamp || ty.Name == Date {
+			return DefaultKindCurrentTime
+		}
+		return DefaultKindExpression
+	case uuidDefaults[name]:
+		if ty.Name == String || ty.Name == UUID {
+			return DefaultKindUUID
+		}
+		return DefaultKindExpression
+	case name == "null":
+		return DefaultKindLiteral
+	}
+	numeric := ty.Name == Int64 || ty.Name == Float32 || ty.Name == Float64 || ty.Name == Numeric
+	if _, ok := boolDefaults[name]; ok && ty.Name == Bool {
+		return DefaultKindLiteral
+	}
+	if s, _, ok := stringLiteral(e); ok {
+		if ty.Name == String {
+			return DefaultKindLiteral
+		}
+		e = s
+	}
+	if numeric && numericLiteralRegexp.MatchString(e) {
+		if ty.Name != Int64 || !strings.ContainsAny(e, ".eE") {
+			return DefaultKindLiteral
+		}
+	}
+	return DefaultKindExpression
+}
+
+// DefaultSequenceName returns the name, without its schema, of the sequence
+// whose next value is the source default expression expr.
+func DefaultSequenceName(expr string) (string, bool) {
+	e, _ := normalizeDefaultExpression(expr)
+	m := nextvalRegexp.FindStringSubmatch(e)
+	if m == nil {
+		return "", false
+	}
+	name := m[1][strings.LastIndex(m[1], ".")+1:]
+	return strings.Trim(name, `"`), true
+}
+
+// normalizeDefaultExpression returns expr without its enclosing parentheses
+// and PostgreSQL cast, and its name in lower case, without the parentheses
+// of calls without arguments.
+func normalizeDefaultExpression(expr string) (string, string) {
+	e := strings.TrimSpace(expr)
+	// SQL Server wraps defaults in parentheses, e.g. ((0)) or (getdate()).
+	for strings.HasPrefix(e, "(") && strings.HasSuffix(e, ")") && balancedParens(e[1:len(e)-1]) {
+		e = strings.TrimSpace(e[1 : len(e)-1])
+	}
+	e = strings.TrimSpace(pgCastSuffixRegexp.ReplaceAllString(e, ""))
+	name := strings.ToLower(e)
+	if m := noArgCallRegexp.FindStringSubmatch(name); m != nil {
+		name = m[1]
+	}
+	return e, name
+}
+
+// TranslateDefaultExpression translates the common source default
+// expressions that Spanner doesn't accept verbatim into their Spanner
+// equivalents for the column type ty and the dialect: current date and
+// time functions, UUID generators, NULL, boolean literals and string
+// literals. Other expressions are returned unchanged.
+func TranslateDefaultExpression(expr string, ty Type, dialect string) string {
+	pg := dialect == constants.DIALECT_POSTGRESQL
+	e, name := normalizeDefaultExpression(expr)
+	switch {
+	case timestampDefaults[name] && ty.Name == Date, dateDefaults[name]:
+		if pg {
+			return "CURRENT_DATE"
+		}
+		return "CURRENT_DATE()"
+	case timestampDefaults[name]:
+		if pg {
+			return "CURRENT_TIMESTAMP"
+		}
+		return "CURRENT_TIMESTAMP()"
+	case uuidDefaults[name]:
+		if pg {
+			return "spanner.generate_uuid()"
+		}
+		if ty.Name == UUID {
+			return "NEW_UUID()"
+		}
+		return "GENERATE_UUID()"
+	case name == "null":
+		return "NULL"
+	}
+	if b, ok := boolDefaults[name]; ok && ty.Name == Bool {
+		return b
+	}
+	if s, q, ok := stringLiteral(e); ok {
+		// Numeric defaults are sometimes quoted, e.g. '0'::integer.
+		if _, err := strconv.ParseFloat(s, 64); err == nil && (ty.Name == Int64 || ty.Name == Float32 || ty.Name == Float64 || ty.Name == Numeric) {
+			return s
+		}
+		return quoteStringLiteral(s, q, pg)
+	}
+	return expr
+}
+
+// stringLiteral returns the body of e, with its escape sequences kept
+// verbatim, and its quote if e is a single string literal, optionally with
+// an N prefix, quoted with single quotes or, as MySQL allows, double quotes.
+func stringLiteral(e string) (string, byte, bool) {
+	if strings.HasPrefix(e, "N'") || strings.HasPrefix(e, "n'") {
+		e = e[1:]
+	}
+	if len(e) < 2 || (e[0] != '\'' && e[0] != '"') {
+		return "", 0, false
+	}
+	q := e[0]
+	for i := 1; i < len(e); i++ {
+		switch {
+		case e[i] == '\\' && i+1 < len(e):
+			i++
+		case e[i] == q && i+1 < len(e) && e[i+1] == q:
+			i++
+		case e[i] == q:
+			if i != len(e)-1 {
+				return "", 0, false
+			}
+			return e[1:i], q, true
+		}
+	}
+	return "", 0, false
+}
+
+// quoteStringLiteral returns the body s of a string literal quoted with q as
+// a single quoted literal of the dialect. Backslash escape sequences are
+// kept verbatim, and only the quotes are escaped as the dialect requires:
+// with a backslash for GoogleSQL, and doubled for PostgreSQL.
+func quoteStringLiteral(s string, q byte, pg bool) string {
+	quote := `\'`
+	if pg {
+		quote = `''`
+	}
+	var b strings.Builder
+	b.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '\'':
+			b.WriteString(quote)
+			i++
+		case s[i] == '\\' && i+1 < len(s):
+			b.WriteString(s[i : i+2])
+			i++
+		case s[i] == q && i+1 < len(s) && s[i+1] == q:
+			// A doubled quote stands for the quote itself.
+			if q == '\'' {
+				b.WriteString(quote)
+			} else {
+				b.WriteByte(q)
+			}
+			i++
+		case s[i] == '\'':
+			b.WriteString(quote)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+func balancedParens(s string) bool {
+	depth := 0
+	for _, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
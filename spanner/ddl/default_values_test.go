@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateDefaultExpression(t *testing.T) {
+	tests := []struct {
+		expr       string
+		ty         Type
+		googleSQL  string
+		postgreSQL string
+	}{
+		{"NOW()", Type{Name: Timestamp}, "CURRENT_TIMESTAMP()", "CURRENT_TIMESTAMP"},
+		{"CURRENT_TIMESTAMP", Type{Name: Timestamp}, "CURRENT_TIMESTAMP()", "CURRENT_TIMESTAMP"},
+		{"current_timestamp(6)", Type{Name: Timestamp}, "CURRENT_TIMESTAMP()", "CURRENT_TIMESTAMP"},
+		{"(getdate())", Type{Name: Timestamp}, "CURRENT_TIMESTAMP()", "CURRENT_TIMESTAMP"},
+		{"now()", Type{Name: Date}, "CURRENT_DATE()", "CURRENT_DATE"},
+		{"CURDATE()", Type{Name: Date}, "CURRENT_DATE()", "CURRENT_DATE"},
+		{"UUID()", Type{Name: String, Len: 36}, "GENERATE_UUID()", "spanner.generate_uuid()"},
+		{"gen_random_uuid()", Type{Name: UUID}, "NEW_UUID()", "spanner.generate_uuid()"},
+		{"b'1'", Type{Name: Bool}, "TRUE", "TRUE"},
+		{"'f'::boolean", Type{Name: Bool}, "FALSE", "FALSE"},
+		{"((0))", Type{Name: Bool}, "FALSE", "FALSE"},
+		{"((0))", Type{Name: Int64}, "((0))", "((0))"},
+		{"'0'::integer", Type{Name: Int64}, "0", "0"},
+		{"'it''s'::character varying", Type{Name: String, Len: MaxLength}, `'it\'s'`, "'it''s'"},
+		{"N'abc'", Type{Name: String, Len: 10}, "'abc'", "'abc'"},
+		{`'a\tb'`, Type{Name: String, Len: 10}, `'a\tb'`, `'a\tb'`},
+		{`'it\'s'`, Type{Name: String, Len: 10}, `'it\'s'`, "'it''s'"},
+		{`'C:\\dir'`, Type{Name: String, Len: 10}, `'C:\\dir'`, `'C:\\dir'`},
+		{`"it's"`, Type{Name: String, Len: 10}, `'it\'s'`, "'it''s'"},
+		{`"say ""hi"""`, Type{Name: String, Len: 10}, `'say "hi"'`, `'say "hi"'`},
+		{`"abc"`, Type{Name: String, Len: 10}, "'abc'", "'abc'"},
+		{"'a' || 'b'", Type{Name: String, Len: 10}, "'a' || 'b'", "'a' || 'b'"},
+		{"(`col2` + 1)", Type{Name: Int64}, "(`col2` + 1)", "(`col2` + 1)"},
+		{"NULL::character varying", Type{Name: String, Len: MaxLength}, "NULL", "NULL"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.googleSQL, TranslateDefaultExpression(tc.expr, tc.ty, constants.DIALECT_GOOGLESQL), tc.expr)
+		assert.Equal(t, tc.postgreSQL, TranslateDefaultExpression(tc.expr, tc.ty, constants.DIALECT_POSTGRESQL), tc.expr)
+	}
+}
+
+func TestClassifyDefaultExpression(t *testing.T) {
+	tests := []struct {
+		expr string
+		ty   Type
+		kind string
+	}{
+		{"NOW()", Type{Name: Timestamp}, DefaultKindCurrentTime},
+		{"CURRENT_DATE", Type{Name: Date}, DefaultKindCurrentTime},
+		{"NOW()", Type{Name: String, Len: MaxLength}, DefaultKindExpression},
+		{"UUID()", Type{Name: String, Len: 36}, DefaultKindUUID},
+		{"gen_random_uuid()", Type{Name: UUID}, DefaultKindUUID},
+		{"newid()", Type{Name: Int64}, DefaultKindExpression},
+		{"nextval('orders_id_seq'::regclass)", Type{Name: Int64}, DefaultKindSequence},
+		{"'t'::boolean", Type{Name: Bool}, DefaultKindLiteral},
+		{"((0))", Type{Name: Int64}, DefaultKindLiteral},
+		{"'0'::integer", Type{Name: Int64}, DefaultKindLiteral},
+		{"2.5", Type{Name: Int64}, DefaultKindExpression},
+		{"-2.5e3", Type{Name: Numeric}, DefaultKindLiteral},
+		{"'abc'::character varying", Type{Name: String, Len: MaxLength}, DefaultKindLiteral},
+		{"'abc'", Type{Name: Int64}, DefaultKindExpression},
+		{"NULL::character varying", Type{Name: String, Len: MaxLength}, DefaultKindLiteral},
+		{"'a' || 'b'", Type{Name: String, Len: 10}, DefaultKindExpression},
+		{"'{}'::integer[]", Type{Name: Int64, IsArray: true}, DefaultKindExpression},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.kind, ClassifyDefaultExpression(tc.expr, tc.ty), tc.expr)
+	}
+}
+
+func TestDefaultSequenceName(t *testing.T) {
+	name, ok := DefaultSequenceName("nextval('public.\"Orders_id_seq\"'::regclass)")
+	assert.True(t, ok)
+	assert.Equal(t, "Orders_id_seq", name)
+	name, ok = DefaultSequenceName("NEXTVAL('orders_id_seq')")
+	assert.True(t, ok)
+	assert.Equal(t, "orders_id_seq", name)
+	_, ok = DefaultSequenceName("now()")
+	assert.False(t, ok)
+}
+
+func TestPrintDefaultValueVerbatim(t *testing.T) {
+	// Defaults are translated when the schema is converted, not printed.
+	dv := DefaultValue{IsPresent: true, Value: Expression{Statement: `'it\'s'`}}
+	assert.Equal(t, ` DEFAULT ('it\'s')`, dv.PrintDefaultValue(Type{Name: String, Len: 10}))
+	dv = DefaultValue{IsPresent: true, Value: Expression{Statement: "TRUE"}}
+	assert.Equal(t, " DEFAULT (CAST(TRUE AS BOOL))", dv.PrintDefaultValue(Type{Name: Bool}))
+	assert.Equal(t, " DEFAULT (CAST(TRUE AS BOOL))", dv.PGPrintDefaultValue(Type{Name: Bool}))
+}
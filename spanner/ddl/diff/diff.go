@@ -0,0 +1,306 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff compares two Spanner schemas and generates the DDL statements
+// that evolve one into the other. This lets a schema converted from an
+// evolving source database be applied incrementally to an existing Spanner
+// database.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// GetSchemaDiffDDL returns the DDL statements that transform oldSchema into
+// newSchema. Ids are generated afresh on every conversion, so tables,
+// columns, indexes and constraints are matched by name.
+//
+// Statements are ordered so that they can be applied in sequence: foreign
+// keys, indexes and check constraints are dropped before the tables and
+// columns they reference, and added after them. Changes that Spanner can't
+// apply to an existing table, such as changing its primary key or the table
+// it is interleaved in, or adding a NOT NULL column without a default value,
+// are returned as an error.
+//
+// Search indexes, vector indexes, views, change streams and sequences are not
+// compared.
+func GetSchemaDiffDDL(c ddl.Config, oldSchema, newSchema ddl.Schema) ([]string, error) {
+	oldTables := tablesByName(oldSchema)
+	newTables := tablesByName(newSchema)
+	oldTableIds := ddl.GetSortedTableIdsBySpName(oldSchema)
+	newTableIds := ddl.GetSortedTableIdsBySpName(newSchema)
+
+	var fkDrops, indexDrops, checkDrops, tableDrops []string
+	for _, oldId := range oldTableIds {
+		oldTable := oldSchema[oldId]
+		newTable, found := newTables[oldTable.Name]
+		for _, fk := range ddl.GetSortedForeignKeys(oldTable) {
+			if found && fkUnchanged(c, oldSchema, newSchema, oldTable, newTable, fk) {
+				continue
+			}
+			if fk.Name == "" {
+				return nil, fmt.Errorf("can't drop unnamed foreign key of table %s", oldTable.Name)
+			}
+			fkDrops = append(fkDrops, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", c.Quote(oldTable.Name), c.Quote(fk.Name)))
+		}
+		for _, index := range ddl.GetSortedIndexes(oldTable) {
+			if found && indexUnchanged(c, oldSchema, newSchema, oldTable, newTable, index) {
+				continue
+			}
+			indexDrops = append(indexDrops, fmt.Sprintf("DROP INDEX %s", c.Quote(index.Name)))
+		}
+		if !found {
+			continue
+		}
+		for _, ck := range oldTable.CheckConstraints {
+			if _, ok := findCheckConstraint(newTable.CheckConstraints, ck); ok {
+				continue
+			}
+			if ck.Name == "" {
+				return nil, fmt.Errorf("can't drop unnamed check constraint %s of table %s", ck.Expr, oldTable.Name)
+			}
+			checkDrops = append(checkDrops, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", c.Quote(oldTable.Name), c.Quote(ck.Name)))
+		}
+	}
+	// Interleaved tables must be dropped before their parents.
+	for i := len(oldTableIds) - 1; i >= 0; i-- {
+		if oldTable := oldSchema[oldTableIds[i]]; newTables[oldTable.Name].Name == "" {
+			tableDrops = append(tableDrops, fmt.Sprintf("DROP TABLE %s", c.Quote(oldTable.Name)))
+		}
+	}
+
+	var tableChanges, indexCreates, fkCreates []string
+	for _, newId := range newTableIds {
+		newTable := newSchema[newId]
+		oldTable, found := oldTables[newTable.Name]
+		if !found {
+			tableChanges = append(tableChanges, newTable.PrintCreateTable(newSchema, c))
+		} else {
+			stmts, err := diffTable(c, oldSchema, newSchema, oldTable, newTable)
+			if err != nil {
+				return nil, err
+			}
+			tableChanges = append(tableChanges, stmts...)
+		}
+		for _, index := range ddl.GetSortedIndexes(newTable) {
+			if found && indexUnchanged(c, newSchema, oldSchema, newTable, oldTable, index) {
+				continue
+			}
+			indexCreates = append(indexCreates, index.PrintCreateIndex(newSchema, newTable, c))
+		}
+		for _, fk := range ddl.GetSortedForeignKeys(newTable) {
+			if found && fkUnchanged(c, newSchema, oldSchema, newTable, oldTable, fk) {
+				continue
+			}
+			fkCreates = append(fkCreates, fk.PrintForeignKeyAlterTable(newSchema, c, newId))
+		}
+	}
+
+	var stmts []string
+	for _, l := range [][]string{fkDrops, indexDrops, checkDrops, tableDrops, tableChanges, indexCreates, fkCreates} {
+		stmts = append(stmts, l...)
+	}
+	return stmts, nil
+}
+
+// diffTable returns the ALTER TABLE statements that transform oldTable into
+// newTable, which have the same name.
+func diffTable(c ddl.Config, oldSchema, newSchema ddl.Schema, oldTable, newTable ddl.CreateTable) ([]string, error) {
+	if pkNames(oldTable) != pkNames(newTable) {
+		return nil, fmt.Errorf("can't change the primary key of table %s from (%s) to (%s)", newTable.Name, pkNames(oldTable), pkNames(newTable))
+	}
+	oldParent, newParent := oldSchema[oldTable.ParentTable.Id].Name, newSchema[newTable.ParentTable.Id].Name
+	if oldParent != newParent || oldTable.ParentTable.InterleaveType != newTable.ParentTable.InterleaveType {
+		return nil, fmt.Errorf("can't change the interleaving of table %s", newTable.Name)
+	}
+
+	alterTable := fmt.Sprintf("ALTER TABLE %s ", c.Quote(newTable.Name))
+	var stmts []string
+	for _, colId := range newTable.ColIds {
+		newCol := newTable.ColDefs[colId]
+		oldCol, found := findColumn(oldTable, newCol.Name)
+		if !found {
+			// Spanner only adds NOT NULL columns to tables with rows when it
+			// can fill them in.
+			if newCol.NotNull && !newCol.DefaultValue.IsPresent && !newCol.GeneratedColumn.IsPresent && newCol.AutoGen.GenerationType == "" {
+				return nil, fmt.Errorf("can't add NOT NULL column %s to table %s without a default value", newCol.Name, newTable.Name)
+			}
+			def, _ := newCol.PrintColumnDef(c)
+			stmts = append(stmts, alterTable+"ADD COLUMN "+strings.TrimSpace(def))
+			continue
+		}
+		alters, err := alterColumn(c, newTable.Name, oldCol, newCol)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, alters...)
+	}
+	for _, colId := range oldTable.ColIds {
+		oldCol := oldTable.ColDefs[colId]
+		if _, found := findColumn(newTable, oldCol.Name); !found {
+			stmts = append(stmts, alterTable+"DROP COLUMN "+c.Quote(oldCol.Name))
+		}
+	}
+	if newParent != "" && oldTable.ParentTable.OnDelete != newTable.ParentTable.OnDelete {
+		onDelete := newTable.ParentTable.OnDelete
+		if onDelete == "" {
+			onDelete = constants.FK_NO_ACTION
+		}
+		stmts = append(stmts, alterTable+"SET ON DELETE "+onDelete)
+	}
+	if oldTable.Synonym != newTable.Synonym {
+		if oldTable.Synonym != "" {
+			stmts = append(stmts, alterTable+"DROP SYNONYM "+c.Quote(oldTable.Synonym))
+		}
+		if newTable.Synonym != "" {
+			stmts = append(stmts, newTable.PrintAddSynonym(c))
+		}
+	}
+	for _, ck := range newTable.CheckConstraints {
+		if _, ok := findCheckConstraint(oldTable.CheckConstraints, ck); ok {
+			continue
+		}
+		if ck.Name != "" {
+			stmts = append(stmts, fmt.Sprintf("%sADD CONSTRAINT %s CHECK %s", alterTable, c.Quote(ck.Name), ck.Expr))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("%sADD CHECK %s", alterTable, ck.Expr))
+		}
+	}
+	return stmts, nil
+}
+
+// alterColumn returns the ALTER COLUMN statements that transform oldCol into
+// newCol. GoogleSQL redefines the column in a single statement, and sets its
+// options in another, while PostgreSQL alters the type, NOT NULL constraint
+// and default separately.
+func alterColumn(c ddl.Config, tableName string, oldCol, newCol ddl.ColumnDef) ([]string, error) {
+	oldDef, _ := oldCol.PrintColumnDef(c)
+	newDef, _ := newCol.PrintColumnDef(c)
+	if oldDef == newDef {
+		return nil, nil
+	}
+	if oldCol.GeneratedColumn.IsPresent || newCol.GeneratedColumn.IsPresent {
+		return nil, fmt.Errorf("can't alter generated column %s of table %s", newCol.Name, tableName)
+	}
+	alterColumn := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN ", c.Quote(tableName))
+	if c.SpDialect != constants.DIALECT_POSTGRESQL {
+		var stmts []string
+		// Options can't be set by redefining the column.
+		oldBase, newBase := oldCol, newCol
+		oldBase.Opts, newBase.Opts = nil, nil
+		oldDef, _ = oldBase.PrintColumnDef(c)
+		newDef, _ = newBase.PrintColumnDef(c)
+		if oldDef != newDef {
+			stmts = append(stmts, alterColumn+strings.TrimSpace(newDef))
+		}
+		if opts := newCol.PrintOptionChanges(oldCol); len(opts) > 0 {
+			stmts = append(stmts, fmt.Sprintf("%s%s SET OPTIONS (%s)", alterColumn, c.Quote(newCol.Name), strings.Join(opts, ", ")))
+		}
+		return stmts, nil
+	}
+	var stmts []string
+	colName := c.Quote(newCol.Name)
+	if oldCol.T != newCol.T || oldCol.AllowsCommitTimestamp() != newCol.AllowsCommitTimestamp() {
+		ty := newCol.T.PGPrintColumnDefType()
+		if newCol.AllowsCommitTimestamp() {
+			ty = ddl.PGCommitTimestamp
+		}
+		stmts = append(stmts, fmt.Sprintf("%s%s TYPE %s", alterColumn, colName, ty))
+	}
+	if oldCol.NotNull != newCol.NotNull {
+		if newCol.NotNull {
+			stmts = append(stmts, fmt.Sprintf("%s%s SET NOT NULL", alterColumn, colName))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("%s%s DROP NOT NULL", alterColumn, colName))
+		}
+	}
+	oldDefault, newDefault := oldCol.DefaultValue.PGPrintDefaultValue(oldCol.T), newCol.DefaultValue.PGPrintDefaultValue(newCol.T)
+	if oldDefault != newDefault {
+		if newDefault != "" {
+			stmts = append(stmts, fmt.Sprintf("%s%s SET%s", alterColumn, colName, newDefault))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("%s%s DROP DEFAULT", alterColumn, colName))
+		}
+	}
+	return stmts, nil
+}
+
+// indexUnchanged returns true if table b has an index with the same name and
+// definition as index of table a.
+func indexUnchanged(c ddl.Config, aSchema, bSchema ddl.Schema, a, b ddl.CreateTable, index ddl.CreateIndex) bool {
+	for _, other := range b.Indexes {
+		if other.Name == index.Name {
+			return index.PrintCreateIndex(aSchema, a, c) == other.PrintCreateIndex(bSchema, b, c)
+		}
+	}
+	return false
+}
+
+// fkUnchanged returns true if table b has a foreign key with the same name
+// and definition as fk of table a.
+func fkUnchanged(c ddl.Config, aSchema, bSchema ddl.Schema, a, b ddl.CreateTable, fk ddl.Foreignkey) bool {
+	for _, other := range b.ForeignKeys {
+		if other.Name == fk.Name {
+			return fk.PrintForeignKeyAlterTable(aSchema, c, a.Id) == other.PrintForeignKeyAlterTable(bSchema, c, b.Id)
+		}
+	}
+	return false
+}
+
+// findCheckConstraint looks up a check constraint with the same name and
+// expression as ck. Unnamed check constraints are matched by expression.
+func findCheckConstraint(cks []ddl.CheckConstraint, ck ddl.CheckConstraint) (ddl.CheckConstraint, bool) {
+	for _, other := range cks {
+		if other.Name == ck.Name && other.Expr == ck.Expr {
+			return other, true
+		}
+	}
+	return ddl.CheckConstraint{}, false
+}
+
+func findColumn(ct ddl.CreateTable, name string) (ddl.ColumnDef, bool) {
+	for _, colId := range ct.ColIds {
+		if ct.ColDefs[colId].Name == name {
+			return ct.ColDefs[colId], true
+		}
+	}
+	return ddl.ColumnDef{}, false
+}
+
+// pkNames returns the primary key of ct in key order, which needn't be the
+// order of ct.PrimaryKeys.
+func pkNames(ct ddl.CreateTable) string {
+	pks := append([]ddl.IndexKey{}, ct.PrimaryKeys...)
+	sort.SliceStable(pks, func(i, j int) bool {
+		return pks[i].Order < pks[j].Order
+	})
+	var keys []string
+	for _, pk := range pks {
+		keys = append(keys, pk.PrintPkOrIndexKey(ct, ddl.Config{}))
+	}
+	return strings.Join(keys, ", ")
+}
+
+func tablesByName(s ddl.Schema) map[string]ddl.CreateTable {
+	tables := make(map[string]ddl.CreateTable)
+	for _, ct := range s {
+		tables[ct.Name] = ct
+	}
+	return tables
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+func oldSchema() ddl.Schema {
+	return ddl.Schema{
+		"t1": {
+			Name:   "singers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "singer_id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 50}},
+				"c3": {Name: "age", Id: "c3", T: ddl.Type{Name: ddl.Int64}},
+			},
+			PrimaryKeys:      []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			Indexes:          []ddl.CreateIndex{{Name: "singers_by_age", TableId: "t1", Id: "i1", Keys: []ddl.IndexKey{{ColId: "c3", Order: 1}}}},
+			CheckConstraints: []ddl.CheckConstraint{{Id: "ck1", Name: "age_check", Expr: "(age > 0)"}},
+		},
+		"t2": {
+			Name:   "albums",
+			Id:     "t2",
+			ColIds: []string{"c4", "c5", "c6"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c4": {Name: "album_id", Id: "c4", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c5": {Name: "singer_id", Id: "c5", T: ddl.Type{Name: ddl.Int64}},
+				"c6": {Name: "title", Id: "c6", T: ddl.Type{Name: ddl.String, Len: 100}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c4", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "fk_singer", ColIds: []string{"c5"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}, Id: "f1", OnDelete: constants.FK_NO_ACTION, OnUpdate: constants.FK_NO_ACTION}},
+		},
+		"t3": {
+			Name:   "concerts",
+			Id:     "t3",
+			ColIds: []string{"c7"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c7": {Name: "concert_id", Id: "c7", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c7", Order: 1}},
+			Indexes:     []ddl.CreateIndex{{Name: "concerts_by_id", TableId: "t3", Id: "i2", Keys: []ddl.IndexKey{{ColId: "c7", Order: 1, Desc: true}}}},
+		},
+	}
+}
+
+// newSchema evolves oldSchema using freshly generated ids: singers gains a
+// column, drops another and has its name widened; albums changes its foreign
+// key; concerts is dropped and venues is created.
+func newSchema() ddl.Schema {
+	return ddl.Schema{
+		"t10": {
+			Name:   "singers",
+			Id:     "t10",
+			ColIds: []string{"c10", "c11", "c12"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c10": {Name: "singer_id", Id: "c10", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c11": {Name: "name", Id: "c11", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+				"c12": {Name: "country", Id: "c12", T: ddl.Type{Name: ddl.String, Len: 2}},
+			},
+			PrimaryKeys:      []ddl.IndexKey{{ColId: "c10", Order: 1}},
+			Indexes:          []ddl.CreateIndex{{Name: "singers_by_country", TableId: "t10", Id: "i10", Keys: []ddl.IndexKey{{ColId: "c12", Order: 1}}}},
+			CheckConstraints: []ddl.CheckConstraint{{Id: "ck10", Name: "country_check", Expr: "(LENGTH(country) = 2)"}},
+		},
+		"t11": {
+			Name:   "albums",
+			Id:     "t11",
+			ColIds: []string{"c13", "c14", "c15"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c13": {Name: "album_id", Id: "c13", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c14": {Name: "singer_id", Id: "c14", T: ddl.Type{Name: ddl.Int64}},
+				"c15": {Name: "title", Id: "c15", T: ddl.Type{Name: ddl.String, Len: 100}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c13", Order: 1}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "fk_singer", ColIds: []string{"c14"}, ReferTableId: "t10", ReferColumnIds: []string{"c10"}, Id: "f10", OnDelete: constants.FK_CASCADE, OnUpdate: constants.FK_NO_ACTION}},
+		},
+		"t12": {
+			Name:   "venues",
+			Id:     "t12",
+			ColIds: []string{"c16"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c16": {Name: "venue_id", Id: "c16", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c16", Order: 1}},
+		},
+	}
+}
+
+func TestGetSchemaDiffDDL(t *testing.T) {
+	stmts, err := GetSchemaDiffDDL(ddl.Config{}, oldSchema(), newSchema())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE albums DROP CONSTRAINT fk_singer",
+		"DROP INDEX concerts_by_id",
+		"DROP INDEX singers_by_age",
+		"ALTER TABLE singers DROP CONSTRAINT age_check",
+		"DROP TABLE concerts",
+		"ALTER TABLE singers ALTER COLUMN name STRING(MAX) NOT NULL",
+		"ALTER TABLE singers ADD COLUMN country STRING(2)",
+		"ALTER TABLE singers DROP COLUMN age",
+		"ALTER TABLE singers ADD CONSTRAINT country_check CHECK (LENGTH(country) = 2)",
+		"CREATE TABLE venues (\n\tvenue_id INT64 NOT NULL ,\n) PRIMARY KEY (venue_id)",
+		"CREATE INDEX singers_by_country ON singers (country)",
+		"ALTER TABLE albums ADD CONSTRAINT fk_singer FOREIGN KEY (singer_id) REFERENCES singers (singer_id) ON DELETE CASCADE",
+	}, stmts)
+}
+
+func TestGetSchemaDiffDDLPG(t *testing.T) {
+	stmts, err := GetSchemaDiffDDL(ddl.Config{SpDialect: constants.DIALECT_POSTGRESQL}, oldSchema(), newSchema())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE albums DROP CONSTRAINT fk_singer",
+		"DROP INDEX concerts_by_id",
+		"DROP INDEX singers_by_age",
+		"ALTER TABLE singers DROP CONSTRAINT age_check",
+		"DROP TABLE concerts",
+		"ALTER TABLE singers ALTER COLUMN name TYPE VARCHAR(2621440)",
+		"ALTER TABLE singers ALTER COLUMN name SET NOT NULL",
+		"ALTER TABLE singers ADD COLUMN country VARCHAR(2)",
+		"ALTER TABLE singers DROP COLUMN age",
+		"ALTER TABLE singers ADD CONSTRAINT country_check CHECK (LENGTH(country) = 2)",
+		"CREATE TABLE venues (\n\tvenue_id INT8 NOT NULL ,\n\tPRIMARY KEY (venue_id)\n)",
+		"CREATE INDEX singers_by_country ON singers (country)",
+		"ALTER TABLE albums ADD CONSTRAINT fk_singer FOREIGN KEY (singer_id) REFERENCES singers (singer_id) ON DELETE CASCADE",
+	}, stmts)
+}
+
+func TestGetSchemaDiffDDLNoChanges(t *testing.T) {
+	stmts, err := GetSchemaDiffDDL(ddl.Config{}, oldSchema(), oldSchema())
+	assert.Nil(t, err)
+	assert.Empty(t, stmts)
+}
+
+func TestGetSchemaDiffDDLSynonym(t *testing.T) {
+	withSynonym := oldSchema()
+	concerts := withSynonym["t3"]
+	concerts.Synonym = "shows"
+	withSynonym["t3"] = concerts
+	stmts, err := GetSchemaDiffDDL(ddl.Config{}, oldSchema(), withSynonym)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ALTER TABLE concerts ADD SYNONYM shows"}, stmts)
+
+	renamed := oldSchema()
+	concerts.Synonym = "gigs"
+	renamed["t3"] = concerts
+	stmts, err = GetSchemaDiffDDL(ddl.Config{}, withSynonym, renamed)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ALTER TABLE concerts DROP SYNONYM shows", "ALTER TABLE concerts ADD SYNONYM gigs"}, stmts)
+}
+
+func TestGetSchemaDiffDDLColumnOptions(t *testing.T) {
+	withOpts := oldSchema()
+	singers := withOpts["t1"]
+	singers.ColIds = append(singers.ColIds, "c4")
+	singers.ColDefs["c4"] = ddl.ColumnDef{Name: "updated_at", Id: "c4", T: ddl.Type{Name: ddl.Timestamp}}
+	withOpts["t1"] = singers
+
+	changed := oldSchema()
+	singers = changed["t1"]
+	singers.ColIds = append(singers.ColIds, "c4")
+	singers.ColDefs["c2"] = ddl.ColumnDef{Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 100}, Opts: map[string]string{ddl.LocalityGroupOpt: "cold"}}
+	singers.ColDefs["c4"] = ddl.ColumnDef{Name: "updated_at", Id: "c4", T: ddl.Type{Name: ddl.Timestamp}, Opts: map[string]string{ddl.AllowCommitTimestampOpt: "true"}}
+	changed["t1"] = singers
+
+	stmts, err := GetSchemaDiffDDL(ddl.Config{}, withOpts, changed)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE singers ALTER COLUMN name STRING(100)",
+		"ALTER TABLE singers ALTER COLUMN name SET OPTIONS (locality_group = 'cold')",
+		"ALTER TABLE singers ALTER COLUMN updated_at SET OPTIONS (allow_commit_timestamp = true)",
+	}, stmts)
+
+	stmts, err = GetSchemaDiffDDL(ddl.Config{}, changed, withOpts)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"ALTER TABLE singers ALTER COLUMN name STRING(50)",
+		"ALTER TABLE singers ALTER COLUMN name SET OPTIONS (locality_group = null)",
+		"ALTER TABLE singers ALTER COLUMN updated_at SET OPTIONS (allow_commit_timestamp = null)",
+	}, stmts)
+}
+
+func TestGetSchemaDiffDDLAddNotNullColumn(t *testing.T) {
+	added := oldSchema()
+	singers := added["t1"]
+	singers.ColIds = append(singers.ColIds, "c4")
+	singers.ColDefs["c4"] = ddl.ColumnDef{Name: "active", Id: "c4", T: ddl.Type{Name: ddl.Bool}, NotNull: true}
+	added["t1"] = singers
+	_, err := GetSchemaDiffDDL(ddl.Config{}, oldSchema(), added)
+	assert.NotNil(t, err)
+
+	singers.ColDefs["c4"] = ddl.ColumnDef{Name: "active", Id: "c4", T: ddl.Type{Name: ddl.Bool}, NotNull: true, DefaultValue: ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{Statement: "TRUE"}}}
+	stmts, err := GetSchemaDiffDDL(ddl.Config{}, oldSchema(), added)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ALTER TABLE singers ADD COLUMN active BOOL NOT NULL  DEFAULT (CAST(TRUE AS BOOL))"}, stmts)
+}
+
+func TestGetSchemaDiffDDLPrimaryKeyOrder(t *testing.T) {
+	old := oldSchema()
+	singers := old["t1"]
+	singers.PrimaryKeys = []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}}
+	old["t1"] = singers
+	reordered := oldSchema()
+	singers = reordered["t1"]
+	singers.PrimaryKeys = []ddl.IndexKey{{ColId: "c2", Order: 2}, {ColId: "c1", Order: 1}}
+	reordered["t1"] = singers
+	stmts, err := GetSchemaDiffDDL(ddl.Config{}, old, reordered)
+	assert.Nil(t, err)
+	assert.Empty(t, stmts)
+}
+
+func TestGetSchemaDiffDDLErrors(t *testing.T) {
+	pkChanged := oldSchema()
+	singers := pkChanged["t1"]
+	singers.PrimaryKeys = []ddl.IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}}
+	pkChanged["t1"] = singers
+	_, err := GetSchemaDiffDDL(ddl.Config{}, oldSchema(), pkChanged)
+	assert.NotNil(t, err)
+
+	interleaved := oldSchema()
+	albums := interleaved["t2"]
+	albums.ParentTable = ddl.InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE}
+	interleaved["t2"] = albums
+	_, err = GetSchemaDiffDDL(ddl.Config{}, oldSchema(), interleaved)
+	assert.NotNil(t, err)
+
+	unnamedFk := oldSchema()
+	albums = unnamedFk["t2"]
+	albums.ForeignKeys = []ddl.Foreignkey{{ColIds: []string{"c5"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}, Id: "f1"}}
+	unnamedFk["t2"] = albums
+	_, err = GetSchemaDiffDDL(ddl.Config{}, unnamedFk, newSchema())
+	assert.NotNil(t, err)
+}
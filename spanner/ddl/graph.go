@@ -0,0 +1,215 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+)
+
+// DependencyKind is the kind of a dependency between two tables.
+type DependencyKind string
+
+const (
+	// InterleaveDependency is the dependency of an interleaved table on its
+	// parent table.
+	InterleaveDependency DependencyKind = "INTERLEAVE"
+	// ForeignKeyDependency is the dependency of a table on a table referenced
+	// by one of its foreign keys.
+	ForeignKeyDependency DependencyKind = "FOREIGN KEY"
+)
+
+// DependencyEdge is a dependency of table FromTableId on table ToTableId:
+// rows of ToTableId must exist before the rows of FromTableId referring to
+// them. Name is the foreign key name for foreign key dependencies.
+type DependencyEdge struct {
+	FromTableId string
+	ToTableId   string
+	Kind        DependencyKind
+	Name        string
+}
+
+// DependencyGraph is the graph of the dependencies between the tables of a
+// schema, from interleaving and foreign keys. Dependencies on tables that
+// are not in the schema are left out.
+type DependencyGraph struct {
+	schema Schema
+	edges  map[string][]DependencyEdge // Edges by FromTableId.
+}
+
+// CycleError is returned when the tables of a schema can't be ordered as
+// their dependencies form a cycle.
+type CycleError struct {
+	Edges []DependencyEdge
+}
+
+func (e *CycleError) Error() string {
+	var l []string
+	for _, edge := range e.Edges {
+		l = append(l, fmt.Sprintf("%s -[%s]-> %s", edge.FromTableId, edge.Kind, edge.ToTableId))
+	}
+	return fmt.Sprintf("tables have cyclic dependencies: %s", strings.Join(l, ", "))
+}
+
+// NewDependencyGraph builds the dependency graph of the tables of s.
+func NewDependencyGraph(s Schema) *DependencyGraph {
+	g := &DependencyGraph{schema: s, edges: make(map[string][]DependencyEdge)}
+	for tableId, ct := range s {
+		if _, ok := s[ct.ParentTable.Id]; ok {
+			g.edges[tableId] = append(g.edges[tableId], DependencyEdge{FromTableId: tableId, ToTableId: ct.ParentTable.Id, Kind: InterleaveDependency})
+		}
+		for _, fk := range GetSortedForeignKeys(ct) {
+			if _, ok := s[fk.ReferTableId]; ok {
+				g.edges[tableId] = append(g.edges[tableId], DependencyEdge{FromTableId: tableId, ToTableId: fk.ReferTableId, Kind: ForeignKeyDependency, Name: fk.Name})
+			}
+		}
+	}
+	return g
+}
+
+// Dependencies returns the dependencies of table tableId: its parent table
+// first, then the tables referenced by its foreign keys in order of
+// foreign key name.
+func (g *DependencyGraph) Dependencies(tableId string) []DependencyEdge {
+	return append([]DependencyEdge{}, g.edges[tableId]...)
+}
+
+// Edges returns all dependencies, grouped by table in alphabetical order of
+// table names.
+func (g *DependencyGraph) Edges() []DependencyEdge {
+	var edges []DependencyEdge
+	for _, tableId := range g.sortedTableIds() {
+		edges = append(edges, g.edges[tableId]...)
+	}
+	return edges
+}
+
+// TopologicalOrder returns the table ids ordered so that each table comes
+// after the tables it depends on, e.g. for loading data. Tables that don't
+// depend on each other are in alphabetical order of table names. Foreign
+// keys of a table referencing the table itself are ignored. If the
+// dependencies form a cycle, a *CycleError holding the edges of one of the
+// cycles is returned.
+func (g *DependencyGraph) TopologicalOrder() ([]string, error) {
+	remaining := make(map[string]int)
+	dependents := make(map[string][]string)
+	for tableId := range g.schema {
+		remaining[tableId] = 0
+	}
+	for tableId, edges := range g.edges {
+		for _, e := range edges {
+			if e.ToTableId != tableId {
+				remaining[tableId]++
+				dependents[e.ToTableId] = append(dependents[e.ToTableId], tableId)
+			}
+		}
+	}
+	var ready, order []string
+	for _, tableId := range g.sortedTableIds() {
+		if remaining[tableId] == 0 {
+			ready = append(ready, tableId)
+		}
+	}
+	for len(ready) > 0 {
+		tableId := ready[0]
+		ready = ready[1:]
+		order = append(order, tableId)
+		var unblocked []string
+		for _, d := range dependents[tableId] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				unblocked = append(unblocked, d)
+			}
+		}
+		ready = append(ready, unblocked...)
+		sortIdsByName(ready, func(id string) string { return g.schema[id].Name })
+	}
+	if len(order) < len(g.schema) {
+		return nil, &CycleError{Edges: g.FindCycle()}
+	}
+	return order, nil
+}
+
+// FindCycle returns the edges of a cycle of dependencies, in the order they
+// are followed, or nil if there is no cycle. Foreign keys of a table
+// referencing the table itself are ignored.
+func (g *DependencyGraph) FindCycle() []DependencyEdge {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int)
+	var path []DependencyEdge
+	var visit func(tableId string) []DependencyEdge
+	visit = func(tableId string) []DependencyEdge {
+		state[tableId] = inProgress
+		for _, e := range g.edges[tableId] {
+			if e.ToTableId == tableId {
+				continue
+			}
+			path = append(path, e)
+			switch state[e.ToTableId] {
+			case inProgress:
+				for i, pe := range path {
+					if pe.FromTableId == e.ToTableId {
+						return append([]DependencyEdge{}, path[i:]...)
+					}
+				}
+			case unvisited:
+				if cycle := visit(e.ToTableId); cycle != nil {
+					return cycle
+				}
+			}
+			path = path[:len(path)-1]
+		}
+		state[tableId] = done
+		return nil
+	}
+	for _, tableId := range g.sortedTableIds() {
+		if state[tableId] == unvisited {
+			if cycle := visit(tableId); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+func (g *DependencyGraph) sortedTableIds() []string {
+	var ids []string
+	for id := range g.schema {
+		ids = append(ids, id)
+	}
+	sortIdsByName(ids, func(id string) string { return g.schema[id].Name })
+	return ids
+}
+
+// GetSortedTableIdsForDataLoad returns the table ids in the order to load
+// their data in: parent tables before their interleaved tables and
+// referenced tables before the tables referencing them, so that loading
+// succeeds with foreign keys already created. If foreign keys form a cycle
+// no such order exists: the order of GetSortedTableIdsBySpName is returned
+// with false, and foreign keys have to be created after the data is loaded.
+func GetSortedTableIdsForDataLoad(s Schema) ([]string, bool) {
+	tableIds, err := NewDependencyGraph(s).TopologicalOrder()
+	if err != nil {
+		logger.Log.Debug(fmt.Sprintf("can't order tables by foreign keys: %v", err))
+		return GetSortedTableIdsBySpName(s), false
+	}
+	return tableIds, true
+}
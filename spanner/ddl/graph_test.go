@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	s := Schema{
+		"t1": {Name: "Singers", Id: "t1"},
+		"t2": {Name: "Albums", Id: "t2", ParentTable: InterleavedParent{Id: "t1"}},
+		"t3": {Name: "Concerts", Id: "t3", ForeignKeys: []Foreignkey{
+			{Name: "fk_venue", ReferTableId: "t4"},
+			{Name: "fk_album", ReferTableId: "t2"},
+			{Name: "fk_missing", ReferTableId: "t9"},
+		}},
+		"t4": {Name: "Venues", Id: "t4", ForeignKeys: []Foreignkey{{Name: "fk_self", ReferTableId: "t4"}}},
+		"t5": {Name: "Awards", Id: "t5"},
+	}
+	g := NewDependencyGraph(s)
+	assert.Equal(t, []DependencyEdge{
+		{FromTableId: "t2", ToTableId: "t1", Kind: InterleaveDependency},
+		{FromTableId: "t3", ToTableId: "t2", Kind: ForeignKeyDependency, Name: "fk_album"},
+		{FromTableId: "t3", ToTableId: "t4", Kind: ForeignKeyDependency, Name: "fk_venue"},
+		{FromTableId: "t4", ToTableId: "t4", Kind: ForeignKeyDependency, Name: "fk_self"},
+	}, g.Edges())
+	assert.Equal(t, []DependencyEdge{{FromTableId: "t2", ToTableId: "t1", Kind: InterleaveDependency}}, g.Dependencies("t2"))
+	assert.Nil(t, g.FindCycle())
+	order, err := g.TopologicalOrder()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"t5", "t1", "t2", "t4", "t3"}, order)
+}
+
+func TestDependencyGraphCycle(t *testing.T) {
+	s := Schema{
+		"t1": {Name: "A", Id: "t1", ForeignKeys: []Foreignkey{{Name: "fk_ab", ReferTableId: "t2"}}},
+		"t2": {Name: "B", Id: "t2", ForeignKeys: []Foreignkey{{Name: "fk_bc", ReferTableId: "t3"}}},
+		"t3": {Name: "C", Id: "t3", ForeignKeys: []Foreignkey{{Name: "fk_cb", ReferTableId: "t2"}}},
+	}
+	g := NewDependencyGraph(s)
+	cycle := []DependencyEdge{
+		{FromTableId: "t2", ToTableId: "t3", Kind: ForeignKeyDependency, Name: "fk_bc"},
+		{FromTableId: "t3", ToTableId: "t2", Kind: ForeignKeyDependency, Name: "fk_cb"},
+	}
+	assert.Equal(t, cycle, g.FindCycle())
+	order, err := g.TopologicalOrder()
+	assert.Nil(t, order)
+	assert.Equal(t, &CycleError{Edges: cycle}, err)
+	assert.Equal(t, "tables have cyclic dependencies: t2 -[FOREIGN KEY]-> t3, t3 -[FOREIGN KEY]-> t2", err.Error())
+}
+
+func TestGetSortedTableIdsForDataLoad(t *testing.T) {
+	logger.Log = zap.NewNop()
+	s := Schema{
+		"t1": {Name: "A", Id: "t1", ForeignKeys: []Foreignkey{{Name: "fk_ab", ReferTableId: "t2"}}},
+		"t2": {Name: "B", Id: "t2"},
+	}
+	tableIds, ok := GetSortedTableIdsForDataLoad(s)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"t2", "t1"}, tableIds)
+
+	s["t2"] = CreateTable{Name: "B", Id: "t2", ForeignKeys: []Foreignkey{{Name: "fk_ba", ReferTableId: "t1"}}}
+	tableIds, ok = GetSortedTableIdsForDataLoad(s)
+	assert.False(t, ok)
+	assert.Equal(t, []string{"t1", "t2"}, tableIds)
+}
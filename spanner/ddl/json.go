@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is the version of the JSON encoding of the AST written by
+// MarshalSchemaJSON. Bump it when a change to the AST needs decoding of
+// older encodings to be adjusted, and handle the older versions in
+// Schema.UnmarshalJSON. Unversioned encodings are version 0, which decodes
+// like version 1.
+const SchemaVersion = 1
+
+// versionedSchema is the JSON encoding of a Schema written by
+// MarshalSchemaJSON.
+type versionedSchema struct {
+	SchemaVersion *int
+	Tables        map[string]CreateTable
+}
+
+// MarshalSchemaJSON encodes s as a JSON object holding the SchemaVersion
+// and the tables of s.
+func MarshalSchemaJSON(s Schema) ([]byte, error) {
+	v := SchemaVersion
+	return json.Marshal(versionedSchema{SchemaVersion: &v, Tables: s})
+}
+
+// UnmarshalSchemaJSON decodes a schema encoded by MarshalSchemaJSON, or
+// unversioned as a plain JSON object of tables like in session files.
+func UnmarshalSchemaJSON(b []byte) (Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UnmarshalJSON decodes both the versioned and the unversioned encoding of a
+// schema. Fields unknown to this version of the AST are ignored, so schemas
+// written by newer versions can still be read.
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var vs versionedSchema
+	version := 0
+	tables := make(map[string]CreateTable)
+	// An unversioned schema either fails to decode as a versioned one, or
+	// has no SchemaVersion.
+	if err := json.Unmarshal(b, &vs); err == nil && vs.SchemaVersion != nil {
+		version, tables = *vs.SchemaVersion, vs.Tables
+	} else if err := json.Unmarshal(b, &tables); err != nil {
+		return fmt.Errorf("can't decode schema: %v", err)
+	}
+	if version < 0 {
+		return fmt.Errorf("can't decode schema: invalid schema version %d", version)
+	}
+	if tables == nil {
+		tables = make(map[string]CreateTable)
+	}
+	*s = tables
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaJSON(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:        "Singers",
+			Id:          "t1",
+			ColIds:      []string{"c1"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "SingerId", Id: "c1", T: Type{Name: Int64}, NotNull: true}},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}},
+		},
+	}
+
+	b, err := MarshalSchemaJSON(s)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"SchemaVersion":1`)
+	got, err := UnmarshalSchemaJSON(b)
+	assert.Nil(t, err)
+	assert.Equal(t, s, got)
+
+	// Plain encoding, as in session files.
+	b, err = json.Marshal(s)
+	assert.Nil(t, err)
+	got, err = UnmarshalSchemaJSON(b)
+	assert.Nil(t, err)
+	assert.Equal(t, s, got)
+
+	// Fields unknown to this version are ignored.
+	got, err = UnmarshalSchemaJSON([]byte(`{"SchemaVersion":99,"Tables":{"t1":{"Name":"Singers","Id":"t1","NewField":true}},"NewTopLevel":1}`))
+	assert.Nil(t, err)
+	assert.Equal(t, Schema{"t1": {Name: "Singers", Id: "t1"}}, got)
+
+	_, err = UnmarshalSchemaJSON([]byte(`{"SchemaVersion":-1}`))
+	assert.NotNil(t, err)
+	_, err = UnmarshalSchemaJSON([]byte(`[1]`))
+	assert.NotNil(t, err)
+
+	// Schemas embedded in other values decode the same way.
+	var conv struct{ SpSchema Schema }
+	assert.Nil(t, json.Unmarshal([]byte(`{"SpSchema":null}`), &conv))
+	assert.Nil(t, conv.SpSchema)
+	assert.Nil(t, json.Unmarshal([]byte(`{"SpSchema":{"t1":{"Name":"Singers"}}}`), &conv))
+	assert.Equal(t, Schema{"t1": {Name: "Singers"}}, conv.SpSchema)
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import "fmt"
+
+// LimitWarningPercent is the usage of a limit, in percent, above which
+// GetLimitUsage warns about it.
+const LimitWarningPercent = 80
+
+// LimitUsage is the usage of a Spanner limit by the schema or by one of its
+// tables.
+type LimitUsage struct {
+	Limit string // The limit, e.g. "columns per table".
+	Value int64
+	Max   int64
+}
+
+// Percent returns the usage of the limit in percent.
+func (u LimitUsage) Percent() float64 {
+	return float64(u.Value) * 100 / float64(u.Max)
+}
+
+// Warn reports whether the usage is above LimitWarningPercent.
+func (u LimitUsage) Warn() bool {
+	return u.Percent() > LimitWarningPercent
+}
+
+func (u LimitUsage) String() string {
+	return fmt.Sprintf("%s: %d of %d (%.0f%%)", u.Limit, u.Value, u.Max, u.Percent())
+}
+
+// TableLimitUsage is the usage of the per table Spanner limits by a table.
+type TableLimitUsage struct {
+	TableId string
+	Table   string
+	Usages  []LimitUsage
+}
+
+// GetLimitUsage returns how close the schema is to the Spanner limits: the
+// usage of the database limits, and the usage of the per table limits by
+// each table, ordered by table name. The size of the non-key columns is
+// computed from their declared lengths, counting STRING(MAX) and BYTES(MAX)
+// columns at their maximum size.
+func GetLimitUsage(schema Schema) ([]LimitUsage, []TableLimitUsage) {
+	var tables []TableLimitUsage
+	indexCount := 0
+	for _, tableId := range sortedTableIdsByName(schema) {
+		ct := schema[tableId]
+		tableIndexes := len(ct.Indexes) + len(ct.SearchIndexes) + len(ct.VectorIndexes)
+		indexCount += tableIndexes
+		isKey := make(map[string]bool)
+		for _, k := range ct.PrimaryKeys {
+			isKey[k.ColId] = true
+		}
+		var nonKeySize int64
+		for _, colId := range ct.ColIds {
+			if !isKey[colId] {
+				nonKeySize += nonKeyColumnSize(ct.ColDefs[colId].T)
+			}
+		}
+		tables = append(tables, TableLimitUsage{
+			TableId: tableId,
+			Table:   ct.Name,
+			Usages: []LimitUsage{
+				{Limit: "columns per table", Value: int64(len(ct.ColIds)), Max: MaxColumnsPerTable},
+				{Limit: "indexes per table", Value: int64(tableIndexes), Max: MaxIndexesPerTable},
+				{Limit: "key columns", Value: int64(len(ct.PrimaryKeys)), Max: MaxKeyColumns},
+				{Limit: "non-key columns size", Value: nonKeySize, Max: MaxNonKeyColumnLength},
+			},
+		})
+	}
+	database := []LimitUsage{
+		{Limit: "tables per database", Value: int64(len(schema)), Max: MaxTablesPerDatabase},
+		{Limit: "indexes per database", Value: int64(indexCount), Max: MaxIndexesPerDatabase},
+	}
+	return database, tables
+}
+
+// nonKeyColumnSize returns the maximum size in bytes of a non-key column of
+// type ty, as counted for the limit on the size of non-key columns.
+func nonKeyColumnSize(ty Type) int64 {
+	switch {
+	case ty.Name == String && ty.Len >= PGMaxLength:
+		return StringMaxLength
+	case ty.Name == Bytes && ty.Len >= PGMaxLength:
+		return BytesMaxLength
+	}
+	return keyColumnSize(ty)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLimitUsage(t *testing.T) {
+	wide := limitsTestTable("t1", "Wide", 900)
+	small := limitsTestTable("t2", "Small", 2)
+	small.ColIds = append(small.ColIds, "t2_c3", "t2_c4", "t2_c5")
+	small.ColDefs["t2_c3"] = ColumnDef{Name: "s", Id: "t2_c3", T: Type{Name: String, Len: 100}}
+	small.ColDefs["t2_c4"] = ColumnDef{Name: "m", Id: "t2_c4", T: Type{Name: String, Len: MaxLength}}
+	small.ColDefs["t2_c5"] = ColumnDef{Name: "a", Id: "t2_c5", T: Type{Name: Int64, IsArray: true}}
+	small.Indexes = []CreateIndex{{Name: "idx", TableId: "t2"}}
+
+	database, tables := GetLimitUsage(Schema{"t1": wide, "t2": small})
+	assert.Equal(t, []LimitUsage{
+		{Limit: "tables per database", Value: 2, Max: MaxTablesPerDatabase},
+		{Limit: "indexes per database", Value: 1, Max: MaxIndexesPerDatabase},
+	}, database)
+	assert.Equal(t, []TableLimitUsage{
+		{TableId: "t2", Table: "Small", Usages: []LimitUsage{
+			{Limit: "columns per table", Value: 5, Max: MaxColumnsPerTable},
+			{Limit: "indexes per table", Value: 1, Max: MaxIndexesPerTable},
+			{Limit: "key columns", Value: 1, Max: MaxKeyColumns},
+			{Limit: "non-key columns size", Value: 8 + 100 + StringMaxLength + 8, Max: MaxNonKeyColumnLength},
+		}},
+		{TableId: "t1", Table: "Wide", Usages: []LimitUsage{
+			{Limit: "columns per table", Value: 900, Max: MaxColumnsPerTable},
+			{Limit: "indexes per table", Value: 0, Max: MaxIndexesPerTable},
+			{Limit: "key columns", Value: 1, Max: MaxKeyColumns},
+			{Limit: "non-key columns size", Value: 899 * 8, Max: MaxNonKeyColumnLength},
+		}},
+	}, tables)
+	assert.True(t, tables[1].Usages[0].Warn())
+	assert.False(t, tables[0].Usages[0].Warn())
+	assert.Equal(t, "columns per table: 900 of 1024 (88%)", tables[1].Usages[0].String())
+}
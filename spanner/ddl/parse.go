@@ -0,0 +1,1731 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+)
+
+// ParsedSchema holds the Spanner schema read back from DDL statements by
+// ParseDDL.
+type ParsedSchema struct {
+	Tables    Schema
+	Sequences map[string]Sequence
+	Objects   SchemaObjects
+	// Skipped lists the statements that were not converted, either because
+	// the AST has no representation for them (e.g. CREATE SEARCH INDEX,
+	// CREATE ROLE or GRANT) or because they don't define schema objects.
+	Skipped []string
+}
+
+// ParseDDL parses Spanner DDL statements, e.g. as returned by
+// GetDatabaseDdl or printed by GetDDL, back into the DDL AST. Statements
+// must be in an order Spanner accepts, i.e. objects are defined before they
+// are referenced. newId generates the ids of the parsed objects from the
+// same prefixes as the internal package, e.g. "t" for tables and "c" for
+// columns.
+//
+// Table clauses without an AST representation, such as row deletion
+// policies, are dropped.
+func ParseDDL(statements []string, dialect string, newId func(prefix string) string) (ParsedSchema, error) {
+	b := &schemaBuilder{
+		dialect:  dialect,
+		newId:    newId,
+		tableIds: make(map[string]string),
+		seqIds:   make(map[string]string),
+		schema: ParsedSchema{
+			Tables:    NewSchema(),
+			Sequences: make(map[string]Sequence),
+			Objects: SchemaObjects{
+				Views:          make(map[string]CreateView),
+				ChangeStreams:  make(map[string]ChangeStream),
+				LocalityGroups: make(map[string]LocalityGroup),
+				Placements:     make(map[string]Placement),
+				Models:         make(map[string]Model),
+				PropertyGraphs: make(map[string]PropertyGraph),
+			},
+		},
+	}
+	for _, stmt := range statements {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		toks, err := tokenize(stmt, dialect)
+		if err != nil {
+			return ParsedSchema{}, fmt.Errorf("can't parse DDL statement %q: %v", stmt, err)
+		}
+		p := &ddlParser{src: stmt, toks: toks}
+		if p.atEnd() {
+			continue
+		}
+		parsed, err := b.parseStatement(p)
+		if err != nil {
+			return ParsedSchema{}, fmt.Errorf("can't parse DDL statement %q: %v", stmt, err)
+		}
+		if !parsed {
+			b.schema.Skipped = append(b.schema.Skipped, strings.TrimSpace(stmt))
+		}
+	}
+	return b.schema, nil
+}
+
+// SplitDDLStatements splits the text of a .sql file into DDL statements.
+// Statements are separated by semicolons; semicolons in comments, quoted
+// identifiers and string literals are ignored, as are empty statements.
+func SplitDDLStatements(text string) ([]string, error) {
+	toks, err := tokenize(text, constants.DIALECT_GOOGLESQL)
+	if err != nil {
+		return nil, err
+	}
+	var stmts []string
+	start, empty := 0, true
+	for _, t := range toks {
+		if t.kind == tokEOF || (t.kind == tokSymbol && t.val == ";") {
+			if !empty {
+				stmts = append(stmts, strings.TrimSpace(text[start:t.start]))
+			}
+			start, empty = t.end, true
+			continue
+		}
+		empty = false
+	}
+	return stmts, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokQuotedIdent
+	tokString
+	tokNumber
+	tokSymbol
+)
+
+type token struct {
+	kind tokenKind
+	val  string // Unquoted value of identifiers and string literals.
+	// Offsets of the token in the statement text.
+	start, end int
+}
+
+var twoCharSymbols = []string{"=>", "<=", ">=", "<>", "!=", "||", "::"}
+
+// tokenize splits a DDL statement into tokens, dropping whitespace and
+// comments. Double quotes delimit identifiers in the PostgreSQL dialect and
+// string literals in GoogleSQL.
+func tokenize(s, dialect string) ([]token, error) {
+	var toks []token
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(s[i:], "--") || (c == '#' && dialect != constants.DIALECT_POSTGRESQL):
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+		case c == '`' || c == '"' || c == '\'':
+			kind := tokString
+			if c == '`' || (c == '"' && dialect == constants.DIALECT_POSTGRESQL) {
+				kind = tokQuotedIdent
+			}
+			val, end, err := scanQuoted(s, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: kind, val: val, start: i, end: end})
+			i = end
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(s) && (isIdentChar(s[j]) || s[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{kind: tokNumber, val: s[i:j], start: i, end: j})
+			i = j
+		case isIdentChar(c):
+			j := i
+			for j < len(s) && isIdentChar(s[j]) {
+				j++
+			}
+			toks = append(toks, token{kind: tokIdent, val: s[i:j], start: i, end: j})
+			i = j
+		default:
+			sym := s[i : i+1]
+			for _, two := range twoCharSymbols {
+				if strings.HasPrefix(s[i:], two) {
+					sym = two
+					break
+				}
+			}
+			toks = append(toks, token{kind: tokSymbol, val: sym, start: i, end: i + len(sym)})
+			i += len(sym)
+		}
+	}
+	return append(toks, token{kind: tokEOF, start: len(s), end: len(s)}), nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// scanQuoted scans the quoted token starting at s[start] and returns its
+// unquoted value and the offset following the closing quote. Quotes are
+// escaped either by doubling them or with a backslash.
+func scanQuoted(s string, start int) (string, int, error) {
+	q := s[start]
+	var val strings.Builder
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && q != '`' && i+1 < len(s):
+			i++
+			val.WriteByte(s[i])
+		case s[i] == q && i+1 < len(s) && s[i+1] == q:
+			i++
+			val.WriteByte(q)
+		case s[i] == q:
+			return val.String(), i + 1, nil
+		default:
+			val.WriteByte(s[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted string starting at offset %d", start)
+}
+
+// ddlParser is a recursive descent parser over the tokens of one statement.
+type ddlParser struct {
+	src  string
+	toks []token
+	pos  int
+}
+
+func (p *ddlParser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *ddlParser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *ddlParser) atEnd() bool {
+	return p.peek().kind == tokEOF
+}
+
+func (p *ddlParser) errorf(format string, args ...interface{}) error {
+	found := "end of statement"
+	if t := p.peek(); t.kind != tokEOF {
+		found = fmt.Sprintf("%q", p.src[t.start:t.end])
+	}
+	return fmt.Errorf("%s, found %s", fmt.Sprintf(format, args...), found)
+}
+
+// peekKeyword reports whether the next tokens are the given keywords.
+func (p *ddlParser) peekKeyword(kws ...string) bool {
+	for i, kw := range kws {
+		if p.pos+i >= len(p.toks) {
+			return false
+		}
+		t := p.toks[p.pos+i]
+		if t.kind != tokIdent || !strings.EqualFold(t.val, kw) {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptKeyword consumes the given keywords if they are the next tokens.
+func (p *ddlParser) acceptKeyword(kws ...string) bool {
+	if !p.peekKeyword(kws...) {
+		return false
+	}
+	p.pos += len(kws)
+	return true
+}
+
+func (p *ddlParser) expectKeyword(kws ...string) error {
+	if !p.acceptKeyword(kws...) {
+		return p.errorf("expected %s", strings.Join(kws, " "))
+	}
+	return nil
+}
+
+func (p *ddlParser) acceptSymbol(sym string) bool {
+	if t := p.peek(); t.kind == tokSymbol && t.val == sym {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *ddlParser) expectSymbol(sym string) error {
+	if !p.acceptSymbol(sym) {
+		return p.errorf("expected %q", sym)
+	}
+	return nil
+}
+
+func (p *ddlParser) expectEnd() error {
+	p.acceptSymbol(";")
+	if !p.atEnd() {
+		return p.errorf("expected end of statement")
+	}
+	return nil
+}
+
+// name parses a possibly qualified name, e.g. a table in a named schema or
+// the fully qualified name of a proto message.
+func (p *ddlParser) name() (string, error) {
+	var parts []string
+	for {
+		t := p.peek()
+		if t.kind != tokIdent && t.kind != tokQuotedIdent {
+			return "", p.errorf("expected name")
+		}
+		p.next()
+		parts = append(parts, t.val)
+		if !p.acceptSymbol(".") {
+			return strings.Join(parts, "."), nil
+		}
+	}
+}
+
+// nameList parses a parenthesized, comma separated list of names.
+func (p *ddlParser) nameList() ([]string, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	if p.acceptSymbol(")") {
+		return names, nil
+	}
+	for {
+		n, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, n)
+		if p.acceptSymbol(")") {
+			return names, nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type keyPart struct {
+	name string
+	desc bool
+}
+
+// keyParts parses a parenthesized list of key columns with optional sort
+// orders.
+func (p *ddlParser) keyParts() ([]keyPart, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	var keys []keyPart
+	if p.acceptSymbol(")") {
+		return keys, nil
+	}
+	for {
+		n, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		desc := p.acceptKeyword("DESC")
+		if !desc {
+			p.acceptKeyword("ASC")
+		}
+		keys = append(keys, keyPart{name: n, desc: desc})
+		if p.acceptSymbol(")") {
+			return keys, nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parenText consumes a parenthesized expression and returns the source text
+// between the parentheses.
+func (p *ddlParser) parenText() (string, error) {
+	open := p.peek()
+	if err := p.expectSymbol("("); err != nil {
+		return "", err
+	}
+	for depth := 1; ; {
+		t := p.next()
+		switch {
+		case t.kind == tokEOF:
+			return "", fmt.Errorf("unbalanced parentheses")
+		case t.kind == tokSymbol && t.val == "(":
+			depth++
+		case t.kind == tokSymbol && t.val == ")":
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(p.src[open.end:t.start]), nil
+			}
+		}
+	}
+}
+
+// exprText consumes an unparenthesized expression, up to a comma or closing
+// parenthesis at the top level or one of the stop keywords, and returns its
+// source text.
+func (p *ddlParser) exprText(stopKeywords ...string) string {
+	start := p.peek().start
+	end := start
+	for depth := 0; ; {
+		t := p.peek()
+		if t.kind == tokEOF || (depth == 0 && t.kind == tokSymbol && (t.val == "," || t.val == ")" || t.val == ";")) {
+			break
+		}
+		if depth == 0 && t.kind == tokIdent {
+			stop := false
+			for _, kw := range stopKeywords {
+				stop = stop || strings.EqualFold(t.val, kw)
+			}
+			if stop {
+				break
+			}
+		}
+		if t.kind == tokSymbol && t.val == "(" {
+			depth++
+		} else if t.kind == tokSymbol && t.val == ")" {
+			depth--
+		}
+		end = t.end
+		p.next()
+	}
+	return strings.TrimSpace(p.src[start:end])
+}
+
+// restText consumes the rest of the statement and returns its source text,
+// without a trailing semicolon.
+func (p *ddlParser) restText() string {
+	start := p.peek().start
+	p.pos = len(p.toks) - 1
+	return strings.TrimSuffix(strings.TrimSpace(p.src[start:]), ";")
+}
+
+// options parses a parenthesized list of name = value options. String
+// values are unquoted; other values are returned as written, in lower case
+// for keywords such as true and null.
+func (p *ddlParser) options() (map[string]string, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	opts := make(map[string]string)
+	if p.acceptSymbol(")") {
+		return opts, nil
+	}
+	for {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectSymbol("="); err != nil {
+			return nil, err
+		}
+		value, err := p.optionValue()
+		if err != nil {
+			return nil, err
+		}
+		opts[strings.ToLower(name)] = value
+		if p.acceptSymbol(")") {
+			return opts, nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *ddlParser) optionValue() (string, error) {
+	sign := ""
+	if p.acceptSymbol("-") {
+		sign = "-"
+	}
+	t := p.peek()
+	switch t.kind {
+	case tokString:
+		p.next()
+		return t.val, nil
+	case tokNumber:
+		p.next()
+		return sign + t.val, nil
+	case tokIdent:
+		p.next()
+		return strings.ToLower(t.val), nil
+	}
+	return "", p.errorf("expected option value")
+}
+
+// number parses an integer literal.
+func (p *ddlParser) number() (int64, error) {
+	t := p.peek()
+	if t.kind != tokNumber {
+		return 0, p.errorf("expected number")
+	}
+	n, err := strconv.ParseInt(t.val, 10, 64)
+	if err != nil {
+		return 0, p.errorf("expected number")
+	}
+	p.next()
+	return n, nil
+}
+
+// schemaBuilder accumulates the schema objects defined by the parsed
+// statements and resolves the names they reference into ids.
+type schemaBuilder struct {
+	dialect  string
+	newId    func(prefix string) string
+	schema   ParsedSchema
+	tableIds map[string]string // Maps lower-cased table names to table ids.
+	seqIds   map[string]string // Maps lower-cased sequence names to sequence ids.
+}
+
+func (b *schemaBuilder) isPG() bool {
+	return b.dialect == constants.DIALECT_POSTGRESQL
+}
+
+// parseStatement parses one statement and adds the objects it defines to the
+// schema. It returns false for statements that are skipped.
+func (b *schemaBuilder) parseStatement(p *ddlParser) (bool, error) {
+	var err error
+	switch {
+	case p.acceptKeyword("CREATE"):
+		p.acceptKeyword("OR", "REPLACE")
+		switch {
+		case p.acceptKeyword("TABLE"):
+			err = b.parseCreateTable(p)
+		case p.peekKeyword("UNIQUE") || p.peekKeyword("NULL_FILTERED") || p.peekKeyword("INDEX"):
+			err = b.parseCreateIndex(p)
+		case p.acceptKeyword("SEQUENCE"):
+			err = b.parseCreateSequence(p)
+		case p.acceptKeyword("VIEW"):
+			err = b.parseCreateView(p)
+		case p.acceptKeyword("CHANGE", "STREAM"):
+			err = b.parseCreateChangeStream(p)
+		case p.acceptKeyword("LOCALITY", "GROUP"):
+			err = b.parseCreateLocalityGroup(p)
+		case p.acceptKeyword("PLACEMENT"):
+			err = b.parseCreatePlacement(p)
+		case p.acceptKeyword("MODEL"):
+			err = b.parseCreateModel(p)
+		case p.acceptKeyword("PROPERTY", "GRAPH"):
+			return b.parseCreatePropertyGraph(p)
+		default:
+			return false, nil
+		}
+	case p.acceptKeyword("ALTER", "TABLE"):
+		return b.parseAlterTable(p)
+	case p.acceptKeyword("ALTER", "DATABASE"):
+		err = b.parseAlterDatabase(p)
+	case p.acceptKeyword("COMMENT", "ON"):
+		err = b.parseComment(p)
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, p.expectEnd()
+}
+
+func (b *schemaBuilder) lookupTable(name string) (CreateTable, error) {
+	id, ok := b.tableIds[strings.ToLower(name)]
+	if !ok {
+		return CreateTable{}, fmt.Errorf("table %s is not defined", name)
+	}
+	return b.schema.Tables[id], nil
+}
+
+func lookupColumn(ct CreateTable, name string) (string, error) {
+	for _, id := range ct.ColIds {
+		if strings.EqualFold(ct.ColDefs[id].Name, name) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("column %s is not defined in table %s", name, ct.Name)
+}
+
+func lookupColumns(ct CreateTable, names []string) ([]string, error) {
+	var ids []string
+	for _, n := range names {
+		id, err := lookupColumn(ct, n)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func lookupKeys(ct CreateTable, parts []keyPart) ([]IndexKey, error) {
+	var keys []IndexKey
+	for i, k := range parts {
+		id, err := lookupColumn(ct, k.name)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, IndexKey{ColId: id, Desc: k.desc, Order: i + 1})
+	}
+	return keys, nil
+}
+
+// parseCreateTable parses the part of a CREATE TABLE statement following
+// the TABLE keyword.
+func (b *schemaBuilder) parseCreateTable(p *ddlParser) error {
+	p.acceptKeyword("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, found := b.tableIds[strings.ToLower(name)]; found {
+		return fmt.Errorf("table %s is defined more than once", name)
+	}
+	ct := CreateTable{Name: name, Id: b.newId("t"), ColDefs: make(map[string]ColumnDef)}
+	// The table is registered before its constraints are parsed, so that
+	// foreign keys can reference the table itself.
+	b.tableIds[strings.ToLower(name)] = ct.Id
+	b.schema.Tables[ct.Id] = ct
+
+	var pkParts []keyPart
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	for !p.acceptSymbol(")") {
+		switch {
+		case p.peekKeyword("CONSTRAINT") || p.peekKeyword("CHECK") || p.peekKeyword("FOREIGN"):
+			if err := b.parseTableConstraint(p, &ct); err != nil {
+				return err
+			}
+		case p.acceptKeyword("SYNONYM"):
+			if err := p.expectSymbol("("); err != nil {
+				return err
+			}
+			if ct.Synonym, err = p.name(); err != nil {
+				return err
+			}
+			if err := p.expectSymbol(")"); err != nil {
+				return err
+			}
+		case p.acceptKeyword("PRIMARY", "KEY"):
+			if pkParts, err = p.keyParts(); err != nil {
+				return err
+			}
+		default:
+			part, err := b.parseColumnDef(p, &ct)
+			if err != nil {
+				return err
+			}
+			if part != nil {
+				pkParts = append(pkParts, *part)
+			}
+		}
+		if !p.acceptSymbol(",") {
+			if err := p.expectSymbol(")"); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if p.acceptKeyword("PRIMARY", "KEY") {
+		if pkParts, err = p.keyParts(); err != nil {
+			return err
+		}
+	}
+	if ct.PrimaryKeys, err = lookupKeys(ct, pkParts); err != nil {
+		return err
+	}
+	for {
+		// GoogleSQL separates the clauses following the primary key with
+		// commas, PostgreSQL doesn't.
+		comma := p.acceptSymbol(",")
+		switch {
+		case p.acceptKeyword("INTERLEAVE", "IN"):
+			ct.ParentTable.InterleaveType = "IN"
+			if p.acceptKeyword("PARENT") {
+				ct.ParentTable.InterleaveType = "IN PARENT"
+			}
+			parentName, err := p.name()
+			if err != nil {
+				return err
+			}
+			parent, err := b.lookupTable(parentName)
+			if err != nil {
+				return err
+			}
+			ct.ParentTable.Id = parent.Id
+			if p.acceptKeyword("ON", "DELETE") {
+				if ct.ParentTable.OnDelete, err = parseReferentialAction(p); err != nil {
+					return err
+				}
+			}
+		case p.acceptKeyword("OPTIONS"):
+			opts, err := p.options()
+			if err != nil {
+				return err
+			}
+			ct.LocalityGroup = opts[LocalityGroupOpt]
+		case p.acceptKeyword("LOCALITY", "GROUP"):
+			if ct.LocalityGroup, err = p.name(); err != nil {
+				return err
+			}
+		case p.acceptKeyword("ROW", "DELETION", "POLICY"):
+			if _, err := p.parenText(); err != nil {
+				return err
+			}
+		case p.acceptKeyword("TTL"):
+			p.exprText()
+		default:
+			if comma {
+				return p.errorf("expected table clause")
+			}
+			b.schema.Tables[ct.Id] = ct
+			return nil
+		}
+	}
+}
+
+// parseColumnDef parses a column definition and adds the column to ct. For
+// PostgreSQL columns declared as PRIMARY KEY, the key part is returned.
+func (b *schemaBuilder) parseColumnDef(p *ddlParser, ct *CreateTable) (*keyPart, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := lookupColumn(*ct, name); err == nil {
+		return nil, fmt.Errorf("column %s is defined more than once in table %s", name, ct.Name)
+	}
+	cd := ColumnDef{Name: name, Id: b.newId("c")}
+	var commitTimestamp bool
+	if b.isPG() {
+		cd.T, commitTimestamp, err = p.pgType()
+	} else {
+		cd.T, err = p.googleSQLType()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if commitTimestamp {
+		cd.Opts = map[string]string{AllowCommitTimestampOpt: "true"}
+	}
+	var pk *keyPart
+	for {
+		switch {
+		case p.acceptKeyword("NOT", "NULL"):
+			cd.NotNull = true
+		case p.acceptKeyword("NULL"):
+		case p.acceptKeyword("DEFAULT"):
+			var expr string
+			if p.peek().kind == tokSymbol && p.peek().val == "(" {
+				expr, err = p.parenText()
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				expr = p.exprText("NOT", "NULL", "GENERATED", "PRIMARY", "LOCALITY", "PLACEMENT", "HIDDEN", "OPTIONS")
+			}
+			b.setDefault(&cd, ct.Id, expr)
+		case p.acceptKeyword("AS") || p.acceptKeyword("GENERATED", "ALWAYS", "AS"):
+			expr, err := p.parenText()
+			if err != nil {
+				return nil, err
+			}
+			cd.GeneratedColumn = GeneratedColumn{IsPresent: true, Value: Expression{ExpressionId: b.newId("e"), Statement: expr}, Type: GeneratedVirtual}
+			if p.acceptKeyword(GeneratedStored) {
+				cd.GeneratedColumn.Type = GeneratedStored
+			} else {
+				p.acceptKeyword(GeneratedVirtual)
+			}
+		case p.acceptKeyword("PLACEMENT", "KEY"):
+			ct.PlacementKey = cd.Id
+		case p.acceptKeyword("PRIMARY", "KEY"):
+			pk = &keyPart{name: name}
+		case p.acceptKeyword("HIDDEN"):
+			cd.Hidden = true
+		case p.acceptKeyword("LOCALITY", "GROUP"):
+			lg, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if cd.Opts == nil {
+				cd.Opts = make(map[string]string)
+			}
+			cd.Opts[LocalityGroupOpt] = lg
+		case p.acceptKeyword("OPTIONS"):
+			opts, err := p.options()
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range opts {
+				if k == AllowCommitTimestampOpt && v != "true" {
+					continue
+				}
+				if cd.Opts == nil {
+					cd.Opts = make(map[string]string)
+				}
+				cd.Opts[k] = v
+			}
+		default:
+			ct.ColIds = append(ct.ColIds, cd.Id)
+			ct.ColDefs[cd.Id] = cd
+			return pk, nil
+		}
+	}
+}
+
+var (
+	castRegexp             = regexp.MustCompile(`(?is)^CAST\s*\((.*)\s+AS\s+(\w+)\s*\)$`)
+	generateUUIDRegexp     = regexp.MustCompile(`(?i)^(spanner\.)?generate_uuid\s*\(\s*\)$`)
+	nextSequenceValRegexp  = regexp.MustCompile("(?i)^GET_NEXT_SEQUENCE_VALUE\\s*\\(\\s*SEQUENCE\\s+`?([^`\\s)]+)`?\\s*\\)$")
+	pgNextSequenceValRegex = regexp.MustCompile(`(?i)^nextval\s*\(\s*'([^']+)'\s*\)$`)
+)
+
+// setDefault sets the default value of cd, recognizing the defaults that
+// the AST represents as auto-generated columns. The CAST that
+// PrintDefaultValue wraps around defaults of some types is removed.
+func (b *schemaBuilder) setDefault(cd *ColumnDef, tableId, expr string) {
+	if m := castRegexp.FindStringSubmatch(expr); m != nil && (strings.EqualFold(m[2], cd.T.Name) || strings.EqualFold(m[2], GetPGType(cd.T))) {
+		expr = strings.TrimSpace(m[1])
+	}
+	if generateUUIDRegexp.MatchString(expr) {
+		cd.AutoGen = AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}
+		return
+	}
+	m := nextSequenceValRegexp.FindStringSubmatch(expr)
+	if m == nil {
+		m = pgNextSequenceValRegex.FindStringSubmatch(expr)
+	}
+	if m != nil {
+		if seqId, ok := b.seqIds[strings.ToLower(m[1])]; ok {
+			seq := b.schema.Sequences[seqId]
+			cd.AutoGen = AutoGenCol{Name: seq.Name, GenerationType: constants.SEQUENCE}
+			seq.ColumnsUsingSeq[tableId] = append(seq.ColumnsUsingSeq[tableId], cd.Id)
+			return
+		}
+	}
+	cd.DefaultValue = DefaultValue{IsPresent: true, Value: Expression{ExpressionId: b.newId("e"), Statement: expr}}
+}
+
+// googleSQLType parses a GoogleSQL column type. Types other than the
+// built-in ones are taken to be proto messages; proto enums can't be told
+// apart from messages by name.
+func (p *ddlParser) googleSQLType() (Type, error) {
+	if p.acceptKeyword("ARRAY") {
+		if err := p.expectSymbol("<"); err != nil {
+			return Type{}, err
+		}
+		ty, err := p.googleSQLType()
+		if err != nil {
+			return Type{}, err
+		}
+		if err := p.expectSymbol(">"); err != nil {
+			return Type{}, err
+		}
+		ty.IsArray = true
+		if p.acceptSymbol("(") {
+			if err := p.expectKeyword("vector_length"); err != nil {
+				return Type{}, err
+			}
+			if err := p.expectSymbol("=>"); err != nil {
+				return Type{}, err
+			}
+			if ty.VectorLength, err = p.number(); err != nil {
+				return Type{}, err
+			}
+			if err := p.expectSymbol(")"); err != nil {
+				return Type{}, err
+			}
+		}
+		return ty, nil
+	}
+	name, err := p.name()
+	if err != nil {
+		return Type{}, err
+	}
+	switch upper := strings.ToUpper(name); upper {
+	case String, Bytes:
+		ty := Type{Name: upper}
+		if err := p.expectSymbol("("); err != nil {
+			return Type{}, err
+		}
+		if p.acceptKeyword("MAX") {
+			ty.Len = MaxLength
+		} else if ty.Len, err = p.number(); err != nil {
+			return Type{}, err
+		}
+		return ty, p.expectSymbol(")")
+	case Bool, Date, Float32, Float64, Int64, JSON, Numeric, Timestamp, TokenList, UUID:
+		return Type{Name: upper}, nil
+	}
+	return Type{Name: Proto, ProtoName: name}, nil
+}
+
+var pgTypeNames = map[string]string{
+	"BIGINT":                   Int64,
+	"INT8":                     Int64,
+	"BOOL":                     Bool,
+	"BOOLEAN":                  Bool,
+	"BYTEA":                    Bytes,
+	"DATE":                     Date,
+	"DOUBLE PRECISION":         Float64,
+	"FLOAT8":                   Float64,
+	"FLOAT4":                   Float32,
+	"REAL":                     Float32,
+	"JSONB":                    JSON,
+	"NUMERIC":                  Numeric,
+	"DECIMAL":                  Numeric,
+	"SPANNER.TOKENLIST":        TokenList,
+	"TIMESTAMPTZ":              Timestamp,
+	"TIMESTAMP WITH TIME ZONE": Timestamp,
+	"UUID":                     UUID,
+	"VARCHAR":                  String,
+	"CHARACTER VARYING":        String,
+	"TEXT":                     String,
+}
+
+// pgType parses a PostgreSQL column type. SPANNER.COMMIT_TIMESTAMP is
+// returned as TIMESTAMP, with commitTimestamp set.
+func (p *ddlParser) pgType() (ty Type, commitTimestamp bool, err error) {
+	name, err := p.name()
+	if err != nil {
+		return Type{}, false, err
+	}
+	upper := strings.ToUpper(name)
+	for _, suffix := range [][]string{{"PRECISION"}, {"VARYING"}, {"WITH", "TIME", "ZONE"}} {
+		if p.acceptKeyword(suffix...) {
+			upper += " " + strings.Join(suffix, " ")
+		}
+	}
+	if upper == PGCommitTimestamp {
+		return Type{Name: Timestamp}, true, nil
+	}
+	spName, ok := pgTypeNames[upper]
+	if !ok {
+		return Type{}, false, fmt.Errorf("unsupported PostgreSQL type %s", name)
+	}
+	ty = Type{Name: spName}
+	if p.acceptSymbol("(") {
+		if ty.Len, err = p.number(); err != nil {
+			return Type{}, false, err
+		}
+		if spName == Numeric {
+			ty.Precision, ty.Len = ty.Len, 0
+			if p.acceptSymbol(",") {
+				if ty.Scale, err = p.number(); err != nil {
+					return Type{}, false, err
+				}
+			}
+		}
+		if err = p.expectSymbol(")"); err != nil {
+			return Type{}, false, err
+		}
+		if spName != String {
+			ty.Len = 0
+		}
+	} else if spName == String {
+		ty.Len = MaxLength
+	}
+	if p.acceptSymbol("[") {
+		if err = p.expectSymbol("]"); err != nil {
+			return Type{}, false, err
+		}
+		ty.IsArray = true
+		if p.acceptKeyword("VECTOR", "LENGTH") {
+			if ty.VectorLength, err = p.number(); err != nil {
+				return Type{}, false, err
+			}
+		}
+	}
+	return ty, false, nil
+}
+
+func parseReferentialAction(p *ddlParser) (string, error) {
+	switch {
+	case p.acceptKeyword("CASCADE"):
+		return constants.FK_CASCADE, nil
+	case p.acceptKeyword("NO", "ACTION"):
+		return constants.FK_NO_ACTION, nil
+	}
+	return "", p.errorf("expected CASCADE or NO ACTION")
+}
+
+// parseTableConstraint parses a check constraint or foreign key, as defined
+// in CREATE TABLE or added by ALTER TABLE, and adds it to ct.
+func (b *schemaBuilder) parseTableConstraint(p *ddlParser, ct *CreateTable) error {
+	var name string
+	var err error
+	if p.acceptKeyword("CONSTRAINT") {
+		if name, err = p.name(); err != nil {
+			return err
+		}
+	}
+	if p.acceptKeyword("CHECK") {
+		expr, err := p.parenText()
+		if err != nil {
+			return err
+		}
+		ct.CheckConstraints = append(ct.CheckConstraints, CheckConstraint{Id: b.newId("cc"), Name: name, Expr: "(" + expr + ")", ExprId: b.newId("e")})
+		return nil
+	}
+	if err := p.expectKeyword("FOREIGN", "KEY"); err != nil {
+		return err
+	}
+	cols, err := p.nameList()
+	if err != nil {
+		return err
+	}
+	if err := p.expectKeyword("REFERENCES"); err != nil {
+		return err
+	}
+	referName, err := p.name()
+	if err != nil {
+		return err
+	}
+	referCols, err := p.nameList()
+	if err != nil {
+		return err
+	}
+	if len(cols) != len(referCols) {
+		return fmt.Errorf("foreign key %s references %d columns with %d columns", name, len(referCols), len(cols))
+	}
+	fk := Foreignkey{Name: name, Id: b.newId("f")}
+	if fk.ColIds, err = lookupColumns(*ct, cols); err != nil {
+		return err
+	}
+	refer := *ct
+	if !strings.EqualFold(referName, ct.Name) {
+		if refer, err = b.lookupTable(referName); err != nil {
+			return err
+		}
+	}
+	fk.ReferTableId = refer.Id
+	if fk.ReferColumnIds, err = lookupColumns(refer, referCols); err != nil {
+		return err
+	}
+	for {
+		switch {
+		case p.acceptKeyword("ON", "DELETE"):
+			if fk.OnDelete, err = parseReferentialAction(p); err != nil {
+				return err
+			}
+		case p.acceptKeyword("ON", "UPDATE"):
+			if fk.OnUpdate, err = parseReferentialAction(p); err != nil {
+				return err
+			}
+		case p.acceptKeyword("NOT", "ENFORCED"):
+			fk.NotEnforced = true
+		case p.acceptKeyword("ENFORCED"):
+		default:
+			ct.ForeignKeys = append(ct.ForeignKeys, fk)
+			return nil
+		}
+	}
+}
+
+// parseAlterTable parses the ALTER TABLE statements that add foreign keys,
+// check constraints and synonyms. Other alterations are skipped.
+func (b *schemaBuilder) parseAlterTable(p *ddlParser) (bool, error) {
+	name, err := p.name()
+	if err != nil {
+		return false, err
+	}
+	ct, err := b.lookupTable(name)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case p.acceptKeyword("ADD", "SYNONYM"):
+		if ct.Synonym, err = p.name(); err != nil {
+			return false, err
+		}
+	case p.peekKeyword("ADD", "CONSTRAINT") || p.peekKeyword("ADD", "CHECK") || p.peekKeyword("ADD", "FOREIGN"):
+		p.next()
+		if err := b.parseTableConstraint(p, &ct); err != nil {
+			return false, err
+		}
+	default:
+		return false, nil
+	}
+	b.schema.Tables[ct.Id] = ct
+	return true, p.expectEnd()
+}
+
+// parseCreateIndex parses a CREATE INDEX statement, following the CREATE
+// keyword. The PostgreSQL WHERE clause is only supported in the form
+// printed for null-filtered indexes.
+func (b *schemaBuilder) parseCreateIndex(p *ddlParser) error {
+	ci := CreateIndex{Id: b.newId("i")}
+	ci.Unique = p.acceptKeyword("UNIQUE")
+	ci.NullFiltered = p.acceptKeyword("NULL_FILTERED")
+	if err := p.expectKeyword("INDEX"); err != nil {
+		return err
+	}
+	p.acceptKeyword("IF", "NOT", "EXISTS")
+	var err error
+	if ci.Name, err = p.name(); err != nil {
+		return err
+	}
+	if err := p.expectKeyword("ON"); err != nil {
+		return err
+	}
+	tableName, err := p.name()
+	if err != nil {
+		return err
+	}
+	ct, err := b.lookupTable(tableName)
+	if err != nil {
+		return err
+	}
+	ci.TableId = ct.Id
+	parts, err := p.keyParts()
+	if err != nil {
+		return err
+	}
+	if ci.Keys, err = lookupKeys(ct, parts); err != nil {
+		return err
+	}
+	if p.acceptKeyword("STORING") || p.acceptKeyword("INCLUDE") {
+		stored, err := p.nameList()
+		if err != nil {
+			return err
+		}
+		if ci.StoredColumnIds, err = lookupColumns(ct, stored); err != nil {
+			return err
+		}
+	}
+	p.acceptSymbol(",")
+	if p.acceptKeyword("INTERLEAVE", "IN") {
+		parentName, err := p.name()
+		if err != nil {
+			return err
+		}
+		parent, err := b.lookupTable(parentName)
+		if err != nil {
+			return err
+		}
+		ci.Interleave = parent.Id
+	}
+	if p.acceptKeyword("WHERE") {
+		for {
+			if _, err := p.name(); err != nil {
+				return err
+			}
+			if err := p.expectKeyword("IS", "NOT", "NULL"); err != nil {
+				return err
+			}
+			if !p.acceptKeyword("AND") {
+				break
+			}
+		}
+		ci.NullFiltered = true
+	}
+	ct.Indexes = append(ct.Indexes, ci)
+	b.schema.Tables[ct.Id] = ct
+	return nil
+}
+
+// parseCreateSequence parses a CREATE SEQUENCE statement, following the
+// SEQUENCE keyword.
+func (b *schemaBuilder) parseCreateSequence(p *ddlParser) error {
+	p.acceptKeyword("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	seq := Sequence{Id: b.newId("s"), Name: name, ColumnsUsingSeq: make(map[string][]string)}
+	for {
+		switch {
+		case p.acceptKeyword("BIT_REVERSED_POSITIVE"):
+			seq.SequenceKind = "BIT REVERSED POSITIVE"
+		case p.acceptKeyword("OPTIONS"):
+			opts, err := p.options()
+			if err != nil {
+				return err
+			}
+			if strings.EqualFold(opts["sequence_kind"], "bit_reversed_positive") {
+				seq.SequenceKind = "BIT REVERSED POSITIVE"
+			}
+			seq.SkipRangeMin = opts["skip_range_min"]
+			seq.SkipRangeMax = opts["skip_range_max"]
+			seq.StartWithCounter = opts["start_with_counter"]
+		case p.acceptKeyword("SKIP", "RANGE"):
+			seq.SkipRangeMin = p.exprText("START")
+			p.acceptSymbol(",")
+			seq.SkipRangeMax = p.exprText("START")
+			// Without a comma, exprText returns both bounds.
+			if fields := strings.Fields(seq.SkipRangeMin); seq.SkipRangeMax == "" && len(fields) == 2 {
+				seq.SkipRangeMin, seq.SkipRangeMax = fields[0], fields[1]
+			}
+		case p.acceptKeyword("START", "COUNTER"):
+			p.acceptKeyword("WITH")
+			seq.StartWithCounter = p.exprText()
+		default:
+			b.seqIds[strings.ToLower(name)] = seq.Id
+			b.schema.Sequences[seq.Id] = seq
+			return nil
+		}
+	}
+}
+
+// parseCreateView parses a CREATE VIEW statement, following the VIEW
+// keyword.
+func (b *schemaBuilder) parseCreateView(p *ddlParser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	cv := CreateView{Id: b.newId("vw"), Name: name}
+	if p.acceptKeyword("SQL", "SECURITY") {
+		switch {
+		case p.acceptKeyword("INVOKER"):
+			cv.SecurityType = "INVOKER"
+		case p.acceptKeyword("DEFINER"):
+			cv.SecurityType = "DEFINER"
+		default:
+			return p.errorf("expected INVOKER or DEFINER")
+		}
+	}
+	if err := p.expectKeyword("AS"); err != nil {
+		return err
+	}
+	cv.Query = p.restText()
+	b.schema.Objects.Views[cv.Id] = cv
+	return nil
+}
+
+// parseCreateChangeStream parses a CREATE CHANGE STREAM statement,
+// following the STREAM keyword.
+func (b *schemaBuilder) parseCreateChangeStream(p *ddlParser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	cs := ChangeStream{Id: b.newId("cs"), Name: name}
+	if p.acceptKeyword("FOR") {
+		if p.acceptKeyword("ALL") {
+			cs.WatchAll = true
+		} else {
+			for {
+				tableName, err := p.name()
+				if err != nil {
+					return err
+				}
+				ct, err := b.lookupTable(tableName)
+				if err != nil {
+					return err
+				}
+				wt := ChangeStreamTable{TableId: ct.Id}
+				if p.peek().kind == tokSymbol && p.peek().val == "(" {
+					cols, err := p.nameList()
+					if err != nil {
+						return err
+					}
+					if wt.ColIds, err = lookupColumns(ct, cols); err != nil {
+						return err
+					}
+				}
+				cs.WatchedTables = append(cs.WatchedTables, wt)
+				if !p.acceptSymbol(",") {
+					break
+				}
+			}
+		}
+	}
+	if p.acceptKeyword("OPTIONS") || p.acceptKeyword("WITH") {
+		opts, err := p.options()
+		if err != nil {
+			return err
+		}
+		cs.RetentionPeriod = opts["retention_period"]
+		cs.ValueCaptureType = strings.ToUpper(opts["value_capture_type"])
+	}
+	b.schema.Objects.ChangeStreams[cs.Id] = cs
+	return nil
+}
+
+// parseCreateLocalityGroup parses a CREATE LOCALITY GROUP statement,
+// following the GROUP keyword.
+func (b *schemaBuilder) parseCreateLocalityGroup(p *ddlParser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	lg := LocalityGroup{Id: b.newId("lg"), Name: name}
+	for {
+		switch {
+		case p.acceptKeyword("OPTIONS"):
+			opts, err := p.options()
+			if err != nil {
+				return err
+			}
+			lg.Storage = opts["storage"]
+			lg.SsdToHddSpillTimespan = opts["ssd_to_hdd_spill_timespan"]
+		case p.acceptKeyword("STORAGE"):
+			if lg.Storage, err = p.optionValue(); err != nil {
+				return err
+			}
+		case p.acceptKeyword("SSD_TO_HDD_SPILL_TIMESPAN"):
+			if lg.SsdToHddSpillTimespan, err = p.optionValue(); err != nil {
+				return err
+			}
+		default:
+			b.schema.Objects.LocalityGroups[lg.Id] = lg
+			return nil
+		}
+	}
+}
+
+// parseCreatePlacement parses a CREATE PLACEMENT statement, following the
+// PLACEMENT keyword.
+func (b *schemaBuilder) parseCreatePlacement(p *ddlParser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	pl := Placement{Id: b.newId("pl"), Name: name}
+	if p.acceptKeyword("OPTIONS") || p.acceptKeyword("WITH") {
+		opts, err := p.options()
+		if err != nil {
+			return err
+		}
+		pl.InstancePartition = opts["instance_partition"]
+		pl.DefaultLeader = opts["default_leader"]
+	}
+	b.schema.Objects.Placements[pl.Id] = pl
+	return nil
+}
+
+// parseCreateModel parses a CREATE MODEL statement, following the MODEL
+// keyword.
+func (b *schemaBuilder) parseCreateModel(p *ddlParser) error {
+	p.acceptKeyword("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	m := Model{Id: b.newId("ml"), Name: name}
+	if p.acceptKeyword("INPUT") {
+		if m.Inputs, err = p.modelColumns(); err != nil {
+			return err
+		}
+		if err := p.expectKeyword("OUTPUT"); err != nil {
+			return err
+		}
+		if m.Outputs, err = p.modelColumns(); err != nil {
+			return err
+		}
+	}
+	if err := p.expectKeyword("REMOTE"); err != nil {
+		return err
+	}
+	if p.acceptKeyword("OPTIONS") {
+		if err := p.expectSymbol("("); err != nil {
+			return err
+		}
+		for first := true; !p.acceptSymbol(")"); first = false {
+			if !first {
+				if err := p.expectSymbol(","); err != nil {
+					return err
+				}
+			}
+			opt, err := p.name()
+			if err != nil {
+				return err
+			}
+			if err := p.expectSymbol("="); err != nil {
+				return err
+			}
+			if strings.EqualFold(opt, "endpoints") {
+				if err := p.expectSymbol("["); err != nil {
+					return err
+				}
+				for !p.acceptSymbol("]") {
+					if len(m.Endpoints) > 0 {
+						if err := p.expectSymbol(","); err != nil {
+							return err
+						}
+					}
+					endpoint, err := p.optionValue()
+					if err != nil {
+						return err
+					}
+					m.Endpoints = append(m.Endpoints, endpoint)
+				}
+				continue
+			}
+			value, err := p.optionValue()
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(opt) {
+			case "endpoint":
+				m.Endpoint = value
+			case "default_batch_size":
+				if m.DefaultBatchSize, err = strconv.ParseInt(value, 10, 64); err != nil {
+					return fmt.Errorf("invalid default_batch_size %s of model %s", value, name)
+				}
+			}
+		}
+	}
+	b.schema.Objects.Models[m.Id] = m
+	return nil
+}
+
+// parseCreatePropertyGraph parses a CREATE PROPERTY GRAPH statement,
+// following the GRAPH keyword. Graphs with properties defined by
+// expressions, or properties exposed under another name, are skipped.
+func (b *schemaBuilder) parseCreatePropertyGraph(p *ddlParser) (bool, error) {
+	p.acceptKeyword("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return false, err
+	}
+	pg := PropertyGraph{Id: b.newId("gr"), Name: name}
+	if err := p.expectKeyword("NODE", "TABLES"); err != nil {
+		return false, err
+	}
+	if ok, err := b.parseGraphElements(p, &pg, false); !ok || err != nil {
+		return false, err
+	}
+	if p.acceptKeyword("EDGE", "TABLES") {
+		if ok, err := b.parseGraphElements(p, &pg, true); !ok || err != nil {
+			return false, err
+		}
+	}
+	b.schema.Objects.PropertyGraphs[pg.Id] = pg
+	return true, p.expectEnd()
+}
+
+// parseGraphElements parses the parenthesized node or edge tables of a
+// property graph and adds them to pg. It returns false for elements that
+// can't be represented in the AST.
+func (b *schemaBuilder) parseGraphElements(p *ddlParser, pg *PropertyGraph, edges bool) (bool, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return false, err
+	}
+	for first := true; !p.acceptSymbol(")"); first = false {
+		if !first {
+			if err := p.expectSymbol(","); err != nil {
+				return false, err
+			}
+		}
+		tableName, err := p.name()
+		if err != nil {
+			return false, err
+		}
+		ct, err := b.lookupTable(tableName)
+		if err != nil {
+			return false, err
+		}
+		e := GraphElementTable{TableId: ct.Id}
+		if p.acceptKeyword("AS") {
+			if e.Alias, err = p.name(); err != nil {
+				return false, err
+			}
+		}
+		if p.acceptKeyword("KEY") {
+			names, err := p.nameList()
+			if err != nil {
+				return false, err
+			}
+			if e.KeyColIds, err = lookupColumns(ct, names); err != nil {
+				return false, err
+			}
+		}
+		if edges {
+			if e.Source, err = b.parseGraphNodeReference(p, *pg, ct, "SOURCE"); err != nil {
+				return false, err
+			}
+			if e.Destination, err = b.parseGraphNodeReference(p, *pg, ct, "DESTINATION"); err != nil {
+				return false, err
+			}
+		}
+		for p.peekKeyword("DEFAULT") || p.peekKeyword("LABEL") || p.peekKeyword("PROPERTIES") || p.peekKeyword("NO") {
+			var l GraphLabel
+			if !p.acceptKeyword("DEFAULT", "LABEL") && p.acceptKeyword("LABEL") {
+				if l.Name, err = p.name(); err != nil {
+					return false, err
+				}
+			}
+			switch {
+			case p.acceptKeyword("NO", "PROPERTIES"):
+				l.NoProperties = true
+			case p.acceptKeyword("PROPERTIES", "ALL", "COLUMNS"):
+				if p.peekKeyword("EXCEPT") {
+					return false, nil
+				}
+			case p.acceptKeyword("PROPERTIES"):
+				names, ok := p.propertyColumns()
+				if !ok {
+					return false, nil
+				}
+				if l.PropertyColIds, err = lookupColumns(ct, names); err != nil {
+					return false, err
+				}
+			}
+			e.Labels = append(e.Labels, l)
+		}
+		if edges {
+			pg.EdgeTables = append(pg.EdgeTables, e)
+		} else {
+			pg.NodeTables = append(pg.NodeTables, e)
+		}
+	}
+	return true, nil
+}
+
+// parseGraphNodeReference parses the SOURCE KEY or DESTINATION KEY clause of
+// an edge table ct. The node columns default to the key of the node table.
+func (b *schemaBuilder) parseGraphNodeReference(p *ddlParser, pg PropertyGraph, ct CreateTable, kw string) (GraphNodeReference, error) {
+	if err := p.expectKeyword(kw, "KEY"); err != nil {
+		return GraphNodeReference{}, err
+	}
+	names, err := p.nameList()
+	if err != nil {
+		return GraphNodeReference{}, err
+	}
+	var ref GraphNodeReference
+	if ref.ColIds, err = lookupColumns(ct, names); err != nil {
+		return GraphNodeReference{}, err
+	}
+	if err := p.expectKeyword("REFERENCES"); err != nil {
+		return GraphNodeReference{}, err
+	}
+	nodeName, err := p.name()
+	if err != nil {
+		return GraphNodeReference{}, err
+	}
+	var node GraphElementTable
+	for _, n := range pg.NodeTables {
+		if strings.EqualFold(n.elementName(b.schema.Tables), nodeName) {
+			node = n
+		}
+	}
+	if node.TableId == "" {
+		return GraphNodeReference{}, fmt.Errorf("node table %s is not defined in property graph %s", nodeName, pg.Name)
+	}
+	ref.NodeTableId = node.TableId
+	nodeTable := b.schema.Tables[node.TableId]
+	if t := p.peek(); t.kind == tokSymbol && t.val == "(" {
+		names, err := p.nameList()
+		if err != nil {
+			return GraphNodeReference{}, err
+		}
+		if ref.NodeColIds, err = lookupColumns(nodeTable, names); err != nil {
+			return GraphNodeReference{}, err
+		}
+	} else if ref.NodeColIds = node.KeyColIds; len(ref.NodeColIds) == 0 {
+		for _, k := range nodeTable.PrimaryKeys {
+			ref.NodeColIds = append(ref.NodeColIds, k.ColId)
+		}
+	}
+	return ref, nil
+}
+
+// propertyColumns parses a parenthesized list of property columns. It
+// returns false if a property is defined by an expression or renamed.
+func (p *ddlParser) propertyColumns() ([]string, bool) {
+	if !p.acceptSymbol("(") {
+		return nil, false
+	}
+	var names []string
+	for !p.acceptSymbol(")") {
+		if len(names) > 0 && !p.acceptSymbol(",") {
+			return nil, false
+		}
+		n, err := p.name()
+		if err != nil {
+			return nil, false
+		}
+		if t := p.peek(); t.kind != tokSymbol || (t.val != "," && t.val != ")") {
+			return nil, false
+		}
+		names = append(names, n)
+	}
+	return names, true
+}
+
+// modelColumns parses the parenthesized input or output columns of a model.
+func (p *ddlParser) modelColumns() ([]ModelColumn, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	var cols []ModelColumn
+	for !p.acceptSymbol(")") {
+		if len(cols) > 0 {
+			if err := p.expectSymbol(","); err != nil {
+				return nil, err
+			}
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		col := ModelColumn{Name: name}
+		if col.T, err = p.googleSQLType(); err != nil {
+			return nil, err
+		}
+		if p.acceptKeyword("OPTIONS") {
+			opts, err := p.options()
+			if err != nil {
+				return nil, err
+			}
+			col.Optional = opts["required"] == "false"
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// ParseGoogleSQLType parses a GoogleSQL column type, e.g. STRING(MAX) or
+// ARRAY<FLOAT32>(vector_length=>128).
+func ParseGoogleSQLType(s string) (Type, error) {
+	toks, err := tokenize(s, constants.DIALECT_GOOGLESQL)
+	if err != nil {
+		return Type{}, fmt.Errorf("can't parse type %q: %v", s, err)
+	}
+	p := &ddlParser{src: s, toks: toks}
+	ty, err := p.googleSQLType()
+	if err == nil && !p.atEnd() {
+		err = p.errorf("unexpected text after type")
+	}
+	if err != nil {
+		return Type{}, fmt.Errorf("can't parse type %q: %v", s, err)
+	}
+	return ty, nil
+}
+
+// parseAlterDatabase parses an ALTER DATABASE statement setting database
+// options, following the DATABASE keyword.
+// parseComment parses the COMMENT ON TABLE and COMMENT ON COLUMN statements
+// of PostgreSQL, setting the comment of the table or column.
+func (b *schemaBuilder) parseComment(p *ddlParser) error {
+	isColumn := p.acceptKeyword("COLUMN")
+	if !isColumn {
+		if err := p.expectKeyword("TABLE"); err != nil {
+			return err
+		}
+	}
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expectKeyword("IS"); err != nil {
+		return err
+	}
+	comment := ""
+	if !p.acceptKeyword("NULL") {
+		if t := p.peek(); t.kind != tokString {
+			return p.errorf("expected comment string")
+		}
+		comment = p.next().val
+	}
+	tableName, colName := name, ""
+	if isColumn {
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			return fmt.Errorf("expected table name in column name %s", name)
+		}
+		tableName, colName = name[:i], name[i+1:]
+	}
+	ct, err := b.lookupTable(tableName)
+	if err != nil {
+		return err
+	}
+	if !isColumn {
+		ct.Comment = comment
+	} else {
+		colId, err := lookupColumn(ct, colName)
+		if err != nil {
+			return err
+		}
+		cd := ct.ColDefs[colId]
+		cd.Comment = comment
+		ct.ColDefs[colId] = cd
+	}
+	b.schema.Tables[ct.Id] = ct
+	return nil
+}
+
+func (b *schemaBuilder) parseAlterDatabase(p *ddlParser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expectKeyword("SET"); err != nil {
+		return err
+	}
+	opts := make(map[string]string)
+	if p.acceptKeyword("OPTIONS") {
+		if opts, err = p.options(); err != nil {
+			return err
+		}
+	} else {
+		// PostgreSQL sets one option per statement, e.g.
+		// SET spanner.default_leader = 'us-central1'.
+		option, err := p.name()
+		if err != nil {
+			return err
+		}
+		if !p.acceptSymbol("=") {
+			if err := p.expectKeyword("TO"); err != nil {
+				return err
+			}
+		}
+		value, err := p.optionValue()
+		if err != nil {
+			return err
+		}
+		opts[strings.TrimPrefix(strings.ToLower(option), "spanner.")] = value
+	}
+	dbOpts := &b.schema.Objects.DatabaseOptions
+	dbOpts.DatabaseName = name
+	for option, value := range opts {
+		if value == "null" {
+			value = ""
+		}
+		switch option {
+		case "version_retention_period":
+			dbOpts.VersionRetentionPeriod = value
+		case "default_leader":
+			dbOpts.DefaultLeader = value
+		case "default_sequence_kind":
+			dbOpts.DefaultSequenceKind = value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIdGenerator() func(prefix string) string {
+	n := 0
+	return func(prefix string) string {
+		n++
+		return fmt.Sprintf("%s%d", prefix, n)
+	}
+}
+
+func roundTripSchema() (Schema, map[string]Sequence, SchemaObjects) {
+	tables := Schema{
+		"t1": {
+			Name:   "Singers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4", "c5", "c6"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "SingerId", Id: "c1", T: Type{Name: Int64}, NotNull: true, AutoGen: AutoGenCol{Name: "seq", GenerationType: constants.SEQUENCE}},
+				"c2": {Name: "Name", Id: "c2", T: Type{Name: String, Len: 100}},
+				"c3": {Name: "Bio", Id: "c3", T: Type{Name: String, Len: MaxLength}, Opts: map[string]string{LocalityGroupOpt: "archive"}},
+				"c4": {Name: "Updated", Id: "c4", T: Type{Name: Timestamp}, Opts: map[string]string{AllowCommitTimestampOpt: "true"}},
+				"c5": {Name: "Rating", Id: "c5", T: Type{Name: Float64}, DefaultValue: DefaultValue{IsPresent: true, Value: Expression{Statement: "0"}}},
+				"c6": {Name: "NameLength", Id: "c6", T: Type{Name: Int64}, GeneratedColumn: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "LENGTH(Name)"}, Type: GeneratedStored}, Hidden: true},
+			},
+			PrimaryKeys:      []IndexKey{{ColId: "c1", Order: 1}},
+			CheckConstraints: []CheckConstraint{{Name: "rating_check", Expr: "(Rating >= 0)"}},
+			Indexes:          []CreateIndex{{Name: "SingersByName", TableId: "t1", Unique: true, NullFiltered: true, Keys: []IndexKey{{ColId: "c2", Desc: true, Order: 1}}, StoredColumnIds: []string{"c3"}}},
+		},
+		"t2": {
+			Name:   "Albums",
+			Id:     "t2",
+			ColIds: []string{"c7", "c8", "c9", "c10"},
+			ColDefs: map[string]ColumnDef{
+				"c7":  {Name: "SingerId", Id: "c7", T: Type{Name: Int64}, NotNull: true},
+				"c8":  {Name: "AlbumId", Id: "c8", T: Type{Name: Int64}, NotNull: true},
+				"c9":  {Name: "Tags", Id: "c9", T: Type{Name: String, Len: 20, IsArray: true}},
+				"c10": {Name: "Label", Id: "c10", T: Type{Name: String, Len: 20}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c7", Order: 1}, {ColId: "c8", Order: 2}},
+			ParentTable: InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+			ForeignKeys: []Foreignkey{{Name: "fk_label", ColIds: []string{"c10"}, ReferTableId: "t3", ReferColumnIds: []string{"c11"}, OnDelete: constants.FK_NO_ACTION}},
+			Indexes:     []CreateIndex{{Name: "AlbumsByLabel", TableId: "t2", Keys: []IndexKey{{ColId: "c7", Order: 1}, {ColId: "c10", Order: 2}}, Interleave: "t1"}},
+			Synonym:     "Records",
+		},
+		"t3": {
+			Name:          "Labels",
+			Id:            "t3",
+			ColIds:        []string{"c11", "c12"},
+			ColDefs:       map[string]ColumnDef{"c11": {Name: "Name", Id: "c11", T: Type{Name: String, Len: 20}, NotNull: true}, "c12": {Name: "Location", Id: "c12", T: Type{Name: String, Len: 10}, NotNull: true}},
+			PrimaryKeys:   []IndexKey{{ColId: "c11", Order: 1}},
+			LocalityGroup: "archive",
+			PlacementKey:  "c12",
+		},
+	}
+	sequences := map[string]Sequence{
+		"s1": {Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "1000", StartWithCounter: "50"},
+	}
+	objects := SchemaObjects{
+		Views:          map[string]CreateView{"v1": {Id: "v1", Name: "SingerNames", SecurityType: "INVOKER", Query: "SELECT Singers.Name FROM Singers"}},
+		ChangeStreams:  map[string]ChangeStream{"cs1": {Id: "cs1", Name: "SingerChanges", WatchedTables: []ChangeStreamTable{{TableId: "t1", ColIds: []string{"c2"}}, {TableId: "t2"}}, ValueCaptureType: "NEW_ROW", RetentionPeriod: "7d"}},
+		LocalityGroups: map[string]LocalityGroup{"lg1": {Id: "lg1", Name: "archive", Storage: "hdd", SsdToHddSpillTimespan: "10d"}},
+		Placements:     map[string]Placement{"pl1": {Id: "pl1", Name: "europe", InstancePartition: "eu-partition", DefaultLeader: "europe-west1"}},
+		Models: map[string]Model{
+			"ml1": {Id: "ml1", Name: "Sentiment", Inputs: []ModelColumn{{Name: "text", T: Type{Name: String, Len: MaxLength}}}, Outputs: []ModelColumn{{Name: "score", T: Type{Name: Float64}, Optional: true}}, Endpoint: "//aiplatform.googleapis.com/projects/p/locations/us-central1/endpoints/e", DefaultBatchSize: 10},
+			"ml2": {Id: "ml2", Name: "Embeddings", Endpoints: []string{"//aiplatform.googleapis.com/a", "//aiplatform.googleapis.com/b"}},
+		},
+		PropertyGraphs: map[string]PropertyGraph{"gr1": {
+			Id:         "gr1",
+			Name:       "MusicGraph",
+			NodeTables: []GraphElementTable{{TableId: "t1", Labels: []GraphLabel{{Name: "Singer", PropertyColIds: []string{"c1", "c2"}}, {}}}, {TableId: "t3", Alias: "Label", KeyColIds: []string{"c11"}}},
+			EdgeTables: []GraphElementTable{{
+				TableId:     "t2",
+				Alias:       "Released",
+				Source:      GraphNodeReference{ColIds: []string{"c7"}, NodeTableId: "t1", NodeColIds: []string{"c1"}},
+				Destination: GraphNodeReference{ColIds: []string{"c10"}, NodeTableId: "t3", NodeColIds: []string{"c11"}},
+				Labels:      []GraphLabel{{Name: "Released", NoProperties: true}},
+			}},
+		}},
+		DatabaseOptions: DatabaseOptions{
+			DatabaseName:           "music",
+			VersionRetentionPeriod: "3d",
+			DefaultLeader:          "us-east1",
+		},
+	}
+	return tables, sequences, objects
+}
+
+func TestParseDDLRoundTrip(t *testing.T) {
+	tables, sequences, objects := roundTripSchema()
+	for _, dialect := range []string{constants.DIALECT_GOOGLESQL, constants.DIALECT_POSTGRESQL} {
+		for _, protectIds := range []bool{false, true} {
+			c := Config{ProtectIds: protectIds, Tables: true, ForeignKeys: true, SpDialect: dialect}
+			stmts := GetDDL(c, tables, sequences, objects)
+			parsed, err := ParseDDL(stmts, dialect, newTestIdGenerator())
+			assert.Nil(t, err, dialect)
+			assert.Empty(t, parsed.Skipped, dialect)
+			assert.Equal(t, stmts, GetDDL(c, parsed.Tables, parsed.Sequences, parsed.Objects), dialect)
+		}
+	}
+}
+
+func TestParseDDL(t *testing.T) {
+	stmts := []string{
+		"CREATE SEQUENCE Seq OPTIONS (sequence_kind = 'bit_reversed_positive')",
+		"CREATE TABLE Singers (\n  SingerId INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE Seq)),\n  Uuid STRING(36) DEFAULT (GENERATE_UUID()),\n  Score NUMERIC DEFAULT (CAST(1.5 AS NUMERIC)),\n  Info examples.SingerInfo,\n  Embedding ARRAY<FLOAT32>(vector_length=>3),\n) PRIMARY KEY(SingerId)",
+		"CREATE TABLE Concerts (\n  ConcertId INT64 NOT NULL,\n  SingerId INT64,\n  CONSTRAINT FK_Singer FOREIGN KEY (SingerId) REFERENCES Singers (SingerId) NOT ENFORCED,\n) PRIMARY KEY(ConcertId), ROW DELETION POLICY (OLDER_THAN(Ts, INTERVAL 30 DAY))",
+		"CREATE SEARCH INDEX SingersIndex ON Singers(Tokens)",
+		"GRANT SELECT ON TABLE Singers TO ROLE reader",
+	}
+	parsed, err := ParseDDL(stmts, constants.DIALECT_GOOGLESQL, newTestIdGenerator())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{stmts[3], stmts[4]}, parsed.Skipped)
+	assert.Equal(t, Sequence{Id: "s1", Name: "Seq", SequenceKind: "BIT REVERSED POSITIVE", ColumnsUsingSeq: map[string][]string{"t2": {"c3"}}}, parsed.Sequences["s1"])
+	singers := parsed.Tables["t2"]
+	assert.Equal(t, "Singers", singers.Name)
+	assert.Equal(t, []IndexKey{{ColId: "c3", Order: 1}}, singers.PrimaryKeys)
+	assert.Equal(t, ColumnDef{Name: "SingerId", Id: "c3", T: Type{Name: Int64}, NotNull: true, AutoGen: AutoGenCol{Name: "Seq", GenerationType: constants.SEQUENCE}}, singers.ColDefs["c3"])
+	assert.Equal(t, ColumnDef{Name: "Uuid", Id: "c4", T: Type{Name: String, Len: 36}, AutoGen: AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}}, singers.ColDefs["c4"])
+	assert.Equal(t, DefaultValue{IsPresent: true, Value: Expression{ExpressionId: "e6", Statement: "1.5"}}, singers.ColDefs["c5"].DefaultValue)
+	assert.Equal(t, Type{Name: Proto, ProtoName: "examples.SingerInfo"}, singers.ColDefs["c7"].T)
+	assert.Equal(t, Type{Name: Float32, IsArray: true, VectorLength: 3}, singers.ColDefs["c8"].T)
+	concerts := parsed.Tables["t9"]
+	assert.Equal(t, []Foreignkey{{Name: "FK_Singer", Id: "f12", ColIds: []string{"c11"}, ReferTableId: "t2", ReferColumnIds: []string{"c3"}, NotEnforced: true}}, concerts.ForeignKeys)
+}
+
+func TestParseDDLPropertyGraph(t *testing.T) {
+	stmts := []string{
+		"CREATE TABLE Person (id INT64 NOT NULL, name STRING(MAX)) PRIMARY KEY (id)",
+		"CREATE TABLE Knows (id INT64 NOT NULL, other_id INT64 NOT NULL) PRIMARY KEY (id, other_id)",
+		"CREATE PROPERTY GRAPH FriendGraph NODE TABLES (Person) EDGE TABLES (Knows SOURCE KEY (id) REFERENCES Person DESTINATION KEY (other_id) REFERENCES Person (id) LABEL Knows PROPERTIES (other_id))",
+		"CREATE PROPERTY GRAPH RenamedGraph NODE TABLES (Person PROPERTIES (name AS full_name))",
+	}
+	parsed, err := ParseDDL(stmts, constants.DIALECT_GOOGLESQL, newTestIdGenerator())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{stmts[3]}, parsed.Skipped)
+	assert.Equal(t, PropertyGraph{
+		Id:         "gr7",
+		Name:       "FriendGraph",
+		NodeTables: []GraphElementTable{{TableId: "t1"}},
+		EdgeTables: []GraphElementTable{{
+			TableId:     "t4",
+			Source:      GraphNodeReference{ColIds: []string{"c5"}, NodeTableId: "t1", NodeColIds: []string{"c2"}},
+			Destination: GraphNodeReference{ColIds: []string{"c6"}, NodeTableId: "t1", NodeColIds: []string{"c2"}},
+			Labels:      []GraphLabel{{Name: "Knows", PropertyColIds: []string{"c6"}}},
+		}},
+	}, parsed.Objects.PropertyGraphs["gr7"])
+}
+
+func TestParseDDLPG(t *testing.T) {
+	stmts := []string{
+		"CREATE TABLE singers (\n  singer_id bigint NOT NULL,\n  first_name character varying(1024),\n  bio text,\n  rating double precision[],\n  updated spanner.commit_timestamp,\n  fee numeric(10, 2),\n  PRIMARY KEY(singer_id)\n)",
+		"ALTER DATABASE \"music\" SET spanner.default_leader = 'us-east1'",
+	}
+	parsed, err := ParseDDL(stmts, constants.DIALECT_POSTGRESQL, newTestIdGenerator())
+	assert.Nil(t, err)
+	singers := parsed.Tables["t1"]
+	assert.Equal(t, []string{"c2", "c3", "c4", "c5", "c6", "c7"}, singers.ColIds)
+	assert.Equal(t, Type{Name: String, Len: 1024}, singers.ColDefs["c3"].T)
+	assert.Equal(t, Type{Name: String, Len: MaxLength}, singers.ColDefs["c4"].T)
+	assert.Equal(t, Type{Name: Float64, IsArray: true}, singers.ColDefs["c5"].T)
+	assert.True(t, singers.ColDefs["c6"].AllowsCommitTimestamp())
+	assert.Equal(t, Type{Name: Numeric, Precision: 10, Scale: 2}, singers.ColDefs["c7"].T)
+	assert.Equal(t, []IndexKey{{ColId: "c2", Order: 1}}, singers.PrimaryKeys)
+	assert.Equal(t, DatabaseOptions{DatabaseName: "music", DefaultLeader: "us-east1"}, parsed.Objects.DatabaseOptions)
+}
+
+func TestParseGoogleSQLType(t *testing.T) {
+	ty, err := ParseGoogleSQLType("ARRAY<FLOAT32>(vector_length=>128)")
+	assert.Nil(t, err)
+	assert.Equal(t, Type{Name: Float32, IsArray: true, VectorLength: 128}, ty)
+	ty, err = ParseGoogleSQLType("string(max)")
+	assert.Nil(t, err)
+	assert.Equal(t, Type{Name: String, Len: MaxLength}, ty)
+	_, err = ParseGoogleSQLType("STRING(MAX) NOT NULL")
+	assert.NotNil(t, err)
+}
+
+func TestParseDDLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		stmt string
+	}{
+		{"missing primary key column", "CREATE TABLE t (a INT64) PRIMARY KEY (b)"},
+		{"unknown parent", "CREATE TABLE t (a INT64) PRIMARY KEY (a), INTERLEAVE IN PARENT p"},
+		{"unknown index table", "CREATE INDEX i ON t (a)"},
+		{"unbalanced parentheses", "CREATE TABLE t (a INT64 DEFAULT ((1)) PRIMARY KEY (a)"},
+		{"unterminated string", "CREATE VIEW v SQL SECURITY INVOKER AS SELECT 'a"},
+		{"trailing tokens", "CREATE SEQUENCE s OPTIONS (sequence_kind = 'bit_reversed_positive') extra"},
+		{"model without remote", "CREATE MODEL m OPTIONS (endpoint = 'e')"},
+	}
+	for _, tc := range tests {
+		_, err := ParseDDL([]string{tc.stmt}, constants.DIALECT_GOOGLESQL, newTestIdGenerator())
+		assert.NotNil(t, err, tc.name)
+	}
+}
+
+func TestSplitDDLStatements(t *testing.T) {
+	text := "-- Schema; exported\nCREATE TABLE t (\n  a STRING(MAX) DEFAULT ('x;y'),\n) PRIMARY KEY (a);\n/* ; */\n;\nCREATE INDEX i ON t (a)\n"
+	stmts, err := SplitDDLStatements(text)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"-- Schema; exported\nCREATE TABLE t (\n  a STRING(MAX) DEFAULT ('x;y'),\n) PRIMARY KEY (a)",
+		"CREATE INDEX i ON t (a)",
+	}, stmts)
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+)
+
+const (
+	// mysqlMaxVarcharLength is the maximum length of the STRING columns
+	// printed as VARCHAR for MySQL; longer columns are printed as LONGTEXT.
+	mysqlMaxVarcharLength = 16383
+	// mysqlMaxVarbinaryLength is the maximum length of the BYTES columns
+	// printed as VARBINARY for MySQL; longer columns are printed as LONGBLOB.
+	mysqlMaxVarbinaryLength = 65535
+	// mysqlMaxKeyLength and mysqlMaxKeyBytes are the maximum lengths of the
+	// STRING and BYTES key columns for MySQL, which can't index LONGTEXT and
+	// LONGBLOB columns. InnoDB keys are limited to 3072 bytes, i.e. 768
+	// characters.
+	mysqlMaxKeyLength = 768
+	mysqlMaxKeyBytes  = 3072
+)
+
+// GetSourceDDL returns the statements creating the tables of s in a MySQL,
+// MariaDB or PostgreSQL database, depending on driver, e.g. to provision the
+// target of reverse replication or a fallback database. Tables come first,
+// in order of table name, followed by their indexes, then by foreign keys
+// added with ALTER TABLE statements, so that tables referencing each other
+// can be created. Interleaved tables reference their parent table with a
+// foreign key. The following are left out as they have no source
+// counterpart or are written in the Spanner dialect: shard id columns,
+// TOKENLIST columns, search and vector indexes, informational foreign keys,
+// default values and generated columns.
+func GetSourceDDL(s Schema, driver string) ([]string, error) {
+	var p sourcePrinter
+	switch driver {
+	case constants.MYSQL, constants.MYSQLDUMP, constants.MARIADB:
+		p = sourcePrinter{schema: s, mysql: true}
+	case constants.POSTGRES, constants.PGDUMP:
+		p = sourcePrinter{schema: s}
+	default:
+		return nil, fmt.Errorf("can't print source DDL for driver %s: only MySQL and PostgreSQL are supported", driver)
+	}
+	var tables, indexes, fks []string
+	for _, tableId := range GetSortedTableIdsBySpName(s) {
+		ct := s[tableId]
+		tables = append(tables, p.createTable(ct))
+		for _, ci := range GetSortedIndexes(ct) {
+			if idx := p.createIndex(ct, ci); idx != "" {
+				indexes = append(indexes, idx)
+			}
+		}
+		fks = append(fks, p.foreignKeys(ct)...)
+	}
+	return append(append(tables, indexes...), fks...), nil
+}
+
+// sourcePrinter prints a Spanner schema in the MySQL dialect if mysql is set,
+// and in the PostgreSQL dialect otherwise.
+type sourcePrinter struct {
+	schema Schema
+	mysql  bool
+}
+
+func (p sourcePrinter) quote(s string) string {
+	if p.mysql {
+		return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+	}
+	return "\"" + strings.ReplaceAll(s, "\"", "\"\"") + "\""
+}
+
+// constraint returns the CONSTRAINT clause naming a constraint, or an empty
+// string for unnamed constraints, which the database names.
+func (p sourcePrinter) constraint(name string) string {
+	if name == "" {
+		return ""
+	}
+	return "CONSTRAINT " + p.quote(name) + " "
+}
+
+// printed reports whether column colId of ct has a source counterpart.
+func (p sourcePrinter) printed(ct CreateTable, colId string) bool {
+	cd, ok := ct.ColDefs[colId]
+	return ok && colId != ct.ShardIdColumn && cd.T.Name != TokenList
+}
+
+func (p sourcePrinter) createTable(ct CreateTable) string {
+	isKey := make(map[string]bool)
+	for _, k := range ct.PrimaryKeys {
+		isKey[k.ColId] = true
+	}
+	for _, ci := range ct.Indexes {
+		for _, k := range ci.Keys {
+			isKey[k.ColId] = true
+		}
+	}
+	var elems []string
+	for _, colId := range ct.ColIds {
+		if !p.printed(ct, colId) {
+			continue
+		}
+		cd := ct.ColDefs[colId]
+		s := p.quote(cd.Name) + " " + p.columnType(cd.T, isKey[colId])
+		if cd.NotNull {
+			s += " NOT NULL"
+		}
+		elems = append(elems, s)
+	}
+	if keys := p.keys(ct, orderedIndexKeys(ct.PrimaryKeys), !p.mysql); len(keys) > 0 {
+		elems = append(elems, "PRIMARY KEY ("+keys+")")
+	}
+	for _, cc := range ct.CheckConstraints {
+		elems = append(elems, p.constraint(cc.Name)+"CHECK "+cc.Expr)
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", p.quote(ct.Name), strings.Join(elems, ",\n\t"))
+}
+
+// keys prints the printed columns of keys, dropping their order if noOrder
+// is set.
+func (p sourcePrinter) keys(ct CreateTable, keys []IndexKey, noOrder bool) string {
+	var l []string
+	for _, k := range keys {
+		if !p.printed(ct, k.ColId) {
+			continue
+		}
+		s := p.quote(ct.ColDefs[k.ColId].Name)
+		if k.Desc && !noOrder {
+			s += " DESC"
+		}
+		l = append(l, s)
+	}
+	return strings.Join(l, ", ")
+}
+
+// columnType returns the source type of columns of type ty. isKey is set
+// for columns of a primary or index key.
+func (p sourcePrinter) columnType(ty Type, isKey bool) string {
+	if p.mysql {
+		if ty.IsArray {
+			return "JSON"
+		}
+		switch ty.Name {
+		case Bool:
+			return "BOOLEAN"
+		case Int64, Enum:
+			return "BIGINT"
+		case Float32:
+			return "FLOAT"
+		case Float64:
+			return "DOUBLE"
+		case Numeric:
+			if ty.Precision > 0 {
+				return fmt.Sprintf("DECIMAL(%d,%d)", ty.Precision, ty.Scale)
+			}
+			return fmt.Sprintf("DECIMAL(%d,%d)", NumericMaxIntegerDigits+NumericMaxScale, NumericMaxScale)
+		case String:
+			switch {
+			case isKey:
+				return fmt.Sprintf("VARCHAR(%d)", min(ty.Len, mysqlMaxKeyLength))
+			case ty.Len <= mysqlMaxVarcharLength:
+				return fmt.Sprintf("VARCHAR(%d)", ty.Len)
+			}
+			return "LONGTEXT"
+		case Bytes:
+			switch {
+			case isKey:
+				return fmt.Sprintf("VARBINARY(%d)", min(ty.Len, mysqlMaxKeyBytes))
+			case ty.Len <= mysqlMaxVarbinaryLength:
+				return fmt.Sprintf("VARBINARY(%d)", ty.Len)
+			}
+			return "LONGBLOB"
+		case Proto:
+			return "LONGBLOB"
+		case Date:
+			return "DATE"
+		case Timestamp:
+			// MySQL TIMESTAMP columns only cover the years 1970 to 2038.
+			return "DATETIME(6)"
+		case JSON:
+			return "JSON"
+		case UUID:
+			return "CHAR(36)"
+		}
+		return ty.Name
+	}
+	var s string
+	switch ty.Name {
+	case Bool:
+		s = "boolean"
+	case Int64, Enum:
+		s = "bigint"
+	case Float32:
+		s = "real"
+	case Float64:
+		s = "double precision"
+	case Numeric:
+		s = "numeric"
+		if ty.Precision > 0 {
+			s += fmt.Sprintf("(%d,%d)", ty.Precision, ty.Scale)
+		}
+	case String:
+		s = "text"
+		if ty.Len < PGMaxLength {
+			s = fmt.Sprintf("varchar(%d)", ty.Len)
+		}
+	case Bytes, Proto:
+		s = "bytea"
+	case Date:
+		s = "date"
+	case Timestamp:
+		s = "timestamptz"
+	case JSON:
+		s = "jsonb"
+	case UUID:
+		s = "uuid"
+	default:
+		s = strings.ToLower(ty.Name)
+	}
+	if ty.IsArray {
+		s += "[]"
+	}
+	return s
+}
+
+func (p sourcePrinter) createIndex(ct CreateTable, ci CreateIndex) string {
+	keys := p.keys(ct, orderedIndexKeys(ci.Keys), false)
+	if keys == "" {
+		return ""
+	}
+	var unique string
+	if ci.Unique {
+		unique = "UNIQUE "
+	}
+	s := fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)", unique, p.quote(ci.Name), p.quote(ct.Name), keys)
+	if p.mysql {
+		return s
+	}
+	var stored []string
+	for _, colId := range ci.StoredColumnIds {
+		if p.printed(ct, colId) {
+			stored = append(stored, p.quote(ct.ColDefs[colId].Name))
+		}
+	}
+	if len(stored) > 0 {
+		s += fmt.Sprintf(" INCLUDE (%s)", strings.Join(stored, ", "))
+	}
+	if ci.NullFiltered {
+		var conds []string
+		for _, k := range orderedIndexKeys(ci.Keys) {
+			if p.printed(ct, k.ColId) {
+				conds = append(conds, p.quote(ct.ColDefs[k.ColId].Name)+" IS NOT NULL")
+			}
+		}
+		s += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return s
+}
+
+// foreignKeys returns the ALTER TABLE statements adding the foreign keys of
+// ct, starting with the foreign key to its parent table if ct is interleaved
+// in parent.
+func (p sourcePrinter) foreignKeys(ct CreateTable) []string {
+	var l []string
+	if parent, ok := p.schema[ct.ParentTable.Id]; ok && ct.ParentTable.InterleaveType != "IN" {
+		fk := Foreignkey{ReferTableId: parent.Id, OnDelete: ct.ParentTable.OnDelete}
+		// The primary key of an interleaved table starts with the primary
+		// key of its parent.
+		parentKeys := orderedIndexKeys(parent.PrimaryKeys)
+		childKeys := orderedIndexKeys(ct.PrimaryKeys)
+		for i := 0; i < len(parentKeys) && i < len(childKeys); i++ {
+			fk.ColIds = append(fk.ColIds, childKeys[i].ColId)
+			fk.ReferColumnIds = append(fk.ReferColumnIds, parentKeys[i].ColId)
+		}
+		l = append(l, p.addForeignKey(ct, fk))
+	}
+	for _, fk := range GetSortedForeignKeys(ct) {
+		if _, ok := p.schema[fk.ReferTableId]; ok && !fk.NotEnforced {
+			l = append(l, p.addForeignKey(ct, fk))
+		}
+	}
+	return l
+}
+
+func (p sourcePrinter) addForeignKey(ct CreateTable, fk Foreignkey) string {
+	refer := p.schema[fk.ReferTableId]
+	var cols, referCols []string
+	for i, colId := range fk.ColIds {
+		cols = append(cols, p.quote(ct.ColDefs[colId].Name))
+		referCols = append(referCols, p.quote(refer.ColDefs[fk.ReferColumnIds[i]].Name))
+	}
+	s := fmt.Sprintf("ALTER TABLE %s ADD %sFOREIGN KEY (%s) REFERENCES %s (%s)", p.quote(ct.Name), p.constraint(fk.Name), strings.Join(cols, ", "), p.quote(refer.Name), strings.Join(referCols, ", "))
+	if fk.OnDelete != "" {
+		s += " ON DELETE " + fk.OnDelete
+	}
+	return s
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func sourceDDLTestSchema() Schema {
+	return Schema{
+		"t1": {
+			Name:   "Singers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4", "c5", "c6", "c7"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "SingerId", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+				"c2": {Name: "Name", Id: "c2", T: Type{Name: String, Len: 100}},
+				"c3": {Name: "Bio", Id: "c3", T: Type{Name: String, Len: MaxLength}},
+				"c4": {Name: "Tags", Id: "c4", T: Type{Name: String, Len: 20, IsArray: true}},
+				"c5": {Name: "Born", Id: "c5", T: Type{Name: Timestamp}},
+				"c6": {Name: "Name_Tokens", Id: "c6", T: Type{Name: TokenList}, Hidden: true},
+				"c7": {Name: "migration_shard_id", Id: "c7", T: Type{Name: String, Len: 50}},
+			},
+			PrimaryKeys:      []IndexKey{{ColId: "c1", Desc: true, Order: 1}},
+			ShardIdColumn:    "c7",
+			Indexes:          []CreateIndex{{Name: "SingersByName", TableId: "t1", Unique: true, Keys: []IndexKey{{ColId: "c2", Order: 1}}, StoredColumnIds: []string{"c5"}, NullFiltered: true}},
+			SearchIndexes:    []SearchIndex{{Name: "SingersSearch", TableId: "t1", Keys: []IndexKey{{ColId: "c6"}}}},
+			CheckConstraints: []CheckConstraint{{Name: "name_not_empty", Expr: "(Name <> '')"}},
+		},
+		"t2": {
+			Name:   "Albums",
+			Id:     "t2",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "SingerId", Id: "c1", T: Type{Name: Int64}, NotNull: true},
+				"c2": {Name: "AlbumId", Id: "c2", T: Type{Name: String, Len: MaxLength}, NotNull: true},
+				"c3": {Name: "LabelId", Id: "c3", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+			ForeignKeys: []Foreignkey{
+				{Name: "fk_label", ColIds: []string{"c3"}, ReferTableId: "t3", ReferColumnIds: []string{"c1"}, OnDelete: constants.FK_NO_ACTION},
+				{Name: "fk_informational", ColIds: []string{"c3"}, ReferTableId: "t3", ReferColumnIds: []string{"c1"}, NotEnforced: true},
+			},
+			ParentTable: InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE},
+		},
+		"t3": {
+			Name:        "Labels",
+			Id:          "t3",
+			ColIds:      []string{"c1"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "LabelId", Id: "c1", T: Type{Name: Int64}, NotNull: true}},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}},
+		},
+	}
+}
+
+func TestGetSourceDDL(t *testing.T) {
+	logger.Log = zap.NewNop()
+	tests := []struct {
+		driver   string
+		expected []string
+	}{
+		{
+			driver: constants.MYSQL,
+			expected: []string{
+				"CREATE TABLE `Labels` (\n" +
+					"\t`LabelId` BIGINT NOT NULL,\n" +
+					"\tPRIMARY KEY (`LabelId`)\n" +
+					")",
+				"CREATE TABLE `Singers` (\n" +
+					"\t`SingerId` BIGINT NOT NULL,\n" +
+					"\t`Name` VARCHAR(100),\n" +
+					"\t`Bio` LONGTEXT,\n" +
+					"\t`Tags` JSON,\n" +
+					"\t`Born` DATETIME(6),\n" +
+					"\tPRIMARY KEY (`SingerId` DESC),\n" +
+					"\tCONSTRAINT `name_not_empty` CHECK (Name <> '')\n" +
+					")",
+				"CREATE TABLE `Albums` (\n" +
+					"\t`SingerId` BIGINT NOT NULL,\n" +
+					"\t`AlbumId` VARCHAR(768) NOT NULL,\n" +
+					"\t`LabelId` BIGINT,\n" +
+					"\tPRIMARY KEY (`SingerId`, `AlbumId`)\n" +
+					")",
+				"CREATE UNIQUE INDEX `SingersByName` ON `Singers` (`Name`)",
+				"ALTER TABLE `Albums` ADD FOREIGN KEY (`SingerId`) REFERENCES `Singers` (`SingerId`) ON DELETE CASCADE",
+				"ALTER TABLE `Albums` ADD CONSTRAINT `fk_label` FOREIGN KEY (`LabelId`) REFERENCES `Labels` (`LabelId`) ON DELETE NO ACTION",
+			},
+		},
+		{
+			driver: constants.PGDUMP,
+			expected: []string{
+				"CREATE TABLE \"Labels\" (\n" +
+					"\t\"LabelId\" bigint NOT NULL,\n" +
+					"\tPRIMARY KEY (\"LabelId\")\n" +
+					")",
+				"CREATE TABLE \"Singers\" (\n" +
+					"\t\"SingerId\" bigint NOT NULL,\n" +
+					"\t\"Name\" varchar(100),\n" +
+					"\t\"Bio\" text,\n" +
+					"\t\"Tags\" varchar(20)[],\n" +
+					"\t\"Born\" timestamptz,\n" +
+					"\tPRIMARY KEY (\"SingerId\"),\n" +
+					"\tCONSTRAINT \"name_not_empty\" CHECK (Name <> '')\n" +
+					")",
+				"CREATE TABLE \"Albums\" (\n" +
+					"\t\"SingerId\" bigint NOT NULL,\n" +
+					"\t\"AlbumId\" text NOT NULL,\n" +
+					"\t\"LabelId\" bigint,\n" +
+					"\tPRIMARY KEY (\"SingerId\", \"AlbumId\")\n" +
+					")",
+				"CREATE UNIQUE INDEX \"SingersByName\" ON \"Singers\" (\"Name\") INCLUDE (\"Born\") WHERE \"Name\" IS NOT NULL",
+				"ALTER TABLE \"Albums\" ADD FOREIGN KEY (\"SingerId\") REFERENCES \"Singers\" (\"SingerId\") ON DELETE CASCADE",
+				"ALTER TABLE \"Albums\" ADD CONSTRAINT \"fk_label\" FOREIGN KEY (\"LabelId\") REFERENCES \"Labels\" (\"LabelId\") ON DELETE NO ACTION",
+			},
+		},
+	}
+	for _, tc := range tests {
+		stmts, err := GetSourceDDL(sourceDDLTestSchema(), tc.driver)
+		assert.Nil(t, err, tc.driver)
+		assert.Equal(t, tc.expected, stmts, tc.driver)
+	}
+}
+
+func TestGetSourceDDLUnsupportedDriver(t *testing.T) {
+	_, err := GetSourceDDL(sourceDDLTestSchema(), constants.ORACLE)
+	assert.NotNil(t, err)
+}
+
+func TestSourceColumnType(t *testing.T) {
+	mysql := sourcePrinter{mysql: true}
+	pg := sourcePrinter{}
+	tests := []struct {
+		ty        Type
+		isKey     bool
+		mysql, pg string
+	}{
+		{ty: Type{Name: Bool}, mysql: "BOOLEAN", pg: "boolean"},
+		{ty: Type{Name: Float32}, mysql: "FLOAT", pg: "real"},
+		{ty: Type{Name: Float64}, mysql: "DOUBLE", pg: "double precision"},
+		{ty: Type{Name: Numeric}, mysql: "DECIMAL(38,9)", pg: "numeric"},
+		{ty: Type{Name: Numeric, Precision: 10, Scale: 2}, mysql: "DECIMAL(10,2)", pg: "numeric(10,2)"},
+		{ty: Type{Name: String, Len: 20000}, mysql: "LONGTEXT", pg: "varchar(20000)"},
+		{ty: Type{Name: String, Len: 100}, isKey: true, mysql: "VARCHAR(100)", pg: "varchar(100)"},
+		{ty: Type{Name: String, Len: 1024}, isKey: true, mysql: "VARCHAR(768)", pg: "varchar(1024)"},
+		{ty: Type{Name: Bytes, Len: 100}, mysql: "VARBINARY(100)", pg: "bytea"},
+		{ty: Type{Name: Bytes, Len: MaxLength}, mysql: "LONGBLOB", pg: "bytea"},
+		{ty: Type{Name: Bytes, Len: MaxLength}, isKey: true, mysql: "VARBINARY(3072)", pg: "bytea"},
+		{ty: Type{Name: Bytes, Len: 4096}, isKey: true, mysql: "VARBINARY(3072)", pg: "bytea"},
+		{ty: Type{Name: Date}, mysql: "DATE", pg: "date"},
+		{ty: Type{Name: JSON}, mysql: "JSON", pg: "jsonb"},
+		{ty: Type{Name: UUID}, mysql: "CHAR(36)", pg: "uuid"},
+		{ty: Type{Name: Int64, IsArray: true}, mysql: "JSON", pg: "bigint[]"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.mysql, mysql.columnType(tc.ty, tc.isKey), tc.ty.PrintColumnDefType())
+		assert.Equal(t, tc.pg, pg.columnType(tc.ty, tc.isKey), tc.ty.PrintColumnDefType())
+	}
+}
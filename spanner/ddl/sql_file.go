@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SQLFileHeader is the provenance of the statements of a SQL file, written
+// as comments at the top of the file. Empty fields are left out.
+type SQLFileHeader struct {
+	Generated   time.Time
+	ToolVersion string
+	Source      string // Source database driver, e.g. mysql.
+	Dialect     string // Dialect of the statements, e.g. google_standard_sql.
+	SessionId   string
+}
+
+// SQLFileOptions controls the layout of the SQL files written by
+// WriteSQLFile.
+type SQLFileOptions struct {
+	Header SQLFileHeader
+	// If true, each statement is preceded by a comment naming the object it
+	// creates or alters, e.g. "-- Table Singers".
+	SectionMarkers bool
+}
+
+// SQLFileConfig returns the config printing DDL that can be run as is, with
+// all identifiers quoted for the dialect, and without comments.
+func SQLFileConfig(spDialect, source string) Config {
+	return Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: spDialect, Source: source}
+}
+
+// WriteSQLFile writes stmts, e.g. the output of GetDDL, as a ready to run SQL
+// file: a provenance header followed by the statements, each terminated by a
+// semicolon.
+func WriteSQLFile(w io.Writer, stmts []string, opts SQLFileOptions) error {
+	var b strings.Builder
+	h := opts.Header
+	if !h.Generated.IsZero() {
+		fmt.Fprintf(&b, "-- Schema generated %s\n", h.Generated.Format("2006-01-02 15:04:05"))
+	}
+	for _, f := range []struct{ name, value string }{
+		{"Tool version", h.ToolVersion},
+		{"Source", h.Source},
+		{"Dialect", h.Dialect},
+		{"Session", h.SessionId},
+	} {
+		if f.value != "" {
+			fmt.Fprintf(&b, "-- %s: %s\n", f.name, f.value)
+		}
+	}
+	if len(stmts) == 0 {
+		b.WriteString("\n-- Schema is empty -- no tables found\n")
+	}
+	for _, stmt := range stmts {
+		stmt = strings.TrimRight(strings.TrimSpace(stmt), ";")
+		if stmt == "" {
+			continue
+		}
+		b.WriteString("\n")
+		if opts.SectionMarkers {
+			if marker := sectionMarker(stmt); marker != "" {
+				b.WriteString("-- " + marker + "\n")
+			}
+		}
+		b.WriteString(stmt + ";\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// statementObjectRegexp matches the statements printed by GetDDL, capturing
+// the verb, the kind of object and its name if any. Leading comments are
+// skipped.
+var statementObjectRegexp = regexp.MustCompile(`(?is)^(?:--[^\n]*\n\s*)*(CREATE|ALTER)\s+(?:OR\s+REPLACE\s+)?(?:UNIQUE\s+|NULL_FILTERED\s+|SEARCH\s+|VECTOR\s+)*(TABLE|INDEX|VIEW|SEQUENCE|CHANGE\s+STREAM|PROPERTY\s+GRAPH|MODEL|LOCALITY\s+GROUP|PLACEMENT|PROTO\s+BUNDLE|DATABASE)\b(?:\s+IF\s+NOT\s+EXISTS)?(?:\s+([^\s(]+))?`)
+
+// sectionMarker returns the section marker of stmt, e.g. "Table Singers" or
+// "Alter table Singers", or an empty string if its object is unknown.
+func sectionMarker(stmt string) string {
+	m := statementObjectRegexp.FindStringSubmatch(stmt)
+	if m == nil {
+		return ""
+	}
+	kind := strings.ToLower(strings.Join(strings.Fields(m[2]), " "))
+	if strings.EqualFold(m[1], "ALTER") {
+		kind = "alter " + kind
+	}
+	marker := strings.ToUpper(kind[:1]) + kind[1:]
+	if name := strings.Trim(m[3], "`\""); name != "" {
+		marker += " " + name
+	}
+	return marker
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSQLFile(t *testing.T) {
+	stmts := []string{
+		"CREATE TABLE `Singers` (\n\t`SingerId` INT64 NOT NULL ,\n) PRIMARY KEY (`SingerId`)",
+		"CREATE UNIQUE NULL_FILTERED INDEX IF NOT EXISTS `SingersByName` ON `Singers` (`Name`)",
+		"ALTER TABLE `Albums` ADD CONSTRAINT `fk` FOREIGN KEY (`SingerId`) REFERENCES `Singers` (`SingerId`);",
+		"CREATE PROTO BUNDLE (\n\tmusic.Genre)",
+		"",
+	}
+	header := SQLFileHeader{
+		Generated:   time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC),
+		ToolVersion: "v1.2.3",
+		Source:      constants.MYSQL,
+		Dialect:     constants.DIALECT_GOOGLESQL,
+		SessionId:   "smt-job-1234",
+	}
+	var b strings.Builder
+	assert.Nil(t, WriteSQLFile(&b, stmts, SQLFileOptions{Header: header, SectionMarkers: true}))
+	assert.Equal(t, "-- Schema generated 2025-03-04 05:06:07\n"+
+		"-- Tool version: v1.2.3\n"+
+		"-- Source: mysql\n"+
+		"-- Dialect: google_standard_sql\n"+
+		"-- Session: smt-job-1234\n"+
+		"\n-- Table Singers\n"+
+		"CREATE TABLE `Singers` (\n\t`SingerId` INT64 NOT NULL ,\n) PRIMARY KEY (`SingerId`);\n"+
+		"\n-- Index SingersByName\n"+
+		"CREATE UNIQUE NULL_FILTERED INDEX IF NOT EXISTS `SingersByName` ON `Singers` (`Name`);\n"+
+		"\n-- Alter table Albums\n"+
+		"ALTER TABLE `Albums` ADD CONSTRAINT `fk` FOREIGN KEY (`SingerId`) REFERENCES `Singers` (`SingerId`);\n"+
+		"\n-- Proto bundle\n"+
+		"CREATE PROTO BUNDLE (\n\tmusic.Genre);\n", b.String())
+
+	// Without options, only the terminated statements are written.
+	b.Reset()
+	assert.Nil(t, WriteSQLFile(&b, stmts[:1], SQLFileOptions{}))
+	assert.Equal(t, "\nCREATE TABLE `Singers` (\n\t`SingerId` INT64 NOT NULL ,\n) PRIMARY KEY (`SingerId`);\n", b.String())
+
+	b.Reset()
+	assert.Nil(t, WriteSQLFile(&b, nil, SQLFileOptions{Header: SQLFileHeader{Source: constants.POSTGRES}}))
+	assert.Equal(t, "-- Source: postgres\n\n-- Schema is empty -- no tables found\n", b.String())
+}
+
+func TestSectionMarker(t *testing.T) {
+	tests := []struct {
+		stmt     string
+		expected string
+	}{
+		{stmt: "--\n-- Singers table\n--\nCREATE TABLE singers (\n\tid INT8,\n\tPRIMARY KEY (id)\n)", expected: "Table singers"},
+		{stmt: `CREATE TABLE IF NOT EXISTS "Singers" (id INT8)`, expected: "Table Singers"},
+		{stmt: "CREATE OR REPLACE VIEW `V` SQL SECURITY INVOKER AS SELECT 1", expected: "View V"},
+		{stmt: "CREATE CHANGE STREAM `cs` FOR ALL", expected: "Change stream cs"},
+		{stmt: "CREATE SEARCH INDEX `si` ON `t`(`tokens`)", expected: "Index si"},
+		{stmt: "ALTER DATABASE `db` SET OPTIONS (default_sequence_kind = 'bit_reversed_positive')", expected: "Alter database db"},
+		{stmt: "GRANT SELECT ON TABLE t TO ROLE r", expected: ""},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, sectionMarker(tc.stmt), tc.stmt)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Spanner schema limits checked by Validate, see
+// https://cloud.google.com/spanner/quotas#tables.
+const (
+	MaxTablesPerDatabase  = 5000
+	MaxColumnsPerTable    = 1024
+	MaxIndexesPerDatabase = 10000
+	MaxIndexesPerTable    = 128
+	MaxKeyColumns         = 16
+	MaxKeySize            = 8192 // In bytes.
+	MaxInterleaveDepth    = 7
+)
+
+// Violation describes a schema object exceeding a Spanner limit.
+type Violation struct {
+	Limit      string // The limit exceeded, e.g. "columns per table".
+	Object     string // The table or index exceeding the limit; empty for database limits.
+	TableId    string // The table of the object; empty for database limits.
+	Value      int64
+	Max        int64
+	Suggestion string // If not empty, how to fix the violation.
+}
+
+func (v Violation) String() string {
+	var s string
+	if v.Object == "" {
+		s = fmt.Sprintf("%s: %d exceeds the limit of %d", v.Limit, v.Value, v.Max)
+	} else {
+		s = fmt.Sprintf("%s of %s: %d exceeds the limit of %d", v.Limit, v.Object, v.Value, v.Max)
+	}
+	if v.Suggestion != "" {
+		s += "; " + v.Suggestion
+	}
+	return s
+}
+
+// Validate checks the schema against the Spanner limits that make Spanner
+// reject the DDL creating it and returns the violations found, ordered by
+// table name. Key sizes are computed from the maximum size of the key
+// columns, counting one byte per STRING character; columns of unbounded
+// length, e.g. STRING(MAX), are not counted. Violations of the key size,
+// key columns and interleave depth limits suggest how to fix them.
+func Validate(schema Schema) []Violation {
+	var violations []Violation
+	if n := len(schema); n > MaxTablesPerDatabase {
+		violations = append(violations, Violation{Limit: "tables per database", Value: int64(n), Max: MaxTablesPerDatabase})
+	}
+	indexCount := 0
+	for _, tableId := range sortedTableIdsByName(schema) {
+		ct := schema[tableId]
+		indexCount += len(ct.Indexes) + len(ct.SearchIndexes) + len(ct.VectorIndexes)
+		for _, v := range validateTable(schema, tableId) {
+			v.TableId = tableId
+			violations = append(violations, v)
+		}
+	}
+	if indexCount > MaxIndexesPerDatabase {
+		violations = append(violations, Violation{Limit: "indexes per database", Value: int64(indexCount), Max: MaxIndexesPerDatabase})
+	}
+	return violations
+}
+
+func validateTable(schema Schema, tableId string) []Violation {
+	var violations []Violation
+	ct := schema[tableId]
+	tableName := "table " + ct.Name
+	if len(ct.Name) > maxIdentifierLength {
+		violations = append(violations, Violation{Limit: "name length", Object: tableName, Value: int64(len(ct.Name)), Max: maxIdentifierLength})
+	}
+	for _, colId := range ct.ColIds {
+		if name := ct.ColDefs[colId].Name; len(name) > maxIdentifierLength {
+			violations = append(violations, Violation{Limit: "name length", Object: fmt.Sprintf("column %s.%s", ct.Name, name), Value: int64(len(name)), Max: maxIdentifierLength})
+		}
+	}
+	if n := len(ct.ColIds); n > MaxColumnsPerTable {
+		violations = append(violations, Violation{Limit: "columns per table", Object: tableName, Value: int64(n), Max: MaxColumnsPerTable})
+	}
+	violations = append(violations, validateKey(tableName, ct, ct.PrimaryKeys)...)
+	if depth := interleaveDepth(schema, tableId); depth > MaxInterleaveDepth {
+		violations = append(violations, Violation{
+			Limit:      "interleave depth",
+			Object:     tableName,
+			Value:      int64(depth),
+			Max:        MaxInterleaveDepth,
+			Suggestion: fmt.Sprintf("interleave %s in a table at most %d levels deep, or replace its interleave with a foreign key", ct.Name, MaxInterleaveDepth-1),
+		})
+	}
+
+	if n := len(ct.Indexes) + len(ct.SearchIndexes) + len(ct.VectorIndexes); n > MaxIndexesPerTable {
+		violations = append(violations, Violation{Limit: "indexes per table", Object: tableName, Value: int64(n), Max: MaxIndexesPerTable})
+	}
+	for _, ci := range ct.Indexes {
+		indexName := "index " + ci.Name
+		if len(ci.Name) > maxIdentifierLength {
+			violations = append(violations, Violation{Limit: "name length", Object: indexName, Value: int64(len(ci.Name)), Max: maxIdentifierLength})
+		}
+		violations = append(violations, validateKey(indexName, ct, ci.Keys)...)
+	}
+	for _, fk := range ct.ForeignKeys {
+		if len(fk.Name) > maxIdentifierLength {
+			violations = append(violations, Violation{Limit: "name length", Object: "foreign key " + fk.Name, Value: int64(len(fk.Name)), Max: maxIdentifierLength})
+		}
+	}
+	return violations
+}
+
+func validateKey(object string, ct CreateTable, keys []IndexKey) []Violation {
+	var violations []Violation
+	if n := len(keys); n > MaxKeyColumns {
+		violations = append(violations, Violation{
+			Limit:      "key columns",
+			Object:     object,
+			Value:      int64(n),
+			Max:        MaxKeyColumns,
+			Suggestion: fmt.Sprintf("remove %d key columns, e.g. by using a synthetic key", n-MaxKeyColumns),
+		})
+	}
+	var size int64
+	for _, k := range keys {
+		size += keyColumnSize(ct.ColDefs[k.ColId].T)
+	}
+	if size > MaxKeySize {
+		violations = append(violations, Violation{
+			Limit:      "key size",
+			Object:     object,
+			Value:      size,
+			Max:        MaxKeySize,
+			Suggestion: fmt.Sprintf("shorten the STRING and BYTES key columns by %d bytes in total: %s", size-MaxKeySize, strings.Join(sizedKeyColumns(ct, keys), ", ")),
+		})
+	}
+	return violations
+}
+
+// sizedKeyColumns returns the STRING and BYTES columns of keys with a
+// declared length, e.g. "Name STRING(5000)", longest first.
+func sizedKeyColumns(ct CreateTable, keys []IndexKey) []string {
+	var colIds []string
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		ty := ct.ColDefs[k.ColId].T
+		if (ty.Name == String || ty.Name == Bytes) && ty.Len < PGMaxLength && !seen[k.ColId] {
+			seen[k.ColId] = true
+			colIds = append(colIds, k.ColId)
+		}
+	}
+	sort.SliceStable(colIds, func(i, j int) bool {
+		return ct.ColDefs[colIds[i]].T.Len > ct.ColDefs[colIds[j]].T.Len
+	})
+	var cols []string
+	for _, colId := range colIds {
+		cd := ct.ColDefs[colId]
+		cols = append(cols, fmt.Sprintf("%s %s(%d)", cd.Name, cd.T.Name, cd.T.Len))
+	}
+	return cols
+}
+
+// keyColumnSize returns the maximum size in bytes of a key column of type ty,
+// or 0 if the size is unbounded.
+func keyColumnSize(ty Type) int64 {
+	switch ty.Name {
+	case Bool:
+		return 1
+	case Date, Float32:
+		return 4
+	case Int64, Float64:
+		return 8
+	case Timestamp:
+		return 12
+	case UUID:
+		return 16
+	case Numeric:
+		return 22
+	case String, Bytes:
+		// MaxLength and PGMaxLength both denote MAX.
+		if ty.Len < PGMaxLength {
+			return ty.Len
+		}
+	}
+	return 0
+}
+
+// interleaveDepth returns the number of tables in the interleave hierarchy
+// ending with the table, including the table itself.
+func interleaveDepth(schema Schema, tableId string) int {
+	depth := 0
+	for id, seen := tableId, map[string]bool{}; id != "" && !seen[id]; id = schema[id].ParentTable.Id {
+		if _, ok := schema[id]; !ok {
+			break
+		}
+		seen[id] = true
+		depth++
+	}
+	return depth
+}
+
+func sortedTableIdsByName(schema Schema) []string {
+	var ids []string
+	for id := range schema {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return schema[ids[i]].Name < schema[ids[j]].Name
+	})
+	return ids
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func limitsTestTable(id, name string, numCols int) CreateTable {
+	ct := CreateTable{Name: name, Id: id, ColDefs: make(map[string]ColumnDef)}
+	for i := 1; i <= numCols; i++ {
+		colId := fmt.Sprintf("%s_c%d", id, i)
+		ct.ColIds = append(ct.ColIds, colId)
+		ct.ColDefs[colId] = ColumnDef{Name: fmt.Sprintf("col%d", i), Id: colId, T: Type{Name: Int64}}
+	}
+	ct.PrimaryKeys = []IndexKey{{ColId: ct.ColIds[0], Order: 1}}
+	return ct
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   func() Schema
+		expected []Violation
+	}{
+		{
+			name:   "within limits",
+			schema: func() Schema { return Schema{"t1": limitsTestTable("t1", "t", 10)} },
+		},
+		{
+			name:     "too many columns",
+			schema:   func() Schema { return Schema{"t1": limitsTestTable("t1", "t", MaxColumnsPerTable+1)} },
+			expected: []Violation{{Limit: "columns per table", Object: "table t", TableId: "t1", Value: MaxColumnsPerTable + 1, Max: MaxColumnsPerTable}},
+		},
+		{
+			name: "too many key columns",
+			schema: func() Schema {
+				ct := limitsTestTable("t1", "t", 20)
+				ct.PrimaryKeys = nil
+				for i, colId := range ct.ColIds[:MaxKeyColumns+1] {
+					ct.PrimaryKeys = append(ct.PrimaryKeys, IndexKey{ColId: colId, Order: i + 1})
+				}
+				return Schema{"t1": ct}
+			},
+			expected: []Violation{{Limit: "key columns", Object: "table t", TableId: "t1", Value: MaxKeyColumns + 1, Max: MaxKeyColumns, Suggestion: "remove 1 key columns, e.g. by using a synthetic key"}},
+		},
+		{
+			name: "key size",
+			schema: func() Schema {
+				ct := limitsTestTable("t1", "t", 3)
+				ct.ColDefs["t1_c2"] = ColumnDef{Name: "col2", Id: "t1_c2", T: Type{Name: String, Len: 8000}}
+				ct.ColDefs["t1_c3"] = ColumnDef{Name: "col3", Id: "t1_c3", T: Type{Name: String, Len: MaxLength}}
+				ct.Indexes = []CreateIndex{
+					{Name: "idx_fits", TableId: "t1", Keys: []IndexKey{{ColId: "t1_c2", Order: 1}, {ColId: "t1_c3", Order: 2}}},
+					{Name: "idx_too_large", TableId: "t1", Keys: []IndexKey{{ColId: "t1_c2", Order: 1}, {ColId: "t1_c2", Order: 2}}},
+				}
+				return Schema{"t1": ct}
+			},
+			expected: []Violation{{Limit: "key size", Object: "index idx_too_large", TableId: "t1", Value: 16000, Max: MaxKeySize, Suggestion: "shorten the STRING and BYTES key columns by 7808 bytes in total: col2 STRING(8000)"}},
+		},
+		{
+			name: "too many indexes",
+			schema: func() Schema {
+				ct := limitsTestTable("t1", "t", 2)
+				for i := 0; i <= MaxIndexesPerTable; i++ {
+					ct.Indexes = append(ct.Indexes, CreateIndex{Name: fmt.Sprintf("idx%d", i), TableId: "t1", Keys: []IndexKey{{ColId: "t1_c2", Order: 1}}})
+				}
+				return Schema{"t1": ct}
+			},
+			expected: []Violation{{Limit: "indexes per table", Object: "table t", TableId: "t1", Value: MaxIndexesPerTable + 1, Max: MaxIndexesPerTable}},
+		},
+		{
+			name: "interleave depth",
+			schema: func() Schema {
+				s := Schema{}
+				for i := 1; i <= MaxInterleaveDepth+1; i++ {
+					ct := limitsTestTable(fmt.Sprintf("t%d", i), fmt.Sprintf("level%d", i), 1)
+					if i > 1 {
+						ct.ParentTable = InterleavedParent{Id: fmt.Sprintf("t%d", i-1), InterleaveType: "IN PARENT"}
+					}
+					s[ct.Id] = ct
+				}
+				return s
+			},
+			expected: []Violation{{Limit: "interleave depth", Object: "table level8", TableId: "t8", Value: MaxInterleaveDepth + 1, Max: MaxInterleaveDepth, Suggestion: "interleave level8 in a table at most 6 levels deep, or replace its interleave with a foreign key"}},
+		},
+		{
+			name: "name length",
+			schema: func() Schema {
+				ct := limitsTestTable("t1", strings.Repeat("t", 129), 1)
+				ct.ForeignKeys = []Foreignkey{{Name: strings.Repeat("f", 130), ColIds: []string{"t1_c1"}, ReferTableId: "t1", ReferColumnIds: []string{"t1_c1"}}}
+				return Schema{"t1": ct}
+			},
+			expected: []Violation{
+				{Limit: "name length", Object: "table " + strings.Repeat("t", 129), TableId: "t1", Value: 129, Max: 128},
+				{Limit: "name length", Object: "foreign key " + strings.Repeat("f", 130), TableId: "t1", Value: 130, Max: 128},
+			},
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, Validate(tc.schema()), tc.name)
+	}
+}
+
+func TestViolationString(t *testing.T) {
+	assert.Equal(t, "tables per database: 5001 exceeds the limit of 5000", Violation{Limit: "tables per database", Value: 5001, Max: MaxTablesPerDatabase}.String())
+	assert.Equal(t, "columns per table of table t: 1025 exceeds the limit of 1024", Violation{Limit: "columns per table", Object: "table t", Value: 1025, Max: MaxColumnsPerTable}.String())
+	assert.Equal(t, "key columns of table t: 17 exceeds the limit of 16; remove 1 key columns, e.g. by using a synthetic key", Violation{Limit: "key columns", Object: "table t", Value: 17, Max: MaxKeyColumns, Suggestion: "remove 1 key columns, e.g. by using a synthetic key"}.String())
+}
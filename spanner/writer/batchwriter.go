@@ -50,22 +50,34 @@ const (
 // in a batch is bad.  BatchWriter respects Spanner's limits on byte size
 // and mutation count and has configurable limits on the number of
 // in-progress writes, amount of data buffered and retry behavior.
-// BatchWriter is not threadsafe: only one call to AddRow or Flush should
-// be active at any time.  See ExampleBatchWriter (batchwriter_test.go)
+// Rows are buffered and written table by table, so that the rows of
+// different tables can be added and flushed concurrently: only one call
+// to AddRow or FlushTable for a given table should be active at any time,
+// and none while Flush is. The limits on in-progress writes and buffered
+// bytes are shared by all tables.  See ExampleBatchWriter (batchwriter_test.go)
 // for sample usage code.
 type BatchWriter struct {
-	rows       []*row                     // Buffered rows.
-	rBytes     int64                      // Estimate of bytes for buffered rows.
-	rCount     int64                      // Mutation count for buffered rows.
+	lock       sync.Mutex                 // Protects tables.
+	tables     map[string]*tableBatch     // Buffered rows and in-progress writes, by table.
 	write      func([]*sp.Mutation) error // Typically a closure that calls client.Apply, but structured this way for testing.
-	wg         sync.WaitGroup             // Tracks in-progress writes.
-	writeLimit int64                      // Limit on number of in-progress writes.
-	bytesLimit int64                      // Limit on bytes buffered. AddRow blocks if rBytes exceeded this value.
+	writeLimit int64                      // Limit on number of in-progress writes of all tables.
+	bytesLimit int64                      // Limit on bytes buffered for all tables. AddRow blocks if rBytes exceeded this value.
+	writes     int64                      // Number of in-progress writes of all tables; access using atomic.
+	rBytes     int64                      // Estimate of bytes for buffered rows of all tables; access using atomic.
 	retryLimit int64                      // Limit on retries.
 	verbose    bool                       // If true, print out messages about each write batch.
 	async      asyncState
 }
 
+// tableBatch holds the rows of a table buffered by BatchWriter, and tracks
+// their in-progress writes.
+type tableBatch struct {
+	rows   []*row         // Buffered rows.
+	rBytes int64          // Estimate of bytes for buffered rows.
+	rCount int64          // Mutation count for buffered rows.
+	wg     sync.WaitGroup // Tracks in-progress writes.
+}
+
 type row struct {
 	table string
 	cols  []string
@@ -80,7 +92,6 @@ type row struct {
 // an error, or because it was part of a batch that generate errors and we'd
 // exhausted our retry budget and didn't split the batch and try again).
 type asyncState struct {
-	writes             int64            // Number of in-progress writes; access using atomic.
 	retries            int64            // Number of retries; access using atomic.
 	lock               sync.Mutex       // Protects errors and badRows
 	errors             map[string]int64 // Errors encountered; protected by lock.
@@ -91,8 +102,8 @@ type asyncState struct {
 
 // BatchWriterConfig specifies parameters for configuring BatchWriter.
 type BatchWriterConfig struct {
-	WriteLimit int64                      // Limit on number of in-progress writes.
-	BytesLimit int64                      // Limit on bytes buffered.
+	WriteLimit int64                      // Limit on number of in-progress writes of all tables.
+	BytesLimit int64                      // Limit on bytes buffered for all tables.
 	RetryLimit int64                      // Limit on retries.
 	Write      func([]*sp.Mutation) error // Function to call to write to Spanner (typically a closure that calls client.Apply).
 	Verbose    bool                       // If true, print out messages about each write batch.
@@ -101,6 +112,7 @@ type BatchWriterConfig struct {
 // NewBatchWriter returns a new BatchWriter with parameters defined by config.
 func NewBatchWriter(config BatchWriterConfig) *BatchWriter {
 	return &BatchWriter{
+		tables:     make(map[string]*tableBatch),
 		write:      config.Write,
 		writeLimit: config.WriteLimit,
 		bytesLimit: config.BytesLimit,
@@ -119,31 +131,65 @@ func NewBatchWriter(config BatchWriterConfig) *BatchWriter {
 // complete) and then initiate writes.
 func (bw *BatchWriter) AddRow(table string, cols []string, vals []interface{}) {
 	r := &row{table, cols, vals}
-	bw.rows = append(bw.rows, r)
-	bw.rBytes += byteSize(r)
-	bw.rCount += int64(len(r.cols))
-	bw.writeData()
+	tb := bw.tableBatch(table)
+	n := byteSize(r)
+	tb.rows = append(tb.rows, r)
+	tb.rBytes += n
+	atomic.AddInt64(&bw.rBytes, n)
+	tb.rCount += int64(len(r.cols))
+	bw.writeData(tb)
 }
 
 // Flush initiates writes to Spanner of all buffered rows of data, and waits
 // for them to complete.
 func (bw *BatchWriter) Flush() {
-	for len(bw.rows) > 0 {
-		if atomic.LoadInt64(&bw.async.writes) < bw.writeLimit {
-			m, count, bytes := bw.getBatch()
+	bw.lock.Lock()
+	var tables []*tableBatch
+	for _, tb := range bw.tables {
+		tables = append(tables, tb)
+	}
+	bw.lock.Unlock()
+	for _, tb := range tables {
+		bw.flush(tb)
+	}
+}
+
+// FlushTable initiates writes to Spanner of the buffered rows of table, and
+// waits for them to complete. Rows of other tables can be added, and
+// flushed, meanwhile.
+func (bw *BatchWriter) FlushTable(table string) {
+	bw.flush(bw.tableBatch(table))
+}
+
+// tableBatch returns the buffered rows and in-progress writes of table.
+func (bw *BatchWriter) tableBatch(table string) *tableBatch {
+	bw.lock.Lock()
+	defer bw.lock.Unlock()
+	tb, ok := bw.tables[table]
+	if !ok {
+		tb = &tableBatch{}
+		bw.tables[table] = tb
+	}
+	return tb
+}
+
+func (bw *BatchWriter) flush(tb *tableBatch) {
+	for len(tb.rows) > 0 {
+		if bw.reserveWrite() {
+			m, count, bytes := bw.getBatch(tb)
 			if bw.verbose {
 				fmt.Printf("Starting write of %d rows to Spanner (%d bytes, %d mutations) [%d in progress]\n",
-					len(m), bytes, count, atomic.LoadInt64(&bw.async.writes))
+					len(m), bytes, count, atomic.LoadInt64(&bw.writes))
 			}
 			logger.Log.Debug(fmt.Sprintf("Starting write of %d rows to Spanner (%d bytes, %d mutations) [%d in progress]\n",
-				len(m), bytes, count, atomic.LoadInt64(&bw.async.writes)))
+				len(m), bytes, count, atomic.LoadInt64(&bw.writes)))
 
-			bw.startWrite(m)
+			bw.startWrite(tb, m)
 		} else {
 			time.Sleep(10 * time.Millisecond)
 		}
 	}
-	bw.wg.Wait()
+	tb.wg.Wait()
 }
 
 // DroppedRowsByTable returns a map of tables to counts of dropped rows.
@@ -195,29 +241,29 @@ func (bw *BatchWriter) getBadRowsForTest() []*row {
 	return bw.async.sampleBadRows
 }
 
-// getBatch returns a slice of data from the front of bw.rows.  The slice
+// getBatch returns a slice of data from the front of tb.rows.  The slice
 // returned is the largest one not exceeding countThreshold and byteThreshold.
-func (bw *BatchWriter) getBatch() (rows []*row, count int64, bytes int64) {
-	for i := range bw.rows {
-		c := count + int64(len(bw.rows[i].cols))
-		b := bytes + byteSize(bw.rows[i])
+func (tb *tableBatch) getBatch() (rows []*row, count int64, bytes int64) {
+	for i := range tb.rows {
+		c := count + int64(len(tb.rows[i].cols))
+		b := bytes + byteSize(tb.rows[i])
 		// If next row puts us over the thresholds, then stop. But make sure
 		// we have at least one row. If a single row puts us over the
 		// thresholds, there's not much we can do: we just try sending it to Spanner
 		// (it might succeed, since our thresholds are conservative).
 		if (c >= countThreshold || b >= byteThreshold) && len(rows) >= 1 {
-			bw.rCount -= count
-			bw.rBytes -= bytes
-			bw.rows = bw.rows[i:]
+			tb.rCount -= count
+			tb.rBytes -= bytes
+			tb.rows = tb.rows[i:]
 			return rows, count, bytes
 		}
 		count = c
 		bytes = b
-		rows = append(rows, bw.rows[i])
+		rows = append(rows, tb.rows[i])
 	}
-	bw.rCount = 0
-	bw.rBytes = 0
-	bw.rows = nil
+	tb.rCount = 0
+	tb.rBytes = 0
+	tb.rows = nil
 	return rows, count, bytes
 }
 
@@ -291,36 +337,59 @@ func (bw *BatchWriter) doWriteAndHandleErrors(rows []*row) {
 
 // Note: backgroundWrite must be thread-safe because it is run as
 // a go routine.
-func (bw *BatchWriter) backgroundWrite(rows []*row) {
-	defer bw.wg.Done()
-	defer atomic.AddInt64(&bw.async.writes, -1)
+func (bw *BatchWriter) backgroundWrite(tb *tableBatch, rows []*row) {
+	defer tb.wg.Done()
+	defer atomic.AddInt64(&bw.writes, -1)
 	bw.doWriteAndHandleErrors(rows)
 }
 
-// startWrite initiates an asynchronous write of rows to Spanner.
-func (bw *BatchWriter) startWrite(rows []*row) {
-	bw.wg.Add(1)
-	atomic.AddInt64(&bw.async.writes, 1)
-	go bw.backgroundWrite(rows)
+// reserveWrite reserves one of the writeLimit in-progress writes shared by
+// all tables, and returns false if they are all in progress.
+func (bw *BatchWriter) reserveWrite() bool {
+	for {
+		n := atomic.LoadInt64(&bw.writes)
+		if n >= bw.writeLimit {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&bw.writes, n, n+1) {
+			return true
+		}
+	}
+}
+
+// startWrite initiates an asynchronous write of rows of tb to Spanner, using
+// a write reserved with reserveWrite.
+func (bw *BatchWriter) startWrite(tb *tableBatch, rows []*row) {
+	tb.wg.Add(1)
+	go bw.backgroundWrite(tb, rows)
 }
 
-// writeData initiates writes to Spanner until either:
+// getBatch takes the next batch of rows of tb to write, see
+// tableBatch.getBatch, and releases their bytes from the buffered bytes of
+// all tables.
+func (bw *BatchWriter) getBatch(tb *tableBatch) (rows []*row, count int64, bytes int64) {
+	rows, count, bytes = tb.getBatch()
+	atomic.AddInt64(&bw.rBytes, -bytes)
+	return rows, count, bytes
+}
+
+// writeData initiates writes to Spanner of the rows of tb until either:
 // a) we have less than a 'batch' to write, or
 // b) we've hit writeLimit and we're under bytesLimit.
 // It will block and re-try till either (a) or (b) holds.
-func (bw *BatchWriter) writeData() {
-	for bw.rCount > countThreshold || bw.rBytes > byteThreshold {
-		if atomic.LoadInt64(&bw.async.writes) < bw.writeLimit {
-			m, count, bytes := bw.getBatch()
+func (bw *BatchWriter) writeData(tb *tableBatch) {
+	for tb.rCount > countThreshold || tb.rBytes > byteThreshold {
+		if bw.reserveWrite() {
+			m, count, bytes := bw.getBatch(tb)
 			if bw.verbose {
 				fmt.Printf("Starting write of %d rows to Spanner (%d bytes, %d mutations) [%d in progress]\n",
-					len(m), bytes, count, atomic.LoadInt64(&bw.async.writes))
+					len(m), bytes, count, atomic.LoadInt64(&bw.writes))
 			}
 			logger.Log.Debug(fmt.Sprintf("Starting write of %d rows to Spanner (%d bytes, %d mutations) [%d in progress]\n",
-				len(m), bytes, count, atomic.LoadInt64(&bw.async.writes)))
-			bw.startWrite(m)
+				len(m), bytes, count, atomic.LoadInt64(&bw.writes)))
+			bw.startWrite(tb, m)
 		} else {
-			if bw.rBytes < bw.bytesLimit {
+			if atomic.LoadInt64(&bw.rBytes) < bw.bytesLimit {
 				return
 			}
 			time.Sleep(10 * time.Millisecond)
@@ -372,5 +441,8 @@ func GetBatchWriterWithConfig(ctx context.Context, spannerClient spannerclient.S
 	conv.DataFlush = func() {
 		batchWriter.Flush()
 	}
+	conv.DataFlushTable = func(table string) {
+		batchWriter.FlushTable(table)
+	}
 	return batchWriter
 }
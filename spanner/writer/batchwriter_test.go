@@ -102,6 +102,53 @@ func TestFlush(t *testing.T) {
 	}
 }
 
+// TestFlushTable tests that the rows of different tables can be added and
+// flushed concurrently, with writeLimit in-progress writes shared by the
+// tables.
+func TestFlushTable(t *testing.T) {
+	mutex := &sync.Mutex{}
+	var writeCount int64
+	rowsWritten := make(map[string]int)
+	config := BatchWriterConfig{
+		BytesLimit: 100 << 20,
+		WriteLimit: 5,
+		RetryLimit: 1000,
+	}
+	config.Write = func(m []*sp.Mutation) error {
+		table := "t1"
+		if strings.Contains(fmt.Sprintf("%+v", m[0]), "t2_id") {
+			table = "t2"
+		}
+		mutex.Lock()
+		writeCount++
+		assert.LessOrEqual(t, writeCount, config.WriteLimit, "Too many pending writes")
+		rowsWritten[table] += len(m)
+		mutex.Unlock()
+		time.Sleep(20 * time.Millisecond) // Mimic a Spanner write.
+		mutex.Lock()
+		writeCount--
+		mutex.Unlock()
+		return nil
+	}
+	bw := NewBatchWriter(config)
+	var wg sync.WaitGroup
+	for _, table := range []string{"t1", "t2"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50000; i++ {
+				bw.AddRow(table, []string{table + "_id", "b"}, []interface{}{i, "x"})
+			}
+			bw.FlushTable(table)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2, len(rowsWritten))
+	for _, n := range rowsWritten {
+		assert.Equal(t, 50000, n)
+	}
+}
+
 func TestDroppedRowsByTable(t *testing.T) {
 	bw := NewBatchWriter(BatchWriterConfig{})
 	bw.async.lock.Lock()
@@ -86,7 +86,10 @@ export enum TargetDetails {
   ReplicationSlot = 'replicationSlot',
   Publication = 'publication',
   GcsMetadataName = 'gcsName',
-  GcsMetadataRootPath = 'gcsRootPath'
+  GcsMetadataRootPath = 'gcsRootPath',
+  VersionRetentionPeriod = 'versionRetentionPeriod',
+  DefaultLeader = 'defaultLeader',
+  DefaultSequenceKind = 'defaultSequenceKind'
 }
 
 export const Profile = {
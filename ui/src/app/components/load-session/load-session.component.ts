@@ -30,6 +30,7 @@ export class LoadSessionComponent implements OnInit {
     { value: 'oracle', displayName: 'Oracle' },
     { value: 'postgres', displayName: 'PostgreSQL' },
     { value: 'cassandra', displayName: 'Cassandra' },
+    { value: 'dynamodb', displayName: 'DynamoDB' },
   ]
   fileToUpload: File | null = null
   uploadStart: boolean = false
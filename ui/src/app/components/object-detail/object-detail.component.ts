@@ -15,8 +15,11 @@ import IConv, {
   ICheckConstraints,
   ICreateIndex,
   IForeignKey,
+  IEntity,
   IIndexKey,
   IPrimaryKey,
+  ISingleTableDesign,
+  IUserDefinedType,
 } from 'src/app/model/conv'
 import { ConversionService } from 'src/app/services/conversion/conversion.service'
 import { DropObjectDetailDialogComponent } from '../drop-object-detail-dialog/drop-object-detail-dialog.component'
@@ -70,6 +73,7 @@ export class ObjectDetailComponent implements OnInit {
   localTableData: IColumnTabData[] = []
   localIndexData: IIndexData[] = []
   localSequenceData: ISequenceData = {}
+  singleTableEntities: Record<string, IEntity[]> = {}
   isMiddleColumnCollapse: boolean = false
   isPostgreSQLDialect: boolean = false
   processedAutoGenMap: GroupedAutoGens = {};
@@ -1816,6 +1820,118 @@ export class ObjectDetailComponent implements OnInit {
     this.currentObject = null
   }
 
+  isSourceNameChanged(): boolean {
+    let tableId = this.currentObject!.id
+    return (
+      this.conv.SrcSchema[tableId] !== undefined &&
+      this.conv.SrcSchema[tableId].Name.toLowerCase() !== this.conv.SpSchema[tableId]?.Name.toLowerCase()
+    )
+  }
+
+  isSourceNameKeptAsSynonym(): boolean {
+    return !!this.conv.SpSchema[this.currentObject!.id]?.Synonym
+  }
+
+  toggleSourceNameSynonym(keep: boolean) {
+    this.data
+      .updateTableSynonym(this.currentObject!.id, keep)
+      .pipe(take(1))
+      .subscribe((res: string) => {
+        if (res === '') {
+          this.data.getDdl()
+        }
+      })
+  }
+
+  getPlacementKey(): string {
+    return this.conv.SpSchema[this.currentObject!.id]?.PlacementKey || ''
+  }
+
+  getPlacementKeyCandidates(): string[] {
+    let spTable = this.conv.SpSchema[this.currentObject!.id]
+    if (!spTable || spTable.ParentTable.Id !== '') {
+      return []
+    }
+    return spTable.ColIds.filter(
+      (colId: string) => spTable.ColDefs[colId].T.Name === 'STRING' && !spTable.ColDefs[colId].T.IsArray
+    )
+  }
+
+  updatePlacementKey(colId: string) {
+    this.data
+      .updatePlacementKey(this.currentObject!.id, colId)
+      .pipe(take(1))
+      .subscribe((res: string) => {
+        if (res === '') {
+          this.data.getDdl()
+        }
+      })
+  }
+
+  getSingleTableDesign(): ISingleTableDesign | undefined {
+    return this.conv.SingleTableDesigns?.[this.currentObject!.id]
+  }
+
+  // Entity types of the single-table design of the table, as edited by the
+  // user before splitting the table.
+  getSingleTableEntities(): IEntity[] {
+    let tableId = this.currentObject!.id
+    if (!this.singleTableEntities[tableId]) {
+      this.singleTableEntities[tableId] = this.getSingleTableDesign()!.Entities.map((e: IEntity) => ({ ...e }))
+    }
+    return this.singleTableEntities[tableId]
+  }
+
+  splitSingleTable() {
+    let tableId = this.currentObject!.id
+    let design: ISingleTableDesign = { ...this.getSingleTableDesign()!, Entities: this.getSingleTableEntities() }
+    this.data
+      .splitSingleTable(design)
+      .pipe(take(1))
+      .subscribe((res: string) => {
+        if (res === '') {
+          delete this.singleTableEntities[tableId]
+          this.data.getDdl()
+        }
+      })
+  }
+
+  // User-defined types used by the columns of the source table, including
+  // the types nested in them.
+  getUserTypes(): IUserDefinedType[] {
+    let userTypes = this.conv.UserDefinedTypes
+    let srcTable = this.conv.SrcSchema[this.currentObject!.id]
+    if (!userTypes || !srcTable) {
+      return []
+    }
+    let used: Record<string, IUserDefinedType> = {}
+    let addTypes = (cqlType: string) => {
+      for (let name of cqlType.split(/[<>,\s"]+/)) {
+        let userType = userTypes![name]
+        if (userType && !used[name]) {
+          used[name] = userType
+          userType.FieldTypes.forEach(addTypes)
+        }
+      }
+    }
+    for (let colId of srcTable.ColIds) {
+      let col = srcTable.ColDefs[colId]
+      addTypes(col.UserTypeField ? col.UserTypeField.ColumnType : col.Type.Name)
+    }
+    return Object.values(used).sort((a, b) => a.Name.localeCompare(b.Name))
+  }
+
+  setUserTypeStrategy(name: string, strategy: string) {
+    this.data
+      .setUserTypeStrategy(name, strategy)
+      .pipe(take(1))
+      .subscribe((res: string) => {
+        if (res === '') {
+          this.data.getDdl()
+        }
+      })
+  }
+
   dropTable() {
     let openDialog = this.dialog.open(DropObjectDetailDialogComponent, {
       width: '35vw',
@@ -129,6 +129,9 @@ export class PrepareMigrationComponent implements OnInit {
       GcsBucketName: localStorage.getItem(TargetDetails.GcsMetadataName) as string,
       GcsBucketRootPath: localStorage.getItem(TargetDetails.GcsMetadataRootPath) || '',
     },
+    VersionRetentionPeriod: localStorage.getItem(TargetDetails.VersionRetentionPeriod) || '',
+    DefaultLeader: localStorage.getItem(TargetDetails.DefaultLeader) || '',
+    DefaultSequenceKind: localStorage.getItem(TargetDetails.DefaultSequenceKind) || '',
   }
 
   datastreamConfig: IDatastreamConfig = {
@@ -472,6 +475,9 @@ export class PrepareMigrationComponent implements OnInit {
           GcsBucketName: localStorage.getItem(TargetDetails.GcsMetadataName) as string,
           GcsBucketRootPath: localStorage.getItem(TargetDetails.GcsMetadataRootPath) as string,
         },
+        VersionRetentionPeriod: localStorage.getItem(TargetDetails.VersionRetentionPeriod) || '',
+        DefaultLeader: localStorage.getItem(TargetDetails.DefaultLeader) || '',
+        DefaultSequenceKind: localStorage.getItem(TargetDetails.DefaultSequenceKind) || '',
       }
       this.isSourceConnectionProfileSet =
         (localStorage.getItem(MigrationDetails.IsSourceConnectionProfileSet) as string) === 'true'
@@ -511,6 +517,9 @@ export class PrepareMigrationComponent implements OnInit {
           GcsBucketName: localStorage.getItem(TargetDetails.GcsMetadataName) as string,
           GcsBucketRootPath: localStorage.getItem(TargetDetails.GcsMetadataRootPath) as string,
         },
+        VersionRetentionPeriod: localStorage.getItem(TargetDetails.VersionRetentionPeriod) || '',
+        DefaultLeader: localStorage.getItem(TargetDetails.DefaultLeader) || '',
+        DefaultSequenceKind: localStorage.getItem(TargetDetails.DefaultSequenceKind) || '',
       }
       if (localStorage.getItem(MigrationDetails.NumberOfShards) != null) {
         this.numberOfShards = localStorage.getItem(MigrationDetails.NumberOfShards) as string
@@ -712,6 +721,9 @@ export class PrepareMigrationComponent implements OnInit {
           GcsBucketName: localStorage.getItem(TargetDetails.GcsMetadataName) as string,
           GcsBucketRootPath: localStorage.getItem(TargetDetails.GcsMetadataRootPath) as string,
         },
+        VersionRetentionPeriod: localStorage.getItem(TargetDetails.VersionRetentionPeriod) || '',
+        DefaultLeader: localStorage.getItem(TargetDetails.DefaultLeader) || '',
+        DefaultSequenceKind: localStorage.getItem(TargetDetails.DefaultSequenceKind) || '',
       }
       this.isTargetDetailSet =
         (localStorage.getItem(MigrationDetails.IsTargetDetailSet) as string) === 'true'
@@ -756,6 +768,9 @@ export class PrepareMigrationComponent implements OnInit {
           GcsBucketName: localStorage.getItem(TargetDetails.GcsMetadataName) as string,
           GcsBucketRootPath: localStorage.getItem(TargetDetails.GcsMetadataRootPath) as string,
         },
+        VersionRetentionPeriod: localStorage.getItem(TargetDetails.VersionRetentionPeriod) || '',
+        DefaultLeader: localStorage.getItem(TargetDetails.DefaultLeader) || '',
+        DefaultSequenceKind: localStorage.getItem(TargetDetails.DefaultSequenceKind) || '',
       }
     })
   }
@@ -24,9 +24,15 @@ export class TargetDetailsFormComponent implements OnInit {
     this.dialect = data.Dialect
     this.targetDetailsForm = this.formBuilder.group({
       targetDb: ['', [Validators.required,Validators.pattern('^[a-z][a-z0-9-_]{0,28}[a-z0-9]$')]],
+      versionRetentionPeriod: ['', [Validators.pattern('^[0-9]+[smhd]$')]],
+      defaultLeader: [''],
+      defaultSequenceKind: [''],
     })
     this.targetDetailsForm.setValue({
       targetDb: localStorage.getItem(TargetDetails.TargetDB),
+      versionRetentionPeriod: localStorage.getItem(TargetDetails.VersionRetentionPeriod) ?? '',
+      defaultLeader: localStorage.getItem(TargetDetails.DefaultLeader) ?? '',
+      defaultSequenceKind: localStorage.getItem(TargetDetails.DefaultSequenceKind) ?? '',
     })
   }
 
@@ -37,6 +43,9 @@ export class TargetDetailsFormComponent implements OnInit {
     let formValue = this.targetDetailsForm.value
     localStorage.setItem(TargetDetails.TargetDB, formValue.targetDb)
     localStorage.setItem(TargetDetails.Dialect, formValue.dialect)
+    localStorage.setItem(TargetDetails.VersionRetentionPeriod, formValue.versionRetentionPeriod)
+    localStorage.setItem(TargetDetails.DefaultLeader, formValue.defaultLeader)
+    localStorage.setItem(TargetDetails.DefaultSequenceKind, formValue.defaultSequenceKind)
     localStorage.setItem(MigrationDetails.IsTargetDetailSet, "true")
     this.dialogRef.close()
   }
@@ -22,6 +22,8 @@ export default interface IConv {
   IsSharded: boolean
   SpSequences: Record<string, ICreateSequence>
   SrcSequences: Record<string, ICreateSequence>
+  SingleTableDesigns?: Record<string, ISingleTableDesign>
+  UserDefinedTypes?: Record<string, IUserDefinedType>
 }
 
 export interface IDefaultValue {
@@ -74,6 +76,22 @@ export interface IColumn {
   Id: string
   AutoGen: AutoGen
   DefaultValue: IDefaultValue
+  UserTypeField?: IUserTypeField
+}
+
+// Field of a column of a user-defined type, e.g. a Cassandra UDT, held by a
+// column of its own when the type is flattened.
+export interface IUserTypeField {
+  Column: string
+  ColumnType: string
+  Path: string[]
+}
+
+export interface IUserDefinedType {
+  Name: string
+  FieldNames: string[]
+  FieldTypes: string[]
+  Flatten: boolean
 }
 
 export interface IIgnored {
@@ -98,6 +116,23 @@ export interface IIndex {
   Id: string
 }
 
+// Single-table design detected for a source table, e.g. a DynamoDB table
+// holding items of several entity types.
+export interface ISingleTableDesign {
+  TableId: string
+  Attribute: string
+  KeyPrefix: boolean
+  Entities: IEntity[]
+}
+
+export interface IEntity {
+  Name: string
+  TableName: string
+  Items: number
+  ColIds: string[]
+  Parent: string
+}
+
 export interface IInterleavedParent{
   Id: string
   OnDelete: string
@@ -115,6 +150,8 @@ export interface ICreateTable {
   CheckConstraints: ICheckConstraints[]
   Indexes: ICreateIndex[]
   ParentTable: IInterleavedParent
+  Synonym?: string
+  PlacementKey?: string
   Comment: string
   Id: string
 }
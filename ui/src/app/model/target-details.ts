@@ -5,6 +5,9 @@ export default interface ITargetDetails {
     ReplicationSlot: string
     Publication: string
     GcsMetadataPath: GcsMetadataPath
+    VersionRetentionPeriod?: string
+    DefaultLeader?: string
+    DefaultSequenceKind?: string
 }
 
 export interface GcsMetadataPath {
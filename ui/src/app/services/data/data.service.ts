@@ -1,6 +1,6 @@
 import { Injectable } from '@angular/core'
 import { FetchService } from '../fetch/fetch.service'
-import IConv, { ICheckConstraints, ICreateIndex, IForeignKey, IInterleaveStatus, IPrimaryKey } from '../../model/conv'
+import IConv, { ICheckConstraints, ICreateIndex, IForeignKey, IInterleaveStatus, IPrimaryKey, ISingleTableDesign } from '../../model/conv'
 import IRule from 'src/app/model/rule'
 import { BehaviorSubject, forkJoin, Observable, of, Subject } from 'rxjs'
 import { catchError, filter, map, tap } from 'rxjs/operators'
@@ -361,6 +361,78 @@ export class DataService {
     )
   }
 
+  updateTableSynonym(tableId: string, keep: boolean): Observable<string> {
+    return this.fetch.updateTableSynonym(tableId, keep).pipe(
+      catchError((e: any) => {
+        return of({ error: e.error })
+      }),
+      tap(console.log),
+      map((data) => {
+        if (data.error) {
+          this.snackbar.openSnackBar(data.error, 'Close')
+          return data.error
+        } else {
+          this.convSubject.next(data)
+          return ''
+        }
+      })
+    )
+  }
+
+  updatePlacementKey(tableId: string, colId: string): Observable<string> {
+    return this.fetch.updatePlacementKey(tableId, colId).pipe(
+      catchError((e: any) => {
+        return of({ error: e.error })
+      }),
+      tap(console.log),
+      map((data) => {
+        if (data.error) {
+          this.snackbar.openSnackBar(data.error, 'Close')
+          return data.error
+        } else {
+          this.convSubject.next(data)
+          return ''
+        }
+      })
+    )
+  }
+
+  splitSingleTable(design: ISingleTableDesign): Observable<string> {
+    return this.fetch.splitSingleTable(design).pipe(
+      catchError((e: any) => {
+        return of({ error: e.error })
+      }),
+      tap(console.log),
+      map((data) => {
+        if (data.error) {
+          this.snackbar.openSnackBar(data.error, 'Close')
+          return data.error
+        } else {
+          this.convSubject.next(data)
+          return ''
+        }
+      })
+    )
+  }
+
+  setUserTypeStrategy(name: string, strategy: string): Observable<string> {
+    return this.fetch.setUserTypeStrategy(name, strategy).pipe(
+      catchError((e: any) => {
+        return of({ error: e.error })
+      }),
+      tap(console.log),
+      map((data) => {
+        if (data.error) {
+          this.snackbar.openSnackBar(data.error, 'Close')
+          return data.error
+        } else {
+          this.convSubject.next(data)
+          return ''
+        }
+      })
+    )
+  }
+
   dropTables(tables: ITables): Observable<string> {
     return this.fetch.dropTables(tables).pipe(
       catchError((e: any) => {
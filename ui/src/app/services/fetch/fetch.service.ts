@@ -10,6 +10,7 @@ import IConv, {
   IInterleaveStatus,
   IPrimaryKey,
   ISessionSummary,
+  ISingleTableDesign,
   ITableIdAndName,
 } from '../../model/conv'
 import IDumpConfig, { IConvertFromDumpRequest } from '../../model/dump-config'
@@ -231,6 +232,25 @@ export class FetchService {
     return this.http.post<HttpResponse<IConv>>(`${this.url}/drop/table?table=${tableId}`, {})
   }
 
+  updateTableSynonym(tableId: string, keep: boolean) {
+    return this.http.post<HttpResponse<IConv>>(`${this.url}/update/synonym?table=${tableId}&keep=${keep}`, {})
+  }
+
+  updatePlacementKey(tableId: string, colId: string) {
+    return this.http.post<HttpResponse<IConv>>(`${this.url}/update/placementKey?table=${tableId}&column=${colId}`, {})
+  }
+
+  splitSingleTable(design: ISingleTableDesign) {
+    return this.http.post<HttpResponse<IConv>>(`${this.url}/dynamodb/splitTable`, design)
+  }
+
+  setUserTypeStrategy(name: string, strategy: string) {
+    return this.http.post<HttpResponse<IConv>>(`${this.url}/cassandra/userTypeStrategy`, {
+      Name: name,
+      Strategy: strategy,
+    })
+  }
+
   dropTables(payload: ITables) {
     return this.http.post(`${this.url}/drop/tables`, payload)
   }
@@ -0,0 +1,97 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func autoIncrementTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = schema.Table{
+		Id:     "t1",
+		Name:   "orders",
+		ColIds: []string{"c1"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "bigint"}, NotNull: true, AutoGen: ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.AUTO_INCREMENT}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1", Order: 1}},
+	}
+	conv.SrcSchema["t2"] = schema.Table{Id: "t2", Name: "customers", ColIds: []string{"c2"}, ColDefs: map[string]schema.Column{"c2": {Id: "c2", Name: "id"}}}
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Name:   "orders",
+		Id:     "t1",
+		ColIds: []string{"c1"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Id: "c1", Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true, AutoGen: ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.SEQUENCE}},
+		},
+		PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+	}
+	conv.SpSchema["t2"] = ddl.CreateTable{Name: "customers", Id: "t2", ColIds: []string{"c2"}, ColDefs: map[string]ddl.ColumnDef{"c2": {Id: "c2", Name: "id", T: ddl.Type{Name: ddl.Int64}}}}
+	conv.SrcSequences["s1"] = ddl.Sequence{Id: "s1", Name: "orders_id_seq"}
+	conv.SpSequences["s1"] = ddl.Sequence{Id: "s1", Name: "orders_id_seq", ColumnsUsingSeq: map[string][]string{"t1": {"c1"}}}
+	return conv
+}
+
+func TestSetAutoIncrementPolicy(t *testing.T) {
+	defer restoreSessionState()()
+	tableHandler := api.TableAPIHandler{DDLVerifier: &expressions_api.MockDDLVerifier{}}
+	tc := []struct {
+		name       string
+		policy     api.AutoIncrementPolicy
+		statusCode int
+		autoGen    ddl.AutoGenCol
+	}{
+		{
+			name:       "Identity column",
+			policy:     api.AutoIncrementPolicy{TableId: "t1", Policy: internal.AutoIncrementPolicyIdentity},
+			statusCode: http.StatusOK,
+			autoGen:    ddl.AutoGenCol{GenerationType: constants.IDENTITY},
+		},
+		{
+			name:       "Keep as is",
+			policy:     api.AutoIncrementPolicy{TableId: "t1", Policy: internal.AutoIncrementPolicyKeep},
+			statusCode: http.StatusOK,
+			autoGen:    ddl.AutoGenCol{},
+		},
+		{
+			name:       "Invalid policy",
+			policy:     api.AutoIncrementPolicy{TableId: "t1", Policy: "serial"},
+			statusCode: http.StatusBadRequest,
+			autoGen:    ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.SEQUENCE},
+		},
+		{
+			name:       "Table without auto-increment columns",
+			policy:     api.AutoIncrementPolicy{TableId: "t2", Policy: internal.AutoIncrementPolicyUUID},
+			statusCode: http.StatusBadRequest,
+			autoGen:    ddl.AutoGenCol{Name: "orders_id_seq", GenerationType: constants.SEQUENCE},
+		},
+	}
+	for _, tc := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		sessionState.Conv = autoIncrementTestConv()
+		body, err := json.Marshal(tc.policy)
+		assert.Nil(t, err)
+		req, err := http.NewRequest("POST", "/autoIncrementPolicy", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(tableHandler.SetAutoIncrementPolicy)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tc.statusCode, rr.Code, tc.name)
+		assert.Equal(t, tc.autoGen, sessionState.Conv.SpSchema["t1"].ColDefs["c1"].AutoGen, tc.name)
+	}
+}
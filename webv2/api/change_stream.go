@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/utilities"
+)
+
+// AddChangeStream adds a change stream to the target schema.
+func AddChangeStream(w http.ResponseWriter, r *http.Request) {
+	fmt.Println("request started", "method", r.Method, "path", r.URL.Path)
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		fmt.Println("request's body Read Error")
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	cs := ddl.ChangeStream{}
+	err = json.Unmarshal(reqBody, &cs)
+	if err != nil {
+		fmt.Println("request's Body parse error")
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	if ok, _ := utilities.CheckSpannerNamesValidity([]string{cs.Name}); !ok {
+		http.Error(w, fmt.Sprintf("Change stream name is not valid: %v", cs.Name), http.StatusBadRequest)
+		return
+	}
+	if ok, err := utilities.CanRename([]string{cs.Name}, ""); !ok {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := internal.ValidateChangeStream(sessionState.Conv.SpSchema, cs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if sessionState.Conv.SpChangeStreams == nil {
+		sessionState.Conv.SpChangeStreams = make(map[string]ddl.ChangeStream)
+	}
+	cs.Id = internal.GenerateChangeStreamId()
+	sessionState.Conv.UsedNames[strings.ToLower(cs.Name)] = true
+	sessionState.Conv.SpChangeStreams[cs.Id] = cs
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            *sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// UpdateChangeStream updates the watched tables and options of an existing
+// change stream. Renaming a change stream is not supported.
+func UpdateChangeStream(w http.ResponseWriter, r *http.Request) {
+	fmt.Println("request started", "method", r.Method, "path", r.URL.Path)
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		fmt.Println("request's body Read Error")
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	newCs := ddl.ChangeStream{}
+	err = json.Unmarshal(reqBody, &newCs)
+	if err != nil {
+		fmt.Println("request's Body parse error")
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	cs, found := sessionState.Conv.SpChangeStreams[newCs.Id]
+	if !found {
+		http.Error(w, "Change stream doesn't exist", http.StatusBadRequest)
+		return
+	}
+	newCs.Name = cs.Name
+	if err := internal.ValidateChangeStream(sessionState.Conv.SpSchema, newCs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sessionState.Conv.SpChangeStreams[newCs.Id] = newCs
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            *sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// DropChangeStream removes a change stream from the target schema.
+func DropChangeStream(w http.ResponseWriter, r *http.Request) {
+	changeStreamId := r.FormValue("changeStream")
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+
+	if changeStreamId == "" {
+		http.Error(w, "Change stream id is empty", http.StatusBadRequest)
+		return
+	}
+	cs, found := sessionState.Conv.SpChangeStreams[changeStreamId]
+	if !found {
+		http.Error(w, "Change stream doesn't exist", http.StatusBadRequest)
+		return
+	}
+	delete(sessionState.Conv.UsedNames, strings.ToLower(cs.Name))
+	delete(sessionState.Conv.SpChangeStreams, changeStreamId)
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            *sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// GetChangeStreamDDL returns the CREATE CHANGE STREAM statement for each
+// change stream, keyed by change stream id.
+func GetChangeStreamDDL(w http.ResponseWriter, r *http.Request) {
+	sessionState := session.GetSessionState()
+	sessionState.Conv.ConvLock.RLock()
+	defer sessionState.Conv.ConvLock.RUnlock()
+	conv := sessionState.Conv
+
+	csDDL := make(map[string]string)
+	for csId, cs := range conv.SpChangeStreams {
+		csDDL[csId] = cs.PrintChangeStream(conv.SpSchema, ddl.Config{ProtectIds: false, SpDialect: conv.SpDialect})
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(csDDL)
+}
@@ -0,0 +1,168 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func changeStreamTestSchema() ddl.Schema {
+	return ddl.Schema{
+		"t1": {
+			Name:    "table1",
+			Id:      "t1",
+			ColIds:  []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Int64}}, "c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Int64}}},
+		},
+	}
+}
+
+// restoreSessionState returns a func that resets the session state and id
+// counter so the change stream tests don't leak state into tests that run
+// after them.
+func restoreSessionState() func() {
+	sessionState := session.GetSessionState()
+	conv, driver, counter := sessionState.Conv, sessionState.Driver, internal.Cntr.ObjectId
+	return func() {
+		sessionState.Conv = conv
+		sessionState.Driver = driver
+		internal.Cntr.ObjectId = counter
+	}
+}
+
+func TestAddChangeStream(t *testing.T) {
+	defer restoreSessionState()()
+	tc := []struct {
+		name         string
+		input        ddl.ChangeStream
+		expectedCode int
+	}{
+		{
+			name:         "valid change stream",
+			input:        ddl.ChangeStream{Name: "cs", WatchedTables: []ddl.ChangeStreamTable{{TableId: "t1", ColIds: []string{"c2"}}}, ValueCaptureType: "NEW_ROW"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "unknown table",
+			input:        ddl.ChangeStream{Name: "cs", WatchedTables: []ddl.ChangeStreamTable{{TableId: "t2"}}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "name used by table",
+			input:        ddl.ChangeStream{Name: "Table1", WatchAll: true},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		sessionState.Conv = &internal.Conv{
+			SpSchema:  changeStreamTestSchema(),
+			UsedNames: map[string]bool{"table1": true},
+		}
+		inputBytes, err := json.Marshal(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := http.NewRequest("POST", "/AddChangeStream", bytes.NewBuffer(inputBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.AddChangeStream)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tt.expectedCode, rr.Code, tt.name)
+		if rr.Code == http.StatusOK {
+			var res *internal.Conv
+			json.Unmarshal(rr.Body.Bytes(), &res)
+			assert.Equal(t, 1, len(res.SpChangeStreams))
+			for id, cs := range res.SpChangeStreams {
+				assert.Equal(t, id, cs.Id)
+				assert.Equal(t, tt.input.Name, cs.Name)
+				assert.Equal(t, tt.input.WatchedTables, cs.WatchedTables)
+			}
+		}
+	}
+}
+
+func TestUpdateChangeStream(t *testing.T) {
+	defer restoreSessionState()()
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = &internal.Conv{
+		SpSchema: changeStreamTestSchema(),
+		SpChangeStreams: map[string]ddl.ChangeStream{
+			"cs1": {Id: "cs1", Name: "cs", WatchAll: true},
+		},
+	}
+	input := ddl.ChangeStream{Id: "cs1", Name: "renamed", WatchedTables: []ddl.ChangeStreamTable{{TableId: "t1"}}, RetentionPeriod: "36h"}
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest("POST", "/UpdateChangeStream", bytes.NewBuffer(inputBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(api.UpdateChangeStream)
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res *internal.Conv
+	json.Unmarshal(rr.Body.Bytes(), &res)
+	expected := ddl.ChangeStream{Id: "cs1", Name: "cs", WatchedTables: []ddl.ChangeStreamTable{{TableId: "t1"}}, RetentionPeriod: "36h"}
+	assert.Equal(t, expected, res.SpChangeStreams["cs1"])
+}
+
+func TestDropChangeStream(t *testing.T) {
+	defer restoreSessionState()()
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = &internal.Conv{
+		SpSchema:        changeStreamTestSchema(),
+		UsedNames:       map[string]bool{"table1": true, "cs": true},
+		SpChangeStreams: map[string]ddl.ChangeStream{"cs1": {Id: "cs1", Name: "cs", WatchAll: true}},
+	}
+	req, err := http.NewRequest("POST", "drop/changeStream?changeStream=cs1", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(api.DropChangeStream)
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 0, len(sessionState.Conv.SpChangeStreams))
+	assert.False(t, sessionState.Conv.UsedNames["cs"])
+}
+
+func TestGetChangeStreamDDL(t *testing.T) {
+	defer restoreSessionState()()
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = &internal.Conv{
+		SpSchema:        changeStreamTestSchema(),
+		SpChangeStreams: map[string]ddl.ChangeStream{"cs1": {Id: "cs1", Name: "cs", WatchedTables: []ddl.ChangeStreamTable{{TableId: "t1", ColIds: []string{"c1"}}}}},
+	}
+	req, err := http.NewRequest("GET", "/changeStreamDdl", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(api.GetChangeStreamDDL)
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res map[string]string
+	json.Unmarshal(rr.Body.Bytes(), &res)
+	assert.Equal(t, map[string]string{"cs1": "CREATE CHANGE STREAM cs FOR table1(a)"}, res)
+}
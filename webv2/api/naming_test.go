@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyConstraintNaming(t *testing.T) {
+	defer restoreSessionState()()
+	tc := []struct {
+		name         string
+		input        internal.ConstraintNaming
+		expectedCode int
+		expectedName string
+	}{
+		{
+			name:         "valid template",
+			input:        internal.ConstraintNaming{Index: "idx_{table}_{cols}"},
+			expectedCode: http.StatusOK,
+			expectedName: "idx_table1_b",
+		},
+		{
+			name:         "unknown placeholder",
+			input:        internal.ConstraintNaming{Index: "idx_{column}"},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = constants.MYSQL
+		spSchema := changeStreamTestSchema()
+		ct := spSchema["t1"]
+		ct.Indexes = []ddl.CreateIndex{{Name: "Index_table1", Id: "i1", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2"}}}}
+		spSchema["t1"] = ct
+		sessionState.Conv = &internal.Conv{
+			SpSchema:  spSchema,
+			SrcSchema: map[string]schema.Table{"t1": {Name: "table1", Id: "t1", Indexes: []schema.Index{{Id: "i1"}}}},
+			UsedNames: map[string]bool{"table1": true, "index_table1": true},
+		}
+		inputBytes, err := json.Marshal(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := http.NewRequest("POST", "/ApplyConstraintNaming", bytes.NewBuffer(inputBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.ApplyConstraintNaming)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tt.expectedCode, rr.Code, tt.name)
+		if rr.Code == http.StatusOK {
+			var res *internal.Conv
+			json.Unmarshal(rr.Body.Bytes(), &res)
+			assert.Equal(t, tt.expectedName, res.SpSchema["t1"].Indexes[0].Name, tt.name)
+		}
+	}
+}
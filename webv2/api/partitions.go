@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+)
+
+// PartitionMapping is the mapping of the partitions of a partitioned source
+// table to Spanner: single-table, parallel-export or primary-key.
+type PartitionMapping struct {
+	TableId string
+	Mapping string
+}
+
+// SetPartitionMapping sets the mapping of the partitions of a partitioned
+// source table to Spanner, and converts its primary key again when the
+// partition key is folded into it, or no longer is.
+func (tableHandler *TableAPIHandler) SetPartitionMapping(w http.ResponseWriter, r *http.Request) {
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	var mapping PartitionMapping
+	if err := json.Unmarshal(reqBody, &mapping); err != nil {
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := common.ValidatePartitionMapping(mapping.Mapping); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sessionState := session.GetSessionState()
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, fmt.Sprintf("Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner."), http.StatusNotFound)
+		return
+	}
+	switch sessionState.Driver {
+	case constants.MYSQL, constants.POSTGRES, constants.ORACLE:
+	default:
+		http.Error(w, fmt.Sprintf("Partition mappings are not supported for driver '%s'", sessionState.Driver), http.StatusBadRequest)
+		return
+	}
+
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+	if err := common.SetPartitionMapping(sessionState.Conv, mapping.TableId, mapping.Mapping); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            *sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
@@ -0,0 +1,115 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func partitionsTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = schema.Table{
+		Id:     "t1",
+		Name:   "orders",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "bigint"}, NotNull: true},
+			"c2": {Id: "c2", Name: "created_at", Type: schema.Type{Name: "date"}, NotNull: true},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1", Order: 1}},
+		Partitioning: &schema.Partitioning{
+			Method:     "RANGE",
+			Expression: "created_at",
+			ColIds:     []string{"c2"},
+			Partitions: []schema.Partition{{Name: "p2024"}, {Name: "p2025"}},
+			Mapping:    schema.PartitionMappingSingleTable,
+		},
+	}
+	conv.SrcSchema["t2"] = schema.Table{Id: "t2", Name: "customers", ColIds: []string{"c3"}, ColDefs: map[string]schema.Column{"c3": {Id: "c3", Name: "id"}}}
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Name:   "orders",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Id: "c1", Name: "id", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+			"c2": {Id: "c2", Name: "created_at", T: ddl.Type{Name: ddl.Date}, NotNull: true},
+		},
+		PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+	}
+	return conv
+}
+
+func TestSetPartitionMapping(t *testing.T) {
+	defer restoreSessionState()()
+	tableHandler := api.TableAPIHandler{DDLVerifier: &expressions_api.MockDDLVerifier{}}
+	tc := []struct {
+		name        string
+		driver      string
+		mapping     api.PartitionMapping
+		statusCode  int
+		primaryKeys []ddl.IndexKey
+	}{
+		{
+			name:        "Fold partition key into primary key",
+			driver:      constants.MYSQL,
+			mapping:     api.PartitionMapping{TableId: "t1", Mapping: schema.PartitionMappingPrimaryKey},
+			statusCode:  http.StatusOK,
+			primaryKeys: []ddl.IndexKey{{ColId: "c2", Order: 1}, {ColId: "c1", Order: 2}},
+		},
+		{
+			name:        "Parallel export",
+			driver:      constants.ORACLE,
+			mapping:     api.PartitionMapping{TableId: "t1", Mapping: schema.PartitionMappingParallelExport},
+			statusCode:  http.StatusOK,
+			primaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+		{
+			name:        "Invalid mapping",
+			driver:      constants.POSTGRES,
+			mapping:     api.PartitionMapping{TableId: "t1", Mapping: "per-partition"},
+			statusCode:  http.StatusBadRequest,
+			primaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+		{
+			name:        "Table not partitioned",
+			driver:      constants.POSTGRES,
+			mapping:     api.PartitionMapping{TableId: "t2", Mapping: schema.PartitionMappingPrimaryKey},
+			statusCode:  http.StatusBadRequest,
+			primaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+		{
+			name:        "Driver without partitioned tables",
+			driver:      constants.DYNAMODB,
+			mapping:     api.PartitionMapping{TableId: "t1", Mapping: schema.PartitionMappingPrimaryKey},
+			statusCode:  http.StatusBadRequest,
+			primaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+	}
+	for _, tc := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = tc.driver
+		sessionState.Conv = partitionsTestConv()
+		body, err := json.Marshal(tc.mapping)
+		assert.Nil(t, err)
+		req, err := http.NewRequest("POST", "/partitionMapping", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(tableHandler.SetPartitionMapping)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tc.statusCode, rr.Code, tc.name)
+		assert.Equal(t, tc.primaryKeys, sessionState.Conv.SpSchema["t1"].PrimaryKeys, tc.name)
+	}
+}
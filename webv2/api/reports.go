@@ -85,7 +85,7 @@ func GetDSpannerDDL(w http.ResponseWriter, r *http.Request) {
 	defer sessionState.Conv.ConvLock.RUnlock()
 	conv := sessionState.Conv
 	now := time.Now()
-	spDDL := ddl.GetDDL(ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: sessionState.Driver}, conv.SpSchema, conv.SpSequences)
+	spDDL := ddl.GetDDL(ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: sessionState.Driver}, conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects())
 	if len(spDDL) == 0 {
 		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
 	}
@@ -105,15 +105,13 @@ func GetSpannerDDLWoComments(w http.ResponseWriter, r *http.Request) {
 	defer sessionState.Conv.ConvLock.RUnlock()
 	conv := sessionState.Conv
 	now := time.Now()
-	spDDL := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: sessionState.Driver}, conv.SpSchema, conv.SpSequences)
-	if len(spDDL) == 0 {
-		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
-	}
-	l := []string{
-		fmt.Sprintf("-- Schema generated %s\n", now.Format("2006-01-02 15:04:05")),
-		strings.Join(spDDL, ";\n\n"),
-		"\n",
+	spDDL := ddl.GetDDL(ddl.SQLFileConfig(conv.SpDialect, sessionState.Driver), conv.SpSchema, conv.SpSequences, conv.SpSchemaObjects())
+	header := ddl.SQLFileHeader{Generated: now, ToolVersion: utils.ToolVersion(), Source: sessionState.Driver, Dialect: conv.SpDialect, SessionId: conv.Audit.MigrationRequestId}
+	var b strings.Builder
+	if err := ddl.WriteSQLFile(&b, spDDL, ddl.SQLFileOptions{Header: header}); err != nil {
+		http.Error(w, fmt.Sprintf("Can't write DDL: %v", err), http.StatusInternalServerError)
+		return
 	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(strings.Join(l, ""))
+	json.NewEncoder(w).Encode(b.String())
 }
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
@@ -21,6 +22,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/cassandra"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/dynamodb"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/mysql"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/oracle"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/postgres"
@@ -246,27 +248,27 @@ func GetDDL(w http.ResponseWriter, r *http.Request) {
 		tables = append(tables, t)
 	}
 	sort.Strings(tables)
-	ddl := make(map[string]string)
+	tableDdls := make(map[string]string)
 	for _, t := range tables {
 		table := sessionState.Conv.SpSchema[t]
 		tableDdl := table.PrintCreateTable(sessionState.Conv.SpSchema, c) + ";"
 		if len(table.Indexes) > 0 {
 			tableDdl = tableDdl + "\n"
 		}
-		for _, index := range table.Indexes {
-			tableDdl = tableDdl + "\n" + index.PrintCreateIndex(table, c) + ";"
+		for _, index := range ddl.GetSortedIndexes(table) {
+			tableDdl = tableDdl + "\n" + index.PrintCreateIndex(sessionState.Conv.SpSchema, table, c) + ";"
 		}
 		if len(table.ForeignKeys) > 0 {
 			tableDdl = tableDdl + "\n"
 		}
-		for _, fk := range table.ForeignKeys {
+		for _, fk := range ddl.GetSortedForeignKeys(table) {
 			tableDdl = tableDdl + "\n" + fk.PrintForeignKeyAlterTable(sessionState.Conv.SpSchema, c, t) + ";"
 		}
 
-		ddl[t] = tableDdl
+		tableDdls[t] = tableDdl
 	}
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(ddl)
+	json.NewEncoder(w).Encode(tableDdls)
 }
 
 func GetStandardTypeToPGSQLTypemap(w http.ResponseWriter, r *http.Request) {
@@ -298,7 +300,7 @@ func SpannerDefaultTypeMap(w http.ResponseWriter, r *http.Request) {
 		typeMap = postgresDefaultTypeMap
 	case constants.SQLSERVER:
 		typeMap = sqlserverDefaultTypeMap
-	case constants.ORACLE:
+	case constants.ORACLE, constants.ORACLEDUMP:
 		typeMap = oracleDefaultTypeMap
 	case constants.CASSANDRA:
 		typeMap = cassandraDefaultTypeMap	
@@ -329,7 +331,7 @@ func GetTypeMap(w http.ResponseWriter, r *http.Request) {
 		typeMap = postgresTypeMap
 	case constants.SQLSERVER:
 		typeMap = sqlserverTypeMap
-	case constants.ORACLE:
+	case constants.ORACLE, constants.ORACLEDUMP:
 		typeMap = oracleTypeMap
 	case constants.CASSANDRA:
 		typeMap = cassandraTypeMap	
@@ -517,6 +519,59 @@ func DropTable(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(convm)
 }
 
+// UpdatePlacementKey nominates the column choosing the placement of each row
+// of a table in a geo-partitioned database. An empty column removes the
+// placement key of the table.
+func UpdatePlacementKey(w http.ResponseWriter, r *http.Request) {
+	tableId := r.FormValue("table")
+	colId := r.FormValue("column")
+	sessionState := session.GetSessionState()
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, fmt.Sprintf("Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner."), http.StatusNotFound)
+		return
+	}
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+	if err := internal.SetPlacementKey(sessionState.Conv, tableId, colId); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            *sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
+// UpdateTableSynonym keeps the source name of a renamed table as a synonym of
+// the Spanner table, or removes the synonym if keep is false.
+func UpdateTableSynonym(w http.ResponseWriter, r *http.Request) {
+	tableId := r.FormValue("table")
+	keep, err := strconv.ParseBool(r.FormValue("keep"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid keep value: %v", err), http.StatusBadRequest)
+		return
+	}
+	sessionState := session.GetSessionState()
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, fmt.Sprintf("Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner."), http.StatusNotFound)
+		return
+	}
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+	if err := internal.SetSourceTableSynonym(sessionState.Conv, tableId, keep); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            *sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
+
 func RestoreSecondaryIndex(w http.ResponseWriter, r *http.Request) {
 	tableId := r.FormValue("tableId")
 	indexId := r.FormValue("indexId")
@@ -1061,6 +1116,13 @@ func UpdateIndexes(w http.ResponseWriter, r *http.Request) {
 	for i, ind := range sp.Indexes {
 		if ind.TableId == newIndexes[0].TableId && ind.Id == newIndexes[0].Id {
 
+			if newIndexes[0].Interleave != "" {
+				if err := newIndexes[0].ValidateInterleave(sessionState.Conv.SpSchema); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+
 			index.RemoveIndexIssues(table, sp.Indexes[i])
 
 			sp.Indexes[i].Keys = newIndexes[0].Keys
@@ -1068,6 +1130,7 @@ func UpdateIndexes(w http.ResponseWriter, r *http.Request) {
 			sp.Indexes[i].TableId = newIndexes[0].TableId
 			sp.Indexes[i].Unique = newIndexes[0].Unique
 			sp.Indexes[i].Id = newIndexes[0].Id
+			sp.Indexes[i].Interleave = newIndexes[0].Interleave
 
 			break
 		}
@@ -1185,10 +1248,14 @@ func (tableHandler *TableAPIHandler) restoreTableHelper(w http.ResponseWriter, t
 		toddl = oracle.InfoSchemaImpl{}.GetToDdl()
 	case constants.CASSANDRA:
 		toddl = cassandra.InfoSchemaImpl{}.GetToDdl()	
+	case constants.DYNAMODB:
+		toddl = dynamodb.InfoSchemaImpl{}.GetToDdl()
 	case constants.MYSQLDUMP:
 		toddl = mysql.DbDumpImpl{}.GetToDdl()
 	case constants.PGDUMP:
 		toddl = postgres.DbDumpImpl{}.GetToDdl()
+	case constants.ORACLEDUMP:
+		toddl = oracle.DbDumpImpl{}.GetToDdl()
 	default:
 		http.Error(w, fmt.Sprintf("Driver : '%s' is not supported", sessionState.Driver), http.StatusBadRequest)
 	}
@@ -1635,7 +1702,7 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -1648,11 +1715,11 @@ func initializeTypeMap() {
 	}
 	// Initialize postgresTypeMap.
 	toddl = postgres.InfoSchemaImpl{}.GetToDdl()
-	for _, srcTypeName := range []string{"bool", "boolean", "bigserial", "bpchar", "character", "bytea", "date", "float8", "double precision", "float4", "real", "int8", "bigint", "int4", "integer", "int2", "smallint", "numeric", "serial", "text", "timestamptz", "timestamp with time zone", "timestamp", "timestamp without time zone", "varchar", "character varying", "path"} {
+	for _, srcTypeName := range []string{"bool", "boolean", "bigserial", "bpchar", "character", "bytea", "date", "float8", "double precision", "float4", "real", "int8", "bigint", "int4", "integer", "int2", "smallint", "numeric", "serial", "text", "timestamptz", "timestamp with time zone", "timestamp", "timestamp without time zone", "uuid", "varchar", "character varying", "path", "geometry", "geography", "hstore", "citext", "ltree", "inet", "cidr"} {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -188,7 +188,8 @@ func TestGetTypeMapMySQL(t *testing.T) {
 			{T: ddl.JSON, DisplayT: ddl.JSON}},
 		"binary": {
 			{T: ddl.Bytes, DisplayT: ddl.Bytes},
-			{T: ddl.String, DisplayT: ddl.String}},
+			{T: ddl.String, DisplayT: ddl.String},
+			{T: ddl.UUID, DisplayT: ddl.UUID}},
 		"blob": {
 			{T: ddl.Bytes, DisplayT: ddl.Bytes},
 			{T: ddl.String, DisplayT: ddl.String}},
@@ -1347,7 +1348,7 @@ func TestDropSecondaryIndex(t *testing.T) {
 					ObjectType:        "table",
 					AssociatedObjects: "t1",
 					Enabled:           false,
-					Data:              map[string]interface{}{"Name": "idx2", "Id": "i2", "TableId": "t1", "Unique": false, "StoredColumnIds": nil, "Keys": []interface{}{map[string]interface{}{"ColId": "c3", "Desc": false, "Order": float64(1)}}},
+					Data:              map[string]interface{}{"Name": "idx2", "Id": "i2", "TableId": "t1", "Unique": false, "StoredColumnIds": nil, "NullFiltered": false, "Interleave": "", "Keys": []interface{}{map[string]interface{}{"ColId": "c3", "Desc": false, "Order": float64(1)}}},
 				}},
 			},
 		},
@@ -1632,6 +1633,92 @@ func TestDropTable(t *testing.T) {
 	assert.Equal(t, expectedConv.SpSchema, res.SpSchema)
 }
 
+func TestUpdateTableSynonym(t *testing.T) {
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = &internal.Conv{
+		SrcSchema: map[string]schema.Table{
+			"t1": {Name: "Orders", Id: "t1"},
+			"t2": {Name: "order-items", Id: "t2"},
+		},
+		SpSchema: map[string]ddl.CreateTable{
+			"t1": {Name: "orders_v2", Id: "t1"},
+			"t2": {Name: "order_items", Id: "t2"},
+		},
+		UsedNames: map[string]bool{"orders_v2": true, "order_items": true},
+		Audit: internal.Audit{
+			MigrationType: migration.MigrationData_SCHEMA_ONLY.Enum(),
+		},
+	}
+
+	tc := []struct {
+		name            string
+		query           string
+		statusCode      int
+		expectedSynonym string
+	}{
+		{name: "Keep source name", query: "table=t1&keep=true", statusCode: http.StatusOK, expectedSynonym: "Orders"},
+		{name: "Invalid source name", query: "table=t2&keep=true", statusCode: http.StatusBadRequest, expectedSynonym: "Orders"},
+		{name: "Invalid keep value", query: "table=t1&keep=maybe", statusCode: http.StatusBadRequest, expectedSynonym: "Orders"},
+		{name: "Remove synonym", query: "table=t1&keep=false", statusCode: http.StatusOK},
+	}
+	for _, tc := range tc {
+		req, err := http.NewRequest("POST", "/update/synonym?"+tc.query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.UpdateTableSynonym)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tc.statusCode, rr.Code, tc.name)
+		assert.Equal(t, tc.expectedSynonym, sessionState.Conv.SpSchema["t1"].Synonym, tc.name)
+	}
+}
+
+func TestUpdatePlacementKey(t *testing.T) {
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	sessionState.Conv = &internal.Conv{
+		SpSchema: map[string]ddl.CreateTable{
+			"t1": {
+				Name:   "customers",
+				Id:     "t1",
+				ColIds: []string{"c1", "c2"},
+				ColDefs: map[string]ddl.ColumnDef{
+					"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+					"c2": {Name: "region", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: true},
+				},
+			},
+		},
+		Audit: internal.Audit{
+			MigrationType: migration.MigrationData_SCHEMA_ONLY.Enum(),
+		},
+	}
+
+	tc := []struct {
+		name                 string
+		query                string
+		statusCode           int
+		expectedPlacementKey string
+	}{
+		{name: "Set placement key", query: "table=t1&column=c2", statusCode: http.StatusOK, expectedPlacementKey: "c2"},
+		{name: "Non string column", query: "table=t1&column=c1", statusCode: http.StatusBadRequest, expectedPlacementKey: "c2"},
+		{name: "Unknown table", query: "table=t2&column=c2", statusCode: http.StatusBadRequest, expectedPlacementKey: "c2"},
+		{name: "Remove placement key", query: "table=t1&column=", statusCode: http.StatusOK},
+	}
+	for _, tc := range tc {
+		req, err := http.NewRequest("POST", "/update/placementKey?"+tc.query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(api.UpdatePlacementKey)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tc.statusCode, rr.Code, tc.name)
+		assert.Equal(t, tc.expectedPlacementKey, sessionState.Conv.SpSchema["t1"].PlacementKey, tc.name)
+	}
+}
+
 func TestRestoreTable(t *testing.T) {
 	sessionState := session.GetSessionState()
 
@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/dynamodb"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/utilities"
+)
+
+// SplitSingleTable splits a DynamoDB table of a single-table design into a
+// table per entity type. The request body is the design detected for the
+// table, with the table names and parents of its entity types as reviewed
+// by the user.
+func (tableHandler *TableAPIHandler) SplitSingleTable(w http.ResponseWriter, r *http.Request) {
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	var design internal.SingleTableDesign
+	if err := json.Unmarshal(reqBody, &design); err != nil {
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+	sessionState := session.GetSessionState()
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, fmt.Sprintf("Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner."), http.StatusNotFound)
+		return
+	}
+	if sessionState.Driver != constants.DYNAMODB {
+		http.Error(w, fmt.Sprintf("Splitting single-table designs is not supported for driver '%s'", sessionState.Driver), http.StatusBadRequest)
+		return
+	}
+	var tableNames []string
+	for _, e := range design.Entities {
+		tableNames = append(tableNames, e.TableName)
+	}
+	if _, invalid := utilities.CheckSpannerNamesValidity(tableNames); len(invalid) > 0 {
+		http.Error(w, fmt.Sprintf("Table names are not valid: %v", invalid), http.StatusBadRequest)
+		return
+	}
+
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+	if err := dynamodb.SplitSingleTable(sessionState.Conv, design, tableHandler.DDLVerifier); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            *sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func singleTableTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = schema.Table{
+		Id:     "t1",
+		Name:   "app",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "PK", Type: schema.Type{Name: "String"}, NotNull: true},
+			"c2": {Id: "c2", Name: "type", Type: schema.Type{Name: "String"}},
+			"c3": {Id: "c3", Name: "email", Type: schema.Type{Name: "String"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1", Order: 1}},
+	}
+	conv.SpSchema["t1"] = ddl.CreateTable{Name: "app", Id: "t1"}
+	conv.UsedNames["app"] = true
+	return conv
+}
+
+func TestSplitSingleTable(t *testing.T) {
+	defer restoreSessionState()()
+	tableHandler := api.TableAPIHandler{DDLVerifier: &expressions_api.MockDDLVerifier{}}
+	tc := []struct {
+		name       string
+		driver     string
+		entities   []internal.Entity
+		statusCode int
+		tables     []string
+	}{
+		{
+			name:   "Split table",
+			driver: constants.DYNAMODB,
+			entities: []internal.Entity{
+				{Name: "customer", TableName: "customers", ColIds: []string{"c1", "c3"}},
+				{Name: "order", TableName: "orders", ColIds: []string{"c1"}},
+			},
+			statusCode: http.StatusOK,
+			tables:     []string{"customers", "orders"},
+		},
+		{
+			name:       "Invalid table name",
+			driver:     constants.DYNAMODB,
+			entities:   []internal.Entity{{Name: "customer", TableName: "customers!"}},
+			statusCode: http.StatusBadRequest,
+			tables:     []string{"app"},
+		},
+		{
+			name:       "Not a DynamoDB session",
+			driver:     constants.MYSQL,
+			entities:   []internal.Entity{{Name: "customer", TableName: "customers"}},
+			statusCode: http.StatusBadRequest,
+			tables:     []string{"app"},
+		},
+	}
+	for _, tc := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = tc.driver
+		sessionState.Conv = singleTableTestConv()
+		design := internal.SingleTableDesign{TableId: "t1", Attribute: "type", Entities: tc.entities}
+		body, err := json.Marshal(design)
+		assert.Nil(t, err)
+		req, err := http.NewRequest("POST", "/dynamodb/splitTable", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(tableHandler.SplitSingleTable)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tc.statusCode, rr.Code, tc.name)
+		var tables []string
+		for _, id := range ddl.GetSortedTableIdsBySpName(sessionState.Conv.SpSchema) {
+			tables = append(tables, sessionState.Conv.SpSchema[id].Name)
+		}
+		assert.Equal(t, tc.tables, tables, tc.name)
+	}
+}
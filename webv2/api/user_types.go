@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/cassandra"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+)
+
+// UserTypeStrategy is the strategy used to map the columns of a Cassandra
+// user-defined type: json or flatten.
+type UserTypeStrategy struct {
+	Name     string
+	Strategy string
+}
+
+// SetUserTypeStrategy sets the strategy used to map the columns of a
+// Cassandra user-defined type, and converts the tables using the type to
+// Spanner again.
+func (tableHandler *TableAPIHandler) SetUserTypeStrategy(w http.ResponseWriter, r *http.Request) {
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Body Read Error : %v", err), http.StatusInternalServerError)
+		return
+	}
+	var strategy UserTypeStrategy
+	if err := json.Unmarshal(reqBody, &strategy); err != nil {
+		http.Error(w, fmt.Sprintf("Request Body parse error : %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := cassandra.ValidateUserTypeStrategies("", map[string]string{strategy.Name: strategy.Strategy}); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sessionState := session.GetSessionState()
+	if sessionState.Conv == nil || sessionState.Driver == "" {
+		http.Error(w, fmt.Sprintf("Schema is not converted or Driver is not configured properly. Please retry converting the database to Spanner."), http.StatusNotFound)
+		return
+	}
+	if sessionState.Driver != constants.CASSANDRA {
+		http.Error(w, fmt.Sprintf("User type strategies are not supported for driver '%s'", sessionState.Driver), http.StatusBadRequest)
+		return
+	}
+
+	sessionState.Conv.ConvLock.Lock()
+	defer sessionState.Conv.ConvLock.Unlock()
+	if err := cassandra.SetUserTypeFlatten(sessionState.Conv, strategy.Name, strategy.Strategy == cassandra.FlattenStrategy, tableHandler.DDLVerifier); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	convm := session.ConvWithMetadata{
+		SessionMetadata: sessionState.SessionMetadata,
+		Conv:            *sessionState.Conv,
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(convm)
+}
@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/api"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func userTypesTestConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.Source = constants.CASSANDRA
+	internal.SetUserDefinedType(conv, internal.UserDefinedType{Name: "address", FieldNames: []string{"city", "zip"}, FieldTypes: []string{"text", "int"}})
+	conv.SrcSchema["t1"] = schema.Table{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Id: "c1", Name: "id", Type: schema.Type{Name: "int"}, NotNull: true},
+			"c2": {Id: "c2", Name: "home", Type: schema.Type{Name: "frozen<address>"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1", Order: 1}},
+	}
+	conv.SpSchema["t1"] = ddl.CreateTable{Name: "users", Id: "t1"}
+	conv.UsedNames["users"] = true
+	return conv
+}
+
+func TestSetUserTypeStrategy(t *testing.T) {
+	defer restoreSessionState()()
+	tableHandler := api.TableAPIHandler{DDLVerifier: &expressions_api.MockDDLVerifier{}}
+	tc := []struct {
+		name       string
+		driver     string
+		strategy   api.UserTypeStrategy
+		statusCode int
+		columns    int
+	}{
+		{
+			name:       "Flatten user type",
+			driver:     constants.CASSANDRA,
+			strategy:   api.UserTypeStrategy{Name: "address", Strategy: "flatten"},
+			statusCode: http.StatusOK,
+			columns:    3,
+		},
+		{
+			name:       "Unknown user type",
+			driver:     constants.CASSANDRA,
+			strategy:   api.UserTypeStrategy{Name: "phone", Strategy: "flatten"},
+			statusCode: http.StatusBadRequest,
+			columns:    2,
+		},
+		{
+			name:       "Invalid strategy",
+			driver:     constants.CASSANDRA,
+			strategy:   api.UserTypeStrategy{Name: "address", Strategy: "tables"},
+			statusCode: http.StatusBadRequest,
+			columns:    2,
+		},
+		{
+			name:       "Not a Cassandra session",
+			driver:     constants.MYSQL,
+			strategy:   api.UserTypeStrategy{Name: "address", Strategy: "flatten"},
+			statusCode: http.StatusBadRequest,
+			columns:    2,
+		},
+	}
+	for _, tc := range tc {
+		sessionState := session.GetSessionState()
+		sessionState.Driver = tc.driver
+		sessionState.Conv = userTypesTestConv()
+		body, err := json.Marshal(tc.strategy)
+		assert.Nil(t, err)
+		req, err := http.NewRequest("POST", "/cassandra/userTypeStrategy", bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(tableHandler.SetUserTypeStrategy)
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, tc.statusCode, rr.Code, tc.name)
+		assert.Equal(t, tc.columns, len(sessionState.Conv.SrcSchema["t1"].ColIds), tc.name)
+	}
+}
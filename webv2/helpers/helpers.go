@@ -175,6 +175,8 @@ func GetSourceDatabaseFromDriver(driver string) (string, error) {
 		return constants.MYSQL, nil
 	case constants.PGDUMP, constants.POSTGRES:
 		return constants.POSTGRES, nil
+	case constants.ORACLEDUMP:
+		return constants.ORACLE, nil
 	case constants.ORACLE, constants.SQLSERVER:
 		return driver, nil
 	case constants.CASSANDRA:
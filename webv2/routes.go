@@ -71,6 +71,7 @@ func getRoutes() *mux.Router {
 	router.HandleFunc("/convert/session", loadSession).Methods("POST")
 	router.HandleFunc("/ddl", api.GetDDL).Methods("GET")
 	router.HandleFunc("/seqDdl", api.GetSequenceDDL).Methods("GET")
+	router.HandleFunc("/changeStreamDdl", api.GetChangeStreamDDL).Methods("GET")
 	router.HandleFunc("/conversion", api.GetConversionRate).Methods("GET")
 	router.HandleFunc("/typemap", api.GetTypeMap).Methods("GET")
 	router.HandleFunc("/report", reportAPIHandler.GetReportFile).Methods("GET")
@@ -100,9 +101,17 @@ func getRoutes() *mux.Router {
 	router.HandleFunc("/restore/tables", tableHandler.RestoreTables).Methods("POST")
 	router.HandleFunc("/drop/table", api.DropTable).Methods("POST")
 	router.HandleFunc("/drop/tables", api.DropTables).Methods("POST")
+	router.HandleFunc("/update/synonym", api.UpdateTableSynonym).Methods("POST")
+	router.HandleFunc("/update/placementKey", api.UpdatePlacementKey).Methods("POST")
+	router.HandleFunc("/dynamodb/splitTable", tableHandler.SplitSingleTable).Methods("POST")
+	router.HandleFunc("/cassandra/userTypeStrategy", tableHandler.SetUserTypeStrategy).Methods("POST")
+	router.HandleFunc("/partitionMapping", tableHandler.SetPartitionMapping).Methods("POST")
+	router.HandleFunc("/autoIncrementPolicy", tableHandler.SetAutoIncrementPolicy).Methods("POST")
 
 	router.HandleFunc("/drop/sequence", api.DropSequence).Methods("POST")
 	router.HandleFunc("/UpdateSequence", api.UpdateSequence).Methods("POST")
+	router.HandleFunc("/drop/changeStream", api.DropChangeStream).Methods("POST")
+	router.HandleFunc("/UpdateChangeStream", api.UpdateChangeStream).Methods("POST")
 
 	router.HandleFunc("/update/fks", api.UpdateForeignKeys).Methods("POST")
 	router.HandleFunc("/update/cc", api.UpdateCheckConstraint).Methods("POST")
@@ -120,6 +129,8 @@ func getRoutes() *mux.Router {
 
 	router.HandleFunc("/AddColumn", table.AddNewColumn).Methods("POST")
 	router.HandleFunc("/AddSequence", api.AddNewSequence).Methods("POST")
+	router.HandleFunc("/AddChangeStream", api.AddChangeStream).Methods("POST")
+	router.HandleFunc("/ApplyConstraintNaming", api.ApplyConstraintNaming).Methods("POST")
 
 	// Summary
 	router.HandleFunc("/summary", summary.GetSummary).Methods("GET")
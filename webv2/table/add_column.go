@@ -99,7 +99,7 @@ func AddNewColumn(w http.ResponseWriter, r *http.Request) {
 	}
 	if sessionState.Conv.Source == constants.CASSANDRA {
 		colDef.Opts = make(map[string]string)
-		colDef.Opts["cassandra_type"] = GetCassandraType(details.Datatype)
+		colDef.Opts[ddl.CassandraTypeOpt] = GetCassandraType(details.Datatype)
 	}
 	ct.ColDefs[columnId] = colDef
 	sessionState.Conv.SpSchema[tableId] = ct
@@ -15,6 +15,8 @@
 package table
 
 import (
+	"strings"
+
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
@@ -82,6 +84,8 @@ func removeColumnFromTableSchema(conv *internal.Conv, tableId string, colId stri
 
 	sp = removeColumnFromSpannerPK(sp, colId)
 
+	sp = removeUniqueConstraintIndexes(conv, tableId, sp, colId)
+
 	sp = removeColumnFromSpannerSecondaryIndex(sp, colId)
 
 	sp = removeColumnFromSpannerForeignkeyColumns(sp, colId)
@@ -127,6 +131,36 @@ func removeColumnFromSpannerColDefs(sp ddl.CreateTable, colId string) ddl.Create
 	return sp
 }
 
+// removeUniqueConstraintIndexes removes the indexes implementing source
+// UNIQUE constraints on the given column, since the constraint on the
+// remaining columns would reject rows the source accepts. They are reported
+// as dropped constraints.
+func removeUniqueConstraintIndexes(conv *internal.Conv, tableId string, sp ddl.CreateTable, colId string) ddl.CreateTable {
+	constraints := make(map[string]bool)
+	for _, index := range conv.SrcSchema[tableId].Indexes {
+		constraints[index.Id] = index.Constraint
+	}
+	var indexes []ddl.CreateIndex
+	for _, index := range sp.Indexes {
+		if constraints[index.Id] && index.Unique && isColumnInIndexKeys(index.Keys, colId) {
+			delete(conv.UsedNames, strings.ToLower(index.Name))
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	sp.Indexes = indexes
+	return sp
+}
+
+func isColumnInIndexKeys(keys []ddl.IndexKey, colId string) bool {
+	for _, key := range keys {
+		if key.ColId == colId {
+			return true
+		}
+	}
+	return false
+}
+
 // removeColumnFromSpannerSecondaryIndex remove given column from Spanner SecondaryIndex List.
 func removeColumnFromSpannerSecondaryIndex(sp ddl.CreateTable, colId string) ddl.CreateTable {
 
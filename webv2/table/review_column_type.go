@@ -256,7 +256,7 @@ func reviewColumnTypeChangeTableSchema(conv *internal.Conv, tableId string, colI
 			if colDef.Opts == nil {
 				colDef.Opts = make(map[string]string)
 			}
-			colDef.Opts["cassandra_type"] = option
+			colDef.Opts[ddl.CassandraTypeOpt] = option
 		}
 	}
 	sp.ColDefs[colId] = colDef
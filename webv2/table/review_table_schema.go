@@ -152,6 +152,14 @@ func ReviewTableSchema(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		if v.Removed {
+			v.EnumStrategy = ""
+		}
+		if err := UpdateEnumStrategy(v.EnumStrategy, tableId, colId, conv); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		if !v.Removed && !v.Add && v.Rename == "" {
 			sequences := UpdateAutoGenCol(v.AutoGen, tableId, colId, conv)
 			conv.SpSequences = sequences
@@ -35,15 +35,23 @@ import (
 // (3) Rename: New name or empty string.
 // (4) NotNull: "ADDED", "REMOVED" or "".
 // (5) ToType: New type or empty string.
+// (6) ProtoName: Fully qualified proto name when ToType is PROTO or ENUM.
+// (7) AllowCommitTimestamp: "ADDED", "REMOVED" or "".
+// (8) Opts: Column options to set, an empty value removes the option.
+// (9) EnumStrategy: Strategy of converting an ENUM column, see internal.EnumStrategyString, or empty string.
 type updateCol struct {
 	Add          bool           `json:"Add"`
 	Removed      bool           `json:"Removed"`
 	Rename       string         `json:"Rename"`
 	NotNull      string         `json:"NotNull"`
 	ToType       string         `json:"ToType"`
+	ProtoName    string         `json:"ProtoName"`
+	AllowCommitTimestamp string `json:"AllowCommitTimestamp"`
+	Opts         map[string]string `json:"Opts"`
 	MaxColLength string         `json:"MaxColLength"`
 	AutoGen      ddl.AutoGenCol `json:"AutoGen"`
 	DefaultValue ddl.DefaultValue `json:"DefaultValue"`
+	EnumStrategy string         `json:"EnumStrategy"`
 }
 
 type updateTable struct {
@@ -109,7 +117,12 @@ func UpdateTableSchema(w http.ResponseWriter, r *http.Request) {
 		}
 
 		_, found := conv.SrcSchema[tableId].ColDefs[colId]
-		if v.ToType != "" && found {
+		if v.ToType == ddl.Proto || v.ToType == ddl.Enum {
+			if err := UpdateProtoType(v.ToType, v.ProtoName, tableId, colId, conv); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		} else if v.ToType != "" && found {
 
 			typeChange, err := utilities.IsTypeChanged(v.ToType, tableId, colId, conv)
 			if err != nil {
@@ -125,9 +138,28 @@ func UpdateTableSchema(w http.ResponseWriter, r *http.Request) {
 		if v.NotNull != "" {
 			UpdateNotNull(v.NotNull, tableId, colId, conv)
 		}
+		if v.AllowCommitTimestamp != "" {
+			if err := UpdateAllowCommitTimestamp(v.AllowCommitTimestamp, tableId, colId, conv); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if len(v.Opts) > 0 {
+			if err := UpdateColumnOpts(v.Opts, tableId, colId, conv); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
 		if v.MaxColLength != "" {
 			UpdateColumnSize(v.MaxColLength, tableId, colId, conv)
 		}
+		if v.Removed {
+			v.EnumStrategy = ""
+		}
+		if err := UpdateEnumStrategy(v.EnumStrategy, tableId, colId, conv); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if !v.Removed {
 			sequences := UpdateAutoGenCol(v.AutoGen, tableId, colId, conv)
 			conv.SpSequences = sequences
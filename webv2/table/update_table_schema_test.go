@@ -1619,3 +1619,130 @@ func TestUpdateTableSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateProtoType(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "status", Id: "c1", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}},
+			},
+		},
+	}
+	assert.Nil(t, UpdateProtoType(ddl.Enum, "shop.v1.Status", "t1", "c1", conv))
+	assert.Equal(t, ddl.Type{Name: ddl.Enum, ProtoName: "shop.v1.Status", IsArray: true}, conv.SpSchema["t1"].ColDefs["c1"].T)
+
+	assert.NotNil(t, UpdateProtoType(ddl.Proto, "shop v1", "t1", "c1", conv))
+	assert.NotNil(t, UpdateProtoType(ddl.String, "shop.v1.Status", "t1", "c1", conv))
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	assert.NotNil(t, UpdateProtoType(ddl.Proto, "shop.v1.Order", "t1", "c1", conv))
+}
+
+func TestUpdateAllowCommitTimestamp(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "last_modified", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+			},
+		},
+	}
+	assert.Nil(t, UpdateAllowCommitTimestamp(CommitTimestampAdded, "t1", "c2", conv))
+	assert.True(t, conv.SpSchema["t1"].ColDefs["c2"].AllowsCommitTimestamp())
+	assert.Nil(t, UpdateAllowCommitTimestamp(CommitTimestampRemoved, "t1", "c2", conv))
+	assert.False(t, conv.SpSchema["t1"].ColDefs["c2"].AllowsCommitTimestamp())
+	assert.NotNil(t, UpdateAllowCommitTimestamp(CommitTimestampAdded, "t1", "c1", conv))
+}
+
+func TestUpdateColumnOpts(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "receipt", Id: "c1", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}},
+			},
+		},
+	}
+	assert.Nil(t, UpdateColumnOpts(map[string]string{ddl.LocalityGroupOpt: "archive"}, "t1", "c1", conv))
+	assert.Equal(t, map[string]string{ddl.LocalityGroupOpt: "archive"}, conv.SpSchema["t1"].ColDefs["c1"].Opts)
+	assert.NotNil(t, UpdateColumnOpts(map[string]string{ddl.LocalityGroupOpt: "", "unknown": "x"}, "t1", "c1", conv))
+	assert.Equal(t, map[string]string{ddl.LocalityGroupOpt: "archive"}, conv.SpSchema["t1"].ColDefs["c1"].Opts)
+	assert.Nil(t, UpdateColumnOpts(map[string]string{ddl.LocalityGroupOpt: ""}, "t1", "c1", conv))
+	assert.Empty(t, conv.SpSchema["t1"].ColDefs["c1"].Opts)
+}
+
+func TestUpdateEnumStrategy(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {
+			Name:   "shirts",
+			Id:     "t1",
+			ColIds: []string{"c1"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "size", Id: "c1", Type: schema.Type{Name: "enum"}, EnumValues: []string{"small", "large"}},
+			},
+		},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:   "shirts",
+			Id:     "t1",
+			ColIds: []string{"c1"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "size", Id: "c1", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+		},
+	}
+	assert.Nil(t, UpdateEnumStrategy(internal.EnumStrategyCheckConstraint, "t1", "c1", conv))
+	assert.Equal(t, internal.EnumStrategyCheckConstraint, internal.GetEnumStrategy(conv, "t1", "c1"))
+	assert.Len(t, conv.SpSchema["t1"].CheckConstraints, 1)
+
+	// The check is dropped once the column is no longer a STRING.
+	col := conv.SpSchema["t1"].ColDefs["c1"]
+	col.T = ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}
+	conv.SpSchema["t1"].ColDefs["c1"] = col
+	assert.Nil(t, UpdateEnumStrategy("", "t1", "c1", conv))
+	assert.Equal(t, internal.EnumStrategyString, internal.GetEnumStrategy(conv, "t1", "c1"))
+	assert.Empty(t, conv.SpSchema["t1"].CheckConstraints)
+
+	assert.NotNil(t, UpdateEnumStrategy(internal.EnumStrategyCheckConstraint, "t1", "c1", conv))
+}
+
+func TestRemoveColumnUniqueConstraint(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema["t1"] = schema.Table{Id: "t1", Name: "users", Indexes: []schema.Index{
+		{Id: "i1", Name: "users_email_tenant_key", Unique: true, Constraint: true},
+		{Id: "i2", Name: "users_email_idx", Unique: true},
+	}}
+	conv.SpSchema["t1"] = ddl.CreateTable{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Id: "c1", Name: "id"},
+			"c2": {Id: "c2", Name: "email"},
+			"c3": {Id: "c3", Name: "tenant"},
+		},
+		Indexes: []ddl.CreateIndex{
+			{Id: "i1", Name: "users_email_tenant_key", TableId: "t1", Unique: true, Keys: []ddl.IndexKey{{ColId: "c2"}, {ColId: "c3"}}},
+			{Id: "i2", Name: "users_email_idx", TableId: "t1", Unique: true, Keys: []ddl.IndexKey{{ColId: "c2"}, {ColId: "c3"}}},
+		},
+	}
+	conv.UsedNames = map[string]bool{"users": true, "users_email_tenant_key": true, "users_email_idx": true}
+	removeColumnFromTableSchema(conv, "t1", "c3")
+	// The constraint on email alone would reject rows the source accepts.
+	assert.Equal(t, []ddl.CreateIndex{
+		{Id: "i2", Name: "users_email_idx", TableId: "t1", Unique: true, Keys: []ddl.IndexKey{{ColId: "c2"}}},
+	}, conv.SpSchema["t1"].Indexes)
+	assert.False(t, conv.UsedNames["users_email_tenant_key"])
+}
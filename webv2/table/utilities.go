@@ -17,6 +17,7 @@ package table
 import (
 	"fmt"
 	"regexp"
+	"sort"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -28,8 +29,14 @@ import (
 const (
 	NotNullAdded   string = "ADDED"
 	NotNullRemoved string = "REMOVED"
+	// CommitTimestampAdded and CommitTimestampRemoved are the changes of
+	// the allow_commit_timestamp option of a column.
+	CommitTimestampAdded   string = "ADDED"
+	CommitTimestampRemoved string = "REMOVED"
 )
 
+var protoNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
 var SpannerToCassandra = map[string]string{
 	ddl.Bool:     "boolean",
 	ddl.Bytes:    "blob",
@@ -358,6 +365,71 @@ func getSequenceId(sequenceName string, spSeq map[string]ddl.Sequence) string {
 	return ""
 }
 
+// UpdateAllowCommitTimestamp adds or removes the allow_commit_timestamp
+// option of a TIMESTAMP column.
+func UpdateAllowCommitTimestamp(change, tableId, colId string, conv *internal.Conv) error {
+	switch change {
+	case CommitTimestampAdded:
+		return UpdateColumnOpts(map[string]string{ddl.AllowCommitTimestampOpt: "true"}, tableId, colId, conv)
+	case CommitTimestampRemoved:
+		return UpdateColumnOpts(map[string]string{ddl.AllowCommitTimestampOpt: ""}, tableId, colId, conv)
+	}
+	return nil
+}
+
+// UpdateEnumStrategy converts an ENUM column with strategy, see
+// internal.SetEnumStrategy. Columns which are no longer ENUM columns
+// converted to STRING, e.g. after their type changed or they were removed,
+// lose the CHECK constraint of their values when strategy is empty.
+func UpdateEnumStrategy(strategy, tableId, colId string, conv *internal.Conv) error {
+	if strategy != "" {
+		return internal.SetEnumStrategy(conv, tableId, colId, strategy)
+	}
+	if !internal.IsEnumColumn(conv, tableId, colId) {
+		return internal.SetEnumStrategy(conv, tableId, colId, internal.EnumStrategyString)
+	}
+	return nil
+}
+
+// UpdateColumnOpts sets the column options in opts after validating them
+// for the column and the Spanner dialect. An empty value removes the option.
+// No option is set if any of them is invalid.
+func UpdateColumnOpts(opts map[string]string, tableId, colId string, conv *internal.Conv) error {
+	// Opts is shared with conv until the column is stored back.
+	col := conv.SpSchema[tableId].ColDefs[colId].Clone()
+	var keys []string
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := col.SetOpt(key, opts[key], conv.SpDialect); err != nil {
+			return err
+		}
+	}
+	conv.SpSchema[tableId].ColDefs[colId] = col
+	return nil
+}
+
+// UpdateProtoType sets the type of a column to the PROTO or ENUM type with
+// the fully qualified name protoName. Proto types are only supported by the
+// GoogleSQL dialect.
+func UpdateProtoType(toType, protoName, tableId, colId string, conv *internal.Conv) error {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Errorf("%s type is not supported for the PostgreSQL dialect", toType)
+	}
+	if toType != ddl.Proto && toType != ddl.Enum {
+		return fmt.Errorf("proto name can only be set for %s and %s types", ddl.Proto, ddl.Enum)
+	}
+	if !protoNameRegex.MatchString(protoName) {
+		return fmt.Errorf("invalid proto name %q", protoName)
+	}
+	col := conv.SpSchema[tableId].ColDefs[colId]
+	col.T = ddl.Type{Name: toType, ProtoName: protoName, IsArray: col.T.IsArray}
+	conv.SpSchema[tableId].ColDefs[colId] = col
+	return nil
+}
+
 // Add, deletes and updates default value associated with a column during edit column functionality
 func UpdateDefaultValue(dv ddl.DefaultValue, tableId, colId string, conv *internal.Conv) {
 	col := conv.SpSchema[tableId].ColDefs[colId]
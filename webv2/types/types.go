@@ -76,6 +76,9 @@ type TargetDetails struct {
 	ReplicationSlot             string          `json:"ReplicationSlot"`
 	Publication                 string          `json:"Publication"`
 	GcsMetadataPath             GcsMetadataPath `json:"GcsMetadataPath"`
+	VersionRetentionPeriod      string          `json:"VersionRetentionPeriod"`
+	DefaultLeader               string          `json:"DefaultLeader"`
+	DefaultSequenceKind         string          `json:"DefaultSequenceKind"`
 }
 
 type GcsMetadataPath struct {
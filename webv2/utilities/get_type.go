@@ -48,7 +48,7 @@ func GetType(conv *internal.Conv, newType, tableId, colId string) (ddl.CreateTab
 	case constants.SQLSERVER:
 		toddl = sqlserver.InfoSchemaImpl{}.GetToDdl()
 		ty, issues = toddl.ToSpannerType(conv, newType, srcCol.Type, isPk)
-	case constants.ORACLE:
+	case constants.ORACLE, constants.ORACLEDUMP:
 		toddl = oracle.InfoSchemaImpl{}.GetToDdl()
 		ty, issues = toddl.ToSpannerType(conv, newType, srcCol.Type, isPk)
 	case constants.CASSANDRA:
@@ -57,13 +57,22 @@ func GetType(conv *internal.Conv, newType, tableId, colId string) (ddl.CreateTab
 	default:
 		return sp, ty, fmt.Errorf("driver : '%s' is not supported", sessionState.Driver)
 	}
-	if len(srcCol.Type.ArrayBounds) > 0 && conv.SpDialect == constants.DIALECT_POSTGRESQL {
-		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
-	} else if len(srcCol.Type.ArrayBounds) > 1 {
+	if len(srcCol.Type.ArrayBounds) > 1 {
 		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
 		issues = append(issues, internal.MultiDimensionalArray)
 	}
-	if srcCol.Ignored.Default {
+	if conv.Source != constants.CASSANDRA {
+		// MySQL SET columns can also be converted to strings, so their
+		// type is that returned by ToSpannerType.
+		if srcCol.Type.Name != "set" {
+			ty.IsArray = len(srcCol.Type.ArrayBounds) == 1
+		}
+		// Datastream doesn't support array datatypes.
+		if ty.IsArray {
+			issues = append(issues, internal.ArrayTypeNotSupported)
+		}
+	}
+	if _, converted := common.ConvertDefaultValue(conv, srcCol, ty); srcCol.Ignored.Default && !converted {
 		issues = append(issues, internal.DefaultValue)
 	}
 	if srcCol.Ignored.AutoIncrement {
@@ -72,8 +81,5 @@ func GetType(conv *internal.Conv, newType, tableId, colId string) (ddl.CreateTab
 	if conv.SchemaIssues != nil && len(issues) > 0 {
 		conv.SchemaIssues[tableId].ColumnLevelIssues[colId] = issues
 	}
-	if conv.Source != constants.CASSANDRA {
-		ty.IsArray = len(srcCol.Type.ArrayBounds) == 1
-	}
 	return sp, ty, nil
 }
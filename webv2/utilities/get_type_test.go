@@ -73,6 +73,28 @@ func TestGetType(t *testing.T) {
 			wantErr:  false,
 			wantIssues: []internal.SchemaIssue{internal.MultiDimensionalArray, internal.MultiDimensionalArray},
 		},
+		{
+			name:       "MySQL set type",
+			driver:     constants.MYSQL,
+			source:     constants.MYSQL,
+			dialect:    constants.DIALECT_GOOGLESQL,
+			srcCol:     schema.Column{Name: "col1", Type: schema.Type{Name: "set", ArrayBounds: []int64{-1}}},
+			newType:    "",
+			wantType:   ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true},
+			wantErr:    false,
+			wantIssues: []internal.SchemaIssue{internal.SetToArray, internal.ArrayTypeNotSupported},
+		},
+		{
+			name:       "MySQL set type to string",
+			driver:     constants.MYSQL,
+			source:     constants.MYSQL,
+			dialect:    constants.DIALECT_GOOGLESQL,
+			srcCol:     schema.Column{Name: "col1", Type: schema.Type{Name: "set", ArrayBounds: []int64{-1}}},
+			newType:    ddl.String,
+			wantType:   ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
+			wantErr:    false,
+			wantIssues: []internal.SchemaIssue{internal.SetToString},
+		},
 		{
 			name:    "Cassandra array type",
 			driver:  constants.CASSANDRA,
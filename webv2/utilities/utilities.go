@@ -358,16 +358,21 @@ func UpdateDataType(conv *internal.Conv, newType, tableId, colId string) error {
 		return err
 	}
 	colDef := sp.ColDefs[colId]
+	// Defaults converted from the source are converted again for the new
+	// type, unless they were changed since.
+	srcCol := conv.SrcSchema[tableId].ColDefs[colId]
+	if dv, _ := common.ConvertDefaultValue(conv, srcCol, colDef.T); reflect.DeepEqual(dv, colDef.DefaultValue) {
+		colDef.DefaultValue, _ = common.ConvertDefaultValue(conv, srcCol, ty)
+	}
 	colDef.T = ty
 	if conv.Source == constants.CASSANDRA {
 		toddl := cassandra.InfoSchemaImpl{}.GetToDdl()
 		if optionProvider, ok := toddl.(common.OptionProvider); ok {
-			srcCol := conv.SrcSchema[tableId].ColDefs[colId]
 			option := optionProvider.GetTypeOption(srcCol.Type.Name, ty)
 			if colDef.Opts == nil {
 				colDef.Opts = make(map[string]string)
 			}
-			colDef.Opts["cassandra_type"] = option
+			colDef.Opts[ddl.CassandraTypeOpt] = option
 		}
 	}
 	sp.ColDefs[colId] = colDef
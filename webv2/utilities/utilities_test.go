@@ -108,4 +108,44 @@ func TestUpdateDataType(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestUpdateDataTypeConvertsDefault(t *testing.T) {
+	tableId := "t1"
+	colId := "c1"
+	sessionState := session.GetSessionState()
+	sessionState.Driver = constants.MYSQL
+	srcCol := schema.Column{Name: "col1", Type: schema.Type{Name: "int"}, Ignored: schema.Ignored{Default: true}, DefaultValue: ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "e1", Statement: "0"}}}
+	for _, tc := range []struct {
+		name       string
+		dv         ddl.DefaultValue
+		wantDv     ddl.DefaultValue
+		wantIssues []internal.SchemaIssue
+	}{
+		{
+			name:       "Default converted from the source",
+			dv:         ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "e1", Statement: "0"}},
+			wantDv:     ddl.DefaultValue{},
+			wantIssues: []internal.SchemaIssue{internal.Widened, internal.DefaultValue},
+		},
+		{
+			name:       "Default changed in the UI",
+			dv:         ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "e1", Statement: "'none'"}},
+			wantDv:     ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: "e1", Statement: "'none'"}},
+			wantIssues: []internal.SchemaIssue{internal.Widened, internal.DefaultValue},
+		},
+	} {
+		conv := &internal.Conv{
+			SpSchema:  map[string]ddl.CreateTable{tableId: {Id: tableId, Name: "t1", ColDefs: map[string]ddl.ColumnDef{colId: {Name: "col1", T: ddl.Type{Name: ddl.Int64}, DefaultValue: tc.dv}}}},
+			SrcSchema: map[string]schema.Table{tableId: {Id: tableId, Name: "t1", ColDefs: map[string]schema.Column{colId: srcCol}, ColIds: []string{colId}}},
+			SchemaIssues: map[string]internal.TableIssues{
+				tableId: {ColumnLevelIssues: make(map[string][]internal.SchemaIssue)},
+			},
+			SpDialect: constants.DIALECT_GOOGLESQL,
+			Source:    constants.MYSQL,
+		}
+		assert.NoError(t, UpdateDataType(conv, "STRING", tableId, colId), tc.name)
+		assert.Equal(t, tc.wantDv, conv.SpSchema[tableId].ColDefs[colId].DefaultValue, tc.name)
+		assert.Equal(t, tc.wantIssues, conv.SchemaIssues[tableId].ColumnLevelIssues[colId], tc.name)
+	}
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/proto/migration"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/streaming"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/config"
 	helpers "github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/helpers"
@@ -844,6 +845,12 @@ func getSourceAndTargetProfiles(sessionState *session.SessionState, details type
 	}
 
 	sessionState.SpannerDatabaseName = details.TargetDetails.TargetDB
+	sessionState.Conv.SpDatabaseOptions = ddl.DatabaseOptions{
+		DatabaseName:           details.TargetDetails.TargetDB,
+		VersionRetentionPeriod: details.TargetDetails.VersionRetentionPeriod,
+		DefaultLeader:          details.TargetDetails.DefaultLeader,
+		DefaultSequenceKind:    details.TargetDetails.DefaultSequenceKind,
+	}
 	targetProfileString := fmt.Sprintf("project=%v,instance=%v,dbName=%v,dialect=%v", sessionState.SpannerProjectId, sessionState.SpannerInstanceID, details.TargetDetails.TargetDB, sessionState.Dialect)
 	if details.MigrationType == helpers.LOW_DOWNTIME_MIGRATION && !details.IsSharded {
 		fileName := sessionState.Conv.Audit.MigrationRequestId + "-streaming.json"
@@ -2,6 +2,7 @@ package webv2
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -157,7 +158,7 @@ func TestValidateCassandraConnection(t *testing.T) {
 		mockClient.On("KeyspaceMetadata", "test_keyspace").Return(mockMetadata, nil).Once()
 		mockClient.On("Close").Return().Once()
 
-		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			return mockClient, nil
 		}
 
@@ -169,7 +170,7 @@ func TestValidateCassandraConnection(t *testing.T) {
 
 	t.Run("Failure", func(t *testing.T) {
 		expectedErr := errors.New("client creation failed")
-		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			return nil, expectedErr
 		}
 
@@ -204,7 +205,7 @@ func TestDatabaseConnectionCassandra(t *testing.T) {
 		mockClient.On("KeyspaceMetadata", "test_keyspace").Return(mockMetadata, nil).Once()
 		mockClient.On("Close").Return().Once()
 
-		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			return mockClient, nil
 		}
 
@@ -224,7 +225,7 @@ func TestDatabaseConnectionCassandra(t *testing.T) {
 
 	t.Run("Failure", func(t *testing.T) {
 		expectedErr := errors.New("client creation failed")
-		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			return nil, expectedErr
 		}
 
@@ -261,7 +262,7 @@ func TestSetSourceDBDetailsForDirectConnectCassandra(t *testing.T) {
 		mockClient.On("KeyspaceMetadata", "test_keyspace").Return(mockMetadata, nil).Once()
 		mockClient.On("Close").Return().Once()
 
-		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			return mockClient, nil
 		}
 
@@ -281,7 +282,7 @@ func TestSetSourceDBDetailsForDirectConnectCassandra(t *testing.T) {
 
 	t.Run("Failure", func(t *testing.T) {
 		expectedErr := errors.New("client creation failed")
-		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string) (cc.CassandraClusterInterface, error) {
+		ca.GetOrCreateClient = func(contactPoints []string, port int, keyspace, datacenter, user, password string, tlsConfig *tls.Config) (cc.CassandraClusterInterface, error) {
 			return nil, expectedErr
 		}
 